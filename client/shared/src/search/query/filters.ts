@@ -30,6 +30,7 @@ export enum FilterType {
     select = 'select',
     timeout = 'timeout',
     type = 'type',
+    view = 'view',
     visibility = 'visibility',
 }
 
@@ -341,6 +342,11 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
             },
         ],
     },
+    [FilterType.view]: {
+        description: 'Search only the paths of a repository view',
+        placeholder: 'view name',
+        singular: true,
+    },
     [FilterType.visibility]: {
         discreteValues: () => ['any', 'private', 'public'].map(value => ({ label: value })),
         description: 'Include results from repositories with the matching visibility (private, public, any).',
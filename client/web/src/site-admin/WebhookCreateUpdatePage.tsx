@@ -90,7 +90,7 @@ export const WebhookCreateUpdatePage: FC<WebhookCreateUpdatePageProps> = ({ exis
                 if (!update) {
                     const [currentKind] = kindToUrlMap.keys()
                     const [currentUrls] = kindToUrlMap.values()
-                    // we always generate a secret once and assign it to the webhook. Bitbucket Cloud special case
+                    // we always generate a secret once and assign it to the webhook. Azure DevOps special case
                     // is handled is an Input and during GraphQL query creation.
                     setWebhook(webhook => ({
                         ...webhook,
@@ -334,7 +334,7 @@ function convertWebhookToCreateWebhookVariables(webhook: Webhook): CreateWebhook
 }
 
 function codeHostSupportsSecretes(codeHostKind: ExternalServiceKind): boolean {
-    if (codeHostKind === ExternalServiceKind.BITBUCKETCLOUD || codeHostKind === ExternalServiceKind.AZUREDEVOPS) {
+    if (codeHostKind === ExternalServiceKind.AZUREDEVOPS) {
         return false
     }
     return true
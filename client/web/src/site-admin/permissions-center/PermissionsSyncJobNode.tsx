@@ -43,6 +43,9 @@ interface JobStateMetadata {
 }
 
 const JOB_REASON_TO_READABLE_REASON: Record<PermissionsSyncJobReason, string> = {
+    REASON_BITBUCKET_CLOUD_REPO_CREATED_EVENT: 'Repository created',
+    REASON_BITBUCKET_CLOUD_REPO_MADE_PRIVATE_EVENT: 'Repository made private',
+    REASON_BITBUCKET_CLOUD_REPO_TRANSFERRED_EVENT: 'Repository transferred',
     REASON_GITHUB_ORG_MEMBER_ADDED_EVENT: 'Team member added',
     REASON_GITHUB_ORG_MEMBER_REMOVED_EVENT: 'Team member removed',
     REASON_GITHUB_REPO_EVENT: 'Repository event',
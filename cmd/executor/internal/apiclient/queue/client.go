@@ -398,6 +398,51 @@ func (c *Client) UpdateExecutionLogEntry(ctx context.Context, job types.Job, ent
 	return c.client.DoAndDrop(ctx, req)
 }
 
+func (c *Client) AppendExecutionLogEntryOutput(ctx context.Context, job types.Job, entryID int, out string) (err error) {
+	queue := c.inferQueueName(job)
+
+	ctx, _, endObservation := c.operations.appendExecutionLogEntryOutput.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("queueName", queue),
+		attribute.Int("jobID", job.ID),
+		attribute.Int("entryID", entryID),
+		attribute.Int("outLen", len(out)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	req, err := c.client.NewJSONJobRequest(job.ID, http.MethodPost, fmt.Sprintf("%s/appendExecutionLogEntryOutput", queue), job.Token, types.AppendExecutionLogEntryOutputRequest{
+		JobOperationRequest: types.JobOperationRequest{
+			ExecutorName: c.options.ExecutorName,
+			JobID:        job.ID,
+		},
+		EntryID: entryID,
+		Out:     out,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.client.DoAndDrop(ctx, req)
+}
+
+func (c *Client) UploadArtifact(ctx context.Context, job types.Job, name string, r io.Reader) (err error) {
+	queue := c.inferQueueName(job)
+
+	ctx, _, endObservation := c.operations.uploadArtifact.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("queueName", queue),
+		attribute.Int("jobID", job.ID),
+		attribute.String("name", name),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	req, err := c.client.NewRequest(job.ID, job.Token, http.MethodPost, fmt.Sprintf("%s/artifacts/%s", queue, name), r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	return c.client.DoAndDrop(ctx, req)
+}
+
 // inferQueueName returns the queue name if it is specified on the job, which is the case
 // when an executor is configured to listen to multiple queues. If the queue name is empty,
 // return the specific queue that is configured.
@@ -727,6 +727,51 @@ func TestUpdateExecutionLogEntryBadResponse(t *testing.T) {
 	})
 }
 
+func TestAppendExecutionLogEntryOutput(t *testing.T) {
+	spec := routeSpec{
+		expectedMethod:       "POST",
+		expectedPath:         "/.executors/queue/test_queue/appendExecutionLogEntryOutput",
+		expectedUsername:     "test",
+		expectedToken:        "job-token",
+		expectedJobID:        "42",
+		expectedExecutorName: "deadbeef",
+		expectedPayload: `{
+			"executorName": "deadbeef",
+			"jobId": 42,
+			"entryId": 99,
+			"out": "<more log payload>"
+		}`,
+		responseStatus:  http.StatusNoContent,
+		responsePayload: ``,
+	}
+
+	testRoute(t, spec, func(client *queue.Client) {
+		if err := client.AppendExecutionLogEntryOutput(context.Background(), types.Job{ID: 42, Token: "job-token"}, 99, "<more log payload>"); err != nil {
+			t.Fatalf("unexpected error appending log contents: %s", err)
+		}
+	})
+}
+
+func TestUploadArtifact(t *testing.T) {
+	spec := routeSpec{
+		expectedMethod:       "POST",
+		expectedPath:         "/.executors/queue/test_queue/artifacts/out/dump.json",
+		expectedUsername:     "test",
+		expectedToken:        "job-token",
+		expectedJobID:        "42",
+		expectedExecutorName: "deadbeef",
+		expectedPayload:      `{"partial": true}`,
+		responseStatus:       http.StatusNoContent,
+		responsePayload:      ``,
+	}
+
+	testRoute(t, spec, func(client *queue.Client) {
+		if err := client.UploadArtifact(context.Background(), types.Job{ID: 42, Token: "job-token"}, "out/dump.json", strings.NewReader(`{"partial": true}`)); err != nil {
+			t.Fatalf("unexpected error uploading artifact: %s", err)
+		}
+	})
+}
+
 type routeSpec struct {
 	expectedMethod       string
 	expectedPath         string
@@ -8,13 +8,15 @@ import (
 )
 
 type operations struct {
-	dequeue                 *observation.Operation
-	markComplete            *observation.Operation
-	markErrored             *observation.Operation
-	markFailed              *observation.Operation
-	heartbeat               *observation.Operation
-	addExecutionLogEntry    *observation.Operation
-	updateExecutionLogEntry *observation.Operation
+	dequeue                       *observation.Operation
+	markComplete                  *observation.Operation
+	markErrored                   *observation.Operation
+	markFailed                    *observation.Operation
+	heartbeat                     *observation.Operation
+	addExecutionLogEntry          *observation.Operation
+	updateExecutionLogEntry       *observation.Operation
+	appendExecutionLogEntryOutput *observation.Operation
+	uploadArtifact                *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -34,12 +36,14 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		dequeue:                 op("Dequeue"),
-		markComplete:            op("MarkComplete"),
-		markErrored:             op("MarkErrored"),
-		markFailed:              op("MarkFailed"),
-		heartbeat:               op("Heartbeat"),
-		addExecutionLogEntry:    op("AddExecutionLogEntry"),
-		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
+		dequeue:                       op("Dequeue"),
+		markComplete:                  op("MarkComplete"),
+		markErrored:                   op("MarkErrored"),
+		markFailed:                    op("MarkFailed"),
+		heartbeat:                     op("Heartbeat"),
+		addExecutionLogEntry:          op("AddExecutionLogEntry"),
+		updateExecutionLogEntry:       op("UpdateExecutionLogEntry"),
+		appendExecutionLogEntryOutput: op("AppendExecutionLogEntryOutput"),
+		uploadArtifact:                op("UploadArtifact"),
 	}
 }
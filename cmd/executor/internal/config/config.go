@@ -87,6 +87,11 @@ type Config struct {
 	// TODO remove in 5.2 if we have moved to a custom image to do the setup work.
 	KubernetesGitCACert string
 
+	VaultAddress            string
+	VaultToken              string
+	VaultNamespace          string
+	AWSSecretsManagerRegion string
+
 	dockerAuthConfigStr                                          string
 	dockerAuthConfigUnmarshalError                               error
 	kubernetesNodeRequiredAffinityMatchExpressions               string
@@ -175,6 +180,10 @@ func (c *Config) Load() {
 	c.kubernetesJobAnnotations = c.GetOptional("KUBERNETES_JOB_ANNOTATIONS", "The JSON encoded annotations to add to the Kubernetes Jobs. e.g. {\"foo\": \"bar\"}")
 	c.kubernetesJobPodAnnotations = c.GetOptional("KUBERNETES_JOB_POD_ANNOTATIONS", "The JSON encoded annotations to add to the Kubernetes Job Pods. e.g. {\"foo\": \"bar\"}")
 	c.KubernetesImagePullSecrets = c.GetOptional("KUBERNETES_IMAGE_PULL_SECRETS", "The names of Kubernetes image pull secrets to use for pulling images. e.g. my-secret,my-other-secret")
+	c.VaultAddress = c.GetOptional("EXECUTOR_VAULT_ADDR", "The URL of the HashiCorp Vault server that job secrets referenced as vault://<path>#<field> are fetched from.")
+	c.VaultToken = c.GetOptional("EXECUTOR_VAULT_TOKEN", "The token used to authenticate with HashiCorp Vault.")
+	c.VaultNamespace = c.GetOptional("EXECUTOR_VAULT_NAMESPACE", "The HashiCorp Vault Enterprise namespace that job secrets are fetched from.")
+	c.AWSSecretsManagerRegion = c.GetOptional("EXECUTOR_AWS_SECRETS_MANAGER_REGION", "The AWS region that job secrets referenced as awssm://<secret> are fetched from. Defaults to the region of the AWS configuration of the host.")
 
 	if c.QueueNamesStr != "" {
 		c.QueueNames = strings.Split(c.QueueNamesStr, ",")
@@ -19,6 +19,7 @@ import (
 	apiworker "github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/secrets"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
 	executorutil "github.com/sourcegraph/sourcegraph/internal/executor/util"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -76,17 +77,36 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 		GitServicePath: "/.executors/git",
 		QueueOptions:   queueOptions(c, queueTelemetryOptions),
 		FilesOptions:   filesOptions(c),
-		RedactedValues: map[string]string{
-			// 🚨 SECURITY: Catch uses of the shared frontend token used to clone
-			// git repositories that make it into commands or stdout/stderr streams.
-			c.FrontendAuthorizationToken: "SECRET_REMOVED",
-		},
+		SecretOptions:  secretOptions(c),
+		RedactedValues: redactedValues(c),
 
 		NodeExporterEndpoint:               c.NodeExporterURL,
 		DockerRegistryNodeExporterEndpoint: c.DockerRegistryNodeExporterURL,
 	}
 }
 
+func redactedValues(c *config.Config) map[string]string {
+	redactedValues := map[string]string{
+		// 🚨 SECURITY: Catch uses of the shared frontend token used to clone
+		// git repositories that make it into commands or stdout/stderr streams.
+		c.FrontendAuthorizationToken: "SECRET_REMOVED",
+	}
+	if c.VaultToken != "" {
+		// 🚨 SECURITY: Catch uses of the token used to fetch job secrets from Vault.
+		redactedValues[c.VaultToken] = "SECRET_REMOVED"
+	}
+	return redactedValues
+}
+
+func secretOptions(c *config.Config) secrets.Options {
+	return secrets.Options{
+		VaultAddress:   c.VaultAddress,
+		VaultToken:     c.VaultToken,
+		VaultNamespace: c.VaultNamespace,
+		AWSRegion:      c.AWSSecretsManagerRegion,
+	}
+}
+
 func workerOptions(c *config.Config) workerutil.WorkerOptions {
 	queueStr := executorutil.FormatQueueNamesForMetrics(c.QueueName, c.QueueNames)
 	return workerutil.WorkerOptions{
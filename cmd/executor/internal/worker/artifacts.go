@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ArtifactStore uploads the files that a job requested to keep as artifacts.
+type ArtifactStore interface {
+	// UploadArtifact uploads the content of r as the artifact of the given job with
+	// the given name.
+	UploadArtifact(ctx context.Context, job types.Job, name string, r io.Reader) error
+}
+
+// The artifact limits are variables so that tests can lower them.
+var (
+	maxArtifactSize     int64 = types.MaxArtifactSize
+	maxJobArtifactsSize int64 = types.MaxJobArtifactsSize
+	maxJobArtifacts           = types.MaxJobArtifacts
+)
+
+// uploadArtifacts uploads the files in the given workspace directory that match the
+// artifact patterns of the job. Artifacts are collected on a best-effort basis: files
+// that cannot be uploaded are logged and skipped, and never fail the job. Files that
+// would exceed the artifact quota of the job are skipped as well.
+func uploadArtifacts(ctx context.Context, logger log.Logger, store ArtifactStore, job types.Job, dir string) {
+	if len(job.Artifacts) == 0 {
+		return
+	}
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		logger.Warn("Failed to resolve workspace directory for artifacts", log.Error(err))
+		return
+	}
+
+	uploaded := map[string]struct{}{}
+	remaining := maxJobArtifactsSize
+	for _, pattern := range job.Artifacts {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		if err != nil {
+			logger.Warn("Invalid artifact pattern", log.String("pattern", pattern), log.Error(err))
+			continue
+		}
+
+		for _, match := range matches {
+			rel, err := filepath.Rel(root, match)
+			if err != nil {
+				continue
+			}
+			name := filepath.ToSlash(rel)
+			if _, ok := uploaded[name]; ok {
+				continue
+			}
+			if len(uploaded) >= maxJobArtifacts {
+				logger.Warn("Job exceeds the maximum number of artifacts", log.Int("max", maxJobArtifacts))
+				return
+			}
+			uploaded[name] = struct{}{}
+
+			size, err := uploadArtifact(ctx, store, job, root, name, remaining)
+			if err != nil {
+				logger.Warn("Failed to upload artifact", log.String("name", name), log.Error(err))
+			}
+			remaining -= size
+		}
+	}
+}
+
+// uploadArtifact uploads the file with the given name if it is not larger than
+// the remaining artifact quota of the job, and returns the number of bytes that
+// count against the quota.
+func uploadArtifact(ctx context.Context, store ArtifactStore, job types.Job, root, name string, remaining int64) (int64, error) {
+	if err := types.ValidateArtifactName(name); err != nil {
+		return 0, err
+	}
+
+	// 🚨 SECURITY: The workspace is controlled by the job, so it may contain symlinks
+	// to files on the host. Only upload regular files within the workspace.
+	path, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(name)))
+	if err != nil {
+		return 0, err
+	}
+	if rel, err := filepath.Rel(root, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return 0, errors.New("artifact is outside of the workspace")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if !info.Mode().IsRegular() {
+		return 0, errors.New("artifact is not a regular file")
+	}
+	if info.Size() > maxArtifactSize {
+		return 0, errors.Newf("artifact exceeds the maximum size of %d bytes", maxArtifactSize)
+	}
+	if info.Size() > remaining {
+		return 0, errors.Newf("artifact exceeds the remaining artifact quota of the job of %d bytes", remaining)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	// Only upload the size we checked, in case the file grows in the meantime.
+	return info.Size(), store.UploadArtifact(ctx, job, name, io.LimitReader(f, info.Size()))
+}
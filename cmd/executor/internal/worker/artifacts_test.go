@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestUploadArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub", "out"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "dump.scip"), []byte("dump"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "out", "a.log"), []byte("a"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "out", "b.log"), []byte("b"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "out", "c log"), []byte("c"), os.ModePerm))
+
+	secret := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secret, []byte("secret"), os.ModePerm))
+	require.NoError(t, os.Symlink(secret, filepath.Join(dir, "sub", "out", "link.log")))
+
+	store := NewMockArtifactStore()
+	uploaded := map[string]string{}
+	store.UploadArtifactFunc.SetDefaultHook(func(_ context.Context, _ types.Job, name string, r io.Reader) error {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if name == "sub/out/b.log" {
+			return errors.New("upload failed")
+		}
+		uploaded[name] = string(content)
+		return nil
+	})
+
+	job := types.Job{ID: 42, Artifacts: []string{"sub/dump.scip", "sub/out/*", "sub/missing.scip", "../*", "sub/dump.scip"}}
+	uploadArtifacts(context.Background(), logtest.Scoped(t), store, job, dir)
+
+	assert.Equal(t, map[string]string{"sub/dump.scip": "dump", "sub/out/a.log": "a"}, uploaded)
+
+	var names []string
+	for _, call := range store.UploadArtifactFunc.History() {
+		assert.Equal(t, 42, call.Arg1.ID)
+		names = append(names, call.Arg2)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"sub/dump.scip", "sub/out/a.log", "sub/out/b.log"}, names)
+}
+
+func TestUploadArtifacts_NoArtifacts(t *testing.T) {
+	store := NewMockArtifactStore()
+	uploadArtifacts(context.Background(), logtest.Scoped(t), store, types.Job{ID: 42}, t.TempDir())
+	assert.Empty(t, store.UploadArtifactFunc.History())
+}
+
+func TestUploadArtifacts_Quota(t *testing.T) {
+	setLimits := func(t *testing.T, artifactSize, jobArtifactsSize int64, jobArtifacts int) {
+		oldArtifactSize, oldJobArtifactsSize, oldJobArtifacts := maxArtifactSize, maxJobArtifactsSize, maxJobArtifacts
+		t.Cleanup(func() {
+			maxArtifactSize, maxJobArtifactsSize, maxJobArtifacts = oldArtifactSize, oldJobArtifactsSize, oldJobArtifacts
+		})
+		maxArtifactSize, maxJobArtifactsSize, maxJobArtifacts = artifactSize, jobArtifactsSize, jobArtifacts
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.log"), []byte("aaaa"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.log"), []byte("bbbbbbbb"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.log"), []byte("cccc"), os.ModePerm))
+	job := types.Job{ID: 42, Artifacts: []string{"*.log"}}
+
+	uploadedNames := func(store *MockArtifactStore) []string {
+		var names []string
+		for _, call := range store.UploadArtifactFunc.History() {
+			names = append(names, call.Arg2)
+		}
+		return names
+	}
+
+	t.Run("artifact size", func(t *testing.T) {
+		setLimits(t, 4, 100, 100)
+		store := NewMockArtifactStore()
+		uploadArtifacts(context.Background(), logtest.Scoped(t), store, job, dir)
+		assert.Equal(t, []string{"a.log", "c.log"}, uploadedNames(store))
+	})
+
+	t.Run("job artifacts size", func(t *testing.T) {
+		setLimits(t, 100, 12, 100)
+		store := NewMockArtifactStore()
+		uploadArtifacts(context.Background(), logtest.Scoped(t), store, job, dir)
+		assert.Equal(t, []string{"a.log", "b.log"}, uploadedNames(store))
+	})
+
+	t.Run("job artifacts", func(t *testing.T) {
+		setLimits(t, 100, 100, 1)
+		store := NewMockArtifactStore()
+		uploadArtifacts(context.Background(), logtest.Scoped(t), store, job, dir)
+		assert.Equal(t, []string{"a.log"}, uploadedNames(store))
+	})
+}
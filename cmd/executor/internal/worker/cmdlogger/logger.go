@@ -45,6 +45,8 @@ type ExecutionLogEntryStore interface {
 	AddExecutionLogEntry(ctx context.Context, job types.Job, entry internalexecutor.ExecutionLogEntry) (int, error)
 	// UpdateExecutionLogEntry updates the log entry with the given ID.
 	UpdateExecutionLogEntry(ctx context.Context, job types.Job, entryID int, entry internalexecutor.ExecutionLogEntry) error
+	// AppendExecutionLogEntryOutput appends the given output to the log entry with the given ID.
+	AppendExecutionLogEntryOutput(ctx context.Context, job types.Job, entryID int, out string) error
 }
 
 // NewLogger creates a new logger instance with the given store, job, record,
@@ -154,7 +156,7 @@ func (l *logger) syncLogEntry(handle *entryHandle, entryID int, old internalexec
 			log.Intp("durationMs", current.DurationMs),
 		)
 
-		if err := l.store.UpdateExecutionLogEntry(context.Background(), l.job, entryID, current); err != nil {
+		if err := l.writeLogEntry(entryID, old, current); err != nil {
 			logMethod := l.internalLogger.Warn
 			if lastWrite {
 				logMethod = l.internalLogger.Error
@@ -180,6 +182,16 @@ func (l *logger) syncLogEntry(handle *entryHandle, entryID int, old internalexec
 
 const syncLogEntryInterval = 1 * time.Second
 
+// writeLogEntry uploads the changes from old to current. While the command is
+// running, only the output written since the last write is sent, so that the logs
+// of long-running commands can be followed without re-uploading them every time.
+func (l *logger) writeLogEntry(entryID int, old, current internalexecutor.ExecutionLogEntry) error {
+	if current.ExitCode == nil && current.DurationMs == nil && old.ExitCode == nil && old.DurationMs == nil && strings.HasPrefix(current.Out, old.Out) {
+		return l.store.AppendExecutionLogEntryOutput(context.Background(), l.job, entryID, current.Out[len(old.Out):])
+	}
+	return l.store.UpdateExecutionLogEntry(context.Background(), l.job, entryID, current)
+}
+
 // If old didn't have exit code or duration and current does, update; we're finished.
 // Otherwise, update if the log text has changed since the last write to the API.
 func entryWasUpdated(old, current internalexecutor.ExecutionLogEntry) bool {
@@ -101,3 +101,64 @@ func TestLogger_Failure(t *testing.T) {
 		t.Fatalf("incorrect invokation count on UpdateExecutionLogEntry, want=%d have=%d", 1, len(s.UpdateExecutionLogEntryFunc.History()))
 	}
 }
+
+func TestLogger_AppendsOutput(t *testing.T) {
+	s := NewMockExecutionLogEntryStore()
+	doneAdding := make(chan struct{})
+	s.AddExecutionLogEntryFunc.SetDefaultHook(func(_ context.Context, _ types.Job, _ internalexecutor.ExecutionLogEntry) (int, error) {
+		doneAdding <- struct{}{}
+		return 1, nil
+	})
+	doneAppending := make(chan struct{})
+	s.AppendExecutionLogEntryOutputFunc.SetDefaultHook(func(_ context.Context, _ types.Job, _ int, _ string) error {
+		doneAppending <- struct{}{}
+		return nil
+	})
+
+	job := types.Job{}
+	internalLogger := logtest.Scoped(t)
+	l := NewLogger(internalLogger, s, job, map[string]string{})
+
+	e := l.LogEntry("the_key", []string{"cmd", "arg1"})
+
+	flushDone := make(chan error)
+	go func() {
+		flushDone <- l.Flush()
+	}()
+
+	// Wait for add to have been called.
+	<-doneAdding
+
+	// Each write is followed by a sync that only uploads the new output.
+	for _, out := range []string{"first\n", "second\n"} {
+		if _, err := e.Write([]byte(out)); err != nil {
+			t.Fatal(err)
+		}
+		<-doneAppending
+	}
+
+	e.Finalize(0)
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-flushDone; err != nil {
+		t.Fatal(err)
+	}
+
+	history := s.AppendExecutionLogEntryOutputFunc.History()
+	if len(history) != 2 {
+		t.Fatalf("incorrect invokation count on AppendExecutionLogEntryOutput, want=%d have=%d", 2, len(history))
+	}
+	if history[0].Arg3 != "first\n" || history[1].Arg3 != "second\n" {
+		t.Fatalf("unexpected appended output, have=%q and %q", history[0].Arg3, history[1].Arg3)
+	}
+	// The final write sets the exit code, and contains the complete output.
+	updates := s.UpdateExecutionLogEntryFunc.History()
+	if len(updates) != 1 {
+		t.Fatalf("incorrect invokation count on UpdateExecutionLogEntry, want=%d have=%d", 1, len(updates))
+	}
+	if out := updates[0].Arg3.Out; out != "first\nsecond\n" {
+		t.Fatalf("unexpected output in final update, have=%q", out)
+	}
+}
@@ -22,6 +22,10 @@ type MockExecutionLogEntryStore struct {
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *ExecutionLogEntryStoreAddExecutionLogEntryFunc
+	// AppendExecutionLogEntryOutputFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// AppendExecutionLogEntryOutput.
+	AppendExecutionLogEntryOutputFunc *ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc
 	// UpdateExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateExecutionLogEntry.
 	UpdateExecutionLogEntryFunc *ExecutionLogEntryStoreUpdateExecutionLogEntryFunc
@@ -37,6 +41,11 @@ func NewMockExecutionLogEntryStore() *MockExecutionLogEntryStore {
 				return
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc{
+			defaultHook: func(context.Context, types.Job, int, string) (r0 error) {
+				return
+			},
+		},
 		UpdateExecutionLogEntryFunc: &ExecutionLogEntryStoreUpdateExecutionLogEntryFunc{
 			defaultHook: func(context.Context, types.Job, int, executor.ExecutionLogEntry) (r0 error) {
 				return
@@ -55,6 +64,11 @@ func NewStrictMockExecutionLogEntryStore() *MockExecutionLogEntryStore {
 				panic("unexpected invocation of MockExecutionLogEntryStore.AddExecutionLogEntry")
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc{
+			defaultHook: func(context.Context, types.Job, int, string) error {
+				panic("unexpected invocation of MockExecutionLogEntryStore.AppendExecutionLogEntryOutput")
+			},
+		},
 		UpdateExecutionLogEntryFunc: &ExecutionLogEntryStoreUpdateExecutionLogEntryFunc{
 			defaultHook: func(context.Context, types.Job, int, executor.ExecutionLogEntry) error {
 				panic("unexpected invocation of MockExecutionLogEntryStore.UpdateExecutionLogEntry")
@@ -71,6 +85,9 @@ func NewMockExecutionLogEntryStoreFrom(i ExecutionLogEntryStore) *MockExecutionL
 		AddExecutionLogEntryFunc: &ExecutionLogEntryStoreAddExecutionLogEntryFunc{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		AppendExecutionLogEntryOutputFunc: &ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc{
+			defaultHook: i.AppendExecutionLogEntryOutput,
+		},
 		UpdateExecutionLogEntryFunc: &ExecutionLogEntryStoreUpdateExecutionLogEntryFunc{
 			defaultHook: i.UpdateExecutionLogEntry,
 		},
@@ -192,6 +209,122 @@ func (c ExecutionLogEntryStoreAddExecutionLogEntryFuncCall) Results() []interfac
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc describes the
+// behavior when the AppendExecutionLogEntryOutput method of the parent
+// MockExecutionLogEntryStore instance is invoked.
+type ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc struct {
+	defaultHook func(context.Context, types.Job, int, string) error
+	hooks       []func(context.Context, types.Job, int, string) error
+	history     []ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall
+	mutex       sync.Mutex
+}
+
+// AppendExecutionLogEntryOutput delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockExecutionLogEntryStore) AppendExecutionLogEntryOutput(v0 context.Context, v1 types.Job, v2 int, v3 string) error {
+	r0 := m.AppendExecutionLogEntryOutputFunc.nextHook()(v0, v1, v2, v3)
+	m.AppendExecutionLogEntryOutputFunc.appendCall(ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// AppendExecutionLogEntryOutput method of the parent
+// MockExecutionLogEntryStore instance is invoked and the hook queue is
+// empty.
+func (f *ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc) SetDefaultHook(hook func(context.Context, types.Job, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AppendExecutionLogEntryOutput method of the parent
+// MockExecutionLogEntryStore instance invokes the hook at the front of the
+// queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc) PushHook(hook func(context.Context, types.Job, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, types.Job, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, types.Job, int, string) error {
+		return r0
+	})
+}
+
+func (f *ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc) nextHook() func(context.Context, types.Job, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc) appendCall(r0 ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall objects
+// describing the invocations of this function.
+func (f *ExecutionLogEntryStoreAppendExecutionLogEntryOutputFunc) History() []ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall {
+	f.mutex.Lock()
+	history := make([]ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall is an object
+// that describes an invocation of method AppendExecutionLogEntryOutput on
+// an instance of MockExecutionLogEntryStore.
+type ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.Job
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExecutionLogEntryStoreAppendExecutionLogEntryOutputFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ExecutionLogEntryStoreUpdateExecutionLogEntryFunc describes the behavior
 // when the UpdateExecutionLogEntry method of the parent
 // MockExecutionLogEntryStore instance is invoked.
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runner"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runtime"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/secrets"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/workspace"
 	"github.com/sourcegraph/sourcegraph/internal/executor/types"
 	executorutil "github.com/sourcegraph/sourcegraph/internal/executor/util"
@@ -26,15 +27,17 @@ import (
 )
 
 type handler struct {
-	nameSet      *janitor.NameSet
-	cmdRunner    util.CmdRunner
-	cmd          command.Command
-	logStore     cmdlogger.ExecutionLogEntryStore
-	filesStore   files.Store
-	options      Options
-	cloneOptions workspace.CloneOptions
-	operations   *command.Operations
-	jobRuntime   runtime.Runtime
+	nameSet        *janitor.NameSet
+	cmdRunner      util.CmdRunner
+	cmd            command.Command
+	logStore       cmdlogger.ExecutionLogEntryStore
+	filesStore     files.Store
+	options        Options
+	cloneOptions   workspace.CloneOptions
+	operations     *command.Operations
+	jobRuntime     runtime.Runtime
+	secretResolver secrets.Resolver
+	artifactStore  ArtifactStore
 }
 
 var (
@@ -83,6 +86,12 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 		}
 	}()
 
+	// Fetch the secrets that are stored in an external secret store. This must happen
+	// before the job logger is created, so that it redacts their values.
+	if err := secrets.ResolveJob(ctx, h.secretResolver, &job); err != nil {
+		return errors.Wrap(err, "resolving external secrets")
+	}
+
 	// 🚨 SECURITY: The job logger must be supplied with all sensitive values that may appear
 	// in a command constructed and run in the following function. Note that the command and
 	// its output may both contain sensitive values, but only values which we directly
@@ -143,6 +152,13 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 			err = errors.Append(err, teardownErr)
 		}
 	}()
+	// Upload the artifacts of the job whether or not its steps succeeded, as partial
+	// results are often what is needed to debug a failure. Like the teardown, this is
+	// done outside of the task execution context. Firecracker workspaces are not
+	// mounted on the host while the job runs, so their artifacts can't be collected.
+	if !h.options.RunnerOptions.FirecrackerOptions.Enabled {
+		defer uploadArtifacts(context.Background(), logger, h.artifactStore, job, ws.WorkingDirectory())
+	}
 
 	// Get the commands we will execute.
 	logger.Info("Creating commands")
@@ -248,6 +264,9 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, commandLogger c
 			err = errors.Append(err, teardownErr)
 		}
 	}()
+	if !h.options.RunnerOptions.FirecrackerOptions.Enabled {
+		defer uploadArtifacts(context.Background(), logger, h.artifactStore, job, ws.WorkingDirectory())
+	}
 
 	// Invoke each docker step sequentially
 	for i, dockerStep := range job.DockerSteps {
@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// awsResolver reads secrets from AWS Secrets Manager. It authenticates with the
+// default AWS credential chain of the host, e.g. an instance profile.
+type awsResolver struct {
+	doer     httpcli.Doer
+	config   *lazy[aws.Config]
+	endpoint func(region string) string
+}
+
+func newAWSResolver(options Options) *awsResolver {
+	return &awsResolver{
+		doer: httpcli.ExternalDoer,
+		config: &lazy[aws.Config]{create: func(ctx context.Context) (aws.Config, error) {
+			var opts []func(*config.LoadOptions) error
+			if options.AWSRegion != "" {
+				opts = append(opts, config.WithRegion(options.AWSRegion))
+			}
+			return config.LoadDefaultConfig(ctx, opts...)
+		}},
+		endpoint: func(region string) string {
+			return fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", region)
+		},
+	}
+}
+
+func (r *awsResolver) Resolve(ctx context.Context, ref types.SecretReference) (string, error) {
+	cfg, err := r.config.get(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "loading aws config")
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "retrieving aws credentials")
+	}
+
+	reqBody, err := json.Marshal(map[string]string{"SecretId": ref.Name})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint(cfg.Region), bytes.NewReader(reqBody))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	// Sign the request with AWS credentials.
+	hash := sha256.Sum256(reqBody)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "secretsmanager", cfg.Region, time.Now()); err != nil {
+		return "", errors.Wrap(err, "signing request")
+	}
+
+	resp, err := r.doer.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", errors.Newf("unexpected status code %d reading %q: %s", resp.StatusCode, ref.Name, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		SecretString *string `json:"SecretString"`
+		SecretBinary []byte  `json:"SecretBinary"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", errors.Wrap(err, "decoding aws response")
+	}
+
+	value := string(payload.SecretBinary)
+	if payload.SecretString != nil {
+		value = *payload.SecretString
+	}
+	return selectField(value, ref.Field)
+}
@@ -0,0 +1,39 @@
+package secrets
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/security/gsm"
+)
+
+// gcpResolver reads secrets from Google Cloud Secret Manager. It authenticates
+// with the application default credentials of the host, e.g. the service account
+// of the compute instance.
+type gcpResolver struct {
+	client *lazy[gsm.GSMClient]
+}
+
+func newGCPResolver() *gcpResolver {
+	return &gcpResolver{client: &lazy[gsm.GSMClient]{create: gsm.GetClient}}
+}
+
+func (r *gcpResolver) Resolve(ctx context.Context, ref types.SecretReference) (string, error) {
+	client, err := r.client.get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	name := ref.Name
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	resp, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: name})
+	if err != nil {
+		return "", err
+	}
+	return selectField(string(resp.Payload.Data), ref.Field)
+}
@@ -0,0 +1,162 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Resolver fetches the values of secrets that are stored in an external secret store.
+type Resolver interface {
+	// Resolve returns the value of the referenced secret.
+	Resolve(ctx context.Context, ref types.SecretReference) (string, error)
+}
+
+// Options configures the external secret stores.
+type Options struct {
+	// VaultAddress is the URL of the HashiCorp Vault server.
+	VaultAddress string
+	// VaultToken is the token used to authenticate with Vault.
+	VaultToken string
+	// VaultNamespace is the optional Vault Enterprise namespace secrets are read from.
+	VaultNamespace string
+	// AWSRegion is the region of AWS Secrets Manager. If empty, the region is read
+	// from the default AWS configuration.
+	AWSRegion string
+}
+
+// NewResolver returns a resolver that fetches secrets from the secret store the
+// reference points to. Cloud secret managers authenticate with the default
+// credentials of the host, and their clients are only created once a secret
+// from that secret manager is requested.
+func NewResolver(options Options) Resolver {
+	return &providerResolver{
+		resolvers: map[types.SecretProvider]Resolver{
+			types.SecretProviderVault:             newVaultResolver(options),
+			types.SecretProviderAWSSecretsManager: newAWSResolver(options),
+			types.SecretProviderGCPSecretManager:  newGCPResolver(),
+		},
+	}
+}
+
+type providerResolver struct {
+	resolvers map[types.SecretProvider]Resolver
+}
+
+func (r *providerResolver) Resolve(ctx context.Context, ref types.SecretReference) (string, error) {
+	resolver, ok := r.resolvers[ref.Provider]
+	if !ok {
+		return "", errors.Newf("unsupported secret provider %q", ref.Provider)
+	}
+	return resolver.Resolve(ctx, ref)
+}
+
+// ResolveJob fetches the external secrets of the job. Step environment variables
+// that reference an external secret are replaced with the secret value, and the
+// value is added to the redacted values of the job.
+func ResolveJob(ctx context.Context, resolver Resolver, job *types.Job) error {
+	if len(job.ExternalSecrets) == 0 {
+		return nil
+	}
+	if resolver == nil {
+		return errors.New("job references external secrets, but no secret resolver is configured")
+	}
+
+	values := make(map[string]string, len(job.ExternalSecrets))
+	for key, reference := range job.ExternalSecrets {
+		ref, err := types.ParseSecretReference(reference)
+		if err != nil {
+			return errors.Wrapf(err, "parsing reference of secret %q", key)
+		}
+		value, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return errors.Wrapf(err, "fetching secret %q from %s", key, ref.Provider)
+		}
+		values[fmt.Sprintf("%s=%s", key, reference)] = fmt.Sprintf("%s=%s", key, value)
+		if value == "" {
+			continue
+		}
+
+		if job.RedactedValues == nil {
+			job.RedactedValues = make(map[string]string)
+		}
+		// 🚨 SECURITY: We redact secret values as ${{ secrets.NAME }}, just like
+		// secrets that are stored in the Sourcegraph database.
+		job.RedactedValues[value] = fmt.Sprintf("${{ secrets.%s }}", key)
+	}
+
+	// Steps may share their env slice, so we build new ones.
+	replace := func(env []string) []string {
+		resolved := make([]string, len(env))
+		for i, e := range env {
+			if v, ok := values[e]; ok {
+				e = v
+			}
+			resolved[i] = e
+		}
+		return resolved
+	}
+	for i := range job.DockerSteps {
+		job.DockerSteps[i].Env = replace(job.DockerSteps[i].Env)
+	}
+	for i := range job.CliSteps {
+		job.CliSteps[i].Env = replace(job.CliSteps[i].Env)
+	}
+	return nil
+}
+
+// selectField returns the given field of a secret value that is a JSON object,
+// or the whole value if no field is given.
+func selectField(value, field string) (string, error) {
+	if field == "" {
+		return value, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", errors.Wrap(err, "secret value is not a JSON object")
+	}
+	return fieldValue(fields, field)
+}
+
+func fieldValue(fields map[string]any, field string) (string, error) {
+	v, ok := fields[field]
+	if !ok {
+		return "", errors.Newf("secret has no field %q", field)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// lazy creates a client the first time it is needed. Creating the client is
+// retried on the next call if it fails.
+type lazy[T any] struct {
+	mu     sync.Mutex
+	create func(ctx context.Context) (T, error)
+	client *T
+}
+
+func (l *lazy[T]) get(ctx context.Context) (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.client == nil {
+		// The client outlives the job that creates it.
+		client, err := l.create(context.WithoutCancel(ctx))
+		if err != nil {
+			return client, err
+		}
+		l.client = &client
+	}
+	return *l.client, nil
+}
@@ -0,0 +1,154 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type resolverFunc func(ctx context.Context, ref types.SecretReference) (string, error)
+
+func (f resolverFunc) Resolve(ctx context.Context, ref types.SecretReference) (string, error) {
+	return f(ctx, ref)
+}
+
+func TestResolveJob(t *testing.T) {
+	resolver := resolverFunc(func(_ context.Context, ref types.SecretReference) (string, error) {
+		if ref.Name == "missing" {
+			return "", errors.New("not found")
+		}
+		return ref.Name + "-" + ref.Field + "-value", nil
+	})
+
+	t.Run("no external secrets", func(t *testing.T) {
+		job := types.Job{DockerSteps: []types.DockerStep{{Env: []string{"FOO=bar"}}}}
+		require.NoError(t, ResolveJob(context.Background(), nil, &job))
+		assert.Equal(t, []string{"FOO=bar"}, job.DockerSteps[0].Env)
+	})
+
+	t.Run("replaces references", func(t *testing.T) {
+		env := []string{"FOO=bar", "TOKEN=vault://secret/data/ci#token"}
+		job := types.Job{
+			DockerSteps: []types.DockerStep{{Env: env}, {Env: []string{"FOO=bar"}}},
+			CliSteps:    []types.CliStep{{Env: env}},
+			ExternalSecrets: map[string]string{
+				"TOKEN": "vault://secret/data/ci#token",
+			},
+		}
+		require.NoError(t, ResolveJob(context.Background(), resolver, &job))
+
+		assert.Equal(t, []string{"FOO=bar", "TOKEN=secret/data/ci-token-value"}, job.DockerSteps[0].Env)
+		assert.Equal(t, []string{"FOO=bar"}, job.DockerSteps[1].Env)
+		assert.Equal(t, []string{"FOO=bar", "TOKEN=secret/data/ci-token-value"}, job.CliSteps[0].Env)
+		assert.Equal(t, map[string]string{"secret/data/ci-token-value": "${{ secrets.TOKEN }}"}, job.RedactedValues)
+		// The shared env slice of the payload is not modified.
+		assert.Equal(t, "TOKEN=vault://secret/data/ci#token", env[1])
+	})
+
+	t.Run("no resolver", func(t *testing.T) {
+		job := types.Job{ExternalSecrets: map[string]string{"TOKEN": "vault://secret/data/ci#token"}}
+		require.Error(t, ResolveJob(context.Background(), nil, &job))
+	})
+
+	t.Run("resolve error", func(t *testing.T) {
+		job := types.Job{ExternalSecrets: map[string]string{"TOKEN": "awssm://missing"}}
+		err := ResolveJob(context.Background(), resolver, &job)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `fetching secret "TOKEN" from awssm`)
+	})
+}
+
+func TestVaultResolver(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		assert.Equal(t, "team", r.Header.Get("X-Vault-Namespace"))
+
+		switch r.URL.Path {
+		case "/v1/secret/data/ci":
+			_, _ = w.Write([]byte(`{"data":{"data":{"token":"kv2"},"metadata":{"version":1}}}`))
+		case "/v1/kv/ci":
+			_, _ = w.Write([]byte(`{"data":{"token":"kv1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	r := newVaultResolver(Options{VaultAddress: ts.URL + "/", VaultToken: "root", VaultNamespace: "team"})
+	r.doer = ts.Client()
+
+	value, err := r.Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderVault, Name: "secret/data/ci", Field: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "kv2", value)
+
+	value, err = r.Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderVault, Name: "kv/ci", Field: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "kv1", value)
+
+	_, err = r.Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderVault, Name: "kv/ci", Field: "password"})
+	assert.EqualError(t, err, `secret has no field "password"`)
+
+	_, err = r.Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderVault, Name: "kv/other", Field: "token"})
+	assert.Error(t, err)
+
+	_, err = newVaultResolver(Options{}).Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderVault, Name: "kv/ci", Field: "token"})
+	assert.EqualError(t, err, "vault is not configured: set EXECUTOR_VAULT_ADDR and EXECUTOR_VAULT_TOKEN")
+}
+
+func TestAWSResolver(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var input struct{ SecretId string }
+		require.NoError(t, json.Unmarshal(body, &input))
+
+		switch input.SecretId {
+		case "plain":
+			_, _ = w.Write([]byte(`{"SecretString":"hunter2"}`))
+		case "json":
+			_, _ = w.Write([]byte(`{"SecretString":"{\"token\":\"hunter3\"}"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	r := newAWSResolver(Options{})
+	r.doer = ts.Client()
+	r.endpoint = func(string) string { return ts.URL }
+	r.config = &lazy[aws.Config]{create: func(context.Context) (aws.Config, error) {
+		return aws.Config{Region: "us-east-1", Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", "")}, nil
+	}}
+
+	value, err := r.Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderAWSSecretsManager, Name: "plain"})
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	value, err = r.Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderAWSSecretsManager, Name: "json", Field: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "hunter3", value)
+
+	_, err = r.Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderAWSSecretsManager, Name: "plain", Field: "token"})
+	assert.Error(t, err)
+
+	_, err = r.Resolve(context.Background(), types.SecretReference{Provider: types.SecretProviderAWSSecretsManager, Name: "unknown"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// vaultResolver reads secrets from HashiCorp Vault using the HTTP API. Both the
+// KV version 1 and version 2 secrets engines are supported; for version 2, the
+// path must include the data/ segment, e.g. secret/data/ci.
+type vaultResolver struct {
+	doer      httpcli.Doer
+	address   string
+	token     string
+	namespace string
+}
+
+func newVaultResolver(options Options) *vaultResolver {
+	return &vaultResolver{
+		doer:      httpcli.ExternalDoer,
+		address:   strings.TrimSuffix(options.VaultAddress, "/"),
+		token:     options.VaultToken,
+		namespace: options.VaultNamespace,
+	}
+}
+
+func (r *vaultResolver) Resolve(ctx context.Context, ref types.SecretReference) (string, error) {
+	if r.address == "" || r.token == "" {
+		return "", errors.New("vault is not configured: set EXECUTOR_VAULT_ADDR and EXECUTOR_VAULT_TOKEN")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.address+"/v1/"+strings.TrimPrefix(ref.Name, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", r.token)
+	if r.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", r.namespace)
+	}
+
+	resp, err := r.doer.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", errors.Newf("unexpected status code %d reading %q: %s", resp.StatusCode, ref.Name, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", errors.Wrap(err, "decoding vault response")
+	}
+
+	fields := payload.Data
+	// The KV version 2 secrets engine nests the secret in data.data, next to its metadata.
+	if data, ok := fields["data"].(map[string]any); ok {
+		if _, ok := fields["metadata"]; ok {
+			fields = data
+		}
+	}
+	return fieldValue(fields, ref.Field)
+}
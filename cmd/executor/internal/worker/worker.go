@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runner"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runtime"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/secrets"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/workspace"
 	"github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -66,6 +67,10 @@ type Options struct {
 
 	RunnerOptions runner.Options
 
+	// SecretOptions configures the external secret stores that job secrets can be
+	// fetched from.
+	SecretOptions secrets.Options
+
 	// NodeExporterEndpoint is the URL of the local node_exporter endpoint, without
 	// the /metrics path.
 	NodeExporterEndpoint string
@@ -115,15 +120,17 @@ func NewWorker(observationCtx *observation.Context, nameSet *janitor.NameSet, op
 	}
 
 	h := &handler{
-		nameSet:      nameSet,
-		cmdRunner:    cmdRunner,
-		cmd:          cmd,
-		logStore:     queueClient,
-		filesStore:   filesClient,
-		options:      options,
-		cloneOptions: cloneOptions,
-		operations:   commandOps,
-		jobRuntime:   jobRuntime,
+		nameSet:        nameSet,
+		cmdRunner:      cmdRunner,
+		cmd:            cmd,
+		logStore:       queueClient,
+		artifactStore:  queueClient,
+		filesStore:     filesClient,
+		options:        options,
+		cloneOptions:   cloneOptions,
+		operations:     commandOps,
+		jobRuntime:     jobRuntime,
+		secretResolver: secrets.NewResolver(options.SecretOptions),
 	}
 
 	return workerutil.NewWorker[types.Job](context.Background(), queueClient, h, options.WorkerOptions), nil
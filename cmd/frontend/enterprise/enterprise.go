@@ -44,6 +44,13 @@ type Services struct {
 	SearchJobsDataExportHandler http.Handler
 	SearchJobsLogsHandler       http.Handler
 
+	// Handler for downloading the manifests and archives of repository snapshots.
+	RepoSnapshotDownloadHandler http.Handler
+
+	// Handlers for streaming the execution logs and downloading the artifacts of executor jobs.
+	ExecutorJobLogStreamHandler http.Handler
+	ExecutorJobArtifactHandler  http.Handler
+
 	// Handler for completions stream.
 	NewChatCompletionsStreamHandler NewChatCompletionsStreamHandler
 
@@ -53,12 +60,13 @@ type Services struct {
 	// Handler for license v2 check.
 	NewDotcomLicenseCheckHandler NewDotcomLicenseCheckHandler
 
-	PermissionsGitHubWebhook  webhooks.Registerer
-	NewCodeIntelUploadHandler NewCodeIntelUploadHandler
-	RankingService            RankingService
-	NewExecutorProxyHandler   NewExecutorProxyHandler
-	NewGitHubAppSetupHandler  NewGitHubAppSetupHandler
-	NewComputeStreamHandler   NewComputeStreamHandler
+	PermissionsGitHubWebhook         webhooks.Registerer
+	PermissionsBitbucketCloudWebhook webhooks.Registerer
+	NewCodeIntelUploadHandler        NewCodeIntelUploadHandler
+	RankingService                   RankingService
+	NewExecutorProxyHandler          NewExecutorProxyHandler
+	NewGitHubAppSetupHandler         NewGitHubAppSetupHandler
+	NewComputeStreamHandler          NewComputeStreamHandler
 	graphqlbackend.OptionalResolver
 }
 
@@ -97,31 +105,35 @@ type NewDotcomLicenseCheckHandler func() http.Handler
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
-		ReposGithubWebhook:              &emptyWebhookHandler{name: "github sync webhook"},
-		ReposGitLabWebhook:              &emptyWebhookHandler{name: "gitlab sync webhook"},
-		ReposBitbucketServerWebhook:     &emptyWebhookHandler{name: "bitbucket server sync webhook"},
-		ReposBitbucketCloudWebhook:      &emptyWebhookHandler{name: "bitbucket cloud sync webhook"},
-		PermissionsGitHubWebhook:        &emptyWebhookHandler{name: "permissions github webhook"},
-		BatchesGitHubWebhook:            &emptyWebhookHandler{name: "batches github webhook"},
-		BatchesGitLabWebhook:            &emptyWebhookHandler{name: "batches gitlab webhook"},
-		BatchesBitbucketServerWebhook:   &emptyWebhookHandler{name: "batches bitbucket server webhook"},
-		BatchesBitbucketCloudWebhook:    &emptyWebhookHandler{name: "batches bitbucket cloud webhook"},
-		BatchesAzureDevOpsWebhook:       &emptyWebhookHandler{name: "batches azure devops webhook"},
-		BatchesChangesFileGetHandler:    makeNotFoundHandler("batches file get handler"),
-		BatchesChangesFileExistsHandler: makeNotFoundHandler("batches file exists handler"),
-		BatchesChangesFileUploadHandler: makeNotFoundHandler("batches file upload handler"),
-		SCIMHandler:                     makeNotFoundHandler("SCIM handler"),
-		NewCodeIntelUploadHandler:       func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
-		RankingService:                  stubRankingService{},
-		NewExecutorProxyHandler:         func() http.Handler { return makeNotFoundHandler("executor proxy") },
-		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
-		NewComputeStreamHandler:         func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
-		CodeInsightsDataExportHandler:   makeNotFoundHandler("code insights data export handler"),
-		NewDotcomLicenseCheckHandler:    func() http.Handler { return makeNotFoundHandler("dotcom license check handler") },
-		NewChatCompletionsStreamHandler: func() http.Handler { return makeNotFoundHandler("chat completions streaming endpoint") },
-		NewCodeCompletionsHandler:       func() http.Handler { return makeNotFoundHandler("code completions streaming endpoint") },
-		SearchJobsDataExportHandler:     makeNotFoundHandler("search jobs data export handler"),
-		SearchJobsLogsHandler:           makeNotFoundHandler("search jobs logs handler"),
+		ReposGithubWebhook:               &emptyWebhookHandler{name: "github sync webhook"},
+		ReposGitLabWebhook:               &emptyWebhookHandler{name: "gitlab sync webhook"},
+		ReposBitbucketServerWebhook:      &emptyWebhookHandler{name: "bitbucket server sync webhook"},
+		ReposBitbucketCloudWebhook:       &emptyWebhookHandler{name: "bitbucket cloud sync webhook"},
+		PermissionsGitHubWebhook:         &emptyWebhookHandler{name: "permissions github webhook"},
+		PermissionsBitbucketCloudWebhook: &emptyWebhookHandler{name: "permissions bitbucket cloud webhook"},
+		BatchesGitHubWebhook:             &emptyWebhookHandler{name: "batches github webhook"},
+		BatchesGitLabWebhook:             &emptyWebhookHandler{name: "batches gitlab webhook"},
+		BatchesBitbucketServerWebhook:    &emptyWebhookHandler{name: "batches bitbucket server webhook"},
+		BatchesBitbucketCloudWebhook:     &emptyWebhookHandler{name: "batches bitbucket cloud webhook"},
+		BatchesAzureDevOpsWebhook:        &emptyWebhookHandler{name: "batches azure devops webhook"},
+		BatchesChangesFileGetHandler:     makeNotFoundHandler("batches file get handler"),
+		BatchesChangesFileExistsHandler:  makeNotFoundHandler("batches file exists handler"),
+		BatchesChangesFileUploadHandler:  makeNotFoundHandler("batches file upload handler"),
+		SCIMHandler:                      makeNotFoundHandler("SCIM handler"),
+		NewCodeIntelUploadHandler:        func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
+		RankingService:                   stubRankingService{},
+		NewExecutorProxyHandler:          func() http.Handler { return makeNotFoundHandler("executor proxy") },
+		NewGitHubAppSetupHandler:         func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
+		NewComputeStreamHandler:          func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
+		CodeInsightsDataExportHandler:    makeNotFoundHandler("code insights data export handler"),
+		NewDotcomLicenseCheckHandler:     func() http.Handler { return makeNotFoundHandler("dotcom license check handler") },
+		NewChatCompletionsStreamHandler:  func() http.Handler { return makeNotFoundHandler("chat completions streaming endpoint") },
+		NewCodeCompletionsHandler:        func() http.Handler { return makeNotFoundHandler("code completions streaming endpoint") },
+		SearchJobsDataExportHandler:      makeNotFoundHandler("search jobs data export handler"),
+		SearchJobsLogsHandler:            makeNotFoundHandler("search jobs logs handler"),
+		ExecutorJobLogStreamHandler:      makeNotFoundHandler("executor job log stream handler"),
+		ExecutorJobArtifactHandler:       makeNotFoundHandler("executor job artifact handler"),
+		RepoSnapshotDownloadHandler:      makeNotFoundHandler("repository snapshot download handler"),
 	}
 }
 
@@ -0,0 +1,159 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+type AuditEventsArgs struct {
+	Actions    *[]string
+	EntityType *string
+	EntityID   *string
+	Actor      *graphql.ID
+	Since      *gqlutil.DateTime
+	Until      *gqlutil.DateTime
+	graphqlutil.ConnectionResolverArgs
+}
+
+func (r *schemaResolver) AuditEvents(ctx context.Context, args *AuditEventsArgs) (*graphqlutil.ConnectionResolver[*auditEventResolver], error) {
+	// 🚨 SECURITY: Only site admins can see the audit log.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	filter := &database.AuditEventsFilterArgs{
+		Actions:    pointers.DerefZero(args.Actions),
+		EntityType: pointers.DerefZero(args.EntityType),
+		EntityID:   pointers.DerefZero(args.EntityID),
+	}
+	if args.Actor != nil {
+		userID, err := UnmarshalUserID(*args.Actor)
+		if err != nil {
+			return nil, err
+		}
+		filter.ActorUserID = userID
+	}
+	if args.Since != nil {
+		filter.Since = &args.Since.Time
+	}
+	if args.Until != nil {
+		filter.Until = &args.Until.Time
+	}
+
+	connectionStore := &auditEventConnectionStore{
+		db:   r.db,
+		args: filter,
+	}
+
+	reverse := false
+	connectionOptions := graphqlutil.ConnectionResolverOptions{
+		Reverse:   &reverse,
+		OrderBy:   database.OrderBy{{Field: string(database.AuditEventListID)}},
+		Ascending: false,
+	}
+	return graphqlutil.NewConnectionResolver[*auditEventResolver](connectionStore, &args.ConnectionResolverArgs, &connectionOptions)
+}
+
+type auditEventConnectionStore struct {
+	db   database.DB
+	args *database.AuditEventsFilterArgs
+}
+
+func (s *auditEventConnectionStore) ComputeTotal(ctx context.Context) (int32, error) {
+	count, err := s.db.AuditEvents().Count(ctx, s.args)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(count), nil
+}
+
+func (s *auditEventConnectionStore) ComputeNodes(ctx context.Context, args *database.PaginationArgs) ([]*auditEventResolver, error) {
+	events, err := s.db.AuditEvents().List(ctx, s.args, args)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*auditEventResolver, len(events))
+	for i, event := range events {
+		resolvers[i] = &auditEventResolver{db: s.db, event: event}
+	}
+
+	return resolvers, nil
+}
+
+func (s *auditEventConnectionStore) MarshalCursor(node *auditEventResolver, _ database.OrderBy) (*string, error) {
+	if node == nil {
+		return nil, errors.New(`node is nil`)
+	}
+
+	cursor := string(node.ID())
+
+	return &cursor, nil
+}
+
+func (s *auditEventConnectionStore) UnmarshalCursor(cursor string, _ database.OrderBy) ([]any, error) {
+	id, err := unmarshalAuditEventID(graphql.ID(cursor))
+	if err != nil {
+		return nil, err
+	}
+
+	return []any{id}, nil
+}
+
+// auditEventResolver resolves an audit event.
+type auditEventResolver struct {
+	db    database.DB
+	event *database.AuditEvent
+}
+
+func (r *auditEventResolver) ID() graphql.ID { return marshalAuditEventID(r.event.ID) }
+
+func (r *auditEventResolver) Action() string { return r.event.Action }
+
+func (r *auditEventResolver) EntityType() string { return r.event.EntityType }
+
+func (r *auditEventResolver) EntityID() *string { return pointers.NonZeroPtr(r.event.EntityID) }
+
+func (r *auditEventResolver) Actor(ctx context.Context) (*UserResolver, error) {
+	if r.event.ActorUserID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, r.event.ActorUserID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *auditEventResolver) ActorIP() *string { return pointers.NonZeroPtr(r.event.ActorIP) }
+
+func (r *auditEventResolver) ActorUserAgent() *string {
+	return pointers.NonZeroPtr(r.event.ActorUserAgent)
+}
+
+func (r *auditEventResolver) Metadata() JSONValue { return JSONValue{r.event.Metadata} }
+
+func (r *auditEventResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.event.CreatedAt}
+}
+
+func marshalAuditEventID(id int64) graphql.ID { return relay.MarshalID("AuditEvent", id) }
+
+func unmarshalAuditEventID(id graphql.ID) (eventID int64, err error) {
+	err = relay.UnmarshalSpec(id, &eventID)
+	return
+}
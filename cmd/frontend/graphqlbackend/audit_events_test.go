@@ -0,0 +1,141 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAuditEventsQuery(t *testing.T) {
+	const auditEventsQuery = `
+	query GetAuditEvents($first: Int, $actions: [String!], $actor: ID, $since: DateTime) {
+		auditEvents(first: $first, actions: $actions, actor: $actor, since: $since) {
+			nodes {
+				id
+				action
+				entityType
+				entityID
+				actor {
+					username
+				}
+				actorIP
+				metadata
+				createdAt
+			}
+			totalCount
+		}
+	}`
+
+	db := dbmocks.NewMockDB()
+
+	userStore := dbmocks.NewMockUserStore()
+	db.UsersFunc.SetDefaultReturn(userStore)
+
+	auditEventStore := dbmocks.NewMockAuditEventStore()
+	db.AuditEventsFunc.SetDefaultReturn(auditEventStore)
+
+	t.Run("non-admin user", func(t *testing.T) {
+		userStore.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: false}, nil)
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		RunTest(t, &Test{
+			Schema:         mustParseGraphQLSchema(t, db),
+			Context:        ctx,
+			Query:          auditEventsQuery,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Path:          []any{"auditEvents"},
+					Message:       auth.ErrMustBeSiteAdmin.Error(),
+					ResolverError: auth.ErrMustBeSiteAdmin,
+				},
+			},
+			Variables: map[string]any{
+				"first": 10,
+			},
+		})
+	})
+
+	t.Run("admin user", func(t *testing.T) {
+		createdAt, _ := time.Parse(time.RFC3339, "2023-12-18T10:00:00Z")
+		auditEventStore.ListFunc.SetDefaultReturn([]*database.AuditEvent{
+			{
+				ID:          2,
+				Action:      database.AuditActionAccessTokenCreated,
+				EntityType:  database.AuditEntityAccessToken,
+				EntityID:    "7",
+				ActorUserID: 1,
+				ActorIP:     "10.0.0.1",
+				Metadata:    map[string]any{"note": "ci"},
+				CreatedAt:   createdAt,
+			},
+			{
+				ID:         1,
+				Action:     database.AuditActionRepoDeleted,
+				EntityType: database.AuditEntityRepo,
+				Metadata:   map[string]any{},
+				CreatedAt:  createdAt,
+			},
+		}, nil)
+		auditEventStore.CountFunc.SetDefaultReturn(2, nil)
+		userStore.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+		userStore.GetByIDFunc.SetDefaultReturn(&types.User{ID: 1, Username: "alice"}, nil)
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+		RunTest(t, &Test{
+			Schema:  mustParseGraphQLSchema(t, db),
+			Context: ctx,
+			Query:   auditEventsQuery,
+			ExpectedResult: `{
+				"auditEvents": {
+					"nodes": [
+						{
+							"id": "QXVkaXRFdmVudDoy",
+							"action": "access_token.created",
+							"entityType": "access_token",
+							"entityID": "7",
+							"actor": {"username": "alice"},
+							"actorIP": "10.0.0.1",
+							"metadata": {"note": "ci"},
+							"createdAt": "2023-12-18T10:00:00Z"
+						},
+						{
+							"id": "QXVkaXRFdmVudDox",
+							"action": "repo.deleted",
+							"entityType": "repo",
+							"entityID": null,
+							"actor": null,
+							"actorIP": null,
+							"metadata": {},
+							"createdAt": "2023-12-18T10:00:00Z"
+						}
+					],
+					"totalCount": 2
+				}
+			}`,
+			Variables: map[string]any{
+				"first":   10,
+				"actions": []any{"access_token.created", "repo.deleted"},
+				"actor":   "VXNlcjox",
+				"since":   "2023-12-01T00:00:00Z",
+			},
+		})
+
+		require.NotEmpty(t, auditEventStore.ListFunc.History())
+		filter := auditEventStore.ListFunc.History()[0].Arg1
+		assert.Equal(t, []string{"access_token.created", "repo.deleted"}, filter.Actions)
+		assert.Equal(t, int32(1), filter.ActorUserID)
+		require.NotNil(t, filter.Since)
+		assert.True(t, filter.Since.Equal(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)))
+		assert.Nil(t, filter.Until)
+	})
+}
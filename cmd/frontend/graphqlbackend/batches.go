@@ -62,6 +62,15 @@ type ReenqueueChangesetArgs struct {
 	Changeset graphql.ID
 }
 
+type SetChangesetExternalCheckArgs struct {
+	Changeset   graphql.ID
+	Context     string
+	State       string
+	Commit      *string
+	TargetURL   *string
+	Description *string
+}
+
 type CreateChangesetSpecsArgs struct {
 	ChangesetSpecs []string
 }
@@ -237,6 +246,10 @@ type ResolveWorkspacesForBatchSpecArgs struct {
 	BatchSpec string
 }
 
+type ValidateBatchSpecArgs struct {
+	BatchSpec string
+}
+
 type ListImportingChangesetsArgs struct {
 	First  int32
 	After  *string
@@ -278,6 +291,7 @@ type BatchChangesResolver interface {
 	CreateChangesetSpecs(ctx context.Context, args *CreateChangesetSpecsArgs) ([]ChangesetSpecResolver, error)
 	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error)
 	ReenqueueChangeset(ctx context.Context, args *ReenqueueChangesetArgs) (ChangesetResolver, error)
+	SetChangesetExternalCheck(ctx context.Context, args *SetChangesetExternalCheckArgs) (ChangesetResolver, error)
 	DetachChangesets(ctx context.Context, args *DetachChangesetsArgs) (BulkOperationResolver, error)
 	CreateChangesetComments(ctx context.Context, args *CreateChangesetCommentsArgs) (BulkOperationResolver, error)
 	ReenqueueChangesets(ctx context.Context, args *ReenqueueChangesetsArgs) (BulkOperationResolver, error)
@@ -299,6 +313,7 @@ type BatchChangesResolver interface {
 	AvailableBulkOperations(ctx context.Context, args *AvailableBulkOperationsArgs) ([]string, error)
 
 	ResolveWorkspacesForBatchSpec(ctx context.Context, args *ResolveWorkspacesForBatchSpecArgs) ([]ResolvedBatchSpecWorkspaceResolver, error)
+	ValidateBatchSpec(ctx context.Context, args *ValidateBatchSpecArgs) (BatchSpecValidationResultResolver, error)
 
 	CheckBatchChangesCredential(ctx context.Context, args *CheckBatchChangesCredentialArgs) (*EmptyResponse, error)
 
@@ -937,6 +952,19 @@ type ResolvedBatchSpecWorkspaceResolver interface {
 	SearchResultPaths() []string
 }
 
+type BatchSpecValidationResultResolver interface {
+	Valid() bool
+	Errors() []BatchSpecValidationErrorResolver
+}
+
+type BatchSpecValidationErrorResolver interface {
+	Kind() string
+	Message() string
+	Path() *string
+	Line() *int32
+	Column() *int32
+}
+
 type BatchSpecWorkspaceStagesResolver interface {
 	Setup() []ExecutionLogEntryResolver
 	SrcExec() []ExecutionLogEntryResolver
@@ -0,0 +1,137 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/conc/pool"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// maxBlobLocationsPerRequest is the maximum number of files that can be
+	// looked up with a single blobs query.
+	maxBlobLocationsPerRequest = 100
+
+	// maxConcurrentBlobLookups is the number of files resolved in parallel for
+	// a single blobs query.
+	maxConcurrentBlobLookups = 10
+)
+
+type BlobLocationInput struct {
+	Repository string
+	Revision   string
+	Path       string
+}
+
+type blobsArgs struct {
+	Locations []BlobLocationInput
+}
+
+// Blobs resolves many repository, revision and path tuples at once. The
+// repositories are fetched in a single database query, and every entry that
+// can't be resolved is returned as null rather than failing the whole request.
+func (r *schemaResolver) Blobs(ctx context.Context, args *blobsArgs) ([]*GitTreeEntryResolver, error) {
+	if len(args.Locations) > maxBlobLocationsPerRequest {
+		return nil, errors.Newf("at most %d blob locations can be looked up per request, got %d", maxBlobLocationsPerRequest, len(args.Locations))
+	}
+	if len(args.Locations) == 0 {
+		return []*GitTreeEntryResolver{}, nil
+	}
+
+	names := make([]string, 0, len(args.Locations))
+	seen := make(map[string]struct{}, len(args.Locations))
+	for _, location := range args.Locations {
+		name := strings.ToLower(location.Repository)
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, location.Repository)
+		}
+	}
+
+	// Repos that don't exist or that the current user can't access are not
+	// returned here, which leaves the entries that reference them null.
+	repos, err := r.db.Repos().List(ctx, database.ReposListOptions{Names: names})
+	if err != nil {
+		return nil, err
+	}
+	reposByName := make(map[string]*types.Repo, len(repos))
+	for _, repo := range repos {
+		reposByName[strings.ToLower(string(repo.Name))] = repo
+	}
+
+	// Multiple locations often share a repository and revision, so the
+	// revisions are only resolved once per pair.
+	type repoRev struct {
+		repo string
+		rev  string
+	}
+	var keys []repoRev
+	seenKeys := make(map[repoRev]struct{}, len(args.Locations))
+	for _, location := range args.Locations {
+		key := repoRev{strings.ToLower(location.Repository), location.Revision}
+		if _, ok := reposByName[key.repo]; !ok {
+			continue
+		}
+		if _, ok := seenKeys[key]; !ok {
+			seenKeys[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+
+	// Errors are handled per entry: an entry that can't be resolved, e.g. because
+	// its revision is invalid or its path is a directory, is left null rather than
+	// failing the whole request.
+	resolved := make([]*GitCommitResolver, len(keys))
+	p := pool.New().WithMaxGoroutines(maxConcurrentBlobLookups)
+	for i, key := range keys {
+		i, key := i, key
+		p.Go(func() {
+			repoResolver := NewRepositoryResolver(r.db, r.gitserverClient, reposByName[key.repo])
+			commit, err := repoResolver.Commit(ctx, &RepositoryCommitArgs{Rev: key.rev})
+			if err != nil {
+				r.logger.Debug("resolving blob revision", log.String("repo", key.repo), log.String("rev", key.rev), log.Error(err))
+				return
+			}
+			resolved[i] = commit
+		})
+	}
+	p.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	commits := make(map[repoRev]*GitCommitResolver, len(keys))
+	for i, key := range keys {
+		commits[key] = resolved[i]
+	}
+
+	p = pool.New().WithMaxGoroutines(maxConcurrentBlobLookups)
+	blobs := make([]*GitTreeEntryResolver, len(args.Locations))
+	for i, location := range args.Locations {
+		commit := commits[repoRev{strings.ToLower(location.Repository), location.Revision}]
+		if commit == nil {
+			continue
+		}
+
+		i, path := i, location.Path
+		p.Go(func() {
+			blob, err := commit.Blob(ctx, &struct{ Path string }{Path: path})
+			if err != nil {
+				r.logger.Debug("resolving blob", log.String("path", path), log.Error(err))
+				return
+			}
+			blobs[i] = blob
+		})
+	}
+	p.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return blobs, nil
+}
@@ -0,0 +1,126 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBlobs(t *testing.T) {
+	repos := dbmocks.NewMockRepoStore()
+	repos.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]*types.Repo, error) {
+		// Repository names are deduplicated before they are looked up.
+		assert.Equal(t, []string{"github.com/gorilla/mux", "github.com/private/repo"}, opts.Names)
+		return []*types.Repo{{ID: 2, Name: "github.com/gorilla/mux", CreatedAt: time.Now()}}, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	gsClient := gitserver.NewMockClient()
+	gsClient.StatFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, commit api.CommitID, path string) (fs.FileInfo, error) {
+		assert.Equal(t, api.CommitID(exampleCommitSHA1), commit)
+		if path == "missing.go" {
+			return nil, &os.PathError{Op: "stat", Path: path, Err: os.ErrNotExist}
+		}
+		if path == "dir" {
+			return &fileutil.FileInfo{Name_: path, Mode_: fs.ModeDir}, nil
+		}
+		return &fileutil.FileInfo{Name_: path, Mode_: 0}, nil
+	})
+
+	var (
+		mu           sync.Mutex
+		resolvedRevs []string
+	)
+	backend.Mocks.Repos.ResolveRev = func(_ context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		mu.Lock()
+		resolvedRevs = append(resolvedRevs, rev)
+		mu.Unlock()
+		if rev == "missing" {
+			return "", &gitdomain.RevisionNotFoundError{Repo: repo.Name, Spec: rev}
+		}
+		if rev == "-invalid" {
+			return "", &gitdomain.BadCommitError{Spec: rev, Commit: api.CommitID(rev), Repo: repo.Name}
+		}
+		return exampleCommitSHA1, nil
+	}
+	t.Cleanup(func() { backend.Mocks = backend.MockServices{} })
+
+	RunTest(t, &Test{
+		Schema: mustParseGraphQLSchemaWithClient(t, db, gsClient),
+		Query: `
+			{
+				blobs(locations: [
+					{repository: "github.com/gorilla/mux", path: "mux.go"},
+					{repository: "github.com/gorilla/mux", path: "missing.go"},
+					{repository: "github.com/gorilla/mux", revision: "missing", path: "mux.go"},
+					{repository: "github.com/gorilla/mux", revision: "-invalid", path: "mux.go"},
+					{repository: "github.com/gorilla/mux", path: "dir"},
+					{repository: "github.com/private/repo", path: "main.go"},
+					{repository: "github.com/Gorilla/Mux", path: "route.go"},
+				]) {
+					path
+				}
+			}
+		`,
+		ExpectedResult: `
+			{
+				"blobs": [
+					{"path": "mux.go"},
+					null,
+					null,
+					null,
+					null,
+					null,
+					{"path": "route.go"}
+				]
+			}
+		`,
+	})
+
+	// Revisions are only resolved once per repository.
+	assert.ElementsMatch(t, []string{"", "missing", "-invalid"}, resolvedRevs)
+}
+
+func TestBlobs_TooManyLocations(t *testing.T) {
+	locations := make([]string, maxBlobLocationsPerRequest+1)
+	for i := range locations {
+		locations[i] = fmt.Sprintf(`{repository: "github.com/gorilla/mux", path: "%d.go"}`, i)
+	}
+
+	RunTest(t, &Test{
+		Schema: mustParseGraphQLSchema(t, dbmocks.NewMockDB()),
+		Query: `
+			{
+				blobs(locations: [` + strings.Join(locations, ", ") + `]) {
+					path
+				}
+			}
+		`,
+		ExpectedResult: "null",
+		ExpectedErrors: []*gqlerrors.QueryError{
+			{
+				Message: "at most 100 blob locations can be looked up per request, got 101",
+				Path:    []any{"blobs"},
+			},
+		},
+	})
+}
@@ -0,0 +1,75 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	stores "github.com/sourcegraph/sourcegraph/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+type codeIntelSlowQueriesArgs struct {
+	First *int32
+}
+
+func (r *schemaResolver) CodeIntelSlowQueries(ctx context.Context, args *codeIntelSlowQueriesArgs) (*codeIntelSlowQueryConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may list slow queries.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	queries, err := stores.GetSlowQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &codeIntelSlowQueryConnectionResolver{first: args.First, queries: queries}, nil
+}
+
+type codeIntelSlowQueryConnectionResolver struct {
+	first   *int32
+	queries []*stores.SlowQuery
+}
+
+func (r *codeIntelSlowQueryConnectionResolver) Nodes() []*codeIntelSlowQueryResolver {
+	queries := r.queries
+	if r.first != nil && *r.first > -1 && len(queries) > int(*r.first) {
+		queries = queries[:*r.first]
+	}
+
+	resolvers := make([]*codeIntelSlowQueryResolver, 0, len(queries))
+	for _, q := range queries {
+		resolvers = append(resolvers, &codeIntelSlowQueryResolver{q: q})
+	}
+	return resolvers
+}
+
+func (r *codeIntelSlowQueryConnectionResolver) TotalCount() int32 {
+	return int32(len(r.queries))
+}
+
+type codeIntelSlowQueryResolver struct {
+	q *stores.SlowQuery
+}
+
+func (r *codeIntelSlowQueryResolver) Query() string { return r.q.Query }
+
+func (r *codeIntelSlowQueryResolver) Operation() *string {
+	if r.q.Operation == "" {
+		return nil
+	}
+	return &r.q.Operation
+}
+
+func (r *codeIntelSlowQueryResolver) StartedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.q.StartedAt}
+}
+
+func (r *codeIntelSlowQueryResolver) DurationMs() int32 { return int32(r.q.Duration.Milliseconds()) }
+
+func (r *codeIntelSlowQueryResolver) Error() *string {
+	if r.q.Error == "" {
+		return nil
+	}
+	return &r.q.Error
+}
@@ -0,0 +1,60 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	stores "github.com/sourcegraph/sourcegraph/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeIntelSlowQueries(t *testing.T) {
+	t.Run("non-admin user", func(t *testing.T) {
+		db := dbmocks.NewMockDB()
+		userStore := dbmocks.NewMockUserStore()
+		userStore.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: false}, nil)
+		db.UsersFunc.SetDefaultReturn(userStore)
+
+		RunTest(t, &Test{
+			Schema:         mustParseGraphQLSchema(t, db),
+			Context:        actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			Query:          `{ codeIntelSlowQueries { totalCount } }`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Path:          []any{"codeIntelSlowQueries"},
+					Message:       auth.ErrMustBeSiteAdmin.Error(),
+					ResolverError: auth.ErrMustBeSiteAdmin,
+				},
+			},
+		})
+	})
+
+	t.Run("connection", func(t *testing.T) {
+		first := int32(1)
+		r := &codeIntelSlowQueryConnectionResolver{
+			first: &first,
+			queries: []*stores.SlowQuery{
+				{Query: "SELECT 2", Operation: "codenav.GetReferences", Duration: 1500 * time.Millisecond, Error: "canceled"},
+				{Query: "SELECT 1", Duration: time.Second},
+			},
+		}
+
+		assert.Equal(t, int32(2), r.TotalCount())
+		nodes := r.Nodes()
+		if assert.Len(t, nodes, 1) {
+			assert.Equal(t, "SELECT 2", nodes[0].Query())
+			assert.Equal(t, "codenav.GetReferences", *nodes[0].Operation())
+			assert.Equal(t, int32(1500), nodes[0].DurationMs())
+			assert.Equal(t, "canceled", *nodes[0].Error())
+		}
+		assert.Nil(t, (&codeIntelSlowQueryResolver{q: r.queries[1]}).Operation())
+	})
+}
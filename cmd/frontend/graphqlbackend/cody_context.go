@@ -15,6 +15,15 @@ type GetContextArgs struct {
 	Query            string
 	CodeResultsCount int32
 	TextResultsCount int32
+	Cursor           *CodyContextCursorInput
+}
+
+type CodyContextCursorInput struct {
+	Repo      graphql.ID
+	Revision  *string
+	Path      string
+	Line      int32
+	Character int32
 }
 
 type ContextResultResolver interface {
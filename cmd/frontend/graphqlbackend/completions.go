@@ -1,9 +1,33 @@
 package graphqlbackend
 
-import "context"
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
 
 type CompletionsResolver interface {
 	Completions(ctx context.Context, args CompletionsArgs) (string, error)
+	CodyTokenUsage(ctx context.Context, args CodyTokenUsageArgs) ([]CodyTokenUsageResolver, error)
+}
+
+type CodyTokenUsageArgs struct {
+	From  *gqlutil.DateTime
+	To    *gqlutil.DateTime
+	User  *graphql.ID
+	First int32
+}
+
+type CodyTokenUsageResolver interface {
+	User(ctx context.Context) (*UserResolver, error)
+	Feature() string
+	Model() string
+	PromptTokens() BigInt
+	CompletionTokens() BigInt
+	TotalTokens() BigInt
+	RequestCount() int32
 }
 
 type CompletionsArgs struct {
@@ -18,6 +18,7 @@ type EmbeddingsResolver interface {
 
 	ScheduleRepositoriesForEmbedding(ctx context.Context, args ScheduleRepositoriesForEmbeddingArgs) (*EmptyResponse, error)
 	CancelRepoEmbeddingJob(ctx context.Context, args CancelRepoEmbeddingJobArgs) (*EmptyResponse, error)
+	SetRepoEmbeddingAdmissionOverride(ctx context.Context, args SetRepoEmbeddingAdmissionOverrideArgs) (*EmptyResponse, error)
 }
 
 type ScheduleRepositoriesForEmbeddingArgs struct {
@@ -68,6 +69,11 @@ type CancelRepoEmbeddingJobArgs struct {
 	Job graphql.ID
 }
 
+type SetRepoEmbeddingAdmissionOverrideArgs struct {
+	Repo   graphql.ID
+	Exempt bool
+}
+
 type RepoEmbeddingJobResolver interface {
 	ID() graphql.ID
 	State() string
@@ -0,0 +1,19 @@
+package graphqlbackend
+
+import (
+	"context"
+)
+
+type ExecutorJobArtifactsResolver interface {
+	ExecutorJobArtifacts(ctx context.Context, args *ExecutorJobArtifactsArgs) ([]ExecutorJobArtifactResolver, error)
+}
+
+type ExecutorJobArtifactsArgs struct {
+	Queue string
+	Job   int32
+}
+
+type ExecutorJobArtifactResolver interface {
+	Name() string
+	URL() (string, error)
+}
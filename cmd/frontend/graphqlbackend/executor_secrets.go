@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	apiclient "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -291,12 +292,18 @@ func checkNamespaceAccess(ctx context.Context, db database.DB, namespaceUserID,
 	return auth.CheckCurrentUserIsSiteAdmin(ctx, db)
 }
 
-// validateExecutorSecret validates that the secret value is non-empty and if the
-// secret key is DOCKER_AUTH_CONFIG that the value is acceptable.
+// validateExecutorSecret validates that the secret value is non-empty, that only
+// global secrets reference an external secret store, and if the secret key is
+// DOCKER_AUTH_CONFIG that the value is acceptable.
 func validateExecutorSecret(secret *database.ExecutorSecret, value string) error {
 	if len(value) == 0 {
 		return errors.New("value cannot be empty string")
 	}
+	// 🚨 SECURITY: Executors resolve references with their own credentials, so a
+	// user or organization must not be able to point them at arbitrary secrets.
+	if (secret.NamespaceUserID != 0 || secret.NamespaceOrgID != 0) && apiclient.IsSecretReference(value) {
+		return apiclient.ErrNamespacedSecretReference
+	}
 	// Validate a docker auth config is correctly formatted before storing it to avoid
 	// confusion and broken config.
 	if secret.Key == "DOCKER_AUTH_CONFIG" {
@@ -772,10 +772,12 @@ type executorSecretsIntegrationTestResponseAccessLogUser struct {
 
 func TestValidateExecutorSecret(t *testing.T) {
 	tts := []struct {
-		name    string
-		key     string
-		value   string
-		wantErr string
+		name            string
+		key             string
+		value           string
+		namespaceUserID int32
+		namespaceOrgID  int32
+		wantErr         string
 	}{
 		{
 			name:    "empty value",
@@ -824,10 +826,34 @@ func TestValidateExecutorSecret(t *testing.T) {
 			value:   `{"auths": { "hub.docker.com": { "auth": "dXNlcm5hbWU6cGFzc3dvcmQ=" }}}`, // content: base64(username:password)
 			wantErr: "",
 		},
+		{
+			name:    "global external secret reference",
+			key:     "GH_TOKEN",
+			value:   "vault://secret/data/ci#token",
+			wantErr: "",
+		},
+		{
+			name:            "user external secret reference",
+			key:             "GH_TOKEN",
+			value:           "vault://secret/data/ci#token",
+			namespaceUserID: 1,
+			wantErr:         "only global executor secrets can reference an external secret store",
+		},
+		{
+			name:           "org external secret reference",
+			key:            "GH_TOKEN",
+			value:          "awssm://prod/db",
+			namespaceOrgID: 1,
+			wantErr:        "only global executor secrets can reference an external secret store",
+		},
 	}
 	for _, tt := range tts {
 		t.Run(tt.name, func(t *testing.T) {
-			have := validateExecutorSecret(&database.ExecutorSecret{Key: tt.key}, tt.value)
+			have := validateExecutorSecret(&database.ExecutorSecret{
+				Key:             tt.key,
+				NamespaceUserID: tt.namespaceUserID,
+				NamespaceOrgID:  tt.namespaceOrgID,
+			}, tt.value)
 			if have == nil && tt.wantErr == "" {
 				return
 			}
@@ -2,6 +2,9 @@ package graphqlbackend
 
 import (
 	"context"
+	"net/url"
+	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
@@ -142,6 +146,90 @@ func (r *externalServiceResolver) RateLimiterState(ctx context.Context) (*rateLi
 	return &rateLimiterStateResolver{state: state}, nil
 }
 
+func (r *externalServiceResolver) RateLimitMonitors(ctx context.Context) ([]*rateLimitMonitorResolver, error) {
+	baseURLs, err := r.codeHostAPIBaseURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolvers []*rateLimitMonitorResolver
+	for _, u := range baseURLs {
+		states, err := ratelimit.GetMonitorStates(u)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting rate limit monitor states")
+		}
+		for _, state := range states {
+			resolvers = append(resolvers, &rateLimitMonitorResolver{state: state})
+		}
+	}
+	sort.Slice(resolvers, func(i, j int) bool {
+		a, b := resolvers[i].state, resolvers[j].state
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return a.AuthHash < b.AuthHash
+	})
+	return resolvers, nil
+}
+
+type rateLimitThrottleEventsArgs struct {
+	First int32
+}
+
+func (r *externalServiceResolver) RateLimitThrottleEvents(ctx context.Context, args *rateLimitThrottleEventsArgs) ([]*rateLimitThrottleEventResolver, error) {
+	baseURLs, err := r.codeHostAPIBaseURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(baseURLs) == 0 {
+		return []*rateLimitThrottleEventResolver{}, nil
+	}
+
+	events, err := ratelimit.GetThrottleEvents(ctx, int(args.First), baseURLs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting rate limit throttle events")
+	}
+	resolvers := make([]*rateLimitThrottleEventResolver, 0, len(events))
+	for _, event := range events {
+		resolvers = append(resolvers, &rateLimitThrottleEventResolver{event: event})
+	}
+	return resolvers, nil
+}
+
+// codeHostAPIBaseURLs returns the base URLs of the code host APIs the clients
+// for this external service talk to, as used to register their rate limit
+// monitors.
+func (r *externalServiceResolver) codeHostAPIBaseURLs(ctx context.Context) ([]string, error) {
+	parsed, err := extsvc.ParseEncryptableConfig(ctx, r.externalService.Kind, r.externalService.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing external service config")
+	}
+
+	switch c := parsed.(type) {
+	case *schema.GitHubConnection:
+		u, err := url.Parse(c.Url)
+		if err != nil {
+			return nil, err
+		}
+		apiURL, _ := github.APIRoot(u)
+		return []string{apiURL.String()}, nil
+	case *schema.GitLabConnection:
+		u, err := url.Parse(c.Url)
+		if err != nil {
+			return nil, err
+		}
+		return []string{u.ResolveReference(&url.URL{Path: path.Join(u.Path, "api/v4") + "/"}).String()}, nil
+	case *schema.AzureDevOpsConnection:
+		return []string{c.Url}, nil
+	default:
+		// Other code hosts don't report rate limits through response headers.
+		return nil, nil
+	}
+}
+
 func (r *externalServiceResolver) Config(ctx context.Context) (JSONCString, error) {
 	redacted, err := r.externalService.RedactedConfig(ctx)
 	if err != nil {
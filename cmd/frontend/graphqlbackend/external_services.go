@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -273,9 +274,12 @@ func (r *schemaResolver) DeleteExternalService(ctx context.Context, args *delete
 	}
 
 	if args.Async {
-		// run deletion in the background and return right away
+		// run deletion in the background and return right away, keeping the
+		// actor so that the deletion is attributed in the audit log.
+		bgCtx := actor.WithActor(context.Background(), actor.FromContext(ctx))
+		bgCtx = requestclient.WithClient(bgCtx, requestclient.FromContext(ctx))
 		go func() {
-			if err := r.db.ExternalServices().Delete(context.Background(), id); err != nil {
+			if err := r.db.ExternalServices().Delete(bgCtx, id); err != nil {
 				r.logger.Error("Background external service deletion failed", log.Error(err))
 			}
 		}()
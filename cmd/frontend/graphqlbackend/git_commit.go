@@ -18,9 +18,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/externallink"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/pathindex"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -315,6 +318,60 @@ func (r *GitCommitResolver) FileNames(ctx context.Context) ([]string, error) {
 	return r.gitserverClient.LsFiles(ctx, r.gitRepo, api.CommitID(r.oid))
 }
 
+var fuzzyFileNamesCacheSize = env.MustGetBytes("FUZZY_FILE_NAMES_CACHE_SIZE", "512MB", "The maximum size of the in-memory path indexes of the fuzzy file finder.")
+
+// fuzzyPathIndexes returns the cache of the path indexes that answer fuzzy
+// file name queries.
+var fuzzyPathIndexes = sync.OnceValue(func() *pathindex.Cache {
+	return pathindex.NewCache(
+		log.Scoped("pathindex"),
+		gitserver.NewClient("graphql.fuzzyfilenames"),
+		int64(fuzzyFileNamesCacheSize),
+	)
+})
+
+// maxFuzzyFileNames is the maximum number of paths returned by FuzzyFileNames.
+const maxFuzzyFileNames = 1000
+
+type fuzzyFileNamesArgs struct {
+	Query string
+	First int32
+}
+
+func (r *GitCommitResolver) FuzzyFileNames(ctx context.Context, args *fuzzyFileNamesArgs) ([]string, error) {
+	if args.First < 0 || args.First > maxFuzzyFileNames {
+		return nil, errors.Errorf("first must be between 0 and %d", maxFuzzyFileNames)
+	}
+
+	// 🚨 SECURITY: The cached indexes contain all paths of a repository, so
+	// repositories with sub-repository permissions are indexed for each request
+	// from the paths that the actor can read.
+	var ix *pathindex.Index
+	subRepoEnabled, err := authz.SubRepoEnabledForRepo(ctx, authz.DefaultSubRepoPermsChecker, r.gitRepo)
+	if err != nil {
+		return nil, err
+	}
+	if subRepoEnabled {
+		paths, err := r.gitserverClient.LsFiles(ctx, r.gitRepo, api.CommitID(r.oid))
+		if err != nil {
+			return nil, err
+		}
+		ix = pathindex.New(paths)
+	} else {
+		ix, err = fuzzyPathIndexes().Get(ctx, r.gitRepo, api.CommitID(r.oid))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matches := ix.Search(args.Query, int(args.First))
+	paths := make([]string, len(matches))
+	for i, m := range matches {
+		paths[i] = m.Path
+	}
+	return paths, nil
+}
+
 func (r *GitCommitResolver) Languages(ctx context.Context) ([]string, error) {
 	repo, err := r.repoResolver.repo(ctx)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -20,7 +21,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/pathindex"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
 func TestGitCommitResolver(t *testing.T) {
@@ -319,6 +322,57 @@ func TestGitCommitFileNames(t *testing.T) {
 	})
 }
 
+func TestGitCommitFuzzyFileNames(t *testing.T) {
+	externalServices := dbmocks.NewMockExternalServiceStore()
+	externalServices.ListFunc.SetDefaultReturn(nil, nil)
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: 2, Name: "github.com/gorilla/mux"}, nil)
+
+	db := dbmocks.NewMockDB()
+	db.ExternalServicesFunc.SetDefaultReturn(externalServices)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return exampleCommitSHA1, nil
+	}
+	backend.Mocks.Repos.MockGetCommit_Return_NoCheck(t, &gitdomain.Commit{ID: exampleCommitSHA1})
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.LsFilesFunc.SetDefaultReturn([]string{"mux.go", "route.go", "regexp.go", "doc/routes.md"}, nil)
+	defer func() {
+		backend.Mocks = backend.MockServices{}
+	}()
+
+	cache := pathindex.NewCache(logtest.Scoped(t), gitserverClient, 1<<20)
+	orig := fuzzyPathIndexes
+	fuzzyPathIndexes = func() *pathindex.Cache { return cache }
+	t.Cleanup(func() { fuzzyPathIndexes = orig })
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchemaWithClient(t, db, gitserverClient),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						commit(rev: "` + exampleCommitSHA1 + `") {
+							fuzzyFileNames(query: "route", first: 2)
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+{
+  "repository": {
+    "commit": {
+		"fuzzyFileNames": ["route.go", "doc/routes.md"]
+    }
+  }
+}
+			`,
+		},
+	})
+}
+
 func TestGitCommitAncestors(t *testing.T) {
 	repos := dbmocks.NewMockRepoStore()
 	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: 2, Name: "github.com/gorilla/mux"}, nil)
@@ -651,6 +705,167 @@ func TestGitCommitAncestors(t *testing.T) {
 	})
 }
 
+func TestGitCommitAncestors_KeysetCursor(t *testing.T) {
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: 2, Name: "github.com/gorilla/mux"}, nil)
+
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return api.CommitID(rev), nil
+	}
+	defer func() {
+		backend.Mocks = backend.MockServices{}
+	}()
+
+	// A linear commit tree, newest first:
+	// c5 (HEAD) -> c4 -> c3 -> c2 -> c1
+	commits := []*gitdomain.Commit{
+		{ID: "c5", Parents: []api.CommitID{"c4"}},
+		{ID: "c4", Parents: []api.CommitID{"c3"}},
+		{ID: "c3", Parents: []api.CommitID{"c2"}},
+		{ID: "c2", Parents: []api.CommitID{"c1"}},
+		{ID: "c1"},
+	}
+
+	client := gitserver.NewMockClient()
+	client.CommitsFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, opt gitserver.CommitsOptions) ([]*gitdomain.Commit, error) {
+		// The second page must continue the walk from the end of the first one instead of
+		// skipping commits.
+		assert.Zero(t, opt.Skip)
+		for i, commit := range commits {
+			if string(commit.ID) == opt.Range {
+				return commits[i:min(i+int(opt.N), len(commits))], nil
+			}
+		}
+		t.Fatalf("unexpected range %q", opt.Range)
+		return nil, nil
+	})
+
+	query := func(after string) string {
+		return `
+			{
+			  repository(name: "github.com/gorilla/mux") {
+				commit(rev: "c5") {
+				  ancestors(first: 2, afterCursor: "` + after + `") {
+					nodes {
+					  oid
+					}
+					pageInfo {
+					  endCursor
+					  hasNextPage
+					}
+				  }
+				}
+			  }
+			}`
+	}
+
+	endCursor := (&gitCommitCursor{Offset: 2, Frontier: []api.CommitID{"c3"}}).marshal()
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchemaWithClient(t, db, client),
+			Query:  query(""),
+			ExpectedResult: `
+				{
+				  "repository": {
+					"commit": {
+					  "ancestors": {
+						"nodes": [{"oid": "c5"}, {"oid": "c4"}],
+						"pageInfo": {
+						  "endCursor": "` + endCursor + `",
+						  "hasNextPage": true
+						}
+					  }
+					}
+				  }
+				}`,
+		},
+		{
+			Schema: mustParseGraphQLSchemaWithClient(t, db, client),
+			Query:  query(endCursor),
+			ExpectedResult: `
+				{
+				  "repository": {
+					"commit": {
+					  "ancestors": {
+						"nodes": [{"oid": "c3"}, {"oid": "c2"}],
+						"pageInfo": {
+						  "endCursor": "` + (&gitCommitCursor{Offset: 4, Frontier: []api.CommitID{"c1"}}).marshal() + `",
+						  "hasNextPage": true
+						}
+					  }
+					}
+				  }
+				}`,
+		},
+	})
+}
+
+func TestGitCommitConnectionResolver_NextFrontier(t *testing.T) {
+	// A merge of a feature branch, newest first:
+	// m -> (main -> base, feature -> base) -> root
+	m := &gitdomain.Commit{ID: "m", Parents: []api.CommitID{"main", "feature"}}
+	main := &gitdomain.Commit{ID: "main", Parents: []api.CommitID{"base"}}
+	feature := &gitdomain.Commit{ID: "feature", Parents: []api.CommitID{"base"}}
+	base := &gitdomain.Commit{ID: "base", Parents: []api.CommitID{"root"}}
+
+	for name, tc := range map[string]struct {
+		resolver *gitCommitConnectionResolver
+		cursor   *gitCommitCursor
+		page     []*gitdomain.Commit
+		want     []api.CommitID
+		wantOK   bool
+	}{
+		"first page": {
+			resolver: &gitCommitConnectionResolver{revisionRange: "m"},
+			cursor:   &gitCommitCursor{},
+			page:     []*gitdomain.Commit{m, main},
+			want:     []api.CommitID{"base", "feature"},
+			wantOK:   true,
+		},
+		"next page": {
+			resolver: &gitCommitConnectionResolver{revisionRange: "m"},
+			cursor:   &gitCommitCursor{Offset: 2, Frontier: []api.CommitID{"base", "feature"}},
+			page:     []*gitdomain.Commit{feature, base},
+			want:     []api.CommitID{"root"},
+			wantOK:   true,
+		},
+		"commits left out of the page": {
+			resolver: &gitCommitConnectionResolver{revisionRange: "m"},
+			cursor:   &gitCommitCursor{},
+			page:     []*gitdomain.Commit{m, base},
+			wantOK:   false,
+		},
+		"page listed by offset": {
+			resolver: &gitCommitConnectionResolver{revisionRange: "m"},
+			cursor:   &gitCommitCursor{Offset: 2},
+			page:     []*gitdomain.Commit{feature, base},
+			wantOK:   false,
+		},
+		"path": {
+			resolver: &gitCommitConnectionResolver{revisionRange: "m", path: pointers.Ptr("README.md")},
+			cursor:   &gitCommitCursor{},
+			page:     []*gitdomain.Commit{m, main},
+			wantOK:   false,
+		},
+		"symmetric difference": {
+			resolver: &gitCommitConnectionResolver{revisionRange: "main...feature"},
+			cursor:   &gitCommitCursor{},
+			page:     []*gitdomain.Commit{main},
+			wantOK:   false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, ok := tc.resolver.nextFrontier(tc.cursor, tc.page)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
 func TestGitCommitPerforceChangelist(t *testing.T) {
 	repos := dbmocks.NewMockRepoStore()
 
@@ -2,10 +2,16 @@ package graphqlbackend
 
 import (
 	"context"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -39,15 +45,125 @@ type gitCommitConnectionResolver struct {
 	err     error
 }
 
-// afterCursorAsInt will parse the afterCursor field and return it as an int. If no value is set, it
-// will return 0. It returns a non-nil error if there are any errors in parsing the input string.
-func (r *gitCommitConnectionResolver) afterCursorAsInt() (int, error) {
+const gitCommitCursorKind = "GitCommitCursor"
+
+// maxGitCommitCursorFrontier is the maximum number of commits stored in a cursor. Pages ending in
+// a part of the history with more branches in flight use an offset cursor instead.
+const maxGitCommitCursorFrontier = 32
+
+// gitCommitCursor is the position after a page of commits.
+//
+// git log walks the history by repeatedly picking the most recent commit from a queue, which
+// starts out with the tips of the revision range and receives the parents of every commit
+// returned. Frontier is the content of that queue after the page, so the next page can be
+// listed by starting a new walk from it. Unlike skipping Offset commits, this costs the same no
+// matter how deep the page is.
+//
+// Frontier is nil if the queue is not known, for example because the history is simplified to
+// the commits touching a path, in which case the next page skips Offset commits.
+type gitCommitCursor struct {
+	Offset   int            `json:"offset"`
+	Frontier []api.CommitID `json:"frontier,omitempty"`
+}
+
+// marshal returns the opaque cursor string. Offset cursors are plain numbers, which is what all
+// cursors used to be.
+func (c *gitCommitCursor) marshal() string {
+	if c.Frontier == nil {
+		return strconv.Itoa(c.Offset)
+	}
+	return string(relay.MarshalID(gitCommitCursorKind, c))
+}
+
+// parseAfterCursor parses the afterCursor field. If no value is set, it returns a cursor at the
+// start of the history.
+func (r *gitCommitConnectionResolver) parseAfterCursor() (*gitCommitCursor, error) {
 	v := pointers.DerefZero(r.afterCursor)
 	if v == "" {
-		return 0, nil
+		return &gitCommitCursor{}, nil
+	}
+
+	offset, err := strconv.Atoi(v)
+	if err == nil {
+		return &gitCommitCursor{Offset: offset}, nil
 	}
+	if relay.UnmarshalKind(graphql.ID(v)) != gitCommitCursorKind {
+		return nil, err
+	}
+
+	var cursor gitCommitCursor
+	if err := relay.UnmarshalSpec(graphql.ID(v), &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
 
-	return strconv.Atoi(v)
+// keysetBase returns the revision excluded by the revision range, if any. ok is false if the walk
+// can't be continued from a cursor frontier, because the range has several tips or because
+// the history is simplified to the commits touching a path.
+func (r *gitCommitConnectionResolver) keysetBase() (base string, ok bool) {
+	if r.path != nil || r.follow {
+		return "", false
+	}
+	if strings.HasPrefix(r.revisionRange, "^") || strings.Contains(r.revisionRange, "...") {
+		return "", false
+	}
+	if base, _, found := strings.Cut(r.revisionRange, ".."); found {
+		if base == "" {
+			base = "HEAD"
+		}
+		return base, true
+	}
+	return "", true
+}
+
+// nextFrontier returns the frontier of the walk after the given page of commits, which was
+// listed starting from the given cursor. ok is false if the frontier is not known.
+func (r *gitCommitConnectionResolver) nextFrontier(cursor *gitCommitCursor, page []*gitdomain.Commit) (_ []api.CommitID, ok bool) {
+	if _, ok := r.keysetBase(); !ok {
+		return nil, false
+	}
+	if len(page) == 0 {
+		return cursor.Frontier, cursor.Frontier != nil
+	}
+	if cursor.Frontier == nil && cursor.Offset > 0 {
+		// The page was listed by skipping commits, so we don't know which commits were queued
+		// when it started.
+		return nil, false
+	}
+
+	pending := make(map[api.CommitID]struct{}, len(cursor.Frontier))
+	for _, id := range cursor.Frontier {
+		pending[id] = struct{}{}
+	}
+	returned := make(map[api.CommitID]struct{}, len(page))
+	for i, commit := range page {
+		if _, ok := pending[commit.ID]; ok {
+			delete(pending, commit.ID)
+		} else if i > 0 || cursor.Frontier != nil {
+			// Only the tip of the revision range is returned without being queued by a
+			// previous commit. Anything else means that commits were left out of the page,
+			// e.g. by a message query, and the parents they queued are unknown.
+			return nil, false
+		}
+
+		returned[commit.ID] = struct{}{}
+		for _, parent := range commit.Parents {
+			if _, ok := returned[parent]; !ok {
+				pending[parent] = struct{}{}
+			}
+		}
+	}
+
+	if len(pending) > maxGitCommitCursorFrontier {
+		return nil, false
+	}
+	frontier := make([]api.CommitID, 0, len(pending))
+	for id := range pending {
+		frontier = append(frontier, id)
+	}
+	slices.Sort(frontier)
+	return frontier, true
 }
 
 func (r *gitCommitConnectionResolver) compute(ctx context.Context) ([]*gitdomain.Commit, error) {
@@ -71,22 +187,36 @@ func (r *gitCommitConnectionResolver) compute(ctx context.Context) ([]*gitdomain
 
 		// If no value for afterCursor is set, then skip is 0. And this is fine as --skip=0 is the
 		// same as not setting the flag.
-		afterCursor, err := r.afterCursorAsInt()
+		afterCursor, err := r.parseAfterCursor()
 		if err != nil {
 			return []*gitdomain.Commit{}, errors.Wrap(err, "failed to parse afterCursor")
 		}
 
-		return r.gitserverClient.Commits(ctx, r.repo.RepoName(), gitserver.CommitsOptions{
+		opts := gitserver.CommitsOptions{
 			Range:        r.revisionRange,
 			N:            uint(n),
 			MessageQuery: pointers.DerefZero(r.query),
 			Author:       pointers.DerefZero(r.author),
 			After:        pointers.DerefZero(r.after),
-			Skip:         uint(afterCursor),
+			Skip:         uint(afterCursor.Offset),
 			Before:       pointers.DerefZero(r.before),
 			Path:         pointers.DerefZero(r.path),
 			Follow:       r.follow,
-		})
+		}
+		if base, ok := r.keysetBase(); ok && len(afterCursor.Frontier) > 0 {
+			// Continue the walk where the previous page ended.
+			opts.Range = string(afterCursor.Frontier[0])
+			opts.Ranges = nil
+			for _, id := range afterCursor.Frontier[1:] {
+				opts.Ranges = append(opts.Ranges, string(id))
+			}
+			if base != "" {
+				opts.Ranges = append(opts.Ranges, "^"+base)
+			}
+			opts.Skip = 0
+		}
+
+		return r.gitserverClient.Commits(ctx, r.repo.RepoName(), opts)
 	}
 
 	r.once.Do(func() { r.commits, r.err = do() })
@@ -164,13 +294,19 @@ func (r *gitCommitConnectionResolver) PageInfo(ctx context.Context) (*graphqluti
 		//
 		// Request 3: first: 50, afterCursor: 200 (endCursor from previous request)
 		// Response 3: commits: 201 to 250, endCursor: 250 (first + offset)
-		after, err := r.afterCursorAsInt()
+		//
+		// If possible, the cursor also records where to continue the walk, so that request 3
+		// doesn't have to walk past the first 200 commits again.
+		after, err := r.parseAfterCursor()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to parse afterCursor")
 		}
 
-		endCursor := limit + after
-		return graphqlutil.NextPageCursor(strconv.Itoa(endCursor)), nil
+		endCursor := gitCommitCursor{Offset: limit + after.Offset}
+		if frontier, ok := r.nextFrontier(after, commits[:min(limit, totalCommits)]); ok {
+			endCursor.Frontier = frontier
+		}
+		return graphqlutil.NextPageCursor(endCursor.marshal()), nil
 	}
 
 	return graphqlutil.HasNextPage(false), nil
@@ -439,6 +439,7 @@ func NewSchema(
 	schemas := []string{
 		mainSchema,
 		outboundWebhooksSchema,
+		repoViewsSchema,
 	}
 
 	for _, optional := range optionals {
@@ -626,6 +627,22 @@ func NewSchema(
 			}
 		}
 
+		if executorJobArtifactsResolver := optional.ExecutorJobArtifactsResolver; executorJobArtifactsResolver != nil {
+			EnterpriseResolvers.executorJobArtifactsResolver = executorJobArtifactsResolver
+			resolver.ExecutorJobArtifactsResolver = executorJobArtifactsResolver
+			schemas = append(schemas, executorJobArtifactsSchema)
+		}
+
+		if repositorySnapshotsResolver := optional.RepositorySnapshotsResolver; repositorySnapshotsResolver != nil {
+			EnterpriseResolvers.repositorySnapshotsResolver = repositorySnapshotsResolver
+			resolver.RepositorySnapshotsResolver = repositorySnapshotsResolver
+			schemas = append(schemas, repositorySnapshotsSchema)
+			// Register NodeByID handlers.
+			for kind, res := range repositorySnapshotsResolver.NodeResolvers() {
+				resolver.nodeByIDFns[kind] = res
+			}
+		}
+
 		if telemetryResolver := optional.TelemetryRootResolver; telemetryResolver != nil {
 			EnterpriseResolvers.telemetryResolver = telemetryResolver
 			resolver.TelemetryRootResolver = telemetryResolver
@@ -673,6 +690,7 @@ type OptionalResolver struct {
 	CodyContextResolver
 	DotcomRootResolver
 	EmbeddingsResolver
+	ExecutorJobArtifactsResolver
 	SearchJobsResolver
 	GitHubAppsResolver
 	GuardrailsResolver
@@ -682,6 +700,7 @@ type OptionalResolver struct {
 	NotebooksResolver
 	OwnResolver
 	RBACResolver
+	RepositorySnapshotsResolver
 	SearchContextsResolver
 	WebhooksResolver
 	ContentLibraryResolver
@@ -765,6 +784,9 @@ func newSchemaResolver(db database.DB, gitserverClient gitserver.Client) *schema
 		outboundWebhookIDKind: func(ctx context.Context, id graphql.ID) (Node, error) {
 			return OutboundWebhookByID(ctx, db, id)
 		},
+		repoViewIDKind: func(ctx context.Context, id graphql.ID) (Node, error) {
+			return r.repoViewByID(ctx, id)
+		},
 		roleIDKind: func(ctx context.Context, id graphql.ID) (Node, error) {
 			return r.roleByID(ctx, id)
 		},
@@ -784,28 +806,30 @@ func newSchemaResolver(db database.DB, gitserverClient gitserver.Client) *schema
 // EnterpriseResolvers holds the instances of resolvers which are enabled only
 // in enterprise mode. These resolver instances are nil when running as OSS.
 var EnterpriseResolvers = struct {
-	authzResolver               AuthzResolver
-	batchChangesResolver        BatchChangesResolver
-	codeIntelResolver           CodeIntelResolver
-	codeMonitorsResolver        CodeMonitorsResolver
-	completionsResolver         CompletionsResolver
-	computeResolver             ComputeResolver
-	contextResolver             CodyContextResolver
-	dotcomResolver              DotcomRootResolver
-	embeddingsResolver          EmbeddingsResolver
-	searchJobsResolver          SearchJobsResolver
-	gitHubAppsResolver          GitHubAppsResolver
-	guardrailsResolver          GuardrailsResolver
-	insightsAggregationResolver InsightsAggregationResolver
-	insightsResolver            InsightsResolver
-	licenseResolver             LicenseResolver
-	notebooksResolver           NotebooksResolver
-	ownResolver                 OwnResolver
-	rbacResolver                RBACResolver
-	searchContextsResolver      SearchContextsResolver
-	webhooksResolver            WebhooksResolver
-	contentLibraryResolver      ContentLibraryResolver
-	telemetryResolver           *TelemetryRootResolver
+	authzResolver                AuthzResolver
+	batchChangesResolver         BatchChangesResolver
+	codeIntelResolver            CodeIntelResolver
+	codeMonitorsResolver         CodeMonitorsResolver
+	completionsResolver          CompletionsResolver
+	computeResolver              ComputeResolver
+	contextResolver              CodyContextResolver
+	dotcomResolver               DotcomRootResolver
+	embeddingsResolver           EmbeddingsResolver
+	executorJobArtifactsResolver ExecutorJobArtifactsResolver
+	searchJobsResolver           SearchJobsResolver
+	gitHubAppsResolver           GitHubAppsResolver
+	guardrailsResolver           GuardrailsResolver
+	insightsAggregationResolver  InsightsAggregationResolver
+	insightsResolver             InsightsResolver
+	licenseResolver              LicenseResolver
+	notebooksResolver            NotebooksResolver
+	ownResolver                  OwnResolver
+	rbacResolver                 RBACResolver
+	repositorySnapshotsResolver  RepositorySnapshotsResolver
+	searchContextsResolver       SearchContextsResolver
+	webhooksResolver             WebhooksResolver
+	contentLibraryResolver       ContentLibraryResolver
+	telemetryResolver            *TelemetryRootResolver
 }{}
 
 // Root returns a new schemaResolver.
@@ -342,6 +342,21 @@ func (r *NodeResolver) ToOutboundWebhook() (OutboundWebhookResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToRepoView() (*repoViewResolver, bool) {
+	n, ok := r.Node.(*repoViewResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToRepositorySnapshot() (RepositorySnapshotResolver, bool) {
+	n, ok := r.Node.(RepositorySnapshotResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToRepositorySnapshotSchedule() (RepositorySnapshotScheduleResolver, bool) {
+	n, ok := r.Node.(RepositorySnapshotScheduleResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToTeam() (*TeamResolver, bool) {
 	n, ok := r.Node.(*TeamResolver)
 	return n, ok
@@ -27,6 +27,8 @@ type NotebooksResolver interface {
 	CreateNotebookStar(ctx context.Context, args CreateNotebookStarInputArgs) (NotebookStarResolver, error)
 	DeleteNotebookStar(ctx context.Context, args DeleteNotebookStarInputArgs) (*EmptyResponse, error)
 
+	ImportJupyterNotebook(ctx context.Context, args ImportJupyterNotebookArgs) (NotebookResolver, error)
+
 	NodeResolvers() map[string]NodeByIDFunc
 }
 
@@ -60,6 +62,7 @@ type NotebookResolver interface {
 	ViewerCanManage(ctx context.Context) (bool, error)
 	ViewerHasStarred(ctx context.Context) (bool, error)
 	Stars(ctx context.Context, args ListNotebookStarsArgs) (NotebookStarConnectionResolver, error)
+	JupyterNotebook(ctx context.Context) (string, error)
 }
 
 type NotebookBlockResolver interface {
@@ -194,3 +197,14 @@ type CreateNotebookStarInputArgs struct {
 type DeleteNotebookStarInputArgs struct {
 	NotebookID graphql.ID
 }
+
+type ImportJupyterNotebookArgs struct {
+	Notebook ImportJupyterNotebookInputArgs `json:"notebook"`
+}
+
+type ImportJupyterNotebookInputArgs struct {
+	Content   string     `json:"content"`
+	Title     *string    `json:"title"`
+	Namespace graphql.ID `json:"namespace"`
+	Public    bool       `json:"public"`
+}
@@ -2,6 +2,7 @@ package graphqlbackend
 
 import (
 	"context"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -73,6 +74,34 @@ func (r *schemaResolver) SetMigrationDirection(ctx context.Context, args *struct
 	return &EmptyResponse{}, nil
 }
 
+// PauseOutOfBandMigration pauses an out-of-band migration by identifier.
+func (r *schemaResolver) PauseOutOfBandMigration(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error) {
+	return r.updateOutOfBandMigrationPaused(ctx, args.ID, true)
+}
+
+// ResumeOutOfBandMigration resumes a paused out-of-band migration by identifier.
+func (r *schemaResolver) ResumeOutOfBandMigration(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error) {
+	return r.updateOutOfBandMigrationPaused(ctx, args.ID, false)
+}
+
+func (r *schemaResolver) updateOutOfBandMigrationPaused(ctx context.Context, id graphql.ID, paused bool) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may modify out-of-band migrations
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	migrationID, err := UnmarshalOutOfBandMigrationID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := oobmigration.NewStoreWithDB(r.db).UpdatePaused(ctx, int(migrationID), paused); err != nil {
+		return nil, err
+	}
+
+	return &EmptyResponse{}, nil
+}
+
 // MarshalOutOfBandMigrationID converts an internal out of band migration id into a GraphQL id.
 func MarshalOutOfBandMigrationID(id int32) graphql.ID {
 	return relay.MarshalID("OutOfBandMigration", id)
@@ -114,6 +143,11 @@ func (r *outOfBandMigrationResolver) LastUpdated() *gqlutil.DateTime {
 }
 func (r *outOfBandMigrationResolver) NonDestructive() bool { return r.m.NonDestructive }
 func (r *outOfBandMigrationResolver) ApplyReverse() bool   { return r.m.ApplyReverse }
+func (r *outOfBandMigrationResolver) Paused() bool         { return r.m.Paused }
+
+func (r *outOfBandMigrationResolver) ProgressDetails() *outOfBandMigrationProgressResolver {
+	return &outOfBandMigrationProgressResolver{m: r.m, now: time.Now()}
+}
 
 func (r *outOfBandMigrationResolver) Errors() []*outOfBandMigrationErrorResolver {
 	resolvers := make([]*outOfBandMigrationErrorResolver, 0, len(r.m.Errors))
@@ -124,6 +158,24 @@ func (r *outOfBandMigrationResolver) Errors() []*outOfBandMigrationErrorResolver
 	return resolvers
 }
 
+// outOfBandMigrationProgressResolver implements the GraphQL type OutOfBandMigrationProgress.
+type outOfBandMigrationProgressResolver struct {
+	m   oobmigration.Migration
+	now time.Time
+}
+
+func (r *outOfBandMigrationProgressResolver) RowsProcessed() BigInt { return BigInt(r.m.RowsProcessed) }
+func (r *outOfBandMigrationProgressResolver) Rate() *float64        { return r.m.ProgressRate }
+
+func (r *outOfBandMigrationProgressResolver) EstimatedCompletion() *gqlutil.DateTime {
+	remaining, ok := r.m.EstimatedTimeRemaining()
+	if !ok {
+		return nil
+	}
+
+	return &gqlutil.DateTime{Time: r.now.Add(remaining)}
+}
+
 // outOfBandMigrationErrorResolver implements the GraphQL type OutOfBandMigrationError.
 type outOfBandMigrationErrorResolver struct {
 	e oobmigration.MigrationError
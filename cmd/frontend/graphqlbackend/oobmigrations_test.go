@@ -0,0 +1,59 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestPauseOutOfBandMigration(t *testing.T) {
+	db := dbmocks.NewMockDB()
+	userStore := dbmocks.NewMockUserStore()
+	userStore.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: false}, nil)
+	db.UsersFunc.SetDefaultReturn(userStore)
+
+	for _, mutation := range []string{"pauseOutOfBandMigration", "resumeOutOfBandMigration"} {
+		t.Run(mutation, func(t *testing.T) {
+			RunTest(t, &Test{
+				Schema:         mustParseGraphQLSchema(t, db),
+				Context:        actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+				Query:          `mutation { ` + mutation + `(id: "T3V0T2ZCYW5kTWlncmF0aW9uOjE=") { alwaysNil } }`,
+				ExpectedResult: `null`,
+				ExpectedErrors: []*gqlerrors.QueryError{
+					{
+						Path:          []any{mutation},
+						Message:       auth.ErrMustBeSiteAdmin.Error(),
+						ResolverError: auth.ErrMustBeSiteAdmin,
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestOutOfBandMigrationProgress(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	rate := 0.01
+
+	r := &outOfBandMigrationProgressResolver{
+		m:   oobmigration.Migration{Progress: 0.5, RowsProcessed: 1200, ProgressRate: &rate},
+		now: now,
+	}
+	assert.Equal(t, BigInt(1200), r.RowsProcessed())
+	assert.Equal(t, &rate, r.Rate())
+	if eta := r.EstimatedCompletion(); assert.NotNil(t, eta) {
+		assert.Equal(t, now.Add(50*time.Second), eta.Time)
+	}
+
+	r.m.Paused = true
+	assert.Nil(t, r.EstimatedCompletion())
+}
@@ -0,0 +1,249 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const outboundWebhookDeadLetterIDKind = "OutboundWebhookDeadLetter"
+
+type OutboundWebhookDeadLetterConnectionResolver interface {
+	Nodes() ([]OutboundWebhookDeadLetterResolver, error)
+	TotalCount() (int32, error)
+	PageInfo() (*graphqlutil.PageInfo, error)
+}
+
+type OutboundWebhookDeadLetterResolver interface {
+	ID() graphql.ID
+	OutboundWebhook(context.Context) OutboundWebhookResolver
+	EventType() string
+	Scope() *string
+	Payload(context.Context) (string, error)
+	Attempts() int32
+	LastStatusCode() int32
+	LastError(context.Context) (string, error)
+	CreatedAt() gqlutil.DateTime
+	ReplayedAt() *gqlutil.DateTime
+	ReplayJob(context.Context) (OutboundWebhookJobResolver, error)
+}
+
+type ListOutboundWebhookDeadLettersArgs struct {
+	First           int32       `json:"first"`
+	After           *string     `json:"after"`
+	OutboundWebhook *graphql.ID `json:"outboundWebhook"`
+	IncludeReplayed bool        `json:"includeReplayed"`
+}
+
+type ReplayOutboundWebhookDeadLetterArgs struct {
+	ID graphql.ID `json:"id"`
+}
+
+func (r *schemaResolver) OutboundWebhookDeadLetters(ctx context.Context, args ListOutboundWebhookDeadLettersArgs) (OutboundWebhookDeadLetterConnectionResolver, error) {
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts := database.OutboundWebhookDeadLetterListOpts{
+		LimitOffset: &database.LimitOffset{
+			Limit: int(args.First),
+		},
+		IncludeReplayed: args.IncludeReplayed,
+	}
+	if args.After != nil {
+		offset, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, errors.Newf("cannot parse offset %q", *args.After)
+		}
+		opts.Offset = offset
+	}
+	if args.OutboundWebhook != nil {
+		id, err := unmarshalOutboundWebhookID(*args.OutboundWebhook)
+		if err != nil {
+			return nil, err
+		}
+		opts.OutboundWebhookID = &id
+	}
+
+	return newOutboundWebhookDeadLetterConnectionResolver(ctx, outboundWebhookStore(r.db), opts), nil
+}
+
+func (r *schemaResolver) ReplayOutboundWebhookDeadLetter(ctx context.Context, args ReplayOutboundWebhookDeadLetterArgs) (OutboundWebhookDeadLetterResolver, error) {
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalOutboundWebhookDeadLetterID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	store := outboundWebhookStore(r.db)
+	letter, err := store.ToDeadLetterStore().Replay(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outboundWebhookDeadLetterResolver{store: store, letter: letter}, nil
+}
+
+func marshalOutboundWebhookDeadLetterID(id int64) graphql.ID {
+	return relay.MarshalID(outboundWebhookDeadLetterIDKind, id)
+}
+
+func unmarshalOutboundWebhookDeadLetterID(gql graphql.ID) (id int64, err error) {
+	if kind := relay.UnmarshalKind(gql); kind != outboundWebhookDeadLetterIDKind {
+		return 0, errors.Newf("invalid outbound webhook dead letter id of kind %q", kind)
+	}
+
+	err = relay.UnmarshalSpec(gql, &id)
+	return
+}
+
+type outboundWebhookDeadLetterConnectionResolver struct {
+	nodes      func() ([]*types.OutboundWebhookDeadLetter, error)
+	resolvers  func() ([]OutboundWebhookDeadLetterResolver, error)
+	totalCount func() (int32, error)
+	first      int
+	offset     int
+}
+
+func newOutboundWebhookDeadLetterConnectionResolver(
+	ctx context.Context, store database.OutboundWebhookStore,
+	opts database.OutboundWebhookDeadLetterListOpts,
+) OutboundWebhookDeadLetterConnectionResolver {
+	limit := opts.Limit
+	deadLetterStore := store.ToDeadLetterStore()
+
+	nodes := sync.OnceValues(func() ([]*types.OutboundWebhookDeadLetter, error) {
+		listOpts := opts
+		listOpts.LimitOffset = &database.LimitOffset{Limit: limit + 1, Offset: opts.Offset}
+		return deadLetterStore.List(ctx, listOpts)
+	})
+
+	return &outboundWebhookDeadLetterConnectionResolver{
+		nodes: nodes,
+		resolvers: sync.OnceValues(func() ([]OutboundWebhookDeadLetterResolver, error) {
+			letters, err := nodes()
+			if err != nil {
+				return nil, err
+			}
+
+			if len(letters) > limit {
+				letters = letters[0:limit]
+			}
+
+			resolvers := make([]OutboundWebhookDeadLetterResolver, len(letters))
+			for i := range letters {
+				resolvers[i] = &outboundWebhookDeadLetterResolver{
+					store:  store,
+					letter: letters[i],
+				}
+			}
+
+			return resolvers, nil
+		}),
+		totalCount: sync.OnceValues(func() (int32, error) {
+			countOpts := opts
+			countOpts.LimitOffset = nil
+			count, err := deadLetterStore.Count(ctx, countOpts)
+			return int32(count), err
+		}),
+		first:  limit,
+		offset: opts.Offset,
+	}
+}
+
+func (r *outboundWebhookDeadLetterConnectionResolver) Nodes() ([]OutboundWebhookDeadLetterResolver, error) {
+	return r.resolvers()
+}
+
+func (r *outboundWebhookDeadLetterConnectionResolver) TotalCount() (int32, error) {
+	return r.totalCount()
+}
+
+func (r *outboundWebhookDeadLetterConnectionResolver) PageInfo() (*graphqlutil.PageInfo, error) {
+	nodes, err := r.nodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nodes) > r.first {
+		return graphqlutil.NextPageCursor(strconv.Itoa(r.first + r.offset)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+type outboundWebhookDeadLetterResolver struct {
+	store  database.OutboundWebhookStore
+	letter *types.OutboundWebhookDeadLetter
+}
+
+func (r *outboundWebhookDeadLetterResolver) ID() graphql.ID {
+	return marshalOutboundWebhookDeadLetterID(r.letter.ID)
+}
+
+func (r *outboundWebhookDeadLetterResolver) OutboundWebhook(ctx context.Context) OutboundWebhookResolver {
+	return newOutboundWebhookResolverFromDatabase(ctx, r.store, r.letter.OutboundWebhookID)
+}
+
+func (r *outboundWebhookDeadLetterResolver) EventType() string {
+	return r.letter.EventType
+}
+
+func (r *outboundWebhookDeadLetterResolver) Scope() *string {
+	return r.letter.Scope
+}
+
+func (r *outboundWebhookDeadLetterResolver) Payload(ctx context.Context) (string, error) {
+	return r.letter.Payload.Decrypt(ctx)
+}
+
+func (r *outboundWebhookDeadLetterResolver) Attempts() int32 {
+	return int32(r.letter.Attempts)
+}
+
+func (r *outboundWebhookDeadLetterResolver) LastStatusCode() int32 {
+	return int32(r.letter.LastStatusCode)
+}
+
+func (r *outboundWebhookDeadLetterResolver) LastError(ctx context.Context) (string, error) {
+	return r.letter.LastError.Decrypt(ctx)
+}
+
+func (r *outboundWebhookDeadLetterResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.letter.CreatedAt}
+}
+
+func (r *outboundWebhookDeadLetterResolver) ReplayedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.letter.ReplayedAt)
+}
+
+func (r *outboundWebhookDeadLetterResolver) ReplayJob(ctx context.Context) (OutboundWebhookJobResolver, error) {
+	if r.letter.ReplayJobID == nil {
+		return nil, nil
+	}
+
+	job, err := r.store.ToJobStore().GetByID(ctx, *r.letter.ReplayJobID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &outboundWebhookJobResolver{
+		id:  job.ID,
+		job: func() (*types.OutboundWebhookJob, error) { return job, nil },
+	}, nil
+}
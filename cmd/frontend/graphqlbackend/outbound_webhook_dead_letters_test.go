@@ -0,0 +1,223 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSchemaResolver_OutboundWebhookDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbmocks.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"outboundWebhookDeadLetters"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					outboundWebhookDeadLetters {
+						totalCount
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		deadLetterStore := dbmocks.NewMockOutboundWebhookDeadLetterStore()
+		deadLetterStore.CountFunc.SetDefaultHook(func(ctx context.Context, opts database.OutboundWebhookDeadLetterListOpts) (int64, error) {
+			assert.Nil(t, opts.LimitOffset)
+			assert.EqualValues(t, 1, *opts.OutboundWebhookID)
+			assert.True(t, opts.IncludeReplayed)
+			return 3, nil
+		})
+		deadLetterStore.ListFunc.SetDefaultHook(func(ctx context.Context, opts database.OutboundWebhookDeadLetterListOpts) ([]*types.OutboundWebhookDeadLetter, error) {
+			// The limit is +1 because the resolver adds an extra item for
+			// pagination purposes.
+			assert.EqualValues(t, 2, opts.Limit)
+			assert.EqualValues(t, 1, opts.Offset)
+			assert.EqualValues(t, 1, *opts.OutboundWebhookID)
+			assert.True(t, opts.IncludeReplayed)
+
+			return []*types.OutboundWebhookDeadLetter{
+				{
+					ID:                7,
+					OutboundWebhookID: 1,
+					EventType:         "test:event",
+					Payload:           encryption.NewUnencrypted(`{"webhook": "body"}`),
+					Attempts:          5,
+					LastStatusCode:    500,
+					LastError:         encryption.NewUnencrypted("unexpected status code: 500"),
+					CreatedAt:         time.Date(2023, 12, 13, 11, 22, 33, 0, time.UTC),
+				},
+			}, nil
+		})
+
+		store := dbmocks.NewMockOutboundWebhookStore()
+		store.ToDeadLetterStoreFunc.SetDefaultReturn(deadLetterStore)
+
+		db := dbmocks.NewMockDB()
+		db.OutboundWebhooksFunc.SetDefaultReturn(store)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					outboundWebhookDeadLetters(first: 1, after: "1", outboundWebhook: "T3V0Ym91bmRXZWJob29rOjE=", includeReplayed: true) {
+						nodes {
+							id
+							eventType
+							payload
+							attempts
+							lastStatusCode
+							lastError
+							createdAt
+							replayedAt
+							replayJob {
+								id
+							}
+						}
+						totalCount
+						pageInfo {
+							hasNextPage
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"outboundWebhookDeadLetters": {
+						"nodes": [
+							{
+								"id": "T3V0Ym91bmRXZWJob29rRGVhZExldHRlcjo3",
+								"eventType": "test:event",
+								"payload": "{\"webhook\": \"body\"}",
+								"attempts": 5,
+								"lastStatusCode": 500,
+								"lastError": "unexpected status code: 500",
+								"createdAt": "2023-12-13T11:22:33Z",
+								"replayedAt": null,
+								"replayJob": null
+							}
+						],
+						"totalCount": 3,
+						"pageInfo": {
+							"hasNextPage": false
+						}
+					}
+				}
+			`,
+		})
+
+		mockassert.CalledOnce(t, deadLetterStore.CountFunc)
+		mockassert.CalledOnce(t, deadLetterStore.ListFunc)
+	})
+}
+
+func TestSchemaResolver_ReplayOutboundWebhookDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	// Outbound webhook dead letter ID 7.
+	id := "T3V0Ym91bmRXZWJob29rRGVhZExldHRlcjo3"
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbmocks.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"replayOutboundWebhookDeadLetter"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation ReplayOutboundWebhookDeadLetter($id: ID!) {
+					replayOutboundWebhookDeadLetter(id: $id) {
+						id
+					}
+				}
+			`,
+			Variables: map[string]any{"id": id},
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		replayedAt := time.Date(2023, 12, 13, 11, 22, 33, 0, time.UTC)
+		replayJobID := int64(10)
+
+		deadLetterStore := dbmocks.NewMockOutboundWebhookDeadLetterStore()
+		deadLetterStore.ReplayFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.OutboundWebhookDeadLetter, error) {
+			assert.EqualValues(t, 7, id)
+			return &types.OutboundWebhookDeadLetter{
+				ID:                7,
+				OutboundWebhookID: 1,
+				EventType:         "test:event",
+				ReplayedAt:        &replayedAt,
+				ReplayJobID:       &replayJobID,
+			}, nil
+		})
+
+		jobStore := dbmocks.NewMockOutboundWebhookJobStore()
+		jobStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.OutboundWebhookJob, error) {
+			assert.EqualValues(t, replayJobID, id)
+			return &types.OutboundWebhookJob{ID: replayJobID, EventType: "test:event"}, nil
+		})
+
+		store := dbmocks.NewMockOutboundWebhookStore()
+		store.ToDeadLetterStoreFunc.SetDefaultReturn(deadLetterStore)
+		store.ToJobStoreFunc.SetDefaultReturn(jobStore)
+
+		db := dbmocks.NewMockDB()
+		db.OutboundWebhooksFunc.SetDefaultReturn(store)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation ReplayOutboundWebhookDeadLetter($id: ID!) {
+					replayOutboundWebhookDeadLetter(id: $id) {
+						id
+						replayedAt
+						replayJob {
+							eventType
+						}
+					}
+				}
+			`,
+			Variables: map[string]any{"id": id},
+			ExpectedResult: `
+				{
+					"replayOutboundWebhookDeadLetter": {
+						"id": "T3V0Ym91bmRXZWJob29rRGVhZExldHRlcjo3",
+						"replayedAt": "2023-12-13T11:22:33Z",
+						"replayJob": {
+							"eventType": "test:event"
+						}
+					}
+				}
+			`,
+		})
+
+		mockassert.CalledOnce(t, deadLetterStore.ReplayFunc)
+		mockassert.CalledOnce(t, jobStore.GetByIDFunc)
+	})
+}
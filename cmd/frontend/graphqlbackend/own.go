@@ -23,6 +23,7 @@ const (
 	AssignedOwner                    OwnershipReasonType = "ASSIGNED_OWNER"
 	RecentContributorOwnershipSignal OwnershipReasonType = "RECENT_CONTRIBUTOR_OWNERSHIP_SIGNAL"
 	RecentViewOwnershipSignal        OwnershipReasonType = "RECENT_VIEW_OWNERSHIP_SIGNAL"
+	BlameOwnershipSignal             OwnershipReasonType = "BLAME_OWNERSHIP_SIGNAL"
 )
 
 func (args *ListOwnershipArgs) IncludeReason(reason OwnershipReasonType) bool {
@@ -68,6 +69,7 @@ type OwnResolver interface {
 	RemoveAssignedOwner(context.Context, *AssignOwnerOrTeamArgs) (*EmptyResponse, error)
 	AssignTeam(context.Context, *AssignOwnerOrTeamArgs) (*EmptyResponse, error)
 	RemoveAssignedTeam(context.Context, *AssignOwnerOrTeamArgs) (*EmptyResponse, error)
+	ImportAssignedOwnership(context.Context, *ImportAssignedOwnershipArgs) (AssignedOwnershipImportResultResolver, error)
 
 	// Config.
 	OwnSignalConfigurations(ctx context.Context) ([]SignalConfigurationResolver, error)
@@ -110,6 +112,7 @@ type OwnershipReasonResolver interface {
 	ToCodeownersFileEntry() (CodeownersFileEntryResolver, bool)
 	ToRecentContributorOwnershipSignal() (RecentContributorOwnershipSignalResolver, bool)
 	ToRecentViewOwnershipSignal() (RecentViewOwnershipSignalResolver, bool)
+	ToBlameOwnershipSignal() (BlameOwnershipSignalResolver, bool)
 	ToAssignedOwner() (AssignedOwnerResolver, bool)
 }
 
@@ -135,6 +138,11 @@ type RecentViewOwnershipSignalResolver interface {
 	Description() (string, error)
 }
 
+type BlameOwnershipSignalResolver interface {
+	Title() (string, error)
+	Description() (string, error)
+}
+
 type AssignedOwnerResolver interface {
 	Title() (string, error)
 	Description() (string, error)
@@ -172,6 +180,36 @@ type AssignOwnerOrTeamInput struct {
 	AbsolutePath    string
 }
 
+type ImportAssignedOwnershipArgs struct {
+	Input ImportAssignedOwnershipInput
+}
+
+type ImportAssignedOwnershipInput struct {
+	Format string
+	Data   string
+	DryRun *bool
+}
+
+type AssignedOwnershipImportResultResolver interface {
+	Applied() bool
+	Assignments() []AssignedOwnershipImportAssignmentResolver
+	Errors() []AssignedOwnershipImportErrorResolver
+}
+
+type AssignedOwnershipImportAssignmentResolver interface {
+	Line() int32
+	Repository() *RepositoryResolver
+	Path() string
+	User(context.Context) *UserResolver
+	Team() *TeamResolver
+	AlreadyAssigned() bool
+}
+
+type AssignedOwnershipImportErrorResolver interface {
+	Line() int32
+	Message() string
+}
+
 type DeleteCodeownersFileArgs struct {
 	Repositories []DeleteCodeownersFilesInput
 }
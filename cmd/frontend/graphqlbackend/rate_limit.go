@@ -201,6 +201,9 @@ func calcNodeCost(def ast.Node, fragmentCosts map[string]int, variables map[stri
 				// Values that won't appear in the result
 				case "nodes", "__typename":
 					return visitor.ActionNoChange, nil
+				case "blobs":
+					// The fields of blobs are returned once per location.
+					currentLimit = blobLocationCount(node, variables)
 				}
 				if inlineFragmentDepth > 0 {
 					// We don't count fields inside of inline fragments as we need to count all fragments
@@ -350,6 +353,26 @@ func extractInt(i any) (int, error) {
 	}
 }
 
+// blobLocationCount returns the number of locations passed to a blobs field, or
+// the maximum number of locations if it can't be determined.
+func blobLocationCount(field *ast.Field, variables map[string]any) int {
+	n := maxBlobLocationsPerRequest
+	for _, arg := range field.Arguments {
+		if arg.Name == nil || arg.Name.Value != "locations" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.ListValue:
+			n = len(v.Values)
+		case *ast.Variable:
+			if locations, ok := variables[v.Name.Value].([]any); ok {
+				n = len(locations)
+			}
+		}
+	}
+	return max(1, min(n, maxBlobLocationsPerRequest))
+}
+
 var quantityParams = map[string]struct{}{
 	"first": {},
 	"last":  {},
@@ -374,6 +397,12 @@ type LimiterArgs struct {
 	Anonymous     bool
 	RequestName   string
 	RequestSource trace.SourceType
+
+	// UserID is the ID of the authenticated user, if any.
+	UserID int32
+	// AccessTokenKey identifies the access token that authenticated the request, if
+	// any. It must not be the token itself.
+	AccessTokenKey string
 }
 
 type Limiter interface {
@@ -389,12 +418,15 @@ func NewBasicLimitWatcher(logger log.Logger, store throttled.GCRAStoreCtx) *Basi
 		store: store,
 	}
 	conf.Watch(func() {
-		e := conf.Get().ExperimentalFeatures
-		if e == nil {
-			basic.updateFromConfig(logger, 0)
-			return
+		var c limiterConfig
+		if e := conf.Get().ExperimentalFeatures; e != nil {
+			c.anonymousLimit = e.RateLimitAnonymous
 		}
-		basic.updateFromConfig(logger, e.RateLimitAnonymous)
+		if rl := conf.Get().RateLimits; rl != nil {
+			c.userCostBudget = rl.GraphQLUserCostBudget
+			c.accessTokenCostBudget = rl.GraphQLAccessTokenCostBudget
+		}
+		basic.updateFromConfig(logger, c)
 	})
 	return basic
 }
@@ -404,27 +436,71 @@ type BasicLimitWatcher struct {
 	rl    atomic.Value // *RateLimiter
 }
 
-func (bl *BasicLimitWatcher) updateFromConfig(logger log.Logger, limit int) {
-	if limit <= 0 {
-		bl.rl.Store(&BasicLimiter{nil, false})
-		logger.Debug("BasicLimiter disabled")
-		return
+// limiterConfig holds the limits of a BasicLimiter. Limits that are not positive are
+// disabled.
+type limiterConfig struct {
+	// anonymousLimit is the number of anonymous requests allowed per hour.
+	anonymousLimit int
+	// userCostBudget is the estimated query cost each user can spend per hour.
+	userCostBudget int
+	// accessTokenCostBudget is the estimated query cost each access token can spend
+	// per hour.
+	accessTokenCostBudget int
+}
+
+func (bl *BasicLimitWatcher) updateFromConfig(logger log.Logger, c limiterConfig) {
+	limiter := &BasicLimiter{}
+
+	if c.anonymousLimit > 0 {
+		maxBurstPercentage := 0.2
+		l, err := throttled.NewGCRARateLimiterCtx(
+			bl.store,
+			throttled.RateQuota{
+				MaxRate:  throttled.PerHour(c.anonymousLimit),
+				MaxBurst: int(float64(c.anonymousLimit) * maxBurstPercentage),
+			},
+		)
+		if err != nil {
+			logger.Warn("error updating BasicLimiter from config")
+			bl.rl.Store(&BasicLimiter{})
+			return
+		}
+		limiter.GCRARateLimiterCtx = l
 	}
-	maxBurstPercentage := 0.2
-	l, err := throttled.NewGCRARateLimiterCtx(
-		bl.store,
-		throttled.RateQuota{
-			MaxRate:  throttled.PerHour(limit),
-			MaxBurst: int(float64(limit) * maxBurstPercentage),
-		},
-	)
-	if err != nil {
-		logger.Warn("error updating BasicLimiter from config")
-		bl.rl.Store(&BasicLimiter{nil, false})
-		return
+
+	// Cost budgets are token buckets that hold the budget of an hour, so that a single
+	// expensive query can spend all of it, and are refilled continuously.
+	for _, budget := range []struct {
+		limit   int
+		limiter **throttled.GCRARateLimiterCtx
+	}{
+		{c.userCostBudget, &limiter.user},
+		{c.accessTokenCostBudget, &limiter.accessToken},
+	} {
+		if budget.limit <= 0 {
+			continue
+		}
+		l, err := throttled.NewGCRARateLimiterCtx(
+			bl.store,
+			throttled.RateQuota{
+				MaxRate:  throttled.PerHour(budget.limit),
+				MaxBurst: budget.limit - 1,
+			},
+		)
+		if err != nil {
+			logger.Warn("error updating BasicLimiter cost budgets from config", log.Error(err))
+			bl.rl.Store(&BasicLimiter{})
+			return
+		}
+		*budget.limiter = l
 	}
-	bl.rl.Store(&BasicLimiter{l, true})
-	logger.Debug("BasicLimiter: rate limit updated", log.Int("new limit", limit))
+
+	limiter.enabled = limiter.GCRARateLimiterCtx != nil || limiter.user != nil || limiter.accessToken != nil
+	bl.rl.Store(limiter)
+	logger.Debug("BasicLimiter: rate limit updated",
+		log.Int("anonymous limit", c.anonymousLimit),
+		log.Int("user cost budget", c.userCostBudget),
+		log.Int("access token cost budget", c.accessTokenCostBudget))
 }
 
 // Get returns the latest Limiter.
@@ -437,14 +513,43 @@ func (bl *BasicLimitWatcher) Get() (Limiter, bool) {
 
 type BasicLimiter struct {
 	*throttled.GCRARateLimiterCtx
-	enabled bool
+	user        *throttled.GCRARateLimiterCtx
+	accessToken *throttled.GCRARateLimiterCtx
+	enabled     bool
 }
 
 // RateLimit limits unauthenticated requests to the GraphQL API with an equal
-// quantity of 1.
-func (bl *BasicLimiter) RateLimit(ctx context.Context, _ string, _ int, args LimiterArgs) (bool, throttled.RateLimitResult, error) {
-	if args.Anonymous && args.RequestName == "unknown" && args.RequestSource == trace.SourceOther && bl.GCRARateLimiterCtx != nil {
-		return bl.GCRARateLimiterCtx.RateLimitCtx(ctx, "basic", 1)
+// quantity of 1, and charges authenticated requests the given quantity from the
+// hourly cost budgets of their user and access token.
+func (bl *BasicLimiter) RateLimit(ctx context.Context, _ string, quantity int, args LimiterArgs) (bool, throttled.RateLimitResult, error) {
+	if args.Anonymous {
+		if args.RequestName == "unknown" && args.RequestSource == trace.SourceOther && bl.GCRARateLimiterCtx != nil {
+			return bl.GCRARateLimiterCtx.RateLimitCtx(ctx, "basic", 1)
+		}
+		return false, throttled.RateLimitResult{}, nil
+	}
+
+	// The budget of the access token is charged first, so that a token that has
+	// exhausted its budget does not also drain the budget of its user.
+	tokenKey := ""
+	if bl.accessToken != nil && args.AccessTokenKey != "" {
+		tokenKey = "token:" + args.AccessTokenKey
+		limited, result, err := bl.accessToken.RateLimitCtx(ctx, tokenKey, quantity)
+		if err != nil || limited {
+			return limited, result, err
+		}
+	}
+	if bl.user != nil && args.UserID != 0 {
+		limited, result, err := bl.user.RateLimitCtx(ctx, "user:"+strconv.Itoa(int(args.UserID)), quantity)
+		if (err != nil || limited) && tokenKey != "" {
+			// The request is rejected, so give the access token back what it was
+			// charged above. A negative quantity moves the bucket back in time and
+			// is never limited.
+			if _, _, refundErr := bl.accessToken.RateLimitCtx(ctx, tokenKey, -quantity); refundErr != nil {
+				err = errors.Append(err, refundErr)
+			}
+		}
+		return limited, result, err
 	}
 	return false, throttled.RateLimitResult{}, nil
 }
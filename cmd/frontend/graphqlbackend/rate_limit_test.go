@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/throttled/throttled/v2"
 	"github.com/throttled/throttled/v2/store/memstore"
 
 	"github.com/sourcegraph/log/logtest"
@@ -376,6 +377,58 @@ fragment UsableFields on Usable {
 				MaxDepth:   2,
 			},
 		},
+		{
+			name: "Blobs with literal locations",
+			query: `
+{
+  blobs(locations: [{repository: "a", path: "a.go"}, {repository: "b", path: "b.go"}]) {
+    path
+    content
+  }
+}
+`,
+			want: QueryCost{
+				FieldCount: 5,
+				MaxDepth:   2,
+			},
+		},
+		{
+			name: "Blobs with locations variable",
+			query: `
+query Blobs($locations: [BlobLocationInput!]!) {
+  blobs(locations: $locations) {
+    path
+    content
+  }
+}
+`,
+			variables: map[string]any{
+				"locations": []any{
+					map[string]any{"repository": "a", "path": "a.go"},
+					map[string]any{"repository": "a", "path": "b.go"},
+					map[string]any{"repository": "b", "path": "c.go"},
+				},
+			},
+			want: QueryCost{
+				FieldCount: 7,
+				MaxDepth:   2,
+			},
+		},
+		{
+			name: "Blobs with unknown locations",
+			query: `
+query Blobs($locations: [BlobLocationInput!]!) {
+  blobs(locations: $locations) {
+    path
+    content
+  }
+}
+`,
+			want: QueryCost{
+				FieldCount: 201,
+				MaxDepth:   2,
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			want := tc.want
@@ -424,7 +477,7 @@ func TestBasicLimiterEnabled(t *testing.T) {
 			logger := logtest.Scoped(t)
 
 			bl := NewBasicLimitWatcher(logger, store)
-			bl.updateFromConfig(logger, tt.limit)
+			bl.updateFromConfig(logger, limiterConfig{anonymousLimit: tt.limit})
 
 			_, enabled := bl.Get()
 
@@ -444,7 +497,7 @@ func TestBasicLimiter(t *testing.T) {
 	logger := logtest.Scoped(t)
 
 	bl := NewBasicLimitWatcher(logger, store)
-	bl.updateFromConfig(logger, 1)
+	bl.updateFromConfig(logger, limiterConfig{anonymousLimit: 1})
 
 	limiter, enabled := bl.Get()
 	if !enabled {
@@ -476,3 +529,77 @@ func TestBasicLimiter(t *testing.T) {
 		t.Fatalf("got %t, want true", limited)
 	}
 }
+
+func TestBasicLimiterCostBudgets(t *testing.T) {
+	store, err := memstore.NewCtx(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := logtest.Scoped(t)
+
+	bl := NewBasicLimitWatcher(logger, store)
+	bl.updateFromConfig(logger, limiterConfig{userCostBudget: 100, accessTokenCostBudget: 60})
+
+	limiter, enabled := bl.Get()
+	if !enabled {
+		t.Fatalf("got %t, want true", enabled)
+	}
+
+	rateLimit := func(quantity int, args LimiterArgs) (bool, throttled.RateLimitResult) {
+		t.Helper()
+		limited, result, err := limiter.RateLimit(context.Background(), "", quantity, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return limited, result
+	}
+
+	// The access token can spend its budget in a single query.
+	if limited, _ := rateLimit(60, LimiterArgs{UserID: 1, AccessTokenKey: "a"}); limited {
+		t.Fatal("expected query within the budget of the access token not to be limited")
+	}
+	limited, result := rateLimit(10, LimiterArgs{UserID: 1, AccessTokenKey: "a"})
+	if !limited {
+		t.Fatal("expected query exceeding the budget of the access token to be limited")
+	}
+	if result.RetryAfter <= 0 {
+		t.Fatalf("got retry after %s, want positive", result.RetryAfter)
+	}
+
+	// Other access tokens of the user have their own budget, but share the budget of
+	// the user, which the first token has used up 60 of.
+	if limited, _ := rateLimit(30, LimiterArgs{UserID: 1, AccessTokenKey: "b"}); limited {
+		t.Fatal("expected query within the budget of the user not to be limited")
+	}
+	if limited, _ := rateLimit(30, LimiterArgs{UserID: 1}); !limited {
+		t.Fatal("expected query exceeding the budget of the user to be limited")
+	}
+
+	// Queries rejected by the budget of the user are not charged to the access token.
+	if limited, _ := rateLimit(30, LimiterArgs{UserID: 1, AccessTokenKey: "c"}); !limited {
+		t.Fatal("expected query exceeding the budget of the user to be limited")
+	}
+	if limited, _ := rateLimit(60, LimiterArgs{UserID: 4, AccessTokenKey: "c"}); limited {
+		t.Fatal("expected rejected query not to be charged to the access token")
+	}
+
+	// Other users have their own budget.
+	if limited, _ := rateLimit(30, LimiterArgs{UserID: 2}); limited {
+		t.Fatal("expected query of another user not to be limited")
+	}
+
+	// Queries that cost more than the budget can never run.
+	limited, result = rateLimit(101, LimiterArgs{UserID: 3})
+	if !limited {
+		t.Fatal("expected query exceeding the budget to be limited")
+	}
+	if result.RetryAfter >= 0 {
+		t.Fatalf("got retry after %s, want negative", result.RetryAfter)
+	}
+
+	// Anonymous requests are not charged to cost budgets.
+	if limited, _ := rateLimit(1000, LimiterArgs{Anonymous: true}); limited {
+		t.Fatal("expected anonymous query not to be limited")
+	}
+}
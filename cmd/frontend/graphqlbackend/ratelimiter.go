@@ -34,3 +34,93 @@ func (rl *rateLimiterStateResolver) LastReplenishment() gqlutil.DateTime {
 func (rl *rateLimiterStateResolver) Limit() int32 {
 	return int32(rl.state.Limit)
 }
+
+type rateLimitMonitorResolver struct {
+	state ratelimit.MonitorState
+}
+
+func (r *rateLimitMonitorResolver) Service() string {
+	return r.state.Service
+}
+
+func (r *rateLimitMonitorResolver) Resource() string {
+	return r.state.Resource
+}
+
+func (r *rateLimitMonitorResolver) Known() bool {
+	return r.state.Known
+}
+
+func (r *rateLimitMonitorResolver) Limit() *int32 {
+	if !r.state.Known {
+		return nil
+	}
+	limit := int32(r.state.Limit)
+	return &limit
+}
+
+func (r *rateLimitMonitorResolver) Remaining() *int32 {
+	if !r.state.Known {
+		return nil
+	}
+	remaining := int32(r.state.Remaining)
+	return &remaining
+}
+
+func (r *rateLimitMonitorResolver) ResetAt() *gqlutil.DateTime {
+	if !r.state.Known {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.state.ResetAt}
+}
+
+func (r *rateLimitMonitorResolver) RetryAt() *gqlutil.DateTime {
+	return dateTimeOrNilIfZero(r.state.RetryAt)
+}
+
+func (r *rateLimitMonitorResolver) BackoffUntil() *gqlutil.DateTime {
+	return dateTimeOrNilIfZero(r.state.BackoffUntil)
+}
+
+func (r *rateLimitMonitorResolver) LastThrottledAt() *gqlutil.DateTime {
+	return dateTimeOrNilIfZero(r.state.LastThrottledAt)
+}
+
+func (r *rateLimitMonitorResolver) WaitingRequests() int32 {
+	return int32(r.state.Waiting)
+}
+
+func (r *rateLimitMonitorResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.state.UpdatedAt}
+}
+
+type rateLimitThrottleEventResolver struct {
+	event ratelimit.ThrottleEvent
+}
+
+func (r *rateLimitThrottleEventResolver) Service() string {
+	return r.event.Service
+}
+
+func (r *rateLimitThrottleEventResolver) Resource() string {
+	return r.event.Resource
+}
+
+func (r *rateLimitThrottleEventResolver) Reason() string {
+	return string(r.event.Reason)
+}
+
+func (r *rateLimitThrottleEventResolver) BackoffSeconds() int32 {
+	return int32(r.event.Backoff / time.Second)
+}
+
+func (r *rateLimitThrottleEventResolver) Timestamp() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.event.Timestamp}
+}
+
+func dateTimeOrNilIfZero(t time.Time) *gqlutil.DateTime {
+	if t.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: t}
+}
@@ -0,0 +1,300 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/repoviews"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const repoViewIDKind = "RepoView"
+
+func marshalRepoViewID(id int32) graphql.ID {
+	return relay.MarshalID(repoViewIDKind, id)
+}
+
+func unmarshalRepoViewID(id graphql.ID) (viewID int32, err error) {
+	if kind := relay.UnmarshalKind(id); kind != repoViewIDKind {
+		return 0, errors.Newf("invalid repository view id of kind %q", kind)
+	}
+	err = relay.UnmarshalSpec(id, &viewID)
+	return viewID, err
+}
+
+type ListRepoViewsArgs struct {
+	Repository *graphql.ID
+	First      int32
+	After      *string
+}
+
+type RepoViewInput struct {
+	Name        string
+	Description string
+	PathGlobs   []string
+}
+
+type CreateRepoViewArgs struct {
+	Repository graphql.ID
+	Input      RepoViewInput
+}
+
+type UpdateRepoViewArgs struct {
+	ID    graphql.ID
+	Input RepoViewInput
+}
+
+type DeleteRepoViewArgs struct {
+	ID graphql.ID
+}
+
+func (input RepoViewInput) validate() error {
+	if err := repoviews.ValidateName(input.Name); err != nil {
+		return err
+	}
+	return repoviews.ValidatePathGlobs(input.PathGlobs)
+}
+
+func (r *schemaResolver) RepoViews(ctx context.Context, args ListRepoViewsArgs) (*repoViewConnectionResolver, error) {
+	opts := database.ListRepoViewsOptions{
+		LimitOffset: &database.LimitOffset{Limit: int(args.First)},
+	}
+	if args.After != nil {
+		offset, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, errors.Newf("cannot parse offset %q", *args.After)
+		}
+		opts.Offset = offset
+	}
+
+	if args.Repository != nil {
+		// 🚨 SECURITY: Resolving the repository checks that the current user
+		// has access to it, and thereby to its views.
+		repo, err := r.repositoryByID(ctx, *args.Repository)
+		if err != nil {
+			return nil, err
+		}
+		opts.RepoID = repo.IDInt32()
+	} else if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		// 🚨 SECURITY: Only site admins may list the views over all repositories.
+		return nil, err
+	}
+
+	return newRepoViewConnectionResolver(ctx, r.db, r.gitserverClient, opts), nil
+}
+
+func (r *schemaResolver) CreateRepoView(ctx context.Context, args CreateRepoViewArgs) (*repoViewResolver, error) {
+	// 🚨 SECURITY: Only site admins may create repository views.
+	user, err := auth.CurrentUser(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.SiteAdmin {
+		return nil, auth.ErrMustBeSiteAdmin
+	}
+
+	if err := args.Input.validate(); err != nil {
+		return nil, err
+	}
+	repo, err := r.repositoryByID(ctx, args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := r.db.RepoViews().Create(ctx, &types.RepoView{
+		Name:        args.Input.Name,
+		Description: args.Input.Description,
+		RepoID:      repo.IDInt32(),
+		PathGlobs:   args.Input.PathGlobs,
+		CreatorID:   &user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &repoViewResolver{db: r.db, gitserverClient: r.gitserverClient, view: view}, nil
+}
+
+func (r *schemaResolver) UpdateRepoView(ctx context.Context, args UpdateRepoViewArgs) (*repoViewResolver, error) {
+	// 🚨 SECURITY: Only site admins may update repository views.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalRepoViewID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := args.Input.validate(); err != nil {
+		return nil, err
+	}
+
+	view, err := r.db.RepoViews().Update(ctx, &types.RepoView{
+		ID:          id,
+		Name:        args.Input.Name,
+		Description: args.Input.Description,
+		PathGlobs:   args.Input.PathGlobs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &repoViewResolver{db: r.db, gitserverClient: r.gitserverClient, view: view}, nil
+}
+
+func (r *schemaResolver) DeleteRepoView(ctx context.Context, args DeleteRepoViewArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may delete repository views.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalRepoViewID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.RepoViews().Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) repoViewByID(ctx context.Context, id graphql.ID) (*repoViewResolver, error) {
+	viewID, err := unmarshalRepoViewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := r.db.RepoViews().GetByID(ctx, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: A view is only visible to users who have access to its
+	// parent repository.
+	if _, err := r.db.Repos().Get(ctx, view.RepoID); err != nil {
+		return nil, err
+	}
+
+	return &repoViewResolver{db: r.db, gitserverClient: r.gitserverClient, view: view}, nil
+}
+
+type repoViewResolver struct {
+	db              database.DB
+	gitserverClient gitserver.Client
+	view            *types.RepoView
+}
+
+func (r *repoViewResolver) ID() graphql.ID {
+	return marshalRepoViewID(r.view.ID)
+}
+
+func (r *repoViewResolver) Name() string {
+	return r.view.Name
+}
+
+func (r *repoViewResolver) Description() string {
+	return r.view.Description
+}
+
+func (r *repoViewResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	repo, err := r.db.Repos().Get(ctx, r.view.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return NewRepositoryResolver(r.db, r.gitserverClient, repo), nil
+}
+
+func (r *repoViewResolver) PathGlobs() []string {
+	return r.view.PathGlobs
+}
+
+func (r *repoViewResolver) SearchQuery() string {
+	return "view:" + r.view.Name
+}
+
+func (r *repoViewResolver) Creator(ctx context.Context) (*UserResolver, error) {
+	if r.view.CreatorID == nil {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, *r.view.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *repoViewResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.view.CreatedAt}
+}
+
+func (r *repoViewResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.view.UpdatedAt}
+}
+
+type repoViewConnectionResolver struct {
+	db              database.DB
+	gitserverClient gitserver.Client
+	opts            database.ListRepoViewsOptions
+
+	nodes      func() ([]*types.RepoView, error)
+	totalCount func() (int32, error)
+}
+
+func newRepoViewConnectionResolver(ctx context.Context, db database.DB, gitserverClient gitserver.Client, opts database.ListRepoViewsOptions) *repoViewConnectionResolver {
+	return &repoViewConnectionResolver{
+		db:              db,
+		gitserverClient: gitserverClient,
+		opts:            opts,
+		nodes: sync.OnceValues(func() ([]*types.RepoView, error) {
+			// Fetch one more view than requested to determine whether there
+			// is a next page.
+			listOpts := opts
+			listOpts.LimitOffset = &database.LimitOffset{Limit: opts.Limit + 1, Offset: opts.Offset}
+			return db.RepoViews().List(ctx, listOpts)
+		}),
+		totalCount: sync.OnceValues(func() (int32, error) {
+			count, err := db.RepoViews().Count(ctx, database.ListRepoViewsOptions{RepoID: opts.RepoID})
+			return int32(count), err
+		}),
+	}
+}
+
+func (r *repoViewConnectionResolver) Nodes() ([]*repoViewResolver, error) {
+	views, err := r.nodes()
+	if err != nil {
+		return nil, err
+	}
+	if len(views) > r.opts.Limit {
+		views = views[:r.opts.Limit]
+	}
+
+	resolvers := make([]*repoViewResolver, 0, len(views))
+	for _, view := range views {
+		resolvers = append(resolvers, &repoViewResolver{db: r.db, gitserverClient: r.gitserverClient, view: view})
+	}
+	return resolvers, nil
+}
+
+func (r *repoViewConnectionResolver) TotalCount() (int32, error) {
+	return r.totalCount()
+}
+
+func (r *repoViewConnectionResolver) PageInfo() (*graphqlutil.PageInfo, error) {
+	views, err := r.nodes()
+	if err != nil {
+		return nil, err
+	}
+	if len(views) > r.opts.Limit {
+		return graphqlutil.NextPageCursor(strconv.Itoa(r.opts.Offset + r.opts.Limit)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
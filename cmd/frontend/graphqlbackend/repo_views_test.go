@@ -0,0 +1,164 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSchemaResolver_RepoViews(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbmocks.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"repoViews"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					repoViews {
+						nodes {
+							name
+						}
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		store := dbmocks.NewMockRepoViewStore()
+		store.ListFunc.SetDefaultReturn([]*types.RepoView{
+			{ID: 1, Name: "backend", RepoID: 1, PathGlobs: []string{"cmd/**", "internal/**"}},
+			{ID: 2, Name: "web", RepoID: 1, PathGlobs: []string{"client/web/**"}},
+		}, nil)
+		store.CountFunc.SetDefaultReturn(2, nil)
+
+		db := dbmocks.NewMockDB()
+		db.RepoViewsFunc.SetDefaultReturn(store)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					repoViews(first: 1) {
+						nodes {
+							name
+							pathGlobs
+							searchQuery
+						}
+						totalCount
+						pageInfo {
+							hasNextPage
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repoViews": {
+						"nodes": [
+							{
+								"name": "backend",
+								"pathGlobs": ["cmd/**", "internal/**"],
+								"searchQuery": "view:backend"
+							}
+						],
+						"totalCount": 2,
+						"pageInfo": {
+							"hasNextPage": true
+						}
+					}
+				}
+			`,
+		})
+	})
+}
+
+func TestSchemaResolver_CreateRepoView(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbmocks.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"createRepoView"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					createRepoView(repository: "UmVwb3NpdG9yeTox", input: {name: "web", pathGlobs: ["client/web/**"]}) {
+						name
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		repos := dbmocks.NewMockRepoStore()
+		repos.GetFunc.SetDefaultReturn(&types.Repo{ID: 1, Name: "github.com/sourcegraph/monorepo"}, nil)
+
+		store := dbmocks.NewMockRepoViewStore()
+		store.CreateFunc.SetDefaultHook(func(_ context.Context, view *types.RepoView) (*types.RepoView, error) {
+			assert.Equal(t, api.RepoID(1), view.RepoID)
+			assert.NotNil(t, view.CreatorID)
+			view.ID = 1
+			return view, nil
+		})
+
+		db := dbmocks.NewMockDB()
+		db.ReposFunc.SetDefaultReturn(repos)
+		db.RepoViewsFunc.SetDefaultReturn(store)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					createRepoView(repository: "UmVwb3NpdG9yeTox", input: {name: "web", pathGlobs: ["client/web/**"]}) {
+						name
+						description
+						pathGlobs
+						repository {
+							name
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"createRepoView": {
+						"name": "web",
+						"description": "",
+						"pathGlobs": ["client/web/**"],
+						"repository": {
+							"name": "github.com/sourcegraph/monorepo"
+						}
+					}
+				}
+			`,
+		})
+
+		mockassert.CalledOnce(t, store.CreateFunc)
+	})
+}
@@ -102,7 +102,15 @@ func (args *repositoryArgs) toReposListOptions() (database.ReposListOptions, err
 	return opt, nil
 }
 
+// maxRepositoryNamesPerRequest is the maximum number of names that can be
+// passed to the repositories query.
+const maxRepositoryNamesPerRequest = 1000
+
 func (r *schemaResolver) Repositories(ctx context.Context, args *repositoryArgs) (*graphqlutil.ConnectionResolver[*RepositoryResolver], error) {
+	if args.Names != nil && len(*args.Names) > maxRepositoryNamesPerRequest {
+		return nil, errors.Newf("at most %d repository names can be given per request, got %d", maxRepositoryNamesPerRequest, len(*args.Names))
+	}
+
 	opt, err := args.toReposListOptions()
 	if err != nil {
 		return nil, err
@@ -704,6 +704,25 @@ func TestRepositories_CursorPagination(t *testing.T) {
 	})
 }
 
+func TestRepositories_TooManyNames(t *testing.T) {
+	names := make([]string, maxRepositoryNamesPerRequest+1)
+	for i := range names {
+		names[i] = fmt.Sprintf("%q", fmt.Sprintf("repo%d", i))
+	}
+
+	RunTest(t, &Test{
+		Schema:         mustParseGraphQLSchema(t, dbmocks.NewMockDB()),
+		Query:          fmt.Sprintf(`{ repositories(first: 10, names: [%s]) { nodes { name } } }`, strings.Join(names, ", ")),
+		ExpectedResult: "null",
+		ExpectedErrors: []*gqlerrors.QueryError{
+			{
+				Message: "at most 1000 repository names can be given per request, got 1001",
+				Path:    []any{"repositories"},
+			},
+		},
+	})
+}
+
 func TestRepositories_Integration(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -15,9 +15,6 @@ type repositoryContributorResolver struct {
 
 	repo *RepositoryResolver
 	args repositoryContributorsArgs
-
-	// For use with RepositoryResolver only
-	index int
 }
 
 // gitContributorGQLID is a type used for marshaling and unmarshaling a Git contributor's
@@ -1,11 +1,16 @@
 package graphqlbackend
 
 import (
+	"cmp"
 	"context"
-	"math"
+	"slices"
+	"sort"
 	"strconv"
 	"sync"
 
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -47,17 +52,40 @@ type repositoryContributorConnectionStore struct {
 	err     error
 }
 
+const repositoryContributorCursorKind = "RepositoryContributorCursor"
+
+// repositoryContributorCursor is the key of a contributor in the list of contributors, which is
+// sorted by descending commit count, then name and email. Unlike an index, the key of a
+// contributor doesn't change when new contributors show up in front of it.
+type repositoryContributorCursor struct {
+	Count int32  `json:"count"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
 func (s *repositoryContributorConnectionStore) MarshalCursor(node *repositoryContributorResolver, _ database.OrderBy) (*string, error) {
-	position := strconv.Itoa(node.index)
-	return &position, nil
+	cursor := string(relay.MarshalID(repositoryContributorCursorKind, repositoryContributorCursor{
+		Count: node.count,
+		Name:  node.name,
+		Email: node.email,
+	}))
+	return &cursor, nil
 }
 
 func (s *repositoryContributorConnectionStore) UnmarshalCursor(cursor string, _ database.OrderBy) ([]any, error) {
-	c, err := strconv.Atoi(cursor)
-	if err != nil {
+	// Cursors used to be indexes, which we still accept.
+	if c, err := strconv.Atoi(cursor); err == nil {
+		return []any{c}, nil
+	}
+
+	if kind := relay.UnmarshalKind(graphql.ID(cursor)); kind != repositoryContributorCursorKind {
+		return nil, errors.Errorf("cannot unmarshal repository contributor cursor type: %q", kind)
+	}
+	var spec repositoryContributorCursor
+	if err := relay.UnmarshalSpec(graphql.ID(cursor), &spec); err != nil {
 		return nil, err
 	}
-	return []any{c}, nil
+	return []any{spec}, nil
 }
 
 func (s *repositoryContributorConnectionStore) ComputeTotal(ctx context.Context) (int32, error) {
@@ -71,8 +99,7 @@ func (s *repositoryContributorConnectionStore) ComputeNodes(ctx context.Context,
 		return nil, err
 	}
 
-	var start int
-	results, start, err = offsetBasedCursorSlice(results, args)
+	results, err = keysetCursorSlice(results, args, compareContributorToCursor)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +113,6 @@ func (s *repositoryContributorConnectionStore) ComputeNodes(ctx context.Context,
 			count: contributor.Count,
 			repo:  s.repo,
 			args:  *s.args,
-			index: start + i,
 		}
 	}
 
@@ -107,30 +133,72 @@ func (s *repositoryContributorConnectionStore) compute(ctx context.Context) ([]*
 			opt.After = *s.args.AfterDate
 		}
 		s.results, s.err = client.ContributorCount(ctx, s.repo.RepoName(), opt)
+
+		// git shortlog already sorts by count, but we depend on the order of contributors with
+		// the same count to find the position of a cursor.
+		slices.SortStableFunc(s.results, func(a, b *gitdomain.ContributorCount) int {
+			return compareContributorToCursor(a, repositoryContributorCursor{Count: b.Count, Name: b.Name, Email: b.Email})
+		})
 	})
 	return s.results, s.err
 }
 
-func offsetBasedCursorSlice[T any](nodes []T, args *database.PaginationArgs) ([]T, int, error) {
-	start := 0
-	end := 0
-	totalFloat := float64(len(nodes))
+// compareContributorToCursor orders contributors by descending count, then name and email.
+func compareContributorToCursor(c *gitdomain.ContributorCount, cursor repositoryContributorCursor) int {
+	if c.Count != cursor.Count {
+		return cmp.Compare(cursor.Count, c.Count)
+	}
+	if c.Name != cursor.Name {
+		return cmp.Compare(c.Name, cursor.Name)
+	}
+	return cmp.Compare(c.Email, cursor.Email)
+}
+
+// keysetCursorSlice returns the page of the sorted nodes described by args. Cursors are either
+// the keys of nodes, compared to the nodes with compare, or indexes into nodes.
+func keysetCursorSlice[T, K any](nodes []T, args *database.PaginationArgs, compare func(T, K) int) ([]T, error) {
+	// position returns the index of the first node after the given cursor, or at the given
+	// cursor if inclusive is set.
+	position := func(cursor any, inclusive bool) (int, error) {
+		switch c := cursor.(type) {
+		case int:
+			if !inclusive {
+				c++
+			}
+			return max(0, min(c, len(nodes))), nil
+		case K:
+			return sort.Search(len(nodes), func(i int) bool {
+				if inclusive {
+					return compare(nodes[i], c) >= 0
+				}
+				return compare(nodes[i], c) > 0
+			}), nil
+		default:
+			return 0, errors.Errorf("unexpected cursor type %T", cursor)
+		}
+	}
+
+	var start, end int
 	if args.First != nil {
 		if len(args.After) > 0 {
-			start = int(math.Min(float64(args.After[0].(int))+1, totalFloat))
+			var err error
+			if start, err = position(args.After[0], false); err != nil {
+				return nil, err
+			}
 		}
-		end = int(math.Min(float64(start+*args.First), totalFloat))
+		end = min(start+*args.First, len(nodes))
 	} else if args.Last != nil {
-		end = int(totalFloat)
+		end = len(nodes)
 		if len(args.Before) > 0 {
-			end = int(math.Max(float64(args.Before[0].(int)), 0))
+			var err error
+			if end, err = position(args.Before[0], true); err != nil {
+				return nil, err
+			}
 		}
-		start = int(math.Max(float64(end-*args.Last), 0))
+		start = max(end-*args.Last, 0)
 	} else {
-		return nil, 0, errors.New(`args.First and args.Last are nil`)
+		return nil, errors.New(`args.First and args.Last are nil`)
 	}
 
-	nodes = nodes[start:end]
-
-	return nodes, start, nil
+	return nodes[start:end], nil
 }
@@ -1,6 +1,7 @@
 package graphqlbackend
 
 import (
+	"cmp"
 	"testing"
 
 	"github.com/hexops/autogold/v2"
@@ -9,7 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
-func TestOffsetBasedCursorSlice(t *testing.T) {
+func TestKeysetCursorSlice(t *testing.T) {
 	slice := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 
 	testCases := []struct {
@@ -37,11 +38,29 @@ func TestOffsetBasedCursorSlice(t *testing.T) {
 			&database.PaginationArgs{Last: pointers.Ptr(2), Before: []any{8}},
 			autogold.Expect([]int{7, 8}),
 		},
+		{
+			"next page by key",
+			&database.PaginationArgs{First: pointers.Ptr(2), After: []any{4.0}},
+			autogold.Expect([]int{5, 6}),
+		},
+		{
+			"next page by removed key",
+			&database.PaginationArgs{First: pointers.Ptr(2), After: []any{4.5}},
+			autogold.Expect([]int{5, 6}),
+		},
+		{
+			"previous page by key",
+			&database.PaginationArgs{Last: pointers.Ptr(2), Before: []any{4.0}},
+			autogold.Expect([]int{2, 3}),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result, _, err := offsetBasedCursorSlice(slice, tc.args)
+			// Integer cursors are indexes, float cursors are keys.
+			result, err := keysetCursorSlice(slice, tc.args, func(node int, key float64) int {
+				return cmp.Compare(float64(node), key)
+			})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -0,0 +1,98 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type repositoryDeployKeyResolver struct {
+	key *database.RepoDeployKey
+}
+
+func (r *repositoryDeployKeyResolver) PublicKey() string {
+	return r.key.PublicKey
+}
+
+func (r *repositoryDeployKeyResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.key.CreatedAt}
+}
+
+func (r *repositoryDeployKeyResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.key.UpdatedAt}
+}
+
+func (r *RepositoryResolver) DeployKey(ctx context.Context) (*repositoryDeployKeyResolver, error) {
+	// 🚨 SECURITY: Only site admins may view deploy keys.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	key, err := r.db.RepoDeployKeys(keyring.Default().ExternalServiceKey).GetByRepoID(ctx, r.IDInt32())
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &repositoryDeployKeyResolver{key: key}, nil
+}
+
+// GenerateRepositoryDeployKey generates a new SSH deploy key for a repository
+// of a generic Git code host, replacing its existing deploy key.
+func (r *schemaResolver) GenerateRepositoryDeployKey(ctx context.Context, args *struct {
+	Repository graphql.ID
+}) (*repositoryDeployKeyResolver, error) {
+	// 🚨 SECURITY: Only site admins may manage deploy keys.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, err := UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if repo.ExternalRepo.ServiceType != extsvc.TypeOther {
+		return nil, errors.Newf("deploy keys can only be generated for repositories of generic Git code hosts, but %q is a %s repository", repo.Name, repo.ExternalRepo.ServiceType)
+	}
+
+	key, err := r.db.RepoDeployKeys(keyring.Default().ExternalServiceKey).Generate(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.Info("generated repository deploy key", log.String("repo", string(repo.Name)), log.Int32("id", int32(repo.ID)))
+	return &repositoryDeployKeyResolver{key: key}, nil
+}
+
+// DeleteRepositoryDeployKey deletes the SSH deploy key of a repository.
+func (r *schemaResolver) DeleteRepositoryDeployKey(ctx context.Context, args *struct {
+	Repository graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may manage deploy keys.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, err := UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.RepoDeployKeys(keyring.Default().ExternalServiceKey).Delete(ctx, repoID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
@@ -0,0 +1,155 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGenerateRepositoryDeployKey(t *testing.T) {
+	users := dbmocks.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		switch id {
+		case 1:
+			return &types.Repo{ID: id, Name: "git.example.com/foo", ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeOther}}, nil
+		default:
+			return &types.Repo{ID: id, Name: "github.com/foo/bar", ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeGitHub}}, nil
+		}
+	})
+
+	createdAt := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	deployKeys := dbmocks.NewMockRepoDeployKeyStore()
+	deployKeys.GenerateFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*database.RepoDeployKey, error) {
+		if id != 1 {
+			t.Errorf("unexpected repo ID: %d", id)
+		}
+		return &database.RepoDeployKey{RepoID: id, PublicKey: "ssh-rsa AAAA", CreatedAt: createdAt, UpdatedAt: createdAt}, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.RepoDeployKeysFunc.SetDefaultReturn(deployKeys)
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: fmt.Sprintf(`
+				mutation {
+					generateRepositoryDeployKey(repository: %q) {
+						publicKey
+						createdAt
+					}
+				}
+			`, MarshalRepositoryID(1)),
+			ExpectedResult: `
+				{
+					"generateRepositoryDeployKey": {
+						"publicKey": "ssh-rsa AAAA",
+						"createdAt": "2023-12-01T00:00:00Z"
+					}
+				}
+			`,
+		},
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: fmt.Sprintf(`
+				mutation {
+					generateRepositoryDeployKey(repository: %q) {
+						publicKey
+					}
+				}
+			`, MarshalRepositoryID(2)),
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: `deploy keys can only be generated for repositories of generic Git code hosts, but "github.com/foo/bar" is a github repository`,
+					Path:    []any{"generateRepositoryDeployKey"},
+				},
+			},
+		},
+	})
+
+	if len(deployKeys.GenerateFunc.History()) != 1 {
+		t.Fatalf("expected one deploy key to be generated, got %d", len(deployKeys.GenerateFunc.History()))
+	}
+}
+
+func TestRepositoryDeployKey(t *testing.T) {
+	users := dbmocks.NewMockUserStore()
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: 1, Name: "git.example.com/foo"}, nil)
+	deployKeys := dbmocks.NewMockRepoDeployKeyStore()
+	deployKeys.GetByRepoIDFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*database.RepoDeployKey, error) {
+		return database.NewMockRepoDeployKey(&database.RepoDeployKey{RepoID: id, PublicKey: "ssh-rsa AAAA"}, "private", "passphrase"), nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.RepoDeployKeysFunc.SetDefaultReturn(deployKeys)
+
+	query := `
+		{
+			repository(name: "git.example.com/foo") {
+				deployKey {
+					publicKey
+				}
+			}
+		}
+	`
+
+	t.Run("site admin", func(t *testing.T) {
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+		repos.GetByNameFunc.SetDefaultReturn(&types.Repo{ID: 1, Name: "git.example.com/foo"}, nil)
+
+		RunTest(t, &Test{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query:  query,
+			ExpectedResult: `
+				{
+					"repository": {
+						"deployKey": {
+							"publicKey": "ssh-rsa AAAA"
+						}
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("non site admin", func(t *testing.T) {
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: false}, nil)
+
+		RunTest(t, &Test{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query:  query,
+			ExpectedResult: `
+				{
+					"repository": {
+						"deployKey": null
+					}
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: "must be site admin",
+					Path:    []any{"repository", "deployKey"},
+				},
+			},
+		})
+	})
+
+}
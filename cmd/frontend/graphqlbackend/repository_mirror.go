@@ -3,12 +3,14 @@ package graphqlbackend
 import (
 	"context"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/graph-gophers/graphql-go"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
@@ -319,6 +321,14 @@ func (r *updateQueueResolver) Total() int32 {
 	return int32(r.queue.Total)
 }
 
+func (r *updateQueueResolver) JobID() *string {
+	if r.queue.JobID == 0 {
+		return nil
+	}
+	jobID := strconv.FormatUint(r.queue.JobID, 10)
+	return &jobID
+}
+
 func (r *schemaResolver) CheckMirrorRepositoryConnection(ctx context.Context, args *struct {
 	Repository graphql.ID
 }) (*checkMirrorRepositoryConnectionResult, error) {
@@ -373,3 +383,86 @@ func (r *schemaResolver) UpdateMirrorRepository(ctx context.Context, args *struc
 	}
 	return &EmptyResponse{}, nil
 }
+
+// maxForceSyncRepositories is the maximum number of repositories that can be
+// synced with a single forceSyncRepositories mutation.
+const maxForceSyncRepositories = 1000
+
+func (r *schemaResolver) ForceSyncRepositories(ctx context.Context, args *struct {
+	Names []string
+}) ([]*forcedRepositorySyncResolver, error) {
+	// 🚨 SECURITY: Forced syncs skip the update queue, so only site admins may
+	// request them.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if len(args.Names) > maxForceSyncRepositories {
+		return nil, errors.Errorf("cannot sync more than %d repositories at once", maxForceSyncRepositories)
+	}
+	if len(args.Names) == 0 {
+		return []*forcedRepositorySyncResolver{}, nil
+	}
+
+	repos, err := r.db.Repos().List(ctx, database.ReposListOptions{Names: args.Names})
+	if err != nil {
+		return nil, err
+	}
+	reposByName := make(map[api.RepoName]*types.Repo, len(repos))
+	for _, repo := range repos {
+		reposByName[repo.Name] = repo
+	}
+
+	results := make([]*forcedRepositorySyncResolver, 0, len(args.Names))
+	for _, name := range args.Names {
+		result := &forcedRepositorySyncResolver{name: name}
+		results = append(results, result)
+
+		repo, ok := reposByName[api.RepoName(name)]
+		if !ok {
+			result.err = errors.Newf("repository %q not found", name)
+			continue
+		}
+		result.repo = NewRepositoryResolver(r.db, r.gitserverClient, repo)
+
+		resp, err := repoupdater.DefaultClient.ForceRepoUpdate(ctx, repo.Name)
+		if err != nil {
+			result.err = err
+			continue
+		}
+		result.jobID = resp.JobID
+	}
+
+	return results, nil
+}
+
+type forcedRepositorySyncResolver struct {
+	name  string
+	repo  *RepositoryResolver
+	jobID uint64
+	err   error
+}
+
+func (r *forcedRepositorySyncResolver) Name() string {
+	return r.name
+}
+
+func (r *forcedRepositorySyncResolver) Repository() *RepositoryResolver {
+	return r.repo
+}
+
+func (r *forcedRepositorySyncResolver) JobID() *string {
+	if r.jobID == 0 {
+		return nil
+	}
+	jobID := strconv.FormatUint(r.jobID, 10)
+	return &jobID
+}
+
+func (r *forcedRepositorySyncResolver) Error() *string {
+	if r.err == nil {
+		return nil
+	}
+	msg := r.err.Error()
+	return &msg
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	repoupdaterprotocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -297,3 +299,64 @@ func TestRepositoryMirrorInfoCloneProgressFetchedFromDatabase(t *testing.T) {
 		`,
 	})
 }
+
+func TestForceSyncRepositories(t *testing.T) {
+	users := dbmocks.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.ListFunc.SetDefaultReturn([]*types.Repo{{ID: 1, Name: "github.com/foo/bar"}}, nil)
+
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	var forced []api.RepoName
+	repoupdater.MockForceRepoUpdate = func(_ context.Context, repo api.RepoName) (*repoupdaterprotocol.RepoUpdateResponse, error) {
+		forced = append(forced, repo)
+		return &repoupdaterprotocol.RepoUpdateResponse{Name: string(repo), JobID: 42}, nil
+	}
+	t.Cleanup(func() { repoupdater.MockForceRepoUpdate = nil })
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					forceSyncRepositories(names: ["github.com/foo/bar", "github.com/foo/missing"]) {
+						name
+						repository {
+							name
+						}
+						jobID
+						error
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"forceSyncRepositories": [
+						{
+							"name": "github.com/foo/bar",
+							"repository": {
+								"name": "github.com/foo/bar"
+							},
+							"jobID": "42",
+							"error": null
+						},
+						{
+							"name": "github.com/foo/missing",
+							"repository": null,
+							"jobID": null,
+							"error": "repository \"github.com/foo/missing\" not found"
+						}
+					]
+				}
+			`,
+		},
+	})
+
+	if want := []api.RepoName{"github.com/foo/bar"}; !reflect.DeepEqual(forced, want) {
+		t.Fatalf("unexpected forced repos: have %v, want %v", forced, want)
+	}
+}
@@ -0,0 +1,91 @@
+package graphqlbackend
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/authz/permssync"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RestoreRepository restores a deleted repository whose data hasn't been purged
+// from gitserver yet.
+func (r *schemaResolver) RestoreRepository(ctx context.Context, args *struct {
+	Name string
+}) (*RepositoryResolver, error) {
+	// 🚨 SECURITY: Only site admins may restore repositories.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	deletedAfter := repos.RestorableDeletedAfter(conf.DefaultClient(), time.Now())
+	repo, err := r.db.Repos().Restore(ctx, api.RepoName(args.Name), deletedAfter)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, errors.Newf("no repository named %q was deleted within the retention window or its clone was already purged", args.Name)
+		}
+		if errors.Is(err, database.ErrRepoNameTaken) {
+			return nil, errors.Newf("cannot restore repository %q: another repository with the same name exists", args.Name)
+		}
+		return nil, err
+	}
+
+	r.logger.Info("restored repository", log.String("repo", string(repo.Name)), log.Int32("id", int32(repo.ID)))
+
+	// Soft-deleting a repo removes its external service links and permissions,
+	// so the restored repo has no sources to clone from and is only visible to
+	// site admins until its code host connections and permissions are synced.
+	if err := r.enqueueRestoredRepositorySyncs(ctx, repo); err != nil {
+		return nil, err
+	}
+	permssync.SchedulePermsSync(ctx, r.logger, r.db, permssync.ScheduleSyncOpts{
+		RepoIDs:           []api.RepoID{repo.ID},
+		Reason:            database.ReasonManualRepoSync,
+		TriggeredByUserID: actor.FromContext(ctx).UID,
+	})
+
+	return NewRepositoryResolver(r.db, r.gitserverClient, repo), nil
+}
+
+// enqueueRestoredRepositorySyncs enqueues a sync job for every external service
+// of the code host of the given repo, which links the repo to the external
+// services that still yield it.
+func (r *schemaResolver) enqueueRestoredRepositorySyncs(ctx context.Context, repo *types.Repo) error {
+	if repo.ExternalRepo.ServiceType == "" {
+		return nil
+	}
+
+	opts := database.ExternalServicesListOptions{
+		Kinds: []string{extsvc.TypeToKind(repo.ExternalRepo.ServiceType)},
+	}
+	// Narrow the sync down to the code host of the repo when we know it.
+	if ch, err := r.db.CodeHosts().GetByURL(ctx, repo.ExternalRepo.ServiceID); err == nil {
+		opts.CodeHostID = ch.ID
+	} else if !errcode.IsNotFound(err) {
+		return err
+	}
+
+	svcs, err := r.db.ExternalServices().List(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	rstore := repos.NewStore(r.logger, r.db)
+	for _, svc := range svcs {
+		if err := rstore.EnqueueSingleSyncJob(ctx, svc.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz/permssync"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRestoreRepository(t *testing.T) {
+	users := dbmocks.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.RestoreFunc.SetDefaultHook(func(_ context.Context, name api.RepoName, deletedAfter time.Time) (*types.Repo, error) {
+		if deletedAfter.IsZero() || deletedAfter.After(time.Now()) {
+			t.Errorf("unexpected deletedAfter: %s", deletedAfter)
+		}
+		switch name {
+		case "github.com/foo/bar":
+			return &types.Repo{ID: 1, Name: name, ExternalRepo: api.ExternalRepoSpec{
+				ID:          "bar",
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+			}}, nil
+		case "github.com/foo/taken":
+			return nil, database.ErrRepoNameTaken
+		default:
+			return nil, &database.RepoNotFoundErr{Name: name}
+		}
+	})
+
+	codeHosts := dbmocks.NewMockCodeHostStore()
+	codeHosts.GetByURLFunc.SetDefaultHook(func(_ context.Context, url string) (*types.CodeHost, error) {
+		if url != "https://github.com/" {
+			t.Errorf("unexpected code host URL: %q", url)
+		}
+		return &types.CodeHost{ID: 3, Kind: extsvc.KindGitHub, URL: url}, nil
+	})
+
+	externalServices := dbmocks.NewMockExternalServiceStore()
+	externalServices.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		if opts.CodeHostID != 3 {
+			t.Errorf("unexpected code host ID: %d", opts.CodeHostID)
+		}
+		return nil, nil
+	})
+
+	var permsSyncRepoIDs []api.RepoID
+	permssync.MockSchedulePermsSync = func(_ context.Context, _ log.Logger, _ database.DB, opts permssync.ScheduleSyncOpts) {
+		permsSyncRepoIDs = append(permsSyncRepoIDs, opts.RepoIDs...)
+	}
+	t.Cleanup(func() { permssync.MockSchedulePermsSync = nil })
+
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.CodeHostsFunc.SetDefaultReturn(codeHosts)
+	db.ExternalServicesFunc.SetDefaultReturn(externalServices)
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					restoreRepository(name: "github.com/foo/bar") {
+						name
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"restoreRepository": {
+						"name": "github.com/foo/bar"
+					}
+				}
+			`,
+		},
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					restoreRepository(name: "github.com/foo/missing") {
+						name
+					}
+				}
+			`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: `no repository named "github.com/foo/missing" was deleted within the retention window or its clone was already purged`,
+					Path:    []any{"restoreRepository"},
+				},
+			},
+		},
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					restoreRepository(name: "github.com/foo/taken") {
+						name
+					}
+				}
+			`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: `cannot restore repository "github.com/foo/taken": another repository with the same name exists`,
+					Path:    []any{"restoreRepository"},
+				},
+			},
+		},
+	})
+
+	// The restored repo is re-linked to the external services of its code
+	// host and its permissions are synced again.
+	mockassert.CalledOnce(t, externalServices.ListFunc)
+	if want := []api.RepoID{1}; !reflect.DeepEqual(permsSyncRepoIDs, want) {
+		t.Errorf("unexpected repos scheduled for a permissions sync: %v, want %v", permsSyncRepoIDs, want)
+	}
+}
@@ -0,0 +1,117 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+type RepositorySnapshotsResolver interface {
+	// Queries
+	RepositorySnapshots(ctx context.Context, args *RepositorySnapshotsArgs) (RepositorySnapshotConnectionResolver, error)
+	RepositorySnapshotSchedules(ctx context.Context) ([]RepositorySnapshotScheduleResolver, error)
+	RepositorySnapshotSigningPublicKey(ctx context.Context) (*string, error)
+
+	// Mutations
+	CreateRepositorySnapshot(ctx context.Context, args *CreateRepositorySnapshotArgs) (RepositorySnapshotResolver, error)
+	DeleteRepositorySnapshot(ctx context.Context, args *DeleteRepositorySnapshotArgs) (*EmptyResponse, error)
+	CreateRepositorySnapshotSchedule(ctx context.Context, args *CreateRepositorySnapshotScheduleArgs) (RepositorySnapshotScheduleResolver, error)
+	UpdateRepositorySnapshotSchedule(ctx context.Context, args *UpdateRepositorySnapshotScheduleArgs) (RepositorySnapshotScheduleResolver, error)
+	DeleteRepositorySnapshotSchedule(ctx context.Context, args *DeleteRepositorySnapshotScheduleArgs) (*EmptyResponse, error)
+
+	NodeResolvers() map[string]NodeByIDFunc
+}
+
+type RepositorySnapshotsArgs struct {
+	First    int32
+	After    *string
+	Schedule *graphql.ID
+}
+
+type RepositorySnapshotTargetInput struct {
+	Repository graphql.ID
+	Revision   *string
+}
+
+type CreateRepositorySnapshotArgs struct {
+	Targets []RepositorySnapshotTargetInput
+}
+
+type DeleteRepositorySnapshotArgs struct {
+	ID graphql.ID
+}
+
+type CreateRepositorySnapshotScheduleArgs struct {
+	Name           string
+	Targets        []RepositorySnapshotTargetInput
+	CronExpression string
+	Enabled        bool
+}
+
+type UpdateRepositorySnapshotScheduleArgs struct {
+	ID             graphql.ID
+	Name           *string
+	Targets        *[]RepositorySnapshotTargetInput
+	CronExpression *string
+	Enabled        *bool
+}
+
+type DeleteRepositorySnapshotScheduleArgs struct {
+	ID graphql.ID
+}
+
+type RepositorySnapshotConnectionResolver interface {
+	Nodes(ctx context.Context) ([]RepositorySnapshotResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type RepositorySnapshotTargetResolver interface {
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	Revision() string
+}
+
+type RepositorySnapshotResolver interface {
+	ID() graphql.ID
+	State() string
+	Failure() *string
+	Targets() []RepositorySnapshotTargetResolver
+	Schedule(ctx context.Context) (RepositorySnapshotScheduleResolver, error)
+	Initiator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+	StartedAt() *gqlutil.DateTime
+	FinishedAt() *gqlutil.DateTime
+	Manifest() RepositorySnapshotManifestResolver
+}
+
+type RepositorySnapshotManifestResolver interface {
+	Archives() []RepositorySnapshotArchiveResolver
+	URL() (string, error)
+	Signature() string
+	SignatureURL() (string, error)
+}
+
+type RepositorySnapshotArchiveResolver interface {
+	RepositoryName() string
+	Revision() string
+	Commit() string
+	SHA256() string
+	Size() BigInt
+	URL() (string, error)
+}
+
+type RepositorySnapshotScheduleResolver interface {
+	ID() graphql.ID
+	Name() string
+	Targets() []RepositorySnapshotTargetResolver
+	CronExpression() string
+	Enabled() bool
+	LastEnqueuedAt() *gqlutil.DateTime
+	NextRunAt() *gqlutil.DateTime
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+}
@@ -0,0 +1,18 @@
+package graphqlbackend
+
+import "testing"
+
+func TestRepositorySnapshots(t *testing.T) {
+	// This test is just asserting that our interface is correct. It seems
+	// graphql-go only does the schema check if your interface is non-nil.
+	_, err := NewSchema(nil, nil, []OptionalResolver{{RepositorySnapshotsResolver: repositorySnapshotsFake{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+type repositorySnapshotsFake struct {
+	RepositorySnapshotsResolver
+}
+
+func (repositorySnapshotsFake) NodeResolvers() map[string]NodeByIDFunc { return nil }
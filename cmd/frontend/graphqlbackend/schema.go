@@ -120,6 +120,21 @@ var contentLibrary string
 //go:embed search_jobs.graphql
 var searchJobSchema string
 
+// executorJobArtifactsSchema is the Sourcegraph executor job artifacts raw graphql schema.
+//
+//go:embed executor_job_artifacts.graphql
+var executorJobArtifactsSchema string
+
+// repoViewsSchema is the repository views raw graphql schema.
+//
+//go:embed repo_views.graphql
+var repoViewsSchema string
+
+// repositorySnapshotsSchema is the Sourcegraph repository snapshots raw graphql schema.
+//
+//go:embed repository_snapshots.graphql
+var repositorySnapshotsSchema string
+
 // telemetrySchema is the Sourcegraph Telemetry V2 raw graphql schema.
 //
 //go:embed telemetry.graphql
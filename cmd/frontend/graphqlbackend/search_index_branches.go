@@ -0,0 +1,213 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grafana/regexp"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type searchIndexBranchRuleResolver struct {
+	rule *database.SearchIndexBranchRule
+}
+
+func marshalSearchIndexBranchRuleID(id int32) graphql.ID {
+	return relay.MarshalID("SearchIndexBranchRule", id)
+}
+
+func unmarshalSearchIndexBranchRuleID(id graphql.ID) (ruleID int32, err error) {
+	err = relay.UnmarshalSpec(id, &ruleID)
+	return
+}
+
+func (r *searchIndexBranchRuleResolver) ID() graphql.ID {
+	return marshalSearchIndexBranchRuleID(r.rule.ID)
+}
+
+func (r *searchIndexBranchRuleResolver) RepositoryPattern() string {
+	return r.rule.RepoPattern
+}
+
+func (r *searchIndexBranchRuleResolver) Branches() []string {
+	return r.rule.Branches
+}
+
+func (r *searchIndexBranchRuleResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rule.CreatedAt}
+}
+
+func (r *searchIndexBranchRuleResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rule.UpdatedAt}
+}
+
+func (r *schemaResolver) SearchIndexBranchRules(ctx context.Context) ([]*searchIndexBranchRuleResolver, error) {
+	// 🚨 SECURITY: Only site admins may view the search indexing configuration.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	rules, err := r.db.SearchIndexBranches().ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*searchIndexBranchRuleResolver, 0, len(rules))
+	for _, rule := range rules {
+		resolvers = append(resolvers, &searchIndexBranchRuleResolver{rule: rule})
+	}
+	return resolvers, nil
+}
+
+type searchIndexBranchRuleArgs struct {
+	RepositoryPattern string
+	Branches          []string
+}
+
+func (r *schemaResolver) CreateSearchIndexBranchRule(ctx context.Context, args *searchIndexBranchRuleArgs) (*searchIndexBranchRuleResolver, error) {
+	// 🚨 SECURITY: Only site admins may change the search indexing configuration.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if err := validateSearchIndexBranchRule(args.RepositoryPattern, args.Branches); err != nil {
+		return nil, err
+	}
+
+	rule := &database.SearchIndexBranchRule{RepoPattern: args.RepositoryPattern, Branches: args.Branches}
+	if err := r.db.SearchIndexBranches().CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return &searchIndexBranchRuleResolver{rule: rule}, nil
+}
+
+func (r *schemaResolver) UpdateSearchIndexBranchRule(ctx context.Context, args *struct {
+	ID graphql.ID
+	searchIndexBranchRuleArgs
+}) (*searchIndexBranchRuleResolver, error) {
+	// 🚨 SECURITY: Only site admins may change the search indexing configuration.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalSearchIndexBranchRuleID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSearchIndexBranchRule(args.RepositoryPattern, args.Branches); err != nil {
+		return nil, err
+	}
+
+	rule := &database.SearchIndexBranchRule{ID: id, RepoPattern: args.RepositoryPattern, Branches: args.Branches}
+	if err := r.db.SearchIndexBranches().UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return &searchIndexBranchRuleResolver{rule: rule}, nil
+}
+
+func (r *schemaResolver) DeleteSearchIndexBranchRule(ctx context.Context, args *struct {
+	ID graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may change the search indexing configuration.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalSearchIndexBranchRuleID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.SearchIndexBranches().DeleteRule(ctx, id); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) SetRepositorySearchIndexBranches(ctx context.Context, args *struct {
+	Repository graphql.ID
+	Branches   []string
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may change the search indexing configuration.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, err := UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSearchIndexBranches(args.Branches); err != nil {
+		return nil, err
+	}
+
+	if err := r.db.SearchIndexBranches().SetRepoBranches(ctx, repoID, args.Branches); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *RepositoryResolver) SearchIndexBranches(ctx context.Context) ([]string, error) {
+	// 🚨 SECURITY: Only site admins may view the search indexing configuration.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	store := r.db.SearchIndexBranches()
+
+	rules, err := store.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indexRules := make([]searchbackend.IndexBranchRule, 0, len(rules))
+	for _, rule := range rules {
+		indexRules = append(indexRules, searchbackend.IndexBranchRule{RepoPattern: rule.RepoPattern, Branches: rule.Branches})
+	}
+
+	repoBranches, err := store.GetRepoBranches(ctx, []api.RepoID{r.IDInt32()})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	branches := []string{}
+	for _, branch := range append(repoBranches[r.IDInt32()], searchbackend.IndexBranchRulesFunc(indexRules)(r.Name())...) {
+		if _, ok := seen[branch]; !ok {
+			seen[branch] = struct{}{}
+			branches = append(branches, branch)
+		}
+	}
+	return branches, nil
+}
+
+func validateSearchIndexBranchRule(repoPattern string, branches []string) error {
+	if _, err := regexp.Compile(repoPattern); err != nil {
+		return errors.Wrap(err, "invalid repository pattern")
+	}
+	if len(branches) == 0 {
+		return errors.New("at least one branch is required")
+	}
+	return validateSearchIndexBranches(branches)
+}
+
+func validateSearchIndexBranches(branches []string) error {
+	for _, branch := range branches {
+		if strings.TrimSpace(branch) == "" {
+			return errors.New("branch names must not be empty")
+		}
+		// Branches of a single repository are stored as a comma-separated list
+		// in repo metadata.
+		if strings.Contains(branch, ",") {
+			return errors.Newf("invalid branch name %q", branch)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearchIndexBranchRules(t *testing.T) {
+	db := dbmocks.NewMockDB()
+
+	userStore := dbmocks.NewMockUserStore()
+	db.UsersFunc.SetDefaultReturn(userStore)
+
+	branchStore := dbmocks.NewMockSearchIndexBranchStore()
+	db.SearchIndexBranchesFunc.SetDefaultReturn(branchStore)
+
+	createdAt, _ := time.Parse(time.RFC3339, "2023-12-18T10:00:00Z")
+	branchStore.ListRulesFunc.SetDefaultReturn([]*database.SearchIndexBranchRule{
+		{ID: 1, RepoPattern: "^github\\.com/sourcegraph/", Branches: []string{"release/5.2"}, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}, nil)
+	branchStore.CreateRuleFunc.SetDefaultHook(func(_ context.Context, rule *database.SearchIndexBranchRule) error {
+		rule.ID = 2
+		rule.CreatedAt = createdAt
+		rule.UpdatedAt = createdAt
+		return nil
+	})
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("non-admin user", func(t *testing.T) {
+		userStore.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: false}, nil)
+		RunTest(t, &Test{
+			Schema:         mustParseGraphQLSchema(t, db),
+			Context:        ctx,
+			Query:          `{ searchIndexBranchRules { id } }`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Path:          []any{"searchIndexBranchRules"},
+					Message:       auth.ErrMustBeSiteAdmin.Error(),
+					ResolverError: auth.ErrMustBeSiteAdmin,
+				},
+			},
+		})
+	})
+
+	userStore.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	t.Run("list", func(t *testing.T) {
+		RunTest(t, &Test{
+			Schema:  mustParseGraphQLSchema(t, db),
+			Context: ctx,
+			Query:   `{ searchIndexBranchRules { id repositoryPattern branches createdAt } }`,
+			ExpectedResult: `{
+				"searchIndexBranchRules": [{
+					"id": "U2VhcmNoSW5kZXhCcmFuY2hSdWxlOjE=",
+					"repositoryPattern": "^github\\.com/sourcegraph/",
+					"branches": ["release/5.2"],
+					"createdAt": "2023-12-18T10:00:00Z"
+				}]
+			}`,
+		})
+	})
+
+	t.Run("create", func(t *testing.T) {
+		RunTest(t, &Test{
+			Schema:  mustParseGraphQLSchema(t, db),
+			Context: ctx,
+			Query: `mutation {
+				createSearchIndexBranchRule(repositoryPattern: "zoekt$", branches: ["dev", "qa"]) { id branches }
+			}`,
+			ExpectedResult: `{
+				"createSearchIndexBranchRule": {
+					"id": "U2VhcmNoSW5kZXhCcmFuY2hSdWxlOjI=",
+					"branches": ["dev", "qa"]
+				}
+			}`,
+		})
+	})
+
+	t.Run("update", func(t *testing.T) {
+		RunTest(t, &Test{
+			Schema:  mustParseGraphQLSchema(t, db),
+			Context: ctx,
+			Query: `mutation {
+				updateSearchIndexBranchRule(id: "U2VhcmNoSW5kZXhCcmFuY2hSdWxlOjI=", repositoryPattern: "zoekt$", branches: ["dev"]) { id branches }
+			}`,
+			ExpectedResult: `{
+				"updateSearchIndexBranchRule": {
+					"id": "U2VhcmNoSW5kZXhCcmFuY2hSdWxlOjI=",
+					"branches": ["dev"]
+				}
+			}`,
+		})
+	})
+
+	t.Run("create with invalid pattern", func(t *testing.T) {
+		_, err := newSchemaResolver(db, nil).CreateSearchIndexBranchRule(ctx, &searchIndexBranchRuleArgs{RepositoryPattern: "(", Branches: []string{"dev"}})
+		if err == nil {
+			t.Fatal("expected error for invalid pattern")
+		}
+	})
+
+	t.Run("set repository branches", func(t *testing.T) {
+		RunTest(t, &Test{
+			Schema:  mustParseGraphQLSchema(t, db),
+			Context: ctx,
+			Query: `mutation {
+				setRepositorySearchIndexBranches(repository: "UmVwb3NpdG9yeTo1", branches: ["release-1"]) { alwaysNil }
+			}`,
+			ExpectedResult: `{"setRepositorySearchIndexBranches": {"alwaysNil": null}}`,
+		})
+
+		calls := branchStore.SetRepoBranchesFunc.History()
+		if len(calls) != 1 || calls[0].Arg1 != api.RepoID(5) || len(calls[0].Arg2) != 1 || calls[0].Arg2[0] != "release-1" {
+			t.Fatalf("unexpected calls to SetRepoBranches: %+v", calls)
+		}
+	})
+}
@@ -3,8 +3,13 @@ package graphqlbackend
 import (
 	"context"
 
+	"github.com/graph-gophers/graphql-go"
+
 	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/session"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -34,3 +39,72 @@ type sessionResolver struct {
 }
 
 func (r *sessionResolver) CanSignOut() bool { return r.canSignOut }
+
+func (r *UserResolver) ActiveSessions(ctx context.Context) ([]*activeSessionResolver, error) {
+	// 🚨 SECURITY: Only the user and site admins can list the sessions of the user.
+	if err := auth.CheckSiteAdminOrSameUserFromActor(r.actor, r.db, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := session.ListActiveSessions(r.user.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*activeSessionResolver, 0, len(sessions))
+	for _, s := range sessions {
+		resolvers = append(resolvers, &activeSessionResolver{s: s})
+	}
+	return resolvers, nil
+}
+
+type activeSessionResolver struct {
+	s *session.ActiveSession
+}
+
+func (r *activeSessionResolver) ID() string { return r.s.ID }
+
+func (r *activeSessionResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.s.CreatedAt}
+}
+
+func (r *activeSessionResolver) LastActiveAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.s.LastActive}
+}
+
+func (r *activeSessionResolver) ExpiresAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.s.ExpiresAt}
+}
+
+func (r *activeSessionResolver) IPAddress() *string {
+	if r.s.IP == "" {
+		return nil
+	}
+	return &r.s.IP
+}
+
+func (r *activeSessionResolver) UserAgent() *string {
+	if r.s.UserAgent == "" {
+		return nil
+	}
+	return &r.s.UserAgent
+}
+
+func (r *schemaResolver) RevokeActiveSession(ctx context.Context, args *struct {
+	User    graphql.ID
+	Session string
+},
+) (*EmptyResponse, error) {
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only the user and site admins can revoke the sessions of the user.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, userID); err != nil {
+		return nil, err
+	}
+
+	if err := session.RevokeSession(userID, args.Session); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
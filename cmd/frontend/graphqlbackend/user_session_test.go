@@ -0,0 +1,107 @@
+package graphqlbackend
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/session"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestActiveSessions(t *testing.T) {
+	t.Cleanup(session.ResetMockSessionStore(t))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	if err := session.SetActor(httptest.NewRecorder(), req, &actor.Actor{UID: 1}, time.Hour, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := session.ListActiveSessions(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 active session, got %d", len(sessions))
+	}
+
+	users := dbmocks.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "alice"}, nil
+	})
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: actor.FromContext(ctx).UID}, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	RunTests(t, []*Test{
+		{
+			Label:   "list own sessions",
+			Context: actor.WithActor(context.Background(), actor.FromUser(1)),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				query($user: ID!) {
+					node(id: $user) {
+						... on User {
+							activeSessions { userAgent ipAddress }
+						}
+					}
+				}
+			`,
+			Variables: map[string]any{"user": string(MarshalUserID(1))},
+			ExpectedResult: `
+				{
+					"node": {
+						"activeSessions": [{ "userAgent": "test-agent", "ipAddress": "192.0.2.1" }]
+					}
+				}
+			`,
+		},
+		{
+			Label:   "revoke session of other user",
+			Context: actor.WithActor(context.Background(), actor.FromUser(2)),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation($user: ID!, $session: String!) {
+					revokeActiveSession(user: $user, session: $session) { alwaysNil }
+				}
+			`,
+			Variables:      map[string]any{"user": string(MarshalUserID(1)), "session": sessions[0].ID},
+			ExpectedResult: `{ "revokeActiveSession": null }`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: auth.ErrMustBeSiteAdminOrSameUser.Error(),
+					Path:    []any{"revokeActiveSession"},
+				},
+			},
+		},
+		{
+			Label:   "revoke own session",
+			Context: actor.WithActor(context.Background(), actor.FromUser(1)),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation($user: ID!, $session: String!) {
+					revokeActiveSession(user: $user, session: $session) { alwaysNil }
+				}
+			`,
+			Variables:      map[string]any{"user": string(MarshalUserID(1)), "session": sessions[0].ID},
+			ExpectedResult: `{ "revokeActiveSession": { "alwaysNil": null } }`,
+		},
+	})
+
+	sessions, err = session.ListActiveSessions(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("expected no active sessions, got %d", len(sessions))
+	}
+}
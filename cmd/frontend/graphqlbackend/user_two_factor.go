@@ -0,0 +1,226 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/auth/userpasswd"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type twoFactorAuthenticationResolver struct {
+	enabled                bool
+	required               bool
+	recoveryCodesRemaining int32
+}
+
+func (r *twoFactorAuthenticationResolver) Enabled() bool { return r.enabled }
+
+func (r *twoFactorAuthenticationResolver) Required() bool { return r.required }
+
+func (r *twoFactorAuthenticationResolver) RecoveryCodesRemaining() int32 {
+	return r.recoveryCodesRemaining
+}
+
+func (r *UserResolver) TwoFactorAuthentication(ctx context.Context) (*twoFactorAuthenticationResolver, error) {
+	// 🚨 SECURITY: Only the user and site admins may see the two-factor
+	// authentication status of the user.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	res := &twoFactorAuthenticationResolver{required: userpasswd.TwoFactorRequired(r.user)}
+	cred, err := userpasswd.TOTPCredentials(r.db).GetByUserID(ctx, r.user.ID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return res, nil
+		}
+		return nil, err
+	}
+	if cred.Enabled() {
+		res.enabled = true
+		res.recoveryCodesRemaining = int32(cred.RecoveryCodesRemaining)
+	}
+	return res, nil
+}
+
+type twoFactorEnrollmentResolver struct {
+	enrollment *userpasswd.TwoFactorEnrollment
+}
+
+func (r *twoFactorEnrollmentResolver) Secret() string { return r.enrollment.Secret }
+
+func (r *twoFactorEnrollmentResolver) ProvisioningURI() string {
+	return r.enrollment.ProvisioningURI
+}
+
+type twoFactorRecoveryCodesResolver struct {
+	recoveryCodes []string
+}
+
+func (r *twoFactorRecoveryCodesResolver) RecoveryCodes() []string { return r.recoveryCodes }
+
+// currentBuiltinAuthUser returns the current user if they use the builtin auth
+// provider and act from a user session.
+func (r *schemaResolver) currentBuiltinAuthUser(ctx context.Context) (*types.User, error) {
+	// 🚨 SECURITY: Two-factor authentication may only be changed from a user
+	// session, not with an access token.
+	if !actor.FromContext(ctx).FromSessionCookie {
+		return nil, errors.New("only allowed from user session")
+	}
+
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("no authenticated user")
+	}
+	if !user.BuiltinAuth || !providers.BuiltinAuthEnabled() {
+		return nil, errors.New("two-factor authentication is only available for users of the builtin auth provider")
+	}
+	return user, nil
+}
+
+func (r *schemaResolver) EnrollTwoFactorAuthentication(ctx context.Context) (*twoFactorEnrollmentResolver, error) {
+	// 🚨 SECURITY: Only the authenticated user can enroll themselves.
+	user, err := r.currentBuiltinAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := userpasswd.StartTwoFactorEnrollment(ctx, r.db, user)
+	if err != nil {
+		return nil, err
+	}
+	return &twoFactorEnrollmentResolver{enrollment: enrollment}, nil
+}
+
+func (r *schemaResolver) ConfirmTwoFactorAuthentication(ctx context.Context, args *struct {
+	Code string
+},
+) (*twoFactorRecoveryCodesResolver, error) {
+	// 🚨 SECURITY: Only the authenticated user can confirm their enrollment.
+	user, err := r.currentBuiltinAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := userpasswd.ConfirmTwoFactorEnrollment(ctx, r.db, nil, user.ID, args.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &twoFactorRecoveryCodesResolver{recoveryCodes: recoveryCodes}, nil
+}
+
+func (r *schemaResolver) RegenerateTwoFactorRecoveryCodes(ctx context.Context, args *struct {
+	Code string
+},
+) (*twoFactorRecoveryCodesResolver, error) {
+	// 🚨 SECURITY: Only the authenticated user can regenerate their recovery
+	// codes, and only with a valid second factor.
+	user, err := r.currentBuiltinAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.verifyTwoFactorCode(ctx, user.ID, args.Code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := userpasswd.RegenerateTwoFactorRecoveryCodes(ctx, r.db, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &twoFactorRecoveryCodesResolver{recoveryCodes: recoveryCodes}, nil
+}
+
+func (r *schemaResolver) DisableTwoFactorAuthentication(ctx context.Context, args *struct {
+	User graphql.ID
+	Code *string
+},
+) (*EmptyResponse, error) {
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+
+	a := actor.FromContext(ctx)
+	if a.UID == userID {
+		// 🚨 SECURITY: Users may only disable their own two-factor
+		// authentication with a valid second factor, and only if they are not
+		// required to use it.
+		user, err := r.currentBuiltinAuthUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if userpasswd.TwoFactorRequired(user) {
+			return nil, errors.New("two-factor authentication is required by the site configuration")
+		}
+		code := ""
+		if args.Code != nil {
+			code = *args.Code
+		}
+		if err := r.verifyTwoFactorCode(ctx, user.ID, code); err != nil {
+			return nil, err
+		}
+	} else {
+		// 🚨 SECURITY: Only site admins may disable two-factor authentication
+		// of other users.
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := userpasswd.TOTPCredentials(r.db).Delete(ctx, userID); err != nil {
+		return nil, err
+	}
+	database.LogPasswordEvent(ctx, r.db, nil, database.SecurityEventNameTwoFactorDisabled, userID)
+	return &EmptyResponse{}, nil
+}
+
+// twoFactorLockoutStore returns the store of failed sign-in attempts, which
+// also counts wrong two-factor authentication codes. It is replaced in tests.
+var twoFactorLockoutStore = func() userpasswd.LockoutStore {
+	return userpasswd.NewLockoutStoreFromConf(conf.AuthLockout())
+}
+
+// verifyTwoFactorCode returns an error unless code is a valid second factor of
+// the user with enabled two-factor authentication.
+//
+// 🚨 SECURITY: Wrong codes count as failed sign-in attempts, so that codes
+// cannot be guessed here more often than when signing in.
+func (r *schemaResolver) verifyTwoFactorCode(ctx context.Context, userID int32, code string) error {
+	lockout := twoFactorLockoutStore()
+	if reason, locked := lockout.IsLockedOut(userID); locked {
+		return errors.Newf("account has been locked out due to %q", reason)
+	}
+
+	cred, err := userpasswd.TOTPCredentials(r.db).GetByUserID(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.New("two-factor authentication is not enabled")
+		}
+		return err
+	}
+	if !cred.Enabled() {
+		return errors.New("two-factor authentication is not enabled")
+	}
+
+	ok, err := userpasswd.VerifyTwoFactorCode(ctx, r.db, nil, cred, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		lockout.IncreaseFailedAttempt(userID)
+		return userpasswd.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/auth/userpasswd"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type mockBuiltinAuthProvider struct {
+	mockAuthnProvider
+	c *schema.BuiltinAuthProvider
+}
+
+func (m mockBuiltinAuthProvider) Config() schema.AuthProviders {
+	return schema.AuthProviders{Builtin: m.c}
+}
+
+// fakeLockoutStore locks users out after a single failed attempt.
+type fakeLockoutStore struct {
+	userpasswd.LockoutStore
+	failed map[int32]int
+}
+
+func (s *fakeLockoutStore) IsLockedOut(userID int32) (string, bool) {
+	if s.failed[userID] > 0 {
+		return "too many failed attempts", true
+	}
+	return "", false
+}
+
+func (s *fakeLockoutStore) IncreaseFailedAttempt(userID int32) { s.failed[userID]++ }
+
+func TestTwoFactorAuthentication(t *testing.T) {
+	builtin := &schema.BuiltinAuthProvider{Type: "builtin", RequireTwoFactor: "siteAdmins"}
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		AuthProviders: []schema.AuthProviders{{Builtin: builtin}},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+	providers.MockProviders = []providers.Provider{mockBuiltinAuthProvider{c: builtin}}
+	t.Cleanup(func() { providers.MockProviders = nil })
+	lockout := &fakeLockoutStore{failed: map[int32]int{}}
+	twoFactorLockoutStore = func() userpasswd.LockoutStore { return lockout }
+	t.Cleanup(func() {
+		twoFactorLockoutStore = func() userpasswd.LockoutStore {
+			return userpasswd.NewLockoutStoreFromConf(conf.AuthLockout())
+		}
+	})
+
+	alice := &types.User{ID: 1, Username: "alice", BuiltinAuth: true}
+	admin := &types.User{ID: 2, Username: "admin", BuiltinAuth: true, SiteAdmin: true}
+
+	users := dbmocks.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		if id == admin.ID {
+			return admin, nil
+		}
+		return alice, nil
+	})
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		return users.GetByID(ctx, actor.FromContext(ctx).UID)
+	})
+
+	creds := dbmocks.NewMockUserTOTPCredentialStore()
+	creds.GetByUserIDFunc.SetDefaultReturn(database.NewMockUserTOTPCredential(&database.UserTOTPCredential{
+		UserID:                 alice.ID,
+		RecoveryCodesRemaining: 7,
+		EnabledAt:              pointers.Ptr(time.Now()),
+	}, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"), nil)
+
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserTOTPCredentialsFunc.SetDefaultReturn(creds)
+	db.EventLogsFunc.SetDefaultReturn(dbmocks.NewMockEventLogStore())
+	db.SecurityEventLogsFunc.SetDefaultReturn(dbmocks.NewMockSecurityEventLogsStore())
+
+	fromSession := func(uid int32) context.Context {
+		a := actor.FromUser(uid)
+		a.FromSessionCookie = true
+		return actor.WithActor(context.Background(), a)
+	}
+	aliceID := string(MarshalUserID(alice.ID))
+
+	RunTests(t, []*Test{
+		{
+			Label:   "status",
+			Context: fromSession(alice.ID),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				query($user: ID!) {
+					node(id: $user) {
+						... on User {
+							twoFactorAuthentication { enabled required recoveryCodesRemaining }
+						}
+					}
+				}
+			`,
+			Variables: map[string]any{"user": aliceID},
+			ExpectedResult: `
+				{
+					"node": {
+						"twoFactorAuthentication": { "enabled": true, "required": false, "recoveryCodesRemaining": 7 }
+					}
+				}
+			`,
+		},
+		{
+			Label:   "enroll from access token",
+			Context: actor.WithActor(context.Background(), actor.FromUser(alice.ID)),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					enrollTwoFactorAuthentication { secret }
+				}
+			`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: "only allowed from user session",
+					Path:    []any{"enrollTwoFactorAuthentication"},
+				},
+			},
+		},
+		{
+			Label:   "disable own without code",
+			Context: fromSession(alice.ID),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation($user: ID!) {
+					disableTwoFactorAuthentication(user: $user) { alwaysNil }
+				}
+			`,
+			Variables:      map[string]any{"user": aliceID},
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: "invalid two-factor authentication code",
+					Path:    []any{"disableTwoFactorAuthentication"},
+				},
+			},
+		},
+		{
+			// The wrong code of the previous test locked the user out.
+			Label:   "regenerate recovery codes while locked out",
+			Context: fromSession(alice.ID),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					regenerateTwoFactorRecoveryCodes(code: "123456") { recoveryCodes }
+				}
+			`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: `account has been locked out due to "too many failed attempts"`,
+					Path:    []any{"regenerateTwoFactorRecoveryCodes"},
+				},
+			},
+		},
+		{
+			Label:   "disable required for site admin",
+			Context: fromSession(admin.ID),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation($user: ID!) {
+					disableTwoFactorAuthentication(user: $user, code: "123456") { alwaysNil }
+				}
+			`,
+			Variables:      map[string]any{"user": string(MarshalUserID(admin.ID))},
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{
+					Message: "two-factor authentication is required by the site configuration",
+					Path:    []any{"disableTwoFactorAuthentication"},
+				},
+			},
+		},
+		{
+			Label:   "site admin disables for other user",
+			Context: actor.WithActor(context.Background(), actor.FromUser(admin.ID)),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation($user: ID!) {
+					disableTwoFactorAuthentication(user: $user) { alwaysNil }
+				}
+			`,
+			Variables:      map[string]any{"user": aliceID},
+			ExpectedResult: `{ "disableTwoFactorAuthentication": { "alwaysNil": null } }`,
+		},
+	})
+
+	mockrequire.CalledOnceWith(t, creds.DeleteFunc, mockrequire.Values(mockrequire.Skip, alice.ID))
+	mockrequire.NotCalled(t, creds.SetRecoveryCodesFunc)
+	if want := map[int32]int{alice.ID: 1}; !reflect.DeepEqual(lockout.failed, want) {
+		t.Errorf("unexpected failed attempts: got %v, want %v", lockout.failed, want)
+	}
+}
@@ -68,6 +68,7 @@ func Init(
 	})
 
 	enterpriseServices.PermissionsGitHubWebhook = webhooks.NewGitHubWebhook(log.Scoped("PermissionsGitHubWebhook"))
+	enterpriseServices.PermissionsBitbucketCloudWebhook = webhooks.NewBitbucketCloudWebhook(log.Scoped("PermissionsBitbucketCloudWebhook"))
 
 	authz.DefaultSubRepoPermsChecker = srp.NewSubRepoPermsClient(db.SubRepoPerms())
 
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"time"
 
@@ -132,6 +133,16 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 		return nil, errors.Wrap(err, "set repository pending permissions")
 	}
 
+	r.db.AuditEvents().Log(ctx, &database.AuditEvent{
+		Action:     database.AuditActionRepoPermissionsSet,
+		EntityType: database.AuditEntityRepo,
+		EntityID:   strconv.Itoa(int(repoID)),
+		Metadata: map[string]any{
+			"userIDs":        userIDs.Sorted(func(a, b int32) bool { return a < b }),
+			"pendingBindIDs": pendingBindIDs,
+		},
+	})
+
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
@@ -228,13 +228,23 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 				return m, nil
 			})
 
+			auditEvents := dbmocks.NewMockAuditEventStore()
+
 			db := dbmocks.NewStrictMockDB()
 			db.UsersFunc.SetDefaultReturn(users)
 			db.UserEmailsFunc.SetDefaultReturn(userEmails)
 			db.ReposFunc.SetDefaultReturn(repos)
 			db.PermsFunc.SetDefaultReturn(perms)
+			db.AuditEventsFunc.SetDefaultReturn(auditEvents)
 
 			graphqlbackend.RunTests(t, test.gqlTests(db))
+
+			require.Len(t, auditEvents.LogFunc.History(), 1)
+			event := auditEvents.LogFunc.History()[0].Arg1
+			assert.Equal(t, database.AuditActionRepoPermissionsSet, event.Action)
+			assert.Equal(t, "1", event.EntityID)
+			assert.ElementsMatch(t, maps.Keys(test.expUserIDs), event.Metadata["userIDs"])
+			assert.Equal(t, test.expAccounts.AccountIDs, event.Metadata["pendingBindIDs"])
 		})
 	}
 }
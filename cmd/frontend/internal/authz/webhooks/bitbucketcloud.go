@@ -0,0 +1,141 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz/permssync"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var bitbucketCloudEvents = []string{
+	"repo:created",
+	"repo:transfer",
+	"repo:updated",
+}
+
+type BitbucketCloudWebhook struct {
+	logger log.Logger
+}
+
+func NewBitbucketCloudWebhook(logger log.Logger) *BitbucketCloudWebhook {
+	return &BitbucketCloudWebhook{logger: logger}
+}
+
+func (h *BitbucketCloudWebhook) Register(router *webhooks.Router) {
+	router.Register(
+		h.handleBitbucketCloudWebhook,
+		extsvc.KindBitbucketCloud,
+		bitbucketCloudEvents...,
+	)
+}
+
+func (h *BitbucketCloudWebhook) handleBitbucketCloudWebhook(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL, payload any) error {
+	switch e := payload.(type) {
+	case *bitbucketcloud.RepoCreatedEvent:
+		// The new repository only exists on Sourcegraph once the code host
+		// connection has been synced, which the repository webhook handler
+		// enqueues, so we wait for it before syncing its permissions.
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), repoCreatedSyncTimeout)
+			defer cancel()
+			if err := h.waitForRepoAndSyncPerms(ctx, db, &e.Repository, codeHostURN, database.ReasonBitbucketCloudRepoCreatedEvent); err != nil {
+				h.logger.Warn("failed to sync permissions of created repository", log.String("repo", e.Repository.FullName), log.Error(err))
+			}
+		}()
+		return nil
+	case *bitbucketcloud.RepoTransferEvent:
+		return h.getRepoAndSyncPerms(ctx, db, &e.Repository, codeHostURN, database.ReasonBitbucketCloudRepoTransferredEvent)
+	case *bitbucketcloud.RepoUpdatedEvent:
+		// On repository updates, we only care if a public repository is made
+		// private, in which case a permissions sync should happen.
+		if e.Changes.IsPrivate == nil || !e.Changes.IsPrivate.New {
+			return nil
+		}
+		return h.getRepoAndSyncPerms(ctx, db, &e.Repository, codeHostURN, database.ReasonBitbucketCloudRepoMadePrivateEvent)
+	}
+	return nil
+}
+
+var (
+	// repoCreatedSyncTimeout bounds how long we wait for a created repository
+	// to be synced from the code host.
+	repoCreatedSyncTimeout = 10 * time.Minute
+	// repoCreatedPollInterval is how often we check whether a created
+	// repository has been synced.
+	repoCreatedPollInterval = 10 * time.Second
+)
+
+// waitForRepoAndSyncPerms schedules a permissions sync of the given repository
+// once it has been synced from the code host, or returns ctx.Err() if it isn't
+// synced before ctx is done.
+func (h *BitbucketCloudWebhook) waitForRepoAndSyncPerms(ctx context.Context, db database.DB, bbRepo *bitbucketcloud.Repo, codeHostURN extsvc.CodeHostBaseURL, reason database.PermissionsSyncJobReason) error {
+	ticker := time.NewTicker(repoCreatedPollInterval)
+	defer ticker.Stop()
+
+	for {
+		repo, err := h.getRepo(ctx, db, bbRepo, codeHostURN)
+		if err != nil {
+			return err
+		}
+		if repo != nil {
+			permssync.SchedulePermsSync(ctx, h.logger, db, permssync.ScheduleSyncOpts{
+				RepoIDs: []api.RepoID{repo.ID},
+				Reason:  reason,
+			})
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *BitbucketCloudWebhook) getRepoAndSyncPerms(ctx context.Context, db database.DB, bbRepo *bitbucketcloud.Repo, codeHostURN extsvc.CodeHostBaseURL, reason database.PermissionsSyncJobReason) error {
+	repo, err := h.getRepo(ctx, db, bbRepo, codeHostURN)
+	if err != nil {
+		return err
+	}
+
+	// Repo not existing on Sourcegraph is fine.
+	if repo == nil {
+		h.logger.Debug("bitbucket cloud repository not found", log.String("repo", bbRepo.FullName))
+		return nil
+	}
+
+	permssync.SchedulePermsSync(ctx, h.logger, db, permssync.ScheduleSyncOpts{
+		RepoIDs:      []api.RepoID{repo.ID},
+		Reason:       reason,
+		ProcessAfter: time.Now().Add(sleepTime),
+	})
+
+	return nil
+}
+
+// getRepo returns the Sourcegraph repository of the given Bitbucket Cloud
+// repository, or nil if it doesn't exist on Sourcegraph.
+func (h *BitbucketCloudWebhook) getRepo(ctx context.Context, db database.DB, bbRepo *bitbucketcloud.Repo, codeHostURN extsvc.CodeHostBaseURL) (*types.Repo, error) {
+	// Repositories are looked up by their UUID rather than their clone URL,
+	// since the URL changes when a repository is transferred.
+	repos, err := db.Repos().List(ctx, database.ReposListOptions{
+		ExternalRepos: []api.ExternalRepoSpec{{
+			ID:          bbRepo.UUID,
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   codeHostURN.String(),
+		}},
+	})
+	if err != nil || len(repos) == 0 {
+		return nil, err
+	}
+	return repos[0], nil
+}
@@ -0,0 +1,160 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz/permssync"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBitbucketCloudWebhook(t *testing.T) {
+	codeHostURN, err := extsvc.NewCodeHostBaseURL("https://bitbucket.org")
+	require.NoError(t, err)
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]*types.Repo, error) {
+		require.Len(t, opts.ExternalRepos, 1)
+		assert.Equal(t, extsvc.TypeBitbucketCloud, opts.ExternalRepos[0].ServiceType)
+		assert.Equal(t, "https://bitbucket.org/", opts.ExternalRepos[0].ServiceID)
+		if opts.ExternalRepos[0].ID != "{repo}" {
+			return nil, nil
+		}
+		return []*types.Repo{{ID: 2}}, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	var scheduled []permssync.ScheduleSyncOpts
+	permssync.MockSchedulePermsSync = func(_ context.Context, _ log.Logger, _ database.DB, opts permssync.ScheduleSyncOpts) {
+		scheduled = append(scheduled, opts)
+	}
+	t.Cleanup(func() { permssync.MockSchedulePermsSync = nil })
+
+	TestSetGitHubHandlerSleepTime(t, 0)
+	h := NewBitbucketCloudWebhook(logtest.Scoped(t))
+
+	newRepoEvent := func(userUUID, repoUUID string) bitbucketcloud.RepoEvent {
+		var e bitbucketcloud.RepoEvent
+		e.Actor.UUID = userUUID
+		e.Repository.UUID = repoUUID
+		return e
+	}
+
+	for _, tc := range []struct {
+		name    string
+		payload any
+		want    *permssync.ScheduleSyncOpts
+	}{
+		{
+			name:    "repo transferred",
+			payload: &bitbucketcloud.RepoTransferEvent{RepoEvent: newRepoEvent("{user}", "{repo}")},
+			want:    &permssync.ScheduleSyncOpts{RepoIDs: []api.RepoID{2}, Reason: database.ReasonBitbucketCloudRepoTransferredEvent},
+		},
+		{
+			name:    "unknown repo transferred",
+			payload: &bitbucketcloud.RepoTransferEvent{RepoEvent: newRepoEvent("{user}", "{unknown}")},
+		},
+		{
+			name: "repo made private",
+			payload: &bitbucketcloud.RepoUpdatedEvent{
+				RepoEvent: newRepoEvent("{user}", "{repo}"),
+				Changes:   bitbucketcloud.RepoChanges{IsPrivate: &bitbucketcloud.BoolChange{Old: false, New: true}},
+			},
+			want: &permssync.ScheduleSyncOpts{RepoIDs: []api.RepoID{2}, Reason: database.ReasonBitbucketCloudRepoMadePrivateEvent},
+		},
+		{
+			name: "repo made public",
+			payload: &bitbucketcloud.RepoUpdatedEvent{
+				RepoEvent: newRepoEvent("{user}", "{repo}"),
+				Changes:   bitbucketcloud.RepoChanges{IsPrivate: &bitbucketcloud.BoolChange{Old: true, New: false}},
+			},
+		},
+		{
+			name: "repo renamed",
+			payload: &bitbucketcloud.RepoUpdatedEvent{
+				RepoEvent: newRepoEvent("{user}", "{repo}"),
+				Changes:   bitbucketcloud.RepoChanges{Name: &bitbucketcloud.StringChange{Old: "old", New: "new"}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			scheduled = nil
+
+			err := h.handleBitbucketCloudWebhook(context.Background(), db, codeHostURN, tc.payload)
+			require.NoError(t, err)
+
+			if tc.want == nil {
+				assert.Empty(t, scheduled)
+				return
+			}
+			require.Len(t, scheduled, 1)
+			assert.Equal(t, tc.want.RepoIDs, scheduled[0].RepoIDs)
+			assert.Equal(t, tc.want.Reason, scheduled[0].Reason)
+		})
+	}
+}
+
+func TestBitbucketCloudWebhookRepoCreated(t *testing.T) {
+	codeHostURN, err := extsvc.NewCodeHostBaseURL("https://bitbucket.org")
+	require.NoError(t, err)
+
+	old := repoCreatedPollInterval
+	t.Cleanup(func() { repoCreatedPollInterval = old })
+	repoCreatedPollInterval = time.Millisecond
+
+	var scheduled []permssync.ScheduleSyncOpts
+	permssync.MockSchedulePermsSync = func(_ context.Context, _ log.Logger, _ database.DB, opts permssync.ScheduleSyncOpts) {
+		scheduled = append(scheduled, opts)
+	}
+	t.Cleanup(func() { permssync.MockSchedulePermsSync = nil })
+
+	h := NewBitbucketCloudWebhook(logtest.Scoped(t))
+	bbRepo := &bitbucketcloud.Repo{UUID: "{new-repo}", FullName: "workspace/new-repo"}
+
+	t.Run("synced", func(t *testing.T) {
+		scheduled = nil
+
+		// The repository only shows up once the code host connection has
+		// been synced.
+		repos := dbmocks.NewMockRepoStore()
+		repos.ListFunc.PushReturn(nil, nil)
+		repos.ListFunc.SetDefaultReturn([]*types.Repo{{ID: 3}}, nil)
+		db := dbmocks.NewMockDB()
+		db.ReposFunc.SetDefaultReturn(repos)
+
+		err := h.waitForRepoAndSyncPerms(context.Background(), db, bbRepo, codeHostURN, database.ReasonBitbucketCloudRepoCreatedEvent)
+		require.NoError(t, err)
+
+		require.Len(t, repos.ListFunc.History(), 2)
+		require.Len(t, scheduled, 1)
+		assert.Equal(t, []api.RepoID{3}, scheduled[0].RepoIDs)
+		assert.Equal(t, database.ReasonBitbucketCloudRepoCreatedEvent, scheduled[0].Reason)
+	})
+
+	t.Run("never synced", func(t *testing.T) {
+		scheduled = nil
+
+		repos := dbmocks.NewMockRepoStore()
+		db := dbmocks.NewMockDB()
+		db.ReposFunc.SetDefaultReturn(repos)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := h.waitForRepoAndSyncPerms(ctx, db, bbRepo, codeHostURN, database.ReasonBitbucketCloudRepoCreatedEvent)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Empty(t, scheduled)
+	})
+}
@@ -0,0 +1,65 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+type batchSpecValidationResultResolver struct {
+	specErrors []batcheslib.SpecError
+}
+
+var _ graphqlbackend.BatchSpecValidationResultResolver = &batchSpecValidationResultResolver{}
+
+func (r *batchSpecValidationResultResolver) Valid() bool {
+	return len(r.specErrors) == 0
+}
+
+func (r *batchSpecValidationResultResolver) Errors() []graphqlbackend.BatchSpecValidationErrorResolver {
+	resolvers := make([]graphqlbackend.BatchSpecValidationErrorResolver, 0, len(r.specErrors))
+	for _, specError := range r.specErrors {
+		resolvers = append(resolvers, &batchSpecValidationErrorResolver{specError: specError})
+	}
+
+	return resolvers
+}
+
+type batchSpecValidationErrorResolver struct {
+	specError batcheslib.SpecError
+}
+
+var _ graphqlbackend.BatchSpecValidationErrorResolver = &batchSpecValidationErrorResolver{}
+
+func (r *batchSpecValidationErrorResolver) Kind() string {
+	return string(r.specError.Kind)
+}
+
+func (r *batchSpecValidationErrorResolver) Message() string {
+	return r.specError.Message
+}
+
+func (r *batchSpecValidationErrorResolver) Path() *string {
+	if r.specError.Path == "" {
+		return nil
+	}
+
+	return &r.specError.Path
+}
+
+func (r *batchSpecValidationErrorResolver) Line() *int32 {
+	return positionOrNil(r.specError.Line)
+}
+
+func (r *batchSpecValidationErrorResolver) Column() *int32 {
+	return positionOrNil(r.specError.Column)
+}
+
+// positionOrNil returns nil for the zero value, which denotes an unknown position.
+func positionOrNil(position int) *int32 {
+	if position == 0 {
+		return nil
+	}
+
+	p := int32(position)
+	return &p
+}
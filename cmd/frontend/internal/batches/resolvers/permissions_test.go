@@ -913,6 +913,12 @@ query($includeLocallyExecutedSpecs: Boolean) {
 					return fmt.Sprintf(`mutation { reenqueueChangeset(changeset: %q) { id } }`, changesetID)
 				},
 			},
+			{
+				name: "setChangesetExternalCheck",
+				mutationFunc: func(userID, batchChangeID, changesetID, batchSpecID string) string {
+					return fmt.Sprintf(`mutation { setChangesetExternalCheck(changeset: %q, context: "ci/build", state: PASSED) { id } }`, changesetID)
+				},
+			},
 			{
 				name: "applyBatchChange",
 				mutationFunc: func(userID, batchChangeID, changesetID, batchSpecID string) string {
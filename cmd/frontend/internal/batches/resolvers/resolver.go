@@ -246,6 +246,25 @@ func (r *Resolver) ResolveWorkspacesForBatchSpec(ctx context.Context, args *grap
 	return resolvers, nil
 }
 
+func (r *Resolver) ValidateBatchSpec(ctx context.Context, args *graphqlbackend.ValidateBatchSpecArgs) (graphqlbackend.BatchSpecValidationResultResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	// Verify the user is authenticated.
+	act := sgactor.FromContext(ctx)
+	if !act.IsAuthenticated() {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	specErrors, err := service.New(r.store).ValidateBatchSpec(ctx, args.BatchSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecValidationResultResolver{specErrors: specErrors}, nil
+}
+
 func (r *Resolver) batchSpecByID(ctx context.Context, id graphql.ID) (graphqlbackend.BatchSpecResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
@@ -1089,6 +1108,55 @@ func (r *Resolver) ReenqueueChangeset(ctx context.Context, args *graphqlbackend.
 	return NewChangesetResolver(r.store, r.gitserverClient, r.logger, changeset, repo), nil
 }
 
+func (r *Resolver) SetChangesetExternalCheck(ctx context.Context, args *graphqlbackend.SetChangesetExternalCheckArgs) (_ graphqlbackend.ChangesetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetChangesetExternalCheck",
+		attribute.String("changeset", string(args.Changeset)),
+		attribute.String("context", args.Context),
+		attribute.String("state", args.State),
+	)
+	defer tr.EndWithErr(&err)
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	changesetID, err := unmarshalChangesetID(args.Changeset)
+	if err != nil {
+		return nil, err
+	}
+
+	if changesetID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	opts := service.SetChangesetExternalCheckOpts{
+		ChangesetID: changesetID,
+		Context:     args.Context,
+		State:       btypes.ChangesetCheckState(args.State),
+	}
+	if args.Commit != nil {
+		opts.CommitOID = *args.Commit
+	}
+	if args.TargetURL != nil {
+		opts.TargetURL = *args.TargetURL
+	}
+	if args.Description != nil {
+		opts.Description = *args.Description
+	}
+
+	// 🚨 SECURITY: SetChangesetExternalCheck checks whether the current user is authorized and can administer the changeset.
+	svc := service.New(r.store)
+	changeset, repo, err := svc.SetChangesetExternalCheck(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChangesetResolver(r.store, r.gitserverClient, r.logger, changeset, repo), nil
+}
+
 func (r *Resolver) CreateBatchChangesCredential(ctx context.Context, args *graphqlbackend.CreateBatchChangesCredentialArgs) (_ graphqlbackend.BatchChangesCredentialResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.CreateBatchChangesCredential",
 		attribute.String("externalServiceKind", args.ExternalServiceKind),
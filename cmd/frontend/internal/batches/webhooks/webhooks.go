@@ -172,7 +172,16 @@ func (h webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
-	state.SetDerivedState(ctx, tx.Repos(), h.gitserverClient, cs, events)
+	if err != nil {
+		return err
+	}
+	checks, err := tx.ListChangesetExternalChecks(ctx, store.ListChangesetExternalChecksOpts{
+		ChangesetIDs: []int64{cs.ID},
+	})
+	if err != nil {
+		return err
+	}
+	state.SetDerivedState(ctx, tx.Repos(), h.gitserverClient, cs, events, checks)
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
@@ -30,6 +30,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/deviceid"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/instrumentation"
+	"github.com/sourcegraph/sourcegraph/internal/metrics/tenant"
 	"github.com/sourcegraph/sourcegraph/internal/requestclient"
 	"github.com/sourcegraph/sourcegraph/internal/requestinteraction"
 	"github.com/sourcegraph/sourcegraph/internal/session"
@@ -44,6 +45,7 @@ func newExternalHTTPHandler(
 	db database.DB,
 	schema *graphql.Schema,
 	rateLimitWatcher graphqlbackend.LimitWatcher,
+	requestRateLimitWatcher *httpapi.RequestRateLimitWatcher,
 	handlers *httpapi.Handlers,
 	newExecutorProxyHandler enterprise.NewExecutorProxyHandler,
 	newGitHubAppSetupHandler enterprise.NewGitHubAppSetupHandler,
@@ -57,7 +59,7 @@ func newExternalHTTPHandler(
 	authMiddlewares := auth.AuthMiddleware()
 
 	// HTTP API handler, the call order of middleware is LIFO.
-	apiHandler, err := httpapi.NewHandler(db, schema, rateLimitWatcher, handlers)
+	apiHandler, err := httpapi.NewHandler(db, schema, rateLimitWatcher, requestRateLimitWatcher, handlers)
 	if err != nil {
 		return nil, errors.Errorf("create external HTTP API handler: %v", err)
 	}
@@ -65,6 +67,7 @@ func newExternalHTTPHandler(
 		// 🚨 SECURITY: These all run after the auth handler so the client is authenticated.
 		apiHandler = hooks.PostAuthMiddleware(apiHandler)
 	}
+	apiHandler = tenant.Middleware(db, apiHandler)
 	apiHandler = featureflag.Middleware(db.FeatureFlags(), apiHandler)
 	apiHandler = actor.AnonymousUIDMiddleware(apiHandler)
 	apiHandler = authMiddlewares.API(apiHandler) // 🚨 SECURITY: auth middleware
@@ -268,34 +268,44 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 		return nil, err
 	}
 
+	requestRateLimiter, err := makeRequestRateLimitWatcher()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the external HTTP handler.
 	externalHandler, err := newExternalHTTPHandler(
 		db,
 		schema,
 		rateLimiter,
+		requestRateLimiter,
 		&httpapi.Handlers{
-			GitHubSyncWebhook:               enterprise.ReposGithubWebhook,
-			GitLabSyncWebhook:               enterprise.ReposGitLabWebhook,
-			BitbucketServerSyncWebhook:      enterprise.ReposBitbucketServerWebhook,
-			BitbucketCloudSyncWebhook:       enterprise.ReposBitbucketCloudWebhook,
-			PermissionsGitHubWebhook:        enterprise.PermissionsGitHubWebhook,
-			BatchesGitHubWebhook:            enterprise.BatchesGitHubWebhook,
-			BatchesGitLabWebhook:            enterprise.BatchesGitLabWebhook,
-			BatchesBitbucketServerWebhook:   enterprise.BatchesBitbucketServerWebhook,
-			BatchesBitbucketCloudWebhook:    enterprise.BatchesBitbucketCloudWebhook,
-			BatchesAzureDevOpsWebhook:       enterprise.BatchesAzureDevOpsWebhook,
-			BatchesChangesFileGetHandler:    enterprise.BatchesChangesFileGetHandler,
-			BatchesChangesFileExistsHandler: enterprise.BatchesChangesFileExistsHandler,
-			BatchesChangesFileUploadHandler: enterprise.BatchesChangesFileUploadHandler,
-			SCIMHandler:                     enterprise.SCIMHandler,
-			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
-			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
-			CodeInsightsDataExportHandler:   enterprise.CodeInsightsDataExportHandler,
-			SearchJobsDataExportHandler:     enterprise.SearchJobsDataExportHandler,
-			SearchJobsLogsHandler:           enterprise.SearchJobsLogsHandler,
-			NewDotcomLicenseCheckHandler:    enterprise.NewDotcomLicenseCheckHandler,
-			NewChatCompletionsStreamHandler: enterprise.NewChatCompletionsStreamHandler,
-			NewCodeCompletionsHandler:       enterprise.NewCodeCompletionsHandler,
+			GitHubSyncWebhook:                enterprise.ReposGithubWebhook,
+			GitLabSyncWebhook:                enterprise.ReposGitLabWebhook,
+			BitbucketServerSyncWebhook:       enterprise.ReposBitbucketServerWebhook,
+			BitbucketCloudSyncWebhook:        enterprise.ReposBitbucketCloudWebhook,
+			PermissionsGitHubWebhook:         enterprise.PermissionsGitHubWebhook,
+			PermissionsBitbucketCloudWebhook: enterprise.PermissionsBitbucketCloudWebhook,
+			BatchesGitHubWebhook:             enterprise.BatchesGitHubWebhook,
+			BatchesGitLabWebhook:             enterprise.BatchesGitLabWebhook,
+			BatchesBitbucketServerWebhook:    enterprise.BatchesBitbucketServerWebhook,
+			BatchesBitbucketCloudWebhook:     enterprise.BatchesBitbucketCloudWebhook,
+			BatchesAzureDevOpsWebhook:        enterprise.BatchesAzureDevOpsWebhook,
+			BatchesChangesFileGetHandler:     enterprise.BatchesChangesFileGetHandler,
+			BatchesChangesFileExistsHandler:  enterprise.BatchesChangesFileExistsHandler,
+			BatchesChangesFileUploadHandler:  enterprise.BatchesChangesFileUploadHandler,
+			SCIMHandler:                      enterprise.SCIMHandler,
+			NewCodeIntelUploadHandler:        enterprise.NewCodeIntelUploadHandler,
+			NewComputeStreamHandler:          enterprise.NewComputeStreamHandler,
+			CodeInsightsDataExportHandler:    enterprise.CodeInsightsDataExportHandler,
+			SearchJobsDataExportHandler:      enterprise.SearchJobsDataExportHandler,
+			SearchJobsLogsHandler:            enterprise.SearchJobsLogsHandler,
+			ExecutorJobLogStreamHandler:      enterprise.ExecutorJobLogStreamHandler,
+			ExecutorJobArtifactHandler:       enterprise.ExecutorJobArtifactHandler,
+			RepoSnapshotDownloadHandler:      enterprise.RepoSnapshotDownloadHandler,
+			NewDotcomLicenseCheckHandler:     enterprise.NewDotcomLicenseCheckHandler,
+			NewChatCompletionsStreamHandler:  enterprise.NewChatCompletionsStreamHandler,
+			NewCodeCompletionsHandler:        enterprise.NewCodeCompletionsHandler,
 		},
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppSetupHandler,
@@ -398,6 +408,23 @@ func makeRateLimitWatcher() (*graphqlbackend.BasicLimitWatcher, error) {
 	return graphqlbackend.NewBasicLimitWatcher(sglog.Scoped("BasicLimitWatcher"), store), nil
 }
 
+func makeRequestRateLimitWatcher() (*httpapi.RequestRateLimitWatcher, error) {
+	var store throttled.GCRAStoreCtx
+	var err error
+	if pool, ok := redispool.Cache.Pool(); ok {
+		store, err = redigostore.NewCtx(pool, "api:rl:", 0)
+	} else {
+		// If redis is disabled we are in Cody App and can rely on an
+		// in-memory store.
+		store, err = memstore.NewCtx(0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return httpapi.NewRequestRateLimitWatcher(sglog.Scoped("RequestRateLimitWatcher"), store), nil
+}
+
 // redispoolRegisterDB registers our postgres backed redis. These package
 // avoid depending on each other, hence the wrapping to get Go to play nice
 // with the interface definitions.
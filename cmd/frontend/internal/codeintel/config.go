@@ -1,7 +1,10 @@
 package codeintel
 
 import (
+	"sync/atomic"
+
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/lsifuploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -30,3 +33,35 @@ func (c *config) Validate() error {
 	errs = errors.Append(errs, c.LSIFUploadStoreConfig.Validate())
 	return errs
 }
+
+// maximumIndexesPerMonikerSearchSetting is the site configuration setting overriding
+// PRECISE_CODE_INTEL_MAXIMUM_INDEXES_PER_MONIKER_SEARCH at runtime.
+const maximumIndexesPerMonikerSearchSetting = "codeIntelNavigation.maximumIndexesPerMonikerSearch"
+
+// watchMaximumIndexesPerMonikerSearch returns a function that returns the current
+// maximum number of indexes to search at once. The value of the site configuration
+// setting is applied as it changes, falling back to the environment when unset.
+func (c *config) watchMaximumIndexesPerMonikerSearch() func() int {
+	var value atomic.Int64
+	value.Store(int64(c.MaximumIndexesPerMonikerSearch))
+
+	conf.WatchSection(
+		maximumIndexesPerMonikerSearchSetting,
+		func(cfg *conf.Unified) int { return cfg.CodeIntelNavigationMaximumIndexesPerMonikerSearch },
+		func(n int) error {
+			if n < 0 || n > 10000 {
+				return errors.Newf("%s must be between 1 and 10000, got %d", maximumIndexesPerMonikerSearchSetting, n)
+			}
+			return nil
+		},
+		func(change conf.SectionChange[int]) {
+			if change.New == 0 {
+				value.Store(int64(c.MaximumIndexesPerMonikerSearch))
+				return
+			}
+			value.Store(int64(change.New))
+		},
+	)
+
+	return func() int { return int(value.Load()) }
+}
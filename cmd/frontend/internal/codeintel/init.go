@@ -80,12 +80,13 @@ func Init(
 		codeIntelServices.GitserverClient,
 		siteAdminChecker,
 		repoStore,
+		db,
 		uploadLoaderFactory,
 		indexLoaderFactory,
 		preciseIndexResolverFactory,
 		locationResolverFactory,
+		ConfigInst.watchMaximumIndexesPerMonikerSearch(),
 		ConfigInst.HunkCacheSize,
-		ConfigInst.MaximumIndexesPerMonikerSearch,
 	)
 	if err != nil {
 		return err
@@ -122,6 +123,7 @@ func Init(
 		scopedContext("ranking"),
 		codeIntelServices.RankingService,
 		siteAdminChecker,
+		locationResolverFactory,
 	)
 
 	enterpriseServices.CodeIntelResolver = graphqlbackend.NewCodeIntelResolver(resolvers.NewCodeIntelResolver(
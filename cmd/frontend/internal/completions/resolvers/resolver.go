@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/cody"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client"
 	"github.com/sourcegraph/sourcegraph/internal/completions/httpapi"
+	"github.com/sourcegraph/sourcegraph/internal/completions/tokenusage"
 	"github.com/sourcegraph/sourcegraph/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -22,14 +23,15 @@ var _ graphqlbackend.CompletionsResolver = &completionsResolver{}
 
 // completionsResolver provides chat completions
 type completionsResolver struct {
-	rl     httpapi.RateLimiter
-	db     database.DB
-	logger log.Logger
+	rl         httpapi.RateLimiter
+	usageStore tokenusage.Store
+	db         database.DB
+	logger     log.Logger
 }
 
 func NewCompletionsResolver(db database.DB, logger log.Logger) graphqlbackend.CompletionsResolver {
 	rl := httpapi.NewRateLimiter(db, redispool.Store, types.CompletionsFeatureChat)
-	return &completionsResolver{rl: rl, db: db, logger: logger}
+	return &completionsResolver{rl: rl, usageStore: tokenusage.NewStore(db), db: db, logger: logger}
 }
 
 func (c *completionsResolver) Completions(ctx context.Context, args graphqlbackend.CompletionsArgs) (_ string, err error) {
@@ -61,6 +63,7 @@ func (c *completionsResolver) Completions(ctx context.Context, args graphqlbacke
 	client, err := client.Get(
 		c.logger,
 		telemetryrecorder.New(c.db),
+		c.usageStore,
 		completionsConfig.Endpoint,
 		completionsConfig.Provider,
 		completionsConfig.AccessToken,
@@ -69,7 +72,10 @@ func (c *completionsResolver) Completions(ctx context.Context, args graphqlbacke
 		return "", errors.Wrap(err, "GetCompletionStreamClient")
 	}
 
-	// Check rate limit.
+	// Check the daily token budget and rate limit.
+	if err := tokenusage.CheckBudget(ctx, c.usageStore); err != nil {
+		return "", err
+	}
 	if err := c.rl.TryAcquire(ctx); err != nil {
 		return "", err
 	}
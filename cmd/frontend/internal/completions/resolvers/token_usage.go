@@ -0,0 +1,82 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/completions/tokenusage"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// defaultTokenUsagePeriod is the period the token usage is reported for if no
+// start is given.
+const defaultTokenUsagePeriod = 30 * 24 * time.Hour
+
+func (c *completionsResolver) CodyTokenUsage(ctx context.Context, args graphqlbackend.CodyTokenUsageArgs) ([]graphqlbackend.CodyTokenUsageResolver, error) {
+	// 🚨 SECURITY: Only site admins may view the token usage.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, c.db); err != nil {
+		return nil, err
+	}
+
+	opts := tokenusage.ListOpts{
+		Since: time.Now().Add(-defaultTokenUsagePeriod),
+		Limit: int(args.First),
+	}
+	if args.From != nil {
+		opts.Since = args.From.Time
+	}
+	if args.To != nil {
+		opts.Until = args.To.Time
+	}
+	if args.User != nil {
+		userID, err := graphqlbackend.UnmarshalUserID(*args.User)
+		if err != nil {
+			return nil, err
+		}
+		opts.UserID = userID
+	}
+
+	usages, err := c.usageStore.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.CodyTokenUsageResolver, 0, len(usages))
+	for _, u := range usages {
+		resolvers = append(resolvers, &codyTokenUsageResolver{db: c.db, usage: u})
+	}
+	return resolvers, nil
+}
+
+type codyTokenUsageResolver struct {
+	db    database.DB
+	usage tokenusage.UserUsage
+}
+
+func (r *codyTokenUsageResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.usage.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *codyTokenUsageResolver) Feature() string { return string(r.usage.Feature) }
+func (r *codyTokenUsageResolver) Model() string   { return r.usage.Model }
+
+func (r *codyTokenUsageResolver) PromptTokens() graphqlbackend.BigInt {
+	return graphqlbackend.BigInt(r.usage.PromptTokens)
+}
+
+func (r *codyTokenUsageResolver) CompletionTokens() graphqlbackend.BigInt {
+	return graphqlbackend.BigInt(r.usage.CompletionTokens)
+}
+
+func (r *codyTokenUsageResolver) TotalTokens() graphqlbackend.BigInt {
+	return graphqlbackend.BigInt(r.usage.TotalTokens())
+}
+
+func (r *codyTokenUsageResolver) RequestCount() int32 { return int32(r.usage.RequestCount) }
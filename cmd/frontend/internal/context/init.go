@@ -34,6 +34,8 @@ func Init(
 		db,
 		embeddingsClient,
 		searchClient,
+		services.CodenavService,
+		services.GitserverClient,
 		getQdrantSearcher,
 	)
 	enterpriseServices.CodyContextResolver = resolvers.NewResolver(
@@ -50,11 +50,17 @@ func (r *Resolver) GetCodyContext(ctx context.Context, args graphqlbackend.GetCo
 		repoNameIDs[i] = types.RepoIDName{ID: repoID, Name: repo.Name}
 	}
 
+	cursor, err := r.resolveCursor(ctx, args.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
 	fileChunks, err := r.contextClient.GetCodyContext(ctx, codycontext.GetContextArgs{
 		Repos:            repoNameIDs,
 		Query:            args.Query,
 		CodeResultsCount: args.CodeResultsCount,
 		TextResultsCount: args.TextResultsCount,
+		Cursor:           cursor,
 	})
 	if err != nil {
 		return nil, err
@@ -68,6 +74,42 @@ func (r *Resolver) GetCodyContext(ctx context.Context, args graphqlbackend.GetCo
 	})
 }
 
+// resolveCursor looks up the repository and commit of the given cursor.
+func (r *Resolver) resolveCursor(ctx context.Context, input *graphqlbackend.CodyContextCursorInput) (*codycontext.CursorPosition, error) {
+	if input == nil {
+		return nil, nil
+	}
+	if input.Line < 0 || input.Character < 0 {
+		return nil, errors.New("cursor line and character must not be negative")
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(input.Repo)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var revision string
+	if input.Revision != nil {
+		revision = *input.Revision
+	}
+	commitID, err := r.gitserverClient.ResolveRevision(ctx, repo.Name, revision, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &codycontext.CursorPosition{
+		Repo:      types.RepoIDName{ID: repo.ID, Name: repo.Name},
+		CommitID:  commitID,
+		Path:      input.Path,
+		Line:      int(input.Line),
+		Character: int(input.Character),
+	}, nil
+}
+
 func (r *Resolver) fileChunkToResolver(ctx context.Context, chunk *codycontext.FileChunkContext) (graphqlbackend.ContextResultResolver, error) {
 	repoResolver := graphqlbackend.NewRepositoryResolver(r.db, r.gitserverClient, &types.Repo{
 		ID:   chunk.RepoID,
@@ -131,6 +131,8 @@ func TestContextResolver(t *testing.T) {
 		mockEmbeddingsClient,
 		mockSearchClient,
 		nil,
+		mockGitserver,
+		nil,
 	)
 
 	resolver := NewResolver(
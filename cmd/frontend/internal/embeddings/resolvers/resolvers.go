@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/cody"
@@ -192,6 +193,32 @@ func (r *Resolver) CancelRepoEmbeddingJob(ctx context.Context, args graphqlbacke
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) SetRepoEmbeddingAdmissionOverride(ctx context.Context, args graphqlbackend.SetRepoEmbeddingAdmissionOverrideArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may exempt repositories from the admission policy.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repo)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the repository exists and is visible to the user.
+	if _, err := r.db.Repos().Get(ctx, repoID); err != nil {
+		return nil, err
+	}
+
+	if args.Exempt {
+		err = r.repoEmbeddingJobsStore.SetAdmissionOverride(ctx, repoID, actor.FromContext(ctx).UID)
+	} else {
+		err = r.repoEmbeddingJobsStore.DeleteAdmissionOverride(ctx, repoID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 type embeddingsSearchResultsResolver struct {
 	results   *embeddings.EmbeddingCombinedSearchResults
 	gitserver gitserver.Client
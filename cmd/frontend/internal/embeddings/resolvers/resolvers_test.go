@@ -96,6 +96,55 @@ func TestEmbeddingSearchResolver(t *testing.T) {
 	require.Equal(t, "test\nfirst\nfour\nlines", codeResults[0].Content(ctx))
 }
 
+func TestSetRepoEmbeddingAdmissionOverride(t *testing.T) {
+	logger := logtest.Scoped(t)
+
+	users := dbmocks.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: actor.FromContext(ctx).UID, SiteAdmin: actor.FromContext(ctx).UID == 1}, nil
+	})
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: 3, Name: "repo3"}, nil)
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	store := repo.NewMockRepoEmbeddingJobsStore()
+	resolver := NewResolver(db, logger, gitserver.NewMockClient(), nil, store)
+
+	args := graphqlbackend.SetRepoEmbeddingAdmissionOverrideArgs{
+		Repo:   graphqlbackend.MarshalRepositoryID(3),
+		Exempt: true,
+	}
+
+	t.Run("non site admin", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), actor.FromMockUser(2))
+		_, err := resolver.SetRepoEmbeddingAdmissionOverride(ctx, args)
+		require.Error(t, err)
+		require.Len(t, store.SetAdmissionOverrideFunc.History(), 0)
+	})
+
+	ctx := actor.WithActor(context.Background(), actor.FromMockUser(1))
+
+	t.Run("exempt", func(t *testing.T) {
+		_, err := resolver.SetRepoEmbeddingAdmissionOverride(ctx, args)
+		require.NoError(t, err)
+		require.Len(t, store.SetAdmissionOverrideFunc.History(), 1)
+		call := store.SetAdmissionOverrideFunc.History()[0]
+		require.Equal(t, api.RepoID(3), call.Arg1)
+		require.Equal(t, int32(1), call.Arg2)
+	})
+
+	t.Run("remove exemption", func(t *testing.T) {
+		args := args
+		args.Exempt = false
+		_, err := resolver.SetRepoEmbeddingAdmissionOverride(ctx, args)
+		require.NoError(t, err)
+		require.Len(t, store.DeleteAdmissionOverrideFunc.History(), 1)
+		require.Equal(t, api.RepoID(3), store.DeleteAdmissionOverrideFunc.History()[0].Arg1)
+	})
+}
+
 func Test_extractLineRange(t *testing.T) {
 	cases := []struct {
 		input      []byte
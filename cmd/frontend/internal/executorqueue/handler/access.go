@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var errJobAccessUnsupported = errors.New("users cannot access the jobs of this queue")
+
+// AuthorizeJobAccess returns an error if the current user may not read the execution
+// logs and artifacts of the job with the given ID.
+func (h *handler[T]) AuthorizeJobAccess(ctx context.Context, id int) error {
+	if h.queueHandler.AuthorizeLogAccess == nil {
+		return errJobAccessUnsupported
+	}
+	// 🚨 SECURITY: The queue decides who may access its jobs.
+	return h.queueHandler.AuthorizeLogAccess(ctx, id)
+}
+
+// findHandler returns the handler of the queue with the given name, or nil if there
+// is no such queue.
+func findHandler(handlers []ExecutorHandler, queueName string) ExecutorHandler {
+	for _, h := range handlers {
+		if h.Name() == queueName {
+			return h
+		}
+	}
+	return nil
+}
+
+// writeJobAccessError writes the response for an error returned by AuthorizeJobAccess
+// and reports whether err was such an error.
+func writeJobAccessError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, ErrUnknownJob), errors.Is(err, errJobAccessUnsupported):
+		http.Error(w, "job not found", http.StatusNotFound)
+	case errors.Is(err, auth.ErrNotAuthenticated):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, auth.ErrMustBeSiteAdmin), errors.HasType(err, &auth.InsufficientAuthorizationError{}):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		return false
+	}
+	return true
+}
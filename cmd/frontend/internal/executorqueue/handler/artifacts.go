@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+	"golang.org/x/exp/slices"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/artifacts"
+	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewArtifactUploadHandler returns a handler that stores the request body as an
+// artifact of a job. The queue and artifact name are read from the queueName and
+// name route variables, and the job ID from the X-Sourcegraph-Job-ID header.
+//
+// 🚨 SECURITY: The caller must make sure that the request was authenticated with the
+// token of the job.
+func NewArtifactUploadHandler(store uploadstore.Store) http.Handler {
+	logger := log.Scoped("executor-artifact-upload-handler")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queueName := mux.Vars(r)["queueName"]
+		name := mux.Vars(r)["name"]
+		if err := executortypes.ValidateArtifactName(name); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		jobID, err := strconv.Atoi(r.Header.Get("X-Sourcegraph-Job-ID"))
+		if err != nil {
+			http.Error(w, "invalid job ID", http.StatusBadRequest)
+			return
+		}
+
+		// Executors keep the total size of the artifacts of a job within
+		// MaxJobArtifactsSize. Here we bound the size and number of the artifacts
+		// of a job, which bounds their total size too.
+		names, err := artifacts.List(r.Context(), store, queueName, jobID)
+		if err != nil {
+			logger.Error("failed to list artifacts", log.String("queue", queueName), log.Int("jobID", jobID), log.Error(err))
+			http.Error(w, "failed to list artifacts", http.StatusInternalServerError)
+			return
+		}
+		if len(names) >= executortypes.MaxJobArtifacts && !slices.Contains(names, name) {
+			http.Error(w, fmt.Sprintf("job exceeds the maximum of %d artifacts", executortypes.MaxJobArtifacts), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		body := http.MaxBytesReader(w, r.Body, executortypes.MaxArtifactSize)
+		if _, err := store.Upload(r.Context(), artifacts.Key(queueName, jobID, name), body); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, fmt.Sprintf("artifact exceeds the maximum size of %d bytes", executortypes.MaxArtifactSize), http.StatusRequestEntityTooLarge)
+				return
+			}
+			logger.Error("failed to upload artifact", log.String("queue", queueName), log.Int("jobID", jobID), log.String("name", name), log.Error(err))
+			http.Error(w, "failed to upload artifact", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// NewArtifactDownloadHandler returns a handler that serves an artifact of a job to
+// users that may access the job. The queue, job ID, and artifact name are read from
+// the queueName, id, and name route variables.
+func NewArtifactDownloadHandler(handlers []ExecutorHandler, store uploadstore.Store) http.Handler {
+	logger := log.Scoped("executor-artifact-download-handler")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		vars := mux.Vars(r)
+
+		queueName := vars["queueName"]
+		id, err := strconv.Atoi(vars["id"])
+		if err != nil {
+			http.Error(w, "job not found", http.StatusNotFound)
+			return
+		}
+		name := vars["name"]
+
+		names, err := ListArtifacts(ctx, handlers, store, queueName, id)
+		if err != nil {
+			if !writeJobAccessError(w, err) {
+				logger.Error("failed to list artifacts", log.String("queue", queueName), log.Int("id", id), log.Error(err))
+				http.Error(w, "failed to list artifacts", http.StatusInternalServerError)
+			}
+			return
+		}
+		if !slices.Contains(names, name) {
+			http.Error(w, "artifact not found", http.StatusNotFound)
+			return
+		}
+
+		rc, err := store.Get(ctx, artifacts.Key(queueName, id, name))
+		if err != nil {
+			logger.Error("failed to get artifact", log.String("queue", queueName), log.Int("id", id), log.String("name", name), log.Error(err))
+			http.Error(w, "failed to get artifact", http.StatusInternalServerError)
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(name)))
+		if _, err := io.Copy(w, rc); err != nil {
+			logger.Warn("failed to write artifact", log.String("queue", queueName), log.Int("id", id), log.String("name", name), log.Error(err))
+		}
+	})
+}
+
+// ListArtifacts returns the names of the artifacts of the job with the given ID in the
+// given queue, if the current user may access the job.
+func ListArtifacts(ctx context.Context, handlers []ExecutorHandler, store uploadstore.Store, queueName string, id int) ([]string, error) {
+	h := findHandler(handlers, queueName)
+	if h == nil {
+		return nil, ErrUnknownJob
+	}
+	if err := h.AuthorizeJobAccess(ctx, id); err != nil {
+		return nil, err
+	}
+	return artifacts.List(ctx, store, queueName, id)
+}
@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	executorstore "github.com/sourcegraph/sourcegraph/internal/executor/store"
+	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
+	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	dbworkerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+func TestArtifactUploadHandler(t *testing.T) {
+	store := uploadstoremocks.NewMockStore()
+	store.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+		return io.Copy(io.Discard, r)
+	})
+	store.ListFunc.SetDefaultHook(func(_ context.Context, prefix string) (*iterator.Iterator[string], error) {
+		if prefix != "test/43/" {
+			return iterator.From([]string{}), nil
+		}
+		names := make([]string, 0, executortypes.MaxJobArtifacts)
+		for i := 0; i < executortypes.MaxJobArtifacts; i++ {
+			names = append(names, fmt.Sprintf("%sout/%d.log", prefix, i))
+		}
+		return iterator.From(names), nil
+	})
+
+	router := mux.NewRouter()
+	router.Path("/{queueName}/artifacts/{name:.+}").Handler(handler.NewArtifactUploadHandler(store))
+
+	t.Run("uploads the artifact", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test/artifacts/out/dump.scip", strings.NewReader("content"))
+		req.Header.Set("X-Sourcegraph-Job-ID", "42")
+		router.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusNoContent, rw.Code)
+		require.Len(t, store.UploadFunc.History(), 1)
+		assert.Equal(t, "test/42/out/dump.scip", store.UploadFunc.History()[0].Arg1)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test/artifacts/out/a%20b", strings.NewReader("content"))
+		req.Header.Set("X-Sourcegraph-Job-ID", "42")
+		router.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+		assert.Len(t, store.UploadFunc.History(), 1)
+	})
+
+	t.Run("too many artifacts", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test/artifacts/out/dump.scip", strings.NewReader("content"))
+		req.Header.Set("X-Sourcegraph-Job-ID", "43")
+		router.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+		assert.Len(t, store.UploadFunc.History(), 1)
+	})
+
+	t.Run("overwrites an artifact of a job with the maximum number of artifacts", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test/artifacts/out/0.log", strings.NewReader("content"))
+		req.Header.Set("X-Sourcegraph-Job-ID", "43")
+		router.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusNoContent, rw.Code)
+		assert.Len(t, store.UploadFunc.History(), 2)
+	})
+
+	t.Run("missing job ID", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/test/artifacts/dump.scip", strings.NewReader("content"))
+		router.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+		assert.Len(t, store.UploadFunc.History(), 2)
+	})
+}
+
+func TestArtifactDownloadHandler(t *testing.T) {
+	newHandler := func(authorize func(ctx context.Context, id int) error) http.Handler {
+		h := handler.NewHandler(
+			dbmocks.NewMockExecutorStore(),
+			executorstore.NewMockJobTokenStore(),
+			metricsstore.NewMockDistributedStore(),
+			handler.QueueHandler[testRecord]{Name: "test", Store: dbworkerstoremocks.NewMockStore[testRecord](), AuthorizeLogAccess: authorize},
+		)
+
+		store := uploadstoremocks.NewMockStore()
+		store.ListFunc.SetDefaultHook(func(_ context.Context, prefix string) (*iterator.Iterator[string], error) {
+			return iterator.From([]string{prefix + "dump.scip"}), nil
+		})
+		store.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("content")), nil)
+
+		router := mux.NewRouter()
+		router.Path("/{queueName}/jobs/{id}/artifacts/{name:.+}").Handler(handler.NewArtifactDownloadHandler([]handler.ExecutorHandler{h}, store))
+		return router
+	}
+	allow := func(context.Context, int) error { return nil }
+
+	t.Run("serves the artifact", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test/jobs/42/artifacts/dump.scip", nil)
+		newHandler(allow).ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, `attachment; filename="dump.scip"`, rw.Header().Get("Content-Disposition"))
+		assert.Equal(t, "content", rw.Body.String())
+	})
+
+	tests := []struct {
+		name               string
+		url                string
+		authorize          func(ctx context.Context, id int) error
+		expectedStatusCode int
+	}{
+		{
+			name:               "unknown artifact",
+			url:                "/test/jobs/42/artifacts/other.scip",
+			authorize:          allow,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "unknown queue",
+			url:                "/other/jobs/42/artifacts/dump.scip",
+			authorize:          allow,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "invalid job ID",
+			url:                "/test/jobs/abc/artifacts/dump.scip",
+			authorize:          allow,
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "unsupported queue",
+			url:                "/test/jobs/42/artifacts/dump.scip",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "not authenticated",
+			url:                "/test/jobs/42/artifacts/dump.scip",
+			authorize:          func(context.Context, int) error { return auth.ErrNotAuthenticated },
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "not authorized",
+			url:                "/test/jobs/42/artifacts/dump.scip",
+			authorize:          func(context.Context, int) error { return auth.ErrMustBeSiteAdmin },
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			newHandler(test.authorize).ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+		})
+	}
+}
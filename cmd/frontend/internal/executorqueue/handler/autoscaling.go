@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sourcegraph/log"
+	"golang.org/x/exp/slices"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// autoscalingThroughputWindow is the window over which the throughput of a
+// queue is averaged.
+const autoscalingThroughputWindow = 5 * time.Minute
+
+// QueueStats are the signals an autoscaler needs to size the executor fleet
+// that serves a queue.
+type QueueStats struct {
+	// Queue is the name of the queue.
+	Queue string `json:"queue"`
+	// Depth is the number of jobs that are waiting to be dequeued, including
+	// errored jobs that will be retried.
+	Depth int `json:"depth"`
+	// Processing is the number of jobs that executors are currently processing.
+	Processing int `json:"processing"`
+	// OldestJobAgeSeconds is the time the oldest job has been waiting to be
+	// dequeued, or zero if no job is waiting.
+	OldestJobAgeSeconds float64 `json:"oldestJobAgeSeconds"`
+	// ThroughputPerMinute is the number of jobs that finished processing per
+	// minute, averaged over the last five minutes.
+	ThroughputPerMinute float64 `json:"throughputPerMinute"`
+	// RecommendedExecutors is the number of executors needed to process all
+	// queued and processing jobs concurrently, given the number of jobs each
+	// executor runs at once.
+	RecommendedExecutors int `json:"recommendedExecutors"`
+}
+
+// Stats returns the autoscaling signals of the queue. RecommendedExecutors is
+// left for the caller to fill in.
+func (h *handler[T]) Stats(ctx context.Context) (QueueStats, error) {
+	s := h.queueHandler.Store
+
+	depth, err := s.QueuedCount(ctx, false)
+	if err != nil {
+		return QueueStats{}, errors.Wrap(err, "dbworkerstore.QueuedCount")
+	}
+	withProcessing, err := s.QueuedCount(ctx, true)
+	if err != nil {
+		return QueueStats{}, errors.Wrap(err, "dbworkerstore.QueuedCount")
+	}
+	oldest, err := s.MaxDurationInQueue(ctx)
+	if err != nil {
+		return QueueStats{}, errors.Wrap(err, "dbworkerstore.MaxDurationInQueue")
+	}
+	finished, err := s.FinishedCount(ctx, time.Now().Add(-autoscalingThroughputWindow))
+	if err != nil {
+		return QueueStats{}, errors.Wrap(err, "dbworkerstore.FinishedCount")
+	}
+
+	return QueueStats{
+		Queue:               h.queueHandler.Name,
+		Depth:               depth,
+		Processing:          withProcessing - depth,
+		OldestJobAgeSeconds: oldest.Seconds(),
+		ThroughputPerMinute: float64(finished) / autoscalingThroughputWindow.Minutes(),
+	}, nil
+}
+
+// recommendedExecutors returns the number of executors that can process all
+// queued and processing jobs concurrently.
+func recommendedExecutors(stats QueueStats, jobsPerExecutor int) int {
+	return int(math.Ceil(float64(stats.Depth+stats.Processing) / float64(jobsPerExecutor)))
+}
+
+// NewAutoscalingHandler returns a handler that reports the autoscaling signals
+// of the given queues.
+//
+// The signals are returned as JSON, or in the Prometheus text format if the
+// format query parameter is "prometheus". The queue query parameter limits the
+// response to the given queues, and the jobsPerExecutor query parameter sets the
+// number of jobs each executor runs at once (defaults to 1), which is used to
+// compute the recommended number of executors.
+func NewAutoscalingHandler(handlers []ExecutorHandler) http.Handler {
+	logger := log.Scoped("executor-autoscaling-handler")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		jobsPerExecutor := 1
+		if v := query.Get("jobsPerExecutor"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				http.Error(w, fmt.Sprintf("invalid jobsPerExecutor %q: must be a positive integer", v), http.StatusBadRequest)
+				return
+			}
+			jobsPerExecutor = n
+		}
+
+		queues := query["queue"]
+		for _, queue := range queues {
+			if !slices.ContainsFunc(handlers, func(h ExecutorHandler) bool { return h.Name() == queue }) {
+				http.Error(w, fmt.Sprintf("unknown queue %q", queue), http.StatusBadRequest)
+				return
+			}
+		}
+
+		stats := make([]QueueStats, 0, len(handlers))
+		for _, h := range handlers {
+			if len(queues) > 0 && !slices.Contains(queues, h.Name()) {
+				continue
+			}
+
+			s, err := h.Stats(r.Context())
+			if err != nil {
+				logger.Error("failed to get queue stats", log.String("queue", h.Name()), log.Error(err))
+				http.Error(w, fmt.Sprintf("failed to get stats of queue %q", h.Name()), http.StatusInternalServerError)
+				return
+			}
+			s.RecommendedExecutors = recommendedExecutors(s, jobsPerExecutor)
+			stats = append(stats, s)
+		}
+
+		switch format := query.Get("format"); format {
+		case "", "json":
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(stats); err != nil {
+				logger.Error("failed to write queue stats", log.Error(err))
+			}
+		case "prometheus":
+			promhttp.HandlerFor(autoscalingRegistry(stats), promhttp.HandlerOpts{}).ServeHTTP(w, r)
+		default:
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+		}
+	})
+}
+
+// autoscalingRegistry returns a registry that exposes the given stats as
+// gauges labeled by queue.
+func autoscalingRegistry(stats []QueueStats) *prometheus.Registry {
+	gauge := func(name, help string, value func(QueueStats) float64) prometheus.Collector {
+		g := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "src_executor_autoscaling_" + name,
+			Help: help,
+		}, []string{"queue"})
+		for _, s := range stats {
+			g.WithLabelValues(s.Queue).Set(value(s))
+		}
+		return g
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		gauge("queue_depth", "Number of jobs waiting to be dequeued.", func(s QueueStats) float64 { return float64(s.Depth) }),
+		gauge("processing_jobs", "Number of jobs that executors are processing.", func(s QueueStats) float64 { return float64(s.Processing) }),
+		gauge("oldest_job_age_seconds", "Time the oldest queued job has been waiting to be dequeued.", func(s QueueStats) float64 { return s.OldestJobAgeSeconds }),
+		gauge("throughput_per_minute", "Number of jobs finished per minute over the last five minutes.", func(s QueueStats) float64 { return s.ThroughputPerMinute }),
+		gauge("recommended_executors", "Number of executors needed to process all queued and processing jobs concurrently.", func(s QueueStats) float64 { return float64(s.RecommendedExecutors) }),
+	)
+	return registry
+}
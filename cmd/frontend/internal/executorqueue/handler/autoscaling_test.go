@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	executorstore "github.com/sourcegraph/sourcegraph/internal/executor/store"
+	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	dbworkerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestAutoscalingHandler(t *testing.T) {
+	newHandler := func(name string, queued, processing, finished int, oldest time.Duration) handler.ExecutorHandler {
+		mockStore := dbworkerstoremocks.NewMockStore[testRecord]()
+		mockStore.QueuedCountFunc.SetDefaultHook(func(_ context.Context, includeProcessing bool) (int, error) {
+			if includeProcessing {
+				return queued + processing, nil
+			}
+			return queued, nil
+		})
+		mockStore.MaxDurationInQueueFunc.SetDefaultReturn(oldest, nil)
+		mockStore.FinishedCountFunc.SetDefaultReturn(finished, nil)
+
+		return handler.NewHandler(
+			dbmocks.NewMockExecutorStore(),
+			executorstore.NewMockJobTokenStore(),
+			metricsstore.NewMockDistributedStore(),
+			handler.QueueHandler[testRecord]{Name: name, Store: mockStore},
+		)
+	}
+	handlers := []handler.ExecutorHandler{
+		newHandler("codeintel", 7, 2, 50, 90*time.Second),
+		newHandler("batches", 0, 0, 0, 0),
+	}
+
+	tests := []struct {
+		name                 string
+		query                string
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:               "JSON",
+			query:              "",
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `[{"queue":"codeintel","depth":7,"processing":2,"oldestJobAgeSeconds":90,"throughputPerMinute":10,"recommendedExecutors":9},` +
+				`{"queue":"batches","depth":0,"processing":0,"oldestJobAgeSeconds":0,"throughputPerMinute":0,"recommendedExecutors":0}]`,
+		},
+		{
+			name:                 "Filter queues and jobs per executor",
+			query:                "?queue=codeintel&jobsPerExecutor=4",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `[{"queue":"codeintel","depth":7,"processing":2,"oldestJobAgeSeconds":90,"throughputPerMinute":10,"recommendedExecutors":3}]`,
+		},
+		{
+			name:                 "Unknown queue",
+			query:                "?queue=foo",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `unknown queue "foo"`,
+		},
+		{
+			name:                 "Invalid jobs per executor",
+			query:                "?jobsPerExecutor=0",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `invalid jobsPerExecutor "0": must be a positive integer`,
+		},
+		{
+			name:                 "Unsupported format",
+			query:                "?format=xml",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `unsupported format "xml"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/autoscaling"+test.query, nil)
+			require.NoError(t, err)
+
+			handler.NewAutoscalingHandler(handlers).ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+			assert.Equal(t, test.expectedResponseBody, strings.TrimSpace(rw.Body.String()))
+		})
+	}
+
+	t.Run("Prometheus", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/autoscaling?format=prometheus&queue=codeintel", nil)
+		require.NoError(t, err)
+
+		handler.NewAutoscalingHandler(handlers).ServeHTTP(rw, req)
+
+		require.Equal(t, http.StatusOK, rw.Code)
+		body := rw.Body.String()
+		assert.Contains(t, body, `src_executor_autoscaling_queue_depth{queue="codeintel"} 7`)
+		assert.Contains(t, body, `src_executor_autoscaling_processing_jobs{queue="codeintel"} 2`)
+		assert.Contains(t, body, `src_executor_autoscaling_oldest_job_age_seconds{queue="codeintel"} 90`)
+		assert.Contains(t, body, `src_executor_autoscaling_throughput_per_minute{queue="codeintel"} 10`)
+		assert.Contains(t, body, `src_executor_autoscaling_recommended_executors{queue="codeintel"} 9`)
+		assert.NotContains(t, body, `queue="batches"`)
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		mockStore := dbworkerstoremocks.NewMockStore[testRecord]()
+		mockStore.QueuedCountFunc.SetDefaultReturn(0, errors.New("boom"))
+		failing := handler.NewHandler(
+			dbmocks.NewMockExecutorStore(),
+			executorstore.NewMockJobTokenStore(),
+			metricsstore.NewMockDistributedStore(),
+			handler.QueueHandler[testRecord]{Name: "codeintel", Store: mockStore},
+		)
+
+		rw := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/autoscaling", nil)
+		require.NoError(t, err)
+
+		handler.NewAutoscalingHandler([]handler.ExecutorHandler{failing}).ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	})
+}
@@ -10,15 +10,20 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/keegancsmith/sqlf"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	internalexecutor "github.com/sourcegraph/sourcegraph/internal/executor"
 	executorstore "github.com/sourcegraph/sourcegraph/internal/executor/store"
 	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/trace/policy"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -36,6 +41,8 @@ type ExecutorHandler interface {
 	HandleAddExecutionLogEntry(w http.ResponseWriter, r *http.Request)
 	// HandleUpdateExecutionLogEntry updates the log entry for the executor.Job.
 	HandleUpdateExecutionLogEntry(w http.ResponseWriter, r *http.Request)
+	// HandleAppendExecutionLogEntryOutput appends output to the log entry for the executor.Job.
+	HandleAppendExecutionLogEntryOutput(w http.ResponseWriter, r *http.Request)
 	// HandleMarkComplete updates the executor.Job to have a completed status.
 	HandleMarkComplete(w http.ResponseWriter, r *http.Request)
 	// HandleMarkErrored updates the executor.Job to have an errored status.
@@ -44,6 +51,14 @@ type ExecutorHandler interface {
 	HandleMarkFailed(w http.ResponseWriter, r *http.Request)
 	// HandleHeartbeat handles the heartbeat of an executor.
 	HandleHeartbeat(w http.ResponseWriter, r *http.Request)
+	// Stats returns the autoscaling signals of the queue.
+	Stats(ctx context.Context) (QueueStats, error)
+	// AuthorizeJobAccess returns an error if the current user may not read the execution
+	// logs and artifacts of the job with the given ID.
+	AuthorizeJobAccess(ctx context.Context, id int) error
+	// ExecutionLogs returns the log entries and state of the job with the given ID, if the
+	// current user may read them.
+	ExecutionLogs(ctx context.Context, id int) ([]internalexecutor.ExecutionLogEntry, string, error)
 }
 
 var _ ExecutorHandler = &handler[workerutil.Record]{}
@@ -65,6 +80,42 @@ type QueueHandler[T workerutil.Record] struct {
 	// RecordTransformer is a required hook for each registered queue that transforms a generic
 	// record from that queue into the job to be given to an executor.
 	RecordTransformer TransformerFunc[T]
+	// DequeueConditions is an optional hook that returns additional conditions records
+	// must match to be dequeued.
+	DequeueConditions func() []*sqlf.Query
+	// AuthorizeLogAccess is an optional hook that returns an error if the current user
+	// may not read the execution logs of the record with the given ID. If not set, the
+	// execution logs of the queue cannot be streamed. It also guards the artifacts of
+	// the record, which cannot be downloaded either if it is not set.
+	AuthorizeLogAccess func(ctx context.Context, id int) error
+}
+
+// traceDequeue records the dequeue of the record with the given ID by the given executor in the
+// trace of the operation that enqueued the record, if the store persists trace context.
+func (q QueueHandler[T]) traceDequeue(ctx context.Context, logger log.Logger, executorName string, id int) {
+	spanContext, err := q.Store.TraceContext(ctx, id)
+	if err != nil {
+		logger.Warn("Failed to get trace context of record", log.String("queue", q.Name), log.Int("recordID", id), log.Error(err))
+		return
+	}
+	if !spanContext.IsValid() {
+		return
+	}
+
+	ctx = policy.WithShouldTrace(oteltrace.ContextWithRemoteSpanContext(ctx, spanContext), spanContext.IsSampled())
+	span, _ := trace.New(ctx, "executorqueue.dequeue",
+		attribute.String("queue", q.Name),
+		attribute.String("executor", executorName),
+		attribute.Int("record.id", id))
+	span.End()
+}
+
+// dequeueConditions returns the additional conditions records must match to be dequeued.
+func (q QueueHandler[T]) dequeueConditions() []*sqlf.Query {
+	if q.DequeueConditions == nil {
+		return nil
+	}
+	return q.DequeueConditions()
 }
 
 // TransformerFunc is the function to transform a workerutil.Record into an executor.Job.
@@ -131,7 +182,7 @@ func (h *handler[T]) dequeue(ctx context.Context, queueName string, metadata exe
 	}
 
 	// executorName is supposed to be unique.
-	record, dequeued, err := h.queueHandler.Store.Dequeue(ctx, metadata.name, nil)
+	record, dequeued, err := h.queueHandler.Store.Dequeue(ctx, metadata.name, h.queueHandler.dequeueConditions())
 	if err != nil {
 		return executortypes.Job{}, false, errors.Wrap(err, "dbworkerstore.Dequeue")
 	}
@@ -150,6 +201,7 @@ func (h *handler[T]) dequeue(ctx context.Context, queueName string, metadata exe
 
 		return executortypes.Job{}, false, errors.Wrap(err, "RecordTransformer")
 	}
+	h.queueHandler.traceDequeue(ctx, logger, metadata.name, record.RecordID())
 
 	// If this executor supports v2, return a v2 payload. Based on this field,
 	// marshalling will be switched between old and new payload.
@@ -235,6 +287,30 @@ func (h *handler[T]) updateExecutionLogEntry(ctx context.Context, executorName s
 	return errors.Wrap(err, "dbworkerstore.UpdateExecutionLogEntry")
 }
 
+func (h *handler[T]) HandleAppendExecutionLogEntryOutput(w http.ResponseWriter, r *http.Request) {
+	var payload executortypes.AppendExecutionLogEntryOutputRequest
+
+	wrapHandler(w, r, &payload, h.logger, func() (int, any, error) {
+		err := h.appendExecutionLogEntryOutput(r.Context(), payload.ExecutorName, payload.JobID, payload.EntryID, payload.Out)
+		return http.StatusNoContent, nil, err
+	})
+}
+
+func (h *handler[T]) appendExecutionLogEntryOutput(ctx context.Context, executorName string, jobID int, entryID int, out string) error {
+	err := h.queueHandler.Store.AppendExecutionLogEntryOutput(ctx, jobID, entryID, out, store.ExecutionLogEntryOptions{
+		// We pass the WorkerHostname, so the store enforces the record to be owned by this executor. When
+		// the previous executor didn't report heartbeats anymore, but is still alive and reporting logs,
+		// both executors that ever got the job would be writing to the same record. This prevents it.
+		WorkerHostname: executorName,
+		// We pass state to enforce adding log entries is only possible while the record is still dequeued.
+		State: "processing",
+	})
+	if err == store.ErrExecutionLogEntryNotUpdated {
+		return ErrUnknownJob
+	}
+	return errors.Wrap(err, "dbworkerstore.AppendExecutionLogEntryOutput")
+}
+
 func (h *handler[T]) HandleMarkComplete(w http.ResponseWriter, r *http.Request) {
 	var payload executortypes.MarkCompleteRequest
 
@@ -487,6 +487,96 @@ func TestHandler_HandleUpdateExecutionLogEntry(t *testing.T) {
 	}
 }
 
+func TestHandler_HandleAppendExecutionLogEntryOutput(t *testing.T) {
+	tests := []struct {
+		name                 string
+		body                 string
+		mockFunc             func(mockStore *dbworkerstoremocks.MockStore[testRecord])
+		expectedStatusCode   int
+		expectedResponseBody string
+		assertionFunc        func(t *testing.T, mockStore *dbworkerstoremocks.MockStore[testRecord])
+	}{
+		{
+			name: "Append execution log entry output",
+			body: `{"entryId": 10, "executorName": "test-executor", "jobId": 42, "out": "more output"}`,
+			mockFunc: func(mockStore *dbworkerstoremocks.MockStore[testRecord]) {
+				mockStore.AppendExecutionLogEntryOutputFunc.PushReturn(nil)
+			},
+			expectedStatusCode: http.StatusNoContent,
+			assertionFunc: func(t *testing.T, mockStore *dbworkerstoremocks.MockStore[testRecord]) {
+				require.Len(t, mockStore.AppendExecutionLogEntryOutputFunc.History(), 1)
+				assert.Equal(t, 42, mockStore.AppendExecutionLogEntryOutputFunc.History()[0].Arg1)
+				assert.Equal(t, 10, mockStore.AppendExecutionLogEntryOutputFunc.History()[0].Arg2)
+				assert.Equal(t, "more output", mockStore.AppendExecutionLogEntryOutputFunc.History()[0].Arg3)
+				assert.Equal(
+					t,
+					dbworkerstore.ExecutionLogEntryOptions{WorkerHostname: "test-executor", State: "processing"},
+					mockStore.AppendExecutionLogEntryOutputFunc.History()[0].Arg4,
+				)
+			},
+		},
+		{
+			name: "Log entry not updated",
+			body: `{"entryId": 10, "executorName": "test-executor", "jobId": 42, "out": "more output"}`,
+			mockFunc: func(mockStore *dbworkerstoremocks.MockStore[testRecord]) {
+				mockStore.AppendExecutionLogEntryOutputFunc.PushReturn(errors.New("failed to update"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"dbworkerstore.AppendExecutionLogEntryOutput: failed to update"}`,
+		},
+		{
+			name: "Unknown job",
+			body: `{"entryId": 10, "executorName": "test-executor", "jobId": 42, "out": "more output"}`,
+			mockFunc: func(mockStore *dbworkerstoremocks.MockStore[testRecord]) {
+				mockStore.AppendExecutionLogEntryOutputFunc.PushReturn(dbworkerstore.ErrExecutionLogEntryNotUpdated)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"unknown job"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockStore := dbworkerstoremocks.NewMockStore[testRecord]()
+
+			h := handler.NewHandler(
+				dbmocks.NewMockExecutorStore(),
+				executorstore.NewMockJobTokenStore(),
+				metricsstore.NewMockDistributedStore(),
+				handler.QueueHandler[testRecord]{Store: mockStore},
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/{queueName}", h.HandleAppendExecutionLogEntryOutput)
+
+			req, err := http.NewRequest(http.MethodPost, "/test", strings.NewReader(test.body))
+			require.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+
+			if test.mockFunc != nil {
+				test.mockFunc(mockStore)
+			}
+
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+
+			b, err := io.ReadAll(rw.Body)
+			require.NoError(t, err)
+
+			if len(test.expectedResponseBody) > 0 {
+				assert.JSONEq(t, test.expectedResponseBody, string(b))
+			} else {
+				assert.Empty(t, string(b))
+			}
+
+			if test.assertionFunc != nil {
+				test.assertionFunc(t, mockStore)
+			}
+		})
+	}
+}
+
 func TestHandler_HandleMarkComplete(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	internalexecutor "github.com/sourcegraph/sourcegraph/internal/executor"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// logStreamPollInterval is the interval at which the execution logs of a streamed
+// job are polled. Executors upload log output about once per second.
+var logStreamPollInterval = time.Second
+
+// ExecutionLogs returns the log entries and state of the job with the given ID, if the
+// current user may read them.
+func (h *handler[T]) ExecutionLogs(ctx context.Context, id int) ([]internalexecutor.ExecutionLogEntry, string, error) {
+	// 🚨 SECURITY: We check on every call, so that revoked access ends a running stream.
+	if err := h.AuthorizeJobAccess(ctx, id); err != nil {
+		return nil, "", err
+	}
+
+	entries, state, ok, err := h.queueHandler.Store.ExecutionLogs(ctx, id)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "dbworkerstore.ExecutionLogs")
+	}
+	if !ok {
+		return nil, "", ErrUnknownJob
+	}
+	return entries, state, nil
+}
+
+// LogStreamEntry is sent to clients whenever a log entry of a streamed job is
+// added or changes.
+type LogStreamEntry struct {
+	// Index is the position of the entry in the execution logs of the job.
+	Index int `json:"index"`
+	// Key, Command, StartTime, ExitCode, and DurationMs are copied from the log entry.
+	Key        string    `json:"key"`
+	Command    []string  `json:"command"`
+	StartTime  time.Time `json:"startTime"`
+	ExitCode   *int      `json:"exitCode,omitempty"`
+	DurationMs *int      `json:"durationMs,omitempty"`
+	// Out is the output that was added to the entry since it was last sent. If Reset
+	// is true, Out is the complete output and replaces what was sent before.
+	Out   string `json:"out"`
+	Reset bool   `json:"reset,omitempty"`
+}
+
+// NewLogStreamHandler returns a handler that streams the execution logs of a running
+// job as server-sent events. The queue and job ID are read from the queueName and id
+// route variables.
+//
+// An "entry" event with a LogStreamEntry is sent for every new or updated log entry,
+// and a "reset" event if the job was retried and its execution logs were cleared. Once
+// the job is no longer queued or processing, a "done" event with the final state of the
+// job is sent and the stream is closed.
+func NewLogStreamHandler(handlers []ExecutorHandler) http.Handler {
+	logger := log.Scoped("executor-log-stream-handler")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		vars := mux.Vars(r)
+
+		h := findHandler(handlers, vars["queueName"])
+		id, err := strconv.Atoi(vars["id"])
+		if h == nil || err != nil {
+			http.Error(w, "job not found", http.StatusNotFound)
+			return
+		}
+
+		entries, state, err := h.ExecutionLogs(ctx, id)
+		if err != nil {
+			if !writeJobAccessError(w, err) {
+				logger.Error("failed to get execution logs", log.String("queue", h.Name()), log.Int("id", id), log.Error(err))
+				http.Error(w, "failed to get execution logs", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		eventWriter, err := streamhttp.NewWriter(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var sent []internalexecutor.ExecutionLogEntry
+		for {
+			if len(entries) < len(sent) {
+				// The job was retried, which clears its execution logs.
+				if err := eventWriter.Event("reset", struct{}{}); err != nil {
+					return
+				}
+				sent = nil
+			}
+			for _, update := range logStreamUpdates(sent, entries) {
+				if err := eventWriter.Event("entry", update); err != nil {
+					return
+				}
+			}
+			sent = entries
+
+			if state != "queued" && state != "processing" {
+				_ = eventWriter.Event("done", map[string]string{"state": state})
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(logStreamPollInterval):
+			}
+
+			entries, state, err = h.ExecutionLogs(ctx, id)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Error("failed to get execution logs", log.String("queue", h.Name()), log.Int("id", id), log.Error(err))
+				}
+				_ = eventWriter.Event("error", map[string]string{"message": "failed to get execution logs"})
+				return
+			}
+		}
+	})
+}
+
+// logStreamUpdates returns the entries that were added or changed since sent was sent
+// to the client.
+func logStreamUpdates(sent, entries []internalexecutor.ExecutionLogEntry) []LogStreamEntry {
+	var updates []LogStreamEntry
+	for i, entry := range entries {
+		update := LogStreamEntry{
+			Index:      i,
+			Key:        entry.Key,
+			Command:    entry.Command,
+			StartTime:  entry.StartTime,
+			ExitCode:   entry.ExitCode,
+			DurationMs: entry.DurationMs,
+			Out:        entry.Out,
+		}
+
+		if i < len(sent) {
+			prev := sent[i]
+			if entry.Out == prev.Out && (entry.ExitCode == nil) == (prev.ExitCode == nil) && (entry.DurationMs == nil) == (prev.DurationMs == nil) {
+				continue
+			}
+			if strings.HasPrefix(entry.Out, prev.Out) {
+				update.Out = entry.Out[len(prev.Out):]
+			} else {
+				update.Reset = true
+			}
+		}
+
+		updates = append(updates, update)
+	}
+	return updates
+}
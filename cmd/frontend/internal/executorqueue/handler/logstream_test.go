@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	internalexecutor "github.com/sourcegraph/sourcegraph/internal/executor"
+	executorstore "github.com/sourcegraph/sourcegraph/internal/executor/store"
+	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	dbworkerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestLogStreamHandler(t *testing.T) {
+	startTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	newHandler := func(mockStore *dbworkerstoremocks.MockStore[testRecord], authorize func(ctx context.Context, id int) error) http.Handler {
+		h := handler.NewHandler(
+			dbmocks.NewMockExecutorStore(),
+			executorstore.NewMockJobTokenStore(),
+			metricsstore.NewMockDistributedStore(),
+			handler.QueueHandler[testRecord]{Name: "test", Store: mockStore, AuthorizeLogAccess: authorize},
+		)
+
+		router := mux.NewRouter()
+		router.Path("/{queueName}/jobs/{id}/logs/stream").Handler(handler.NewLogStreamHandler([]handler.ExecutorHandler{h}))
+		return router
+	}
+	allow := func(context.Context, int) error { return nil }
+
+	t.Run("streams output until the job completes", func(t *testing.T) {
+		mockStore := dbworkerstoremocks.NewMockStore[testRecord]()
+		mockStore.ExecutionLogsFunc.PushReturn([]internalexecutor.ExecutionLogEntry{
+			{Key: "step.0", Command: []string{"echo"}, StartTime: startTime, Out: "hello "},
+		}, "processing", true, nil)
+		mockStore.ExecutionLogsFunc.PushReturn([]internalexecutor.ExecutionLogEntry{
+			{Key: "step.0", Command: []string{"echo"}, StartTime: startTime, Out: "hello world", ExitCode: pointers.Ptr(0), DurationMs: pointers.Ptr(10)},
+			{Key: "step.1", Command: []string{"ls"}, StartTime: startTime, Out: "file"},
+		}, "completed", true, nil)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test/jobs/42/logs/stream", nil)
+		newHandler(mockStore, allow).ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "text/event-stream", rw.Header().Get("Content-Type"))
+		assert.Equal(t, "event: entry\n"+
+			`data: {"index":0,"key":"step.0","command":["echo"],"startTime":"2023-01-02T03:04:05Z","out":"hello "}`+"\n\n"+
+			"event: entry\n"+
+			`data: {"index":0,"key":"step.0","command":["echo"],"startTime":"2023-01-02T03:04:05Z","exitCode":0,"durationMs":10,"out":"world"}`+"\n\n"+
+			"event: entry\n"+
+			`data: {"index":1,"key":"step.1","command":["ls"],"startTime":"2023-01-02T03:04:05Z","out":"file"}`+"\n\n"+
+			"event: done\n"+
+			`data: {"state":"completed"}`+"\n\n",
+			rw.Body.String(),
+		)
+
+		require.Len(t, mockStore.ExecutionLogsFunc.History(), 2)
+		assert.Equal(t, 42, mockStore.ExecutionLogsFunc.History()[0].Arg1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name               string
+			path               string
+			found              bool
+			authorize          func(ctx context.Context, id int) error
+			expectedStatusCode int
+		}{
+			{name: "unknown queue", path: "/other/jobs/42/logs/stream", found: true, authorize: allow, expectedStatusCode: http.StatusNotFound},
+			{name: "invalid id", path: "/test/jobs/abc/logs/stream", found: true, authorize: allow, expectedStatusCode: http.StatusNotFound},
+			{name: "unknown job", path: "/test/jobs/42/logs/stream", authorize: allow, expectedStatusCode: http.StatusNotFound},
+			{name: "streaming unsupported", path: "/test/jobs/42/logs/stream", found: true, expectedStatusCode: http.StatusNotFound},
+			{
+				name:               "not authenticated",
+				path:               "/test/jobs/42/logs/stream",
+				found:              true,
+				authorize:          func(context.Context, int) error { return auth.ErrNotAuthenticated },
+				expectedStatusCode: http.StatusUnauthorized,
+			},
+			{
+				name:               "not authorized",
+				path:               "/test/jobs/42/logs/stream",
+				found:              true,
+				authorize:          func(context.Context, int) error { return auth.ErrMustBeSiteAdmin },
+				expectedStatusCode: http.StatusForbidden,
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				mockStore := dbworkerstoremocks.NewMockStore[testRecord]()
+				mockStore.ExecutionLogsFunc.SetDefaultReturn(nil, "processing", test.found, nil)
+
+				rw := httptest.NewRecorder()
+				req := httptest.NewRequest(http.MethodGet, test.path, nil)
+				newHandler(mockStore, test.authorize).ServeHTTP(rw, req)
+
+				assert.Equal(t, test.expectedStatusCode, rw.Code)
+			})
+		}
+	})
+}
@@ -125,6 +125,13 @@ func (m *MultiHandler) dequeue(ctx context.Context, req executortypes.DequeueReq
 		if err != nil {
 			return executortypes.Job{}, false, err
 		}
+		if len(candidateQueues) > 1 {
+			// discard queues that are above their fair share of processing jobs
+			candidateQueues, err = m.SelectFairQueues(ctx, candidateQueues)
+			if err != nil {
+				return executortypes.Job{}, false, err
+			}
+		}
 		if len(candidateQueues) == 1 {
 			// only one queue hasn't reached dequeue limit for this window, select as candidate
 			selectedQueue = candidateQueues[0]
@@ -147,7 +154,7 @@ func (m *MultiHandler) dequeue(ctx context.Context, req executortypes.DequeueReq
 	var job executortypes.Job
 	switch selectedQueue {
 	case m.BatchesQueueHandler.Name:
-		record, dequeued, err := m.BatchesQueueHandler.Store.Dequeue(ctx, req.ExecutorName, nil)
+		record, dequeued, err := m.BatchesQueueHandler.Store.Dequeue(ctx, req.ExecutorName, m.BatchesQueueHandler.dequeueConditions())
 		if err != nil {
 			err = errors.Wrapf(err, "dbworkerstore.Dequeue %s", selectedQueue)
 			logger.Error("Failed to dequeue", log.String("queue", selectedQueue), log.Error(err))
@@ -166,8 +173,9 @@ func (m *MultiHandler) dequeue(ctx context.Context, req executortypes.DequeueReq
 			logger.Error("Failed to transform record", log.String("queue", selectedQueue), log.Error(err))
 			return executortypes.Job{}, false, err
 		}
+		m.BatchesQueueHandler.traceDequeue(ctx, logger, req.ExecutorName, record.RecordID())
 	case m.CodeIntelQueueHandler.Name:
-		record, dequeued, err := m.CodeIntelQueueHandler.Store.Dequeue(ctx, req.ExecutorName, nil)
+		record, dequeued, err := m.CodeIntelQueueHandler.Store.Dequeue(ctx, req.ExecutorName, m.CodeIntelQueueHandler.dequeueConditions())
 		if err != nil {
 			err = errors.Wrapf(err, "dbworkerstore.Dequeue %s", selectedQueue)
 			logger.Error("Failed to dequeue", log.String("queue", selectedQueue), log.Error(err))
@@ -186,6 +194,7 @@ func (m *MultiHandler) dequeue(ctx context.Context, req executortypes.DequeueReq
 			logger.Error("Failed to transform record", log.String("queue", selectedQueue), log.Error(err))
 			return executortypes.Job{}, false, err
 		}
+		m.CodeIntelQueueHandler.traceDequeue(ctx, logger, req.ExecutorName, record.RecordID())
 	}
 	job.Queue = selectedQueue
 
@@ -232,14 +241,7 @@ var DoSelectQueueForDequeueing = func(candidateQueues []string, config *schema.D
 	// pick a queue based on the defined weights
 	var choices []weightedrand.Choice[string, int]
 	for _, queue := range candidateQueues {
-		var weight int
-		switch queue {
-		case "batches":
-			weight = config.Batches.Weight
-		case "codeintel":
-			weight = config.Codeintel.Weight
-		}
-		choices = append(choices, weightedrand.NewChoice(queue, weight))
+		choices = append(choices, weightedrand.NewChoice(queue, queueWeight(queue, config)))
 	}
 	chooser, err := weightedrand.NewChooser(choices...)
 	if err != nil {
@@ -275,6 +277,50 @@ func (m *MultiHandler) SelectEligibleQueues(queues []string) ([]string, error) {
 	return candidateQueues, nil
 }
 
+// SelectFairQueues returns the queues whose share of the jobs that are currently being processed does
+// not exceed their weighted share, so that a queue with many long-running jobs can't take up all executors
+// while other queues have work.
+func (m *MultiHandler) SelectFairQueues(ctx context.Context, queues []string) ([]string, error) {
+	processing := make([]int, len(queues))
+	var totalProcessing, totalWeight int
+	for i, queue := range queues {
+		var err error
+		switch queue {
+		case m.BatchesQueueHandler.Name:
+			processing[i], err = m.BatchesQueueHandler.Store.ProcessingCount(ctx)
+		case m.CodeIntelQueueHandler.Name:
+			processing[i], err = m.CodeIntelQueueHandler.Store.ProcessingCount(ctx)
+		}
+		if err != nil {
+			m.logger.Error("fetching processing count", log.Error(err), log.String("queue", queue))
+			return nil, err
+		}
+		totalProcessing += processing[i]
+		totalWeight += queueWeight(queue, m.dequeueCacheConfig)
+	}
+
+	// At least one queue is always at or below its share.
+	var fairQueues []string
+	for i, queue := range queues {
+		// processing[i] / totalProcessing <= weight / totalWeight
+		if processing[i]*totalWeight <= totalProcessing*queueWeight(queue, m.dequeueCacheConfig) {
+			fairQueues = append(fairQueues, queue)
+		}
+	}
+	return fairQueues, nil
+}
+
+// queueWeight returns the configured weight of the given queue.
+func queueWeight(queue string, config *schema.DequeueCacheConfig) int {
+	switch queue {
+	case "batches":
+		return config.Batches.Weight
+	case "codeintel":
+		return config.Codeintel.Weight
+	}
+	return 0
+}
+
 // SelectNonEmptyQueues gets the queue size from the store of each provided queue name and returns
 // only those names that have at least one job queued.
 func (m *MultiHandler) SelectNonEmptyQueues(ctx context.Context, queueNames []string) ([]string, error) {
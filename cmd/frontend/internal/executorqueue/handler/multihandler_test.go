@@ -1113,3 +1113,60 @@ func TestMultiHandler_SelectNonEmptyQueues(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiHandler_SelectFairQueues(t *testing.T) {
+	tests := []struct {
+		name                string
+		codeintelProcessing int
+		batchesProcessing   int
+		expectedQueues      []string
+	}{
+		{
+			name:           "Nothing processing",
+			expectedQueues: []string{"batches", "codeintel"},
+		},
+		{
+			name:                "Both at their share",
+			codeintelProcessing: 2,
+			batchesProcessing:   8,
+			expectedQueues:      []string{"batches", "codeintel"},
+		},
+		{
+			name:                "Batches above its share",
+			codeintelProcessing: 1,
+			batchesProcessing:   10,
+			expectedQueues:      []string{"codeintel"},
+		},
+		{
+			name:                "Codeintel above its share",
+			codeintelProcessing: 3,
+			batchesProcessing:   2,
+			expectedQueues:      []string{"batches"},
+		},
+	}
+
+	// batches has a weight of 4, codeintel a weight of 1
+	mockSiteConfig()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codeIntelMockStore := dbworkerstoremocks.NewMockStore[uploadsshared.Index]()
+			codeIntelMockStore.ProcessingCountFunc.SetDefaultReturn(tt.codeintelProcessing, nil)
+			batchesMockStore := dbworkerstoremocks.NewMockStore[*btypes.BatchSpecWorkspaceExecutionJob]()
+			batchesMockStore.ProcessingCountFunc.SetDefaultReturn(tt.batchesProcessing, nil)
+			m := handler.NewMultiHandler(
+				nil,
+				nil,
+				nil,
+				handler.QueueHandler[uploadsshared.Index]{Name: "codeintel", Store: codeIntelMockStore},
+				handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob]{Name: "batches", Store: batchesMockStore},
+			)
+
+			got, err := m.SelectFairQueues(context.Background(), []string{"batches", "codeintel"})
+			if err != nil {
+				t.Fatalf("unexpected error while selecting fair queues: %s", err)
+			}
+			assert.Equalf(t, tt.expectedQueues, got, "SelectFairQueues(%d, %d)", tt.batchesProcessing, tt.codeintelProcessing)
+		})
+	}
+}
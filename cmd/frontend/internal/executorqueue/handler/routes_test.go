@@ -1,6 +1,7 @@
 package handler_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
 )
 
 func TestSetupRoutes(t *testing.T) {
@@ -100,6 +102,15 @@ func TestSetupJobRoutes(t *testing.T) {
 				h.On("HandleUpdateExecutionLogEntry").Once()
 			},
 		},
+		{
+			name:               "AppendExecutionLogEntryOutput",
+			method:             http.MethodPost,
+			path:               "/test/appendExecutionLogEntryOutput",
+			expectedStatusCode: http.StatusOK,
+			expectationsFunc: func(h *testExecutorHandler) {
+				h.On("HandleAppendExecutionLogEntryOutput").Once()
+			},
+		},
 		{
 			name:               "MarkComplete",
 			method:             http.MethodPost,
@@ -182,6 +193,10 @@ func (t *testExecutorHandler) HandleUpdateExecutionLogEntry(w http.ResponseWrite
 	t.Called()
 }
 
+func (t *testExecutorHandler) HandleAppendExecutionLogEntryOutput(w http.ResponseWriter, r *http.Request) {
+	t.Called()
+}
+
 func (t *testExecutorHandler) HandleMarkComplete(w http.ResponseWriter, r *http.Request) {
 	t.Called()
 }
@@ -201,3 +216,18 @@ func (t *testExecutorHandler) HandleHeartbeat(w http.ResponseWriter, r *http.Req
 func (t *testExecutorHandler) HandleCanceledJobs(w http.ResponseWriter, r *http.Request) {
 	t.Called()
 }
+
+func (t *testExecutorHandler) Stats(ctx context.Context) (handler.QueueStats, error) {
+	args := t.Called()
+	return args.Get(0).(handler.QueueStats), args.Error(1)
+}
+
+func (t *testExecutorHandler) ExecutionLogs(ctx context.Context, id int) ([]executor.ExecutionLogEntry, string, error) {
+	args := t.Called(id)
+	return args.Get(0).([]executor.ExecutionLogEntry), args.String(1), args.Error(2)
+}
+
+func (t *testExecutorHandler) AuthorizeJobAccess(ctx context.Context, id int) error {
+	args := t.Called(id)
+	return args.Error(0)
+}
@@ -1,12 +1,16 @@
 package executorqueue
 
 import (
+	"context"
+
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
 	"github.com/sourcegraph/sourcegraph/internal/conf/confdefaults"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/executor/artifacts"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
@@ -32,7 +36,12 @@ func Init(
 
 	logger := log.Scoped("executorqueue")
 
-	queueHandler := newExecutorQueuesHandler(
+	artifactStore, err := artifacts.New(context.Background(), observationCtx, artifacts.ConfigInst)
+	if err != nil {
+		return err
+	}
+
+	queueHandler, handlers := newExecutorQueuesHandler(
 		observationCtx,
 		db,
 		logger,
@@ -40,8 +49,19 @@ func Init(
 		codeintelUploadHandler,
 		batchesWorkspaceFileGetHandler,
 		batchesWorkspaceFileExistsHandler,
+		artifactStore,
 	)
 
 	enterpriseServices.NewExecutorProxyHandler = queueHandler
+	// Unlike the executor proxy, the following are served to users from the API, and
+	// each queue decides who may access its jobs.
+	enterpriseServices.ExecutorJobLogStreamHandler = handler.NewLogStreamHandler(handlers)
+	enterpriseServices.ExecutorJobArtifactHandler = handler.NewArtifactDownloadHandler(handlers, artifactStore)
+	enterpriseServices.ExecutorJobArtifactsResolver = &artifactsResolver{handlers: handlers, store: artifactStore}
 	return nil
 }
+
+// LoadConfig loads the configuration of the executor artifact store.
+func LoadConfig() {
+	artifacts.ConfigInst.Load()
+}
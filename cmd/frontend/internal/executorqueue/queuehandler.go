@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/grafana/regexp"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
@@ -20,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -31,7 +33,8 @@ func newExecutorQueuesHandler(
 	uploadHandler http.Handler,
 	batchesWorkspaceFileGetHandler http.Handler,
 	batchesWorkspaceFileExistsHandler http.Handler,
-) func() http.Handler {
+	artifactStore uploadstore.Store,
+) (factory func() http.Handler, handlers []handler.ExecutorHandler) {
 	metricsStore := metricsstore.NewDistributedStore("executors:")
 	executorStore := db.Executors()
 	jobTokenStore := store.NewJobTokenStore(observationCtx, db)
@@ -46,14 +49,14 @@ func newExecutorQueuesHandler(
 
 	codeintelHandler := handler.NewHandler(executorStore, jobTokenStore, metricsStore, codeIntelQueueHandler)
 	batchesHandler := handler.NewHandler(executorStore, jobTokenStore, metricsStore, batchesQueueHandler)
-	handlers := []handler.ExecutorHandler{codeintelHandler, batchesHandler}
+	handlers = []handler.ExecutorHandler{codeintelHandler, batchesHandler}
 
 	multiHandler := handler.NewMultiHandler(executorStore, jobTokenStore, metricsStore, codeIntelQueueHandler, batchesQueueHandler)
 
 	// Auth middleware
 	executorAuth := executorAuthMiddleware(logger, accessToken)
 
-	factory := func() http.Handler {
+	factory = func() http.Handler {
 		// 🚨 SECURITY: These routes are secured by checking a token shared between services.
 		base := mux.NewRouter().PathPrefix("/.executors/").Subrouter()
 		base.StrictSlash(true)
@@ -85,15 +88,20 @@ func newExecutorQueuesHandler(
 		queueRouter.Use(withInternalActor, executorAuth)
 		queueRouter.Path("/dequeue").Methods(http.MethodPost).HandlerFunc(multiHandler.HandleDequeue)
 		queueRouter.Path("/heartbeat").Methods(http.MethodPost).HandlerFunc(multiHandler.HandleHeartbeat)
+		// Report queue depth and throughput to executor autoscalers.
+		queueRouter.Path("/autoscaling").Methods(http.MethodGet).Handler(handler.NewAutoscalingHandler(handlers))
 
 		jobRouter := base.PathPrefix("/queue").Subrouter()
 		// The job routes are treated as internal actor. Additionally, each job comes with a short-lived token that is
 		// checked by jobAuthMiddleware.
 		jobRouter.Use(withInternalActor, jobAuthMiddleware(logger, routeQueue, jobTokenStore, executorStore))
 
+		artifactUploadHandler := handler.NewArtifactUploadHandler(artifactStore)
 		for _, h := range handlers {
 			handler.SetupRoutes(h, queueRouter)
 			handler.SetupJobRoutes(h, jobRouter)
+			// Upload artifacts of the job, e.g. partial indexes of failed auto-indexing jobs.
+			jobRouter.Path(fmt.Sprintf("/{queueName:(?:%s)}/artifacts/{name:.+}", regexp.QuoteMeta(h.Name()))).Methods(http.MethodPost).Handler(artifactUploadHandler)
 		}
 
 		// Upload LSIF indexes without a sudo access token or github tokens.
@@ -119,7 +127,7 @@ func newExecutorQueuesHandler(
 		return base
 	}
 
-	return factory
+	return factory, handlers
 }
 
 type routeName string
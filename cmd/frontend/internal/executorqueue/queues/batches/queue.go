@@ -3,11 +3,14 @@ package batches
 import (
 	"context"
 
+	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
 	bstore "github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	apiclient "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -20,10 +23,36 @@ func QueueHandler(observationCtx *observation.Context, db database.DB, _ func()
 		return transformRecord(ctx, logger, batchesStore, record, version)
 	}
 
+	authorizeLogAccess := func(ctx context.Context, id int) error {
+		batchesStore := bstore.New(db, observationCtx, nil)
+		job, err := batchesStore.GetBatchSpecWorkspaceExecutionJob(ctx, bstore.GetBatchSpecWorkspaceExecutionJobOpts{ID: int64(id), ExcludeRank: true})
+		if err != nil {
+			if err == bstore.ErrNoResults {
+				return handler.ErrUnknownJob
+			}
+			return err
+		}
+		// 🚨 SECURITY: Only the user who executes the batch spec and site admins
+		// can view its execution logs and artifacts.
+		return auth.CheckSiteAdminOrSameUser(ctx, db, job.UserID)
+	}
+
 	store := bstore.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle())
 	return handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob]{
-		Name:              "batches",
-		Store:             store,
-		RecordTransformer: recordTransformer,
+		Name:               "batches",
+		Store:              store,
+		RecordTransformer:  recordTransformer,
+		DequeueConditions:  dequeueConditions,
+		AuthorizeLogAccess: authorizeLogAccess,
+	}
+}
+
+// dequeueConditions limits the number of jobs of a single user that executors
+// process at once, if configured.
+func dequeueConditions() []*sqlf.Query {
+	multiqueue := conf.Get().ExecutorsMultiqueue
+	if multiqueue == nil || multiqueue.MaxConcurrentJobsPerUser <= 0 {
+		return nil
 	}
+	return []*sqlf.Query{bstore.BatchSpecWorkspaceExecutionJobUserConcurrencyCondition(multiqueue.MaxConcurrentJobsPerUser)}
 }
@@ -84,6 +84,7 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 	// And build the env vars from the secrets.
 	secretEnvVars := make([]string, len(secrets))
 	redactedEnvVars := make(map[string]string, len(secrets))
+	var externalSecrets map[string]string
 	esalStore := s.DatabaseDB().ExecutorSecretAccessLogs()
 	for i, secret := range secrets {
 		// Get the secret value. This also creates an access log entry in the
@@ -94,6 +95,21 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		}
 
 		secretEnvVars[i] = fmt.Sprintf("%s=%s", secret.Key, val)
+		if apiclient.IsSecretReference(val) {
+			// 🚨 SECURITY: The executor fetches the referenced secret with its own
+			// credentials, so we only allow references in global secrets that
+			// only site admins can create.
+			if secret.NamespaceUserID != 0 || secret.NamespaceOrgID != 0 {
+				return apiclient.Job{}, errors.Wrapf(apiclient.ErrNamespacedSecretReference, "secret %s", secret.Key)
+			}
+			// The secret is stored in an external secret store. The executor
+			// fetches and redacts the value before running the job.
+			if externalSecrets == nil {
+				externalSecrets = make(map[string]string)
+			}
+			externalSecrets[secret.Key] = val
+			continue
+		}
 		// We redact secret values as ${{ secrets.NAME }}.
 		redactedEnvVars[val] = fmt.Sprintf("${{ secrets.%s }}", secret.Key)
 	}
@@ -187,9 +203,10 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		Commit:              workspace.Commit,
 		// We only care about the current repos content, so a shallow clone is good enough.
 		// Later we might allow to tweak more git parameters, like submodules and LFS.
-		ShallowClone:   true,
-		SparseCheckout: sparseCheckout,
-		RedactedValues: redactedEnvVars,
+		ShallowClone:    true,
+		SparseCheckout:  sparseCheckout,
+		RedactedValues:  redactedEnvVars,
+		ExternalSecrets: externalSecrets,
 	}
 
 	if job.Version == 2 {
@@ -23,6 +23,7 @@ import (
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -396,4 +397,24 @@ steps:
 		mockassert.CalledN(t, secs.ListFunc, 9)
 		mockassert.CalledN(t, sal.CreateFunc, 5)
 	})
+
+	t.Run("user namespace secret reference", func(t *testing.T) {
+		secs.ListFunc.PushReturn(
+			[]*database.ExecutorSecret{
+				database.NewMockExecutorSecret(&database.ExecutorSecret{
+					Key:             "FOO",
+					Scope:           database.ExecutorSecretScopeBatches,
+					CreatorID:       123,
+					NamespaceUserID: 123,
+				}, "vault://secret/data/ci#token"),
+			},
+			0,
+			nil,
+		)
+
+		_, err := transformRecord(context.Background(), logtest.Scoped(t), store, workspaceExecutionJob, "0.0.0-dev")
+		if !errors.Is(err, apiclient.ErrNamespacedSecretReference) {
+			t.Fatalf("unexpected error: want=%q have=%v", apiclient.ErrNamespacedSecretReference, err)
+		}
+	})
 }
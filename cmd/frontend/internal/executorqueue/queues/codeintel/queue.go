@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing"
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -23,5 +24,9 @@ func QueueHandler(observationCtx *observation.Context, db database.DB, accessTok
 		Name:              "codeintel",
 		Store:             store,
 		RecordTransformer: recordTransformer,
+		AuthorizeLogAccess: func(ctx context.Context, _ int) error {
+			// 🚨 SECURITY: Only site admins can view executor log contents and artifacts.
+			return auth.CheckCurrentUserIsSiteAdmin(ctx, db)
+		},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	apiclient "github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 const (
@@ -57,6 +59,7 @@ func transformRecord(ctx context.Context, db database.DB, index uploadsshared.In
 	// And build the env vars from the secrets.
 	secretEnvVars := make([]string, len(secrets))
 	redactedEnvVars := make(map[string]string, len(secrets))
+	var externalSecrets map[string]string
 	secretStore := &accessLogTransformer{db.ExecutorSecretAccessLogs()}
 	for i, secret := range secrets {
 		// Get the secret value. This also creates an access log entry in the
@@ -67,6 +70,21 @@ func transformRecord(ctx context.Context, db database.DB, index uploadsshared.In
 		}
 
 		secretEnvVars[i] = fmt.Sprintf("%s=%s", secret.Key, val)
+		if apiclient.IsSecretReference(val) {
+			// 🚨 SECURITY: The executor fetches the referenced secret with its own
+			// credentials, so we only allow references in global secrets that
+			// only site admins can create.
+			if secret.NamespaceUserID != 0 || secret.NamespaceOrgID != 0 {
+				return apiclient.Job{}, errors.Wrapf(apiclient.ErrNamespacedSecretReference, "secret %s", secret.Key)
+			}
+			// The secret is stored in an external secret store. The executor
+			// fetches and redacts the value before running the job.
+			if externalSecrets == nil {
+				externalSecrets = make(map[string]string)
+			}
+			externalSecrets[secret.Key] = val
+			continue
+		}
 		// We redact secret values as ${{ secrets.NAME }}.
 		redactedEnvVars[val] = fmt.Sprintf("${{ secrets.%s }}", secret.Key)
 	}
@@ -151,13 +169,17 @@ func transformRecord(ctx context.Context, db database.DB, index uploadsshared.In
 	maps.Copy(allRedactedValues, redactedEnvVars)
 
 	aj := apiclient.Job{
-		ID:             index.ID,
-		Commit:         index.Commit,
-		RepositoryName: index.RepositoryName,
-		ShallowClone:   true,
-		FetchTags:      fetchTags,
-		DockerSteps:    dockerSteps,
-		RedactedValues: allRedactedValues,
+		ID:              index.ID,
+		Commit:          index.Commit,
+		RepositoryName:  index.RepositoryName,
+		ShallowClone:    true,
+		FetchTags:       fetchTags,
+		DockerSteps:     dockerSteps,
+		RedactedValues:  allRedactedValues,
+		ExternalSecrets: externalSecrets,
+		// Keep the index as an artifact, so that it can be inspected even if the
+		// upload step failed.
+		Artifacts: []string{path.Join(root, outfile)},
 	}
 
 	// Append docker auth config.
@@ -139,6 +139,7 @@ func TestTransformRecord(t *testing.T) {
 					"hunter2":                "PASSWORD_REMOVED",
 					"token-executor hunter2": "token-executor REDACTED",
 				},
+				Artifacts: []string{"web/dump.lsif"},
 			}
 			if diff := cmp.Diff(expected, job); diff != "" {
 				t.Errorf("unexpected job (-want +got):\n%s", diff)
@@ -241,6 +242,7 @@ func TestTransformRecordWithoutIndexer(t *testing.T) {
 			"hunter2":                "PASSWORD_REMOVED",
 			"token-executor hunter2": "token-executor REDACTED",
 		},
+		Artifacts: []string{"other/path/lsif.dump"},
 	}
 	if diff := cmp.Diff(expected, job); diff != "" {
 		t.Errorf("unexpected job (-want +got):\n%s", diff)
@@ -263,7 +265,12 @@ func TestTransformRecordWithSecrets(t *testing.T) {
 				Scope:                  database.ExecutorSecretScopeCodeIntel,
 				OverwritesGlobalSecret: false,
 			}, "banana"),
-		}, 1, nil
+			database.NewMockExecutorSecret(&database.ExecutorSecret{
+				Key:                    "GH_TOKEN",
+				Scope:                  database.ExecutorSecretScopeCodeIntel,
+				OverwritesGlobalSecret: false,
+			}, "vault://secret/data/ci#token"),
+		}, 2, nil
 	})
 
 	for _, testCase := range []struct {
@@ -276,7 +283,7 @@ func TestTransformRecordWithSecrets(t *testing.T) {
 			resourceMetadata: handler.ResourceMetadata{},
 			expected: []string{
 				// Default resource variables
-				"VM_MEM=12.0 GB", "VM_MEM_GB=12", "VM_MEM_MB=12288", "VM_DISK=20.0 GB", "VM_DISK_GB=20", "VM_DISK_MB=20480", "NPM_TOKEN=banana",
+				"VM_MEM=12.0 GB", "VM_MEM_GB=12", "VM_MEM_MB=12288", "VM_DISK=20.0 GB", "VM_DISK_GB=20", "VM_DISK_MB=20480", "NPM_TOKEN=banana", "GH_TOKEN=vault://secret/data/ci#token",
 			},
 		},
 	} {
@@ -301,7 +308,7 @@ func TestTransformRecordWithSecrets(t *testing.T) {
 					"-author", "Test User",
 				},
 				Outfile:          "",
-				RequestedEnvVars: []string{"NPM_TOKEN"},
+				RequestedEnvVars: []string{"NPM_TOKEN", "GH_TOKEN"},
 			}
 			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExternalURL: "https://test.io"}})
 			t.Cleanup(func() {
@@ -313,8 +320,8 @@ func TestTransformRecordWithSecrets(t *testing.T) {
 				t.Fatalf("unexpected error transforming record: %s", err)
 			}
 
-			if len(sal.CreateFunc.History()) != 1 {
-				t.Errorf("unexpected secrets access log creation count: want=%d got=%d", 1, len(sal.CreateFunc.History()))
+			if len(sal.CreateFunc.History()) != 2 {
+				t.Errorf("unexpected secrets access log creation count: want=%d got=%d", 2, len(sal.CreateFunc.History()))
 			}
 
 			expected := apiclient.Job{
@@ -371,6 +378,10 @@ func TestTransformRecordWithSecrets(t *testing.T) {
 					"hunter2":                "PASSWORD_REMOVED",
 					"token-executor hunter2": "token-executor REDACTED",
 				},
+				ExternalSecrets: map[string]string{
+					"GH_TOKEN": "vault://secret/data/ci#token",
+				},
+				Artifacts: []string{"web/dump.lsif"},
 			}
 			if diff := cmp.Diff(expected, job); diff != "" {
 				t.Errorf("unexpected job (-want +got):\n%s", diff)
@@ -420,6 +431,7 @@ func TestTransformRecordDockerAuthConfig(t *testing.T) {
 				},
 			},
 		},
+		Artifacts: []string{"dump.lsif"},
 	}
 	if diff := cmp.Diff(expected, job); diff != "" {
 		t.Errorf("unexpected job (-want +got):\n%s", diff)
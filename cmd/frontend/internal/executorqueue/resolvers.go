@@ -0,0 +1,47 @@
+package executorqueue
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+)
+
+type artifactsResolver struct {
+	handlers []handler.ExecutorHandler
+	store    uploadstore.Store
+}
+
+var _ graphqlbackend.ExecutorJobArtifactsResolver = &artifactsResolver{}
+
+func (r *artifactsResolver) ExecutorJobArtifacts(ctx context.Context, args *graphqlbackend.ExecutorJobArtifactsArgs) ([]graphqlbackend.ExecutorJobArtifactResolver, error) {
+	// 🚨 SECURITY: ListArtifacts checks that the current user may access the job.
+	names, err := handler.ListArtifacts(ctx, r.handlers, r.store, args.Queue, int(args.Job))
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ExecutorJobArtifactResolver, 0, len(names))
+	for _, name := range names {
+		resolvers = append(resolvers, &artifactResolver{queue: args.Queue, jobID: int(args.Job), name: name})
+	}
+	return resolvers, nil
+}
+
+type artifactResolver struct {
+	queue string
+	jobID int
+	name  string
+}
+
+func (r *artifactResolver) Name() string {
+	return r.name
+}
+
+func (r *artifactResolver) URL() (string, error) {
+	return url.JoinPath(conf.Get().ExternalURL, fmt.Sprintf("/.api/executors/%s/jobs/%d/artifacts/%s", r.queue, r.jobID, r.name))
+}
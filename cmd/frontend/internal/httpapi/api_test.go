@@ -30,22 +30,24 @@ func newTest(t *testing.T) *httptestutil.Client {
 	handler, err := NewHandler(db,
 		nil,
 		rateLimiter,
+		NewRequestRateLimitWatcher(logger, rateLimitStore),
 		&Handlers{
-			BatchesGitHubWebhook:            enterpriseServices.BatchesGitHubWebhook,
-			BatchesGitLabWebhook:            enterpriseServices.BatchesGitLabWebhook,
-			GitHubSyncWebhook:               enterpriseServices.ReposGithubWebhook,
-			GitLabSyncWebhook:               enterpriseServices.ReposGitLabWebhook,
-			BitbucketServerSyncWebhook:      enterpriseServices.ReposBitbucketServerWebhook,
-			BitbucketCloudSyncWebhook:       enterpriseServices.ReposBitbucketCloudWebhook,
-			BatchesBitbucketServerWebhook:   enterpriseServices.BatchesBitbucketServerWebhook,
-			BatchesBitbucketCloudWebhook:    enterpriseServices.BatchesBitbucketCloudWebhook,
-			BatchesAzureDevOpsWebhook:       enterpriseServices.BatchesAzureDevOpsWebhook,
-			SCIMHandler:                     enterpriseServices.SCIMHandler,
-			NewCodeIntelUploadHandler:       enterpriseServices.NewCodeIntelUploadHandler,
-			NewComputeStreamHandler:         enterpriseServices.NewComputeStreamHandler,
-			PermissionsGitHubWebhook:        enterpriseServices.PermissionsGitHubWebhook,
-			NewChatCompletionsStreamHandler: enterpriseServices.NewChatCompletionsStreamHandler,
-			NewCodeCompletionsHandler:       enterpriseServices.NewCodeCompletionsHandler,
+			BatchesGitHubWebhook:             enterpriseServices.BatchesGitHubWebhook,
+			BatchesGitLabWebhook:             enterpriseServices.BatchesGitLabWebhook,
+			GitHubSyncWebhook:                enterpriseServices.ReposGithubWebhook,
+			GitLabSyncWebhook:                enterpriseServices.ReposGitLabWebhook,
+			BitbucketServerSyncWebhook:       enterpriseServices.ReposBitbucketServerWebhook,
+			BitbucketCloudSyncWebhook:        enterpriseServices.ReposBitbucketCloudWebhook,
+			BatchesBitbucketServerWebhook:    enterpriseServices.BatchesBitbucketServerWebhook,
+			BatchesBitbucketCloudWebhook:     enterpriseServices.BatchesBitbucketCloudWebhook,
+			BatchesAzureDevOpsWebhook:        enterpriseServices.BatchesAzureDevOpsWebhook,
+			SCIMHandler:                      enterpriseServices.SCIMHandler,
+			NewCodeIntelUploadHandler:        enterpriseServices.NewCodeIntelUploadHandler,
+			NewComputeStreamHandler:          enterpriseServices.NewComputeStreamHandler,
+			PermissionsGitHubWebhook:         enterpriseServices.PermissionsGitHubWebhook,
+			PermissionsBitbucketCloudWebhook: enterpriseServices.PermissionsBitbucketCloudWebhook,
+			NewChatCompletionsStreamHandler:  enterpriseServices.NewChatCompletionsStreamHandler,
+			NewCodeCompletionsHandler:        enterpriseServices.NewCodeCompletionsHandler,
 		},
 	)
 	require.NoError(t, err)
@@ -1,7 +1,9 @@
 package httpapi
 
 import (
+	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -308,8 +310,27 @@ func AccessTokenAuthMiddleware(db database.DB, baseLogger log.Logger, next http.
 					},
 				),
 			)
+			r = r.WithContext(withAccessTokenKey(r.Context(), token))
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+type accessTokenKeyContextKey struct{}
+
+// withAccessTokenKey returns a context that identifies the access token that
+// authenticated the request, so that its cost budget can be enforced.
+//
+// 🚨 SECURITY: Only a hash of the token is stored, so that it can't leak.
+func withAccessTokenKey(ctx context.Context, token string) context.Context {
+	h := sha256.Sum256([]byte(token))
+	return context.WithValue(ctx, accessTokenKeyContextKey{}, hex.EncodeToString(h[:16]))
+}
+
+// accessTokenKeyFromContext returns the key of the access token that authenticated
+// the request, or an empty string if the request wasn't authenticated with one.
+func accessTokenKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(accessTokenKeyContextKey{}).(string)
+	return key
+}
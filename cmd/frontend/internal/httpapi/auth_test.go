@@ -133,6 +133,23 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		})
 	}
 
+	t.Run("valid non-sudo token sets access token key", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "token abcdef")
+
+		accessTokens := dbmocks.NewMockAccessTokenStore()
+		accessTokens.LookupFunc.SetDefaultReturn(123, nil)
+		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
+
+		var key string
+		AccessTokenAuthMiddleware(db, logtest.NoOp(t), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key = accessTokenKeyFromContext(r.Context())
+		})).ServeHTTP(httptest.NewRecorder(), req)
+
+		require.NotEmpty(t, key)
+		require.NotContains(t, key, "abcdef")
+	})
+
 	// Test that an access token overwrites the actor set by a prior auth middleware.
 	t.Run("actor present, valid non-sudo token", func(t *testing.T) {
 		req, _ := http.NewRequest("GET", "/", nil)
@@ -4,6 +4,8 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -23,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/audit"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/cookie"
+	"github.com/sourcegraph/sourcegraph/internal/metrics/tenant"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -61,6 +64,37 @@ func writeViolationError(w http.ResponseWriter, message string) error {
 	})
 }
 
+// writeRateLimitError writes the error response for a request that exceeded its rate
+// limit. The error has the code ErrRateLimited, and tells clients when they can retry
+// in its retryAfter extension and the Retry-After header. Both are omitted if the
+// query costs more than the rate limit ever allows, in which case retrying won't help.
+func writeRateLimitError(w http.ResponseWriter, result throttled.RateLimitResult, cost int) error {
+	message := "query cost exceeds the rate limit"
+	extensions := map[string]any{
+		"code":      "ErrRateLimited",
+		"cost":      cost,
+		"limit":     result.Limit,
+		"remaining": result.Remaining,
+	}
+	if result.RetryAfter >= 0 {
+		retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+		message = fmt.Sprintf("rate limit exceeded, retry after %d seconds", retryAfter)
+		extensions["retryAfter"] = retryAfter
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusTooManyRequests)
+	return writeJSON(w, graphql.Response{
+		Errors: []*gqlerrors.QueryError{
+			{
+				Message:    message,
+				Extensions: extensions,
+			},
+		},
+	})
+}
+
 func serveGraphQL(logger log.Logger, schema *graphql.Schema, rlw graphqlbackend.LimitWatcher, isInternal bool) func(w http.ResponseWriter, r *http.Request) (err error) {
 	return func(w http.ResponseWriter, r *http.Request) (err error) {
 		if r.Method != "POST" {
@@ -102,6 +136,7 @@ func serveGraphQL(logger log.Logger, schema *graphql.Schema, rlw graphqlbackend.
 			isInternal:    isInternal,
 			requestName:   requestName,
 			requestSource: string(requestSource),
+			tenant:        tenant.FromContext(r.Context()),
 		}
 
 		defer func() {
@@ -147,10 +182,12 @@ func serveGraphQL(logger log.Logger, schema *graphql.Schema, rlw graphqlbackend.
 
 				if rl, enabled := rlw.Get(); enabled {
 					limited, result, err := rl.RateLimit(r.Context(), uid, cost.FieldCount, graphqlbackend.LimiterArgs{
-						IsIP:          isIP,
-						Anonymous:     anonymous,
-						RequestName:   requestName,
-						RequestSource: requestSource,
+						IsIP:           isIP,
+						Anonymous:      anonymous,
+						RequestName:    requestName,
+						RequestSource:  requestSource,
+						UserID:         actor.FromContext(r.Context()).UID,
+						AccessTokenKey: accessTokenKeyFromContext(r.Context()),
 					})
 					if err != nil {
 						logger.Error("checking GraphQL rate limit", log.Error(err))
@@ -159,9 +196,7 @@ func serveGraphQL(logger log.Logger, schema *graphql.Schema, rlw graphqlbackend.
 						traceData.limited = limited
 						traceData.limitResult = result
 						if limited {
-							w.Header().Set("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
-							w.WriteHeader(http.StatusTooManyRequests)
-							return nil
+							return writeRateLimitError(w, result, cost.FieldCount)
 						}
 					}
 				}
@@ -197,6 +232,7 @@ type traceData struct {
 	isInternal    bool
 	requestName   string
 	requestSource string
+	tenant        string
 	queryErrors   []*gqlerrors.QueryError
 
 	cost      *graphqlbackend.QueryCost
@@ -2,11 +2,16 @@ package httpapi
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	gqlerrors "github.com/graph-gophers/graphql-go/errors"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled/v2"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -94,3 +99,25 @@ func makeQueryErrors(errors bool) []*gqlerrors.QueryError {
 	result = append(result, &gqlerrors.QueryError{Message: "oops"})
 	return result
 }
+
+func TestWriteRateLimitError(t *testing.T) {
+	t.Run("retryable", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		err := writeRateLimitError(rw, throttled.RateLimitResult{Limit: 100, Remaining: 3, RetryAfter: 1500 * time.Millisecond}, 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+		assert.Equal(t, "2", rw.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"errors":[{"message":"rate limit exceeded, retry after 2 seconds","extensions":{"code":"ErrRateLimited","cost":10,"limit":100,"remaining":3,"retryAfter":2}}]}`, rw.Body.String())
+	})
+
+	t.Run("cost exceeds limit", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		err := writeRateLimitError(rw, throttled.RateLimitResult{Limit: 100, RetryAfter: -1}, 200)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+		assert.Empty(t, rw.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"errors":[{"message":"query cost exceeds the rate limit","extensions":{"code":"ErrRateLimited","cost":200,"limit":100,"remaining":0}}]}`, rw.Body.String())
+	})
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/updatecheck"
@@ -50,7 +51,8 @@ type Handlers struct {
 	BitbucketCloudSyncWebhook  webhooks.Registerer
 
 	// Permissions
-	PermissionsGitHubWebhook webhooks.Registerer
+	PermissionsGitHubWebhook         webhooks.Registerer
+	PermissionsBitbucketCloudWebhook webhooks.Registerer
 
 	// Batch changes
 	BatchesGitHubWebhook            webhooks.Registerer
@@ -78,6 +80,13 @@ type Handlers struct {
 	SearchJobsDataExportHandler http.Handler
 	SearchJobsLogsHandler       http.Handler
 
+	// Repository snapshots
+	RepoSnapshotDownloadHandler http.Handler
+
+	// Executors
+	ExecutorJobLogStreamHandler http.Handler
+	ExecutorJobArtifactHandler  http.Handler
+
 	// Dotcom license check
 	NewDotcomLicenseCheckHandler enterprise.NewDotcomLicenseCheckHandler
 
@@ -94,12 +103,14 @@ func NewHandler(
 	db database.DB,
 	schema *graphql.Schema,
 	rateLimiter graphqlbackend.LimitWatcher,
+	requestRateLimiter *RequestRateLimitWatcher,
 	handlers *Handlers,
 ) (http.Handler, error) {
 	logger := sglog.Scoped("Handler")
 
 	m := mux.NewRouter().PathPrefix("/.api/").Subrouter()
 	m.StrictSlash(true)
+	m.Use(requestRateLimitMiddleware(logger, db, requestRateLimiter))
 
 	jsonHandler := JsonMiddleware(&ErrorHandler{
 		Logger: logger,
@@ -111,7 +122,8 @@ func NewHandler(
 
 	m.PathPrefix("/registry").Methods("GET").Handler(trace.Route(jsonHandler(frontendregistry.HandleRegistry)))
 	m.PathPrefix("/scim/v2").Methods("GET", "POST", "PUT", "PATCH", "DELETE").Handler(trace.Route(handlers.SCIMHandler))
-	m.Path("/graphql").Methods("POST").Handler(trace.Route(jsonHandler(serveGraphQL(logger, schema, rateLimiter, false))))
+	m.Path("/graphql").Methods("POST").Name(graphQLRoute).Handler(trace.Route(jsonHandler(serveGraphQL(logger, schema, rateLimiter, false))))
+	m.Path("/job-states/stream").Methods("GET").Handler(trace.Route(serveJobStateStream(logger, schema, rateLimiter)))
 
 	m.Path("/opencodegraph").Methods("POST").Handler(trace.Route(jsonHandler(serveOpenCodeGraph(logger))))
 
@@ -132,6 +144,7 @@ func NewHandler(
 	handlers.GitHubSyncWebhook.Register(&wh)
 	handlers.GitLabSyncWebhook.Register(&wh)
 	handlers.PermissionsGitHubWebhook.Register(&wh)
+	handlers.PermissionsBitbucketCloudWebhook.Register(&wh)
 	handlers.BatchesAzureDevOpsWebhook.Register(&wh)
 	// Second: register handler on main router
 	// 🚨 SECURITY: This handler implements its own secret-based auth
@@ -164,6 +177,9 @@ func NewHandler(
 	m.Path("/search/stream").Methods("GET").Handler(trace.Route(frontendsearch.StreamHandler(db)))
 	m.Path("/search/export/{id}.csv").Methods("GET").Handler(trace.Route(handlers.SearchJobsDataExportHandler))
 	m.Path("/search/export/{id}.log").Methods("GET").Handler(trace.Route(handlers.SearchJobsLogsHandler))
+	m.Path("/repository-snapshots/{id}/{name:.+}").Methods("GET").Handler(trace.Route(handlers.RepoSnapshotDownloadHandler))
+	m.Path("/executors/{queueName}/jobs/{id}/logs/stream").Methods("GET").Handler(trace.Route(handlers.ExecutorJobLogStreamHandler))
+	m.Path("/executors/{queueName}/jobs/{id}/artifacts/{name:.+}").Methods("GET").Handler(trace.Route(handlers.ExecutorJobArtifactHandler))
 
 	m.Path("/completions/stream").Methods("POST").Handler(trace.Route(handlers.NewChatCompletionsStreamHandler()))
 	m.Path("/completions/code").Methods("POST").Handler(trace.Route(handlers.NewCodeCompletionsHandler()))
@@ -242,6 +258,20 @@ func RegisterInternalServices(
 		SearchContextsRepoRevs: func(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID][]string, error) {
 			return searchcontexts.RepoRevs(ctx, db, repoIDs)
 		},
+		IndexBranchRules: func(ctx context.Context) ([]searchbackend.IndexBranchRule, error) {
+			rules, err := db.SearchIndexBranches().ListRules(ctx)
+			if err != nil {
+				return nil, err
+			}
+			indexRules := make([]searchbackend.IndexBranchRule, 0, len(rules))
+			for _, rule := range rules {
+				indexRules = append(indexRules, searchbackend.IndexBranchRule{RepoPattern: rule.RepoPattern, Branches: rule.Branches})
+			}
+			return indexRules, nil
+		},
+		RepoIndexBranches: func(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID][]string, error) {
+			return db.SearchIndexBranches().GetRepoBranches(ctx, repoIDs)
+		},
 		Indexers:               search.Indexers(),
 		Ranking:                rankingService,
 		MinLastChangedDisabled: os.Getenv("SRC_SEARCH_INDEXER_EFFICIENT_POLLING_DISABLED") != "",
@@ -0,0 +1,229 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	// jobStatePollInterval is the interval at which the states of watched jobs are
+	// polled.
+	jobStatePollInterval = 2 * time.Second
+
+	// maxJobStateStreamDuration is the time after which a job state stream is closed,
+	// even if some of its jobs haven't finished yet. Clients may reconnect.
+	maxJobStateStreamDuration = time.Hour
+)
+
+// maxWatchedJobs is the maximum number of jobs that a single stream can watch.
+const maxWatchedJobs = 50
+
+// jobStateQuery reads the state of a job from the resolver of its node type, which
+// also checks that the current user may see the job.
+const jobStateQuery = `query JobState($id: ID!) {
+	node(id: $id) {
+		__typename
+		... on BatchSpec { state }
+		... on PermissionsSyncJob { state }
+		... on PreciseIndex { state }
+	}
+}`
+
+// terminalJobStates are the states by node type after which a job does not change
+// until a user acts on it. Errored permissions sync jobs are not included, as the
+// workers retry them. Pending batch specs only change once they are executed.
+var terminalJobStates = map[string][]string{
+	"BatchSpec":          {"PENDING", "COMPLETED", "FAILED", "CANCELED"},
+	"PermissionsSyncJob": {"COMPLETED", "FAILED", "CANCELED"},
+	"PreciseIndex":       {"COMPLETED", "DELETED", "PROCESSING_ERRORED", "INDEXING_ERRORED"},
+}
+
+// errJobStateRateLimited is returned by a jobStateFetcher if reading the state of a
+// job would exceed the GraphQL cost budgets of the user. The job is polled again
+// later.
+var errJobStateRateLimited = errors.New("job state poll rate limited")
+
+// JobStateEvent is sent to clients when a watched job is first read and whenever
+// its state changes.
+type JobStateEvent struct {
+	ID       string `json:"id"`
+	TypeName string `json:"__typename"`
+	State    string `json:"state"`
+}
+
+// JobStateErrorEvent is sent to clients when the state of a watched job can't be
+// read. The job is not watched anymore afterwards.
+type JobStateErrorEvent struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// jobStateFetcher returns the GraphQL type name and state of the job with the given
+// node ID.
+type jobStateFetcher func(ctx context.Context, id string) (typeName, state string, err error)
+
+// graphQLJobStateFetcher returns a jobStateFetcher that runs jobStateQuery against
+// the given schema.
+func graphQLJobStateFetcher(schema *graphql.Schema) jobStateFetcher {
+	return func(ctx context.Context, id string) (string, string, error) {
+		response := schema.Exec(ctx, jobStateQuery, "JobState", map[string]any{"id": id})
+		if len(response.Errors) > 0 {
+			return "", "", response.Errors[0]
+		}
+
+		var data struct {
+			Node *struct {
+				TypeName string `json:"__typename"`
+				State    string `json:"state"`
+			} `json:"node"`
+		}
+		if err := json.Unmarshal(response.Data, &data); err != nil {
+			return "", "", err
+		}
+		if data.Node == nil {
+			return "", "", errors.New("job not found")
+		}
+		if _, ok := terminalJobStates[data.Node.TypeName]; !ok {
+			return "", "", errors.Newf("cannot watch the state of a %s", data.Node.TypeName)
+		}
+		return data.Node.TypeName, data.Node.State, nil
+	}
+}
+
+// rateLimitedJobStateFetcher returns a jobStateFetcher that charges the cost of
+// jobStateQuery against the GraphQL cost budgets of the user before each call to
+// fetchState, just like a GraphQL request would be charged.
+func rateLimitedJobStateFetcher(logger log.Logger, rlw graphqlbackend.LimitWatcher, fetchState jobStateFetcher) jobStateFetcher {
+	cost := 1
+	if c, err := graphqlbackend.EstimateQueryCost(jobStateQuery, nil); err == nil && c != nil {
+		cost = c.FieldCount
+	}
+
+	return func(ctx context.Context, id string) (string, string, error) {
+		if rl, enabled := rlw.Get(); enabled {
+			a := actor.FromContext(ctx)
+			limited, result, err := rl.RateLimit(ctx, a.UIDString(), cost, graphqlbackend.LimiterArgs{
+				Anonymous:      !a.IsAuthenticated(),
+				RequestName:    "JobState",
+				UserID:         a.UID,
+				AccessTokenKey: accessTokenKeyFromContext(ctx),
+			})
+			if err != nil {
+				logger.Error("checking GraphQL rate limit", log.Error(err))
+			} else if limited {
+				if result.RetryAfter < 0 {
+					return "", "", errors.New("query cost exceeds the rate limit")
+				}
+				return "", "", errJobStateRateLimited
+			}
+		}
+
+		return fetchState(ctx, id)
+	}
+}
+
+// serveJobStateStream returns a handler that streams the state transitions of
+// long-running jobs as server-sent events, so that clients don't have to poll the
+// GraphQL API for them. The jobs are given as the GraphQL node IDs of batch specs,
+// permissions sync jobs, and precise indexes in the id query parameter, which may
+// be repeated.
+//
+// A "state" event with a JobStateEvent is sent for every job once its state is
+// first read, and whenever its state changes. If the state of a job can't be read,
+// e.g. because it doesn't exist or the user may not see it, an "error" event with
+// a JobStateErrorEvent is sent. Once all jobs have reached a terminal state or
+// failed, a "done" event is sent and the stream is closed.
+//
+// Every poll of a job is charged against the GraphQL cost budgets of the user. A
+// job whose poll is rate limited keeps its state until the next poll.
+func serveJobStateStream(logger log.Logger, schema *graphql.Schema, rlw graphqlbackend.LimitWatcher) http.HandlerFunc {
+	return jobStateStreamHandler(logger, rateLimitedJobStateFetcher(logger, rlw, graphQLJobStateFetcher(schema)))
+}
+
+func jobStateStreamHandler(logger log.Logger, fetchState jobStateFetcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query()["id"]
+		if len(ids) == 0 {
+			http.Error(w, "at least one id is required", http.StatusBadRequest)
+			return
+		}
+		if len(ids) > maxWatchedJobs {
+			http.Error(w, "too many ids", http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), maxJobStateStreamDuration)
+		defer cancel()
+
+		eventWriter, err := streamhttp.NewWriter(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// states holds the last state sent for each job that is still watched.
+		states := make(map[string]string, len(ids))
+		for _, id := range ids {
+			states[id] = ""
+		}
+
+		for {
+			for _, id := range ids {
+				prev, ok := states[id]
+				if !ok {
+					continue
+				}
+
+				typeName, state, err := fetchState(ctx, id)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					if errors.Is(err, errJobStateRateLimited) {
+						continue
+					}
+					delete(states, id)
+					if err := eventWriter.Event("error", JobStateErrorEvent{ID: id, Message: err.Error()}); err != nil {
+						return
+					}
+					continue
+				}
+
+				if state != prev {
+					if err := eventWriter.Event("state", JobStateEvent{ID: id, TypeName: typeName, State: state}); err != nil {
+						return
+					}
+					states[id] = state
+				}
+				if slices.Contains(terminalJobStates[typeName], state) {
+					delete(states, id)
+				}
+			}
+
+			if len(states) == 0 {
+				_ = eventWriter.Event("done", struct{}{})
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					logger.Debug("closing job state stream after max duration", log.Int("jobs", len(states)))
+				}
+				return
+			case <-time.After(jobStatePollInterval):
+			}
+		}
+	}
+}
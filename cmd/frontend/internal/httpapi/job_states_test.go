@@ -0,0 +1,170 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled/v2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestJobStateStream(t *testing.T) {
+	oldInterval := jobStatePollInterval
+	jobStatePollInterval = time.Millisecond
+	t.Cleanup(func() { jobStatePollInterval = oldInterval })
+
+	// Each job goes through the given states, one per poll, and then stays in the
+	// last one.
+	states := map[string][]string{
+		"spec":  {"PROCESSING", "PROCESSING", "COMPLETED"},
+		"sync":  {"QUEUED", "PROCESSING", "ERRORED", "PROCESSING", "FAILED"},
+		"index": {"UPLOADING_INDEX", "QUEUED_FOR_PROCESSING", "PROCESSING", "COMPLETED"},
+	}
+	typeNames := map[string]string{"spec": "BatchSpec", "sync": "PermissionsSyncJob", "index": "PreciseIndex"}
+	polls := map[string]int{}
+	fetchState := func(_ context.Context, id string) (string, string, error) {
+		s, ok := states[id]
+		if !ok {
+			return "", "", errors.New("job not found")
+		}
+		i := polls[id]
+		polls[id]++
+		if i >= len(s) {
+			i = len(s) - 1
+		}
+		return typeNames[id], s[i], nil
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/job-states/stream?id=spec&id=sync&id=missing&id=index", nil)
+	jobStateStreamHandler(logtest.Scoped(t), fetchState)(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	want := `event: state
+data: {"id":"spec","__typename":"BatchSpec","state":"PROCESSING"}
+
+event: state
+data: {"id":"sync","__typename":"PermissionsSyncJob","state":"QUEUED"}
+
+event: error
+data: {"id":"missing","message":"job not found"}
+
+event: state
+data: {"id":"index","__typename":"PreciseIndex","state":"UPLOADING_INDEX"}
+
+event: state
+data: {"id":"sync","__typename":"PermissionsSyncJob","state":"PROCESSING"}
+
+event: state
+data: {"id":"index","__typename":"PreciseIndex","state":"QUEUED_FOR_PROCESSING"}
+
+event: state
+data: {"id":"spec","__typename":"BatchSpec","state":"COMPLETED"}
+
+event: state
+data: {"id":"sync","__typename":"PermissionsSyncJob","state":"ERRORED"}
+
+event: state
+data: {"id":"index","__typename":"PreciseIndex","state":"PROCESSING"}
+
+event: state
+data: {"id":"sync","__typename":"PermissionsSyncJob","state":"PROCESSING"}
+
+event: state
+data: {"id":"index","__typename":"PreciseIndex","state":"COMPLETED"}
+
+event: state
+data: {"id":"sync","__typename":"PermissionsSyncJob","state":"FAILED"}
+
+event: done
+data: {}
+
+`
+	assert.Equal(t, want, rec.Body.String())
+	// Jobs are not polled anymore once they reached a terminal state.
+	assert.Equal(t, map[string]int{"spec": 3, "sync": 5, "index": 4}, polls)
+}
+
+func TestJobStateStreamBadRequest(t *testing.T) {
+	fetchState := func(context.Context, string) (string, string, error) {
+		t.Fatal("unexpected fetch")
+		return "", "", nil
+	}
+
+	rec := httptest.NewRecorder()
+	jobStateStreamHandler(logtest.Scoped(t), fetchState)(rec, httptest.NewRequest("GET", "/job-states/stream", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	query := "?"
+	for i := 0; i <= maxWatchedJobs; i++ {
+		query += "id=x&"
+	}
+	rec = httptest.NewRecorder()
+	jobStateStreamHandler(logtest.Scoped(t), fetchState)(rec, httptest.NewRequest("GET", "/job-states/stream"+query, nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+type fakeLimiter func(ctx context.Context, key string, quantity int, args graphqlbackend.LimiterArgs) (bool, throttled.RateLimitResult, error)
+
+func (f fakeLimiter) RateLimit(ctx context.Context, key string, quantity int, args graphqlbackend.LimiterArgs) (bool, throttled.RateLimitResult, error) {
+	return f(ctx, key, quantity, args)
+}
+
+type fakeLimitWatcher struct{ limiter graphqlbackend.Limiter }
+
+func (w fakeLimitWatcher) Get() (graphqlbackend.Limiter, bool) { return w.limiter, w.limiter != nil }
+
+func TestRateLimitedJobStateFetcher(t *testing.T) {
+	fetchState := func(context.Context, string) (string, string, error) {
+		return "BatchSpec", "PROCESSING", nil
+	}
+
+	var results []throttled.RateLimitResult
+	var charged []int
+	limiter := fakeLimiter(func(_ context.Context, _ string, quantity int, args graphqlbackend.LimiterArgs) (bool, throttled.RateLimitResult, error) {
+		assert.Equal(t, int32(1), args.UserID)
+		charged = append(charged, quantity)
+		result := results[0]
+		results = results[1:]
+		return result.RetryAfter != 0, result, nil
+	})
+	fetch := rateLimitedJobStateFetcher(logtest.Scoped(t), fakeLimitWatcher{limiter}, fetchState)
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	results = []throttled.RateLimitResult{{}, {RetryAfter: time.Second}, {RetryAfter: -1}}
+
+	typeName, state, err := fetch(ctx, "spec")
+	require.NoError(t, err)
+	assert.Equal(t, "BatchSpec", typeName)
+	assert.Equal(t, "PROCESSING", state)
+
+	// A rate limited poll is retried later.
+	_, _, err = fetch(ctx, "spec")
+	assert.ErrorIs(t, err, errJobStateRateLimited)
+
+	// A poll that costs more than the budget allows fails.
+	_, _, err = fetch(ctx, "spec")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errJobStateRateLimited)
+
+	// Every poll is charged the cost of the query.
+	require.Len(t, charged, 3)
+	assert.Greater(t, charged[0], 0)
+	assert.Equal(t, charged[0], charged[1])
+
+	// Nothing is charged while rate limiting is disabled.
+	fetch = rateLimitedJobStateFetcher(logtest.Scoped(t), fakeLimitWatcher{}, fetchState)
+	_, _, err = fetch(ctx, "spec")
+	require.NoError(t, err)
+	assert.Len(t, charged, 3)
+}
@@ -8,11 +8,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
+	"github.com/sourcegraph/sourcegraph/internal/metrics/tenant"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
 var (
-	metricLabels    = []string{"mutation", "route", "success"}
+	metricLabels    = []string{"mutation", "route", "success", tenant.LabelName}
 	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "src_graphql_request_duration_seconds",
 		Help:    "GraphQL request latencies in seconds.",
@@ -23,9 +24,10 @@ var (
 func instrumentGraphQL(data traceData) {
 	duration := time.Since(data.execStart)
 	labels := prometheus.Labels{
-		"route":    data.requestName,
-		"success":  strconv.FormatBool(len(data.queryErrors) == 0),
-		"mutation": strconv.FormatBool(strings.Contains(data.queryParams.Query, "mutation")),
+		"route":          data.requestName,
+		"success":        strconv.FormatBool(len(data.queryErrors) == 0),
+		"mutation":       strconv.FormatBool(strings.Contains(data.queryParams.Query, "mutation")),
+		tenant.LabelName: data.tenant,
 	}
 	requestDuration.With(labels).Observe(duration.Seconds())
 }
@@ -0,0 +1,195 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+	"github.com/throttled/throttled/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RequestRateLimitWatcher keeps the request rate limits of the REST and streaming
+// API endpoints in sync with the site configuration.
+type RequestRateLimitWatcher struct {
+	store throttled.GCRAStoreCtx
+	rl    atomic.Pointer[requestRateLimiter]
+}
+
+// NewRequestRateLimitWatcher returns a RequestRateLimitWatcher that keeps the state
+// of the rate limits in store, which is shared by all frontend instances.
+func NewRequestRateLimitWatcher(logger log.Logger, store throttled.GCRAStoreCtx) *RequestRateLimitWatcher {
+	w := &RequestRateLimitWatcher{store: store}
+	conf.Watch(func() {
+		var c requestRateLimiterConfig
+		if rl := conf.Get().RateLimits; rl != nil {
+			c.userRequestsPerMinute = rl.UserRequestsPerMinute
+			c.accessTokenRequestsPerMinute = rl.AccessTokenRequestsPerMinute
+			c.exemptRoles = rl.RequestRateLimitExemptRoles
+		}
+		w.updateFromConfig(logger, c)
+	})
+	return w
+}
+
+// requestRateLimiterConfig holds the limits of a requestRateLimiter. Limits that
+// are not positive are disabled.
+type requestRateLimiterConfig struct {
+	userRequestsPerMinute        int
+	accessTokenRequestsPerMinute int
+	exemptRoles                  []string
+}
+
+func (w *RequestRateLimitWatcher) updateFromConfig(logger log.Logger, c requestRateLimiterConfig) {
+	limiter := &requestRateLimiter{exemptRoles: map[string]struct{}{}}
+	for _, role := range c.exemptRoles {
+		limiter.exemptRoles[role] = struct{}{}
+	}
+
+	// The limits are token buckets that hold the requests of a minute, so that
+	// clients can make all of them in a burst, and are refilled continuously.
+	for _, l := range []struct {
+		limit   int
+		limiter **throttled.GCRARateLimiterCtx
+	}{
+		{c.userRequestsPerMinute, &limiter.user},
+		{c.accessTokenRequestsPerMinute, &limiter.accessToken},
+	} {
+		if l.limit <= 0 {
+			continue
+		}
+		rl, err := throttled.NewGCRARateLimiterCtx(
+			w.store,
+			throttled.RateQuota{
+				MaxRate:  throttled.PerMin(l.limit),
+				MaxBurst: l.limit - 1,
+			},
+		)
+		if err != nil {
+			logger.Warn("error updating request rate limits from config", log.Error(err))
+			w.rl.Store(nil)
+			return
+		}
+		*l.limiter = rl
+	}
+
+	if limiter.user == nil && limiter.accessToken == nil {
+		limiter = nil
+	}
+	w.rl.Store(limiter)
+	logger.Debug("request rate limits updated",
+		log.Int("user requests per minute", c.userRequestsPerMinute),
+		log.Int("access token requests per minute", c.accessTokenRequestsPerMinute))
+}
+
+type requestRateLimiter struct {
+	user        *throttled.GCRARateLimiterCtx
+	accessToken *throttled.GCRARateLimiterCtx
+	exemptRoles map[string]struct{}
+}
+
+// rateLimit counts a request of the given user, made with the access token with
+// the given key if it is not empty, against the limits of both.
+func (l *requestRateLimiter) rateLimit(ctx context.Context, userID int32, accessTokenKey string) (bool, throttled.RateLimitResult, error) {
+	// The limit of the access token is checked first, so that a token that has
+	// exhausted its limit does not also drain the limit of its user.
+	if l.accessToken != nil && accessTokenKey != "" {
+		limited, result, err := l.accessToken.RateLimitCtx(ctx, "token:"+accessTokenKey, 1)
+		if err != nil || limited {
+			return limited, result, err
+		}
+	}
+	if l.user != nil {
+		return l.user.RateLimitCtx(ctx, "user:"+strconv.Itoa(int(userID)), 1)
+	}
+	return false, throttled.RateLimitResult{}, nil
+}
+
+// isExempt reports whether the given user has one of the exempt roles.
+func (l *requestRateLimiter) isExempt(ctx context.Context, db database.DB, userID int32) (bool, error) {
+	if len(l.exemptRoles) == 0 {
+		return false, nil
+	}
+	roles, err := db.Roles().List(ctx, database.RolesListOptions{UserID: userID})
+	if err != nil {
+		return false, errors.Wrap(err, "listing roles of user")
+	}
+	for _, role := range roles {
+		if _, ok := l.exemptRoles[role.Name]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// graphQLRoute is the name of the route of the GraphQL API, whose requests are
+// limited by their estimated cost instead of their rate.
+const graphQLRoute = "graphql"
+
+// requestRateLimitMiddleware rejects requests of authenticated users that exceed
+// the request rate limits of their user or access token. Unauthenticated and
+// internal requests are not limited.
+func requestRateLimitMiddleware(logger log.Logger, db database.DB, w *RequestRateLimitWatcher) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if w == nil {
+				next.ServeHTTP(rw, r)
+				return
+			}
+			if route := mux.CurrentRoute(r); route != nil && route.GetName() == graphQLRoute {
+				next.ServeHTTP(rw, r)
+				return
+			}
+			l := w.rl.Load()
+			a := actor.FromContext(r.Context())
+			if l == nil || !a.IsAuthenticated() || a.IsInternal() {
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			limited, result, err := l.rateLimit(r.Context(), a.UID, accessTokenKeyFromContext(r.Context()))
+			if err != nil {
+				// Don't fail requests because the rate limit store is unavailable.
+				logger.Error("checking request rate limit", log.Error(err))
+				next.ServeHTTP(rw, r)
+				return
+			}
+			if limited {
+				// Exemptions are only looked up for requests that exceed a limit, so
+				// that other requests don't cost an additional query.
+				exempt, err := l.isExempt(r.Context(), db, a.UID)
+				if err != nil {
+					logger.Error("checking request rate limit exemption", log.Error(err))
+				}
+				if !exempt {
+					writeRequestRateLimitError(rw, result)
+					return
+				}
+			}
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// writeRequestRateLimitError writes the response for a request that exceeded its
+// rate limit. Clients are told when they can retry in the Retry-After header.
+func writeRequestRateLimitError(w http.ResponseWriter, result throttled.RateLimitResult) {
+	message := "rate limit exceeded"
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	if result.RetryAfter >= 0 {
+		retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+		message = fmt.Sprintf("rate limit exceeded, retry after %d seconds", retryAfter)
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	http.Error(w, message, http.StatusTooManyRequests)
+}
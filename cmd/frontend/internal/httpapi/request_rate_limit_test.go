@@ -0,0 +1,104 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled/v2/store/memstore"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRequestRateLimitMiddleware(t *testing.T) {
+	logger := logtest.Scoped(t)
+
+	roles := dbmocks.NewMockRoleStore()
+	roles.ListFunc.SetDefaultHook(func(_ context.Context, opts database.RolesListOptions) ([]*types.Role, error) {
+		if opts.UserID == 3 {
+			return []*types.Role{{Name: string(types.SiteAdministratorSystemRole)}}, nil
+		}
+		return []*types.Role{{Name: string(types.UserSystemRole)}}, nil
+	})
+	db := dbmocks.NewMockDB()
+	db.RolesFunc.SetDefaultReturn(roles)
+
+	setup := func(t *testing.T, c requestRateLimiterConfig) http.Handler {
+		store, err := memstore.NewCtx(1024)
+		require.NoError(t, err)
+		w := NewRequestRateLimitWatcher(logger, store)
+		w.updateFromConfig(logger, c)
+
+		m := mux.NewRouter()
+		m.Use(requestRateLimitMiddleware(logger, db, w))
+		m.Path("/graphql").Name(graphQLRoute).HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+		m.Path("/search/stream").HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+		return m
+	}
+
+	do := func(h http.Handler, path string, a *actor.Actor, token string) *httptest.ResponseRecorder {
+		ctx := actor.WithActor(context.Background(), a)
+		if token != "" {
+			ctx = withAccessTokenKey(ctx, token)
+		}
+		req := httptest.NewRequest("GET", path, nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		h := setup(t, requestRateLimiterConfig{})
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(1), "").Code)
+		}
+	})
+
+	t.Run("user limit", func(t *testing.T) {
+		h := setup(t, requestRateLimiterConfig{userRequestsPerMinute: 2})
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(1), "").Code)
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(1), "token").Code)
+
+		rec := do(h, "/search/stream", actor.FromUser(1), "")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+		// Other users, anonymous users, internal actors and GraphQL requests are not
+		// affected.
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(2), "").Code)
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", &actor.Actor{}, "").Code)
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.Internal(), "").Code)
+		assert.Equal(t, http.StatusOK, do(h, "/graphql", actor.FromUser(1), "").Code)
+	})
+
+	t.Run("access token limit", func(t *testing.T) {
+		h := setup(t, requestRateLimiterConfig{userRequestsPerMinute: 3, accessTokenRequestsPerMinute: 1})
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(1), "a").Code)
+		assert.Equal(t, http.StatusTooManyRequests, do(h, "/search/stream", actor.FromUser(1), "a").Code)
+		// Another token and the session of the user have their own limits, and the
+		// rejected request did not count towards the limit of the user.
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(1), "b").Code)
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(1), "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, do(h, "/search/stream", actor.FromUser(1), "").Code)
+	})
+
+	t.Run("exempt roles", func(t *testing.T) {
+		h := setup(t, requestRateLimiterConfig{
+			userRequestsPerMinute: 1,
+			exemptRoles:           []string{string(types.SiteAdministratorSystemRole)},
+		})
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(3), "").Code)
+		}
+		assert.Equal(t, http.StatusOK, do(h, "/search/stream", actor.FromUser(1), "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, do(h, "/search/stream", actor.FromUser(1), "").Code)
+	})
+}
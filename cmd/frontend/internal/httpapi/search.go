@@ -162,6 +162,14 @@ type searchIndexerServer struct {
 
 	SearchContextsRepoRevs func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
 
+	// IndexBranchRules returns the rules configuring additional branches to
+	// index for repositories matching a pattern.
+	IndexBranchRules func(context.Context) ([]searchbackend.IndexBranchRule, error)
+
+	// RepoIndexBranches returns the additional branches to index configured
+	// for individual repositories.
+	RepoIndexBranches func(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
+
 	// Indexers is the subset of searchbackend.Indexers methods we
 	// use. reposListServer is used by indexed-search to get the list of
 	// repositories to index. These methods are used to return the correct
@@ -256,11 +264,15 @@ func (h *searchIndexerServer) doSearchConfiguration(ctx context.Context, paramet
 		return nil, &parameterError{err: "at least one repoID required"}
 	}
 
+	branchRules, err := h.IndexBranchRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var minLastChanged time.Time
 	nextFingerPrint := parameters.fingerprint
 	if !h.MinLastChangedDisabled {
-		var err error
-		fp, err := searchbackend.NewConfigFingerprint(&siteConfig)
+		fp, err := searchbackend.NewConfigFingerprint(&siteConfig, branchRules...)
 		if err != nil {
 			return nil, err
 		}
@@ -306,6 +318,9 @@ func (h *searchIndexerServer) doSearchConfiguration(ctx context.Context, paramet
 		rankingLastUpdatedAt = make(map[api.RepoID]time.Time)
 	}
 
+	getRuleBranches := searchbackend.IndexBranchRulesFunc(branchRules)
+	repoBranches, repoBranchesErr := h.RepoIndexBranches(ctx, parameters.repoIDs)
+
 	getRepoIndexOptions := func(repoID api.RepoID) (*searchbackend.RepoIndexOptions, error) {
 		if loadReposErr != nil {
 			return nil, loadReposErr
@@ -337,6 +352,11 @@ func (h *searchIndexerServer) doSearchConfiguration(ctx context.Context, paramet
 			documentRanksVersion = t.String()
 		}
 
+		if repoBranchesErr != nil {
+			return nil, repoBranchesErr
+		}
+		branches := append(getRuleBranches(string(repo.Name)), repoBranches[repoID]...)
+
 		return &searchbackend.RepoIndexOptions{
 			Name:       string(repo.Name),
 			RepoID:     repo.ID,
@@ -345,6 +365,7 @@ func (h *searchIndexerServer) doSearchConfiguration(ctx context.Context, paramet
 			Fork:       repo.Fork,
 			Archived:   repo.Archived,
 			GetVersion: getVersion,
+			Branches:   branches,
 
 			DocumentRanksVersion: documentRanksVersion,
 		}, nil
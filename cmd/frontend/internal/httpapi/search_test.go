@@ -28,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -53,6 +54,12 @@ func TestServeConfiguration(t *testing.T) {
 	searchContextRepoRevsFunc := func(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID][]string, error) {
 		return map[api.RepoID][]string{6: {"a", "b"}}, nil
 	}
+	indexBranchRulesFunc := func(ctx context.Context) ([]searchbackend.IndexBranchRule, error) {
+		return nil, nil
+	}
+	repoIndexBranchesFunc := func(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID][]string, error) {
+		return nil, nil
+	}
 	rankingService := &fakeRankingService{}
 
 	t.Run("gRPC", func(t *testing.T) {
@@ -64,6 +71,8 @@ func TestServeConfiguration(t *testing.T) {
 				gitserverClient:        gsClient,
 				Ranking:                rankingService,
 				SearchContextsRepoRevs: searchContextRepoRevsFunc,
+				IndexBranchRules:       indexBranchRulesFunc,
+				RepoIndexBranches:      repoIndexBranchesFunc,
 			},
 		}
 
@@ -200,6 +209,8 @@ func TestServeConfiguration(t *testing.T) {
 			gitserverClient:        gsClient,
 			Ranking:                rankingService,
 			SearchContextsRepoRevs: searchContextRepoRevsFunc,
+			IndexBranchRules:       indexBranchRulesFunc,
+			RepoIndexBranches:      repoIndexBranchesFunc,
 		}
 
 		data := url.Values{
@@ -252,6 +263,40 @@ func TestServeConfiguration(t *testing.T) {
 		}
 	})
 
+	t.Run("branch configuration", func(t *testing.T) {
+		srv := &searchIndexerServer{
+			RepoStore:              repoStore,
+			gitserverClient:        gsClient,
+			Ranking:                rankingService,
+			SearchContextsRepoRevs: searchContextRepoRevsFunc,
+			IndexBranchRules: func(ctx context.Context) ([]searchbackend.IndexBranchRule, error) {
+				return []searchbackend.IndexBranchRule{{RepoPattern: "^5$", Branches: []string{"release"}}}, nil
+			},
+			RepoIndexBranches: func(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID][]string, error) {
+				return map[api.RepoID][]string{6: {"b", "c"}}, nil
+			},
+		}
+
+		response, err := srv.doSearchConfiguration(context.Background(), searchConfigurationParameters{repoIDs: []api.RepoID{5, 6}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		have := map[api.RepoID][]string{}
+		for _, options := range response.options {
+			for _, branch := range options.Branches {
+				have[options.RepoID] = append(have[options.RepoID], branch.Name)
+			}
+		}
+
+		want := map[api.RepoID][]string{
+			5: {"HEAD", "release"},
+			6: {"HEAD", "a", "b", "c"},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("mismatch in indexed branches (-want, +got):\n%s", diff)
+		}
+	})
 }
 
 func TestReposIndex(t *testing.T) {
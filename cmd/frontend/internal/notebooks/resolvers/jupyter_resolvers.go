@@ -0,0 +1,53 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/notebooks"
+)
+
+func (r *Resolver) ImportJupyterNotebook(ctx context.Context, args graphqlbackend.ImportJupyterNotebookArgs) (graphqlbackend.NotebookResolver, error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	title, blocks, err := notebooks.ImportJupyter([]byte(args.Notebook.Content))
+	if err != nil {
+		return nil, err
+	}
+	if args.Notebook.Title != nil {
+		title = *args.Notebook.Title
+	}
+
+	notebook := &notebooks.Notebook{
+		Title:         title,
+		Public:        args.Notebook.Public,
+		CreatorUserID: user.ID,
+		UpdaterUserID: user.ID,
+		Blocks:        blocks,
+	}
+	err = graphqlbackend.UnmarshalNamespaceID(args.Notebook.Namespace, &notebook.NamespaceUserID, &notebook.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	createdNotebook, err := notebooks.Notebooks(r.db).CreateNotebook(ctx, notebook)
+	if err != nil {
+		return nil, err
+	}
+	return &notebookResolver{createdNotebook, r.db}, nil
+}
+
+func (r *notebookResolver) JupyterNotebook(ctx context.Context) (string, error) {
+	data, err := notebooks.ExportJupyter(r.notebook)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,108 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (r *ownResolver) ImportAssignedOwnership(ctx context.Context, args *graphqlbackend.ImportAssignedOwnershipArgs) (graphqlbackend.AssignedOwnershipImportResultResolver, error) {
+	// Internal actor is a no-op, only a user can assign an owner.
+	if actor.FromContext(ctx).IsInternal() {
+		return nil, nil
+	}
+	user, err := r.checkAssignedOwnershipPermission(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := own.ParseAssignedOwnershipImport(own.ImportFormat(args.Input.Format), strings.NewReader(args.Input.Data))
+	if err != nil {
+		return nil, err
+	}
+	dryRun := args.Input.DryRun != nil && *args.Input.DryRun
+	res, err := own.ImportAssignedOwnership(ctx, r.db, r.gitserver, entries, user.ID, dryRun)
+	if err != nil {
+		return nil, errors.Wrap(err, "importing assigned ownership")
+	}
+	return &assignedOwnershipImportResultResolver{db: r.db, gitserver: r.gitserver, result: res}, nil
+}
+
+type assignedOwnershipImportResultResolver struct {
+	db        database.DB
+	gitserver gitserver.Client
+	result    *own.AssignedOwnershipImportResult
+}
+
+func (r *assignedOwnershipImportResultResolver) Applied() bool {
+	return r.result.Applied
+}
+
+func (r *assignedOwnershipImportResultResolver) Assignments() []graphqlbackend.AssignedOwnershipImportAssignmentResolver {
+	resolvers := make([]graphqlbackend.AssignedOwnershipImportAssignmentResolver, 0, len(r.result.Assignments))
+	for _, a := range r.result.Assignments {
+		resolvers = append(resolvers, &assignedOwnershipImportAssignmentResolver{db: r.db, gitserver: r.gitserver, assignment: a})
+	}
+	return resolvers
+}
+
+func (r *assignedOwnershipImportResultResolver) Errors() []graphqlbackend.AssignedOwnershipImportErrorResolver {
+	resolvers := make([]graphqlbackend.AssignedOwnershipImportErrorResolver, 0, len(r.result.Errors))
+	for _, e := range r.result.Errors {
+		resolvers = append(resolvers, &assignedOwnershipImportErrorResolver{err: e})
+	}
+	return resolvers
+}
+
+type assignedOwnershipImportAssignmentResolver struct {
+	db         database.DB
+	gitserver  gitserver.Client
+	assignment own.ImportedAssignment
+}
+
+func (r *assignedOwnershipImportAssignmentResolver) Line() int32 {
+	return int32(r.assignment.Line)
+}
+
+func (r *assignedOwnershipImportAssignmentResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return graphqlbackend.NewRepositoryResolver(r.db, r.gitserver, r.assignment.Repo)
+}
+
+func (r *assignedOwnershipImportAssignmentResolver) Path() string {
+	return r.assignment.Path
+}
+
+func (r *assignedOwnershipImportAssignmentResolver) User(ctx context.Context) *graphqlbackend.UserResolver {
+	if r.assignment.User == nil {
+		return nil
+	}
+	return graphqlbackend.NewUserResolver(ctx, r.db, r.assignment.User)
+}
+
+func (r *assignedOwnershipImportAssignmentResolver) Team() *graphqlbackend.TeamResolver {
+	if r.assignment.Team == nil {
+		return nil
+	}
+	return graphqlbackend.NewTeamResolver(r.db, r.assignment.Team)
+}
+
+func (r *assignedOwnershipImportAssignmentResolver) AlreadyAssigned() bool {
+	return r.assignment.Exists
+}
+
+type assignedOwnershipImportErrorResolver struct {
+	err own.ImportError
+}
+
+func (r *assignedOwnershipImportErrorResolver) Line() int32 {
+	return int32(r.err.Line)
+}
+
+func (r *assignedOwnershipImportErrorResolver) Message() string {
+	return r.err.Message
+}
@@ -0,0 +1,54 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func computeBlameSignals(ctx context.Context, db database.DB, path string, repoID api.RepoID) ([]reasonAndReference, error) {
+	enabled, err := db.OwnSignalConfigurations().IsEnabled(ctx, types.SignalBlame)
+	if err != nil {
+		return nil, errors.Wrap(err, "IsEnabled")
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	authors, err := db.BlameSignals().FindBlameAuthors(ctx, repoID, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindBlameAuthors")
+	}
+
+	var rrs []reasonAndReference
+	for _, a := range authors {
+		rrs = append(rrs, reasonAndReference{
+			reason: ownershipReason{blameLineCount: a.LineCount},
+			reference: own.Reference{
+				// Just use the email.
+				Email: a.AuthorEmail,
+			},
+		})
+	}
+	return rrs, nil
+}
+
+type blameOwnershipSignal struct {
+	total int32
+}
+
+func (g *blameOwnershipSignal) Title() (string, error) {
+	return "blame", nil
+}
+
+func (g *blameOwnershipSignal) Description() (string, error) {
+	if g.total == 1 {
+		return "Associated because they are the author of 1 line according to git blame.", nil
+	}
+	return fmt.Sprintf("Associated because they are the author of %d lines according to git blame.", g.total), nil
+}
@@ -26,13 +26,15 @@ func (r *ownResolver) computeCodeowners(ctx context.Context, blob *graphqlbacken
 	if err != nil {
 		return nil, err
 	}
-	var rule *codeownerspb.Rule
+	// Every section of the CODEOWNERS file is evaluated separately, so
+	// there can be a matching rule per section.
+	var rules []*codeownerspb.Rule
 	if ruleset != nil {
-		rule = ruleset.Match(blob.Path())
+		rules = ruleset.MatchSections(blob.Path())
 	}
 	// Compute repo context if possible to allow better unification of references.
 	var repoContext *own.RepoContext
-	if len(rule.GetOwner()) > 0 {
+	if len(rules) > 0 {
 		spec, err := repo.ExternalRepo(ctx)
 		// Best effort resolution. We still want to serve the reason if external service cannot be resolved here.
 		if err == nil {
@@ -44,18 +46,25 @@ func (r *ownResolver) computeCodeowners(ctx context.Context, blob *graphqlbacken
 	}
 	// Return references
 	var rrs []reasonAndReference
-	for _, o := range rule.GetOwner() {
-		rrs = append(rrs, reasonAndReference{
-			reason: ownershipReason{
-				codeownersRule:   rule,
-				codeownersSource: ruleset.GetSource(),
-			},
-			reference: own.Reference{
-				RepoContext: repoContext,
-				Handle:      o.Handle,
-				Email:       o.Email,
-			},
-		})
+	for _, rule := range rules {
+		for _, o := range rule.GetOwner() {
+			// Owners given by a GitLab role don't refer to a specific user
+			// or team, so they cannot be resolved.
+			if o.GetRole() != "" {
+				continue
+			}
+			rrs = append(rrs, reasonAndReference{
+				reason: ownershipReason{
+					codeownersRule:   rule,
+					codeownersSource: ruleset.GetSource(),
+				},
+				reference: own.Reference{
+					RepoContext: repoContext,
+					Handle:      o.Handle,
+					Email:       o.Email,
+				},
+			})
+		}
 	}
 	return rrs, nil
 }
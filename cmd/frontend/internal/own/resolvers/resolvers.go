@@ -54,6 +54,8 @@ var (
 	_ graphqlbackend.SimpleOwnReasonResolver                  = &recentContributorOwnershipSignal{}
 	_ graphqlbackend.RecentViewOwnershipSignalResolver        = &recentViewOwnershipSignal{}
 	_ graphqlbackend.SimpleOwnReasonResolver                  = &recentViewOwnershipSignal{}
+	_ graphqlbackend.BlameOwnershipSignalResolver             = &blameOwnershipSignal{}
+	_ graphqlbackend.SimpleOwnReasonResolver                  = &blameOwnershipSignal{}
 	_ graphqlbackend.AssignedOwnerResolver                    = &assignedOwner{}
 	_ graphqlbackend.SimpleOwnReasonResolver                  = &assignedOwner{}
 	_ graphqlbackend.SimpleOwnReasonResolver                  = &codeownersFileEntryResolver{}
@@ -75,6 +77,7 @@ type ownershipReason struct {
 	codeownersSource         codeowners.RulesetSource
 	recentContributionsCount int
 	recentViewsCount         int
+	blameLineCount           int
 	assignedOwnerPath        []string
 }
 
@@ -105,6 +108,11 @@ func (o *ownershipReasonResolver) ToRecentViewOwnershipSignal() (res graphqlback
 	return
 }
 
+func (o *ownershipReasonResolver) ToBlameOwnershipSignal() (res graphqlbackend.BlameOwnershipSignalResolver, ok bool) {
+	res, ok = o.resolver.(*blameOwnershipSignal)
+	return
+}
+
 func (o *ownershipReasonResolver) ToAssignedOwner() (res graphqlbackend.AssignedOwnerResolver, ok bool) {
 	res, ok = o.resolver.(*assignedOwner)
 	return
@@ -154,6 +162,15 @@ func (r *ownResolver) GitBlobOwnership(
 		rrs = append(rrs, viewerResolvers...)
 	}
 
+	// Retrieve blame signals.
+	if args.IncludeReason(graphqlbackend.BlameOwnershipSignal) {
+		blameResolvers, err := computeBlameSignals(ctx, r.db, blob.Path(), repoID)
+		if err != nil {
+			return nil, err
+		}
+		rrs = append(rrs, blameResolvers...)
+	}
+
 	if args.IncludeReason(graphqlbackend.AssignedOwner) {
 		// Retrieve assigned owners.
 		assignedOwners, err := r.computeAssignedOwners(ctx, blob, repoID)
@@ -206,6 +223,13 @@ func (r *ownResolver) GitCommitOwnership(
 	}
 	rrs = append(rrs, viewerResolvers...)
 
+	// Retrieve blame signals.
+	blameResolvers, err := computeBlameSignals(ctx, r.db, repoRootPath, repoID)
+	if err != nil {
+		return nil, err
+	}
+	rrs = append(rrs, blameResolvers...)
+
 	return r.ownershipConnection(ctx, args, rrs, commit.Repository(), "")
 }
 
@@ -231,6 +255,13 @@ func (r *ownResolver) GitTreeOwnership(
 	}
 	rrs = append(rrs, viewerResolvers...)
 
+	// Retrieve blame signals.
+	blameResolvers, err := computeBlameSignals(ctx, r.db, tree.Path(), repoID)
+	if err != nil {
+		return nil, err
+	}
+	rrs = append(rrs, blameResolvers...)
+
 	// Retrieve assigned owners.
 	assignedOwners, err := r.computeAssignedOwners(ctx, tree, repoID)
 	if err != nil {
@@ -316,12 +347,15 @@ func (ro reasonsAndOwner) String() string {
 		if r.recentViewsCount > 0 {
 			fmt.Fprint(&b, " recent-viewer")
 		}
+		if r.blameLineCount > 0 {
+			fmt.Fprint(&b, " blame")
+		}
 	}
 	return b.String()
 }
 
 func (ro reasonsAndOwner) order() int {
-	var ownershipReasons, reasons, contributions, views int
+	var ownershipReasons, reasons, contributions, views, blameLines int
 	for _, r := range ro.reasons {
 		if len(r.assignedOwnerPath) > 0 || r.codeownersRule != nil {
 			ownershipReasons++
@@ -329,12 +363,14 @@ func (ro reasonsAndOwner) order() int {
 		reasons++
 		contributions += r.recentContributionsCount
 		views += r.recentViewsCount
+		blameLines += r.blameLineCount
 	}
 	// Smaller numbers are ordered in front, so take negative score.
 	return -(100000*ownershipReasons +
 		1000*reasons +
 		10*contributions +
-		views)
+		views +
+		blameLines/10)
 }
 
 func (ro reasonsAndOwner) isOwner() bool {
@@ -573,6 +609,13 @@ func (r *ownershipResolver) Reasons(_ context.Context) ([]graphqlbackend.Ownersh
 				},
 			})
 		}
+		if reason.blameLineCount > 0 {
+			rs = append(rs, &ownershipReasonResolver{
+				resolver: &blameOwnershipSignal{
+					total: int32(reason.blameLineCount),
+				},
+			})
+		}
 	}
 	return rs, nil
 }
@@ -19,7 +19,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -83,6 +85,18 @@ func (g *GitLabHandler) Register(router *webhooks.Router) {
 	router.Register(func(ctx context.Context, db database.DB, _ extsvc.CodeHostBaseURL, payload any) error {
 		return g.handlePushEvent(ctx, db, payload)
 	}, extsvc.KindGitLab, "push")
+	router.Register(func(ctx context.Context, db database.DB, _ extsvc.CodeHostBaseURL, payload any) error {
+		return g.handleRepositoryUpdateEvent(ctx, db, payload)
+	}, extsvc.KindGitLab, gitlabwebhooks.RepositoryUpdateEventName)
+	router.Register(func(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL, payload any) error {
+		return g.handleProjectEvent(ctx, db, codeHostURN, payload)
+	}, extsvc.KindGitLab,
+		gitlabwebhooks.ProjectCreateEventName,
+		gitlabwebhooks.ProjectDestroyEventName,
+		gitlabwebhooks.ProjectRenameEventName,
+		gitlabwebhooks.ProjectTransferEventName,
+		gitlabwebhooks.ProjectUpdateEventName,
+	)
 }
 
 func (g *GitLabHandler) handlePushEvent(ctx context.Context, db database.DB, payload any) error {
@@ -96,6 +110,75 @@ func gitLabCloneURLFromEvent(event *gitlabwebhooks.PushEvent) (string, error) {
 	return event.Repository.GitSSHURL, nil
 }
 
+// handleRepositoryUpdateEvent handles the repository_update system hook event,
+// which is sent instead of a push event when system hooks are used.
+func (g *GitLabHandler) handleRepositoryUpdateEvent(ctx context.Context, db database.DB, payload any) error {
+	return handlePushEvent[*gitlabwebhooks.RepositoryUpdateEvent](ctx, db, g.logger, payload, gitLabCloneURLFromRepositoryUpdateEvent)
+}
+
+func gitLabCloneURLFromRepositoryUpdateEvent(event *gitlabwebhooks.RepositoryUpdateEvent) (string, error) {
+	if event == nil {
+		return "", errors.New("nil RepositoryUpdateEvent received")
+	}
+	return event.Project.GitSSHURL, nil
+}
+
+// handleProjectEvent handles the project lifecycle system hook events. Projects
+// that are created, renamed, transferred, updated (for example when their
+// visibility changes) or destroyed on GitLab are otherwise only picked up by the
+// next periodic sync, so we enqueue a sync of all GitLab external services for
+// the code host the event originated from.
+func (g *GitLabHandler) handleProjectEvent(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL, payload any) error {
+	event, ok := payload.(*gitlabwebhooks.ProjectSystemHookEvent)
+	if !ok {
+		return errors.Newf("incorrect event type: %T", payload)
+	}
+
+	logger := g.logger.With(
+		log.String("event", event.EventName),
+		log.String("project", event.PathWithNamespace),
+		log.String("codeHost", codeHostURN.String()),
+	)
+
+	return errors.Wrap(enqueueCodeHostSync(ctx, db, logger, codeHostURN), "handleProjectEvent")
+}
+
+// enqueueCodeHostSync enqueues a sync of all external services connected to
+// the code host with the given URL.
+func enqueueCodeHostSync(ctx context.Context, db database.DB, logger log.Logger, codeHostURN extsvc.CodeHostBaseURL) error {
+	svcs, err := externalServicesForCodeHost(ctx, db, codeHostURN)
+	if err != nil {
+		return errors.Wrap(err, "listing external services")
+	}
+	if len(svcs) == 0 {
+		logger.Warn("repository lifecycle event received for unknown code host")
+		return nil
+	}
+
+	store := repos.NewStore(logger, db)
+	for _, svc := range svcs {
+		if err := store.EnqueueSingleSyncJob(ctx, svc.ID); err != nil {
+			return errors.Wrapf(err, "enqueueing sync job for external service %d", svc.ID)
+		}
+	}
+
+	logger.Info("enqueued external service syncs", log.Int("count", len(svcs)))
+	return nil
+}
+
+// externalServicesForCodeHost returns all external services connected to the
+// code host with the given URL.
+func externalServicesForCodeHost(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL) ([]*types.ExternalService, error) {
+	codeHost, err := db.CodeHosts().GetByURL(ctx, codeHostURN.String())
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return db.ExternalServices().List(ctx, database.ExternalServicesListOptions{CodeHostID: codeHost.ID})
+}
+
 type BitbucketServerHandler struct {
 	logger log.Logger
 }
@@ -144,6 +227,12 @@ func (g *BitbucketCloudHandler) Register(router *webhooks.Router) {
 	router.Register(func(ctx context.Context, db database.DB, _ extsvc.CodeHostBaseURL, payload any) error {
 		return g.handlePushEvent(ctx, db, payload)
 	}, extsvc.KindBitbucketCloud, "repo:push")
+	router.Register(func(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL, payload any) error {
+		return g.handleRepoUpdatedEvent(ctx, db, codeHostURN, payload)
+	}, extsvc.KindBitbucketCloud, "repo:updated")
+	router.Register(func(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL, payload any) error {
+		return g.handleRepoLifecycleEvent(ctx, db, codeHostURN, payload)
+	}, extsvc.KindBitbucketCloud, "repo:created", "repo:deleted", "repo:transfer")
 }
 
 func (g *BitbucketCloudHandler) handlePushEvent(ctx context.Context, db database.DB, payload any) error {
@@ -154,7 +243,62 @@ func bitbucketCloudCloneURLFromEvent(event *bitbucketcloud.PushEvent) (string, e
 	if event == nil {
 		return "", errors.New("nil PushEvent received")
 	}
-	href := event.Repository.Links.HTML.Href
+	return bitbucketCloudCloneURLFromRepo(&event.Repository)
+}
+
+// handleRepoUpdatedEvent handles changes to the settings of a repository. Only
+// the affected repository is updated, unless it was renamed: the repository
+// can't be found under its new name yet, so the whole code host is synced.
+func (g *BitbucketCloudHandler) handleRepoUpdatedEvent(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL, payload any) error {
+	event, ok := payload.(*bitbucketcloud.RepoUpdatedEvent)
+	if !ok {
+		return errors.Newf("incorrect event type: %T", payload)
+	}
+
+	if event.Changes.FullName != nil {
+		logger := g.logger.With(
+			log.String("event", "repo:updated"),
+			log.String("repo", event.Repository.FullName),
+			log.String("codeHost", codeHostURN.String()),
+		)
+		return errors.Wrap(enqueueCodeHostSync(ctx, db, logger, codeHostURN), "handleRepoUpdatedEvent")
+	}
+
+	return handlePushEvent[*bitbucketcloud.RepoUpdatedEvent](ctx, db, g.logger, event, func(event *bitbucketcloud.RepoUpdatedEvent) (string, error) {
+		return bitbucketCloudCloneURLFromRepo(&event.Repository)
+	})
+}
+
+// handleRepoLifecycleEvent handles repositories being created, deleted or
+// transferred to another workspace. These are otherwise only picked up by the
+// next periodic sync, so we enqueue a sync of all Bitbucket Cloud external
+// services for the code host the event originated from.
+func (g *BitbucketCloudHandler) handleRepoLifecycleEvent(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL, payload any) error {
+	var (
+		eventName string
+		repo      bitbucketcloud.Repo
+	)
+	switch e := payload.(type) {
+	case *bitbucketcloud.RepoCreatedEvent:
+		eventName, repo = "repo:created", e.Repository
+	case *bitbucketcloud.RepoDeletedEvent:
+		eventName, repo = "repo:deleted", e.Repository
+	case *bitbucketcloud.RepoTransferEvent:
+		eventName, repo = "repo:transfer", e.Repository
+	default:
+		return errors.Newf("incorrect event type: %T", payload)
+	}
+
+	logger := g.logger.With(
+		log.String("event", eventName),
+		log.String("repo", repo.FullName),
+		log.String("codeHost", codeHostURN.String()),
+	)
+	return errors.Wrap(enqueueCodeHostSync(ctx, db, logger, codeHostURN), "handleRepoLifecycleEvent")
+}
+
+func bitbucketCloudCloneURLFromRepo(repo *bitbucketcloud.Repo) (string, error) {
+	href := repo.Links.HTML.Href
 	if href == "" {
 		return "", errors.New("clone url is empty")
 	}
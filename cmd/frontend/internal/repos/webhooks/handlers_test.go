@@ -27,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
@@ -231,6 +232,50 @@ func TestGitLabHandler(t *testing.T) {
 	assert.Equal(t, repoName, updateQueued)
 }
 
+func TestGitLabHandler_ProjectEvents(t *testing.T) {
+	codeHostURN, err := extsvc.NewCodeHostBaseURL("https://gitlab.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codeHosts := dbmocks.NewMockCodeHostStore()
+	codeHosts.GetByURLFunc.SetDefaultHook(func(_ context.Context, url string) (*types.CodeHost, error) {
+		assert.Equal(t, codeHostURN.String(), url)
+		// No external services are connected to the code host, so no sync
+		// jobs are enqueued.
+		return nil, &errcode.Mock{IsNotFound: true}
+	})
+	db := dbmocks.NewMockDB()
+	db.CodeHostsFunc.SetDefaultReturn(codeHosts)
+
+	router := &webhooks.Router{Logger: logtest.Scoped(t), DB: db}
+	NewGitLabHandler().Register(router)
+
+	for _, eventName := range []string{
+		gitlabwebhooks.ProjectCreateEventName,
+		gitlabwebhooks.ProjectDestroyEventName,
+		gitlabwebhooks.ProjectRenameEventName,
+		gitlabwebhooks.ProjectTransferEventName,
+		gitlabwebhooks.ProjectUpdateEventName,
+	} {
+		t.Run(eventName, func(t *testing.T) {
+			calls := len(codeHosts.GetByURLFunc.History())
+
+			payload, err := gitlabwebhooks.UnmarshalEvent([]byte(fmt.Sprintf(`{"event_name": %q, "path_with_namespace": "sourcegraph/sourcegraph"}`, eventName)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := router.Dispatch(context.Background(), eventName, extsvc.KindGitLab, codeHostURN, payload); err != nil {
+				t.Fatal(err)
+			}
+
+			// The event is handled by looking up the external services of
+			// the code host to sync.
+			assert.Len(t, codeHosts.GetByURLFunc.History(), calls+1)
+		})
+	}
+}
+
 func TestBitbucketServerHandler(t *testing.T) {
 	repoName := "bitbucket.sgdev.org/private/test-2020-06-01"
 
@@ -302,3 +347,36 @@ func TestBitbucketCloudHandler(t *testing.T) {
 	}
 	assert.Equal(t, repoName, updateQueued)
 }
+
+func TestBitbucketCloudHandler_RepoUpdated(t *testing.T) {
+	repoName := "bitbucket.org/sourcegraph-testing/sourcegraph"
+
+	db := dbmocks.NewMockDB()
+	repositories := dbmocks.NewMockRepoStore()
+	repositories.GetFirstRepoNameByCloneURLFunc.SetDefaultHook(func(ctx context.Context, s string) (api.RepoName, error) {
+		assert.Equal(t, "https://bitbucket.org/sourcegraph-testing/sourcegraph", s)
+		return api.RepoName(repoName), nil
+	})
+	db.ReposFunc.SetDefaultReturn(repositories)
+
+	var payload bitbucketcloud.RepoUpdatedEvent
+	payload.Repository.FullName = "sourcegraph-testing/sourcegraph"
+	payload.Repository.Links.HTML.Href = "https://bitbucket.org/sourcegraph-testing/sourcegraph"
+	payload.Changes.IsPrivate = &bitbucketcloud.BoolChange{Old: false, New: true}
+
+	var updateQueued string
+	repoupdater.MockEnqueueRepoUpdate = func(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
+		updateQueued = string(repo)
+		return &protocol.RepoUpdateResponse{
+			ID:   1,
+			Name: string(repo),
+		}, nil
+	}
+	t.Cleanup(func() { repoupdater.MockEnqueueRepoUpdate = nil })
+
+	handler := NewBitbucketCloudHandler()
+	if err := handler.handleRepoUpdatedEvent(context.Background(), db, extsvc.CodeHostBaseURL{}, &payload); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, repoName, updateQueued)
+}
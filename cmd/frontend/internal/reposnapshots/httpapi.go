@@ -0,0 +1,103 @@
+package reposnapshots
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/reposnapshots"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// snapshotGetter is the subset of reposnapshots.Store used by the download handler.
+type snapshotGetter interface {
+	GetSnapshot(ctx context.Context, id int) (*reposnapshots.Snapshot, error)
+}
+
+// NewDownloadHandler returns a handler that serves the manifest, the signature,
+// and the archives of completed snapshots to site admins. The snapshot ID and the
+// object name are read from the id and name route variables.
+func NewDownloadHandler(db database.DB, store snapshotGetter, uploadStore uploadstore.Store) http.Handler {
+	logger := log.Scoped("repository-snapshot-download-handler")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// 🚨 SECURITY: Only site admins may download snapshots.
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		vars := mux.Vars(r)
+		id, err := strconv.Atoi(vars["id"])
+		if err != nil {
+			http.Error(w, "snapshot not found", http.StatusNotFound)
+			return
+		}
+		name := vars["name"]
+
+		snapshot, err := store.GetSnapshot(ctx, id)
+		if err != nil {
+			if errors.Is(err, reposnapshots.ErrNotFound) {
+				http.Error(w, "snapshot not found", http.StatusNotFound)
+				return
+			}
+			logger.Error("failed to get snapshot", log.Int("id", id), log.Error(err))
+			http.Error(w, "failed to get snapshot", http.StatusInternalServerError)
+			return
+		}
+		if snapshot.State != reposnapshots.SnapshotStateCompleted || snapshot.Manifest == nil {
+			http.Error(w, "snapshot is not completed", http.StatusNotFound)
+			return
+		}
+
+		contentType, ok := objectContentType(snapshot.Manifest, name)
+		if !ok {
+			http.Error(w, "object not found", http.StatusNotFound)
+			return
+		}
+
+		rc, err := uploadStore.Get(ctx, reposnapshots.Key(id, name))
+		if err != nil {
+			logger.Error("failed to get snapshot object", log.Int("id", id), log.String("name", name), log.Error(err))
+			http.Error(w, "failed to get snapshot object", http.StatusInternalServerError)
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(name)))
+		if _, err := io.Copy(w, rc); err != nil {
+			logger.Warn("failed to write snapshot object", log.Int("id", id), log.String("name", name), log.Error(err))
+		}
+	})
+}
+
+// objectContentType returns the content type of the object of a snapshot with
+// the given manifest and name, and false if the snapshot has no such object.
+func objectContentType(manifest *reposnapshots.Manifest, name string) (string, bool) {
+	switch name {
+	case reposnapshots.ManifestName:
+		return "application/json", true
+	case reposnapshots.SignatureName:
+		return "text/plain", true
+	}
+
+	for _, archive := range manifest.Archives {
+		if archive.Name == name && strings.HasSuffix(name, ".tar.gz") {
+			return "application/gzip", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,90 @@
+package reposnapshots
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/reposnapshots"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+)
+
+type fakeSnapshotGetter map[int]*reposnapshots.Snapshot
+
+func (f fakeSnapshotGetter) GetSnapshot(_ context.Context, id int) (*reposnapshots.Snapshot, error) {
+	if snapshot, ok := f[id]; ok {
+		return snapshot, nil
+	}
+	return nil, reposnapshots.ErrNotFound
+}
+
+func TestDownloadHandler(t *testing.T) {
+	users := dbmocks.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultHook(func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: actor.FromContext(ctx).UID, SiteAdmin: actor.FromContext(ctx).UID == 1}, nil
+	})
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	snapshots := fakeSnapshotGetter{
+		1: {
+			ID:    1,
+			State: reposnapshots.SnapshotStateCompleted,
+			Manifest: &reposnapshots.Manifest{
+				SnapshotID: 1,
+				Archives:   []reposnapshots.ManifestArchive{{Name: "archives/1@deadbeef.tar.gz"}},
+			},
+		},
+		2: {ID: 2, State: reposnapshots.SnapshotStateProcessing},
+	}
+
+	uploadStore := uploadstoremocks.NewMockStore()
+	uploadStore.GetFunc.SetDefaultHook(func(_ context.Context, key string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(key)), nil
+	})
+
+	router := mux.NewRouter()
+	router.Path("/{id}/{name:.+}").Handler(NewDownloadHandler(db, snapshots, uploadStore))
+
+	tests := []struct {
+		name        string
+		userID      int32
+		path        string
+		wantStatus  int
+		wantBody    string
+		contentType string
+	}{
+		{name: "not a site admin", userID: 2, path: "/1/manifest.json", wantStatus: http.StatusForbidden},
+		{name: "manifest", userID: 1, path: "/1/manifest.json", wantStatus: http.StatusOK, wantBody: "1/manifest.json", contentType: "application/json"},
+		{name: "signature", userID: 1, path: "/1/manifest.json.sig", wantStatus: http.StatusOK, wantBody: "1/manifest.json.sig", contentType: "text/plain"},
+		{name: "archive", userID: 1, path: "/1/archives/1@deadbeef.tar.gz", wantStatus: http.StatusOK, wantBody: "1/archives/1@deadbeef.tar.gz", contentType: "application/gzip"},
+		{name: "unlisted object", userID: 1, path: "/1/archives/2@deadbeef.tar.gz", wantStatus: http.StatusNotFound},
+		{name: "snapshot not completed", userID: 1, path: "/2/manifest.json", wantStatus: http.StatusNotFound},
+		{name: "unknown snapshot", userID: 1, path: "/3/manifest.json", wantStatus: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			req = req.WithContext(actor.WithActor(req.Context(), actor.FromUser(test.userID)))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, test.wantStatus, rec.Code)
+			if test.wantStatus == http.StatusOK {
+				assert.Equal(t, test.wantBody, rec.Body.String())
+				assert.Equal(t, test.contentType, rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
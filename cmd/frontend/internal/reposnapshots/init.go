@@ -0,0 +1,38 @@
+package reposnapshots
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/reposnapshots"
+)
+
+func LoadConfig() {
+	reposnapshots.ConfigInst.Load()
+}
+
+// Init initializes the given enterpriseServices to include the required resolvers
+// and handlers for repository snapshots.
+func Init(
+	ctx context.Context,
+	observationCtx *observation.Context,
+	db database.DB,
+	_ codeintel.Services,
+	_ conftypes.UnifiedWatchable,
+	enterpriseServices *enterprise.Services,
+) error {
+	uploadStore, err := reposnapshots.NewUploadStore(ctx, observationCtx, reposnapshots.ConfigInst)
+	if err != nil {
+		return err
+	}
+	store := reposnapshots.NewStore(db, observationCtx)
+
+	enterpriseServices.RepositorySnapshotsResolver = newResolver(observationCtx.Logger, db, store, uploadStore)
+	enterpriseServices.RepoSnapshotDownloadHandler = NewDownloadHandler(db, store, uploadStore)
+
+	return nil
+}
@@ -0,0 +1,597 @@
+package reposnapshots
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/reposnapshots"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	snapshotIDKind = "RepositorySnapshot"
+	scheduleIDKind = "RepositorySnapshotSchedule"
+)
+
+func marshalSnapshotID(id int) graphql.ID {
+	return relay.MarshalID(snapshotIDKind, id)
+}
+
+func unmarshalSnapshotID(id graphql.ID) (snapshotID int, err error) {
+	if kind := relay.UnmarshalKind(id); kind != snapshotIDKind {
+		return 0, errors.Newf("expected graphql ID to have kind %q; got %q", snapshotIDKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &snapshotID)
+	return snapshotID, err
+}
+
+func marshalScheduleID(id int) graphql.ID {
+	return relay.MarshalID(scheduleIDKind, id)
+}
+
+func unmarshalScheduleID(id graphql.ID) (scheduleID int, err error) {
+	if kind := relay.UnmarshalKind(id); kind != scheduleIDKind {
+		return 0, errors.Newf("expected graphql ID to have kind %q; got %q", scheduleIDKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &scheduleID)
+	return scheduleID, err
+}
+
+// downloadURL returns the URL of the object of the given snapshot with the given
+// name, served by the handler returned by NewDownloadHandler.
+func downloadURL(snapshotID int, name string) (string, error) {
+	return url.JoinPath(conf.Get().ExternalURL, "/.api/repository-snapshots", strconv.Itoa(snapshotID), name)
+}
+
+type resolver struct {
+	logger          log.Logger
+	db              database.DB
+	gitserverClient gitserver.Client
+	store           *reposnapshots.Store
+	uploadStore     uploadstore.Store
+}
+
+var _ graphqlbackend.RepositorySnapshotsResolver = &resolver{}
+
+func newResolver(logger log.Logger, db database.DB, store *reposnapshots.Store, uploadStore uploadstore.Store) *resolver {
+	return &resolver{
+		logger:          logger,
+		db:              db,
+		gitserverClient: gitserver.NewClient("graphql.reposnapshots"),
+		store:           store,
+		uploadStore:     uploadStore,
+	}
+}
+
+func (r *resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
+	return map[string]graphqlbackend.NodeByIDFunc{
+		snapshotIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.snapshotByID(ctx, id)
+		},
+		scheduleIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.scheduleByID(ctx, id)
+		},
+	}
+}
+
+func (r *resolver) snapshotByID(ctx context.Context, id graphql.ID) (*snapshotResolver, error) {
+	// 🚨 SECURITY: Only site admins may access snapshots.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	snapshotID, err := unmarshalSnapshotID(id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := r.store.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	return r.newSnapshotResolver(snapshot), nil
+}
+
+func (r *resolver) scheduleByID(ctx context.Context, id graphql.ID) (*scheduleResolver, error) {
+	// 🚨 SECURITY: Only site admins may access snapshot schedules.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	scheduleID, err := unmarshalScheduleID(id)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := r.store.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return r.newScheduleResolver(schedule), nil
+}
+
+func (r *resolver) RepositorySnapshots(ctx context.Context, args *graphqlbackend.RepositorySnapshotsArgs) (graphqlbackend.RepositorySnapshotConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may list snapshots.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if args.First < 0 {
+		return nil, errors.New("first must be non-negative")
+	}
+	offset, err := graphqlutil.DecodeIntCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := reposnapshots.ListSnapshotsOpts{
+		Limit:  int(args.First),
+		Offset: offset,
+	}
+	if args.Schedule != nil {
+		scheduleID, err := unmarshalScheduleID(*args.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		opts.ScheduleID = &scheduleID
+	}
+
+	snapshots, totalCount, err := r.store.ListSnapshots(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.RepositorySnapshotResolver, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		resolvers = append(resolvers, r.newSnapshotResolver(snapshot))
+	}
+
+	var nextOffset *int32
+	if next := offset + len(snapshots); next < totalCount {
+		n := int32(next)
+		nextOffset = &n
+	}
+
+	return &snapshotConnectionResolver{
+		nodes:      resolvers,
+		totalCount: totalCount,
+		nextOffset: nextOffset,
+	}, nil
+}
+
+func (r *resolver) RepositorySnapshotSchedules(ctx context.Context) ([]graphqlbackend.RepositorySnapshotScheduleResolver, error) {
+	// 🚨 SECURITY: Only site admins may list snapshot schedules.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	schedules, err := r.store.ListSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.RepositorySnapshotScheduleResolver, 0, len(schedules))
+	for _, schedule := range schedules {
+		resolvers = append(resolvers, r.newScheduleResolver(schedule))
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) RepositorySnapshotSigningPublicKey(ctx context.Context) (*string, error) {
+	// 🚨 SECURITY: Only site admins may read the signing key.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	signer, err := reposnapshots.SignerFromSiteConfig()
+	if err != nil {
+		if errors.Is(err, reposnapshots.ErrNoSigningKey) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	publicKey := signer.PublicKey()
+	return &publicKey, nil
+}
+
+func (r *resolver) CreateRepositorySnapshot(ctx context.Context, args *graphqlbackend.CreateRepositorySnapshotArgs) (graphqlbackend.RepositorySnapshotResolver, error) {
+	// 🚨 SECURITY: Only site admins may create snapshots.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	// Fail early instead of enqueueing snapshots that can't be signed.
+	if _, err := reposnapshots.SignerFromSiteConfig(); err != nil {
+		return nil, err
+	}
+
+	targets, err := r.resolveTargets(ctx, args.Targets)
+	if err != nil {
+		return nil, err
+	}
+
+	initiatorID := actor.FromContext(ctx).UID
+	snapshot, err := r.store.CreateSnapshot(ctx, targets, nil, &initiatorID)
+	if err != nil {
+		return nil, err
+	}
+	return r.newSnapshotResolver(snapshot), nil
+}
+
+func (r *resolver) DeleteRepositorySnapshot(ctx context.Context, args *graphqlbackend.DeleteRepositorySnapshotArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may delete snapshots.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	snapshotID, err := unmarshalSnapshotID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := r.store.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.State == reposnapshots.SnapshotStateProcessing {
+		return nil, errors.New("cannot delete a snapshot that is being processed")
+	}
+
+	if err := reposnapshots.DeleteObjects(ctx, r.uploadStore, snapshotID); err != nil {
+		return nil, err
+	}
+	if err := r.store.DeleteSnapshot(ctx, snapshotID); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *resolver) CreateRepositorySnapshotSchedule(ctx context.Context, args *graphqlbackend.CreateRepositorySnapshotScheduleArgs) (graphqlbackend.RepositorySnapshotScheduleResolver, error) {
+	// 🚨 SECURITY: Only site admins may create snapshot schedules.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(args.Name) == "" {
+		return nil, errors.New("name must not be empty")
+	}
+	targets, err := r.resolveTargets(ctx, args.Targets)
+	if err != nil {
+		return nil, err
+	}
+
+	creatorID := actor.FromContext(ctx).UID
+	schedule, err := r.store.CreateSchedule(ctx, reposnapshots.Schedule{
+		Name:           args.Name,
+		Targets:        targets,
+		CronExpression: args.CronExpression,
+		Enabled:        args.Enabled,
+		CreatorID:      &creatorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.newScheduleResolver(schedule), nil
+}
+
+func (r *resolver) UpdateRepositorySnapshotSchedule(ctx context.Context, args *graphqlbackend.UpdateRepositorySnapshotScheduleArgs) (graphqlbackend.RepositorySnapshotScheduleResolver, error) {
+	// 🚨 SECURITY: Only site admins may update snapshot schedules.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	scheduleID, err := unmarshalScheduleID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := r.store.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Name != nil {
+		if strings.TrimSpace(*args.Name) == "" {
+			return nil, errors.New("name must not be empty")
+		}
+		schedule.Name = *args.Name
+	}
+	if args.Targets != nil {
+		targets, err := r.resolveTargets(ctx, *args.Targets)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Targets = targets
+	}
+	if args.CronExpression != nil {
+		schedule.CronExpression = *args.CronExpression
+	}
+	if args.Enabled != nil {
+		schedule.Enabled = *args.Enabled
+	}
+
+	updated, err := r.store.UpdateSchedule(ctx, *schedule)
+	if err != nil {
+		return nil, err
+	}
+	return r.newScheduleResolver(updated), nil
+}
+
+func (r *resolver) DeleteRepositorySnapshotSchedule(ctx context.Context, args *graphqlbackend.DeleteRepositorySnapshotScheduleArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may delete snapshot schedules.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	scheduleID, err := unmarshalScheduleID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.DeleteSchedule(ctx, scheduleID); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// resolveTargets converts the given target inputs and checks that their
+// repositories exist.
+func (r *resolver) resolveTargets(ctx context.Context, inputs []graphqlbackend.RepositorySnapshotTargetInput) ([]reposnapshots.Target, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("at least one target is required")
+	}
+
+	targets := make([]reposnapshots.Target, 0, len(inputs))
+	for _, input := range inputs {
+		repoID, err := graphqlbackend.UnmarshalRepositoryID(input.Repository)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := r.db.Repos().Get(ctx, repoID); err != nil {
+			return nil, err
+		}
+
+		revision := "HEAD"
+		if input.Revision != nil && *input.Revision != "" {
+			revision = *input.Revision
+		}
+		targets = append(targets, reposnapshots.Target{RepositoryID: int32(repoID), Revision: revision})
+	}
+	return targets, nil
+}
+
+func (r *resolver) newTargetResolvers(targets []reposnapshots.Target) []graphqlbackend.RepositorySnapshotTargetResolver {
+	resolvers := make([]graphqlbackend.RepositorySnapshotTargetResolver, 0, len(targets))
+	for _, target := range targets {
+		resolvers = append(resolvers, &targetResolver{db: r.db, gitserverClient: r.gitserverClient, target: target})
+	}
+	return resolvers
+}
+
+type snapshotConnectionResolver struct {
+	nodes      []graphqlbackend.RepositorySnapshotResolver
+	totalCount int
+	nextOffset *int32
+}
+
+func (r *snapshotConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.RepositorySnapshotResolver, error) {
+	return r.nodes, nil
+}
+
+func (r *snapshotConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	return int32(r.totalCount), nil
+}
+
+func (r *snapshotConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	return graphqlutil.EncodeIntCursor(r.nextOffset), nil
+}
+
+type targetResolver struct {
+	db              database.DB
+	gitserverClient gitserver.Client
+	target          reposnapshots.Target
+}
+
+func (r *targetResolver) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	repo, err := r.db.Repos().Get(ctx, api.RepoID(r.target.RepositoryID))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return graphqlbackend.NewRepositoryResolver(r.db, r.gitserverClient, repo), nil
+}
+
+func (r *targetResolver) Revision() string {
+	return r.target.Revision
+}
+
+type snapshotResolver struct {
+	resolver *resolver
+	snapshot *reposnapshots.Snapshot
+}
+
+func (r *resolver) newSnapshotResolver(snapshot *reposnapshots.Snapshot) *snapshotResolver {
+	return &snapshotResolver{resolver: r, snapshot: snapshot}
+}
+
+func (r *snapshotResolver) ID() graphql.ID {
+	return marshalSnapshotID(r.snapshot.ID)
+}
+
+func (r *snapshotResolver) State() string {
+	return strings.ToUpper(string(r.snapshot.State))
+}
+
+func (r *snapshotResolver) Failure() *string {
+	return r.snapshot.FailureMessage
+}
+
+func (r *snapshotResolver) Targets() []graphqlbackend.RepositorySnapshotTargetResolver {
+	return r.resolver.newTargetResolvers(r.snapshot.Targets)
+}
+
+func (r *snapshotResolver) Schedule(ctx context.Context) (graphqlbackend.RepositorySnapshotScheduleResolver, error) {
+	if r.snapshot.ScheduleID == nil {
+		return nil, nil
+	}
+
+	schedule, err := r.resolver.store.GetSchedule(ctx, *r.snapshot.ScheduleID)
+	if err != nil {
+		if errors.Is(err, reposnapshots.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.resolver.newScheduleResolver(schedule), nil
+}
+
+func (r *snapshotResolver) Initiator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	return userByID(ctx, r.resolver.db, r.snapshot.InitiatorID)
+}
+
+func (r *snapshotResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.snapshot.CreatedAt}
+}
+
+func (r *snapshotResolver) StartedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.snapshot.StartedAt)
+}
+
+func (r *snapshotResolver) FinishedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.snapshot.FinishedAt)
+}
+
+func (r *snapshotResolver) Manifest() graphqlbackend.RepositorySnapshotManifestResolver {
+	if r.snapshot.State != reposnapshots.SnapshotStateCompleted || r.snapshot.Manifest == nil {
+		return nil
+	}
+	return &manifestResolver{snapshot: r.snapshot}
+}
+
+type manifestResolver struct {
+	snapshot *reposnapshots.Snapshot
+}
+
+func (r *manifestResolver) Archives() []graphqlbackend.RepositorySnapshotArchiveResolver {
+	resolvers := make([]graphqlbackend.RepositorySnapshotArchiveResolver, 0, len(r.snapshot.Manifest.Archives))
+	for _, archive := range r.snapshot.Manifest.Archives {
+		resolvers = append(resolvers, &archiveResolver{snapshotID: r.snapshot.ID, archive: archive})
+	}
+	return resolvers
+}
+
+func (r *manifestResolver) URL() (string, error) {
+	return downloadURL(r.snapshot.ID, reposnapshots.ManifestName)
+}
+
+func (r *manifestResolver) Signature() string {
+	return r.snapshot.Signature
+}
+
+func (r *manifestResolver) SignatureURL() (string, error) {
+	return downloadURL(r.snapshot.ID, reposnapshots.SignatureName)
+}
+
+type archiveResolver struct {
+	snapshotID int
+	archive    reposnapshots.ManifestArchive
+}
+
+func (r *archiveResolver) RepositoryName() string { return r.archive.Repository }
+func (r *archiveResolver) Revision() string       { return r.archive.Revision }
+func (r *archiveResolver) Commit() string         { return r.archive.Commit }
+func (r *archiveResolver) SHA256() string         { return r.archive.SHA256 }
+
+func (r *archiveResolver) Size() graphqlbackend.BigInt {
+	return graphqlbackend.BigInt(r.archive.Size)
+}
+
+func (r *archiveResolver) URL() (string, error) {
+	return downloadURL(r.snapshotID, r.archive.Name)
+}
+
+type scheduleResolver struct {
+	resolver *resolver
+	schedule *reposnapshots.Schedule
+}
+
+func (r *resolver) newScheduleResolver(schedule *reposnapshots.Schedule) *scheduleResolver {
+	return &scheduleResolver{resolver: r, schedule: schedule}
+}
+
+func (r *scheduleResolver) ID() graphql.ID {
+	return marshalScheduleID(r.schedule.ID)
+}
+
+func (r *scheduleResolver) Name() string {
+	return r.schedule.Name
+}
+
+func (r *scheduleResolver) Targets() []graphqlbackend.RepositorySnapshotTargetResolver {
+	return r.resolver.newTargetResolvers(r.schedule.Targets)
+}
+
+func (r *scheduleResolver) CronExpression() string {
+	return r.schedule.CronExpression
+}
+
+func (r *scheduleResolver) Enabled() bool {
+	return r.schedule.Enabled
+}
+
+func (r *scheduleResolver) LastEnqueuedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.schedule.LastEnqueuedAt)
+}
+
+func (r *scheduleResolver) NextRunAt() *gqlutil.DateTime {
+	if !r.schedule.Enabled {
+		return nil
+	}
+	next, err := reposnapshots.NextRun(r.schedule)
+	if err != nil || next.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: next}
+}
+
+func (r *scheduleResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	return userByID(ctx, r.resolver.db, r.schedule.CreatorID)
+}
+
+func (r *scheduleResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.schedule.CreatedAt}
+}
+
+func (r *scheduleResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.schedule.UpdatedAt}
+}
+
+func userByID(ctx context.Context, db database.DB, id *int32) (*graphqlbackend.UserResolver, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, db, *id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
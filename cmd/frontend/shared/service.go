@@ -6,6 +6,8 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/cli"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/codeintel"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/reposnapshots"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -25,6 +27,8 @@ func (svc) Configure() (env.Config, []debugserver.Endpoint) {
 	CLILoadConfig()
 	codeintel.LoadConfig()
 	search.LoadConfig()
+	executorqueue.LoadConfig()
+	reposnapshots.LoadConfig()
 	return nil, CreateDebugServerEndpoints()
 }
 
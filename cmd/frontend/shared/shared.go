@@ -35,6 +35,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/rbac"
 	_ "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/registry"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/repos/webhooks"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/reposnapshots"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/scim"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/searchcontexts"
@@ -70,6 +71,7 @@ var initFunctions = map[string]EnterpriseInitializer{
 	"own":            own.Init,
 	"rbac":           rbac.Init,
 	"repos.webhooks": webhooks.Init,
+	"reposnapshots":  reposnapshots.Init,
 	"scim":           scim.Init,
 	"searchcontexts": searchcontexts.Init,
 	"contentLibrary": contentlibrary.Init,
@@ -120,12 +122,18 @@ func mustInitializeCodeIntelDB(logger log.Logger) codeintelshared.CodeIntelDB {
 		return serviceConnections.CodeIntelPostgresDSN
 	})
 
-	db, err := connections.EnsureNewCodeIntelDB(observation.NewContext(logger), dsn, "frontend")
+	observationCtx := observation.NewContext(logger)
+	db, err := connections.EnsureNewCodeIntelDB(observationCtx, dsn, "frontend")
 	if err != nil {
 		logger.Fatal("Failed to connect to codeintel database", log.Error(err))
 	}
 
-	return codeintelshared.NewCodeIntelDB(logger, db)
+	replica, err := connections.NewCodeIntelReplicaDB(observationCtx, "frontend")
+	if err != nil {
+		logger.Fatal("Failed to connect to codeintel database replica", log.Error(err))
+	}
+
+	return codeintelshared.NewCodeIntelDBWithReplica(logger, db, replica, connections.ReplicaMaxLag)
 }
 
 func SwitchableSiteConfig() conftypes.WatchableSiteConfig {
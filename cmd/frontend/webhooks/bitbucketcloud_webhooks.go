@@ -4,6 +4,8 @@ import (
 	"io"
 	"net/http"
 
+	gh "github.com/google/go-github/v55/github"
+
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -13,13 +15,26 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func (wr *Router) HandleBitbucketCloudWebhook(logger log.Logger, w http.ResponseWriter, r *http.Request, codeHostURN extsvc.CodeHostBaseURL) {
+func (wr *Router) HandleBitbucketCloudWebhook(logger log.Logger, w http.ResponseWriter, r *http.Request, codeHostURN extsvc.CodeHostBaseURL, secret string) {
 	payload, err := io.ReadAll(r.Body)
 	if err != nil {
 		http.Error(w, "Error while reading request body.", http.StatusInternalServerError)
 		return
 	}
 	defer r.Body.Close()
+
+	// Bitbucket Cloud signs the payload with HMAC-SHA256 in the same format as
+	// GitHub if the webhook has a secret configured. Webhooks created before
+	// secrets were supported don't have one, so we only validate when it's set.
+	if secret != "" {
+		if err := gh.ValidateSignature(r.Header.Get("X-Hub-Signature"), payload, []byte(secret)); err != nil {
+			http.Error(w, "Could not validate payload with secret.", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use an
+	// internal actor on the context.
 	ctx := actor.WithInternalActor(r.Context())
 
 	eventType := r.Header.Get("X-Event-Key")
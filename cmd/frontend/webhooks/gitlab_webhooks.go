@@ -3,7 +3,6 @@ package webhooks
 import (
 	"context"
 	"crypto/subtle"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -22,11 +21,11 @@ func (wr *Router) HandleGitLabWebhook(ctx context.Context, logger log.Logger, w
 	// internal actor on the context.
 	ctx = actor.WithInternalActor(ctx)
 
-	var eventKind struct {
-		ObjectKind string `json:"object_kind"`
-	}
-	if err := json.Unmarshal(payload, &eventKind); err != nil {
-		http.Error(w, errors.Wrap(err, "determining object kind").Error(), http.StatusInternalServerError)
+	// System hook events are identified by their event_name rather than the
+	// object_kind, EventKind takes care of that for us.
+	eventKind, err := webhooks.EventKind(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -50,7 +49,7 @@ func (wr *Router) HandleGitLabWebhook(ctx context.Context, logger log.Logger, w
 	}
 
 	// Route the request based on the event type.
-	err = wr.Dispatch(ctx, eventKind.ObjectKind, extsvc.KindGitLab, codeHostURN, event)
+	err = wr.Dispatch(ctx, eventKind, extsvc.KindGitLab, codeHostURN, event)
 	if err != nil {
 		logger.Error("Error handling gitlab webhook event", log.Error(err))
 		if errcode.IsNotFound(err) {
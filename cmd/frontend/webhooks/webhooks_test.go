@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -356,12 +357,16 @@ func TestWebhooksHandler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
-	t.Run("Bitbucket Cloud returns 200", func(t *testing.T) {
+	t.Run("correct Bitbucket Cloud secret returns 200", func(t *testing.T) {
 		requestURL := fmt.Sprintf("%s/.api/webhooks/%v", srv.URL, bbCloudWH.UUID)
 
+		h := hmac.New(sha256.New, []byte("bbcloudsecret"))
 		event := bitbucketcloud.PullRequestCommentCreatedEvent{}
 		payload, err := json.Marshal(event)
 		require.NoError(t, err)
+		h.Write(payload)
+		res := h.Sum(nil)
+
 		wh := &fakeWebhookHandler{}
 		wr.handlers = map[string]eventHandlers{
 			extsvc.KindBitbucketCloud: {
@@ -371,6 +376,7 @@ func TestWebhooksHandler(t *testing.T) {
 
 		req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(payload))
 		require.NoError(t, err)
+		req.Header.Set("X-Hub-Signature", "sha256="+hex.EncodeToString(res))
 		req.Header.Set("X-Event-Key", "pullrequest:comment_created")
 		req.Header.Set("Content-Type", "application/json")
 
@@ -391,14 +397,37 @@ func TestWebhooksHandler(t *testing.T) {
 		assert.Equal(t, &event, wh.eventReceived)
 	})
 
-	t.Run("Bitbucket Cloud returns 404 not found if webhook event type unknown", func(t *testing.T) {
+	t.Run("incorrect Bitbucket Cloud secret returns 400", func(t *testing.T) {
 		requestURL := fmt.Sprintf("%s/.api/webhooks/%v", srv.URL, bbCloudWH.UUID)
 
+		h := hmac.New(sha256.New, []byte("wrongsecret"))
 		payload := []byte(`{"body": "text"}`)
+		h.Write(payload)
+		res := h.Sum(nil)
+
+		req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(payload))
+		require.NoError(t, err)
+		req.Header.Set("X-Hub-Signature", "sha256="+hex.EncodeToString(res))
+		req.Header.Set("X-Event-Key", "repo:push")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
 		require.NoError(t, err)
 
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Bitbucket Cloud returns 404 not found if webhook event type unknown", func(t *testing.T) {
+		requestURL := fmt.Sprintf("%s/.api/webhooks/%v", srv.URL, bbCloudWH.UUID)
+
+		h := hmac.New(sha256.New, []byte("bbcloudsecret"))
+		payload := []byte(`{"body": "text"}`)
+		h.Write(payload)
+		res := h.Sum(nil)
+
 		req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(payload))
 		require.NoError(t, err)
+		req.Header.Set("X-Hub-Signature", "sha256="+hex.EncodeToString(res))
 		req.Header.Set("X-Event-Key", "unknown_event")
 		req.Header.Set("Content-Type", "application/json")
 
@@ -1924,13 +1924,14 @@ func setHEAD(ctx context.Context, logger log.Logger, rcf *wrexec.RecordingComman
 	headBranch := "master"
 
 	// try to fetch HEAD from origin
-	cmd, err := syncer.RemoteShowCommand(ctx, remoteURL)
+	cmd, cleanup, err := syncer.RemoteShowCommand(ctx, remoteURL)
 	if err != nil {
 		return errors.Wrap(err, "get remote show command")
 	}
 	dir.Set(cmd)
 	r := urlredactor.New(remoteURL)
 	output, err := executil.RunRemoteGitCommand(ctx, rcf.WrapWithRepoName(ctx, logger, repoName, cmd).WithRedactorFunc(r.Redact), true)
+	cleanup()
 	if err != nil {
 		logger.Error("Failed to fetch remote info", log.Error(err), log.String("output", string(output)))
 		return errors.Wrap(err, "failed to fetch remote info")
@@ -2,6 +2,7 @@ package vcssyncer
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
@@ -14,7 +15,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/sshagent"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/urlredactor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -24,6 +27,9 @@ import (
 type gitRepoSyncer struct {
 	logger                  log.Logger
 	recordingCommandFactory *wrexec.RecordingCommandFactory
+	// deployKey is the deploy key of the repository, if it has one. It is
+	// offered to ssh when talking to an SSH remote.
+	deployKey *database.RepoDeployKey
 }
 
 func NewGitRepoSyncer(logger log.Logger, r *wrexec.RecordingCommandFactory) *gitRepoSyncer {
@@ -53,6 +59,11 @@ func (s *gitRepoSyncer) IsCloneable(ctx context.Context, repoName api.RepoName,
 
 	r := urlredactor.New(remoteURL)
 	cmd := exec.CommandContext(ctx, "git", args...)
+	closeAgent, err := s.useDeployKey(ctx, cmd, remoteURL)
+	if err != nil {
+		return err
+	}
+	defer closeAgent()
 	out, err := executil.RunRemoteGitCommand(ctx, s.recordingCommandFactory.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).WithRedactorFunc(r.Redact), true)
 	if err != nil {
 		if ctxerr := ctx.Err(); ctxerr != nil {
@@ -93,6 +104,11 @@ func (s *gitRepoSyncer) Clone(ctx context.Context, repo api.RepoName, remoteURL
 	// see issue #7322: skip LFS content in repositories with Git LFS configured.
 	cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
 	executil.ConfigureRemoteGitCommand(cmd)
+	closeAgent, err := s.useDeployKey(ctx, cmd, remoteURL)
+	if err != nil {
+		return err
+	}
+	defer closeAgent()
 
 	tryWrite(s.logger, progressWriter, "Fetching remote contents\n")
 	redactor := urlredactor.New(remoteURL)
@@ -112,6 +128,11 @@ func (s *gitRepoSyncer) Clone(ctx context.Context, repo api.RepoName, remoteURL
 func (s *gitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, repoName api.RepoName, dir common.GitDir, _ string) ([]byte, error) {
 	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
 	dir.Set(cmd)
+	closeAgent, err := s.useDeployKey(ctx, cmd, remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAgent()
 	r := urlredactor.New(remoteURL)
 	output, err := executil.RunRemoteGitCommand(ctx, s.recordingCommandFactory.WrapWithRepoName(ctx, log.NoOp(), repoName, cmd).WithRedactorFunc(r.Redact), configRemoteOpts)
 	if err != nil {
@@ -121,8 +142,45 @@ func (s *gitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, repoName
 }
 
 // RemoteShowCommand returns the command to be executed for showing remote of a Git repository.
-func (s *gitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
-	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
+// The returned cleanup function shuts down the ssh-agent serving the deploy key.
+func (s *gitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, cleanup func(), err error) {
+	cmd = exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String())
+	closeAgent, err := s.useDeployKey(ctx, cmd, remoteURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cmd, closeAgent, nil
+}
+
+// useDeployKey makes the deploy key of the repository available to ssh when
+// cmd talks to an SSH remote. The private key is served by an in-memory
+// ssh-agent, so it never needs to be written to disk. The returned function
+// shuts down the agent and must be called once cmd has finished.
+func (s *gitRepoSyncer) useDeployKey(ctx context.Context, cmd *exec.Cmd, remoteURL *vcs.URL) (closeAgent func(), err error) {
+	if s.deployKey == nil || !remoteURL.IsSSH() {
+		return func() {}, nil
+	}
+
+	privateKey, passphrase, err := s.deployKey.PrivateKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting deploy key")
+	}
+	agent, err := sshagent.New(s.logger, []byte(privateKey), []byte(passphrase))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating ssh-agent")
+	}
+	go agent.Listen()
+
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, fmt.Sprintf("SSH_AUTH_SOCK=%s", agent.Socket()))
+
+	return func() {
+		if err := agent.Close(); err != nil {
+			s.logger.Warn("failed to close ssh-agent", log.Error(err))
+		}
+	}, nil
 }
 
 func (s *gitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, configRemoteOpts bool) {
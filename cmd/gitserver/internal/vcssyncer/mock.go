@@ -59,7 +59,7 @@ func NewMockVCSSyncer() *MockVCSSyncer {
 			},
 		},
 		RemoteShowCommandFunc: &VCSSyncerRemoteShowCommandFunc{
-			defaultHook: func(context.Context, *vcs.URL) (r0 *exec.Cmd, r1 error) {
+			defaultHook: func(context.Context, *vcs.URL) (r0 *exec.Cmd, r1 func(), r2 error) {
 				return
 			},
 		},
@@ -91,7 +91,7 @@ func NewStrictMockVCSSyncer() *MockVCSSyncer {
 			},
 		},
 		RemoteShowCommandFunc: &VCSSyncerRemoteShowCommandFunc{
-			defaultHook: func(context.Context, *vcs.URL) (*exec.Cmd, error) {
+			defaultHook: func(context.Context, *vcs.URL) (*exec.Cmd, func(), error) {
 				panic("unexpected invocation of MockVCSSyncer.RemoteShowCommand")
 			},
 		},
@@ -468,24 +468,24 @@ func (c VCSSyncerIsCloneableFuncCall) Results() []interface{} {
 // VCSSyncerRemoteShowCommandFunc describes the behavior when the
 // RemoteShowCommand method of the parent MockVCSSyncer instance is invoked.
 type VCSSyncerRemoteShowCommandFunc struct {
-	defaultHook func(context.Context, *vcs.URL) (*exec.Cmd, error)
-	hooks       []func(context.Context, *vcs.URL) (*exec.Cmd, error)
+	defaultHook func(context.Context, *vcs.URL) (*exec.Cmd, func(), error)
+	hooks       []func(context.Context, *vcs.URL) (*exec.Cmd, func(), error)
 	history     []VCSSyncerRemoteShowCommandFuncCall
 	mutex       sync.Mutex
 }
 
 // RemoteShowCommand delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockVCSSyncer) RemoteShowCommand(v0 context.Context, v1 *vcs.URL) (*exec.Cmd, error) {
-	r0, r1 := m.RemoteShowCommandFunc.nextHook()(v0, v1)
-	m.RemoteShowCommandFunc.appendCall(VCSSyncerRemoteShowCommandFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockVCSSyncer) RemoteShowCommand(v0 context.Context, v1 *vcs.URL) (*exec.Cmd, func(), error) {
+	r0, r1, r2 := m.RemoteShowCommandFunc.nextHook()(v0, v1)
+	m.RemoteShowCommandFunc.appendCall(VCSSyncerRemoteShowCommandFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the RemoteShowCommand
 // method of the parent MockVCSSyncer instance is invoked and the hook queue
 // is empty.
-func (f *VCSSyncerRemoteShowCommandFunc) SetDefaultHook(hook func(context.Context, *vcs.URL) (*exec.Cmd, error)) {
+func (f *VCSSyncerRemoteShowCommandFunc) SetDefaultHook(hook func(context.Context, *vcs.URL) (*exec.Cmd, func(), error)) {
 	f.defaultHook = hook
 }
 
@@ -493,7 +493,7 @@ func (f *VCSSyncerRemoteShowCommandFunc) SetDefaultHook(hook func(context.Contex
 // RemoteShowCommand method of the parent MockVCSSyncer instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *VCSSyncerRemoteShowCommandFunc) PushHook(hook func(context.Context, *vcs.URL) (*exec.Cmd, error)) {
+func (f *VCSSyncerRemoteShowCommandFunc) PushHook(hook func(context.Context, *vcs.URL) (*exec.Cmd, func(), error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -501,20 +501,20 @@ func (f *VCSSyncerRemoteShowCommandFunc) PushHook(hook func(context.Context, *vc
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *VCSSyncerRemoteShowCommandFunc) SetDefaultReturn(r0 *exec.Cmd, r1 error) {
-	f.SetDefaultHook(func(context.Context, *vcs.URL) (*exec.Cmd, error) {
-		return r0, r1
+func (f *VCSSyncerRemoteShowCommandFunc) SetDefaultReturn(r0 *exec.Cmd, r1 func(), r2 error) {
+	f.SetDefaultHook(func(context.Context, *vcs.URL) (*exec.Cmd, func(), error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *VCSSyncerRemoteShowCommandFunc) PushReturn(r0 *exec.Cmd, r1 error) {
-	f.PushHook(func(context.Context, *vcs.URL) (*exec.Cmd, error) {
-		return r0, r1
+func (f *VCSSyncerRemoteShowCommandFunc) PushReturn(r0 *exec.Cmd, r1 func(), r2 error) {
+	f.PushHook(func(context.Context, *vcs.URL) (*exec.Cmd, func(), error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *VCSSyncerRemoteShowCommandFunc) nextHook() func(context.Context, *vcs.URL) (*exec.Cmd, error) {
+func (f *VCSSyncerRemoteShowCommandFunc) nextHook() func(context.Context, *vcs.URL) (*exec.Cmd, func(), error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -558,7 +558,10 @@ type VCSSyncerRemoteShowCommandFuncCall struct {
 	Result0 *exec.Cmd
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 func()
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
@@ -570,7 +573,7 @@ func (c VCSSyncerRemoteShowCommandFuncCall) Args() []interface{} {
 // Results returns an interface slice containing the results of this
 // invocation.
 func (c VCSSyncerRemoteShowCommandFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
 // VCSSyncerTypeFunc describes the behavior when the Type method of the
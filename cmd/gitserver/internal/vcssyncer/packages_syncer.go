@@ -79,8 +79,8 @@ func (s *vcsPackagesSyncer) Type() string {
 	return s.typ
 }
 
-func (s *vcsPackagesSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
-	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+func (s *vcsPackagesSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, cleanup func(), err error) {
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), func() {}, nil
 }
 
 // Clone writes a package and all requested versions of it into a synthetic git
@@ -225,9 +225,9 @@ func (s *perforceDepotSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, _ a
 }
 
 // RemoteShowCommand returns the command to be executed for showing Git remote of a Perforce depot.
-func (s *perforceDepotSyncer) RemoteShowCommand(ctx context.Context, _ *vcs.URL) (cmd *exec.Cmd, err error) {
+func (s *perforceDepotSyncer) RemoteShowCommand(ctx context.Context, _ *vcs.URL) (cmd *exec.Cmd, cleanup func(), err error) {
 	// Remote info is encoded as in the current repository
-	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), func() {}, nil
 }
 
 func (s *perforceDepotSyncer) p4CommandOptions() []string {
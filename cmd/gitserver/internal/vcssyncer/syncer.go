@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/crates"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
@@ -63,12 +64,15 @@ type VCSSyncer interface {
 	// Beware that the revspec parameter can be any random user-provided string.
 	Fetch(ctx context.Context, remoteURL *vcs.URL, repoName api.RepoName, dir common.GitDir, revspec string) ([]byte, error)
 	// RemoteShowCommand returns the command to be executed for showing remote.
-	RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error)
+	// The returned cleanup function must be called once the command has
+	// finished.
+	RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, cleanup func(), err error)
 }
 
 type NewVCSSyncerOpts struct {
 	ExternalServiceStore    database.ExternalServiceStore
 	RepoStore               database.RepoStore
+	RepoDeployKeyStore      database.RepoDeployKeyStore
 	DepsSvc                 *dependencies.Service
 	Repo                    api.RepoName
 	ReposDir                string
@@ -179,6 +183,19 @@ func NewVCSSyncer(ctx context.Context, opts *NewVCSSyncerOpts) (VCSSyncer, error
 			return nil, err
 		}
 		return NewRubyPackagesSyncer(&c, opts.DepsSvc, cli, opts.ReposDir), nil
+	case extsvc.TypeOther:
+		s := NewGitRepoSyncer(opts.Logger, opts.RecordingCommandFactory)
+		if opts.RepoDeployKeyStore == nil {
+			return s, nil
+		}
+		// Repositories of generic Git code hosts can have their own deploy key,
+		// which takes precedence over the SSH credentials of gitserver.
+		key, err := opts.RepoDeployKeyStore.GetByRepoID(ctx, r.ID)
+		if err != nil && !errcode.IsNotFound(err) {
+			return nil, errors.Wrap(err, "get deploy key")
+		}
+		s.deployKey = key
+		return s, nil
 	}
 
 	return NewGitRepoSyncer(opts.Logger, opts.RecordingCommandFactory), nil
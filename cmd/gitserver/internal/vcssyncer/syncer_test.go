@@ -3,7 +3,9 @@ package vcssyncer
 import (
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,9 +14,12 @@ import (
 
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
 )
 
 func TestGetVCSSyncer(t *testing.T) {
@@ -67,3 +72,92 @@ func TestGetVCSSyncer(t *testing.T) {
 
 	require.Equal(t, "perforce", s.Type())
 }
+
+func TestGetVCSSyncer_DeployKey(t *testing.T) {
+	repoStore := dbmocks.NewMockRepoStore()
+	repoStore.GetByNameFunc.SetDefaultHook(func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{
+			ID:   42,
+			Name: name,
+			ExternalRepo: api.ExternalRepoSpec{
+				ServiceType: extsvc.TypeOther,
+			},
+		}, nil
+	})
+
+	pair, err := encryption.GenerateRSAKey()
+	require.NoError(t, err)
+
+	deployKeyStore := dbmocks.NewMockRepoDeployKeyStore()
+	deployKeyStore.GetByRepoIDFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*database.RepoDeployKey, error) {
+		if id != 42 {
+			return nil, database.RepoDeployKeyNotFoundErr{}
+		}
+		return database.NewMockRepoDeployKey(&database.RepoDeployKey{RepoID: id, PublicKey: pair.PublicKey}, pair.PrivateKey, pair.Passphrase), nil
+	})
+
+	s, err := NewVCSSyncer(context.Background(), &NewVCSSyncerOpts{
+		ExternalServiceStore: dbmocks.NewMockExternalServiceStore(),
+		RepoStore:            repoStore,
+		RepoDeployKeyStore:   deployKeyStore,
+		Repo:                 "git.example.com/foo/bar",
+		Logger:               logtest.Scoped(t),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "git", s.Type())
+
+	gs := s.(*gitRepoSyncer)
+	require.NotNil(t, gs.deployKey)
+
+	sshAuthSock := func(cmd *exec.Cmd) string {
+		for _, e := range cmd.Env {
+			if v, ok := strings.CutPrefix(e, "SSH_AUTH_SOCK="); ok {
+				return v
+			}
+		}
+		return ""
+	}
+
+	t.Run("ssh remote", func(t *testing.T) {
+		remoteURL, err := vcs.ParseURL("git@git.example.com:foo/bar.git")
+		require.NoError(t, err)
+
+		cmd := exec.Command("git", "ls-remote", remoteURL.String())
+		closeAgent, err := gs.useDeployKey(context.Background(), cmd, remoteURL)
+		require.NoError(t, err)
+		defer closeAgent()
+
+		sock := sshAuthSock(cmd)
+		require.NotEmpty(t, sock)
+		_, err = os.Stat(sock)
+		require.NoError(t, err)
+	})
+
+	t.Run("https remote", func(t *testing.T) {
+		remoteURL, err := vcs.ParseURL("https://git.example.com/foo/bar.git")
+		require.NoError(t, err)
+
+		cmd := exec.Command("git", "ls-remote", remoteURL.String())
+		closeAgent, err := gs.useDeployKey(context.Background(), cmd, remoteURL)
+		require.NoError(t, err)
+		defer closeAgent()
+
+		require.Empty(t, sshAuthSock(cmd))
+	})
+
+	t.Run("no deploy key", func(t *testing.T) {
+		repoStore.GetByNameFunc.PushReturn(&types.Repo{
+			ID:           43,
+			ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeOther},
+		}, nil)
+		s, err := NewVCSSyncer(context.Background(), &NewVCSSyncerOpts{
+			ExternalServiceStore: dbmocks.NewMockExternalServiceStore(),
+			RepoStore:            repoStore,
+			RepoDeployKeyStore:   deployKeyStore,
+			Repo:                 "git.example.com/foo/baz",
+			Logger:               logtest.Scoped(t),
+		})
+		require.NoError(t, err)
+		require.Nil(t, s.(*gitRepoSyncer).deployKey)
+	})
+}
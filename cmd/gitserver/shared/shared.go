@@ -99,6 +99,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 			return vcssyncer.NewVCSSyncer(ctx, &vcssyncer.NewVCSSyncerOpts{
 				ExternalServiceStore:    db.ExternalServices(),
 				RepoStore:               db.Repos(),
+				RepoDeployKeyStore:      db.RepoDeployKeys(keyring.Default().ExternalServiceKey),
 				DepsSvc:                 dependencies.NewService(observationCtx, db),
 				Repo:                    repo,
 				ReposDir:                config.ReposDir,
@@ -11,11 +11,14 @@ import (
 type Config struct {
 	env.BaseConfig
 
-	WorkerPollInterval    time.Duration
-	WorkerConcurrency     int
-	WorkerBudget          int64
-	MaximumRuntimePerJob  time.Duration
-	LSIFUploadStoreConfig *lsifuploadstore.Config
+	WorkerPollInterval     time.Duration
+	WorkerConcurrency      int
+	WorkerBudget           int64
+	MaximumRuntimePerJob   time.Duration
+	DocumentConcurrency    int
+	DocumentMemoryBudget   int64
+	DocumentsPerCheckpoint int
+	LSIFUploadStoreConfig  *lsifuploadstore.Config
 }
 
 func (c *Config) Load() {
@@ -26,6 +29,9 @@ func (c *Config) Load() {
 	c.WorkerConcurrency = c.GetInt("PRECISE_CODE_INTEL_WORKER_CONCURRENCY", "1", "The maximum number of indexes that can be processed concurrently.")
 	c.WorkerBudget = int64(c.GetInt("PRECISE_CODE_INTEL_WORKER_BUDGET", "0", "The amount of compressed input data (in bytes) a worker can process concurrently. Zero acts as an infinite budget."))
 	c.MaximumRuntimePerJob = c.GetInterval("PRECISE_CODE_INTEL_WORKER_MAXIMUM_RUNTIME_PER_JOB", "25m", "The maximum time a single LSIF processing job can take.")
+	c.DocumentConcurrency = c.GetInt("PRECISE_CODE_INTEL_WORKER_DOCUMENT_CONCURRENCY", "4", "The maximum number of documents of a single index that can be processed concurrently.")
+	c.DocumentMemoryBudget = int64(c.GetInt("PRECISE_CODE_INTEL_WORKER_DOCUMENT_MEMORY_BUDGET", "268435456", "The amount of memory (in bytes) that decoded documents of a single index can occupy while being processed. Zero acts as an infinite budget."))
+	c.DocumentsPerCheckpoint = c.GetInt("PRECISE_CODE_INTEL_WORKER_DOCUMENTS_PER_CHECKPOINT", "5000", "The number of documents written per transaction. Processing of an index resumes after the last committed transaction if it fails. Zero writes all documents of an index in a single transaction.")
 }
 
 func (c *Config) Validate() error {
@@ -84,6 +84,9 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		config.WorkerBudget,
 		config.WorkerPollInterval,
 		config.MaximumRuntimePerJob,
+		config.DocumentConcurrency,
+		config.DocumentMemoryBudget,
+		config.DocumentsPerCheckpoint,
 	)
 
 	// Initialize health server
@@ -130,12 +130,14 @@ func MakeStore(observationCtx *observation.Context, dbHandle basestore.Transacta
 		ColumnExpressions: database.PermissionSyncJobColumns,
 		Scan:              dbworkerstore.BuildWorkerScan(database.ScanPermissionSyncJob),
 		// NOTE(naman): the priority order to process the queue is as follows:
-		// 1. priority: 10(high) > 5(medium) > 0(low)
+		// 1. priority: 10(high) > 5(medium) > 0(low), see the Priority option
 		// 2. process_after: null(scheduled for immediate processing) > 1 > 2(scheduled for processing at a later time than 1)
 		// 3. job_id: 1(old) > 2(enqueued after 1)
-		OrderByExpression: sqlf.Sprintf("permission_sync_jobs.priority DESC, permission_sync_jobs.process_after ASC NULLS FIRST, permission_sync_jobs.id ASC"),
+		OrderByExpression: sqlf.Sprintf("permission_sync_jobs.process_after ASC NULLS FIRST, permission_sync_jobs.id ASC"),
+		Priority:          true,
 		MaxNumResets:      5,
 		StalledMaxAge:     time.Second * 30,
+		TraceContext:      true,
 	})
 }
 
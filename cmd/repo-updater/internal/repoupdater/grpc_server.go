@@ -32,7 +32,8 @@ func (s *RepoUpdaterServiceServer) RepoLookup(ctx context.Context, req *proto.Re
 
 func (s *RepoUpdaterServiceServer) EnqueueRepoUpdate(ctx context.Context, req *proto.EnqueueRepoUpdateRequest) (*proto.EnqueueRepoUpdateResponse, error) {
 	args := &protocol.RepoUpdateRequest{
-		Repo: api.RepoName(req.GetRepo()),
+		Repo:  api.RepoName(req.GetRepo()),
+		Force: req.GetForce(),
 	}
 	res, httpStatus, err := s.Server.enqueueRepoUpdate(ctx, args)
 	if err != nil {
@@ -42,8 +43,9 @@ func (s *RepoUpdaterServiceServer) EnqueueRepoUpdate(ctx context.Context, req *p
 		return nil, err
 	}
 	return &proto.EnqueueRepoUpdateResponse{
-		Id:   int32(res.ID),
-		Name: res.Name,
+		Id:    int32(res.ID),
+		Name:  res.Name,
+		JobId: res.JobID,
 	}, nil
 }
 
@@ -31,6 +31,7 @@ type Server struct {
 	SourcegraphDotComMode bool
 	Scheduler             interface {
 		UpdateOnce(id api.RepoID, name api.RepoName)
+		ForceUpdate(id api.RepoID, name api.RepoName) (jobID uint64)
 		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
 	}
 	ChangesetSyncRegistry syncer.ChangesetSyncRegistry
@@ -104,6 +105,7 @@ func (s *Server) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdate
 			tr.SetAttributes(
 				attribute.Int("resp.id", int(resp.ID)),
 				attribute.String("resp.name", resp.Name),
+				attribute.Int64("resp.job_id", int64(resp.JobID)),
 			)
 		}
 		tr.SetError(err)
@@ -121,12 +123,17 @@ func (s *Server) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdate
 
 	repo := rs[0]
 
-	s.Scheduler.UpdateOnce(repo.ID, repo.Name)
-
-	return &protocol.RepoUpdateResponse{
+	resp = &protocol.RepoUpdateResponse{
 		ID:   repo.ID,
 		Name: string(repo.Name),
-	}, http.StatusOK, nil
+	}
+	if req.Force {
+		resp.JobID = s.Scheduler.ForceUpdate(repo.ID, repo.Name)
+	} else {
+		s.Scheduler.UpdateOnce(repo.ID, repo.Name)
+	}
+
+	return resp, http.StatusOK, nil
 }
 
 func (s *Server) respond(w http.ResponseWriter, code int, v any) {
@@ -705,6 +705,9 @@ func TestServer_RepoLookup(t *testing.T) {
 type fakeScheduler struct{}
 
 func (s *fakeScheduler) UpdateOnce(_ api.RepoID, _ api.RepoName) {}
+func (s *fakeScheduler) ForceUpdate(_ api.RepoID, _ api.RepoName) uint64 {
+	return 1
+}
 func (s *fakeScheduler) ScheduleInfo(_ api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
 	return &protocol.RepoUpdateSchedulerInfoResult{}
 }
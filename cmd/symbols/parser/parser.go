@@ -267,6 +267,11 @@ func shouldPersistEntry(e *ctags.Entry) bool {
 func SpawnCtags(logger log.Logger, ctagsConfig types.CtagsConfig, source ctags_config.ParserType) (ctags.Parser, error) {
 	logger = logger.Scoped("ctags")
 
+	if source == ctags_config.TreeSitterCtags {
+		// Tree-sitter runs in process and does not need a ctags binary.
+		return NewFilteringParser(NewTreeSitterParser(), ctagsConfig.MaxFileSize, ctagsConfig.MaxSymbols), nil
+	}
+
 	var options ctags.Options
 	if source == ctags_config.UniversalCtags {
 		options = ctags.Options{
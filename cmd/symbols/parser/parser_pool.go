@@ -16,7 +16,7 @@ type parserPool struct {
 	pool      map[ctags_config.ParserType]chan ctags.Parser
 }
 
-var DefaultParserTypes = []ctags_config.ParserType{ctags_config.UniversalCtags, ctags_config.ScipCtags}
+var DefaultParserTypes = []ctags_config.ParserType{ctags_config.UniversalCtags, ctags_config.ScipCtags, ctags_config.TreeSitterCtags}
 
 func NewParserPool(newParser ParserFactory, numParserProcesses int, parserTypes []ctags_config.ParserType) (*parserPool, error) {
 	pool := make(map[ctags_config.ParserType]chan ctags.Parser)
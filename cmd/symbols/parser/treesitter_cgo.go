@@ -0,0 +1,270 @@
+//go:build cgo
+
+package parser
+
+import (
+	"context"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+	"github.com/sourcegraph/go-ctags"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/languages"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// treeSitterSymbolsSpec is the grammar and symbols query of a language supported
+// by the tree-sitter parser.
+//
+// Each pattern of the query captures a definition node as @definition.<kind>, and
+// the name of the definition as @name. The parent of a symbol is the nearest
+// enclosing definition.
+type treeSitterSymbolsSpec struct {
+	language *sitter.Language
+	query    string
+	// methodParentKinds are the kinds of parents whose "function" children are
+	// reported as methods, as ctags does.
+	methodParentKinds map[string]struct{}
+}
+
+// Mapping from normalized language name to symbols spec. Keep in sync with
+// treeSitterSupportedLanguages in internal/ctags_config.
+var treeSitterSymbolsSpecs = map[string]treeSitterSymbolsSpec{
+	"go": {
+		language: golang.GetLanguage(),
+		query: `
+(function_declaration name: (identifier) @name) @definition.function
+(method_declaration name: (field_identifier) @name) @definition.method
+(type_spec name: (type_identifier) @name type: (struct_type)) @definition.struct
+(type_spec name: (type_identifier) @name type: (interface_type)) @definition.interface
+(type_spec name: (type_identifier) @name type: [(type_identifier) (qualified_type) (pointer_type) (function_type) (map_type) (slice_type) (array_type) (channel_type)]) @definition.type
+(method_spec name: (field_identifier) @name) @definition.method
+(field_declaration name: (field_identifier) @name) @definition.field
+(const_spec name: (identifier) @name) @definition.constant
+(source_file (var_declaration (var_spec name: (identifier) @name) @definition.variable))
+`,
+	},
+	"java": {
+		language: java.GetLanguage(),
+		query: `
+(class_declaration name: (identifier) @name) @definition.class
+(interface_declaration name: (identifier) @name) @definition.interface
+(enum_declaration name: (identifier) @name) @definition.enum
+(enum_constant name: (identifier) @name) @definition.enumConstant
+(method_declaration name: (identifier) @name) @definition.method
+(constructor_declaration name: (identifier) @name) @definition.method
+(field_declaration declarator: (variable_declarator name: (identifier) @name)) @definition.field
+`,
+	},
+	"javascript": {
+		language: javascript.GetLanguage(),
+		query: `
+(function_declaration name: (identifier) @name) @definition.function
+(generator_function_declaration name: (identifier) @name) @definition.function
+(class_declaration name: (identifier) @name) @definition.class
+(method_definition name: (property_identifier) @name) @definition.method
+(program (lexical_declaration (variable_declarator name: (identifier) @name) @definition.variable))
+(program (export_statement (lexical_declaration (variable_declarator name: (identifier) @name) @definition.variable)))
+`,
+	},
+	"python": {
+		language: python.GetLanguage(),
+		query: `
+(class_definition name: (identifier) @name) @definition.class
+(function_definition name: (identifier) @name) @definition.function
+(module (expression_statement (assignment left: (identifier) @name) @definition.variable))
+`,
+		methodParentKinds: map[string]struct{}{"class": {}},
+	},
+	"rust": {
+		language: rust.GetLanguage(),
+		query: `
+(mod_item name: (identifier) @name) @definition.module
+(struct_item name: (type_identifier) @name) @definition.struct
+(union_item name: (type_identifier) @name) @definition.union
+(enum_item name: (type_identifier) @name) @definition.enum
+(enum_variant name: (identifier) @name) @definition.enumerator
+(trait_item name: (type_identifier) @name) @definition.interface
+(impl_item type: (type_identifier) @name) @definition.implementation
+(impl_item type: (generic_type type: (type_identifier) @name)) @definition.implementation
+(function_item name: (identifier) @name) @definition.function
+(function_signature_item name: (identifier) @name) @definition.function
+(field_declaration name: (field_identifier) @name) @definition.field
+(type_item name: (type_identifier) @name) @definition.typedef
+(const_item name: (identifier) @name) @definition.constant
+(static_item name: (identifier) @name) @definition.variable
+(macro_definition name: (identifier) @name) @definition.macro
+`,
+		methodParentKinds: map[string]struct{}{"implementation": {}, "interface": {}},
+	},
+	"typescript": {
+		language: typescript.GetLanguage(),
+		query: `
+(function_declaration name: (identifier) @name) @definition.function
+(function_signature name: (identifier) @name) @definition.function
+(generator_function_declaration name: (identifier) @name) @definition.function
+(class_declaration name: (type_identifier) @name) @definition.class
+(abstract_class_declaration name: (type_identifier) @name) @definition.class
+(interface_declaration name: (type_identifier) @name) @definition.interface
+(type_alias_declaration name: (type_identifier) @name) @definition.alias
+(enum_declaration name: (identifier) @name) @definition.enum
+(module name: [(identifier) (string)] @name) @definition.namespace
+(internal_module name: [(identifier) (nested_identifier)] @name) @definition.namespace
+(method_definition name: (property_identifier) @name) @definition.method
+(method_signature name: (property_identifier) @name) @definition.method
+(abstract_method_signature name: (property_identifier) @name) @definition.method
+(public_field_definition name: (property_identifier) @name) @definition.property
+(property_signature name: (property_identifier) @name) @definition.property
+(program (lexical_declaration (variable_declarator name: (identifier) @name) @definition.variable))
+(program (export_statement (lexical_declaration (variable_declarator name: (identifier) @name) @definition.variable)))
+`,
+	},
+}
+
+// NewTreeSitterParser returns a parser that extracts symbols with tree-sitter
+// grammars. Files in languages without a grammar yield no symbols.
+func NewTreeSitterParser() ctags.Parser {
+	return &treeSitterParser{
+		parser:  sitter.NewParser(),
+		queries: map[string]*sitter.Query{},
+	}
+}
+
+// treeSitterParser is not safe for concurrent use, like the ctags parsers it can
+// be used in place of.
+type treeSitterParser struct {
+	parser  *sitter.Parser
+	queries map[string]*sitter.Query
+}
+
+func (p *treeSitterParser) Parse(path string, content []byte) ([]*ctags.Entry, error) {
+	language, found := languages.GetLanguage(path, string(content))
+	if !found {
+		return nil, nil
+	}
+	name := languages.NormalizeLanguage(language)
+	spec, ok := treeSitterSymbolsSpecs[name]
+	if !ok {
+		return nil, nil
+	}
+
+	query, err := p.query(name, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	p.parser.SetLanguage(spec.language)
+	tree, err := p.parser.ParseCtx(context.Background(), nil, content)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse")
+	}
+	defer tree.Close()
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(query, tree.RootNode())
+
+	type definition struct {
+		node  *sitter.Node
+		entry *ctags.Entry
+	}
+	var definitions []definition
+	byNode := map[treeSitterNodeKey]*ctags.Entry{}
+
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+
+		var nameNode, definitionNode *sitter.Node
+		var kind string
+		for _, capture := range match.Captures {
+			captureName := query.CaptureNameForId(capture.Index)
+			if captureName == "name" {
+				nameNode = capture.Node
+			} else if k, ok := strings.CutPrefix(captureName, "definition."); ok {
+				definitionNode = capture.Node
+				kind = k
+			}
+		}
+		if nameNode == nil || definitionNode == nil {
+			continue
+		}
+
+		// A definition can be matched by several patterns, e.g. a generic impl.
+		key := newTreeSitterNodeKey(definitionNode)
+		if _, ok := byNode[key]; ok {
+			continue
+		}
+
+		entry := &ctags.Entry{
+			Name:     nameNode.Content(content),
+			Path:     path,
+			Line:     int(nameNode.StartPoint().Row) + 1,
+			Kind:     kind,
+			Language: language,
+		}
+		byNode[key] = entry
+		definitions = append(definitions, definition{node: definitionNode, entry: entry})
+	}
+
+	entries := make([]*ctags.Entry, 0, len(definitions))
+	for _, d := range definitions {
+		for ancestor := d.node.Parent(); ancestor != nil; ancestor = ancestor.Parent() {
+			parent, ok := byNode[newTreeSitterNodeKey(ancestor)]
+			if !ok {
+				continue
+			}
+
+			d.entry.Parent = parent.Name
+			d.entry.ParentKind = parent.Kind
+			if _, ok := spec.methodParentKinds[parent.Kind]; ok && d.entry.Kind == "function" {
+				d.entry.Kind = "method"
+			}
+			break
+		}
+
+		entries = append(entries, d.entry)
+	}
+
+	return entries, nil
+}
+
+// query returns the compiled symbols query of the given language.
+func (p *treeSitterParser) query(name string, spec treeSitterSymbolsSpec) (*sitter.Query, error) {
+	if query, ok := p.queries[name]; ok {
+		return query, nil
+	}
+
+	query, err := sitter.NewQuery([]byte(spec.query), spec.language)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile tree-sitter symbols query for %s", name)
+	}
+	p.queries[name] = query
+	return query, nil
+}
+
+func (p *treeSitterParser) Close() {
+	for _, query := range p.queries {
+		query.Close()
+	}
+	p.parser.Close()
+}
+
+// treeSitterNodeKey identifies a node of a syntax tree. Nodes returned by the
+// bindings are not comparable by pointer.
+type treeSitterNodeKey struct {
+	start, end uint32
+	typ        string
+}
+
+func newTreeSitterNodeKey(node *sitter.Node) treeSitterNodeKey {
+	return treeSitterNodeKey{start: node.StartByte(), end: node.EndByte(), typ: node.Type()}
+}
@@ -0,0 +1,114 @@
+//go:build cgo
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-ctags"
+)
+
+func TestTreeSitterParser(t *testing.T) {
+	tests := []struct {
+		path    string
+		content string
+		want    []*ctags.Entry
+	}{
+		{
+			path: "lib.rs",
+			content: `pub trait Shape<T> {
+    fn area(&self) -> T;
+}
+
+pub struct Square<T> {
+    side: T,
+}
+
+impl<T: Copy> Shape<T> for Square<T> {
+    fn area(&self) -> T {
+        self.side
+    }
+}
+
+pub fn new_square(side: f64) -> Square<f64> {
+    Square { side }
+}
+`,
+			want: []*ctags.Entry{
+				{Name: "Shape", Line: 1, Kind: "interface"},
+				{Name: "area", Line: 2, Kind: "method", Parent: "Shape", ParentKind: "interface"},
+				{Name: "Square", Line: 5, Kind: "struct"},
+				{Name: "side", Line: 6, Kind: "field", Parent: "Square", ParentKind: "struct"},
+				{Name: "Square", Line: 9, Kind: "implementation"},
+				{Name: "area", Line: 10, Kind: "method", Parent: "Square", ParentKind: "implementation"},
+				{Name: "new_square", Line: 15, Kind: "function"},
+			},
+		},
+		{
+			path: "index.ts",
+			content: `export interface Repository<T extends object> {
+    find(id: string): Promise<T>
+}
+
+export type Handler<T> = (value: T) => void
+
+export class Store<T extends object> implements Repository<T> {
+    public async find(id: string): Promise<T> {
+        throw new Error(id)
+    }
+}
+
+export function createStore<T extends object>(): Store<T> {
+    return new Store<T>()
+}
+`,
+			want: []*ctags.Entry{
+				{Name: "Repository", Line: 1, Kind: "interface"},
+				{Name: "find", Line: 2, Kind: "method", Parent: "Repository", ParentKind: "interface"},
+				{Name: "Handler", Line: 5, Kind: "alias"},
+				{Name: "Store", Line: 7, Kind: "class"},
+				{Name: "find", Line: 8, Kind: "method", Parent: "Store", ParentKind: "class"},
+				{Name: "createStore", Line: 13, Kind: "function"},
+			},
+		},
+		{
+			path:    "README.md",
+			content: "# Title\n",
+			want:    nil,
+		},
+	}
+
+	parser := NewTreeSitterParser()
+	defer parser.Close()
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			entries, err := parser.Parse(test.path, []byte(test.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Only compare the fields specific to each symbol.
+			for _, e := range entries {
+				e.Path = ""
+				e.Language = ""
+			}
+
+			if diff := cmp.Diff(test.want, entries); diff != "" {
+				t.Errorf("unexpected entries (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTreeSitterSymbolsQueries(t *testing.T) {
+	parser := NewTreeSitterParser().(*treeSitterParser)
+	defer parser.Close()
+
+	for name, spec := range treeSitterSymbolsSpecs {
+		if _, err := parser.query(name, spec); err != nil {
+			t.Errorf("invalid query for %s: %s", name, err)
+		}
+	}
+}
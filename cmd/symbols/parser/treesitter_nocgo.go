@@ -0,0 +1,23 @@
+//go:build !cgo
+
+package parser
+
+import (
+	"github.com/sourcegraph/go-ctags"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewTreeSitterParser returns a parser that fails to parse any file, as tree-sitter
+// requires cgo. This non-cgo variant must only be used for development.
+func NewTreeSitterParser() ctags.Parser {
+	return treeSitterParser{}
+}
+
+type treeSitterParser struct{}
+
+func (treeSitterParser) Parse(path string, content []byte) ([]*ctags.Entry, error) {
+	return nil, errors.New("tree-sitter symbol extraction requires cgo")
+}
+
+func (treeSitterParser) Close() {}
@@ -0,0 +1,69 @@
+package auditlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type config struct {
+	env.BaseConfig
+
+	ExportInterval  time.Duration
+	ExportBatchSize int
+}
+
+var configInst = &config{}
+
+func (c *config) Load() {
+	c.ExportInterval = c.GetInterval("AUDIT_LOG_EXPORTER_INTERVAL", "1m", "Interval at which to export audit events to the configured destinations.")
+	c.ExportBatchSize = c.GetInt("AUDIT_LOG_EXPORTER_BATCH_SIZE", "500", "Maximum number of audit events to export in each batch.")
+	if c.ExportBatchSize < 1 {
+		c.AddError(errors.New("AUDIT_LOG_EXPORTER_BATCH_SIZE must be positive"))
+	}
+}
+
+type exporter struct{}
+
+// NewExporter returns the job exporting audit events to the destinations
+// configured in "log.auditLog.export".
+func NewExporter() job.Job {
+	return &exporter{}
+}
+
+func (*exporter) Description() string {
+	return "Exports audit events to the SIEM destinations configured in the site configuration."
+}
+
+func (*exporter) Config() []env.Config {
+	return []env.Config{configInst}
+}
+
+func (*exporter) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		newExporterJob(observationCtx, db.AuditEvents(), httpcli.ExternalDoer, exportConfig, *configInst),
+	}, nil
+}
+
+// exportConfig returns the audit log export configuration, or nil if export
+// is not configured.
+func exportConfig() *schema.AuditLogExport {
+	if log := conf.Get().Log; log != nil && log.AuditLog != nil {
+		return log.AuditLog.Export
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/syslog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// signatureHeader is the header carrying the HMAC-SHA256 of the body of
+// webhook export requests.
+const signatureHeader = "X-Sourcegraph-Signature"
+
+// exportedEvent is the stable JSON representation of an audit event, as
+// delivered to SIEMs.
+type exportedEvent struct {
+	ID             int64          `json:"id"`
+	Action         string         `json:"action"`
+	EntityType     string         `json:"entityType"`
+	EntityID       string         `json:"entityID,omitempty"`
+	ActorUserID    int32          `json:"actorUserID,omitempty"`
+	ActorIP        string         `json:"actorIP,omitempty"`
+	ActorUserAgent string         `json:"actorUserAgent,omitempty"`
+	Metadata       map[string]any `json:"metadata"`
+	CreatedAt      time.Time      `json:"createdAt"`
+}
+
+func newExportedEvent(e *database.AuditEvent) exportedEvent {
+	return exportedEvent{
+		ID:             e.ID,
+		Action:         e.Action,
+		EntityType:     e.EntityType,
+		EntityID:       e.EntityID,
+		ActorUserID:    e.ActorUserID,
+		ActorIP:        e.ActorIP,
+		ActorUserAgent: e.ActorUserAgent,
+		Metadata:       e.Metadata,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+type exporterJob struct {
+	logger    log.Logger
+	store     database.AuditEventStore
+	client    httpcli.Doer
+	getConfig func() *schema.AuditLogExport
+	batchSize int
+
+	// dialSyslog is replaced in tests.
+	dialSyslog func(network, address, tag string) (io.WriteCloser, error)
+}
+
+func newExporterJob(
+	observationCtx *observation.Context,
+	store database.AuditEventStore,
+	client httpcli.Doer,
+	getConfig func() *schema.AuditLogExport,
+	cfg config,
+) goroutine.BackgroundRoutine {
+	job := &exporterJob{
+		logger:     observationCtx.Logger.Scoped("auditlog.exporter"),
+		store:      store,
+		client:     client,
+		getConfig:  getConfig,
+		batchSize:  cfg.ExportBatchSize,
+		dialSyslog: dialSyslog,
+	}
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		job,
+		goroutine.WithName("auditlog.exporter"),
+		goroutine.WithDescription("exports audit events to the configured SIEM destinations"),
+		goroutine.WithInterval(cfg.ExportInterval),
+		goroutine.WithOperation(observationCtx.Operation(observation.Op{
+			Name:    "AuditLog.Export",
+			Metrics: metrics.NewREDMetrics(prometheus.DefaultRegisterer, "auditlog_exporter"),
+		})),
+	)
+}
+
+func (j *exporterJob) Handle(ctx context.Context) error {
+	cfg := j.getConfig()
+	if cfg == nil || (cfg.Webhook == nil && cfg.Syslog == nil) {
+		return nil
+	}
+	logger := trace.Logger(ctx, j.logger)
+
+	events, err := j.store.ListForExport(ctx, j.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "ListForExport")
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	exported := make([]exportedEvent, 0, len(events))
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		exported = append(exported, newExportedEvent(e))
+		ids = append(ids, e.ID)
+	}
+
+	// Events are only marked as exported once delivered to all destinations,
+	// so a destination may receive an event more than once if another one
+	// fails. Consumers can deduplicate on the event ID.
+	if cfg.Webhook != nil {
+		if err := j.sendWebhook(ctx, cfg.Webhook, exported); err != nil {
+			return errors.Wrap(err, "exporting audit events to webhook")
+		}
+	}
+	if cfg.Syslog != nil {
+		if err := j.sendSyslog(cfg.Syslog, exported); err != nil {
+			return errors.Wrap(err, "exporting audit events to syslog")
+		}
+	}
+
+	if err := j.store.MarkAsExported(ctx, ids); err != nil {
+		return err
+	}
+	logger.Debug("exported audit events", log.Int("count", len(ids)))
+	return nil
+}
+
+func (j *exporterJob) sendWebhook(ctx context.Context, cfg *schema.AuditLogWebhookExport, events []exportedEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(cfg.Secret))
+		mac.Write(body)
+		req.Header.Set(signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (j *exporterJob) sendSyslog(cfg *schema.AuditLogSyslogExport, events []exportedEvent) error {
+	network := cfg.Network
+	if network == "" {
+		network = "udp"
+	}
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "sourcegraph-audit"
+	}
+
+	w, err := j.dialSyslog(network, cfg.Address, tag)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	for _, e := range events {
+		msg, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dialSyslog(network, address, tag string) (io.WriteCloser, error) {
+	return syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+}
@@ -0,0 +1,110 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type syslogRecorder struct {
+	bytes.Buffer
+	network, address, tag string
+}
+
+func (*syslogRecorder) Close() error { return nil }
+
+func TestExporterJob(t *testing.T) {
+	createdAt := time.Date(2023, 12, 18, 10, 0, 0, 0, time.UTC)
+	events := []*database.AuditEvent{
+		{ID: 1, Action: database.AuditActionAccessTokenCreated, EntityType: database.AuditEntityAccessToken, EntityID: "7", ActorUserID: 1, Metadata: map[string]any{"note": "ci"}, CreatedAt: createdAt},
+		{ID: 2, Action: database.AuditActionRepoDeleted, EntityType: database.AuditEntityRepo, EntityID: "3", Metadata: map[string]any{}, CreatedAt: createdAt},
+	}
+
+	newJob := func(cfg *schema.AuditLogExport) (*exporterJob, *dbmocks.MockAuditEventStore, *syslogRecorder) {
+		store := dbmocks.NewMockAuditEventStore()
+		store.ListForExportFunc.SetDefaultReturn(events, nil)
+		recorder := &syslogRecorder{}
+		return &exporterJob{
+			logger:    logtest.Scoped(t),
+			store:     store,
+			client:    httpcli.InternalDoer,
+			getConfig: func() *schema.AuditLogExport { return cfg },
+			batchSize: 10,
+			dialSyslog: func(network, address, tag string) (io.WriteCloser, error) {
+				recorder.network, recorder.address, recorder.tag = network, address, tag
+				return recorder, nil
+			},
+		}, store, recorder
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		job, store, _ := newJob(nil)
+		require.NoError(t, job.Handle(context.Background()))
+		assert.Empty(t, store.ListForExportFunc.History())
+	})
+
+	t.Run("webhook and syslog", func(t *testing.T) {
+		var body []byte
+		var signature string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+			signature = r.Header.Get(signatureHeader)
+		}))
+		t.Cleanup(srv.Close)
+
+		job, store, recorder := newJob(&schema.AuditLogExport{
+			Webhook: &schema.AuditLogWebhookExport{Url: srv.URL, Secret: "s3cr3t"},
+			Syslog:  &schema.AuditLogSyslogExport{Address: "siem:514"},
+		})
+		require.NoError(t, job.Handle(context.Background()))
+
+		var got []map[string]any
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.Len(t, got, 2)
+		assert.Equal(t, "access_token.created", got[0]["action"])
+		assert.Equal(t, "7", got[0]["entityID"])
+		assert.Equal(t, "2023-12-18T10:00:00Z", got[0]["createdAt"])
+
+		mac := hmac.New(sha256.New, []byte("s3cr3t"))
+		mac.Write(body)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+
+		assert.Equal(t, "udp", recorder.network)
+		assert.Equal(t, "siem:514", recorder.address)
+		assert.Equal(t, "sourcegraph-audit", recorder.tag)
+		assert.Contains(t, recorder.String(), `"action":"repo.deleted"`)
+
+		require.Len(t, store.MarkAsExportedFunc.History(), 1)
+		assert.Equal(t, []int64{1, 2}, store.MarkAsExportedFunc.History()[0].Arg1)
+	})
+
+	t.Run("failed delivery is retried", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(srv.Close)
+
+		job, store, _ := newJob(&schema.AuditLogExport{
+			Webhook: &schema.AuditLogWebhookExport{Url: srv.URL},
+		})
+		require.Error(t, job.Handle(context.Background()))
+		assert.Empty(t, store.MarkAsExportedFunc.History())
+	})
+}
@@ -127,7 +127,14 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 		}
 	}
 
-	includedFiles, excludedFiles := getFileFilterPathPatterns(embeddingsConfig)
+	// The site configuration takes precedence over the environment variable, so
+	// that the batch size can be tuned to the limits of the configured provider.
+	batchSize := embeddingsBatchSize
+	if embeddingsConfig.BatchSize > 0 {
+		batchSize = embeddingsConfig.BatchSize
+	}
+
+	includedFiles, excludedFiles := getFileFilterPathPatterns(embeddingsConfig, repo.Name)
 	opts := embed.EmbedRepoOpts{
 		RepoName: repo.Name,
 		Revision: record.Revision,
@@ -139,14 +146,14 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 		SplitOptions:          splitOptions,
 		MaxCodeEmbeddings:     embeddingsConfig.MaxCodeEmbeddingsPerRepo,
 		MaxTextEmbeddings:     embeddingsConfig.MaxTextEmbeddingsPerRepo,
-		BatchSize:             embeddingsBatchSize,
+		BatchSize:             batchSize,
 		ExcludeChunks:         embeddingsConfig.ExcludeChunkOnError,
 		TolerableFailureRatio: embeddingsTolerableFailureRatio,
 	}
 
 	if previousIndex != nil {
 		logger.Info("found previous embeddings index. Attempting incremental update", log.String("old_revision", string(previousIndex.Revision)))
-		opts.IndexedRevision = previousIndex.Revision
+		opts.PreviousIndex = previousIndex
 
 		hasPreviousIndex, err := qdrantInserter.HasIndex(ctx, modelID, repo.ID, previousIndex.Revision)
 		if err != nil {
@@ -214,20 +221,9 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 	}
 }
 
-func getFileFilterPathPatterns(embeddingsConfig *conftypes.EmbeddingsConfig) (includedFiles, excludedFiles []*paths.GlobPattern) {
-	var includedGlobPatterns, excludedGlobPatterns []*paths.GlobPattern
-	if embeddingsConfig != nil {
-		if len(embeddingsConfig.FileFilters.ExcludedFilePathPatterns) != 0 {
-			excludedGlobPatterns = embed.CompileGlobPatterns(embeddingsConfig.FileFilters.ExcludedFilePathPatterns)
-		}
-		if len(embeddingsConfig.FileFilters.IncludedFilePathPatterns) != 0 {
-			includedGlobPatterns = embed.CompileGlobPatterns(embeddingsConfig.FileFilters.IncludedFilePathPatterns)
-		}
-	}
-	if len(excludedGlobPatterns) == 0 {
-		excludedGlobPatterns = embed.GetDefaultExcludedFilePathPatterns()
-	}
-	return includedGlobPatterns, excludedGlobPatterns
+func getFileFilterPathPatterns(embeddingsConfig *conftypes.EmbeddingsConfig, repoName api.RepoName) (includedFiles, excludedFiles []*paths.GlobPattern) {
+	included, excluded := embeddings.FileFilterPatternsForRepo(embeddingsConfig, repoName)
+	return embed.CompileGlobPatterns(included), embed.CompileGlobPatterns(excluded)
 }
 
 type revisionFetcher struct {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 )
@@ -151,16 +152,16 @@ func (fi FakeFileInfo) Sys() interface{} {
 func TestGetFileFilterPathPatterns(t *testing.T) {
 	// nil embeddingsConfig. This shouldn't happen, but just in case
 	var embeddingsConfig *conftypes.EmbeddingsConfig
-	_, exclude := getFileFilterPathPatterns(embeddingsConfig)
-	if len(exclude) != len(embed.DefaultExcludedFilePathPatterns) {
-		t.Fatalf("Expected %d items, got %d", len(embed.DefaultExcludedFilePathPatterns), len(exclude))
+	_, exclude := getFileFilterPathPatterns(embeddingsConfig, "github.com/sourcegraph/sourcegraph")
+	if len(exclude) != len(embeddings.DefaultExcludedFilePathPatterns) {
+		t.Fatalf("Expected %d items, got %d", len(embeddings.DefaultExcludedFilePathPatterns), len(exclude))
 	}
 
 	// Empty embeddingsConfig
 	embeddingsConfig = &conftypes.EmbeddingsConfig{}
-	_, exclude = getFileFilterPathPatterns(embeddingsConfig)
-	if len(exclude) != len(embed.DefaultExcludedFilePathPatterns) {
-		t.Fatalf("Expected %d items, got %d", len(embed.DefaultExcludedFilePathPatterns), len(exclude))
+	_, exclude = getFileFilterPathPatterns(embeddingsConfig, "github.com/sourcegraph/sourcegraph")
+	if len(exclude) != len(embeddings.DefaultExcludedFilePathPatterns) {
+		t.Fatalf("Expected %d items, got %d", len(embeddings.DefaultExcludedFilePathPatterns), len(exclude))
 	}
 
 	// Non-empty embeddingsConfig
@@ -173,7 +174,7 @@ func TestGetFileFilterPathPatterns(t *testing.T) {
 			IncludedFilePathPatterns: []string{"*.go"},
 		},
 	}
-	include, exclude := getFileFilterPathPatterns(embeddingsConfig)
+	include, exclude := getFileFilterPathPatterns(embeddingsConfig, "github.com/sourcegraph/sourcegraph")
 	if len(exclude) != 2 {
 		t.Fatalf("Expected 2 items, got %d", len(exclude))
 	}
@@ -200,4 +201,33 @@ func TestGetFileFilterPathPatterns(t *testing.T) {
 	if include[0].Match("test.bar") == true {
 		t.Fatalf("Expected false, got true")
 	}
+
+	// Per-repository filters
+	embeddingsConfig.Admission.RepoFileFilters = []conftypes.EmbeddingsRepoFileFilters{
+		{
+			RepoNamePattern:          "^github.com/sourcegraph/",
+			IncludedFilePathPatterns: []string{"*.ts", "*.tsx"},
+			ExcludedFilePathPatterns: []string{"*.baz"},
+		},
+		{
+			RepoNamePattern:          "^github.com/other/",
+			ExcludedFilePathPatterns: []string{"*.qux"},
+		},
+	}
+	include, exclude = getFileFilterPathPatterns(embeddingsConfig, "github.com/sourcegraph/sourcegraph")
+	if len(exclude) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(exclude))
+	}
+	if len(include) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(include))
+	}
+	if exclude[2].Match("test.baz") == false {
+		t.Fatalf("Expected true, got false")
+	}
+	if include[0].Match("test.go") == true {
+		t.Fatalf("Expected false, got true")
+	}
+	if len(embeddingsConfig.FileFilters.ExcludedFilePathPatterns) != 2 {
+		t.Fatalf("Expected the config to be unchanged")
+	}
 }
@@ -1,10 +1,14 @@
 package executors
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/executor/artifacts"
 	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type janitorConfig struct {
@@ -15,6 +19,11 @@ type janitorConfig struct {
 
 	CacheCleanupInterval time.Duration
 	CacheDequeueTtl      time.Duration
+
+	// ArtifactsMaxAge is the max age of job artifacts by queue name. A zero max age
+	// keeps the artifacts of the queue forever.
+	ArtifactsMaxAge     map[string]time.Duration
+	ArtifactStoreConfig *artifacts.Config
 }
 
 var janitorConfigInst = &janitorConfig{}
@@ -25,4 +34,22 @@ func (c *janitorConfig) Load() {
 
 	c.CacheCleanupInterval = c.GetInterval("EXECUTORS_MULTIQUEUE_CACHE_CLEANUP_INTERVAL", executortypes.CleanupInterval.String(), "The frequency with which the multiqueue dequeue cache is cleaned up.")
 	c.CacheDequeueTtl = c.GetInterval("EXECUTORS_MULTIQUEUE_CACHE_DEQUEUE_TTL", executortypes.DequeueTtl.String(), "The duration after which a dequeue is deleted from the multiqueue dequeue cache.")
+
+	c.ArtifactsMaxAge = make(map[string]time.Duration, len(executortypes.ValidQueueNames))
+	for _, queue := range executortypes.ValidQueueNames {
+		c.ArtifactsMaxAge[queue] = c.GetInterval(
+			fmt.Sprintf("EXECUTORS_ARTIFACTS_%s_MAX_AGE", strings.ToUpper(queue)),
+			"168h", // one week
+			fmt.Sprintf("The age after which artifacts of %s jobs are deleted. Set to 0 to keep them forever.", queue),
+		)
+	}
+	c.ArtifactStoreConfig = &artifacts.Config{}
+	c.ArtifactStoreConfig.Load()
+}
+
+func (c *janitorConfig) Validate() error {
+	var errs error
+	errs = errors.Append(errs, c.BaseConfig.Validate())
+	errs = errors.Append(errs, c.ArtifactStoreConfig.Validate())
+	return errs
 }
@@ -2,14 +2,18 @@ package executors
 
 import (
 	"context"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/executor/artifacts"
 	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type janitorJob struct{}
@@ -34,6 +38,11 @@ func (j *janitorJob) Routines(_ context.Context, observationCtx *observation.Con
 
 	dequeueCache := rcache.New(executortypes.DequeueCachePrefix)
 
+	artifactStore, err := artifacts.New(context.Background(), observationCtx, janitorConfigInst.ArtifactStoreConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	routines := []goroutine.BackgroundRoutine{
 		goroutine.NewPeriodicGoroutine(
 			context.Background(),
@@ -45,7 +54,32 @@ func (j *janitorJob) Routines(_ context.Context, observationCtx *observation.Con
 			goroutine.WithInterval(janitorConfigInst.CleanupTaskInterval),
 		),
 		NewMultiqueueCacheCleaner(executortypes.ValidQueueNames, dequeueCache, janitorConfigInst.CacheDequeueTtl, janitorConfigInst.CacheCleanupInterval),
+		goroutine.NewPeriodicGoroutine(
+			context.Background(),
+			goroutine.HandlerFunc(func(ctx context.Context) error {
+				return expireArtifacts(ctx, artifactStore, janitorConfigInst.ArtifactsMaxAge)
+			}),
+			goroutine.WithName("executor.artifact-janitor"),
+			goroutine.WithDescription("deletes executor job artifacts older than the max age of their queue"),
+			goroutine.WithInterval(janitorConfigInst.CleanupTaskInterval),
+		),
 	}
 
 	return routines, nil
 }
+
+// expireArtifacts deletes the artifacts of each queue that are older than the max age
+// of the queue. Queues without a max age are skipped.
+func expireArtifacts(ctx context.Context, store uploadstore.Store, maxAges map[string]time.Duration) error {
+	var errs error
+	for _, queue := range executortypes.ValidQueueNames {
+		maxAge := maxAges[queue]
+		if maxAge <= 0 {
+			continue
+		}
+		if err := store.ExpireObjects(ctx, artifacts.QueuePrefix(queue), maxAge); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "expiring artifacts of queue %q", queue))
+		}
+	}
+	return errs
+}
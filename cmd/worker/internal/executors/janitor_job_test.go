@@ -0,0 +1,38 @@
+package executors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestExpireArtifacts(t *testing.T) {
+	store := mocks.NewMockStore()
+	store.ExpireObjectsFunc.SetDefaultHook(func(_ context.Context, prefix string, _ time.Duration) error {
+		if prefix == "codeintel/" {
+			return errors.New("expire failed")
+		}
+		return nil
+	})
+
+	err := expireArtifacts(context.Background(), store, map[string]time.Duration{"batches": 24 * time.Hour, "codeintel": time.Hour})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `expiring artifacts of queue "codeintel"`)
+
+	history := store.ExpireObjectsFunc.History()
+	require.Len(t, history, 2)
+	assert.Equal(t, "batches/", history[0].Arg1)
+	assert.Equal(t, 24*time.Hour, history[0].Arg2)
+	assert.Equal(t, "codeintel/", history[1].Arg1)
+
+	// Queues without a max age keep their artifacts.
+	store = mocks.NewMockStore()
+	require.NoError(t, expireArtifacts(context.Background(), store, map[string]time.Duration{"batches": 0}))
+	assert.Empty(t, store.ExpireObjectsFunc.History())
+}
@@ -0,0 +1,74 @@
+package outboundwebhooks
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreakers tracks consecutive delivery failures by outbound webhook, so
+// that we stop sending payloads to webhooks that are down instead of using up
+// the attempts of every queued job. The state is kept in memory, so each worker
+// instance trips its breakers independently.
+type circuitBreakers struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	breakers map[int64]*circuitBreaker
+}
+
+type circuitBreaker struct {
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreakers(threshold int, cooldown time.Duration) *circuitBreakers {
+	return &circuitBreakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		breakers:  map[int64]*circuitBreaker{},
+	}
+}
+
+// retryAfter returns how long to wait before sending a payload to the given
+// webhook, or zero if the circuit is closed.
+func (c *circuitBreakers) retryAfter(webhookID int64) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if b, ok := c.breakers[webhookID]; ok {
+		if wait := b.openUntil.Sub(c.now()); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// record updates the circuit of the given webhook with the outcome of a
+// delivery. Once the circuit has opened, a single failure after the cooldown
+// opens it again until a delivery succeeds.
+func (c *circuitBreakers) record(webhookID int64, delivered bool) {
+	if c.threshold <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if delivered {
+		delete(c.breakers, webhookID)
+		return
+	}
+
+	b, ok := c.breakers[webhookID]
+	if !ok {
+		b = &circuitBreaker{}
+		c.breakers[webhookID] = b
+	}
+	b.failures++
+	if b.failures >= c.threshold {
+		b.openUntil = c.now().Add(c.cooldown)
+	}
+}
@@ -0,0 +1,57 @@
+package outboundwebhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	now := time.Now()
+	breakers := newCircuitBreakers(2, time.Minute)
+	breakers.now = func() time.Time { return now }
+
+	breakers.record(1, false)
+	assert.Zero(t, breakers.retryAfter(1))
+
+	// The second consecutive failure opens the circuit of the webhook, but not
+	// of other webhooks.
+	breakers.record(1, false)
+	assert.Equal(t, time.Minute, breakers.retryAfter(1))
+	assert.Zero(t, breakers.retryAfter(2))
+
+	// After the cooldown, a single failure opens the circuit again.
+	now = now.Add(time.Minute)
+	assert.Zero(t, breakers.retryAfter(1))
+	breakers.record(1, false)
+	assert.Equal(t, time.Minute, breakers.retryAfter(1))
+
+	// A successful delivery resets the circuit.
+	now = now.Add(time.Minute)
+	breakers.record(1, true)
+	breakers.record(1, false)
+	assert.Zero(t, breakers.retryAfter(1))
+}
+
+func TestCircuitBreakersDisabled(t *testing.T) {
+	breakers := newCircuitBreakers(0, time.Minute)
+	for i := 0; i < 10; i++ {
+		breakers.record(1, false)
+	}
+	assert.Zero(t, breakers.retryAfter(1))
+}
+
+func TestSenderConfigBackoff(t *testing.T) {
+	c := &senderConfig{RetryBackoff: 30 * time.Second, MaxRetryBackoff: 5 * time.Minute}
+	for attempts, want := range map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		3: 2 * time.Minute,
+		4: 4 * time.Minute,
+		5: 5 * time.Minute,
+		9: 5 * time.Minute,
+	} {
+		assert.Equal(t, want, c.backoff(attempts), "attempts=%d", attempts)
+	}
+}
@@ -0,0 +1,61 @@
+package outboundwebhooks
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type senderConfig struct {
+	env.BaseConfig
+
+	// MaxAttempts is the number of times a payload is sent to a webhook before
+	// it is moved to the dead letter queue.
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry, which doubles with
+	// every further attempt up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+
+	// CircuitBreakerThreshold is the number of consecutive failures after which
+	// no payloads are sent to a webhook for CircuitBreakerCooldown. Zero
+	// disables the circuit breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+}
+
+var senderConfigInst = &senderConfig{}
+
+func (c *senderConfig) Load() {
+	c.MaxAttempts = c.GetInt("OUTBOUND_WEBHOOK_MAX_ATTEMPTS", "5", "The number of times a payload is sent to an outbound webhook before it is moved to the dead letter queue.")
+	c.RetryBackoff = c.GetInterval("OUTBOUND_WEBHOOK_RETRY_BACKOFF", "30s", "The delay before a failed outbound webhook payload is retried for the first time. The delay doubles with every further attempt.")
+	c.MaxRetryBackoff = c.GetInterval("OUTBOUND_WEBHOOK_MAX_RETRY_BACKOFF", "1h", "The maximum delay between attempts to send an outbound webhook payload.")
+	c.CircuitBreakerThreshold = c.GetInt("OUTBOUND_WEBHOOK_CIRCUIT_BREAKER_THRESHOLD", "10", "The number of consecutive failures after which no payloads are sent to an outbound webhook until the cooldown has passed. Set to 0 to disable.")
+	c.CircuitBreakerCooldown = c.GetInterval("OUTBOUND_WEBHOOK_CIRCUIT_BREAKER_COOLDOWN", "5m", "The time for which no payloads are sent to an outbound webhook once its circuit breaker opens.")
+}
+
+func (c *senderConfig) Validate() error {
+	var errs error
+	errs = errors.Append(errs, c.BaseConfig.Validate())
+	if c.MaxAttempts < 1 {
+		errs = errors.Append(errs, errors.New("OUTBOUND_WEBHOOK_MAX_ATTEMPTS must be at least 1"))
+	}
+	if c.CircuitBreakerThreshold < 0 {
+		errs = errors.Append(errs, errors.New("OUTBOUND_WEBHOOK_CIRCUIT_BREAKER_THRESHOLD must not be negative"))
+	}
+	return errs
+}
+
+// backoff returns the delay before the next attempt after the given number of
+// failed attempts.
+func (c *senderConfig) backoff(attempts int) time.Duration {
+	delay := c.RetryBackoff
+	for i := 1; i < attempts && delay < c.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxRetryBackoff {
+		delay = c.MaxRetryBackoff
+	}
+	return delay
+}
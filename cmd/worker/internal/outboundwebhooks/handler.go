@@ -8,22 +8,30 @@ import (
 	"encoding/hex"
 	"io"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/sourcegraph/conc/pool"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type handler struct {
-	client   *http.Client
-	store    database.OutboundWebhookStore
-	logStore database.OutboundWebhookLogStore
+	client          *http.Client
+	store           database.OutboundWebhookStore
+	logStore        database.OutboundWebhookLogStore
+	deadLetterStore database.OutboundWebhookDeadLetterStore
+	workerStore     dbworkerstore.Store[*types.OutboundWebhookJob]
+	breakers        *circuitBreakers
+	config          *senderConfig
 }
 
 var _ workerutil.Handler[*types.OutboundWebhookJob] = &handler{}
@@ -35,49 +43,154 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job *types.Outb
 		log.Stringp("job.scope", job.Scope),
 	)
 
-	webhooks, err := h.store.List(ctx, database.OutboundWebhookListOpts{
-		OutboundWebhookCountOpts: database.OutboundWebhookCountOpts{
-			EventTypes: []database.FilterEventType{{
-				EventType: job.EventType,
-				Scope:     job.Scope,
-			}},
-		},
-	})
+	webhooks, err := h.listWebhooks(ctx, job)
 	if err != nil {
 		logger.Error("error retrieving outbound webhooks", log.Error(err))
 		return errors.Wrap(err, "retrieving outbound webhooks")
 	}
 
+	// If the job has been handled before, we only send the payload to the
+	// webhooks that haven't received it yet.
+	attempts, err := h.logStore.DeliveryAttemptsForJob(ctx, job.ID)
+	if err != nil {
+		logger.Error("error retrieving delivery attempts", log.Error(err))
+		return errors.Wrap(err, "retrieving delivery attempts")
+	}
+
+	// retryAfter is the shortest delay after which a delivery that failed or
+	// was postponed should be attempted again, if any.
+	var (
+		mu         sync.Mutex
+		retryAfter time.Duration
+	)
+	postpone := func(delay time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		if retryAfter == 0 || delay < retryAfter {
+			retryAfter = delay
+		}
+	}
+
 	// Since sending HTTP requests is (generally) cheap, and we've already done
 	// the relatively expensive parts of constructing the payload and retrieving
 	// the matching hooks, we're going to just fan these out with a high
 	// concurrency limit.
 	p := pool.New().WithContext(ctx).WithMaxGoroutines(100)
 	for _, webhook := range webhooks {
-		p.Go(h.buildWebhookSender(
-			logger.With(log.Int64("webhook.id", webhook.ID)),
-			job, webhook,
-		))
+		webhook := webhook
+		previous := attempts[webhook.ID]
+		if previous.Delivered {
+			continue
+		}
+		if previous.Attempts >= h.config.MaxAttempts {
+			// The payload was already moved to the dead letter queue of the
+			// webhook when its last attempt failed.
+			continue
+		}
+
+		logger := logger.With(log.Int64("webhook.id", webhook.ID))
+		if delay := h.breakers.retryAfter(webhook.ID); delay > 0 {
+			// Postponed deliveries don't count as attempts.
+			logger.Debug("circuit breaker is open; postponing delivery", log.Duration("delay", delay))
+			postpone(delay)
+			continue
+		}
+
+		p.Go(func(ctx context.Context) error {
+			statusCode, err := h.sendWebhook(ctx, logger, job, webhook)
+			h.breakers.record(webhook.ID, err == nil)
+			if err == nil {
+				return nil
+			}
+
+			if n := previous.Attempts + 1; n < h.config.MaxAttempts {
+				postpone(h.config.backoff(n))
+				return nil
+			}
+			return h.deadLetter(ctx, logger, job, webhook, previous.Attempts+1, statusCode, err)
+		})
 	}
 
 	// Errors will have been logged individually, so we can just return the
 	// error back out of the handler.
-	return p.Wait()
+	err = p.Wait()
+
+	if retryAfter > 0 {
+		// Requeueing the job means that the worker won't mark it as completed
+		// or errored, so any dead letter errors are only logged.
+		if err := h.workerStore.Requeue(ctx, int(job.ID), time.Now().Add(retryAfter)); err != nil {
+			logger.Error("error requeueing outbound webhook job", log.Error(err))
+			return errors.Wrap(err, "requeueing job")
+		}
+		logger.Debug("requeued outbound webhook job", log.Duration("delay", retryAfter))
+		return nil
+	}
+
+	return err
 }
 
-func (h *handler) buildWebhookSender(
-	logger log.Logger, job *types.OutboundWebhookJob,
-	webhook *types.OutboundWebhook,
-) func(context.Context) error {
-	return func(ctx context.Context) error {
-		return h.sendWebhook(ctx, logger, job, webhook)
+// listWebhooks returns the webhooks the payload of the job is sent to.
+func (h *handler) listWebhooks(ctx context.Context, job *types.OutboundWebhookJob) ([]*types.OutboundWebhook, error) {
+	if job.OutboundWebhookID != nil {
+		webhook, err := h.store.GetByID(ctx, *job.OutboundWebhookID)
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []*types.OutboundWebhook{webhook}, nil
 	}
+
+	return h.store.List(ctx, database.OutboundWebhookListOpts{
+		OutboundWebhookCountOpts: database.OutboundWebhookCountOpts{
+			EventTypes: []database.FilterEventType{{
+				EventType: job.EventType,
+				Scope:     job.Scope,
+			}},
+		},
+	})
 }
 
-func (h *handler) sendWebhook(
+// deadLetter moves the payload of the job to the dead letter queue of the
+// webhook after the last delivery attempt failed with sendErr.
+func (h *handler) deadLetter(
 	ctx context.Context, logger log.Logger,
 	job *types.OutboundWebhookJob, webhook *types.OutboundWebhook,
+	attempts, statusCode int, sendErr error,
 ) error {
+	err := errors.Wrapf(sendErr, "giving up after %d attempts", attempts)
+
+	payload, decryptErr := job.Payload.Decrypt(ctx)
+	if decryptErr != nil {
+		logger.Error("cannot decrypt payload", log.Error(decryptErr))
+		return errors.Append(err, errors.Wrap(decryptErr, "decrypting payload"))
+	}
+
+	if createErr := h.deadLetterStore.Create(ctx, &types.OutboundWebhookDeadLetter{
+		OutboundWebhookID: webhook.ID,
+		JobID:             job.ID,
+		EventType:         job.EventType,
+		Scope:             job.Scope,
+		Payload:           encryption.NewUnencrypted(payload),
+		Attempts:          attempts,
+		LastStatusCode:    statusCode,
+		LastError:         encryption.NewUnencrypted(sendErr.Error()),
+	}); createErr != nil {
+		logger.Error("error writing outbound webhook dead letter", log.Error(createErr))
+		return errors.Append(err, errors.Wrap(createErr, "writing dead letter"))
+	}
+
+	logger.Warn("moved outbound webhook payload to the dead letter queue", log.Int("attempts", attempts))
+	return err
+}
+
+// sendWebhook sends the payload of the job to the webhook and returns the
+// status code of the response, which is 0 if no response was received.
+func (h *handler) sendWebhook(
+	ctx context.Context, logger log.Logger,
+	job *types.OutboundWebhookJob, webhook *types.OutboundWebhook,
+) (int, error) {
 	// This function is a bit of a god function, but there isn't an obvious way
 	// to break it down — in classic Go style, much of its weight is really just
 	// repetitive error handling.
@@ -88,25 +201,25 @@ func (h *handler) sendWebhook(
 	url, err := webhook.URL.Decrypt(ctx)
 	if err != nil {
 		logger.Error("cannot decrypt webhook URL", log.Error(err))
-		return errors.Wrap(err, "decrypting webhook URL")
+		return 0, errors.Wrap(err, "decrypting webhook URL")
 	}
 
 	err = outbound.CheckURL(url)
 	if err != nil {
 		logger.Error("webhook URL is not allowed", log.Error(err))
-		return errors.Wrap(err, "checking webhook URL")
+		return 0, errors.Wrap(err, "checking webhook URL")
 	}
 
 	secret, err := webhook.Secret.Decrypt(ctx)
 	if err != nil {
 		logger.Error("cannot decrypt webhook secret", log.Error(err))
-		return errors.Wrap(err, "decrypting webhook secret")
+		return 0, errors.Wrap(err, "decrypting webhook secret")
 	}
 
 	payload, err := job.Payload.Decrypt(ctx)
 	if err != nil {
 		logger.Error("cannot decrypt payload", log.Error(err))
-		return errors.Wrap(err, "decrypting payload")
+		return 0, errors.Wrap(err, "decrypting payload")
 	}
 
 	// Second, we need to generate a signature based on the shared secret and
@@ -115,7 +228,7 @@ func (h *handler) sendWebhook(
 	sig, err := calculateSignature(secret, payloadReader)
 	if err != nil {
 		logger.Error("error signing payload", log.Error(err))
-		return errors.Wrap(err, "calculating payload signature")
+		return 0, errors.Wrap(err, "calculating payload signature")
 	}
 	payloadReader.Seek(0, io.SeekStart)
 
@@ -123,7 +236,7 @@ func (h *handler) sendWebhook(
 	req, err := http.NewRequestWithContext(ctx, "POST", url, payloadReader)
 	if err != nil {
 		logger.Error("cannot build webhook request", log.Error(err))
-		return errors.Wrap(err, "building request")
+		return 0, errors.Wrap(err, "building request")
 	}
 
 	req.Header.Add("Content-Type", "application/json; charset=utf-8")
@@ -158,7 +271,7 @@ func (h *handler) sendWebhook(
 	if err != nil {
 		logger.Info("error sending webhook", log.Error(err))
 		webhookLog.Error = encryption.NewUnencrypted(err.Error())
-		return errors.Wrap(err, "sending webhook")
+		return 0, errors.Wrap(err, "sending webhook")
 	}
 
 	// Sixth, we process the response for logging purposes.
@@ -168,7 +281,7 @@ func (h *handler) sendWebhook(
 	response, err := io.ReadAll(resp.Body)
 	if err != nil {
 		logger.Error("cannot read response body", log.Error(err))
-		return errors.Wrap(err, "reading response body")
+		return resp.StatusCode, errors.Wrap(err, "reading response body")
 	}
 	webhookLog.Response = types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{
 		Header: resp.Header,
@@ -179,11 +292,11 @@ func (h *handler) sendWebhook(
 
 	if resp.StatusCode >= http.StatusBadRequest {
 		logger.Info("got unexpected status code from webhook", log.Int("status_code", resp.StatusCode))
-		return errors.Errorf("unexpected status code: %d", resp.StatusCode)
+		return resp.StatusCode, errors.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	logger.Debug("webhook sent successfully")
-	return nil
+	return resp.StatusCode, nil
 }
 
 func calculateSignature(secret string, payload io.Reader) (string, error) {
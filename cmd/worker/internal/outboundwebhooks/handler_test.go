@@ -8,16 +8,19 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+	dbworkerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -78,11 +81,8 @@ func TestHandler_Handle(t *testing.T) {
 			return nil
 		})
 
-		h := &handler{
-			client:   http.DefaultClient,
-			store:    store,
-			logStore: logStore,
-		}
+		deadLetterStore := dbmocks.NewMockOutboundWebhookDeadLetterStore()
+		h := newTestHandler(http.DefaultClient, store, logStore, deadLetterStore, 1)
 
 		outbound.SetTestDenyList()
 		t.Cleanup(outbound.ResetDenyList)
@@ -93,6 +93,8 @@ func TestHandler_Handle(t *testing.T) {
 
 		mockassert.CalledN(t, store.ListFunc, 1)
 		mockassert.CalledN(t, logStore.CreateFunc, 2)
+		mockassert.CalledN(t, deadLetterStore.CreateFunc, 1)
+		assert.Equal(t, sadWebhook.ID, deadLetterStore.CreateFunc.History()[0].Arg1.OutboundWebhookID)
 
 		assert.EqualValues(t, 1, happyServer.requestCount)
 		assert.EqualValues(t, 1, sadServer.requestCount)
@@ -134,11 +136,7 @@ func TestHandler_Handle(t *testing.T) {
 			return nil
 		})
 
-		h := &handler{
-			client:   &http.Client{Transport: &badTransport{Err: want}},
-			store:    store,
-			logStore: logStore,
-		}
+		h := newTestHandler(&http.Client{Transport: &badTransport{Err: want}}, store, logStore, dbmocks.NewMockOutboundWebhookDeadLetterStore(), 1)
 
 		outbound.SetTestDenyList()
 		t.Cleanup(outbound.ResetDenyList)
@@ -150,6 +148,177 @@ func TestHandler_Handle(t *testing.T) {
 		mockassert.CalledN(t, logStore.CreateFunc, 1)
 	})
 
+	t.Run("retries", func(t *testing.T) {
+		ctx := context.Background()
+		logger := logtest.Scoped(t)
+
+		payload := []byte(`"test payload"`)
+		happyServer := newMockServer(t, payload, http.StatusOK)
+		sadServer := newMockServer(t, payload, http.StatusInternalServerError)
+
+		job := &types.OutboundWebhookJob{
+			ID:        1,
+			EventType: "event",
+			Payload:   encryption.NewUnencrypted(string(payload)),
+		}
+		deliveredWebhook := &types.OutboundWebhook{
+			ID:     1,
+			URL:    encryption.NewUnencrypted(happyServer.URL),
+			Secret: encryption.NewUnencrypted("secret"),
+		}
+		sadWebhook := &types.OutboundWebhook{
+			ID:     2,
+			URL:    encryption.NewUnencrypted(sadServer.URL),
+			Secret: encryption.NewUnencrypted("secret"),
+		}
+
+		store := dbmocks.NewMockOutboundWebhookStore()
+		store.ListFunc.SetDefaultReturn([]*types.OutboundWebhook{deliveredWebhook, sadWebhook}, nil)
+
+		logStore := dbmocks.NewMockOutboundWebhookLogStore()
+		logStore.DeliveryAttemptsForJobFunc.SetDefaultReturn(map[int64]database.OutboundWebhookDeliveryAttempts{
+			deliveredWebhook.ID: {Attempts: 1, Delivered: true},
+			sadWebhook.ID:       {Attempts: 1},
+		}, nil)
+
+		outbound.SetTestDenyList()
+		t.Cleanup(outbound.ResetDenyList)
+
+		t.Run("requeued", func(t *testing.T) {
+			deadLetterStore := dbmocks.NewMockOutboundWebhookDeadLetterStore()
+			h := newTestHandler(http.DefaultClient, store, logStore, deadLetterStore, 3)
+			workerStore := h.workerStore.(*dbworkerstoremocks.MockStore[*types.OutboundWebhookJob])
+
+			before := time.Now()
+			require.NoError(t, h.Handle(ctx, logger, job))
+
+			// The webhook that already received the payload is skipped.
+			assert.EqualValues(t, 0, happyServer.requestCount)
+			assert.EqualValues(t, 1, sadServer.requestCount)
+			mockassert.NotCalled(t, deadLetterStore.CreateFunc)
+
+			// This was the second attempt, so we back off for twice the initial delay.
+			require.Len(t, workerStore.RequeueFunc.History(), 1)
+			call := workerStore.RequeueFunc.History()[0]
+			assert.Equal(t, 1, call.Arg1)
+			assert.WithinDuration(t, before.Add(2*time.Minute), call.Arg2, 10*time.Second)
+		})
+
+		t.Run("dead lettered", func(t *testing.T) {
+			deadLetterStore := dbmocks.NewMockOutboundWebhookDeadLetterStore()
+			h := newTestHandler(http.DefaultClient, store, logStore, deadLetterStore, 2)
+			workerStore := h.workerStore.(*dbworkerstoremocks.MockStore[*types.OutboundWebhookJob])
+
+			err := h.Handle(ctx, logger, job)
+			require.ErrorContains(t, err, "giving up after 2 attempts")
+			mockassert.NotCalled(t, workerStore.RequeueFunc)
+
+			require.Len(t, deadLetterStore.CreateFunc.History(), 1)
+			letter := deadLetterStore.CreateFunc.History()[0].Arg1
+			assert.Equal(t, sadWebhook.ID, letter.OutboundWebhookID)
+			assert.Equal(t, job.ID, letter.JobID)
+			assert.Equal(t, 2, letter.Attempts)
+			assert.Equal(t, http.StatusInternalServerError, letter.LastStatusCode)
+			assert.Equal(t, string(payload), decrypt(t, letter.Payload))
+			assert.Equal(t, "unexpected status code: 500", decrypt(t, letter.LastError))
+		})
+
+		t.Run("attempts exhausted", func(t *testing.T) {
+			deadLetterStore := dbmocks.NewMockOutboundWebhookDeadLetterStore()
+			h := newTestHandler(http.DefaultClient, store, logStore, deadLetterStore, 1)
+			workerStore := h.workerStore.(*dbworkerstoremocks.MockStore[*types.OutboundWebhookJob])
+
+			requests := sadServer.requestCount
+			require.NoError(t, h.Handle(ctx, logger, job))
+
+			// The payload isn't sent or dead lettered again.
+			assert.Equal(t, requests, sadServer.requestCount)
+			mockassert.NotCalled(t, deadLetterStore.CreateFunc)
+			mockassert.NotCalled(t, workerStore.RequeueFunc)
+		})
+
+		t.Run("circuit breaker", func(t *testing.T) {
+			deadLetterStore := dbmocks.NewMockOutboundWebhookDeadLetterStore()
+			h := newTestHandler(http.DefaultClient, store, logStore, deadLetterStore, 2)
+			h.breakers = newCircuitBreakers(1, time.Hour)
+			h.breakers.record(sadWebhook.ID, false)
+			workerStore := h.workerStore.(*dbworkerstoremocks.MockStore[*types.OutboundWebhookJob])
+
+			requests := sadServer.requestCount
+			require.NoError(t, h.Handle(ctx, logger, job))
+
+			// The delivery is postponed until the breaker closes, without
+			// counting as an attempt.
+			assert.Equal(t, requests, sadServer.requestCount)
+			mockassert.NotCalled(t, deadLetterStore.CreateFunc)
+			require.Len(t, workerStore.RequeueFunc.History(), 1)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), workerStore.RequeueFunc.History()[0].Arg2, 10*time.Second)
+		})
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		ctx := context.Background()
+		logger := logtest.Scoped(t)
+
+		payload := []byte(`"test payload"`)
+		server := newMockServer(t, payload, http.StatusOK)
+
+		webhookID := int64(2)
+		job := &types.OutboundWebhookJob{
+			ID:                1,
+			EventType:         "event",
+			Payload:           encryption.NewUnencrypted(string(payload)),
+			OutboundWebhookID: &webhookID,
+		}
+
+		store := dbmocks.NewMockOutboundWebhookStore()
+		store.GetByIDFunc.SetDefaultReturn(&types.OutboundWebhook{
+			ID:     webhookID,
+			URL:    encryption.NewUnencrypted(server.URL),
+			Secret: encryption.NewUnencrypted("secret"),
+		}, nil)
+
+		outbound.SetTestDenyList()
+		t.Cleanup(outbound.ResetDenyList)
+
+		h := newTestHandler(http.DefaultClient, store, dbmocks.NewMockOutboundWebhookLogStore(), dbmocks.NewMockOutboundWebhookDeadLetterStore(), 1)
+		require.NoError(t, h.Handle(ctx, logger, job))
+
+		// Only the target webhook receives the payload.
+		mockassert.NotCalled(t, store.ListFunc)
+		mockassert.CalledOnceWith(t, store.GetByIDFunc, mockassert.Values(mockassert.Skip, webhookID))
+		assert.EqualValues(t, 1, server.requestCount)
+	})
+}
+
+func newTestHandler(
+	client *http.Client,
+	store database.OutboundWebhookStore,
+	logStore database.OutboundWebhookLogStore,
+	deadLetterStore database.OutboundWebhookDeadLetterStore,
+	maxAttempts int,
+) *handler {
+	return &handler{
+		client:          client,
+		store:           store,
+		logStore:        logStore,
+		deadLetterStore: deadLetterStore,
+		workerStore:     dbworkerstoremocks.NewMockStore[*types.OutboundWebhookJob](),
+		breakers:        newCircuitBreakers(0, 0),
+		config: &senderConfig{
+			MaxAttempts:     maxAttempts,
+			RetryBackoff:    time.Minute,
+			MaxRetryBackoff: time.Hour,
+		},
+	}
+}
+
+func decrypt(t *testing.T, e *encryption.Encryptable) string {
+	t.Helper()
+
+	value, err := e.Decrypt(context.Background())
+	require.NoError(t, err)
+	return value
 }
 
 type badTransport struct {
@@ -10,24 +10,34 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 const janitorFrequency = 1 * time.Hour
 
 // makeJanitor creates a background goroutine to expunge old outbound webhook
-// jobs and logs from the database.
-func makeJanitor(observationCtx *observation.Context, store database.OutboundWebhookJobStore) goroutine.BackgroundRoutine {
+// jobs and logs, and replayed dead letters, from the database. Dead letters that
+// haven't been replayed are kept until their webhook is deleted.
+func makeJanitor(
+	observationCtx *observation.Context,
+	store database.OutboundWebhookJobStore,
+	deadLetterStore database.OutboundWebhookDeadLetterStore,
+) goroutine.BackgroundRoutine {
 	return goroutine.NewPeriodicGoroutine(
 		context.Background(),
 		goroutine.HandlerFunc(func(ctx context.Context) error {
-			err := store.DeleteBefore(ctx, time.Now().Add(-1*calculateRetention(observationCtx.Logger, conf.Get())))
+			before := time.Now().Add(-1 * calculateRetention(observationCtx.Logger, conf.Get()))
+			err := errors.Append(
+				store.DeleteBefore(ctx, before),
+				deadLetterStore.DeleteReplayedBefore(ctx, before),
+			)
 			if err != nil {
 				observationCtx.Logger.Error("outbound webhook janitor error", log.Error(err))
 			}
 			return err
 		}),
 		goroutine.WithName("outbound-webhooks.janitor"),
-		goroutine.WithDescription("cleans up stale outbound webhook jobs"),
+		goroutine.WithDescription("cleans up stale outbound webhook jobs and dead letters"),
 		goroutine.WithInterval(janitorFrequency),
 	)
 }
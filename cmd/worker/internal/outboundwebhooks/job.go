@@ -32,7 +32,7 @@ func (s *sender) Description() string {
 }
 
 func (*sender) Config() []env.Config {
-	return nil
+	return []env.Config{senderConfigInst}
 }
 
 func (s *sender) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
@@ -47,15 +47,17 @@ func (s *sender) Routines(_ context.Context, observationCtx *observation.Context
 	client := httpcli.ExternalClient
 	key := keyring.Default().OutboundWebhookKey
 	workerStore := makeStore(observationCtx, db.Handle(), key)
+	webhookStore := database.OutboundWebhooksWith(db, key)
 
 	return []goroutine.BackgroundRoutine{
 		makeWorker(
 			ctx, observationCtx, workerStore, client,
-			database.OutboundWebhooksWith(db, key),
+			webhookStore,
 			database.OutboundWebhookLogsWith(db, key),
+			senderConfigInst,
 		),
 		makeResetter(observationCtx, workerStore),
-		makeJanitor(observationCtx, db.OutboundWebhookJobs(key)),
+		makeJanitor(observationCtx, db.OutboundWebhookJobs(key), webhookStore.ToDeadLetterStore()),
 	}, nil
 }
 
@@ -66,11 +68,16 @@ func makeWorker(
 	client *http.Client,
 	webhookStore database.OutboundWebhookStore,
 	logStore database.OutboundWebhookLogStore,
+	config *senderConfig,
 ) *workerutil.Worker[*types.OutboundWebhookJob] {
 	handler := &handler{
-		client:   client,
-		store:    webhookStore,
-		logStore: logStore,
+		client:          client,
+		store:           webhookStore,
+		logStore:        logStore,
+		deadLetterStore: webhookStore.ToDeadLetterStore(),
+		workerStore:     workerStore,
+		breakers:        newCircuitBreakers(config.CircuitBreakerThreshold, config.CircuitBreakerCooldown),
+		config:          config,
 	}
 
 	return dbworker.NewWorker[*types.OutboundWebhookJob](
@@ -0,0 +1,64 @@
+package reposnapshots
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/reposnapshots"
+)
+
+type snapshotJob struct{}
+
+// NewSnapshotJob returns a job that enqueues the snapshots of due schedules and
+// writes queued snapshots to the blob store.
+func NewSnapshotJob() job.Job {
+	return &snapshotJob{}
+}
+
+func (j *snapshotJob) Description() string {
+	return "Produces signed point-in-time snapshots of repositories on demand and on schedule."
+}
+
+func (j *snapshotJob) Config() []env.Config {
+	return []env.Config{reposnapshots.ConfigInst}
+}
+
+func (j *snapshotJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	workCtx := actor.WithInternalActor(context.Background())
+
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadStore, err := reposnapshots.NewUploadStore(workCtx, observationCtx, reposnapshots.ConfigInst)
+	if err != nil {
+		return nil, err
+	}
+
+	store := reposnapshots.NewStore(db, observationCtx)
+	workerStore := reposnapshots.NewWorkerStore(observationCtx, db.Handle())
+	builder := reposnapshots.NewBuilder(db.Repos(), gitserver.NewClient("reposnapshots.worker"), uploadStore)
+
+	return []goroutine.BackgroundRoutine{
+		newSnapshotWorker(workCtx, observationCtx, workerStore, store, builder, uploadStore),
+		newSnapshotWorkerResetter(observationCtx, workerStore),
+		goroutine.NewPeriodicGoroutine(
+			workCtx,
+			goroutine.HandlerFunc(func(ctx context.Context) error {
+				_, err := store.EnqueueDueSnapshots(ctx, time.Now())
+				return err
+			}),
+			goroutine.WithName("reposnapshots.scheduler"),
+			goroutine.WithDescription("enqueues the snapshots of repository snapshot schedules that are due"),
+			goroutine.WithInterval(time.Minute),
+		),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package reposnapshots
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/reposnapshots"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// newSnapshotWorker creates a background routine that writes queued snapshots to
+// the blob store.
+func newSnapshotWorker(
+	ctx context.Context,
+	observationCtx *observation.Context,
+	workerStore dbworkerstore.Store[*reposnapshots.Snapshot],
+	store *reposnapshots.Store,
+	builder *reposnapshots.Builder,
+	uploadStore uploadstore.Store,
+) goroutine.BackgroundRoutine {
+	handler := &snapshotHandler{
+		store:       store,
+		builder:     builder,
+		uploadStore: uploadStore,
+	}
+
+	opts := workerutil.WorkerOptions{
+		Name:              "repository_snapshots_worker",
+		Description:       "writes repository snapshots to the blob store",
+		NumHandlers:       1,
+		Interval:          10 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           workerutil.NewMetrics(observationCtx, "repository_snapshots_worker"),
+	}
+
+	return dbworker.NewWorker[*reposnapshots.Snapshot](ctx, workerStore, handler, opts)
+}
+
+type snapshotHandler struct {
+	store       *reposnapshots.Store
+	builder     *reposnapshots.Builder
+	uploadStore uploadstore.Store
+}
+
+var _ workerutil.Handler[*reposnapshots.Snapshot] = &snapshotHandler{}
+
+func (h *snapshotHandler) Handle(ctx context.Context, logger log.Logger, record *reposnapshots.Snapshot) error {
+	signer, err := reposnapshots.SignerFromSiteConfig()
+	if err != nil {
+		return err
+	}
+
+	manifest, signature, err := h.builder.Build(ctx, record, signer)
+	if err != nil {
+		// Don't leave the archives of a partial snapshot behind. A retry uploads
+		// all archives again.
+		if deleteErr := reposnapshots.DeleteObjects(ctx, h.uploadStore, record.ID); deleteErr != nil {
+			logger.Warn("failed to delete objects of partial snapshot", log.Int("id", record.ID), log.Error(deleteErr))
+		}
+		return err
+	}
+
+	return h.store.SetSnapshotManifest(ctx, record.ID, manifest, signature)
+}
+
+func newSnapshotWorkerResetter(
+	observationCtx *observation.Context,
+	workerStore dbworkerstore.Store[*reposnapshots.Snapshot],
+) *dbworker.Resetter[*reposnapshots.Snapshot] {
+	options := dbworker.ResetterOptions{
+		Name:     "repository_snapshots_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics:  dbworker.NewResetterMetrics(observationCtx, "repository_snapshots_worker"),
+	}
+
+	return dbworker.NewResetter(observationCtx.Logger, workerStore, options)
+}
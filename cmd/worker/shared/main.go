@@ -12,6 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/auth"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/batches"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/codeintel"
@@ -30,6 +31,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/own"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/permissions"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/reposnapshots"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repostatistics"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/search"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/telemetry"
@@ -101,6 +103,7 @@ func LoadConfig(registerEnterpriseMigrators oobmigration.RegisterMigratorsFunc)
 		"permission-sync-job-scheduler":         permissions.NewPermissionSyncJobScheduler(),
 		"export-usage-telemetry":                telemetry.NewTelemetryJob(),
 		"telemetrygateway-exporter":             telemetrygatewayexporter.NewJob(),
+		"audit-log-exporter":                    auditlog.NewExporter(),
 
 		"codeintel-policies-repository-matcher":       codeintel.NewPoliciesRepositoryMatcherJob(),
 		"codeintel-autoindexing-summary-builder":      codeintel.NewAutoindexingSummaryBuilder(),
@@ -128,6 +131,8 @@ func LoadConfig(registerEnterpriseMigrators oobmigration.RegisterMigratorsFunc)
 		"github-apps-installation-validation-job": githubapps.NewGitHubApsInstallationJob(),
 
 		"exhaustive-search-job": search.NewSearchJob(),
+
+		"repository-snapshots-job": reposnapshots.NewSnapshotJob(),
 	}
 
 	var config Config
@@ -0,0 +1,161 @@
+// Package totp implements time-based one-time passwords (RFC 6238) as they are
+// generated by common authenticator apps, along with single-use recovery codes.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// Period is the number of seconds a single code is valid for.
+	Period = 30
+	// Digits is the number of digits of a code.
+	Digits = 6
+
+	// secretSize is the number of random bytes in a secret, as recommended
+	// by RFC 4226 for HMAC-SHA1.
+	secretSize = 20
+	// skew is the number of periods before and after the current one whose
+	// codes are accepted, to allow for clock drift and slow typists.
+	skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32-encoded secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generating secret")
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI that authenticator apps scan (as a
+// QR code) to enroll the given secret for the given account.
+func ProvisioningURI(issuer, accountName, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(Period))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+// Step returns the time step the given time falls into.
+func Step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// Code returns the code for the given secret at the given time step.
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "decoding secret")
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as described in RFC 4226, section 5.3.
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate checks the given code against the secret at time t. Codes of time
+// steps up to and including lastUsedStep are rejected so that a code cannot be
+// used twice. On success, the time step of the matching code is returned and
+// should be recorded as the new last used step.
+func Validate(secret, code string, t time.Time, lastUsedStep int64) (step int64, ok bool, err error) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false, nil
+	}
+
+	current := Step(t)
+	for s := current - skew; s <= current+skew; s++ {
+		if s <= lastUsedStep {
+			continue
+		}
+		expected, err := Code(secret, s)
+		if err != nil {
+			return 0, false, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return s, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+const (
+	// RecoveryCodeCount is the number of recovery codes generated at once.
+	RecoveryCodeCount = 10
+
+	// recoveryCodeSize is the number of random bytes in a recovery code. It is
+	// large enough that the unsalted hashes of recovery codes cannot be
+	// reversed by brute force.
+	recoveryCodeSize = 10
+)
+
+// GenerateRecoveryCodes returns RecoveryCodeCount new random recovery codes of
+// the form xxxxx-xxxxx-xxxxx-xxxxx.
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, errors.Wrap(err, "generating recovery code")
+		}
+		s := hex.EncodeToString(b)
+		codes = append(codes, s[:5]+"-"+s[5:10]+"-"+s[10:15]+"-"+s[15:])
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the representation of a recovery code that is
+// stored. Recovery codes are compared case-insensitively and ignoring
+// surrounding whitespace.
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashRecoveryCodes returns the hashes of all given recovery codes.
+func HashRecoveryCodes(codes []string) []string {
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return hashes
+}
@@ -0,0 +1,109 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the base32 encoding of the SHA1 test secret of RFC 6238,
+// "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// The test vectors of RFC 6238, appendix B, truncated to six digits.
+	for unix, want := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		have, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, have, "time %d", unix)
+	}
+
+	_, err := Code("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := Step(now)
+
+	t.Run("current code", func(t *testing.T) {
+		have, ok, err := Validate(rfcSecret, "005924", now, 0)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, step, have)
+	})
+
+	t.Run("whitespace is ignored", func(t *testing.T) {
+		_, ok, err := Validate(rfcSecret, " 005 924 ", now, 0)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("previous period is accepted", func(t *testing.T) {
+		_, ok, err := Validate(rfcSecret, "005924", now.Add(Period*time.Second), 0)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("older periods are rejected", func(t *testing.T) {
+		_, ok, err := Validate(rfcSecret, "005924", now.Add(2*Period*time.Second), 0)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("used codes are rejected", func(t *testing.T) {
+		_, ok, err := Validate(rfcSecret, "005924", now, step)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		_, ok, err := Validate(rfcSecret, "123456", now, 0)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	_, err = Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	u, err := url.Parse(ProvisioningURI("Sourcegraph", "alice", rfcSecret))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Sourcegraph:alice", u.Path)
+	assert.Equal(t, rfcSecret, u.Query().Get("secret"))
+	assert.Equal(t, "Sourcegraph", u.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+	assert.Regexp(t, `^[0-9a-f]{5}-[0-9a-f]{5}-[0-9a-f]{5}-[0-9a-f]{5}$`, codes[0])
+
+	hashes := HashRecoveryCodes(codes)
+	assert.Equal(t, HashRecoveryCode(" "+codes[0]+"\n"), hashes[0])
+	assert.NotEqual(t, hashes[0], hashes[1])
+}
@@ -41,6 +41,7 @@ type credentials struct {
 	Email           string `json:"email"`
 	Username        string `json:"username"`
 	Password        string `json:"password"`
+	TwoFactorCode   string `json:"twoFactorCode"`
 	AnonymousUserID string `json:"anonymousUserId"`
 	FirstSourceURL  string `json:"firstSourceUrl"`
 	LastSourceURL   string `json:"lastSourceUrl"`
@@ -397,6 +398,12 @@ func HandleSignIn(logger log.Logger, db database.DB, store LockoutStore, recorde
 			return
 		}
 
+		// 🚨 SECURITY: check the second factor
+		recoveryCodes, proceed := checkTwoFactor(ctx, logger, db, w, r, &user, creds.TwoFactorCode, &signInResult)
+		if !proceed {
+			return
+		}
+
 		// Write the session cookie
 		ctx, err = session.SetActorFromUser(ctx, w, r, &user, 0)
 		if err != nil {
@@ -407,7 +414,85 @@ func HandleSignIn(logger log.Logger, db database.DB, store LockoutStore, recorde
 		// Update the events we record
 		signInResult = database.SecurityEventNameSignInSucceeded
 		telemetrySignInResult = telemetry.ActionSucceeded
+
+		if len(recoveryCodes) > 0 {
+			writeJSON(w, http.StatusOK, twoFactorEnrolled{RecoveryCodes: recoveryCodes})
+		}
+	}
+}
+
+// checkTwoFactor checks the second factor of a sign-in attempt with a valid
+// password. Users with two-factor authentication enabled must provide a valid
+// code, users that are required to use two-factor authentication but have not
+// enrolled yet must enroll an authenticator app first.
+//
+// If the sign-in must not proceed, a response is written and proceed is false.
+// If the sign-in completed an enrollment, the new recovery codes of the user
+// are returned.
+//
+// 🚨 SECURITY: Any change to this function could allow bypassing two-factor
+// authentication. Be careful.
+func checkTwoFactor(
+	ctx context.Context,
+	logger log.Logger,
+	db database.DB,
+	w http.ResponseWriter,
+	r *http.Request,
+	user *types.User,
+	code string,
+	signInResult *database.SecurityEventName,
+) (recoveryCodes []string, proceed bool) {
+	cred, err := TOTPCredentials(db).GetByUserID(ctx, user.ID)
+	if err != nil && !errcode.IsNotFound(err) {
+		httpLogError(logger.Error, w, "Error checking two-factor authentication", http.StatusInternalServerError, log.Error(err))
+		return nil, false
+	}
+
+	switch {
+	case cred != nil && cred.Enabled():
+		if code == "" {
+			// Not a failed attempt, the client has yet to ask for the code.
+			*signInResult = database.SecurityEventNameSignInTwoFactorChallenged
+			writeJSON(w, http.StatusUnauthorized, twoFactorChallenge{TwoFactor: "required"})
+			return nil, false
+		}
+
+		ok, err := VerifyTwoFactorCode(ctx, db, r, cred, code)
+		if err != nil {
+			httpLogError(logger.Error, w, "Error checking two-factor authentication", http.StatusInternalServerError, log.Error(err))
+			return nil, false
+		}
+		if !ok {
+			httpLogError(logger.Warn, w, "Authentication failed", http.StatusUnauthorized)
+			return nil, false
+		}
+		return nil, true
+
+	case TwoFactorRequired(user):
+		if code == "" || cred == nil {
+			enrollment, err := StartTwoFactorEnrollment(ctx, db, user)
+			if err != nil {
+				httpLogError(logger.Error, w, "Error starting two-factor authentication enrollment", http.StatusInternalServerError, log.Error(err))
+				return nil, false
+			}
+			*signInResult = database.SecurityEventNameSignInTwoFactorChallenged
+			writeJSON(w, http.StatusUnauthorized, twoFactorChallenge{TwoFactor: "enrollmentRequired", TwoFactorEnrollment: enrollment})
+			return nil, false
+		}
+
+		recoveryCodes, err := ConfirmTwoFactorEnrollment(ctx, db, r, user.ID, code)
+		if err != nil {
+			if errors.Is(err, ErrInvalidTwoFactorCode) {
+				httpLogError(logger.Warn, w, "Authentication failed", http.StatusUnauthorized)
+			} else {
+				httpLogError(logger.Error, w, "Error confirming two-factor authentication enrollment", http.StatusInternalServerError, log.Error(err))
+			}
+			return nil, false
+		}
+		return recoveryCodes, true
 	}
+
+	return nil, true
 }
 
 func HandleUnlockAccount(logger log.Logger, _ database.DB, store LockoutStore) http.HandlerFunc {
@@ -0,0 +1,185 @@
+package userpasswd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth/totp"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrInvalidTwoFactorCode is returned when a two-factor authentication code is
+// wrong, expired or was used before.
+var ErrInvalidTwoFactorCode = errors.New("invalid two-factor authentication code")
+
+// timeNow is the time used to validate two-factor authentication codes. It is
+// replaced in tests.
+var timeNow = time.Now
+
+// TwoFactorRequired reports whether the builtin auth provider requires the
+// given user to use two-factor authentication.
+func TwoFactorRequired(user *types.User) bool {
+	pc, _ := GetProviderConfig()
+	if pc == nil {
+		return false
+	}
+	switch pc.RequireTwoFactor {
+	case "allUsers":
+		return true
+	case "siteAdmins":
+		return user.SiteAdmin
+	default:
+		return false
+	}
+}
+
+// TOTPCredentials returns the store of TOTP credentials, which are encrypted
+// with the same key as the authentication data of external accounts.
+func TOTPCredentials(db database.DB) database.UserTOTPCredentialStore {
+	return db.UserTOTPCredentials(keyring.Default().UserExternalAccountKey)
+}
+
+// TwoFactorEnrollment is a pending TOTP enrollment, which the user adds to an
+// authenticator app by scanning ProvisioningURI as a QR code or by entering
+// Secret manually.
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningURI"`
+}
+
+// StartTwoFactorEnrollment creates a new pending TOTP credential for the given
+// user, replacing a previous pending one. The enrollment takes effect once it
+// is confirmed with ConfirmTwoFactorEnrollment.
+func StartTwoFactorEnrollment(ctx context.Context, db database.DB, user *types.User) (*TwoFactorEnrollment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := TOTPCredentials(db).CreatePending(ctx, user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(twoFactorIssuer(), user.Username, secret),
+	}, nil
+}
+
+// twoFactorIssuer returns the issuer that authenticator apps display next to
+// the codes of this instance.
+func twoFactorIssuer() string {
+	if u, err := url.Parse(conf.ExternalURL()); err == nil && u.Host != "" {
+		return "Sourcegraph (" + u.Host + ")"
+	}
+	return "Sourcegraph"
+}
+
+// ConfirmTwoFactorEnrollment enables the pending TOTP credential of the given
+// user if code is valid for it. It returns the new recovery codes of the user,
+// which are not stored in plain text and can only be shown once.
+func ConfirmTwoFactorEnrollment(ctx context.Context, db database.DB, r *http.Request, userID int32, code string) ([]string, error) {
+	store := TOTPCredentials(db)
+	cred, err := store.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cred.Enabled() {
+		return nil, database.ErrUserTOTPAlreadyEnabled
+	}
+
+	secret, err := cred.Secret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	step, ok, err := totp.Validate(secret, code, timeNow(), cred.LastUsedStep)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Enable(ctx, userID, step, totp.HashRecoveryCodes(recoveryCodes)); err != nil {
+		return nil, err
+	}
+
+	database.LogPasswordEvent(ctx, db, r, database.SecurityEventNameTwoFactorEnabled, userID)
+	return recoveryCodes, nil
+}
+
+// VerifyTwoFactorCode checks the given code against the enabled TOTP credential
+// of a user. The code may either be a TOTP code or one of the user's recovery
+// codes. Valid codes are consumed and cannot be used again.
+func VerifyTwoFactorCode(ctx context.Context, db database.DB, r *http.Request, cred *database.UserTOTPCredential, code string) (bool, error) {
+	if !cred.Enabled() {
+		return false, nil
+	}
+
+	secret, err := cred.Secret(ctx)
+	if err != nil {
+		return false, err
+	}
+	step, ok, err := totp.Validate(secret, code, timeNow(), cred.LastUsedStep)
+	if err != nil {
+		return false, err
+	}
+	store := TOTPCredentials(db)
+	if ok {
+		// 🚨 SECURITY: Recording the step fails if the code (or a later one)
+		// was used concurrently, which prevents replaying codes.
+		return store.RecordStep(ctx, cred.UserID, step)
+	}
+
+	used, err := store.UseRecoveryCode(ctx, cred.UserID, totp.HashRecoveryCode(code))
+	if err != nil || !used {
+		return false, err
+	}
+	database.LogPasswordEvent(ctx, db, r, database.SecurityEventNameTwoFactorRecoveryCodeUsed, cred.UserID)
+	return true, nil
+}
+
+// RegenerateTwoFactorRecoveryCodes replaces the recovery codes of the enabled
+// TOTP credential of the given user and returns the new ones.
+func RegenerateTwoFactorRecoveryCodes(ctx context.Context, db database.DB, userID int32) ([]string, error) {
+	recoveryCodes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := TOTPCredentials(db).SetRecoveryCodes(ctx, userID, totp.HashRecoveryCodes(recoveryCodes)); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// twoFactorChallenge is the response to a sign-in attempt with a valid
+// password that lacks a (valid) second factor.
+type twoFactorChallenge struct {
+	// TwoFactor is "required" if the user has to provide a code of their
+	// authenticator app or a recovery code, and "enrollmentRequired" if the
+	// user has to enroll an authenticator app first.
+	TwoFactor string `json:"twoFactor"`
+	*TwoFactorEnrollment
+}
+
+// twoFactorEnrolled is the response to a successful sign-in that enabled
+// two-factor authentication for the user.
+type twoFactorEnrolled struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,183 @@
+package userpasswd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth/totp"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/session"
+	"github.com/sourcegraph/sourcegraph/internal/telemetry"
+	"github.com/sourcegraph/sourcegraph/internal/telemetry/telemetrytest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestTwoFactorRequired(t *testing.T) {
+	for _, tc := range []struct {
+		policy    string
+		siteAdmin bool
+		want      bool
+	}{
+		{policy: "", siteAdmin: true, want: false},
+		{policy: "none", siteAdmin: true, want: false},
+		{policy: "siteAdmins", siteAdmin: false, want: false},
+		{policy: "siteAdmins", siteAdmin: true, want: true},
+		{policy: "allUsers", siteAdmin: false, want: true},
+	} {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{Type: providerType, RequireTwoFactor: tc.policy}}},
+		}})
+		assert.Equal(t, tc.want, TwoFactorRequired(&types.User{SiteAdmin: tc.siteAdmin}), "policy %q, site admin %v", tc.policy, tc.siteAdmin)
+	}
+	conf.Mock(nil)
+}
+
+func TestHandleSignIn_TwoFactor(t *testing.T) {
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	now := time.Unix(1234567890, 0)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+	validCode, err := totp.Code(secret, totp.Step(now))
+	require.NoError(t, err)
+
+	setup := func(t *testing.T, policy string, cred *database.UserTOTPCredential) (http.HandlerFunc, *dbmocks.MockUserTOTPCredentialStore, *MockLockoutStore) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{Type: providerType, RequireTwoFactor: policy}}},
+		}})
+		t.Cleanup(func() { conf.Mock(nil) })
+		t.Cleanup(session.ResetMockSessionStore(t))
+
+		users := dbmocks.NewMockUserStore()
+		users.GetByUsernameFunc.SetDefaultReturn(&types.User{ID: 1, Username: "alice", SiteAdmin: true}, nil)
+		users.IsPasswordFunc.SetDefaultReturn(true, nil)
+
+		creds := dbmocks.NewMockUserTOTPCredentialStore()
+		if cred != nil {
+			creds.GetByUserIDFunc.SetDefaultReturn(cred, nil)
+		} else {
+			creds.GetByUserIDFunc.SetDefaultReturn(nil, database.UserTOTPCredentialNotFoundErr{})
+		}
+		creds.RecordStepFunc.SetDefaultReturn(true, nil)
+
+		gss := dbmocks.NewMockGlobalStateStore()
+		gss.GetFunc.SetDefaultReturn(database.GlobalState{SiteID: "a"}, nil)
+
+		db := dbmocks.NewMockDB()
+		db.GlobalStateFunc.SetDefaultReturn(gss)
+		db.UsersFunc.SetDefaultReturn(users)
+		db.UserTOTPCredentialsFunc.SetDefaultReturn(creds)
+		db.EventLogsFunc.SetDefaultReturn(dbmocks.NewMockEventLogStore())
+		db.SecurityEventLogsFunc.SetDefaultReturn(dbmocks.NewMockSecurityEventLogsStore())
+
+		lockout := NewMockLockoutStore()
+		return HandleSignIn(logtest.NoOp(t), db, lockout, telemetry.NewEventRecorder(telemetrytest.NewMockEventsStore())), creds, lockout
+	}
+
+	signIn := func(h http.HandlerFunc, code string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"email": "alice", "password": "pw", "twoFactorCode": code})
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+		h(resp, req)
+		return resp
+	}
+
+	enabled := database.NewMockUserTOTPCredential(&database.UserTOTPCredential{UserID: 1, EnabledAt: pointers.Ptr(now)}, secret)
+
+	t.Run("not enrolled and not required", func(t *testing.T) {
+		h, _, lockout := setup(t, "none", nil)
+		resp := signIn(h, "")
+		assert.Equal(t, http.StatusOK, resp.Code)
+		mockrequire.CalledOnce(t, lockout.ResetFunc)
+	})
+
+	t.Run("code missing", func(t *testing.T) {
+		h, _, lockout := setup(t, "none", enabled)
+		resp := signIn(h, "")
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.JSONEq(t, `{"twoFactor": "required"}`, resp.Body.String())
+		// Asking for the code does not count as a failed attempt.
+		mockrequire.NotCalled(t, lockout.IncreaseFailedAttemptFunc)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		h, _, lockout := setup(t, "none", enabled)
+		resp := signIn(h, "000000")
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		mockrequire.CalledOnce(t, lockout.IncreaseFailedAttemptFunc)
+	})
+
+	t.Run("valid code", func(t *testing.T) {
+		h, creds, _ := setup(t, "none", enabled)
+		resp := signIn(h, validCode)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		mockrequire.CalledOnceWith(t, creds.RecordStepFunc, mockrequire.Values(mockrequire.Skip, int32(1), totp.Step(now)))
+	})
+
+	t.Run("replayed code", func(t *testing.T) {
+		h, creds, _ := setup(t, "none", enabled)
+		creds.RecordStepFunc.SetDefaultReturn(false, nil)
+		resp := signIn(h, validCode)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("recovery code", func(t *testing.T) {
+		h, creds, _ := setup(t, "none", enabled)
+		creds.UseRecoveryCodeFunc.SetDefaultReturn(true, nil)
+		resp := signIn(h, "abcde-12345")
+		assert.Equal(t, http.StatusOK, resp.Code)
+		mockrequire.CalledOnceWith(t, creds.UseRecoveryCodeFunc, mockrequire.Values(mockrequire.Skip, int32(1), totp.HashRecoveryCode("abcde-12345")))
+	})
+
+	t.Run("enrollment required", func(t *testing.T) {
+		h, creds, _ := setup(t, "siteAdmins", nil)
+		creds.CreatePendingFunc.SetDefaultHook(func(_ context.Context, userID int32, secret string) (*database.UserTOTPCredential, error) {
+			return database.NewMockUserTOTPCredential(&database.UserTOTPCredential{UserID: userID}, secret), nil
+		})
+
+		resp := signIn(h, "")
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		var challenge twoFactorChallenge
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &challenge))
+		assert.Equal(t, "enrollmentRequired", challenge.TwoFactor)
+		require.NotNil(t, challenge.TwoFactorEnrollment)
+		assert.NotEmpty(t, challenge.Secret)
+		assert.Contains(t, challenge.ProvisioningURI, "otpauth://totp/")
+		mockrequire.CalledOnce(t, creds.CreatePendingFunc)
+	})
+
+	t.Run("enrollment confirmed", func(t *testing.T) {
+		pending := database.NewMockUserTOTPCredential(&database.UserTOTPCredential{UserID: 1}, secret)
+		h, creds, _ := setup(t, "siteAdmins", pending)
+
+		resp := signIn(h, validCode)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var enrolled twoFactorEnrolled
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &enrolled))
+		assert.Len(t, enrolled.RecoveryCodes, totp.RecoveryCodeCount)
+		mockrequire.CalledOnceWith(t, creds.EnableFunc, mockrequire.Values(mockrequire.Skip, int32(1), totp.Step(now), totp.HashRecoveryCodes(enrolled.RecoveryCodes)))
+	})
+
+	t.Run("enrollment with wrong code", func(t *testing.T) {
+		pending := database.NewMockUserTOTPCredential(&database.UserTOTPCredential{UserID: 1}, secret)
+		h, creds, _ := setup(t, "siteAdmins", pending)
+
+		resp := signIn(h, "000000")
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		mockrequire.NotCalled(t, creds.EnableFunc)
+	})
+}
@@ -31,11 +31,34 @@ func NewAuthzProviders(db database.DB, conns []*types.AzureDevOpsConnection, htt
 
 	// Iterate over all Azure Dev Ops code host connections to make sure we sync permissions for all
 	// orgs and projects in every permissions sync iteration.
+	initResults := &authztypes.ProviderInitResult{}
+
+	// Azure DevOps Server connections each get their own provider, since every
+	// server is a distinct code host.
+	serverProviders := map[string]struct{}{}
+
 	for _, c := range conns {
 		if !c.EnforcePermissions {
 			continue
 		}
 
+		if isServerConnection(c) {
+			p, err := newServerAuthzProvider(c, httpClient)
+			if err != nil {
+				initResults.InvalidConnections = append(initResults.InvalidConnections, extsvc.TypeAzureDevOps)
+				initResults.Problems = append(initResults.Problems, err.Error())
+				continue
+			}
+			if _, ok := serverProviders[p.ServiceID()]; ok {
+				// Another connection to the same server already provides
+				// permissions for it.
+				continue
+			}
+			serverProviders[p.ServiceID()] = struct{}{}
+			initResults.Providers = append(initResults.Providers, p)
+			continue
+		}
+
 		// The list of orgs and projects may have duplicates if there are multiple Azure DevOps code
 		// host connections that have the same project in their config.
 		//
@@ -53,7 +76,6 @@ func NewAuthzProviders(db database.DB, conns []*types.AzureDevOpsConnection, htt
 		authorizedConnections = append(authorizedConnections, c)
 	}
 
-	initResults := &authztypes.ProviderInitResult{}
 	if len(authorizedConnections) == 0 {
 		return initResults
 	}
@@ -69,6 +91,12 @@ func NewAuthzProviders(db database.DB, conns []*types.AzureDevOpsConnection, htt
 	return initResults
 }
 
+// isServerConnection returns true if the connection is to an Azure DevOps
+// Server instance rather than Azure DevOps Services.
+func isServerConnection(c *types.AzureDevOpsConnection) bool {
+	return c.Url != "" && !azuredevops.IsAzureDevOpsServicesURL(c.Url)
+}
+
 func newAuthzProvider(db database.DB, conns []*types.AzureDevOpsConnection, orgs, projects map[string]struct{}, httpClient *http.Client) (*Provider, error) {
 	if err := licensing.Check(licensing.FeatureACLs); err != nil {
 		return nil, err
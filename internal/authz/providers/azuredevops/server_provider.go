@@ -0,0 +1,235 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ServerProvider is the authz provider for Azure DevOps Server.
+//
+// Azure DevOps Server has no OAuth support, so unlike Provider it can't list
+// the repositories a user has access to with their own credentials. Instead,
+// it uses the credentials of the code host connection to list the members of
+// the teams of the project a repository belongs to: on Azure DevOps Server,
+// access to repositories is granted at the project level.
+//
+// Sourcegraph users are matched to Azure DevOps Server identities by their
+// verified email addresses only: usernames are chosen by the users themselves
+// and would let anyone claim the identity of another person.
+type ServerProvider struct {
+	urn      string
+	codeHost *extsvc.CodeHost
+	conn     *types.AzureDevOpsConnection
+	client   azuredevops.Client
+}
+
+var _ authz.Provider = &ServerProvider{}
+
+func newServerAuthzProvider(conn *types.AzureDevOpsConnection, httpClient *http.Client) (*ServerProvider, error) {
+	if err := licensing.Check(licensing.FeatureACLs); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(conn.Url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse url: %q", conn.Url)
+	}
+	codeHost := extsvc.NewCodeHost(u, extsvc.TypeAzureDevOps)
+
+	client, err := azuredevops.NewClient(conn.URN, conn.Url, azuredevops.AuthenticatorFromConfig(conn.AzureDevOpsConnection), httpClient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create client for %q", conn.URN)
+	}
+
+	return &ServerProvider{
+		urn:      conn.URN,
+		codeHost: codeHost,
+		conn:     conn,
+		client:   client,
+	}, nil
+}
+
+// collections returns the collections configured in the code host connection.
+func (p *ServerProvider) collections() []string {
+	seen := map[string]struct{}{}
+	var collections []string
+	add := func(name string) {
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		collections = append(collections, name)
+	}
+	for _, org := range p.conn.Orgs {
+		add(org)
+	}
+	for _, project := range p.conn.Projects {
+		org, _, _ := strings.Cut(project, "/")
+		add(org)
+	}
+	return collections
+}
+
+// FetchAccount looks up the Azure DevOps Server identity of the user by their
+// verified email addresses.
+func (p *ServerProvider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, verifiedEmails []string) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	searches := make([]azuredevops.ListIdentitiesArgs, 0, len(verifiedEmails))
+	for _, email := range verifiedEmails {
+		searches = append(searches, azuredevops.ListIdentitiesArgs{
+			SearchFilter: azuredevops.IdentitySearchFilterMailAddress,
+			FilterValue:  email,
+		})
+	}
+
+	// Identities are shared by all collections of a server, so it is enough
+	// to find the user in any of them.
+	for _, collection := range p.collections() {
+		for _, search := range searches {
+			identities, err := p.client.ListIdentities(ctx, collection, search)
+			if err != nil {
+				return nil, errors.Wrapf(err, "searching identities of collection %q", collection)
+			}
+			for _, identity := range identities {
+				if !identity.IsActive || identity.ID == "" {
+					continue
+				}
+				return p.newAccount(user, identity)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (p *ServerProvider) newAccount(user *types.User, identity azuredevops.Identity) (*extsvc.Account, error) {
+	accountData, err := json.Marshal(azuredevops.IdentityRef{
+		ID:          identity.ID,
+		DisplayName: identity.ProviderDisplayName,
+		UniqueName:  identity.Properties.Account.Value,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   identity.ID,
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms is not implemented for Azure DevOps Server, permissions are
+// synced per repository with FetchRepoPerms.
+func (p *ServerProvider) FetchUserPerms(_ context.Context, _ *extsvc.Account, _ authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	return nil, authz.ErrUnimplemented{Feature: "azuredevops.ServerProvider.FetchUserPerms"}
+}
+
+// FetchRepoPerms returns the identity IDs of the members of all teams of the
+// project the repository belongs to.
+func (p *ServerProvider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, _ authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	if repo == nil {
+		return nil, errors.New("no repository provided")
+	} else if !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec) {
+		return nil, errors.Errorf("not a code host of the repository: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	collection, project, err := p.collectionAndProject(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.Scoped("azuredevops.ServerProvider.FetchRepoPerms").With(
+		log.String("collection", collection),
+		log.String("project", project),
+	)
+
+	teams, err := p.client.ListTeams(ctx, collection, project)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing teams of project %q", project)
+	}
+
+	seen := map[string]struct{}{}
+	var accountIDs []extsvc.AccountID
+	for _, team := range teams {
+		members, err := p.client.ListTeamMembers(ctx, collection, project, team.ID)
+		if err != nil {
+			return accountIDs, errors.Wrapf(err, "listing members of team %q", team.Name)
+		}
+		for _, member := range members {
+			if _, ok := seen[member.Identity.ID]; ok || member.Identity.ID == "" {
+				continue
+			}
+			seen[member.Identity.ID] = struct{}{}
+			accountIDs = append(accountIDs, extsvc.AccountID(member.Identity.ID))
+		}
+	}
+
+	logger.Debug("fetched repo permissions", log.Int("teams", len(teams)), log.Int("accounts", len(accountIDs)))
+	return accountIDs, nil
+}
+
+// collectionAndProject returns the collection and project of the repository
+// from its URI, which has the form <host>/<server path>/<collection>/<project>/<repo>.
+func (p *ServerProvider) collectionAndProject(repo *extsvc.Repository) (collection, project string, err error) {
+	prefix := strings.TrimSuffix(p.codeHost.BaseURL.Host+p.codeHost.BaseURL.Path, "/") + "/"
+
+	rest, ok := strings.CutPrefix(repo.URI, prefix)
+	if !ok {
+		return "", "", errors.Errorf("repository URI %q is not on code host %q", repo.URI, p.codeHost.ServiceID)
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 {
+		return "", "", errors.Errorf("unexpected repository URI %q, expected <collection>/<project>/<repo>", repo.URI)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (p *ServerProvider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+func (p *ServerProvider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *ServerProvider) URN() string {
+	return p.urn
+}
+
+func (p *ServerProvider) ValidateConnection(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	collections := p.collections()
+	if len(collections) == 0 {
+		return errors.New("ValidateConnection failed for Azure DevOps Server: no collections or projects configured")
+	}
+	if _, err := p.client.ListProjects(ctx, collections[0]); err != nil {
+		return errors.Wrap(err, "ValidateConnection failed for Azure DevOps Server")
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestServerProvider_NewAuthzProviders(t *testing.T) {
+	licensing.MockCheckFeature = allowLicensingCheck
+	t.Cleanup(func() { licensing.MockCheckFeature = nil })
+
+	result := NewAuthzProviders(dbmocks.NewMockDB(), []*types.AzureDevOpsConnection{
+		{
+			URN: "1",
+			AzureDevOpsConnection: &schema.AzureDevOpsConnection{
+				Url:                "https://dev.azure.com",
+				EnforcePermissions: true,
+			},
+		},
+		{
+			URN: "2",
+			AzureDevOpsConnection: &schema.AzureDevOpsConnection{
+				Url:                "https://ado.example.com/tfs",
+				EnforcePermissions: true,
+				Orgs:               []string{"DefaultCollection"},
+			},
+		},
+		{
+			URN: "3",
+			AzureDevOpsConnection: &schema.AzureDevOpsConnection{
+				Url:                "https://ado.example.com/tfs/",
+				EnforcePermissions: true,
+				Projects:           []string{"DefaultCollection/project"},
+			},
+		},
+	}, httpcli.TestExternalClient)
+
+	assert.Empty(t, result.Problems)
+	require.Len(t, result.Providers, 2)
+
+	server, ok := result.Providers[0].(*ServerProvider)
+	require.True(t, ok, "want *ServerProvider, got %T", result.Providers[0])
+	assert.Equal(t, "2", server.URN())
+	assert.Equal(t, "https://ado.example.com/tfs/", server.ServiceID())
+
+	_, ok = result.Providers[1].(*Provider)
+	require.True(t, ok, "want *Provider, got %T", result.Providers[1])
+}
+
+func TestServerProvider_FetchRepoPerms(t *testing.T) {
+	ratelimit.SetupForTest(t)
+	licensing.MockCheckFeature = allowLicensingCheck
+	t.Cleanup(func() { licensing.MockCheckFeature = nil })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tfs/DefaultCollection/_apis/projects/My Project/teams", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"value": []map[string]string{
+			{"id": "team-1", "name": "Team 1"},
+			{"id": "team-2", "name": "Team 2"},
+		}})
+	})
+	mux.HandleFunc("/tfs/DefaultCollection/_apis/projects/My Project/teams/team-1/members", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"value": []map[string]any{
+			{"identity": map[string]string{"id": "alice"}},
+			{"identity": map[string]string{"id": "bob"}},
+		}})
+	})
+	mux.HandleFunc("/tfs/DefaultCollection/_apis/projects/My Project/teams/team-2/members", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"value": []map[string]any{
+			{"identity": map[string]string{"id": "bob"}},
+			{"identity": map[string]string{"id": "carol"}},
+		}})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	p, err := newServerAuthzProvider(&types.AzureDevOpsConnection{
+		URN: "extsvc:azuredevops:1",
+		AzureDevOpsConnection: &schema.AzureDevOpsConnection{
+			Url:  srv.URL + "/tfs",
+			Orgs: []string{"DefaultCollection"},
+		},
+	}, httpcli.TestExternalClient)
+	require.NoError(t, err)
+
+	repo := &extsvc.Repository{
+		URI: p.codeHost.BaseURL.Host + "/tfs/DefaultCollection/My Project/repo",
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          "repo-id",
+			ServiceType: p.ServiceType(),
+			ServiceID:   p.ServiceID(),
+		},
+	}
+
+	accountIDs, err := p.FetchRepoPerms(context.Background(), repo, authz.FetchPermsOptions{})
+	require.NoError(t, err)
+	if diff := cmp.Diff([]extsvc.AccountID{"alice", "bob", "carol"}, accountIDs); diff != "" {
+		t.Errorf("mismatched account IDs (-want, +got)\n%s", diff)
+	}
+
+	repo.URI = p.codeHost.BaseURL.Host + "/tfs/DefaultCollection/repo"
+	_, err = p.FetchRepoPerms(context.Background(), repo, authz.FetchPermsOptions{})
+	assert.Error(t, err)
+}
+
+func TestServerProvider_FetchAccount(t *testing.T) {
+	ratelimit.SetupForTest(t)
+	licensing.MockCheckFeature = allowLicensingCheck
+	t.Cleanup(func() { licensing.MockCheckFeature = nil })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/DefaultCollection/_apis/identities" || q.Get("searchFilter") != "MailAddress" || q.Get("filterValue") != "alice@example.com" {
+			writeJSON(t, w, map[string]any{"value": []any{}})
+			return
+		}
+		writeJSON(t, w, map[string]any{"value": []map[string]any{
+			{
+				"id":                  "alice-id",
+				"providerDisplayName": "Alice",
+				"isActive":            true,
+				"properties": map[string]any{
+					"Account": map[string]string{"$value": `CORP\alice`},
+				},
+			},
+		}})
+	}))
+	t.Cleanup(srv.Close)
+
+	p, err := newServerAuthzProvider(&types.AzureDevOpsConnection{
+		URN: "extsvc:azuredevops:1",
+		AzureDevOpsConnection: &schema.AzureDevOpsConnection{
+			Url:      srv.URL,
+			Projects: []string{"DefaultCollection/project"},
+		},
+	}, httpcli.TestExternalClient)
+	require.NoError(t, err)
+
+	account, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "alice"}, nil, []string{"alice@example.com"})
+	require.NoError(t, err)
+	require.NotNil(t, account)
+	assert.Equal(t, int32(42), account.UserID)
+	assert.Equal(t, "alice-id", account.AccountID)
+	assert.Equal(t, p.ServiceID(), account.ServiceID)
+
+	account, err = p.FetchAccount(context.Background(), &types.User{ID: 43, Username: "bob"}, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, account)
+
+	// The username isn't verified and must not be matched with an account name.
+	account, err = p.FetchAccount(context.Background(), &types.User{ID: 44, Username: "alice"}, nil, []string{"mallory@example.com"})
+	require.NoError(t, err)
+	assert.Nil(t, account)
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
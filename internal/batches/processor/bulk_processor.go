@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
@@ -174,6 +175,16 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return nil, errors.Wrap(err, "loading remote repo")
 	}
 
+	checks, err := b.tx.ListChangesetExternalChecks(ctx, store.ListChangesetExternalChecksOpts{ChangesetIDs: []int64{b.ch.ID}})
+	if err != nil {
+		return nil, errors.Wrap(err, "loading external checks")
+	}
+	// External checks are treated like required checks on the code host: the
+	// changeset must not be merged until all of them passed.
+	if err := checkExternalChecksPassed(b.ch, checks); err != nil {
+		return nil, errcode.MakeNonRetryable(err)
+	}
+
 	cs := &sources.Changeset{
 		Changeset:  b.ch,
 		TargetRepo: b.repo,
@@ -188,7 +199,7 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		b.logger.Error("Events", log.Error(err))
 		return nil, errcode.MakeNonRetryable(err)
 	}
-	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient("batches.bulkprocessor.mergechangeset"), cs.Changeset, events)
+	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient("batches.bulkprocessor.mergechangeset"), cs.Changeset, events, checks)
 
 	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
 		b.logger.Error("UpsertChangesetEvents", log.Error(err))
@@ -224,7 +235,12 @@ func (b *bulkProcessor) closeChangeset(ctx context.Context) (afterDone func(*sto
 		b.logger.Error("Events", log.Error(err))
 		return nil, errcode.MakeNonRetryable(err)
 	}
-	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient("batches.bulkprocessor.closechangeset"), cs.Changeset, events)
+	checks, err := b.tx.ListChangesetExternalChecks(ctx, store.ListChangesetExternalChecksOpts{ChangesetIDs: []int64{cs.Changeset.ID}})
+	if err != nil {
+		b.logger.Error("ListChangesetExternalChecks", log.Error(err))
+		return nil, errcode.MakeNonRetryable(err)
+	}
+	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient("batches.bulkprocessor.closechangeset"), cs.Changeset, events, checks)
 
 	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
 		b.logger.Error("UpsertChangesetEvents", log.Error(err))
@@ -292,3 +308,23 @@ func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.Change
 func (b *bulkProcessor) enqueueWebhook(ctx context.Context, store *store.Store, eventType string) {
 	webhooks.EnqueueChangeset(ctx, b.logger, store, eventType, bgql.MarshalChangesetID(b.ch.ID))
 }
+
+// checkExternalChecksPassed returns an error listing the contexts of all
+// external checks that didn't pass yet for the head commit of the changeset.
+// A check whose latest status was reported for another commit is pending until
+// the external CI system reports it for the head commit.
+func checkExternalChecksPassed(ch *btypes.Changeset, checks []*btypes.ChangesetExternalCheck) error {
+	// If the head commit cannot be determined, all statuses apply.
+	head, _ := ch.HeadRefOid()
+
+	var pending []string
+	for _, check := range checks {
+		if !check.AppliesTo(head) || check.State != btypes.ChangesetCheckStatePassed {
+			pending = append(pending, check.Context)
+		}
+	}
+	if len(pending) > 0 {
+		return errors.Errorf("external checks have not passed: %s", strings.Join(pending, ", "))
+	}
+	return nil
+}
@@ -484,3 +484,57 @@ func TestBulkProcessor(t *testing.T) {
 		})
 	})
 }
+
+func TestCheckExternalChecksPassed(t *testing.T) {
+	check := func(context string, state btypes.ChangesetCheckState, commit string) *btypes.ChangesetExternalCheck {
+		return &btypes.ChangesetExternalCheck{Context: context, State: state, CommitOID: commit}
+	}
+	ch := &btypes.Changeset{Metadata: &github.PullRequest{HeadRefOid: "deadbeef"}}
+
+	tests := []struct {
+		name    string
+		checks  []*btypes.ChangesetExternalCheck
+		wantErr string
+	}{
+		{
+			name: "no external checks",
+		},
+		{
+			name: "passed",
+			checks: []*btypes.ChangesetExternalCheck{
+				check("ci/build", btypes.ChangesetCheckStatePassed, "deadbeef"),
+				check("ci/test", btypes.ChangesetCheckStatePassed, ""),
+			},
+		},
+		{
+			name: "failed",
+			checks: []*btypes.ChangesetExternalCheck{
+				check("ci/build", btypes.ChangesetCheckStatePassed, "deadbeef"),
+				check("ci/test", btypes.ChangesetCheckStateFailed, "deadbeef"),
+			},
+			wantErr: "external checks have not passed: ci/test",
+		},
+		{
+			// The check passed for a previous push, but hasn't reported for
+			// the head commit yet.
+			name:    "stale",
+			checks:  []*btypes.ChangesetExternalCheck{check("ci/build", btypes.ChangesetCheckStatePassed, "cafebabe")},
+			wantErr: "external checks have not passed: ci/build",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkExternalChecksPassed(ch, tc.checks)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("wrong error. want=%q, have=%v", tc.wantErr, err)
+			}
+		})
+	}
+}
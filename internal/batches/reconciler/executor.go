@@ -144,7 +144,12 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (afterDone func(store *s
 		log15.Error("Events", "err", err)
 		return afterDone, errcode.MakeNonRetryable(err)
 	}
-	state.SetDerivedState(ctx, e.tx.Repos(), e.client, e.ch, events)
+	checks, err := e.tx.ListChangesetExternalChecks(ctx, store.ListChangesetExternalChecksOpts{ChangesetIDs: []int64{e.ch.ID}})
+	if err != nil {
+		log15.Error("ListChangesetExternalChecks", "err", err)
+		return afterDone, errcode.MakeNonRetryable(err)
+	}
+	state.SetDerivedState(ctx, e.tx.Repos(), e.client, e.ch, events, checks)
 
 	if err := e.tx.UpsertChangesetEvents(ctx, events...); err != nil {
 		log15.Error("UpsertChangesetEvents", "err", err)
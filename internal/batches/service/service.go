@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -19,17 +20,20 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/batches/global"
 	bgql "github.com/sourcegraph/sourcegraph/internal/batches/graphql"
 	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/batches/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	extsvcauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	searchquery "github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/template"
@@ -74,6 +78,7 @@ type Service struct {
 type operations struct {
 	createBatchSpec                      *observation.Operation
 	createBatchSpecFromRaw               *observation.Operation
+	validateBatchSpec                    *observation.Operation
 	executeBatchSpec                     *observation.Operation
 	cancelBatchSpec                      *observation.Operation
 	replaceBatchSpecInput                *observation.Operation
@@ -88,6 +93,7 @@ type operations struct {
 	deleteBatchChange                    *observation.Operation
 	enqueueChangesetSync                 *observation.Operation
 	reenqueueChangeset                   *observation.Operation
+	setChangesetExternalCheck            *observation.Operation
 	checkNamespaceAccess                 *observation.Operation
 	fetchUsernameForBitbucketServerToken *observation.Operation
 	validateAuthenticator                *observation.Operation
@@ -124,6 +130,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		singletonOperations = &operations{
 			createBatchSpec:                      op("CreateBatchSpec"),
 			createBatchSpecFromRaw:               op("CreateBatchSpecFromRaw"),
+			validateBatchSpec:                    op("ValidateBatchSpec"),
 			executeBatchSpec:                     op("ExecuteBatchSpec"),
 			cancelBatchSpec:                      op("CancelBatchSpec"),
 			replaceBatchSpecInput:                op("ReplaceBatchSpecInput"),
@@ -138,6 +145,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			deleteBatchChange:                    op("DeleteBatchChange"),
 			enqueueChangesetSync:                 op("EnqueueChangesetSync"),
 			reenqueueChangeset:                   op("ReenqueueChangeset"),
+			setChangesetExternalCheck:            op("SetChangesetExternalCheck"),
 			checkNamespaceAccess:                 op("CheckNamespaceAccess"),
 			fetchUsernameForBitbucketServerToken: op("FetchUsernameForBitbucketServerToken"),
 			validateAuthenticator:                op("ValidateAuthenticator"),
@@ -531,6 +539,33 @@ func (s *Service) CreateBatchSpecFromRaw(ctx context.Context, opts CreateBatchSp
 	})
 }
 
+// ValidateBatchSpec validates the given raw batch spec without persisting it and
+// returns all problems found, positioned within the raw spec. In addition to the
+// checks of batcheslib.ValidateBatchSpec, the repositoriesMatchingQuery values of
+// the `on` section are parsed as they would be when resolving workspaces.
+func (s *Service) ValidateBatchSpec(ctx context.Context, rawSpec string) (_ []batcheslib.SpecError, err error) {
+	_, _, endObservation := s.operations.validateBatchSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	spec, specErrors := batcheslib.ValidateBatchSpec([]byte(rawSpec))
+	if spec == nil {
+		return specErrors, nil
+	}
+
+	locator := batcheslib.NewSpecLocator([]byte(rawSpec))
+	for i, on := range spec.On {
+		if on.RepositoriesMatchingQuery == "" {
+			continue
+		}
+
+		if _, err := searchquery.Pipeline(searchquery.Init(on.RepositoriesMatchingQuery, searchquery.SearchTypeStandard)); err != nil {
+			specErrors = append(specErrors, locator.ErrorAt(batcheslib.SpecErrorKindOnQuery, err.Error(), "on", strconv.Itoa(i), "repositoriesMatchingQuery"))
+		}
+	}
+
+	return specErrors, nil
+}
+
 type createBatchSpecForExecutionOpts struct {
 	spec             *btypes.BatchSpec
 	allowUnsupported bool
@@ -1181,9 +1216,23 @@ func (s *Service) ReenqueueChangeset(ctx context.Context, id int64) (changeset *
 		return nil, nil, err
 	}
 
+	if err := s.checkViewerCanAdministerChangeset(ctx, id); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.store.EnqueueChangeset(ctx, changeset, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateFailed); err != nil {
+		return nil, nil, err
+	}
+
+	return changeset, repo, nil
+}
+
+// checkViewerCanAdministerChangeset checks whether the current user has admin
+// rights for one of the batch changes the changeset is attached to.
+func (s *Service) checkViewerCanAdministerChangeset(ctx context.Context, id int64) error {
 	attachedBatchChanges, _, err := s.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{ChangesetID: id})
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
 	// Check whether the user has admin rights for one of the batches.
@@ -1203,10 +1252,87 @@ func (s *Service) ReenqueueChangeset(ctx context.Context, id int64) (changeset *
 	}
 
 	if !hasAdminRights {
-		return nil, nil, authErr
+		return authErr
 	}
+	return nil
+}
 
-	if err := s.store.EnqueueChangeset(ctx, changeset, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateFailed); err != nil {
+// SetChangesetExternalCheckOpts are the options passed to
+// SetChangesetExternalCheck.
+type SetChangesetExternalCheckOpts struct {
+	ChangesetID int64
+	Context     string
+	State       btypes.ChangesetCheckState
+	CommitOID   string
+	TargetURL   string
+	Description string
+}
+
+// ErrInvalidExternalCheck is returned by SetChangesetExternalCheck if the
+// reported check is missing a context or has an invalid state.
+var ErrInvalidExternalCheck = errors.New("external check requires a non-empty context and a state of PENDING, PASSED or FAILED")
+
+// SetChangesetExternalCheck records the status of a check run by an external CI
+// system for the given changeset and recomputes the check state of the
+// changeset, so that the external check is treated like a native check of the
+// code host.
+func (s *Service) SetChangesetExternalCheck(ctx context.Context, opts SetChangesetExternalCheckOpts) (changeset *btypes.Changeset, repo *types.Repo, err error) {
+	ctx, _, endObservation := s.operations.setChangesetExternalCheck.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	switch opts.State {
+	case btypes.ChangesetCheckStatePending, btypes.ChangesetCheckStatePassed, btypes.ChangesetCheckStateFailed:
+	default:
+		return nil, nil, ErrInvalidExternalCheck
+	}
+	if opts.Context == "" {
+		return nil, nil, ErrInvalidExternalCheck
+	}
+
+	changeset, err = s.store.GetChangeset(ctx, store.GetChangesetOpts{ID: opts.ChangesetID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 🚨 SECURITY: We use database.Repos.Get to check whether the user has access to
+	// the repository or not.
+	repo, err = s.store.Repos().Get(ctx, changeset.RepoID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.checkViewerCanAdministerChangeset(ctx, changeset.ID); err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.UpsertChangesetExternalCheck(ctx, &btypes.ChangesetExternalCheck{
+		ChangesetID: changeset.ID,
+		Context:     opts.Context,
+		State:       opts.State,
+		CommitOID:   opts.CommitOID,
+		TargetURL:   opts.TargetURL,
+		Description: opts.Description,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	checks, err := tx.ListChangesetExternalChecks(ctx, store.ListChangesetExternalChecksOpts{ChangesetIDs: []int64{changeset.ID}})
+	if err != nil {
+		return nil, nil, err
+	}
+	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{ChangesetIDs: []int64{changeset.ID}})
+	if err != nil {
+		return nil, nil, err
+	}
+	state.SetDerivedState(ctx, tx.Repos(), gitserver.NewClient("batches.service.setchangesetexternalcheck"), changeset, events, checks)
+
+	if err := tx.UpdateChangesetCodeHostState(ctx, changeset); err != nil {
 		return nil, nil, err
 	}
 
@@ -602,6 +602,70 @@ func TestService(t *testing.T) {
 		}
 	})
 
+	t.Run("SetChangesetExternalCheck", func(t *testing.T) {
+		otherUser := bt.CreateTestUser(t, db, false)
+		otherUserCtx := actor.WithActor(context.Background(), actor.FromUser(otherUser.ID))
+
+		spec := testBatchSpec(user.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(user.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := testChangeset(rs[1].ID, batchChange.ID, btypes.ChangesetExternalStateOpen)
+		if err := s.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("invalid state", func(t *testing.T) {
+			_, _, err := svc.SetChangesetExternalCheck(userCtx, SetChangesetExternalCheckOpts{
+				ChangesetID: changeset.ID,
+				Context:     "ci/build",
+				State:       btypes.ChangesetCheckStateUnknown,
+			})
+			if err != ErrInvalidExternalCheck {
+				t.Fatalf("expected ErrInvalidExternalCheck but got %v", err)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			have, _, err := svc.SetChangesetExternalCheck(userCtx, SetChangesetExternalCheckOpts{
+				ChangesetID: changeset.ID,
+				Context:     "ci/build",
+				State:       btypes.ChangesetCheckStateFailed,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.ExternalCheckState != btypes.ChangesetCheckStateFailed {
+				t.Fatalf("wrong check state. want=%s, have=%s", btypes.ChangesetCheckStateFailed, have.ExternalCheckState)
+			}
+
+			checks, err := s.ListChangesetExternalChecks(ctx, store.ListChangesetExternalChecksOpts{ChangesetIDs: []int64{changeset.ID}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(checks) != 1 || checks[0].Context != "ci/build" {
+				t.Fatalf("wrong external checks: %+v", checks)
+			}
+		})
+
+		t.Run("not batch change admin", func(t *testing.T) {
+			_, _, err := svc.SetChangesetExternalCheck(otherUserCtx, SetChangesetExternalCheckOpts{
+				ChangesetID: changeset.ID,
+				Context:     "ci/build",
+				State:       btypes.ChangesetCheckStatePassed,
+			})
+			if err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+	})
+
 	t.Run("CreateBatchSpec", func(t *testing.T) {
 		changesetSpecs := make([]*btypes.ChangesetSpec, 0, len(rs))
 		changesetSpecRandIDs := make([]string, 0, len(rs))
@@ -2165,6 +2229,27 @@ changesetTemplate:
 		})
 	})
 
+	t.Run("ValidateBatchSpec", func(t *testing.T) {
+		t.Run("valid spec", func(t *testing.T) {
+			specErrors, err := svc.ValidateBatchSpec(ctx, bt.TestRawBatchSpecYAML)
+			require.NoError(t, err)
+			assert.Empty(t, specErrors)
+		})
+
+		t.Run("invalid on query", func(t *testing.T) {
+			specErrors, err := svc.ValidateBatchSpec(ctx, `name: test
+on:
+  - repository: github.com/sourcegraph/sourcegraph
+  - repositoriesMatchingQuery: repo:foo count:abc
+`)
+			require.NoError(t, err)
+			require.Len(t, specErrors, 1)
+			assert.Equal(t, batcheslib.SpecErrorKindOnQuery, specErrors[0].Kind)
+			assert.Equal(t, "on[1].repositoriesMatchingQuery", specErrors[0].Path)
+			assert.Equal(t, 4, specErrors[0].Line)
+		})
+	})
+
 	t.Run("UpsertBatchSpecInput", func(t *testing.T) {
 		adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
 		t.Run("new spec", func(t *testing.T) {
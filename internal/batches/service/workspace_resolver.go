@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/repoviews"
 	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -44,6 +45,11 @@ type RepoRevision struct {
 	Branch      string
 	Commit      api.CommitID
 	FileMatches []string
+
+	// PathGlobs is set if the revision was resolved from a repository view. It
+	// limits the workspaces and file matches to the paths that are part of the
+	// view.
+	PathGlobs []string
 }
 
 func (r *RepoRevision) HasBranch() bool {
@@ -243,6 +249,11 @@ func (wr *workspaceResolver) resolveRepositoriesOn(ctx context.Context, on *batc
 		return nil, onlib.RepositoryRuleTypeExplicit, err
 	}
 
+	if on.View != "" {
+		revs, err := wr.resolveView(ctx, on.View, branches)
+		return revs, onlib.RepositoryRuleTypeExplicit, err
+	}
+
 	if on.Repository != "" && len(branches) > 0 {
 		revs := make([]*RepoRevision, len(branches))
 		for i, branch := range branches {
@@ -312,6 +323,39 @@ func (wr *workspaceResolver) resolveRepositoryNameAndBranch(ctx context.Context,
 	}, nil
 }
 
+// resolveView resolves the parent repository of the repository view with the
+// given name, on its default branch if no branches are given.
+func (wr *workspaceResolver) resolveView(ctx context.Context, name string, branches []string) (_ []*RepoRevision, err error) {
+	tr, ctx := trace.New(ctx, "workspaceResolver.resolveView")
+	defer tr.EndWithErr(&err)
+
+	view, repo, err := repoviews.Resolve(ctx, wr.store.DatabaseDB(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	var revs []*RepoRevision
+	if len(branches) == 0 {
+		rev, err := wr.resolveRepositoryName(ctx, string(repo.Name))
+		if err != nil {
+			return nil, err
+		}
+		revs = append(revs, rev)
+	}
+	for _, branch := range branches {
+		rev, err := wr.resolveRepositoryNameAndBranch(ctx, string(repo.Name), branch)
+		if err != nil {
+			return nil, err
+		}
+		revs = append(revs, rev)
+	}
+
+	for _, rev := range revs {
+		rev.PathGlobs = view.PathGlobs
+	}
+	return revs, nil
+}
+
 func (wr *workspaceResolver) resolveRepositoriesMatchingQuery(ctx context.Context, query string) (_ []*RepoRevision, err error) {
 	tr, ctx := trace.New(ctx, "workspaceResolver.resolveRepositorySearch")
 	defer tr.EndWithErr(&err)
@@ -583,6 +627,19 @@ func findWorkspaces(
 		return nil, errs
 	}
 
+	// Compile the path globs of revisions resolved from repository views.
+	viewMatchers := make(map[*RepoRevision]*repoviews.Matcher)
+	for _, repoRev := range repoRevs {
+		if len(repoRev.PathGlobs) == 0 {
+			continue
+		}
+		m, err := repoviews.NewMatcher(repoRev.PathGlobs)
+		if err != nil {
+			return nil, batcheslib.NewValidationError(errors.Wrapf(err, "repository view on %s", repoRev.Repo.Name))
+		}
+		viewMatchers[repoRev] = m
+	}
+
 	root := []*RepoRevision{}
 
 	// Maps workspace config indexes to repositories matching them.
@@ -634,12 +691,17 @@ func findWorkspaces(
 		}
 
 		for repoRevKey, dirs := range repoRevDirs {
+			repoRev := repoRevsByKey[repoRevKey]
+			// Only keep the workspaces within a view.
+			if matcher := viewMatchers[repoRev]; matcher != nil {
+				dirs = filterDirs(dirs, matcher.ContainsDir)
+			}
 			// Don't add repos that don't have any matched workspaces.
 			if len(dirs) == 0 {
 				continue
 			}
 			workspacesByRepoRev[repoRevKey] = repoWorkspaces{
-				RepoRevision:       repoRevsByKey[repoRevKey],
+				RepoRevision:       repoRev,
 				Paths:              dirs,
 				OnlyFetchWorkspace: conf.OnlyFetchWorkspace,
 			}
@@ -649,6 +711,15 @@ func findWorkspaces(
 	// And add the root for repos.
 	for _, repoRev := range root {
 		conf, ok := workspacesByRepoRev[repoRev.Key()]
+		if !ok && len(repoRev.PathGlobs) > 0 {
+			// The root of a view are the directories containing its paths.
+			workspacesByRepoRev[repoRev.Key()] = repoWorkspaces{
+				RepoRevision:       repoRev,
+				Paths:              repoviews.Roots(repoRev.PathGlobs),
+				OnlyFetchWorkspace: true,
+			}
+			continue
+		}
 		if !ok {
 			workspacesByRepoRev[repoRev.Key()] = repoWorkspaces{
 				RepoRevision: repoRev,
@@ -672,7 +743,11 @@ func findWorkspaces(
 			// Filter file matches by workspace. Only include paths that are
 			// _within_ the directory.
 			paths := []string{}
+			matcher := viewMatchers[workspace.RepoRevision]
 			for _, probe := range workspace.RepoRevision.FileMatches {
+				if matcher != nil && !matcher.Match(probe) {
+					continue
+				}
 				if strings.HasPrefix(probe, path) {
 					paths = append(paths, probe)
 				}
@@ -714,6 +789,16 @@ func findWorkspaces(
 	return workspaces, nil
 }
 
+func filterDirs(dirs []string, keep func(string) bool) []string {
+	filtered := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if keep(dir) {
+			filtered = append(filtered, dir)
+		}
+	}
+	return filtered
+}
+
 type repoRevKey struct {
 	RepoID int32
 	Branch string
@@ -689,6 +689,54 @@ func TestFindWorkspaces(t *testing.T) {
 	}
 }
 
+func TestFindWorkspaces_RepoView(t *testing.T) {
+	view := &RepoRevision{
+		Repo:        &types.Repo{ID: 1, Name: "github.com/sourcegraph/monorepo"},
+		FileMatches: []string{"client/web/package.json", "client/shared/package.json", "cmd/frontend/main.go"},
+		PathGlobs:   []string{"client/web/**", "client/web-sveltekit/**"},
+	}
+	steps := []batcheslib.Step{{Run: "echo 1"}}
+
+	t.Run("without workspace configuration", func(t *testing.T) {
+		spec := &batcheslib.BatchSpec{Steps: steps}
+		workspaces, err := findWorkspaces(context.Background(), spec, &mockDirectoryFinder{}, []*RepoRevision{view})
+		require.NoError(t, err)
+
+		withFileMatches := func(fileMatches ...string) *RepoRevision {
+			rev := *view
+			rev.FileMatches = append([]string{}, fileMatches...)
+			return &rev
+		}
+		want := []*RepoWorkspace{
+			{RepoRevision: withFileMatches("client/web/package.json"), Path: "client/web", OnlyFetchWorkspace: true},
+			{RepoRevision: withFileMatches(), Path: "client/web-sveltekit", OnlyFetchWorkspace: true},
+		}
+		if diff := cmp.Diff(want, workspaces); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("workspace configuration", func(t *testing.T) {
+		spec := &batcheslib.BatchSpec{
+			Steps: steps,
+			Workspaces: []batcheslib.WorkspaceConfiguration{
+				{In: "*monorepo", RootAtLocationOf: "package.json"},
+			},
+		}
+		finder := &mockDirectoryFinder{results: map[repoRevKey][]string{
+			view.Key(): {"client/shared", "client/web", "client/web/e2e"},
+		}}
+		workspaces, err := findWorkspaces(context.Background(), spec, finder, []*RepoRevision{view})
+		require.NoError(t, err)
+
+		var paths []string
+		for _, ws := range workspaces {
+			paths = append(paths, ws.Path)
+		}
+		require.Equal(t, []string{"client/web", "client/web/e2e"}, paths)
+	})
+}
+
 type mockDirectoryFinder struct {
 	results map[repoRevKey][]string
 }
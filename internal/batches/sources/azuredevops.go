@@ -47,7 +47,7 @@ func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *h
 		return nil, errors.Wrap(err, "creating external client")
 	}
 
-	client, err := azuredevops.NewClient(svc.URN(), c.Url, &auth.BasicAuth{Username: c.Username, Password: c.Token}, cli)
+	client, err := azuredevops.NewClient(svc.URN(), c.Url, azuredevops.AuthenticatorFromConfig(&c), cli)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating Azure DevOps client")
 	}
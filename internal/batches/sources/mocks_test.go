@@ -1,8 +1,4 @@
 // Code generated by go-mockgen 1.3.7; DO NOT EDIT.
-//
-// This file was generated by running `sg generate` (or `go-mockgen`) at the root of
-// this repository. To add additional mocks to this or another package, add a new entry
-// to the mockgen.yaml file in the root of this repository.
 
 package sources
 
@@ -6360,10 +6356,22 @@ type MockAzureDevOpsClient struct {
 	// object controlling the behavior of the method
 	// ListAuthorizedUserOrganizations.
 	ListAuthorizedUserOrganizationsFunc *AzureDevOpsClientListAuthorizedUserOrganizationsFunc
+	// ListIdentitiesFunc is an instance of a mock function object
+	// controlling the behavior of the method ListIdentities.
+	ListIdentitiesFunc *AzureDevOpsClientListIdentitiesFunc
+	// ListProjectsFunc is an instance of a mock function object controlling
+	// the behavior of the method ListProjects.
+	ListProjectsFunc *AzureDevOpsClientListProjectsFunc
 	// ListRepositoriesByProjectOrOrgFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// ListRepositoriesByProjectOrOrg.
 	ListRepositoriesByProjectOrOrgFunc *AzureDevOpsClientListRepositoriesByProjectOrOrgFunc
+	// ListTeamMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method ListTeamMembers.
+	ListTeamMembersFunc *AzureDevOpsClientListTeamMembersFunc
+	// ListTeamsFunc is an instance of a mock function object controlling
+	// the behavior of the method ListTeams.
+	ListTeamsFunc *AzureDevOpsClientListTeamsFunc
 	// SetWaitForRateLimitFunc is an instance of a mock function object
 	// controlling the behavior of the method SetWaitForRateLimit.
 	SetWaitForRateLimitFunc *AzureDevOpsClientSetWaitForRateLimitFunc
@@ -6454,11 +6462,31 @@ func NewMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				return
 			},
 		},
+		ListIdentitiesFunc: &AzureDevOpsClientListIdentitiesFunc{
+			defaultHook: func(context.Context, string, azuredevops.ListIdentitiesArgs) (r0 []azuredevops.Identity, r1 error) {
+				return
+			},
+		},
+		ListProjectsFunc: &AzureDevOpsClientListProjectsFunc{
+			defaultHook: func(context.Context, string) (r0 []azuredevops.Project, r1 error) {
+				return
+			},
+		},
 		ListRepositoriesByProjectOrOrgFunc: &AzureDevOpsClientListRepositoriesByProjectOrOrgFunc{
 			defaultHook: func(context.Context, azuredevops.ListRepositoriesByProjectOrOrgArgs) (r0 []azuredevops.Repository, r1 error) {
 				return
 			},
 		},
+		ListTeamMembersFunc: &AzureDevOpsClientListTeamMembersFunc{
+			defaultHook: func(context.Context, string, string, string) (r0 []azuredevops.TeamMember, r1 error) {
+				return
+			},
+		},
+		ListTeamsFunc: &AzureDevOpsClientListTeamsFunc{
+			defaultHook: func(context.Context, string, string) (r0 []azuredevops.Team, r1 error) {
+				return
+			},
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: func(bool) {
 				return
@@ -6556,11 +6584,31 @@ func NewStrictMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				panic("unexpected invocation of MockAzureDevOpsClient.ListAuthorizedUserOrganizations")
 			},
 		},
+		ListIdentitiesFunc: &AzureDevOpsClientListIdentitiesFunc{
+			defaultHook: func(context.Context, string, azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.ListIdentities")
+			},
+		},
+		ListProjectsFunc: &AzureDevOpsClientListProjectsFunc{
+			defaultHook: func(context.Context, string) ([]azuredevops.Project, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.ListProjects")
+			},
+		},
 		ListRepositoriesByProjectOrOrgFunc: &AzureDevOpsClientListRepositoriesByProjectOrOrgFunc{
 			defaultHook: func(context.Context, azuredevops.ListRepositoriesByProjectOrOrgArgs) ([]azuredevops.Repository, error) {
 				panic("unexpected invocation of MockAzureDevOpsClient.ListRepositoriesByProjectOrOrg")
 			},
 		},
+		ListTeamMembersFunc: &AzureDevOpsClientListTeamMembersFunc{
+			defaultHook: func(context.Context, string, string, string) ([]azuredevops.TeamMember, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.ListTeamMembers")
+			},
+		},
+		ListTeamsFunc: &AzureDevOpsClientListTeamsFunc{
+			defaultHook: func(context.Context, string, string) ([]azuredevops.Team, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.ListTeams")
+			},
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: func(bool) {
 				panic("unexpected invocation of MockAzureDevOpsClient.SetWaitForRateLimit")
@@ -6629,9 +6677,21 @@ func NewMockAzureDevOpsClientFrom(i azuredevops.Client) *MockAzureDevOpsClient {
 		ListAuthorizedUserOrganizationsFunc: &AzureDevOpsClientListAuthorizedUserOrganizationsFunc{
 			defaultHook: i.ListAuthorizedUserOrganizations,
 		},
+		ListIdentitiesFunc: &AzureDevOpsClientListIdentitiesFunc{
+			defaultHook: i.ListIdentities,
+		},
+		ListProjectsFunc: &AzureDevOpsClientListProjectsFunc{
+			defaultHook: i.ListProjects,
+		},
 		ListRepositoriesByProjectOrOrgFunc: &AzureDevOpsClientListRepositoriesByProjectOrOrgFunc{
 			defaultHook: i.ListRepositoriesByProjectOrOrg,
 		},
+		ListTeamMembersFunc: &AzureDevOpsClientListTeamMembersFunc{
+			defaultHook: i.ListTeamMembers,
+		},
+		ListTeamsFunc: &AzureDevOpsClientListTeamsFunc{
+			defaultHook: i.ListTeams,
+		},
 		SetWaitForRateLimitFunc: &AzureDevOpsClientSetWaitForRateLimitFunc{
 			defaultHook: i.SetWaitForRateLimit,
 		},
@@ -8295,6 +8355,230 @@ func (c AzureDevOpsClientListAuthorizedUserOrganizationsFuncCall) Results() []in
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AzureDevOpsClientListIdentitiesFunc describes the behavior when the
+// ListIdentities method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientListIdentitiesFunc struct {
+	defaultHook func(context.Context, string, azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error)
+	hooks       []func(context.Context, string, azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error)
+	history     []AzureDevOpsClientListIdentitiesFuncCall
+	mutex       sync.Mutex
+}
+
+// ListIdentities delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) ListIdentities(v0 context.Context, v1 string, v2 azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error) {
+	r0, r1 := m.ListIdentitiesFunc.nextHook()(v0, v1, v2)
+	m.ListIdentitiesFunc.appendCall(AzureDevOpsClientListIdentitiesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListIdentities
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientListIdentitiesFunc) SetDefaultHook(hook func(context.Context, string, azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListIdentities method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientListIdentitiesFunc) PushHook(hook func(context.Context, string, azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientListIdentitiesFunc) SetDefaultReturn(r0 []azuredevops.Identity, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientListIdentitiesFunc) PushReturn(r0 []azuredevops.Identity, r1 error) {
+	f.PushHook(func(context.Context, string, azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientListIdentitiesFunc) nextHook() func(context.Context, string, azuredevops.ListIdentitiesArgs) ([]azuredevops.Identity, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientListIdentitiesFunc) appendCall(r0 AzureDevOpsClientListIdentitiesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientListIdentitiesFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientListIdentitiesFunc) History() []AzureDevOpsClientListIdentitiesFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientListIdentitiesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientListIdentitiesFuncCall is an object that describes an
+// invocation of method ListIdentities on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientListIdentitiesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 azuredevops.ListIdentitiesArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []azuredevops.Identity
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientListIdentitiesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientListIdentitiesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientListProjectsFunc describes the behavior when the
+// ListProjects method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientListProjectsFunc struct {
+	defaultHook func(context.Context, string) ([]azuredevops.Project, error)
+	hooks       []func(context.Context, string) ([]azuredevops.Project, error)
+	history     []AzureDevOpsClientListProjectsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListProjects delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) ListProjects(v0 context.Context, v1 string) ([]azuredevops.Project, error) {
+	r0, r1 := m.ListProjectsFunc.nextHook()(v0, v1)
+	m.ListProjectsFunc.appendCall(AzureDevOpsClientListProjectsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListProjects method
+// of the parent MockAzureDevOpsClient instance is invoked and the hook
+// queue is empty.
+func (f *AzureDevOpsClientListProjectsFunc) SetDefaultHook(hook func(context.Context, string) ([]azuredevops.Project, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListProjects method of the parent MockAzureDevOpsClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AzureDevOpsClientListProjectsFunc) PushHook(hook func(context.Context, string) ([]azuredevops.Project, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientListProjectsFunc) SetDefaultReturn(r0 []azuredevops.Project, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]azuredevops.Project, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientListProjectsFunc) PushReturn(r0 []azuredevops.Project, r1 error) {
+	f.PushHook(func(context.Context, string) ([]azuredevops.Project, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientListProjectsFunc) nextHook() func(context.Context, string) ([]azuredevops.Project, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientListProjectsFunc) appendCall(r0 AzureDevOpsClientListProjectsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientListProjectsFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientListProjectsFunc) History() []AzureDevOpsClientListProjectsFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientListProjectsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientListProjectsFuncCall is an object that describes an
+// invocation of method ListProjects on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientListProjectsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []azuredevops.Project
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientListProjectsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientListProjectsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AzureDevOpsClientListRepositoriesByProjectOrOrgFunc describes the
 // behavior when the ListRepositoriesByProjectOrOrg method of the parent
 // MockAzureDevOpsClient instance is invoked.
@@ -8407,6 +8691,234 @@ func (c AzureDevOpsClientListRepositoriesByProjectOrOrgFuncCall) Results() []int
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AzureDevOpsClientListTeamMembersFunc describes the behavior when the
+// ListTeamMembers method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientListTeamMembersFunc struct {
+	defaultHook func(context.Context, string, string, string) ([]azuredevops.TeamMember, error)
+	hooks       []func(context.Context, string, string, string) ([]azuredevops.TeamMember, error)
+	history     []AzureDevOpsClientListTeamMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTeamMembers delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) ListTeamMembers(v0 context.Context, v1 string, v2 string, v3 string) ([]azuredevops.TeamMember, error) {
+	r0, r1 := m.ListTeamMembersFunc.nextHook()(v0, v1, v2, v3)
+	m.ListTeamMembersFunc.appendCall(AzureDevOpsClientListTeamMembersFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListTeamMembers
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientListTeamMembersFunc) SetDefaultHook(hook func(context.Context, string, string, string) ([]azuredevops.TeamMember, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTeamMembers method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientListTeamMembersFunc) PushHook(hook func(context.Context, string, string, string) ([]azuredevops.TeamMember, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientListTeamMembersFunc) SetDefaultReturn(r0 []azuredevops.TeamMember, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string) ([]azuredevops.TeamMember, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientListTeamMembersFunc) PushReturn(r0 []azuredevops.TeamMember, r1 error) {
+	f.PushHook(func(context.Context, string, string, string) ([]azuredevops.TeamMember, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientListTeamMembersFunc) nextHook() func(context.Context, string, string, string) ([]azuredevops.TeamMember, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientListTeamMembersFunc) appendCall(r0 AzureDevOpsClientListTeamMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientListTeamMembersFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientListTeamMembersFunc) History() []AzureDevOpsClientListTeamMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientListTeamMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientListTeamMembersFuncCall is an object that describes an
+// invocation of method ListTeamMembers on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientListTeamMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []azuredevops.TeamMember
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientListTeamMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientListTeamMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientListTeamsFunc describes the behavior when the ListTeams
+// method of the parent MockAzureDevOpsClient instance is invoked.
+type AzureDevOpsClientListTeamsFunc struct {
+	defaultHook func(context.Context, string, string) ([]azuredevops.Team, error)
+	hooks       []func(context.Context, string, string) ([]azuredevops.Team, error)
+	history     []AzureDevOpsClientListTeamsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTeams delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) ListTeams(v0 context.Context, v1 string, v2 string) ([]azuredevops.Team, error) {
+	r0, r1 := m.ListTeamsFunc.nextHook()(v0, v1, v2)
+	m.ListTeamsFunc.appendCall(AzureDevOpsClientListTeamsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListTeams method of
+// the parent MockAzureDevOpsClient instance is invoked and the hook queue
+// is empty.
+func (f *AzureDevOpsClientListTeamsFunc) SetDefaultHook(hook func(context.Context, string, string) ([]azuredevops.Team, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTeams method of the parent MockAzureDevOpsClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *AzureDevOpsClientListTeamsFunc) PushHook(hook func(context.Context, string, string) ([]azuredevops.Team, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientListTeamsFunc) SetDefaultReturn(r0 []azuredevops.Team, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) ([]azuredevops.Team, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientListTeamsFunc) PushReturn(r0 []azuredevops.Team, r1 error) {
+	f.PushHook(func(context.Context, string, string) ([]azuredevops.Team, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientListTeamsFunc) nextHook() func(context.Context, string, string) ([]azuredevops.Team, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientListTeamsFunc) appendCall(r0 AzureDevOpsClientListTeamsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientListTeamsFuncCall objects
+// describing the invocations of this function.
+func (f *AzureDevOpsClientListTeamsFunc) History() []AzureDevOpsClientListTeamsFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientListTeamsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientListTeamsFuncCall is an object that describes an
+// invocation of method ListTeams on an instance of MockAzureDevOpsClient.
+type AzureDevOpsClientListTeamsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []azuredevops.Team
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientListTeamsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientListTeamsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AzureDevOpsClientSetWaitForRateLimitFunc describes the behavior when the
 // SetWaitForRateLimit method of the parent MockAzureDevOpsClient instance
 // is invoked.
@@ -34,8 +34,9 @@ import (
 )
 
 // SetDerivedState will update the external state fields on the Changeset based
-// on the current state of the changeset and associated events.
-func SetDerivedState(ctx context.Context, repoStore database.RepoStore, client gitserver.Client, c *btypes.Changeset, es []*btypes.ChangesetEvent) {
+// on the current state of the changeset, associated events, and the statuses
+// reported by external CI systems.
+func SetDerivedState(ctx context.Context, repoStore database.RepoStore, client gitserver.Client, c *btypes.Changeset, es []*btypes.ChangesetEvent, checks []*btypes.ChangesetExternalCheck) {
 	// Copy so that we can sort without mutating the argument
 	events := make(ChangesetEvents, len(es))
 	copy(events, es)
@@ -52,7 +53,7 @@ func SetDerivedState(ctx context.Context, repoStore database.RepoStore, client g
 		return
 	}
 
-	c.ExternalCheckState = computeCheckState(c, events)
+	c.ExternalCheckState = combineExternalChecks(computeCheckState(c, events), ExternalChecksForHead(c, checks))
 
 	history, err := computeHistory(c, events)
 	if err != nil {
@@ -132,6 +133,42 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 	return btypes.ChangesetCheckStateUnknown
 }
 
+// ExternalChecksForHead returns the external checks that were reported for the
+// current head commit of the changeset.
+func ExternalChecksForHead(c *btypes.Changeset, checks []*btypes.ChangesetExternalCheck) []*btypes.ChangesetExternalCheck {
+	// If the head commit cannot be determined, we don't know which statuses
+	// are stale and consider all of them.
+	head, _ := c.HeadRefOid()
+
+	var current []*btypes.ChangesetExternalCheck
+	for _, check := range checks {
+		if check.AppliesTo(head) {
+			current = append(current, check)
+		}
+	}
+	return current
+}
+
+// combineExternalChecks combines the check state reported by the code host
+// with the states of external checks, so that external CI systems are treated
+// like the native checks of the code host.
+func combineExternalChecks(native btypes.ChangesetCheckState, checks []*btypes.ChangesetExternalCheck) btypes.ChangesetCheckState {
+	if len(checks) == 0 {
+		return native
+	}
+
+	states := make([]btypes.ChangesetCheckState, 0, len(checks)+1)
+	for _, check := range checks {
+		states = append(states, check.State)
+	}
+	// An unknown native state means that the code host doesn't run any checks,
+	// which must not hide the state of the external checks.
+	if native != btypes.ChangesetCheckStateUnknown {
+		states = append(states, native)
+	}
+	return combineCheckStates(states)
+}
+
 // computeExternalState computes the external state for the changeset and its
 // associated events.
 func computeExternalState(c *btypes.Changeset, history []changesetStatesAtTime, repo *types.Repo) (btypes.ChangesetExternalState, error) {
@@ -1065,3 +1065,59 @@ func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
 }
+
+func TestCombineExternalChecks(t *testing.T) {
+	t.Parallel()
+
+	check := func(context string, state btypes.ChangesetCheckState, commit string) *btypes.ChangesetExternalCheck {
+		return &btypes.ChangesetExternalCheck{Context: context, State: state, CommitOID: commit}
+	}
+	changeset := &btypes.Changeset{Metadata: &github.PullRequest{HeadRefOid: "deadbeef"}}
+
+	tests := []struct {
+		name   string
+		native btypes.ChangesetCheckState
+		checks []*btypes.ChangesetExternalCheck
+		want   btypes.ChangesetCheckState
+	}{
+		{
+			name:   "no external checks",
+			native: btypes.ChangesetCheckStateFailed,
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:   "no native checks",
+			native: btypes.ChangesetCheckStateUnknown,
+			checks: []*btypes.ChangesetExternalCheck{check("ci/build", btypes.ChangesetCheckStatePassed, "")},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:   "pending external check",
+			native: btypes.ChangesetCheckStatePassed,
+			checks: []*btypes.ChangesetExternalCheck{check("ci/build", btypes.ChangesetCheckStatePending, "deadbeef")},
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		{
+			name:   "failed external check",
+			native: btypes.ChangesetCheckStatePassed,
+			checks: []*btypes.ChangesetExternalCheck{
+				check("ci/build", btypes.ChangesetCheckStatePassed, ""),
+				check("ci/test", btypes.ChangesetCheckStateFailed, ""),
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:   "stale external check",
+			native: btypes.ChangesetCheckStatePassed,
+			checks: []*btypes.ChangesetExternalCheck{check("ci/build", btypes.ChangesetCheckStateFailed, "cafebabe")},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := combineExternalChecks(tc.native, ExternalChecksForHead(changeset, tc.checks))
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
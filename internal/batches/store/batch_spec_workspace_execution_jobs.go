@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -76,10 +77,11 @@ FROM batch_spec_workspace_execution_queue
 
 const createBatchSpecWorkspaceExecutionJobsQueryFmtstr = `
 INSERT INTO
-	batch_spec_workspace_execution_jobs (batch_spec_workspace_id, user_id, version)
+	batch_spec_workspace_execution_jobs (batch_spec_workspace_id, user_id, version, trace_context)
 SELECT
 	batch_spec_workspaces.id,
 	batch_specs.user_id,
+	%s,
 	%s
 FROM
 	batch_spec_workspaces
@@ -110,16 +112,17 @@ func (s *Store) CreateBatchSpecWorkspaceExecutionJobs(ctx context.Context, batch
 	defer endObservation(1, observation.Args{})
 
 	cond := sqlf.Sprintf(executableWorkspaceJobsConditionFmtstr)
-	q := sqlf.Sprintf(createBatchSpecWorkspaceExecutionJobsQueryFmtstr, versionForExecution(ctx, s), batchSpecID, cond)
+	q := sqlf.Sprintf(createBatchSpecWorkspaceExecutionJobsQueryFmtstr, versionForExecution(ctx, s), dbworkerstore.NewTraceContext(ctx), batchSpecID, cond)
 	return s.Exec(ctx, q)
 }
 
 const createBatchSpecWorkspaceExecutionJobsForWorkspacesQueryFmtstr = `
 INSERT INTO
-	batch_spec_workspace_execution_jobs (batch_spec_workspace_id, user_id, version)
+	batch_spec_workspace_execution_jobs (batch_spec_workspace_id, user_id, version, trace_context)
 SELECT
 	batch_spec_workspaces.id,
 	batch_specs.user_id,
+	%s,
 	%s
 FROM
 	batch_spec_workspaces
@@ -134,7 +137,7 @@ func (s *Store) CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(ctx context.C
 	ctx, _, endObservation := s.operations.createBatchSpecWorkspaceExecutionJobsForWorkspaces.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
-	q := sqlf.Sprintf(createBatchSpecWorkspaceExecutionJobsForWorkspacesQueryFmtstr, versionForExecution(ctx, s), pq.Array(workspaceIDs))
+	q := sqlf.Sprintf(createBatchSpecWorkspaceExecutionJobsForWorkspacesQueryFmtstr, versionForExecution(ctx, s), dbworkerstore.NewTraceContext(ctx), pq.Array(workspaceIDs))
 	return s.Exec(ctx, q)
 }
 
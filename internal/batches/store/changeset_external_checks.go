@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+var changesetExternalCheckColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_external_checks.id"),
+	sqlf.Sprintf("changeset_external_checks.changeset_id"),
+	sqlf.Sprintf("changeset_external_checks.context"),
+	sqlf.Sprintf("changeset_external_checks.state"),
+	sqlf.Sprintf("changeset_external_checks.commit_oid"),
+	sqlf.Sprintf("changeset_external_checks.target_url"),
+	sqlf.Sprintf("changeset_external_checks.description"),
+	sqlf.Sprintf("changeset_external_checks.created_at"),
+	sqlf.Sprintf("changeset_external_checks.updated_at"),
+}
+
+// UpsertChangesetExternalCheck creates the given external check, or replaces
+// the status of the existing check of the changeset with the same context.
+func (s *Store) UpsertChangesetExternalCheck(ctx context.Context, c *btypes.ChangesetExternalCheck) (err error) {
+	ctx, _, endObservation := s.operations.upsertChangesetExternalCheck.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(c.ChangesetID)),
+		attribute.String("context", c.Context),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = s.now()
+	}
+	c.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		upsertChangesetExternalCheckQueryFmtstr,
+		c.ChangesetID,
+		c.Context,
+		c.State,
+		c.CommitOID,
+		c.TargetURL,
+		c.Description,
+		c.CreatedAt,
+		c.UpdatedAt,
+		sqlf.Join(changesetExternalCheckColumns, ", "),
+	)
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetExternalCheck(c, sc)
+	})
+}
+
+var upsertChangesetExternalCheckQueryFmtstr = `
+INSERT INTO changeset_external_checks (
+	changeset_id,
+	context,
+	state,
+	commit_oid,
+	target_url,
+	description,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (changeset_id, context)
+DO UPDATE
+SET
+	state       = excluded.state,
+	commit_oid  = excluded.commit_oid,
+	target_url  = excluded.target_url,
+	description = excluded.description,
+	updated_at  = excluded.updated_at
+RETURNING %s
+`
+
+// ListChangesetExternalChecksOpts captures the query options needed for
+// listing external checks.
+type ListChangesetExternalChecksOpts struct {
+	ChangesetIDs []int64
+}
+
+// ListChangesetExternalChecks lists the external checks of the given
+// changesets, ordered by context.
+func (s *Store) ListChangesetExternalChecks(ctx context.Context, opts ListChangesetExternalChecksOpts) (cs []*btypes.ChangesetExternalCheck, err error) {
+	ctx, _, endObservation := s.operations.listChangesetExternalChecks.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesets", len(opts.ChangesetIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(opts.ChangesetIDs) > 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_external_checks.changeset_id = ANY (%s)", pq.Array(opts.ChangesetIDs)))
+	}
+
+	q := sqlf.Sprintf(
+		listChangesetExternalChecksQueryFmtstr,
+		sqlf.Join(changesetExternalCheckColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.ChangesetExternalCheck
+		if err := scanChangesetExternalCheck(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+var listChangesetExternalChecksQueryFmtstr = `
+SELECT %s
+FROM changeset_external_checks
+WHERE %s
+ORDER BY changeset_external_checks.changeset_id ASC, changeset_external_checks.context ASC
+`
+
+func scanChangesetExternalCheck(c *btypes.ChangesetExternalCheck, sc dbutil.Scanner) error {
+	return sc.Scan(
+		&c.ID,
+		&c.ChangesetID,
+		&c.Context,
+		&c.State,
+		&c.CommitOID,
+		&c.TargetURL,
+		&c.Description,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreChangesetExternalChecks(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID})
+	otherChangeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID})
+
+	build := &btypes.ChangesetExternalCheck{
+		ChangesetID: changeset.ID,
+		Context:     "jenkins/build",
+		State:       btypes.ChangesetCheckStatePending,
+		CommitOID:   "deadbeef",
+		TargetURL:   "https://jenkins.example.com/job/1",
+	}
+	lint := &btypes.ChangesetExternalCheck{
+		ChangesetID: changeset.ID,
+		Context:     "buildkite/lint",
+		State:       btypes.ChangesetCheckStatePassed,
+	}
+	other := &btypes.ChangesetExternalCheck{
+		ChangesetID: otherChangeset.ID,
+		Context:     "jenkins/build",
+		State:       btypes.ChangesetCheckStateFailed,
+	}
+
+	t.Run("Upsert", func(t *testing.T) {
+		for _, c := range []*btypes.ChangesetExternalCheck{build, lint, other} {
+			if err := s.UpsertChangesetExternalCheck(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			if c.ID == 0 {
+				t.Fatal("id should not be zero")
+			}
+			if have, want := c.CreatedAt, clock.Now(); !have.Equal(want) {
+				t.Fatalf("created_at: have=%s want=%s", have, want)
+			}
+		}
+
+		id := build.ID
+		clock.Add(time.Second)
+		build.State = btypes.ChangesetCheckStatePassed
+		build.Description = "Build passed"
+		if err := s.UpsertChangesetExternalCheck(ctx, build); err != nil {
+			t.Fatal(err)
+		}
+		if build.ID != id {
+			t.Fatalf("upserting the same context created a new check: have=%d want=%d", build.ID, id)
+		}
+		if have, want := build.UpdatedAt, clock.Now(); !have.Equal(want) {
+			t.Fatalf("updated_at: have=%s want=%s", have, want)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListChangesetExternalChecks(ctx, ListChangesetExternalChecksOpts{ChangesetIDs: []int64{changeset.ID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.ChangesetExternalCheck{lint, build}, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.ListChangesetExternalChecks(ctx, ListChangesetExternalChecksOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 3 {
+			t.Fatalf("unexpected number of checks: have=%d want=%d", len(have), 3)
+		}
+	})
+}
@@ -22,6 +22,7 @@ func TestIntegration(t *testing.T) {
 		t.Run("BatchChangesDeletedNamespace", storeTest(db, nil, testBatchChangesDeletedNamespace))
 		t.Run("Changesets", storeTest(db, nil, testStoreChangesets))
 		t.Run("ChangesetEvents", storeTest(db, nil, testStoreChangesetEvents))
+		t.Run("ChangesetExternalChecks", storeTest(db, nil, testStoreChangesetExternalChecks))
 		t.Run("ChangesetScheduling", storeTest(db, nil, testStoreChangesetScheduling))
 		t.Run("ListChangesetSyncData", storeTest(db, nil, testStoreListChangesetSyncData))
 		t.Run("ListChangesetsTextSearch", storeTest(db, nil, testStoreListChangesetsTextSearch))
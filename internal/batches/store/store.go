@@ -219,6 +219,9 @@ type operations struct {
 	countChangesetEvents  *observation.Operation
 	upsertChangesetEvents *observation.Operation
 
+	upsertChangesetExternalCheck *observation.Operation
+	listChangesetExternalChecks  *observation.Operation
+
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
 
@@ -364,6 +367,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 			countChangesetEvents:  op("CountChangesetEvents"),
 			upsertChangesetEvents: op("UpsertChangesetEvents"),
 
+			upsertChangesetExternalCheck: op("UpsertChangesetExternalCheck"),
+			listChangesetExternalChecks:  op("ListChangesetExternalChecks"),
+
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
 
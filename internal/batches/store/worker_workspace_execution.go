@@ -47,12 +47,30 @@ var batchSpecWorkspaceExecutionWorkerStoreOptions = dbworkerstore.Options[*btype
 	MaxNumResets:      batchSpecWorkspaceExecutionJobMaximumNumResets,
 	// Explicitly disable retries.
 	MaxNumRetries: 0,
+	TraceContext:  true,
 
 	// This view ranks jobs from different users in a round-robin fashion
 	// so that no single user can clog the queue.
 	ViewName: "batch_spec_workspace_execution_jobs_with_rank batch_spec_workspace_execution_jobs",
 }
 
+// BatchSpecWorkspaceExecutionJobUserConcurrencyCondition returns a dequeue
+// condition that skips the jobs of users who already have at least limit jobs
+// processing.
+func BatchSpecWorkspaceExecutionJobUserConcurrencyCondition(limit int) *sqlf.Query {
+	return sqlf.Sprintf(userConcurrencyConditionFmtstr, limit)
+}
+
+const userConcurrencyConditionFmtstr = `
+batch_spec_workspace_execution_jobs.user_id NOT IN (
+	SELECT user_id
+	FROM batch_spec_workspace_execution_jobs
+	WHERE state = 'processing'
+	GROUP BY user_id
+	HAVING COUNT(*) >= %s
+)
+`
+
 // NewBatchSpecWorkspaceExecutionWorkerStore creates a dbworker store that
 // wraps the batch_spec_workspace_execution_jobs table.
 func NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx *observation.Context, handle basestore.TransactableHandle) dbworkerstore.Store[*btypes.BatchSpecWorkspaceExecutionJob] {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -507,6 +508,49 @@ func TestBatchSpecWorkspaceExecutionWorkerStore_Dequeue_RoundRobin(t *testing.T)
 	}
 }
 
+func TestBatchSpecWorkspaceExecutionWorkerStore_Dequeue_UserConcurrencyLimit(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	s := New(db, &observation.TestContext, nil)
+	workerStore := dbworkerstore.New(&observation.TestContext, s.Handle(), batchSpecWorkspaceExecutionWorkerStoreOptions)
+
+	user1 := bt.CreateTestUser(t, db, true)
+	user2 := bt.CreateTestUser(t, db, true)
+
+	user1BatchSpec := setupUserBatchSpec(t, ctx, s, user1)
+	user2BatchSpec := setupUserBatchSpec(t, ctx, s, user2)
+
+	job1 := setupBatchSpecAssociation(ctx, s, t, user1BatchSpec, repo) // User_ID: 1
+	job2 := setupBatchSpecAssociation(ctx, s, t, user1BatchSpec, repo) // User_ID: 1
+	setupBatchSpecAssociation(ctx, s, t, user1BatchSpec, repo)         // User_ID: 1
+	job4 := setupBatchSpecAssociation(ctx, s, t, user2BatchSpec, repo) // User_ID: 2
+
+	// Each user may have at most two jobs processing, so the third job of
+	// user 1 stays queued.
+	conditions := []*sqlf.Query{BatchSpecWorkspaceExecutionJobUserConcurrencyCondition(2)}
+
+	want := []int64{job1, job4, job2}
+	have := []int64{}
+	for {
+		r, found, err := workerStore.Dequeue(ctx, "test-worker", conditions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !found {
+			break
+		}
+		have = append(have, int64(r.RecordID()))
+	}
+
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func TestBatchSpecWorkspaceExecutionWorkerStore_Dequeue_RoundRobin_NoDoubleDequeue(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx := context.Background()
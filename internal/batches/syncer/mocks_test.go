@@ -48,6 +48,10 @@ type MockSyncStore struct {
 	// GitHubAppsStoreFunc is an instance of a mock function object
 	// controlling the behavior of the method GitHubAppsStore.
 	GitHubAppsStoreFunc *SyncStoreGitHubAppsStoreFunc
+	// ListChangesetExternalChecksFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListChangesetExternalChecks.
+	ListChangesetExternalChecksFunc *SyncStoreListChangesetExternalChecksFunc
 	// ListChangesetSyncDataFunc is an instance of a mock function object
 	// controlling the behavior of the method ListChangesetSyncData.
 	ListChangesetSyncDataFunc *SyncStoreListChangesetSyncDataFunc
@@ -124,6 +128,11 @@ func NewMockSyncStore() *MockSyncStore {
 				return
 			},
 		},
+		ListChangesetExternalChecksFunc: &SyncStoreListChangesetExternalChecksFunc{
+			defaultHook: func(context.Context, store.ListChangesetExternalChecksOpts) (r0 []*types.ChangesetExternalCheck, r1 error) {
+				return
+			},
+		},
 		ListChangesetSyncDataFunc: &SyncStoreListChangesetSyncDataFunc{
 			defaultHook: func(context.Context, store.ListChangesetSyncDataOpts) (r0 []*types.ChangesetSyncData, r1 error) {
 				return
@@ -216,6 +225,11 @@ func NewStrictMockSyncStore() *MockSyncStore {
 				panic("unexpected invocation of MockSyncStore.GitHubAppsStore")
 			},
 		},
+		ListChangesetExternalChecksFunc: &SyncStoreListChangesetExternalChecksFunc{
+			defaultHook: func(context.Context, store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error) {
+				panic("unexpected invocation of MockSyncStore.ListChangesetExternalChecks")
+			},
+		},
 		ListChangesetSyncDataFunc: &SyncStoreListChangesetSyncDataFunc{
 			defaultHook: func(context.Context, store.ListChangesetSyncDataOpts) ([]*types.ChangesetSyncData, error) {
 				panic("unexpected invocation of MockSyncStore.ListChangesetSyncData")
@@ -290,6 +304,9 @@ func NewMockSyncStoreFrom(i SyncStore) *MockSyncStore {
 		GitHubAppsStoreFunc: &SyncStoreGitHubAppsStoreFunc{
 			defaultHook: i.GitHubAppsStore,
 		},
+		ListChangesetExternalChecksFunc: &SyncStoreListChangesetExternalChecksFunc{
+			defaultHook: i.ListChangesetExternalChecks,
+		},
 		ListChangesetSyncDataFunc: &SyncStoreListChangesetSyncDataFunc{
 			defaultHook: i.ListChangesetSyncData,
 		},
@@ -1255,6 +1272,118 @@ func (c SyncStoreGitHubAppsStoreFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// SyncStoreListChangesetExternalChecksFunc describes the behavior when the
+// ListChangesetExternalChecks method of the parent MockSyncStore instance
+// is invoked.
+type SyncStoreListChangesetExternalChecksFunc struct {
+	defaultHook func(context.Context, store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error)
+	hooks       []func(context.Context, store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error)
+	history     []SyncStoreListChangesetExternalChecksFuncCall
+	mutex       sync.Mutex
+}
+
+// ListChangesetExternalChecks delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockSyncStore) ListChangesetExternalChecks(v0 context.Context, v1 store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error) {
+	r0, r1 := m.ListChangesetExternalChecksFunc.nextHook()(v0, v1)
+	m.ListChangesetExternalChecksFunc.appendCall(SyncStoreListChangesetExternalChecksFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListChangesetExternalChecks method of the parent MockSyncStore instance
+// is invoked and the hook queue is empty.
+func (f *SyncStoreListChangesetExternalChecksFunc) SetDefaultHook(hook func(context.Context, store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListChangesetExternalChecks method of the parent MockSyncStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SyncStoreListChangesetExternalChecksFunc) PushHook(hook func(context.Context, store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *SyncStoreListChangesetExternalChecksFunc) SetDefaultReturn(r0 []*types.ChangesetExternalCheck, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *SyncStoreListChangesetExternalChecksFunc) PushReturn(r0 []*types.ChangesetExternalCheck, r1 error) {
+	f.PushHook(func(context.Context, store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error) {
+		return r0, r1
+	})
+}
+
+func (f *SyncStoreListChangesetExternalChecksFunc) nextHook() func(context.Context, store.ListChangesetExternalChecksOpts) ([]*types.ChangesetExternalCheck, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SyncStoreListChangesetExternalChecksFunc) appendCall(r0 SyncStoreListChangesetExternalChecksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SyncStoreListChangesetExternalChecksFuncCall objects describing the
+// invocations of this function.
+func (f *SyncStoreListChangesetExternalChecksFunc) History() []SyncStoreListChangesetExternalChecksFuncCall {
+	f.mutex.Lock()
+	history := make([]SyncStoreListChangesetExternalChecksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SyncStoreListChangesetExternalChecksFuncCall is an object that describes
+// an invocation of method ListChangesetExternalChecks on an instance of
+// MockSyncStore.
+type SyncStoreListChangesetExternalChecksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.ListChangesetExternalChecksOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.ChangesetExternalCheck
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SyncStoreListChangesetExternalChecksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SyncStoreListChangesetExternalChecksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // SyncStoreListChangesetSyncDataFunc describes the behavior when the
 // ListChangesetSyncData method of the parent MockSyncStore instance is
 // invoked.
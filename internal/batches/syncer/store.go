@@ -17,6 +17,7 @@ type SyncStore interface {
 	GetChangeset(context.Context, store.GetChangesetOpts) (*btypes.Changeset, error)
 	UpdateChangesetCodeHostState(ctx context.Context, cs *btypes.Changeset) error
 	UpsertChangesetEvents(ctx context.Context, cs ...*btypes.ChangesetEvent) error
+	ListChangesetExternalChecks(ctx context.Context, opts store.ListChangesetExternalChecksOpts) ([]*btypes.ChangesetExternalCheck, error)
 	GetSiteCredential(ctx context.Context, opts store.GetSiteCredentialOpts) (*btypes.SiteCredential, error)
 	Transact(context.Context) (*store.Store, error)
 	Repos() database.RepoStore
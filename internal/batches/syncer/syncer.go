@@ -525,7 +525,11 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 	if err != nil {
 		return err
 	}
-	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events)
+	checks, err := syncStore.ListChangesetExternalChecks(ctx, store.ListChangesetExternalChecksOpts{ChangesetIDs: []int64{c.ID}})
+	if err != nil {
+		return err
+	}
+	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events, checks)
 
 	tx, err := syncStore.Transact(ctx)
 	if err != nil {
@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+// ChangesetExternalCheck is the status of a check on a changeset that was
+// reported by an external CI system the code host doesn't know about. External
+// checks are combined with the checks reported by the code host into the
+// ExternalCheckState of a changeset.
+type ChangesetExternalCheck struct {
+	ID          int64
+	ChangesetID int64
+	// Context is the name of the check, e.g. jenkins/build. A changeset has at
+	// most one status per context.
+	Context string
+	State   ChangesetCheckState
+	// CommitOID is the commit the status was reported for. Statuses for
+	// commits other than the head of the changeset are ignored. Empty if the
+	// status applies to any commit.
+	CommitOID   string
+	TargetURL   string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// AppliesTo returns whether the check was reported for the given head commit
+// of a changeset.
+func (c *ChangesetExternalCheck) AppliesTo(headRefOid string) bool {
+	return c.CommitOID == "" || headRefOid == "" || c.CommitOID == headRefOid
+}
@@ -1,10 +1,13 @@
 package autoindexing
 
 import (
+	"context"
+
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/background/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/background/scheduler"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/background/summary"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/jobselector"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 )
 
 type (
@@ -17,6 +20,10 @@ type (
 	InferenceService     = jobselector.InferenceService
 )
 
+type DependencyResolver interface {
+	ResolvePackages(ctx context.Context, uploadID int, pkgs []uploadsshared.Package) error
+}
+
 type RepoUpdaterClient interface {
 	dependencies.RepoUpdaterClient
 }
@@ -28,6 +28,7 @@ func NewService(
 ) *Service {
 	store := autoindexingstore.New(scopedContext("store", observationCtx), db)
 	inferenceSvc := inference.NewService(db)
+	dependencyResolver := dependencies.NewDependencyResolver(depsSvc, store, db.ExternalServices())
 
 	return newService(
 		scopedContext("service", observationCtx),
@@ -35,6 +36,7 @@ func NewService(
 		inferenceSvc,
 		db.Repos(),
 		gitserverClient,
+		dependencyResolver,
 	)
 }
 
@@ -80,6 +82,7 @@ func NewDependencyIndexSchedulers(
 		autoindexingSvc.store,
 		autoindexingSvc.indexEnqueuer,
 		repoUpdater,
+		autoindexingSvc.gitserverClient,
 		DependenciesConfigInst,
 	)
 }
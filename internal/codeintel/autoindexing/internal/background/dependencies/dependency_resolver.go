@@ -0,0 +1,132 @@
+package dependencies
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/packagefilters"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DependencyResolver queues the resolution of individual dependency packages, such as
+// a package defining a symbol for which no precise definition exists on the instance.
+type DependencyResolver struct {
+	depsSvc     DependenciesService
+	store       store.Store
+	extsvcStore ExternalServiceStore
+	logger      log.Logger
+}
+
+func NewDependencyResolver(
+	depsSvc DependenciesService,
+	store store.Store,
+	externalServiceStore ExternalServiceStore,
+) *DependencyResolver {
+	return &DependencyResolver{
+		depsSvc:     depsSvc,
+		store:       store,
+		extsvcStore: externalServiceStore,
+		logger:      log.Scoped("dependencyResolver"),
+	}
+}
+
+// ResolvePackages queues a dependency indexing job for each of the given packages referenced
+// by the given upload. Packages hosted by a package host external service are added to the
+// instance as package repo references and the corresponding external services are scheduled
+// to sync. The dependency indexing scheduler clones the resulting repositories and queues
+// auto-indexing jobs for them. Packages that already have a pending job are skipped.
+func (r *DependencyResolver) ResolvePackages(ctx context.Context, uploadID int, pkgs []uploadsshared.Package) error {
+	var (
+		nextSync = time.Now()
+		kinds    = map[string]struct{}{}
+		queued   []precise.Package
+		errs     []error
+	)
+
+	for _, pkg := range pkgs {
+		pkgRef, err := newPackage(pkg)
+		if err != nil {
+			r.logger.Warn("requested package was invalid",
+				log.Error(err),
+				log.String("name", pkg.Name),
+				log.String("version", pkg.Version))
+			continue
+		}
+
+		extsvcKind := schemeToExternalService[pkgRef.Scheme]
+		_, inserted, err := r.store.InsertDependencyIndexingJobForPackage(ctx, uploadID, dependencies.MinimialVersionedPackageRepo{
+			Scheme:  pkgRef.Scheme,
+			Name:    reposource.PackageName(pkgRef.Name),
+			Version: pkgRef.Version,
+		}, extsvcKind, nextSync)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "dbstore.InsertDependencyIndexingJobForPackage"))
+			continue
+		}
+		if !inserted || extsvcKind == "" {
+			continue
+		}
+
+		kinds[extsvcKind] = struct{}{}
+		queued = append(queued, *pkgRef)
+	}
+
+	if len(queued) > 0 {
+		if err := r.syncPackageRepos(ctx, queued, kinds, nextSync); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	return errors.Append(nil, errs...)
+}
+
+// syncPackageRepos inserts package repo references for the given packages and schedules the
+// external services of the given kinds to sync at the given time.
+func (r *DependencyResolver) syncPackageRepos(ctx context.Context, pkgs []precise.Package, kinds map[string]struct{}, nextSync time.Time) error {
+	pkgFilters, _, err := r.depsSvc.ListPackageRepoFilters(ctx, dependencies.ListPackageRepoRefFiltersOpts{})
+	if err != nil {
+		return errors.Wrap(err, "error listing package repo filters")
+	}
+	packageFilters, err := packagefilters.NewFilterLists(pkgFilters)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range pkgs {
+		if _, _, err := insertPackageRepoRef(ctx, r.depsSvc, pkg, packageFilters, nextSync); err != nil {
+			return err
+		}
+	}
+
+	externalServices, err := r.extsvcStore.List(ctx, database.ExternalServicesListOptions{
+		Kinds: kindsToArray(kinds),
+	})
+	if err != nil {
+		return errors.Wrap(err, "dbstore.List")
+	}
+
+	for _, externalService := range externalServices {
+		externalService.NextSyncAt = nextSync
+		if err := r.extsvcStore.Upsert(ctx, externalService); err != nil {
+			return errors.Wrapf(err, "extsvcStore.Upsert: error setting next_sync_at for external service %d - %s", externalService.ID, externalService.DisplayName)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package dependencies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDependencyResolver(t *testing.T) {
+	mockDepedenciesSvc := NewMockDependenciesService()
+	mockStore := NewMockStore()
+	mockExtsvcStore := NewMockExternalServiceStore()
+
+	// The second package already has a pending job
+	mockStore.InsertDependencyIndexingJobForPackageFunc.PushReturn(1, true, nil)
+	mockStore.InsertDependencyIndexingJobForPackageFunc.PushReturn(0, false, nil)
+	mockStore.InsertDependencyIndexingJobForPackageFunc.PushReturn(2, true, nil)
+	mockExtsvcStore.ListFunc.SetDefaultReturn([]*types.ExternalService{{ID: 1, Kind: extsvc.KindNpmPackages}}, nil)
+
+	resolver := NewDependencyResolver(mockDepedenciesSvc, mockStore, mockExtsvcStore)
+	if err := resolver.ResolvePackages(context.Background(), 42, []shared.Package{
+		{Scheme: "scip-typescript", Manager: "npm", Name: "left-pad", Version: "1.3.0"},
+		{Scheme: "scip-typescript", Manager: "npm", Name: "right-pad", Version: "1.0.0"},
+		{Scheme: "gomod", Name: "https://github.com/sample/text", Version: "v2.2.0"},
+	}); err != nil {
+		t.Fatalf("unexpected error resolving packages: %s", err)
+	}
+
+	var packages []dependencies.MinimialVersionedPackageRepo
+	var kinds []string
+	for _, call := range mockStore.InsertDependencyIndexingJobForPackageFunc.History() {
+		packages = append(packages, call.Arg2)
+		kinds = append(kinds, call.Arg3)
+	}
+	expectedPackages := []dependencies.MinimialVersionedPackageRepo{
+		{Scheme: dependencies.NpmPackagesScheme, Name: "left-pad", Version: "1.3.0"},
+		{Scheme: dependencies.NpmPackagesScheme, Name: "right-pad", Version: "1.0.0"},
+		{Scheme: "gomod", Name: "https://github.com/sample/text", Version: "v2.2.0"},
+	}
+	if diff := cmp.Diff(expectedPackages, packages); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{extsvc.KindNpmPackages, extsvc.KindNpmPackages, ""}, kinds); diff != "" {
+		t.Errorf("unexpected kinds (-want +got):\n%s", diff)
+	}
+
+	// Only the newly queued package hosted by an external service is synced
+	if len(mockDepedenciesSvc.InsertPackageRepoRefsFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to InsertPackageRepoRefs. want=%d have=%d", 1, len(mockDepedenciesSvc.InsertPackageRepoRefsFunc.History()))
+	} else if name := mockDepedenciesSvc.InsertPackageRepoRefsFunc.History()[0].Arg1[0].Name; name != "left-pad" {
+		t.Errorf("unexpected package repo ref. want=%q have=%q", "left-pad", name)
+	}
+	if len(mockExtsvcStore.UpsertFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to extsvcStore.Upsert. want=%d have=%d", 1, len(mockExtsvcStore.UpsertFunc.History()))
+	}
+}
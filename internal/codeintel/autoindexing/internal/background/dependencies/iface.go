@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -21,6 +22,10 @@ type GitserverRepoStore interface {
 	GetByNames(ctx context.Context, names ...api.RepoName) (map[api.RepoName]*types.GitserverRepo, error)
 }
 
+type GitserverClient interface {
+	RequestRepoClone(ctx context.Context, repo api.RepoName) (*gitserverprotocol.RepoCloneResponse, error)
+}
+
 type ExternalServiceStore interface {
 	Upsert(ctx context.Context, svcs ...*types.ExternalService) (err error)
 	List(ctx context.Context, opt database.ExternalServicesListOptions) ([]*types.ExternalService, error)
@@ -37,6 +37,7 @@ func NewDependencyIndexingScheduler(
 	gitserverRepoStore GitserverRepoStore,
 	indexEnqueuer IndexEnqueuer,
 	repoUpdater RepoUpdaterClient,
+	gitserverClient GitserverClient,
 	metrics workerutil.WorkerObservability,
 	config *Config,
 ) *workerutil.Worker[dependencyIndexingJob] {
@@ -50,6 +51,7 @@ func NewDependencyIndexingScheduler(
 		indexEnqueuer:      indexEnqueuer,
 		workerStore:        dependencyIndexingStore,
 		repoUpdater:        repoUpdater,
+		gitserverClient:    gitserverClient,
 	}
 
 	return dbworker.NewWorker[dependencyIndexingJob](rootContext, dependencyIndexingStore, handler, workerutil.WorkerOptions{
@@ -70,6 +72,7 @@ type dependencyIndexingSchedulerHandler struct {
 	gitserverRepoStore GitserverRepoStore
 	workerStore        dbworkerstore.Store[dependencyIndexingJob]
 	repoUpdater        RepoUpdaterClient
+	gitserverClient    GitserverClient
 }
 
 const requeueBackoff = time.Second * 30
@@ -82,7 +85,8 @@ var _ workerutil.Handler[dependencyIndexingJob] = &dependencyIndexingSchedulerHa
 // Handle iterates all import monikers associated with a given upload that has
 // recently completed processing. Each moniker is interpreted according to its
 // scheme to determine the dependent repository and commit. A set of indexing
-// jobs are enqueued for each repository and commit pair.
+// jobs are enqueued for each repository and commit pair. Jobs queued for a
+// single package only consider that package, and clone its repository on demand.
 func (h *dependencyIndexingSchedulerHandler) Handle(ctx context.Context, logger log.Logger, job dependencyIndexingJob) error {
 	if !autoIndexingEnabled() || disableIndexScheduler {
 		return nil
@@ -124,33 +128,14 @@ func (h *dependencyIndexingSchedulerHandler) Handle(ctx context.Context, logger
 		}
 	}
 
-	scanner, err := h.uploadsSvc.ReferencesForUpload(ctx, job.UploadID)
+	pkgs, err := h.packagesForJob(ctx, job)
 	if err != nil {
-		return errors.Wrap(err, "dbstore.ReferencesForUpload")
+		return err
 	}
-	defer func() {
-		if closeErr := scanner.Close(); closeErr != nil {
-			err = errors.Append(err, errors.Wrap(closeErr, "dbstore.ReferencesForUpload.Close"))
-		}
-	}()
 
 	repoToPackages := make(map[api.RepoName][]dependencies.MinimialVersionedPackageRepo)
 	var repoNames []api.RepoName
-	for {
-		packageReference, exists, err := scanner.Next()
-		if err != nil {
-			return errors.Wrap(err, "dbstore.ReferencesForUpload.Next")
-		}
-		if !exists {
-			break
-		}
-
-		pkg := dependencies.MinimialVersionedPackageRepo{
-			Scheme:  packageReference.Scheme,
-			Name:    reposource.PackageName(packageReference.Name),
-			Version: packageReference.Version,
-		}
-
+	for _, pkg := range pkgs {
 		repoName, _, ok := inference.InferRepositoryAndRevision(pkg)
 		if !ok {
 			continue
@@ -184,11 +169,11 @@ func (h *dependencyIndexingSchedulerHandler) Handle(ctx context.Context, logger
 			listedRepoNames = append(listedRepoNames, repo.Name)
 		}
 
-		// for any repos that are not known to the instance, we need to sync them if on dot-com,
-		// otherwise skip them.
+		// for any repos that are not known to the instance, we need to sync them if on dot-com
+		// or if the package was explicitly requested, otherwise skip them.
 		difference := setDifference(repoNames, listedRepoNames)
 
-		if envvar.SourcegraphDotComMode() {
+		if envvar.SourcegraphDotComMode() || isPackageJob(job) {
 			for _, repo := range difference {
 				if _, err := h.repoUpdater.RepoLookup(ctx, protocol.RepoLookupArgs{Repo: repo}); errcode.IsNotFound(err) {
 					delete(repoToPackages, repo)
@@ -210,6 +195,14 @@ func (h *dependencyIndexingSchedulerHandler) Handle(ctx context.Context, logger
 
 	for _, repoName := range repoNames {
 		repoInfo, ok := results[repoName]
+		if ok && repoInfo.CloneStatus == types.CloneStatusNotCloned && isPackageJob(job) {
+			// an explicitly requested package is cloned on demand rather than skipped
+			if _, err := h.gitserverClient.RequestRepoClone(ctx, repoName); err != nil {
+				return errors.Wrap(err, "gitserver.RequestRepoClone")
+			}
+			return h.workerStore.Requeue(ctx, job.ID, time.Now().Add(requeueBackoff))
+		}
+
 		if !ok || repoInfo.CloneStatus != types.CloneStatusCloned && repoInfo.CloneStatus != types.CloneStatusCloning {
 			delete(repoToPackages, repoName)
 		} else if repoInfo.CloneStatus == types.CloneStatusCloning { // we can't enqueue if still cloning
@@ -237,6 +230,53 @@ func (h *dependencyIndexingSchedulerHandler) Handle(ctx context.Context, logger
 	return errors.Append(nil, errs...)
 }
 
+// packagesForJob returns the packages whose repositories should be indexed for the given job. This
+// is the single package requested by the job, if any, or every package referenced by the job's upload.
+func (h *dependencyIndexingSchedulerHandler) packagesForJob(ctx context.Context, job dependencyIndexingJob) (_ []dependencies.MinimialVersionedPackageRepo, err error) {
+	if isPackageJob(job) {
+		return []dependencies.MinimialVersionedPackageRepo{{
+			Scheme:  job.PackageScheme,
+			Name:    reposource.PackageName(job.PackageName),
+			Version: job.PackageVersion,
+		}}, nil
+	}
+
+	scanner, err := h.uploadsSvc.ReferencesForUpload(ctx, job.UploadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbstore.ReferencesForUpload")
+	}
+	defer func() {
+		if closeErr := scanner.Close(); closeErr != nil {
+			err = errors.Append(err, errors.Wrap(closeErr, "dbstore.ReferencesForUpload.Close"))
+		}
+	}()
+
+	var pkgs []dependencies.MinimialVersionedPackageRepo
+	for {
+		packageReference, exists, err := scanner.Next()
+		if err != nil {
+			return nil, errors.Wrap(err, "dbstore.ReferencesForUpload.Next")
+		}
+		if !exists {
+			break
+		}
+
+		pkgs = append(pkgs, dependencies.MinimialVersionedPackageRepo{
+			Scheme:  packageReference.Scheme,
+			Name:    reposource.PackageName(packageReference.Name),
+			Version: packageReference.Version,
+		})
+	}
+
+	return pkgs, nil
+}
+
+// isPackageJob returns true if the given job was queued for a single package (e.g., one whose
+// definitions were requested by a code navigation request) rather than all references of an upload.
+func isPackageJob(job dependencyIndexingJob) bool {
+	return job.PackageScheme != ""
+}
+
 // Returns the set of elements in superset that are not in subset
 // invariants:
 //   - superset is, of course, a superset of subset.
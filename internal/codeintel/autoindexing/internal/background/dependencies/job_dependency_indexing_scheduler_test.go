@@ -436,3 +436,77 @@ func TestDependencyIndexingSchedulerHandlerNoExtsvc(t *testing.T) {
 		t.Errorf("unexpected number of calls to QueueIndexesForPackage. want=%d have=%d", 0, len(indexEnqueuer.QueueIndexesForPackageFunc.History()))
 	}
 }
+
+func TestDependencyIndexingSchedulerHandlerPackageJob(t *testing.T) {
+	mockUploadsSvc := NewMockUploadService()
+	mockRepoStore := NewMockReposStore()
+	mockExtSvcStore := NewMockExternalServiceStore()
+	mockRepoUpdater := NewMockRepoUpdaterClient()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverReposStore := NewMockGitserverRepoStore()
+	mockWorkerStore := NewMockWorkerStore[dependencyIndexingJob]()
+	indexEnqueuer := NewMockIndexEnqueuer()
+
+	// The repository is not yet known to the instance and has not been cloned
+	mockGitserverReposStore.GetByNamesFunc.PushReturn(map[api.RepoName]*types.GitserverRepo{
+		"github.com/sample/text": {CloneStatus: types.CloneStatusNotCloned},
+	}, nil)
+	mockGitserverReposStore.GetByNamesFunc.PushReturn(map[api.RepoName]*types.GitserverRepo{
+		"github.com/sample/text": {CloneStatus: types.CloneStatusCloned},
+	}, nil)
+
+	envvar.MockSourcegraphDotComMode(false)
+
+	handler := &dependencyIndexingSchedulerHandler{
+		uploadsSvc:         mockUploadsSvc,
+		repoStore:          mockRepoStore,
+		indexEnqueuer:      indexEnqueuer,
+		extsvcStore:        mockExtSvcStore,
+		workerStore:        mockWorkerStore,
+		gitserverRepoStore: mockGitserverReposStore,
+		repoUpdater:        mockRepoUpdater,
+		gitserverClient:    mockGitserverClient,
+	}
+
+	job := dependencyIndexingJob{
+		ID:             23,
+		UploadID:       42,
+		PackageScheme:  "gomod",
+		PackageName:    "https://github.com/sample/text",
+		PackageVersion: "v2.2.0",
+	}
+	logger := logtest.Scoped(t)
+	if err := handler.Handle(context.Background(), logger, job); err != nil {
+		t.Fatalf("unexpected error performing update: %s", err)
+	}
+
+	if len(mockUploadsSvc.ReferencesForUploadFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to ReferencesForUpload. want=%d have=%d", 0, len(mockUploadsSvc.ReferencesForUploadFunc.History()))
+	}
+	if len(mockRepoUpdater.RepoLookupFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to RepoLookup. want=%d have=%d", 1, len(mockRepoUpdater.RepoLookupFunc.History()))
+	}
+	if len(mockGitserverClient.RequestRepoCloneFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to RequestRepoClone. want=%d have=%d", 1, len(mockGitserverClient.RequestRepoCloneFunc.History()))
+	}
+	if len(mockWorkerStore.RequeueFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to Requeue. want=%d have=%d", 1, len(mockWorkerStore.RequeueFunc.History()))
+	}
+	if len(indexEnqueuer.QueueIndexesForPackageFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to QueueIndexesForPackage. want=%d have=%d", 0, len(indexEnqueuer.QueueIndexesForPackageFunc.History()))
+	}
+
+	// Once cloned, only the requested package is indexed
+	if err := handler.Handle(context.Background(), logger, job); err != nil {
+		t.Fatalf("unexpected error performing update: %s", err)
+	}
+
+	if len(indexEnqueuer.QueueIndexesForPackageFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to QueueIndexesForPackage. want=%d have=%d", 1, len(indexEnqueuer.QueueIndexesForPackageFunc.History()))
+	} else {
+		expectedPackage := dependencies.MinimialVersionedPackageRepo{Scheme: "gomod", Name: "https://github.com/sample/text", Version: "v2.2.0"}
+		if diff := cmp.Diff(expectedPackage, indexEnqueuer.QueueIndexesForPackageFunc.History()[0].Arg1); diff != "" {
+			t.Errorf("unexpected package (-want +got):\n%s", diff)
+		}
+	}
+}
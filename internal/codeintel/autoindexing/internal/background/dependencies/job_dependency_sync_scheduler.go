@@ -138,7 +138,7 @@ func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.
 			continue
 		}
 
-		newRepo, newVersion, err := h.insertPackageRepoRef(ctx, pkg, packageFilters, instant)
+		newRepo, newVersion, err := insertPackageRepoRef(ctx, h.depsSvc, pkg, packageFilters, instant)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -247,8 +247,8 @@ func newPackage(pkg uploadsshared.Package) (*precise.Package, error) {
 	return &p, nil
 }
 
-func (h *dependencySyncSchedulerHandler) insertPackageRepoRef(ctx context.Context, pkg precise.Package, filters packagefilters.PackageFilters, instant time.Time) (newRepos, newVersions bool, err error) {
-	insertedRepos, insertedVersions, err := h.depsSvc.InsertPackageRepoRefs(ctx, []dependencies.MinimalPackageRepoRef{
+func insertPackageRepoRef(ctx context.Context, depsSvc DependenciesService, pkg precise.Package, filters packagefilters.PackageFilters, instant time.Time) (newRepos, newVersions bool, err error) {
+	insertedRepos, insertedVersions, err := depsSvc.InsertPackageRepoRefs(ctx, []dependencies.MinimalPackageRepoRef{
 		{
 			Name:          reposource.PackageName(pkg.Name),
 			Scheme:        pkg.Scheme,
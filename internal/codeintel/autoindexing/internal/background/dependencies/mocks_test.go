@@ -21,10 +21,12 @@ import (
 	database "github.com/sourcegraph/sourcegraph/internal/database"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	executor "github.com/sourcegraph/sourcegraph/internal/executor"
-	protocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	protocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	protocol1 "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	types "github.com/sourcegraph/sourcegraph/internal/types"
 	workerutil "github.com/sourcegraph/sourcegraph/internal/workerutil"
 	store1 "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	trace "go.opentelemetry.io/otel/trace"
 )
 
 // MockDependenciesService is a mock implementation of the
@@ -603,6 +605,163 @@ func (c ExternalServiceStoreUpsertFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockGitserverClient is a mock implementation of the GitserverClient
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/background/dependencies)
+// used for unit testing.
+type MockGitserverClient struct {
+	// RequestRepoCloneFunc is an instance of a mock function object
+	// controlling the behavior of the method RequestRepoClone.
+	RequestRepoCloneFunc *GitserverClientRequestRepoCloneFunc
+}
+
+// NewMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		RequestRepoCloneFunc: &GitserverClientRequestRepoCloneFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 *protocol.RepoCloneResponse, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		RequestRepoCloneFunc: &GitserverClientRequestRepoCloneFunc{
+			defaultHook: func(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error) {
+				panic("unexpected invocation of MockGitserverClient.RequestRepoClone")
+			},
+		},
+	}
+}
+
+// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
+	return &MockGitserverClient{
+		RequestRepoCloneFunc: &GitserverClientRequestRepoCloneFunc{
+			defaultHook: i.RequestRepoClone,
+		},
+	}
+}
+
+// GitserverClientRequestRepoCloneFunc describes the behavior when the
+// RequestRepoClone method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientRequestRepoCloneFunc struct {
+	defaultHook func(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error)
+	hooks       []func(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error)
+	history     []GitserverClientRequestRepoCloneFuncCall
+	mutex       sync.Mutex
+}
+
+// RequestRepoClone delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) RequestRepoClone(v0 context.Context, v1 api.RepoName) (*protocol.RepoCloneResponse, error) {
+	r0, r1 := m.RequestRepoCloneFunc.nextHook()(v0, v1)
+	m.RequestRepoCloneFunc.appendCall(GitserverClientRequestRepoCloneFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RequestRepoClone
+// method of the parent MockGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *GitserverClientRequestRepoCloneFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RequestRepoClone method of the parent MockGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverClientRequestRepoCloneFunc) PushHook(hook func(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientRequestRepoCloneFunc) SetDefaultReturn(r0 *protocol.RepoCloneResponse, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientRequestRepoCloneFunc) PushReturn(r0 *protocol.RepoCloneResponse, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientRequestRepoCloneFunc) nextHook() func(context.Context, api.RepoName) (*protocol.RepoCloneResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientRequestRepoCloneFunc) appendCall(r0 GitserverClientRequestRepoCloneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientRequestRepoCloneFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientRequestRepoCloneFunc) History() []GitserverClientRequestRepoCloneFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientRequestRepoCloneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientRequestRepoCloneFuncCall is an object that describes an
+// invocation of method RequestRepoClone on an instance of
+// MockGitserverClient.
+type GitserverClientRequestRepoCloneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *protocol.RepoCloneResponse
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientRequestRepoCloneFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientRequestRepoCloneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockGitserverRepoStore is a mock implementation of the GitserverRepoStore
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/background/dependencies)
@@ -1071,7 +1230,7 @@ type MockRepoUpdaterClient struct {
 func NewMockRepoUpdaterClient() *MockRepoUpdaterClient {
 	return &MockRepoUpdaterClient{
 		RepoLookupFunc: &RepoUpdaterClientRepoLookupFunc{
-			defaultHook: func(context.Context, protocol.RepoLookupArgs) (r0 *protocol.RepoLookupResult, r1 error) {
+			defaultHook: func(context.Context, protocol1.RepoLookupArgs) (r0 *protocol1.RepoLookupResult, r1 error) {
 				return
 			},
 		},
@@ -1084,7 +1243,7 @@ func NewMockRepoUpdaterClient() *MockRepoUpdaterClient {
 func NewStrictMockRepoUpdaterClient() *MockRepoUpdaterClient {
 	return &MockRepoUpdaterClient{
 		RepoLookupFunc: &RepoUpdaterClientRepoLookupFunc{
-			defaultHook: func(context.Context, protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error) {
+			defaultHook: func(context.Context, protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error) {
 				panic("unexpected invocation of MockRepoUpdaterClient.RepoLookup")
 			},
 		},
@@ -1106,15 +1265,15 @@ func NewMockRepoUpdaterClientFrom(i RepoUpdaterClient) *MockRepoUpdaterClient {
 // RepoLookup method of the parent MockRepoUpdaterClient instance is
 // invoked.
 type RepoUpdaterClientRepoLookupFunc struct {
-	defaultHook func(context.Context, protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error)
-	hooks       []func(context.Context, protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error)
+	defaultHook func(context.Context, protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error)
+	hooks       []func(context.Context, protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error)
 	history     []RepoUpdaterClientRepoLookupFuncCall
 	mutex       sync.Mutex
 }
 
 // RepoLookup delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockRepoUpdaterClient) RepoLookup(v0 context.Context, v1 protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error) {
+func (m *MockRepoUpdaterClient) RepoLookup(v0 context.Context, v1 protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error) {
 	r0, r1 := m.RepoLookupFunc.nextHook()(v0, v1)
 	m.RepoLookupFunc.appendCall(RepoUpdaterClientRepoLookupFuncCall{v0, v1, r0, r1})
 	return r0, r1
@@ -1123,7 +1282,7 @@ func (m *MockRepoUpdaterClient) RepoLookup(v0 context.Context, v1 protocol.RepoL
 // SetDefaultHook sets function that is called when the RepoLookup method of
 // the parent MockRepoUpdaterClient instance is invoked and the hook queue
 // is empty.
-func (f *RepoUpdaterClientRepoLookupFunc) SetDefaultHook(hook func(context.Context, protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error)) {
+func (f *RepoUpdaterClientRepoLookupFunc) SetDefaultHook(hook func(context.Context, protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error)) {
 	f.defaultHook = hook
 }
 
@@ -1131,7 +1290,7 @@ func (f *RepoUpdaterClientRepoLookupFunc) SetDefaultHook(hook func(context.Conte
 // RepoLookup method of the parent MockRepoUpdaterClient instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *RepoUpdaterClientRepoLookupFunc) PushHook(hook func(context.Context, protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error)) {
+func (f *RepoUpdaterClientRepoLookupFunc) PushHook(hook func(context.Context, protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1139,20 +1298,20 @@ func (f *RepoUpdaterClientRepoLookupFunc) PushHook(hook func(context.Context, pr
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *RepoUpdaterClientRepoLookupFunc) SetDefaultReturn(r0 *protocol.RepoLookupResult, r1 error) {
-	f.SetDefaultHook(func(context.Context, protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error) {
+func (f *RepoUpdaterClientRepoLookupFunc) SetDefaultReturn(r0 *protocol1.RepoLookupResult, r1 error) {
+	f.SetDefaultHook(func(context.Context, protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *RepoUpdaterClientRepoLookupFunc) PushReturn(r0 *protocol.RepoLookupResult, r1 error) {
-	f.PushHook(func(context.Context, protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error) {
+func (f *RepoUpdaterClientRepoLookupFunc) PushReturn(r0 *protocol1.RepoLookupResult, r1 error) {
+	f.PushHook(func(context.Context, protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error) {
 		return r0, r1
 	})
 }
 
-func (f *RepoUpdaterClientRepoLookupFunc) nextHook() func(context.Context, protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error) {
+func (f *RepoUpdaterClientRepoLookupFunc) nextHook() func(context.Context, protocol1.RepoLookupArgs) (*protocol1.RepoLookupResult, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1190,10 +1349,10 @@ type RepoUpdaterClientRepoLookupFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 protocol.RepoLookupArgs
+	Arg1 protocol1.RepoLookupArgs
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *protocol.RepoLookupResult
+	Result0 *protocol1.RepoLookupResult
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -1940,6 +2099,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// InsertDependencyIndexingJob.
 	InsertDependencyIndexingJobFunc *StoreInsertDependencyIndexingJobFunc
+	// InsertDependencyIndexingJobForPackageFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// InsertDependencyIndexingJobForPackage.
+	InsertDependencyIndexingJobForPackageFunc *StoreInsertDependencyIndexingJobForPackageFunc
 	// InsertIndexesFunc is an instance of a mock function object
 	// controlling the behavior of the method InsertIndexes.
 	InsertIndexesFunc *StoreInsertIndexesFunc
@@ -2022,6 +2185,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertDependencyIndexingJobForPackageFunc: &StoreInsertDependencyIndexingJobForPackageFunc{
+			defaultHook: func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (r0 int, r1 bool, r2 error) {
+				return
+			},
+		},
 		InsertIndexesFunc: &StoreInsertIndexesFunc{
 			defaultHook: func(context.Context, []shared1.Index) (r0 []shared1.Index, r1 error) {
 				return
@@ -2129,6 +2297,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertDependencyIndexingJob")
 			},
 		},
+		InsertDependencyIndexingJobForPackageFunc: &StoreInsertDependencyIndexingJobForPackageFunc{
+			defaultHook: func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error) {
+				panic("unexpected invocation of MockStore.InsertDependencyIndexingJobForPackage")
+			},
+		},
 		InsertIndexesFunc: &StoreInsertIndexesFunc{
 			defaultHook: func(context.Context, []shared1.Index) ([]shared1.Index, error) {
 				panic("unexpected invocation of MockStore.InsertIndexes")
@@ -2224,6 +2397,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		InsertDependencyIndexingJobFunc: &StoreInsertDependencyIndexingJobFunc{
 			defaultHook: i.InsertDependencyIndexingJob,
 		},
+		InsertDependencyIndexingJobForPackageFunc: &StoreInsertDependencyIndexingJobForPackageFunc{
+			defaultHook: i.InsertDependencyIndexingJobForPackage,
+		},
 		InsertIndexesFunc: &StoreInsertIndexesFunc{
 			defaultHook: i.InsertIndexes,
 		},
@@ -2949,6 +3125,131 @@ func (c StoreInsertDependencyIndexingJobFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreInsertDependencyIndexingJobForPackageFunc describes the behavior
+// when the InsertDependencyIndexingJobForPackage method of the parent
+// MockStore instance is invoked.
+type StoreInsertDependencyIndexingJobForPackageFunc struct {
+	defaultHook func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error)
+	hooks       []func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error)
+	history     []StoreInsertDependencyIndexingJobForPackageFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDependencyIndexingJobForPackage delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockStore) InsertDependencyIndexingJobForPackage(v0 context.Context, v1 int, v2 shared.MinimialVersionedPackageRepo, v3 string, v4 time.Time) (int, bool, error) {
+	r0, r1, r2 := m.InsertDependencyIndexingJobForPackageFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertDependencyIndexingJobForPackageFunc.appendCall(StoreInsertDependencyIndexingJobForPackageFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertDependencyIndexingJobForPackage method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) SetDefaultHook(hook func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDependencyIndexingJobForPackage method of the parent MockStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) PushHook(hook func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) SetDefaultReturn(r0 int, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) PushReturn(r0 int, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) nextHook() func(context.Context, int, shared.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) appendCall(r0 StoreInsertDependencyIndexingJobForPackageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreInsertDependencyIndexingJobForPackageFuncCall objects describing the
+// invocations of this function.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) History() []StoreInsertDependencyIndexingJobForPackageFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertDependencyIndexingJobForPackageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertDependencyIndexingJobForPackageFuncCall is an object that
+// describes an invocation of method InsertDependencyIndexingJobForPackage
+// on an instance of MockStore.
+type StoreInsertDependencyIndexingJobForPackageFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 shared.MinimialVersionedPackageRepo
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertDependencyIndexingJobForPackageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertDependencyIndexingJobForPackageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreInsertIndexesFunc describes the behavior when the InsertIndexes
 // method of the parent MockStore instance is invoked.
 type StoreInsertIndexesFunc struct {
@@ -4498,9 +4799,19 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *WorkerStoreAddExecutionLogEntryFunc[T]
+	// AppendExecutionLogEntryOutputFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// AppendExecutionLogEntryOutput.
+	AppendExecutionLogEntryOutputFunc *WorkerStoreAppendExecutionLogEntryOutputFunc[T]
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *WorkerStoreDequeueFunc[T]
+	// ExecutionLogsFunc is an instance of a mock function object
+	// controlling the behavior of the method ExecutionLogs.
+	ExecutionLogsFunc *WorkerStoreExecutionLogsFunc[T]
+	// FinishedCountFunc is an instance of a mock function object
+	// controlling the behavior of the method FinishedCount.
+	FinishedCountFunc *WorkerStoreFinishedCountFunc[T]
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *WorkerStoreHandleFunc[T]
@@ -4510,6 +4821,9 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// MarkCompleteFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkComplete.
 	MarkCompleteFunc *WorkerStoreMarkCompleteFunc[T]
+	// MarkDeadlineExceededFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkDeadlineExceeded.
+	MarkDeadlineExceededFunc *WorkerStoreMarkDeadlineExceededFunc[T]
 	// MarkErroredFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkErrored.
 	MarkErroredFunc *WorkerStoreMarkErroredFunc[T]
@@ -4519,6 +4833,9 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// MaxDurationInQueueFunc is an instance of a mock function object
 	// controlling the behavior of the method MaxDurationInQueue.
 	MaxDurationInQueueFunc *WorkerStoreMaxDurationInQueueFunc[T]
+	// ProcessingCountFunc is an instance of a mock function object
+	// controlling the behavior of the method ProcessingCount.
+	ProcessingCountFunc *WorkerStoreProcessingCountFunc[T]
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *WorkerStoreQueuedCountFunc[T]
@@ -4528,6 +4845,9 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// ResetStalledFunc is an instance of a mock function object controlling
 	// the behavior of the method ResetStalled.
 	ResetStalledFunc *WorkerStoreResetStalledFunc[T]
+	// TraceContextFunc is an instance of a mock function object controlling
+	// the behavior of the method TraceContext.
+	TraceContextFunc *WorkerStoreTraceContextFunc[T]
 	// UpdateExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateExecutionLogEntry.
 	UpdateExecutionLogEntryFunc *WorkerStoreUpdateExecutionLogEntryFunc[T]
@@ -4545,11 +4865,26 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &WorkerStoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) (r0 error) {
+				return
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (r0 T, r1 bool, r2 error) {
 				return
 			},
 		},
+		ExecutionLogsFunc: &WorkerStoreExecutionLogsFunc[T]{
+			defaultHook: func(context.Context, int) (r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+				return
+			},
+		},
+		FinishedCountFunc: &WorkerStoreFinishedCountFunc[T]{
+			defaultHook: func(context.Context, time.Time) (r0 int, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
@@ -4565,6 +4900,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		MarkDeadlineExceededFunc: &WorkerStoreMarkDeadlineExceededFunc[T]{
+			defaultHook: func(context.Context) (r0 []int, r1 error) {
+				return
+			},
+		},
 		MarkErroredFunc: &WorkerStoreMarkErroredFunc[T]{
 			defaultHook: func(context.Context, int, string, store1.MarkFinalOptions) (r0 bool, r1 error) {
 				return
@@ -4580,6 +4920,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		ProcessingCountFunc: &WorkerStoreProcessingCountFunc[T]{
+			defaultHook: func(context.Context) (r0 int, r1 error) {
+				return
+			},
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (r0 int, r1 error) {
 				return
@@ -4595,6 +4940,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		TraceContextFunc: &WorkerStoreTraceContextFunc[T]{
+			defaultHook: func(context.Context, int) (r0 trace.SpanContext, r1 error) {
+				return
+			},
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (r0 error) {
 				return
@@ -4617,11 +4967,26 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.AddExecutionLogEntry")
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &WorkerStoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error {
+				panic("unexpected invocation of MockWorkerStore.AppendExecutionLogEntryOutput")
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (T, bool, error) {
 				panic("unexpected invocation of MockWorkerStore.Dequeue")
 			},
 		},
+		ExecutionLogsFunc: &WorkerStoreExecutionLogsFunc[T]{
+			defaultHook: func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+				panic("unexpected invocation of MockWorkerStore.ExecutionLogs")
+			},
+		},
+		FinishedCountFunc: &WorkerStoreFinishedCountFunc[T]{
+			defaultHook: func(context.Context, time.Time) (int, error) {
+				panic("unexpected invocation of MockWorkerStore.FinishedCount")
+			},
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockWorkerStore.Handle")
@@ -4637,6 +5002,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.MarkComplete")
 			},
 		},
+		MarkDeadlineExceededFunc: &WorkerStoreMarkDeadlineExceededFunc[T]{
+			defaultHook: func(context.Context) ([]int, error) {
+				panic("unexpected invocation of MockWorkerStore.MarkDeadlineExceeded")
+			},
+		},
 		MarkErroredFunc: &WorkerStoreMarkErroredFunc[T]{
 			defaultHook: func(context.Context, int, string, store1.MarkFinalOptions) (bool, error) {
 				panic("unexpected invocation of MockWorkerStore.MarkErrored")
@@ -4652,6 +5022,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.MaxDurationInQueue")
 			},
 		},
+		ProcessingCountFunc: &WorkerStoreProcessingCountFunc[T]{
+			defaultHook: func(context.Context) (int, error) {
+				panic("unexpected invocation of MockWorkerStore.ProcessingCount")
+			},
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (int, error) {
 				panic("unexpected invocation of MockWorkerStore.QueuedCount")
@@ -4667,6 +5042,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.ResetStalled")
 			},
 		},
+		TraceContextFunc: &WorkerStoreTraceContextFunc[T]{
+			defaultHook: func(context.Context, int) (trace.SpanContext, error) {
+				panic("unexpected invocation of MockWorkerStore.TraceContext")
+			},
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) error {
 				panic("unexpected invocation of MockWorkerStore.UpdateExecutionLogEntry")
@@ -4688,9 +5068,18 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		AddExecutionLogEntryFunc: &WorkerStoreAddExecutionLogEntryFunc[T]{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		AppendExecutionLogEntryOutputFunc: &WorkerStoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: i.AppendExecutionLogEntryOutput,
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: i.Dequeue,
 		},
+		ExecutionLogsFunc: &WorkerStoreExecutionLogsFunc[T]{
+			defaultHook: i.ExecutionLogs,
+		},
+		FinishedCountFunc: &WorkerStoreFinishedCountFunc[T]{
+			defaultHook: i.FinishedCount,
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: i.Handle,
 		},
@@ -4700,6 +5089,9 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		MarkCompleteFunc: &WorkerStoreMarkCompleteFunc[T]{
 			defaultHook: i.MarkComplete,
 		},
+		MarkDeadlineExceededFunc: &WorkerStoreMarkDeadlineExceededFunc[T]{
+			defaultHook: i.MarkDeadlineExceeded,
+		},
 		MarkErroredFunc: &WorkerStoreMarkErroredFunc[T]{
 			defaultHook: i.MarkErrored,
 		},
@@ -4709,6 +5101,9 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		MaxDurationInQueueFunc: &WorkerStoreMaxDurationInQueueFunc[T]{
 			defaultHook: i.MaxDurationInQueue,
 		},
+		ProcessingCountFunc: &WorkerStoreProcessingCountFunc[T]{
+			defaultHook: i.ProcessingCount,
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
@@ -4718,6 +5113,9 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		ResetStalledFunc: &WorkerStoreResetStalledFunc[T]{
 			defaultHook: i.ResetStalled,
 		},
+		TraceContextFunc: &WorkerStoreTraceContextFunc[T]{
+			defaultHook: i.TraceContext,
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: i.UpdateExecutionLogEntry,
 		},
@@ -4844,21 +5242,139 @@ func (c WorkerStoreAddExecutionLogEntryFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// WorkerStoreDequeueFunc describes the behavior when the Dequeue method of
-// the parent MockWorkerStore instance is invoked.
-type WorkerStoreDequeueFunc[T workerutil.Record] struct {
-	defaultHook func(context.Context, string, []*sqlf.Query) (T, bool, error)
-	hooks       []func(context.Context, string, []*sqlf.Query) (T, bool, error)
-	history     []WorkerStoreDequeueFuncCall[T]
+// WorkerStoreAppendExecutionLogEntryOutputFunc describes the behavior when
+// the AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance is invoked.
+type WorkerStoreAppendExecutionLogEntryOutputFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error
+	hooks       []func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error
+	history     []WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]
 	mutex       sync.Mutex
 }
 
-// Dequeue delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockWorkerStore[T]) Dequeue(v0 context.Context, v1 string, v2 []*sqlf.Query) (T, bool, error) {
-	r0, r1, r2 := m.DequeueFunc.nextHook()(v0, v1, v2)
-	m.DequeueFunc.appendCall(WorkerStoreDequeueFuncCall[T]{v0, v1, v2, r0, r1, r2})
-	return r0, r1, r2
+// AppendExecutionLogEntryOutput delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) AppendExecutionLogEntryOutput(v0 context.Context, v1 int, v2 int, v3 string, v4 store1.ExecutionLogEntryOptions) error {
+	r0 := m.AppendExecutionLogEntryOutputFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AppendExecutionLogEntryOutputFunc.appendCall(WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance is invoked and the hook queue is empty.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) SetDefaultHook(hook func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) PushHook(hook func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) nextHook() func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) appendCall(r0 WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// WorkerStoreAppendExecutionLogEntryOutputFuncCall objects describing the
+// invocations of this function.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) History() []WorkerStoreAppendExecutionLogEntryOutputFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreAppendExecutionLogEntryOutputFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreAppendExecutionLogEntryOutputFuncCall is an object that
+// describes an invocation of method AppendExecutionLogEntryOutput on an
+// instance of MockWorkerStore.
+type WorkerStoreAppendExecutionLogEntryOutputFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 store1.ExecutionLogEntryOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// WorkerStoreDequeueFunc describes the behavior when the Dequeue method of
+// the parent MockWorkerStore instance is invoked.
+type WorkerStoreDequeueFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, string, []*sqlf.Query) (T, bool, error)
+	hooks       []func(context.Context, string, []*sqlf.Query) (T, bool, error)
+	history     []WorkerStoreDequeueFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// Dequeue delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) Dequeue(v0 context.Context, v1 string, v2 []*sqlf.Query) (T, bool, error) {
+	r0, r1, r2 := m.DequeueFunc.nextHook()(v0, v1, v2)
+	m.DequeueFunc.appendCall(WorkerStoreDequeueFuncCall[T]{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the Dequeue method of
@@ -4958,6 +5474,228 @@ func (c WorkerStoreDequeueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// WorkerStoreExecutionLogsFunc describes the behavior when the
+// ExecutionLogs method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreExecutionLogsFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)
+	hooks       []func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)
+	history     []WorkerStoreExecutionLogsFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// ExecutionLogs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) ExecutionLogs(v0 context.Context, v1 int) ([]executor.ExecutionLogEntry, string, bool, error) {
+	r0, r1, r2, r3 := m.ExecutionLogsFunc.nextHook()(v0, v1)
+	m.ExecutionLogsFunc.appendCall(WorkerStoreExecutionLogsFuncCall[T]{v0, v1, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the ExecutionLogs method
+// of the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreExecutionLogsFunc[T]) SetDefaultHook(hook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExecutionLogs method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreExecutionLogsFunc[T]) PushHook(hook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreExecutionLogsFunc[T]) SetDefaultReturn(r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreExecutionLogsFunc[T]) PushReturn(r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *WorkerStoreExecutionLogsFunc[T]) nextHook() func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreExecutionLogsFunc[T]) appendCall(r0 WorkerStoreExecutionLogsFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreExecutionLogsFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreExecutionLogsFunc[T]) History() []WorkerStoreExecutionLogsFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreExecutionLogsFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreExecutionLogsFuncCall is an object that describes an
+// invocation of method ExecutionLogs on an instance of MockWorkerStore.
+type WorkerStoreExecutionLogsFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []executor.ExecutionLogEntry
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreExecutionLogsFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreExecutionLogsFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// WorkerStoreFinishedCountFunc describes the behavior when the
+// FinishedCount method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreFinishedCountFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, time.Time) (int, error)
+	hooks       []func(context.Context, time.Time) (int, error)
+	history     []WorkerStoreFinishedCountFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// FinishedCount delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) FinishedCount(v0 context.Context, v1 time.Time) (int, error) {
+	r0, r1 := m.FinishedCountFunc.nextHook()(v0, v1)
+	m.FinishedCountFunc.appendCall(WorkerStoreFinishedCountFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FinishedCount method
+// of the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreFinishedCountFunc[T]) SetDefaultHook(hook func(context.Context, time.Time) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FinishedCount method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreFinishedCountFunc[T]) PushHook(hook func(context.Context, time.Time) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreFinishedCountFunc[T]) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreFinishedCountFunc[T]) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreFinishedCountFunc[T]) nextHook() func(context.Context, time.Time) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreFinishedCountFunc[T]) appendCall(r0 WorkerStoreFinishedCountFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreFinishedCountFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreFinishedCountFunc[T]) History() []WorkerStoreFinishedCountFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreFinishedCountFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreFinishedCountFuncCall is an object that describes an
+// invocation of method FinishedCount on an instance of MockWorkerStore.
+type WorkerStoreFinishedCountFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreFinishedCountFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreFinishedCountFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreHandleFunc describes the behavior when the Handle method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreHandleFunc[T workerutil.Record] struct {
@@ -5281,6 +6019,114 @@ func (c WorkerStoreMarkCompleteFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreMarkDeadlineExceededFunc describes the behavior when the
+// MarkDeadlineExceeded method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreMarkDeadlineExceededFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []WorkerStoreMarkDeadlineExceededFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// MarkDeadlineExceeded delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) MarkDeadlineExceeded(v0 context.Context) ([]int, error) {
+	r0, r1 := m.MarkDeadlineExceededFunc.nextHook()(v0)
+	m.MarkDeadlineExceededFunc.appendCall(WorkerStoreMarkDeadlineExceededFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the MarkDeadlineExceeded
+// method of the parent MockWorkerStore instance is invoked and the hook
+// queue is empty.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkDeadlineExceeded method of the parent MockWorkerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) PushHook(hook func(context.Context) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) nextHook() func(context.Context) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) appendCall(r0 WorkerStoreMarkDeadlineExceededFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreMarkDeadlineExceededFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) History() []WorkerStoreMarkDeadlineExceededFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreMarkDeadlineExceededFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreMarkDeadlineExceededFuncCall is an object that describes an
+// invocation of method MarkDeadlineExceeded on an instance of
+// MockWorkerStore.
+type WorkerStoreMarkDeadlineExceededFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreMarkDeadlineExceededFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreMarkDeadlineExceededFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreMarkErroredFunc describes the behavior when the MarkErrored
 // method of the parent MockWorkerStore instance is invoked.
 type WorkerStoreMarkErroredFunc[T workerutil.Record] struct {
@@ -5616,6 +6462,111 @@ func (c WorkerStoreMaxDurationInQueueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreProcessingCountFunc describes the behavior when the
+// ProcessingCount method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreProcessingCountFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) (int, error)
+	hooks       []func(context.Context) (int, error)
+	history     []WorkerStoreProcessingCountFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// ProcessingCount delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) ProcessingCount(v0 context.Context) (int, error) {
+	r0, r1 := m.ProcessingCountFunc.nextHook()(v0)
+	m.ProcessingCountFunc.appendCall(WorkerStoreProcessingCountFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ProcessingCount
+// method of the parent MockWorkerStore instance is invoked and the hook
+// queue is empty.
+func (f *WorkerStoreProcessingCountFunc[T]) SetDefaultHook(hook func(context.Context) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ProcessingCount method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreProcessingCountFunc[T]) PushHook(hook func(context.Context) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreProcessingCountFunc[T]) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreProcessingCountFunc[T]) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreProcessingCountFunc[T]) nextHook() func(context.Context) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreProcessingCountFunc[T]) appendCall(r0 WorkerStoreProcessingCountFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreProcessingCountFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreProcessingCountFunc[T]) History() []WorkerStoreProcessingCountFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreProcessingCountFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreProcessingCountFuncCall is an object that describes an
+// invocation of method ProcessingCount on an instance of MockWorkerStore.
+type WorkerStoreProcessingCountFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreProcessingCountFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreProcessingCountFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreQueuedCountFunc describes the behavior when the QueuedCount
 // method of the parent MockWorkerStore instance is invoked.
 type WorkerStoreQueuedCountFunc[T workerutil.Record] struct {
@@ -5940,6 +6891,114 @@ func (c WorkerStoreResetStalledFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// WorkerStoreTraceContextFunc describes the behavior when the TraceContext
+// method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreTraceContextFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) (trace.SpanContext, error)
+	hooks       []func(context.Context, int) (trace.SpanContext, error)
+	history     []WorkerStoreTraceContextFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// TraceContext delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) TraceContext(v0 context.Context, v1 int) (trace.SpanContext, error) {
+	r0, r1 := m.TraceContextFunc.nextHook()(v0, v1)
+	m.TraceContextFunc.appendCall(WorkerStoreTraceContextFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the TraceContext method
+// of the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreTraceContextFunc[T]) SetDefaultHook(hook func(context.Context, int) (trace.SpanContext, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TraceContext method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreTraceContextFunc[T]) PushHook(hook func(context.Context, int) (trace.SpanContext, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreTraceContextFunc[T]) SetDefaultReturn(r0 trace.SpanContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (trace.SpanContext, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreTraceContextFunc[T]) PushReturn(r0 trace.SpanContext, r1 error) {
+	f.PushHook(func(context.Context, int) (trace.SpanContext, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreTraceContextFunc[T]) nextHook() func(context.Context, int) (trace.SpanContext, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreTraceContextFunc[T]) appendCall(r0 WorkerStoreTraceContextFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreTraceContextFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreTraceContextFunc[T]) History() []WorkerStoreTraceContextFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreTraceContextFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreTraceContextFuncCall is an object that describes an invocation
+// of method TraceContext on an instance of MockWorkerStore.
+type WorkerStoreTraceContextFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 trace.SpanContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreTraceContextFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreTraceContextFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreUpdateExecutionLogEntryFunc describes the behavior when the
 // UpdateExecutionLogEntry method of the parent MockWorkerStore instance is
 // invoked.
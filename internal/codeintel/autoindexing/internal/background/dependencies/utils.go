@@ -179,6 +179,9 @@ var dependencyIndexingJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("lsif_dependency_indexing_jobs.upload_id"),
 	sqlf.Sprintf("lsif_dependency_indexing_jobs.external_service_kind"),
 	sqlf.Sprintf("lsif_dependency_indexing_jobs.external_service_sync"),
+	sqlf.Sprintf("lsif_dependency_indexing_jobs.package_scheme"),
+	sqlf.Sprintf("lsif_dependency_indexing_jobs.package_name"),
+	sqlf.Sprintf("lsif_dependency_indexing_jobs.package_version"),
 }
 
 func scanDependencyIndexingJob(s dbutil.Scanner) (job dependencyIndexingJob, err error) {
@@ -194,5 +197,8 @@ func scanDependencyIndexingJob(s dbutil.Scanner) (job dependencyIndexingJob, err
 		&job.UploadID,
 		&job.ExternalServiceKind,
 		&job.ExternalServiceSync,
+		&job.PackageScheme,
+		&job.PackageName,
+		&job.PackageVersion,
 	)
 }
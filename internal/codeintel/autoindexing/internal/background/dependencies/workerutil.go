@@ -19,6 +19,9 @@ type dependencyIndexingJob struct {
 	UploadID            int        `json:"uploadId"`
 	ExternalServiceKind string     `json:"externalServiceKind"`
 	ExternalServiceSync time.Time  `json:"externalServiceSync"`
+	PackageScheme       string     `json:"packageScheme"`
+	PackageName         string     `json:"packageName"`
+	PackageVersion      string     `json:"packageVersion"`
 }
 
 func (u dependencyIndexingJob) RecordID() int {
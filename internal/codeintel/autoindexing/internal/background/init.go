@@ -56,6 +56,7 @@ func NewDependencyIndexSchedulers(
 	store store.Store,
 	indexEnqueuer dependencies.IndexEnqueuer,
 	repoUpdater dependencies.RepoUpdaterClient,
+	gitserverClient dependencies.GitserverClient,
 	config *dependencies.Config,
 ) []goroutine.BackgroundRoutine {
 	metrics := dependencies.NewResetterMetrics(observationCtx)
@@ -85,6 +86,7 @@ func NewDependencyIndexSchedulers(
 			gitserverRepoStore,
 			indexEnqueuer,
 			repoUpdater,
+			gitserverClient,
 			workerutil.NewMetrics(observationCtx, "codeintel_dependency_index_queueing"),
 			config,
 		),
@@ -13,6 +13,19 @@ func TestGoGenerator(t *testing.T) {
 				"foo/baz/go.mod": "",
 			},
 		},
+		generatorTestCase{
+			description: "go workspace",
+			repositoryContents: map[string]string{
+				"go.work":            "go 1.21\n\nuse (\n\t./foo\n\t\"./bar/baz\" // comment\n)\n\nuse ./tools\n",
+				"foo/go.mod":         "",
+				"bar/baz/go.mod":     "",
+				"tools/go.mod":       "",
+				"unused/go.mod":      "",
+				"nested/go.work":     "go 1.21\n",
+				"nested/mod/go.mod":  "",
+				"testdata/x/go.work": "",
+			},
+		},
 		generatorTestCase{
 			description: "go files in root",
 			repositoryContents: map[string]string{
@@ -54,6 +54,38 @@ func TestTypeScriptGenerator(t *testing.T) {
 				"tsconfig.json": "",
 			},
 		},
+		generatorTestCase{
+			description: "javascript workspaces",
+			repositoryContents: map[string]string{
+				"package.json":                   `{"workspaces": ["packages/*", "apps/**", "!packages/excluded"]}`,
+				"yarn.lock":                      "",
+				"packages/a/package.json":        "",
+				"packages/b/package.json":        "",
+				"packages/excluded/package.json": "",
+				"apps/web/client/package.json":   "",
+				"scripts/package.json":           "",
+			},
+		},
+		generatorTestCase{
+			description: "typescript workspaces",
+			repositoryContents: map[string]string{
+				"package.json":                 `{"workspaces": {"packages": ["packages/*"]}}`,
+				"packages/a/package.json":      "",
+				"packages/a/tsconfig.json":     "",
+				"packages/b/package.json":      "",
+				"packages/b/src/tsconfig.json": "",
+				"packages/c/package.json":      "",
+			},
+		},
+		generatorTestCase{
+			description: "typescript with lerna packages",
+			repositoryContents: map[string]string{
+				"package.json":            "",
+				"lerna.json":              `{"packages": ["modules/*"], "npmClient": "yarn"}`,
+				"modules/a/package.json":  "",
+				"modules/a/tsconfig.json": "",
+			},
+		},
 		generatorTestCase{
 			description: "typescript with node version",
 			repositoryContents: map[string]string{
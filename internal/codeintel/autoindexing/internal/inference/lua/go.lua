@@ -19,30 +19,121 @@ local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
   pattern.new_path_segment "vendor",
 })
 
+-- parse_go_work_uses returns the directories listed by the use directives of
+-- the given go.work file contents.
+local parse_go_work_uses = function(contents)
+  local uses = {}
+  local in_block = false
+
+  for line in string.gmatch(contents, "[^\n]+") do
+    line = line:gsub("//.*$", ""):gsub("^%s+", ""):gsub("%s+$", "")
+
+    local use = nil
+    if in_block then
+      if line == ")" then
+        in_block = false
+      elseif line ~= "" then
+        use = line
+      end
+    elseif string.match(line, "^use%s*%($") then
+      in_block = true
+    else
+      use = string.match(line, "^use%s+(.+)$")
+    end
+
+    if use then
+      table.insert(uses, (use:gsub('^"(.*)"$', "%1")))
+    end
+  end
+
+  return uses
+end
+
+-- new_workspace_resolver returns a function that returns the root of the
+-- go.work file governing the module at the given root, and whether or not
+-- that workspace uses the module. Parsed go.work files are shared between
+-- all modules of the repository.
+local new_workspace_resolver = function(contents_by_path)
+  local uses_by_workspace = {}
+
+  return function(root)
+    local candidates = { root }
+    if root ~= "" then
+      local ancestors = path.ancestors(root)
+      for i = 1, #ancestors do
+        table.insert(candidates, ancestors[i])
+      end
+    end
+
+    for _, candidate in ipairs(candidates) do
+      local contents = contents_by_path[path.join(candidate, "go.work")]
+      if contents then
+        if not uses_by_workspace[candidate] then
+          uses_by_workspace[candidate] = parse_go_work_uses(contents)
+        end
+
+        for _, use in ipairs(uses_by_workspace[candidate]) do
+          if path.join(candidate, use) == root then
+            return candidate, true
+          end
+        end
+
+        -- The go command only considers the closest go.work file
+        return candidate, false
+      end
+    end
+
+    return nil, false
+  end
+end
+
 local gomod_recognizer = recognizer.new_path_recognizer {
   patterns = {
     pattern.new_path_basename "go.mod",
     pattern.new_path_exclude(exclude_paths),
   },
 
+  patterns_for_content = {
+    -- To detect modules that are part of a workspace
+    pattern.new_path_basename "go.work",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
   -- Invoked when go.mod files exist
-  generate = function(_, paths)
+  generate = function(_, paths, contents_by_path)
+    local resolve_workspace = new_workspace_resolver(contents_by_path)
+
     local jobs = {}
     for i = 1, #paths do
       local root = path.dirname(paths[i])
+      local workspace_root, in_workspace = resolve_workspace(root)
+
+      local download_root = root
+      local download_command = "go mod download"
+      local indexer_args = { "scip-go", "--no-animation" }
+      if in_workspace then
+        -- Modules of the same workspace share the step downloading the
+        -- dependencies of the whole workspace
+        download_root = workspace_root
+      elseif workspace_root then
+        -- The module is not used by the enclosing workspace, so the module
+        -- must be built on its own
+        download_command = "GOWORK=off " .. download_command
+        indexer_args = { "GOWORK=off", "scip-go", "--no-animation" }
+      end
 
       table.insert(jobs, {
         steps = {
           {
-            root = root,
+            root = download_root,
             image = indexer,
-            commands = { netrc_steps, "go mod download" },
+            commands = { netrc_steps, download_command },
           },
         },
         local_steps = { netrc_steps },
         root = root,
         indexer = indexer,
-        indexer_args = { "scip-go", "--no-animation" },
+        indexer_args = indexer_args,
         outfile = "index.scip",
         requested_envvars = { "GOPRIVATE", "GOPROXY", "GONOPROXY", "GOSUMDB", "GONOSUMDB", "NETRC_DATA" },
       })
@@ -58,7 +58,107 @@ local can_derive_node_version = function(root, paths, contents_by_path)
 	return false
 end
 
-local infer_typescript_job = function(api, tsconfig_path, should_infer_config)
+-- relative_to returns the given path relative to the given directory, or nil
+-- if the path is not within the directory.
+local relative_to = function(dir, p)
+	if dir == "" then
+		return p
+	end
+	if p == dir then
+		return ""
+	end
+	if string.sub(p, 1, #dir + 1) == dir .. "/" then
+		return string.sub(p, #dir + 2)
+	end
+
+	return nil
+end
+
+-- glob_to_pattern converts a workspace glob such as `packages/*` into an
+-- anchored Lua pattern.
+local glob_to_pattern = function(glob)
+	glob = glob:gsub("^%./", ""):gsub("/+$", "")
+	glob = glob:gsub("[%^%$%(%)%%%.%[%]%+%-%?]", "%%%0")
+	glob = glob:gsub("%*%*", "\001"):gsub("%*", "[^/]*"):gsub("\001", ".*")
+	return "^" .. glob .. "$"
+end
+
+-- workspace_globs returns the globs of the workspace packages declared by
+-- the package.json or lerna.json file in the given directory.
+local workspace_globs = function(dir, contents_by_path)
+	local globs = {}
+	local add_globs = function(values)
+		if type(values) ~= "table" then
+			return
+		end
+		for _, value in ipairs(values) do
+			if type(value) == "string" then
+				table.insert(globs, value)
+			end
+		end
+	end
+
+	local package_payload = safe_decode(contents_by_path[path.join(dir, "package.json")] or "")
+	if type(package_payload) == "table" then
+		local workspaces = package_payload["workspaces"]
+		if type(workspaces) == "table" and workspaces["packages"] then
+			-- Yarn also accepts an object with additional settings
+			workspaces = workspaces["packages"]
+		end
+		add_globs(workspaces)
+	end
+
+	local lerna_payload = safe_decode(contents_by_path[path.join(dir, "lerna.json")] or "")
+	if type(lerna_payload) == "table" then
+		add_globs(lerna_payload["packages"])
+	end
+
+	return globs
+end
+
+-- matches_workspace_globs returns true if the given path matches any of the given
+-- globs and none of the negated (`!`-prefixed) globs.
+local matches_workspace_globs = function(p, globs)
+	local matched = false
+	for _, glob in ipairs(globs) do
+		if string.sub(glob, 1, 1) == "!" then
+			if string.match(p, glob_to_pattern(string.sub(glob, 2))) then
+				return false
+			end
+		elseif string.match(p, glob_to_pattern(glob)) then
+			matched = true
+		end
+	end
+
+	return matched
+end
+
+-- find_workspace_packages returns a table from the directory of each package that
+-- belongs to a (yarn, npm, or lerna) workspace to the root directory of that workspace.
+local find_workspace_packages = function(package_dirs, contents_by_path)
+	local workspace_roots = {}
+	for _, dir in ipairs(package_dirs) do
+		local globs = workspace_globs(dir, contents_by_path)
+		if #globs > 0 then
+			table.insert(workspace_roots, { root = dir, globs = globs })
+		end
+	end
+
+	local workspace_packages = {}
+	for _, dir in ipairs(package_dirs) do
+		for _, workspace in ipairs(workspace_roots) do
+			local relative = relative_to(workspace.root, dir)
+			if relative and relative ~= "" and matches_workspace_globs(relative, workspace.globs) then
+				workspace_packages[dir] = workspace.root
+				break
+			end
+		end
+	end
+
+	return workspace_packages
+end
+
+local infer_typescript_job = function(api, tsconfig_path, should_infer_config, workspace_packages)
 	local root = path.dirname(tsconfig_path)
 	local reverse_ancestors = util.reverse(path.ancestors(tsconfig_path))
 
@@ -88,7 +188,11 @@ local infer_typescript_job = function(api, tsconfig_path, should_infer_config)
 
 			local docker_steps = {}
 			for i = 1, #reverse_ancestors do
-				if contents_by_path[path.join(reverse_ancestors[i], "package.json")] then
+				-- Dependencies of workspace packages are installed from the root of their workspace
+				if
+					contents_by_path[path.join(reverse_ancestors[i], "package.json")]
+					and not workspace_packages[reverse_ancestors[i]]
+				then
 					local install_command = ""
 					if is_yarn or util.contains(paths, path.join(reverse_ancestors[i], "yarn.lock")) then
 						install_command = "yarn"
@@ -152,20 +256,55 @@ return recognizer.new_path_recognizer({
 		pattern.new_path_exclude(exclude_paths),
 	},
 
+	patterns_for_content = {
+		-- To read the packages of workspaces
+		pattern.new_path_basename("package.json"),
+		pattern.new_path_basename("lerna.json"),
+		pattern.new_path_exclude(exclude_paths),
+	},
+
 	-- Invoked when package.json or tsconfig.json files exist
-	generate = function(api, paths)
-		local has_tsconfig = false
+	generate = function(api, paths, contents_by_path)
+		local tsconfig_dirs = {}
+		local package_dirs = {}
 		for i = 1, #paths do
 			if path.basename(paths[i]) == "tsconfig.json" then
-				-- Infer typescript jobs
-				infer_typescript_job(api, paths[i], false)
-				has_tsconfig = true
+				table.insert(tsconfig_dirs, path.dirname(paths[i]))
+			elseif path.basename(paths[i]) == "package.json" then
+				table.insert(package_dirs, path.dirname(paths[i]))
+			end
+		end
+
+		local workspace_packages = find_workspace_packages(package_dirs, contents_by_path)
+
+		for _, dir in ipairs(tsconfig_dirs) do
+			-- Infer typescript jobs
+			infer_typescript_job(api, path.join(dir, "tsconfig.json"), false, workspace_packages)
+		end
+
+		-- Infer javascript jobs for workspace packages without a tsconfig.json
+		local has_workspace_packages = false
+		for _, dir in ipairs(package_dirs) do
+			if workspace_packages[dir] then
+				has_workspace_packages = true
+
+				local has_tsconfig = false
+				for _, tsconfig_dir in ipairs(tsconfig_dirs) do
+					if relative_to(dir, tsconfig_dir) then
+						has_tsconfig = true
+						break
+					end
+				end
+
+				if not has_tsconfig then
+					infer_typescript_job(api, path.join(dir, "tsconfig.json"), true, workspace_packages)
+				end
 			end
 		end
 
-		if not has_tsconfig then
+		if #tsconfig_dirs == 0 and not has_workspace_packages then
 			-- Infer javascript jobs if there's no tsconfig.json found
-			infer_typescript_job(api, "tsconfig.json", true)
+			infer_typescript_job(api, "tsconfig.json", true, workspace_packages)
 		end
 
 		return {}
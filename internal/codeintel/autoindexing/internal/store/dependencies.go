@@ -7,6 +7,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -37,6 +38,54 @@ VALUES (%s, %s, %s)
 RETURNING id
 `
 
+// InsertDependencyIndexingJobForPackage inserts a dependency indexing job that resolves and indexes
+// only the given package. No job is inserted if a queued, processing, or completed job already
+// exists for the same package, in which case the returned flag is false.
+func (s *store) InsertDependencyIndexingJobForPackage(ctx context.Context, uploadID int, pkg dependencies.MinimialVersionedPackageRepo, externalServiceKind string, syncTime time.Time) (id int, inserted bool, err error) {
+	ctx, _, endObservation := s.operations.insertDependencyIndexingJobForPackage.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadId", uploadID),
+		attribute.String("scheme", pkg.Scheme),
+		attribute.String("name", string(pkg.Name)),
+		attribute.String("version", pkg.Version),
+		attribute.String("extSvcKind", externalServiceKind),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{Attrs: []attribute.KeyValue{
+			attribute.Int("id", id),
+			attribute.Bool("inserted", inserted),
+		}})
+	}()
+
+	id, inserted, err = basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		insertDependencyIndexingJobForPackageQuery,
+		uploadID,
+		externalServiceKind,
+		syncTime,
+		pkg.Scheme,
+		pkg.Name,
+		pkg.Version,
+		pkg.Scheme,
+		pkg.Name,
+		pkg.Version,
+	)))
+	return id, inserted, err
+}
+
+const insertDependencyIndexingJobForPackageQuery = `
+INSERT INTO lsif_dependency_indexing_jobs (upload_id, external_service_kind, external_service_sync, package_scheme, package_name, package_version)
+SELECT %s, %s, %s, %s, %s, %s
+WHERE NOT EXISTS (
+	SELECT 1
+	FROM lsif_dependency_indexing_jobs
+	WHERE
+		package_scheme = %s AND
+		package_name = %s AND
+		package_version = %s AND
+		state IN ('queued', 'processing', 'completed')
+)
+RETURNING id
+`
+
 func (s *store) QueueRepoRev(ctx context.Context, repositoryID int, rev string) (err error) {
 	ctx, _, endObservation := s.operations.queueRepoRev.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.Int("repositoryID", repositoryID),
@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -42,6 +43,62 @@ func TestInsertDependencyIndexingJob(t *testing.T) {
 	}
 }
 
+func TestInsertDependencyIndexingJobForPackage(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+	ctx := context.Background()
+
+	insertRepo(t, db, 50, "")
+
+	insertUploads(t, db, upload{
+		ID:            42,
+		Commit:        makeCommit(1),
+		Root:          "sub/",
+		State:         "completed",
+		RepositoryID:  50,
+		Indexer:       "scip-typescript",
+		NumParts:      1,
+		UploadedParts: []int{0},
+	})
+
+	pkg := dependencies.MinimialVersionedPackageRepo{
+		Scheme:  dependencies.NpmPackagesScheme,
+		Name:    "left-pad",
+		Version: "1.3.0",
+	}
+
+	if _, inserted, err := store.InsertDependencyIndexingJobForPackage(ctx, 42, pkg, "NPMPACKAGES", time.Now()); err != nil {
+		t.Fatalf("unexpected error enqueueing dependency index queueing job: %s", err)
+	} else if !inserted {
+		t.Fatalf("expected job to be inserted")
+	}
+
+	// A second request for the same package is deduplicated while the first job is queued
+	if _, inserted, err := store.InsertDependencyIndexingJobForPackage(ctx, 42, pkg, "NPMPACKAGES", time.Now()); err != nil {
+		t.Fatalf("unexpected error enqueueing dependency index queueing job: %s", err)
+	} else if inserted {
+		t.Fatalf("expected job to be deduplicated")
+	}
+
+	// Nor is it inserted again once the first job has completed
+	if _, err := db.ExecContext(ctx, `UPDATE lsif_dependency_indexing_jobs SET state = 'completed'`); err != nil {
+		t.Fatalf("unexpected error completing dependency indexing job: %s", err)
+	}
+	if _, inserted, err := store.InsertDependencyIndexingJobForPackage(ctx, 42, pkg, "NPMPACKAGES", time.Now()); err != nil {
+		t.Fatalf("unexpected error enqueueing dependency index queueing job: %s", err)
+	} else if inserted {
+		t.Fatalf("expected job to be deduplicated against the completed job")
+	}
+
+	pkg.Version = "1.4.0"
+	if _, inserted, err := store.InsertDependencyIndexingJobForPackage(ctx, 42, pkg, "NPMPACKAGES", time.Now()); err != nil {
+		t.Fatalf("unexpected error enqueueing dependency index queueing job: %s", err)
+	} else if !inserted {
+		t.Fatalf("expected job for distinct version to be inserted")
+	}
+}
+
 func TestGetQueuedRepoRev(t *testing.T) {
 	ctx := context.Background()
 	logger := logtest.Scoped(t)
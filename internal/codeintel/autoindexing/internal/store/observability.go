@@ -29,6 +29,7 @@ type operations struct {
 	isQueuedRootIndexer                    *observation.Operation
 	insertIndexes                          *observation.Operation
 	insertDependencyIndexingJob            *observation.Operation
+	insertDependencyIndexingJobForPackage  *observation.Operation
 	queueRepoRev                           *observation.Operation
 
 	indexesInserted prometheus.Counter
@@ -85,6 +86,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		isQueuedRootIndexer:                    op("IsQueuedRootIndexer"),
 		insertIndexes:                          op("InsertIndexes"),
 		insertDependencyIndexingJob:            op("InsertDependencyIndexingJob"),
+		insertDependencyIndexingJobForPackage:  op("InsertDependencyIndexingJobForPackage"),
 		queueRepoRev:                           op("QueueRepoRev"),
 
 		indexesInserted: indexesInsertedCounter,
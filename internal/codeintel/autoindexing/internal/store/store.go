@@ -7,6 +7,7 @@ import (
 	logger "github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -45,6 +46,7 @@ type Store interface {
 
 	// Dependency indexing
 	InsertDependencyIndexingJob(ctx context.Context, uploadID int, externalServiceKind string, syncTime time.Time) (int, error)
+	InsertDependencyIndexingJobForPackage(ctx context.Context, uploadID int, pkg dependencies.MinimialVersionedPackageRepo, externalServiceKind string, syncTime time.Time) (int, bool, error)
 	QueueRepoRev(ctx context.Context, repositoryID int, commit string) error
 }
 
@@ -14,7 +14,8 @@ import (
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	store "github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/store"
 	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/shared"
-	shared1 "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	shared1 "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	shared2 "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	protocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 )
 
@@ -45,6 +46,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// InsertDependencyIndexingJob.
 	InsertDependencyIndexingJobFunc *StoreInsertDependencyIndexingJobFunc
+	// InsertDependencyIndexingJobForPackageFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// InsertDependencyIndexingJobForPackage.
+	InsertDependencyIndexingJobForPackageFunc *StoreInsertDependencyIndexingJobForPackageFunc
 	// InsertIndexesFunc is an instance of a mock function object
 	// controlling the behavior of the method InsertIndexes.
 	InsertIndexesFunc *StoreInsertIndexesFunc
@@ -127,8 +132,13 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertDependencyIndexingJobForPackageFunc: &StoreInsertDependencyIndexingJobForPackageFunc{
+			defaultHook: func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (r0 int, r1 bool, r2 error) {
+				return
+			},
+		},
 		InsertIndexesFunc: &StoreInsertIndexesFunc{
-			defaultHook: func(context.Context, []shared1.Index) (r0 []shared1.Index, r1 error) {
+			defaultHook: func(context.Context, []shared2.Index) (r0 []shared2.Index, r1 error) {
 				return
 			},
 		},
@@ -158,12 +168,12 @@ func NewMockStore() *MockStore {
 			},
 		},
 		RepositoryIDsWithConfigurationFunc: &StoreRepositoryIDsWithConfigurationFunc{
-			defaultHook: func(context.Context, int, int) (r0 []shared1.RepositoryWithAvailableIndexers, r1 int, r2 error) {
+			defaultHook: func(context.Context, int, int) (r0 []shared2.RepositoryWithAvailableIndexers, r1 int, r2 error) {
 				return
 			},
 		},
 		SetConfigurationSummaryFunc: &StoreSetConfigurationSummaryFunc{
-			defaultHook: func(context.Context, int, int, map[string]shared1.AvailableIndexer) (r0 error) {
+			defaultHook: func(context.Context, int, int, map[string]shared2.AvailableIndexer) (r0 error) {
 				return
 			},
 		},
@@ -178,7 +188,7 @@ func NewMockStore() *MockStore {
 			},
 		},
 		TopRepositoriesToConfigureFunc: &StoreTopRepositoriesToConfigureFunc{
-			defaultHook: func(context.Context, int) (r0 []shared1.RepositoryWithCount, r1 error) {
+			defaultHook: func(context.Context, int) (r0 []shared2.RepositoryWithCount, r1 error) {
 				return
 			},
 		},
@@ -234,8 +244,13 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertDependencyIndexingJob")
 			},
 		},
+		InsertDependencyIndexingJobForPackageFunc: &StoreInsertDependencyIndexingJobForPackageFunc{
+			defaultHook: func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error) {
+				panic("unexpected invocation of MockStore.InsertDependencyIndexingJobForPackage")
+			},
+		},
 		InsertIndexesFunc: &StoreInsertIndexesFunc{
-			defaultHook: func(context.Context, []shared1.Index) ([]shared1.Index, error) {
+			defaultHook: func(context.Context, []shared2.Index) ([]shared2.Index, error) {
 				panic("unexpected invocation of MockStore.InsertIndexes")
 			},
 		},
@@ -265,12 +280,12 @@ func NewStrictMockStore() *MockStore {
 			},
 		},
 		RepositoryIDsWithConfigurationFunc: &StoreRepositoryIDsWithConfigurationFunc{
-			defaultHook: func(context.Context, int, int) ([]shared1.RepositoryWithAvailableIndexers, int, error) {
+			defaultHook: func(context.Context, int, int) ([]shared2.RepositoryWithAvailableIndexers, int, error) {
 				panic("unexpected invocation of MockStore.RepositoryIDsWithConfiguration")
 			},
 		},
 		SetConfigurationSummaryFunc: &StoreSetConfigurationSummaryFunc{
-			defaultHook: func(context.Context, int, int, map[string]shared1.AvailableIndexer) error {
+			defaultHook: func(context.Context, int, int, map[string]shared2.AvailableIndexer) error {
 				panic("unexpected invocation of MockStore.SetConfigurationSummary")
 			},
 		},
@@ -285,7 +300,7 @@ func NewStrictMockStore() *MockStore {
 			},
 		},
 		TopRepositoriesToConfigureFunc: &StoreTopRepositoriesToConfigureFunc{
-			defaultHook: func(context.Context, int) ([]shared1.RepositoryWithCount, error) {
+			defaultHook: func(context.Context, int) ([]shared2.RepositoryWithCount, error) {
 				panic("unexpected invocation of MockStore.TopRepositoriesToConfigure")
 			},
 		},
@@ -329,6 +344,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		InsertDependencyIndexingJobFunc: &StoreInsertDependencyIndexingJobFunc{
 			defaultHook: i.InsertDependencyIndexingJob,
 		},
+		InsertDependencyIndexingJobForPackageFunc: &StoreInsertDependencyIndexingJobForPackageFunc{
+			defaultHook: i.InsertDependencyIndexingJobForPackage,
+		},
 		InsertIndexesFunc: &StoreInsertIndexesFunc{
 			defaultHook: i.InsertIndexes,
 		},
@@ -1054,18 +1072,143 @@ func (c StoreInsertDependencyIndexingJobFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreInsertDependencyIndexingJobForPackageFunc describes the behavior
+// when the InsertDependencyIndexingJobForPackage method of the parent
+// MockStore instance is invoked.
+type StoreInsertDependencyIndexingJobForPackageFunc struct {
+	defaultHook func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error)
+	hooks       []func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error)
+	history     []StoreInsertDependencyIndexingJobForPackageFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDependencyIndexingJobForPackage delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockStore) InsertDependencyIndexingJobForPackage(v0 context.Context, v1 int, v2 shared1.MinimialVersionedPackageRepo, v3 string, v4 time.Time) (int, bool, error) {
+	r0, r1, r2 := m.InsertDependencyIndexingJobForPackageFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertDependencyIndexingJobForPackageFunc.appendCall(StoreInsertDependencyIndexingJobForPackageFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertDependencyIndexingJobForPackage method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) SetDefaultHook(hook func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDependencyIndexingJobForPackage method of the parent MockStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) PushHook(hook func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) SetDefaultReturn(r0 int, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) PushReturn(r0 int, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) nextHook() func(context.Context, int, shared1.MinimialVersionedPackageRepo, string, time.Time) (int, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) appendCall(r0 StoreInsertDependencyIndexingJobForPackageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreInsertDependencyIndexingJobForPackageFuncCall objects describing the
+// invocations of this function.
+func (f *StoreInsertDependencyIndexingJobForPackageFunc) History() []StoreInsertDependencyIndexingJobForPackageFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertDependencyIndexingJobForPackageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertDependencyIndexingJobForPackageFuncCall is an object that
+// describes an invocation of method InsertDependencyIndexingJobForPackage
+// on an instance of MockStore.
+type StoreInsertDependencyIndexingJobForPackageFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 shared1.MinimialVersionedPackageRepo
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertDependencyIndexingJobForPackageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertDependencyIndexingJobForPackageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreInsertIndexesFunc describes the behavior when the InsertIndexes
 // method of the parent MockStore instance is invoked.
 type StoreInsertIndexesFunc struct {
-	defaultHook func(context.Context, []shared1.Index) ([]shared1.Index, error)
-	hooks       []func(context.Context, []shared1.Index) ([]shared1.Index, error)
+	defaultHook func(context.Context, []shared2.Index) ([]shared2.Index, error)
+	hooks       []func(context.Context, []shared2.Index) ([]shared2.Index, error)
 	history     []StoreInsertIndexesFuncCall
 	mutex       sync.Mutex
 }
 
 // InsertIndexes delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockStore) InsertIndexes(v0 context.Context, v1 []shared1.Index) ([]shared1.Index, error) {
+func (m *MockStore) InsertIndexes(v0 context.Context, v1 []shared2.Index) ([]shared2.Index, error) {
 	r0, r1 := m.InsertIndexesFunc.nextHook()(v0, v1)
 	m.InsertIndexesFunc.appendCall(StoreInsertIndexesFuncCall{v0, v1, r0, r1})
 	return r0, r1
@@ -1073,7 +1216,7 @@ func (m *MockStore) InsertIndexes(v0 context.Context, v1 []shared1.Index) ([]sha
 
 // SetDefaultHook sets function that is called when the InsertIndexes method
 // of the parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreInsertIndexesFunc) SetDefaultHook(hook func(context.Context, []shared1.Index) ([]shared1.Index, error)) {
+func (f *StoreInsertIndexesFunc) SetDefaultHook(hook func(context.Context, []shared2.Index) ([]shared2.Index, error)) {
 	f.defaultHook = hook
 }
 
@@ -1081,7 +1224,7 @@ func (f *StoreInsertIndexesFunc) SetDefaultHook(hook func(context.Context, []sha
 // InsertIndexes method of the parent MockStore instance invokes the hook at
 // the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *StoreInsertIndexesFunc) PushHook(hook func(context.Context, []shared1.Index) ([]shared1.Index, error)) {
+func (f *StoreInsertIndexesFunc) PushHook(hook func(context.Context, []shared2.Index) ([]shared2.Index, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1089,20 +1232,20 @@ func (f *StoreInsertIndexesFunc) PushHook(hook func(context.Context, []shared1.I
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreInsertIndexesFunc) SetDefaultReturn(r0 []shared1.Index, r1 error) {
-	f.SetDefaultHook(func(context.Context, []shared1.Index) ([]shared1.Index, error) {
+func (f *StoreInsertIndexesFunc) SetDefaultReturn(r0 []shared2.Index, r1 error) {
+	f.SetDefaultHook(func(context.Context, []shared2.Index) ([]shared2.Index, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreInsertIndexesFunc) PushReturn(r0 []shared1.Index, r1 error) {
-	f.PushHook(func(context.Context, []shared1.Index) ([]shared1.Index, error) {
+func (f *StoreInsertIndexesFunc) PushReturn(r0 []shared2.Index, r1 error) {
+	f.PushHook(func(context.Context, []shared2.Index) ([]shared2.Index, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreInsertIndexesFunc) nextHook() func(context.Context, []shared1.Index) ([]shared1.Index, error) {
+func (f *StoreInsertIndexesFunc) nextHook() func(context.Context, []shared2.Index) ([]shared2.Index, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1140,10 +1283,10 @@ type StoreInsertIndexesFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 []shared1.Index
+	Arg1 []shared2.Index
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared1.Index
+	Result0 []shared2.Index
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -1716,15 +1859,15 @@ func (c StoreRepositoryExceptionsFuncCall) Results() []interface{} {
 // RepositoryIDsWithConfiguration method of the parent MockStore instance is
 // invoked.
 type StoreRepositoryIDsWithConfigurationFunc struct {
-	defaultHook func(context.Context, int, int) ([]shared1.RepositoryWithAvailableIndexers, int, error)
-	hooks       []func(context.Context, int, int) ([]shared1.RepositoryWithAvailableIndexers, int, error)
+	defaultHook func(context.Context, int, int) ([]shared2.RepositoryWithAvailableIndexers, int, error)
+	hooks       []func(context.Context, int, int) ([]shared2.RepositoryWithAvailableIndexers, int, error)
 	history     []StoreRepositoryIDsWithConfigurationFuncCall
 	mutex       sync.Mutex
 }
 
 // RepositoryIDsWithConfiguration delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) RepositoryIDsWithConfiguration(v0 context.Context, v1 int, v2 int) ([]shared1.RepositoryWithAvailableIndexers, int, error) {
+func (m *MockStore) RepositoryIDsWithConfiguration(v0 context.Context, v1 int, v2 int) ([]shared2.RepositoryWithAvailableIndexers, int, error) {
 	r0, r1, r2 := m.RepositoryIDsWithConfigurationFunc.nextHook()(v0, v1, v2)
 	m.RepositoryIDsWithConfigurationFunc.appendCall(StoreRepositoryIDsWithConfigurationFuncCall{v0, v1, v2, r0, r1, r2})
 	return r0, r1, r2
@@ -1733,7 +1876,7 @@ func (m *MockStore) RepositoryIDsWithConfiguration(v0 context.Context, v1 int, v
 // SetDefaultHook sets function that is called when the
 // RepositoryIDsWithConfiguration method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreRepositoryIDsWithConfigurationFunc) SetDefaultHook(hook func(context.Context, int, int) ([]shared1.RepositoryWithAvailableIndexers, int, error)) {
+func (f *StoreRepositoryIDsWithConfigurationFunc) SetDefaultHook(hook func(context.Context, int, int) ([]shared2.RepositoryWithAvailableIndexers, int, error)) {
 	f.defaultHook = hook
 }
 
@@ -1742,7 +1885,7 @@ func (f *StoreRepositoryIDsWithConfigurationFunc) SetDefaultHook(hook func(conte
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreRepositoryIDsWithConfigurationFunc) PushHook(hook func(context.Context, int, int) ([]shared1.RepositoryWithAvailableIndexers, int, error)) {
+func (f *StoreRepositoryIDsWithConfigurationFunc) PushHook(hook func(context.Context, int, int) ([]shared2.RepositoryWithAvailableIndexers, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1750,20 +1893,20 @@ func (f *StoreRepositoryIDsWithConfigurationFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreRepositoryIDsWithConfigurationFunc) SetDefaultReturn(r0 []shared1.RepositoryWithAvailableIndexers, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, int, int) ([]shared1.RepositoryWithAvailableIndexers, int, error) {
+func (f *StoreRepositoryIDsWithConfigurationFunc) SetDefaultReturn(r0 []shared2.RepositoryWithAvailableIndexers, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int) ([]shared2.RepositoryWithAvailableIndexers, int, error) {
 		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreRepositoryIDsWithConfigurationFunc) PushReturn(r0 []shared1.RepositoryWithAvailableIndexers, r1 int, r2 error) {
-	f.PushHook(func(context.Context, int, int) ([]shared1.RepositoryWithAvailableIndexers, int, error) {
+func (f *StoreRepositoryIDsWithConfigurationFunc) PushReturn(r0 []shared2.RepositoryWithAvailableIndexers, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, int) ([]shared2.RepositoryWithAvailableIndexers, int, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *StoreRepositoryIDsWithConfigurationFunc) nextHook() func(context.Context, int, int) ([]shared1.RepositoryWithAvailableIndexers, int, error) {
+func (f *StoreRepositoryIDsWithConfigurationFunc) nextHook() func(context.Context, int, int) ([]shared2.RepositoryWithAvailableIndexers, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1808,7 +1951,7 @@ type StoreRepositoryIDsWithConfigurationFuncCall struct {
 	Arg2 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared1.RepositoryWithAvailableIndexers
+	Result0 []shared2.RepositoryWithAvailableIndexers
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 int
@@ -1833,15 +1976,15 @@ func (c StoreRepositoryIDsWithConfigurationFuncCall) Results() []interface{} {
 // SetConfigurationSummary method of the parent MockStore instance is
 // invoked.
 type StoreSetConfigurationSummaryFunc struct {
-	defaultHook func(context.Context, int, int, map[string]shared1.AvailableIndexer) error
-	hooks       []func(context.Context, int, int, map[string]shared1.AvailableIndexer) error
+	defaultHook func(context.Context, int, int, map[string]shared2.AvailableIndexer) error
+	hooks       []func(context.Context, int, int, map[string]shared2.AvailableIndexer) error
 	history     []StoreSetConfigurationSummaryFuncCall
 	mutex       sync.Mutex
 }
 
 // SetConfigurationSummary delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockStore) SetConfigurationSummary(v0 context.Context, v1 int, v2 int, v3 map[string]shared1.AvailableIndexer) error {
+func (m *MockStore) SetConfigurationSummary(v0 context.Context, v1 int, v2 int, v3 map[string]shared2.AvailableIndexer) error {
 	r0 := m.SetConfigurationSummaryFunc.nextHook()(v0, v1, v2, v3)
 	m.SetConfigurationSummaryFunc.appendCall(StoreSetConfigurationSummaryFuncCall{v0, v1, v2, v3, r0})
 	return r0
@@ -1850,7 +1993,7 @@ func (m *MockStore) SetConfigurationSummary(v0 context.Context, v1 int, v2 int,
 // SetDefaultHook sets function that is called when the
 // SetConfigurationSummary method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreSetConfigurationSummaryFunc) SetDefaultHook(hook func(context.Context, int, int, map[string]shared1.AvailableIndexer) error) {
+func (f *StoreSetConfigurationSummaryFunc) SetDefaultHook(hook func(context.Context, int, int, map[string]shared2.AvailableIndexer) error) {
 	f.defaultHook = hook
 }
 
@@ -1858,7 +2001,7 @@ func (f *StoreSetConfigurationSummaryFunc) SetDefaultHook(hook func(context.Cont
 // SetConfigurationSummary method of the parent MockStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *StoreSetConfigurationSummaryFunc) PushHook(hook func(context.Context, int, int, map[string]shared1.AvailableIndexer) error) {
+func (f *StoreSetConfigurationSummaryFunc) PushHook(hook func(context.Context, int, int, map[string]shared2.AvailableIndexer) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1867,19 +2010,19 @@ func (f *StoreSetConfigurationSummaryFunc) PushHook(hook func(context.Context, i
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *StoreSetConfigurationSummaryFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int, int, map[string]shared1.AvailableIndexer) error {
+	f.SetDefaultHook(func(context.Context, int, int, map[string]shared2.AvailableIndexer) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *StoreSetConfigurationSummaryFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int, int, map[string]shared1.AvailableIndexer) error {
+	f.PushHook(func(context.Context, int, int, map[string]shared2.AvailableIndexer) error {
 		return r0
 	})
 }
 
-func (f *StoreSetConfigurationSummaryFunc) nextHook() func(context.Context, int, int, map[string]shared1.AvailableIndexer) error {
+func (f *StoreSetConfigurationSummaryFunc) nextHook() func(context.Context, int, int, map[string]shared2.AvailableIndexer) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1923,7 +2066,7 @@ type StoreSetConfigurationSummaryFuncCall struct {
 	Arg2 int
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 map[string]shared1.AvailableIndexer
+	Arg3 map[string]shared2.AvailableIndexer
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -2162,15 +2305,15 @@ func (c StoreSetRepositoryExceptionsFuncCall) Results() []interface{} {
 // TopRepositoriesToConfigure method of the parent MockStore instance is
 // invoked.
 type StoreTopRepositoriesToConfigureFunc struct {
-	defaultHook func(context.Context, int) ([]shared1.RepositoryWithCount, error)
-	hooks       []func(context.Context, int) ([]shared1.RepositoryWithCount, error)
+	defaultHook func(context.Context, int) ([]shared2.RepositoryWithCount, error)
+	hooks       []func(context.Context, int) ([]shared2.RepositoryWithCount, error)
 	history     []StoreTopRepositoriesToConfigureFuncCall
 	mutex       sync.Mutex
 }
 
 // TopRepositoriesToConfigure delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) TopRepositoriesToConfigure(v0 context.Context, v1 int) ([]shared1.RepositoryWithCount, error) {
+func (m *MockStore) TopRepositoriesToConfigure(v0 context.Context, v1 int) ([]shared2.RepositoryWithCount, error) {
 	r0, r1 := m.TopRepositoriesToConfigureFunc.nextHook()(v0, v1)
 	m.TopRepositoriesToConfigureFunc.appendCall(StoreTopRepositoriesToConfigureFuncCall{v0, v1, r0, r1})
 	return r0, r1
@@ -2179,7 +2322,7 @@ func (m *MockStore) TopRepositoriesToConfigure(v0 context.Context, v1 int) ([]sh
 // SetDefaultHook sets function that is called when the
 // TopRepositoriesToConfigure method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreTopRepositoriesToConfigureFunc) SetDefaultHook(hook func(context.Context, int) ([]shared1.RepositoryWithCount, error)) {
+func (f *StoreTopRepositoriesToConfigureFunc) SetDefaultHook(hook func(context.Context, int) ([]shared2.RepositoryWithCount, error)) {
 	f.defaultHook = hook
 }
 
@@ -2188,7 +2331,7 @@ func (f *StoreTopRepositoriesToConfigureFunc) SetDefaultHook(hook func(context.C
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreTopRepositoriesToConfigureFunc) PushHook(hook func(context.Context, int) ([]shared1.RepositoryWithCount, error)) {
+func (f *StoreTopRepositoriesToConfigureFunc) PushHook(hook func(context.Context, int) ([]shared2.RepositoryWithCount, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2196,20 +2339,20 @@ func (f *StoreTopRepositoriesToConfigureFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreTopRepositoriesToConfigureFunc) SetDefaultReturn(r0 []shared1.RepositoryWithCount, r1 error) {
-	f.SetDefaultHook(func(context.Context, int) ([]shared1.RepositoryWithCount, error) {
+func (f *StoreTopRepositoriesToConfigureFunc) SetDefaultReturn(r0 []shared2.RepositoryWithCount, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]shared2.RepositoryWithCount, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreTopRepositoriesToConfigureFunc) PushReturn(r0 []shared1.RepositoryWithCount, r1 error) {
-	f.PushHook(func(context.Context, int) ([]shared1.RepositoryWithCount, error) {
+func (f *StoreTopRepositoriesToConfigureFunc) PushReturn(r0 []shared2.RepositoryWithCount, r1 error) {
+	f.PushHook(func(context.Context, int) ([]shared2.RepositoryWithCount, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreTopRepositoriesToConfigureFunc) nextHook() func(context.Context, int) ([]shared1.RepositoryWithCount, error) {
+func (f *StoreTopRepositoriesToConfigureFunc) nextHook() func(context.Context, int) ([]shared2.RepositoryWithCount, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2251,7 +2394,7 @@ type StoreTopRepositoriesToConfigureFuncCall struct {
 	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared1.RepositoryWithCount
+	Result0 []shared2.RepositoryWithCount
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2595,6 +2738,164 @@ func (c StoreWithTransactionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockDependencyResolver is a mock implementation of the DependencyResolver
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing) used
+// for unit testing.
+type MockDependencyResolver struct {
+	// ResolvePackagesFunc is an instance of a mock function object
+	// controlling the behavior of the method ResolvePackages.
+	ResolvePackagesFunc *DependencyResolverResolvePackagesFunc
+}
+
+// NewMockDependencyResolver creates a new mock of the DependencyResolver
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockDependencyResolver() *MockDependencyResolver {
+	return &MockDependencyResolver{
+		ResolvePackagesFunc: &DependencyResolverResolvePackagesFunc{
+			defaultHook: func(context.Context, int, []shared2.Package) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockDependencyResolver creates a new mock of the
+// DependencyResolver interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockDependencyResolver() *MockDependencyResolver {
+	return &MockDependencyResolver{
+		ResolvePackagesFunc: &DependencyResolverResolvePackagesFunc{
+			defaultHook: func(context.Context, int, []shared2.Package) error {
+				panic("unexpected invocation of MockDependencyResolver.ResolvePackages")
+			},
+		},
+	}
+}
+
+// NewMockDependencyResolverFrom creates a new mock of the
+// MockDependencyResolver interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockDependencyResolverFrom(i DependencyResolver) *MockDependencyResolver {
+	return &MockDependencyResolver{
+		ResolvePackagesFunc: &DependencyResolverResolvePackagesFunc{
+			defaultHook: i.ResolvePackages,
+		},
+	}
+}
+
+// DependencyResolverResolvePackagesFunc describes the behavior when the
+// ResolvePackages method of the parent MockDependencyResolver instance is
+// invoked.
+type DependencyResolverResolvePackagesFunc struct {
+	defaultHook func(context.Context, int, []shared2.Package) error
+	hooks       []func(context.Context, int, []shared2.Package) error
+	history     []DependencyResolverResolvePackagesFuncCall
+	mutex       sync.Mutex
+}
+
+// ResolvePackages delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDependencyResolver) ResolvePackages(v0 context.Context, v1 int, v2 []shared2.Package) error {
+	r0 := m.ResolvePackagesFunc.nextHook()(v0, v1, v2)
+	m.ResolvePackagesFunc.appendCall(DependencyResolverResolvePackagesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ResolvePackages
+// method of the parent MockDependencyResolver instance is invoked and the
+// hook queue is empty.
+func (f *DependencyResolverResolvePackagesFunc) SetDefaultHook(hook func(context.Context, int, []shared2.Package) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ResolvePackages method of the parent MockDependencyResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DependencyResolverResolvePackagesFunc) PushHook(hook func(context.Context, int, []shared2.Package) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DependencyResolverResolvePackagesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []shared2.Package) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DependencyResolverResolvePackagesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []shared2.Package) error {
+		return r0
+	})
+}
+
+func (f *DependencyResolverResolvePackagesFunc) nextHook() func(context.Context, int, []shared2.Package) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DependencyResolverResolvePackagesFunc) appendCall(r0 DependencyResolverResolvePackagesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DependencyResolverResolvePackagesFuncCall
+// objects describing the invocations of this function.
+func (f *DependencyResolverResolvePackagesFunc) History() []DependencyResolverResolvePackagesFuncCall {
+	f.mutex.Lock()
+	history := make([]DependencyResolverResolvePackagesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DependencyResolverResolvePackagesFuncCall is an object that describes an
+// invocation of method ResolvePackages on an instance of
+// MockDependencyResolver.
+type DependencyResolverResolvePackagesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []shared2.Package
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DependencyResolverResolvePackagesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DependencyResolverResolvePackagesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockInferenceService is a mock implementation of the InferenceService
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing) used
@@ -2937,22 +3238,22 @@ type MockUploadService struct {
 func NewMockUploadService() *MockUploadService {
 	return &MockUploadService{
 		GetRecentIndexesSummaryFunc: &UploadServiceGetRecentIndexesSummaryFunc{
-			defaultHook: func(context.Context, int) (r0 []shared1.IndexesWithRepositoryNamespace, r1 error) {
+			defaultHook: func(context.Context, int) (r0 []shared2.IndexesWithRepositoryNamespace, r1 error) {
 				return
 			},
 		},
 		GetRecentUploadsSummaryFunc: &UploadServiceGetRecentUploadsSummaryFunc{
-			defaultHook: func(context.Context, int) (r0 []shared1.UploadsWithRepositoryNamespace, r1 error) {
+			defaultHook: func(context.Context, int) (r0 []shared2.UploadsWithRepositoryNamespace, r1 error) {
 				return
 			},
 		},
 		GetUploadByIDFunc: &UploadServiceGetUploadByIDFunc{
-			defaultHook: func(context.Context, int) (r0 shared1.Upload, r1 bool, r2 error) {
+			defaultHook: func(context.Context, int) (r0 shared2.Upload, r1 bool, r2 error) {
 				return
 			},
 		},
 		ReferencesForUploadFunc: &UploadServiceReferencesForUploadFunc{
-			defaultHook: func(context.Context, int) (r0 shared1.PackageReferenceScanner, r1 error) {
+			defaultHook: func(context.Context, int) (r0 shared2.PackageReferenceScanner, r1 error) {
 				return
 			},
 		},
@@ -2964,22 +3265,22 @@ func NewMockUploadService() *MockUploadService {
 func NewStrictMockUploadService() *MockUploadService {
 	return &MockUploadService{
 		GetRecentIndexesSummaryFunc: &UploadServiceGetRecentIndexesSummaryFunc{
-			defaultHook: func(context.Context, int) ([]shared1.IndexesWithRepositoryNamespace, error) {
+			defaultHook: func(context.Context, int) ([]shared2.IndexesWithRepositoryNamespace, error) {
 				panic("unexpected invocation of MockUploadService.GetRecentIndexesSummary")
 			},
 		},
 		GetRecentUploadsSummaryFunc: &UploadServiceGetRecentUploadsSummaryFunc{
-			defaultHook: func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error) {
+			defaultHook: func(context.Context, int) ([]shared2.UploadsWithRepositoryNamespace, error) {
 				panic("unexpected invocation of MockUploadService.GetRecentUploadsSummary")
 			},
 		},
 		GetUploadByIDFunc: &UploadServiceGetUploadByIDFunc{
-			defaultHook: func(context.Context, int) (shared1.Upload, bool, error) {
+			defaultHook: func(context.Context, int) (shared2.Upload, bool, error) {
 				panic("unexpected invocation of MockUploadService.GetUploadByID")
 			},
 		},
 		ReferencesForUploadFunc: &UploadServiceReferencesForUploadFunc{
-			defaultHook: func(context.Context, int) (shared1.PackageReferenceScanner, error) {
+			defaultHook: func(context.Context, int) (shared2.PackageReferenceScanner, error) {
 				panic("unexpected invocation of MockUploadService.ReferencesForUpload")
 			},
 		},
@@ -3010,15 +3311,15 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 // GetRecentIndexesSummary method of the parent MockUploadService instance
 // is invoked.
 type UploadServiceGetRecentIndexesSummaryFunc struct {
-	defaultHook func(context.Context, int) ([]shared1.IndexesWithRepositoryNamespace, error)
-	hooks       []func(context.Context, int) ([]shared1.IndexesWithRepositoryNamespace, error)
+	defaultHook func(context.Context, int) ([]shared2.IndexesWithRepositoryNamespace, error)
+	hooks       []func(context.Context, int) ([]shared2.IndexesWithRepositoryNamespace, error)
 	history     []UploadServiceGetRecentIndexesSummaryFuncCall
 	mutex       sync.Mutex
 }
 
 // GetRecentIndexesSummary delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockUploadService) GetRecentIndexesSummary(v0 context.Context, v1 int) ([]shared1.IndexesWithRepositoryNamespace, error) {
+func (m *MockUploadService) GetRecentIndexesSummary(v0 context.Context, v1 int) ([]shared2.IndexesWithRepositoryNamespace, error) {
 	r0, r1 := m.GetRecentIndexesSummaryFunc.nextHook()(v0, v1)
 	m.GetRecentIndexesSummaryFunc.appendCall(UploadServiceGetRecentIndexesSummaryFuncCall{v0, v1, r0, r1})
 	return r0, r1
@@ -3027,7 +3328,7 @@ func (m *MockUploadService) GetRecentIndexesSummary(v0 context.Context, v1 int)
 // SetDefaultHook sets function that is called when the
 // GetRecentIndexesSummary method of the parent MockUploadService instance
 // is invoked and the hook queue is empty.
-func (f *UploadServiceGetRecentIndexesSummaryFunc) SetDefaultHook(hook func(context.Context, int) ([]shared1.IndexesWithRepositoryNamespace, error)) {
+func (f *UploadServiceGetRecentIndexesSummaryFunc) SetDefaultHook(hook func(context.Context, int) ([]shared2.IndexesWithRepositoryNamespace, error)) {
 	f.defaultHook = hook
 }
 
@@ -3036,7 +3337,7 @@ func (f *UploadServiceGetRecentIndexesSummaryFunc) SetDefaultHook(hook func(cont
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *UploadServiceGetRecentIndexesSummaryFunc) PushHook(hook func(context.Context, int) ([]shared1.IndexesWithRepositoryNamespace, error)) {
+func (f *UploadServiceGetRecentIndexesSummaryFunc) PushHook(hook func(context.Context, int) ([]shared2.IndexesWithRepositoryNamespace, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3044,20 +3345,20 @@ func (f *UploadServiceGetRecentIndexesSummaryFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UploadServiceGetRecentIndexesSummaryFunc) SetDefaultReturn(r0 []shared1.IndexesWithRepositoryNamespace, r1 error) {
-	f.SetDefaultHook(func(context.Context, int) ([]shared1.IndexesWithRepositoryNamespace, error) {
+func (f *UploadServiceGetRecentIndexesSummaryFunc) SetDefaultReturn(r0 []shared2.IndexesWithRepositoryNamespace, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]shared2.IndexesWithRepositoryNamespace, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UploadServiceGetRecentIndexesSummaryFunc) PushReturn(r0 []shared1.IndexesWithRepositoryNamespace, r1 error) {
-	f.PushHook(func(context.Context, int) ([]shared1.IndexesWithRepositoryNamespace, error) {
+func (f *UploadServiceGetRecentIndexesSummaryFunc) PushReturn(r0 []shared2.IndexesWithRepositoryNamespace, r1 error) {
+	f.PushHook(func(context.Context, int) ([]shared2.IndexesWithRepositoryNamespace, error) {
 		return r0, r1
 	})
 }
 
-func (f *UploadServiceGetRecentIndexesSummaryFunc) nextHook() func(context.Context, int) ([]shared1.IndexesWithRepositoryNamespace, error) {
+func (f *UploadServiceGetRecentIndexesSummaryFunc) nextHook() func(context.Context, int) ([]shared2.IndexesWithRepositoryNamespace, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3100,7 +3401,7 @@ type UploadServiceGetRecentIndexesSummaryFuncCall struct {
 	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared1.IndexesWithRepositoryNamespace
+	Result0 []shared2.IndexesWithRepositoryNamespace
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3122,15 +3423,15 @@ func (c UploadServiceGetRecentIndexesSummaryFuncCall) Results() []interface{} {
 // GetRecentUploadsSummary method of the parent MockUploadService instance
 // is invoked.
 type UploadServiceGetRecentUploadsSummaryFunc struct {
-	defaultHook func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error)
-	hooks       []func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error)
+	defaultHook func(context.Context, int) ([]shared2.UploadsWithRepositoryNamespace, error)
+	hooks       []func(context.Context, int) ([]shared2.UploadsWithRepositoryNamespace, error)
 	history     []UploadServiceGetRecentUploadsSummaryFuncCall
 	mutex       sync.Mutex
 }
 
 // GetRecentUploadsSummary delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockUploadService) GetRecentUploadsSummary(v0 context.Context, v1 int) ([]shared1.UploadsWithRepositoryNamespace, error) {
+func (m *MockUploadService) GetRecentUploadsSummary(v0 context.Context, v1 int) ([]shared2.UploadsWithRepositoryNamespace, error) {
 	r0, r1 := m.GetRecentUploadsSummaryFunc.nextHook()(v0, v1)
 	m.GetRecentUploadsSummaryFunc.appendCall(UploadServiceGetRecentUploadsSummaryFuncCall{v0, v1, r0, r1})
 	return r0, r1
@@ -3139,7 +3440,7 @@ func (m *MockUploadService) GetRecentUploadsSummary(v0 context.Context, v1 int)
 // SetDefaultHook sets function that is called when the
 // GetRecentUploadsSummary method of the parent MockUploadService instance
 // is invoked and the hook queue is empty.
-func (f *UploadServiceGetRecentUploadsSummaryFunc) SetDefaultHook(hook func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error)) {
+func (f *UploadServiceGetRecentUploadsSummaryFunc) SetDefaultHook(hook func(context.Context, int) ([]shared2.UploadsWithRepositoryNamespace, error)) {
 	f.defaultHook = hook
 }
 
@@ -3148,7 +3449,7 @@ func (f *UploadServiceGetRecentUploadsSummaryFunc) SetDefaultHook(hook func(cont
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *UploadServiceGetRecentUploadsSummaryFunc) PushHook(hook func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error)) {
+func (f *UploadServiceGetRecentUploadsSummaryFunc) PushHook(hook func(context.Context, int) ([]shared2.UploadsWithRepositoryNamespace, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3156,20 +3457,20 @@ func (f *UploadServiceGetRecentUploadsSummaryFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UploadServiceGetRecentUploadsSummaryFunc) SetDefaultReturn(r0 []shared1.UploadsWithRepositoryNamespace, r1 error) {
-	f.SetDefaultHook(func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error) {
+func (f *UploadServiceGetRecentUploadsSummaryFunc) SetDefaultReturn(r0 []shared2.UploadsWithRepositoryNamespace, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]shared2.UploadsWithRepositoryNamespace, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UploadServiceGetRecentUploadsSummaryFunc) PushReturn(r0 []shared1.UploadsWithRepositoryNamespace, r1 error) {
-	f.PushHook(func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error) {
+func (f *UploadServiceGetRecentUploadsSummaryFunc) PushReturn(r0 []shared2.UploadsWithRepositoryNamespace, r1 error) {
+	f.PushHook(func(context.Context, int) ([]shared2.UploadsWithRepositoryNamespace, error) {
 		return r0, r1
 	})
 }
 
-func (f *UploadServiceGetRecentUploadsSummaryFunc) nextHook() func(context.Context, int) ([]shared1.UploadsWithRepositoryNamespace, error) {
+func (f *UploadServiceGetRecentUploadsSummaryFunc) nextHook() func(context.Context, int) ([]shared2.UploadsWithRepositoryNamespace, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3212,7 +3513,7 @@ type UploadServiceGetRecentUploadsSummaryFuncCall struct {
 	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared1.UploadsWithRepositoryNamespace
+	Result0 []shared2.UploadsWithRepositoryNamespace
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3233,15 +3534,15 @@ func (c UploadServiceGetRecentUploadsSummaryFuncCall) Results() []interface{} {
 // UploadServiceGetUploadByIDFunc describes the behavior when the
 // GetUploadByID method of the parent MockUploadService instance is invoked.
 type UploadServiceGetUploadByIDFunc struct {
-	defaultHook func(context.Context, int) (shared1.Upload, bool, error)
-	hooks       []func(context.Context, int) (shared1.Upload, bool, error)
+	defaultHook func(context.Context, int) (shared2.Upload, bool, error)
+	hooks       []func(context.Context, int) (shared2.Upload, bool, error)
 	history     []UploadServiceGetUploadByIDFuncCall
 	mutex       sync.Mutex
 }
 
 // GetUploadByID delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockUploadService) GetUploadByID(v0 context.Context, v1 int) (shared1.Upload, bool, error) {
+func (m *MockUploadService) GetUploadByID(v0 context.Context, v1 int) (shared2.Upload, bool, error) {
 	r0, r1, r2 := m.GetUploadByIDFunc.nextHook()(v0, v1)
 	m.GetUploadByIDFunc.appendCall(UploadServiceGetUploadByIDFuncCall{v0, v1, r0, r1, r2})
 	return r0, r1, r2
@@ -3250,7 +3551,7 @@ func (m *MockUploadService) GetUploadByID(v0 context.Context, v1 int) (shared1.U
 // SetDefaultHook sets function that is called when the GetUploadByID method
 // of the parent MockUploadService instance is invoked and the hook queue is
 // empty.
-func (f *UploadServiceGetUploadByIDFunc) SetDefaultHook(hook func(context.Context, int) (shared1.Upload, bool, error)) {
+func (f *UploadServiceGetUploadByIDFunc) SetDefaultHook(hook func(context.Context, int) (shared2.Upload, bool, error)) {
 	f.defaultHook = hook
 }
 
@@ -3258,7 +3559,7 @@ func (f *UploadServiceGetUploadByIDFunc) SetDefaultHook(hook func(context.Contex
 // GetUploadByID method of the parent MockUploadService instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *UploadServiceGetUploadByIDFunc) PushHook(hook func(context.Context, int) (shared1.Upload, bool, error)) {
+func (f *UploadServiceGetUploadByIDFunc) PushHook(hook func(context.Context, int) (shared2.Upload, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3266,20 +3567,20 @@ func (f *UploadServiceGetUploadByIDFunc) PushHook(hook func(context.Context, int
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UploadServiceGetUploadByIDFunc) SetDefaultReturn(r0 shared1.Upload, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int) (shared1.Upload, bool, error) {
+func (f *UploadServiceGetUploadByIDFunc) SetDefaultReturn(r0 shared2.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (shared2.Upload, bool, error) {
 		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UploadServiceGetUploadByIDFunc) PushReturn(r0 shared1.Upload, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int) (shared1.Upload, bool, error) {
+func (f *UploadServiceGetUploadByIDFunc) PushReturn(r0 shared2.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (shared2.Upload, bool, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *UploadServiceGetUploadByIDFunc) nextHook() func(context.Context, int) (shared1.Upload, bool, error) {
+func (f *UploadServiceGetUploadByIDFunc) nextHook() func(context.Context, int) (shared2.Upload, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3320,7 +3621,7 @@ type UploadServiceGetUploadByIDFuncCall struct {
 	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 shared1.Upload
+	Result0 shared2.Upload
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 bool
@@ -3345,15 +3646,15 @@ func (c UploadServiceGetUploadByIDFuncCall) Results() []interface{} {
 // ReferencesForUpload method of the parent MockUploadService instance is
 // invoked.
 type UploadServiceReferencesForUploadFunc struct {
-	defaultHook func(context.Context, int) (shared1.PackageReferenceScanner, error)
-	hooks       []func(context.Context, int) (shared1.PackageReferenceScanner, error)
+	defaultHook func(context.Context, int) (shared2.PackageReferenceScanner, error)
+	hooks       []func(context.Context, int) (shared2.PackageReferenceScanner, error)
 	history     []UploadServiceReferencesForUploadFuncCall
 	mutex       sync.Mutex
 }
 
 // ReferencesForUpload delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockUploadService) ReferencesForUpload(v0 context.Context, v1 int) (shared1.PackageReferenceScanner, error) {
+func (m *MockUploadService) ReferencesForUpload(v0 context.Context, v1 int) (shared2.PackageReferenceScanner, error) {
 	r0, r1 := m.ReferencesForUploadFunc.nextHook()(v0, v1)
 	m.ReferencesForUploadFunc.appendCall(UploadServiceReferencesForUploadFuncCall{v0, v1, r0, r1})
 	return r0, r1
@@ -3362,7 +3663,7 @@ func (m *MockUploadService) ReferencesForUpload(v0 context.Context, v1 int) (sha
 // SetDefaultHook sets function that is called when the ReferencesForUpload
 // method of the parent MockUploadService instance is invoked and the hook
 // queue is empty.
-func (f *UploadServiceReferencesForUploadFunc) SetDefaultHook(hook func(context.Context, int) (shared1.PackageReferenceScanner, error)) {
+func (f *UploadServiceReferencesForUploadFunc) SetDefaultHook(hook func(context.Context, int) (shared2.PackageReferenceScanner, error)) {
 	f.defaultHook = hook
 }
 
@@ -3371,7 +3672,7 @@ func (f *UploadServiceReferencesForUploadFunc) SetDefaultHook(hook func(context.
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *UploadServiceReferencesForUploadFunc) PushHook(hook func(context.Context, int) (shared1.PackageReferenceScanner, error)) {
+func (f *UploadServiceReferencesForUploadFunc) PushHook(hook func(context.Context, int) (shared2.PackageReferenceScanner, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3379,20 +3680,20 @@ func (f *UploadServiceReferencesForUploadFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UploadServiceReferencesForUploadFunc) SetDefaultReturn(r0 shared1.PackageReferenceScanner, r1 error) {
-	f.SetDefaultHook(func(context.Context, int) (shared1.PackageReferenceScanner, error) {
+func (f *UploadServiceReferencesForUploadFunc) SetDefaultReturn(r0 shared2.PackageReferenceScanner, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (shared2.PackageReferenceScanner, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UploadServiceReferencesForUploadFunc) PushReturn(r0 shared1.PackageReferenceScanner, r1 error) {
-	f.PushHook(func(context.Context, int) (shared1.PackageReferenceScanner, error) {
+func (f *UploadServiceReferencesForUploadFunc) PushReturn(r0 shared2.PackageReferenceScanner, r1 error) {
+	f.PushHook(func(context.Context, int) (shared2.PackageReferenceScanner, error) {
 		return r0, r1
 	})
 }
 
-func (f *UploadServiceReferencesForUploadFunc) nextHook() func(context.Context, int) (shared1.PackageReferenceScanner, error) {
+func (f *UploadServiceReferencesForUploadFunc) nextHook() func(context.Context, int) (shared2.PackageReferenceScanner, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3434,7 +3735,7 @@ type UploadServiceReferencesForUploadFuncCall struct {
 	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 shared1.PackageReferenceScanner
+	Result0 shared2.PackageReferenceScanner
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
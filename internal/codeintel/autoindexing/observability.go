@@ -10,7 +10,8 @@ import (
 )
 
 type operations struct {
-	inferIndexConfiguration *observation.Operation
+	inferIndexConfiguration   *observation.Operation
+	resolveDependencyPackages *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -40,6 +41,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		inferIndexConfiguration: op("InferIndexConfiguration"),
+		inferIndexConfiguration:   op("InferIndexConfiguration"),
+		resolveDependencyPackages: op("ResolveDependencyPackages"),
 	}
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/shared"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -22,12 +23,13 @@ import (
 )
 
 type Service struct {
-	store           store.Store
-	repoStore       database.RepoStore
-	gitserverClient gitserver.Client
-	indexEnqueuer   *enqueuer.IndexEnqueuer
-	jobSelector     *jobselector.JobSelector
-	operations      *operations
+	store              store.Store
+	repoStore          database.RepoStore
+	gitserverClient    gitserver.Client
+	indexEnqueuer      *enqueuer.IndexEnqueuer
+	jobSelector        *jobselector.JobSelector
+	dependencyResolver DependencyResolver
+	operations         *operations
 }
 
 func newService(
@@ -36,6 +38,7 @@ func newService(
 	inferenceSvc InferenceService,
 	repoStore database.RepoStore,
 	gitserverClient gitserver.Client,
+	dependencyResolver DependencyResolver,
 ) *Service {
 	// NOTE - this should go up a level in init.go.
 	// Not going to do this now so that we don't blow up all of the
@@ -61,12 +64,13 @@ func newService(
 	)
 
 	return &Service{
-		store:           store,
-		repoStore:       repoStore,
-		gitserverClient: gitserverClient,
-		indexEnqueuer:   indexEnqueuer,
-		jobSelector:     jobSelector,
-		operations:      newOperations(observationCtx),
+		store:              store,
+		repoStore:          repoStore,
+		gitserverClient:    gitserverClient,
+		indexEnqueuer:      indexEnqueuer,
+		jobSelector:        jobSelector,
+		dependencyResolver: dependencyResolver,
+		operations:         newOperations(observationCtx),
 	}
 }
 
@@ -132,6 +136,23 @@ func (s *Service) QueueIndexesForPackage(ctx context.Context, pkg dependencies.M
 	return s.indexEnqueuer.QueueIndexesForPackage(ctx, pkg)
 }
 
+// ResolveDependencyPackages queues the given packages referenced by the given upload to be added to
+// the instance, cloned, and auto-indexed. This is a no-op unless auto-indexing is enabled and dependencies
+// are configured to be resolved on navigation.
+func (s *Service) ResolveDependencyPackages(ctx context.Context, uploadID int, pkgs []uploadsshared.Package) (err error) {
+	ctx, _, endObservation := s.operations.resolveDependencyPackages.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.Int("numPackages", len(pkgs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if !conf.CodeIntelAutoIndexingEnabled() || !conf.CodeIntelAutoIndexingResolveDependenciesOnNavigation() {
+		return nil
+	}
+
+	return s.dependencyResolver.ResolvePackages(ctx, uploadID, pkgs)
+}
+
 func (s *Service) InferIndexJobsFromRepositoryStructure(ctx context.Context, repositoryID int, commit string, localOverrideScript string, bypassLimit bool) (*shared.InferenceResult, error) {
 	return s.jobSelector.InferIndexJobsFromRepositoryStructure(ctx, repositoryID, commit, localOverrideScript, bypassLimit)
 }
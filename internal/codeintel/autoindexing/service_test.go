@@ -67,6 +67,7 @@ func TestQueueIndexesExplicit(t *testing.T) {
 		inferenceService,
 		defaultMockRepoStore(), // repoStore
 		mockGitserverClient,
+		nil, // dependencyResolver
 	)
 	_, _ = service.QueueIndexes(context.Background(), 42, "HEAD", conf, false, false)
 
@@ -165,6 +166,7 @@ func TestQueueIndexesInDatabase(t *testing.T) {
 		inferenceService,
 		defaultMockRepoStore(), // repoStore
 		mockGitserverClient,
+		nil, // dependencyResolver
 	)
 	_, _ = service.QueueIndexes(context.Background(), 42, "HEAD", "", false, false)
 
@@ -268,6 +270,7 @@ func TestQueueIndexesInRepository(t *testing.T) {
 		inferenceService,
 		defaultMockRepoStore(), // repoStore
 		gitserverClient,
+		nil, // dependencyResolver
 	)
 
 	if _, err := service.QueueIndexes(context.Background(), 42, "HEAD", "", false, false); err != nil {
@@ -354,6 +357,7 @@ func TestQueueIndexesInferred(t *testing.T) {
 		inferenceService,
 		defaultMockRepoStore(), // repoStore
 		gitserverClient,
+		nil, // dependencyResolver
 	)
 
 	for _, id := range []int{41, 42, 43, 44} {
@@ -442,6 +446,7 @@ func TestQueueIndexesForPackage(t *testing.T) {
 		inferenceService,
 		mockRepoStore, // repoStore
 		gitserverClient,
+		nil, // dependencyResolver
 	)
 
 	_ = service.QueueIndexesForPackage(context.Background(), dependencies.MinimialVersionedPackageRepo{
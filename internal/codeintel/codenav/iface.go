@@ -13,3 +13,7 @@ type UploadService interface {
 	GetDumpsByIDs(ctx context.Context, ids []int) (_ []shared.Dump, err error)
 	InferClosestUploads(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []shared.Dump, err error)
 }
+
+type AutoIndexingService interface {
+	ResolveDependencyPackages(ctx context.Context, uploadID int, pkgs []shared.Package) error
+}
@@ -14,6 +14,7 @@ func NewService(
 	codeIntelDB codeintelshared.CodeIntelDB,
 	uploadSvc UploadService,
 	gitserver gitserver.Client,
+	autoindexingSvc AutoIndexingService,
 ) *Service {
 	lsifStore := lsifstore.New(scopedContext("lsifstore", observationCtx), codeIntelDB)
 
@@ -23,6 +24,7 @@ func NewService(
 		lsifStore,
 		uploadSvc,
 		gitserver,
+		autoindexingSvc,
 	)
 }
 
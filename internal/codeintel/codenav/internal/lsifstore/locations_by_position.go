@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
@@ -50,6 +51,9 @@ func (s *store) GetBulkMonikerLocations(ctx context.Context, tableName string, u
 	}})
 	defer endObservation(1, observation.Args{})
 
+	// Location queries are read-heavy and tolerate the bounded lag of a replica.
+	ctx = basestore.WithReadReplicaPreferred(ctx)
+
 	if len(uploadIDs) == 0 || len(monikers) == 0 {
 		return nil, 0, nil
 	}
@@ -141,6 +145,8 @@ func (s *store) getLocations(
 	}})
 	defer endObservation(1, observation.Args{})
 
+	ctx = basestore.WithReadReplicaPreferred(ctx)
+
 	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
 		locationsDocumentQuery,
 		bundleID,
@@ -441,6 +447,8 @@ func (s *store) extractLocationsFromPosition(
 	}})
 	defer endObservation(1, observation.Args{})
 
+	ctx = basestore.WithReadReplicaPreferred(ctx)
+
 	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
 		locationsDocumentQuery,
 		locationKey.UploadID,
@@ -516,6 +524,8 @@ func (s *store) GetMinimalBulkMonikerLocations(ctx context.Context, tableName st
 	}})
 	defer endObservation(1, observation.Args{})
 
+	ctx = basestore.WithReadReplicaPreferred(ctx)
+
 	if len(uploadIDs) == 0 || len(monikers) == 0 {
 		return nil, 0, nil
 	}
@@ -2502,6 +2502,166 @@ func (c LsifStoreSCIPDocumentFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// MockAutoIndexingService is a mock implementation of the
+// AutoIndexingService interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/codenav) used for
+// unit testing.
+type MockAutoIndexingService struct {
+	// ResolveDependencyPackagesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ResolveDependencyPackages.
+	ResolveDependencyPackagesFunc *AutoIndexingServiceResolveDependencyPackagesFunc
+}
+
+// NewMockAutoIndexingService creates a new mock of the AutoIndexingService
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockAutoIndexingService() *MockAutoIndexingService {
+	return &MockAutoIndexingService{
+		ResolveDependencyPackagesFunc: &AutoIndexingServiceResolveDependencyPackagesFunc{
+			defaultHook: func(context.Context, int, []shared1.Package) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockAutoIndexingService creates a new mock of the
+// AutoIndexingService interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockAutoIndexingService() *MockAutoIndexingService {
+	return &MockAutoIndexingService{
+		ResolveDependencyPackagesFunc: &AutoIndexingServiceResolveDependencyPackagesFunc{
+			defaultHook: func(context.Context, int, []shared1.Package) error {
+				panic("unexpected invocation of MockAutoIndexingService.ResolveDependencyPackages")
+			},
+		},
+	}
+}
+
+// NewMockAutoIndexingServiceFrom creates a new mock of the
+// MockAutoIndexingService interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockAutoIndexingServiceFrom(i AutoIndexingService) *MockAutoIndexingService {
+	return &MockAutoIndexingService{
+		ResolveDependencyPackagesFunc: &AutoIndexingServiceResolveDependencyPackagesFunc{
+			defaultHook: i.ResolveDependencyPackages,
+		},
+	}
+}
+
+// AutoIndexingServiceResolveDependencyPackagesFunc describes the behavior
+// when the ResolveDependencyPackages method of the parent
+// MockAutoIndexingService instance is invoked.
+type AutoIndexingServiceResolveDependencyPackagesFunc struct {
+	defaultHook func(context.Context, int, []shared1.Package) error
+	hooks       []func(context.Context, int, []shared1.Package) error
+	history     []AutoIndexingServiceResolveDependencyPackagesFuncCall
+	mutex       sync.Mutex
+}
+
+// ResolveDependencyPackages delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockAutoIndexingService) ResolveDependencyPackages(v0 context.Context, v1 int, v2 []shared1.Package) error {
+	r0 := m.ResolveDependencyPackagesFunc.nextHook()(v0, v1, v2)
+	m.ResolveDependencyPackagesFunc.appendCall(AutoIndexingServiceResolveDependencyPackagesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ResolveDependencyPackages method of the parent MockAutoIndexingService
+// instance is invoked and the hook queue is empty.
+func (f *AutoIndexingServiceResolveDependencyPackagesFunc) SetDefaultHook(hook func(context.Context, int, []shared1.Package) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ResolveDependencyPackages method of the parent MockAutoIndexingService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *AutoIndexingServiceResolveDependencyPackagesFunc) PushHook(hook func(context.Context, int, []shared1.Package) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AutoIndexingServiceResolveDependencyPackagesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []shared1.Package) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AutoIndexingServiceResolveDependencyPackagesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []shared1.Package) error {
+		return r0
+	})
+}
+
+func (f *AutoIndexingServiceResolveDependencyPackagesFunc) nextHook() func(context.Context, int, []shared1.Package) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AutoIndexingServiceResolveDependencyPackagesFunc) appendCall(r0 AutoIndexingServiceResolveDependencyPackagesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AutoIndexingServiceResolveDependencyPackagesFuncCall objects describing
+// the invocations of this function.
+func (f *AutoIndexingServiceResolveDependencyPackagesFunc) History() []AutoIndexingServiceResolveDependencyPackagesFuncCall {
+	f.mutex.Lock()
+	history := make([]AutoIndexingServiceResolveDependencyPackagesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AutoIndexingServiceResolveDependencyPackagesFuncCall is an object that
+// describes an invocation of method ResolveDependencyPackages on an
+// instance of MockAutoIndexingService.
+type AutoIndexingServiceResolveDependencyPackagesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []shared1.Package
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AutoIndexingServiceResolveDependencyPackagesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AutoIndexingServiceResolveDependencyPackagesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockGitTreeTranslator is a mock implementation of the GitTreeTranslator
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/codeintel/codenav) used for
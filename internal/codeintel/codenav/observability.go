@@ -23,6 +23,9 @@ type operations struct {
 	getClosestDumpsForBlob *observation.Operation
 	snapshotForDocument    *observation.Operation
 	visibleUploadsForPath  *observation.Operation
+
+	getSemanticHighlighting      *observation.Operation
+	supportsSemanticHighlighting *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -57,6 +60,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getClosestDumpsForBlob: op("GetClosestDumpsForBlob"),
 		snapshotForDocument:    op("SnapshotForDocument"),
 		visibleUploadsForPath:  op("VisibleUploadsForPath"),
+
+		getSemanticHighlighting:      op("getSemanticHighlighting"),
+		supportsSemanticHighlighting: op("supportsSemanticHighlighting"),
 	}
 }
 
@@ -0,0 +1,251 @@
+package codenav
+
+import (
+	"context"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gosyntect"
+	"github.com/sourcegraph/sourcegraph/internal/highlight"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SupportsSemanticHighlighting returns true if a precise index of the given path has
+// occurrences from which GetSemanticHighlighting can classify identifiers.
+func (s *Service) SupportsSemanticHighlighting(ctx context.Context, args PositionalRequestArgs, requestState RequestState) (_ bool, err error) {
+	ctx, _, endObservation := observeResolver(ctx, &err, s.operations.supportsSemanticHighlighting, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", args.RepositoryID),
+		attribute.String("commit", args.Commit),
+		attribute.String("path", args.Path),
+		attribute.Int("numUploads", len(requestState.GetCacheUploads())),
+		attribute.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+	}})
+	defer endObservation()
+
+	adjustedUploads, err := s.getUploadPaths(ctx, args.Path, requestState)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range adjustedUploads {
+		document, err := s.lsifstore.SCIPDocument(ctx, adjustedUploads[i].Upload.ID, adjustedUploads[i].TargetPathWithoutRoot)
+		if err != nil {
+			return false, errors.Wrap(err, "lsifStore.SCIPDocument")
+		}
+		if document == nil {
+			continue
+		}
+
+		for _, occurrence := range document.Occurrences {
+			if _, ok := semanticSyntaxKind(occurrence); ok {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// GetSemanticHighlighting returns the syntax highlighting of the given path, in which the
+// syntax kinds of identifiers are replaced by the kinds of the symbols they resolve to in
+// the precise indexes of the path. It returns nil if no precise index of the path has
+// occurrences from which identifiers can be classified.
+func (s *Service) GetSemanticHighlighting(ctx context.Context, args PositionalRequestArgs, requestState RequestState, disableTimeout bool) (_ *scip.Document, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getSemanticHighlighting, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", args.RepositoryID),
+		attribute.String("commit", args.Commit),
+		attribute.String("path", args.Path),
+		attribute.Int("numUploads", len(requestState.GetCacheUploads())),
+		attribute.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+	}})
+	defer endObservation()
+
+	adjustedUploads, err := s.getUploadPaths(ctx, args.Path, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []*scip.Occurrence
+	for i := range adjustedUploads {
+		upload := adjustedUploads[i].Upload
+
+		document, err := s.lsifstore.SCIPDocument(ctx, upload.ID, adjustedUploads[i].TargetPathWithoutRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.SCIPDocument")
+		}
+		if document == nil {
+			continue
+		}
+
+		for _, occurrence := range document.Occurrences {
+			if _, ok := semanticSyntaxKind(occurrence); !ok {
+				continue
+			}
+
+			// Adjust the occurrence back to the appropriate range in the target commit
+			_, adjustedRange, ok, err := s.getSourceRange(ctx, args.RequestArgs, requestState, upload.RepositoryID, upload.Commit, args.Path, translateSCIPRange(scip.NewRange(occurrence.Range)))
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			occurrences = append(occurrences, &scip.Occurrence{
+				Range:       scipRangeFromRange(adjustedRange),
+				Symbol:      occurrence.Symbol,
+				SymbolRoles: occurrence.SymbolRoles,
+			})
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numOccurrences", len(occurrences)))
+
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	repo, err := s.repoStore.Get(ctx, api.RepoID(args.RepositoryID))
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.gitserver.ReadFile(ctx, repo.Name, api.CommitID(args.Commit), args.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	highlighted, aborted, err := highlight.Code(ctx, highlight.Params{
+		Content:        content,
+		Filepath:       args.Path,
+		DisableTimeout: disableTimeout,
+		Format:         gosyntect.FormatJSONSCIP,
+		Metadata: highlight.Metadata{
+			RepoName: string(repo.Name),
+			Revision: args.Commit,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "highlight.Code")
+	}
+
+	// Files that could not be highlighted in time, or in a language without syntax
+	// highlighting data, are classified by the precise index alone.
+	var syntactic *scip.Document
+	if !aborted {
+		syntactic = highlighted.LSIF()
+	}
+
+	return mergeSemanticHighlighting(syntactic, occurrences), nil
+}
+
+// mergeSemanticHighlighting returns a copy of the given syntax highlighting document in
+// which the syntax kinds of ranges with a precise occurrence are replaced by the kind
+// of the occurrence's symbol. Precise occurrences without a syntax highlighting range
+// of their own are added to the document.
+func mergeSemanticHighlighting(syntactic *scip.Document, occurrences []*scip.Occurrence) *scip.Document {
+	merged := &scip.Document{}
+	if syntactic != nil {
+		merged.Language = syntactic.Language
+		merged.RelativePath = syntactic.RelativePath
+
+		merged.Occurrences = make([]*scip.Occurrence, 0, len(syntactic.Occurrences))
+		for _, occurrence := range syntactic.Occurrences {
+			merged.Occurrences = append(merged.Occurrences, &scip.Occurrence{
+				Range:      occurrence.Range,
+				SyntaxKind: occurrence.SyntaxKind,
+			})
+		}
+	}
+
+	indexesByRange := make(map[scip.Range]int, len(merged.Occurrences))
+	for i, occurrence := range merged.Occurrences {
+		indexesByRange[*scip.NewRange(occurrence.Range)] = i
+	}
+
+	for _, occurrence := range occurrences {
+		kind, ok := semanticSyntaxKind(occurrence)
+		if !ok {
+			continue
+		}
+
+		r := scip.NewRange(occurrence.Range)
+		if !r.IsSingleLine() {
+			// Highlighting ranges span a single line
+			continue
+		}
+
+		if i, ok := indexesByRange[*r]; ok {
+			merged.Occurrences[i].SyntaxKind = kind
+			continue
+		}
+
+		indexesByRange[*r] = len(merged.Occurrences)
+		merged.Occurrences = append(merged.Occurrences, &scip.Occurrence{
+			Range:      r.SCIPRange(),
+			SyntaxKind: kind,
+		})
+	}
+
+	merged.Occurrences = scip.SortOccurrences(merged.Occurrences)
+	return merged
+}
+
+// semanticSyntaxKind returns the syntax kind of identifiers referring to the symbol of
+// the given occurrence. It returns false if the kind of the symbol is unknown or does
+// not map to a syntax kind more specific than the syntax highlighter's.
+func semanticSyntaxKind(occurrence *scip.Occurrence) (scip.SyntaxKind, bool) {
+	if occurrence.Symbol == "" {
+		return scip.SyntaxKind_UnspecifiedSyntaxKind, false
+	}
+	if scip.IsLocalSymbol(occurrence.Symbol) {
+		return scip.SyntaxKind_IdentifierLocal, true
+	}
+
+	symbol, err := scip.ParseSymbol(occurrence.Symbol)
+	if err != nil || len(symbol.Descriptors) == 0 {
+		return scip.SyntaxKind_UnspecifiedSyntaxKind, false
+	}
+
+	isDefinition := occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0
+
+	switch symbol.Descriptors[len(symbol.Descriptors)-1].Suffix {
+	case scip.Descriptor_Namespace:
+		return scip.SyntaxKind_IdentifierNamespace, true
+	case scip.Descriptor_Type, scip.Descriptor_TypeParameter:
+		return scip.SyntaxKind_IdentifierType, true
+	case scip.Descriptor_Method:
+		if isDefinition {
+			return scip.SyntaxKind_IdentifierFunctionDefinition, true
+		}
+		return scip.SyntaxKind_IdentifierFunction, true
+	case scip.Descriptor_Macro:
+		if isDefinition {
+			return scip.SyntaxKind_IdentifierMacroDefinition, true
+		}
+		return scip.SyntaxKind_IdentifierMacro, true
+	case scip.Descriptor_Parameter:
+		return scip.SyntaxKind_IdentifierParameter, true
+	}
+
+	// Terms can be constants, variables, fields, etc. which can't be told apart
+	// from the symbol alone, so we keep the syntax highlighter's classification.
+	return scip.SyntaxKind_UnspecifiedSyntaxKind, false
+}
+
+func translateSCIPRange(r *scip.Range) shared.Range {
+	return shared.Range{
+		Start: shared.Position{Line: int(r.Start.Line), Character: int(r.Start.Character)},
+		End:   shared.Position{Line: int(r.End.Line), Character: int(r.End.Character)},
+	}
+}
+
+func scipRangeFromRange(r shared.Range) []int32 {
+	return scip.Range{
+		Start: scip.Position{Line: int32(r.Start.Line), Character: int32(r.Start.Character)},
+		End:   scip.Position{Line: int32(r.End.Line), Character: int32(r.End.Character)},
+	}.SCIPRange()
+}
@@ -0,0 +1,147 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/highlight"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	semanticTestType   = "scip-go gomod github.com/sourcegraph/banter v4.2.0 github.com/sourcegraph/banter/food/banana#"
+	semanticTestMethod = "scip-go gomod github.com/sourcegraph/banter v4.2.0 github.com/sourcegraph/banter/food/banana#Peel()."
+	semanticTestTerm   = "scip-go gomod github.com/sourcegraph/banter v4.2.0 github.com/sourcegraph/banter/food/ripe."
+)
+
+func TestMergeSemanticHighlighting(t *testing.T) {
+	syntactic := &scip.Document{
+		Language: "go",
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{2, 0, 4}, SyntaxKind: scip.SyntaxKind_IdentifierKeyword},
+			{Range: []int32{2, 5, 11}, SyntaxKind: scip.SyntaxKind_Identifier},
+			{Range: []int32{4, 1, 5}, SyntaxKind: scip.SyntaxKind_Identifier},
+			{Range: []int32{5, 1, 5}, SyntaxKind: scip.SyntaxKind_IdentifierConstant},
+		},
+	}
+	occurrences := []*scip.Occurrence{
+		{Range: []int32{2, 5, 11}, Symbol: semanticTestType, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{3, 7, 11}, Symbol: semanticTestMethod, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{4, 1, 5}, Symbol: "local 1"},
+		{Range: []int32{5, 1, 5}, Symbol: semanticTestTerm},
+		{Range: []int32{6, 1, 7, 2}, Symbol: semanticTestType},
+	}
+
+	expected := &scip.Document{
+		Language: "go",
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{2, 0, 4}, SyntaxKind: scip.SyntaxKind_IdentifierKeyword},
+			{Range: []int32{2, 5, 11}, SyntaxKind: scip.SyntaxKind_IdentifierType},
+			{Range: []int32{3, 7, 11}, SyntaxKind: scip.SyntaxKind_IdentifierFunctionDefinition},
+			{Range: []int32{4, 1, 5}, SyntaxKind: scip.SyntaxKind_IdentifierLocal},
+			{Range: []int32{5, 1, 5}, SyntaxKind: scip.SyntaxKind_IdentifierConstant},
+		},
+	}
+	if diff := cmp.Diff(expected, mergeSemanticHighlighting(syntactic, occurrences), protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected document (-want +got):\n%s", diff)
+	}
+
+	// The syntax highlighting document is not modified
+	if syntactic.Occurrences[1].SyntaxKind != scip.SyntaxKind_Identifier {
+		t.Errorf("syntax highlighting document was modified")
+	}
+}
+
+func TestGetSemanticHighlighting(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, NewMockAutoIndexingService())
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	mockRequestState.SetUploadsDataLoader([]uploadsshared.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	})
+
+	mockRepoStore.GetFunc.SetDefaultReturn(&sgtypes.Repo{Name: "github.com/sourcegraph/banter"}, nil)
+	mockGitserverClient.ReadFileFunc.SetDefaultReturn([]byte(sampleFile1), nil)
+
+	// Syntax highlighting times out, so the document is classified by the precise index alone
+	highlight.Mocks.Code = func(p highlight.Params) (*highlight.HighlightedCode, bool, error) {
+		return nil, true, nil
+	}
+	t.Cleanup(highlight.ResetMocks)
+
+	mockRequest := PositionalRequestArgs{
+		RequestArgs: RequestArgs{
+			RepositoryID: 42,
+			Commit:       mockCommit,
+		},
+		Path: mockPath,
+	}
+
+	// No occurrences with symbols
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(&scip.Document{
+		Occurrences: []*scip.Occurrence{{Range: []int32{2, 5, 11}}},
+	}, nil)
+
+	supported, err := svc.SupportsSemanticHighlighting(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if supported {
+		t.Errorf("expected semantic highlighting to be unsupported")
+	}
+	document, err := svc.GetSemanticHighlighting(context.Background(), mockRequest, mockRequestState, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if document != nil {
+		t.Errorf("expected no document, got %v", document)
+	}
+
+	// Occurrences with symbols in the second upload
+	mockLsifStore.SCIPDocumentFunc.PushReturn(nil, nil)
+	mockLsifStore.SCIPDocumentFunc.PushReturn(&scip.Document{
+		Occurrences: []*scip.Occurrence{{Range: []int32{2, 5, 11}, Symbol: semanticTestType, SymbolRoles: int32(scip.SymbolRole_Definition)}},
+	}, nil)
+	mockLsifStore.SCIPDocumentFunc.PushReturn(nil, nil)
+	mockLsifStore.SCIPDocumentFunc.PushReturn(&scip.Document{
+		Occurrences: []*scip.Occurrence{{Range: []int32{2, 5, 11}, Symbol: semanticTestType, SymbolRoles: int32(scip.SymbolRole_Definition)}},
+	}, nil)
+
+	supported, err = svc.SupportsSemanticHighlighting(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !supported {
+		t.Errorf("expected semantic highlighting to be supported")
+	}
+	document, err = svc.GetSemanticHighlighting(context.Background(), mockRequest, mockRequestState, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &scip.Document{
+		Occurrences: []*scip.Occurrence{{Range: []int32{2, 5, 11}, SyntaxKind: scip.SyntaxKind_IdentifierType}},
+	}
+	if diff := cmp.Diff(expected, document, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected document (-want +got):\n%s", diff)
+	}
+}
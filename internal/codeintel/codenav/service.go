@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/scip/bindings/go/scip"
@@ -25,12 +26,18 @@ import (
 )
 
 type Service struct {
-	repoStore  database.RepoStore
-	lsifstore  lsifstore.LsifStore
-	gitserver  gitserver.Client
-	uploadSvc  UploadService
-	operations *operations
-	logger     log.Logger
+	repoStore       database.RepoStore
+	lsifstore       lsifstore.LsifStore
+	gitserver       gitserver.Client
+	uploadSvc       UploadService
+	autoindexingSvc AutoIndexingService
+	operations      *operations
+	logger          log.Logger
+
+	// dependencyResolutionQueue feeds the workers that resolve the packages of
+	// unresolved monikers, which are started on first use.
+	dependencyResolutionQueue       chan dependencyResolution
+	dependencyResolutionWorkersOnce sync.Once
 }
 
 func newService(
@@ -39,14 +46,18 @@ func newService(
 	lsifstore lsifstore.LsifStore,
 	uploadSvc UploadService,
 	gitserver gitserver.Client,
+	autoindexingSvc AutoIndexingService,
 ) *Service {
 	return &Service{
-		repoStore:  repoStore,
-		lsifstore:  lsifstore,
-		gitserver:  gitserver,
-		uploadSvc:  uploadSvc,
-		operations: newOperations(observationCtx),
-		logger:     log.Scoped("codenav"),
+		repoStore:       repoStore,
+		lsifstore:       lsifstore,
+		gitserver:       gitserver,
+		uploadSvc:       uploadSvc,
+		autoindexingSvc: autoindexingSvc,
+		operations:      newOperations(observationCtx),
+		logger:          log.Scoped("codenav"),
+
+		dependencyResolutionQueue: make(chan dependencyResolution, dependencyResolutionQueueSize),
 	}
 }
 
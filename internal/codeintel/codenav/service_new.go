@@ -4,15 +4,20 @@ import (
 	"context"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/scip/bindings/go/scip"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/collections"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func (s *Service) GetDefinitions(
@@ -26,6 +31,7 @@ func (s *Service) GetDefinitions(
 		s.operations.getDefinitions, // operation
 		"definitions",               // tableName
 		false,                       // includeReferencingIndexes
+		true,                        // resolveDependencies
 		LocationExtractorFunc(s.lsifstore.ExtractDefinitionLocationsFromPosition),
 	)
 
@@ -44,6 +50,7 @@ func (s *Service) GetReferences(
 		s.operations.getReferences, // operation
 		"references",               // tableName
 		true,                       // includeReferencingIndexes
+		false,                      // resolveDependencies
 		LocationExtractorFunc(s.lsifstore.ExtractReferenceLocationsFromPosition),
 	)
 }
@@ -60,6 +67,7 @@ func (s *Service) GetImplementations(
 		s.operations.getImplementations, // operation
 		"implementations",               // tableName
 		true,                            // includeReferencingIndexes
+		false,                           // resolveDependencies
 		LocationExtractorFunc(s.lsifstore.ExtractImplementationLocationsFromPosition),
 	)
 }
@@ -76,6 +84,7 @@ func (s *Service) GetPrototypes(
 		s.operations.getPrototypes, // operation
 		"definitions",              // N.B.: we're looking for definitions of interfaces
 		false,                      // includeReferencingIndexes
+		false,                      // resolveDependencies
 		LocationExtractorFunc(s.lsifstore.ExtractPrototypeLocationsFromPosition),
 	)
 }
@@ -92,6 +101,7 @@ func (s *Service) GetDefinitionsBySymbolNames(
 		s.operations.getDefinitions, // operation
 		"definitions",               // tableName
 		false,                       // includeReferencingIndexes
+		true,                        // resolveDependencies
 		symbolNames,
 	)
 
@@ -125,6 +135,7 @@ func (s *Service) gatherLocations(
 	operation *observation.Operation,
 	tableName string,
 	includeReferencingIndexes bool,
+	resolveDependencies bool,
 	extractor LocationExtractor,
 ) (allLocations []shared.UploadLocation, _ Cursor, err error) {
 	ctx, trace, endObservation := observeResolver(ctx, &err, operation, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
@@ -165,6 +176,10 @@ func (s *Service) gatherLocations(
 	}
 	trace.AddEvent("VisibleUploads", attribute.IntSlice("visibleUploadIDs", visibleUploadIDs))
 
+	if err := s.prefetchCursorUploads(ctx, trace, requestState, cursor); err != nil {
+		return nil, Cursor{}, err
+	}
+
 	// The following loop calls local and remote location resolution phases in alternation. As
 	// each phase controls whether or not it should execute, this is safe.
 	//
@@ -196,6 +211,7 @@ outer:
 				requestState,
 				tableName,
 				includeReferencingIndexes,
+				resolveDependencies,
 				cursor,
 				args.Limit-len(allLocations), // remaining space in the page
 				extractor,
@@ -219,6 +235,7 @@ func (s *Service) gatherLocationsBySymbolNames(
 	operation *observation.Operation,
 	tableName string,
 	includeReferencingIndexes bool,
+	resolveDependencies bool,
 	symbolNames []string,
 ) (allLocations []shared.UploadLocation, _ Cursor, err error) {
 	ctx, trace, endObservation := observeResolver(ctx, &err, operation, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
@@ -238,6 +255,10 @@ func (s *Service) gatherLocationsBySymbolNames(
 		cursor.SymbolNames = symbolNames
 	}
 
+	if err := s.prefetchCursorUploads(ctx, trace, requestState, cursor); err != nil {
+		return nil, Cursor{}, err
+	}
+
 	// The following loop calls to fill additional results into the currently-being-constructed page.
 	// Such a loop exists as each invocation of either phase may produce fewer results than the requested
 	// page size. For example, if there are many references to a symbol over a large number of indexes but
@@ -264,6 +285,7 @@ func (s *Service) gatherLocationsBySymbolNames(
 			cursor,
 			tableName,
 			includeReferencingIndexes,
+			resolveDependencies,
 			args.Limit-len(allLocations), // remaining space in the page
 		)
 		if err != nil {
@@ -319,6 +341,48 @@ func (s *Service) getVisibleUploadsFromCursor(
 	return visibleUploads, cursor, nil
 }
 
+// prefetchCursorUploads hydrates the request state with the uploads referenced by the given cursor
+// before any locations of the page are resolved. The commits of all such uploads are checked with a
+// single batched request to gitserver, which populates the commit cache of the request state. Upload
+// records and commits that are already known to the request state are not fetched again.
+func (s *Service) prefetchCursorUploads(
+	ctx context.Context,
+	trace observation.TraceLogger,
+	requestState RequestState,
+	cursor Cursor,
+) error {
+	idMap := make(map[int]struct{}, len(cursor.UploadIDs)+len(cursor.DefinitionIDs))
+	for _, ids := range [][]int{cursor.UploadIDs, cursor.DefinitionIDs} {
+		for _, id := range ids {
+			if _, ok := requestState.dataLoader.GetUploadFromCacheMap(id); !ok {
+				idMap[id] = struct{}{}
+			}
+		}
+	}
+	if len(idMap) == 0 {
+		return nil
+	}
+	missingIDs := make([]int, 0, len(idMap))
+	for id := range idMap {
+		missingIDs = append(missingIDs, id)
+	}
+	sort.Ints(missingIDs)
+
+	uploads, err := s.uploadSvc.GetDumpsByIDs(ctx, missingIDs)
+	if err != nil {
+		return errors.Wrap(err, "service.GetDumpsByIDs")
+	}
+
+	uploadsWithResolvableCommits, err := s.removeUploadsWithUnknownCommits(ctx, uploads, requestState)
+	if err != nil {
+		return err
+	}
+	requestState.dataLoader.SetUploadInCacheMap(uploadsWithResolvableCommits)
+	trace.AddEvent("PrefetchCursorUploads", attribute.IntSlice("ids", missingIDs), attribute.Int("numResolvable", len(uploadsWithResolvableCommits)))
+
+	return nil
+}
+
 type gatherLocationsFunc func(
 	ctx context.Context,
 	trace observation.TraceLogger,
@@ -326,6 +390,7 @@ type gatherLocationsFunc func(
 	requestState RequestState,
 	tableName string,
 	includeReferencingIndexes bool,
+	resolveDependencies bool,
 	cursor Cursor,
 	limit int,
 	extractor LocationExtractor,
@@ -341,6 +406,7 @@ func (s *Service) gatherLocalLocations(
 	requestState RequestState,
 	tableName string,
 	includeReferencingIndexes bool,
+	resolveDependencies bool,
 	cursor Cursor,
 	limit int,
 	extractor LocationExtractor,
@@ -451,6 +517,7 @@ func (s *Service) gatherRemoteLocationsShim(
 	requestState RequestState,
 	tableName string,
 	includeReferencingIndexes bool,
+	resolveDependencies bool,
 	cursor Cursor,
 	limit int,
 	_ LocationExtractor,
@@ -464,6 +531,7 @@ func (s *Service) gatherRemoteLocationsShim(
 		cursor,
 		tableName,
 		includeReferencingIndexes,
+		resolveDependencies,
 		limit,
 	)
 }
@@ -476,6 +544,7 @@ func (s *Service) gatherRemoteLocations(
 	cursor Cursor,
 	tableName string,
 	includeReferencingIndexes bool,
+	resolveDependencies bool,
 	limit int,
 ) ([]shared.UploadLocation, Cursor, error) {
 	if cursor.Phase != "remote" {
@@ -506,6 +575,7 @@ func (s *Service) gatherRemoteLocations(
 		requestState,
 		cursor,
 		includeReferencingIndexes,
+		resolveDependencies,
 		monikers,
 	)
 	if err != nil {
@@ -558,6 +628,7 @@ func (s *Service) prepareCandidateUploads(
 	requestState RequestState,
 	cursor Cursor,
 	includeReferencingIndexes bool,
+	resolveDependencies bool,
 	monikers []precise.QualifiedMonikerData,
 ) (_ Cursor, fallback bool, _ error) {
 	fallback = true // TODO - document
@@ -578,6 +649,11 @@ func (s *Service) prepareCandidateUploads(
 		if err != nil {
 			return Cursor{}, false, err
 		}
+		if resolveDependencies && len(uploads) == 0 && len(cursor.VisibleUploads) > 0 {
+			// No index on the instance defines these symbols; ask for their packages to be
+			// resolved so that a subsequent request can jump into the dependency.
+			s.resolveDependencyPackages(cursor.VisibleUploads[0].DumpID, monikers)
+		}
 		idMap := make(map[int]struct{}, len(uploads)+len(cursor.VisibleUploads))
 		for _, upload := range cursor.VisibleUploads {
 			idMap[upload.DumpID] = struct{}{}
@@ -642,6 +718,74 @@ func (s *Service) prepareCandidateUploads(
 //
 //
 
+const (
+	// dependencyResolutionTimeout bounds the time spent queueing the resolution of the packages
+	// of unresolved monikers, which happens outside of the request that encountered them.
+	dependencyResolutionTimeout = time.Second * 30
+
+	// dependencyResolutionWorkers is the number of workers resolving dependency packages.
+	dependencyResolutionWorkers = 4
+
+	// dependencyResolutionQueueSize is the number of dependency resolutions that can wait
+	// for a worker. Further resolutions are dropped until the queue drains.
+	dependencyResolutionQueueSize = 100
+)
+
+type dependencyResolution struct {
+	uploadID int
+	pkgs     []uploadsshared.Package
+}
+
+// resolveDependencyPackages asynchronously asks the auto-indexing service to add, clone, and
+// index the repositories of the packages of the given monikers, which are referenced from the
+// given upload but are not defined by any upload on the instance.
+func (s *Service) resolveDependencyPackages(uploadID int, monikers []precise.QualifiedMonikerData) {
+	pkgs := make([]uploadsshared.Package, 0, len(monikers))
+	seen := make(map[uploadsshared.Package]struct{}, len(monikers))
+	for _, moniker := range monikers {
+		if moniker.Name == "" || moniker.Version == "" {
+			continue
+		}
+
+		pkg := uploadsshared.Package{
+			Scheme:  moniker.Scheme,
+			Manager: moniker.Manager,
+			Name:    moniker.Name,
+			Version: moniker.Version,
+		}
+		if _, ok := seen[pkg]; ok {
+			continue
+		}
+		seen[pkg] = struct{}{}
+		pkgs = append(pkgs, pkg)
+	}
+	if len(pkgs) == 0 {
+		return
+	}
+
+	s.dependencyResolutionWorkersOnce.Do(func() {
+		for i := 0; i < dependencyResolutionWorkers; i++ {
+			go s.dependencyResolutionWorker()
+		}
+	})
+
+	select {
+	case s.dependencyResolutionQueue <- dependencyResolution{uploadID: uploadID, pkgs: pkgs}:
+	default:
+		s.logger.Debug("dependency resolution queue is full, dropping packages", log.Int("uploadID", uploadID))
+	}
+}
+
+func (s *Service) dependencyResolutionWorker() {
+	for resolution := range s.dependencyResolutionQueue {
+		ctx, cancel := context.WithTimeout(actor.WithInternalActor(context.Background()), dependencyResolutionTimeout)
+		if err := s.autoindexingSvc.ResolveDependencyPackages(ctx, resolution.uploadID, resolution.pkgs); err != nil {
+			s.logger.Warn("failed to resolve dependency packages", log.Int("uploadID", resolution.uploadID), log.Error(err))
+		}
+		cancel()
+	}
+}
+
 func symbolsToMonikers(symbolNames []string) ([]precise.QualifiedMonikerData, error) {
 	var monikers []precise.QualifiedMonikerData
 	for _, symbolName := range symbolNames {
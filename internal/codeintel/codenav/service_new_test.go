@@ -3,6 +3,7 @@ package codenav
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -25,7 +26,7 @@ func TestGetDefinitions(t *testing.T) {
 		hunkCache, _ := NewHunkCache(50)
 
 		// Init service
-		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, NewMockAutoIndexingService())
 
 		// Set up request state
 		mockRequestState := RequestState{}
@@ -85,7 +86,7 @@ func TestGetDefinitions(t *testing.T) {
 		hunkCache, _ := NewHunkCache(50)
 
 		// Init service
-		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, NewMockAutoIndexingService())
 
 		// Set up request state
 		mockRequestState := RequestState{}
@@ -198,6 +199,79 @@ func TestGetDefinitions(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("remote unresolved", func(t *testing.T) {
+		// Set up mocks
+		mockRepoStore := defaultMockRepoStore()
+		mockLsifStore := NewMockLsifStore()
+		mockUploadSvc := NewMockUploadService()
+		mockGitserverClient := gitserver.NewMockClient()
+		mockAutoIndexingSvc := NewMockAutoIndexingService()
+		hunkCache, _ := NewHunkCache(50)
+
+		resolved := make(chan []uploadsshared.Package, 1)
+		mockAutoIndexingSvc.ResolveDependencyPackagesFunc.SetDefaultHook(func(_ context.Context, _ int, pkgs []uploadsshared.Package) error {
+			resolved <- pkgs
+			return nil
+		})
+
+		// Init service
+		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockAutoIndexingSvc)
+
+		// Set up request state
+		mockRequestState := RequestState{}
+		mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+		err := mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{ID: 42}, mockCommit, mockPath, hunkCache)
+		if err != nil {
+			t.Fatalf("unexpected error setting local git tree translator: %s", err)
+		}
+		mockRequestState.GitTreeTranslator = mockedGitTreeTranslator()
+		mockRequestState.SetUploadsDataLoader([]uploadsshared.Dump{
+			{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		})
+
+		// No upload on the instance defines the symbols
+		mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.PushReturn(nil, nil)
+
+		symbolNames := []string{
+			"tsc npm leftpad 0.1.0 padLeft.",
+			"tsc npm leftpad 0.1.0 padRight.",
+			"local pad_left.",
+		}
+		mockLsifStore.ExtractDefinitionLocationsFromPositionFunc.PushReturn(nil, symbolNames, nil)
+
+		mockRequest := PositionalRequestArgs{
+			RequestArgs: RequestArgs{
+				RepositoryID: 42,
+				Commit:       mockCommit,
+				Limit:        50,
+			},
+			Path:      mockPath,
+			Line:      10,
+			Character: 20,
+		}
+		if _, err := svc.GetDefinitions(context.Background(), mockRequest, mockRequestState); err != nil {
+			t.Fatalf("unexpected error querying definitions: %s", err)
+		}
+
+		select {
+		case pkgs := <-resolved:
+			expectedPackages := []uploadsshared.Package{
+				{Scheme: "tsc", Manager: "npm", Name: "leftpad", Version: "0.1.0"},
+			}
+			if diff := cmp.Diff(expectedPackages, pkgs); diff != "" {
+				t.Errorf("unexpected packages (-want +got):\n%s", diff)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("timed out waiting for dependency packages to be resolved")
+		}
+
+		if history := mockAutoIndexingSvc.ResolveDependencyPackagesFunc.History(); len(history) != 1 {
+			t.Fatalf("unexpected call count for ResolveDependencyPackages. want=%d have=%d", 1, len(history))
+		} else if history[0].Arg1 != 50 {
+			t.Errorf("unexpected upload id. want=%d have=%d", 50, history[0].Arg1)
+		}
+	})
 }
 
 func TestGetReferences(t *testing.T) {
@@ -210,7 +284,7 @@ func TestGetReferences(t *testing.T) {
 		hunkCache, _ := NewHunkCache(50)
 
 		// Init service
-		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, NewMockAutoIndexingService())
 
 		// Set up request state
 		mockRequestState := RequestState{}
@@ -275,7 +349,7 @@ func TestGetReferences(t *testing.T) {
 		hunkCache, _ := NewHunkCache(50)
 
 		// Init service
-		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, NewMockAutoIndexingService())
 
 		// Set up request state
 		mockRequestState := RequestState{}
@@ -444,6 +518,161 @@ func TestGetReferences(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("remote page from cursor", func(t *testing.T) {
+		// Set up mocks
+		mockRepoStore := defaultMockRepoStore()
+		mockLsifStore := NewMockLsifStore()
+		mockUploadSvc := NewMockUploadService()
+		mockGitserverClient := gitserver.NewMockClient()
+		hunkCache, _ := NewHunkCache(50)
+
+		// Init service
+		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, NewMockAutoIndexingService())
+
+		// Set up request state
+		mockRequestState := RequestState{}
+		mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+		mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+		uploads := []uploadsshared.Dump{
+			{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+			{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+		}
+		mockRequestState.SetUploadsDataLoader(uploads)
+
+		cursorUploads := []uploadsshared.Dump{
+			{ID: 150, RepositoryID: 43, Commit: "deadbeef1", Root: "sub1/"},
+			{ID: 151, RepositoryID: 43, Commit: "deadbeef2", Root: "sub2/"},
+			{ID: 250, RepositoryID: 44, Commit: "deadbeef1", Root: "sub1/"},
+			{ID: 251, RepositoryID: 44, Commit: "deadbeef3", Root: "sub2/"},
+		}
+		mockUploadSvc.GetDumpsByIDsFunc.SetDefaultHook(func(ctx context.Context, ids []int) (dumps []uploadsshared.Dump, _ error) {
+			for _, upload := range cursorUploads {
+				for _, id := range ids {
+					if upload.ID == id {
+						dumps = append(dumps, upload)
+					}
+				}
+			}
+			return dumps, nil
+		})
+
+		// commit deadbeef1 no longer exists; all others do
+		mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []api.RepoCommit) (exists []bool, _ error) {
+			for _, rc := range rcs {
+				exists = append(exists, rc.CommitID != "deadbeef1")
+			}
+			return
+		})
+
+		monikerLocations := []shared.Location{
+			{DumpID: 251, Path: "a.go", Range: testRange1},
+		}
+		mockLsifStore.GetMinimalBulkMonikerLocationsFunc.PushReturn(monikerLocations, 2, nil)
+
+		mockCursor := Cursor{
+			Phase:              "remote",
+			VisibleUploads:     []CursorVisibleUpload{{DumpID: 51, TargetPath: mockPath}},
+			SymbolNames:        []string{"tsc npm leftpad 0.1.0 padLeft."},
+			DefinitionIDs:      []int{50, 51, 150, 151},
+			UploadIDs:          []int{250, 251},
+			RemoteUploadOffset: 2,
+		}
+		mockRequest := PositionalRequestArgs{
+			RequestArgs: RequestArgs{
+				RepositoryID: 42,
+				Commit:       mockCommit,
+				Limit:        1,
+			},
+			Path:      mockPath,
+			Line:      10,
+			Character: 20,
+		}
+		adjustedLocations, _, err := svc.GetReferences(context.Background(), mockRequest, mockRequestState, mockCursor)
+		if err != nil {
+			t.Fatalf("unexpected error querying references: %s", err)
+		}
+
+		expectedLocations := []shared.UploadLocation{
+			{Dump: cursorUploads[3], Path: "sub2/a.go", TargetCommit: "deadbeef3", TargetRange: testRange1},
+		}
+		if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+			t.Errorf("unexpected locations (-want +got):\n%s", diff)
+		}
+
+		if history := mockUploadSvc.GetDumpsByIDsFunc.History(); len(history) == 0 {
+			t.Fatalf("expected uploads to be fetched")
+		} else if diff := cmp.Diff([]int{150, 151, 250, 251}, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected prefetched ids (-want +got):\n%s", diff)
+		}
+
+		// All candidate commits of the page are checked in a single batch
+		if history := mockGitserverClient.CommitsExistFunc.History(); len(history) != 1 {
+			t.Fatalf("unexpected call count for gitserver.CommitsExist. want=%d have=%d", 1, len(history))
+		} else if len(history[0].Arg1) != 4 {
+			t.Errorf("unexpected number of commits checked. want=%d have=%d", 4, len(history[0].Arg1))
+		}
+	})
+
+	t.Run("remote unresolved", func(t *testing.T) {
+		// Set up mocks
+		mockRepoStore := defaultMockRepoStore()
+		mockLsifStore := NewMockLsifStore()
+		mockUploadSvc := NewMockUploadService()
+		mockGitserverClient := gitserver.NewMockClient()
+		mockAutoIndexingSvc := NewMockAutoIndexingService()
+		hunkCache, _ := NewHunkCache(50)
+
+		resolved := make(chan []uploadsshared.Package, 1)
+		mockAutoIndexingSvc.ResolveDependencyPackagesFunc.SetDefaultHook(func(_ context.Context, _ int, pkgs []uploadsshared.Package) error {
+			resolved <- pkgs
+			return nil
+		})
+
+		// Init service
+		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockAutoIndexingSvc)
+
+		// Set up request state
+		mockRequestState := RequestState{}
+		mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+		err := mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{ID: 42}, mockCommit, mockPath, hunkCache)
+		if err != nil {
+			t.Fatalf("unexpected error setting local git tree translator: %s", err)
+		}
+		mockRequestState.GitTreeTranslator = mockedGitTreeTranslator()
+		mockRequestState.SetUploadsDataLoader([]uploadsshared.Dump{
+			{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		})
+
+		// No upload on the instance defines the symbols
+		mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.PushReturn(nil, nil)
+
+		symbolNames := []string{
+			"tsc npm leftpad 0.1.0 padLeft.",
+		}
+		mockLsifStore.ExtractReferenceLocationsFromPositionFunc.PushReturn(nil, symbolNames, nil)
+
+		mockRequest := PositionalRequestArgs{
+			RequestArgs: RequestArgs{
+				RepositoryID: 42,
+				Commit:       mockCommit,
+				Limit:        50,
+			},
+			Path:      mockPath,
+			Line:      10,
+			Character: 20,
+		}
+		if _, _, err := svc.GetReferences(context.Background(), mockRequest, mockRequestState, Cursor{}); err != nil {
+			t.Fatalf("unexpected error querying references: %s", err)
+		}
+
+		// Dependencies are only resolved for definition requests
+		select {
+		case pkgs := <-resolved:
+			t.Fatalf("unexpected dependency resolution for references: %v", pkgs)
+		case <-time.After(time.Millisecond * 100):
+		}
+	})
 }
 
 func TestGetImplementations(t *testing.T) {
@@ -456,7 +685,7 @@ func TestGetImplementations(t *testing.T) {
 		hunkCache, _ := NewHunkCache(50)
 
 		// Init service
-		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+		svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient, NewMockAutoIndexingService())
 
 		// Set up request state
 		mockRequestState := RequestState{}
@@ -3,6 +3,8 @@ package graphql
 import (
 	"context"
 
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
@@ -20,6 +22,8 @@ type CodeNavService interface {
 	GetClosestDumpsForBlob(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []uploadsshared.Dump, err error)
 	VisibleUploadsForPath(ctx context.Context, requestState codenav.RequestState) ([]uploadsshared.Dump, error)
 	SnapshotForDocument(ctx context.Context, repositoryID int, commit, path string, uploadID int) (data []shared.SnapshotData, err error)
+	SupportsSemanticHighlighting(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState) (_ bool, err error)
+	GetSemanticHighlighting(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState, disableTimeout bool) (_ *scip.Document, err error)
 }
 
 type AutoIndexingService interface {
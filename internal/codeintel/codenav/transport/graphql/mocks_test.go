@@ -10,6 +10,7 @@ import (
 	"context"
 	"sync"
 
+	scip "github.com/sourcegraph/scip/bindings/go/scip"
 	codenav "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
 	shared1 "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
 	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
@@ -202,12 +203,19 @@ type MockCodeNavService struct {
 	// GetReferencesFunc is an instance of a mock function object
 	// controlling the behavior of the method GetReferences.
 	GetReferencesFunc *CodeNavServiceGetReferencesFunc
+	// GetSemanticHighlightingFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSemanticHighlighting.
+	GetSemanticHighlightingFunc *CodeNavServiceGetSemanticHighlightingFunc
 	// GetStencilFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStencil.
 	GetStencilFunc *CodeNavServiceGetStencilFunc
 	// SnapshotForDocumentFunc is an instance of a mock function object
 	// controlling the behavior of the method SnapshotForDocument.
 	SnapshotForDocumentFunc *CodeNavServiceSnapshotForDocumentFunc
+	// SupportsSemanticHighlightingFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// SupportsSemanticHighlighting.
+	SupportsSemanticHighlightingFunc *CodeNavServiceSupportsSemanticHighlightingFunc
 	// VisibleUploadsForPathFunc is an instance of a mock function object
 	// controlling the behavior of the method VisibleUploadsForPath.
 	VisibleUploadsForPathFunc *CodeNavServiceVisibleUploadsForPathFunc
@@ -257,6 +265,11 @@ func NewMockCodeNavService() *MockCodeNavService {
 				return
 			},
 		},
+		GetSemanticHighlightingFunc: &CodeNavServiceGetSemanticHighlightingFunc{
+			defaultHook: func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (r0 *scip.Document, r1 error) {
+				return
+			},
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (r0 []shared1.Range, r1 error) {
 				return
@@ -267,6 +280,11 @@ func NewMockCodeNavService() *MockCodeNavService {
 				return
 			},
 		},
+		SupportsSemanticHighlightingFunc: &CodeNavServiceSupportsSemanticHighlightingFunc{
+			defaultHook: func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (r0 bool, r1 error) {
+				return
+			},
+		},
 		VisibleUploadsForPathFunc: &CodeNavServiceVisibleUploadsForPathFunc{
 			defaultHook: func(context.Context, codenav.RequestState) (r0 []shared.Dump, r1 error) {
 				return
@@ -319,6 +337,11 @@ func NewStrictMockCodeNavService() *MockCodeNavService {
 				panic("unexpected invocation of MockCodeNavService.GetReferences")
 			},
 		},
+		GetSemanticHighlightingFunc: &CodeNavServiceGetSemanticHighlightingFunc{
+			defaultHook: func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (*scip.Document, error) {
+				panic("unexpected invocation of MockCodeNavService.GetSemanticHighlighting")
+			},
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) ([]shared1.Range, error) {
 				panic("unexpected invocation of MockCodeNavService.GetStencil")
@@ -329,6 +352,11 @@ func NewStrictMockCodeNavService() *MockCodeNavService {
 				panic("unexpected invocation of MockCodeNavService.SnapshotForDocument")
 			},
 		},
+		SupportsSemanticHighlightingFunc: &CodeNavServiceSupportsSemanticHighlightingFunc{
+			defaultHook: func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (bool, error) {
+				panic("unexpected invocation of MockCodeNavService.SupportsSemanticHighlighting")
+			},
+		},
 		VisibleUploadsForPathFunc: &CodeNavServiceVisibleUploadsForPathFunc{
 			defaultHook: func(context.Context, codenav.RequestState) ([]shared.Dump, error) {
 				panic("unexpected invocation of MockCodeNavService.VisibleUploadsForPath")
@@ -366,12 +394,18 @@ func NewMockCodeNavServiceFrom(i CodeNavService) *MockCodeNavService {
 		GetReferencesFunc: &CodeNavServiceGetReferencesFunc{
 			defaultHook: i.GetReferences,
 		},
+		GetSemanticHighlightingFunc: &CodeNavServiceGetSemanticHighlightingFunc{
+			defaultHook: i.GetSemanticHighlighting,
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: i.GetStencil,
 		},
 		SnapshotForDocumentFunc: &CodeNavServiceSnapshotForDocumentFunc{
 			defaultHook: i.SnapshotForDocument,
 		},
+		SupportsSemanticHighlightingFunc: &CodeNavServiceSupportsSemanticHighlightingFunc{
+			defaultHook: i.SupportsSemanticHighlighting,
+		},
 		VisibleUploadsForPathFunc: &CodeNavServiceVisibleUploadsForPathFunc{
 			defaultHook: i.VisibleUploadsForPath,
 		},
@@ -1319,6 +1353,124 @@ func (c CodeNavServiceGetReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// CodeNavServiceGetSemanticHighlightingFunc describes the behavior when the
+// GetSemanticHighlighting method of the parent MockCodeNavService instance
+// is invoked.
+type CodeNavServiceGetSemanticHighlightingFunc struct {
+	defaultHook func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (*scip.Document, error)
+	hooks       []func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (*scip.Document, error)
+	history     []CodeNavServiceGetSemanticHighlightingFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSemanticHighlighting delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeNavService) GetSemanticHighlighting(v0 context.Context, v1 codenav.PositionalRequestArgs, v2 codenav.RequestState, v3 bool) (*scip.Document, error) {
+	r0, r1 := m.GetSemanticHighlightingFunc.nextHook()(v0, v1, v2, v3)
+	m.GetSemanticHighlightingFunc.appendCall(CodeNavServiceGetSemanticHighlightingFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetSemanticHighlighting method of the parent MockCodeNavService instance
+// is invoked and the hook queue is empty.
+func (f *CodeNavServiceGetSemanticHighlightingFunc) SetDefaultHook(hook func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (*scip.Document, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSemanticHighlighting method of the parent MockCodeNavService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeNavServiceGetSemanticHighlightingFunc) PushHook(hook func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (*scip.Document, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeNavServiceGetSemanticHighlightingFunc) SetDefaultReturn(r0 *scip.Document, r1 error) {
+	f.SetDefaultHook(func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (*scip.Document, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceGetSemanticHighlightingFunc) PushReturn(r0 *scip.Document, r1 error) {
+	f.PushHook(func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (*scip.Document, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeNavServiceGetSemanticHighlightingFunc) nextHook() func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState, bool) (*scip.Document, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceGetSemanticHighlightingFunc) appendCall(r0 CodeNavServiceGetSemanticHighlightingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeNavServiceGetSemanticHighlightingFuncCall objects describing the
+// invocations of this function.
+func (f *CodeNavServiceGetSemanticHighlightingFunc) History() []CodeNavServiceGetSemanticHighlightingFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceGetSemanticHighlightingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceGetSemanticHighlightingFuncCall is an object that describes
+// an invocation of method GetSemanticHighlighting on an instance of
+// MockCodeNavService.
+type CodeNavServiceGetSemanticHighlightingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 codenav.PositionalRequestArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 codenav.RequestState
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *scip.Document
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeNavServiceGetSemanticHighlightingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeNavServiceGetSemanticHighlightingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeNavServiceGetStencilFunc describes the behavior when the GetStencil
 // method of the parent MockCodeNavService instance is invoked.
 type CodeNavServiceGetStencilFunc struct {
@@ -1550,6 +1702,121 @@ func (c CodeNavServiceSnapshotForDocumentFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeNavServiceSupportsSemanticHighlightingFunc describes the behavior
+// when the SupportsSemanticHighlighting method of the parent
+// MockCodeNavService instance is invoked.
+type CodeNavServiceSupportsSemanticHighlightingFunc struct {
+	defaultHook func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (bool, error)
+	hooks       []func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (bool, error)
+	history     []CodeNavServiceSupportsSemanticHighlightingFuncCall
+	mutex       sync.Mutex
+}
+
+// SupportsSemanticHighlighting delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeNavService) SupportsSemanticHighlighting(v0 context.Context, v1 codenav.PositionalRequestArgs, v2 codenav.RequestState) (bool, error) {
+	r0, r1 := m.SupportsSemanticHighlightingFunc.nextHook()(v0, v1, v2)
+	m.SupportsSemanticHighlightingFunc.appendCall(CodeNavServiceSupportsSemanticHighlightingFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// SupportsSemanticHighlighting method of the parent MockCodeNavService
+// instance is invoked and the hook queue is empty.
+func (f *CodeNavServiceSupportsSemanticHighlightingFunc) SetDefaultHook(hook func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SupportsSemanticHighlighting method of the parent MockCodeNavService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeNavServiceSupportsSemanticHighlightingFunc) PushHook(hook func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeNavServiceSupportsSemanticHighlightingFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceSupportsSemanticHighlightingFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeNavServiceSupportsSemanticHighlightingFunc) nextHook() func(context.Context, codenav.PositionalRequestArgs, codenav.RequestState) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceSupportsSemanticHighlightingFunc) appendCall(r0 CodeNavServiceSupportsSemanticHighlightingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeNavServiceSupportsSemanticHighlightingFuncCall objects describing the
+// invocations of this function.
+func (f *CodeNavServiceSupportsSemanticHighlightingFunc) History() []CodeNavServiceSupportsSemanticHighlightingFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceSupportsSemanticHighlightingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceSupportsSemanticHighlightingFuncCall is an object that
+// describes an invocation of method SupportsSemanticHighlighting on an
+// instance of MockCodeNavService.
+type CodeNavServiceSupportsSemanticHighlightingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 codenav.PositionalRequestArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 codenav.RequestState
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeNavServiceSupportsSemanticHighlightingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeNavServiceSupportsSemanticHighlightingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeNavServiceVisibleUploadsForPathFunc describes the behavior when the
 // VisibleUploadsForPath method of the parent MockCodeNavService instance is
 // invoked.
@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/metrics/tenant"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -25,13 +26,16 @@ type operations struct {
 	ranges          *observation.Operation
 	snapshot        *observation.Operation
 	visibleIndexes  *observation.Operation
+
+	supportsSemanticHighlighting *observation.Operation
+	semanticHighlighting         *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
 	m := metrics.NewREDMetrics(
 		observationCtx.Registerer,
 		"codeintel_codenav_transport_graphql",
-		metrics.WithLabels("op"),
+		metrics.WithLabels("op", tenant.LabelName),
 		metrics.WithCountHelp("Total number of method invocations."),
 	)
 
@@ -55,6 +59,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 		ranges:          op("Ranges"),
 		snapshot:        op("Snapshot"),
 		visibleIndexes:  op("VisibleIndexes"),
+
+		supportsSemanticHighlighting: op("SupportsSemanticHighlighting"),
+		semanticHighlighting:         op("SemanticHighlighting"),
 	}
 }
 
@@ -70,7 +77,7 @@ func observeResolver(
 
 	return ctx, trace, func() {
 		duration := time.Since(start)
-		endObservation(1, observation.Args{})
+		endObservation(1, finishArgs(ctx))
 
 		if duration >= threshold {
 			// use trace logger which includes all relevant fields
@@ -79,6 +86,12 @@ func observeResolver(
 	}
 }
 
+// finishArgs returns the arguments to finish the observation of a resolver,
+// which must carry the tenant label of the request.
+func finishArgs(ctx context.Context) observation.Args {
+	return observation.Args{MetricLabelValues: []string{tenant.FromContext(ctx)}}
+}
+
 func lowSlowRequest(logger log.Logger, duration time.Duration, err *error) {
 	fields := []log.Field{log.Duration("duration", duration)}
 	if err != nil && *err != nil {
@@ -25,12 +25,13 @@ type rootResolver struct {
 	gitserverClient                gitserver.Client
 	siteAdminChecker               sharedresolvers.SiteAdminChecker
 	repoStore                      database.RepoStore
+	db                             database.DB
 	uploadLoaderFactory            uploadsgraphql.UploadLoaderFactory
 	indexLoaderFactory             uploadsgraphql.IndexLoaderFactory
 	locationResolverFactory        *gitresolvers.CachedLocationResolverFactory
 	hunkCache                      codenav.HunkCache
 	indexResolverFactory           *uploadsgraphql.PreciseIndexResolverFactory
-	maximumIndexesPerMonikerSearch int
+	maximumIndexesPerMonikerSearch func() int
 	operations                     *operations
 }
 
@@ -41,11 +42,12 @@ func NewRootResolver(
 	gitserverClient gitserver.Client,
 	siteAdminChecker sharedresolvers.SiteAdminChecker,
 	repoStore database.RepoStore,
+	db database.DB,
 	uploadLoaderFactory uploadsgraphql.UploadLoaderFactory,
 	indexLoaderFactory uploadsgraphql.IndexLoaderFactory,
 	indexResolverFactory *uploadsgraphql.PreciseIndexResolverFactory,
 	locationResolverFactory *gitresolvers.CachedLocationResolverFactory,
-	maxIndexSearch int,
+	maxIndexSearch func() int,
 	hunkCacheSize int,
 ) (resolverstubs.CodeNavServiceResolver, error) {
 	hunkCache, err := codenav.NewHunkCache(hunkCacheSize)
@@ -59,6 +61,7 @@ func NewRootResolver(
 		gitserverClient:                gitserverClient,
 		siteAdminChecker:               siteAdminChecker,
 		repoStore:                      repoStore,
+		db:                             db,
 		uploadLoaderFactory:            uploadLoaderFactory,
 		indexLoaderFactory:             indexLoaderFactory,
 		indexResolverFactory:           indexResolverFactory,
@@ -78,7 +81,7 @@ func (r *rootResolver) GitBlobLSIFData(ctx context.Context, args *resolverstubs.
 		attribute.Bool("exactPath", args.ExactPath),
 		attribute.String("toolName", args.ToolName),
 	}})
-	endObservation.OnCancel(ctx, 1, observation.Args{})
+	endObservation.OnCancel(ctx, 1, finishArgs(ctx))
 
 	uploads, err := r.svc.GetClosestDumpsForBlob(ctx, int(args.Repo.ID), string(args.Commit), args.Path, args.ExactPath, args.ToolName)
 	if err != nil || len(uploads) == 0 {
@@ -102,7 +105,7 @@ func (r *rootResolver) GitBlobLSIFData(ctx context.Context, args *resolverstubs.
 		args.Repo,
 		string(args.Commit),
 		args.Path,
-		r.maximumIndexesPerMonikerSearch,
+		r.maximumIndexesPerMonikerSearch(),
 		r.hunkCache,
 	)
 
@@ -113,6 +116,7 @@ func (r *rootResolver) GitBlobLSIFData(ctx context.Context, args *resolverstubs.
 		r.uploadLoaderFactory.Create(),
 		r.indexLoaderFactory.Create(),
 		r.locationResolverFactory.Create(),
+		r.db,
 		r.operations,
 	), nil
 }
@@ -128,6 +132,7 @@ type gitBlobLSIFDataResolver struct {
 	uploadLoader         uploadsgraphql.UploadLoader
 	indexLoader          uploadsgraphql.IndexLoader
 	locationResolver     *gitresolvers.CachedLocationResolver
+	db                   database.DB
 	operations           *operations
 }
 
@@ -141,6 +146,7 @@ func newGitBlobLSIFDataResolver(
 	uploadLoader uploadsgraphql.UploadLoader,
 	indexLoader uploadsgraphql.IndexLoader,
 	locationResolver *gitresolvers.CachedLocationResolver,
+	db database.DB,
 	operations *operations,
 ) resolverstubs.GitBlobLSIFDataResolver {
 	return &gitBlobLSIFDataResolver{
@@ -150,6 +156,7 @@ func newGitBlobLSIFDataResolver(
 		indexResolverFactory: indexResolverFactory,
 		requestState:         requestState,
 		locationResolver:     locationResolver,
+		db:                   db,
 		operations:           operations,
 	}
 }
@@ -168,7 +175,7 @@ func (r *gitBlobLSIFDataResolver) VisibleIndexes(ctx context.Context) (_ *[]reso
 		attribute.String("commit", r.requestState.Commit),
 		attribute.String("path", r.requestState.Path),
 	}})
-	defer endObservation(1, observation.Args{})
+	defer endObservation(1, finishArgs(ctx))
 
 	visibleUploads, err := r.codeNavSvc.VisibleUploadsForPath(ctx, r.requestState)
 	if err != nil {
@@ -52,5 +52,10 @@ func (r *gitBlobLSIFDataResolver) Definitions(ctx context.Context, args *resolve
 		def = filtered
 	}
 
+	def, err = r.filterLocationsByView(ctx, args.View, def)
+	if err != nil {
+		return nil, err
+	}
+
 	return newLocationConnectionResolver(def, nil, r.locationResolver), nil
 }
@@ -72,6 +72,11 @@ func (r *gitBlobLSIFDataResolver) Implementations(ctx context.Context, args *res
 		impls = filtered
 	}
 
+	impls, err = r.filterLocationsByView(ctx, args.View, impls)
+	if err != nil {
+		return nil, err
+	}
+
 	return newLocationConnectionResolver(impls, pointers.NonZeroPtr(nextCursor), r.locationResolver), nil
 }
 
@@ -129,5 +134,10 @@ func (r *gitBlobLSIFDataResolver) Prototypes(ctx context.Context, args *resolver
 		prototypes = filtered
 	}
 
+	prototypes, err = r.filterLocationsByView(ctx, args.View, prototypes)
+	if err != nil {
+		return nil, err
+	}
+
 	return newLocationConnectionResolver(prototypes, pointers.NonZeroPtr(nextCursor), r.locationResolver), nil
 }
@@ -21,7 +21,7 @@ func (r *gitBlobLSIFDataResolver) Snapshot(ctx context.Context, args *struct{ In
 	ctx, _, endObservation := r.operations.snapshot.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.Int("uploadID", uploadID),
 	}})
-	defer endObservation(1, observation.Args{})
+	defer endObservation(1, finishArgs(ctx))
 
 	data, err := r.codeNavSvc.SnapshotForDocument(ctx, r.requestState.RepositoryID, r.requestState.Commit, r.requestState.Path, uploadID)
 	if err != nil {
@@ -71,6 +71,11 @@ func (r *gitBlobLSIFDataResolver) References(ctx context.Context, args *resolver
 		refs = filtered
 	}
 
+	refs, err = r.filterLocationsByView(ctx, args.View, refs)
+	if err != nil {
+		return nil, err
+	}
+
 	return newLocationConnectionResolver(refs, pointers.NonZeroPtr(nextCursor), r.locationResolver), nil
 }
 
@@ -0,0 +1,60 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (r *gitBlobLSIFDataResolver) SupportsSemanticHighlighting(ctx context.Context) (_ bool, err error) {
+	args := r.positionalRequestArgs()
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.supportsSemanticHighlighting, time.Second, getObservationArgs(args))
+	defer endObservation()
+
+	supported, err := r.codeNavSvc.SupportsSemanticHighlighting(ctx, args, r.requestState)
+	if err != nil {
+		return false, errors.Wrap(err, "svc.SupportsSemanticHighlighting")
+	}
+
+	return supported, nil
+}
+
+func (r *gitBlobLSIFDataResolver) SemanticHighlighting(ctx context.Context, args *resolverstubs.SemanticHighlightingArgs) (_ *string, err error) {
+	requestArgs := r.positionalRequestArgs()
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.semanticHighlighting, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	document, err := r.codeNavSvc.GetSemanticHighlighting(ctx, requestArgs, r.requestState, args.DisableTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "svc.GetSemanticHighlighting")
+	}
+	if document == nil {
+		return nil, nil
+	}
+
+	marshaller := &jsonpb.Marshaler{
+		EnumsAsInts:  true,
+		EmitDefaults: false,
+	}
+	payload, err := marshaller.MarshalToString(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payload, nil
+}
+
+func (r *gitBlobLSIFDataResolver) positionalRequestArgs() codenav.PositionalRequestArgs {
+	return codenav.PositionalRequestArgs{
+		RequestArgs: codenav.RequestArgs{
+			RepositoryID: r.requestState.RepositoryID,
+			Commit:       r.requestState.Commit,
+		},
+		Path: r.requestState.Path,
+	}
+}
@@ -14,7 +14,9 @@ import (
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/resolvers/gitresolvers"
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -38,6 +40,7 @@ func TestRanges(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
@@ -73,6 +76,7 @@ func TestDefinitions(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
@@ -108,6 +112,7 @@ func TestReferences(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
@@ -161,6 +166,7 @@ func TestReferencesDefaultLimit(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
@@ -200,6 +206,7 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
@@ -217,6 +224,53 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	}
 }
 
+func TestFilterLocationsByView(t *testing.T) {
+	repoViewStore := dbmocks.NewMockRepoViewStore()
+	repoViewStore.GetByNameFunc.SetDefaultReturn(&sgtypes.RepoView{
+		Name:      "web",
+		RepoID:    1,
+		PathGlobs: []string{"client/web/**"},
+	}, nil)
+	repoStore := dbmocks.NewMockRepoStore()
+	repoStore.GetFunc.SetDefaultReturn(&sgtypes.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}, nil)
+	db := dbmocks.NewMockDB()
+	db.RepoViewsFunc.SetDefaultReturn(repoViewStore)
+	db.ReposFunc.SetDefaultReturn(repoStore)
+
+	resolver := &gitBlobLSIFDataResolver{db: db}
+
+	locations := []shared.UploadLocation{
+		{Dump: uploadsshared.Dump{RepositoryID: 1}, Path: "client/web/src/index.ts"},
+		{Dump: uploadsshared.Dump{RepositoryID: 1}, Path: "client/shared/src/index.ts"},
+		{Dump: uploadsshared.Dump{RepositoryID: 2}, Path: "client/web/src/index.ts"},
+	}
+
+	unfiltered, err := resolver.filterLocationsByView(context.Background(), nil, locations)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(unfiltered) != 3 {
+		t.Fatalf("unexpected number of locations. want=%d have=%d", 3, len(unfiltered))
+	}
+
+	view := "web"
+	filtered, err := resolver.filterLocationsByView(context.Background(), &view, locations)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(filtered) != 1 || filtered[0].Dump.RepositoryID != 1 || filtered[0].Path != "client/web/src/index.ts" {
+		t.Fatalf("unexpected locations: %v", filtered)
+	}
+	mockrequire.CalledOnceWith(t, repoViewStore.GetByNameFunc, mockrequire.Values(mockrequire.Skip, "web"))
+	mockrequire.CalledOnceWith(t, repoStore.GetFunc, mockrequire.Values(mockrequire.Skip, api.RepoID(1)))
+
+	// The view cannot be used if the parent repository is not accessible.
+	repoStore.GetFunc.SetDefaultReturn(nil, &database.RepoNotFoundErr{ID: 1})
+	if _, err := resolver.filterLocationsByView(context.Background(), &view, locations); !errcode.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockCodeNavService := NewMockCodeNavService()
 	mockRequestState := codenav.RequestState{
@@ -233,6 +287,7 @@ func TestHover(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
@@ -269,6 +324,7 @@ func TestDiagnostics(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
@@ -305,6 +361,7 @@ func TestDiagnosticsDefaultLimit(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
@@ -338,6 +395,7 @@ func TestDiagnosticsDefaultIllegalLimit(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		mockOperations,
 	)
 
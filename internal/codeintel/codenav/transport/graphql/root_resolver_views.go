@@ -0,0 +1,41 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/repoviews"
+)
+
+// filterLocationsByView returns the locations that are part of the repository
+// view with the given name, i.e. the locations in the parent repository of the
+// view whose path matches one of its path globs. If no view is given, the
+// locations are returned unchanged.
+//
+// The locations are filtered after they have been paginated, like the locations
+// filtered by path, so a page may contain fewer locations than requested.
+func (r *gitBlobLSIFDataResolver) filterLocationsByView(ctx context.Context, view *string, locations []shared.UploadLocation) ([]shared.UploadLocation, error) {
+	if view == nil || *view == "" {
+		return locations, nil
+	}
+
+	// 🚨 SECURITY: Resolve returns a not found error if the current user does not
+	// have access to the parent repository of the view.
+	repoView, _, err := repoviews.Resolve(ctx, r.db, *view)
+	if err != nil {
+		return nil, err
+	}
+	matcher, err := repoviews.NewMatcher(repoView.PathGlobs)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := locations[:0]
+	for _, loc := range locations {
+		if api.RepoID(loc.Dump.RepositoryID) == repoView.RepoID && matcher.Match(loc.Path) {
+			filtered = append(filtered, loc)
+		}
+	}
+	return filtered, nil
+}
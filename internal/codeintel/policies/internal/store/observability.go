@@ -15,6 +15,7 @@ type operations struct {
 	updateConfigurationPolicy                   *observation.Operation
 	deleteConfigurationPolicyByID               *observation.Operation
 	getRepoIDsByGlobPatterns                    *observation.Operation
+	getUploadPreviewByGlobPatterns              *observation.Operation
 	updateReposMatchingPatterns                 *observation.Operation
 	selectPoliciesForRepositoryMembershipUpdate *observation.Operation
 }
@@ -47,6 +48,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		updateConfigurationPolicy:                   op("UpdateConfigurationPolicy"),
 		deleteConfigurationPolicyByID:               op("DeleteConfigurationPolicyByID"),
 		getRepoIDsByGlobPatterns:                    op("GetRepoIDsByGlobPatterns"),
+		getUploadPreviewByGlobPatterns:              op("GetUploadPreviewByGlobPatterns"),
 		updateReposMatchingPatterns:                 op("UpdateReposMatchingPatterns"),
 		selectPoliciesForRepositoryMembershipUpdate: op("SelectPoliciesForRepositoryMembershipUpdate"),
 	}
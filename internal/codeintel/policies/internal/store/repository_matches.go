@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
@@ -75,6 +76,84 @@ LIMIT %s
 OFFSET %s
 `
 
+// GetUploadPreviewByGlobPatterns returns a sample of distinct upload roots and the number of completed
+// uploads that belong to a repository matching the given repository patterns and that have a root
+// matching the given root patterns. When a repository match limit is supplied, only the repositories
+// a policy would actually match (the most starred ones) are considered.
+func (s *store) GetUploadPreviewByGlobPatterns(ctx context.Context, repositoryPatterns, rootPatterns []string, repositoryMatchLimit *int, sampleSize int) (_ []string, _ int, err error) {
+	ctx, _, endObservation := s.operations.getUploadPreviewByGlobPatterns.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("numRepositoryPatterns", len(repositoryPatterns)),
+		attribute.Int("numRootPatterns", len(rootPatterns)),
+		attribute.Int("sampleSize", sampleSize),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(repositoryPatterns) == 0 {
+		return nil, 0, nil
+	}
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit := sqlf.Sprintf("ALL")
+	if repositoryMatchLimit != nil {
+		limit = sqlf.Sprintf("%s", *repositoryMatchLimit)
+	}
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(
+		uploadPreviewByGlobPatternsQuery,
+		makePatternCondition(repositoryPatterns, false),
+		authzConds,
+		limit,
+		makeRootPatternCondition(rootPatterns),
+		sampleSize,
+	))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var (
+		sampleRoots []string
+		numUploads  int
+	)
+	if rows.Next() {
+		if err := rows.Scan(&numUploads, pq.Array(&sampleRoots)); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return sampleRoots, numUploads, nil
+}
+
+const uploadPreviewByGlobPatternsQuery = `
+WITH
+candidate_repositories AS (
+	SELECT id
+	FROM repo
+	WHERE
+		(%s) AND
+		deleted_at IS NULL AND
+		blocked IS NULL AND
+		(%s)
+	ORDER BY stars DESC NULLS LAST, id
+	LIMIT %s
+),
+matching_uploads AS (
+	SELECT u.root
+	FROM lsif_uploads u
+	WHERE
+		u.repository_id IN (SELECT id FROM candidate_repositories) AND
+		u.state = 'completed' AND
+		(%s)
+)
+SELECT
+	(SELECT COUNT(*) FROM matching_uploads),
+	ARRAY(SELECT DISTINCT root FROM matching_uploads ORDER BY root LIMIT %s)
+`
+
 func (s *store) UpdateReposMatchingPatterns(ctx context.Context, patterns []string, policyID int, repositoryMatchLimit *int) (err error) {
 	ctx, _, endObservation := s.operations.updateReposMatchingPatterns.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.Int("numPatterns", len(patterns)),
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -89,6 +90,71 @@ func TestRepoIDsByGlobPatterns(t *testing.T) {
 	})
 }
 
+func TestGetUploadPreviewByGlobPatterns(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+
+	insertRepo(t, db, 50, "r1", false)
+	insertRepo(t, db, 51, "r2", false)
+	insertRepo(t, db, 52, "other", false)
+
+	for i, upload := range []struct {
+		repositoryID int
+		root         string
+		state        string
+	}{
+		{50, "", "completed"},
+		{50, "lib/", "completed"},
+		{50, "lib/sub/", "completed"},
+		{51, "lib/", "completed"},
+		{51, "cmd/", "completed"},
+		{51, "lib/", "errored"},
+		{52, "lib/", "completed"},
+	} {
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO lsif_uploads (id, repository_id, commit, indexer, num_parts, uploaded_parts, state, root)
+			VALUES ($1, $2, $3, 'lsif-go', 1, '{}', $4, $5)
+		`, i+1, upload.repositoryID, fmt.Sprintf("%040d", i+1), upload.state, upload.root); err != nil {
+			t.Fatalf("unexpected error inserting upload: %s", err)
+		}
+	}
+
+	testCases := []struct {
+		repositoryPatterns   []string
+		rootPatterns         []string
+		repositoryMatchLimit *int
+		expectedRoots        []string
+		expectedNumUploads   int
+	}{
+		{repositoryPatterns: []string{"r*"}, expectedRoots: []string{"", "cmd/", "lib/"}, expectedNumUploads: 5},
+		{repositoryPatterns: []string{"r*"}, rootPatterns: []string{"lib/*"}, expectedRoots: []string{"lib/", "lib/sub/"}, expectedNumUploads: 3},
+		{repositoryPatterns: []string{"r*"}, rootPatterns: []string{"lib/", "cmd/"}, expectedRoots: []string{"cmd/", "lib/"}, expectedNumUploads: 3},
+		{repositoryPatterns: []string{"*"}, rootPatterns: []string{"lib/"}, repositoryMatchLimit: pointers.Ptr(1), expectedRoots: []string{"lib/"}, expectedNumUploads: 1},
+		{repositoryPatterns: []string{"missing"}, expectedRoots: []string{}, expectedNumUploads: 0},
+		{repositoryPatterns: []string{"r*"}, rootPatterns: []string{"li_/"}, expectedRoots: []string{}, expectedNumUploads: 0},
+	}
+
+	for _, testCase := range testCases {
+		name := fmt.Sprintf("repositoryPatterns=%v rootPatterns=%v", testCase.repositoryPatterns, testCase.rootPatterns)
+
+		t.Run(name, func(t *testing.T) {
+			roots, numUploads, err := store.GetUploadPreviewByGlobPatterns(ctx, testCase.repositoryPatterns, testCase.rootPatterns, testCase.repositoryMatchLimit, 3)
+			if err != nil {
+				t.Fatalf("unexpected error fetching upload preview: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedRoots, roots); diff != "" {
+				t.Errorf("unexpected roots (-want +got):\n%s", diff)
+			}
+			if numUploads != testCase.expectedNumUploads {
+				t.Errorf("unexpected number of uploads. want=%d have=%d", testCase.expectedNumUploads, numUploads)
+			}
+		})
+	}
+}
+
 func TestUpdateReposMatchingPatterns(t *testing.T) {
 	ctx := context.Background()
 	logger := logtest.Scoped(t)
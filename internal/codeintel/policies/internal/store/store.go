@@ -25,6 +25,7 @@ type Store interface {
 
 	// Repository matches
 	GetRepoIDsByGlobPatterns(ctx context.Context, patterns []string, limit, offset int) ([]int, int, error)
+	GetUploadPreviewByGlobPatterns(ctx context.Context, repositoryPatterns, rootPatterns []string, repositoryMatchLimit *int, sampleSize int) (sampleRoots []string, numUploads int, _ error)
 	UpdateReposMatchingPatterns(ctx context.Context, patterns []string, policyID int, repositoryMatchLimit *int) error
 	SelectPoliciesForRepositoryMembershipUpdate(ctx context.Context, batchSize int) ([]shared.ConfigurationPolicy, error)
 }
@@ -25,6 +25,25 @@ func makePatternCondition(patterns []string, defaultValue bool) *sqlf.Query {
 	return sqlf.Join(conds, "OR")
 }
 
+// makeRootPatternCondition returns a condition matching the root of an upload against any of
+// the given glob patterns. Every root matches when no patterns are supplied. Only `*` is a
+// wildcard; the LIKE wildcards `_` and `%` match literally.
+func makeRootPatternCondition(patterns []string) *sqlf.Query {
+	if len(patterns) == 0 {
+		return sqlf.Sprintf("TRUE")
+	}
+
+	conds := make([]*sqlf.Query, 0, len(patterns))
+	for _, pattern := range patterns {
+		conds = append(conds, sqlf.Sprintf(`u.root LIKE %s ESCAPE '\'`, strings.ReplaceAll(likeEscaper.Replace(pattern), "*", "%")))
+	}
+
+	return sqlf.Join(conds, "OR")
+}
+
+// likeEscaper escapes the characters that are special in LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func optionalLimit(limit *int) *sqlf.Query {
 	if limit != nil {
 		return sqlf.Sprintf("LIMIT %d", *limit)
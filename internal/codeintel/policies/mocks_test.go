@@ -37,6 +37,10 @@ type MockStore struct {
 	// GetRepoIDsByGlobPatternsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetRepoIDsByGlobPatterns.
 	GetRepoIDsByGlobPatternsFunc *StoreGetRepoIDsByGlobPatternsFunc
+	// GetUploadPreviewByGlobPatternsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUploadPreviewByGlobPatterns.
+	GetUploadPreviewByGlobPatternsFunc *StoreGetUploadPreviewByGlobPatternsFunc
 	// RepoCountFunc is an instance of a mock function object controlling
 	// the behavior of the method RepoCount.
 	RepoCountFunc *StoreRepoCountFunc
@@ -83,6 +87,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUploadPreviewByGlobPatternsFunc: &StoreGetUploadPreviewByGlobPatternsFunc{
+			defaultHook: func(context.Context, []string, []string, *int, int) (r0 []string, r1 int, r2 error) {
+				return
+			},
+		},
 		RepoCountFunc: &StoreRepoCountFunc{
 			defaultHook: func(context.Context) (r0 int, r1 error) {
 				return
@@ -135,6 +144,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetRepoIDsByGlobPatterns")
 			},
 		},
+		GetUploadPreviewByGlobPatternsFunc: &StoreGetUploadPreviewByGlobPatternsFunc{
+			defaultHook: func(context.Context, []string, []string, *int, int) ([]string, int, error) {
+				panic("unexpected invocation of MockStore.GetUploadPreviewByGlobPatterns")
+			},
+		},
 		RepoCountFunc: &StoreRepoCountFunc{
 			defaultHook: func(context.Context) (int, error) {
 				panic("unexpected invocation of MockStore.RepoCount")
@@ -177,6 +191,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetRepoIDsByGlobPatternsFunc: &StoreGetRepoIDsByGlobPatternsFunc{
 			defaultHook: i.GetRepoIDsByGlobPatterns,
 		},
+		GetUploadPreviewByGlobPatternsFunc: &StoreGetUploadPreviewByGlobPatternsFunc{
+			defaultHook: i.GetUploadPreviewByGlobPatterns,
+		},
 		RepoCountFunc: &StoreRepoCountFunc{
 			defaultHook: i.RepoCount,
 		},
@@ -756,6 +773,129 @@ func (c StoreGetRepoIDsByGlobPatternsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetUploadPreviewByGlobPatternsFunc describes the behavior when the
+// GetUploadPreviewByGlobPatterns method of the parent MockStore instance is
+// invoked.
+type StoreGetUploadPreviewByGlobPatternsFunc struct {
+	defaultHook func(context.Context, []string, []string, *int, int) ([]string, int, error)
+	hooks       []func(context.Context, []string, []string, *int, int) ([]string, int, error)
+	history     []StoreGetUploadPreviewByGlobPatternsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadPreviewByGlobPatterns delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadPreviewByGlobPatterns(v0 context.Context, v1 []string, v2 []string, v3 *int, v4 int) ([]string, int, error) {
+	r0, r1, r2 := m.GetUploadPreviewByGlobPatternsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetUploadPreviewByGlobPatternsFunc.appendCall(StoreGetUploadPreviewByGlobPatternsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadPreviewByGlobPatterns method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUploadPreviewByGlobPatternsFunc) SetDefaultHook(hook func(context.Context, []string, []string, *int, int) ([]string, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadPreviewByGlobPatterns method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetUploadPreviewByGlobPatternsFunc) PushHook(hook func(context.Context, []string, []string, *int, int) ([]string, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadPreviewByGlobPatternsFunc) SetDefaultReturn(r0 []string, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, []string, []string, *int, int) ([]string, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadPreviewByGlobPatternsFunc) PushReturn(r0 []string, r1 int, r2 error) {
+	f.PushHook(func(context.Context, []string, []string, *int, int) ([]string, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetUploadPreviewByGlobPatternsFunc) nextHook() func(context.Context, []string, []string, *int, int) ([]string, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadPreviewByGlobPatternsFunc) appendCall(r0 StoreGetUploadPreviewByGlobPatternsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadPreviewByGlobPatternsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUploadPreviewByGlobPatternsFunc) History() []StoreGetUploadPreviewByGlobPatternsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadPreviewByGlobPatternsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadPreviewByGlobPatternsFuncCall is an object that describes
+// an invocation of method GetUploadPreviewByGlobPatterns on an instance of
+// MockStore.
+type StoreGetUploadPreviewByGlobPatternsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 *int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadPreviewByGlobPatternsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadPreviewByGlobPatternsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreRepoCountFunc describes the behavior when the RepoCount method of
 // the parent MockStore instance is invoked.
 type StoreRepoCountFunc struct {
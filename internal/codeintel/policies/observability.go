@@ -12,6 +12,7 @@ type operations struct {
 	getRetentionPolicyOverview *observation.Operation
 	getPreviewRepositoryFilter *observation.Operation
 	getPreviewGitObjectFilter  *observation.Operation
+	getPreviewPolicy           *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -39,5 +40,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getRetentionPolicyOverview: op("GetRetentionPolicyOverview"),
 		getPreviewRepositoryFilter: op("GetPreviewRepositoryFilter"),
 		getPreviewGitObjectFilter:  op("GetPreviewGitObjectFilter"),
+		getPreviewPolicy:           op("GetPreviewPolicy"),
 	}
 }
@@ -185,7 +185,13 @@ func (s *Service) GetPreviewRepositoryFilter(ctx context.Context, patterns []str
 	ctx, _, endObservation := s.operations.getPreviewRepositoryFilter.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
-	if val := conf.CodeIntelAutoIndexingPolicyRepositoryMatchLimit(); val != -1 {
+	return s.getPreviewRepositoryFilter(ctx, patterns, limit, true)
+}
+
+// getPreviewRepositoryFilter returns the repositories matching the given patterns. If applyMatchLimit
+// is set, the repository match limit of the site configuration caps the number of matches.
+func (s *Service) getPreviewRepositoryFilter(ctx context.Context, patterns []string, limit int, applyMatchLimit bool) (_ []int, totalCount int, matchesAll bool, repositoryMatchLimit *int, err error) {
+	if val := conf.CodeIntelAutoIndexingPolicyRepositoryMatchLimit(); applyMatchLimit && val != -1 {
 		repositoryMatchLimit = &val
 
 		if limit > *repositoryMatchLimit {
@@ -205,6 +211,39 @@ func (s *Service) GetPreviewRepositoryFilter(ctx context.Context, patterns []str
 	return ids, totalCount, totalCount == totalRepoCount, repositoryMatchLimit, nil
 }
 
+// GetPreviewPolicy evaluates the repository and path patterns of a draft configuration policy against
+// the instance. An empty set of repository patterns describes a global policy and matches every
+// repository, regardless of the repository match limit, which only applies to policies with
+// repository patterns. Path patterns are matched against the roots of existing uploads.
+func (s *Service) GetPreviewPolicy(ctx context.Context, repositoryPatterns, pathPatterns []string, limit int) (_ policiesshared.ConfigurationPolicyPreview, err error) {
+	ctx, _, endObservation := s.operations.getPreviewPolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	global := len(repositoryPatterns) == 0
+	if global {
+		repositoryPatterns = []string{"*"}
+	}
+
+	ids, totalMatches, matchesAll, repositoryMatchLimit, err := s.getPreviewRepositoryFilter(ctx, repositoryPatterns, limit, !global)
+	if err != nil {
+		return policiesshared.ConfigurationPolicyPreview{}, err
+	}
+
+	sampleRoots, numUploads, err := s.store.GetUploadPreviewByGlobPatterns(ctx, repositoryPatterns, pathPatterns, repositoryMatchLimit, limit)
+	if err != nil {
+		return policiesshared.ConfigurationPolicyPreview{}, err
+	}
+
+	return policiesshared.ConfigurationPolicyPreview{
+		RepositoryIDs:        ids,
+		TotalMatches:         totalMatches,
+		MatchesAllRepos:      matchesAll,
+		RepositoryMatchLimit: repositoryMatchLimit,
+		SampleRoots:          sampleRoots,
+		NumUploads:           numUploads,
+	}, nil
+}
+
 type GitObject struct {
 	Name        string
 	Rev         string
@@ -12,12 +12,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	policiesshared "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	internaltypes "github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestGetRetentionPolicyOverview(t *testing.T) {
@@ -330,6 +332,75 @@ func TestRetentionPolicyOverview_ByVisibility(t *testing.T) {
 	}
 }
 
+func TestGetPreviewPolicy(t *testing.T) {
+	mockStore := NewMockStore()
+	mockStore.GetRepoIDsByGlobPatternsFunc.SetDefaultReturn([]int{50, 51}, 3, nil)
+	mockStore.RepoCountFunc.SetDefaultReturn(3, nil)
+	mockStore.GetUploadPreviewByGlobPatternsFunc.SetDefaultReturn([]string{"lib/", "lib/sub/"}, 7, nil)
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelAutoIndexingPolicyRepositoryMatchLimit: pointers.Ptr(2),
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	svc := newService(&observation.TestContext, mockStore, defaultMockRepoStore(), NewMockUploadService(), gitserver.NewMockClient())
+
+	preview, err := svc.GetPreviewPolicy(context.Background(), nil, []string{"lib/*"}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error previewing policy: %s", err)
+	}
+
+	// A policy without repository patterns is global, so the repository match limit doesn't apply
+	expected := policiesshared.ConfigurationPolicyPreview{
+		RepositoryIDs:        []int{50, 51},
+		TotalMatches:         3,
+		MatchesAllRepos:      true,
+		RepositoryMatchLimit: nil,
+		SampleRoots:          []string{"lib/", "lib/sub/"},
+		NumUploads:           7,
+	}
+	if diff := cmp.Diff(expected, preview); diff != "" {
+		t.Errorf("unexpected preview (-want +got):\n%s", diff)
+	}
+
+	if history := mockStore.GetRepoIDsByGlobPatternsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to GetRepoIDsByGlobPatterns. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff([]string{"*"}, history[0].Arg1); diff != "" {
+		t.Errorf("unexpected repository patterns (-want +got):\n%s", diff)
+	} else if history[0].Arg2 != 10 {
+		t.Errorf("unexpected repository sample size. want=%d have=%d", 10, history[0].Arg2)
+	}
+
+	if history := mockStore.GetUploadPreviewByGlobPatternsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to GetUploadPreviewByGlobPatterns. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff([]string{"lib/*"}, history[0].Arg2); diff != "" {
+		t.Errorf("unexpected path patterns (-want +got):\n%s", diff)
+	} else if history[0].Arg3 != nil {
+		t.Errorf("unexpected repository match limit. want=nil have=%d", *history[0].Arg3)
+	}
+
+	// A policy with repository patterns is capped by the repository match limit
+	preview, err = svc.GetPreviewPolicy(context.Background(), []string{"github.com/*"}, nil, 10)
+	if err != nil {
+		t.Fatalf("unexpected error previewing policy: %s", err)
+	}
+	if diff := cmp.Diff(pointers.Ptr(2), preview.RepositoryMatchLimit); diff != "" {
+		t.Errorf("unexpected repository match limit (-want +got):\n%s", diff)
+	}
+
+	if history := mockStore.GetRepoIDsByGlobPatternsFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected number of calls to GetRepoIDsByGlobPatterns. want=%d have=%d", 2, len(history))
+	} else if history[1].Arg2 != 2 {
+		t.Errorf("expected repository sample to be capped by the match limit. want=%d have=%d", 2, history[1].Arg2)
+	}
+
+	if history := mockStore.GetUploadPreviewByGlobPatternsFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected number of calls to GetUploadPreviewByGlobPatterns. want=%d have=%d", 2, len(history))
+	} else if diff := cmp.Diff(pointers.Ptr(2), history[1].Arg3); diff != "" {
+		t.Errorf("unexpected repository match limit (-want +got):\n%s", diff)
+	}
+}
+
 func mockConfigurationPolicies(policies []policiesshared.RetentionPolicyMatchCandidate) (mockedCandidates []policiesshared.RetentionPolicyMatchCandidate, mockedPolicies []policiesshared.ConfigurationPolicy) {
 	for i, policy := range policies {
 		if policy.ConfigurationPolicy != nil {
@@ -19,6 +19,27 @@ type ConfigurationPolicy struct {
 	EmbeddingEnabled          bool
 }
 
+// ConfigurationPolicyPreview describes what a draft configuration policy would match if it were saved.
+type ConfigurationPolicyPreview struct {
+	// RepositoryIDs is a sample of the matching repositories.
+	RepositoryIDs []int
+
+	// TotalMatches is the number of repositories matching the repository patterns.
+	TotalMatches int
+
+	// MatchesAllRepos is true if every repository on the instance is matched.
+	MatchesAllRepos bool
+
+	// RepositoryMatchLimit is the maximum number of repositories a policy can match, if any.
+	RepositoryMatchLimit *int
+
+	// SampleRoots is a sample of distinct upload roots matching the path patterns.
+	SampleRoots []string
+
+	// NumUploads is the number of completed uploads in matching repositories with a matching root.
+	NumUploads int
+}
+
 type GitObjectType string
 
 const (
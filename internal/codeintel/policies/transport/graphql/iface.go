@@ -20,5 +20,6 @@ type PoliciesService interface {
 
 	// Filter previews
 	GetPreviewRepositoryFilter(ctx context.Context, patterns []string, limit int) (_ []int, totalCount int, matchesAll bool, repositoryMatchLimit *int, _ error)
+	GetPreviewPolicy(ctx context.Context, repositoryPatterns, pathPatterns []string, limit int) (policiesshared.ConfigurationPolicyPreview, error)
 	GetPreviewGitObjectFilter(ctx context.Context, repositoryID int, gitObjectType shared.GitObjectType, pattern string, limit int, countObjectsYoungerThanHours *int32) (_ []policies.GitObject, totalCount int, totalCountYoungerThanThreshold *int, _ error)
 }
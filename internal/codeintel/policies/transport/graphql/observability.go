@@ -13,6 +13,7 @@ type operations struct {
 	createConfigurationPolicy *observation.Operation
 	deleteConfigurationPolicy *observation.Operation
 	previewGitObjectFilter    *observation.Operation
+	previewPolicy             *observation.Operation
 	previewRepoFilter         *observation.Operation
 	updateConfigurationPolicy *observation.Operation
 }
@@ -39,6 +40,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		createConfigurationPolicy: op("CreateConfigurationPolicy"),
 		deleteConfigurationPolicy: op("DeleteConfigurationPolicy"),
 		previewGitObjectFilter:    op("PreviewGitObjectFilter"),
+		previewPolicy:             op("PreviewPolicy"),
 		previewRepoFilter:         op("PreviewRepoFilter"),
 		updateConfigurationPolicy: op("UpdateConfigurationPolicy"),
 	}
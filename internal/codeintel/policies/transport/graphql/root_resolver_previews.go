@@ -55,6 +55,37 @@ func (r *rootResolver) PreviewRepositoryFilter(ctx context.Context, args *resolv
 	return newRepositoryFilterPreviewResolver(resv, limitedCount, totalMatches, matchesAll, repositoryMatchLimit), nil
 }
 
+func (r *rootResolver) PreviewCodeIntelligenceConfigurationPolicy(ctx context.Context, args *resolverstubs.PreviewCodeIntelligenceConfigurationPolicyArgs) (_ resolverstubs.CodeIntelligenceConfigurationPolicyPreviewResolver, err error) {
+	ctx, _, endObservation := r.operations.previewPolicy.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("first", int(pointers.Deref(args.First, 0))),
+		attribute.StringSlice("repositoryPatterns", pointers.Deref(args.RepositoryPatterns, nil)),
+		attribute.StringSlice("pathPatterns", pointers.Deref(args.PathPatterns, nil)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preview, err := r.policySvc.GetPreviewPolicy(
+		ctx,
+		pointers.Deref(args.RepositoryPatterns, nil),
+		pointers.Deref(args.PathPatterns, nil),
+		int(args.Limit(DefaultRepositoryFilterPreviewPageSize)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resv := make([]resolverstubs.RepositoryResolver, 0, len(preview.RepositoryIDs))
+	for _, id := range preview.RepositoryIDs {
+		res, err := gitresolvers.NewRepositoryFromID(ctx, r.repoStore, id)
+		if err != nil {
+			return nil, err
+		}
+
+		resv = append(resv, res)
+	}
+
+	return newPolicyPreviewResolver(resv, preview), nil
+}
+
 func (r *rootResolver) PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *resolverstubs.PreviewGitObjectFilterArgs) (_ resolverstubs.GitObjectFilterPreviewResolver, err error) {
 	ctx, _, endObservation := r.operations.previewGitObjectFilter.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.Int("first", int(pointers.Deref(args.First, 0))),
@@ -137,6 +168,45 @@ func (r *repositoryFilterPreviewResolver) Limit() *int32 {
 //
 //
 
+type policyPreviewResolver struct {
+	repositoryResolvers []resolverstubs.RepositoryResolver
+	preview             shared.ConfigurationPolicyPreview
+}
+
+func newPolicyPreviewResolver(repositoryResolvers []resolverstubs.RepositoryResolver, preview shared.ConfigurationPolicyPreview) resolverstubs.CodeIntelligenceConfigurationPolicyPreviewResolver {
+	return &policyPreviewResolver{
+		repositoryResolvers: repositoryResolvers,
+		preview:             preview,
+	}
+}
+
+func (r *policyPreviewResolver) Repositories() []resolverstubs.RepositoryResolver {
+	return r.repositoryResolvers
+}
+
+func (r *policyPreviewResolver) TotalMatches() int32 {
+	return int32(r.preview.TotalMatches)
+}
+
+func (r *policyPreviewResolver) MatchesAllRepos() bool {
+	return r.preview.MatchesAllRepos
+}
+
+func (r *policyPreviewResolver) Limit() *int32 {
+	return toInt32(r.preview.RepositoryMatchLimit)
+}
+
+func (r *policyPreviewResolver) SamplePaths() []string {
+	return r.preview.SampleRoots
+}
+
+func (r *policyPreviewResolver) AffectedUploadCount() int32 {
+	return int32(r.preview.NumUploads)
+}
+
+//
+//
+
 type gitObjectFilterPreviewResolver struct {
 	gitObjectResolvers             []resolverstubs.CodeIntelGitObjectResolver
 	totalCount                     int
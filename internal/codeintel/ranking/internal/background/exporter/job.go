@@ -208,6 +208,7 @@ func setDefinitionsForUpload(
 					UploadID:         uploadID,
 					ExportedUploadID: exportedUploadID,
 					SymbolChecksum:   checksum,
+					SymbolName:       searchableSymbolName(occ.Symbol),
 					DocumentPath:     documentPath,
 				}
 				seenDefinitions[occ.Symbol] = struct{}{}
@@ -252,6 +253,24 @@ func canonicalizeSymbol(symbolName string) ([16]byte, bool) {
 	return md5.Sum([]byte(symbol)), true
 }
 
+// searchableSymbolName returns the name of the last descriptor of the given
+// symbol, e.g. `Foo` for a type `Foo` or `Bar` for a method `Foo.Bar`. It
+// returns the empty string for parameters and other symbols that should not
+// be found by symbol search.
+func searchableSymbolName(symbolName string) string {
+	symbol, err := scip.ParseSymbol(symbolName)
+	if err != nil || len(symbol.Descriptors) == 0 {
+		return ""
+	}
+
+	descriptor := symbol.Descriptors[len(symbol.Descriptors)-1]
+	switch descriptor.Suffix {
+	case scip.Descriptor_Parameter, scip.Descriptor_TypeParameter, scip.Descriptor_Local, scip.Descriptor_Meta:
+		return ""
+	}
+	return descriptor.Name
+}
+
 var noVersionFormatter = scip.SymbolFormatter{
 	OnError:               func(err error) error { return err },
 	IncludeScheme:         func(_ string) bool { return true },
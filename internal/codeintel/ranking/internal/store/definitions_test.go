@@ -40,6 +40,7 @@ func TestInsertDefinition(t *testing.T) {
 			UploadID:         4,
 			ExportedUploadID: 104,
 			SymbolChecksum:   hash("foo"),
+			SymbolName:       "Foo",
 			DocumentPath:     "foo.go",
 		},
 		{
@@ -52,6 +53,7 @@ func TestInsertDefinition(t *testing.T) {
 			UploadID:         4,
 			ExportedUploadID: 104,
 			SymbolChecksum:   hash("foo"),
+			SymbolName:       "Foo",
 			DocumentPath:     "foo.go",
 		},
 	}
@@ -87,7 +89,7 @@ func getRankingDefinitions(
 	graphKey string,
 ) (_ []shared.RankingDefinitions, err error) {
 	query := fmt.Sprintf(`
-		SELECT cre.upload_id, cre.id, rd.symbol_checksum, rd.document_path
+		SELECT cre.upload_id, cre.id, rd.symbol_checksum, rd.symbol_name, rd.document_path
 		FROM codeintel_ranking_definitions rd
 		JOIN codeintel_ranking_exports cre ON cre.id = rd.exported_upload_id
 		WHERE rd.graph_key = '%s'
@@ -105,8 +107,9 @@ func getRankingDefinitions(
 		var uploadID int
 		var exportedUploadID int
 		var symbolChecksum []byte
+		var symbolName string
 		var documentPath string
-		err = rows.Scan(&uploadID, &exportedUploadID, &symbolChecksum, &documentPath)
+		err = rows.Scan(&uploadID, &exportedUploadID, &symbolChecksum, &symbolName, &documentPath)
 		if err != nil {
 			return nil, err
 		}
@@ -114,6 +117,7 @@ func getRankingDefinitions(
 			UploadID:         uploadID,
 			ExportedUploadID: exportedUploadID,
 			SymbolChecksum:   castToChecksum(symbolChecksum),
+			SymbolName:       symbolName,
 			DocumentPath:     documentPath,
 		})
 	}
@@ -20,6 +20,7 @@ type operations struct {
 	vacuumDeletedExportedUploads   *observation.Operation
 	insertDefinitionsForRanking    *observation.Operation
 	insertReferencesForRanking     *observation.Operation
+	searchSymbolDefinitions        *observation.Operation
 	insertInitialPathRanks         *observation.Operation
 	derivativeGraphKey             *observation.Operation
 	bumpDerivativeGraphKey         *observation.Operation
@@ -67,6 +68,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		vacuumDeletedExportedUploads:   op("VacuumDeletedExportedUploads"),
 		insertDefinitionsForRanking:    op("InsertDefinitionsForRanking"),
 		insertReferencesForRanking:     op("InsertReferencesForRanking"),
+		searchSymbolDefinitions:        op("SearchSymbolDefinitions"),
 		insertInitialPathRanks:         op("InsertInitialPathRanks"),
 		coordinate:                     op("Coordinate"),
 		derivativeGraphKey:             op("DerivativeGraphKey"),
@@ -38,6 +38,9 @@ type Store interface {
 	InsertReferencesForRanking(ctx context.Context, graphKey string, batchSize int, exportedUploadID int, references chan [16]byte) error
 	InsertInitialPathRanks(ctx context.Context, exportedUploadID int, documentPaths []string, batchSize int, graphKey string) error
 
+	// Symbol search
+	SearchSymbolDefinitions(ctx context.Context, graphKey, name string, prefix bool, limit int) ([]shared.SymbolDefinition, error)
+
 	// Graph keys
 	DerivativeGraphKey(ctx context.Context) (string, time.Time, bool, error)
 	BumpDerivativeGraphKey(ctx context.Context) error
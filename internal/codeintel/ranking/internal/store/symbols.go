@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func (s *store) SearchSymbolDefinitions(ctx context.Context, graphKey, name string, prefix bool, limit int) (_ []shared.SymbolDefinition, err error) {
+	ctx, _, endObservation := s.operations.searchSymbolDefinitions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("graphKey", graphKey),
+		attribute.String("name", name),
+		attribute.Bool("prefix", prefix),
+		attribute.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if name == "" {
+		return nil, nil
+	}
+
+	nameCond := sqlf.Sprintf("rd.symbol_name = %s", name)
+	if prefix {
+		nameCond = sqlf.Sprintf(`rd.symbol_name LIKE %s ESCAPE '\'`, escapeLikePattern(name)+"%")
+	}
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSymbolDefinitions(s.db.Query(ctx, sqlf.Sprintf(
+		searchSymbolDefinitionsQuery,
+		graphKey,
+		nameCond,
+		authzConds,
+		limit,
+	)))
+}
+
+const searchSymbolDefinitionsQuery = `
+SELECT
+	rd.symbol_name,
+	r.id,
+	r.name,
+	u.commit,
+	rd.document_path
+FROM codeintel_ranking_definitions rd
+JOIN codeintel_ranking_exports re ON re.id = rd.exported_upload_id
+JOIN lsif_uploads u ON u.id = re.upload_id
+JOIN repo r ON r.id = u.repository_id
+WHERE
+	rd.graph_key = %s AND
+	rd.symbol_name <> '' AND
+	%s AND
+	re.deleted_at IS NULL AND
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL AND
+	%s
+ORDER BY length(rd.symbol_name), rd.symbol_name, r.stars DESC NULLS LAST, r.name, rd.document_path
+LIMIT %s
+`
+
+var scanSymbolDefinitions = basestore.NewSliceScanner(func(s dbutil.Scanner) (d shared.SymbolDefinition, _ error) {
+	err := s.Scan(&d.SymbolName, &d.RepositoryID, &d.Repository, &d.Commit, &d.DocumentPath)
+	return d, err
+})
+
+// escapeLikePattern escapes the characters of s that are special in LIKE
+// patterns.
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
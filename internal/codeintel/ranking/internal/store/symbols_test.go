@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestSearchSymbolDefinitions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+
+	// Insert uploads
+	insertUploads(t, db,
+		uploadsshared.Upload{ID: 4, RepositoryID: 50, RepositoryName: "foo"},
+		uploadsshared.Upload{ID: 5, RepositoryID: 51, RepositoryName: "bar"},
+		uploadsshared.Upload{ID: 6, RepositoryID: 52, RepositoryName: "DELETED-baz"},
+	)
+
+	// Insert exported uploads
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_exports (id, upload_id, graph_key, upload_key, deleted_at)
+		VALUES
+			(104, 4, $1, md5('key-4'), NULL),
+			(105, 5, $1, md5('key-5'), NULL),
+			(106, 6, $1, md5('key-6'), NULL),
+			(107, 4, 'other', md5('key-4'), NULL)
+	`,
+		mockRankingGraphKey,
+	); err != nil {
+		t.Fatalf("unexpected error inserting exported upload record: %s", err)
+	}
+
+	// Insert definitions
+	for graphKey, definitions := range map[string][]shared.RankingDefinitions{
+		mockRankingGraphKey: {
+			{ExportedUploadID: 104, SymbolChecksum: hash("foo"), SymbolName: "Foo", DocumentPath: "foo.go"},
+			{ExportedUploadID: 104, SymbolChecksum: hash("foobar"), SymbolName: "FooBar", DocumentPath: "foo.go"},
+			{ExportedUploadID: 104, SymbolChecksum: hash("param"), DocumentPath: "foo.go"},
+			{ExportedUploadID: 105, SymbolChecksum: hash("foo"), SymbolName: "Foo", DocumentPath: "lib/foo.go"},
+			{ExportedUploadID: 105, SymbolChecksum: hash("f_o"), SymbolName: "F_o", DocumentPath: "lib/f.go"},
+			{ExportedUploadID: 106, SymbolChecksum: hash("foo"), SymbolName: "Foo", DocumentPath: "deleted.go"},
+		},
+		"other": {
+			{ExportedUploadID: 107, SymbolChecksum: hash("foo"), SymbolName: "Foo", DocumentPath: "stale.go"},
+		},
+	} {
+		ch := make(chan shared.RankingDefinitions, len(definitions))
+		for _, definition := range definitions {
+			ch <- definition
+		}
+		close(ch)
+		if err := store.InsertDefinitionsForRanking(ctx, graphKey, ch); err != nil {
+			t.Fatalf("unexpected error inserting definitions: %s", err)
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		prefix   bool
+		limit    int
+		expected []shared.SymbolDefinition
+	}{
+		{
+			name:  "Foo",
+			limit: 10,
+			expected: []shared.SymbolDefinition{
+				{SymbolName: "Foo", RepositoryID: 51, Repository: "bar", Commit: makeCommit(5), DocumentPath: "lib/foo.go"},
+				{SymbolName: "Foo", RepositoryID: 50, Repository: "foo", Commit: makeCommit(4), DocumentPath: "foo.go"},
+			},
+		},
+		{
+			name:   "Foo",
+			prefix: true,
+			limit:  10,
+			expected: []shared.SymbolDefinition{
+				{SymbolName: "Foo", RepositoryID: 51, Repository: "bar", Commit: makeCommit(5), DocumentPath: "lib/foo.go"},
+				{SymbolName: "Foo", RepositoryID: 50, Repository: "foo", Commit: makeCommit(4), DocumentPath: "foo.go"},
+				{SymbolName: "FooBar", RepositoryID: 50, Repository: "foo", Commit: makeCommit(4), DocumentPath: "foo.go"},
+			},
+		},
+		{
+			name:   "Foo",
+			prefix: true,
+			limit:  1,
+			expected: []shared.SymbolDefinition{
+				{SymbolName: "Foo", RepositoryID: 51, Repository: "bar", Commit: makeCommit(5), DocumentPath: "lib/foo.go"},
+			},
+		},
+		{
+			// Wildcards in the prefix are matched literally.
+			name:     "F_",
+			prefix:   true,
+			limit:    10,
+			expected: []shared.SymbolDefinition{{SymbolName: "F_o", RepositoryID: 51, Repository: "bar", Commit: makeCommit(5), DocumentPath: "lib/f.go"}},
+		},
+		{
+			name:     "foo",
+			limit:    10,
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		definitions, err := store.SearchSymbolDefinitions(ctx, mockRankingGraphKey, testCase.name, testCase.prefix, testCase.limit)
+		if err != nil {
+			t.Fatalf("unexpected error searching symbol definitions: %s", err)
+		}
+		if diff := cmp.Diff(testCase.expected, definitions); diff != "" {
+			t.Errorf("unexpected definitions for %q (prefix=%v, limit=%d) (-want +got):\n%s", testCase.name, testCase.prefix, testCase.limit, diff)
+		}
+	}
+}
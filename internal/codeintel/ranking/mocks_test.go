@@ -75,6 +75,9 @@ type MockStore struct {
 	// LastUpdatedAtFunc is an instance of a mock function object
 	// controlling the behavior of the method LastUpdatedAt.
 	LastUpdatedAtFunc *StoreLastUpdatedAtFunc
+	// SearchSymbolDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchSymbolDefinitions.
+	SearchSymbolDefinitionsFunc *StoreSearchSymbolDefinitionsFunc
 	// SoftDeleteStaleExportedUploadsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// SoftDeleteStaleExportedUploads.
@@ -193,6 +196,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		SearchSymbolDefinitionsFunc: &StoreSearchSymbolDefinitionsFunc{
+			defaultHook: func(context.Context, string, string, bool, int) (r0 []shared.SymbolDefinition, r1 error) {
+				return
+			},
+		},
 		SoftDeleteStaleExportedUploadsFunc: &StoreSoftDeleteStaleExportedUploadsFunc{
 			defaultHook: func(context.Context, string) (r0 int, r1 int, r2 error) {
 				return
@@ -325,6 +333,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.LastUpdatedAt")
 			},
 		},
+		SearchSymbolDefinitionsFunc: &StoreSearchSymbolDefinitionsFunc{
+			defaultHook: func(context.Context, string, string, bool, int) ([]shared.SymbolDefinition, error) {
+				panic("unexpected invocation of MockStore.SearchSymbolDefinitions")
+			},
+		},
 		SoftDeleteStaleExportedUploadsFunc: &StoreSoftDeleteStaleExportedUploadsFunc{
 			defaultHook: func(context.Context, string) (int, int, error) {
 				panic("unexpected invocation of MockStore.SoftDeleteStaleExportedUploads")
@@ -425,6 +438,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
 			defaultHook: i.LastUpdatedAt,
 		},
+		SearchSymbolDefinitionsFunc: &StoreSearchSymbolDefinitionsFunc{
+			defaultHook: i.SearchSymbolDefinitions,
+		},
 		SoftDeleteStaleExportedUploadsFunc: &StoreSoftDeleteStaleExportedUploadsFunc{
 			defaultHook: i.SoftDeleteStaleExportedUploads,
 		},
@@ -2219,6 +2235,124 @@ func (c StoreLastUpdatedAtFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreSearchSymbolDefinitionsFunc describes the behavior when the
+// SearchSymbolDefinitions method of the parent MockStore instance is
+// invoked.
+type StoreSearchSymbolDefinitionsFunc struct {
+	defaultHook func(context.Context, string, string, bool, int) ([]shared.SymbolDefinition, error)
+	hooks       []func(context.Context, string, string, bool, int) ([]shared.SymbolDefinition, error)
+	history     []StoreSearchSymbolDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchSymbolDefinitions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) SearchSymbolDefinitions(v0 context.Context, v1 string, v2 string, v3 bool, v4 int) ([]shared.SymbolDefinition, error) {
+	r0, r1 := m.SearchSymbolDefinitionsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.SearchSymbolDefinitionsFunc.appendCall(StoreSearchSymbolDefinitionsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// SearchSymbolDefinitions method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreSearchSymbolDefinitionsFunc) SetDefaultHook(hook func(context.Context, string, string, bool, int) ([]shared.SymbolDefinition, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchSymbolDefinitions method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreSearchSymbolDefinitionsFunc) PushHook(hook func(context.Context, string, string, bool, int) ([]shared.SymbolDefinition, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreSearchSymbolDefinitionsFunc) SetDefaultReturn(r0 []shared.SymbolDefinition, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, bool, int) ([]shared.SymbolDefinition, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreSearchSymbolDefinitionsFunc) PushReturn(r0 []shared.SymbolDefinition, r1 error) {
+	f.PushHook(func(context.Context, string, string, bool, int) ([]shared.SymbolDefinition, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreSearchSymbolDefinitionsFunc) nextHook() func(context.Context, string, string, bool, int) ([]shared.SymbolDefinition, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreSearchSymbolDefinitionsFunc) appendCall(r0 StoreSearchSymbolDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreSearchSymbolDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreSearchSymbolDefinitionsFunc) History() []StoreSearchSymbolDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreSearchSymbolDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreSearchSymbolDefinitionsFuncCall is an object that describes an
+// invocation of method SearchSymbolDefinitions on an instance of MockStore.
+type StoreSearchSymbolDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.SymbolDefinition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreSearchSymbolDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreSearchSymbolDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreSoftDeleteStaleExportedUploadsFunc describes the behavior when the
 // SoftDeleteStaleExportedUploads method of the parent MockStore instance is
 // invoked.
@@ -121,6 +121,17 @@ func (s *Service) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (
 	}, nil
 }
 
+// SearchSymbolDefinitions returns the definitions of the symbols named name, or
+// whose names start with name if prefix is set, in the SCIP indexes that have
+// been exported for ranking. Only definitions in repositories that are visible
+// to the actor of the context are returned.
+func (s *Service) SearchSymbolDefinitions(ctx context.Context, name string, prefix bool, limit int) (_ []shared.SymbolDefinition, err error) {
+	ctx, _, endObservation := s.operations.searchSymbolDefinitions.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.SearchSymbolDefinitions(ctx, internalshared.GraphKey(), name, prefix, limit)
+}
+
 func (s *Service) Summaries(ctx context.Context) ([]shared.Summary, error) {
 	return s.store.Summaries(ctx)
 }
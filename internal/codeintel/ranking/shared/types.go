@@ -27,7 +27,11 @@ type RankingDefinitions struct {
 	UploadID         int
 	ExportedUploadID int
 	SymbolChecksum   [16]byte
-	DocumentPath     string
+	// SymbolName is the name of the last descriptor of the symbol, under which
+	// the definition can be found by SearchSymbolDefinitions. It is empty for
+	// symbols that are not searchable.
+	SymbolName   string
+	DocumentPath string
 }
 
 type RankingReferences struct {
@@ -35,3 +39,13 @@ type RankingReferences struct {
 	ExportedUploadID int
 	SymbolChecksums  [][16]byte
 }
+
+// SymbolDefinition is the location of the definition of a symbol in a SCIP
+// index that was exported for ranking.
+type SymbolDefinition struct {
+	SymbolName   string
+	RepositoryID int
+	Repository   string
+	Commit       string
+	DocumentPath string
+}
@@ -14,4 +14,5 @@ type RankingService interface {
 	NextJobStartsAt(ctx context.Context) (time.Time, bool, error)
 	CoverageCounts(ctx context.Context, graphKey string) (shared.CoverageCounts, error)
 	DeleteRankingProgress(ctx context.Context, graphKey string) error
+	SearchSymbolDefinitions(ctx context.Context, name string, prefix bool, limit int) ([]shared.SymbolDefinition, error)
 }
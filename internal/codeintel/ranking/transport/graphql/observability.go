@@ -8,9 +8,10 @@ import (
 )
 
 type operations struct {
-	rankingSummary         *observation.Operation
-	bumpDerivativeGraphKey *observation.Operation
-	deleteRankingProgress  *observation.Operation
+	rankingSummary           *observation.Operation
+	bumpDerivativeGraphKey   *observation.Operation
+	deleteRankingProgress    *observation.Operation
+	preciseSymbolDefinitions *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -30,8 +31,9 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		rankingSummary:         op("RankingSummary"),
-		bumpDerivativeGraphKey: op("BumpDerivativeGraphKey"),
-		deleteRankingProgress:  op("DeleteRankingProgress"),
+		rankingSummary:           op("RankingSummary"),
+		bumpDerivativeGraphKey:   op("BumpDerivativeGraphKey"),
+		deleteRankingProgress:    op("DeleteRankingProgress"),
+		preciseSymbolDefinitions: op("PreciseSymbolDefinitions"),
 	}
 }
@@ -4,30 +4,37 @@ import (
 	"context"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking"
 	rankingshared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/shared"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	sharedresolvers "github.com/sourcegraph/sourcegraph/internal/codeintel/shared/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/resolvers/gitresolvers"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
 type rootResolver struct {
-	rankingSvc       RankingService
-	siteAdminChecker sharedresolvers.SiteAdminChecker
-	operations       *operations
+	rankingSvc              RankingService
+	siteAdminChecker        sharedresolvers.SiteAdminChecker
+	locationResolverFactory *gitresolvers.CachedLocationResolverFactory
+	subRepoPermsChecker     authz.SubRepoPermissionChecker
+	operations              *operations
 }
 
 func NewRootResolver(
 	observationCtx *observation.Context,
 	rankingSvc *ranking.Service,
 	siteAdminChecker sharedresolvers.SiteAdminChecker,
+	locationResolverFactory *gitresolvers.CachedLocationResolverFactory,
 ) resolverstubs.RankingServiceResolver {
 	return &rootResolver{
-		rankingSvc:       rankingSvc,
-		siteAdminChecker: siteAdminChecker,
-		operations:       newOperations(observationCtx),
+		rankingSvc:              rankingSvc,
+		siteAdminChecker:        siteAdminChecker,
+		locationResolverFactory: locationResolverFactory,
+		subRepoPermsChecker:     authz.DefaultSubRepoPermsChecker,
+		operations:              newOperations(observationCtx),
 	}
 }
 
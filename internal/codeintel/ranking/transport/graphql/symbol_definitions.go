@@ -0,0 +1,115 @@
+package graphql
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxPreciseSymbolDefinitions is the maximum number of definitions returned by
+// PreciseSymbolDefinitions.
+const maxPreciseSymbolDefinitions = 1000
+
+// maxScannedPreciseSymbolDefinitions is the maximum number of definitions read
+// to fill a page of PreciseSymbolDefinitions after filtering.
+const maxScannedPreciseSymbolDefinitions = 4 * maxPreciseSymbolDefinitions
+
+func (r *rootResolver) PreciseSymbolDefinitions(ctx context.Context, args *resolverstubs.PreciseSymbolDefinitionsArgs) (_ []resolverstubs.PreciseSymbolDefinitionResolver, err error) {
+	ctx, _, endObservation := r.operations.preciseSymbolDefinitions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("name", args.Name),
+		attribute.Bool("prefix", args.Prefix),
+		attribute.Int("first", int(args.First)),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if args.First < 0 || args.First > maxPreciseSymbolDefinitions {
+		return nil, errors.Newf("first must be between 0 and %d", maxPreciseSymbolDefinitions)
+	}
+
+	first := int(args.First)
+	resolvers := make([]resolverstubs.PreciseSymbolDefinitionResolver, 0, first)
+	if first == 0 {
+		return resolvers, nil
+	}
+
+	// Definitions in unavailable commits or in paths hidden by sub-repo
+	// permissions are dropped after the query, so we over-fetch until the page
+	// is full or there are no more definitions to consider.
+	pathResolver := r.locationResolverFactory.Create()
+	a := actor.FromContext(ctx)
+	scanned := 0
+	for limit := first; ; limit *= 2 {
+		if limit > maxScannedPreciseSymbolDefinitions {
+			limit = maxScannedPreciseSymbolDefinitions
+		}
+
+		definitions, err := r.rankingSvc.SearchSymbolDefinitions(ctx, args.Name, args.Prefix, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, definition := range definitions[min(scanned, len(definitions)):] {
+			// 🚨 SECURITY: Filter out definitions in paths the actor cannot see.
+			ok, err := authz.FilterActorPath(ctx, r.subRepoPermsChecker, a, api.RepoName(definition.Repository), definition.DocumentPath)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			blob, err := pathResolver.Path(ctx, api.RepoID(definition.RepositoryID), definition.Commit, definition.DocumentPath, false)
+			if err != nil {
+				return nil, err
+			}
+			if blob == nil {
+				// The repository or commit of the index is no longer available
+				continue
+			}
+
+			resolvers = append(resolvers, &preciseSymbolDefinitionResolver{
+				name:     definition.SymbolName,
+				location: &documentLocationResolver{resource: blob},
+			})
+			if len(resolvers) == first {
+				return resolvers, nil
+			}
+		}
+
+		if len(definitions) < limit || limit == maxScannedPreciseSymbolDefinitions {
+			return resolvers, nil
+		}
+		scanned = len(definitions)
+	}
+}
+
+type preciseSymbolDefinitionResolver struct {
+	name     string
+	location resolverstubs.LocationResolver
+}
+
+func (r *preciseSymbolDefinitionResolver) Name() string { return r.name }
+func (r *preciseSymbolDefinitionResolver) Location() resolverstubs.LocationResolver {
+	return r.location
+}
+
+// documentLocationResolver resolves the location of a whole document, as the
+// exported definitions don't include the ranges of the symbols.
+type documentLocationResolver struct {
+	resource resolverstubs.GitTreeEntryResolver
+}
+
+func (r *documentLocationResolver) Resource() resolverstubs.GitTreeEntryResolver { return r.resource }
+func (r *documentLocationResolver) Range() resolverstubs.RangeResolver           { return nil }
+func (r *documentLocationResolver) CanonicalURL() string                         { return r.resource.URL() }
+
+func (r *documentLocationResolver) URL(ctx context.Context) (string, error) {
+	return r.resource.URL(), nil
+}
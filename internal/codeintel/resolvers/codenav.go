@@ -36,6 +36,12 @@ type GitBlobLSIFDataResolver interface {
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 	VisibleIndexes(ctx context.Context) (_ *[]PreciseIndexResolver, err error)
 	Snapshot(ctx context.Context, args *struct{ IndexID graphql.ID }) (_ *[]SnapshotDataResolver, err error)
+	SupportsSemanticHighlighting(ctx context.Context) (bool, error)
+	SemanticHighlighting(ctx context.Context, args *SemanticHighlightingArgs) (*string, error)
+}
+
+type SemanticHighlightingArgs struct {
+	DisableTimeout bool
 }
 
 type SnapshotDataResolver interface {
@@ -53,6 +59,7 @@ type LSIFQueryPositionArgs struct {
 	Line      int32
 	Character int32
 	Filter    *string
+	View      *string
 }
 
 type LSIFPagedQueryPositionArgs struct {
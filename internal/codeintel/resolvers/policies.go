@@ -21,6 +21,7 @@ type PoliciesServiceResolver interface {
 	// Filter previews
 	PreviewRepositoryFilter(ctx context.Context, args *PreviewRepositoryFilterArgs) (RepositoryFilterPreviewResolver, error)
 	PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *PreviewGitObjectFilterArgs) (GitObjectFilterPreviewResolver, error)
+	PreviewCodeIntelligenceConfigurationPolicy(ctx context.Context, args *PreviewCodeIntelligenceConfigurationPolicyArgs) (CodeIntelligenceConfigurationPolicyPreviewResolver, error)
 }
 
 type CodeIntelligenceConfigurationPoliciesArgs struct {
@@ -69,6 +70,12 @@ type PreviewRepositoryFilterArgs struct {
 	Patterns []string
 }
 
+type PreviewCodeIntelligenceConfigurationPolicyArgs struct {
+	ConnectionArgs
+	RepositoryPatterns *[]string
+	PathPatterns       *[]string
+}
+
 type PreviewGitObjectFilterArgs struct {
 	ConnectionArgs
 	Type                         GitObjectType
@@ -105,6 +112,15 @@ type RepositoryFilterPreviewResolver interface {
 	MatchesAllRepos() bool
 }
 
+type CodeIntelligenceConfigurationPolicyPreviewResolver interface {
+	Repositories() []RepositoryResolver
+	TotalMatches() int32
+	MatchesAllRepos() bool
+	Limit() *int32
+	SamplePaths() []string
+	AffectedUploadCount() int32
+}
+
 type GitObjectFilterPreviewResolver interface {
 	Nodes() []CodeIntelGitObjectResolver
 	TotalCount() int32
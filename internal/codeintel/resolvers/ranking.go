@@ -10,12 +10,24 @@ type RankingServiceResolver interface {
 	RankingSummary(ctx context.Context) (GlobalRankingSummaryResolver, error)
 	BumpDerivativeGraphKey(ctx context.Context) (*EmptyResponse, error)
 	DeleteRankingProgress(ctx context.Context, args *DeleteRankingProgressArgs) (*EmptyResponse, error)
+	PreciseSymbolDefinitions(ctx context.Context, args *PreciseSymbolDefinitionsArgs) ([]PreciseSymbolDefinitionResolver, error)
 }
 
 type DeleteRankingProgressArgs struct {
 	GraphKey string
 }
 
+type PreciseSymbolDefinitionsArgs struct {
+	Name   string
+	Prefix bool
+	First  int32
+}
+
+type PreciseSymbolDefinitionResolver interface {
+	Name() string
+	Location() LocationResolver
+}
+
 type GlobalRankingSummaryResolver interface {
 	DerivativeGraphKey() *string
 	RankingSummary() []RankingSummaryResolver
@@ -197,6 +197,10 @@ func (r *Resolver) PreviewRepositoryFilter(ctx context.Context, args *PreviewRep
 	return r.policiesRootResolver.PreviewRepositoryFilter(ctx, args)
 }
 
+func (r *Resolver) PreviewCodeIntelligenceConfigurationPolicy(ctx context.Context, args *PreviewCodeIntelligenceConfigurationPolicyArgs) (_ CodeIntelligenceConfigurationPolicyPreviewResolver, err error) {
+	return r.policiesRootResolver.PreviewCodeIntelligenceConfigurationPolicy(ctx, args)
+}
+
 func (r *Resolver) CodeIntelligenceInferenceScript(ctx context.Context) (_ string, err error) {
 	return r.autoIndexingRootResolver.CodeIntelligenceInferenceScript(ctx)
 }
@@ -220,3 +224,7 @@ func (r *Resolver) BumpDerivativeGraphKey(ctx context.Context) (_ *EmptyResponse
 func (r *Resolver) DeleteRankingProgress(ctx context.Context, args *DeleteRankingProgressArgs) (_ *EmptyResponse, err error) {
 	return r.rankingServiceResolver.DeleteRankingProgress(ctx, args)
 }
+
+func (r *Resolver) PreciseSymbolDefinitions(ctx context.Context, args *PreciseSymbolDefinitionsArgs) (_ []PreciseSymbolDefinitionResolver, err error) {
+	return r.rankingServiceResolver.PreciseSymbolDefinitions(ctx, args)
+}
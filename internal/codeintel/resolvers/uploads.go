@@ -62,6 +62,23 @@ type ReindexPreciseIndexesArgs struct {
 type CodeIntelligenceCommitGraphResolver interface {
 	Stale() bool
 	UpdatedAt() *gqlutil.DateTime
+	VisibilityLogs(ctx context.Context, args *VisibilityLogsArgs) (CodeIntelligenceVisibilityLogConnectionResolver, error)
+}
+
+type VisibilityLogsArgs struct {
+	PagedConnectionArgs
+	Upload *graphql.ID
+}
+
+type CodeIntelligenceVisibilityLogConnectionResolver = PagedConnectionWithTotalCountResolver[CodeIntelligenceVisibilityLogResolver]
+
+type CodeIntelligenceVisibilityLogResolver interface {
+	LogTimestamp() gqlutil.DateTime
+	Operation() string
+	UploadID() *graphql.ID
+	BranchOrTagName() *string
+	IsDefaultBranch() *bool
+	Reason() string
 }
 
 type (
@@ -3,6 +3,7 @@ package stores
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/sourcegraph/log"
 
@@ -18,8 +19,24 @@ type CodeIntelDB interface {
 	Done(error) error
 }
 
+// NewCodeIntelDB returns a handle to the codeintel-db. Slow queries issued through
+// the returned handle are recorded; see GetSlowQueries.
 func NewCodeIntelDB(logger log.Logger, inner *sql.DB) CodeIntelDB {
-	return &codeIntelDB{basestore.NewWithHandle(basestore.NewHandleWithDB(logger, inner, sql.TxOptions{}))}
+	handle := basestore.NewHandleWithDB(logger, inner, sql.TxOptions{})
+	return &codeIntelDB{basestore.NewWithHandle(newSlowQueryHandle(handle))}
+}
+
+// NewCodeIntelDBWithReplica returns a handle to the codeintel-db which sends read-only
+// queries opted in with basestore.WithReadReplicaPreferred to the given replica, as
+// long as it lags at most maxLag behind the primary. If replica is nil, this is
+// equivalent to NewCodeIntelDB.
+func NewCodeIntelDBWithReplica(logger log.Logger, inner, replica *sql.DB, maxLag time.Duration) CodeIntelDB {
+	if replica == nil {
+		return NewCodeIntelDB(logger, inner)
+	}
+
+	handle := basestore.NewHandleWithReplica(logger, inner, replica, sql.TxOptions{}, maxLag)
+	return &codeIntelDB{basestore.NewWithHandle(newSlowQueryHandle(handle))}
 }
 
 func NewCodeIntelDBWith(other basestore.ShareableStore) CodeIntelDB {
@@ -23,10 +23,15 @@ type Config struct {
 	S3AccessKeyID     string
 	S3SecretAccessKey string
 	S3SessionToken    string
+	S3KMSKeyID        string
+	S3PartSizeMB      int
+	S3Concurrency     int
 
 	GCSProjectID               string
 	GCSCredentialsFile         string
 	GCSCredentialsFileContents string
+	GCSKMSKeyName              string
+	GCSChunkSizeMB             int
 }
 
 func (c *Config) Load() {
@@ -50,9 +55,28 @@ func (c *Config) Load() {
 			c.S3SecretAccessKey = c.Get("PRECISE_CODE_INTEL_UPLOAD_AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY", "An AWS secret key associated with a user with access to S3.")
 			c.S3SessionToken = c.GetOptional("PRECISE_CODE_INTEL_UPLOAD_AWS_SESSION_TOKEN", "An optional AWS session token associated with a user with access to S3.")
 		}
+
+		if c.Backend == "s3" {
+			c.S3KMSKeyID = c.GetOptional("PRECISE_CODE_INTEL_UPLOAD_AWS_KMS_KEY_ID", "The ID or ARN of an AWS KMS key used to encrypt uploads (SSE-KMS).")
+		}
+		c.S3PartSizeMB = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_AWS_PART_SIZE_MB", "5", "The size in MiB of each part of a multipart upload.")
+		c.S3Concurrency = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_AWS_CONCURRENCY", "5", "The number of parts of a single upload sent in parallel.")
+
+		if c.S3PartSizeMB < 5 {
+			c.AddError(errors.Errorf("invalid value %d for PRECISE_CODE_INTEL_UPLOAD_AWS_PART_SIZE_MB: must be at least 5", c.S3PartSizeMB))
+		}
+		if c.S3Concurrency < 1 {
+			c.AddError(errors.Errorf("invalid value %d for PRECISE_CODE_INTEL_UPLOAD_AWS_CONCURRENCY: must be at least 1", c.S3Concurrency))
+		}
 	} else if c.Backend == "gcs" {
 		c.GCSProjectID = c.Get("PRECISE_CODE_INTEL_UPLOAD_GCP_PROJECT_ID", "", "The project containing the GCS bucket.")
 		c.GCSCredentialsFile = c.GetOptional("PRECISE_CODE_INTEL_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE", "The path to a service account key file with access to GCS.")
 		c.GCSCredentialsFileContents = c.GetOptional("PRECISE_CODE_INTEL_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE_CONTENT", "The contents of a service account key file with access to GCS.")
+		c.GCSKMSKeyName = c.GetOptional("PRECISE_CODE_INTEL_UPLOAD_GCP_KMS_KEY_NAME", "The resource name of a Cloud KMS key used to encrypt uploads (CMEK).")
+		c.GCSChunkSizeMB = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_GCP_CHUNK_SIZE_MB", "16", "The size in MiB of each chunk of a resumable upload.")
+
+		if c.GCSChunkSizeMB < 1 {
+			c.AddError(errors.Errorf("invalid value %d for PRECISE_CODE_INTEL_UPLOAD_GCP_CHUNK_SIZE_MB: must be at least 1", c.GCSChunkSizeMB))
+		}
 	}
 }
@@ -43,6 +43,9 @@ func TestConfigS3(t *testing.T) {
 		"PRECISE_CODE_INTEL_UPLOAD_AWS_ACCESS_KEY_ID":     "access-key-id",
 		"PRECISE_CODE_INTEL_UPLOAD_AWS_SECRET_ACCESS_KEY": "secret-access-key",
 		"PRECISE_CODE_INTEL_UPLOAD_AWS_SESSION_TOKEN":     "session-token",
+		"PRECISE_CODE_INTEL_UPLOAD_AWS_KMS_KEY_ID":        "kms-key-id",
+		"PRECISE_CODE_INTEL_UPLOAD_AWS_PART_SIZE_MB":      "64",
+		"PRECISE_CODE_INTEL_UPLOAD_AWS_CONCURRENCY":       "10",
 	}
 
 	config := Config{}
@@ -68,6 +71,30 @@ func TestConfigS3(t *testing.T) {
 	if config.S3SessionToken != "session-token" {
 		t.Errorf("unexpected value for S3.SessionToken. want=%s have=%s", "session-token", config.S3SessionToken)
 	}
+	if config.S3KMSKeyID != "kms-key-id" {
+		t.Errorf("unexpected value for S3.KMSKeyID. want=%s have=%s", "kms-key-id", config.S3KMSKeyID)
+	}
+	if config.S3PartSizeMB != 64 {
+		t.Errorf("unexpected value for S3.PartSizeMB. want=%d have=%d", 64, config.S3PartSizeMB)
+	}
+	if config.S3Concurrency != 10 {
+		t.Errorf("unexpected value for S3.Concurrency. want=%d have=%d", 10, config.S3Concurrency)
+	}
+}
+
+func TestConfigS3InvalidPartSize(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":          "S3",
+		"PRECISE_CODE_INTEL_UPLOAD_AWS_PART_SIZE_MB": "1",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err == nil {
+		t.Fatalf("expected validation error")
+	}
 }
 
 func TestConfigGCS(t *testing.T) {
@@ -79,6 +106,7 @@ func TestConfigGCS(t *testing.T) {
 		"PRECISE_CODE_INTEL_UPLOAD_GCP_PROJECT_ID":                              "test-project-id",
 		"PRECISE_CODE_INTEL_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE":         "test-credentials-file",
 		"PRECISE_CODE_INTEL_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE_CONTENT": "test-credentials-file-contents",
+		"PRECISE_CODE_INTEL_UPLOAD_GCP_KMS_KEY_NAME":                            "test-kms-key-name",
 	}
 
 	config := Config{}
@@ -104,6 +132,12 @@ func TestConfigGCS(t *testing.T) {
 	if config.GCSCredentialsFileContents != "test-credentials-file-contents" {
 		t.Errorf("unexpected value for GCS.CredentialsFileContents. want=%s have=%s", "test-credentials-file-contents", config.GCSCredentialsFileContents)
 	}
+	if config.GCSKMSKeyName != "test-kms-key-name" {
+		t.Errorf("unexpected value for GCS.KMSKeyName. want=%s have=%s", "test-kms-key-name", config.GCSKMSKeyName)
+	}
+	if config.GCSChunkSizeMB != 16 {
+		t.Errorf("unexpected value for GCS.ChunkSizeMB. want=%d have=%d", 16, config.GCSChunkSizeMB)
+	}
 }
 
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
@@ -20,11 +20,16 @@ func New(ctx context.Context, observationCtx *observation.Context, conf *Config)
 			AccessKeyID:     conf.S3AccessKeyID,
 			SecretAccessKey: conf.S3SecretAccessKey,
 			SessionToken:    conf.S3SessionToken,
+			KMSKeyID:        conf.S3KMSKeyID,
+			PartSize:        int64(conf.S3PartSizeMB) << 20,
+			Concurrency:     conf.S3Concurrency,
 		},
 		GCS: uploadstore.GCSConfig{
 			ProjectID:               conf.GCSProjectID,
 			CredentialsFile:         conf.GCSCredentialsFile,
 			CredentialsFileContents: conf.GCSCredentialsFileContents,
+			KMSKeyName:              conf.GCSKMSKeyName,
+			ChunkSize:               conf.GCSChunkSizeMB << 20,
 		},
 	}
 
@@ -0,0 +1,174 @@
+package stores
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SlowQuery is a statement issued against the codeintel-db that took at least
+// as long as the threshold configured in "codeIntelSlowQueries.thresholdMs".
+type SlowQuery struct {
+	ID        string        `json:"id"`
+	Query     string        `json:"query"`
+	Operation string        `json:"operation,omitempty"`
+	StartedAt time.Time     `json:"startedAt"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+const (
+	defaultSlowQueryLimit = 100
+	maxSlowQueryLength    = 4096
+)
+
+// slowQueriesFIFOList is a FIFO redis cache storing the most recent slow queries
+// of all services connected to the codeintel-db.
+var slowQueriesFIFOList = rcache.NewFIFOListDynamic("codeintel-slow-queries", func() int {
+	if limit := conf.Get().CodeIntelSlowQueriesLimit; limit > 0 {
+		return limit
+	}
+	return defaultSlowQueryLimit
+})
+
+// GetSlowQueries returns the recorded slow queries, most recent first.
+func GetSlowQueries(ctx context.Context) ([]*SlowQuery, error) {
+	rawItems, err := slowQueriesFIFOList.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "list all slow queries")
+	}
+
+	queries := make([]*SlowQuery, 0, len(rawItems))
+	for _, rawItem := range rawItems {
+		var query SlowQuery
+		if err := json.Unmarshal(rawItem, &query); err != nil {
+			return nil, err
+		}
+		queries = append(queries, &query)
+	}
+
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].ID > queries[j].ID
+	})
+
+	return queries, nil
+}
+
+func slowQueryThreshold() time.Duration {
+	return time.Duration(conf.Get().CodeIntelSlowQueriesThresholdMs) * time.Millisecond
+}
+
+func recordSlowQuery(query SlowQuery) {
+	item, err := json.Marshal(query)
+	if err != nil {
+		log.Scoped("codeintel.slowQueries").Error("marshal slow query", log.Error(err))
+		return
+	}
+
+	go func() {
+		if err := slowQueriesFIFOList.Insert(item); err != nil {
+			// Log would get upset if we created a logger at init time → create logger on the fly
+			log.Scoped("codeintel.slowQueries").Error("insert slow query", log.Error(err))
+		}
+	}()
+}
+
+// slowQueryHandle wraps a handle to the codeintel-db and records statements
+// that exceed the configured threshold. For QueryContext, the duration covers
+// the time until the first row is available, not the time taken to read the
+// entire result set.
+type slowQueryHandle struct {
+	basestore.TransactableHandle
+
+	threshold func() time.Duration
+	record    func(SlowQuery)
+}
+
+func newSlowQueryHandle(handle basestore.TransactableHandle) *slowQueryHandle {
+	return &slowQueryHandle{
+		TransactableHandle: handle,
+		threshold:          slowQueryThreshold,
+		record:             recordSlowQuery,
+	}
+}
+
+func (h *slowQueryHandle) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := h.TransactableHandle.QueryContext(ctx, query, args...)
+	h.observe(ctx, query, start, err)
+	return rows, err
+}
+
+func (h *slowQueryHandle) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	res, err := h.TransactableHandle.ExecContext(ctx, query, args...)
+	h.observe(ctx, query, start, err)
+	return res, err
+}
+
+func (h *slowQueryHandle) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := h.TransactableHandle.QueryRowContext(ctx, query, args...)
+	h.observe(ctx, query, start, row.Err())
+	return row
+}
+
+func (h *slowQueryHandle) Transact(ctx context.Context) (basestore.TransactableHandle, error) {
+	tx, err := h.TransactableHandle.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &slowQueryHandle{TransactableHandle: tx, threshold: h.threshold, record: h.record}, nil
+}
+
+func (h *slowQueryHandle) observe(ctx context.Context, query string, start time.Time, err error) {
+	duration := time.Since(start)
+	if threshold := h.threshold(); threshold <= 0 || duration < threshold {
+		return
+	}
+
+	var errorMessage string
+	if err != nil {
+		errorMessage = err.Error()
+	}
+
+	h.record(SlowQuery{
+		ID:        start.UTC().Format("2006-01-02T15_04_05.999999999"),
+		Query:     normalizeQuery(query),
+		Operation: observation.OperationName(ctx),
+		StartedAt: start,
+		Duration:  duration,
+		Error:     errorMessage,
+	})
+}
+
+// normalizeQuery strips comment lines and collapses whitespace so that the same
+// statement is always recorded identically. Arguments are never part of the
+// statement text, so no values are recorded. Long statements (e.g. batch
+// inserts) are truncated.
+func normalizeQuery(query string) string {
+	lines := strings.Split(query, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			kept = append(kept, line)
+		}
+	}
+
+	normalized := strings.Join(strings.Fields(strings.Join(kept, " ")), " ")
+	if len(normalized) > maxSlowQueryLength {
+		normalized = normalized[:maxSlowQueryLength] + "..."
+	}
+	return normalized
+}
@@ -0,0 +1,104 @@
+package stores
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// fakeHandle is a handle whose statements take the given time to execute.
+type fakeHandle struct {
+	basestore.TransactableHandle
+	latency time.Duration
+	err     error
+}
+
+func (h *fakeHandle) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	time.Sleep(h.latency)
+	return nil, h.err
+}
+
+func (h *fakeHandle) Transact(context.Context) (basestore.TransactableHandle, error) {
+	return &fakeHandle{latency: h.latency, err: h.err}, nil
+}
+
+func TestSlowQueryHandle(t *testing.T) {
+	const query = `
+		-- source: internal/codeintel/uploads/internal/store/store.go:DeleteUploads
+		DELETE FROM lsif_uploads
+		WHERE   id = %s
+	`
+
+	newHandle := func(latency, threshold time.Duration, err error) (*slowQueryHandle, *[]SlowQuery) {
+		var recorded []SlowQuery
+		return &slowQueryHandle{
+			TransactableHandle: &fakeHandle{latency: latency, err: err},
+			threshold:          func() time.Duration { return threshold },
+			record:             func(q SlowQuery) { recorded = append(recorded, q) },
+		}, &recorded
+	}
+
+	op := observation.TestContextTB(t).Operation(observation.Op{Name: "uploads.DeleteUploads"})
+	ctx, _, endObservation := op.With(context.Background(), nil, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	t.Run("disabled", func(t *testing.T) {
+		handle, recorded := newHandle(5*time.Millisecond, 0, nil)
+		_, err := handle.ExecContext(ctx, query)
+		require.NoError(t, err)
+		assert.Empty(t, *recorded)
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		handle, recorded := newHandle(0, time.Hour, nil)
+		_, err := handle.ExecContext(ctx, query)
+		require.NoError(t, err)
+		assert.Empty(t, *recorded)
+	})
+
+	t.Run("above threshold", func(t *testing.T) {
+		handle, recorded := newHandle(5*time.Millisecond, time.Millisecond, errors.New("canceling statement"))
+		_, err := handle.ExecContext(ctx, query)
+		require.Error(t, err)
+
+		require.Len(t, *recorded, 1)
+		got := (*recorded)[0]
+		assert.Equal(t, "DELETE FROM lsif_uploads WHERE id = %s", got.Query)
+		assert.Equal(t, "uploads.DeleteUploads", got.Operation)
+		assert.Equal(t, "canceling statement", got.Error)
+		assert.GreaterOrEqual(t, got.Duration, 5*time.Millisecond)
+		assert.NotEmpty(t, got.ID)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		handle, recorded := newHandle(5*time.Millisecond, time.Millisecond, nil)
+		tx, err := handle.Transact(ctx)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(context.Background(), query)
+		require.NoError(t, err)
+
+		require.Len(t, *recorded, 1)
+		assert.Empty(t, (*recorded)[0].Operation)
+	})
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	long := "SELECT " + strings.Repeat("x, ", maxSlowQueryLength)
+	for input, want := range map[string]string{
+		"SELECT 1":                          "SELECT 1",
+		"  SELECT\n\t*\n  FROM   t  ":       "SELECT * FROM t",
+		"-- source: a.go\nSELECT 1\n-- end": "SELECT 1",
+		long:                                long[:maxSlowQueryLength] + "...",
+	} {
+		assert.Equal(t, want, normalizeQuery(input))
+	}
+}
@@ -64,11 +64,17 @@ func NewUploadProcessorJob(
 	workerBudget int64,
 	workerPollInterval time.Duration,
 	maximumRuntimePerJob time.Duration,
+	documentConcurrency int,
+	documentMemoryBudget int64,
+	documentsPerCheckpoint int,
 ) []goroutine.BackgroundRoutine {
 	ProcessorConfigInst.WorkerConcurrency = workerConcurrency
 	ProcessorConfigInst.WorkerBudget = workerBudget
 	ProcessorConfigInst.WorkerPollInterval = workerPollInterval
 	ProcessorConfigInst.MaximumRuntimePerJob = maximumRuntimePerJob
+	ProcessorConfigInst.DocumentConcurrency = documentConcurrency
+	ProcessorConfigInst.DocumentMemoryBudget = documentMemoryBudget
+	ProcessorConfigInst.DocumentsPerCheckpoint = documentsPerCheckpoint
 
 	return background.NewUploadProcessorJob(
 		scopedContext("processor", observationCtx),
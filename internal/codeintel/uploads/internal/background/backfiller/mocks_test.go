@@ -124,6 +124,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetUploadIDsWithReferences.
 	GetUploadIDsWithReferencesFunc *StoreGetUploadIDsWithReferencesFunc
+	// GetUploadVisibilityLogsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadVisibilityLogs.
+	GetUploadVisibilityLogsFunc *StoreGetUploadVisibilityLogsFunc
 	// GetUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUploads.
 	GetUploadsFunc *StoreGetUploadsFunc
@@ -157,6 +160,10 @@ type MockStore struct {
 	// InsertUploadFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertUpload.
 	InsertUploadFunc *StoreInsertUploadFunc
+	// InsertUploadVisibilityLogFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// InsertUploadVisibilityLog.
+	InsertUploadVisibilityLogFunc *StoreInsertUploadVisibilityLogFunc
 	// MarkFailedFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkFailed.
 	MarkFailedFunc *StoreMarkFailedFunc
@@ -391,6 +398,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUploadVisibilityLogsFunc: &StoreGetUploadVisibilityLogsFunc{
+			defaultHook: func(context.Context, shared.GetUploadVisibilityLogsOptions) (r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+				return
+			},
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) (r0 []shared.Upload, r1 int, r2 error) {
 				return
@@ -441,6 +453,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertUploadVisibilityLogFunc: &StoreInsertUploadVisibilityLogFunc{
+			defaultHook: func(context.Context, int, string, string) (r0 error) {
+				return
+			},
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: func(context.Context, int, string) (r0 error) {
 				return
@@ -718,6 +735,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUploadIDsWithReferences")
 			},
 		},
+		GetUploadVisibilityLogsFunc: &StoreGetUploadVisibilityLogsFunc{
+			defaultHook: func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+				panic("unexpected invocation of MockStore.GetUploadVisibilityLogs")
+			},
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) ([]shared.Upload, int, error) {
 				panic("unexpected invocation of MockStore.GetUploads")
@@ -768,6 +790,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertUpload")
 			},
 		},
+		InsertUploadVisibilityLogFunc: &StoreInsertUploadVisibilityLogFunc{
+			defaultHook: func(context.Context, int, string, string) error {
+				panic("unexpected invocation of MockStore.InsertUploadVisibilityLog")
+			},
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: func(context.Context, int, string) error {
 				panic("unexpected invocation of MockStore.MarkFailed")
@@ -985,6 +1012,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetUploadIDsWithReferencesFunc: &StoreGetUploadIDsWithReferencesFunc{
 			defaultHook: i.GetUploadIDsWithReferences,
 		},
+		GetUploadVisibilityLogsFunc: &StoreGetUploadVisibilityLogsFunc{
+			defaultHook: i.GetUploadVisibilityLogs,
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: i.GetUploads,
 		},
@@ -1015,6 +1045,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: i.InsertUpload,
 		},
+		InsertUploadVisibilityLogFunc: &StoreInsertUploadVisibilityLogFunc{
+			defaultHook: i.InsertUploadVisibilityLog,
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: i.MarkFailed,
 		},
@@ -4456,6 +4489,118 @@ func (c StoreGetUploadIDsWithReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
+// StoreGetUploadVisibilityLogsFunc describes the behavior when the
+// GetUploadVisibilityLogs method of the parent MockStore instance is
+// invoked.
+type StoreGetUploadVisibilityLogsFunc struct {
+	defaultHook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)
+	hooks       []func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)
+	history     []StoreGetUploadVisibilityLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadVisibilityLogs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadVisibilityLogs(v0 context.Context, v1 shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+	r0, r1, r2 := m.GetUploadVisibilityLogsFunc.nextHook()(v0, v1)
+	m.GetUploadVisibilityLogsFunc.appendCall(StoreGetUploadVisibilityLogsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadVisibilityLogs method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUploadVisibilityLogsFunc) SetDefaultHook(hook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadVisibilityLogs method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetUploadVisibilityLogsFunc) PushHook(hook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadVisibilityLogsFunc) SetDefaultReturn(r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadVisibilityLogsFunc) PushReturn(r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+	f.PushHook(func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetUploadVisibilityLogsFunc) nextHook() func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadVisibilityLogsFunc) appendCall(r0 StoreGetUploadVisibilityLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadVisibilityLogsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUploadVisibilityLogsFunc) History() []StoreGetUploadVisibilityLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadVisibilityLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadVisibilityLogsFuncCall is an object that describes an
+// invocation of method GetUploadVisibilityLogs on an instance of MockStore.
+type StoreGetUploadVisibilityLogsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared.GetUploadVisibilityLogsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.UploadVisibilityLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadVisibilityLogsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadVisibilityLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetUploadsFunc describes the behavior when the GetUploads method of
 // the parent MockStore instance is invoked.
 type StoreGetUploadsFunc struct {
@@ -5572,6 +5717,119 @@ func (c StoreInsertUploadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreInsertUploadVisibilityLogFunc describes the behavior when the
+// InsertUploadVisibilityLog method of the parent MockStore instance is
+// invoked.
+type StoreInsertUploadVisibilityLogFunc struct {
+	defaultHook func(context.Context, int, string, string) error
+	hooks       []func(context.Context, int, string, string) error
+	history     []StoreInsertUploadVisibilityLogFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertUploadVisibilityLog delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertUploadVisibilityLog(v0 context.Context, v1 int, v2 string, v3 string) error {
+	r0 := m.InsertUploadVisibilityLogFunc.nextHook()(v0, v1, v2, v3)
+	m.InsertUploadVisibilityLogFunc.appendCall(StoreInsertUploadVisibilityLogFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertUploadVisibilityLog method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertUploadVisibilityLogFunc) SetDefaultHook(hook func(context.Context, int, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertUploadVisibilityLog method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreInsertUploadVisibilityLogFunc) PushHook(hook func(context.Context, int, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertUploadVisibilityLogFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertUploadVisibilityLogFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string, string) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertUploadVisibilityLogFunc) nextHook() func(context.Context, int, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertUploadVisibilityLogFunc) appendCall(r0 StoreInsertUploadVisibilityLogFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertUploadVisibilityLogFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertUploadVisibilityLogFunc) History() []StoreInsertUploadVisibilityLogFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertUploadVisibilityLogFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertUploadVisibilityLogFuncCall is an object that describes an
+// invocation of method InsertUploadVisibilityLog on an instance of
+// MockStore.
+type StoreInsertUploadVisibilityLogFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertUploadVisibilityLogFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertUploadVisibilityLogFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreMarkFailedFunc describes the behavior when the MarkFailed method of
 // the parent MockStore instance is invoked.
 type StoreMarkFailedFunc struct {
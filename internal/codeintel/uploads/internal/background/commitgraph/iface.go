@@ -2,10 +2,16 @@ package commitgraph
 
 import (
 	"context"
+	"time"
 
-	"github.com/sourcegraph/sourcegraph/internal/database/locker"
+	dblocker "github.com/sourcegraph/sourcegraph/internal/database/locker"
+	"github.com/sourcegraph/sourcegraph/internal/locker"
 )
 
 type Locker interface {
-	Lock(ctx context.Context, key int32, blocking bool) (bool, locker.UnlockFunc, error)
+	Do(ctx context.Context, name string, ttl time.Duration, f func(ctx context.Context, lease *locker.Lease) error) (bool, error)
+}
+
+type AdvisoryLocker interface {
+	Lock(ctx context.Context, key int32, blocking bool) (bool, dblocker.UnlockFunc, error)
 }
@@ -2,15 +2,18 @@ package commitgraph
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/store"
-	"github.com/sourcegraph/sourcegraph/internal/database/locker"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	dblocker "github.com/sourcegraph/sourcegraph/internal/database/locker"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/locker"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -21,7 +24,8 @@ func NewCommitGraphUpdater(
 ) goroutine.BackgroundRoutine {
 	updater := &commitGraphUpdater{
 		store:           store,
-		locker:          locker.NewWith(store.Handle(), "codeintel"),
+		locker:          locker.NewWith(store.Handle()),
+		advisoryLocker:  dblocker.NewWith(store.Handle(), "codeintel"),
 		gitserverClient: gitserverClient,
 	}
 
@@ -39,6 +43,7 @@ func NewCommitGraphUpdater(
 type commitGraphUpdater struct {
 	store           store.Store
 	locker          Locker
+	advisoryLocker  AdvisoryLocker
 	gitserverClient gitserver.Client
 }
 
@@ -74,17 +79,42 @@ func (s *commitGraphUpdater) UpdateAllDirtyCommitGraphs(ctx context.Context, max
 	return updateErr
 }
 
-// lockAndUpdateUploadsVisibleToCommits will call UpdateUploadsVisibleToCommits while holding an advisory lock to give exclusive access to the
+// leaseTTL is the duration of the lease on the commit graph of a repository. The lease is
+// renewed while the commit graph is being updated.
+const leaseTTL = time.Minute
+
+// lockAndUpdateUploadsVisibleToCommits will call UpdateUploadsVisibleToCommits while holding a lease to give exclusive access to the
 // update procedure for this repository. If the lock is already held, this method will simply do nothing.
-func (s *commitGraphUpdater) lockAndUpdateUploadsVisibleToCommits(ctx context.Context, repositoryID int, repositoryName string, dirtyToken int, maxAgeForNonStaleBranches time.Duration, maxAgeForNonStaleTags time.Duration) (err error) {
-	ok, unlock, err := s.locker.Lock(ctx, int32(repositoryID), false)
-	if err != nil || !ok {
-		return errors.Wrap(err, "locker.Lock")
-	}
-	defer func() {
-		err = unlock(err)
-	}()
+func (s *commitGraphUpdater) lockAndUpdateUploadsVisibleToCommits(ctx context.Context, repositoryID int, repositoryName string, dirtyToken int, maxAgeForNonStaleBranches time.Duration, maxAgeForNonStaleTags time.Duration) error {
+	_, err := s.locker.Do(ctx, "codeintel.commitgraph:"+strconv.Itoa(repositoryID), leaseTTL, func(ctx context.Context, lease *locker.Lease) (err error) {
+		// Instances running the previous release only take the advisory lock on the repository,
+		// so it is held as well while both kinds of instances can run side by side.
+		// TODO: Remove the advisory lock once no instance of the previous release can be running.
+		ok, unlock, err := s.advisoryLocker.Lock(ctx, int32(repositoryID), false)
+		if err != nil || !ok {
+			return errors.Wrap(err, "locker.Lock")
+		}
+		defer func() {
+			err = unlock(err)
+		}()
+
+		if err := s.updateUploadsVisibleToCommits(ctx, lease, repositoryID, repositoryName, dirtyToken, maxAgeForNonStaleBranches, maxAgeForNonStaleTags); err != nil {
+			// Record the failure outside of the update transaction so that it survives the rollback
+			if ctx.Err() == nil {
+				if logErr := s.store.InsertUploadVisibilityLog(ctx, repositoryID, shared.UploadVisibilityOperationCommitGraphUpdateFailed, err.Error()); logErr != nil {
+					return errors.Append(err, logErr)
+				}
+			}
+
+			return err
+		}
 
+		return nil
+	})
+	return errors.Wrap(err, "locker.Do")
+}
+
+func (s *commitGraphUpdater) updateUploadsVisibleToCommits(ctx context.Context, lease *locker.Lease, repositoryID int, repositoryName string, dirtyToken int, maxAgeForNonStaleBranches time.Duration, maxAgeForNonStaleTags time.Duration) error {
 	repo := api.RepoName(repositoryName)
 
 	// The following process pulls the commit graph for the given repository from gitserver, pulls the set of LSIF
@@ -108,12 +138,16 @@ func (s *commitGraphUpdater) lockAndUpdateUploadsVisibleToCommits(ctx context.Co
 
 	// Decorate the commit graph with the set of processed uploads are visible from each commit,
 	// then bulk update the denormalized view in Postgres. We call this with an empty graph as well
-	// so that we end up clearing the stale data and bulk inserting nothing.
-	if err := s.store.UpdateUploadsVisibleToCommits(ctx, repositoryID, commitGraph, refDescriptions, maxAgeForNonStaleBranches, maxAgeForNonStaleTags, dirtyToken, time.Time{}); err != nil {
-		return errors.Wrap(err, "uploadSvc.UpdateUploadsVisibleToCommits")
-	}
+	// so that we end up clearing the stale data and bulk inserting nothing. The lease is fenced
+	// at the end of the same transaction so that a stale graph never overwrites the one computed
+	// by the next holder of the lease, without blocking renewals of the lease during the update.
+	return s.store.WithTransaction(ctx, func(tx store.Store) error {
+		if err := tx.UpdateUploadsVisibleToCommits(ctx, repositoryID, commitGraph, refDescriptions, maxAgeForNonStaleBranches, maxAgeForNonStaleTags, dirtyToken, time.Time{}); err != nil {
+			return errors.Wrap(err, "uploadSvc.UpdateUploadsVisibleToCommits")
+		}
 
-	return nil
+		return lease.Fence(ctx, tx.Handle())
+	})
 }
 
 // getCommitGraph builds a partial commit graph that includes the most recent commits on each branch
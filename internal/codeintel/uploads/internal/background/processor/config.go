@@ -10,4 +10,8 @@ type Config struct {
 	WorkerBudget         int64
 	WorkerPollInterval   time.Duration
 	MaximumRuntimePerJob time.Duration
+
+	DocumentConcurrency    int
+	DocumentMemoryBudget   int64
+	DocumentsPerCheckpoint int
 }
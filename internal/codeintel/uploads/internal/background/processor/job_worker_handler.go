@@ -54,6 +54,9 @@ func NewUploadProcessorWorker(
 		workerStore,
 		uploadStore,
 		config.WorkerBudget,
+		config.DocumentConcurrency,
+		config.DocumentMemoryBudget,
+		config.DocumentsPerCheckpoint,
 	)
 
 	metrics := workerutil.NewMetrics(observationCtx, "codeintel_upload_processor", workerutil.WithSampler(func(job workerutil.Record) bool { return true }))
@@ -80,6 +83,10 @@ type handler struct {
 	budgetRemaining int64
 	enableBudget    bool
 	uploadSizeGauge prometheus.Gauge
+
+	// Bounds on the resources used to process the documents of a single upload
+	documentOptions        documentProcessingOptions
+	documentsPerCheckpoint int
 }
 
 var (
@@ -97,6 +104,9 @@ func NewUploadProcessorHandler(
 	workerStore dbworkerstore.Store[uploadsshared.Upload],
 	uploadStore uploadstore.Store,
 	budgetMax int64,
+	documentConcurrency int,
+	documentMemoryBudget int64,
+	documentsPerCheckpoint int,
 ) workerutil.Handler[uploadsshared.Upload] {
 	operations := newWorkerOperations(observationCtx)
 
@@ -111,6 +121,11 @@ func NewUploadProcessorHandler(
 		budgetRemaining: budgetMax,
 		enableBudget:    budgetMax > 0,
 		uploadSizeGauge: operations.uploadSizeGauge,
+		documentOptions: documentProcessingOptions{
+			concurrency:  documentConcurrency,
+			memoryBudget: documentMemoryBudget,
+		},
+		documentsPerCheckpoint: documentsPerCheckpoint,
 	}
 }
 
@@ -267,20 +282,21 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 			return errors.Wrap(err, "store.CommitDate")
 		}
 
-		scipDataStream, err := prepareSCIPDataStream(ctx, indexReader, upload.Root, getChildren)
+		scipDataStream, err := prepareSCIPDataStream(ctx, indexReader, upload.Root, getChildren, h.documentOptions)
 		if err != nil {
 			return errors.Wrap(err, "prepareSCIPDataStream")
 		}
 
 		// Note: this is writing to a different database than the block below, so we need to use a
-		// different transaction context (managed by the writeData function).
-		pkgData, err := writeSCIPDocuments(ctx, logger, h.lsifStore, upload, scipDataStream, trace)
+		// different transaction context (managed by the writeData function). Documents committed
+		// by a previous attempt to process this upload are not written again.
+		pkgData, err := writeSCIPDocuments(ctx, logger, h.lsifStore, upload, scipDataStream, h.documentsPerCheckpoint, trace)
 		if err != nil {
 			if isUniqueConstraintViolation(err) {
 				// If this is a unique constraint violation, then we've previously processed this same
-				// upload record up to this point, but failed to perform the transaction below. We can
-				// safely assume that the entire index's data is in the codeintel database, as it's
-				// parsed deterministically and written atomically.
+				// upload record up to this point without recording a processing checkpoint, but failed
+				// to perform the transaction below. We can safely assume that the entire index's data
+				// is in the codeintel database, as it's parsed deterministically and written atomically.
 				logger.Warn("SCIP data already exists for upload record")
 				trace.AddEvent("TODO Domain Owner", attribute.Bool("rewriting", true))
 			} else {
@@ -292,7 +308,7 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 		// point fails, we want to update the upload record with an error message but do not want to
 		// alter any other data in the database. Rolling back to this savepoint will allow us to discard
 		// any other changes but still commit the transaction as a whole.
-		return inTransaction(ctx, h.store, func(tx store.Store) error {
+		if err := inTransaction(ctx, h.store, func(tx store.Store) error {
 			// Before we mark the upload as complete, we need to delete any existing completed uploads
 			// that have the same repository_id, commit, root, and indexer values. Otherwise, the transaction
 			// will fail as these values form a unique constraint.
@@ -328,7 +344,17 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 			}
 
 			return nil
-		})
+		}); err != nil {
+			return err
+		}
+
+		// The upload has been processed completely, so it will not be resumed. Stale checkpoints
+		// are otherwise removed along with the upload's data.
+		if err := h.lsifStore.DeleteProcessingCheckpoint(ctx, upload.ID); err != nil {
+			logger.Warn("Failed to delete processing checkpoint", log.Int("uploadID", upload.ID), log.Error(err))
+		}
+
+		return nil
 	})
 }
 
@@ -428,12 +454,26 @@ func withUploadData(ctx context.Context, logger log.Logger, uploadStore uploadst
 		// a temporary file, to allow processing in multiple passes.
 		shouldWriteToDisk := uploadStats.UncompressedSize != nil && *uploadStats.UncompressedSize > uncompressedSizeLimitBytes
 
+		// The factor of 5 is based on ~worst-case gzip compression ratio.
+		// See NOTE(scip-index-size-stats).
+		compressedSizeLimitBytes := uncompressedSizeLimitBytes / 5
+		if uploadStats.UncompressedSize == nil && uploadStats.UploadSize != nil {
+			shouldWriteToDisk = *uploadStats.UploadSize > compressedSizeLimitBytes
+		}
+
 		if !shouldWriteToDisk {
 			compressedSizeHint := int64(0)
 			if uploadStats.UploadSize != nil {
 				compressedSizeHint = *uploadStats.UploadSize
 			}
-			buf, err := readAllWithSizeHint(rc, compressedSizeHint)
+
+			// Never read more of an upload of unknown size into memory than we'd
+			// process in-memory, as it could be arbitrarily large.
+			r := io.Reader(rc)
+			if uploadStats.UncompressedSize == nil {
+				r = io.LimitReader(rc, compressedSizeLimitBytes+1)
+			}
+			buf, err := readAllWithSizeHint(r, compressedSizeHint)
 			if err != nil {
 				return gzipReadSeeker{}, nil, errors.Wrap(err, "failed to read upload file")
 			}
@@ -441,12 +481,8 @@ func withUploadData(ctx context.Context, logger log.Logger, uploadStore uploadst
 			if uploadStats.UncompressedSize == nil {
 				// Make a best-effort estimate for the uncompressed size, as it may
 				// make sense to write it the upload to disk despite having read
-				// it into memory to avoid OOM during processing.
-				// The factor of 5 is based on ~worst-case gzip compression ratio.
-				// See NOTE(scip-index-size-stats).
-				compressedSize := len(buf)
-				uncompressedSizeEstimate := compressedSize * 5
-				shouldWriteToDisk = int64(uncompressedSizeEstimate) > uncompressedSizeLimitBytes
+				// (part of) it into memory to avoid OOM during processing.
+				shouldWriteToDisk = int64(len(buf)) > compressedSizeLimitBytes
 			}
 
 			if !shouldWriteToDisk {
@@ -461,7 +497,8 @@ func withUploadData(ctx context.Context, logger log.Logger, uploadStore uploadst
 
 			// Fallthrough:
 			// Replace the reader we'll write to disk with the content we've already read
-			rc = io.NopCloser(bytes.NewReader(buf))
+			// followed by the remainder of the upload
+			rc = io.NopCloser(io.MultiReader(bytes.NewReader(buf), rc))
 		}
 
 		tempFile, err := os.CreateTemp("", fmt.Sprintf("upload-%d-tmp.gz", uploadStats.ID))
@@ -265,6 +265,102 @@ func TestHandle(t *testing.T) {
 	}
 }
 
+func TestHandleResumesFromCheckpoint(t *testing.T) {
+	setupRepoMocks(t)
+
+	upload := shared.Upload{
+		ID:           42,
+		Root:         "",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		ContentType:  "application/x-protobuf+scip",
+	}
+
+	mockWorkerStore := NewMockWorkerStore[shared.Upload]()
+	mockDBStore := NewMockStore()
+	mockRepoStore := defaultMockRepoStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := gitserver.NewMockClient()
+
+	// Set default transaction behavior
+	mockDBStore.WithTransactionFunc.SetDefaultHook(func(ctx context.Context, f func(s store.Store) error) error { return f(mockDBStore) })
+	mockLSIFStore.WithTransactionFunc.SetDefaultHook(func(ctx context.Context, f func(s lsifstore.Store) error) error { return f(mockLSIFStore) })
+
+	// A previous attempt committed the first five documents
+	mockLSIFStore.GetProcessingCheckpointFunc.SetDefaultReturn(lsifstore.ProcessingCheckpoint{NumDocuments: 5, NextSymbolNameID: 100}, true, nil)
+
+	// Track writes to symbols table
+	scipWriter := NewMockLSIFSCIPWriter()
+	mockLSIFStore.NewSCIPWriterFunc.SetDefaultReturn(scipWriter, nil)
+
+	// Give correlation package a valid input dump
+	mockUploadStore.GetFunc.SetDefaultHook(copyTestDumpScip)
+
+	// Allowlist all files in dump
+	gitserverClient.ListDirectoryChildrenFunc.SetDefaultReturn(scipDirectoryChildren, nil)
+	gitserverClient.CommitDateFunc.SetDefaultReturn("deadbeef", time.Now(), true, nil)
+
+	svc := &handler{
+		store:                  mockDBStore,
+		lsifStore:              mockLSIFStore,
+		gitserverClient:        gitserverClient,
+		repoStore:              mockRepoStore,
+		workerStore:            mockWorkerStore,
+		documentOptions:        documentProcessingOptions{concurrency: 4, memoryBudget: 1024},
+		documentsPerCheckpoint: 4,
+	}
+
+	requeued, err := svc.HandleRawUpload(context.Background(), logtest.Scoped(t), upload, mockUploadStore, observation.TestTraceLogger(logtest.Scoped(t)))
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if requeued {
+		t.Errorf("unexpected requeue")
+	}
+
+	if len(mockLSIFStore.InsertMetadataFunc.History()) != 0 {
+		t.Errorf("unexpected number of InsertMetadata calls. want=%d have=%d", 0, len(mockLSIFStore.InsertMetadataFunc.History()))
+	}
+
+	// The remaining six documents are written in two transactions
+	if len(scipWriter.InsertDocumentFunc.History()) != 6 {
+		t.Errorf("unexpected number of InsertDocument calls. want=%d have=%d", 6, len(scipWriter.InsertDocumentFunc.History()))
+	}
+	if len(mockLSIFStore.NewSCIPWriterFunc.History()) != 2 {
+		t.Errorf("unexpected number of NewSCIPWriter calls. want=%d have=%d", 2, len(mockLSIFStore.NewSCIPWriterFunc.History()))
+	}
+	if len(scipWriter.FlushFunc.History()) != 2 {
+		t.Errorf("unexpected number of Flush calls. want=%d have=%d", 2, len(scipWriter.FlushFunc.History()))
+	}
+
+	// Packages are assembled from skipped documents as well
+	expectedPackages := []precise.Package{
+		{
+			Scheme:  "scip-typescript",
+			Manager: "npm",
+			Name:    "template",
+			Version: "0.0.0-DEVELOPMENT",
+		},
+	}
+	if len(mockDBStore.UpdatePackagesFunc.History()) != 1 {
+		t.Errorf("unexpected number of UpdatePackages calls. want=%d have=%d", 1, len(mockDBStore.UpdatePackagesFunc.History()))
+	} else if diff := cmp.Diff(expectedPackages, mockDBStore.UpdatePackagesFunc.History()[0].Arg2); diff != "" {
+		t.Errorf("unexpected UpdatePackagesFunc args (-want +got):\n%s", diff)
+	}
+	if len(mockDBStore.UpdatePackageReferencesFunc.History()) != 1 {
+		t.Errorf("unexpected number of UpdatePackageReferences calls. want=%d have=%d", 1, len(mockDBStore.UpdatePackageReferencesFunc.History()))
+	} else if n := len(mockDBStore.UpdatePackageReferencesFunc.History()[0].Arg2); n != 8 {
+		t.Errorf("unexpected number of package references. want=%d have=%d", 8, n)
+	}
+
+	if len(mockLSIFStore.DeleteProcessingCheckpointFunc.History()) != 1 {
+		t.Errorf("unexpected number of DeleteProcessingCheckpoint calls. want=%d have=%d", 1, len(mockLSIFStore.DeleteProcessingCheckpointFunc.History()))
+	} else if mockLSIFStore.DeleteProcessingCheckpointFunc.History()[0].Arg1 != 42 {
+		t.Errorf("unexpected value for upload id. want=%d have=%d", 42, mockLSIFStore.DeleteProcessingCheckpointFunc.History()[0].Arg1)
+	}
+}
+
 func TestHandleError(t *testing.T) {
 	setupRepoMocks(t)
 
@@ -322,6 +418,9 @@ func TestHandleError(t *testing.T) {
 	if len(mockUploadStore.DeleteFunc.History()) != 0 {
 		t.Errorf("unexpected number of Delete calls. want=%d have=%d", 0, len(mockUploadStore.DeleteFunc.History()))
 	}
+	if len(mockLSIFStore.DeleteProcessingCheckpointFunc.History()) != 0 {
+		t.Errorf("unexpected number of DeleteProcessingCheckpoint calls. want=%d have=%d", 0, len(mockLSIFStore.DeleteProcessingCheckpointFunc.History()))
+	}
 }
 
 func TestHandleCloneInProgress(t *testing.T) {
@@ -5,9 +5,12 @@ import (
 	"context"
 	"io"
 
+	"github.com/sourcegraph/conc/pool"
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/scip/bindings/go/scip"
 	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/semaphore"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
@@ -57,20 +60,78 @@ type documentOneShotIterator struct {
 	ignorePaths  collections.Set[string]
 	indexSummary firstPassResult
 	indexReader  gzipReadSeeker
+	options      documentProcessingOptions
+}
+
+// documentProcessingOptions bounds the resources used to process the documents of an index.
+type documentProcessingOptions struct {
+	// concurrency is the number of documents that are canonicalized in parallel.
+	concurrency int
+	// memoryBudget is the approximate number of bytes that decoded documents may occupy
+	// between being read from the index and being consumed. Reading the index blocks
+	// while the budget is exhausted. Zero acts as an infinite budget.
+	memoryBudget int64
 }
 
 var _ lsifstore.SCIPDocumentVisitor = &documentOneShotIterator{}
 
+// processedDocumentResult is a document processed by a worker of VisitAllDocuments along
+// with the packages it defines and references.
+type processedDocumentResult struct {
+	document lsifstore.ProcessedSCIPDocument
+	packages map[precise.Package]bool
+	size     int64
+}
+
+// VisitAllDocuments processes the documents of the index in parallel, and invokes the given
+// function with each processed document in the order the documents appear in the index.
 func (it *documentOneShotIterator) VisitAllDocuments(
 	ctx context.Context,
 	logger log.Logger,
 	p *lsifstore.ProcessedPackageData,
 	doIt func(lsifstore.ProcessedSCIPDocument) error,
 ) error {
-	repeatedDocumentsByPath := make(map[string][]*scip.Document, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var budget *semaphore.Weighted
+	if it.options.memoryBudget > 0 {
+		budget = semaphore.NewWeighted(it.options.memoryBudget)
+	}
+
+	concurrency := max(it.options.concurrency, 1)
+	workers := pool.New().WithMaxGoroutines(concurrency)
+
+	// Results are queued in index order so that the consumer observes the same sequence
+	// of documents regardless of the order in which workers finish processing them.
+	queue := make(chan chan processedDocumentResult, concurrency)
+
 	packageSet := map[precise.Package]bool{}
+	consumerErr := make(chan error, 1)
+	go func() {
+		consumerErr <- func() error {
+			for resultCh := range queue {
+				result := <-resultCh
+				err := doIt(result.document)
+				if budget != nil {
+					budget.Release(result.size)
+				}
+				if err != nil {
+					// Stop reading the index
+					cancel()
+					return err
+				}
 
-	var outerError error = nil
+				for pkg, hasDefinition := range result.packages {
+					packageSet[pkg] = packageSet[pkg] || hasDefinition
+				}
+			}
+
+			return nil
+		}()
+	}()
+
+	repeatedDocumentsByPath := make(map[string][]*scip.Document, 1)
 
 	secondPassVisitor := scip.IndexVisitor{VisitDocument: func(currentDocument *scip.Document) {
 		path := currentDocument.RelativePath
@@ -99,54 +160,47 @@ func (it *documentOneShotIterator) VisitAllDocuments(
 		}
 
 		if ctx.Err() != nil {
-			outerError = ctx.Err()
-			return
-		}
-		if err := doIt(processDocument(document, it.indexSummary.externalSymbolsByName)); err != nil {
-			outerError = err
 			return
 		}
 
-		// While processing this document, stash the unique packages of each symbol name
-		// in the document. If there is an occurrence that defines that symbol, mark that
-		// package as being one that we define (rather than simply reference).
-
-		for _, symbol := range document.Symbols {
-			if pkg, ok := packageFromSymbol(symbol.Symbol); ok {
-				// no-op if key exists; add false if key is absent
-				packageSet[pkg] = packageSet[pkg] || false
+		size := int64(proto.Size(document))
+		if budget != nil {
+			// A document larger than the entire budget is processed on its own
+			size = min(size, it.options.memoryBudget)
+			if err := budget.Acquire(ctx, size); err != nil {
+				return
 			}
+		}
 
-			for _, relationship := range symbol.Relationships {
-				if pkg, ok := packageFromSymbol(relationship.Symbol); ok {
-					// no-op if key exists; add false if key is absent
-					packageSet[pkg] = packageSet[pkg] || false
-				}
-			}
+		resultCh := make(chan processedDocumentResult, 1)
+		select {
+		case queue <- resultCh:
+		case <-ctx.Done():
+			return
 		}
 
-		for _, occurrence := range document.Occurrences {
-			if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
-				continue
-			}
+		workers.Go(func() {
+			processedDocument := processDocument(document, it.indexSummary.externalSymbolsByName)
 
-			if pkg, ok := packageFromSymbol(occurrence.Symbol); ok {
-				if isDefinition := scip.SymbolRole_Definition.Matches(occurrence); isDefinition {
-					packageSet[pkg] = true
-				} else {
-					// no-op if key exists; add false if key is absent
-					packageSet[pkg] = packageSet[pkg] || false
-				}
+			resultCh <- processedDocumentResult{
+				document: processedDocument,
+				packages: documentPackages(processedDocument.Document),
+				size:     size,
 			}
-		}
+		})
 	},
 	}
 	if err := secondPassVisitor.ParseStreaming(&it.indexReader); err != nil {
 		logger.Warn("error on second pass over SCIP index; should've hit it in the first pass",
 			log.Error(err))
 	}
-	if outerError != nil {
-		return outerError
+	workers.Wait()
+	close(queue)
+	if err := <-consumerErr; err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 	// Reset state in case we want to read documents again
 	if err := it.indexReader.seekToStart(); err != nil {
@@ -154,13 +208,9 @@ func (it *documentOneShotIterator) VisitAllDocuments(
 	}
 
 	// Now that we've populated our index-global packages map, separate them into ones that
-	// we define and ones that we simply reference. The closing of the documents channel at
-	// the end of this function will signal that these lists have been populated.
+	// we define and ones that we simply reference.
 
 	for pkg, hasDefinition := range packageSet {
-		if ctx.Err() != nil {
-			return ctx.Err()
-		}
 		if hasDefinition {
 			p.Packages = append(p.Packages, pkg)
 		} else {
@@ -171,6 +221,44 @@ func (it *documentOneShotIterator) VisitAllDocuments(
 	return nil
 }
 
+// documentPackages returns the unique packages of each symbol name in the given document.
+// A package maps to true if there is an occurrence in the document that defines one of its
+// symbols, and false if the document simply references the package.
+func documentPackages(document *scip.Document) map[precise.Package]bool {
+	packageSet := map[precise.Package]bool{}
+
+	for _, symbol := range document.Symbols {
+		if pkg, ok := packageFromSymbol(symbol.Symbol); ok {
+			// no-op if key exists; add false if key is absent
+			packageSet[pkg] = packageSet[pkg] || false
+		}
+
+		for _, relationship := range symbol.Relationships {
+			if pkg, ok := packageFromSymbol(relationship.Symbol); ok {
+				// no-op if key exists; add false if key is absent
+				packageSet[pkg] = packageSet[pkg] || false
+			}
+		}
+	}
+
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+			continue
+		}
+
+		if pkg, ok := packageFromSymbol(occurrence.Symbol); ok {
+			if isDefinition := scip.SymbolRole_Definition.Matches(occurrence); isDefinition {
+				packageSet[pkg] = true
+			} else {
+				// no-op if key exists; add false if key is absent
+				packageSet[pkg] = packageSet[pkg] || false
+			}
+		}
+	}
+
+	return packageSet
+}
+
 // prepareSCIPDataStream performs a streaming traversal of the index to get some preliminary
 // information, and creates a SCIPDataStream that can be used to write Documents into the database.
 //
@@ -180,6 +268,7 @@ func prepareSCIPDataStream(
 	indexReader gzipReadSeeker,
 	root string,
 	getChildren pathexistence.GetChildrenFunc,
+	options documentProcessingOptions,
 ) (lsifstore.SCIPDataStream, error) {
 	indexSummary, err := aggregateExternalSymbolsAndPaths(&indexReader)
 	if err != nil {
@@ -201,7 +290,7 @@ func prepareSCIPDataStream(
 
 	return lsifstore.SCIPDataStream{
 		Metadata:         metadata,
-		DocumentIterator: &documentOneShotIterator{ignorePaths, indexSummary, indexReader},
+		DocumentIterator: &documentOneShotIterator{ignorePaths, indexSummary, indexReader, options},
 	}, nil
 }
 
@@ -312,8 +401,9 @@ func injectExternalSymbols(document *scip.Document, externalSymbolsByName map[st
 				continue
 			}
 
-			// Add new definition for referenced symbol
-			document.Symbols = append(document.Symbols, symbol)
+			// Add new definition for referenced symbol. External symbols are shared by
+			// documents that are canonicalized concurrently, so each document gets a copy.
+			document.Symbols = append(document.Symbols, proto.Clone(symbol).(*scip.SymbolInformation))
 
 			// Populate new frontier
 			for _, relationship := range symbol.Relationships {
@@ -352,44 +442,126 @@ func packageFromSymbol(symbolName string) (precise.Package, bool) {
 // writeSCIPDocuments iterates over the documents in the index and:
 // - Assembles package information
 // - Writes processed documents into the given store targeting codeintel-db
+//
+// Documents are written in transactions of at most documentsPerCheckpoint documents (or in
+// a single transaction if documentsPerCheckpoint is not positive). Each transaction records
+// a processing checkpoint, and documents covered by the checkpoint of a previous attempt to
+// process the same upload are skipped.
 func writeSCIPDocuments(
 	ctx context.Context,
 	logger log.Logger,
 	lsifStore lsifstore.Store,
 	upload shared.Upload,
 	scipDataStream lsifstore.SCIPDataStream,
+	documentsPerCheckpoint int,
 	trace observation.TraceLogger,
 ) (pkgData lsifstore.ProcessedPackageData, err error) {
-	return pkgData, lsifStore.WithTransaction(ctx, func(tx lsifstore.Store) error {
-		if err := tx.InsertMetadata(ctx, upload.ID, scipDataStream.Metadata); err != nil {
-			return err
+	checkpoint, resuming, err := lsifStore.GetProcessingCheckpoint(ctx, upload.ID)
+	if err != nil {
+		return pkgData, err
+	}
+	if resuming {
+		trace.AddEvent("TODO Domain Owner", attribute.Int("resumeAfterDocuments", checkpoint.NumDocuments))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Documents are visited in a separate goroutine so that the documents of the index can
+	// be consumed across several transactions.
+	documents := make(chan lsifstore.ProcessedSCIPDocument)
+	visitErr := make(chan error, 1)
+	go func() {
+		defer close(documents)
+
+		visitErr <- scipDataStream.DocumentIterator.VisitAllDocuments(ctx, logger, &pkgData, func(document lsifstore.ProcessedSCIPDocument) error {
+			select {
+			case documents <- document:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	if err := writeSCIPDocumentsInTransactions(ctx, lsifStore, upload, scipDataStream.Metadata, checkpoint.NumDocuments, resuming, documents, documentsPerCheckpoint, trace); err != nil {
+		// Stop visiting documents and wait for the visitor to exit
+		cancel()
+		for range documents {
 		}
+		<-visitErr
+		return pkgData, err
+	}
+	if err := <-visitErr; err != nil {
+		return pkgData, err
+	}
 
-		scipWriter, err := tx.NewSCIPWriter(ctx, upload.ID)
-		if err != nil {
-			return err
+	pkgData.Normalize()
+	return pkgData, nil
+}
+
+func writeSCIPDocumentsInTransactions(
+	ctx context.Context,
+	lsifStore lsifstore.Store,
+	upload shared.Upload,
+	metadata lsifstore.ProcessedMetadata,
+	numCommittedDocuments int,
+	resuming bool,
+	documents <-chan lsifstore.ProcessedSCIPDocument,
+	documentsPerCheckpoint int,
+	trace observation.TraceLogger,
+) error {
+	// Skip the documents committed by a previous attempt. They're still visited
+	// as package information is assembled from every document of the index.
+	for i := 0; i < numCommittedDocuments; i++ {
+		if _, ok := <-documents; !ok {
+			break
 		}
+	}
+
+	var numDocuments, numSymbols uint32
+	for done := false; !done; {
+		if err := lsifStore.WithTransaction(ctx, func(tx lsifstore.Store) error {
+			if !resuming {
+				// Metadata is written along with the first batch of documents
+				if err := tx.InsertMetadata(ctx, upload.ID, metadata); err != nil {
+					return err
+				}
+			}
+
+			scipWriter, err := tx.NewSCIPWriter(ctx, upload.ID)
+			if err != nil {
+				return err
+			}
+
+			for n := 0; documentsPerCheckpoint <= 0 || n < documentsPerCheckpoint; n++ {
+				document, ok := <-documents
+				if !ok {
+					done = true
+					break
+				}
+
+				numDocuments += 1
+				if err := scipWriter.InsertDocument(ctx, document.Path, document.Document); err != nil {
+					return err
+				}
+			}
 
-		var numDocuments uint32
-		processDoc := func(document lsifstore.ProcessedSCIPDocument) error {
-			numDocuments += 1
-			if err := scipWriter.InsertDocument(ctx, document.Path, document.Document); err != nil {
+			count, err := scipWriter.Flush(ctx)
+			if err != nil {
 				return err
 			}
+			numSymbols += count
+
 			return nil
-		}
-		if err := scipDataStream.DocumentIterator.VisitAllDocuments(ctx, logger, &pkgData, processDoc); err != nil {
+		}); err != nil {
 			return err
 		}
-		trace.AddEvent("TODO Domain Owner", attribute.Int64("numDocuments", int64(numDocuments)))
 
-		count, err := scipWriter.Flush(ctx)
-		if err != nil {
-			return err
-		}
-		trace.AddEvent("TODO Domain Owner", attribute.Int64("numSymbols", int64(count)))
+		resuming = true
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int64("numDocuments", int64(numDocuments)))
+	trace.AddEvent("TODO Domain Owner", attribute.Int64("numSymbols", int64(numSymbols)))
 
-		pkgData.Normalize()
-		return nil
-	})
+	return nil
 }
@@ -50,9 +50,12 @@ func implCorrelateSCIP(t *testing.T, testIndexPath string, indexSizeLimit int64)
 	}
 
 	// Correlate and consume channels from returned object
+	// Process documents concurrently with a budget smaller than most documents
+	options := documentProcessingOptions{concurrency: 4, memoryBudget: 1024}
+
 	scipDataStream, err := prepareSCIPDataStream(ctx, testReader(), "", func(ctx context.Context, dirnames []string) (map[string][]string, error) {
 		return scipDirectoryChildren, nil
-	})
+	}, options)
 	if err != nil {
 		t.Fatalf("unexpected error processing SCIP: %s", err)
 	}
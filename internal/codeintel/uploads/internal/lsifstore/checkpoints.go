@@ -0,0 +1,64 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ProcessingCheckpoint records how far the documents of an upload have been written.
+// Documents are written in index order, so the first NumDocuments documents of the
+// index have been committed and can be skipped when processing resumes.
+type ProcessingCheckpoint struct {
+	NumDocuments     int
+	NextSymbolNameID int
+}
+
+func (s *store) GetProcessingCheckpoint(ctx context.Context, uploadID int) (_ ProcessingCheckpoint, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getProcessingCheckpoint.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstProcessingCheckpoint(s.db.Query(ctx, sqlf.Sprintf(getProcessingCheckpointQuery, uploadID)))
+}
+
+const getProcessingCheckpointQuery = `
+SELECT num_documents, next_symbol_name_id
+FROM codeintel_scip_processing_checkpoints
+WHERE upload_id = %s
+`
+
+func (s *store) DeleteProcessingCheckpoint(ctx context.Context, uploadID int) (err error) {
+	ctx, _, endObservation := s.operations.deleteProcessingCheckpoint.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.db.Exec(ctx, sqlf.Sprintf(deleteProcessingCheckpointQuery, uploadID))
+}
+
+const deleteProcessingCheckpointQuery = `
+DELETE FROM codeintel_scip_processing_checkpoints WHERE upload_id = %s
+`
+
+// updateProcessingCheckpointQuery is executed by the SCIP writer in the same transaction
+// as the documents it covers.
+const updateProcessingCheckpointQuery = `
+INSERT INTO codeintel_scip_processing_checkpoints (upload_id, num_documents, next_symbol_name_id)
+VALUES (%s, %s, %s)
+ON CONFLICT (upload_id) DO UPDATE SET
+	num_documents = EXCLUDED.num_documents,
+	next_symbol_name_id = EXCLUDED.next_symbol_name_id,
+	updated_at = NOW()
+`
+
+var scanFirstProcessingCheckpoint = basestore.NewFirstScanner(func(s dbutil.Scanner) (checkpoint ProcessingCheckpoint, _ error) {
+	err := s.Scan(&checkpoint.NumDocuments, &checkpoint.NextSymbolNameID)
+	return checkpoint, err
+})
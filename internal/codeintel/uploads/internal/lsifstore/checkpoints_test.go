@@ -0,0 +1,81 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	codeintelshared "github.com/sourcegraph/sourcegraph/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestProcessingCheckpoints(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	if _, ok, err := store.GetProcessingCheckpoint(ctx, 42); err != nil {
+		t.Fatalf("failed to get processing checkpoint: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected processing checkpoint")
+	}
+
+	writeDocuments := func(paths ...string) {
+		if err := store.WithTransaction(ctx, func(tx Store) error {
+			scipWriter, err := tx.NewSCIPWriter(ctx, 42)
+			if err != nil {
+				return err
+			}
+			for _, path := range paths {
+				if err := scipWriter.InsertDocument(ctx, path, &scip.Document{
+					Symbols: []*scip.SymbolInformation{
+						{Symbol: "scip-test . . . " + path},
+					},
+				}); err != nil {
+					return err
+				}
+			}
+			_, err = scipWriter.Flush(ctx)
+			return err
+		}); err != nil {
+			t.Fatalf("failed to write SCIP documents: %s", err)
+		}
+	}
+
+	// Documents written in separate transactions accumulate in the checkpoint
+	writeDocuments("a.go", "b.go")
+	writeDocuments("c.go")
+
+	checkpoint, ok, err := store.GetProcessingCheckpoint(ctx, 42)
+	if err != nil {
+		t.Fatalf("failed to get processing checkpoint: %s", err)
+	} else if !ok {
+		t.Fatalf("expected processing checkpoint")
+	} else if diff := cmp.Diff(3, checkpoint.NumDocuments); diff != "" {
+		t.Errorf("unexpected number of documents (-want +got):\n%s", diff)
+	}
+
+	// Symbol names of the second transaction must not reuse identifiers of the first
+	var numSymbolNames, numDistinctIDs int
+	if err := codeIntelDB.Handle().QueryRowContext(ctx, `SELECT COUNT(*), COUNT(DISTINCT id) FROM codeintel_scip_symbol_names WHERE upload_id = 42`).Scan(&numSymbolNames, &numDistinctIDs); err != nil {
+		t.Fatalf("failed to query symbol names: %s", err)
+	} else if numSymbolNames != numDistinctIDs {
+		t.Errorf("unexpected duplicate symbol name identifiers. names=%d distinctIDs=%d", numSymbolNames, numDistinctIDs)
+	} else if checkpoint.NextSymbolNameID < numSymbolNames {
+		t.Errorf("unexpected next symbol name id. want>=%d have=%d", numSymbolNames, checkpoint.NextSymbolNameID)
+	}
+
+	if err := store.DeleteProcessingCheckpoint(ctx, 42); err != nil {
+		t.Fatalf("failed to delete processing checkpoint: %s", err)
+	}
+	if _, ok, err := store.GetProcessingCheckpoint(ctx, 42); err != nil {
+		t.Fatalf("failed to get processing checkpoint: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected processing checkpoint")
+	}
+}
@@ -100,6 +100,9 @@ func (s *store) DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int)
 		if err := tx.db.Exec(ctx, sqlf.Sprintf(deleteSCIPSymbolsSchemaVersionsQuery, pq.Array(bundleIDs))); err != nil {
 			return err
 		}
+		if err := tx.db.Exec(ctx, sqlf.Sprintf(deleteProcessingCheckpointsQuery, pq.Array(bundleIDs))); err != nil {
+			return err
+		}
 
 		if err := s.db.Exec(ctx, sqlf.Sprintf(deleteLastReconcileQuery, pq.Array(bundleIDs))); err != nil {
 			return err
@@ -156,6 +159,10 @@ const deleteSCIPSymbolsSchemaVersionsQuery = `
 DELETE FROM codeintel_scip_symbols_schema_versions WHERE upload_id = ANY(%s)
 `
 
+const deleteProcessingCheckpointsQuery = `
+DELETE FROM codeintel_scip_processing_checkpoints WHERE upload_id = ANY(%s)
+`
+
 const deleteLastReconcileQuery = `
 WITH locked_rows AS (
 	SELECT dump_id
@@ -135,8 +135,16 @@ func (s *store) NewSCIPWriter(ctx context.Context, uploadID int) (SCIPWriter, er
 		"type_definition_ranges",
 	)
 
+	// Continue where a previous attempt to write the documents of this upload left off
+	checkpoint, _, err := scanFirstProcessingCheckpoint(s.db.Query(ctx, sqlf.Sprintf(newSCIPWriterCheckpointQuery, uploadID)))
+	if err != nil {
+		return nil, err
+	}
+
 	scipWriter := &scipWriter{
 		uploadID:           uploadID,
+		nextID:             checkpoint.NextSymbolNameID,
+		numDocuments:       checkpoint.NumDocuments,
 		db:                 s.db,
 		symbolNameInserter: symbolNameInserter,
 		symbolInserter:     symbolInserter,
@@ -146,6 +154,13 @@ func (s *store) NewSCIPWriter(ctx context.Context, uploadID int) (SCIPWriter, er
 	return scipWriter, nil
 }
 
+const newSCIPWriterCheckpointQuery = `
+SELECT num_documents, next_symbol_name_id
+FROM codeintel_scip_processing_checkpoints
+WHERE upload_id = %s
+FOR UPDATE
+`
+
 const newSCIPWriterTemporarySymbolNamesTableQuery = `
 CREATE TEMPORARY TABLE t_codeintel_scip_symbol_names (
 	id integer NOT NULL,
@@ -168,6 +183,7 @@ CREATE TEMPORARY TABLE t_codeintel_scip_symbols (
 type scipWriter struct {
 	uploadID           int
 	nextID             int
+	numDocuments       int
 	db                 *basestore.Store
 	symbolNameInserter *batch.Inserter
 	symbolInserter     *batch.Inserter
@@ -205,6 +221,7 @@ func (s *scipWriter) InsertDocument(ctx context.Context, path string, scipDocume
 		return err
 	}
 
+	s.numDocuments++
 	s.batch = append(s.batch, bufferedDocument{
 		path:         path,
 		scipDocument: scipDocument,
@@ -420,6 +437,17 @@ func (s *scipWriter) Flush(ctx context.Context) (uint32, error) {
 		return 0, err
 	}
 
+	// Record the progress made in this transaction so that a failed attempt to process
+	// the upload can resume after the documents written so far
+	if err := s.db.Exec(ctx, sqlf.Sprintf(
+		updateProcessingCheckpointQuery,
+		s.uploadID,
+		s.numDocuments,
+		s.nextID,
+	)); err != nil {
+		return 0, err
+	}
+
 	return s.count, nil
 }
 
@@ -10,6 +10,8 @@ import (
 type operations struct {
 	insertMetadata                            *observation.Operation
 	newSCIPWriter                             *observation.Operation
+	getProcessingCheckpoint                   *observation.Operation
+	deleteProcessingCheckpoint                *observation.Operation
 	idsWithMeta                               *observation.Operation
 	reconcileCandidates                       *observation.Operation
 	deleteLsifDataByUploadIds                 *observation.Operation
@@ -40,6 +42,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 	return &operations{
 		insertMetadata:                            op("InsertMetadata"),
 		newSCIPWriter:                             op("NewSCIPWriter"),
+		getProcessingCheckpoint:                   op("GetProcessingCheckpoint"),
+		deleteProcessingCheckpoint:                op("DeleteProcessingCheckpoint"),
 		idsWithMeta:                               op("IDsWithMeta"),
 		reconcileCandidates:                       op("ReconcileCandidates"),
 		deleteLsifDataByUploadIds:                 op("DeleteLsifDataByUploadIds"),
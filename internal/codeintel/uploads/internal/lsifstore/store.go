@@ -19,6 +19,10 @@ type Store interface {
 	InsertMetadata(ctx context.Context, uploadID int, meta ProcessedMetadata) error
 	NewSCIPWriter(ctx context.Context, uploadID int) (SCIPWriter, error)
 
+	// Resumable processing
+	GetProcessingCheckpoint(ctx context.Context, uploadID int) (ProcessingCheckpoint, bool, error)
+	DeleteProcessingCheckpoint(ctx context.Context, uploadID int) error
+
 	// Reconciliation and cleanup
 	IDsWithMeta(ctx context.Context, ids []int) ([]int, error)
 	ReconcileCandidates(ctx context.Context, batchSize int) ([]int, error)
@@ -31,6 +35,9 @@ type Store interface {
 	InsertDefinitionsAndReferencesForDocument(ctx context.Context, upload shared.ExportedUpload, rankingGraphKey string, rankingBatchSize int, f func(ctx context.Context, upload shared.ExportedUpload, rankingBatchSize int, rankingGraphKey, path string, document *scip.Document) error) (err error)
 }
 
+// SCIPWriter writes the documents of an upload within a transaction. Flush records the number
+// of documents written so far as the upload's processing checkpoint, which lets the documents
+// of a large upload be written over several transactions.
 type SCIPWriter interface {
 	InsertDocument(ctx context.Context, path string, scipDocument *scip.Document) error
 	Flush(ctx context.Context) (uint32, error)
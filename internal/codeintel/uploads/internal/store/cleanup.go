@@ -162,18 +162,24 @@ func (s *store) DeleteOldAuditLogs(ctx context.Context, maxAge time.Duration, no
 	ctx, _, endObservation := s.operations.deleteOldAuditLogs.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
-	query := sqlf.Sprintf(deleteOldAuditLogsQuery, now, int(maxAge/time.Second))
+	query := sqlf.Sprintf(deleteOldAuditLogsQuery, now, int(maxAge/time.Second), now, int(maxAge/time.Second))
 	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, query))
 	return count, count, err
 }
 
 const deleteOldAuditLogsQuery = `
-WITH deleted AS (
+WITH
+deleted AS (
 	DELETE FROM lsif_uploads_audit_logs
 	WHERE %s - log_timestamp > (%s * '1 second'::interval)
 	RETURNING upload_id
+),
+deleted_visibility_logs AS (
+	DELETE FROM lsif_uploads_visibility_audit_logs
+	WHERE %s - log_timestamp > (%s * '1 second'::interval)
+	RETURNING id
 )
-SELECT count(*) FROM deleted
+SELECT (SELECT count(*) FROM deleted) + (SELECT count(*) FROM deleted_visibility_logs)
 `
 
 func (s *store) ReconcileCandidates(ctx context.Context, batchSize int) (_ []int, err error) {
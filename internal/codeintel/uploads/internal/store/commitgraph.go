@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -162,6 +163,11 @@ func (s *store) UpdateUploadsVisibleToCommits(
 			return err
 		}
 
+		reason := fmt.Sprintf("commit graph updated with %d commits, %d refs, and %d completed uploads", len(commitGraph.Order()), len(refDescriptions), len(commitGraphView.Meta))
+		if err := tx.db.Exec(ctx, sqlf.Sprintf(insertUploadVisibilityLogQuery, repositoryID, shared.UploadVisibilityOperationCommitGraphUpdated, reason)); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -880,6 +886,12 @@ func (s *store) persistUploadsVisibleAtTip(ctx context.Context, repositoryID int
 	ctx, trace, endObservation := s.operations.persistUploadsVisibleAtTip.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
+	// Record the visibility transitions before the permanent table is modified so that we can
+	// explain why an upload is (or is no longer) used to answer queries at the tip of a ref.
+	if err := tx.Exec(ctx, sqlf.Sprintf(uploadsVisibleAtTipAuditLogQuery, repositoryID, repositoryID, repositoryID)); err != nil {
+		return err
+	}
+
 	insertQuery := sqlf.Sprintf(uploadsVisibleAtTipInsertQuery, repositoryID, repositoryID)
 	deleteQuery := sqlf.Sprintf(uploadsVisibleAtTipDeleteQuery, repositoryID)
 
@@ -895,6 +907,64 @@ func (s *store) persistUploadsVisibleAtTip(ctx context.Context, repositoryID int
 	return nil
 }
 
+const uploadsVisibleAtTipAuditLogQuery = `
+INSERT INTO lsif_uploads_visibility_audit_logs (repository_id, upload_id, operation, branch_or_tag_name, is_default_branch, reason)
+SELECT
+	%s,
+	source.upload_id,
+	'visible',
+	source.branch_or_tag_name,
+	source.is_default_branch,
+	'visible from the tip of ' || source.branch_or_tag_name
+FROM t_lsif_uploads_visible_at_tip source
+WHERE NOT EXISTS (
+	SELECT 1
+	FROM lsif_uploads_visible_at_tip vat
+	WHERE
+		vat.repository_id = %s AND
+		vat.upload_id = source.upload_id AND
+		vat.branch_or_tag_name = source.branch_or_tag_name AND
+		vat.is_default_branch = source.is_default_branch
+)
+UNION ALL
+SELECT
+	vat.repository_id,
+	vat.upload_id,
+	'hidden',
+	vat.branch_or_tag_name,
+	vat.is_default_branch,
+	CASE
+		WHEN u.id IS NULL THEN 'upload no longer exists'
+		WHEN u.state != 'completed' THEN 'upload is ' || u.state
+		WHEN shadow.id IS NOT NULL THEN 'shadowed by upload ' || shadow.id || ' on ' || vat.branch_or_tag_name
+		ELSE 'no longer visible from the tip of ' || vat.branch_or_tag_name
+	END
+FROM lsif_uploads_visible_at_tip vat
+LEFT JOIN lsif_uploads u ON u.id = vat.upload_id
+LEFT JOIN LATERAL (
+	SELECT su.id
+	FROM t_lsif_uploads_visible_at_tip source
+	JOIN lsif_uploads su ON su.id = source.upload_id
+	WHERE
+		source.branch_or_tag_name = vat.branch_or_tag_name AND
+		su.id != vat.upload_id AND
+		su.root = u.root AND
+		su.indexer = u.indexer
+	ORDER BY su.id DESC
+	LIMIT 1
+) shadow ON true
+WHERE
+	vat.repository_id = %s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM t_lsif_uploads_visible_at_tip source
+		WHERE
+			source.upload_id = vat.upload_id AND
+			source.branch_or_tag_name = vat.branch_or_tag_name AND
+			source.is_default_branch = vat.is_default_branch
+	)
+`
+
 const uploadsVisibleAtTipInsertQuery = `
 INSERT INTO lsif_uploads_visible_at_tip
 SELECT %s, source.upload_id, source.branch_or_tag_name, source.is_default_branch
@@ -18,6 +18,8 @@ type operations struct {
 	getCommitsVisibleToUpload           *observation.Operation
 	getOldestCommitDate                 *observation.Operation
 	getCommitGraphMetadata              *observation.Operation
+	getUploadVisibilityLogs             *observation.Operation
+	insertUploadVisibilityLog           *observation.Operation
 	hasCommit                           *observation.Operation
 	repositoryIDsWithErrors             *observation.Operation
 	numRepositoriesWithCodeIntelligence *observation.Operation
@@ -125,6 +127,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getOldestCommitDate:       op("GetOldestCommitDate"),
 		getStaleSourcedCommits:    op("GetStaleSourcedCommits"),
 		getCommitGraphMetadata:    op("GetCommitGraphMetadata"),
+		getUploadVisibilityLogs:   op("GetUploadVisibilityLogs"),
+		insertUploadVisibilityLog: op("InsertUploadVisibilityLog"),
 		deleteSourcedCommits:      op("DeleteSourcedCommits"),
 		updateSourcedCommits:      op("UpdateSourcedCommits"),
 		hasCommit:                 op("HasCommit"),
@@ -44,6 +44,7 @@ func (s *store) InsertUpload(ctx context.Context, upload shared.Upload) (id int,
 			upload.AssociatedIndexID,
 			upload.ContentType,
 			upload.UncompressedSize,
+			dbworkerstore.NewTraceContext(ctx),
 		),
 	))
 
@@ -63,8 +64,9 @@ INSERT INTO lsif_uploads (
 	upload_size,
 	associated_index_id,
 	content_type,
-	uncompressed_size
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	uncompressed_size,
+	trace_context
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -91,7 +93,7 @@ func (s *store) MarkQueued(ctx context.Context, id int, uploadSize *int64) (err
 	}})
 	defer endObservation(1, observation.Args{})
 
-	return s.db.Exec(ctx, sqlf.Sprintf(markQueuedQuery, dbutil.NullInt64{N: uploadSize}, id))
+	return s.db.Exec(ctx, sqlf.Sprintf(markQueuedQuery, dbutil.NullInt64{N: uploadSize}, dbworkerstore.NewTraceContext(ctx), id))
 }
 
 const markQueuedQuery = `
@@ -99,7 +101,8 @@ UPDATE lsif_uploads
 SET
 	state = 'queued',
 	queued_at = clock_timestamp(),
-	upload_size = %s
+	upload_size = %s,
+	trace_context = %s
 WHERE id = %s
 `
 
@@ -231,4 +234,5 @@ var UploadWorkerStoreOptions = dbworkerstore.Options[shared.Upload]{
 	`),
 	StalledMaxAge: stalledUploadMaxAge,
 	MaxNumResets:  uploadMaxNumResets,
+	TraceContext:  true,
 }
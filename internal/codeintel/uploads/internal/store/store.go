@@ -72,6 +72,8 @@ type Store interface {
 	FindClosestDumpsFromGraphFragment(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string, commitGraph *gitdomain.CommitGraph) ([]shared.Dump, error)
 	GetRepositoriesMaxStaleAge(ctx context.Context) (time.Duration, error)
 	GetCommitGraphMetadata(ctx context.Context, repositoryID int) (stale bool, updatedAt *time.Time, _ error)
+	GetUploadVisibilityLogs(ctx context.Context, opts shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)
+	InsertUploadVisibilityLog(ctx context.Context, repositoryID int, operation, reason string) error
 
 	// Expiration
 	GetLastUploadRetentionScanForRepository(ctx context.Context, repositoryID int) (*time.Time, error)
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetUploadVisibilityLogs returns the visibility audit logs of the given repository, most recent
+// first, along with the total number of matching logs.
+func (s *store) GetUploadVisibilityLogs(ctx context.Context, opts shared.GetUploadVisibilityLogsOptions) (_ []shared.UploadVisibilityLog, _ int, err error) {
+	ctx, _, endObservation := s.operations.getUploadVisibilityLogs.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", opts.RepositoryID),
+		attribute.Int("uploadID", opts.UploadID),
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("l.repository_id = %s", opts.RepositoryID), authzConds}
+	if opts.UploadID != 0 {
+		conds = append(conds, sqlf.Sprintf("l.upload_id = %s", opts.UploadID))
+	}
+
+	limit := sqlf.Sprintf("ALL")
+	if opts.Limit > 0 {
+		limit = sqlf.Sprintf("%s", opts.Limit)
+	}
+
+	return scanUploadVisibilityLogs(s.db.Query(ctx, sqlf.Sprintf(getUploadVisibilityLogsQuery, sqlf.Join(conds, " AND "), limit, opts.Offset)))
+}
+
+const getUploadVisibilityLogsQuery = `
+SELECT
+	l.id,
+	l.log_timestamp,
+	l.repository_id,
+	l.upload_id,
+	l.operation,
+	l.branch_or_tag_name,
+	l.is_default_branch,
+	l.reason,
+	COUNT(*) OVER() AS count
+FROM lsif_uploads_visibility_audit_logs l
+JOIN repo ON repo.id = l.repository_id
+WHERE %s
+ORDER BY l.log_timestamp DESC, l.id DESC
+LIMIT %s OFFSET %s
+`
+
+var scanUploadVisibilityLogs = basestore.NewSliceWithCountScanner(func(s dbutil.Scanner) (log shared.UploadVisibilityLog, count int, _ error) {
+	err := s.Scan(
+		&log.ID,
+		&log.LogTimestamp,
+		&log.RepositoryID,
+		&log.UploadID,
+		&log.Operation,
+		&log.BranchOrTagName,
+		&log.IsDefaultBranch,
+		&log.Reason,
+		&count,
+	)
+	return log, count, err
+})
+
+// InsertUploadVisibilityLog records a repository-level visibility event that is not tied to a
+// particular upload, such as a failed commit graph update.
+func (s *store) InsertUploadVisibilityLog(ctx context.Context, repositoryID int, operation, reason string) (err error) {
+	ctx, _, endObservation := s.operations.insertUploadVisibilityLog.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", repositoryID),
+		attribute.String("operation", operation),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.db.Exec(ctx, sqlf.Sprintf(insertUploadVisibilityLogQuery, repositoryID, operation, reason))
+}
+
+const insertUploadVisibilityLogQuery = `
+INSERT INTO lsif_uploads_visibility_audit_logs (repository_id, operation, reason)
+VALUES (%s, %s, %s)
+`
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestUploadVisibilityLogs(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+	ctx := context.Background()
+
+	graph := gitdomain.ParseCommitGraph([]string{
+		strings.Join([]string{makeCommit(2), makeCommit(1)}, " "),
+		strings.Join([]string{makeCommit(1)}, " "),
+	})
+	refDescriptions := map[string][]gitdomain.RefDescription{
+		makeCommit(2): {{Name: "main", Type: gitdomain.RefTypeBranch, IsDefaultBranch: true}},
+	}
+
+	insertUploads(t, db, shared.Upload{ID: 1, Commit: makeCommit(1)})
+	if err := store.UpdateUploadsVisibleToCommits(ctx, 50, graph, refDescriptions, time.Hour, time.Hour, 0, time.Now()); err != nil {
+		t.Fatalf("unexpected error while calculating visible uploads: %s", err)
+	}
+
+	// A newer upload for the same root and indexer shadows the first one
+	insertUploads(t, db, shared.Upload{ID: 2, Commit: makeCommit(2)})
+	if err := store.UpdateUploadsVisibleToCommits(ctx, 50, graph, refDescriptions, time.Hour, time.Hour, 0, time.Now()); err != nil {
+		t.Fatalf("unexpected error while calculating visible uploads: %s", err)
+	}
+
+	if err := store.InsertUploadVisibilityLog(ctx, 50, shared.UploadVisibilityOperationCommitGraphUpdateFailed, "gitserver unavailable"); err != nil {
+		t.Fatalf("unexpected error inserting visibility log: %s", err)
+	}
+
+	logs, totalCount, err := store.GetUploadVisibilityLogs(ctx, shared.GetUploadVisibilityLogsOptions{RepositoryID: 50})
+	if err != nil {
+		t.Fatalf("unexpected error getting visibility logs: %s", err)
+	}
+	if totalCount != len(logs) {
+		t.Errorf("unexpected total count. want=%d have=%d", len(logs), totalCount)
+	}
+
+	type summary struct {
+		UploadID  int
+		Operation string
+		Reason    string
+	}
+	var summaries []summary
+	for _, log := range logs {
+		s := summary{Operation: log.Operation, Reason: log.Reason}
+		if log.UploadID != nil {
+			s.UploadID = *log.UploadID
+		}
+		if s.Operation == shared.UploadVisibilityOperationCommitGraphUpdated {
+			// Counts are not interesting here
+			s.Reason = ""
+		}
+		summaries = append(summaries, s)
+	}
+
+	expected := []summary{
+		{Operation: shared.UploadVisibilityOperationCommitGraphUpdateFailed, Reason: "gitserver unavailable"},
+		{Operation: shared.UploadVisibilityOperationCommitGraphUpdated},
+		{UploadID: 1, Operation: shared.UploadVisibilityOperationHidden, Reason: "shadowed by upload 2 on main"},
+		{UploadID: 2, Operation: shared.UploadVisibilityOperationVisible, Reason: "visible from the tip of main"},
+		{Operation: shared.UploadVisibilityOperationCommitGraphUpdated},
+		{UploadID: 1, Operation: shared.UploadVisibilityOperationVisible, Reason: "visible from the tip of main"},
+	}
+	if diff := cmp.Diff(expected, summaries); diff != "" {
+		t.Errorf("unexpected visibility logs (-want +got):\n%s", diff)
+	}
+
+	// Filter by upload
+	logs, totalCount, err = store.GetUploadVisibilityLogs(ctx, shared.GetUploadVisibilityLogsOptions{RepositoryID: 50, UploadID: 1, Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error getting visibility logs: %s", err)
+	}
+	if totalCount != 2 {
+		t.Errorf("unexpected total count. want=%d have=%d", 2, totalCount)
+	}
+	if len(logs) != 1 || logs[0].Operation != shared.UploadVisibilityOperationHidden {
+		t.Errorf("unexpected visibility logs: %v", logs)
+	}
+}
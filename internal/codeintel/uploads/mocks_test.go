@@ -26,6 +26,7 @@ import (
 	workerutil "github.com/sourcegraph/sourcegraph/internal/workerutil"
 	store1 "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	precise "github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	trace "go.opentelemetry.io/otel/trace"
 )
 
 // MockStore is a mock implementation of the Store interface (from the
@@ -132,6 +133,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetUploadIDsWithReferences.
 	GetUploadIDsWithReferencesFunc *StoreGetUploadIDsWithReferencesFunc
+	// GetUploadVisibilityLogsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadVisibilityLogs.
+	GetUploadVisibilityLogsFunc *StoreGetUploadVisibilityLogsFunc
 	// GetUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUploads.
 	GetUploadsFunc *StoreGetUploadsFunc
@@ -165,6 +169,10 @@ type MockStore struct {
 	// InsertUploadFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertUpload.
 	InsertUploadFunc *StoreInsertUploadFunc
+	// InsertUploadVisibilityLogFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// InsertUploadVisibilityLog.
+	InsertUploadVisibilityLogFunc *StoreInsertUploadVisibilityLogFunc
 	// MarkFailedFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkFailed.
 	MarkFailedFunc *StoreMarkFailedFunc
@@ -399,6 +407,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUploadVisibilityLogsFunc: &StoreGetUploadVisibilityLogsFunc{
+			defaultHook: func(context.Context, shared.GetUploadVisibilityLogsOptions) (r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+				return
+			},
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) (r0 []shared.Upload, r1 int, r2 error) {
 				return
@@ -449,6 +462,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertUploadVisibilityLogFunc: &StoreInsertUploadVisibilityLogFunc{
+			defaultHook: func(context.Context, int, string, string) (r0 error) {
+				return
+			},
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: func(context.Context, int, string) (r0 error) {
 				return
@@ -726,6 +744,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUploadIDsWithReferences")
 			},
 		},
+		GetUploadVisibilityLogsFunc: &StoreGetUploadVisibilityLogsFunc{
+			defaultHook: func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+				panic("unexpected invocation of MockStore.GetUploadVisibilityLogs")
+			},
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) ([]shared.Upload, int, error) {
 				panic("unexpected invocation of MockStore.GetUploads")
@@ -776,6 +799,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertUpload")
 			},
 		},
+		InsertUploadVisibilityLogFunc: &StoreInsertUploadVisibilityLogFunc{
+			defaultHook: func(context.Context, int, string, string) error {
+				panic("unexpected invocation of MockStore.InsertUploadVisibilityLog")
+			},
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: func(context.Context, int, string) error {
 				panic("unexpected invocation of MockStore.MarkFailed")
@@ -993,6 +1021,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetUploadIDsWithReferencesFunc: &StoreGetUploadIDsWithReferencesFunc{
 			defaultHook: i.GetUploadIDsWithReferences,
 		},
+		GetUploadVisibilityLogsFunc: &StoreGetUploadVisibilityLogsFunc{
+			defaultHook: i.GetUploadVisibilityLogs,
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: i.GetUploads,
 		},
@@ -1023,6 +1054,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: i.InsertUpload,
 		},
+		InsertUploadVisibilityLogFunc: &StoreInsertUploadVisibilityLogFunc{
+			defaultHook: i.InsertUploadVisibilityLog,
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: i.MarkFailed,
 		},
@@ -4464,6 +4498,118 @@ func (c StoreGetUploadIDsWithReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
+// StoreGetUploadVisibilityLogsFunc describes the behavior when the
+// GetUploadVisibilityLogs method of the parent MockStore instance is
+// invoked.
+type StoreGetUploadVisibilityLogsFunc struct {
+	defaultHook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)
+	hooks       []func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)
+	history     []StoreGetUploadVisibilityLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadVisibilityLogs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadVisibilityLogs(v0 context.Context, v1 shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+	r0, r1, r2 := m.GetUploadVisibilityLogsFunc.nextHook()(v0, v1)
+	m.GetUploadVisibilityLogsFunc.appendCall(StoreGetUploadVisibilityLogsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadVisibilityLogs method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUploadVisibilityLogsFunc) SetDefaultHook(hook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadVisibilityLogs method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetUploadVisibilityLogsFunc) PushHook(hook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadVisibilityLogsFunc) SetDefaultReturn(r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadVisibilityLogsFunc) PushReturn(r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+	f.PushHook(func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetUploadVisibilityLogsFunc) nextHook() func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadVisibilityLogsFunc) appendCall(r0 StoreGetUploadVisibilityLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadVisibilityLogsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUploadVisibilityLogsFunc) History() []StoreGetUploadVisibilityLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadVisibilityLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadVisibilityLogsFuncCall is an object that describes an
+// invocation of method GetUploadVisibilityLogs on an instance of MockStore.
+type StoreGetUploadVisibilityLogsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared.GetUploadVisibilityLogsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.UploadVisibilityLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadVisibilityLogsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadVisibilityLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetUploadsFunc describes the behavior when the GetUploads method of
 // the parent MockStore instance is invoked.
 type StoreGetUploadsFunc struct {
@@ -5580,6 +5726,119 @@ func (c StoreInsertUploadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreInsertUploadVisibilityLogFunc describes the behavior when the
+// InsertUploadVisibilityLog method of the parent MockStore instance is
+// invoked.
+type StoreInsertUploadVisibilityLogFunc struct {
+	defaultHook func(context.Context, int, string, string) error
+	hooks       []func(context.Context, int, string, string) error
+	history     []StoreInsertUploadVisibilityLogFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertUploadVisibilityLog delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertUploadVisibilityLog(v0 context.Context, v1 int, v2 string, v3 string) error {
+	r0 := m.InsertUploadVisibilityLogFunc.nextHook()(v0, v1, v2, v3)
+	m.InsertUploadVisibilityLogFunc.appendCall(StoreInsertUploadVisibilityLogFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertUploadVisibilityLog method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertUploadVisibilityLogFunc) SetDefaultHook(hook func(context.Context, int, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertUploadVisibilityLog method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreInsertUploadVisibilityLogFunc) PushHook(hook func(context.Context, int, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertUploadVisibilityLogFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertUploadVisibilityLogFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string, string) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertUploadVisibilityLogFunc) nextHook() func(context.Context, int, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertUploadVisibilityLogFunc) appendCall(r0 StoreInsertUploadVisibilityLogFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertUploadVisibilityLogFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertUploadVisibilityLogFunc) History() []StoreInsertUploadVisibilityLogFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertUploadVisibilityLogFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertUploadVisibilityLogFuncCall is an object that describes an
+// invocation of method InsertUploadVisibilityLog on an instance of
+// MockStore.
+type StoreInsertUploadVisibilityLogFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertUploadVisibilityLogFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertUploadVisibilityLogFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreMarkFailedFunc describes the behavior when the MarkFailed method of
 // the parent MockStore instance is invoked.
 type StoreMarkFailedFunc struct {
@@ -8395,10 +8654,17 @@ type MockLSIFStore struct {
 	// object controlling the behavior of the method
 	// DeleteLsifDataByUploadIds.
 	DeleteLsifDataByUploadIdsFunc *LSIFStoreDeleteLsifDataByUploadIdsFunc
+	// DeleteProcessingCheckpointFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteProcessingCheckpoint.
+	DeleteProcessingCheckpointFunc *LSIFStoreDeleteProcessingCheckpointFunc
 	// DeleteUnreferencedDocumentsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// DeleteUnreferencedDocuments.
 	DeleteUnreferencedDocumentsFunc *LSIFStoreDeleteUnreferencedDocumentsFunc
+	// GetProcessingCheckpointFunc is an instance of a mock function object
+	// controlling the behavior of the method GetProcessingCheckpoint.
+	GetProcessingCheckpointFunc *LSIFStoreGetProcessingCheckpointFunc
 	// IDsWithMetaFunc is an instance of a mock function object controlling
 	// the behavior of the method IDsWithMeta.
 	IDsWithMetaFunc *LSIFStoreIDsWithMetaFunc
@@ -8438,11 +8704,21 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return
 			},
 		},
+		DeleteProcessingCheckpointFunc: &LSIFStoreDeleteProcessingCheckpointFunc{
+			defaultHook: func(context.Context, int) (r0 error) {
+				return
+			},
+		},
 		DeleteUnreferencedDocumentsFunc: &LSIFStoreDeleteUnreferencedDocumentsFunc{
 			defaultHook: func(context.Context, int, time.Duration, time.Time) (r0 int, r1 int, r2 error) {
 				return
 			},
 		},
+		GetProcessingCheckpointFunc: &LSIFStoreGetProcessingCheckpointFunc{
+			defaultHook: func(context.Context, int) (r0 lsifstore.ProcessingCheckpoint, r1 bool, r2 error) {
+				return
+			},
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: func(context.Context, []int) (r0 []int, r1 error) {
 				return
@@ -8495,11 +8771,21 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.DeleteLsifDataByUploadIds")
 			},
 		},
+		DeleteProcessingCheckpointFunc: &LSIFStoreDeleteProcessingCheckpointFunc{
+			defaultHook: func(context.Context, int) error {
+				panic("unexpected invocation of MockLSIFStore.DeleteProcessingCheckpoint")
+			},
+		},
 		DeleteUnreferencedDocumentsFunc: &LSIFStoreDeleteUnreferencedDocumentsFunc{
 			defaultHook: func(context.Context, int, time.Duration, time.Time) (int, int, error) {
 				panic("unexpected invocation of MockLSIFStore.DeleteUnreferencedDocuments")
 			},
 		},
+		GetProcessingCheckpointFunc: &LSIFStoreGetProcessingCheckpointFunc{
+			defaultHook: func(context.Context, int) (lsifstore.ProcessingCheckpoint, bool, error) {
+				panic("unexpected invocation of MockLSIFStore.GetProcessingCheckpoint")
+			},
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: func(context.Context, []int) ([]int, error) {
 				panic("unexpected invocation of MockLSIFStore.IDsWithMeta")
@@ -8548,9 +8834,15 @@ func NewMockLSIFStoreFrom(i lsifstore.Store) *MockLSIFStore {
 		DeleteLsifDataByUploadIdsFunc: &LSIFStoreDeleteLsifDataByUploadIdsFunc{
 			defaultHook: i.DeleteLsifDataByUploadIds,
 		},
+		DeleteProcessingCheckpointFunc: &LSIFStoreDeleteProcessingCheckpointFunc{
+			defaultHook: i.DeleteProcessingCheckpoint,
+		},
 		DeleteUnreferencedDocumentsFunc: &LSIFStoreDeleteUnreferencedDocumentsFunc{
 			defaultHook: i.DeleteUnreferencedDocuments,
 		},
+		GetProcessingCheckpointFunc: &LSIFStoreGetProcessingCheckpointFunc{
+			defaultHook: i.GetProcessingCheckpoint,
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: i.IDsWithMeta,
 		},
@@ -8800,37 +9092,37 @@ func (c LSIFStoreDeleteLsifDataByUploadIdsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// LSIFStoreDeleteUnreferencedDocumentsFunc describes the behavior when the
-// DeleteUnreferencedDocuments method of the parent MockLSIFStore instance
-// is invoked.
-type LSIFStoreDeleteUnreferencedDocumentsFunc struct {
-	defaultHook func(context.Context, int, time.Duration, time.Time) (int, int, error)
-	hooks       []func(context.Context, int, time.Duration, time.Time) (int, int, error)
-	history     []LSIFStoreDeleteUnreferencedDocumentsFuncCall
+// LSIFStoreDeleteProcessingCheckpointFunc describes the behavior when the
+// DeleteProcessingCheckpoint method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreDeleteProcessingCheckpointFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []LSIFStoreDeleteProcessingCheckpointFuncCall
 	mutex       sync.Mutex
 }
 
-// DeleteUnreferencedDocuments delegates to the next hook function in the
+// DeleteProcessingCheckpoint delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockLSIFStore) DeleteUnreferencedDocuments(v0 context.Context, v1 int, v2 time.Duration, v3 time.Time) (int, int, error) {
-	r0, r1, r2 := m.DeleteUnreferencedDocumentsFunc.nextHook()(v0, v1, v2, v3)
-	m.DeleteUnreferencedDocumentsFunc.appendCall(LSIFStoreDeleteUnreferencedDocumentsFuncCall{v0, v1, v2, v3, r0, r1, r2})
-	return r0, r1, r2
+func (m *MockLSIFStore) DeleteProcessingCheckpoint(v0 context.Context, v1 int) error {
+	r0 := m.DeleteProcessingCheckpointFunc.nextHook()(v0, v1)
+	m.DeleteProcessingCheckpointFunc.appendCall(LSIFStoreDeleteProcessingCheckpointFuncCall{v0, v1, r0})
+	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// DeleteUnreferencedDocuments method of the parent MockLSIFStore instance
-// is invoked and the hook queue is empty.
-func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) SetDefaultHook(hook func(context.Context, int, time.Duration, time.Time) (int, int, error)) {
+// DeleteProcessingCheckpoint method of the parent MockLSIFStore instance is
+// invoked and the hook queue is empty.
+func (f *LSIFStoreDeleteProcessingCheckpointFunc) SetDefaultHook(hook func(context.Context, int) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DeleteUnreferencedDocuments method of the parent MockLSIFStore instance
+// DeleteProcessingCheckpoint method of the parent MockLSIFStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) PushHook(hook func(context.Context, int, time.Duration, time.Time) (int, int, error)) {
+func (f *LSIFStoreDeleteProcessingCheckpointFunc) PushHook(hook func(context.Context, int) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -8838,20 +9130,20 @@ func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, int, time.Duration, time.Time) (int, int, error) {
-		return r0, r1, r2
+func (f *LSIFStoreDeleteProcessingCheckpointFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) PushReturn(r0 int, r1 int, r2 error) {
-	f.PushHook(func(context.Context, int, time.Duration, time.Time) (int, int, error) {
-		return r0, r1, r2
+func (f *LSIFStoreDeleteProcessingCheckpointFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
 	})
 }
 
-func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) nextHook() func(context.Context, int, time.Duration, time.Time) (int, int, error) {
+func (f *LSIFStoreDeleteProcessingCheckpointFunc) nextHook() func(context.Context, int) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -8864,48 +9156,156 @@ func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) nextHook() func(context.Conte
 	return hook
 }
 
-func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) appendCall(r0 LSIFStoreDeleteUnreferencedDocumentsFuncCall) {
+func (f *LSIFStoreDeleteProcessingCheckpointFunc) appendCall(r0 LSIFStoreDeleteProcessingCheckpointFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// LSIFStoreDeleteUnreferencedDocumentsFuncCall objects describing the
-// invocations of this function.
-func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) History() []LSIFStoreDeleteUnreferencedDocumentsFuncCall {
+// History returns a sequence of LSIFStoreDeleteProcessingCheckpointFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreDeleteProcessingCheckpointFunc) History() []LSIFStoreDeleteProcessingCheckpointFuncCall {
 	f.mutex.Lock()
-	history := make([]LSIFStoreDeleteUnreferencedDocumentsFuncCall, len(f.history))
+	history := make([]LSIFStoreDeleteProcessingCheckpointFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LSIFStoreDeleteUnreferencedDocumentsFuncCall is an object that describes
-// an invocation of method DeleteUnreferencedDocuments on an instance of
+// LSIFStoreDeleteProcessingCheckpointFuncCall is an object that describes
+// an invocation of method DeleteProcessingCheckpoint on an instance of
 // MockLSIFStore.
-type LSIFStoreDeleteUnreferencedDocumentsFuncCall struct {
+type LSIFStoreDeleteProcessingCheckpointFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 time.Duration
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 int
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreDeleteProcessingCheckpointFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreDeleteProcessingCheckpointFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreDeleteUnreferencedDocumentsFunc describes the behavior when the
+// DeleteUnreferencedDocuments method of the parent MockLSIFStore instance
+// is invoked.
+type LSIFStoreDeleteUnreferencedDocumentsFunc struct {
+	defaultHook func(context.Context, int, time.Duration, time.Time) (int, int, error)
+	hooks       []func(context.Context, int, time.Duration, time.Time) (int, int, error)
+	history     []LSIFStoreDeleteUnreferencedDocumentsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteUnreferencedDocuments delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) DeleteUnreferencedDocuments(v0 context.Context, v1 int, v2 time.Duration, v3 time.Time) (int, int, error) {
+	r0, r1, r2 := m.DeleteUnreferencedDocumentsFunc.nextHook()(v0, v1, v2, v3)
+	m.DeleteUnreferencedDocumentsFunc.appendCall(LSIFStoreDeleteUnreferencedDocumentsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteUnreferencedDocuments method of the parent MockLSIFStore instance
+// is invoked and the hook queue is empty.
+func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) SetDefaultHook(hook func(context.Context, int, time.Duration, time.Time) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteUnreferencedDocuments method of the parent MockLSIFStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) PushHook(hook func(context.Context, int, time.Duration, time.Time) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, time.Duration, time.Time) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, time.Duration, time.Time) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) nextHook() func(context.Context, int, time.Duration, time.Time) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) appendCall(r0 LSIFStoreDeleteUnreferencedDocumentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// LSIFStoreDeleteUnreferencedDocumentsFuncCall objects describing the
+// invocations of this function.
+func (f *LSIFStoreDeleteUnreferencedDocumentsFunc) History() []LSIFStoreDeleteUnreferencedDocumentsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreDeleteUnreferencedDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreDeleteUnreferencedDocumentsFuncCall is an object that describes
+// an invocation of method DeleteUnreferencedDocuments on an instance of
+// MockLSIFStore.
+type LSIFStoreDeleteUnreferencedDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Duration
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
 	Result2 error
 }
 
@@ -8921,6 +9321,120 @@ func (c LSIFStoreDeleteUnreferencedDocumentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreGetProcessingCheckpointFunc describes the behavior when the
+// GetProcessingCheckpoint method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreGetProcessingCheckpointFunc struct {
+	defaultHook func(context.Context, int) (lsifstore.ProcessingCheckpoint, bool, error)
+	hooks       []func(context.Context, int) (lsifstore.ProcessingCheckpoint, bool, error)
+	history     []LSIFStoreGetProcessingCheckpointFuncCall
+	mutex       sync.Mutex
+}
+
+// GetProcessingCheckpoint delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) GetProcessingCheckpoint(v0 context.Context, v1 int) (lsifstore.ProcessingCheckpoint, bool, error) {
+	r0, r1, r2 := m.GetProcessingCheckpointFunc.nextHook()(v0, v1)
+	m.GetProcessingCheckpointFunc.appendCall(LSIFStoreGetProcessingCheckpointFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetProcessingCheckpoint method of the parent MockLSIFStore instance is
+// invoked and the hook queue is empty.
+func (f *LSIFStoreGetProcessingCheckpointFunc) SetDefaultHook(hook func(context.Context, int) (lsifstore.ProcessingCheckpoint, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetProcessingCheckpoint method of the parent MockLSIFStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LSIFStoreGetProcessingCheckpointFunc) PushHook(hook func(context.Context, int) (lsifstore.ProcessingCheckpoint, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreGetProcessingCheckpointFunc) SetDefaultReturn(r0 lsifstore.ProcessingCheckpoint, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (lsifstore.ProcessingCheckpoint, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreGetProcessingCheckpointFunc) PushReturn(r0 lsifstore.ProcessingCheckpoint, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (lsifstore.ProcessingCheckpoint, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreGetProcessingCheckpointFunc) nextHook() func(context.Context, int) (lsifstore.ProcessingCheckpoint, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreGetProcessingCheckpointFunc) appendCall(r0 LSIFStoreGetProcessingCheckpointFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreGetProcessingCheckpointFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreGetProcessingCheckpointFunc) History() []LSIFStoreGetProcessingCheckpointFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreGetProcessingCheckpointFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreGetProcessingCheckpointFuncCall is an object that describes an
+// invocation of method GetProcessingCheckpoint on an instance of
+// MockLSIFStore.
+type LSIFStoreGetProcessingCheckpointFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 lsifstore.ProcessingCheckpoint
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreGetProcessingCheckpointFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreGetProcessingCheckpointFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // LSIFStoreIDsWithMetaFunc describes the behavior when the IDsWithMeta
 // method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreIDsWithMetaFunc struct {
@@ -9862,9 +10376,19 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *WorkerStoreAddExecutionLogEntryFunc[T]
+	// AppendExecutionLogEntryOutputFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// AppendExecutionLogEntryOutput.
+	AppendExecutionLogEntryOutputFunc *WorkerStoreAppendExecutionLogEntryOutputFunc[T]
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *WorkerStoreDequeueFunc[T]
+	// ExecutionLogsFunc is an instance of a mock function object
+	// controlling the behavior of the method ExecutionLogs.
+	ExecutionLogsFunc *WorkerStoreExecutionLogsFunc[T]
+	// FinishedCountFunc is an instance of a mock function object
+	// controlling the behavior of the method FinishedCount.
+	FinishedCountFunc *WorkerStoreFinishedCountFunc[T]
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *WorkerStoreHandleFunc[T]
@@ -9874,6 +10398,9 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// MarkCompleteFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkComplete.
 	MarkCompleteFunc *WorkerStoreMarkCompleteFunc[T]
+	// MarkDeadlineExceededFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkDeadlineExceeded.
+	MarkDeadlineExceededFunc *WorkerStoreMarkDeadlineExceededFunc[T]
 	// MarkErroredFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkErrored.
 	MarkErroredFunc *WorkerStoreMarkErroredFunc[T]
@@ -9883,6 +10410,9 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// MaxDurationInQueueFunc is an instance of a mock function object
 	// controlling the behavior of the method MaxDurationInQueue.
 	MaxDurationInQueueFunc *WorkerStoreMaxDurationInQueueFunc[T]
+	// ProcessingCountFunc is an instance of a mock function object
+	// controlling the behavior of the method ProcessingCount.
+	ProcessingCountFunc *WorkerStoreProcessingCountFunc[T]
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *WorkerStoreQueuedCountFunc[T]
@@ -9892,6 +10422,9 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// ResetStalledFunc is an instance of a mock function object controlling
 	// the behavior of the method ResetStalled.
 	ResetStalledFunc *WorkerStoreResetStalledFunc[T]
+	// TraceContextFunc is an instance of a mock function object controlling
+	// the behavior of the method TraceContext.
+	TraceContextFunc *WorkerStoreTraceContextFunc[T]
 	// UpdateExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateExecutionLogEntry.
 	UpdateExecutionLogEntryFunc *WorkerStoreUpdateExecutionLogEntryFunc[T]
@@ -9909,11 +10442,26 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &WorkerStoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) (r0 error) {
+				return
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (r0 T, r1 bool, r2 error) {
 				return
 			},
 		},
+		ExecutionLogsFunc: &WorkerStoreExecutionLogsFunc[T]{
+			defaultHook: func(context.Context, int) (r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+				return
+			},
+		},
+		FinishedCountFunc: &WorkerStoreFinishedCountFunc[T]{
+			defaultHook: func(context.Context, time.Time) (r0 int, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
@@ -9929,6 +10477,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		MarkDeadlineExceededFunc: &WorkerStoreMarkDeadlineExceededFunc[T]{
+			defaultHook: func(context.Context) (r0 []int, r1 error) {
+				return
+			},
+		},
 		MarkErroredFunc: &WorkerStoreMarkErroredFunc[T]{
 			defaultHook: func(context.Context, int, string, store1.MarkFinalOptions) (r0 bool, r1 error) {
 				return
@@ -9944,6 +10497,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		ProcessingCountFunc: &WorkerStoreProcessingCountFunc[T]{
+			defaultHook: func(context.Context) (r0 int, r1 error) {
+				return
+			},
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (r0 int, r1 error) {
 				return
@@ -9959,6 +10517,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		TraceContextFunc: &WorkerStoreTraceContextFunc[T]{
+			defaultHook: func(context.Context, int) (r0 trace.SpanContext, r1 error) {
+				return
+			},
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (r0 error) {
 				return
@@ -9981,11 +10544,26 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.AddExecutionLogEntry")
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &WorkerStoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error {
+				panic("unexpected invocation of MockWorkerStore.AppendExecutionLogEntryOutput")
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (T, bool, error) {
 				panic("unexpected invocation of MockWorkerStore.Dequeue")
 			},
 		},
+		ExecutionLogsFunc: &WorkerStoreExecutionLogsFunc[T]{
+			defaultHook: func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+				panic("unexpected invocation of MockWorkerStore.ExecutionLogs")
+			},
+		},
+		FinishedCountFunc: &WorkerStoreFinishedCountFunc[T]{
+			defaultHook: func(context.Context, time.Time) (int, error) {
+				panic("unexpected invocation of MockWorkerStore.FinishedCount")
+			},
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockWorkerStore.Handle")
@@ -10001,6 +10579,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.MarkComplete")
 			},
 		},
+		MarkDeadlineExceededFunc: &WorkerStoreMarkDeadlineExceededFunc[T]{
+			defaultHook: func(context.Context) ([]int, error) {
+				panic("unexpected invocation of MockWorkerStore.MarkDeadlineExceeded")
+			},
+		},
 		MarkErroredFunc: &WorkerStoreMarkErroredFunc[T]{
 			defaultHook: func(context.Context, int, string, store1.MarkFinalOptions) (bool, error) {
 				panic("unexpected invocation of MockWorkerStore.MarkErrored")
@@ -10016,6 +10599,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.MaxDurationInQueue")
 			},
 		},
+		ProcessingCountFunc: &WorkerStoreProcessingCountFunc[T]{
+			defaultHook: func(context.Context) (int, error) {
+				panic("unexpected invocation of MockWorkerStore.ProcessingCount")
+			},
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (int, error) {
 				panic("unexpected invocation of MockWorkerStore.QueuedCount")
@@ -10031,6 +10619,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.ResetStalled")
 			},
 		},
+		TraceContextFunc: &WorkerStoreTraceContextFunc[T]{
+			defaultHook: func(context.Context, int) (trace.SpanContext, error) {
+				panic("unexpected invocation of MockWorkerStore.TraceContext")
+			},
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) error {
 				panic("unexpected invocation of MockWorkerStore.UpdateExecutionLogEntry")
@@ -10052,9 +10645,18 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		AddExecutionLogEntryFunc: &WorkerStoreAddExecutionLogEntryFunc[T]{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		AppendExecutionLogEntryOutputFunc: &WorkerStoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: i.AppendExecutionLogEntryOutput,
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: i.Dequeue,
 		},
+		ExecutionLogsFunc: &WorkerStoreExecutionLogsFunc[T]{
+			defaultHook: i.ExecutionLogs,
+		},
+		FinishedCountFunc: &WorkerStoreFinishedCountFunc[T]{
+			defaultHook: i.FinishedCount,
+		},
 		HandleFunc: &WorkerStoreHandleFunc[T]{
 			defaultHook: i.Handle,
 		},
@@ -10064,6 +10666,9 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		MarkCompleteFunc: &WorkerStoreMarkCompleteFunc[T]{
 			defaultHook: i.MarkComplete,
 		},
+		MarkDeadlineExceededFunc: &WorkerStoreMarkDeadlineExceededFunc[T]{
+			defaultHook: i.MarkDeadlineExceeded,
+		},
 		MarkErroredFunc: &WorkerStoreMarkErroredFunc[T]{
 			defaultHook: i.MarkErrored,
 		},
@@ -10073,6 +10678,9 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		MaxDurationInQueueFunc: &WorkerStoreMaxDurationInQueueFunc[T]{
 			defaultHook: i.MaxDurationInQueue,
 		},
+		ProcessingCountFunc: &WorkerStoreProcessingCountFunc[T]{
+			defaultHook: i.ProcessingCount,
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
@@ -10082,6 +10690,9 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		ResetStalledFunc: &WorkerStoreResetStalledFunc[T]{
 			defaultHook: i.ResetStalled,
 		},
+		TraceContextFunc: &WorkerStoreTraceContextFunc[T]{
+			defaultHook: i.TraceContext,
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: i.UpdateExecutionLogEntry,
 		},
@@ -10208,21 +10819,139 @@ func (c WorkerStoreAddExecutionLogEntryFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// WorkerStoreDequeueFunc describes the behavior when the Dequeue method of
-// the parent MockWorkerStore instance is invoked.
-type WorkerStoreDequeueFunc[T workerutil.Record] struct {
-	defaultHook func(context.Context, string, []*sqlf.Query) (T, bool, error)
-	hooks       []func(context.Context, string, []*sqlf.Query) (T, bool, error)
-	history     []WorkerStoreDequeueFuncCall[T]
+// WorkerStoreAppendExecutionLogEntryOutputFunc describes the behavior when
+// the AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance is invoked.
+type WorkerStoreAppendExecutionLogEntryOutputFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error
+	hooks       []func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error
+	history     []WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]
 	mutex       sync.Mutex
 }
 
-// Dequeue delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockWorkerStore[T]) Dequeue(v0 context.Context, v1 string, v2 []*sqlf.Query) (T, bool, error) {
-	r0, r1, r2 := m.DequeueFunc.nextHook()(v0, v1, v2)
-	m.DequeueFunc.appendCall(WorkerStoreDequeueFuncCall[T]{v0, v1, v2, r0, r1, r2})
-	return r0, r1, r2
+// AppendExecutionLogEntryOutput delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) AppendExecutionLogEntryOutput(v0 context.Context, v1 int, v2 int, v3 string, v4 store1.ExecutionLogEntryOptions) error {
+	r0 := m.AppendExecutionLogEntryOutputFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AppendExecutionLogEntryOutputFunc.appendCall(WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance is invoked and the hook queue is empty.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) SetDefaultHook(hook func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AppendExecutionLogEntryOutput method of the parent MockWorkerStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) PushHook(hook func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) nextHook() func(context.Context, int, int, string, store1.ExecutionLogEntryOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) appendCall(r0 WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// WorkerStoreAppendExecutionLogEntryOutputFuncCall objects describing the
+// invocations of this function.
+func (f *WorkerStoreAppendExecutionLogEntryOutputFunc[T]) History() []WorkerStoreAppendExecutionLogEntryOutputFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreAppendExecutionLogEntryOutputFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreAppendExecutionLogEntryOutputFuncCall is an object that
+// describes an invocation of method AppendExecutionLogEntryOutput on an
+// instance of MockWorkerStore.
+type WorkerStoreAppendExecutionLogEntryOutputFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 store1.ExecutionLogEntryOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreAppendExecutionLogEntryOutputFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// WorkerStoreDequeueFunc describes the behavior when the Dequeue method of
+// the parent MockWorkerStore instance is invoked.
+type WorkerStoreDequeueFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, string, []*sqlf.Query) (T, bool, error)
+	hooks       []func(context.Context, string, []*sqlf.Query) (T, bool, error)
+	history     []WorkerStoreDequeueFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// Dequeue delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) Dequeue(v0 context.Context, v1 string, v2 []*sqlf.Query) (T, bool, error) {
+	r0, r1, r2 := m.DequeueFunc.nextHook()(v0, v1, v2)
+	m.DequeueFunc.appendCall(WorkerStoreDequeueFuncCall[T]{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the Dequeue method of
@@ -10322,6 +11051,228 @@ func (c WorkerStoreDequeueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// WorkerStoreExecutionLogsFunc describes the behavior when the
+// ExecutionLogs method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreExecutionLogsFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)
+	hooks       []func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)
+	history     []WorkerStoreExecutionLogsFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// ExecutionLogs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) ExecutionLogs(v0 context.Context, v1 int) ([]executor.ExecutionLogEntry, string, bool, error) {
+	r0, r1, r2, r3 := m.ExecutionLogsFunc.nextHook()(v0, v1)
+	m.ExecutionLogsFunc.appendCall(WorkerStoreExecutionLogsFuncCall[T]{v0, v1, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the ExecutionLogs method
+// of the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreExecutionLogsFunc[T]) SetDefaultHook(hook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExecutionLogs method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreExecutionLogsFunc[T]) PushHook(hook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreExecutionLogsFunc[T]) SetDefaultReturn(r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreExecutionLogsFunc[T]) PushReturn(r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *WorkerStoreExecutionLogsFunc[T]) nextHook() func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreExecutionLogsFunc[T]) appendCall(r0 WorkerStoreExecutionLogsFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreExecutionLogsFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreExecutionLogsFunc[T]) History() []WorkerStoreExecutionLogsFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreExecutionLogsFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreExecutionLogsFuncCall is an object that describes an
+// invocation of method ExecutionLogs on an instance of MockWorkerStore.
+type WorkerStoreExecutionLogsFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []executor.ExecutionLogEntry
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreExecutionLogsFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreExecutionLogsFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// WorkerStoreFinishedCountFunc describes the behavior when the
+// FinishedCount method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreFinishedCountFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, time.Time) (int, error)
+	hooks       []func(context.Context, time.Time) (int, error)
+	history     []WorkerStoreFinishedCountFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// FinishedCount delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) FinishedCount(v0 context.Context, v1 time.Time) (int, error) {
+	r0, r1 := m.FinishedCountFunc.nextHook()(v0, v1)
+	m.FinishedCountFunc.appendCall(WorkerStoreFinishedCountFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FinishedCount method
+// of the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreFinishedCountFunc[T]) SetDefaultHook(hook func(context.Context, time.Time) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FinishedCount method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreFinishedCountFunc[T]) PushHook(hook func(context.Context, time.Time) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreFinishedCountFunc[T]) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreFinishedCountFunc[T]) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreFinishedCountFunc[T]) nextHook() func(context.Context, time.Time) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreFinishedCountFunc[T]) appendCall(r0 WorkerStoreFinishedCountFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreFinishedCountFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreFinishedCountFunc[T]) History() []WorkerStoreFinishedCountFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreFinishedCountFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreFinishedCountFuncCall is an object that describes an
+// invocation of method FinishedCount on an instance of MockWorkerStore.
+type WorkerStoreFinishedCountFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreFinishedCountFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreFinishedCountFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreHandleFunc describes the behavior when the Handle method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreHandleFunc[T workerutil.Record] struct {
@@ -10645,6 +11596,114 @@ func (c WorkerStoreMarkCompleteFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreMarkDeadlineExceededFunc describes the behavior when the
+// MarkDeadlineExceeded method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreMarkDeadlineExceededFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []WorkerStoreMarkDeadlineExceededFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// MarkDeadlineExceeded delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) MarkDeadlineExceeded(v0 context.Context) ([]int, error) {
+	r0, r1 := m.MarkDeadlineExceededFunc.nextHook()(v0)
+	m.MarkDeadlineExceededFunc.appendCall(WorkerStoreMarkDeadlineExceededFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the MarkDeadlineExceeded
+// method of the parent MockWorkerStore instance is invoked and the hook
+// queue is empty.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkDeadlineExceeded method of the parent MockWorkerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) PushHook(hook func(context.Context) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) nextHook() func(context.Context) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) appendCall(r0 WorkerStoreMarkDeadlineExceededFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreMarkDeadlineExceededFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreMarkDeadlineExceededFunc[T]) History() []WorkerStoreMarkDeadlineExceededFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreMarkDeadlineExceededFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreMarkDeadlineExceededFuncCall is an object that describes an
+// invocation of method MarkDeadlineExceeded on an instance of
+// MockWorkerStore.
+type WorkerStoreMarkDeadlineExceededFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreMarkDeadlineExceededFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreMarkDeadlineExceededFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreMarkErroredFunc describes the behavior when the MarkErrored
 // method of the parent MockWorkerStore instance is invoked.
 type WorkerStoreMarkErroredFunc[T workerutil.Record] struct {
@@ -10980,6 +12039,111 @@ func (c WorkerStoreMaxDurationInQueueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreProcessingCountFunc describes the behavior when the
+// ProcessingCount method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreProcessingCountFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) (int, error)
+	hooks       []func(context.Context) (int, error)
+	history     []WorkerStoreProcessingCountFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// ProcessingCount delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) ProcessingCount(v0 context.Context) (int, error) {
+	r0, r1 := m.ProcessingCountFunc.nextHook()(v0)
+	m.ProcessingCountFunc.appendCall(WorkerStoreProcessingCountFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ProcessingCount
+// method of the parent MockWorkerStore instance is invoked and the hook
+// queue is empty.
+func (f *WorkerStoreProcessingCountFunc[T]) SetDefaultHook(hook func(context.Context) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ProcessingCount method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreProcessingCountFunc[T]) PushHook(hook func(context.Context) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreProcessingCountFunc[T]) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreProcessingCountFunc[T]) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreProcessingCountFunc[T]) nextHook() func(context.Context) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreProcessingCountFunc[T]) appendCall(r0 WorkerStoreProcessingCountFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreProcessingCountFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreProcessingCountFunc[T]) History() []WorkerStoreProcessingCountFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreProcessingCountFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreProcessingCountFuncCall is an object that describes an
+// invocation of method ProcessingCount on an instance of MockWorkerStore.
+type WorkerStoreProcessingCountFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreProcessingCountFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreProcessingCountFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreQueuedCountFunc describes the behavior when the QueuedCount
 // method of the parent MockWorkerStore instance is invoked.
 type WorkerStoreQueuedCountFunc[T workerutil.Record] struct {
@@ -11304,6 +12468,114 @@ func (c WorkerStoreResetStalledFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// WorkerStoreTraceContextFunc describes the behavior when the TraceContext
+// method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreTraceContextFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) (trace.SpanContext, error)
+	hooks       []func(context.Context, int) (trace.SpanContext, error)
+	history     []WorkerStoreTraceContextFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// TraceContext delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) TraceContext(v0 context.Context, v1 int) (trace.SpanContext, error) {
+	r0, r1 := m.TraceContextFunc.nextHook()(v0, v1)
+	m.TraceContextFunc.appendCall(WorkerStoreTraceContextFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the TraceContext method
+// of the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreTraceContextFunc[T]) SetDefaultHook(hook func(context.Context, int) (trace.SpanContext, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TraceContext method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreTraceContextFunc[T]) PushHook(hook func(context.Context, int) (trace.SpanContext, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreTraceContextFunc[T]) SetDefaultReturn(r0 trace.SpanContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (trace.SpanContext, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreTraceContextFunc[T]) PushReturn(r0 trace.SpanContext, r1 error) {
+	f.PushHook(func(context.Context, int) (trace.SpanContext, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreTraceContextFunc[T]) nextHook() func(context.Context, int) (trace.SpanContext, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreTraceContextFunc[T]) appendCall(r0 WorkerStoreTraceContextFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreTraceContextFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreTraceContextFunc[T]) History() []WorkerStoreTraceContextFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreTraceContextFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreTraceContextFuncCall is an object that describes an invocation
+// of method TraceContext on an instance of MockWorkerStore.
+type WorkerStoreTraceContextFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 trace.SpanContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreTraceContextFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreTraceContextFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreUpdateExecutionLogEntryFunc describes the behavior when the
 // UpdateExecutionLogEntry method of the parent MockWorkerStore instance is
 // invoked.
@@ -49,6 +49,10 @@ func (s *Service) GetCommitGraphMetadata(ctx context.Context, repositoryID int)
 	return s.store.GetCommitGraphMetadata(ctx, repositoryID)
 }
 
+func (s *Service) GetUploadVisibilityLogs(ctx context.Context, opts shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+	return s.store.GetUploadVisibilityLogs(ctx, opts)
+}
+
 func (s *Service) GetDirtyRepositories(ctx context.Context) (_ []shared.DirtyRepository, err error) {
 	return s.store.GetDirtyRepositories(ctx)
 }
@@ -95,6 +95,33 @@ type UploadLog struct {
 	Operation         string
 }
 
+// UploadVisibilityLog records a change to the set of uploads visible from the tip of a
+// branch or tag, or the outcome of a commit graph recalculation for a repository.
+type UploadVisibilityLog struct {
+	ID              int
+	LogTimestamp    time.Time
+	RepositoryID    int
+	UploadID        *int
+	Operation       string
+	BranchOrTagName *string
+	IsDefaultBranch *bool
+	Reason          string
+}
+
+const (
+	UploadVisibilityOperationVisible                 = "visible"
+	UploadVisibilityOperationHidden                  = "hidden"
+	UploadVisibilityOperationCommitGraphUpdated      = "commit_graph_updated"
+	UploadVisibilityOperationCommitGraphUpdateFailed = "commit_graph_update_failed"
+)
+
+type GetUploadVisibilityLogsOptions struct {
+	RepositoryID int
+	UploadID     int
+	Limit        int
+	Offset       int
+}
+
 type Index struct {
 	ID                 int                          `json:"id"`
 	Commit             string                       `json:"commit"`
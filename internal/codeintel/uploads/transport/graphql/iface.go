@@ -29,6 +29,7 @@ type UploadsService interface {
 	ReindexUploads(ctx context.Context, opts uploadshared.ReindexUploadsOptions) error
 	ReindexUploadByID(ctx context.Context, id int) error
 	GetCommitGraphMetadata(ctx context.Context, repositoryID int) (stale bool, updatedAt *time.Time, err error)
+	GetUploadVisibilityLogs(ctx context.Context, opts uploadshared.GetUploadVisibilityLogsOptions) ([]uploadshared.UploadVisibilityLog, int, error)
 	GetRecentUploadsSummary(ctx context.Context, repositoryID int) ([]uploadshared.UploadsWithRepositoryNamespace, error)
 	GetLastUploadRetentionScanForRepository(ctx context.Context, repositoryID int) (*time.Time, error)
 	GetRecentIndexesSummary(ctx context.Context, repositoryID int) ([]uploadshared.IndexesWithRepositoryNamespace, error)
@@ -62,6 +62,9 @@ type MockUploadsService struct {
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *UploadsServiceGetUploadByIDFunc
+	// GetUploadVisibilityLogsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadVisibilityLogs.
+	GetUploadVisibilityLogsFunc *UploadsServiceGetUploadVisibilityLogsFunc
 	// GetUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUploads.
 	GetUploadsFunc *UploadsServiceGetUploadsFunc
@@ -163,6 +166,11 @@ func NewMockUploadsService() *MockUploadsService {
 				return
 			},
 		},
+		GetUploadVisibilityLogsFunc: &UploadsServiceGetUploadVisibilityLogsFunc{
+			defaultHook: func(context.Context, shared.GetUploadVisibilityLogsOptions) (r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+				return
+			},
+		},
 		GetUploadsFunc: &UploadsServiceGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) (r0 []shared.Upload, r1 int, r2 error) {
 				return
@@ -280,6 +288,11 @@ func NewStrictMockUploadsService() *MockUploadsService {
 				panic("unexpected invocation of MockUploadsService.GetUploadByID")
 			},
 		},
+		GetUploadVisibilityLogsFunc: &UploadsServiceGetUploadVisibilityLogsFunc{
+			defaultHook: func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+				panic("unexpected invocation of MockUploadsService.GetUploadVisibilityLogs")
+			},
+		},
 		GetUploadsFunc: &UploadsServiceGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) ([]shared.Upload, int, error) {
 				panic("unexpected invocation of MockUploadsService.GetUploads")
@@ -370,6 +383,9 @@ func NewMockUploadsServiceFrom(i UploadsService) *MockUploadsService {
 		GetUploadByIDFunc: &UploadsServiceGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
+		GetUploadVisibilityLogsFunc: &UploadsServiceGetUploadVisibilityLogsFunc{
+			defaultHook: i.GetUploadVisibilityLogs,
+		},
 		GetUploadsFunc: &UploadsServiceGetUploadsFunc{
 			defaultHook: i.GetUploads,
 		},
@@ -1952,6 +1968,121 @@ func (c UploadsServiceGetUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// UploadsServiceGetUploadVisibilityLogsFunc describes the behavior when the
+// GetUploadVisibilityLogs method of the parent MockUploadsService instance
+// is invoked.
+type UploadsServiceGetUploadVisibilityLogsFunc struct {
+	defaultHook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)
+	hooks       []func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)
+	history     []UploadsServiceGetUploadVisibilityLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadVisibilityLogs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUploadsService) GetUploadVisibilityLogs(v0 context.Context, v1 shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+	r0, r1, r2 := m.GetUploadVisibilityLogsFunc.nextHook()(v0, v1)
+	m.GetUploadVisibilityLogsFunc.appendCall(UploadsServiceGetUploadVisibilityLogsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadVisibilityLogs method of the parent MockUploadsService instance
+// is invoked and the hook queue is empty.
+func (f *UploadsServiceGetUploadVisibilityLogsFunc) SetDefaultHook(hook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadVisibilityLogs method of the parent MockUploadsService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadsServiceGetUploadVisibilityLogsFunc) PushHook(hook func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceGetUploadVisibilityLogsFunc) SetDefaultReturn(r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceGetUploadVisibilityLogsFunc) PushReturn(r0 []shared.UploadVisibilityLog, r1 int, r2 error) {
+	f.PushHook(func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadsServiceGetUploadVisibilityLogsFunc) nextHook() func(context.Context, shared.GetUploadVisibilityLogsOptions) ([]shared.UploadVisibilityLog, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceGetUploadVisibilityLogsFunc) appendCall(r0 UploadsServiceGetUploadVisibilityLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadsServiceGetUploadVisibilityLogsFuncCall objects describing the
+// invocations of this function.
+func (f *UploadsServiceGetUploadVisibilityLogsFunc) History() []UploadsServiceGetUploadVisibilityLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceGetUploadVisibilityLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceGetUploadVisibilityLogsFuncCall is an object that describes
+// an invocation of method GetUploadVisibilityLogs on an instance of
+// MockUploadsService.
+type UploadsServiceGetUploadVisibilityLogsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared.GetUploadVisibilityLogsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.UploadVisibilityLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceGetUploadVisibilityLogsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceGetUploadVisibilityLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UploadsServiceGetUploadsFunc describes the behavior when the GetUploads
 // method of the parent MockUploadsService instance is invoked.
 type UploadsServiceGetUploadsFunc struct {
@@ -2,14 +2,18 @@ package graphql
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"go.opentelemetry.io/otel/attribute"
 
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // 🚨 SECURITY: Only entrypoint is within the repository resolver so the user is already authenticated
@@ -29,18 +33,22 @@ func (r *rootResolver) CommitGraph(ctx context.Context, repoID graphql.ID) (_ re
 		return nil, err
 	}
 
-	return newCommitGraphResolver(stale, updatedAt), nil
+	return newCommitGraphResolver(r.uploadSvc, repositoryID, stale, updatedAt), nil
 }
 
 type commitGraphResolver struct {
-	stale     bool
-	updatedAt *time.Time
+	uploadSvc    UploadsService
+	repositoryID int
+	stale        bool
+	updatedAt    *time.Time
 }
 
-func newCommitGraphResolver(stale bool, updatedAt *time.Time) resolverstubs.CodeIntelligenceCommitGraphResolver {
+func newCommitGraphResolver(uploadSvc UploadsService, repositoryID int, stale bool, updatedAt *time.Time) resolverstubs.CodeIntelligenceCommitGraphResolver {
 	return &commitGraphResolver{
-		stale:     stale,
-		updatedAt: updatedAt,
+		uploadSvc:    uploadSvc,
+		repositoryID: repositoryID,
+		stale:        stale,
+		updatedAt:    updatedAt,
 	}
 }
 
@@ -51,3 +59,75 @@ func (r *commitGraphResolver) Stale() bool {
 func (r *commitGraphResolver) UpdatedAt() *gqlutil.DateTime {
 	return gqlutil.DateTimeOrNil(r.updatedAt)
 }
+
+func (r *commitGraphResolver) VisibilityLogs(ctx context.Context, args *resolverstubs.VisibilityLogsArgs) (resolverstubs.CodeIntelligenceVisibilityLogConnectionResolver, error) {
+	pageSize := 25
+	if args.First != nil {
+		pageSize = int(*args.First)
+	}
+
+	offset := 0
+	if args.After != nil {
+		after, err := strconv.Atoi(*args.After)
+		if err != nil || after < 0 {
+			return nil, errors.New("invalid cursor")
+		}
+		offset = after
+	}
+
+	uploadID := 0
+	if args.Upload != nil {
+		id, err := resolverstubs.UnmarshalID[int](*args.Upload)
+		if err != nil {
+			return nil, err
+		}
+		uploadID = id
+	}
+
+	logs, totalCount, err := r.uploadSvc.GetUploadVisibilityLogs(ctx, shared.GetUploadVisibilityLogsOptions{
+		RepositoryID: r.repositoryID,
+		UploadID:     uploadID,
+		Limit:        pageSize,
+		Offset:       offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]resolverstubs.CodeIntelligenceVisibilityLogResolver, 0, len(logs))
+	for _, log := range logs {
+		resolvers = append(resolvers, &visibilityLogResolver{log: log})
+	}
+
+	endCursor := ""
+	if newOffset := offset + pageSize; newOffset < totalCount {
+		endCursor = strconv.Itoa(newOffset)
+	}
+
+	return resolverstubs.NewCursorWithTotalCountConnectionResolver(resolvers, endCursor, int32(totalCount)), nil
+}
+
+type visibilityLogResolver struct {
+	log shared.UploadVisibilityLog
+}
+
+func (r *visibilityLogResolver) LogTimestamp() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.log.LogTimestamp}
+}
+
+func (r *visibilityLogResolver) Operation() string {
+	return strings.ToUpper(r.log.Operation)
+}
+
+func (r *visibilityLogResolver) UploadID() *graphql.ID {
+	if r.log.UploadID == nil {
+		return nil
+	}
+
+	id := resolverstubs.MarshalID("LSIFUpload", *r.log.UploadID)
+	return &id
+}
+
+func (r *visibilityLogResolver) BranchOrTagName() *string { return r.log.BranchOrTagName }
+func (r *visibilityLogResolver) IsDefaultBranch() *bool   { return r.log.IsDefaultBranch }
+func (r *visibilityLogResolver) Reason() string           { return r.log.Reason }
@@ -0,0 +1,183 @@
+package context
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+const (
+	// bm25K1 controls how quickly the score saturates as a term occurs more
+	// often in a chunk.
+	bm25K1 = 1.2
+	// bm25B controls how much the score is normalized by the chunk length.
+	bm25B = 0.75
+
+	// keywordCandidateMultiplier is the number of candidate files collected
+	// from keyword search for each requested result, so that BM25 has a
+	// candidate set to rank.
+	keywordCandidateMultiplier = 4
+)
+
+// keywordCandidate is a chunk returned by keyword search, along with the terms
+// it contains.
+type keywordCandidate struct {
+	chunk FileChunkContext
+	terms []string
+}
+
+// fileMatchToKeywordCandidates returns a candidate for each chunk match of the
+// file match. The path is included in the terms of every chunk, since a query
+// term matching the file name is a strong signal of relevance.
+func fileMatchToKeywordCandidates(fm *result.FileMatch) []keywordCandidate {
+	pathTerms := tokenize(fm.Path)
+	candidates := make([]keywordCandidate, 0, len(fm.ChunkMatches))
+	for _, cm := range fm.ChunkMatches {
+		// 4 lines of leading context, clamped to zero
+		startLine := max(0, cm.ContentStart.Line-4)
+		candidates = append(candidates, keywordCandidate{
+			chunk: FileChunkContext{
+				RepoName:  fm.Repo.Name,
+				RepoID:    fm.Repo.ID,
+				CommitID:  fm.CommitID,
+				Path:      fm.Path,
+				StartLine: startLine,
+				// depend on content fetching to trim to the end of the file
+				EndLine: startLine + 8,
+			},
+			terms: append(tokenize(cm.Content), pathTerms...),
+		})
+	}
+	return candidates
+}
+
+// rankKeywordCandidates scores the candidates with BM25 against the query,
+// using the candidates as the corpus for document frequencies, and returns up
+// to limit chunks in order of decreasing score. To provide some context
+// variety, at most one chunk is returned per file.
+func rankKeywordCandidates(query string, candidates []keywordCandidate, limit int) []FileChunkContext {
+	queryTerms := uniqueTerms(tokenize(query))
+	scores := bm25Scores(queryTerms, candidates)
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	// Use a stable sort so that ties keep the order of the search results.
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	type fileKey struct {
+		repo string
+		path string
+	}
+	seen := make(map[fileKey]struct{}, len(candidates))
+	res := make([]FileChunkContext, 0, min(limit, len(candidates)))
+	for _, i := range order {
+		if len(res) >= limit {
+			break
+		}
+		chunk := candidates[i].chunk
+		key := fileKey{repo: string(chunk.RepoName), path: chunk.Path}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		res = append(res, chunk)
+	}
+	return res
+}
+
+// bm25Scores returns the BM25 score of each candidate for the query terms.
+func bm25Scores(queryTerms []string, candidates []keywordCandidate) []float64 {
+	scores := make([]float64, len(candidates))
+	if len(candidates) == 0 || len(queryTerms) == 0 {
+		return scores
+	}
+
+	termFreqs := make([]map[string]int, len(candidates))
+	docFreqs := make(map[string]int, len(queryTerms))
+	totalLength := 0
+	for i, c := range candidates {
+		tf := make(map[string]int)
+		for _, term := range c.terms {
+			tf[term]++
+		}
+		for _, term := range queryTerms {
+			if tf[term] > 0 {
+				docFreqs[term]++
+			}
+		}
+		termFreqs[i] = tf
+		totalLength += len(c.terms)
+	}
+
+	n := float64(len(candidates))
+	avgLength := float64(totalLength) / n
+	if avgLength == 0 {
+		return scores
+	}
+
+	for i, c := range candidates {
+		lengthNorm := 1 - bm25B + bm25B*float64(len(c.terms))/avgLength
+		for _, term := range queryTerms {
+			tf := float64(termFreqs[i][term])
+			if tf == 0 {
+				continue
+			}
+			df := float64(docFreqs[term])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			scores[i] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*lengthNorm)
+		}
+	}
+	return scores
+}
+
+// tokenize splits text into lowercase terms on non-alphanumeric characters and
+// camelCase boundaries, so that "getUserByID" matches the query "user id".
+func tokenize(text string) []string {
+	var terms []string
+	var current []rune
+	flush := func() {
+		if len(current) > 1 {
+			terms = append(terms, strings.ToLower(string(current)))
+		}
+		current = current[:0]
+	}
+
+	runes := []rune(text)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := current[len(current)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// Split "userID" before "I" and "HTTPServer" before "S".
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return terms
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]struct{}, len(terms))
+	unique := terms[:0]
+	for _, term := range terms {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		unique = append(unique, term)
+	}
+	return unique
+}
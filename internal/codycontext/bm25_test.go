@@ -0,0 +1,88 @@
+package context
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{text: "", want: nil},
+		{text: "hello world", want: []string{"hello", "world"}},
+		{text: "getUserByID", want: []string{"get", "user", "by", "id"}},
+		{text: "HTTPServer.ListenAndServe()", want: []string{"http", "server", "listen", "and", "serve"}},
+		{text: "cmd/frontend/main_test.go", want: []string{"cmd", "frontend", "main", "test", "go"}},
+		{text: "a + b = c", want: nil},
+		{text: "sha256sum", want: []string{"sha256sum"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.text, func(t *testing.T) {
+			require.Equal(t, tc.want, tokenize(tc.text))
+		})
+	}
+}
+
+func TestRankKeywordCandidates(t *testing.T) {
+	fileMatch := func(path string, chunks ...string) *result.FileMatch {
+		fm := &result.FileMatch{File: result.File{
+			Repo: types.MinimalRepo{ID: 1, Name: "repo"},
+			Path: path,
+		}}
+		for i, content := range chunks {
+			fm.ChunkMatches = append(fm.ChunkMatches, result.ChunkMatch{
+				Content:      content,
+				ContentStart: result.Location{Line: 10 * (i + 1)},
+			})
+		}
+		return fm
+	}
+
+	var candidates []keywordCandidate
+	for _, fm := range []*result.FileMatch{
+		// Matches a common term only.
+		fileMatch("util.go", "func parse(input string) error { return nil }"),
+		// Matches both terms, but is longer.
+		fileMatch("long.go", "func parse() {} // token "+strings.Repeat("filler text ", 5)),
+		// Matches the rare term in the path and the content, in the second chunk.
+		fileMatch("tokenizer.go", "package main", "func parseToken(s string) token { return token{s} }"),
+		// Doesn't match anything.
+		fileMatch("other.go", "func other() {}"),
+	} {
+		candidates = append(candidates, fileMatchToKeywordCandidates(fm)...)
+	}
+
+	t.Run("ranks by relevance", func(t *testing.T) {
+		got := rankKeywordCandidates("parse token", candidates, 10)
+		var paths []string
+		for _, c := range got {
+			paths = append(paths, c.Path)
+		}
+		require.Equal(t, []string{"tokenizer.go", "long.go", "util.go", "other.go"}, paths)
+
+		// The best chunk of the file is used.
+		require.Equal(t, 16, got[0].StartLine)
+		require.Equal(t, 24, got[0].EndLine)
+	})
+
+	t.Run("respects limit", func(t *testing.T) {
+		got := rankKeywordCandidates("parse token", candidates, 1)
+		require.Len(t, got, 1)
+		require.Equal(t, "tokenizer.go", got[0].Path)
+	})
+
+	t.Run("keeps search order without query terms", func(t *testing.T) {
+		got := rankKeywordCandidates("!!", candidates, 2)
+		require.Len(t, got, 2)
+		require.Equal(t, "util.go", got[0].Path)
+		require.Equal(t, "long.go", got[1].Path)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	vdb "github.com/sourcegraph/sourcegraph/internal/embeddings/db"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search"
@@ -40,7 +41,7 @@ type FileChunkContext struct {
 	EndLine   int
 }
 
-func NewCodyContextClient(obsCtx *observation.Context, db database.DB, embeddingsClient embeddings.Client, searchClient client.SearchClient, getQdrantSearcher func() (vdb.VectorSearcher, error)) *CodyContextClient {
+func NewCodyContextClient(obsCtx *observation.Context, db database.DB, embeddingsClient embeddings.Client, searchClient client.SearchClient, codeNavService CodeNavService, gitserverClient gitserver.Client, getQdrantSearcher func() (vdb.VectorSearcher, error)) *CodyContextClient {
 	redMetrics := metrics.NewREDMetrics(
 		obsCtx.Registerer,
 		"codycontext_client",
@@ -62,12 +63,15 @@ func NewCodyContextClient(obsCtx *observation.Context, db database.DB, embedding
 		db:                db,
 		embeddingsClient:  embeddingsClient,
 		searchClient:      searchClient,
+		codeNavService:    codeNavService,
+		gitserverClient:   gitserverClient,
 		getQdrantSearcher: getQdrantSearcher,
 
 		obsCtx:                 obsCtx,
 		getCodyContextOp:       op("getCodyContext"),
 		getEmbeddingsContextOp: op("getEmbeddingsContext"),
 		getKeywordContextOp:    op("getKeywordContext"),
+		getPreciseContextOp:    op("getPreciseContext"),
 	}
 }
 
@@ -75,12 +79,15 @@ type CodyContextClient struct {
 	db                database.DB
 	embeddingsClient  embeddings.Client
 	searchClient      client.SearchClient
+	codeNavService    CodeNavService
+	gitserverClient   gitserver.Client
 	getQdrantSearcher func() (vdb.VectorSearcher, error)
 
 	obsCtx                 *observation.Context
 	getCodyContextOp       *observation.Operation
 	getEmbeddingsContextOp *observation.Operation
 	getKeywordContextOp    *observation.Operation
+	getPreciseContextOp    *observation.Operation
 }
 
 type GetContextArgs struct {
@@ -88,6 +95,9 @@ type GetContextArgs struct {
 	Query            string
 	CodeResultsCount int32
 	TextResultsCount int32
+	// Cursor is the optional location of the user's cursor. If set, the
+	// definitions of the symbols around it are included in the context.
+	Cursor *CursorPosition
 }
 
 func (a *GetContextArgs) RepoIDs() []api.RepoID {
@@ -104,6 +114,7 @@ func (a *GetContextArgs) Attrs() []attribute.KeyValue {
 		attribute.String("query", a.Query),
 		attribute.Int("codeResultsCount", int(a.CodeResultsCount)),
 		attribute.Int("textResultsCount", int(a.TextResultsCount)),
+		attribute.Bool("hasCursor", a.Cursor != nil),
 	}
 }
 
@@ -116,6 +127,22 @@ func (c *CodyContextClient) GetCodyContext(ctx context.Context, args GetContextA
 		return nil, err
 	}
 
+	// Precise results are the definitions of the symbols the user is looking
+	// at, so they are ranked ahead of embeddings and keyword results. They can
+	// use up to half of the code results budget, and whatever they leave unused
+	// is assigned to embeddings and keyword search.
+	preciseResults, err := c.getPreciseContext(ctx, GetContextArgs{
+		Cursor:           args.Cursor,
+		CodeResultsCount: args.CodeResultsCount / 2,
+	})
+	if err != nil {
+		// Precise context is an optional addition, so we still return the
+		// other results if it fails.
+		c.obsCtx.Logger.Warn("failed to get precise context", log.Error(err))
+		preciseResults = nil
+	}
+	codeResultsCount := args.CodeResultsCount - int32(len(preciseResults))
+
 	// NOTE: We use a pretty simple heuristic for combining results from
 	// embeddings and keyword search. We use the ratio of repos with embeddings
 	// to decide how many results out of our limit should be reserved for
@@ -126,14 +153,14 @@ func (c *CodyContextClient) GetCodyContext(ctx context.Context, args GetContextA
 	embeddingsArgs := GetContextArgs{
 		Repos:            embeddingRepos,
 		Query:            args.Query,
-		CodeResultsCount: int32(float32(args.CodeResultsCount) * embeddingsResultRatio),
+		CodeResultsCount: int32(float32(codeResultsCount) * embeddingsResultRatio),
 		TextResultsCount: int32(float32(args.TextResultsCount) * embeddingsResultRatio),
 	}
 	keywordArgs := GetContextArgs{
 		Repos: keywordRepos,
 		Query: args.Query,
 		// Assign the remaining result budget to keyword search
-		CodeResultsCount: args.CodeResultsCount - embeddingsArgs.CodeResultsCount,
+		CodeResultsCount: codeResultsCount - embeddingsArgs.CodeResultsCount,
 		TextResultsCount: args.TextResultsCount - embeddingsArgs.TextResultsCount,
 	}
 
@@ -155,7 +182,14 @@ func (c *CodyContextClient) GetCodyContext(ctx context.Context, args GetContextA
 		return nil, err
 	}
 
-	return append(embeddingsResults, keywordResults...), nil
+	results := preciseResults
+	for _, result := range append(embeddingsResults, keywordResults...) {
+		// Drop chunks that are already covered by a precise result.
+		if !overlapsAny(preciseResults, result) {
+			results = append(results, result)
+		}
+	}
+	return results, nil
 }
 
 // partitionRepos splits a set of repos into repos with embeddings and repos without embeddings
@@ -279,18 +313,23 @@ func (c *CodyContextClient) getKeywordContext(ctx context.Context, args GetConte
 			return nil, err
 		}
 
+		// Collect more files than we need, so that we return the best
+		// chunks according to BM25 rather than the first ones found.
+		candidateLimit := limit * keywordCandidateMultiplier
 		var (
-			mu        sync.Mutex
-			collected []FileChunkContext
+			mu         sync.Mutex
+			files      int
+			candidates []keywordCandidate
 		)
 		stream := streaming.StreamFunc(func(e streaming.SearchEvent) {
 			mu.Lock()
 			defer mu.Unlock()
 
 			for _, res := range e.Results {
-				if fm, ok := res.(*result.FileMatch); ok {
-					collected = append(collected, fileMatchToContextMatches(fm)...)
-					if len(collected) >= limit {
+				if fm, ok := res.(*result.FileMatch); ok && len(fm.ChunkMatches) > 0 {
+					candidates = append(candidates, fileMatchToKeywordCandidates(fm)...)
+					files++
+					if files >= candidateLimit {
 						cancel()
 						return
 					}
@@ -309,7 +348,9 @@ func (c *CodyContextClient) getKeywordContext(ctx context.Context, args GetConte
 			)
 		}
 
-		return collected, nil
+		mu.Lock()
+		defer mu.Unlock()
+		return rankKeywordCandidates(args.Query, candidates, limit), nil
 	}
 
 	p := pool.NewWithResults[[]FileChunkContext]().WithContext(ctx)
@@ -372,26 +413,3 @@ func (c *CodyContextClient) getEmbeddingsContextFromQdrant(ctx context.Context,
 	}
 	return res, nil
 }
-
-func fileMatchToContextMatches(fm *result.FileMatch) []FileChunkContext {
-	if len(fm.ChunkMatches) == 0 {
-		return nil
-	}
-
-	// To provide some context variety, we just use the top-ranked
-	// chunk (the first chunk) from each file
-
-	// 4 lines of leading context, clamped to zero
-	startLine := max(0, fm.ChunkMatches[0].ContentStart.Line-4)
-	// depend on content fetching to trim to the end of the file
-	endLine := startLine + 8
-
-	return []FileChunkContext{{
-		RepoName:  fm.Repo.Name,
-		RepoID:    fm.Repo.ID,
-		CommitID:  fm.CommitID,
-		Path:      fm.Path,
-		StartLine: startLine,
-		EndLine:   endLine,
-	}}
-}
@@ -0,0 +1,163 @@
+package context
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	codenavshared "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	// preciseContextLineWindow is the number of lines above and below the
+	// cursor in which symbols are resolved.
+	preciseContextLineWindow = 5
+
+	// preciseContextLeadingLines is the number of lines included before a
+	// definition, which usually hold its docstring.
+	preciseContextLeadingLines = 5
+
+	// preciseContextChunkLines is the total number of lines included for a
+	// definition, which covers its docstring, signature and the start of its
+	// body.
+	preciseContextChunkLines = 15
+
+	// maxPreciseIndexesPerMonikerSearch is passed to the request state, but
+	// unused since we only look up definitions within the closest indexes.
+	maxPreciseIndexesPerMonikerSearch = 500
+)
+
+// CodeNavService is the subset of the code navigation service used to resolve
+// the symbols around the user's cursor.
+type CodeNavService interface {
+	GetClosestDumpsForBlob(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) ([]uploadsshared.Dump, error)
+	GetRanges(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState, startLine, endLine int) ([]codenav.AdjustedCodeIntelligenceRange, error)
+}
+
+// CursorPosition is the location of the user's cursor, used to include the
+// definitions of the symbols the user is looking at in the context.
+type CursorPosition struct {
+	Repo     types.RepoIDName
+	CommitID api.CommitID
+	Path     string
+	// Line and Character are zero-based.
+	Line      int
+	Character int
+}
+
+// getPreciseContext uses precise code intelligence to find the definitions of
+// the symbols around the cursor. Results are ordered by the distance of the
+// symbol from the cursor, so the symbol under the cursor comes first.
+func (c *CodyContextClient) getPreciseContext(ctx context.Context, args GetContextArgs) (_ []FileChunkContext, err error) {
+	ctx, _, endObservation := c.getPreciseContextOp.With(ctx, &err, observation.Args{Attrs: args.Attrs()})
+	defer endObservation(1, observation.Args{})
+
+	cursor := args.Cursor
+	if c.codeNavService == nil || cursor == nil || args.CodeResultsCount == 0 {
+		return nil, nil
+	}
+
+	uploads, err := c.codeNavService.GetClosestDumpsForBlob(ctx, int(cursor.Repo.ID), string(cursor.CommitID), cursor.Path, false, "")
+	if err != nil || len(uploads) == 0 {
+		// Repositories without precise indexes are common, so don't fail the
+		// whole request because of them.
+		return nil, err
+	}
+
+	requestState := codenav.NewRequestState(
+		uploads,
+		c.db.Repos(),
+		authz.DefaultSubRepoPermsChecker,
+		c.gitserverClient,
+		&types.Repo{ID: cursor.Repo.ID, Name: cursor.Repo.Name},
+		string(cursor.CommitID),
+		cursor.Path,
+		maxPreciseIndexesPerMonikerSearch,
+		nil,
+	)
+
+	startLine := max(0, cursor.Line-preciseContextLineWindow)
+	endLine := cursor.Line + preciseContextLineWindow + 1
+	ranges, err := c.codeNavService.GetRanges(ctx, codenav.PositionalRequestArgs{
+		RequestArgs: codenav.RequestArgs{
+			RepositoryID: int(cursor.Repo.ID),
+			Commit:       string(cursor.CommitID),
+		},
+		Path:      cursor.Path,
+		Line:      cursor.Line,
+		Character: cursor.Character,
+	}, requestState, startLine, endLine)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return distanceToCursor(ranges[i].Range, cursor) < distanceToCursor(ranges[j].Range, cursor)
+	})
+
+	var res []FileChunkContext
+	for _, rn := range ranges {
+		for _, definition := range rn.Definitions {
+			if len(res) >= int(args.CodeResultsCount) {
+				return res, nil
+			}
+
+			definitionLine := definition.TargetRange.Start.Line
+			// Definitions right next to the cursor are already visible to the
+			// user, so there is no point in including them again.
+			if api.RepoID(definition.Dump.RepositoryID) == cursor.Repo.ID && definition.Path == cursor.Path && definitionLine >= startLine && definitionLine < endLine {
+				continue
+			}
+
+			chunkStartLine := max(0, definitionLine-preciseContextLeadingLines)
+			chunk := FileChunkContext{
+				RepoName:  api.RepoName(definition.Dump.RepositoryName),
+				RepoID:    api.RepoID(definition.Dump.RepositoryID),
+				CommitID:  api.CommitID(definition.TargetCommit),
+				Path:      definition.Path,
+				StartLine: chunkStartLine,
+				// Depend on content fetching to trim to the end of the file.
+				EndLine: chunkStartLine + preciseContextChunkLines,
+			}
+			if !overlapsAny(res, chunk) {
+				res = append(res, chunk)
+			}
+		}
+	}
+	return res, nil
+}
+
+// distanceToCursor returns a rough measure of how far a range is from the
+// cursor, weighing lines more heavily than characters.
+func distanceToCursor(r codenavshared.Range, cursor *CursorPosition) int {
+	lineDistance := abs(r.Start.Line - cursor.Line)
+	if lineDistance > 0 {
+		return lineDistance * 1000
+	}
+	if cursor.Character >= r.Start.Character && cursor.Character <= r.End.Character {
+		return 0
+	}
+	return min(abs(r.Start.Character-cursor.Character), abs(r.End.Character-cursor.Character))
+}
+
+// overlapsAny returns true if the given chunk overlaps with any of the chunks.
+func overlapsAny(chunks []FileChunkContext, chunk FileChunkContext) bool {
+	for _, other := range chunks {
+		if other.RepoID == chunk.RepoID && other.Path == chunk.Path && other.StartLine < chunk.EndLine && chunk.StartLine < other.EndLine {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
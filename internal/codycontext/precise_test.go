@@ -0,0 +1,139 @@
+package context
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	codenavshared "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakeCodeNavService struct {
+	uploads []uploadsshared.Dump
+	ranges  []codenav.AdjustedCodeIntelligenceRange
+
+	gotStartLine, gotEndLine int
+}
+
+func (s *fakeCodeNavService) GetClosestDumpsForBlob(context.Context, int, string, string, bool, string) ([]uploadsshared.Dump, error) {
+	return s.uploads, nil
+}
+
+func (s *fakeCodeNavService) GetRanges(_ context.Context, _ codenav.PositionalRequestArgs, _ codenav.RequestState, startLine, endLine int) ([]codenav.AdjustedCodeIntelligenceRange, error) {
+	s.gotStartLine, s.gotEndLine = startLine, endLine
+	return s.ranges, nil
+}
+
+func TestGetPreciseContext(t *testing.T) {
+	dump := uploadsshared.Dump{ID: 1, RepositoryID: 1, RepositoryName: "repo"}
+	otherDump := uploadsshared.Dump{ID: 2, RepositoryID: 2, RepositoryName: "other"}
+
+	newRange := func(line, startChar, endChar int, definitions ...codenavshared.UploadLocation) codenav.AdjustedCodeIntelligenceRange {
+		return codenav.AdjustedCodeIntelligenceRange{
+			Range: codenavshared.Range{
+				Start: codenavshared.Position{Line: line, Character: startChar},
+				End:   codenavshared.Position{Line: line, Character: endChar},
+			},
+			Definitions: definitions,
+		}
+	}
+	definition := func(dump uploadsshared.Dump, path string, line int) codenavshared.UploadLocation {
+		return codenavshared.UploadLocation{
+			Dump:         dump,
+			Path:         path,
+			TargetCommit: "deadbeef",
+			TargetRange: codenavshared.Range{
+				Start: codenavshared.Position{Line: line},
+				End:   codenavshared.Position{Line: line},
+			},
+		}
+	}
+
+	codeNav := &fakeCodeNavService{
+		uploads: []uploadsshared.Dump{dump},
+		ranges: []codenav.AdjustedCodeIntelligenceRange{
+			// A symbol further away from the cursor.
+			newRange(12, 0, 5, definition(otherDump, "lib/far.go", 2)),
+			// A symbol defined right next to the cursor is skipped.
+			newRange(9, 0, 5, definition(dump, "main.go", 8)),
+			// The symbol under the cursor.
+			newRange(10, 4, 8, definition(dump, "util.go", 40)),
+			// A symbol whose definition overlaps the one under the cursor.
+			newRange(10, 10, 12, definition(dump, "util.go", 45)),
+		},
+	}
+
+	client := NewCodyContextClient(
+		observation.TestContextTB(t),
+		dbmocks.NewMockDB(),
+		nil,
+		nil,
+		codeNav,
+		gitserver.NewMockClient(),
+		nil,
+	)
+
+	args := GetContextArgs{
+		CodeResultsCount: 5,
+		Cursor: &CursorPosition{
+			Repo:      types.RepoIDName{ID: 1, Name: "repo"},
+			CommitID:  "deadbeef",
+			Path:      "main.go",
+			Line:      10,
+			Character: 6,
+		},
+	}
+
+	t.Run("resolves definitions around the cursor", func(t *testing.T) {
+		results, err := client.getPreciseContext(context.Background(), args)
+		require.NoError(t, err)
+		require.Equal(t, 5, codeNav.gotStartLine)
+		require.Equal(t, 16, codeNav.gotEndLine)
+		require.Equal(t, []FileChunkContext{
+			{RepoName: "repo", RepoID: 1, CommitID: "deadbeef", Path: "util.go", StartLine: 35, EndLine: 50},
+			{RepoName: "other", RepoID: 2, CommitID: "deadbeef", Path: "lib/far.go", StartLine: 0, EndLine: 15},
+		}, results)
+	})
+
+	t.Run("respects the code results count", func(t *testing.T) {
+		args := args
+		args.CodeResultsCount = 1
+		results, err := client.getPreciseContext(context.Background(), args)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "util.go", results[0].Path)
+	})
+
+	t.Run("no cursor", func(t *testing.T) {
+		args := args
+		args.Cursor = nil
+		results, err := client.getPreciseContext(context.Background(), args)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("no precise index", func(t *testing.T) {
+		client := NewCodyContextClient(observation.TestContextTB(t), dbmocks.NewMockDB(), nil, nil, &fakeCodeNavService{}, gitserver.NewMockClient(), nil)
+		results, err := client.getPreciseContext(context.Background(), args)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+}
+
+func TestOverlapsAny(t *testing.T) {
+	chunks := []FileChunkContext{{RepoID: 1, Path: "a.go", StartLine: 10, EndLine: 20}}
+
+	require.True(t, overlapsAny(chunks, FileChunkContext{RepoID: 1, Path: "a.go", StartLine: 15, EndLine: 25}))
+	require.True(t, overlapsAny(chunks, FileChunkContext{RepoID: 1, Path: "a.go", StartLine: 0, EndLine: 11}))
+	require.False(t, overlapsAny(chunks, FileChunkContext{RepoID: 1, Path: "a.go", StartLine: 20, EndLine: 30}))
+	require.False(t, overlapsAny(chunks, FileChunkContext{RepoID: 1, Path: "b.go", StartLine: 10, EndLine: 20}))
+	require.False(t, overlapsAny(chunks, FileChunkContext{RepoID: api.RepoID(2), Path: "a.go", StartLine: 10, EndLine: 20}))
+}
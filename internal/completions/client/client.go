@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/codygateway"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/fireworks"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/openai"
+	"github.com/sourcegraph/sourcegraph/internal/completions/tokenusage"
 	"github.com/sourcegraph/sourcegraph/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -19,6 +20,7 @@ import (
 func Get(
 	logger log.Logger,
 	events *telemetry.EventRecorder,
+	usageStore tokenusage.Store,
 	endpoint string,
 	provider conftypes.CompletionsProviderName,
 	accessToken string,
@@ -27,6 +29,9 @@ func Get(
 	if err != nil {
 		return nil, err
 	}
+	if usageStore != nil {
+		client = newUsageRecordingClient(logger, usageStore, client)
+	}
 	return newObservedClient(logger, events, client), nil
 }
 
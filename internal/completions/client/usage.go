@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/completions/tokenusage"
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// usageRecordingClient records the number of tokens used by each request
+// of an authenticated user.
+type usageRecordingClient struct {
+	inner  types.CompletionsClient
+	store  tokenusage.Store
+	logger log.Logger
+}
+
+var _ types.CompletionsClient = (*usageRecordingClient)(nil)
+
+func newUsageRecordingClient(logger log.Logger, store tokenusage.Store, inner types.CompletionsClient) *usageRecordingClient {
+	return &usageRecordingClient{
+		inner:  inner,
+		store:  store,
+		logger: logger.Scoped("tokenusage"),
+	}
+}
+
+func (c *usageRecordingClient) Stream(ctx context.Context, feature types.CompletionsFeature, params types.CompletionRequestParameters, send types.SendCompletionEvent) error {
+	// Events contain the whole completion so far, so the last one holds the
+	// full completion.
+	var completion string
+	err := c.inner.Stream(ctx, feature, params, func(event types.CompletionResponse) error {
+		completion = event.Completion
+		return send(event)
+	})
+	// Failed requests are usually not billed by the providers, unless they
+	// failed after the completion started.
+	if err == nil || completion != "" {
+		c.record(ctx, feature, params, completion)
+	}
+	return err
+}
+
+func (c *usageRecordingClient) Complete(ctx context.Context, feature types.CompletionsFeature, params types.CompletionRequestParameters) (*types.CompletionResponse, error) {
+	resp, err := c.inner.Complete(ctx, feature, params)
+	if err != nil {
+		return nil, err
+	}
+	c.record(ctx, feature, params, resp.Completion)
+	return resp, nil
+}
+
+func (c *usageRecordingClient) record(ctx context.Context, feature types.CompletionsFeature, params types.CompletionRequestParameters, completion string) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || a.IsInternal() {
+		return
+	}
+
+	// Record the usage even if the request was cancelled by the client, since
+	// the tokens were still used.
+	err := c.store.Record(context.WithoutCancel(ctx), tokenusage.Usage{
+		UserID:           a.UID,
+		Feature:          feature,
+		Model:            params.Model,
+		PromptTokens:     tokenusage.EstimatePromptTokens(params),
+		CompletionTokens: tokenusage.EstimateTokens(completion),
+	})
+	if err != nil {
+		trace.Logger(ctx, c.logger).Warn("failed to record token usage", log.Error(err))
+	}
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/completions/tokenusage"
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeClient struct {
+	completions []string
+	err         error
+}
+
+func (c *fakeClient) Stream(_ context.Context, _ types.CompletionsFeature, _ types.CompletionRequestParameters, send types.SendCompletionEvent) error {
+	for _, completion := range c.completions {
+		if err := send(types.CompletionResponse{Completion: completion}); err != nil {
+			return err
+		}
+	}
+	return c.err
+}
+
+func (c *fakeClient) Complete(context.Context, types.CompletionsFeature, types.CompletionRequestParameters) (*types.CompletionResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &types.CompletionResponse{Completion: c.completions[len(c.completions)-1]}, nil
+}
+
+func TestUsageRecordingClient(t *testing.T) {
+	params := types.CompletionRequestParameters{
+		Model:    "claude-2",
+		Messages: []types.Message{{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: "12345678"}},
+	}
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	t.Run("stream", func(t *testing.T) {
+		store := tokenusage.NewMockStore()
+		c := newUsageRecordingClient(logtest.Scoped(t), store, &fakeClient{completions: []string{"abcd", "abcdefgh", "abcdefghijkl"}})
+		require.NoError(t, c.Stream(userCtx, types.CompletionsFeatureChat, params, func(types.CompletionResponse) error { return nil }))
+
+		require.Len(t, store.RecordFunc.History(), 1)
+		require.Equal(t, tokenusage.Usage{
+			UserID:           1,
+			Feature:          types.CompletionsFeatureChat,
+			Model:            "claude-2",
+			PromptTokens:     2,
+			CompletionTokens: 3,
+		}, store.RecordFunc.History()[0].Arg1)
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		store := tokenusage.NewMockStore()
+		c := newUsageRecordingClient(logtest.Scoped(t), store, &fakeClient{completions: []string{"abcd"}})
+		_, err := c.Complete(userCtx, types.CompletionsFeatureCode, params)
+		require.NoError(t, err)
+
+		require.Len(t, store.RecordFunc.History(), 1)
+		require.Equal(t, 1, store.RecordFunc.History()[0].Arg1.CompletionTokens)
+	})
+
+	t.Run("failed requests are not recorded", func(t *testing.T) {
+		store := tokenusage.NewMockStore()
+		c := newUsageRecordingClient(logtest.Scoped(t), store, &fakeClient{err: errors.New("boom")})
+		require.Error(t, c.Stream(userCtx, types.CompletionsFeatureChat, params, func(types.CompletionResponse) error { return nil }))
+		_, err := c.Complete(userCtx, types.CompletionsFeatureChat, params)
+		require.Error(t, err)
+		require.Empty(t, store.RecordFunc.History())
+	})
+
+	t.Run("anonymous requests are not recorded", func(t *testing.T) {
+		store := tokenusage.NewMockStore()
+		c := newUsageRecordingClient(logtest.Scoped(t), store, &fakeClient{completions: []string{"abcd"}})
+		_, err := c.Complete(context.Background(), types.CompletionsFeatureChat, params)
+		require.NoError(t, err)
+		require.Empty(t, store.RecordFunc.History())
+	})
+}
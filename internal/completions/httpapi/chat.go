@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/completions/tokenusage"
 	"github.com/sourcegraph/sourcegraph/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -27,6 +28,7 @@ func NewChatCompletionsStreamHandler(logger log.Logger, db database.DB) http.Han
 		logger,
 		db.Users(),
 		db.AccessTokens(),
+		tokenusage.NewStore(db),
 		telemetryrecorder.New(db),
 		types.CompletionsFeatureChat,
 		rl,
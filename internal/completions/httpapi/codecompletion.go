@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/completions/tokenusage"
 	"github.com/sourcegraph/sourcegraph/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -23,6 +24,7 @@ func NewCodeCompletionsHandler(logger log.Logger, db database.DB) http.Handler {
 		logger,
 		db.Users(),
 		db.AccessTokens(),
+		tokenusage.NewStore(db),
 		telemetryrecorder.New(db),
 		types.CompletionsFeatureCode,
 		rl,
@@ -26,12 +26,14 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/cody"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client"
+	"github.com/sourcegraph/sourcegraph/internal/completions/tokenusage"
 	"github.com/sourcegraph/sourcegraph/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/telemetry"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // maxRequestDuration is the maximum amount of time a request can take before
@@ -49,6 +51,7 @@ func newCompletionsHandler(
 	logger log.Logger,
 	userStore database.UserStore,
 	accessTokenStore database.AccessTokenStore,
+	usageStore tokenusage.Store,
 	events *telemetry.EventRecorder,
 	feature types.CompletionsFeature,
 	rl RateLimiter,
@@ -138,6 +141,7 @@ func newCompletionsHandler(
 		completionClient, err := client.Get(
 			logger,
 			events,
+			usageStore,
 			completionsConfig.Endpoint,
 			completionsConfig.Provider,
 			accessToken,
@@ -149,6 +153,18 @@ func newCompletionsHandler(
 		}
 
 		if !isCodyProEnabled || !isDotcom || !isProviderCodyGateway {
+			// Check the daily token budget.
+			if err := tokenusage.CheckBudget(ctx, usageStore); err != nil {
+				var budgetErr tokenusage.BudgetExceededError
+				if errors.As(err, &budgetErr) {
+					respondBudgetExceeded(w, budgetErr)
+					return
+				}
+				l.Warn("Token budget error", log.Error(err))
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
 			// Check rate limit.
 			err = rl.TryAcquire(ctx)
 			if err != nil {
@@ -189,6 +205,11 @@ func respondRateLimited(w http.ResponseWriter, err RateLimitExceededError, isDot
 	http.Error(w, err.Error(), http.StatusTooManyRequests)
 }
 
+func respondBudgetExceeded(w http.ResponseWriter, err tokenusage.BudgetExceededError) {
+	w.Header().Set("retry-after", err.RetryAfter.Format(time.RFC1123))
+	http.Error(w, err.Error(), http.StatusTooManyRequests)
+}
+
 // newSwitchingResponseHandler handles requests to an LLM provider, and wraps the correct
 // handler based on the requestParams.Stream flag.
 func newSwitchingResponseHandler(logger log.Logger, feature types.CompletionsFeature) func(ctx context.Context, requestParams types.CompletionRequestParameters, cc types.CompletionsClient, w http.ResponseWriter, userStore database.UserStore) {
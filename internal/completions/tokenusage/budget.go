@@ -0,0 +1,54 @@
+package tokenusage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// BudgetExceededError is returned when a user used up their daily token
+// budget.
+type BudgetExceededError struct {
+	Limit      int64
+	Used       int64
+	RetryAfter time.Time
+}
+
+func (e BudgetExceededError) Error() string {
+	return fmt.Sprintf("you exceeded your daily budget of %d LLM tokens (used: %d). Retry after %s", e.Limit, e.Used, e.RetryAfter.Truncate(time.Second))
+}
+
+// CheckBudget returns a BudgetExceededError if the user in the context already
+// used up their daily token budget. Requests of anonymous users and internal
+// actors are not budgeted.
+func CheckBudget(ctx context.Context, store Store) error {
+	cfg := conf.GetCompletionsConfig(conf.Get().SiteConfig())
+	if cfg == nil || cfg.PerUserDailyTokenBudget <= 0 {
+		return nil
+	}
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || a.IsInternal() {
+		return nil
+	}
+
+	now := time.Now()
+	used, err := store.GetUserTokensForDay(ctx, a.UID, now)
+	if err != nil {
+		return err
+	}
+
+	limit := int64(cfg.PerUserDailyTokenBudget)
+	if used >= limit {
+		return BudgetExceededError{
+			Limit: limit,
+			Used:  used,
+			// Budgets reset at midnight UTC.
+			RetryAfter: now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour),
+		}
+	}
+	return nil
+}
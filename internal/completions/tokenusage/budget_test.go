@@ -0,0 +1,62 @@
+package tokenusage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCheckBudget(t *testing.T) {
+	mockBudget := func(t *testing.T, budget int) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			CodyEnabled: pointers.Ptr(true),
+			LicenseKey:  "asdf",
+			Completions: &schema.Completions{
+				Provider:                "anthropic",
+				AccessToken:             "secret",
+				PerUserDailyTokenBudget: budget,
+			},
+		}})
+		t.Cleanup(func() { conf.Mock(nil) })
+	}
+
+	store := NewMockStore()
+	store.GetUserTokensForDayFunc.SetDefaultReturn(1000, nil)
+
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	t.Run("no budget", func(t *testing.T) {
+		mockBudget(t, 0)
+		require.NoError(t, CheckBudget(userCtx, store))
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		mockBudget(t, 1001)
+		require.NoError(t, CheckBudget(userCtx, store))
+	})
+
+	t.Run("budget exceeded", func(t *testing.T) {
+		mockBudget(t, 1000)
+		err := CheckBudget(userCtx, store)
+		require.Error(t, err)
+		budgetErr, ok := err.(BudgetExceededError)
+		require.True(t, ok)
+		require.Equal(t, int64(1000), budgetErr.Limit)
+		require.Equal(t, int64(1000), budgetErr.Used)
+		require.True(t, budgetErr.RetryAfter.After(time.Now()))
+		require.True(t, budgetErr.RetryAfter.Before(time.Now().Add(24*time.Hour)))
+	})
+
+	t.Run("anonymous and internal actors are not budgeted", func(t *testing.T) {
+		mockBudget(t, 1)
+		require.NoError(t, CheckBudget(context.Background(), store))
+		require.NoError(t, CheckBudget(actor.WithInternalActor(context.Background()), store))
+	})
+}
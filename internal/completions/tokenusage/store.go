@@ -0,0 +1,144 @@
+package tokenusage
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// Usage is the number of tokens used by a single completions request.
+type Usage struct {
+	UserID           int32
+	Feature          types.CompletionsFeature
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// UserUsage is the number of tokens used by a user for a feature and model
+// over a period of time.
+type UserUsage struct {
+	UserID           int32
+	Feature          types.CompletionsFeature
+	Model            string
+	PromptTokens     int64
+	CompletionTokens int64
+	RequestCount     int
+}
+
+// TotalTokens returns the sum of prompt and completion tokens.
+func (u UserUsage) TotalTokens() int64 {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+type ListOpts struct {
+	// Since and Until restrict the usage to the days in [Since, Until). A zero
+	// value means no restriction.
+	Since time.Time
+	Until time.Time
+	// UserID restricts the usage to a single user if non-zero.
+	UserID int32
+	// Limit is the maximum number of results to return if non-zero.
+	Limit int
+}
+
+// Store aggregates the number of LLM tokens used per user, feature, model and
+// day.
+type Store interface {
+	// Record adds the given usage to today's totals.
+	Record(ctx context.Context, usage Usage) error
+	// GetUserTokensForDay returns the total number of tokens used by the user
+	// on the day of the given time.
+	GetUserTokensForDay(ctx context.Context, userID int32, day time.Time) (int64, error)
+	// List returns the usage per user, feature and model, ordered by the total
+	// number of tokens used.
+	List(ctx context.Context, opts ListOpts) ([]UserUsage, error)
+}
+
+type store struct {
+	*basestore.Store
+}
+
+func NewStore(other basestore.ShareableStore) Store {
+	return &store{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+const recordQuery = `
+INSERT INTO cody_token_usage (user_id, feature, model, day, prompt_tokens, completion_tokens, request_count)
+VALUES (%s, %s, %s, %s, %s, %s, 1)
+ON CONFLICT (user_id, feature, model, day) DO UPDATE SET
+	prompt_tokens = cody_token_usage.prompt_tokens + EXCLUDED.prompt_tokens,
+	completion_tokens = cody_token_usage.completion_tokens + EXCLUDED.completion_tokens,
+	request_count = cody_token_usage.request_count + 1
+`
+
+func (s *store) Record(ctx context.Context, usage Usage) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		recordQuery,
+		usage.UserID,
+		string(usage.Feature),
+		usage.Model,
+		dayOf(time.Now()),
+		usage.PromptTokens,
+		usage.CompletionTokens,
+	))
+}
+
+const getUserTokensForDayQuery = `
+SELECT COALESCE(SUM(prompt_tokens + completion_tokens), 0)
+FROM cody_token_usage
+WHERE user_id = %s AND day = %s
+`
+
+func (s *store) GetUserTokensForDay(ctx context.Context, userID int32, day time.Time) (int64, error) {
+	tokens, _, err := basestore.ScanFirstInt64(s.Query(ctx, sqlf.Sprintf(getUserTokensForDayQuery, userID, dayOf(day))))
+	return tokens, err
+}
+
+const listQuery = `
+SELECT
+	user_id,
+	feature,
+	model,
+	SUM(prompt_tokens),
+	SUM(completion_tokens),
+	SUM(request_count)
+FROM cody_token_usage
+WHERE %s
+GROUP BY user_id, feature, model
+ORDER BY SUM(prompt_tokens + completion_tokens) DESC, user_id, feature, model
+%s
+`
+
+var scanUserUsages = basestore.NewSliceScanner(func(s dbutil.Scanner) (u UserUsage, err error) {
+	err = s.Scan(&u.UserID, &u.Feature, &u.Model, &u.PromptTokens, &u.CompletionTokens, &u.RequestCount)
+	return u, err
+})
+
+func (s *store) List(ctx context.Context, opts ListOpts) ([]UserUsage, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if !opts.Since.IsZero() {
+		conds = append(conds, sqlf.Sprintf("day >= %s", dayOf(opts.Since)))
+	}
+	if !opts.Until.IsZero() {
+		conds = append(conds, sqlf.Sprintf("day < %s", dayOf(opts.Until)))
+	}
+	if opts.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id = %s", opts.UserID))
+	}
+	limit := sqlf.Sprintf("")
+	if opts.Limit > 0 {
+		limit = sqlf.Sprintf("LIMIT %s", opts.Limit)
+	}
+	return scanUserUsages(s.Query(ctx, sqlf.Sprintf(listQuery, sqlf.Join(conds, "AND"), limit)))
+}
+
+// dayOf returns the UTC date of the given time, formatted for a date column.
+func dayOf(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
@@ -0,0 +1,63 @@
+package tokenusage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	alice, err := db.Users().Create(ctx, database.NewUser{Username: "alice"})
+	require.NoError(t, err)
+	bob, err := db.Users().Create(ctx, database.NewUser{Username: "bob"})
+	require.NoError(t, err)
+
+	store := NewStore(db)
+
+	for _, usage := range []Usage{
+		{UserID: alice.ID, Feature: types.CompletionsFeatureChat, Model: "claude-2", PromptTokens: 100, CompletionTokens: 10},
+		{UserID: alice.ID, Feature: types.CompletionsFeatureChat, Model: "claude-2", PromptTokens: 200, CompletionTokens: 20},
+		{UserID: alice.ID, Feature: types.CompletionsFeatureCode, Model: "starcoder", PromptTokens: 50, CompletionTokens: 5},
+		{UserID: bob.ID, Feature: types.CompletionsFeatureChat, Model: "claude-2", PromptTokens: 1000, CompletionTokens: 100},
+	} {
+		require.NoError(t, store.Record(ctx, usage))
+	}
+
+	tokens, err := store.GetUserTokensForDay(ctx, alice.ID, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, int64(385), tokens)
+
+	tokens, err = store.GetUserTokensForDay(ctx, alice.ID, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, tokens)
+
+	usages, err := store.List(ctx, ListOpts{})
+	require.NoError(t, err)
+	require.Equal(t, []UserUsage{
+		{UserID: bob.ID, Feature: types.CompletionsFeatureChat, Model: "claude-2", PromptTokens: 1000, CompletionTokens: 100, RequestCount: 1},
+		{UserID: alice.ID, Feature: types.CompletionsFeatureChat, Model: "claude-2", PromptTokens: 300, CompletionTokens: 30, RequestCount: 2},
+		{UserID: alice.ID, Feature: types.CompletionsFeatureCode, Model: "starcoder", PromptTokens: 50, CompletionTokens: 5, RequestCount: 1},
+	}, usages)
+
+	usages, err = store.List(ctx, ListOpts{UserID: alice.ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	require.Equal(t, types.CompletionsFeatureChat, usages[0].Feature)
+
+	usages, err = store.List(ctx, ListOpts{Since: time.Now().Add(24 * time.Hour)})
+	require.NoError(t, err)
+	require.Empty(t, usages)
+}
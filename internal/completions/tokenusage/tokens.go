@@ -0,0 +1,27 @@
+package tokenusage
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+)
+
+// charsPerToken is the average number of characters per token of the models
+// we support, for English text and code.
+const charsPerToken = 4
+
+// EstimateTokens returns an estimate of the number of tokens in the given
+// text. Not all providers report the number of tokens used, and we don't have
+// the tokenizers of every model, so we rely on an estimate for all of them to
+// keep the numbers comparable.
+func EstimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// EstimatePromptTokens returns an estimate of the number of tokens in the
+// prompt of the given request.
+func EstimatePromptTokens(params types.CompletionRequestParameters) int {
+	tokens := EstimateTokens(params.Prompt)
+	for _, m := range params.Messages {
+		tokens += EstimateTokens(m.Text)
+	}
+	return tokens
+}
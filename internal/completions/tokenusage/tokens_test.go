@@ -0,0 +1,24 @@
+package tokenusage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	require.Equal(t, 0, EstimateTokens(""))
+	require.Equal(t, 1, EstimateTokens("a"))
+	require.Equal(t, 1, EstimateTokens("abcd"))
+	require.Equal(t, 2, EstimateTokens("abcde"))
+
+	require.Equal(t, 4, EstimatePromptTokens(types.CompletionRequestParameters{
+		Prompt: "abcd",
+		Messages: []types.Message{
+			{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: "hello"},
+			{Speaker: types.ASISSTANT_MESSAGE_SPEAKER, Text: "hi"},
+		},
+	}))
+}
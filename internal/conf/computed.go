@@ -249,6 +249,13 @@ func CodeIntelAutoIndexingAllowGlobalPolicies() bool {
 	return false
 }
 
+func CodeIntelAutoIndexingResolveDependenciesOnNavigation() bool {
+	if enabled := Get().CodeIntelAutoIndexingResolveDependenciesOnNavigation; enabled != nil {
+		return *enabled
+	}
+	return false
+}
+
 func CodeIntelAutoIndexingPolicyRepositoryMatchLimit() int {
 	val := Get().CodeIntelAutoIndexingPolicyRepositoryMatchLimit
 	if val == nil || *val < -1 {
@@ -796,6 +803,7 @@ func GetCompletionsConfig(siteConfig schema.SiteConfiguration) (c *conftypes.Com
 		Endpoint:                         completionsConfig.Endpoint,
 		PerUserDailyLimit:                completionsConfig.PerUserDailyLimit,
 		PerUserCodeCompletionsDailyLimit: completionsConfig.PerUserCodeCompletionsDailyLimit,
+		PerUserDailyTokenBudget:          completionsConfig.PerUserDailyTokenBudget,
 		PerCommunityUserChatMonthlyLLMRequestLimit:             completionsConfig.PerCommunityUserChatMonthlyLLMRequestLimit,
 		PerCommunityUserCodeCompletionsMonthlyLLMRequestLimit:  completionsConfig.PerCommunityUserCodeCompletionsMonthlyLLMRequestLimit,
 		PerProUserChatDailyLLMRequestLimit:                     completionsConfig.PerProUserChatDailyLLMRequestLimit,
@@ -944,6 +952,13 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		// Make sure models are always treated case-insensitive.
 		// TODO: Are model names on azure case insensitive?
 		embeddingsConfig.Model = strings.ToLower(embeddingsConfig.Model)
+	} else if embeddingsConfig.Provider == string(conftypes.EmbeddingsProviderNameOpenAICompatible) {
+		// Self-hosted model servers have no well-known defaults, so the endpoint,
+		// model and dimensions must all be configured. The access token is
+		// optional, since these servers are often deployed without auth.
+		if embeddingsConfig.Endpoint == "" || embeddingsConfig.Model == "" || embeddingsConfig.Dimensions <= 0 {
+			return nil
+		}
 	} else {
 		// Unknown provider value.
 		return nil
@@ -966,6 +981,22 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		MaxFileSizeBytes:         maxFileSizeLimit,
 	}
 
+	var admission conftypes.EmbeddingsAdmissionConfig
+	if embeddingsConfig.Admission != nil {
+		admission.MaxRepoSizeBytes = int64(embeddingsConfig.Admission.MaxRepoSizeBytes)
+		admission.MaxFileCount = embeddingsConfig.Admission.MaxFileCount
+		for _, f := range embeddingsConfig.Admission.RepoFileFilters {
+			if f == nil {
+				continue
+			}
+			admission.RepoFileFilters = append(admission.RepoFileFilters, conftypes.EmbeddingsRepoFileFilters{
+				RepoNamePattern:          f.RepoNamePattern,
+				IncludedFilePathPatterns: f.IncludedFilePathPatterns,
+				ExcludedFilePathPatterns: f.ExcludedFilePathPatterns,
+			})
+		}
+	}
+
 	// Default values should match the documented defaults in site.schema.json.
 	computedQdrantConfig := conftypes.QdrantConfig{
 		Enabled: false,
@@ -1025,9 +1056,11 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		Model:       embeddingsConfig.Model,
 		Endpoint:    embeddingsConfig.Endpoint,
 		Dimensions:  embeddingsConfig.Dimensions,
+		BatchSize:   embeddingsConfig.BatchSize,
 		// This is definitely set at this point.
 		Incremental:                            *embeddingsConfig.Incremental,
 		FileFilters:                            fileFilters,
+		Admission:                              admission,
 		MaxCodeEmbeddingsPerRepo:               embeddingsConfig.MaxCodeEmbeddingsPerRepo,
 		MaxTextEmbeddingsPerRepo:               embeddingsConfig.MaxTextEmbeddingsPerRepo,
 		PolicyRepositoryMatchLimit:             embeddingsConfig.PolicyRepositoryMatchLimit,
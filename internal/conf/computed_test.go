@@ -867,6 +867,49 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				Qdrant:              defaultQdrantConfig,
 			},
 		},
+		{
+			name: "Admission limits",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider: "sourcegraph",
+					Admission: &schema.EmbeddingsAdmission{
+						MaxRepoSizeBytes: 1_000_000,
+						MaxFileCount:     500,
+						RepoFileFilters: []*schema.EmbeddingsRepoFileFilters{{
+							RepoNamePattern:          "^github.com/sourcegraph/sourcegraph$",
+							ExcludedFilePathPatterns: []string{"client/*"},
+						}},
+					},
+				},
+			},
+			wantConfig: &conftypes.EmbeddingsConfig{
+				Provider:                   "sourcegraph",
+				AccessToken:                licenseAccessToken,
+				Model:                      "openai/text-embedding-ada-002",
+				Endpoint:                   "https://cody-gateway.sourcegraph.com/v1/embeddings",
+				Dimensions:                 1536,
+				Incremental:                true,
+				MinimumInterval:            24 * time.Hour,
+				MaxCodeEmbeddingsPerRepo:   3_072_000,
+				MaxTextEmbeddingsPerRepo:   512_000,
+				PolicyRepositoryMatchLimit: pointers.Ptr(5000),
+				FileFilters: conftypes.EmbeddingsFileFilters{
+					MaxFileSizeBytes: embeddingsMaxFileSizeBytes,
+				},
+				Admission: conftypes.EmbeddingsAdmissionConfig{
+					MaxRepoSizeBytes: 1_000_000,
+					MaxFileCount:     500,
+					RepoFileFilters: []conftypes.EmbeddingsRepoFileFilters{{
+						RepoNamePattern:          "^github.com/sourcegraph/sourcegraph$",
+						ExcludedFilePathPatterns: []string{"client/*"},
+					}},
+				},
+				ExcludeChunkOnError: true,
+				Qdrant:              defaultQdrantConfig,
+			},
+		},
 		{
 			name: "Disable exclude failed chunk during indexing",
 			siteConfig: schema.SiteConfiguration{
@@ -1010,6 +1053,50 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				Qdrant:              defaultQdrantConfig,
 			},
 		},
+		{
+			name: "OpenAI-compatible provider",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider:   "openai-compatible",
+					Endpoint:   "https://embeddings.acmecorp.com/v1/embeddings",
+					Dimensions: 384,
+					Model:      "BAAI/bge-small-en",
+					BatchSize:  32,
+				},
+			},
+			wantConfig: &conftypes.EmbeddingsConfig{
+				Provider:                   "openai-compatible",
+				Model:                      "BAAI/bge-small-en",
+				Endpoint:                   "https://embeddings.acmecorp.com/v1/embeddings",
+				Dimensions:                 384,
+				BatchSize:                  32,
+				Incremental:                true,
+				MinimumInterval:            24 * time.Hour,
+				MaxCodeEmbeddingsPerRepo:   3_072_000,
+				MaxTextEmbeddingsPerRepo:   512_000,
+				PolicyRepositoryMatchLimit: pointers.Ptr(5000),
+				FileFilters: conftypes.EmbeddingsFileFilters{
+					MaxFileSizeBytes: 1000000,
+				},
+				ExcludeChunkOnError: true,
+				Qdrant:              defaultQdrantConfig,
+			},
+		},
+		{
+			name: "OpenAI-compatible provider without dimensions",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider: "openai-compatible",
+					Endpoint: "https://embeddings.acmecorp.com/v1/embeddings",
+					Model:    "BAAI/bge-small-en",
+				},
+			},
+			wantDisabled: true,
+		},
 		{
 			name:       "App default config",
 			deployType: deploy.App,
@@ -17,6 +17,7 @@ type CompletionsConfig struct {
 	Endpoint                                               string
 	PerUserDailyLimit                                      int
 	PerUserCodeCompletionsDailyLimit                       int
+	PerUserDailyTokenBudget                                int
 	PerCommunityUserChatMonthlyLLMRequestLimit             int
 	PerCommunityUserCodeCompletionsMonthlyLLMRequestLimit  int
 	PerProUserChatDailyLLMRequestLimit                     int
@@ -44,9 +45,11 @@ type EmbeddingsConfig struct {
 	Model                                  string
 	Endpoint                               string
 	Dimensions                             int
+	BatchSize                              int
 	Incremental                            bool
 	MinimumInterval                        time.Duration
 	FileFilters                            EmbeddingsFileFilters
+	Admission                              EmbeddingsAdmissionConfig
 	MaxCodeEmbeddingsPerRepo               int
 	MaxTextEmbeddingsPerRepo               int
 	PolicyRepositoryMatchLimit             *int
@@ -84,9 +87,10 @@ type QdrantQuantizationConfig struct {
 type EmbeddingsProviderName string
 
 const (
-	EmbeddingsProviderNameOpenAI      EmbeddingsProviderName = "openai"
-	EmbeddingsProviderNameAzureOpenAI EmbeddingsProviderName = "azure-openai"
-	EmbeddingsProviderNameSourcegraph EmbeddingsProviderName = "sourcegraph"
+	EmbeddingsProviderNameOpenAI           EmbeddingsProviderName = "openai"
+	EmbeddingsProviderNameAzureOpenAI      EmbeddingsProviderName = "azure-openai"
+	EmbeddingsProviderNameSourcegraph      EmbeddingsProviderName = "sourcegraph"
+	EmbeddingsProviderNameOpenAICompatible EmbeddingsProviderName = "openai-compatible"
 )
 
 type EmbeddingsFileFilters struct {
@@ -94,3 +98,15 @@ type EmbeddingsFileFilters struct {
 	ExcludedFilePathPatterns []string
 	MaxFileSizeBytes         int
 }
+
+type EmbeddingsAdmissionConfig struct {
+	MaxRepoSizeBytes int64
+	MaxFileCount     int
+	RepoFileFilters  []EmbeddingsRepoFileFilters
+}
+
+type EmbeddingsRepoFileFilters struct {
+	RepoNamePattern          string
+	IncludedFilePathPatterns []string
+	ExcludedFilePathPatterns []string
+}
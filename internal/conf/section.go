@@ -0,0 +1,96 @@
+package conf
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/sourcegraph/log"
+)
+
+// SectionChange describes a change to a section of the configuration.
+type SectionChange[T any] struct {
+	// Old is the previously applied value of the section. It is the zero value
+	// for the initial change.
+	Old T
+	// New is the value of the section to apply.
+	New T
+	// Initial is true for the change delivered when the watch is registered.
+	Initial bool
+}
+
+// WatchSection calls onChange whenever the section of the configuration returned by
+// get changes. Unlike Watch, changes to other parts of the configuration are ignored.
+// The name of the section is only used in logs.
+//
+// If validate is non-nil, it is called with every new value of the section before
+// onChange. If it returns an error, the new value is rejected and logged, and the
+// last valid value remains applied. This allows services to tune limits at runtime
+// without applying values they cannot handle.
+//
+// Before WatchSection returns, it will invoke onChange with the current value of the
+// section, unless that value is rejected.
+//
+// IMPORTANT: Like Watch, WatchSection will block on config initialization. It therefore
+// should *never* be called synchronously in `init` functions.
+func WatchSection[T any](name string, get func(*Unified) T, validate func(T) error, onChange func(SectionChange[T])) {
+	watchSection(DefaultClient(), name, get, validate, onChange)
+}
+
+func watchSection[T any](c *client, name string, get func(*Unified) T, validate func(T) error, onChange func(SectionChange[T])) {
+	w := &sectionWatcher[T]{
+		name:     name,
+		get:      get,
+		validate: validate,
+		onChange: onChange,
+		logger:   log.Scoped("conf.section"),
+	}
+	c.Watch(func() {
+		w.update(c.Get())
+	})
+}
+
+type sectionWatcher[T any] struct {
+	name     string
+	get      func(*Unified) T
+	validate func(T) error
+	onChange func(SectionChange[T])
+	logger   log.Logger
+
+	mu      sync.Mutex
+	applied *T
+	// rejected is the last rejected value, so that it is only logged once.
+	rejected *T
+}
+
+// update applies the section of the given configuration if it changed and is valid.
+func (w *sectionWatcher[T]) update(cfg *Unified) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	value := w.get(cfg)
+	if w.applied != nil && reflect.DeepEqual(value, *w.applied) {
+		return
+	}
+	if w.rejected != nil && reflect.DeepEqual(value, *w.rejected) {
+		return
+	}
+
+	if w.validate != nil {
+		if err := w.validate(value); err != nil {
+			w.logger.Warn("rejected invalid configuration section, keeping the last valid value",
+				log.String("section", w.name),
+				log.Error(err))
+			w.rejected = &value
+			return
+		}
+	}
+	w.rejected = nil
+
+	change := SectionChange[T]{New: value, Initial: w.applied == nil}
+	if w.applied != nil {
+		change.Old = *w.applied
+	}
+	w.applied = &value
+
+	w.onChange(change)
+}
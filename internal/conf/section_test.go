@@ -0,0 +1,77 @@
+package conf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSectionWatcher(t *testing.T) {
+	var changes []SectionChange[int]
+	w := &sectionWatcher[int]{
+		name: "codeIntelNavigation.maximumIndexesPerMonikerSearch",
+		get: func(c *Unified) int {
+			return c.CodeIntelNavigationMaximumIndexesPerMonikerSearch
+		},
+		validate: func(n int) error {
+			if n > 100 {
+				return errors.New("too large")
+			}
+			return nil
+		},
+		onChange: func(change SectionChange[int]) {
+			changes = append(changes, change)
+		},
+		logger: logtest.Scoped(t),
+	}
+	update := func(n int, otherChanges ...string) {
+		w.update(&Unified{SiteConfiguration: schema.SiteConfiguration{
+			CodeIntelNavigationMaximumIndexesPerMonikerSearch: n,
+			ExternalURL: "https://sourcegraph.example.com/" + strings.Join(otherChanges, "/"),
+		}})
+	}
+
+	update(10)
+	require.Equal(t, []SectionChange[int]{{Old: 0, New: 10, Initial: true}}, changes)
+
+	// Changes to other sections are ignored.
+	update(10, "other")
+	require.Len(t, changes, 1)
+
+	update(20)
+	require.Equal(t, SectionChange[int]{Old: 10, New: 20}, changes[1])
+
+	// Invalid values are rejected and the last valid value remains applied.
+	update(200)
+	update(200, "other")
+	require.Len(t, changes, 2)
+
+	update(30)
+	require.Equal(t, SectionChange[int]{Old: 20, New: 30}, changes[2])
+
+	// Returning to the rejected value validates it again.
+	update(200)
+	require.Len(t, changes, 3)
+}
+
+func TestSectionWatcherInitialInvalid(t *testing.T) {
+	var changes []SectionChange[int]
+	w := &sectionWatcher[int]{
+		get:      func(c *Unified) int { return c.CodeIntelNavigationMaximumIndexesPerMonikerSearch },
+		validate: func(int) error { return errors.New("invalid") },
+		onChange: func(change SectionChange[int]) { changes = append(changes, change) },
+		logger:   logtest.Scoped(t),
+	}
+	w.update(&Unified{SiteConfiguration: schema.SiteConfiguration{CodeIntelNavigationMaximumIndexesPerMonikerSearch: 5}})
+	require.Empty(t, changes)
+
+	// Once valid, the first applied change is reported as initial.
+	w.validate = nil
+	w.update(&Unified{SiteConfiguration: schema.SiteConfiguration{CodeIntelNavigationMaximumIndexesPerMonikerSearch: 6}})
+	require.Equal(t, []SectionChange[int]{{New: 6, Initial: true}}, changes)
+}
@@ -221,6 +221,8 @@ var siteConfigSecrets = []struct {
 	{readPath: `embeddings.accessToken`, editPaths: []string{"embeddings", "accessToken"}},
 	{readPath: `completions.accessToken`, editPaths: []string{"completions", "accessToken"}},
 	{readPath: `app.dotcomAuthToken`, editPaths: []string{"app", "dotcomAuthToken"}},
+	{readPath: `repositorySnapshots.signingKey`, editPaths: []string{"repositorySnapshots", "signingKey"}},
+	{readPath: `log.auditLog.export.webhook.secret`, editPaths: []string{"log", "auditLog", "export", "webhook", "secret"}},
 }
 
 // UnredactSecrets unredacts unchanged secrets back to their original value for
@@ -15,6 +15,7 @@ const (
 	NoCtags
 	UniversalCtags
 	ScipCtags
+	TreeSitterCtags
 )
 
 func ParserTypeToName(parserType ParserType) string {
@@ -25,6 +26,8 @@ func ParserTypeToName(parserType ParserType) string {
 		return "universal-ctags"
 	case ScipCtags:
 		return "scip-ctags"
+	case TreeSitterCtags:
+		return "tree-sitter"
 	default:
 		return "unknown-ctags-type"
 	}
@@ -38,6 +41,8 @@ func ParserNameToParserType(name string) (ParserType, error) {
 		return UniversalCtags, nil
 	case "scip-ctags":
 		return ScipCtags, nil
+	case "tree-sitter":
+		return TreeSitterCtags, nil
 	default:
 		return UnknownCtags, errors.Errorf("unknown parser type: %s", name)
 	}
@@ -52,6 +57,9 @@ func LanguageSupportsParserType(language string, parserType ParserType) bool {
 	case ScipCtags:
 		_, ok := supportedLanguages[strings.ToLower(language)]
 		return ok
+	case TreeSitterCtags:
+		_, ok := treeSitterSupportedLanguages[strings.ToLower(language)]
+		return ok
 	default:
 		return true
 	}
@@ -74,6 +82,17 @@ var supportedLanguages = map[string]struct{}{
 	"zig":        {},
 }
 
+// treeSitterSupportedLanguages are the languages the symbols service has a
+// tree-sitter grammar and symbols query for.
+var treeSitterSupportedLanguages = map[string]struct{}{
+	"go":         {},
+	"java":       {},
+	"javascript": {},
+	"python":     {},
+	"rust":       {},
+	"typescript": {},
+}
+
 var DefaultEngines = map[string]ParserType{
 	// Add the languages we want to turn on by default (you'll need to
 	// update the ctags_config module for supported languages as well)
@@ -108,7 +127,7 @@ func CreateEngineMap(siteConfig schema.SiteConfiguration) map[string]ParserType
 		for lang, engine := range configuration.Symbols.Engine {
 			lang = languages.NormalizeLanguage(lang)
 
-			if engine, err := ParserNameToParserType(engine); err != nil {
+			if engine, err := ParserNameToParserType(engine); err == nil {
 				engines[lang] = engine
 			}
 		}
@@ -116,3 +135,16 @@ func CreateEngineMap(siteConfig schema.SiteConfiguration) map[string]ParserType
 
 	return engines
 }
+
+// ZoektEngine returns the parser type Zoekt should use for a language configured
+// with the given parser type. Zoekt only runs ctags, so languages configured to
+// use tree-sitter in the symbols service fall back to their default engine.
+func ZoektEngine(language string, parserType ParserType) ParserType {
+	if parserType != TreeSitterCtags {
+		return parserType
+	}
+	if engine, ok := DefaultEngines[language]; ok {
+		return engine
+	}
+	return UniversalCtags
+}
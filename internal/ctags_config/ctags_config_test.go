@@ -0,0 +1,44 @@
+package ctags_config
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCreateEngineMap(t *testing.T) {
+	engines := CreateEngineMap(schema.SiteConfiguration{
+		SyntaxHighlighting: &schema.SyntaxHighlighting{
+			Symbols: &schema.SymbolConfiguration{
+				Engine: map[string]string{
+					"Rust":   "tree-sitter",
+					"go":     "off",
+					"kotlin": "not-an-engine",
+				},
+			},
+		},
+	})
+
+	for lang, want := range map[string]ParserType{
+		"rust":       TreeSitterCtags,
+		"go":         NoCtags,
+		"kotlin":     DefaultEngines["kotlin"],
+		"typescript": DefaultEngines["typescript"],
+	} {
+		if got := engines[lang]; got != want {
+			t.Errorf("unexpected engine for %s: want %s, got %s", lang, ParserTypeToName(want), ParserTypeToName(got))
+		}
+	}
+}
+
+func TestZoektEngine(t *testing.T) {
+	if got := ZoektEngine("rust", TreeSitterCtags); got != ScipCtags {
+		t.Errorf("unexpected engine for rust: %s", ParserTypeToName(got))
+	}
+	if got := ZoektEngine("java", TreeSitterCtags); got != UniversalCtags {
+		t.Errorf("unexpected engine for java: %s", ParserTypeToName(got))
+	}
+	if got := ZoektEngine("go", NoCtags); got != NoCtags {
+		t.Errorf("unexpected engine for go: %s", ParserTypeToName(got))
+	}
+}
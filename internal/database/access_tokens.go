@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
@@ -227,14 +228,25 @@ INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_u
 			s.logger.Error("failed to marshall the access token log argument")
 		}
 
-		securityEventStore := NewDBWith(s.logger, s).SecurityEventLogs()
-		securityEventStore.LogEvent(ctx, &SecurityEvent{
+		db := NewDBWith(s.logger, s)
+		db.SecurityEventLogs().LogEvent(ctx, &SecurityEvent{
 			Name:      SecurityEventAccessTokenCreated,
 			UserID:    uint32(creatorUserID),
 			Argument:  arg,
 			Source:    "BACKEND",
 			Timestamp: time.Now(),
 		})
+		db.AuditEvents().Log(ctx, &AuditEvent{
+			Action:      AuditActionAccessTokenCreated,
+			EntityType:  AuditEntityAccessToken,
+			EntityID:    strconv.FormatInt(id, 10),
+			ActorUserID: creatorUserID,
+			Metadata: map[string]any{
+				"subjectUserID": subjectUserID,
+				"scopes":        scopes,
+				"note":          note,
+			},
+		})
 	}
 
 	return id, token, nil
@@ -426,7 +438,7 @@ func (s *accessTokenStore) Count(ctx context.Context, opt AccessTokensListOption
 }
 
 func (s *accessTokenStore) DeleteByID(ctx context.Context, id int64) error {
-	err := s.delete(ctx, sqlf.Sprintf("id=%d", id))
+	_, err := s.delete(ctx, sqlf.Sprintf("id=%d", id))
 	if err != nil {
 		return err
 	}
@@ -438,7 +450,7 @@ func (s *accessTokenStore) DeleteByID(ctx context.Context, id int64) error {
 		s.logger.Error("failed to marshall the access token log argument")
 	}
 
-	s.logAccessTokenDeleted(ctx, SecurityEventAccessTokenDeleted, arg)
+	s.logAccessTokenDeleted(ctx, SecurityEventAccessTokenDeleted, id, arg)
 
 	return nil
 }
@@ -463,7 +475,7 @@ func (s *accessTokenStore) HardDeleteByID(ctx context.Context, id int64) error {
 		s.logger.Error("failed to marshall the access token log argument")
 	}
 
-	s.logAccessTokenDeleted(ctx, SecurityEventAccessTokenHardDeleted, arg)
+	s.logAccessTokenDeleted(ctx, SecurityEventAccessTokenHardDeleted, id, arg)
 
 	return nil
 }
@@ -474,7 +486,7 @@ func (s *accessTokenStore) DeleteByToken(ctx context.Context, token string) erro
 		return errors.Wrap(err, "AccessTokens.DeleteByToken")
 	}
 
-	err = s.delete(ctx, sqlf.Sprintf("value_sha256=%s", tokenHash))
+	id, err := s.delete(ctx, sqlf.Sprintf("value_sha256=%s", tokenHash))
 	if err != nil {
 		return err
 	}
@@ -488,27 +500,24 @@ func (s *accessTokenStore) DeleteByToken(ctx context.Context, token string) erro
 		s.logger.Error("failed to marshall the access token log argument")
 	}
 
-	s.logAccessTokenDeleted(ctx, SecurityEventAccessTokenDeleted, arg)
+	s.logAccessTokenDeleted(ctx, SecurityEventAccessTokenDeleted, id, arg)
 
 	return nil
 }
 
-func (s *accessTokenStore) delete(ctx context.Context, cond *sqlf.Query) error {
+// delete soft-deletes the access token matching cond and returns its ID.
+func (s *accessTokenStore) delete(ctx context.Context, cond *sqlf.Query) (int64, error) {
 	conds := []*sqlf.Query{cond, sqlf.Sprintf("deleted_at IS NULL")}
-	q := sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE (%s)", sqlf.Join(conds, ") AND ("))
+	q := sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE (%s) RETURNING id", sqlf.Join(conds, ") AND ("))
 
-	res, err := s.ExecResult(ctx, q)
-	if err != nil {
-		return err
-	}
-	nrows, err := res.RowsAffected()
+	id, ok, err := basestore.ScanFirstInt64(s.Query(ctx, q))
 	if err != nil {
-		return err
+		return 0, err
 	}
-	if nrows == 0 {
-		return ErrAccessTokenNotFound
+	if !ok {
+		return 0, ErrAccessTokenNotFound
 	}
-	return nil
+	return id, nil
 }
 
 // tokenSHA256Hash returns the 32-byte long SHA-256 hash of its hex-encoded value
@@ -526,17 +535,23 @@ func tokenSHA256Hash(token string) ([]byte, error) {
 	return hashutil.ToSHA256Bytes(value), nil
 }
 
-func (s *accessTokenStore) logAccessTokenDeleted(ctx context.Context, deletionType SecurityEventName, arg []byte) {
+func (s *accessTokenStore) logAccessTokenDeleted(ctx context.Context, deletionType SecurityEventName, id int64, arg []byte) {
 	a := actor.FromContext(ctx)
 
-	securityEventStore := NewDBWith(s.logger, s).SecurityEventLogs()
-	securityEventStore.LogEvent(ctx, &SecurityEvent{
+	db := NewDBWith(s.logger, s)
+	db.SecurityEventLogs().LogEvent(ctx, &SecurityEvent{
 		Name:      deletionType,
 		UserID:    uint32(a.UID),
 		Argument:  arg,
 		Source:    "BACKEND",
 		Timestamp: time.Now(),
 	})
+	db.AuditEvents().Log(ctx, &AuditEvent{
+		Action:     AuditActionAccessTokenDeleted,
+		EntityType: AuditEntityAccessToken,
+		EntityID:   strconv.FormatInt(id, 10),
+		Metadata:   map[string]any{"hardDelete": deletionType == SecurityEventAccessTokenHardDeleted},
+	})
 }
 
 type MockAccessTokens struct {
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/log"
+
+	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Audit event actions. The action names are part of the stable audit log
+// schema consumed by SIEMs: existing names must never change.
+const (
+	AuditActionAccessTokenCreated = "access_token.created"
+	AuditActionAccessTokenDeleted = "access_token.deleted"
+
+	AuditActionExternalServiceCreated = "external_service.created"
+	AuditActionExternalServiceUpdated = "external_service.updated"
+	AuditActionExternalServiceDeleted = "external_service.deleted"
+
+	AuditActionRepoPermissionsSet = "repo_permissions.set"
+
+	AuditActionRepoDeleted = "repo.deleted"
+)
+
+// Audit event entity types.
+const (
+	AuditEntityAccessToken     = "access_token"
+	AuditEntityExternalService = "external_service"
+	AuditEntityRepo            = "repo"
+)
+
+// AuditEvent is an admin-sensitive action recorded in the audit log.
+type AuditEvent struct {
+	ID int64
+	// Action is the action that was performed, such as "access_token.created".
+	Action string
+	// EntityType and EntityID identify the entity the action was performed on.
+	EntityType string
+	EntityID   string
+	// ActorUserID is the ID of the user who performed the action, or 0 for
+	// internal actors.
+	ActorUserID    int32
+	ActorIP        string
+	ActorUserAgent string
+	// Metadata holds action-specific details. It must never contain secrets.
+	Metadata  map[string]any
+	CreatedAt time.Time
+}
+
+// AuditEventsFilterArgs filters the audit events returned by
+// AuditEventStore.List and AuditEventStore.Count.
+type AuditEventsFilterArgs struct {
+	// Actions, if non-empty, only returns events with one of the given actions.
+	Actions     []string
+	EntityType  string
+	EntityID    string
+	ActorUserID int32
+	// Since and Until, if set, only return events created in [Since, Until).
+	Since *time.Time
+	Until *time.Time
+}
+
+func (o *AuditEventsFilterArgs) SQL() []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if o == nil {
+		return conds
+	}
+	if len(o.Actions) > 0 {
+		conds = append(conds, sqlf.Sprintf("action = ANY(%s)", pq.Array(o.Actions)))
+	}
+	if o.EntityType != "" {
+		conds = append(conds, sqlf.Sprintf("entity_type = %s", o.EntityType))
+	}
+	if o.EntityID != "" {
+		conds = append(conds, sqlf.Sprintf("entity_id = %s", o.EntityID))
+	}
+	if o.ActorUserID != 0 {
+		conds = append(conds, sqlf.Sprintf("actor_user_id = %s", o.ActorUserID))
+	}
+	if o.Since != nil {
+		conds = append(conds, sqlf.Sprintf("created_at >= %s", *o.Since))
+	}
+	if o.Until != nil {
+		conds = append(conds, sqlf.Sprintf("created_at < %s", *o.Until))
+	}
+	return conds
+}
+
+// AuditEventStore provides access to the `audit_events` table.
+//
+// For a detailed overview of the schema, see schema.md.
+type AuditEventStore interface {
+	basestore.ShareableStore
+	// Insert records the given event. ID and CreatedAt are populated from the
+	// database.
+	Insert(ctx context.Context, event *AuditEvent) error
+	// Log records the given event, filling in the actor from the context. It
+	// is meant to be called after the audited action succeeded, so errors are
+	// logged rather than returned. Within a transaction, the event is inserted
+	// in a savepoint, so that failing to record it doesn't abort the
+	// transaction.
+	Log(ctx context.Context, event *AuditEvent)
+	Count(context.Context, *AuditEventsFilterArgs) (int, error)
+	List(context.Context, *AuditEventsFilterArgs, *PaginationArgs) ([]*AuditEvent, error)
+	// ListForExport returns up to limit events that have not been exported
+	// yet, oldest first.
+	ListForExport(ctx context.Context, limit int) ([]*AuditEvent, error)
+	// MarkAsExported marks the events with the given IDs as exported.
+	MarkAsExported(ctx context.Context, ids []int64) error
+}
+
+type auditEventStore struct {
+	*basestore.Store
+	logger log.Logger
+}
+
+// AuditEventsWith instantiates and returns a new AuditEventStore using the other store handle.
+func AuditEventsWith(other basestore.ShareableStore, logger log.Logger) AuditEventStore {
+	return &auditEventStore{Store: basestore.NewWithHandle(other.Handle()), logger: logger}
+}
+
+type AuditEventListColumn string
+
+const (
+	AuditEventListID AuditEventListColumn = "id"
+)
+
+var auditEventColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("action"),
+	sqlf.Sprintf("entity_type"),
+	sqlf.Sprintf("entity_id"),
+	sqlf.Sprintf("actor_user_id"),
+	sqlf.Sprintf("actor_ip"),
+	sqlf.Sprintf("actor_user_agent"),
+	sqlf.Sprintf("metadata"),
+	sqlf.Sprintf("created_at"),
+}
+
+const auditEventInsertQuery = `
+INSERT INTO audit_events (action, entity_type, entity_id, actor_user_id, actor_ip, actor_user_agent, metadata)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING id, created_at
+`
+
+func (s *auditEventStore) Insert(ctx context.Context, event *AuditEvent) error {
+	if event.Action == "" || event.EntityType == "" {
+		return errors.New("audit event action and entity type are required")
+	}
+	metadata := event.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	rawMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "marshalling audit event metadata")
+	}
+	q := sqlf.Sprintf(
+		auditEventInsertQuery,
+		event.Action,
+		event.EntityType,
+		dbutil.NewNullString(event.EntityID),
+		dbutil.NewNullInt32(event.ActorUserID),
+		dbutil.NewNullString(event.ActorIP),
+		dbutil.NewNullString(event.ActorUserAgent),
+		rawMetadata,
+	)
+	return s.QueryRow(ctx, q).Scan(&event.ID, &event.CreatedAt)
+}
+
+func (s *auditEventStore) Log(ctx context.Context, event *AuditEvent) {
+	if a := sgactor.FromContext(ctx); a.IsAuthenticated() && event.ActorUserID == 0 {
+		event.ActorUserID = a.UID
+	}
+	if client := requestclient.FromContext(ctx); client != nil {
+		if event.ActorIP == "" {
+			event.ActorIP = client.IP
+		}
+		if event.ActorUserAgent == "" {
+			event.ActorUserAgent = client.UserAgent
+		}
+	}
+	err := s.WithTransact(ctx, func(tx *basestore.Store) error {
+		return (&auditEventStore{Store: tx, logger: s.logger}).Insert(ctx, event)
+	})
+	if err != nil {
+		s.logger.Error("failed to record audit event",
+			log.String("action", event.Action),
+			log.String("entityType", event.EntityType),
+			log.String("entityID", event.EntityID),
+			log.Error(err))
+	}
+}
+
+func (s *auditEventStore) Count(ctx context.Context, fArgs *AuditEventsFilterArgs) (int, error) {
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM audit_events WHERE (%s)", sqlf.Join(fArgs.SQL(), ") AND ("))
+	return basestore.ScanInt(s.QueryRow(ctx, q))
+}
+
+func (s *auditEventStore) List(ctx context.Context, fArgs *AuditEventsFilterArgs, pArgs *PaginationArgs) ([]*AuditEvent, error) {
+	where := fArgs.SQL()
+	if pArgs == nil {
+		pArgs = &PaginationArgs{}
+	}
+	p := pArgs.SQL()
+	if p.Where != nil {
+		where = append(where, p.Where)
+	}
+
+	q := sqlf.Sprintf("SELECT %s FROM audit_events WHERE (%s)", sqlf.Join(auditEventColumns, ","), sqlf.Join(where, ") AND ("))
+	q = p.AppendOrderToQuery(q)
+	q = p.AppendLimitToQuery(q)
+
+	return scanAuditEvents(s.Query(ctx, q))
+}
+
+const auditEventListForExportQuery = `
+SELECT %s
+FROM audit_events
+WHERE exported_at IS NULL
+ORDER BY id ASC
+LIMIT %s
+`
+
+func (s *auditEventStore) ListForExport(ctx context.Context, limit int) ([]*AuditEvent, error) {
+	return scanAuditEvents(s.Query(ctx, sqlf.Sprintf(auditEventListForExportQuery, sqlf.Join(auditEventColumns, ","), limit)))
+}
+
+func (s *auditEventStore) MarkAsExported(ctx context.Context, ids []int64) error {
+	if err := s.Exec(ctx, sqlf.Sprintf("UPDATE audit_events SET exported_at = NOW() WHERE id = ANY(%s)", pq.Array(ids))); err != nil {
+		return errors.Wrap(err, "failed to mark audit events as exported")
+	}
+	return nil
+}
+
+func scanAuditEvent(sc dbutil.Scanner) (*AuditEvent, error) {
+	var (
+		event       AuditEvent
+		rawMetadata []byte
+	)
+	if err := sc.Scan(
+		&event.ID,
+		&event.Action,
+		&event.EntityType,
+		&dbutil.NullString{S: &event.EntityID},
+		&dbutil.NullInt32{N: &event.ActorUserID},
+		&dbutil.NullString{S: &event.ActorIP},
+		&dbutil.NullString{S: &event.ActorUserAgent},
+		&rawMetadata,
+		&event.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rawMetadata, &event.Metadata); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling audit event metadata")
+	}
+	return &event, nil
+}
+
+var scanAuditEvents = basestore.NewSliceScanner(scanAuditEvent)
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+)
+
+func TestAuditEventStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	store := db.AuditEvents()
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	ctx = requestclient.WithClient(ctx, &requestclient.Client{IP: "10.0.0.1", UserAgent: "curl/8.0"})
+
+	store.Log(ctx, &AuditEvent{
+		Action:     AuditActionAccessTokenCreated,
+		EntityType: AuditEntityAccessToken,
+		EntityID:   "7",
+		Metadata:   map[string]any{"note": "ci"},
+	})
+	store.Log(context.Background(), &AuditEvent{
+		Action:     AuditActionRepoDeleted,
+		EntityType: AuditEntityRepo,
+		EntityID:   "3",
+	})
+	require.Error(t, store.Insert(ctx, &AuditEvent{Action: AuditActionRepoDeleted}))
+
+	t.Run("List", func(t *testing.T) {
+		events, err := store.List(ctx, nil, &PaginationArgs{OrderBy: OrderBy{{Field: string(AuditEventListID)}}})
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		first := events[0]
+		assert.Equal(t, AuditActionAccessTokenCreated, first.Action)
+		assert.Equal(t, "7", first.EntityID)
+		assert.Equal(t, int32(1), first.ActorUserID)
+		assert.Equal(t, "10.0.0.1", first.ActorIP)
+		assert.Equal(t, "curl/8.0", first.ActorUserAgent)
+		assert.Equal(t, map[string]any{"note": "ci"}, first.Metadata)
+
+		second := events[1]
+		assert.Zero(t, second.ActorUserID)
+		assert.Empty(t, second.ActorIP)
+		assert.Equal(t, map[string]any{}, second.Metadata)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		future := time.Now().Add(time.Hour)
+		for name, tc := range map[string]struct {
+			args *AuditEventsFilterArgs
+			want int
+		}{
+			"all":         {args: nil, want: 2},
+			"actions":     {args: &AuditEventsFilterArgs{Actions: []string{AuditActionRepoDeleted}}, want: 1},
+			"entity":      {args: &AuditEventsFilterArgs{EntityType: AuditEntityRepo, EntityID: "3"}, want: 1},
+			"actor":       {args: &AuditEventsFilterArgs{ActorUserID: 1}, want: 1},
+			"since":       {args: &AuditEventsFilterArgs{Since: &future}, want: 0},
+			"until":       {args: &AuditEventsFilterArgs{Until: &future}, want: 2},
+			"no match":    {args: &AuditEventsFilterArgs{Actions: []string{"unknown"}}, want: 0},
+			"other actor": {args: &AuditEventsFilterArgs{ActorUserID: 2}, want: 0},
+		} {
+			count, err := store.Count(ctx, tc.args)
+			require.NoError(t, err, name)
+			assert.Equal(t, tc.want, count, name)
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		events, err := store.ListForExport(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, AuditActionAccessTokenCreated, events[0].Action)
+
+		require.NoError(t, store.MarkAsExported(ctx, []int64{events[0].ID}))
+
+		events, err = store.ListForExport(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, AuditActionRepoDeleted, events[0].Action)
+	})
+}
+
+func TestAuditEventStoreLogInTransaction(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	err := db.WithTransact(ctx, func(tx DB) error {
+		// A NUL byte can't be stored in a text column, so this insert fails.
+		tx.AuditEvents().Log(ctx, &AuditEvent{
+			Action:     AuditActionRepoDeleted,
+			EntityType: AuditEntityRepo,
+			EntityID:   "\x00",
+		})
+		// The transaction can still be used afterwards.
+		tx.AuditEvents().Log(ctx, &AuditEvent{
+			Action:     AuditActionRepoDeleted,
+			EntityType: AuditEntityRepo,
+			EntityID:   "3",
+		})
+		return nil
+	})
+	require.NoError(t, err)
+
+	events, err := db.AuditEvents().List(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "3", events[0].EntityID)
+}
@@ -53,6 +53,7 @@ var (
 	_ TransactableHandle = (*dbHandle)(nil)
 	_ TransactableHandle = (*txHandle)(nil)
 	_ TransactableHandle = (*savepointHandle)(nil)
+	_ TransactableHandle = (*replicaHandle)(nil)
 )
 
 // NewHandleWithDB returns a new transactable database handle using the given database connection.
@@ -86,6 +87,9 @@ func Raw(v any) (*sql.DB, bool) {
 	if shareableStore, ok := v.(ShareableStore); ok {
 		v = shareableStore.Handle()
 	}
+	if replicaHandle, ok := v.(*replicaHandle); ok {
+		v = replicaHandle.dbHandle
+	}
 	if dbHandle, ok := v.(*dbHandle); ok {
 		v = dbHandle.DB
 	}
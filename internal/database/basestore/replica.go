@@ -0,0 +1,214 @@
+package basestore
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type readReplicaPreferredKey struct{}
+
+// WithReadReplicaPreferred returns a context indicating that read-only queries
+// issued with it may be served by a read replica. Such queries are sent to the
+// replica only if the handle was created with NewHandleWithReplica and the
+// replica is not lagging too far behind the primary. Queries issued within a
+// transaction always run against the primary.
+func WithReadReplicaPreferred(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readReplicaPreferredKey{}, true)
+}
+
+// ReadReplicaPreferred returns true if the given context was returned by
+// WithReadReplicaPreferred.
+func ReadReplicaPreferred(ctx context.Context) bool {
+	preferred, _ := ctx.Value(readReplicaPreferredKey{}).(bool)
+	return preferred
+}
+
+// NewHandleWithReplica returns a new transactable database handle using the given
+// primary database connection. Queries issued with a context returned by
+// WithReadReplicaPreferred are sent to the given replica as long as its replication
+// lag, which is measured in the background, is at most maxLag. They fall back to
+// the primary if the replica is lagging, hasn't been measured yet, or cannot be
+// queried.
+func NewHandleWithReplica(logger log.Logger, primary, replica *sql.DB, txOptions sql.TxOptions, maxLag time.Duration) TransactableHandle {
+	logger = logger.Scoped("db-handle")
+
+	return &replicaHandle{
+		dbHandle: &dbHandle{
+			DB:        primary,
+			logger:    logger,
+			txOptions: txOptions,
+		},
+		replica: newReplicaDB(logger, replica, maxLag),
+	}
+}
+
+// replicaHandle is a dbHandle that sends read-only queries to a replica when the
+// caller opts in. Writes and transactions always use the primary.
+type replicaHandle struct {
+	*dbHandle
+	replica *replicaDB
+}
+
+func (h *replicaHandle) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if ReadReplicaPreferred(ctx) && h.replica.usable() {
+		rows, err := h.replica.db.QueryContext(ctx, query, args...)
+		if !h.replica.shouldFallBack(ctx, err) {
+			return rows, err
+		}
+	}
+
+	return h.dbHandle.QueryContext(ctx, query, args...)
+}
+
+func (h *replicaHandle) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if ReadReplicaPreferred(ctx) && h.replica.usable() {
+		row := h.replica.db.QueryRowContext(ctx, query, args...)
+		if !h.replica.shouldFallBack(ctx, row.Err()) {
+			return row
+		}
+	}
+
+	return h.dbHandle.QueryRowContext(ctx, query, args...)
+}
+
+const (
+	// replicaLagCheckInterval is how long a replication lag measurement is trusted.
+	replicaLagCheckInterval = 5 * time.Second
+
+	// replicaLagCheckTimeout bounds the time spent measuring the replication lag.
+	replicaLagCheckTimeout = 2 * time.Second
+)
+
+// replicaLagQuery returns the replication lag of the server in seconds. The replay
+// timestamp only advances when a transaction committed on the primary is replayed,
+// so a replica that is connected to the primary and has replayed all the WAL it has
+// received is not lagging, even if the primary has not committed anything for a
+// while. A replica without a running WAL receiver doesn't receive any WAL, so its
+// lag is always measured from the replay timestamp.
+const replicaLagQuery = `
+SELECT CASE
+	WHEN NOT pg_is_in_recovery() THEN 0
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() AND EXISTS (SELECT 1 FROM pg_stat_wal_receiver) THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END
+`
+
+// replicaDB tracks whether a replica is reachable and close enough to the primary
+// to serve queries.
+type replicaDB struct {
+	db      *sql.DB
+	maxLag  time.Duration
+	logger  log.Logger
+	now     func() time.Time
+	readLag func(context.Context) (time.Duration, error)
+
+	mu sync.Mutex
+	// checking is true while the replication lag is being measured.
+	checking  bool
+	checkedAt time.Time
+	// measured is false until the first lag measurement completes, the replica
+	// isn't used before.
+	measured bool
+	healthy  bool
+}
+
+func newReplicaDB(logger log.Logger, db *sql.DB, maxLag time.Duration) *replicaDB {
+	r := &replicaDB{
+		db:     db,
+		maxLag: maxLag,
+		logger: logger,
+		now:    time.Now,
+		// Assume the replica is healthy until measured otherwise, so that
+		// only failures are logged on startup.
+		healthy: true,
+	}
+	r.readLag = r.queryLag
+	return r
+}
+
+func (r *replicaDB) queryLag(ctx context.Context) (time.Duration, error) {
+	var seconds float64
+	if err := r.db.QueryRowContext(ctx, replicaLagQuery).Scan(&seconds); err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// usable returns true if the replica can serve queries, according to the last
+// replication lag measurement. It never blocks on a measurement: once the last
+// one is older than replicaLagCheckInterval, a new one is started in the
+// background and the result of the last one is returned in the meantime.
+func (r *replicaDB) usable() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.checking && (r.checkedAt.IsZero() || r.now().Sub(r.checkedAt) >= replicaLagCheckInterval) {
+		r.checking = true
+		go r.checkLag()
+	}
+
+	return r.measured && r.healthy
+}
+
+// checkLag measures the replication lag and records whether the replica can
+// serve queries.
+func (r *replicaDB) checkLag() {
+	ctx, cancel := context.WithTimeout(context.Background(), replicaLagCheckTimeout)
+	defer cancel()
+
+	lag, err := r.readLag(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checking = false
+	r.measured = true
+	r.checkedAt = r.now()
+
+	switch {
+	case err != nil:
+		r.setHealthy(false, "failed to measure replication lag", log.Error(err))
+	case lag > r.maxLag:
+		r.setHealthy(false, "replica is lagging behind the primary", log.Duration("lag", lag), log.Duration("maxLag", r.maxLag))
+	default:
+		r.setHealthy(true, "replica caught up with the primary", log.Duration("lag", lag))
+	}
+}
+
+// shouldFallBack returns true if a query which returned the given error should be
+// retried against the primary. Errors caused by the caller's context are returned
+// as-is. Other errors, such as connection failures or queries canceled due to a
+// conflict with recovery, mark the replica unusable until the next lag check.
+func (r *replicaDB) shouldFallBack(ctx context.Context, err error) bool {
+	if err == nil || errors.IsContextError(err) || ctx.Err() != nil {
+		return false
+	}
+
+	r.mu.Lock()
+	r.checkedAt = r.now()
+	r.setHealthy(false, "query against replica failed, falling back to primary", log.Error(err))
+	r.mu.Unlock()
+
+	return true
+}
+
+// setHealthy records the health of the replica, logging transitions. It must be
+// called with r.mu held.
+func (r *replicaDB) setHealthy(healthy bool, msg string, fields ...log.Field) {
+	if healthy == r.healthy {
+		return
+	}
+	r.healthy = healthy
+
+	if healthy {
+		r.logger.Info(msg, fields...)
+	} else {
+		r.logger.Warn(msg, fields...)
+	}
+}
@@ -0,0 +1,134 @@
+package basestore
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestReadReplicaPreferred(t *testing.T) {
+	ctx := context.Background()
+	require.False(t, ReadReplicaPreferred(ctx))
+	require.True(t, ReadReplicaPreferred(WithReadReplicaPreferred(ctx)))
+}
+
+func TestReplicaDBUsable(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var (
+		lag    time.Duration
+		lagErr error
+		calls  int
+	)
+
+	r := newReplicaDB(logtest.Scoped(t), nil, 10*time.Second)
+	r.now = func() time.Time { return now }
+	r.readLag = func(context.Context) (time.Duration, error) {
+		calls++
+		return lag, lagErr
+	}
+
+	// The replica isn't used until the lag has been measured.
+	lag = time.Second
+	require.False(t, r.usable())
+	waitForLagCheck(t, r)
+	require.True(t, r.usable())
+
+	// The measurement is cached.
+	lag = time.Minute
+	require.True(t, r.usable())
+	require.Equal(t, 1, calls)
+
+	// Once the measurement is stale, the last one is used until the new one
+	// completes.
+	now = now.Add(replicaLagCheckInterval)
+	require.True(t, r.usable())
+	waitForLagCheck(t, r)
+	require.False(t, r.usable())
+	require.Equal(t, 2, calls)
+
+	now = now.Add(replicaLagCheckInterval)
+	lag, lagErr = 0, errors.New("connection refused")
+	r.usable()
+	waitForLagCheck(t, r)
+	require.False(t, r.usable())
+
+	now = now.Add(replicaLagCheckInterval)
+	lagErr = nil
+	r.usable()
+	waitForLagCheck(t, r)
+	require.True(t, r.usable())
+}
+
+func TestReplicaDBUsableDoesNotBlock(t *testing.T) {
+	r := newReplicaDB(logtest.Scoped(t), nil, 10*time.Second)
+	measuring := make(chan struct{})
+	release := make(chan struct{})
+	var calls int
+	r.readLag = func(context.Context) (time.Duration, error) {
+		calls++
+		close(measuring)
+		<-release
+		return 0, nil
+	}
+
+	require.False(t, r.usable())
+	<-measuring
+
+	// Callers don't wait for the measurement in progress, and don't start
+	// another one.
+	for i := 0; i < 10; i++ {
+		require.False(t, r.usable())
+	}
+
+	close(release)
+	waitForLagCheck(t, r)
+	require.True(t, r.usable())
+	require.Equal(t, 1, calls)
+}
+
+func TestReplicaDBShouldFallBack(t *testing.T) {
+	r := newReplicaDB(logtest.Scoped(t), nil, 10*time.Second)
+	r.readLag = func(context.Context) (time.Duration, error) { return 0, nil }
+	ctx := context.Background()
+
+	r.usable()
+	waitForLagCheck(t, r)
+	require.True(t, r.usable())
+	require.False(t, r.shouldFallBack(ctx, nil))
+	require.False(t, r.shouldFallBack(ctx, context.DeadlineExceeded))
+	require.True(t, r.usable())
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(t, r.shouldFallBack(canceledCtx, errors.New("canceling statement due to user request")))
+
+	// Other errors mark the replica unusable until the next lag check.
+	require.True(t, r.shouldFallBack(ctx, errors.New("connection refused")))
+	require.False(t, r.usable())
+}
+
+// waitForLagCheck waits for the lag measurement started by usable to complete.
+func waitForLagCheck(t *testing.T, r *replicaDB) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return !r.checking
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestRawReplicaHandle(t *testing.T) {
+	primary := &sql.DB{}
+	handle := NewHandleWithReplica(logtest.Scoped(t), primary, &sql.DB{}, sql.TxOptions{}, time.Second)
+
+	db, ok := Raw(NewWithHandle(handle))
+	require.True(t, ok)
+	require.Same(t, primary, db)
+}
@@ -0,0 +1,40 @@
+package connections
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+var (
+	codeIntelReplicaDSN    = env.Get("CODEINTEL_PGREPLICA_DATASOURCE", "", "Data source name of a read replica of the codeintel database, used for heavy read-only queries.")
+	codeInsightsReplicaDSN = env.Get("CODEINSIGHTS_PGREPLICA_DATASOURCE", "", "Data source name of a read replica of the codeinsights database, used for heavy read-only queries.")
+
+	// ReplicaMaxLag is the maximum replication lag of a read replica. Queries are sent
+	// to the primary while a replica lags further behind.
+	ReplicaMaxLag = env.MustGetDuration("SRC_PGSQL_REPLICA_MAX_LAG", 30*time.Second, "Maximum replication lag of a read replica before queries fall back to the primary database.")
+)
+
+// NewCodeIntelReplicaDB creates a new connection to the read replica of the codeintel database configured
+// by CODEINTEL_PGREPLICA_DATASOURCE. It returns nil if no replica is configured. The schema of the replica
+// is not validated, as it mirrors the primary.
+func NewCodeIntelReplicaDB(observationCtx *observation.Context, appName string) (*sql.DB, error) {
+	return connectReplica(observationCtx, codeIntelReplicaDSN, appName, "codeintel-replica")
+}
+
+// NewCodeInsightsReplicaDB creates a new connection to the read replica of the codeinsights database
+// configured by CODEINSIGHTS_PGREPLICA_DATASOURCE. It returns nil if no replica is configured. The schema
+// of the replica is not validated, as it mirrors the primary.
+func NewCodeInsightsReplicaDB(observationCtx *observation.Context, appName string) (*sql.DB, error) {
+	return connectReplica(observationCtx, codeInsightsReplicaDSN, appName, "codeinsights-replica")
+}
+
+func connectReplica(observationCtx *observation.Context, dsn, appName, dbName string) (*sql.DB, error) {
+	if dsn == "" {
+		return nil, nil
+	}
+
+	return connect(observationCtx, dsn, appName, dbName, nil, false)
+}
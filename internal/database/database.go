@@ -24,8 +24,10 @@ type DB interface {
 
 	AccessRequests() AccessRequestStore
 	AccessTokens() AccessTokenStore
+	AuditEvents() AuditEventStore
 	Authz() AuthzStore
 	BitbucketProjectPermissions() BitbucketProjectPermissionsStore
+	BlameSignals() BlameSignalStore
 	CodeMonitors() CodeMonitorStore
 	CodeHosts() CodeHostStore
 	Codeowners() CodeownersStore
@@ -55,12 +57,15 @@ type DB interface {
 	RedisKeyValue() RedisKeyValueStore
 	Repos() RepoStore
 	RepoCommitsChangelists() RepoCommitsChangelistsStore
+	RepoDeployKeys(encryption.Key) RepoDeployKeyStore
 	RepoKVPs() RepoKVPStore
 	RepoPaths() RepoPathStore
+	RepoViews() RepoViewStore
 	RolePermissions() RolePermissionStore
 	Roles() RoleStore
 	SavedSearches() SavedSearchStore
 	SearchContexts() SearchContextsStore
+	SearchIndexBranches() SearchIndexBranchStore
 	Settings() SettingsStore
 	SubRepoPerms() SubRepoPermsStore
 	TemporarySettings() TemporarySettingsStore
@@ -69,6 +74,7 @@ type DB interface {
 	UserEmails() UserEmailsStore
 	UserExternalAccounts() UserExternalAccountsStore
 	UserRoles() UserRoleStore
+	UserTOTPCredentials(encryption.Key) UserTOTPCredentialStore
 	Users() UserStore
 	WebhookLogs(encryption.Key) WebhookLogStore
 	Webhooks(encryption.Key) WebhookStore
@@ -142,6 +148,10 @@ func (d *db) AccessRequests() AccessRequestStore {
 	return AccessRequestsWith(d.Store, d.logger.Scoped("AccessRequestStore"))
 }
 
+func (d *db) AuditEvents() AuditEventStore {
+	return AuditEventsWith(d.Store, d.logger.Scoped("AuditEventStore"))
+}
+
 func (d *db) BitbucketProjectPermissions() BitbucketProjectPermissionsStore {
 	return BitbucketProjectPermissionsStoreWith(d.Store)
 }
@@ -150,6 +160,10 @@ func (d *db) Authz() AuthzStore {
 	return AuthzWith(d.Store)
 }
 
+func (d *db) BlameSignals() BlameSignalStore {
+	return BlameSignalStoreWith(d.Store)
+}
+
 func (d *db) CodeMonitors() CodeMonitorStore {
 	return CodeMonitorsWith(d.Store)
 }
@@ -266,6 +280,10 @@ func (d *db) RepoCommitsChangelists() RepoCommitsChangelistsStore {
 	return RepoCommitsChangelistsWith(d.logger, d.Store)
 }
 
+func (d *db) RepoDeployKeys(key encryption.Key) RepoDeployKeyStore {
+	return RepoDeployKeysWith(d.Store, key)
+}
+
 func (d *db) RepoKVPs() RepoKVPStore {
 	return &repoKVPStore{d.Store}
 }
@@ -274,6 +292,10 @@ func (d *db) RepoPaths() RepoPathStore {
 	return &repoPathStore{d.Store}
 }
 
+func (d *db) RepoViews() RepoViewStore {
+	return RepoViewsWith(d.Store)
+}
+
 func (d *db) RolePermissions() RolePermissionStore {
 	return RolePermissionsWith(d.Store)
 }
@@ -290,6 +312,10 @@ func (d *db) SearchContexts() SearchContextsStore {
 	return SearchContextsWith(d.logger, d.Store)
 }
 
+func (d *db) SearchIndexBranches() SearchIndexBranchStore {
+	return SearchIndexBranchesWith(d.Store)
+}
+
 func (d *db) Settings() SettingsStore {
 	return SettingsWith(d.Store)
 }
@@ -325,6 +351,10 @@ func (d *db) UserRoles() UserRoleStore {
 	return UserRolesWith(d.Store)
 }
 
+func (d *db) UserTOTPCredentials(key encryption.Key) UserTOTPCredentialStore {
+	return UserTOTPCredentialsWith(d.Store, key)
+}
+
 func (d *db) Users() UserStore {
 	return UsersWith(d.logger, d.Store)
 }
@@ -27,6 +27,8 @@ var EncryptionConfigs = []EncryptionConfig{
 	webhooklogsEncryptionConfig,
 	executorSecretsEncryptionConfig,
 	outboundWebhooksEncryptionConfig,
+	repoDeployKeysEncryptionConfig,
+	userTOTPCredentialsEncryptionConfig,
 }
 
 var externalServicesEncryptionConfig = EncryptionConfig{
@@ -103,6 +105,26 @@ var outboundWebhooksEncryptionConfig = EncryptionConfig{
 	Limit:               5,
 }
 
+var repoDeployKeysEncryptionConfig = EncryptionConfig{
+	TableName:           "repo_deploy_keys",
+	IDFieldName:         "repo_id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"private_key", "passphrase"},
+	Scan:                basestore.NewMapScanner(scanEncryptedStringPair),
+	Key:                 func() encryption.Key { return keyring.Default().ExternalServiceKey },
+	Limit:               5,
+}
+
+var userTOTPCredentialsEncryptionConfig = EncryptionConfig{
+	TableName:           "user_totp_credentials",
+	IDFieldName:         "user_id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"secret"},
+	Scan:                basestore.NewMapScanner(scanEncryptedString),
+	Key:                 func() encryption.Key { return keyring.Default().UserExternalAccountKey },
+	Limit:               100,
+}
+
 func scanEncryptedString(scanner dbutil.Scanner) (id int, e Encrypted, err error) {
 	e.Values = make([]string, 1)
 	err = scanner.Scan(&id, &e.KeyID, &e.Values[0])
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -613,7 +614,7 @@ func (e *externalServiceStore) Create(ctx context.Context, confGet func() *conf.
 	}
 	es.CodeHostID = &chID
 
-	return tx.QueryRow(
+	err = tx.QueryRow(
 		ctx,
 		sqlf.Sprintf(
 			createExternalServiceQueryFmtstr,
@@ -631,6 +632,17 @@ func (e *externalServiceStore) Create(ctx context.Context, confGet func() *conf.
 			es.LastUpdaterID,
 		),
 	).Scan(&es.ID)
+	if err != nil {
+		return err
+	}
+
+	NewDBWith(e.logger, tx).AuditEvents().Log(ctx, &AuditEvent{
+		Action:     AuditActionExternalServiceCreated,
+		EntityType: AuditEntityExternalService,
+		EntityID:   strconv.FormatInt(es.ID, 10),
+		Metadata:   map[string]any{"kind": es.Kind, "displayName": es.DisplayName},
+	})
+	return nil
 }
 
 const createExternalServiceQueryFmtstr = `
@@ -1007,6 +1019,27 @@ func (e *externalServiceStore) Update(ctx context.Context, ps []schema.AuthProvi
 	if affected == 0 {
 		return externalServiceNotFoundError{id: id}
 	}
+
+	// Sync bookkeeping updates are not audited, only changes to the
+	// connection itself.
+	if update.Config == nil && update.DisplayName == nil && update.CloudDefault == nil {
+		return nil
+	}
+	// 🚨 SECURITY: The configuration contains secrets and must never be
+	// recorded, only whether it changed.
+	metadata := map[string]any{"configChanged": update.Config != nil}
+	if update.DisplayName != nil {
+		metadata["displayName"] = *update.DisplayName
+	}
+	if update.CloudDefault != nil {
+		metadata["cloudDefault"] = *update.CloudDefault
+	}
+	NewDBWith(e.logger, tx).AuditEvents().Log(ctx, &AuditEvent{
+		Action:     AuditActionExternalServiceUpdated,
+		EntityType: AuditEntityExternalService,
+		EntityID:   strconv.FormatInt(id, 10),
+		Metadata:   metadata,
+	})
 	return nil
 }
 
@@ -1125,6 +1158,12 @@ CREATE TEMPORARY TABLE IF NOT EXISTS
 	if nrows == 0 {
 		return externalServiceNotFoundError{id: id}
 	}
+
+	NewDBWith(e.logger, tx).AuditEvents().Log(ctx, &AuditEvent{
+		Action:     AuditActionExternalServiceDeleted,
+		EntityType: AuditEntityExternalService,
+		EntityID:   strconv.FormatInt(id, 10),
+	})
 	return nil
 }
 
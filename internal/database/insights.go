@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/sourcegraph/log"
 
@@ -23,6 +24,18 @@ func NewInsightsDB(inner *sql.DB, logger log.Logger) InsightsDB {
 	return &insightsDB{basestore.NewWithHandle(basestore.NewHandleWithDB(logger, inner, sql.TxOptions{}))}
 }
 
+// NewInsightsDBWithReplica returns a handle to the codeinsights-db which sends read-only
+// queries opted in with basestore.WithReadReplicaPreferred to the given replica, as
+// long as it lags at most maxLag behind the primary. If replica is nil, this is
+// equivalent to NewInsightsDB.
+func NewInsightsDBWithReplica(inner, replica *sql.DB, maxLag time.Duration, logger log.Logger) InsightsDB {
+	if replica == nil {
+		return NewInsightsDB(inner, logger)
+	}
+
+	return &insightsDB{basestore.NewWithHandle(basestore.NewHandleWithReplica(logger, inner, replica, sql.TxOptions{}, maxLag))}
+}
+
 func NewInsightsDBWith(other basestore.ShareableStore) InsightsDB {
 	return &insightsDB{basestore.NewWithHandle(other.Handle())}
 }
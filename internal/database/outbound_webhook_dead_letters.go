@@ -0,0 +1,363 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type OutboundWebhookDeadLetterStore interface {
+	basestore.ShareableStore
+	WithTransact(context.Context, func(OutboundWebhookDeadLetterStore) error) error
+	With(basestore.ShareableStore) OutboundWebhookDeadLetterStore
+	Query(ctx context.Context, query *sqlf.Query) (*sql.Rows, error)
+	Done(error) error
+
+	Count(context.Context, OutboundWebhookDeadLetterListOpts) (int64, error)
+	Create(context.Context, *types.OutboundWebhookDeadLetter) error
+	DeleteReplayedBefore(ctx context.Context, before time.Time) error
+	GetByID(context.Context, int64) (*types.OutboundWebhookDeadLetter, error)
+	List(context.Context, OutboundWebhookDeadLetterListOpts) ([]*types.OutboundWebhookDeadLetter, error)
+	// Replay enqueues a job that sends the payload of the dead letter to its
+	// outbound webhook again, and marks the dead letter as replayed.
+	Replay(ctx context.Context, id int64) (*types.OutboundWebhookDeadLetter, error)
+}
+
+type OutboundWebhookDeadLetterNotFoundErr struct{ id int64 }
+
+func (err OutboundWebhookDeadLetterNotFoundErr) Error() string {
+	return fmt.Sprintf("outbound webhook dead letter with id %v not found", err.id)
+}
+
+func (OutboundWebhookDeadLetterNotFoundErr) NotFound() bool { return true }
+
+// ErrOutboundWebhookDeadLetterReplayed is returned when replaying a dead letter
+// that has already been replayed.
+var ErrOutboundWebhookDeadLetterReplayed = errors.New("outbound webhook dead letter has already been replayed")
+
+type OutboundWebhookDeadLetterListOpts struct {
+	*LimitOffset
+	// OutboundWebhookID limits the dead letters to the given webhook, if set.
+	OutboundWebhookID *int64
+	// IncludeReplayed includes dead letters that have already been replayed.
+	IncludeReplayed bool
+}
+
+func (opts *OutboundWebhookDeadLetterListOpts) where() *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.OutboundWebhookID != nil {
+		preds = append(preds, sqlf.Sprintf("outbound_webhook_id = %s", *opts.OutboundWebhookID))
+	}
+	if !opts.IncludeReplayed {
+		preds = append(preds, sqlf.Sprintf("replayed_at IS NULL"))
+	}
+
+	return sqlf.Join(preds, "AND")
+}
+
+type outboundWebhookDeadLetterStore struct {
+	*basestore.Store
+	key encryption.Key
+}
+
+func OutboundWebhookDeadLettersWith(other basestore.ShareableStore, key encryption.Key) OutboundWebhookDeadLetterStore {
+	return &outboundWebhookDeadLetterStore{
+		Store: basestore.NewWithHandle(other.Handle()),
+		key:   key,
+	}
+}
+
+func (s *outboundWebhookDeadLetterStore) With(other basestore.ShareableStore) OutboundWebhookDeadLetterStore {
+	return &outboundWebhookDeadLetterStore{
+		Store: s.Store.With(other),
+		key:   s.key,
+	}
+}
+
+func (s *outboundWebhookDeadLetterStore) WithTransact(ctx context.Context, f func(OutboundWebhookDeadLetterStore) error) error {
+	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		return f(&outboundWebhookDeadLetterStore{
+			Store: tx,
+			key:   s.key,
+		})
+	})
+}
+
+func (s *outboundWebhookDeadLetterStore) Count(ctx context.Context, opts OutboundWebhookDeadLetterListOpts) (int64, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookDeadLetterCountQueryFmtstr,
+		opts.where(),
+	)
+
+	var count int64
+	err := s.QueryRow(ctx, q).Scan(&count)
+	return count, err
+}
+
+func (s *outboundWebhookDeadLetterStore) Create(ctx context.Context, letter *types.OutboundWebhookDeadLetter) error {
+	rawPayload, _, err := letter.Payload.Encrypt(ctx, s.key)
+	if err != nil {
+		return errors.Wrap(err, "encrypting payload")
+	}
+
+	rawError, keyID, err := letter.LastError.Encrypt(ctx, s.key)
+	if err != nil {
+		return errors.Wrap(err, "encrypting error")
+	}
+
+	q := sqlf.Sprintf(
+		outboundWebhookDeadLetterCreateQueryFmtstr,
+		letter.OutboundWebhookID,
+		letter.JobID,
+		letter.EventType,
+		letter.Scope,
+		dbutil.NullStringColumn(keyID),
+		[]byte(rawPayload),
+		letter.Attempts,
+		letter.LastStatusCode,
+		[]byte(rawError),
+		sqlf.Join(outboundWebhookDeadLetterColumns, ","),
+	)
+
+	if err := s.scanOutboundWebhookDeadLetter(letter, s.QueryRow(ctx, q)); err != nil {
+		return errors.Wrap(err, "scanning outbound webhook dead letter")
+	}
+
+	return nil
+}
+
+func (s *outboundWebhookDeadLetterStore) DeleteReplayedBefore(ctx context.Context, before time.Time) error {
+	q := sqlf.Sprintf(
+		outboundWebhookDeadLetterDeleteReplayedBeforeQueryFmtstr,
+		before,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+func (s *outboundWebhookDeadLetterStore) GetByID(ctx context.Context, id int64) (*types.OutboundWebhookDeadLetter, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookDeadLetterGetByIDQueryFmtstr,
+		sqlf.Join(outboundWebhookDeadLetterColumns, ","),
+		id,
+	)
+
+	var letter types.OutboundWebhookDeadLetter
+	if err := s.scanOutboundWebhookDeadLetter(&letter, s.QueryRow(ctx, q)); err == sql.ErrNoRows {
+		return nil, OutboundWebhookDeadLetterNotFoundErr{id: id}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &letter, nil
+}
+
+func (s *outboundWebhookDeadLetterStore) List(ctx context.Context, opts OutboundWebhookDeadLetterListOpts) ([]*types.OutboundWebhookDeadLetter, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookDeadLetterListQueryFmtstr,
+		sqlf.Join(outboundWebhookDeadLetterColumns, ","),
+		opts.where(),
+		opts.LimitOffset.SQL(),
+	)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	letters := []*types.OutboundWebhookDeadLetter{}
+	for rows.Next() {
+		var letter types.OutboundWebhookDeadLetter
+		if err := s.scanOutboundWebhookDeadLetter(&letter, rows); err != nil {
+			return nil, err
+		}
+		letters = append(letters, &letter)
+	}
+
+	return letters, rows.Err()
+}
+
+func (s *outboundWebhookDeadLetterStore) Replay(ctx context.Context, id int64) (letter *types.OutboundWebhookDeadLetter, err error) {
+	err = s.WithTransact(ctx, func(tx OutboundWebhookDeadLetterStore) error {
+		store := tx.(*outboundWebhookDeadLetterStore)
+
+		letter = &types.OutboundWebhookDeadLetter{}
+		q := sqlf.Sprintf(
+			outboundWebhookDeadLetterGetByIDForUpdateQueryFmtstr,
+			sqlf.Join(outboundWebhookDeadLetterColumns, ","),
+			id,
+		)
+		if err := store.scanOutboundWebhookDeadLetter(letter, store.QueryRow(ctx, q)); err == sql.ErrNoRows {
+			return OutboundWebhookDeadLetterNotFoundErr{id: id}
+		} else if err != nil {
+			return err
+		}
+		if letter.ReplayedAt != nil {
+			return ErrOutboundWebhookDeadLetterReplayed
+		}
+
+		payload, err := letter.Payload.Decrypt(ctx)
+		if err != nil {
+			return errors.Wrap(err, "decrypting payload")
+		}
+
+		job, err := OutboundWebhookJobsWith(store, store.key).CreateForOutboundWebhook(
+			ctx, letter.OutboundWebhookID, letter.EventType, letter.Scope, []byte(payload),
+		)
+		if err != nil {
+			return errors.Wrap(err, "creating outbound webhook job")
+		}
+
+		q = sqlf.Sprintf(
+			outboundWebhookDeadLetterMarkReplayedQueryFmtstr,
+			job.ID,
+			id,
+			sqlf.Join(outboundWebhookDeadLetterColumns, ","),
+		)
+		return store.scanOutboundWebhookDeadLetter(letter, store.QueryRow(ctx, q))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return letter, nil
+}
+
+func (s *outboundWebhookDeadLetterStore) scanOutboundWebhookDeadLetter(letter *types.OutboundWebhookDeadLetter, sc dbutil.Scanner) error {
+	var (
+		keyID      string
+		rawPayload []byte
+		rawError   []byte
+	)
+
+	if err := sc.Scan(
+		&letter.ID,
+		&letter.OutboundWebhookID,
+		&letter.JobID,
+		&letter.EventType,
+		&letter.Scope,
+		&dbutil.NullString{S: &keyID},
+		&rawPayload,
+		&letter.Attempts,
+		&letter.LastStatusCode,
+		&rawError,
+		&letter.CreatedAt,
+		&letter.ReplayedAt,
+		&letter.ReplayJobID,
+	); err != nil {
+		return err
+	}
+
+	letter.Payload = encryption.NewEncrypted(string(rawPayload), keyID, s.key)
+	letter.LastError = encryption.NewEncrypted(string(rawError), keyID, s.key)
+
+	return nil
+}
+
+var outboundWebhookDeadLetterColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("outbound_webhook_id"),
+	sqlf.Sprintf("job_id"),
+	sqlf.Sprintf("event_type"),
+	sqlf.Sprintf("scope"),
+	sqlf.Sprintf("encryption_key_id"),
+	sqlf.Sprintf("payload"),
+	sqlf.Sprintf("attempts"),
+	sqlf.Sprintf("last_status_code"),
+	sqlf.Sprintf("last_error"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("replayed_at"),
+	sqlf.Sprintf("replay_job_id"),
+}
+
+const outboundWebhookDeadLetterCountQueryFmtstr = `
+-- source: internal/database/outbound_webhook_dead_letters.go:Count
+SELECT
+	COUNT(*)
+FROM
+	outbound_webhook_dead_letters
+WHERE
+	%s
+`
+
+const outboundWebhookDeadLetterCreateQueryFmtstr = `
+-- source: internal/database/outbound_webhook_dead_letters.go:Create
+INSERT INTO
+	outbound_webhook_dead_letters (
+		outbound_webhook_id,
+		job_id,
+		event_type,
+		scope,
+		encryption_key_id,
+		payload,
+		attempts,
+		last_status_code,
+		last_error
+	)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+const outboundWebhookDeadLetterDeleteReplayedBeforeQueryFmtstr = `
+-- source: internal/database/outbound_webhook_dead_letters.go:DeleteReplayedBefore
+DELETE FROM
+	outbound_webhook_dead_letters
+WHERE
+	replayed_at < %s
+`
+
+const outboundWebhookDeadLetterGetByIDQueryFmtstr = `
+-- source: internal/database/outbound_webhook_dead_letters.go:GetByID
+SELECT
+	%s
+FROM
+	outbound_webhook_dead_letters
+WHERE
+	id = %s
+`
+
+const outboundWebhookDeadLetterGetByIDForUpdateQueryFmtstr = `
+-- source: internal/database/outbound_webhook_dead_letters.go:Replay
+SELECT
+	%s
+FROM
+	outbound_webhook_dead_letters
+WHERE
+	id = %s
+FOR UPDATE
+`
+
+const outboundWebhookDeadLetterListQueryFmtstr = `
+-- source: internal/database/outbound_webhook_dead_letters.go:List
+SELECT
+	%s
+FROM
+	outbound_webhook_dead_letters
+WHERE
+	%s
+ORDER BY
+	id DESC
+%s -- LIMIT
+`
+
+const outboundWebhookDeadLetterMarkReplayedQueryFmtstr = `
+-- source: internal/database/outbound_webhook_dead_letters.go:Replay
+UPDATE
+	outbound_webhook_dead_letters
+SET
+	replayed_at = NOW(),
+	replay_job_id = %s
+WHERE
+	id = %s
+RETURNING %s
+`
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestOutboundWebhookDeadLetters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	runBothEncryptionStates(t, func(t *testing.T, logger log.Logger, db DB, key encryption.Key) {
+		user, webhook := setupOutboundWebhookTest(t, ctx, db, key)
+		other := newTestWebhook(t, user, ScopedEventType{EventType: "foo"})
+		require.NoError(t, db.OutboundWebhooks(key).Create(ctx, other))
+
+		job, err := db.OutboundWebhookJobs(key).Create(ctx, "foo", pointers.Ptr("scope"), []byte(`"TEST"`))
+		require.NoError(t, err)
+
+		store := db.OutboundWebhooks(key).ToDeadLetterStore()
+
+		var first, second *types.OutboundWebhookDeadLetter
+
+		t.Run("Create", func(t *testing.T) {
+			t.Run("bad key", func(t *testing.T) {
+				want := errors.New("bad key")
+				key := &et.BadKey{Err: want}
+
+				have := OutboundWebhookDeadLettersWith(store, key).Create(ctx, newOutboundWebhookDeadLetter(job, webhook))
+				assert.ErrorIs(t, have, want)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				first = newOutboundWebhookDeadLetter(job, webhook)
+				require.NoError(t, store.Create(ctx, first))
+				assert.NotZero(t, first.ID)
+				assert.Equal(t, `"TEST"`, decryptedValue(t, ctx, first.Payload))
+				assert.Equal(t, "unexpected status code: 500", decryptedValue(t, ctx, first.LastError))
+
+				second = newOutboundWebhookDeadLetter(job, other)
+				require.NoError(t, store.Create(ctx, second))
+			})
+		})
+
+		t.Run("GetByID", func(t *testing.T) {
+			_, err := store.GetByID(ctx, 0)
+			assert.True(t, errcode.IsNotFound(err))
+
+			have, err := store.GetByID(ctx, first.ID)
+			require.NoError(t, err)
+			assertEqualOutboundWebhookDeadLetters(t, ctx, first, have)
+		})
+
+		t.Run("List and Count", func(t *testing.T) {
+			for name, tc := range map[string]struct {
+				opts OutboundWebhookDeadLetterListOpts
+				want []*types.OutboundWebhookDeadLetter
+			}{
+				"all": {
+					want: []*types.OutboundWebhookDeadLetter{second, first},
+				},
+				"by webhook": {
+					opts: OutboundWebhookDeadLetterListOpts{OutboundWebhookID: &webhook.ID},
+					want: []*types.OutboundWebhookDeadLetter{first},
+				},
+				"first page": {
+					opts: OutboundWebhookDeadLetterListOpts{LimitOffset: &LimitOffset{Limit: 1}},
+					want: []*types.OutboundWebhookDeadLetter{second},
+				},
+			} {
+				t.Run(name, func(t *testing.T) {
+					have, err := store.List(ctx, tc.opts)
+					require.NoError(t, err)
+					require.Len(t, have, len(tc.want))
+					for i := range have {
+						assertEqualOutboundWebhookDeadLetters(t, ctx, tc.want[i], have[i])
+					}
+
+					count, err := store.Count(ctx, tc.opts)
+					require.NoError(t, err)
+					if tc.opts.LimitOffset == nil {
+						assert.EqualValues(t, len(tc.want), count)
+					}
+				})
+			}
+		})
+
+		t.Run("Replay", func(t *testing.T) {
+			_, err := store.Replay(ctx, 0)
+			assert.True(t, errcode.IsNotFound(err))
+
+			replayed, err := store.Replay(ctx, first.ID)
+			require.NoError(t, err)
+			require.NotNil(t, replayed.ReplayedAt)
+			require.NotNil(t, replayed.ReplayJobID)
+
+			replayJob, err := db.OutboundWebhookJobs(key).GetByID(ctx, *replayed.ReplayJobID)
+			require.NoError(t, err)
+			assert.Equal(t, &webhook.ID, replayJob.OutboundWebhookID)
+			assert.Equal(t, "foo", replayJob.EventType)
+			assert.Equal(t, pointers.Ptr("scope"), replayJob.Scope)
+			assert.Equal(t, `"TEST"`, decryptedValue(t, ctx, replayJob.Payload))
+
+			_, err = store.Replay(ctx, first.ID)
+			assert.ErrorIs(t, err, ErrOutboundWebhookDeadLetterReplayed)
+
+			// Replayed dead letters are hidden by default.
+			have, err := store.List(ctx, OutboundWebhookDeadLetterListOpts{})
+			require.NoError(t, err)
+			require.Len(t, have, 1)
+			assert.Equal(t, second.ID, have[0].ID)
+
+			have, err = store.List(ctx, OutboundWebhookDeadLetterListOpts{IncludeReplayed: true})
+			require.NoError(t, err)
+			assert.Len(t, have, 2)
+		})
+
+		t.Run("DeleteReplayedBefore", func(t *testing.T) {
+			require.NoError(t, store.DeleteReplayedBefore(ctx, time.Now().Add(time.Hour)))
+
+			have, err := store.List(ctx, OutboundWebhookDeadLetterListOpts{IncludeReplayed: true})
+			require.NoError(t, err)
+			require.Len(t, have, 1)
+			assert.Equal(t, second.ID, have[0].ID)
+		})
+	})
+}
+
+func newOutboundWebhookDeadLetter(job *types.OutboundWebhookJob, webhook *types.OutboundWebhook) *types.OutboundWebhookDeadLetter {
+	return &types.OutboundWebhookDeadLetter{
+		OutboundWebhookID: webhook.ID,
+		JobID:             job.ID,
+		EventType:         job.EventType,
+		Scope:             job.Scope,
+		Payload:           encryption.NewUnencrypted(`"TEST"`),
+		Attempts:          5,
+		LastStatusCode:    500,
+		LastError:         encryption.NewUnencrypted("unexpected status code: 500"),
+	}
+}
+
+func assertEqualOutboundWebhookDeadLetters(t *testing.T, ctx context.Context, want, have *types.OutboundWebhookDeadLetter) {
+	t.Helper()
+
+	assert.Equal(t, want.ID, have.ID)
+	assert.Equal(t, want.OutboundWebhookID, have.OutboundWebhookID)
+	assert.Equal(t, want.JobID, have.JobID)
+	assert.Equal(t, want.EventType, have.EventType)
+	assert.Equal(t, want.Scope, have.Scope)
+	assert.Equal(t, want.Attempts, have.Attempts)
+	assert.Equal(t, want.LastStatusCode, have.LastStatusCode)
+	assert.Equal(t, want.CreatedAt, have.CreatedAt)
+	assert.Equal(t, decryptedValue(t, ctx, want.Payload), decryptedValue(t, ctx, have.Payload))
+	assert.Equal(t, decryptedValue(t, ctx, want.LastError), decryptedValue(t, ctx, have.LastError))
+}
@@ -25,6 +25,9 @@ type OutboundWebhookJobStore interface {
 	Done(error) error
 
 	Create(ctx context.Context, eventType string, scope *string, payload []byte) (*types.OutboundWebhookJob, error)
+	// CreateForOutboundWebhook creates a job that is only sent to the given
+	// outbound webhook, regardless of the event types it is registered for.
+	CreateForOutboundWebhook(ctx context.Context, outboundWebhookID int64, eventType string, scope *string, payload []byte) (*types.OutboundWebhookJob, error)
 	DeleteBefore(ctx context.Context, before time.Time) error
 	GetByID(ctx context.Context, id int64) (*types.OutboundWebhookJob, error)
 	GetLast(ctx context.Context) (*types.OutboundWebhookJob, error)
@@ -70,11 +73,23 @@ func (s *outboundWebhookJobStore) WithTransact(ctx context.Context, f func(Outbo
 }
 
 func (s *outboundWebhookJobStore) Create(ctx context.Context, eventType string, scope *string, payload []byte) (*types.OutboundWebhookJob, error) {
-	job := &types.OutboundWebhookJob{
+	return s.create(ctx, &types.OutboundWebhookJob{
 		EventType: eventType,
 		Scope:     scope,
 		Payload:   encryption.NewUnencrypted(string(payload)),
-	}
+	})
+}
+
+func (s *outboundWebhookJobStore) CreateForOutboundWebhook(ctx context.Context, outboundWebhookID int64, eventType string, scope *string, payload []byte) (*types.OutboundWebhookJob, error) {
+	return s.create(ctx, &types.OutboundWebhookJob{
+		EventType:         eventType,
+		Scope:             scope,
+		Payload:           encryption.NewUnencrypted(string(payload)),
+		OutboundWebhookID: &outboundWebhookID,
+	})
+}
+
+func (s *outboundWebhookJobStore) create(ctx context.Context, job *types.OutboundWebhookJob) (*types.OutboundWebhookJob, error) {
 
 	enc, keyID, err := job.Payload.Encrypt(ctx, s.key)
 	if err != nil {
@@ -87,6 +102,7 @@ func (s *outboundWebhookJobStore) Create(ctx context.Context, eventType string,
 		job.Scope,
 		dbutil.NullStringColumn(keyID),
 		[]byte(enc),
+		job.OutboundWebhookID,
 		sqlf.Join(OutboundWebhookJobColumns, ","),
 	)
 
@@ -164,6 +180,7 @@ func scanOutboundWebhookJob(key encryption.Key, job *types.OutboundWebhookJob, s
 		&job.ID,
 		&job.EventType,
 		&job.Scope,
+		&job.OutboundWebhookID,
 		&dbutil.NullString{S: &keyID},
 		&rawPayload,
 		&job.State,
@@ -197,6 +214,7 @@ var OutboundWebhookJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("id"),
 	sqlf.Sprintf("event_type"),
 	sqlf.Sprintf("scope"),
+	sqlf.Sprintf("outbound_webhook_id"),
 	sqlf.Sprintf("encryption_key_id"),
 	sqlf.Sprintf("payload"),
 	sqlf.Sprintf("state"),
@@ -220,9 +238,10 @@ INSERT INTO
 		event_type,
 		scope,
 		encryption_key_id,
-		payload
+		payload,
+		outbound_webhook_id
 	)
-VALUES (%s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -122,6 +122,7 @@ func assertEqualOutboundWebhookJobs(t *testing.T, ctx context.Context, want, hav
 	assert.Equal(t, want.ID, have.ID)
 	assert.Equal(t, want.EventType, have.EventType)
 	assert.Equal(t, want.Scope, have.Scope)
+	assert.Equal(t, want.OutboundWebhookID, have.OutboundWebhookID)
 	assert.Equal(t, want.State, have.State)
 	assert.Equal(t, want.FailureMessage, have.FailureMessage)
 	assert.Equal(t, want.QueuedAt, have.QueuedAt)
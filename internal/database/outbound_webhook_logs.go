@@ -22,6 +22,9 @@ type OutboundWebhookLogStore interface {
 
 	CountsForOutboundWebhook(ctx context.Context, outboundWebhookID int64) (total, errored int64, err error)
 	Create(context.Context, *types.OutboundWebhookLog) error
+	// DeliveryAttemptsForJob returns the delivery attempts of the given job by
+	// outbound webhook ID.
+	DeliveryAttemptsForJob(ctx context.Context, jobID int64) (map[int64]OutboundWebhookDeliveryAttempts, error)
 	ListForOutboundWebhook(ctx context.Context, opts OutboundWebhookLogListOpts) ([]*types.OutboundWebhookLog, error)
 }
 
@@ -46,6 +49,15 @@ func (opts *OutboundWebhookLogListOpts) where() *sqlf.Query {
 	return sqlf.Join(preds, "AND")
 }
 
+// OutboundWebhookDeliveryAttempts summarises the logged attempts to send the
+// payload of a job to an outbound webhook.
+type OutboundWebhookDeliveryAttempts struct {
+	// Attempts is the number of times the payload was sent.
+	Attempts int
+	// Delivered is true if any attempt succeeded.
+	Delivered bool
+}
+
 type outboundWebhookLogStore struct {
 	*basestore.Store
 	key encryption.Key
@@ -120,6 +132,33 @@ func (s *outboundWebhookLogStore) Create(ctx context.Context, log *types.Outboun
 	return nil
 }
 
+func (s *outboundWebhookLogStore) DeliveryAttemptsForJob(ctx context.Context, jobID int64) (map[int64]OutboundWebhookDeliveryAttempts, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookLogDeliveryAttemptsForJobQueryFmtstr,
+		jobID,
+	)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := map[int64]OutboundWebhookDeliveryAttempts{}
+	for rows.Next() {
+		var (
+			webhookID int64
+			a         OutboundWebhookDeliveryAttempts
+		)
+		if err := rows.Scan(&webhookID, &a.Attempts, &a.Delivered); err != nil {
+			return nil, err
+		}
+		attempts[webhookID] = a
+	}
+
+	return attempts, rows.Err()
+}
+
 func (s *outboundWebhookLogStore) ListForOutboundWebhook(ctx context.Context, opts OutboundWebhookLogListOpts) ([]*types.OutboundWebhookLog, error) {
 	q := sqlf.Sprintf(
 		outboundWebhookLogListForOutboundWebhookQueryFmtstr,
@@ -214,6 +253,20 @@ VALUES (%s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
+const outboundWebhookLogDeliveryAttemptsForJobQueryFmtstr = `
+-- source: internal/database/outbound_webhook_logs.go:DeliveryAttemptsForJob
+SELECT
+	outbound_webhook_id,
+	COUNT(*) AS attempts,
+	bool_or(status_code BETWEEN 100 AND 399) AS delivered
+FROM
+	outbound_webhook_logs
+WHERE
+	job_id = %s
+GROUP BY
+	outbound_webhook_id
+`
+
 const outboundWebhookLogListForOutboundWebhookQueryFmtstr = `
 -- source: internal/database/outbound_webhook_logs.go:ListForOutboundWebhook
 SELECT
@@ -95,6 +95,18 @@ func TestOutboundWebhookLogs(t *testing.T) {
 			})
 		})
 
+		t.Run("DeliveryAttemptsForJob", func(t *testing.T) {
+			have, err := store.DeliveryAttemptsForJob(ctx, job.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, map[int64]OutboundWebhookDeliveryAttempts{
+				webhook.ID: {Attempts: 3, Delivered: true},
+			}, have)
+
+			have, err = store.DeliveryAttemptsForJob(ctx, 0)
+			assert.NoError(t, err)
+			assert.Empty(t, have)
+		})
+
 		t.Run("ListForOutboundWebhook", func(t *testing.T) {
 			for name, tc := range map[string]struct {
 				opts OutboundWebhookLogListOpts
@@ -22,10 +22,11 @@ type OutboundWebhookStore interface {
 	Query(ctx context.Context, query *sqlf.Query) (*sql.Rows, error)
 	Done(error) error
 
-	// Convenience methods to construct job and log stores with the same
-	// encryption key.
+	// Convenience methods to construct job, log, and dead letter stores with
+	// the same encryption key.
 	ToJobStore() OutboundWebhookJobStore
 	ToLogStore() OutboundWebhookLogStore
+	ToDeadLetterStore() OutboundWebhookDeadLetterStore
 
 	Count(context.Context, OutboundWebhookCountOpts) (int64, error)
 	Create(context.Context, *types.OutboundWebhook) error
@@ -84,6 +85,13 @@ func (s *outboundWebhookStore) ToLogStore() OutboundWebhookLogStore {
 	}
 }
 
+func (s *outboundWebhookStore) ToDeadLetterStore() OutboundWebhookDeadLetterStore {
+	return &outboundWebhookDeadLetterStore{
+		Store: s.Store,
+		key:   s.key,
+	}
+}
+
 const FilterEventTypeNoScope string = "RESERVED_KEYWORD_MATCH_NULL_SCOPE"
 
 type FilterEventType struct {
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// BlameSignalStore persists the blame ownership signal: the number of lines
+// attributed to each author by git blame, aggregated per file and up the file
+// tree.
+type BlameSignalStore interface {
+	// ReplaceBlameSignals replaces all the blame signals of the repository with
+	// the given per-file signals. Line counts are aggregated for all the
+	// ancestor directories of the given files, including the repo root.
+	ReplaceBlameSignals(ctx context.Context, repoID api.RepoID, signals []BlameSignal) error
+	// FindBlameAuthors returns the authors of the lines within given `repoID`
+	// and `path`, ordered by decreasing line count. Empty string `path`
+	// designates the repo root.
+	FindBlameAuthors(ctx context.Context, repoID api.RepoID, path string) ([]BlameAuthorSummary, error)
+}
+
+// BlameSignal is the number of lines of the file at Path attributed to an
+// author by git blame.
+type BlameSignal struct {
+	Path        string
+	AuthorName  string
+	AuthorEmail string
+	LineCount   int
+}
+
+type BlameAuthorSummary struct {
+	AuthorName  string
+	AuthorEmail string
+	LineCount   int
+}
+
+func BlameSignalStoreWith(other basestore.ShareableStore) BlameSignalStore {
+	return &blameSignalStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+type blameSignalStore struct {
+	*basestore.Store
+}
+
+const clearBlameSignalsFmtstr = `
+	DELETE FROM own_aggregate_blame_lines
+	WHERE file_path_id IN (
+		SELECT id FROM repo_paths WHERE repo_id = %s
+	)
+`
+
+func (s *blameSignalStore) ReplaceBlameSignals(ctx context.Context, repoID api.RepoID, signals []BlameSignal) error {
+	type author struct {
+		name  string
+		email string
+	}
+	type key struct {
+		path   string
+		author author
+	}
+	// Aggregate the line counts up the file tree.
+	counts := map[key]int{}
+	for _, signal := range signals {
+		a := author{name: signal.AuthorName, email: signal.AuthorEmail}
+		for p := signal.Path; ; p = path.Dir(p) {
+			if p == "." {
+				p = ""
+			}
+			counts[key{path: p, author: a}] += signal.LineCount
+			if p == "" {
+				break
+			}
+		}
+	}
+	// Sort the keys so that the inserts are deterministic.
+	keys := make([]key, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path != keys[j].path {
+			return keys[i].path < keys[j].path
+		}
+		if keys[i].author.name != keys[j].author.name {
+			return keys[i].author.name < keys[j].author.name
+		}
+		return keys[i].author.email < keys[j].author.email
+	})
+
+	return s.WithTransact(ctx, func(tx *basestore.Store) error {
+		if err := tx.Exec(ctx, sqlf.Sprintf(clearBlameSignalsFmtstr, repoID)); err != nil {
+			return errors.Wrap(err, "clearing blame signals")
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+
+		var paths []string
+		seenPaths := map[string]bool{}
+		authorIDs := map[author]int{}
+		for _, k := range keys {
+			if !seenPaths[k.path] {
+				seenPaths[k.path] = true
+				paths = append(paths, k.path)
+			}
+			if _, ok := authorIDs[k.author]; !ok {
+				id, err := ensureCommitAuthor(ctx, tx, k.author.name, k.author.email)
+				if err != nil {
+					return errors.Wrap(err, "cannot insert commit author")
+				}
+				authorIDs[k.author] = id
+			}
+		}
+		pathIDs, err := ensureRepoPaths(ctx, tx, paths, repoID)
+		if err != nil {
+			return errors.Wrap(err, "cannot insert repo paths")
+		}
+		pathIDsByPath := make(map[string]int, len(paths))
+		for i, p := range paths {
+			pathIDsByPath[p] = pathIDs[i]
+		}
+
+		inserter := batch.NewInserter(ctx, tx.Handle(), "own_aggregate_blame_lines", batch.MaxNumPostgresParameters, "commit_author_id", "file_path_id", "line_count")
+		for _, k := range keys {
+			if err := inserter.Insert(ctx, authorIDs[k.author], pathIDsByPath[k.path], counts[k]); err != nil {
+				return err
+			}
+		}
+		return inserter.Flush(ctx)
+	})
+}
+
+const findBlameAuthorsFmtstr = `
+	SELECT a.name, a.email, b.line_count
+	FROM commit_authors AS a
+	INNER JOIN own_aggregate_blame_lines AS b
+	ON a.id = b.commit_author_id
+	INNER JOIN repo_paths AS p
+	ON p.id = b.file_path_id
+	WHERE p.repo_id = %s
+	AND p.absolute_path = %s
+	ORDER BY 3 DESC, 1, 2
+`
+
+var scanBlameAuthorSummaries = basestore.NewSliceScanner(func(scanner dbutil.Scanner) (BlameAuthorSummary, error) {
+	var s BlameAuthorSummary
+	err := scanner.Scan(&s.AuthorName, &s.AuthorEmail, &s.LineCount)
+	return s, err
+})
+
+func (s *blameSignalStore) FindBlameAuthors(ctx context.Context, repoID api.RepoID, path string) ([]BlameAuthorSummary, error) {
+	return scanBlameAuthorSummaries(s.Query(ctx, sqlf.Sprintf(findBlameAuthorsFmtstr, repoID, path)))
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBlameSignalStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	store := BlameSignalStoreWith(db)
+
+	ctx := context.Background()
+	repo := mustCreate(ctx, t, db, &types.Repo{Name: "a/b"})
+	otherRepo := mustCreate(ctx, t, db, &types.Repo{Name: "a/c"})
+
+	alice := func(path string, lines int) BlameSignal {
+		return BlameSignal{Path: path, AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: lines}
+	}
+	bob := func(path string, lines int) BlameSignal {
+		return BlameSignal{Path: path, AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: lines}
+	}
+
+	require.NoError(t, store.ReplaceBlameSignals(ctx, repo.ID, []BlameSignal{
+		alice("file1.txt", 10),
+		bob("file1.txt", 2),
+		bob("dir/file2.txt", 5),
+		alice("dir/subdir/file.txt", 1),
+		bob("dir/subdir/file.txt", 3),
+	}))
+	require.NoError(t, store.ReplaceBlameSignals(ctx, otherRepo.ID, []BlameSignal{
+		alice("file1.txt", 100),
+	}))
+
+	for p, want := range map[string][]BlameAuthorSummary{
+		"file1.txt": {
+			{AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 10},
+			{AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 2},
+		},
+		"dir": {
+			{AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 8},
+			{AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 1},
+		},
+		"dir/subdir": {
+			{AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 3},
+			{AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 1},
+		},
+		"": {
+			{AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 11},
+			{AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 10},
+		},
+		"unknown": nil,
+	} {
+		got, err := store.FindBlameAuthors(ctx, repo.ID, p)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "path %q", p)
+	}
+
+	t.Run("replacing signals", func(t *testing.T) {
+		require.NoError(t, store.ReplaceBlameSignals(ctx, repo.ID, []BlameSignal{bob("file1.txt", 4)}))
+
+		got, err := store.FindBlameAuthors(ctx, repo.ID, "")
+		require.NoError(t, err)
+		assert.Equal(t, []BlameAuthorSummary{{AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 4}}, got)
+
+		got, err = store.FindBlameAuthors(ctx, repo.ID, "dir")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		// Signals of other repositories are untouched.
+		got, err = store.FindBlameAuthors(ctx, otherRepo.ID, "")
+		require.NoError(t, err)
+		assert.Equal(t, []BlameAuthorSummary{{AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 100}}, got)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
 const CancellationReasonHigherPriority = "A job with higher priority was added."
@@ -96,6 +97,9 @@ var ReasonGroupToReasons = map[PermissionsSyncJobReasonGroup][]PermissionsSyncJo
 		ReasonGitHubOrgMemberRemovedEvent,
 		ReasonGitHubRepoEvent,
 		ReasonGitHubRepoMadePrivateEvent,
+		ReasonBitbucketCloudRepoCreatedEvent,
+		ReasonBitbucketCloudRepoMadePrivateEvent,
+		ReasonBitbucketCloudRepoTransferredEvent,
 	},
 	PermissionsSyncJobReasonGroupSchedule: {
 		ReasonUserOutdatedPermissions,
@@ -150,7 +154,10 @@ func (r PermissionsSyncJobReason) ResolveGroup() PermissionsSyncJobReasonGroup {
 		ReasonGitHubOrgMemberAddedEvent,
 		ReasonGitHubOrgMemberRemovedEvent,
 		ReasonGitHubRepoEvent,
-		ReasonGitHubRepoMadePrivateEvent:
+		ReasonGitHubRepoMadePrivateEvent,
+		ReasonBitbucketCloudRepoCreatedEvent,
+		ReasonBitbucketCloudRepoMadePrivateEvent,
+		ReasonBitbucketCloudRepoTransferredEvent:
 		return PermissionsSyncJobReasonGroupWebhook
 	case ReasonUserOutdatedPermissions,
 		ReasonUserNoPermissions,
@@ -206,6 +213,10 @@ const (
 	ReasonGitHubRepoEvent                  PermissionsSyncJobReason = "REASON_GITHUB_REPO_EVENT"
 	ReasonGitHubRepoMadePrivateEvent       PermissionsSyncJobReason = "REASON_GITHUB_REPO_MADE_PRIVATE_EVENT"
 
+	ReasonBitbucketCloudRepoCreatedEvent     PermissionsSyncJobReason = "REASON_BITBUCKET_CLOUD_REPO_CREATED_EVENT"
+	ReasonBitbucketCloudRepoMadePrivateEvent PermissionsSyncJobReason = "REASON_BITBUCKET_CLOUD_REPO_MADE_PRIVATE_EVENT"
+	ReasonBitbucketCloudRepoTransferredEvent PermissionsSyncJobReason = "REASON_BITBUCKET_CLOUD_REPO_TRANSFERRED_EVENT"
+
 	// ReasonManualRepoSync and below are reasons of permission syncs triggered
 	// manually.
 	ReasonManualRepoSync PermissionsSyncJobReason = "REASON_MANUAL_REPO_SYNC"
@@ -307,7 +318,8 @@ INSERT INTO permission_sync_jobs (
 	user_id,
 	priority,
 	invalidate_caches,
-	no_perms
+	no_perms,
+	trace_context
 )
 VALUES (
 	%s,
@@ -317,6 +329,7 @@ VALUES (
 	%s,
 	%s,
 	%s,
+	%s,
 	%s
 )
 ON CONFLICT DO NOTHING
@@ -344,6 +357,7 @@ func (s *permissionSyncJobStore) create(ctx context.Context, job *PermissionSync
 		job.Priority,
 		job.InvalidateCaches,
 		job.NoPerms,
+		dbworkerstore.NewTraceContext(ctx),
 		sqlf.Join(PermissionSyncJobColumns, ", "),
 	)
 
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RepoDeployKey is an SSH key pair that is used by gitserver to clone and
+// fetch a single repository. The public key is meant to be installed as a
+// (read-only) deploy key on the code host, the private key never leaves
+// Sourcegraph.
+type RepoDeployKey struct {
+	RepoID    api.RepoID
+	PublicKey string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	privateKey *encryption.Encryptable
+	passphrase *encryption.Encryptable
+}
+
+// PrivateKey decrypts and returns the private key of the deploy key along with
+// the passphrase that protects it.
+func (k *RepoDeployKey) PrivateKey(ctx context.Context) (privateKey, passphrase string, err error) {
+	privateKey, err = k.privateKey.Decrypt(ctx)
+	if err != nil {
+		return "", "", errors.Wrap(err, "decrypting private key")
+	}
+	passphrase, err = k.passphrase.Decrypt(ctx)
+	if err != nil {
+		return "", "", errors.Wrap(err, "decrypting passphrase")
+	}
+	return privateKey, passphrase, nil
+}
+
+// RepoDeployKeyNotFoundErr is returned when a repository has no deploy key.
+type RepoDeployKeyNotFoundErr struct {
+	repoID api.RepoID
+}
+
+func (err RepoDeployKeyNotFoundErr) Error() string {
+	return fmt.Sprintf("deploy key not found: repo_id=%d", err.repoID)
+}
+
+func (RepoDeployKeyNotFoundErr) NotFound() bool {
+	return true
+}
+
+// RepoDeployKeyStore provides access to the `repo_deploy_keys` table.
+type RepoDeployKeyStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) RepoDeployKeyStore
+
+	// Generate generates a new deploy key for the given repository. An existing
+	// deploy key of the repository is replaced, which means that the old public
+	// key stops working once it is removed from the code host.
+	Generate(ctx context.Context, repoID api.RepoID) (*RepoDeployKey, error)
+	// GetByRepoID returns the deploy key of the given repository. If the
+	// repository has no deploy key, a RepoDeployKeyNotFoundErr is returned.
+	GetByRepoID(ctx context.Context, repoID api.RepoID) (*RepoDeployKey, error)
+	// Delete removes the deploy key of the given repository. If the repository
+	// has no deploy key, a RepoDeployKeyNotFoundErr is returned.
+	Delete(ctx context.Context, repoID api.RepoID) error
+}
+
+type repoDeployKeyStore struct {
+	*basestore.Store
+
+	key encryption.Key
+}
+
+var _ RepoDeployKeyStore = (*repoDeployKeyStore)(nil)
+
+// RepoDeployKeysWith instantiates and returns a new RepoDeployKeyStore using
+// the other store handle.
+func RepoDeployKeysWith(other basestore.ShareableStore, key encryption.Key) RepoDeployKeyStore {
+	return &repoDeployKeyStore{
+		Store: basestore.NewWithHandle(other.Handle()),
+		key:   key,
+	}
+}
+
+func (s *repoDeployKeyStore) With(other basestore.ShareableStore) RepoDeployKeyStore {
+	return &repoDeployKeyStore{Store: s.Store.With(other), key: s.key}
+}
+
+func (s *repoDeployKeyStore) Generate(ctx context.Context, repoID api.RepoID) (*RepoDeployKey, error) {
+	pair, err := encryption.GenerateRSAKey()
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedPrivateKey, keyID, err := encryption.MaybeEncrypt(ctx, s.key, pair.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting private key")
+	}
+	encryptedPassphrase, _, err := encryption.MaybeEncrypt(ctx, s.key, pair.Passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting passphrase")
+	}
+
+	q := sqlf.Sprintf(
+		repoDeployKeyGenerateQueryFmtstr,
+		repoID,
+		pair.PublicKey,
+		encryptedPrivateKey,
+		encryptedPassphrase,
+		keyID,
+		sqlf.Join(repoDeployKeyColumns, ", "),
+	)
+	return scanRepoDeployKey(s.QueryRow(ctx, q), s.key)
+}
+
+const repoDeployKeyGenerateQueryFmtstr = `
+INSERT INTO repo_deploy_keys (repo_id, public_key, private_key, passphrase, encryption_key_id)
+VALUES (%s, %s, %s, %s, %s)
+ON CONFLICT (repo_id) DO UPDATE SET
+	public_key = EXCLUDED.public_key,
+	private_key = EXCLUDED.private_key,
+	passphrase = EXCLUDED.passphrase,
+	encryption_key_id = EXCLUDED.encryption_key_id,
+	updated_at = NOW()
+RETURNING %s
+`
+
+func (s *repoDeployKeyStore) GetByRepoID(ctx context.Context, repoID api.RepoID) (*RepoDeployKey, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM repo_deploy_keys WHERE repo_id = %s",
+		sqlf.Join(repoDeployKeyColumns, ", "),
+		repoID,
+	)
+	key, err := scanRepoDeployKey(s.QueryRow(ctx, q), s.key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, RepoDeployKeyNotFoundErr{repoID: repoID}
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+func (s *repoDeployKeyStore) Delete(ctx context.Context, repoID api.RepoID) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("DELETE FROM repo_deploy_keys WHERE repo_id = %s", repoID))
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return RepoDeployKeyNotFoundErr{repoID: repoID}
+	}
+	return nil
+}
+
+var repoDeployKeyColumns = []*sqlf.Query{
+	sqlf.Sprintf("repo_id"),
+	sqlf.Sprintf("public_key"),
+	sqlf.Sprintf("private_key"),
+	sqlf.Sprintf("passphrase"),
+	sqlf.Sprintf("encryption_key_id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+func scanRepoDeployKey(sc dbutil.Scanner, key encryption.Key) (*RepoDeployKey, error) {
+	var (
+		k                      RepoDeployKey
+		privateKey, passphrase string
+		keyID                  string
+	)
+	if err := sc.Scan(
+		&k.RepoID,
+		&k.PublicKey,
+		&privateKey,
+		&passphrase,
+		&keyID,
+		&k.CreatedAt,
+		&k.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	k.privateKey = encryption.NewEncrypted(privateKey, keyID, key)
+	k.passphrase = encryption.NewEncrypted(passphrase, keyID, key)
+	return &k, nil
+}
+
+// NewMockRepoDeployKey can be used in tests to create a deploy key with the
+// given private key and passphrase. DO NOT USE THIS OUTSIDE OF TESTS.
+func NewMockRepoDeployKey(k *RepoDeployKey, privateKey, passphrase string) *RepoDeployKey {
+	k.privateKey = encryption.NewUnencrypted(privateKey)
+	k.passphrase = encryption.NewUnencrypted(passphrase)
+	return k
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoDeployKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	pairs := []*encryption.RSAKey{
+		{PrivateKey: "private-1", Passphrase: "pass-1", PublicKey: "public-1"},
+		{PrivateKey: "private-2", Passphrase: "pass-2", PublicKey: "public-2"},
+	}
+	encryption.MockGenerateRSAKey = func() (*encryption.RSAKey, error) {
+		pair := pairs[0]
+		pairs = pairs[1:]
+		return pair, nil
+	}
+	t.Cleanup(func() { encryption.MockGenerateRSAKey = nil })
+
+	require.NoError(t, db.Repos().Create(ctx, &types.Repo{Name: "git.example.com/repo"}))
+	repo, err := db.Repos().GetByName(ctx, "git.example.com/repo")
+	require.NoError(t, err)
+
+	store := db.RepoDeployKeys(et.TestKey{})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := store.GetByRepoID(ctx, repo.ID)
+		assert.True(t, errcode.IsNotFound(err))
+		assert.True(t, errcode.IsNotFound(store.Delete(ctx, repo.ID)))
+	})
+
+	t.Run("generate", func(t *testing.T) {
+		key, err := store.Generate(ctx, repo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "public-1", key.PublicKey)
+
+		key, err = store.GetByRepoID(ctx, repo.ID)
+		require.NoError(t, err)
+		privateKey, passphrase, err := key.PrivateKey(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "private-1", privateKey)
+		assert.Equal(t, "pass-1", passphrase)
+
+		// The private key must not be stored in plain text.
+		var stored string
+		err = db.QueryRowContext(ctx, "SELECT private_key FROM repo_deploy_keys WHERE repo_id = $1", repo.ID).Scan(&stored)
+		require.NoError(t, err)
+		assert.NotEqual(t, "private-1", stored)
+	})
+
+	t.Run("rotate", func(t *testing.T) {
+		key, err := store.Generate(ctx, repo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "public-2", key.PublicKey)
+
+		privateKey, _, err := key.PrivateKey(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "private-2", privateKey)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, repo.ID))
+		_, err := store.GetByRepoID(ctx, repo.ID)
+		assert.True(t, errcode.IsNotFound(err))
+	})
+}
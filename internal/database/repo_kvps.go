@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -24,6 +25,7 @@ type RepoKVPStore interface {
 	Create(context.Context, api.RepoID, KeyValuePair) error
 	Update(context.Context, api.RepoID, KeyValuePair) (KeyValuePair, error)
 	Delete(context.Context, api.RepoID, string) error
+	SyncCodeHostTags(context.Context, api.RepoID, []string) error
 }
 type repoKVPStore struct {
 	*basestore.Store
@@ -31,6 +33,11 @@ type repoKVPStore struct {
 
 var _ RepoKVPStore = (*repoKVPStore)(nil)
 
+// RepoKVPsWith instantiates and returns a new RepoKVPStore using the other store handle.
+func RepoKVPsWith(other basestore.ShareableStore) RepoKVPStore {
+	return &repoKVPStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
 func (s *repoKVPStore) WithTransact(ctx context.Context, f func(RepoKVPStore) error) error {
 	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
 		return f(&repoKVPStore{Store: tx})
@@ -51,6 +58,15 @@ type KeyValuePair struct {
 	Value *string
 }
 
+// Sources of key-value pairs, stored in the source column of repo_kvps.
+const (
+	// repoKVPSourceManual is used for key-value pairs set by users.
+	repoKVPSourceManual = "manual"
+	// repoKVPSourceCodeHost is used for tags synced from the code host, for
+	// example from GitHub topics.
+	repoKVPSourceCodeHost = "code_host"
+)
+
 func (s *repoKVPStore) Create(ctx context.Context, repoID api.RepoID, kvp KeyValuePair) error {
 	q := `
 	INSERT INTO repo_kvps (repo_id, key, value)
@@ -145,15 +161,17 @@ func (s *repoKVPStore) ListValues(ctx context.Context, options RepoKVPListValues
 }
 
 func (s *repoKVPStore) Update(ctx context.Context, repoID api.RepoID, kvp KeyValuePair) (KeyValuePair, error) {
+	// Updating a key-value pair synced from the code host turns it into a
+	// manual one, so that the next sync doesn't override it.
 	q := `
 	UPDATE repo_kvps
-	SET value = %s
+	SET value = %s, source = %s
 	WHERE repo_id = %s
 		AND key = %s
 	RETURNING key, value
 	`
 
-	kvp, err := scanKVP(s.QueryRow(ctx, sqlf.Sprintf(q, kvp.Value, repoID, kvp.Key)))
+	kvp, err := scanKVP(s.QueryRow(ctx, sqlf.Sprintf(q, kvp.Value, repoKVPSourceManual, repoID, kvp.Key)))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -174,6 +192,46 @@ func (s *repoKVPStore) Delete(ctx context.Context, repoID api.RepoID, key string
 	return s.Exec(ctx, sqlf.Sprintf(q, repoID, key))
 }
 
+// SyncCodeHostTags sets the tags synced from the code host for the given
+// repository to the given list. Tags are key-value pairs without a value.
+//
+// Values set manually take precedence: a tag is not added if a key-value pair
+// with the same key was set manually, and manually set key-value pairs are
+// never removed. Tags previously synced from the code host that aren't in the
+// list anymore are removed.
+func (s *repoKVPStore) SyncCodeHostTags(ctx context.Context, repoID api.RepoID, tags []string) error {
+	if tags == nil {
+		// A nil array is NULL, which would never match in the query below.
+		tags = []string{}
+	}
+
+	return s.WithTransact(ctx, func(tx RepoKVPStore) error {
+		store := tx.(*repoKVPStore)
+
+		q := `
+		DELETE FROM repo_kvps
+		WHERE repo_id = %s
+			AND source = %s
+			AND NOT key = ANY(%s)
+		`
+		if err := store.Exec(ctx, sqlf.Sprintf(q, repoID, repoKVPSourceCodeHost, pq.Array(tags))); err != nil {
+			return errors.Wrap(err, "deleting stale tags")
+		}
+
+		if len(tags) == 0 {
+			return nil
+		}
+
+		q = `
+		INSERT INTO repo_kvps (repo_id, key, value, source)
+		SELECT %s, tag, NULL, %s
+		FROM unnest(%s::text[]) AS tag
+		ON CONFLICT (repo_id, key) DO NOTHING
+		`
+		return errors.Wrap(store.Exec(ctx, sqlf.Sprintf(q, repoID, repoKVPSourceCodeHost, pq.Array(tags))), "inserting tags")
+	})
+}
+
 func scanKVP(scanner dbutil.Scanner) (KeyValuePair, error) {
 	var kvp KeyValuePair
 	return kvp, scanner.Scan(&kvp.Key, &kvp.Value)
@@ -222,3 +222,45 @@ func TestRepoKVPs(t *testing.T) {
 		})
 	})
 }
+
+func TestRepoKVPs_SyncCodeHostTags(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+	kvps := db.RepoKVPs()
+
+	err := db.Repos().Create(ctx, &types.Repo{Name: "repo"})
+	require.NoError(t, err)
+	repo, err := db.Repos().GetByName(ctx, "repo")
+	require.NoError(t, err)
+
+	listKeys := func(t *testing.T) []string {
+		t.Helper()
+		keys, err := kvps.ListKeys(ctx, RepoKVPListKeysOptions{}, PaginationArgs{
+			OrderBy: OrderBy{{Field: string(RepoKVPListKeyColumn)}},
+		})
+		require.NoError(t, err)
+		sort.Strings(keys)
+		return keys
+	}
+
+	// A manually set key-value pair that conflicts with a topic.
+	err = kvps.Create(ctx, repo.ID, KeyValuePair{Key: "go", Value: pointers.Ptr("manual")})
+	require.NoError(t, err)
+
+	require.NoError(t, kvps.SyncCodeHostTags(ctx, repo.ID, []string{"go", "search", "code-intel"}))
+	require.Equal(t, []string{"code-intel", "go", "search"}, listKeys(t))
+
+	// The manually set value wins.
+	kvp, err := kvps.Get(ctx, repo.ID, "go")
+	require.NoError(t, err)
+	require.Equal(t, pointers.Ptr("manual"), kvp.Value)
+
+	// Updating a synced tag turns it into a manual key-value pair.
+	_, err = kvps.Update(ctx, repo.ID, KeyValuePair{Key: "search", Value: pointers.Ptr("yes")})
+	require.NoError(t, err)
+
+	// Topics removed on the code host are removed, except manual ones.
+	require.NoError(t, kvps.SyncCodeHostTags(ctx, repo.ID, nil))
+	require.Equal(t, []string{"go", "search"}, listKeys(t))
+}
@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RepoViewNotFoundError is returned when a repository view cannot be found.
+type RepoViewNotFoundError struct {
+	args any
+}
+
+func (err RepoViewNotFoundError) Error() string {
+	return fmt.Sprintf("repository view not found: %v", err.args)
+}
+
+func (RepoViewNotFoundError) NotFound() bool {
+	return true
+}
+
+// ErrRepoViewNameAlreadyExists is returned when the name of a repository view
+// is already in use.
+var ErrRepoViewNameAlreadyExists = errors.New("repository view name is already taken")
+
+// ListRepoViewsOptions are options for listing and counting repository views.
+type ListRepoViewsOptions struct {
+	// RepoID, if set, only returns the views of the given parent repository.
+	RepoID api.RepoID
+
+	*LimitOffset
+}
+
+func (opts ListRepoViewsOptions) sqlConds() *sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("repo_id = %s", opts.RepoID))
+	}
+	return sqlf.Join(conds, "AND")
+}
+
+// RepoViewStore stores the virtual repositories defined by path globs over a
+// parent repository.
+//
+// 🚨 SECURITY: The store does NOT check that the current user has access to the
+// parent repository of a view. Callers must resolve the parent repository
+// through the RepoStore before exposing a view.
+type RepoViewStore interface {
+	basestore.ShareableStore
+	WithTransact(context.Context, func(RepoViewStore) error) error
+	With(basestore.ShareableStore) RepoViewStore
+	Create(context.Context, *types.RepoView) (*types.RepoView, error)
+	Update(context.Context, *types.RepoView) (*types.RepoView, error)
+	Delete(ctx context.Context, id int32) error
+	GetByID(ctx context.Context, id int32) (*types.RepoView, error)
+	GetByName(ctx context.Context, name string) (*types.RepoView, error)
+	List(context.Context, ListRepoViewsOptions) ([]*types.RepoView, error)
+	Count(context.Context, ListRepoViewsOptions) (int, error)
+}
+
+type repoViewStore struct {
+	*basestore.Store
+}
+
+var _ RepoViewStore = (*repoViewStore)(nil)
+
+// RepoViewsWith instantiates and returns a new RepoViewStore using the other store handle.
+func RepoViewsWith(other basestore.ShareableStore) RepoViewStore {
+	return &repoViewStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *repoViewStore) With(other basestore.ShareableStore) RepoViewStore {
+	return &repoViewStore{Store: s.Store.With(other)}
+}
+
+func (s *repoViewStore) WithTransact(ctx context.Context, f func(RepoViewStore) error) error {
+	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		return f(&repoViewStore{Store: tx})
+	})
+}
+
+var repoViewColumns = []*sqlf.Query{
+	sqlf.Sprintf("repo_views.id"),
+	sqlf.Sprintf("repo_views.name"),
+	sqlf.Sprintf("repo_views.description"),
+	sqlf.Sprintf("repo_views.repo_id"),
+	sqlf.Sprintf("repo_views.path_globs"),
+	sqlf.Sprintf("repo_views.creator_id"),
+	sqlf.Sprintf("repo_views.created_at"),
+	sqlf.Sprintf("repo_views.updated_at"),
+}
+
+const createRepoViewQueryFmtstr = `
+INSERT INTO repo_views (name, description, repo_id, path_globs, creator_id, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *repoViewStore) Create(ctx context.Context, view *types.RepoView) (*types.RepoView, error) {
+	if view.CreatedAt.IsZero() {
+		view.CreatedAt = timeutil.Now()
+	}
+	if view.UpdatedAt.IsZero() {
+		view.UpdatedAt = view.CreatedAt
+	}
+
+	created, err := scanRepoView(s.QueryRow(ctx, sqlf.Sprintf(
+		createRepoViewQueryFmtstr,
+		view.Name,
+		view.Description,
+		view.RepoID,
+		pq.Array(view.PathGlobs),
+		view.CreatorID,
+		view.CreatedAt,
+		view.UpdatedAt,
+		sqlf.Join(repoViewColumns, ","),
+	)))
+	if err != nil {
+		return nil, wrapRepoViewError(err, view)
+	}
+	return created, nil
+}
+
+const updateRepoViewQueryFmtstr = `
+UPDATE repo_views
+SET
+	name = %s,
+	description = %s,
+	path_globs = %s,
+	updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// Update updates the name, the description, and the path globs of the given
+// view. The parent repository of a view cannot be changed.
+func (s *repoViewStore) Update(ctx context.Context, view *types.RepoView) (*types.RepoView, error) {
+	updated, err := scanRepoView(s.QueryRow(ctx, sqlf.Sprintf(
+		updateRepoViewQueryFmtstr,
+		view.Name,
+		view.Description,
+		pq.Array(view.PathGlobs),
+		timeutil.Now(),
+		view.ID,
+		sqlf.Join(repoViewColumns, ","),
+	)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, RepoViewNotFoundError{args: view.ID}
+		}
+		return nil, wrapRepoViewError(err, view)
+	}
+	return updated, nil
+}
+
+func (s *repoViewStore) Delete(ctx context.Context, id int32) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`DELETE FROM repo_views WHERE id = %s`, id))
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return RepoViewNotFoundError{args: id}
+	}
+	return nil
+}
+
+func (s *repoViewStore) GetByID(ctx context.Context, id int32) (*types.RepoView, error) {
+	return s.getBy(ctx, sqlf.Sprintf("repo_views.id = %s", id), id)
+}
+
+func (s *repoViewStore) GetByName(ctx context.Context, name string) (*types.RepoView, error) {
+	return s.getBy(ctx, sqlf.Sprintf("repo_views.name = %s", name), name)
+}
+
+const getRepoViewQueryFmtstr = `
+SELECT %s
+FROM repo_views
+WHERE %s
+`
+
+func (s *repoViewStore) getBy(ctx context.Context, cond *sqlf.Query, arg any) (*types.RepoView, error) {
+	view, err := scanRepoView(s.QueryRow(ctx, sqlf.Sprintf(getRepoViewQueryFmtstr, sqlf.Join(repoViewColumns, ","), cond)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, RepoViewNotFoundError{args: arg}
+		}
+		return nil, err
+	}
+	return view, nil
+}
+
+const listRepoViewsQueryFmtstr = `
+SELECT %s
+FROM repo_views
+WHERE %s
+ORDER BY repo_views.name ASC
+%s
+`
+
+func (s *repoViewStore) List(ctx context.Context, opts ListRepoViewsOptions) ([]*types.RepoView, error) {
+	return scanRepoViews(s.Query(ctx, sqlf.Sprintf(
+		listRepoViewsQueryFmtstr,
+		sqlf.Join(repoViewColumns, ","),
+		opts.sqlConds(),
+		opts.LimitOffset.SQL(),
+	)))
+}
+
+func (s *repoViewStore) Count(ctx context.Context, opts ListRepoViewsOptions) (int, error) {
+	return basestore.ScanInt(s.QueryRow(ctx, sqlf.Sprintf(`SELECT COUNT(*) FROM repo_views WHERE %s`, opts.sqlConds())))
+}
+
+func scanRepoView(sc dbutil.Scanner) (*types.RepoView, error) {
+	var view types.RepoView
+	if err := sc.Scan(
+		&view.ID,
+		&view.Name,
+		&view.Description,
+		&view.RepoID,
+		pq.Array(&view.PathGlobs),
+		&view.CreatorID,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+var scanRepoViews = basestore.NewSliceScanner(scanRepoView)
+
+func wrapRepoViewError(err error, view *types.RepoView) error {
+	var e *pgconn.PgError
+	if errors.As(err, &e) {
+		switch e.ConstraintName {
+		case "repo_views_name_unique":
+			return ErrRepoViewNameAlreadyExists
+		case "repo_views_path_globs_not_empty":
+			return errors.New("repository view must have at least one path glob")
+		case "repo_views_repo_id_fkey":
+			return &RepoNotFoundErr{ID: view.RepoID}
+		}
+	}
+	return err
+}
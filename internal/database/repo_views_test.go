@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoViews(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+	views := db.RepoViews()
+
+	require.NoError(t, db.Repos().Create(ctx, &types.Repo{Name: "monorepo"}, &types.Repo{Name: "other"}))
+	monorepo, err := db.Repos().GetByName(ctx, "monorepo")
+	require.NoError(t, err)
+	other, err := db.Repos().GetByName(ctx, "other")
+	require.NoError(t, err)
+
+	web, err := views.Create(ctx, &types.RepoView{Name: "web", RepoID: monorepo.ID, PathGlobs: []string{"client/web/**"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client/web/**"}, web.PathGlobs)
+	assert.Nil(t, web.CreatorID)
+
+	_, err = views.Create(ctx, &types.RepoView{Name: "backend", RepoID: monorepo.ID, PathGlobs: []string{"cmd/**", "internal/**"}})
+	require.NoError(t, err)
+	_, err = views.Create(ctx, &types.RepoView{Name: "docs", RepoID: other.ID, PathGlobs: []string{"doc/**"}})
+	require.NoError(t, err)
+
+	t.Run("Create", func(t *testing.T) {
+		_, err := views.Create(ctx, &types.RepoView{Name: "WEB", RepoID: other.ID, PathGlobs: []string{"web/**"}})
+		assert.ErrorIs(t, err, ErrRepoViewNameAlreadyExists)
+
+		_, err = views.Create(ctx, &types.RepoView{Name: "empty", RepoID: monorepo.ID, PathGlobs: []string{}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := views.GetByName(ctx, "web")
+		require.NoError(t, err)
+		assert.Equal(t, web, have)
+
+		have, err = views.GetByID(ctx, web.ID)
+		require.NoError(t, err)
+		assert.Equal(t, web, have)
+
+		_, err = views.GetByName(ctx, "missing")
+		assert.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		all, err := views.List(ctx, ListRepoViewsOptions{})
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, []string{"backend", "docs", "web"}, []string{all[0].Name, all[1].Name, all[2].Name})
+
+		forMonorepo, err := views.List(ctx, ListRepoViewsOptions{RepoID: monorepo.ID, LimitOffset: &LimitOffset{Limit: 1}})
+		require.NoError(t, err)
+		require.Len(t, forMonorepo, 1)
+		assert.Equal(t, "backend", forMonorepo[0].Name)
+
+		count, err := views.Count(ctx, ListRepoViewsOptions{RepoID: monorepo.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		web.Description = "The web app"
+		web.PathGlobs = []string{"client/web/**", "client/shared/**"}
+		updated, err := views.Update(ctx, web)
+		require.NoError(t, err)
+		assert.Equal(t, "The web app", updated.Description)
+		assert.Equal(t, []string{"client/web/**", "client/shared/**"}, updated.PathGlobs)
+
+		_, err = views.Update(ctx, &types.RepoView{ID: 1000, Name: "missing", PathGlobs: []string{"*"}})
+		assert.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, views.Delete(ctx, web.ID))
+		assert.True(t, errcode.IsNotFound(views.Delete(ctx, web.ID)))
+	})
+}
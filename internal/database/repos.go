@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/grafana/regexp"
 	regexpsyntax "github.com/grafana/regexp/syntax"
+	"github.com/jackc/pgconn"
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus"
@@ -72,6 +74,7 @@ type RepoStore interface {
 	Count(context.Context, ReposListOptions) (int, error)
 	Create(context.Context, ...*types.Repo) error
 	Delete(context.Context, ...api.RepoID) error
+	Restore(ctx context.Context, name api.RepoName, deletedAfter time.Time) (*types.Repo, error)
 	Get(context.Context, api.RepoID) (*types.Repo, error)
 	GetByIDs(context.Context, ...api.RepoID) ([]*types.Repo, error)
 	GetByName(context.Context, api.RepoName) (*types.Repo, error)
@@ -1613,6 +1616,15 @@ func (s *repoStore) Delete(ctx context.Context, ids ...api.RepoID) error {
 		return errors.Wrap(err, "delete")
 	}
 
+	auditEvents := NewDBWith(s.logger, s).AuditEvents()
+	for _, id := range ids {
+		auditEvents.Log(ctx, &AuditEvent{
+			Action:     AuditActionRepoDeleted,
+			EntityType: AuditEntityRepo,
+			EntityID:   strconv.Itoa(int(id)),
+		})
+	}
+
 	return nil
 }
 
@@ -1628,6 +1640,67 @@ FROM repo_ids
 WHERE repo.id = repo_ids.id::int
 `
 
+// ErrRepoNameTaken is returned by Restore when the name of the restored repo is
+// used by another repo.
+var ErrRepoNameTaken = errors.New("another repo with the same name exists")
+
+// Restore restores the most recently soft-deleted repo with the given name,
+// that is the name it had before it was deleted. Only repos deleted after
+// deletedAfter whose clone hasn't been purged from gitserver yet are restored,
+// the zero value restores repos regardless of when they were deleted.
+//
+// Restore returns an error satisfying errcode.IsNotFound if there is no such
+// repo, and ErrRepoNameTaken if another repo with the same name exists.
+func (s *repoStore) Restore(ctx context.Context, name api.RepoName, deletedAfter time.Time) (_ *types.Repo, err error) {
+	tr, ctx := trace.New(ctx, "repos.Restore", name.Attr())
+	defer tr.EndWithErr(&err)
+
+	id, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(restoreRepoQuery, "DELETED-%-"+likeEscaper.Replace(string(name)), string(name), deletedAfter, types.CloneStatusCloned, string(name))))
+	if err != nil {
+		var e *pgconn.PgError
+		if errors.As(err, &e) && e.ConstraintName == "repo_name_unique" {
+			return nil, ErrRepoNameTaken
+		}
+		return nil, errors.Wrap(err, "restore")
+	}
+	if !ok {
+		return nil, &RepoNotFoundErr{Name: name}
+	}
+
+	return s.Get(ctx, api.RepoID(id))
+}
+
+// likeEscaper escapes the characters that are special in LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// The prefix of soft-deleted repo names matches the one added by the
+// soft_deleted_repository_name function and stripped by api.UndeletedRepoName.
+// The LIKE condition narrows the candidates down using the trigram index on
+// name, the substring condition checks the exact prefix. The clone status
+// condition skips repos whose clone was already purged from gitserver.
+const restoreRepoQuery = `
+WITH candidate AS (
+	SELECT repo.id
+	FROM repo
+	JOIN gitserver_repos gr ON gr.repo_id = repo.id
+	WHERE
+		repo.name LIKE %s ESCAPE '\'
+		AND substring(repo.name from '^DELETED-[0-9]+\.[0-9]+-(.*)$') = %s
+		AND repo.deleted_at IS NOT NULL
+		AND repo.deleted_at > %s
+		AND gr.clone_status = %s
+	ORDER BY repo.deleted_at DESC
+	LIMIT 1
+)
+UPDATE repo
+SET
+	name = %s,
+	deleted_at = NULL
+FROM candidate
+WHERE repo.id = candidate.id
+RETURNING repo.id
+`
+
 const getFirstRepoNamesByCloneURLQueryFmtstr = `
 SELECT
 	name
@@ -2548,6 +2548,86 @@ func TestRepos_DeleteReconcilesName(t *testing.T) {
 	}
 }
 
+func TestRepos_Restore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+	ctx = actor.WithActor(ctx, &actor.Actor{UID: 1, Internal: true})
+
+	repo := mustCreate(ctx, t, db, &types.Repo{Name: "github.com/foo/bar"})
+	if err := db.GitserverRepos().SetCloneStatus(ctx, repo.Name, types.CloneStatusCloned, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Repos().Delete(ctx, repo.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("outside of the retention window", func(t *testing.T) {
+		_, err := db.Repos().Restore(ctx, repo.Name, time.Now().Add(time.Hour))
+		if !errcode.IsNotFound(err) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("unknown repo", func(t *testing.T) {
+		_, err := db.Repos().Restore(ctx, "github.com/foo/baz", time.Time{})
+		if !errcode.IsNotFound(err) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("purged clone", func(t *testing.T) {
+		purged := mustCreate(ctx, t, db, &types.Repo{Name: "github.com/foo/purged"})
+		if err := db.Repos().Delete(ctx, purged.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := db.Repos().Restore(ctx, purged.Name, time.Time{})
+		if !errcode.IsNotFound(err) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		other := mustCreate(ctx, t, db, &types.Repo{Name: repo.Name})
+		if err := db.GitserverRepos().SetCloneStatus(ctx, other.Name, types.CloneStatusCloned, "test"); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if err := db.Repos().Delete(ctx, other.ID); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		_, err := db.Repos().Restore(ctx, repo.Name, time.Time{})
+		if !errors.Is(err, ErrRepoNameTaken) {
+			t.Fatalf("expected ErrRepoNameTaken, got %v", err)
+		}
+	})
+
+	t.Run("restores the most recently deleted repo", func(t *testing.T) {
+		restored, err := db.Repos().Restore(ctx, repo.Name, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if restored.Name != repo.Name {
+			t.Errorf("unexpected name: have %q, want %q", restored.Name, repo.Name)
+		}
+		if !restored.DeletedAt.IsZero() {
+			t.Errorf("unexpected deleted_at: %s", restored.DeletedAt)
+		}
+		// The repo deleted in the cleanup of the previous test was deleted
+		// more recently.
+		if restored.ID == repo.ID {
+			t.Errorf("expected the most recently deleted repo to be restored, got %d", restored.ID)
+		}
+	})
+}
+
 func TestRepos_MultipleDeletesKeepTheSameTombstoneData(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
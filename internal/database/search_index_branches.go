@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SearchIndexBranchesRepoMetadataKey is the repo metadata key holding a
+// comma-separated list of additional branches Zoekt should index for a single
+// repository.
+const SearchIndexBranchesRepoMetadataKey = "search.index.branches"
+
+// SearchIndexBranchRule configures additional branches Zoekt should index for
+// all repositories whose name matches RepoPattern.
+type SearchIndexBranchRule struct {
+	ID int32
+	// RepoPattern is a regular expression matched against repository names.
+	RepoPattern string
+	Branches    []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SearchIndexBranchRuleNotFoundErr is returned when a rule cannot be found.
+type SearchIndexBranchRuleNotFoundErr struct {
+	id int32
+}
+
+func (err SearchIndexBranchRuleNotFoundErr) Error() string {
+	return fmt.Sprintf("search index branch rule not found: id=%d", err.id)
+}
+
+func (SearchIndexBranchRuleNotFoundErr) NotFound() bool {
+	return true
+}
+
+// SearchIndexBranchStore provides access to the branch indexing configuration
+// of Zoekt, which is stored in the `search_index_branch_rules` table for
+// pattern-based rules and in repo metadata for single repositories.
+type SearchIndexBranchStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) SearchIndexBranchStore
+
+	// ListRules returns all pattern-based rules ordered by ID.
+	ListRules(ctx context.Context) ([]*SearchIndexBranchRule, error)
+	// GetRule returns the rule with the given ID.
+	GetRule(ctx context.Context, id int32) (*SearchIndexBranchRule, error)
+	// CreateRule creates a new rule. The ID and timestamps of rule are set
+	// on success.
+	CreateRule(ctx context.Context, rule *SearchIndexBranchRule) error
+	// UpdateRule updates the pattern and branches of the rule with the ID of
+	// rule. The timestamps of rule are set on success.
+	UpdateRule(ctx context.Context, rule *SearchIndexBranchRule) error
+	// DeleteRule deletes the rule with the given ID.
+	DeleteRule(ctx context.Context, id int32) error
+
+	// GetRepoBranches returns the branches configured in the repo metadata of
+	// the given repositories. Repositories without configuration are omitted.
+	GetRepoBranches(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID][]string, error)
+	// SetRepoBranches replaces the branches configured in the repo metadata of
+	// the given repository. An empty list removes the configuration.
+	SetRepoBranches(ctx context.Context, repoID api.RepoID, branches []string) error
+}
+
+var _ SearchIndexBranchStore = (*searchIndexBranchStore)(nil)
+
+type searchIndexBranchStore struct {
+	*basestore.Store
+}
+
+// SearchIndexBranchesWith instantiates and returns a new SearchIndexBranchStore
+// using the other store handle.
+func SearchIndexBranchesWith(other basestore.ShareableStore) SearchIndexBranchStore {
+	return &searchIndexBranchStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *searchIndexBranchStore) With(other basestore.ShareableStore) SearchIndexBranchStore {
+	return &searchIndexBranchStore{Store: s.Store.With(other)}
+}
+
+const searchIndexBranchRuleColumns = `id, repo_pattern, branches, created_at, updated_at`
+
+func (s *searchIndexBranchStore) ListRules(ctx context.Context) ([]*SearchIndexBranchRule, error) {
+	q := sqlf.Sprintf(`SELECT ` + searchIndexBranchRuleColumns + ` FROM search_index_branch_rules ORDER BY id`)
+	return scanSearchIndexBranchRules(s.Query(ctx, q))
+}
+
+func (s *searchIndexBranchStore) GetRule(ctx context.Context, id int32) (*SearchIndexBranchRule, error) {
+	q := sqlf.Sprintf(`SELECT `+searchIndexBranchRuleColumns+` FROM search_index_branch_rules WHERE id = %s`, id)
+	rule, err := scanSearchIndexBranchRule(s.QueryRow(ctx, q))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, SearchIndexBranchRuleNotFoundErr{id: id}
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *searchIndexBranchStore) CreateRule(ctx context.Context, rule *SearchIndexBranchRule) error {
+	q := sqlf.Sprintf(`
+	INSERT INTO search_index_branch_rules (repo_pattern, branches)
+	VALUES (%s, %s)
+	RETURNING `+searchIndexBranchRuleColumns,
+		rule.RepoPattern,
+		pq.Array(rule.Branches),
+	)
+
+	created, err := scanSearchIndexBranchRule(s.QueryRow(ctx, q))
+	if err != nil {
+		return err
+	}
+	*rule = *created
+	return nil
+}
+
+func (s *searchIndexBranchStore) UpdateRule(ctx context.Context, rule *SearchIndexBranchRule) error {
+	q := sqlf.Sprintf(`
+	UPDATE search_index_branch_rules
+	SET repo_pattern = %s, branches = %s, updated_at = NOW()
+	WHERE id = %s
+	RETURNING `+searchIndexBranchRuleColumns,
+		rule.RepoPattern,
+		pq.Array(rule.Branches),
+		rule.ID,
+	)
+
+	updated, err := scanSearchIndexBranchRule(s.QueryRow(ctx, q))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return SearchIndexBranchRuleNotFoundErr{id: rule.ID}
+		}
+		return err
+	}
+	*rule = *updated
+	return nil
+}
+
+func (s *searchIndexBranchStore) DeleteRule(ctx context.Context, id int32) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`DELETE FROM search_index_branch_rules WHERE id = %s`, id))
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return SearchIndexBranchRuleNotFoundErr{id: id}
+	}
+	return nil
+}
+
+func (s *searchIndexBranchStore) GetRepoBranches(ctx context.Context, repoIDs []api.RepoID) (_ map[api.RepoID][]string, err error) {
+	q := sqlf.Sprintf(`
+	SELECT repo_id, value
+	FROM repo_kvps
+	WHERE repo_id = ANY(%s)
+		AND key = %s
+		AND value IS NOT NULL
+	`, pq.Array(repoIDs), SearchIndexBranchesRepoMetadataKey)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	branches := make(map[api.RepoID][]string)
+	for rows.Next() {
+		var (
+			repoID api.RepoID
+			value  string
+		)
+		if err := rows.Scan(&repoID, &value); err != nil {
+			return nil, err
+		}
+		if parsed := ParseSearchIndexBranches(value); len(parsed) > 0 {
+			branches[repoID] = parsed
+		}
+	}
+
+	return branches, rows.Err()
+}
+
+func (s *searchIndexBranchStore) SetRepoBranches(ctx context.Context, repoID api.RepoID, branches []string) error {
+	if len(branches) == 0 {
+		q := sqlf.Sprintf(`DELETE FROM repo_kvps WHERE repo_id = %s AND key = %s`, repoID, SearchIndexBranchesRepoMetadataKey)
+		return s.Exec(ctx, q)
+	}
+
+	q := sqlf.Sprintf(`
+	INSERT INTO repo_kvps (repo_id, key, value)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (repo_id, key) DO UPDATE SET value = EXCLUDED.value
+	`, repoID, SearchIndexBranchesRepoMetadataKey, strings.Join(branches, ","))
+	return s.Exec(ctx, q)
+}
+
+// ParseSearchIndexBranches parses the value of the search.index.branches repo
+// metadata key. Blank entries are ignored.
+func ParseSearchIndexBranches(value string) []string {
+	var branches []string
+	for _, branch := range strings.Split(value, ",") {
+		if branch = strings.TrimSpace(branch); branch != "" {
+			branches = append(branches, branch)
+		}
+	}
+	return branches
+}
+
+func scanSearchIndexBranchRule(sc dbutil.Scanner) (*SearchIndexBranchRule, error) {
+	var rule SearchIndexBranchRule
+	err := sc.Scan(
+		&rule.ID,
+		&rule.RepoPattern,
+		pq.Array(&rule.Branches),
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	return &rule, err
+}
+
+var scanSearchIndexBranchRules = basestore.NewSliceScanner(scanSearchIndexBranchRule)
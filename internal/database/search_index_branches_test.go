@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestSearchIndexBranchRules(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+	store := db.SearchIndexBranches()
+
+	rule := &SearchIndexBranchRule{RepoPattern: "^github\\.com/sourcegraph/", Branches: []string{"release/5.2"}}
+	require.NoError(t, store.CreateRule(ctx, rule))
+	require.NotZero(t, rule.ID)
+
+	rule.Branches = []string{"release/5.2", "release/5.3"}
+	require.NoError(t, store.UpdateRule(ctx, rule))
+
+	have, err := store.GetRule(ctx, rule.ID)
+	require.NoError(t, err)
+	require.Equal(t, rule, have)
+
+	rules, err := store.ListRules(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*SearchIndexBranchRule{rule}, rules)
+
+	require.NoError(t, store.DeleteRule(ctx, rule.ID))
+
+	var notFound SearchIndexBranchRuleNotFoundErr
+	_, err = store.GetRule(ctx, rule.ID)
+	require.True(t, errors.As(err, &notFound))
+	require.True(t, errors.As(store.DeleteRule(ctx, rule.ID), &notFound))
+}
+
+func TestSearchIndexRepoBranches(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+	store := db.SearchIndexBranches()
+
+	require.NoError(t, db.Repos().Create(ctx, &types.Repo{Name: "repo1"}, &types.Repo{Name: "repo2"}))
+	repo1, err := db.Repos().GetByName(ctx, "repo1")
+	require.NoError(t, err)
+	repo2, err := db.Repos().GetByName(ctx, "repo2")
+	require.NoError(t, err)
+
+	require.NoError(t, store.SetRepoBranches(ctx, repo1.ID, []string{"release-1", "release-2"}))
+	// Values written through the generic repo metadata API are honored too.
+	require.NoError(t, db.RepoKVPs().Create(ctx, repo2.ID, KeyValuePair{Key: SearchIndexBranchesRepoMetadataKey, Value: pointers.Ptr(" dev , ,main")}))
+
+	branches, err := store.GetRepoBranches(ctx, []api.RepoID{repo1.ID, repo2.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoID][]string{
+		repo1.ID: {"release-1", "release-2"},
+		repo2.ID: {"dev", "main"},
+	}, branches)
+
+	// Changes to the configuration mark the repository as updated, which is
+	// what the search indexer polls for.
+	before, err := db.Repos().Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	require.NoError(t, store.SetRepoBranches(ctx, repo1.ID, nil))
+	after, err := db.Repos().Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	require.True(t, after.UpdatedAt.After(before.UpdatedAt))
+
+	branches, err = store.GetRepoBranches(ctx, []api.RepoID{repo1.ID, repo2.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoID][]string{repo2.ID: {"dev", "main"}}, branches)
+}
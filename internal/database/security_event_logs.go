@@ -26,9 +26,10 @@ const (
 	SecurityEventNameSignOutFailed    SecurityEventName = "SignOutFailed"
 	SecurityEventNameSignOutSucceeded SecurityEventName = "SignOutSucceeded"
 
-	SecurityEventNameSignInAttempted SecurityEventName = "SignInAttempted"
-	SecurityEventNameSignInFailed    SecurityEventName = "SignInFailed"
-	SecurityEventNameSignInSucceeded SecurityEventName = "SignInSucceeded"
+	SecurityEventNameSignInAttempted           SecurityEventName = "SignInAttempted"
+	SecurityEventNameSignInFailed              SecurityEventName = "SignInFailed"
+	SecurityEventNameSignInSucceeded           SecurityEventName = "SignInSucceeded"
+	SecurityEventNameSignInTwoFactorChallenged SecurityEventName = "SignInTwoFactorChallenged"
 
 	SecurityEventNameAccountCreated  SecurityEventName = "AccountCreated"
 	SecurityEventNameAccountDeleted  SecurityEventName = "AccountDeleted"
@@ -39,6 +40,10 @@ const (
 	SecurityEventNamPasswordRandomized     SecurityEventName = "PasswordRandomized"
 	SecurityEventNamePasswordChanged       SecurityEventName = "PasswordChanged"
 
+	SecurityEventNameTwoFactorEnabled          SecurityEventName = "TwoFactorEnabled"
+	SecurityEventNameTwoFactorDisabled         SecurityEventName = "TwoFactorDisabled"
+	SecurityEventNameTwoFactorRecoveryCodeUsed SecurityEventName = "TwoFactorRecoveryCodeUsed"
+
 	SecurityEventNameEmailVerified       SecurityEventName = "EmailVerified"
 	SecurityEventNameEmailVerifiedToggle SecurityEventName = "EmailVerificationChanged"
 	SecurityEventNameEmailAdded          SecurityEventName = "EmailAdded"
@@ -0,0 +1,304 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// UserTOTPCredential is the TOTP two-factor authentication credential of a
+// user. A credential is pending until the user confirms the enrollment with a
+// valid code, only enabled credentials are checked on sign-in.
+type UserTOTPCredential struct {
+	UserID int32
+	// LastUsedStep is the time step of the last accepted code.
+	LastUsedStep int64
+	// RecoveryCodesRemaining is the number of unused recovery codes.
+	RecoveryCodesRemaining int
+	EnabledAt              *time.Time
+	CreatedAt              time.Time
+
+	secret *encryption.Encryptable
+}
+
+// Enabled returns whether the enrollment of the credential was confirmed.
+func (c *UserTOTPCredential) Enabled() bool {
+	return c.EnabledAt != nil
+}
+
+// Secret decrypts and returns the shared secret of the credential.
+func (c *UserTOTPCredential) Secret(ctx context.Context) (string, error) {
+	secret, err := c.secret.Decrypt(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "decrypting secret")
+	}
+	return secret, nil
+}
+
+// UserTOTPCredentialNotFoundErr is returned when a user has no TOTP credential.
+type UserTOTPCredentialNotFoundErr struct {
+	userID int32
+}
+
+func (err UserTOTPCredentialNotFoundErr) Error() string {
+	return fmt.Sprintf("TOTP credential not found: user_id=%d", err.userID)
+}
+
+func (UserTOTPCredentialNotFoundErr) NotFound() bool {
+	return true
+}
+
+// ErrUserTOTPAlreadyEnabled is returned when a pending enrollment is started
+// or confirmed for a user whose TOTP credential is already enabled.
+var ErrUserTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+
+// UserTOTPCredentialStore provides access to the `user_totp_credentials` table.
+type UserTOTPCredentialStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) UserTOTPCredentialStore
+
+	// GetByUserID returns the TOTP credential of the given user. If the user
+	// has no credential, a UserTOTPCredentialNotFoundErr is returned.
+	GetByUserID(ctx context.Context, userID int32) (*UserTOTPCredential, error)
+	// CreatePending stores the given secret as the pending credential of the
+	// given user, replacing a previous pending credential. If the user already
+	// has an enabled credential, ErrUserTOTPAlreadyEnabled is returned.
+	CreatePending(ctx context.Context, userID int32, secret string) (*UserTOTPCredential, error)
+	// Enable confirms the pending credential of the given user. The given
+	// step is recorded as the last used step and the given recovery code hashes
+	// replace any existing ones. If the credential is already enabled,
+	// ErrUserTOTPAlreadyEnabled is returned.
+	Enable(ctx context.Context, userID int32, step int64, recoveryCodeHashes []string) error
+	// RecordStep records the given step as the last used step of the enabled
+	// credential of the given user. It returns false if a code of this or a
+	// later step has already been used, which means the code must be rejected.
+	RecordStep(ctx context.Context, userID int32, step int64) (bool, error)
+	// UseRecoveryCode removes the recovery code with the given hash from the
+	// enabled credential of the given user. It returns false if no such unused
+	// recovery code exists.
+	UseRecoveryCode(ctx context.Context, userID int32, recoveryCodeHash string) (bool, error)
+	// SetRecoveryCodes replaces the recovery codes of the enabled credential
+	// of the given user.
+	SetRecoveryCodes(ctx context.Context, userID int32, recoveryCodeHashes []string) error
+	// Delete removes the TOTP credential of the given user. If the user has no
+	// credential, a UserTOTPCredentialNotFoundErr is returned.
+	Delete(ctx context.Context, userID int32) error
+}
+
+type userTOTPCredentialStore struct {
+	*basestore.Store
+
+	key encryption.Key
+}
+
+var _ UserTOTPCredentialStore = (*userTOTPCredentialStore)(nil)
+
+// UserTOTPCredentialsWith instantiates and returns a new UserTOTPCredentialStore
+// using the other store handle.
+func UserTOTPCredentialsWith(other basestore.ShareableStore, key encryption.Key) UserTOTPCredentialStore {
+	return &userTOTPCredentialStore{
+		Store: basestore.NewWithHandle(other.Handle()),
+		key:   key,
+	}
+}
+
+func (s *userTOTPCredentialStore) With(other basestore.ShareableStore) UserTOTPCredentialStore {
+	return &userTOTPCredentialStore{Store: s.Store.With(other), key: s.key}
+}
+
+func (s *userTOTPCredentialStore) GetByUserID(ctx context.Context, userID int32) (*UserTOTPCredential, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM user_totp_credentials WHERE user_id = %s",
+		sqlf.Join(userTOTPCredentialColumns, ", "),
+		userID,
+	)
+	cred, err := scanUserTOTPCredential(s.QueryRow(ctx, q), s.key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, UserTOTPCredentialNotFoundErr{userID: userID}
+		}
+		return nil, err
+	}
+	return cred, nil
+}
+
+func (s *userTOTPCredentialStore) CreatePending(ctx context.Context, userID int32, secret string) (*UserTOTPCredential, error) {
+	encryptedSecret, keyID, err := encryption.MaybeEncrypt(ctx, s.key, secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting secret")
+	}
+
+	q := sqlf.Sprintf(
+		userTOTPCredentialCreatePendingQueryFmtstr,
+		userID,
+		encryptedSecret,
+		keyID,
+		sqlf.Join(userTOTPCredentialColumns, ", "),
+	)
+	cred, err := scanUserTOTPCredential(s.QueryRow(ctx, q), s.key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// The conflicting row is enabled and was not updated.
+			return nil, ErrUserTOTPAlreadyEnabled
+		}
+		return nil, err
+	}
+	return cred, nil
+}
+
+const userTOTPCredentialCreatePendingQueryFmtstr = `
+INSERT INTO user_totp_credentials (user_id, secret, encryption_key_id)
+VALUES (%s, %s, %s)
+ON CONFLICT (user_id) DO UPDATE SET
+	secret = EXCLUDED.secret,
+	encryption_key_id = EXCLUDED.encryption_key_id,
+	recovery_codes = '{}',
+	last_used_step = 0,
+	created_at = NOW()
+WHERE user_totp_credentials.enabled_at IS NULL
+RETURNING %s
+`
+
+func (s *userTOTPCredentialStore) Enable(ctx context.Context, userID int32, step int64, recoveryCodeHashes []string) error {
+	q := sqlf.Sprintf(
+		userTOTPCredentialEnableQueryFmtstr,
+		step,
+		pq.Array(recoveryCodeHashes),
+		userID,
+	)
+	res, err := s.ExecResult(ctx, q)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	// Distinguish a missing credential from an already enabled one.
+	if _, err := s.GetByUserID(ctx, userID); err != nil {
+		return err
+	}
+	return ErrUserTOTPAlreadyEnabled
+}
+
+const userTOTPCredentialEnableQueryFmtstr = `
+UPDATE user_totp_credentials
+SET enabled_at = NOW(), last_used_step = %s, recovery_codes = %s
+WHERE user_id = %s AND enabled_at IS NULL
+`
+
+func (s *userTOTPCredentialStore) RecordStep(ctx context.Context, userID int32, step int64) (bool, error) {
+	q := sqlf.Sprintf(`
+UPDATE user_totp_credentials
+SET last_used_step = %s
+WHERE user_id = %s AND enabled_at IS NOT NULL AND last_used_step < %s
+`,
+		step,
+		userID,
+		step,
+	)
+	return s.execAffectsRow(ctx, q)
+}
+
+func (s *userTOTPCredentialStore) UseRecoveryCode(ctx context.Context, userID int32, recoveryCodeHash string) (bool, error) {
+	q := sqlf.Sprintf(`
+UPDATE user_totp_credentials
+SET recovery_codes = array_remove(recovery_codes, %s)
+WHERE user_id = %s AND enabled_at IS NOT NULL AND %s = ANY(recovery_codes)
+`,
+		recoveryCodeHash,
+		userID,
+		recoveryCodeHash,
+	)
+	return s.execAffectsRow(ctx, q)
+}
+
+func (s *userTOTPCredentialStore) SetRecoveryCodes(ctx context.Context, userID int32, recoveryCodeHashes []string) error {
+	q := sqlf.Sprintf(
+		"UPDATE user_totp_credentials SET recovery_codes = %s WHERE user_id = %s AND enabled_at IS NOT NULL",
+		pq.Array(recoveryCodeHashes),
+		userID,
+	)
+	ok, err := s.execAffectsRow(ctx, q)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return UserTOTPCredentialNotFoundErr{userID: userID}
+	}
+	return nil
+}
+
+func (s *userTOTPCredentialStore) Delete(ctx context.Context, userID int32) error {
+	ok, err := s.execAffectsRow(ctx, sqlf.Sprintf("DELETE FROM user_totp_credentials WHERE user_id = %s", userID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return UserTOTPCredentialNotFoundErr{userID: userID}
+	}
+	return nil
+}
+
+func (s *userTOTPCredentialStore) execAffectsRow(ctx context.Context, q *sqlf.Query) (bool, error) {
+	res, err := s.ExecResult(ctx, q)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+var userTOTPCredentialColumns = []*sqlf.Query{
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("secret"),
+	sqlf.Sprintf("encryption_key_id"),
+	sqlf.Sprintf("last_used_step"),
+	sqlf.Sprintf("cardinality(recovery_codes)"),
+	sqlf.Sprintf("enabled_at"),
+	sqlf.Sprintf("created_at"),
+}
+
+func scanUserTOTPCredential(sc dbutil.Scanner, key encryption.Key) (*UserTOTPCredential, error) {
+	var (
+		c      UserTOTPCredential
+		secret string
+		keyID  string
+	)
+	if err := sc.Scan(
+		&c.UserID,
+		&secret,
+		&keyID,
+		&c.LastUsedStep,
+		&c.RecoveryCodesRemaining,
+		&c.EnabledAt,
+		&c.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	c.secret = encryption.NewEncrypted(secret, keyID, key)
+	return &c, nil
+}
+
+// NewMockUserTOTPCredential can be used in tests to create a TOTP credential
+// with the given secret. DO NOT USE THIS OUTSIDE OF TESTS.
+func NewMockUserTOTPCredential(c *UserTOTPCredential, secret string) *UserTOTPCredential {
+	c.secret = encryption.NewUnencrypted(secret)
+	return c
+}
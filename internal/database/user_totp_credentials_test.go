@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestUserTOTPCredentials(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+
+	store := db.UserTOTPCredentials(et.TestKey{})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := store.GetByUserID(ctx, user.ID)
+		assert.True(t, errcode.IsNotFound(err))
+		assert.True(t, errcode.IsNotFound(store.Delete(ctx, user.ID)))
+		assert.True(t, errcode.IsNotFound(store.Enable(ctx, user.ID, 1, nil)))
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		_, err := store.CreatePending(ctx, user.ID, "secret-1")
+		require.NoError(t, err)
+		// A pending enrollment can be restarted.
+		cred, err := store.CreatePending(ctx, user.ID, "secret-2")
+		require.NoError(t, err)
+		assert.False(t, cred.Enabled())
+
+		secret, err := cred.Secret(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "secret-2", secret)
+
+		// The secret must not be stored in plain text.
+		var stored string
+		err = db.QueryRowContext(ctx, "SELECT secret FROM user_totp_credentials WHERE user_id = $1", user.ID).Scan(&stored)
+		require.NoError(t, err)
+		assert.NotEqual(t, "secret-2", stored)
+
+		// Codes cannot be used before the enrollment is confirmed.
+		ok, err := store.RecordStep(ctx, user.ID, 10)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("enable", func(t *testing.T) {
+		require.NoError(t, store.Enable(ctx, user.ID, 10, []string{"a", "b"}))
+
+		cred, err := store.GetByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, cred.Enabled())
+		assert.Equal(t, int64(10), cred.LastUsedStep)
+		assert.Equal(t, 2, cred.RecoveryCodesRemaining)
+
+		assert.True(t, errors.Is(store.Enable(ctx, user.ID, 11, nil), ErrUserTOTPAlreadyEnabled))
+		_, err = store.CreatePending(ctx, user.ID, "secret-3")
+		assert.True(t, errors.Is(err, ErrUserTOTPAlreadyEnabled))
+	})
+
+	t.Run("record step", func(t *testing.T) {
+		ok, err := store.RecordStep(ctx, user.ID, 10)
+		require.NoError(t, err)
+		assert.False(t, ok, "replayed step must be rejected")
+
+		ok, err = store.RecordStep(ctx, user.ID, 11)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("recovery codes", func(t *testing.T) {
+		ok, err := store.UseRecoveryCode(ctx, user.ID, "a")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = store.UseRecoveryCode(ctx, user.ID, "a")
+		require.NoError(t, err)
+		assert.False(t, ok, "recovery codes are single use")
+
+		require.NoError(t, store.SetRecoveryCodes(ctx, user.ID, []string{"c", "d", "e"}))
+		cred, err := store.GetByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, cred.RecoveryCodesRemaining)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, user.ID))
+		_, err := store.GetByUserID(ctx, user.ID)
+		assert.True(t, errcode.IsNotFound(err))
+	})
+}
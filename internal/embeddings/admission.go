@@ -0,0 +1,174 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/background/repo"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/paths"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var DefaultExcludedFilePathPatterns = []string{
+	".*ignore", // Files like .gitignore, .eslintignore
+	".gitattributes",
+	".mailmap",
+	"*.csv",
+	"*.svg",
+	"*.xml",
+	"__fixtures__/",
+	"node_modules/",
+	"testdata/",
+	"mocks/",
+	"vendor/",
+}
+
+// FileFilterPatternsForRepo returns the glob patterns of the files to include
+// in and exclude from the embeddings of the given repository. Included
+// patterns of a matching per-repository filter replace the global ones, while
+// its excluded patterns are added to the global ones.
+func FileFilterPatternsForRepo(config *conftypes.EmbeddingsConfig, repoName api.RepoName) (included, excluded []string) {
+	if config == nil {
+		return nil, DefaultExcludedFilePathPatterns
+	}
+
+	included = config.FileFilters.IncludedFilePathPatterns
+	excluded = config.FileFilters.ExcludedFilePathPatterns
+	if len(excluded) == 0 {
+		excluded = DefaultExcludedFilePathPatterns
+	}
+
+	for _, filter := range config.Admission.RepoFileFilters {
+		re, err := regexp.Compile(filter.RepoNamePattern)
+		if err != nil || !re.MatchString(string(repoName)) {
+			continue
+		}
+		if len(filter.IncludedFilePathPatterns) > 0 {
+			included = filter.IncludedFilePathPatterns
+		}
+		// Copy to not modify the slice from the config.
+		excluded = append(excluded[:len(excluded):len(excluded)], filter.ExcludedFilePathPatterns...)
+	}
+	return included, excluded
+}
+
+// AdmissionError is returned when a repository doesn't satisfy the embeddings
+// admission policy.
+type AdmissionError struct {
+	RepoName api.RepoName
+	Reason   string
+}
+
+func (e *AdmissionError) Error() string {
+	return fmt.Sprintf("repository %s cannot be embedded: %s (a site admin can exempt it from the embeddings admission policy)", e.RepoName, e.Reason)
+}
+
+// CheckAdmission returns an *AdmissionError if the repository exceeds the
+// limits of the embeddings admission policy and hasn't been exempted by a site
+// admin. The file count is only checked when a revision is given.
+func CheckAdmission(
+	ctx context.Context,
+	db database.DB,
+	repoEmbeddingJobsStore repo.RepoEmbeddingJobsStore,
+	gitserverClient gitserver.Client,
+	repoID api.RepoID,
+	repoName api.RepoName,
+	revision api.CommitID,
+	config *conftypes.EmbeddingsConfig,
+) error {
+	if config == nil || (config.Admission.MaxRepoSizeBytes <= 0 && config.Admission.MaxFileCount <= 0) {
+		return nil
+	}
+
+	exempt, err := repoEmbeddingJobsStore.HasAdmissionOverride(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if exempt {
+		return nil
+	}
+
+	if maxSize := config.Admission.MaxRepoSizeBytes; maxSize > 0 {
+		gr, err := db.GitserverRepos().GetByID(ctx, repoID)
+		if err != nil {
+			return errors.Wrap(err, "getting repository size")
+		}
+		if gr.RepoSizeBytes > maxSize {
+			return &AdmissionError{
+				RepoName: repoName,
+				Reason:   fmt.Sprintf("repository size of %d bytes exceeds the limit of %d bytes", gr.RepoSizeBytes, maxSize),
+			}
+		}
+	}
+
+	if maxCount := config.Admission.MaxFileCount; maxCount > 0 && revision != "" {
+		count, err := countEmbeddableFiles(ctx, gitserverClient, repoName, revision, config)
+		if err != nil {
+			return errors.Wrap(err, "counting files")
+		}
+		if count > maxCount {
+			return &AdmissionError{
+				RepoName: repoName,
+				Reason:   fmt.Sprintf("%d files would be embedded, which exceeds the limit of %d files", count, maxCount),
+			}
+		}
+	}
+
+	return nil
+}
+
+// countEmbeddableFiles returns the number of files of the repository at the
+// given revision that pass the file filters.
+func countEmbeddableFiles(ctx context.Context, gitserverClient gitserver.Client, repoName api.RepoName, revision api.CommitID, config *conftypes.EmbeddingsConfig) (int, error) {
+	included, excluded := FileFilterPatternsForRepo(config, repoName)
+	includedGlobs, excludedGlobs := compileGlobPatterns(included), compileGlobPatterns(excluded)
+
+	fileInfos, err := gitserverClient.ReadDir(ctx, repoName, revision, "", true)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			continue
+		}
+		if config.FileFilters.MaxFileSizeBytes > 0 && fileInfo.Size() > int64(config.FileFilters.MaxFileSizeBytes) {
+			continue
+		}
+		if matchesAnyGlob(fileInfo.Name(), excludedGlobs) {
+			continue
+		}
+		if len(includedGlobs) > 0 && !matchesAnyGlob(fileInfo.Name(), includedGlobs) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func compileGlobPatterns(patterns []string) []*paths.GlobPattern {
+	globPatterns := make([]*paths.GlobPattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		globPattern, err := paths.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		globPatterns = append(globPatterns, globPattern)
+	}
+	return globPatterns
+}
+
+func matchesAnyGlob(path string, patterns []*paths.GlobPattern) bool {
+	for _, pattern := range patterns {
+		if pattern.Match(path) {
+			return true
+		}
+	}
+	return false
+}
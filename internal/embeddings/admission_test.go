@@ -0,0 +1,143 @@
+package embeddings
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/background/repo"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeFileInfo struct {
+	name  string
+	size  int64
+	isDir bool
+}
+
+func (fi fakeFileInfo) Name() string       { return fi.name }
+func (fi fakeFileInfo) Size() int64        { return fi.size }
+func (fi fakeFileInfo) Mode() fs.FileMode  { return 0 }
+func (fi fakeFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fakeFileInfo) IsDir() bool        { return fi.isDir }
+func (fi fakeFileInfo) Sys() any           { return nil }
+
+func TestCheckAdmission(t *testing.T) {
+	ctx := context.Background()
+
+	gitserverRepos := dbmocks.NewMockGitserverRepoStore()
+	gitserverRepos.GetByIDFunc.SetDefaultReturn(&types.GitserverRepo{RepoID: 1, RepoSizeBytes: 1000}, nil)
+	db := dbmocks.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gitserverRepos)
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadDirFunc.SetDefaultReturn([]fs.FileInfo{
+		fakeFileInfo{name: "src", isDir: true},
+		fakeFileInfo{name: "src/a.go", size: 10},
+		fakeFileInfo{name: "src/b.go", size: 10},
+		fakeFileInfo{name: "src/c.ts", size: 10},
+		fakeFileInfo{name: "vendor/d.go", size: 10},
+		fakeFileInfo{name: "big.go", size: 10_000},
+	}, nil)
+
+	newStore := func(exempt bool) repo.RepoEmbeddingJobsStore {
+		store := repo.NewMockRepoEmbeddingJobsStore()
+		store.HasAdmissionOverrideFunc.SetDefaultReturn(exempt, nil)
+		return store
+	}
+
+	newConfig := func(admission conftypes.EmbeddingsAdmissionConfig) *conftypes.EmbeddingsConfig {
+		return &conftypes.EmbeddingsConfig{
+			FileFilters: conftypes.EmbeddingsFileFilters{MaxFileSizeBytes: 1000},
+			Admission:   admission,
+		}
+	}
+
+	isAdmissionError := func(err error) bool {
+		var admissionErr *AdmissionError
+		return errors.As(err, &admissionErr)
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		store := repo.NewMockRepoEmbeddingJobsStore()
+		err := CheckAdmission(ctx, db, store, gitserverClient, 1, "repo", "abc", newConfig(conftypes.EmbeddingsAdmissionConfig{}))
+		require.NoError(t, err)
+		require.Len(t, store.HasAdmissionOverrideFunc.History(), 0)
+	})
+
+	t.Run("repo too large", func(t *testing.T) {
+		err := CheckAdmission(ctx, db, newStore(false), gitserverClient, 1, "repo", "abc", newConfig(conftypes.EmbeddingsAdmissionConfig{MaxRepoSizeBytes: 999}))
+		require.True(t, isAdmissionError(err), "unexpected error: %v", err)
+	})
+
+	t.Run("repo size within limit", func(t *testing.T) {
+		err := CheckAdmission(ctx, db, newStore(false), gitserverClient, 1, "repo", "abc", newConfig(conftypes.EmbeddingsAdmissionConfig{MaxRepoSizeBytes: 1000}))
+		require.NoError(t, err)
+	})
+
+	t.Run("too many files", func(t *testing.T) {
+		err := CheckAdmission(ctx, db, newStore(false), gitserverClient, 1, "repo", "abc", newConfig(conftypes.EmbeddingsAdmissionConfig{MaxFileCount: 2}))
+		require.True(t, isAdmissionError(err), "unexpected error: %v", err)
+	})
+
+	t.Run("file count within limit", func(t *testing.T) {
+		// Directories, vendored files and files above the size limit aren't counted.
+		err := CheckAdmission(ctx, db, newStore(false), gitserverClient, 1, "repo", "abc", newConfig(conftypes.EmbeddingsAdmissionConfig{MaxFileCount: 3}))
+		require.NoError(t, err)
+	})
+
+	t.Run("file count with per-repo filters", func(t *testing.T) {
+		config := newConfig(conftypes.EmbeddingsAdmissionConfig{
+			MaxFileCount: 2,
+			RepoFileFilters: []conftypes.EmbeddingsRepoFileFilters{{
+				RepoNamePattern:          "^repo$",
+				ExcludedFilePathPatterns: []string{"*.ts"},
+			}},
+		})
+		err := CheckAdmission(ctx, db, newStore(false), gitserverClient, 1, "repo", "abc", config)
+		require.NoError(t, err)
+	})
+
+	t.Run("file count is skipped without revision", func(t *testing.T) {
+		err := CheckAdmission(ctx, db, newStore(false), gitserverClient, 1, "repo", "", newConfig(conftypes.EmbeddingsAdmissionConfig{MaxFileCount: 1}))
+		require.NoError(t, err)
+	})
+
+	t.Run("exempted repo", func(t *testing.T) {
+		err := CheckAdmission(ctx, db, newStore(true), gitserverClient, 1, "repo", "abc", newConfig(conftypes.EmbeddingsAdmissionConfig{MaxRepoSizeBytes: 1, MaxFileCount: 1}))
+		require.NoError(t, err)
+	})
+}
+
+func TestFileFilterPatternsForRepo(t *testing.T) {
+	config := &conftypes.EmbeddingsConfig{
+		FileFilters: conftypes.EmbeddingsFileFilters{
+			IncludedFilePathPatterns: []string{"*.go"},
+		},
+		Admission: conftypes.EmbeddingsAdmissionConfig{
+			RepoFileFilters: []conftypes.EmbeddingsRepoFileFilters{
+				{RepoNamePattern: "^github.com/acme/", IncludedFilePathPatterns: []string{"*.ts"}, ExcludedFilePathPatterns: []string{"*.d.ts"}},
+				{RepoNamePattern: "(invalid", ExcludedFilePathPatterns: []string{"*.md"}},
+			},
+		},
+	}
+
+	included, excluded := FileFilterPatternsForRepo(config, "github.com/acme/web")
+	require.Equal(t, []string{"*.ts"}, included)
+	require.Equal(t, append(DefaultExcludedFilePathPatterns[:len(DefaultExcludedFilePathPatterns):len(DefaultExcludedFilePathPatterns)], "*.d.ts"), excluded)
+
+	included, excluded = FileFilterPatternsForRepo(config, "github.com/other/web")
+	require.Equal(t, []string{"*.go"}, included)
+	require.Equal(t, DefaultExcludedFilePathPatterns, excluded)
+
+	included, excluded = FileFilterPatternsForRepo(nil, "github.com/acme/web")
+	require.Empty(t, included)
+	require.Equal(t, DefaultExcludedFilePathPatterns, excluded)
+}
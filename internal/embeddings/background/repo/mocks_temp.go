@@ -32,6 +32,9 @@ type MockRepoEmbeddingJobsStore struct {
 	// CreateRepoEmbeddingJobFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateRepoEmbeddingJob.
 	CreateRepoEmbeddingJobFunc *RepoEmbeddingJobsStoreCreateRepoEmbeddingJobFunc
+	// DeleteAdmissionOverrideFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteAdmissionOverride.
+	DeleteAdmissionOverrideFunc *RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *RepoEmbeddingJobsStoreDoneFunc
@@ -55,12 +58,18 @@ type MockRepoEmbeddingJobsStore struct {
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *RepoEmbeddingJobsStoreHandleFunc
+	// HasAdmissionOverrideFunc is an instance of a mock function object
+	// controlling the behavior of the method HasAdmissionOverride.
+	HasAdmissionOverrideFunc *RepoEmbeddingJobsStoreHasAdmissionOverrideFunc
 	// ListRepoEmbeddingJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListRepoEmbeddingJobs.
 	ListRepoEmbeddingJobsFunc *RepoEmbeddingJobsStoreListRepoEmbeddingJobsFunc
 	// RescheduleAllReposFunc is an instance of a mock function object
 	// controlling the behavior of the method RescheduleAllRepos.
 	RescheduleAllReposFunc *RepoEmbeddingJobsStoreRescheduleAllReposFunc
+	// SetAdmissionOverrideFunc is an instance of a mock function object
+	// controlling the behavior of the method SetAdmissionOverride.
+	SetAdmissionOverrideFunc *RepoEmbeddingJobsStoreSetAdmissionOverrideFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *RepoEmbeddingJobsStoreTransactFunc
@@ -95,6 +104,11 @@ func NewMockRepoEmbeddingJobsStore() *MockRepoEmbeddingJobsStore {
 				return
 			},
 		},
+		DeleteAdmissionOverrideFunc: &RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 error) {
+				return
+			},
+		},
 		DoneFunc: &RepoEmbeddingJobsStoreDoneFunc{
 			defaultHook: func(error) (r0 error) {
 				return
@@ -130,6 +144,11 @@ func NewMockRepoEmbeddingJobsStore() *MockRepoEmbeddingJobsStore {
 				return
 			},
 		},
+		HasAdmissionOverrideFunc: &RepoEmbeddingJobsStoreHasAdmissionOverrideFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 bool, r1 error) {
+				return
+			},
+		},
 		ListRepoEmbeddingJobsFunc: &RepoEmbeddingJobsStoreListRepoEmbeddingJobsFunc{
 			defaultHook: func(context.Context, ListOpts) (r0 []*RepoEmbeddingJob, r1 error) {
 				return
@@ -140,6 +159,11 @@ func NewMockRepoEmbeddingJobsStore() *MockRepoEmbeddingJobsStore {
 				return
 			},
 		},
+		SetAdmissionOverrideFunc: &RepoEmbeddingJobsStoreSetAdmissionOverrideFunc{
+			defaultHook: func(context.Context, api.RepoID, int32) (r0 error) {
+				return
+			},
+		},
 		TransactFunc: &RepoEmbeddingJobsStoreTransactFunc{
 			defaultHook: func(context.Context) (r0 RepoEmbeddingJobsStore, r1 error) {
 				return
@@ -178,6 +202,11 @@ func NewStrictMockRepoEmbeddingJobsStore() *MockRepoEmbeddingJobsStore {
 				panic("unexpected invocation of MockRepoEmbeddingJobsStore.CreateRepoEmbeddingJob")
 			},
 		},
+		DeleteAdmissionOverrideFunc: &RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc{
+			defaultHook: func(context.Context, api.RepoID) error {
+				panic("unexpected invocation of MockRepoEmbeddingJobsStore.DeleteAdmissionOverride")
+			},
+		},
 		DoneFunc: &RepoEmbeddingJobsStoreDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockRepoEmbeddingJobsStore.Done")
@@ -213,6 +242,11 @@ func NewStrictMockRepoEmbeddingJobsStore() *MockRepoEmbeddingJobsStore {
 				panic("unexpected invocation of MockRepoEmbeddingJobsStore.Handle")
 			},
 		},
+		HasAdmissionOverrideFunc: &RepoEmbeddingJobsStoreHasAdmissionOverrideFunc{
+			defaultHook: func(context.Context, api.RepoID) (bool, error) {
+				panic("unexpected invocation of MockRepoEmbeddingJobsStore.HasAdmissionOverride")
+			},
+		},
 		ListRepoEmbeddingJobsFunc: &RepoEmbeddingJobsStoreListRepoEmbeddingJobsFunc{
 			defaultHook: func(context.Context, ListOpts) ([]*RepoEmbeddingJob, error) {
 				panic("unexpected invocation of MockRepoEmbeddingJobsStore.ListRepoEmbeddingJobs")
@@ -223,6 +257,11 @@ func NewStrictMockRepoEmbeddingJobsStore() *MockRepoEmbeddingJobsStore {
 				panic("unexpected invocation of MockRepoEmbeddingJobsStore.RescheduleAllRepos")
 			},
 		},
+		SetAdmissionOverrideFunc: &RepoEmbeddingJobsStoreSetAdmissionOverrideFunc{
+			defaultHook: func(context.Context, api.RepoID, int32) error {
+				panic("unexpected invocation of MockRepoEmbeddingJobsStore.SetAdmissionOverride")
+			},
+		},
 		TransactFunc: &RepoEmbeddingJobsStoreTransactFunc{
 			defaultHook: func(context.Context) (RepoEmbeddingJobsStore, error) {
 				panic("unexpected invocation of MockRepoEmbeddingJobsStore.Transact")
@@ -253,6 +292,9 @@ func NewMockRepoEmbeddingJobsStoreFrom(i RepoEmbeddingJobsStore) *MockRepoEmbedd
 		CreateRepoEmbeddingJobFunc: &RepoEmbeddingJobsStoreCreateRepoEmbeddingJobFunc{
 			defaultHook: i.CreateRepoEmbeddingJob,
 		},
+		DeleteAdmissionOverrideFunc: &RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc{
+			defaultHook: i.DeleteAdmissionOverride,
+		},
 		DoneFunc: &RepoEmbeddingJobsStoreDoneFunc{
 			defaultHook: i.Done,
 		},
@@ -274,12 +316,18 @@ func NewMockRepoEmbeddingJobsStoreFrom(i RepoEmbeddingJobsStore) *MockRepoEmbedd
 		HandleFunc: &RepoEmbeddingJobsStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		HasAdmissionOverrideFunc: &RepoEmbeddingJobsStoreHasAdmissionOverrideFunc{
+			defaultHook: i.HasAdmissionOverride,
+		},
 		ListRepoEmbeddingJobsFunc: &RepoEmbeddingJobsStoreListRepoEmbeddingJobsFunc{
 			defaultHook: i.ListRepoEmbeddingJobs,
 		},
 		RescheduleAllReposFunc: &RepoEmbeddingJobsStoreRescheduleAllReposFunc{
 			defaultHook: i.RescheduleAllRepos,
 		},
+		SetAdmissionOverrideFunc: &RepoEmbeddingJobsStoreSetAdmissionOverrideFunc{
+			defaultHook: i.SetAdmissionOverride,
+		},
 		TransactFunc: &RepoEmbeddingJobsStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
@@ -734,6 +782,115 @@ func (c RepoEmbeddingJobsStoreCreateRepoEmbeddingJobFuncCall) Results() []interf
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc describes the behavior
+// when the DeleteAdmissionOverride method of the parent
+// MockRepoEmbeddingJobsStore instance is invoked.
+type RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc struct {
+	defaultHook func(context.Context, api.RepoID) error
+	hooks       []func(context.Context, api.RepoID) error
+	history     []RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteAdmissionOverride delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockRepoEmbeddingJobsStore) DeleteAdmissionOverride(v0 context.Context, v1 api.RepoID) error {
+	r0 := m.DeleteAdmissionOverrideFunc.nextHook()(v0, v1)
+	m.DeleteAdmissionOverrideFunc.appendCall(RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteAdmissionOverride method of the parent MockRepoEmbeddingJobsStore
+// instance is invoked and the hook queue is empty.
+func (f *RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc) SetDefaultHook(hook func(context.Context, api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteAdmissionOverride method of the parent MockRepoEmbeddingJobsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc) PushHook(hook func(context.Context, api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc) nextHook() func(context.Context, api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc) appendCall(r0 RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall objects describing
+// the invocations of this function.
+func (f *RepoEmbeddingJobsStoreDeleteAdmissionOverrideFunc) History() []RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall is an object that
+// describes an invocation of method DeleteAdmissionOverride on an instance
+// of MockRepoEmbeddingJobsStore.
+type RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoEmbeddingJobsStoreDeleteAdmissionOverrideFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // RepoEmbeddingJobsStoreDoneFunc describes the behavior when the Done
 // method of the parent MockRepoEmbeddingJobsStore instance is invoked.
 type RepoEmbeddingJobsStoreDoneFunc struct {
@@ -1495,6 +1652,118 @@ func (c RepoEmbeddingJobsStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// RepoEmbeddingJobsStoreHasAdmissionOverrideFunc describes the behavior
+// when the HasAdmissionOverride method of the parent
+// MockRepoEmbeddingJobsStore instance is invoked.
+type RepoEmbeddingJobsStoreHasAdmissionOverrideFunc struct {
+	defaultHook func(context.Context, api.RepoID) (bool, error)
+	hooks       []func(context.Context, api.RepoID) (bool, error)
+	history     []RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall
+	mutex       sync.Mutex
+}
+
+// HasAdmissionOverride delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoEmbeddingJobsStore) HasAdmissionOverride(v0 context.Context, v1 api.RepoID) (bool, error) {
+	r0, r1 := m.HasAdmissionOverrideFunc.nextHook()(v0, v1)
+	m.HasAdmissionOverrideFunc.appendCall(RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the HasAdmissionOverride
+// method of the parent MockRepoEmbeddingJobsStore instance is invoked and
+// the hook queue is empty.
+func (f *RepoEmbeddingJobsStoreHasAdmissionOverrideFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HasAdmissionOverride method of the parent MockRepoEmbeddingJobsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *RepoEmbeddingJobsStoreHasAdmissionOverrideFunc) PushHook(hook func(context.Context, api.RepoID) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoEmbeddingJobsStoreHasAdmissionOverrideFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoEmbeddingJobsStoreHasAdmissionOverrideFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoEmbeddingJobsStoreHasAdmissionOverrideFunc) nextHook() func(context.Context, api.RepoID) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoEmbeddingJobsStoreHasAdmissionOverrideFunc) appendCall(r0 RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall objects describing the
+// invocations of this function.
+func (f *RepoEmbeddingJobsStoreHasAdmissionOverrideFunc) History() []RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall is an object that
+// describes an invocation of method HasAdmissionOverride on an instance of
+// MockRepoEmbeddingJobsStore.
+type RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoEmbeddingJobsStoreHasAdmissionOverrideFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // RepoEmbeddingJobsStoreListRepoEmbeddingJobsFunc describes the behavior
 // when the ListRepoEmbeddingJobs method of the parent
 // MockRepoEmbeddingJobsStore instance is invoked.
@@ -1713,6 +1982,118 @@ func (c RepoEmbeddingJobsStoreRescheduleAllReposFuncCall) Results() []interface{
 	return []interface{}{c.Result0}
 }
 
+// RepoEmbeddingJobsStoreSetAdmissionOverrideFunc describes the behavior
+// when the SetAdmissionOverride method of the parent
+// MockRepoEmbeddingJobsStore instance is invoked.
+type RepoEmbeddingJobsStoreSetAdmissionOverrideFunc struct {
+	defaultHook func(context.Context, api.RepoID, int32) error
+	hooks       []func(context.Context, api.RepoID, int32) error
+	history     []RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall
+	mutex       sync.Mutex
+}
+
+// SetAdmissionOverride delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoEmbeddingJobsStore) SetAdmissionOverride(v0 context.Context, v1 api.RepoID, v2 int32) error {
+	r0 := m.SetAdmissionOverrideFunc.nextHook()(v0, v1, v2)
+	m.SetAdmissionOverrideFunc.appendCall(RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetAdmissionOverride
+// method of the parent MockRepoEmbeddingJobsStore instance is invoked and
+// the hook queue is empty.
+func (f *RepoEmbeddingJobsStoreSetAdmissionOverrideFunc) SetDefaultHook(hook func(context.Context, api.RepoID, int32) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetAdmissionOverride method of the parent MockRepoEmbeddingJobsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *RepoEmbeddingJobsStoreSetAdmissionOverrideFunc) PushHook(hook func(context.Context, api.RepoID, int32) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoEmbeddingJobsStoreSetAdmissionOverrideFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, int32) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoEmbeddingJobsStoreSetAdmissionOverrideFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, int32) error {
+		return r0
+	})
+}
+
+func (f *RepoEmbeddingJobsStoreSetAdmissionOverrideFunc) nextHook() func(context.Context, api.RepoID, int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoEmbeddingJobsStoreSetAdmissionOverrideFunc) appendCall(r0 RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall objects describing the
+// invocations of this function.
+func (f *RepoEmbeddingJobsStoreSetAdmissionOverrideFunc) History() []RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall is an object that
+// describes an invocation of method SetAdmissionOverride on an instance of
+// MockRepoEmbeddingJobsStore.
+type RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoEmbeddingJobsStoreSetAdmissionOverrideFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // RepoEmbeddingJobsStoreTransactFunc describes the behavior when the
 // Transact method of the parent MockRepoEmbeddingJobsStore instance is
 // invoked.
@@ -112,6 +112,10 @@ type RepoEmbeddingJobsStore interface {
 	GetRepoEmbeddingJobStats(ctx context.Context, jobID int) (EmbedRepoStats, error)
 
 	CountRepoEmbeddings(ctx context.Context) (int, error)
+
+	SetAdmissionOverride(ctx context.Context, repoID api.RepoID, userID int32) error
+	DeleteAdmissionOverride(ctx context.Context, repoID api.RepoID) error
+	HasAdmissionOverride(ctx context.Context, repoID api.RepoID) (bool, error)
 }
 
 var _ basestore.ShareableStore = &repoEmbeddingJobsStore{}
@@ -591,3 +595,41 @@ WHERE state = 'completed';
 func (s *repoEmbeddingJobsStore) CountRepoEmbeddings(ctx context.Context) (int, error) {
 	return basestore.ScanInt(s.QueryRow(ctx, sqlf.Sprintf(countRepoEmbeddingsQuery)))
 }
+
+const setAdmissionOverrideQuery = `
+INSERT INTO repo_embedding_admission_overrides (repo_id, created_by)
+VALUES (%s, %s)
+ON CONFLICT (repo_id) DO NOTHING
+`
+
+// SetAdmissionOverride exempts the given repository from the embeddings
+// admission policy.
+func (s *repoEmbeddingJobsStore) SetAdmissionOverride(ctx context.Context, repoID api.RepoID, userID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(setAdmissionOverrideQuery, repoID, dbutil.NullInt32Column(userID)))
+}
+
+const deleteAdmissionOverrideQuery = `
+DELETE FROM repo_embedding_admission_overrides
+WHERE repo_id = %s
+`
+
+// DeleteAdmissionOverride removes the exemption of the given repository from
+// the embeddings admission policy, if any.
+func (s *repoEmbeddingJobsStore) DeleteAdmissionOverride(ctx context.Context, repoID api.RepoID) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAdmissionOverrideQuery, repoID))
+}
+
+const hasAdmissionOverrideQuery = `
+SELECT EXISTS (
+	SELECT 1
+	FROM repo_embedding_admission_overrides
+	WHERE repo_id = %s
+)
+`
+
+// HasAdmissionOverride returns true if the given repository is exempt from the
+// embeddings admission policy.
+func (s *repoEmbeddingJobsStore) HasAdmissionOverride(ctx context.Context, repoID api.RepoID) (bool, error) {
+	exists, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(hasAdmissionOverrideQuery, repoID)))
+	return exists, err
+}
@@ -241,6 +241,37 @@ func TestCancelRepoEmbeddingJob(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestAdmissionOverrides(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	createdRepo := &types.Repo{Name: "github.com/sourcegraph/sourcegraph", URI: "github.com/sourcegraph/sourcegraph", ExternalRepo: api.ExternalRepoSpec{}}
+	require.NoError(t, db.Repos().Create(ctx, createdRepo))
+
+	store := NewRepoEmbeddingJobsStore(db)
+
+	exempt, err := store.HasAdmissionOverride(ctx, createdRepo.ID)
+	require.NoError(t, err)
+	require.False(t, exempt)
+
+	// Setting an override twice is fine.
+	require.NoError(t, store.SetAdmissionOverride(ctx, createdRepo.ID, 0))
+	require.NoError(t, store.SetAdmissionOverride(ctx, createdRepo.ID, 0))
+
+	exempt, err = store.HasAdmissionOverride(ctx, createdRepo.ID)
+	require.NoError(t, err)
+	require.True(t, exempt)
+
+	require.NoError(t, store.DeleteAdmissionOverride(ctx, createdRepo.ID))
+
+	exempt, err = store.HasAdmissionOverride(ctx, createdRepo.ID)
+	require.NoError(t, err)
+	require.False(t, exempt)
+}
+
 func TestGetEmbeddableRepos(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,147 @@
+// Package openaicompatible implements an embeddings client for self-hosted
+// model servers that expose an OpenAI-compatible embeddings API, such as
+// vLLM, text-embeddings-inference or LocalAI.
+package openaicompatible
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/modeltransformations"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func NewClient(httpClient *http.Client, config *conftypes.EmbeddingsConfig) *openaiCompatibleEmbeddingsClient {
+	return &openaiCompatibleEmbeddingsClient{
+		httpClient:  httpClient,
+		dimensions:  config.Dimensions,
+		accessToken: config.AccessToken,
+		model:       config.Model,
+		endpoint:    config.Endpoint,
+	}
+}
+
+type openaiCompatibleEmbeddingsClient struct {
+	httpClient  *http.Client
+	model       string
+	dimensions  int
+	endpoint    string
+	accessToken string
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetDimensions() (int, error) {
+	if c.dimensions <= 0 {
+		return 0, errors.New("invalid config for embeddings.dimensions, must be > 0")
+	}
+	return c.dimensions, nil
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetModelIdentifier() string {
+	return fmt.Sprintf("%s/%s", conftypes.EmbeddingsProviderNameOpenAICompatible, c.model)
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetQueryEmbedding(ctx context.Context, query string) (*client.EmbeddingsResults, error) {
+	return c.getEmbeddings(ctx, []string{modeltransformations.ApplyToQuery(query, c.GetModelIdentifier())})
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetDocumentEmbeddings(ctx context.Context, documents []string) (*client.EmbeddingsResults, error) {
+	return c.getEmbeddings(ctx, modeltransformations.ApplyToDocuments(documents, c.GetModelIdentifier()))
+}
+
+func (c *openaiCompatibleEmbeddingsClient) getEmbeddings(ctx context.Context, texts []string) (*client.EmbeddingsResults, error) {
+	for _, text := range texts {
+		if text == "" {
+			// Most OpenAI-compatible servers reject empty inputs, so fail fast
+			// to avoid making tons of retryable requests.
+			return nil, errors.New("cannot generate embeddings for an empty string")
+		}
+	}
+
+	response, err := c.do(ctx, embeddingAPIRequest{Model: c.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) != len(texts) {
+		return nil, errors.Newf("expected %d embeddings, got %d", len(texts), len(response.Data))
+	}
+
+	// Ensure embedding responses are sorted in the original order.
+	sort.Slice(response.Data, func(i, j int) bool {
+		return response.Data[i].Index < response.Data[j].Index
+	})
+
+	// Unlike the hosted OpenAI API, we know the dimensionality of the model
+	// up front, so we can validate the server's response against it.
+	embeddings := make([]float32, 0, len(response.Data)*c.dimensions)
+	failed := make([]int, 0)
+	for i, embedding := range response.Data {
+		switch len(embedding.Embedding) {
+		case c.dimensions:
+			embeddings = append(embeddings, embedding.Embedding...)
+		case 0:
+			// Provide a zero value embedding for the failed chunk.
+			failed = append(failed, i)
+			embeddings = append(embeddings, make([]float32, c.dimensions)...)
+		default:
+			return nil, errors.Newf("expected embedding of dimension %d, got %d; check the value of embeddings.dimensions", c.dimensions, len(embedding.Embedding))
+		}
+	}
+
+	return &client.EmbeddingsResults{Embeddings: embeddings, Failed: failed, Dimensions: c.dimensions}, nil
+}
+
+func (c *openaiCompatibleEmbeddingsClient) do(ctx context.Context, request embeddingAPIRequest) (*embeddingAPIResponse, error) {
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Self-hosted model servers are commonly deployed without authentication.
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("embeddings: %s %q: failed with status %d: %s", req.Method, req.URL.String(), resp.StatusCode, string(respBody))
+	}
+
+	var response embeddingAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+type embeddingAPIRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingAPIResponse struct {
+	Data []embeddingAPIResponseData `json:"data"`
+}
+
+type embeddingAPIResponseData struct {
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
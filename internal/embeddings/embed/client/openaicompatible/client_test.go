@@ -0,0 +1,117 @@
+package openaicompatible
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+)
+
+func TestOpenAICompatible(t *testing.T) {
+	newServer := func(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, req embeddingAPIRequest)) *httptest.Server {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req embeddingAPIRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			handler(w, r, req)
+		}))
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	t.Run("errors on empty embedding string", func(t *testing.T) {
+		client := NewClient(http.DefaultClient, &conftypes.EmbeddingsConfig{Dimensions: 2})
+		_, err := client.GetDocumentEmbeddings(context.Background(), []string{"a", ""})
+		require.ErrorContains(t, err, "empty string")
+	})
+
+	t.Run("model identifier", func(t *testing.T) {
+		client := NewClient(http.DefaultClient, &conftypes.EmbeddingsConfig{Model: "bge-small-en"})
+		require.Equal(t, "openai-compatible/bge-small-en", client.GetModelIdentifier())
+	})
+
+	t.Run("sends embeddings request", func(t *testing.T) {
+		s := newServer(t, func(w http.ResponseWriter, r *http.Request, req embeddingAPIRequest) {
+			require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			require.Equal(t, "bge-small-en", req.Model)
+			require.Equal(t, []string{"a", "b"}, req.Input)
+			// Respond out of order, the client should sort the results.
+			json.NewEncoder(w).Encode(embeddingAPIResponse{
+				Data: []embeddingAPIResponseData{
+					{Index: 1, Embedding: []float32{3, 4}},
+					{Index: 0, Embedding: []float32{1, 2}},
+				},
+			})
+		})
+
+		client := NewClient(s.Client(), &conftypes.EmbeddingsConfig{
+			Endpoint:    s.URL,
+			AccessToken: "secret",
+			Model:       "bge-small-en",
+			Dimensions:  2,
+		})
+		resp, err := client.GetDocumentEmbeddings(context.Background(), []string{"a", "b"})
+		require.NoError(t, err)
+		require.Equal(t, []float32{1, 2, 3, 4}, resp.Embeddings)
+		require.Empty(t, resp.Failed)
+		require.Equal(t, 2, resp.Dimensions)
+	})
+
+	t.Run("omits authorization header without access token", func(t *testing.T) {
+		s := newServer(t, func(w http.ResponseWriter, r *http.Request, req embeddingAPIRequest) {
+			_, ok := r.Header["Authorization"]
+			require.False(t, ok)
+			json.NewEncoder(w).Encode(embeddingAPIResponse{
+				Data: []embeddingAPIResponseData{{Index: 0, Embedding: []float32{1, 2}}},
+			})
+		})
+
+		client := NewClient(s.Client(), &conftypes.EmbeddingsConfig{Endpoint: s.URL, Model: "m", Dimensions: 2})
+		_, err := client.GetQueryEmbedding(context.Background(), "a")
+		require.NoError(t, err)
+	})
+
+	t.Run("marks empty embeddings as failed", func(t *testing.T) {
+		s := newServer(t, func(w http.ResponseWriter, r *http.Request, req embeddingAPIRequest) {
+			json.NewEncoder(w).Encode(embeddingAPIResponse{
+				Data: []embeddingAPIResponseData{
+					{Index: 0, Embedding: nil},
+					{Index: 1, Embedding: []float32{3, 4}},
+				},
+			})
+		})
+
+		client := NewClient(s.Client(), &conftypes.EmbeddingsConfig{Endpoint: s.URL, Model: "m", Dimensions: 2})
+		resp, err := client.GetDocumentEmbeddings(context.Background(), []string{"a", "b"})
+		require.NoError(t, err)
+		require.Equal(t, []float32{0, 0, 3, 4}, resp.Embeddings)
+		require.Equal(t, []int{0}, resp.Failed)
+	})
+
+	t.Run("errors on dimension mismatch", func(t *testing.T) {
+		s := newServer(t, func(w http.ResponseWriter, r *http.Request, req embeddingAPIRequest) {
+			json.NewEncoder(w).Encode(embeddingAPIResponse{
+				Data: []embeddingAPIResponseData{{Index: 0, Embedding: []float32{1, 2, 3}}},
+			})
+		})
+
+		client := NewClient(s.Client(), &conftypes.EmbeddingsConfig{Endpoint: s.URL, Model: "m", Dimensions: 2})
+		_, err := client.GetQueryEmbedding(context.Background(), "a")
+		require.ErrorContains(t, err, "expected embedding of dimension 2, got 3")
+	})
+
+	t.Run("errors on non-200 response", func(t *testing.T) {
+		s := newServer(t, func(w http.ResponseWriter, r *http.Request, req embeddingAPIRequest) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("model is loading"))
+		})
+
+		client := NewClient(s.Client(), &conftypes.EmbeddingsConfig{Endpoint: s.URL, Model: "m", Dimensions: 2})
+		_, err := client.GetQueryEmbedding(context.Background(), "a")
+		require.ErrorContains(t, err, "failed with status 503: model is loading")
+	})
+}
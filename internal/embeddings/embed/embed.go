@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/azureopenai"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/openai"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/openaicompatible"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/sourcegraph"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/paths"
@@ -30,6 +31,8 @@ func NewEmbeddingsClient(config *conftypes.EmbeddingsConfig) (client.EmbeddingsC
 		return openai.NewClient(httpcli.UncachedExternalClient, config), nil
 	case conftypes.EmbeddingsProviderNameAzureOpenAI:
 		return azureopenai.NewClient(azureopenai.GetAPIClient, config)
+	case conftypes.EmbeddingsProviderNameOpenAICompatible:
+		return openaicompatible.NewClient(httpcli.UncachedExternalClient, config), nil
 	default:
 		return nil, errors.Newf("invalid provider %q", config.Provider)
 	}
@@ -54,20 +57,30 @@ func EmbedRepo(
 	var toRemove []string
 	var err error
 
-	isIncremental := opts.IndexedRevision != ""
+	isIncremental := opts.PreviousIndex != nil
+	maxCodeEmbeddings, maxTextEmbeddings := opts.MaxCodeEmbeddings, opts.MaxTextEmbeddings
 
 	if isIncremental {
-		toIndex, toRemove, err = readLister.Diff(ctx, opts.IndexedRevision)
+		toIndex, toRemove, err = readLister.Diff(ctx, opts.PreviousIndex.Revision)
 		if err != nil {
 			logger.Error(
 				"failed to get diff. Falling back to full index",
 				log.String("RepoName", string(opts.RepoName)),
 				log.String("revision", string(opts.Revision)),
-				log.String("old revision", string(opts.IndexedRevision)),
+				log.String("old revision", string(opts.PreviousIndex.Revision)),
 				log.Error(err),
 			)
 			toRemove = nil
 			isIncremental = false
+		} else {
+			// The new chunks are merged into the previous index, so the chunks
+			// we keep from it count towards the limits, too.
+			toRemoveSet := make(map[string]struct{}, len(toRemove))
+			for _, file := range toRemove {
+				toRemoveSet[file] = struct{}{}
+			}
+			maxCodeEmbeddings -= opts.PreviousIndex.CodeIndex.CountRowsExcluding(toRemoveSet)
+			maxTextEmbeddings -= opts.PreviousIndex.TextIndex.CountRowsExcluding(toRemoveSet)
 		}
 	}
 
@@ -135,7 +148,7 @@ func EmbedRepo(
 		reportProgress(&stats)
 	}
 
-	codeIndexStats, err := embedFiles(ctx, logger, codeFileNames, client, contextService, opts.FileFilters, opts.SplitOptions, readLister, opts.MaxCodeEmbeddings, opts.BatchSize, opts.ExcludeChunks, insertCode, reportCodeProgress)
+	codeIndexStats, err := embedFiles(ctx, logger, codeFileNames, client, contextService, opts.FileFilters, opts.SplitOptions, readLister, maxCodeEmbeddings, opts.BatchSize, opts.ExcludeChunks, insertCode, reportCodeProgress)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -160,7 +173,7 @@ func EmbedRepo(
 		reportProgress(&stats)
 	}
 
-	textIndexStats, err := embedFiles(ctx, logger, textFileNames, client, contextService, opts.FileFilters, opts.SplitOptions, readLister, opts.MaxTextEmbeddings, opts.BatchSize, opts.ExcludeChunks, insertText, reportTextProgress)
+	textIndexStats, err := embedFiles(ctx, logger, textFileNames, client, contextService, opts.FileFilters, opts.SplitOptions, readLister, maxTextEmbeddings, opts.BatchSize, opts.ExcludeChunks, insertText, reportTextProgress)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -204,8 +217,10 @@ type EmbedRepoOpts struct {
 	ExcludeChunks         bool
 	TolerableFailureRatio float64
 
-	// If set, we already have an index for a previous commit.
-	IndexedRevision api.CommitID
+	// If set, we already have an index for a previous commit. Only the files
+	// that changed since are embedded, and the chunks kept from the previous
+	// index count towards MaxCodeEmbeddings and MaxTextEmbeddings.
+	PreviousIndex *embeddings.RepoEmbeddingIndex
 }
 
 type FileFilters struct {
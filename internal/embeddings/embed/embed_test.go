@@ -321,6 +321,74 @@ func TestEmbedRepo(t *testing.T) {
 		require.Len(t, index.TextIndex.Embeddings, index.CodeIndex.ColumnDimension*2)
 	})
 
+	t.Run("incremental", func(t *testing.T) {
+		// The previous index contains the chunks of a.go and c.java, and a.go
+		// has changed since.
+		previousIndex := &embeddings.RepoEmbeddingIndex{
+			RepoName: repoName,
+			Revision: "cafebabe",
+			CodeIndex: embeddings.EmbeddingIndex{
+				ColumnDimension: 3,
+				RowMetadata: []embeddings.RepoEmbeddingRowMetadata{
+					{FileName: "a.go"}, {FileName: "a.go"},
+					{FileName: "c.java"}, {FileName: "c.java"}, {FileName: "c.java"},
+				},
+			},
+		}
+		rl := listReader{
+			FileReader: reader,
+			FileLister: staticLister(nil),
+			FileDiffer: funcDiffer(func(_ context.Context, oldCommit api.CommitID) ([]FileEntry, []string, error) {
+				require.Equal(t, previousIndex.Revision, oldCommit)
+				return []FileEntry{{Name: "a.go", Size: 350}}, []string{"a.go"}, nil
+			}),
+		}
+
+		t.Run("only changed files are embedded", func(t *testing.T) {
+			optsCopy := opts
+			optsCopy.PreviousIndex = previousIndex
+
+			index, toRemove, stats, err := EmbedRepo(ctx, embeddingsClient, inserter, contextService, rl, repoIDName, mockRepoPathRanks, optsCopy, logger, noopReport)
+			require.NoError(t, err)
+			require.True(t, stats.IsIncremental)
+			require.Equal(t, []string{"a.go"}, toRemove)
+			// a.go has 2 chunks
+			require.Len(t, index.CodeIndex.RowMetadata, 2)
+			require.Len(t, index.TextIndex.RowMetadata, 0)
+		})
+
+		t.Run("kept chunks count towards limits", func(t *testing.T) {
+			optsCopy := opts
+			optsCopy.PreviousIndex = previousIndex
+			// c.java is kept and already has 3 chunks.
+			optsCopy.MaxCodeEmbeddings = 3
+
+			index, _, stats, err := EmbedRepo(ctx, embeddingsClient, inserter, contextService, rl, repoIDName, mockRepoPathRanks, optsCopy, logger, noopReport)
+			require.NoError(t, err)
+			require.Len(t, index.CodeIndex.RowMetadata, 0)
+			require.Equal(t, 1, stats.CodeIndexStats.FilesSkipped[SkipReasonMaxEmbeddings])
+		})
+
+		t.Run("falls back to full index if diff fails", func(t *testing.T) {
+			optsCopy := opts
+			optsCopy.PreviousIndex = previousIndex
+
+			rl := listReader{
+				FileReader: reader,
+				FileLister: staticLister([]FileEntry{{Name: "a.go", Size: 350}, {Name: "c.java", Size: 350}}),
+				FileDiffer: funcDiffer(func(context.Context, api.CommitID) ([]FileEntry, []string, error) {
+					return nil, nil, errors.New("diff failed")
+				}),
+			}
+			index, toRemove, stats, err := EmbedRepo(ctx, embeddingsClient, inserter, contextService, rl, repoIDName, mockRepoPathRanks, optsCopy, logger, noopReport)
+			require.NoError(t, err)
+			require.False(t, stats.IsIncremental)
+			require.Empty(t, toRemove)
+			// a.go has 2 chunks, c.java has 3 chunks
+			require.Len(t, index.CodeIndex.RowMetadata, 5)
+		})
+	})
+
 	t.Run("misbehaving embeddings service", func(t *testing.T) {
 		// We should not trust the embeddings service to return the correct number of dimensions.
 		// We've had multiple issues in the past where the embeddings call succeeds, but returns
@@ -805,6 +873,12 @@ func (l staticLister) List(_ context.Context) ([]FileEntry, error) {
 	return l, nil
 }
 
+type funcDiffer func(ctx context.Context, oldCommit api.CommitID) ([]FileEntry, []string, error)
+
+func (f funcDiffer) Diff(ctx context.Context, oldCommit api.CommitID) ([]FileEntry, []string, error) {
+	return f(ctx, oldCommit)
+}
+
 type listReader struct {
 	FileReader
 	FileLister
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/binary"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	"github.com/sourcegraph/sourcegraph/internal/paths"
 )
 
@@ -28,22 +29,8 @@ var TextFileExtensions = map[string]struct{}{
 	"txt":      {},
 }
 
-var DefaultExcludedFilePathPatterns = []string{
-	".*ignore", // Files like .gitignore, .eslintignore
-	".gitattributes",
-	".mailmap",
-	"*.csv",
-	"*.svg",
-	"*.xml",
-	"__fixtures__/",
-	"node_modules/",
-	"testdata/",
-	"mocks/",
-	"vendor/",
-}
-
 func GetDefaultExcludedFilePathPatterns() []*paths.GlobPattern {
-	return CompileGlobPatterns(DefaultExcludedFilePathPatterns)
+	return CompileGlobPatterns(embeddings.DefaultExcludedFilePathPatterns)
 }
 
 func CompileGlobPatterns(patterns []string) []*paths.GlobPattern {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/background/repo"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -23,6 +24,7 @@ func ScheduleRepositories(
 	}
 	defer func() { err = tx.Done(err) }()
 
+	embeddingsConfig := conf.GetEmbeddingsConfig(conf.Get().SiteConfig())
 	repoStore := db.Repos()
 	for _, repoName := range repoNames {
 		r, err := repoStore.GetByName(ctx, repoName)
@@ -44,6 +46,10 @@ func ScheduleRepositories(
 			}
 		}
 
+		if err := CheckAdmission(ctx, db, tx, gitserverClient, r.ID, r.Name, latestRevision, embeddingsConfig); err != nil {
+			return err
+		}
+
 		_, err = tx.CreateRepoEmbeddingJob(ctx, r.ID, latestRevision)
 		if err != nil {
 			return err
@@ -65,6 +71,7 @@ func ScheduleRepositoriesForPolicy(
 	}
 	defer func() { err = tx.Done(err) }()
 
+	embeddingsConfig := conf.GetEmbeddingsConfig(conf.Get().SiteConfig())
 	repoStore := db.Repos()
 	repos, err := repoStore.ListMinimalRepos(ctx, database.ReposListOptions{IDs: repoIDs})
 	if err != nil {
@@ -86,6 +93,12 @@ func ScheduleRepositoriesForPolicy(
 			continue
 		}
 
+		// Repositories that are not admitted, or that we fail to check, are
+		// skipped until the next run.
+		if err := CheckAdmission(ctx, db, tx, gitserverClient, r.ID, r.Name, latestRevision, embeddingsConfig); err != nil {
+			continue
+		}
+
 		_, err = tx.CreateRepoEmbeddingJob(ctx, r.ID, latestRevision)
 		if err != nil {
 			return err
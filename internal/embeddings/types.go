@@ -63,6 +63,18 @@ func (index *EmbeddingIndex) filter(set map[string]struct{}, ranks types.RepoPat
 	index.Embeddings = index.Embeddings[:cursor*index.ColumnDimension]
 }
 
+// CountRowsExcluding returns the number of rows in the index that don't belong
+// to any of the files in the set.
+func (index *EmbeddingIndex) CountRowsExcluding(set map[string]struct{}) int {
+	count := 0
+	for _, s := range index.RowMetadata {
+		if _, ok := set[s.FileName]; !ok {
+			count++
+		}
+	}
+	return count
+}
+
 func (index *EmbeddingIndex) append(other EmbeddingIndex) {
 	index.RowMetadata = append(index.RowMetadata, other.RowMetadata...)
 	index.Ranks = append(index.Ranks, other.Ranks...)
@@ -109,6 +109,24 @@ func TestEmbeddingIndexFilter(t *testing.T) {
 	}
 }
 
+func TestEmbeddingIndexCountRowsExcluding(t *testing.T) {
+	index := &EmbeddingIndex{
+		RowMetadata: []RepoEmbeddingRowMetadata{
+			{FileName: "file1"},
+			{FileName: "file1"},
+			{FileName: "file2"},
+			{FileName: "file3"},
+		},
+	}
+
+	if have, want := index.CountRowsExcluding(nil), 4; have != want {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	if have, want := index.CountRowsExcluding(map[string]struct{}{"file1": {}, "file4": {}}), 2; have != want {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+}
+
 func TestAppend(t *testing.T) {
 	index := EmbeddingIndex{
 		Embeddings:      []int8{1, 2, 3},
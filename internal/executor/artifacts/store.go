@@ -0,0 +1,71 @@
+// Package artifacts stores the files that executors upload for the jobs they
+// process, such as partial indexes of failed auto-indexing jobs.
+//
+// Artifacts are stored in a blob store under the key <queue>/<job ID>/<name>, so
+// that all artifacts of a queue can be expired with a single prefix.
+package artifacts
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func New(ctx context.Context, observationCtx *observation.Context, conf *Config) (uploadstore.Store, error) {
+	c := uploadstore.Config{
+		Backend:      conf.Backend,
+		ManageBucket: conf.ManageBucket,
+		Bucket:       conf.Bucket,
+		S3: uploadstore.S3Config{
+			Region:          conf.S3Region,
+			Endpoint:        conf.S3Endpoint,
+			UsePathStyle:    conf.S3UsePathStyle,
+			AccessKeyID:     conf.S3AccessKeyID,
+			SecretAccessKey: conf.S3SecretAccessKey,
+			SessionToken:    conf.S3SessionToken,
+		},
+		GCS: uploadstore.GCSConfig{
+			ProjectID:               conf.GCSProjectID,
+			CredentialsFile:         conf.GCSCredentialsFile,
+			CredentialsFileContents: conf.GCSCredentialsFileContents,
+		},
+	}
+	return uploadstore.CreateLazy(ctx, c, uploadstore.NewOperations(observationCtx, "executors", "artifacts"))
+}
+
+// QueuePrefix returns the prefix of the keys of all artifacts of the given queue.
+func QueuePrefix(queue string) string {
+	return queue + "/"
+}
+
+// JobPrefix returns the prefix of the keys of all artifacts of the given job.
+func JobPrefix(queue string, jobID int) string {
+	return QueuePrefix(queue) + strconv.Itoa(jobID) + "/"
+}
+
+// Key returns the key of the artifact with the given name.
+func Key(queue string, jobID int, name string) string {
+	return JobPrefix(queue, jobID) + name
+}
+
+// List returns the names of all artifacts of the given job.
+func List(ctx context.Context, store uploadstore.Store, queue string, jobID int) ([]string, error) {
+	prefix := JobPrefix(queue, jobID)
+	it, err := store.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for it.Next() {
+		names = append(names, strings.TrimPrefix(it.Current(), prefix))
+	}
+	if err := it.Err(); err != nil {
+		return nil, errors.Wrapf(err, "listing artifacts of job %d", jobID)
+	}
+	return names, nil
+}
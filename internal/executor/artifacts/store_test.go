@@ -0,0 +1,28 @@
+package artifacts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "codeintel/", QueuePrefix("codeintel"))
+	assert.Equal(t, "codeintel/42/", JobPrefix("codeintel", 42))
+	assert.Equal(t, "codeintel/42/build/index.scip", Key("codeintel", 42, "build/index.scip"))
+}
+
+func TestList(t *testing.T) {
+	store := mocks.NewMockStore()
+	store.ListFunc.SetDefaultReturn(iterator.From([]string{"codeintel/42/index.scip", "codeintel/42/build/output.log"}), nil)
+
+	names, err := List(context.Background(), store, "codeintel", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"index.scip", "build/output.log"}, names)
+	assert.Equal(t, "codeintel/42/", store.ListFunc.History()[0].Arg1)
+}
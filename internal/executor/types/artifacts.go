@@ -0,0 +1,44 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxArtifactNameLength is the maximum length of the name of an artifact.
+const maxArtifactNameLength = 255
+
+const (
+	// MaxArtifactSize is the maximum size of a single artifact in bytes.
+	MaxArtifactSize = 512 * 1024 * 1024
+
+	// MaxJobArtifactsSize is the maximum total size of the artifacts of a job in
+	// bytes.
+	MaxJobArtifactsSize = 1024 * 1024 * 1024
+
+	// MaxJobArtifacts is the maximum number of artifacts of a job.
+	MaxJobArtifacts = 100
+)
+
+// ValidateArtifactName returns an error if name is not a valid artifact name. Names
+// are relative slash-separated paths, e.g. build/output.log.
+func ValidateArtifactName(name string) error {
+	if name == "" {
+		return errors.New("artifact name must not be empty")
+	}
+	if len(name) > maxArtifactNameLength {
+		return errors.Newf("artifact name must not be longer than %d characters", maxArtifactNameLength)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("._-/", r)) {
+			return errors.Newf("invalid character %q in artifact name", r)
+		}
+	}
+	for _, segment := range strings.Split(name, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return errors.Newf("invalid artifact name %q", name)
+		}
+	}
+	return nil
+}
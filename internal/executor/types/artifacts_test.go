@@ -0,0 +1,16 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateArtifactName(t *testing.T) {
+	for _, name := range []string{"index.scip", "build/output.log", "a-b_c.d"} {
+		assert.NoError(t, ValidateArtifactName(name), name)
+	}
+	for _, name := range []string{"", "/index.scip", "build/", "../index.scip", "build/./index.scip", "index scip", "index\\scip", string(make([]byte, 256))} {
+		assert.Error(t, ValidateArtifactName(name), name)
+	}
+}
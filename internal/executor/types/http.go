@@ -33,6 +33,12 @@ type UpdateExecutionLogEntryRequest struct {
 	executor.ExecutionLogEntry
 }
 
+type AppendExecutionLogEntryOutputRequest struct {
+	JobOperationRequest
+	EntryID int    `json:"entryId"`
+	Out     string `json:"out"`
+}
+
 type MarkCompleteRequest struct {
 	JobOperationRequest
 }
@@ -71,6 +71,17 @@ type Job struct {
 	// takes precedence over a potentially configured EXECUTOR_DOCKER_AUTH_CONFIG environment
 	// variable.
 	DockerAuthConfig DockerAuthConfig `json:"dockerAuthConfig,omitempty"`
+
+	// ExternalSecrets is a map from secret names to references to secrets in an external
+	// secret store (see ParseSecretReference). Step environment variables of the form
+	// NAME=reference are replaced with the secret value, which the executor fetches from
+	// the external secret store right before running the job.
+	ExternalSecrets map[string]string `json:"externalSecrets,omitempty"`
+
+	// Artifacts is a list of paths or glob patterns, relative to the workspace of the job,
+	// of files that the executor uploads as artifacts of the job once its steps have run,
+	// regardless of whether they succeeded.
+	Artifacts []string `json:"artifacts,omitempty"`
 }
 
 func (j Job) MarshalJSON() ([]byte, error) {
@@ -90,6 +101,8 @@ func (j Job) MarshalJSON() ([]byte, error) {
 			CliSteps:            j.CliSteps,
 			RedactedValues:      j.RedactedValues,
 			DockerAuthConfig:    j.DockerAuthConfig,
+			ExternalSecrets:     j.ExternalSecrets,
+			Artifacts:           j.Artifacts,
 		}
 		v2.VirtualMachineFiles = make(map[string]v2VirtualMachineFile, len(j.VirtualMachineFiles))
 		for k, v := range j.VirtualMachineFiles {
@@ -110,6 +123,8 @@ func (j Job) MarshalJSON() ([]byte, error) {
 		DockerSteps:         j.DockerSteps,
 		CliSteps:            j.CliSteps,
 		RedactedValues:      j.RedactedValues,
+		ExternalSecrets:     j.ExternalSecrets,
+		Artifacts:           j.Artifacts,
 	}
 	v1.VirtualMachineFiles = make(map[string]v1VirtualMachineFile, len(j.VirtualMachineFiles))
 	for k, v := range j.VirtualMachineFiles {
@@ -151,6 +166,8 @@ func (j *Job) UnmarshalJSON(data []byte) error {
 		j.CliSteps = v2.CliSteps
 		j.RedactedValues = v2.RedactedValues
 		j.DockerAuthConfig = v2.DockerAuthConfig
+		j.ExternalSecrets = v2.ExternalSecrets
+		j.Artifacts = v2.Artifacts
 		return nil
 	}
 	var v1 v1Job
@@ -178,6 +195,8 @@ func (j *Job) UnmarshalJSON(data []byte) error {
 	j.DockerSteps = v1.DockerSteps
 	j.CliSteps = v1.CliSteps
 	j.RedactedValues = v1.RedactedValues
+	j.ExternalSecrets = v1.ExternalSecrets
+	j.Artifacts = v1.Artifacts
 	return nil
 }
 
@@ -201,6 +220,8 @@ type v2Job struct {
 	CliSteps            []CliStep                       `json:"cliSteps"`
 	RedactedValues      map[string]string               `json:"redactedValues"`
 	DockerAuthConfig    DockerAuthConfig                `json:"dockerAuthConfig,omitempty"`
+	ExternalSecrets     map[string]string               `json:"externalSecrets,omitempty"`
+	Artifacts           []string                        `json:"artifacts,omitempty"`
 }
 
 type v1Job struct {
@@ -217,6 +238,8 @@ type v1Job struct {
 	DockerSteps         []DockerStep                    `json:"dockerSteps"`
 	CliSteps            []CliStep                       `json:"cliSteps"`
 	RedactedValues      map[string]string               `json:"redactedValues"`
+	ExternalSecrets     map[string]string               `json:"externalSecrets,omitempty"`
+	Artifacts           []string                        `json:"artifacts,omitempty"`
 }
 
 // VirtualMachineFile is a file that will be written to the VM. A file can contain the raw content of the file or
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SecretProvider is an external secret store that executors can fetch secrets from.
+type SecretProvider string
+
+const (
+	// SecretProviderVault references a secret in HashiCorp Vault, as
+	// vault://<path>#<field>.
+	SecretProviderVault SecretProvider = "vault"
+	// SecretProviderAWSSecretsManager references a secret in AWS Secrets Manager,
+	// as awssm://<secret name or ARN>[#<JSON key>].
+	SecretProviderAWSSecretsManager SecretProvider = "awssm"
+	// SecretProviderGCPSecretManager references a secret in Google Cloud Secret
+	// Manager, as gcpsm://projects/<project>/secrets/<secret>[/versions/<version>][#<JSON key>].
+	SecretProviderGCPSecretManager SecretProvider = "gcpsm"
+)
+
+var secretProviders = []SecretProvider{
+	SecretProviderVault,
+	SecretProviderAWSSecretsManager,
+	SecretProviderGCPSecretManager,
+}
+
+// SecretReference points to a secret in an external secret store.
+type SecretReference struct {
+	// Provider is the secret store that holds the secret.
+	Provider SecretProvider
+	// Name identifies the secret within the secret store.
+	Name string
+	// Field optionally selects a single key of a secret that holds multiple
+	// values. For AWS and GCP secrets, the secret value must then be a JSON object.
+	Field string
+}
+
+// IsSecretReference returns true if the given executor secret value is a reference
+// to a secret in an external secret store rather than the secret value itself.
+func IsSecretReference(value string) bool {
+	for _, provider := range secretProviders {
+		if strings.HasPrefix(value, string(provider)+"://") {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNamespacedSecretReference is returned when an executor secret that is owned
+// by a user or an organization references a secret in an external secret store.
+// Executors fetch referenced secrets with their own credentials, so only global
+// secrets, which can only be managed by site admins, may reference them.
+var ErrNamespacedSecretReference = errors.New("only global executor secrets can reference an external secret store")
+
+// ParseSecretReference parses a reference to a secret in an external secret store.
+func ParseSecretReference(value string) (SecretReference, error) {
+	provider, rest, ok := strings.Cut(value, "://")
+	if !ok || !IsSecretReference(value) {
+		return SecretReference{}, errors.New("not a secret reference")
+	}
+
+	ref := SecretReference{Provider: SecretProvider(provider), Name: rest}
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		ref.Name, ref.Field = rest[:i], rest[i+1:]
+	}
+	if ref.Name == "" {
+		return SecretReference{}, errors.Newf("%s secret reference is missing the secret name", provider)
+	}
+	if ref.Provider == SecretProviderVault && ref.Field == "" {
+		return SecretReference{}, errors.New("vault secret reference is missing the field")
+	}
+	return ref, nil
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretReference(t *testing.T) {
+	tests := []struct {
+		value         string
+		expected      SecretReference
+		expectedError string
+	}{
+		{
+			value:    "vault://secret/data/ci#token",
+			expected: SecretReference{Provider: SecretProviderVault, Name: "secret/data/ci", Field: "token"},
+		},
+		{
+			value:    "awssm://arn:aws:secretsmanager:us-east-1:123456789012:secret:ci-AbCdEf",
+			expected: SecretReference{Provider: SecretProviderAWSSecretsManager, Name: "arn:aws:secretsmanager:us-east-1:123456789012:secret:ci-AbCdEf"},
+		},
+		{
+			value:    "gcpsm://projects/p/secrets/s/versions/3#token",
+			expected: SecretReference{Provider: SecretProviderGCPSecretManager, Name: "projects/p/secrets/s/versions/3", Field: "token"},
+		},
+		{
+			value:         "vault://secret/data/ci",
+			expectedError: "vault secret reference is missing the field",
+		},
+		{
+			value:         "awssm://#token",
+			expectedError: "awssm secret reference is missing the secret name",
+		},
+		{
+			value:         "hunter2",
+			expectedError: "not a secret reference",
+		},
+		{
+			value:         "https://example.com",
+			expectedError: "not a secret reference",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			assert.Equal(t, test.expectedError != "not a secret reference", IsSecretReference(test.value))
+
+			ref, err := ParseSecretReference(test.value)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ref)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/goware/urlx"
 	"github.com/sourcegraph/log"
@@ -20,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/oauthutil"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 const (
@@ -47,6 +49,10 @@ type Client interface {
 	ForkRepository(ctx context.Context, org string, input ForkRepositoryInput) (Repository, error)
 	GetRepositoryBranch(ctx context.Context, args OrgProjectRepoArgs, branchName string) (Ref, error)
 	GetProject(ctx context.Context, org, project string) (Project, error)
+	ListProjects(ctx context.Context, org string) ([]Project, error)
+	ListTeams(ctx context.Context, org, project string) ([]Team, error)
+	ListTeamMembers(ctx context.Context, org, project, team string) ([]TeamMember, error)
+	ListIdentities(ctx context.Context, org string, args ListIdentitiesArgs) ([]Identity, error)
 	GetAuthorizedProfile(ctx context.Context) (Profile, error)
 	ListAuthorizedUserOrganizations(ctx context.Context, profile Profile) ([]Org, error)
 	SetWaitForRateLimit(wait bool)
@@ -55,6 +61,9 @@ type Client interface {
 type client struct {
 	// HTTP Client used to communicate with the API.
 	httpClient httpcli.Doer
+	// baseHTTPClient is the HTTP client passed to NewClient, before any
+	// authentication specific wrapping.
+	baseHTTPClient httpcli.Doer
 
 	// URL is the base URL of AzureDevOps.
 	URL *url.URL
@@ -76,13 +85,31 @@ func NewClient(urn string, url string, auth auth.Authenticator, httpClient httpc
 	if err != nil {
 		return nil, err
 	}
+	// Azure DevOps Server is commonly hosted under a path, e.g.
+	// https://ado.example.com/tfs/. Request paths are resolved relative to the
+	// base URL, so it must end with a slash to keep that path.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
 
 	if httpClient == nil {
 		httpClient = httpcli.ExternalDoer
 	}
 
+	// NTLM authenticates connections rather than requests, so every NTLM
+	// client uses its own HTTP client that performs the handshake.
+	doer := httpClient
+	if _, ok := auth.(*NTLMAuth); ok {
+		base, _ := httpClient.(*http.Client)
+		doer, err = newNTLMDoer(base)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &client{
-		httpClient:          httpClient,
+		httpClient:          doer,
+		baseHTTPClient:      httpClient,
 		URL:                 u,
 		internalRateLimiter: ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("AzureDevOpsClient"), urn)),
 		externalRateLimiter: ratelimit.DefaultMonitorRegistry.GetOrSet(url, auth.Hash(), "rest", &ratelimit.Monitor{HeaderPrefix: "X-"}),
@@ -146,10 +173,15 @@ func (c *client) do(ctx context.Context, req *http.Request, urlOverride string,
 		numRetries < c.maxRateLimitRetries {
 		// We always retry since we got a StatusTooManyRequests. This is safe
 		// since we bound retries by maxRateLimitRetries.
+		c.externalRateLimiter.RecordThrottle()
 		_ = c.externalRateLimiter.WaitForRateLimit(ctx, 1)
 
 		req.Body = io.NopCloser(bytes.NewReader(reqBody))
 		resp, err = oauthutil.DoRequest(ctx, logger, c.httpClient, req, c.auth)
+		if err != nil {
+			return "", err
+		}
+		c.externalRateLimiter.Update(resp.Header)
 		numRetries++
 	}
 
@@ -176,17 +208,17 @@ func (c *client) do(ctx context.Context, req *http.Request, urlOverride string,
 // the given authenticator instance.
 //
 // Note that using an unsupported Authenticator implementation may result in
-// unexpected behaviour, or (more likely) errors. At present, only BasicAuth and
-// BasicAuthWithSSH are supported.
+// unexpected behaviour, or (more likely) errors. At present, only BasicAuth,
+// BasicAuthWithSSH and NTLMAuth are supported.
 func (c *client) WithAuthenticator(a auth.Authenticator) (Client, error) {
 	switch a.(type) {
-	case *auth.BasicAuth, *auth.BasicAuthWithSSH:
+	case *auth.BasicAuth, *auth.BasicAuthWithSSH, *NTLMAuth:
 		break
 	default:
 		return nil, errors.Errorf("authenticator type unsupported for Azure DevOps clients: %s", a)
 	}
 
-	return NewClient(c.urn, c.URL.String(), a, c.httpClient)
+	return NewClient(c.urn, c.URL.String(), a, c.baseHTTPClient)
 }
 
 func (c *client) SetWaitForRateLimit(wait bool) {
@@ -207,6 +239,30 @@ func (c *client) IsAzureDevOpsServices() bool {
 	return c.URL.String() == AzureDevOpsAPIURL
 }
 
+// IsAzureDevOpsServicesURL returns true if the given code host connection URL
+// points to Azure DevOps Services (https://dev.azure.com), and false if it
+// points to an Azure DevOps Server instance.
+func IsAzureDevOpsServicesURL(rawURL string) bool {
+	u, err := urlx.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, "dev.azure.com")
+}
+
+// AuthTypeNTLM is the value of the authType code host connection setting to
+// authenticate with NTLM instead of a personal access token.
+const AuthTypeNTLM = "ntlm"
+
+// AuthenticatorFromConfig returns the authenticator to use for the given code
+// host connection.
+func AuthenticatorFromConfig(c *schema.AzureDevOpsConnection) auth.Authenticator {
+	if c.AuthType == AuthTypeNTLM {
+		return &NTLMAuth{Username: c.Username, Password: c.Token}
+	}
+	return &auth.BasicAuth{Username: c.Username, Password: c.Token}
+}
+
 func GetOAuthContext(refreshToken string) (*oauthutil.OAuthContext, error) {
 	for _, authProvider := range conf.SiteConfig().AuthProviders {
 		if authProvider.AzureDevOps != nil {
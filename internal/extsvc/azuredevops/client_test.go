@@ -171,3 +171,25 @@ func ignoreHostMatcher(r *http.Request, i cassette.Request) bool {
 	u.Scheme = r.URL.Scheme
 	return r.URL.String() == u.String()
 }
+
+func TestNewClient_URL(t *testing.T) {
+	for _, tc := range []struct {
+		url                   string
+		wantURL               string
+		isAzureDevOpsServices bool
+	}{
+		{url: "https://dev.azure.com", wantURL: "https://dev.azure.com/", isAzureDevOpsServices: true},
+		{url: "https://dev.azure.com/", wantURL: "https://dev.azure.com/", isAzureDevOpsServices: true},
+		{url: "https://ado.example.com/tfs", wantURL: "https://ado.example.com/tfs/", isAzureDevOpsServices: false},
+		{url: "https://ado.example.com/tfs/", wantURL: "https://ado.example.com/tfs/", isAzureDevOpsServices: false},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			c, err := NewClient("test", tc.url, &auth.BasicAuth{Username: "test", Password: "test"}, httpcli.TestExternalDoer)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantURL, c.GetURL().String())
+			assert.Equal(t, tc.isAzureDevOpsServices, c.IsAzureDevOpsServices())
+			assert.Equal(t, tc.isAzureDevOpsServices, IsAzureDevOpsServicesURL(tc.url))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ListIdentities searches the identities known to the given organization, or
+// collection for Azure DevOps Server.
+//
+// See https://learn.microsoft.com/en-us/rest/api/azure/devops/ims/identities/read-identities
+func (c *client) ListIdentities(ctx context.Context, org string, args ListIdentitiesArgs) ([]Identity, error) {
+	queryParams := make(url.Values)
+	queryParams.Set("searchFilter", string(args.SearchFilter))
+	queryParams.Set("filterValue", args.FilterValue)
+	queryParams.Set("queryMembership", "None")
+	reqURL := url.URL{Path: fmt.Sprintf("%s/_apis/identities", org), RawQuery: queryParams.Encode()}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListIdentitiesResponse
+	if _, err = c.do(ctx, req, "", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
@@ -0,0 +1,89 @@
+package azuredevops
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/Azure/go-ntlmssp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// NTLMAuth authenticates requests to Azure DevOps Server instances that use
+// Windows authentication, using the NTLM protocol.
+//
+// Unlike other authenticators, NTLM authenticates connections rather than
+// requests and needs a challenge/response round trip with the server.
+// Authenticate therefore only attaches the credentials to the request as basic
+// auth, and the NTLM transport of the Azure DevOps client turns them into a
+// NTLM handshake when the server asks for one.
+type NTLMAuth struct {
+	// Username is either a plain username, or a username qualified with a
+	// domain in the form DOMAIN\username.
+	Username string
+	Password string
+}
+
+var _ auth.Authenticator = &NTLMAuth{}
+
+func (a *NTLMAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+func (a *NTLMAuth) Hash() string {
+	shaSum := sha256.Sum256([]byte("ntlm:" + a.Username + ":" + a.Password))
+	return hex.EncodeToString(shaSum[:])
+}
+
+// ntlmClientFactory creates the HTTP clients of NTLM authenticated clients
+// that aren't given a client to start from. It doesn't cache responses, since
+// the cache isn't keyed by the credentials of the connection the response was
+// received on.
+var ntlmClientFactory = httpcli.UncachedExternalClientFactory
+
+// newNTLMDoer returns a Doer that performs the NTLM handshake for requests that
+// the server rejects with a NTLM challenge.
+//
+// NTLM authenticates the connection the handshake happened on, so the Doer
+// uses a dedicated transport whose connections are never shared with clients
+// using other credentials. Requests are first sent without credentials, and
+// only if the connection isn't authenticated yet does the server answer with a
+// 401 and does the handshake happen.
+func newNTLMDoer(base *http.Client) (httpcli.Doer, error) {
+	cli, err := ntlmClient(base)
+	if err != nil {
+		return nil, err
+	}
+	cli.Transport = ntlmssp.Negotiator{RoundTripper: cli.Transport}
+
+	return httpcli.NewMiddleware(
+		httpcli.ContextErrorMiddleware,
+		httpcli.HeadersMiddleware("User-Agent", "Sourcegraph-Bot"),
+	)(cli), nil
+}
+
+// ntlmClient returns a copy of the given client with its own connection pool.
+// The given client is usually shared and is left untouched. If it is nil or
+// its transport can't be cloned, e.g. because it caches responses, a new
+// client of ntlmClientFactory is returned instead.
+func ntlmClient(base *http.Client) (*http.Client, error) {
+	if base == nil {
+		return ntlmClientFactory.Client()
+	}
+
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	tr, ok := transport.(*http.Transport)
+	if !ok {
+		return ntlmClientFactory.Client()
+	}
+
+	cli := *base
+	cli.Transport = tr.Clone()
+	return &cli, nil
+}
@@ -0,0 +1,160 @@
+package azuredevops
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func TestNTLMDoer(t *testing.T) {
+	// Allow requests to the local test server.
+	ntlmClientFactory = httpcli.NewFactory(nil)
+	t.Cleanup(func() { ntlmClientFactory = httpcli.UncachedExternalClientFactory })
+
+	var (
+		mu            sync.Mutex
+		authenticated = map[string]bool{}
+		handshakes    int
+		authenticate  []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// NTLM authenticates connections, so requests on a connection that
+		// completed the handshake are answered right away.
+		if authenticated[r.RemoteAddr] {
+			assert.Empty(t, r.Header.Get("Authorization"), "credentials sent on an authenticated connection")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "NTLM ")
+		if !ok {
+			assert.Empty(t, r.Header.Get("Authorization"), "unexpected authorization scheme")
+			w.Header().Set("WWW-Authenticate", "NTLM")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		msg, err := base64.StdEncoding.DecodeString(token)
+		require.NoError(t, err)
+
+		switch binary.LittleEndian.Uint32(msg[8:]) {
+		case 1:
+			handshakes++
+			w.Header().Set("WWW-Authenticate", "NTLM "+base64.StdEncoding.EncodeToString(testNTLMChallenge("CORP")))
+			w.WriteHeader(http.StatusUnauthorized)
+		case 3:
+			authenticate = msg
+			authenticated[r.RemoteAddr] = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	doer, err := newNTLMDoer(nil)
+	require.NoError(t, err)
+	a := &NTLMAuth{Username: `CORP\jdoe`, Password: "secret"}
+
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest("POST", srv.URL, strings.NewReader("body"))
+		require.NoError(t, err)
+		require.NoError(t, a.Authenticate(req))
+
+		resp, err := doer.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Only the first request needed a handshake, the others reused the
+	// authenticated connection.
+	assert.Equal(t, 1, handshakes)
+
+	require.NotNil(t, authenticate)
+	assert.Equal(t, "CORP", testNTLMField(t, authenticate, 28))
+	assert.Equal(t, "jdoe", testNTLMField(t, authenticate, 36))
+}
+
+// testNTLMChallenge returns a NTLM challenge message, as specified in MS-NLMP
+// section 2.2.1.2, for the given domain.
+func testNTLMChallenge(domain string) []byte {
+	const (
+		negotiateUnicode    = 0x00000001
+		requestTarget       = 0x00000004
+		negotiateNTLM       = 0x00000200
+		targetTypeDomain    = 0x00010000
+		negotiateTargetInfo = 0x00800000
+	)
+
+	targetName := testUTF16(domain)
+	// A target info with only the terminating MsvAvEOL pair.
+	targetInfo := make([]byte, 4)
+
+	msg := make([]byte, 48)
+	copy(msg, "NTLMSSP\x00")
+	binary.LittleEndian.PutUint32(msg[8:], 2)
+	binary.LittleEndian.PutUint16(msg[12:], uint16(len(targetName)))
+	binary.LittleEndian.PutUint16(msg[14:], uint16(len(targetName)))
+	binary.LittleEndian.PutUint32(msg[16:], 48)
+	binary.LittleEndian.PutUint32(msg[20:], negotiateUnicode|requestTarget|negotiateNTLM|targetTypeDomain|negotiateTargetInfo)
+	copy(msg[24:32], "\x01\x23\x45\x67\x89\xab\xcd\xef")
+	binary.LittleEndian.PutUint16(msg[40:], uint16(len(targetInfo)))
+	binary.LittleEndian.PutUint16(msg[42:], uint16(len(targetInfo)))
+	binary.LittleEndian.PutUint32(msg[44:], uint32(48+len(targetName)))
+	msg = append(msg, targetName...)
+	return append(msg, targetInfo...)
+}
+
+// testNTLMField returns the UTF-16 string field of the NTLM message whose
+// descriptor starts at the given offset.
+func testNTLMField(t *testing.T, msg []byte, offset int) string {
+	t.Helper()
+
+	length := int(binary.LittleEndian.Uint16(msg[offset:]))
+	start := int(binary.LittleEndian.Uint32(msg[offset+4:]))
+	require.LessOrEqual(t, start+length, len(msg))
+
+	field := msg[start : start+length]
+	encoded := make([]uint16, len(field)/2)
+	for i := range encoded {
+		encoded[i] = binary.LittleEndian.Uint16(field[2*i:])
+	}
+	return string(utf16.Decode(encoded))
+}
+
+func testUTF16(s string) []byte {
+	encoded := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(encoded))
+	for i, r := range encoded {
+		binary.LittleEndian.PutUint16(b[2*i:], r)
+	}
+	return b
+}
+
+func TestNTLMClient(t *testing.T) {
+	transport := &http.Transport{}
+	base := &http.Client{Transport: transport}
+
+	cli, err := ntlmClient(base)
+	require.NoError(t, err)
+
+	// The shared client is left untouched, and the NTLM client has its own
+	// connection pool.
+	assert.Same(t, transport, base.Transport)
+	assert.NotSame(t, base, cli)
+	assert.NotSame(t, transport, cli.Transport)
+	assert.IsType(t, &http.Transport{}, cli.Transport)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 func (c *client) GetProject(ctx context.Context, org, project string) (Project, error) {
@@ -19,3 +20,89 @@ func (c *client) GetProject(ctx context.Context, org, project string) (Project,
 	_, err = c.do(ctx, req, "", &p)
 	return p, err
 }
+
+// ListProjects returns all projects of the given organization, or collection
+// for Azure DevOps Server.
+func (c *client) ListProjects(ctx context.Context, org string) ([]Project, error) {
+	var projects []Project
+	continuationToken := ""
+	queryParams := make(url.Values)
+	reqURL := url.URL{Path: fmt.Sprintf("%s/_apis/projects", org)}
+	for {
+		if continuationToken != "" {
+			queryParams.Set("continuationToken", continuationToken)
+		}
+		reqURL.RawQuery = queryParams.Encode()
+		req, err := http.NewRequest("GET", reqURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp ListProjectsResponse
+		continuationToken, err = c.do(ctx, req, "", &resp)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, resp.Value...)
+
+		if continuationToken == "" {
+			return projects, nil
+		}
+	}
+}
+
+// teamsPageSize is the maximum page size of the teams and team members APIs.
+const teamsPageSize = 100
+
+// ListTeams returns all teams of the given project.
+func (c *client) ListTeams(ctx context.Context, org, project string) ([]Team, error) {
+	var teams []Team
+	reqURL := url.URL{Path: fmt.Sprintf("%s/_apis/projects/%s/teams", org, project)}
+	for skip := 0; ; skip += teamsPageSize {
+		reqURL.RawQuery = pageQuery(skip).Encode()
+		req, err := http.NewRequest("GET", reqURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp ListTeamsResponse
+		if _, err = c.do(ctx, req, "", &resp); err != nil {
+			return nil, err
+		}
+		teams = append(teams, resp.Value...)
+
+		if len(resp.Value) < teamsPageSize {
+			return teams, nil
+		}
+	}
+}
+
+// ListTeamMembers returns all members of the given team.
+func (c *client) ListTeamMembers(ctx context.Context, org, project, team string) ([]TeamMember, error) {
+	var members []TeamMember
+	reqURL := url.URL{Path: fmt.Sprintf("%s/_apis/projects/%s/teams/%s/members", org, project, team)}
+	for skip := 0; ; skip += teamsPageSize {
+		reqURL.RawQuery = pageQuery(skip).Encode()
+		req, err := http.NewRequest("GET", reqURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp ListTeamMembersResponse
+		if _, err = c.do(ctx, req, "", &resp); err != nil {
+			return nil, err
+		}
+		members = append(members, resp.Value...)
+
+		if len(resp.Value) < teamsPageSize {
+			return members, nil
+		}
+	}
+}
+
+func pageQuery(skip int) url.Values {
+	q := make(url.Values)
+	q.Set("$top", strconv.Itoa(teamsPageSize))
+	q.Set("$skip", strconv.Itoa(skip))
+	return q
+}
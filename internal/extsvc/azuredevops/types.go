@@ -244,6 +244,74 @@ type Project struct {
 	URL        string `json:"url"`
 }
 
+type ListProjectsResponse struct {
+	Value []Project `json:"value"`
+	Count int       `json:"count"`
+}
+
+type Team struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ProjectID   string `json:"projectId"`
+	ProjectName string `json:"projectName"`
+}
+
+type ListTeamsResponse struct {
+	Value []Team `json:"value"`
+	Count int    `json:"count"`
+}
+
+type TeamMember struct {
+	Identity    IdentityRef `json:"identity"`
+	IsTeamAdmin bool        `json:"isTeamAdmin"`
+}
+
+type ListTeamMembersResponse struct {
+	Value []TeamMember `json:"value"`
+	Count int          `json:"count"`
+}
+
+// IdentityRef is a reference to an identity, as embedded in other objects.
+type IdentityRef struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	// UniqueName is the account name of the identity, e.g. DOMAIN\username on
+	// Azure DevOps Server.
+	UniqueName string `json:"uniqueName"`
+}
+
+type Identity struct {
+	ID                  string `json:"id"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	IsActive            bool   `json:"isActive"`
+	Properties          struct {
+		Account IdentityProperty `json:"Account"`
+		Mail    IdentityProperty `json:"Mail"`
+	} `json:"properties"`
+}
+
+type IdentityProperty struct {
+	Value string `json:"$value"`
+}
+
+type ListIdentitiesResponse struct {
+	Value []Identity `json:"value"`
+	Count int        `json:"count"`
+}
+
+// IdentitySearchFilter is the attribute identities are searched by.
+type IdentitySearchFilter string
+
+const (
+	IdentitySearchFilterAccountName IdentitySearchFilter = "AccountName"
+	IdentitySearchFilterMailAddress IdentitySearchFilter = "MailAddress"
+)
+
+type ListIdentitiesArgs struct {
+	SearchFilter IdentitySearchFilter
+	FilterValue  string
+}
+
 func (p Repository) GetOrganization() (string, error) {
 	u, err := url.Parse(p.APIURL)
 	if err != nil {
@@ -33,8 +33,16 @@ func ParseWebhookEvent(eventKey string, payload []byte) (any, error) {
 		target = &RepoCommitStatusCreatedEvent{}
 	case "repo:commit_status_updated":
 		target = &RepoCommitStatusUpdatedEvent{}
+	case "repo:created":
+		target = &RepoCreatedEvent{}
+	case "repo:deleted":
+		target = &RepoDeletedEvent{}
 	case "repo:push":
 		target = &PushEvent{}
+	case "repo:transfer":
+		target = &RepoTransferEvent{}
+	case "repo:updated":
+		target = &RepoUpdatedEvent{}
 	default:
 		return nil, UnknownWebhookEventKey(eventKey)
 	}
@@ -125,6 +133,42 @@ type RepoEvent struct {
 	Repository Repo `json:"repository"`
 }
 
+type RepoCreatedEvent struct {
+	RepoEvent
+}
+
+type RepoDeletedEvent struct {
+	RepoEvent
+}
+
+type RepoTransferEvent struct {
+	RepoEvent
+	PreviousOwner Account `json:"previous_owner"`
+}
+
+type RepoUpdatedEvent struct {
+	RepoEvent
+	Changes RepoChanges `json:"changes"`
+}
+
+// RepoChanges contains the repository attributes changed by a repo:updated
+// event. Attributes that weren't changed are nil.
+type RepoChanges struct {
+	Name      *StringChange `json:"name"`
+	FullName  *StringChange `json:"full_name"`
+	IsPrivate *BoolChange   `json:"is_private"`
+}
+
+type StringChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type BoolChange struct {
+	Old bool `json:"old"`
+	New bool `json:"new"`
+}
+
 type RepoCommitStatusEvent struct {
 	RepoEvent
 	CommitStatus CommitStatus `json:"commit_status"`
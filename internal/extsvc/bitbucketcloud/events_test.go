@@ -59,6 +59,26 @@ func TestParseWebhookEvent(t *testing.T) {
 			payload:  `{"commit_status":{},"pullrequest":{},"repository":{}}`,
 			wantType: &RepoCommitStatusUpdatedEvent{},
 		},
+		"repo:created": {
+			payload:  `{"actor":{},"repository":{}}`,
+			wantType: &RepoCreatedEvent{},
+		},
+		"repo:deleted": {
+			payload:  `{"actor":{},"repository":{}}`,
+			wantType: &RepoDeletedEvent{},
+		},
+		"repo:push": {
+			payload:  `{"actor":{},"repository":{}}`,
+			wantType: &PushEvent{},
+		},
+		"repo:transfer": {
+			payload:  `{"actor":{},"previous_owner":{},"repository":{}}`,
+			wantType: &RepoTransferEvent{},
+		},
+		"repo:updated": {
+			payload:  `{"actor":{},"changes":{"is_private":{"old":false,"new":true}},"repository":{}}`,
+			wantType: &RepoUpdatedEvent{},
+		},
 	} {
 		t.Run(key, func(t *testing.T) {
 			t.Run("success", func(t *testing.T) {
@@ -267,7 +267,12 @@ func (c *V3Client) request(ctx context.Context, req *http.Request, result any) (
 	// 2. The error returned is not a rate limit error
 	// 3. We succeed
 	for c.waitForRateLimit && err != nil && numRetries < c.maxRateLimitRetries &&
-		errors.As(err, &apiError) && apiError.Code == http.StatusForbidden {
+		errors.As(err, &apiError) && (apiError.Code == http.StatusForbidden || apiError.Code == http.StatusTooManyRequests) {
+		if apiError.Code == http.StatusTooManyRequests {
+			// Unlike a 403, a 429 is unambiguously a rate limit.
+			c.externalRateLimiter.RecordThrottle()
+		}
+
 		// Because GitHub responds with http.StatusForbidden when a rate limit is hit, we cannot
 		// say with absolute certainty that a rate limit was hit. It might have been an honest
 		// http.StatusForbidden. So we use the externalRateLimiter's WaitForRateLimit function
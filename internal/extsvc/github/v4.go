@@ -173,7 +173,12 @@ func (c *V4Client) requestGraphQL(ctx context.Context, query string, vars map[st
 	numRetries := 0
 
 	for c.waitForRateLimit && err != nil && numRetries < c.maxRateLimitRetries &&
-		errors.As(err, &apiError) && apiError.Code == http.StatusForbidden {
+		errors.As(err, &apiError) && (apiError.Code == http.StatusForbidden || apiError.Code == http.StatusTooManyRequests) {
+		if apiError.Code == http.StatusTooManyRequests {
+			// Unlike a 403, a 429 is unambiguously a rate limit.
+			c.externalRateLimiter.RecordThrottle()
+		}
+
 		// Reset Body/URL to the originals, to ignore changes a previous
 		// `doRequest` might have made.
 		req.Body = io.NopCloser(bytes.NewBuffer(reqBody))
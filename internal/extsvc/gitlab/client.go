@@ -236,7 +236,10 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (respons
 	numRetries := 0
 	for c.waitForRateLimit && numRetries < c.maxRateLimitRetries && respCode == http.StatusTooManyRequests {
 		// We always retry since we got a StatusTooManyRequests. This is safe
-		// since we bound retries by maxRateLimitRetries.
+		// since we bound retries by maxRateLimitRetries. GitLab doesn't
+		// always tell us how long to wait, in which case the monitor backs
+		// off adaptively.
+		c.externalRateLimiter.RecordThrottle()
 		_ = c.externalRateLimiter.WaitForRateLimit(ctx, 1)
 
 		req.Body = io.NopCloser(bytes.NewReader(reqBody))
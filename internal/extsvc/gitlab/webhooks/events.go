@@ -40,8 +40,26 @@ type downcaster interface {
 	downcast() (any, error)
 }
 
+// EventKind returns the kind of the given webhook payload. This is the
+// object_kind of the payload, or the event_name for system hook events that
+// don't have an object_kind.
+func EventKind(data []byte) (string, error) {
+	var event struct {
+		ObjectKind string `json:"object_kind"`
+		EventName  string `json:"event_name"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return "", errors.Wrap(err, "determining object kind")
+	}
+	if event.ObjectKind != "" {
+		return event.ObjectKind, nil
+	}
+	return event.EventName, nil
+}
+
 // UnmarshalEvent unmarshals the given JSON into an event type. Possible return
-// types are *MergeRequestEvent.
+// types are *MergeRequestEvent, *PipelineEvent, *PushEvent,
+// *ProjectSystemHookEvent and *RepositoryUpdateEvent.
 //
 // Errors caused by a valid payload being of an unknown type may be
 // distinguished from other errors by checking for ErrObjectKindUnknown in the
@@ -54,25 +72,28 @@ func UnmarshalEvent(data []byte) (any, error) {
 	// Since we only care about the object_kind field, we'll start by
 	// unmarshalling into a minimal type that only has that field. We use
 	// object_kind instead of event_type because not all GitLab webhook types
-	// include event_type, whereas object_kind is generally reliable.
-	var event struct {
-		ObjectKind string `json:"object_kind"`
-	}
-	if err := json.Unmarshal(data, &event); err != nil {
-		return nil, errors.Wrap(err, "determining object kind")
+	// include event_type, whereas object_kind is generally reliable. System
+	// hooks are the exception, see EventKind.
+	kind, err := EventKind(data)
+	if err != nil {
+		return nil, err
 	}
 
 	// Now we can set up the typed event that we'll unmarshal into.
 	var typedEvent any
-	switch event.ObjectKind {
+	switch kind {
 	case "merge_request":
 		typedEvent = &mergeRequestEvent{}
 	case "pipeline":
 		typedEvent = &PipelineEvent{}
 	case "push":
 		typedEvent = &PushEvent{}
+	case ProjectCreateEventName, ProjectDestroyEventName, ProjectRenameEventName, ProjectTransferEventName, ProjectUpdateEventName:
+		typedEvent = &ProjectSystemHookEvent{}
+	case RepositoryUpdateEventName:
+		typedEvent = &RepositoryUpdateEvent{}
 	default:
-		return nil, errors.Wrapf(ErrObjectKindUnknown, "kind: %s", event.ObjectKind)
+		return nil, errors.Wrapf(ErrObjectKindUnknown, "kind: %s", kind)
 	}
 
 	// Let's perform the real unmarshal.
@@ -109,4 +109,64 @@ func TestUnmarshalEvent(t *testing.T) {
 			t.Errorf("unexpected IID: have %d; want %d", pe.Pipeline.ID, want)
 		}
 	})
+	t.Run("valid project system hook", func(t *testing.T) {
+		event, err := UnmarshalEvent([]byte(`
+			{
+				"event_name": "project_rename",
+				"project_id": 74,
+				"path_with_namespace": "jsmith/renamed",
+				"old_path_with_namespace": "jsmith/storecloud"
+			}
+		`))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		pe := event.(*ProjectSystemHookEvent)
+		if want := ProjectRenameEventName; pe.EventName != want {
+			t.Errorf("unexpected event_name: have %s; want %s", pe.EventName, want)
+		}
+		if want := "jsmith/storecloud"; pe.OldPathWithNamespace != want {
+			t.Errorf("unexpected old_path_with_namespace: have %s; want %s", pe.OldPathWithNamespace, want)
+		}
+	})
+
+	t.Run("valid repository update system hook", func(t *testing.T) {
+		event, err := UnmarshalEvent([]byte(`
+			{
+				"event_name": "repository_update",
+				"project_id": 1,
+				"project": {
+					"path_with_namespace": "jsmith/example",
+					"git_ssh_url": "git@example.com:jsmith/example.git"
+				},
+				"refs": ["refs/heads/master"]
+			}
+		`))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		re := event.(*RepositoryUpdateEvent)
+		if want := "git@example.com:jsmith/example.git"; re.Project.GitSSHURL != want {
+			t.Errorf("unexpected git_ssh_url: have %s; want %s", re.Project.GitSSHURL, want)
+		}
+	})
+}
+
+func TestEventKind(t *testing.T) {
+	for payload, want := range map[string]string{
+		`{"object_kind":"push","event_name":"push"}`: "push",
+		`{"object_kind":"merge_request"}`:            "merge_request",
+		`{"event_name":"project_create"}`:            "project_create",
+		`{}`:                                         "",
+	} {
+		have, err := EventKind([]byte(payload))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if have != want {
+			t.Errorf("unexpected kind for %s: have %q; want %q", payload, have, want)
+		}
+	}
 }
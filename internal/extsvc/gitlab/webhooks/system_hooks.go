@@ -0,0 +1,45 @@
+package webhooks
+
+// System hooks are configured instance-wide by a GitLab administrator and fire
+// for events across all projects. Unlike project webhooks, most system hook
+// payloads have no object_kind and are identified by their event_name instead.
+// https://docs.gitlab.com/ee/administration/system_hooks.html
+
+// Event names of the project lifecycle system hook events.
+const (
+	ProjectCreateEventName   = "project_create"
+	ProjectDestroyEventName  = "project_destroy"
+	ProjectRenameEventName   = "project_rename"
+	ProjectTransferEventName = "project_transfer"
+	ProjectUpdateEventName   = "project_update"
+
+	RepositoryUpdateEventName = "repository_update"
+)
+
+// ProjectSystemHookEvent represents a project being created, destroyed,
+// renamed, transferred to another namespace, or updated.
+type ProjectSystemHookEvent struct {
+	EventName         string `json:"event_name"`
+	ProjectID         int    `json:"project_id"`
+	Name              string `json:"name"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	ProjectVisibility string `json:"project_visibility"`
+
+	// OldPathWithNamespace is only set for project_rename and
+	// project_transfer events.
+	OldPathWithNamespace string `json:"old_path_with_namespace,omitempty"`
+}
+
+// RepositoryUpdateEvent is sent by system hooks when refs of a repository
+// change. It is the system hook equivalent of a push event.
+type RepositoryUpdateEvent struct {
+	EventName string `json:"event_name"`
+	ProjectID int    `json:"project_id"`
+	Project   struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		GitSSHURL         string `json:"git_ssh_url"`
+		GitHTTPURL        string `json:"git_http_url"`
+	} `json:"project"`
+	Refs []string `json:"refs"`
+}
@@ -1656,7 +1656,8 @@ func (c *clientImplementor) Stat(ctx context.Context, repo api.RepoName, commit
 
 // CommitsOptions specifies options for Commits.
 type CommitsOptions struct {
-	Range string // commit range (revspec, "A..B", "A...B", etc.)
+	Range  string   // commit range (revspec, "A..B", "A...B", etc.)
+	Ranges []string // additional commit ranges whose history is walked together with Range (optional)
 
 	N    uint // limit the number of returned commits to this many (0 means no limit)
 	Skip uint // skip this many commits at the beginning
@@ -2116,6 +2117,11 @@ func commitLogArgs(initialArgs []string, opt CommitsOptions) (args []string, err
 	if err := checkSpecArgSafety(opt.Range); err != nil {
 		return nil, err
 	}
+	for _, r := range opt.Ranges {
+		if err := checkSpecArgSafety(r); err != nil {
+			return nil, err
+		}
+	}
 
 	args = initialArgs
 	if opt.N != 0 {
@@ -2149,6 +2155,7 @@ func commitLogArgs(initialArgs []string, opt CommitsOptions) (args []string, err
 	if opt.Range != "" {
 		args = append(args, opt.Range)
 	}
+	args = append(args, opt.Ranges...)
 	if opt.NameOnly {
 		args = append(args, "--name-only")
 	}
@@ -1839,6 +1839,14 @@ func TestRepository_Commits_options(t *testing.T) {
 			wantCommits: wantGitCommits2,
 			wantTotal:   1,
 		},
+		"ranges": {
+			opt: CommitsOptions{
+				Range:  "b266c7e3ca00b1a17ad0b1449825d0854225c007",
+				Ranges: []string{"^ea167fe3d76b1e5fd3ed8ca44cbd2fe3897684f8"},
+			},
+			wantCommits: wantGitCommits,
+			wantTotal:   1,
+		},
 		"before": {
 			opt: CommitsOptions{
 				Before: "2006-01-02T15:04:07Z",
@@ -0,0 +1,169 @@
+package httpcli
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// AdaptiveConcurrencyOptions configures NewAdaptiveConcurrencyMiddleware.
+type AdaptiveConcurrencyOptions struct {
+	// MinLimit is the lowest the concurrency limit can drop to. Defaults to 1.
+	MinLimit int
+	// MaxLimit is the highest the concurrency limit can grow to, and the initial
+	// limit. Defaults to 50.
+	MaxLimit int
+	// LatencyThreshold is the duration above which a request counts as slow.
+	// Defaults to 5 seconds.
+	LatencyThreshold time.Duration
+}
+
+const (
+	defaultAdaptiveConcurrencyMinLimit         = 1
+	defaultAdaptiveConcurrencyMaxLimit         = 50
+	defaultAdaptiveConcurrencyLatencyThreshold = 5 * time.Second
+)
+
+var (
+	metricAdaptiveConcurrencyLimit = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_httpcli_adaptive_concurrency_limit",
+		Help: "The current concurrency limit of HTTP clients with adaptive concurrency.",
+	}, []string{"name"})
+	metricAdaptiveConcurrencyInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_httpcli_adaptive_concurrency_in_flight",
+		Help: "The number of in-flight requests of HTTP clients with adaptive concurrency.",
+	}, []string{"name"})
+)
+
+// NewAdaptiveConcurrencyMiddleware returns a middleware that limits the number of
+// concurrent requests, and adapts the limit to the health of the server: the limit
+// is halved whenever a request fails with an error, a 429 or 5xx response, or takes
+// longer than LatencyThreshold, and grows back by one for every limit's worth of
+// successful requests. Requests over the limit wait for an in-flight request to
+// complete, or for their context to be canceled.
+//
+// Middleware created with the same name share their limit, like
+// NewCircuitBreakerMiddleware.
+func NewAdaptiveConcurrencyMiddleware(name string, opts AdaptiveConcurrencyOptions) Middleware {
+	l := getConcurrencyLimiter(name, opts)
+
+	return func(cli Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if err := l.acquire(req.Context()); err != nil {
+				return nil, err
+			}
+
+			start := l.now()
+			resp, err := cli.Do(req)
+			if err != nil && req.Context().Err() != nil {
+				l.release(nil)
+				return resp, err
+			}
+
+			healthy := err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 &&
+				l.now().Sub(start) <= l.opts.LatencyThreshold
+			l.release(&healthy)
+			return resp, err
+		})
+	}
+}
+
+var concurrencyLimiters = struct {
+	sync.Mutex
+	m map[string]*concurrencyLimiter
+}{m: map[string]*concurrencyLimiter{}}
+
+// getConcurrencyLimiter returns the limiter with the given name, creating it if it
+// does not exist or if its options changed.
+func getConcurrencyLimiter(name string, opts AdaptiveConcurrencyOptions) *concurrencyLimiter {
+	if opts.MinLimit <= 0 {
+		opts.MinLimit = defaultAdaptiveConcurrencyMinLimit
+	}
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = defaultAdaptiveConcurrencyMaxLimit
+	}
+	if opts.MaxLimit < opts.MinLimit {
+		opts.MaxLimit = opts.MinLimit
+	}
+	if opts.LatencyThreshold <= 0 {
+		opts.LatencyThreshold = defaultAdaptiveConcurrencyLatencyThreshold
+	}
+
+	concurrencyLimiters.Lock()
+	defer concurrencyLimiters.Unlock()
+
+	if l, ok := concurrencyLimiters.m[name]; ok && l.opts == opts {
+		return l
+	}
+
+	l := &concurrencyLimiter{
+		name:    name,
+		opts:    opts,
+		now:     time.Now,
+		limit:   float64(opts.MaxLimit),
+		changed: make(chan struct{}),
+	}
+	metricAdaptiveConcurrencyLimit.WithLabelValues(name).Set(l.limit)
+	concurrencyLimiters.m[name] = l
+	return l
+}
+
+type concurrencyLimiter struct {
+	name string
+	opts AdaptiveConcurrencyOptions
+	now  func() time.Time
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	// changed is closed and replaced whenever a request completes.
+	changed chan struct{}
+}
+
+// acquire blocks until a request may be sent, or the given context is canceled.
+func (l *concurrencyLimiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.inFlight < int(l.limit) {
+			l.inFlight++
+			metricAdaptiveConcurrencyInFlight.WithLabelValues(l.name).Set(float64(l.inFlight))
+			l.mu.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// release records the completion of a request allowed by acquire. The limit is
+// adjusted unless healthy is nil, i.e. the outcome of the request was inconclusive.
+func (l *concurrencyLimiter) release(healthy *bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	metricAdaptiveConcurrencyInFlight.WithLabelValues(l.name).Set(float64(l.inFlight))
+
+	if healthy != nil {
+		if *healthy {
+			l.limit += 1 / l.limit
+		} else {
+			l.limit /= 2
+		}
+		l.limit = max(float64(l.opts.MinLimit), min(float64(l.opts.MaxLimit), l.limit))
+		metricAdaptiveConcurrencyLimit.WithLabelValues(l.name).Set(l.limit)
+	}
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
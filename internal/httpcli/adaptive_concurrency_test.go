@@ -0,0 +1,63 @@
+package httpcli
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveConcurrencyMiddleware(t *testing.T) {
+	opts := AdaptiveConcurrencyOptions{MinLimit: 1, MaxLimit: 4, LatencyThreshold: time.Second}
+	l := getConcurrencyLimiter(t.Name(), opts)
+
+	status := http.StatusOK
+	cli := NewAdaptiveConcurrencyMiddleware(t.Name(), opts)(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: status}, nil
+	}))
+	do := func() {
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+		_, err := cli.Do(req)
+		require.NoError(t, err)
+	}
+
+	do()
+	require.Equal(t, 4.0, l.limit)
+
+	// Failures halve the limit down to the minimum.
+	status = http.StatusServiceUnavailable
+	do()
+	require.Equal(t, 2.0, l.limit)
+	do()
+	do()
+	require.Equal(t, 1.0, l.limit)
+
+	// Successes grow the limit back.
+	status = http.StatusOK
+	do()
+	require.Equal(t, 2.0, l.limit)
+	do()
+	require.Equal(t, 2.5, l.limit)
+	require.Equal(t, 0, l.inFlight)
+}
+
+func TestAdaptiveConcurrencyLimit(t *testing.T) {
+	l := getConcurrencyLimiter(t.Name(), AdaptiveConcurrencyOptions{MaxLimit: 1})
+	ctx := context.Background()
+
+	require.NoError(t, l.acquire(ctx))
+
+	// Requests over the limit wait for their context.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, l.acquire(timeoutCtx), context.DeadlineExceeded)
+
+	// Requests over the limit are let through once an in-flight request completes.
+	acquired := make(chan error)
+	go func() { acquired <- l.acquire(ctx) }()
+	l.release(nil)
+	require.NoError(t, <-acquired)
+	require.Equal(t, 1, l.inFlight)
+}
@@ -0,0 +1,202 @@
+package httpcli
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrCircuitOpen is returned by clients wrapped with NewCircuitBreakerMiddleware
+// while the circuit is open and requests are not sent.
+var ErrCircuitOpen = errors.New("httpcli: circuit breaker is open")
+
+// CircuitBreakerOptions configures NewCircuitBreakerMiddleware.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failed requests after which the
+	// circuit opens. Defaults to 5.
+	FailureThreshold int
+	// OpenDuration is how long the circuit stays open before a single trial request
+	// is let through. Defaults to 30 seconds.
+	OpenDuration time.Duration
+}
+
+const (
+	defaultCircuitBreakerFailureThreshold = 5
+	defaultCircuitBreakerOpenDuration     = 30 * time.Second
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitHalfOpen
+	circuitOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitHalfOpen:
+		return "half-open"
+	case circuitOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+var (
+	metricCircuitBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_httpcli_circuit_breaker_state",
+		Help: "The state of HTTP client circuit breakers: 0 if closed, 1 if half-open, 2 if open.",
+	}, []string{"name"})
+	metricCircuitBreakerTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_httpcli_circuit_breaker_transitions_total",
+		Help: "Total number of HTTP client circuit breaker state transitions, by the state transitioned to.",
+	}, []string{"name", "state"})
+	metricCircuitBreakerRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_httpcli_circuit_breaker_rejected_total",
+		Help: "Total number of HTTP requests not sent because the circuit breaker was open.",
+	}, []string{"name"})
+)
+
+// NewCircuitBreakerMiddleware returns a middleware that stops sending requests
+// after FailureThreshold consecutive requests failed with an error or a 5xx
+// response. While the circuit is open, requests fail immediately with
+// ErrCircuitOpen. After OpenDuration, a single trial request is sent: the circuit
+// closes again if it succeeds, and stays open for another OpenDuration otherwise.
+//
+// Middleware created with the same name share their state, so that clients
+// created for the same external service, e.g. on every sync, trip together.
+func NewCircuitBreakerMiddleware(name string, opts CircuitBreakerOptions) Middleware {
+	b := getCircuitBreaker(name, opts)
+
+	return func(cli Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if !b.allow() {
+				metricCircuitBreakerRejected.WithLabelValues(b.name).Inc()
+				return nil, errors.Wrap(ErrCircuitOpen, b.name)
+			}
+
+			resp, err := cli.Do(req)
+			if err != nil && req.Context().Err() != nil {
+				// The request was canceled by the caller, which says nothing about
+				// the health of the server.
+				b.abandon()
+				return resp, err
+			}
+
+			b.record(err == nil && resp.StatusCode < 500)
+			return resp, err
+		})
+	}
+}
+
+var circuitBreakers = struct {
+	sync.Mutex
+	m map[string]*circuitBreaker
+}{m: map[string]*circuitBreaker{}}
+
+// getCircuitBreaker returns the circuit breaker with the given name, creating it
+// if it does not exist or if its options changed.
+func getCircuitBreaker(name string, opts CircuitBreakerOptions) *circuitBreaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultCircuitBreakerFailureThreshold
+	}
+	if opts.OpenDuration <= 0 {
+		opts.OpenDuration = defaultCircuitBreakerOpenDuration
+	}
+
+	circuitBreakers.Lock()
+	defer circuitBreakers.Unlock()
+
+	if b, ok := circuitBreakers.m[name]; ok && b.opts == opts {
+		return b
+	}
+
+	b := &circuitBreaker{name: name, opts: opts, now: time.Now}
+	metricCircuitBreakerState.WithLabelValues(name).Set(float64(circuitClosed))
+	circuitBreakers.m[name] = b
+	return b
+}
+
+type circuitBreaker struct {
+	name string
+	opts CircuitBreakerOptions
+	now  func() time.Time
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	// trial is true while the trial request of the half-open state is in flight.
+	trial bool
+}
+
+// allow returns true if a request may be sent.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.opts.OpenDuration {
+			return false
+		}
+		b.transition(circuitHalfOpen)
+		b.trial = true
+		return true
+
+	case circuitHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+
+	default:
+		return true
+	}
+}
+
+// record records the outcome of a request allowed by allow.
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.failures = 0
+		if b.state != circuitClosed {
+			b.trial = false
+			b.transition(circuitClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || (b.state == circuitClosed && b.failures >= b.opts.FailureThreshold) {
+		b.trial = false
+		b.openedAt = b.now()
+		b.transition(circuitOpen)
+	}
+}
+
+// abandon records that a request allowed by allow had no conclusive outcome.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.trial = false
+	}
+}
+
+func (b *circuitBreaker) transition(state circuitState) {
+	b.state = state
+	metricCircuitBreakerState.WithLabelValues(b.name).Set(float64(state))
+	metricCircuitBreakerTransitions.WithLabelValues(b.name, state.String()).Inc()
+}
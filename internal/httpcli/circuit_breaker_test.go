@@ -0,0 +1,80 @@
+package httpcli
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestCircuitBreakerMiddleware(t *testing.T) {
+	status := http.StatusInternalServerError
+	var calls int
+	cli := NewCircuitBreakerMiddleware(t.Name(), CircuitBreakerOptions{
+		FailureThreshold: 2,
+		OpenDuration:     time.Minute,
+	})(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: status}, nil
+	}))
+
+	now := time.Now()
+	b := getCircuitBreaker(t.Name(), CircuitBreakerOptions{FailureThreshold: 2, OpenDuration: time.Minute})
+	b.now = func() time.Time { return now }
+
+	do := func() error {
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+		_, err := cli.Do(req)
+		return err
+	}
+
+	// The circuit opens after two consecutive failures.
+	require.NoError(t, do())
+	require.NoError(t, do())
+	require.Equal(t, circuitOpen, b.state)
+	require.ErrorIs(t, do(), ErrCircuitOpen)
+	require.Equal(t, 2, calls)
+
+	// A single trial request is let through once the circuit has been open long
+	// enough. It fails, so the circuit opens again.
+	now = now.Add(time.Minute)
+	require.NoError(t, do())
+	require.Equal(t, 3, calls)
+	require.Equal(t, circuitOpen, b.state)
+	require.ErrorIs(t, do(), ErrCircuitOpen)
+
+	// A successful trial request closes the circuit.
+	now = now.Add(time.Minute)
+	status = http.StatusOK
+	require.NoError(t, do())
+	require.Equal(t, circuitClosed, b.state)
+	require.NoError(t, do())
+	require.Equal(t, 5, calls)
+}
+
+func TestCircuitBreakerIgnoresCanceledRequests(t *testing.T) {
+	b := getCircuitBreaker(t.Name(), CircuitBreakerOptions{FailureThreshold: 1})
+	cli := NewCircuitBreakerMiddleware(t.Name(), CircuitBreakerOptions{FailureThreshold: 1})(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "http://example.com", nil)
+	_, err := cli.Do(req)
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Equal(t, circuitClosed, b.state)
+}
+
+func TestCircuitBreakerSharedByName(t *testing.T) {
+	opts := CircuitBreakerOptions{FailureThreshold: 3}
+	a := getCircuitBreaker(t.Name(), opts)
+	require.Same(t, a, getCircuitBreaker(t.Name(), opts))
+
+	// Changing the options resets the circuit breaker.
+	require.NotSame(t, a, getCircuitBreaker(t.Name(), CircuitBreakerOptions{FailureThreshold: 4}))
+}
@@ -282,6 +282,26 @@ func (f Factory) Client(base ...Opt) (*http.Client, error) {
 	return &cli, err
 }
 
+// WithMiddleware returns a copy of the Factory whose Doers are additionally wrapped
+// by the given middleware, on top of the Factory's own middleware stack.
+func (f Factory) WithMiddleware(mws ...Middleware) *Factory {
+	if len(mws) == 0 {
+		return &f
+	}
+
+	stack := f.stack
+	extra := NewMiddleware(mws...)
+	return &Factory{
+		stack: func(cli Doer) Doer {
+			if stack != nil {
+				cli = stack(cli)
+			}
+			return extra(cli)
+		},
+		common: f.common,
+	}
+}
+
 // NewFactory returns a Factory that applies the given common
 // Opts after the ones provided on each invocation of Client or Doer.
 //
@@ -29,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/insights/query"
 	"github.com/sourcegraph/sourcegraph/internal/insights/scheduler"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/locker"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -107,6 +108,7 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 				}),
 			CostAnalyzer:      priority.DefaultQueryAnalyzer(),
 			RepoQueryExecutor: query.NewStreamingRepoQueryExecutor(logger.Scoped("StreamingRepoExecutor")),
+			Locker:            locker.NewWith(mainAppDB),
 		}
 
 		// Add the backfill v2 workers
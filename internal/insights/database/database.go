@@ -22,5 +22,10 @@ func InitializeCodeInsightsDB(observationCtx *observation.Context, app string) (
 		return nil, errors.Errorf("Failed to connect to codeinsights database: %s", err)
 	}
 
-	return database.NewInsightsDB(db, observationCtx.Logger), nil
+	replica, err := connections.NewCodeInsightsReplicaDB(observationCtx, app)
+	if err != nil {
+		return nil, errors.Errorf("Failed to connect to codeinsights database replica: %s", err)
+	}
+
+	return database.NewInsightsDBWithReplica(db, replica, connections.ReplicaMaxLag, observationCtx.Logger), nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
 	itypes "github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/locker"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
@@ -35,6 +36,10 @@ const (
 	defaultInterruptSeconds    = 60
 	inProgressPollingInterval  = time.Second * 5
 	defaultErrorThresholdFloor = 50
+
+	// backfillLeaseTTL is the duration of the lease on a backfill. The lease is renewed
+	// while the backfill is being processed.
+	backfillLeaseTTL = time.Minute
 )
 
 func makeInProgressWorker(ctx context.Context, config JobMonitorConfig) (*workerutil.Worker[*BaseJob], *dbworker.Resetter[*BaseJob], dbworkerstore.Store[*BaseJob]) {
@@ -65,6 +70,7 @@ func makeInProgressWorker(ctx context.Context, config JobMonitorConfig) (*worker
 		insightsStore:      config.InsightStore,
 		backfillRunner:     config.BackfillRunner,
 		repoStore:          config.RepoStore,
+		locker:             config.Locker,
 		clock:              glock.NewRealClock(),
 		config:             handlerConfig,
 	}
@@ -116,6 +122,7 @@ type inProgressHandler struct {
 	repoStore          database.RepoStore
 	insightsStore      store.Interface
 	backfillRunner     pipeline.Backfiller
+	locker             BackfillLocker
 	config             handlerConfig
 
 	clock glock.Clock
@@ -137,11 +144,34 @@ var _ workerutil.Handler[*BaseJob] = &inProgressHandler{}
 func (h *inProgressHandler) Handle(ctx context.Context, logger log.Logger, job *BaseJob) error {
 	ctx = actor.WithInternalActor(ctx)
 
-	execution, err := h.load(ctx, logger, job.backfillId)
+	// The job of a stalled worker is reset and may be dequeued by another worker while the
+	// stalled one is still processing it. The lease ensures that a backfill is processed by
+	// a single worker at a time.
+	var interrupt bool
+	acquired, err := h.locker.Do(ctx, fmt.Sprintf("insights.backfill:%d", job.backfillId), backfillLeaseTTL, func(ctx context.Context, lease *locker.Lease) (err error) {
+		interrupt, err = h.handle(ctx, logger, job, lease)
+		return err
+	})
 	if err != nil {
 		return err
 	}
+	if !acquired {
+		logger.Info("insights backfill is being processed by another worker", log.Int("backfillId", job.backfillId))
+		interrupt = true
+	}
+	if interrupt {
+		return h.doInterrupt(ctx, job)
+	}
+	return nil
+}
+
+func (h *inProgressHandler) handle(ctx context.Context, logger log.Logger, job *BaseJob, lease *locker.Lease) (interrupt bool, _ error) {
+	execution, err := h.load(ctx, logger, job.backfillId)
+	if err != nil {
+		return false, err
+	}
 	execution.config = h.config
+	execution.lease = lease
 
 	logger.Info("insights backfill progress handler loaded",
 		log.Int("recordId", job.RecordID()),
@@ -154,14 +184,7 @@ func (h *inProgressHandler) Handle(ctx context.Context, logger log.Logger, job *
 		log.Int("erroredRepos", execution.itr.ErroredRepos()),
 		log.Int("totalErrors", execution.itr.TotalErrors()))
 
-	interrupt, err := h.doExecution(ctx, execution)
-	if err != nil {
-		return err
-	}
-	if interrupt {
-		return h.doInterrupt(ctx, job)
-	}
-	return nil
+	return h.doExecution(ctx, execution)
 }
 
 type nextNFunc func(pageSize int, config iterator.IterationConfig) ([]api.RepoID, bool, iterator.FinishNFunc)
@@ -239,6 +262,9 @@ func (h *inProgressHandler) doExecution(ctx context.Context, execution *backfill
 				// The groups functions don't return errors so not checking for them
 				p.Wait()
 				execution.logger.Debug("page complete", log.Duration("page duration", time.Since(startPage)), log.Int("page size", pageSize), log.Int("number repos", len(repoIds)))
+				if err := execution.checkLease(ctx); err != nil {
+					return false, err
+				}
 				err = finish(ctx, h.backfillStore.Store, repoErrors)
 				if err != nil {
 					return false, err
@@ -271,6 +297,9 @@ func (h *inProgressHandler) doExecution(ctx context.Context, execution *backfill
 	}
 
 	if !execution.itr.HasMore() && !execution.itr.HasErrors() {
+		if err := execution.checkLease(ctx); err != nil {
+			return false, err
+		}
 		return false, h.finish(ctx, execution)
 	} else {
 		// in this state we have some errors that will need reprocessing, we will place this job back in queue
@@ -378,6 +407,17 @@ type backfillExecution struct {
 	logger      log.Logger
 	sampleTimes []time.Time
 	config      handlerConfig
+	lease       *locker.Lease
+}
+
+// checkLease returns an error if the lease on the backfill was lost, so that no progress
+// is recorded over the progress of the worker now processing the backfill. The lease is
+// held in the frontend database, so it cannot be fenced within insights transactions.
+func (b *backfillExecution) checkLease(ctx context.Context) error {
+	if b.lease == nil {
+		return nil
+	}
+	return b.lease.Check(ctx)
 }
 
 func (b *backfillExecution) logFields(extra ...log.Field) []log.Field {
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/insights/scheduler/iterator"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/locker"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	itypes "github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -45,6 +46,13 @@ func (e *delegateBackfillRunner) Run(ctx context.Context, req pipeline.BackfillR
 	return e.doSomething(ctx, req)
 }
 
+// noopLocker runs the given function without taking a lease.
+type noopLocker struct{}
+
+func (noopLocker) Do(ctx context.Context, _ string, _ time.Duration, f func(ctx context.Context, lease *locker.Lease) error) (bool, error) {
+	return true, f(ctx, nil)
+}
+
 func Test_MovesBackfillFromProcessingToComplete(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx := context.Background()
@@ -97,6 +105,7 @@ func Test_MovesBackfillFromProcessingToComplete(t *testing.T) {
 		repoStore:          repos,
 		insightsStore:      seriesStore,
 		backfillRunner:     &noopBackfillRunner{},
+		locker:             noopLocker{},
 		config:             newHandlerConfig(),
 
 		clock: clock,
@@ -250,6 +259,7 @@ func Test_BackfillWithRetry(t *testing.T) {
 		repoStore:          repos,
 		insightsStore:      seriesStore,
 		backfillRunner:     runner,
+		locker:             noopLocker{},
 		config:             newHandlerConfig(),
 		clock:              clock,
 	}
@@ -328,6 +338,7 @@ func Test_BackfillWithRetryAndComplete(t *testing.T) {
 		repoStore:          repos,
 		insightsStore:      seriesStore,
 		backfillRunner:     runner,
+		locker:             noopLocker{},
 		config:             newHandlerConfig(),
 		clock:              clock,
 	}
@@ -410,6 +421,7 @@ func Test_BackfillWithRepoNotFound(t *testing.T) {
 		repoStore:          repos,
 		insightsStore:      seriesStore,
 		backfillRunner:     runner,
+		locker:             noopLocker{},
 		config:             newHandlerConfig(),
 		clock:              clock,
 	}
@@ -493,6 +505,7 @@ func Test_BackfillWithARepoError(t *testing.T) {
 		repoStore:          repos,
 		insightsStore:      seriesStore,
 		backfillRunner:     runner,
+		locker:             noopLocker{},
 		config:             newHandlerConfig(),
 		clock:              clock,
 	}
@@ -569,6 +582,7 @@ func Test_BackfillWithInterrupt(t *testing.T) {
 		repoStore:          repos,
 		insightsStore:      seriesStore,
 		backfillRunner:     &runner,
+		locker:             noopLocker{},
 		config:             newHandlerConfig(),
 		clock:              clock,
 	}
@@ -660,6 +674,7 @@ func Test_BackfillCrossingErrorThreshold(t *testing.T) {
 		repoStore:          repos,
 		insightsStore:      seriesStore,
 		backfillRunner:     &runner,
+		locker:             noopLocker{},
 		config:             handlerConfig,
 		clock:              clock,
 	}
@@ -711,3 +726,79 @@ func Test_calculateErrorThreshold(t *testing.T) {
 		})
 	}
 }
+
+// heldLocker reports the lease as held by another worker.
+type heldLocker struct{}
+
+func (heldLocker) Do(context.Context, string, time.Duration, func(context.Context, *locker.Lease) error) (bool, error) {
+	return false, nil
+}
+
+func Test_InProgressHandlerLeaseHeldElsewhere(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	permStore := store.NewInsightPermissionStore(dbmocks.NewMockDB())
+	repos := dbmocks.NewMockRepoStore()
+	insightsStore := store.NewInsightStore(insightsDB)
+	seriesStore := store.New(insightsDB, permStore)
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := glock.NewMockClockAt(now)
+	bfs := newBackfillStoreWithClock(insightsDB, clock)
+
+	config := JobMonitorConfig{
+		InsightsDB:     insightsDB,
+		RepoStore:      repos,
+		InsightStore:   seriesStore,
+		ObservationCtx: &observation.TestContext,
+		BackfillRunner: &noopBackfillRunner{},
+		CostAnalyzer:   priority.NewQueryAnalyzer(),
+	}
+	monitor := NewBackgroundJobMonitor(ctx, config)
+
+	series, err := insightsStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:            "series1",
+		Query:               "asdf",
+		SampleIntervalUnit:  string(types.Month),
+		Repositories:        []string{"repo1"},
+		SampleIntervalValue: 1,
+		GenerationMethod:    types.Search,
+	})
+	require.NoError(t, err)
+
+	backfill, err := bfs.NewBackfill(ctx, series)
+	require.NoError(t, err)
+	backfill, err = backfill.SetScope(ctx, bfs, []int32{1}, 0)
+	require.NoError(t, err)
+	err = backfill.setState(ctx, bfs, BackfillStateProcessing)
+	require.NoError(t, err)
+
+	err = enqueueBackfill(ctx, bfs.Handle(), backfill)
+	require.NoError(t, err)
+
+	dequeue, _, _ := monitor.inProgressStore.Dequeue(ctx, "test", nil)
+	handler := inProgressHandler{
+		workerStore:        monitor.inProgressStore,
+		backfillStore:      bfs,
+		seriesReadComplete: insightsStore,
+		repoStore:          repos,
+		insightsStore:      seriesStore,
+		backfillRunner: &delegateBackfillRunner{doSomething: func(context.Context, pipeline.BackfillRequest) error {
+			t.Fatal("unexpected backfill while the lease is held by another worker")
+			return nil
+		}},
+		locker: heldLocker{},
+		config: newHandlerConfig(),
+
+		clock: clock,
+	}
+	err = handler.Handle(ctx, logger, dequeue)
+	require.NoError(t, err)
+
+	// The job is requeued and the backfill is left untouched.
+	loaded, err := bfs.LoadBackfill(ctx, backfill.Id)
+	require.NoError(t, err)
+	require.Equal(t, BackfillStateProcessing, loaded.State)
+	require.Empty(t, repos.GetFunc.History())
+}
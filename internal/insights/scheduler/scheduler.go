@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/insights/query"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/locker"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	itypes "github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -118,6 +119,13 @@ type JobMonitorConfig struct {
 	AllRepoIterator   *discovery.AllReposIterator
 	CostAnalyzer      *priority.QueryAnalyzer
 	RepoQueryExecutor query.RepoQueryExecutor
+	Locker            BackfillLocker
+}
+
+// BackfillLocker coordinates the processing of a backfill between workers. It is
+// implemented by *locker.Service.
+type BackfillLocker interface {
+	Do(ctx context.Context, name string, ttl time.Duration, f func(ctx context.Context, lease *locker.Lease) error) (bool, error)
 }
 
 func NewBackgroundJobMonitor(ctx context.Context, config JobMonitorConfig) *BackgroundJobMonitor {
@@ -155,7 +155,8 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 	q := seriesPointsQuery(fullVectorSeriesAggregation, opts)
 	pointsMap := make(map[string]*SeriesPoint)
 	captureValues := make(map[string]struct{})
-	err = s.query(ctx, q, func(sc scanner) error {
+	// Series points are read on every insight view, and may be a few seconds stale.
+	err = s.query(basestore.WithReadReplicaPreferred(ctx), q, func(sc scanner) error {
 		var point SeriesPoint
 		err := sc.Scan(
 			&point.SeriesID,
@@ -241,7 +242,7 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 			  GROUP BY sp.series_id, interval_time, sp.repo_id, capture
 	;`
 	fullQ := seriesPointsQuery(q, opts)
-	err = s.query(ctx, fullQ, func(sc scanner) (err error) {
+	err = s.query(basestore.WithReadReplicaPreferred(ctx), fullQ, func(sc scanner) (err error) {
 		var row loadStruct
 		err = sc.Scan(
 			&row.Time,
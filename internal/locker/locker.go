@@ -0,0 +1,282 @@
+// Package locker provides leases on named locks shared by all instances of a
+// service, backed by the distributed_locks table.
+//
+// Unlike Postgres advisory locks (see internal/database/locker), a lease does not
+// depend on a single database connection staying healthy. It expires unless it is
+// renewed, and every acquisition issues a new fencing token. Writes guarded by a
+// lock call Lease.Fence at the end of their transaction, so that a holder whose
+// lease was lost, for example after a long pause, cannot overwrite the work of the
+// next holder.
+//
+// Fencing tokens are drawn from a sequence, so rows are deleted once the lock is
+// released or its lease expired without making tokens go backwards.
+package locker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/hostname"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrLeaseLost occurs when a lease expired or was taken over by another holder.
+var ErrLeaseLost = errors.New("locker: lease lost")
+
+// ErrNoTransaction occurs when Fence is called outside of a transaction.
+var ErrNoTransaction = errors.New("locker: not in a transaction")
+
+// Service issues leases on named locks.
+type Service struct {
+	store  *basestore.Store
+	holder string
+	logger log.Logger
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+// NewWith creates a new Service with the given ShareableStore, which must be a
+// handle to the frontend database.
+func NewWith(other basestore.ShareableStore) *Service {
+	return &Service{
+		store:  basestore.NewWithHandle(other.Handle()),
+		holder: fmt.Sprintf("%s/%d", hostname.Get(), os.Getpid()),
+		logger: log.Scoped("locker"),
+	}
+}
+
+// Lease is a time-limited hold on a named lock.
+type Lease struct {
+	// Name is the name of the lock.
+	Name string
+	// Token is the fencing token of this lease. It is greater than the token of
+	// any previous lease on the same lock.
+	Token int64
+
+	service *Service
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	expiresAt time.Time
+}
+
+// TryAcquire attempts to acquire the named lock for the given duration. It does not
+// block if the lock is held by someone else, in which case a false-valued flag is
+// returned.
+func (s *Service) TryAcquire(ctx context.Context, name string, ttl time.Duration) (_ *Lease, _ bool, err error) {
+	if s.shouldCleanup() {
+		if err := s.store.Exec(ctx, sqlf.Sprintf(cleanupQuery)); err != nil {
+			s.logger.Warn("failed to delete expired locks", log.Error(err))
+		}
+	}
+
+	rows, err := s.store.Query(ctx, sqlf.Sprintf(acquireQuery, name, s.holder, ttl.Seconds()))
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if !rows.Next() {
+		return nil, false, rows.Err()
+	}
+
+	lease := &Lease{Name: name, service: s, ttl: ttl}
+	if err := rows.Scan(&lease.Token, &lease.expiresAt); err != nil {
+		return nil, false, err
+	}
+
+	return lease, true, nil
+}
+
+const acquireQuery = `
+INSERT INTO distributed_locks (name, holder, fencing_token, acquired_at, expires_at)
+VALUES (%s, %s, nextval('distributed_locks_fencing_token_seq'), NOW(), NOW() + %s * interval '1 second')
+ON CONFLICT (name) DO UPDATE SET
+	holder = EXCLUDED.holder,
+	fencing_token = EXCLUDED.fencing_token,
+	acquired_at = EXCLUDED.acquired_at,
+	expires_at = EXCLUDED.expires_at
+WHERE distributed_locks.expires_at <= NOW()
+RETURNING fencing_token, expires_at
+`
+
+// cleanupInterval is the minimum interval between two deletions of expired locks by
+// a Service.
+const cleanupInterval = 10 * time.Minute
+
+// shouldCleanup returns true if the expired locks should be deleted, which happens
+// at most once per cleanupInterval. Locks are usually deleted on release, this only
+// deletes the locks of holders that stopped before releasing them.
+func (s *Service) shouldCleanup() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.lastCleanup) < cleanupInterval {
+		return false
+	}
+	s.lastCleanup = time.Now()
+	return true
+}
+
+const cleanupQuery = `
+DELETE FROM distributed_locks
+WHERE expires_at <= NOW()
+`
+
+// Do attempts to acquire the named lock and calls f while holding it. The lease is
+// renewed in the background until f returns, and released afterwards. The context
+// passed to f is canceled if the lease is lost. If the lock is held by someone
+// else, f is not called and a false-valued flag is returned.
+func (s *Service) Do(ctx context.Context, name string, ttl time.Duration, f func(ctx context.Context, lease *Lease) error) (acquired bool, err error) {
+	lease, ok, err := s.TryAcquire(ctx, name, ttl)
+	if err != nil || !ok {
+		return false, err
+	}
+	defer func() {
+		if releaseErr := lease.Release(context.Background()); releaseErr != nil && releaseErr != ErrLeaseLost {
+			err = errors.Append(err, releaseErr)
+		}
+	}()
+
+	leaseCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		lease.keepAlive(leaseCtx, cancel)
+	}()
+
+	err = f(leaseCtx, lease)
+	cancel()
+	wg.Wait()
+
+	return true, err
+}
+
+// keepAlive renews the lease every third of its duration until the given context
+// is canceled. If the lease cannot be renewed before it expires, onLost is called.
+func (l *Lease) keepAlive(ctx context.Context, onLost func()) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := l.Renew(ctx)
+		if err == nil || ctx.Err() != nil {
+			continue
+		}
+		if err == ErrLeaseLost || time.Now().After(l.ExpiresAt()) {
+			l.service.logger.Warn("lost lease", log.String("name", l.Name), log.Int64("token", l.Token), log.Error(err))
+			onLost()
+			return
+		}
+
+		// Transient failures are retried until the lease expires.
+		l.service.logger.Warn("failed to renew lease", log.String("name", l.Name), log.Int64("token", l.Token), log.Error(err))
+	}
+}
+
+// ExpiresAt returns the time the lease expires unless it is renewed.
+func (l *Lease) ExpiresAt() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.expiresAt
+}
+
+// Renew extends the lease by its original duration. ErrLeaseLost is returned if
+// the lease has already expired or was taken over.
+func (l *Lease) Renew(ctx context.Context) error {
+	expiresAt, ok, err := basestore.ScanFirstTime(l.service.store.Query(ctx, sqlf.Sprintf(renewQuery, l.ttl.Seconds(), l.Name, l.Token)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLeaseLost
+	}
+
+	l.mu.Lock()
+	l.expiresAt = expiresAt
+	l.mu.Unlock()
+	return nil
+}
+
+const renewQuery = `
+UPDATE distributed_locks
+SET expires_at = NOW() + %s * interval '1 second'
+WHERE name = %s AND fencing_token = %s AND expires_at > NOW()
+RETURNING expires_at
+`
+
+// Release frees the lock so that it can be acquired again immediately.
+// ErrLeaseLost is returned if the lease had already expired or was taken over.
+func (l *Lease) Release(ctx context.Context) error {
+	_, ok, err := basestore.ScanFirstString(l.service.store.Query(ctx, sqlf.Sprintf(releaseQuery, l.Name, l.Token)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+const releaseQuery = `
+DELETE FROM distributed_locks
+WHERE name = %s AND fencing_token = %s AND expires_at > NOW()
+RETURNING name
+`
+
+// Check returns ErrLeaseLost if the lease is no longer held. Prefer Fence when the
+// guarded writes are made to the frontend database.
+func (l *Lease) Check(ctx context.Context) error {
+	return l.check(ctx, l.service.store, checkQuery)
+}
+
+// Fence returns ErrLeaseLost if the lease is no longer held. It must be called
+// within the transaction making the guarded writes: the lock row is share-locked
+// until the transaction ends, so that the lock cannot be taken over before the
+// writes are committed.
+//
+// The share lock also blocks renewals of the lease, so Fence should be the last
+// statement of the transaction. If the lease is lost while the writes are made,
+// Fence fails and the transaction is rolled back.
+func (l *Lease) Fence(ctx context.Context, tx basestore.ShareableStore) error {
+	store := basestore.NewWithHandle(tx.Handle())
+	if !store.InTransaction() {
+		return ErrNoTransaction
+	}
+	return l.check(ctx, store, fenceQuery)
+}
+
+func (l *Lease) check(ctx context.Context, store *basestore.Store, query string) error {
+	_, ok, err := basestore.ScanFirstString(store.Query(ctx, sqlf.Sprintf(query, l.Name, l.Token)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+const checkQuery = `
+SELECT name FROM distributed_locks
+WHERE name = %s AND fencing_token = %s AND expires_at > NOW()
+`
+
+const fenceQuery = checkQuery + `FOR SHARE`
@@ -0,0 +1,223 @@
+package locker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestTryAcquire(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(logtest.Scoped(t), dbtest.NewDB(t))
+	a := NewWith(db)
+	b := NewWith(db)
+	b.holder = "b"
+
+	lease, ok, err := a.TryAcquire(ctx, "test", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The lock is held.
+	_, ok, err = b.TryAcquire(ctx, "test", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Other locks are independent.
+	_, ok, err = b.TryAcquire(ctx, "other", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, lease.Renew(ctx))
+	require.NoError(t, lease.Check(ctx))
+	require.NoError(t, lease.Release(ctx))
+	require.ErrorIs(t, lease.Check(ctx), ErrLeaseLost)
+	require.ErrorIs(t, lease.Renew(ctx), ErrLeaseLost)
+
+	// Every acquisition issues a greater fencing token, even though the lock was
+	// deleted on release.
+	require.Equal(t, 0, countLocks(t, a, "test"))
+	next, ok, err := b.TryAcquire(ctx, "test", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Greater(t, next.Token, lease.Token)
+}
+
+func TestTryAcquireExpired(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(logtest.Scoped(t), dbtest.NewDB(t))
+	s := NewWith(db)
+
+	lease, ok, err := s.TryAcquire(ctx, "test", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Simulate a holder which stopped renewing its lease.
+	require.NoError(t, s.store.Exec(ctx, sqlf.Sprintf(`UPDATE distributed_locks SET expires_at = NOW() - interval '1 second'`)))
+
+	next, ok, err := s.TryAcquire(ctx, "test", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Greater(t, next.Token, lease.Token)
+	require.ErrorIs(t, lease.Check(ctx), ErrLeaseLost)
+	require.ErrorIs(t, lease.Release(ctx), ErrLeaseLost)
+}
+
+func TestFence(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(logtest.Scoped(t), dbtest.NewDB(t))
+	s := NewWith(db)
+
+	lease, ok, err := s.TryAcquire(ctx, "test", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.ErrorIs(t, lease.Fence(ctx, db), ErrNoTransaction)
+
+	require.NoError(t, basestore.NewWithHandle(db.Handle()).WithTransact(ctx, func(tx *basestore.Store) error {
+		return lease.Fence(ctx, tx)
+	}))
+
+	require.NoError(t, lease.Release(ctx))
+	require.ErrorIs(t, basestore.NewWithHandle(db.Handle()).WithTransact(ctx, func(tx *basestore.Store) error {
+		return lease.Fence(ctx, tx)
+	}), ErrLeaseLost)
+}
+
+func TestFenceAfterWrites(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(logtest.Scoped(t), dbtest.NewDB(t))
+	s := NewWith(db)
+
+	lease, ok, err := s.TryAcquire(ctx, "test", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, basestore.NewWithHandle(db.Handle()).WithTransact(ctx, func(tx *basestore.Store) error {
+		if err := tx.Exec(ctx, sqlf.Sprintf(`INSERT INTO distributed_locks (name, holder, fencing_token, expires_at) VALUES ('write', 'test', 0, NOW())`)); err != nil {
+			return err
+		}
+
+		// The lease is not locked before the transaction is fenced, so it can be renewed.
+		if err := lease.Renew(ctx); err != nil {
+			return err
+		}
+
+		return lease.Fence(ctx, tx)
+	}))
+	require.Equal(t, 1, countLocks(t, s, "write"))
+
+	require.ErrorIs(t, basestore.NewWithHandle(db.Handle()).WithTransact(ctx, func(tx *basestore.Store) error {
+		if err := tx.Exec(ctx, sqlf.Sprintf(`DELETE FROM distributed_locks WHERE name = 'write'`)); err != nil {
+			return err
+		}
+
+		// Another holder takes over the lock while the writes are made.
+		if err := s.store.Exec(ctx, sqlf.Sprintf(`UPDATE distributed_locks SET fencing_token = fencing_token + 1 WHERE name = 'test'`)); err != nil {
+			return err
+		}
+
+		return lease.Fence(ctx, tx)
+	}), ErrLeaseLost)
+
+	// The writes were rolled back.
+	require.Equal(t, 1, countLocks(t, s, "write"))
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(logtest.Scoped(t), dbtest.NewDB(t))
+	a := NewWith(db)
+
+	_, ok, err := a.TryAcquire(ctx, "expired", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, ok, err = a.TryAcquire(ctx, "held", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Simulate a holder which stopped without releasing its lease.
+	require.NoError(t, a.store.Exec(ctx, sqlf.Sprintf(`UPDATE distributed_locks SET expires_at = NOW() - interval '1 second' WHERE name = 'expired'`)))
+
+	// Expired locks are deleted at most once per interval.
+	_, ok, err = a.TryAcquire(ctx, "other", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, countLocks(t, a, "expired"))
+
+	b := NewWith(db)
+	_, ok, err = b.TryAcquire(ctx, "other", time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, 0, countLocks(t, b, "expired"))
+	require.Equal(t, 1, countLocks(t, b, "held"))
+}
+
+func countLocks(t *testing.T, s *Service, name string) int {
+	t.Helper()
+
+	count, _, err := basestore.ScanFirstInt(s.store.Query(context.Background(), sqlf.Sprintf(`SELECT COUNT(*) FROM distributed_locks WHERE name = %s`, name)))
+	require.NoError(t, err)
+	return count
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(logtest.Scoped(t), dbtest.NewDB(t))
+	s := NewWith(db)
+
+	called := false
+	acquired, err := s.Do(ctx, "test", time.Minute, func(ctx context.Context, lease *Lease) error {
+		called = true
+
+		// The lock is held while f runs.
+		_, ok, err := s.TryAcquire(ctx, "test", time.Minute)
+		require.NoError(t, err)
+		require.False(t, ok)
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.True(t, called)
+
+	// The lock is released once f returns.
+	_, ok, err := s.TryAcquire(ctx, "test", time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	acquired, err = s.Do(ctx, "test", time.Minute, func(ctx context.Context, lease *Lease) error {
+		t.Fatal("unexpected call")
+		return nil
+	})
+	require.NoError(t, err)
+	require.False(t, acquired)
+}
+
+func TestDoLeaseLost(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(logtest.Scoped(t), dbtest.NewDB(t))
+	s := NewWith(db)
+
+	acquired, err := s.Do(ctx, "test", 300*time.Millisecond, func(ctx context.Context, lease *Lease) error {
+		// Another holder takes over the lock.
+		if err := s.store.Exec(ctx, sqlf.Sprintf(`UPDATE distributed_locks SET fencing_token = fencing_token + 1`)); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Second):
+			return nil
+		}
+	})
+	require.True(t, acquired)
+	require.ErrorIs(t, err, context.Canceled)
+}
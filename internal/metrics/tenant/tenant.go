@@ -0,0 +1,172 @@
+// Package tenant attributes request-level metrics to the tenant of the
+// requesting user. A tenant is the user's organization, or a group of
+// organizations configured in "observability.metricsTenantLabel".
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// LabelName is the name of the Prometheus label carrying the tenant.
+	LabelName = "tenant"
+
+	// noTenant is reported for anonymous users and users without an organization.
+	noTenant = "none"
+	// otherTenant is reported once the maximum number of tenants is reached.
+	otherTenant = "other"
+
+	defaultMaxTenants = 20
+)
+
+type contextKey struct{}
+
+// WithTenant returns a context carrying the given tenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+// FromContext returns the tenant to report in metrics for the current request.
+// It returns an empty string, which Prometheus treats as an absent label, if
+// tenant labels are disabled.
+func FromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(contextKey{}).(string)
+	return tenant
+}
+
+// Middleware sets the tenant of the current actor on the request context when
+// "observability.metricsTenantLabel" is enabled. It must run after the
+// authentication middlewares.
+func Middleware(db database.DB, next http.Handler) http.Handler {
+	r := newResolver(db, log.Scoped("tenant"))
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		cfg := conf.Get().ObservabilityMetricsTenantLabel
+		if cfg == nil || !cfg.Enabled {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		ctx := req.Context()
+		next.ServeHTTP(w, req.WithContext(WithTenant(ctx, r.tenant(ctx, cfg))))
+	})
+}
+
+// orgsTTL is how long the organization memberships of a user are cached.
+const orgsTTL = 5 * time.Minute
+
+// maxCachedUsers bounds the memory used by the membership cache.
+const maxCachedUsers = 10000
+
+type cachedOrgs struct {
+	names     []string
+	fetchedAt time.Time
+}
+
+type resolver struct {
+	db     database.DB
+	logger log.Logger
+
+	mu   sync.Mutex
+	orgs map[int32]cachedOrgs
+	// seen holds the tenants reported so far, to enforce maxTenants.
+	seen map[string]struct{}
+}
+
+func newResolver(db database.DB, logger log.Logger) *resolver {
+	return &resolver{
+		db:     db,
+		logger: logger,
+		orgs:   map[int32]cachedOrgs{},
+		seen:   map[string]struct{}{},
+	}
+}
+
+func (r *resolver) tenant(ctx context.Context, cfg *schema.MetricsTenantLabel) string {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return noTenant
+	}
+
+	names, err := r.orgNames(ctx, a.UID)
+	if err != nil {
+		r.logger.Warn("failed to get organizations of user", log.Int32("userID", a.UID), log.Error(err))
+		return noTenant
+	}
+
+	return r.capped(tenantForOrgs(names, cfg.Groups), cfg.MaxTenants)
+}
+
+func (r *resolver) orgNames(ctx context.Context, userID int32) ([]string, error) {
+	r.mu.Lock()
+	cached, ok := r.orgs[userID]
+	r.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < orgsTTL {
+		return cached.names, nil
+	}
+
+	orgs, err := r.db.Orgs().GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		names = append(names, org.Name)
+	}
+	// Sort so that users in several organizations are consistently reported.
+	sort.Strings(names)
+
+	r.mu.Lock()
+	if len(r.orgs) >= maxCachedUsers {
+		r.orgs = map[int32]cachedOrgs{}
+	}
+	r.orgs[userID] = cachedOrgs{names: names, fetchedAt: time.Now()}
+	r.mu.Unlock()
+
+	return names, nil
+}
+
+// capped returns the given tenant, or otherTenant if maxTenants distinct
+// tenants have already been reported.
+func (r *resolver) capped(tenant string, maxTenants int) string {
+	if maxTenants <= 0 {
+		maxTenants = defaultMaxTenants
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.seen[tenant]; ok {
+		return tenant
+	}
+	if len(r.seen) >= maxTenants {
+		return otherTenant
+	}
+	r.seen[tenant] = struct{}{}
+	return tenant
+}
+
+// tenantForOrgs returns the tenant of a member of the given organizations: the
+// group of the first organization that is part of a configured group, otherwise
+// the name of the first organization. names must be sorted.
+func tenantForOrgs(names []string, groups map[string]string) string {
+	for _, name := range names {
+		if group, ok := groups[name]; ok && group != "" {
+			return group
+		}
+	}
+	if len(names) > 0 {
+		return names[0]
+	}
+	return noTenant
+}
@@ -0,0 +1,90 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMiddleware(t *testing.T) {
+	orgs := dbmocks.NewMockOrgStore()
+	orgs.GetByUserIDFunc.SetDefaultHook(func(_ context.Context, userID int32) ([]*types.Org, error) {
+		switch userID {
+		case 1:
+			return []*types.Org{{Name: "payments-frontend"}, {Name: "growth"}}, nil
+		case 2:
+			return []*types.Org{{Name: "search"}}, nil
+		}
+		return nil, nil
+	})
+	db := dbmocks.NewMockDB()
+	db.OrgsFunc.SetDefaultReturn(orgs)
+
+	var got string
+	handler := Middleware(db, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = FromContext(r.Context())
+	}))
+	serve := func(a *actor.Actor) string {
+		req := httptest.NewRequest("GET", "/.api/graphql", nil)
+		req = req.WithContext(actor.WithActor(req.Context(), a))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		return got
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Empty(t, serve(actor.FromUser(1)))
+		assert.Empty(t, orgs.GetByUserIDFunc.History())
+	})
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ObservabilityMetricsTenantLabel: &schema.MetricsTenantLabel{
+			Enabled:    true,
+			Groups:     map[string]string{"payments-frontend": "payments"},
+			MaxTenants: 1,
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	t.Run("enabled", func(t *testing.T) {
+		assert.Equal(t, "payments", serve(actor.FromUser(1)))
+		assert.Equal(t, "none", serve(&actor.Actor{}))
+		// The cap of one tenant is reached.
+		assert.Equal(t, "other", serve(actor.FromUser(2)))
+		assert.Equal(t, "payments", serve(actor.FromUser(1)))
+
+		// Memberships are cached.
+		assert.Len(t, orgs.GetByUserIDFunc.History(), 2)
+	})
+}
+
+func TestTenantForOrgs(t *testing.T) {
+	groups := map[string]string{"payments-backend": "payments"}
+	for _, tc := range []struct {
+		orgs []string
+		want string
+	}{
+		{orgs: nil, want: "none"},
+		{orgs: []string{"growth", "search"}, want: "growth"},
+		{orgs: []string{"growth", "payments-backend"}, want: "payments"},
+	} {
+		assert.Equal(t, tc.want, tenantForOrgs(tc.orgs, groups), "%v", tc.orgs)
+	}
+}
+
+func TestCapped(t *testing.T) {
+	r := newResolver(dbmocks.NewMockDB(), logtest.Scoped(t))
+	assert.Equal(t, "a", r.capped("a", 1))
+	assert.Equal(t, "other", r.capped("b", 1))
+	assert.Equal(t, "a", r.capped("a", 1))
+	assert.Equal(t, "b", r.capped("b", 0))
+}
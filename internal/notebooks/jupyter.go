@@ -0,0 +1,254 @@
+package notebooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// The Jupyter notebook format (nbformat) version we read and write. Older minor
+// versions are compatible with the subset of the format we use.
+const (
+	jupyterNBFormat      = 4
+	jupyterNBFormatMinor = 5
+)
+
+// jupyterSourcegraphTag is the cell tag added to exported cells that originate
+// from Sourcegraph-specific block types (query, file, symbol). The original
+// block is stored under the "sourcegraph" key of the cell metadata so that it
+// can be restored losslessly on import.
+const jupyterSourcegraphTag = "sourcegraph"
+
+type jupyterNotebook struct {
+	Cells         []jupyterCell  `json:"cells"`
+	Metadata      map[string]any `json:"metadata"`
+	NBFormat      int            `json:"nbformat"`
+	NBFormatMinor int            `json:"nbformat_minor"`
+}
+
+type jupyterCell struct {
+	ID             string              `json:"id,omitempty"`
+	CellType       string              `json:"cell_type"`
+	Metadata       jupyterCellMetadata `json:"metadata"`
+	Source         jupyterSource       `json:"source"`
+	Outputs        []json.RawMessage   `json:"outputs,omitempty"`
+	ExecutionCount *int                `json:"execution_count,omitempty"`
+}
+
+type jupyterCellMetadata struct {
+	Tags        []string       `json:"tags,omitempty"`
+	Sourcegraph *NotebookBlock `json:"sourcegraph,omitempty"`
+}
+
+// jupyterSource is the source of a Jupyter cell. The format allows either a
+// single string or a list of lines, so we accept both on import and always
+// export the list form, which is what Jupyter itself writes.
+type jupyterSource string
+
+func (s *jupyterSource) UnmarshalJSON(data []byte) error {
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*s = jupyterSource(strings.Join(lines, ""))
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return errors.Wrap(err, "invalid cell source")
+	}
+	*s = jupyterSource(text)
+	return nil
+}
+
+func (s jupyterSource) MarshalJSON() ([]byte, error) {
+	lines := strings.SplitAfter(string(s), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if lines == nil {
+		lines = []string{}
+	}
+	return json.Marshal(lines)
+}
+
+// ExportJupyter serializes the notebook in the Jupyter .ipynb format.
+//
+// Markdown blocks are exported as markdown cells. Query blocks are exported as
+// raw cells containing the query text. File and symbol blocks have no Jupyter
+// equivalent and are exported as markdown cells containing a human-readable
+// reference. All non-markdown blocks are tagged and carry the original block in
+// their metadata, so a round trip through ImportJupyter is lossless.
+func ExportJupyter(notebook *Notebook) ([]byte, error) {
+	nb := jupyterNotebook{
+		Cells: make([]jupyterCell, 0, len(notebook.Blocks)),
+		Metadata: map[string]any{
+			"title": notebook.Title,
+		},
+		NBFormat:      jupyterNBFormat,
+		NBFormatMinor: jupyterNBFormatMinor,
+	}
+
+	for _, block := range notebook.Blocks {
+		block := block
+		cell := jupyterCell{ID: block.ID}
+		switch block.Type {
+		case NotebookMarkdownBlockType:
+			cell.CellType = "markdown"
+			cell.Source = jupyterSource(block.MarkdownInput.Text)
+		case NotebookQueryBlockType:
+			cell.CellType = "raw"
+			cell.Source = jupyterSource(block.QueryInput.Text)
+			cell.Metadata = jupyterCellMetadata{Tags: []string{jupyterSourcegraphTag}, Sourcegraph: &block}
+		case NotebookFileBlockType:
+			cell.CellType = "markdown"
+			cell.Source = jupyterSource(describeFileBlock(block.FileInput))
+			cell.Metadata = jupyterCellMetadata{Tags: []string{jupyterSourcegraphTag}, Sourcegraph: &block}
+		case NotebookSymbolBlockType:
+			cell.CellType = "markdown"
+			cell.Source = jupyterSource(describeSymbolBlock(block.SymbolInput))
+			cell.Metadata = jupyterCellMetadata{Tags: []string{jupyterSourcegraphTag}, Sourcegraph: &block}
+		default:
+			return nil, errors.Errorf("invalid block type: %s", string(block.Type))
+		}
+		nb.Cells = append(nb.Cells, cell)
+	}
+
+	return json.MarshalIndent(nb, "", " ")
+}
+
+// ImportJupyter parses a Jupyter .ipynb document into notebook blocks. The
+// returned title is taken from the notebook metadata, if present.
+//
+// Cells that were exported by ExportJupyter are restored to their original
+// block. Other markdown cells become markdown blocks. Code and raw cells, which
+// Sourcegraph notebooks cannot execute, are converted into markdown blocks
+// containing a fenced code block; their outputs are dropped.
+func ImportJupyter(data []byte) (title string, blocks NotebookBlocks, err error) {
+	var nb jupyterNotebook
+	if err := json.Unmarshal(data, &nb); err != nil {
+		return "", nil, errors.Wrap(err, "invalid Jupyter notebook")
+	}
+	if nb.NBFormat != jupyterNBFormat {
+		return "", nil, errors.Errorf("unsupported Jupyter notebook format version: %d", nb.NBFormat)
+	}
+
+	if t, ok := nb.Metadata["title"].(string); ok {
+		title = t
+	}
+	language := jupyterLanguage(nb.Metadata)
+
+	blocks = make(NotebookBlocks, 0, len(nb.Cells))
+	seenIDs := map[string]struct{}{}
+	for _, cell := range nb.Cells {
+		block, err := convertJupyterCell(cell, language)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// Cell IDs are optional before nbformat 4.5, and are not guaranteed to
+		// be unique in hand-edited notebooks.
+		if _, ok := seenIDs[block.ID]; ok || block.ID == "" {
+			block.ID = uuid.NewString()
+		}
+		seenIDs[block.ID] = struct{}{}
+
+		blocks = append(blocks, block)
+	}
+
+	if err := validateNotebookBlocks(blocks); err != nil {
+		return "", nil, err
+	}
+	return title, blocks, nil
+}
+
+func convertJupyterCell(cell jupyterCell, language string) (NotebookBlock, error) {
+	if original := cell.Metadata.Sourcegraph; original != nil && hasTag(cell.Metadata.Tags, jupyterSourcegraphTag) {
+		block := *original
+		block.ID = cell.ID
+		// Query blocks are editable in Jupyter, prefer the cell source over
+		// the stored block so that edits are preserved.
+		if block.Type == NotebookQueryBlockType {
+			block.QueryInput = &NotebookQueryBlockInput{Text: string(cell.Source)}
+		}
+		if err := validateNotebookBlock(block); err != nil {
+			return NotebookBlock{}, err
+		}
+		return block, nil
+	}
+
+	switch cell.CellType {
+	case "markdown":
+		return NotebookBlock{
+			ID:            cell.ID,
+			Type:          NotebookMarkdownBlockType,
+			MarkdownInput: &NotebookMarkdownBlockInput{Text: string(cell.Source)},
+		}, nil
+	case "code":
+		return NotebookBlock{
+			ID:            cell.ID,
+			Type:          NotebookMarkdownBlockType,
+			MarkdownInput: &NotebookMarkdownBlockInput{Text: fencedCodeBlock(language, string(cell.Source))},
+		}, nil
+	case "raw":
+		return NotebookBlock{
+			ID:            cell.ID,
+			Type:          NotebookMarkdownBlockType,
+			MarkdownInput: &NotebookMarkdownBlockInput{Text: fencedCodeBlock("", string(cell.Source))},
+		}, nil
+	default:
+		return NotebookBlock{}, errors.Errorf("unsupported Jupyter cell type: %s", cell.CellType)
+	}
+}
+
+// jupyterLanguage returns the programming language of the notebook kernel, as
+// recorded in the notebook metadata.
+func jupyterLanguage(metadata map[string]any) string {
+	if info, ok := metadata["language_info"].(map[string]any); ok {
+		if name, ok := info["name"].(string); ok {
+			return name
+		}
+	}
+	if spec, ok := metadata["kernelspec"].(map[string]any); ok {
+		if language, ok := spec["language"].(string); ok {
+			return language
+		}
+	}
+	return ""
+}
+
+func fencedCodeBlock(language, source string) string {
+	return fmt.Sprintf("```%s\n%s\n```", language, strings.TrimSuffix(source, "\n"))
+}
+
+func describeFileBlock(input *NotebookFileBlockInput) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "File `%s` in repository `%s`", input.FilePath, input.RepositoryName)
+	if input.Revision != nil && *input.Revision != "" {
+		fmt.Fprintf(&b, " at revision `%s`", *input.Revision)
+	}
+	if input.LineRange != nil {
+		fmt.Fprintf(&b, ", lines %d-%d", input.LineRange.StartLine, input.LineRange.EndLine)
+	}
+	return b.String()
+}
+
+func describeSymbolBlock(input *NotebookSymbolBlockInput) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Symbol `%s` in file `%s` in repository `%s`", input.SymbolName, input.FilePath, input.RepositoryName)
+	if input.Revision != nil && *input.Revision != "" {
+		fmt.Fprintf(&b, " at revision `%s`", *input.Revision)
+	}
+	return b.String()
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
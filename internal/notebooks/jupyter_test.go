@@ -0,0 +1,113 @@
+package notebooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJupyterRoundTrip(t *testing.T) {
+	revision := "main"
+	notebook := &Notebook{
+		Title: "My notebook",
+		Blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "# Title\n\nSome text"}},
+			{ID: "id2", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "repo:a b"}},
+			{ID: "id3", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{RepositoryName: "github.com/a/b", FilePath: "c.go", Revision: &revision, LineRange: &LineRange{1, 10}}},
+			{ID: "id4", Type: NotebookSymbolBlockType, SymbolInput: &NotebookSymbolBlockInput{RepositoryName: "github.com/a/b", FilePath: "c.go", LineContext: 3, SymbolName: "Foo", SymbolKind: "FUNCTION"}},
+		},
+	}
+
+	data, err := ExportJupyter(notebook)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title, blocks, err := ImportJupyter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != notebook.Title {
+		t.Errorf("unexpected title, want %q, got %q", notebook.Title, title)
+	}
+	if diff := cmp.Diff(notebook.Blocks, blocks); diff != "" {
+		t.Errorf("unexpected blocks (-want +got):\n%s", diff)
+	}
+}
+
+func TestImportJupyter(t *testing.T) {
+	data := `{
+ "cells": [
+  {"cell_type": "markdown", "id": "a", "metadata": {}, "source": ["# Analysis\n", "text"]},
+  {"cell_type": "code", "id": "b", "metadata": {}, "execution_count": 1, "outputs": [{"output_type": "stream", "text": "1"}], "source": "print(1)\n"},
+  {"cell_type": "raw", "id": "b", "metadata": {}, "source": "raw text"},
+  {"cell_type": "raw", "id": "c", "metadata": {"tags": ["sourcegraph"], "sourcegraph": {"id": "c", "type": "query", "queryInput": {"text": "old"}}}, "source": "repo:a new"}
+ ],
+ "metadata": {"language_info": {"name": "python"}},
+ "nbformat": 4,
+ "nbformat_minor": 2
+}`
+
+	title, blocks, err := ImportJupyter([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title != "" {
+		t.Errorf("unexpected title %q", title)
+	}
+	if len(blocks) != 4 {
+		t.Fatalf("unexpected number of blocks, want 4, got %d", len(blocks))
+	}
+
+	want := []struct {
+		typ  NotebookBlockType
+		text string
+	}{
+		{NotebookMarkdownBlockType, "# Analysis\ntext"},
+		{NotebookMarkdownBlockType, "```python\nprint(1)\n```"},
+		{NotebookMarkdownBlockType, "```\nraw text\n```"},
+		{NotebookQueryBlockType, "repo:a new"},
+	}
+	for i, w := range want {
+		block := blocks[i]
+		if block.Type != w.typ {
+			t.Errorf("block %d: unexpected type, want %q, got %q", i, w.typ, block.Type)
+		}
+		var text string
+		switch block.Type {
+		case NotebookMarkdownBlockType:
+			text = block.MarkdownInput.Text
+		case NotebookQueryBlockType:
+			text = block.QueryInput.Text
+		}
+		if text != w.text {
+			t.Errorf("block %d: unexpected text, want %q, got %q", i, w.text, text)
+		}
+	}
+
+	// The duplicate cell ID must have been replaced.
+	if blocks[2].ID == "b" || blocks[2].ID == "" {
+		t.Errorf("expected duplicate cell ID to be replaced, got %q", blocks[2].ID)
+	}
+}
+
+func TestImportJupyterErrors(t *testing.T) {
+	tests := []struct {
+		data    string
+		wantErr string
+	}{
+		{data: `{"cells": [], "nbformat": 3}`, wantErr: "unsupported Jupyter notebook format version: 3"},
+		{data: `{"cells": [{"cell_type": "widget", "source": ""}], "nbformat": 4}`, wantErr: "unsupported Jupyter cell type: widget"},
+		{data: `{"cells": [{"cell_type": "raw", "id": "a", "metadata": {"tags": ["sourcegraph"], "sourcegraph": {"type": "file"}}, "source": ""}], "nbformat": 4}`, wantErr: "invalid file block with id: a"},
+	}
+
+	for _, tt := range tests {
+		_, _, err := ImportJupyter([]byte(tt.data))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if err.Error() != tt.wantErr {
+			t.Errorf("unexpected error, want %q, got %q", tt.wantErr, err.Error())
+		}
+	}
+}
@@ -238,6 +238,7 @@ func (op *Operation) With(ctx context.Context, err *error, args Args) (context.C
 	parentTraceContext := trace.Context(ctx)
 	start := time.Now()
 	tr, ctx := op.startTrace(ctx)
+	ctx = context.WithValue(ctx, operationNameKey{}, op.name)
 
 	event := honey.NoopEvent()
 	snakecaseOpName := toSnakeCase(op.name)
@@ -307,6 +308,15 @@ func (op *Operation) With(ctx context.Context, err *error, args Args) (context.C
 	}
 }
 
+type operationNameKey struct{}
+
+// OperationName returns the name of the innermost operation observed on the
+// given context, or an empty string if there is none.
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationNameKey{}).(string)
+	return name
+}
+
 // startTrace creates a new Trace object and returns the wrapped context. This returns
 // an unmodified context and a nil startTrace if no tracer was supplied on the observation context.
 func (op *Operation) startTrace(ctx context.Context) (trace.Trace, context.Context) {
@@ -12,6 +12,7 @@ type storeIface interface {
 	UpdateDirection(ctx context.Context, id int, applyReverse bool) error
 	UpdateProgress(ctx context.Context, id int, progress float64) error
 	AddError(ctx context.Context, id int, message string) error
+	AddRowsProcessed(ctx context.Context, id int, n int64) error
 }
 
 type storeShim struct {
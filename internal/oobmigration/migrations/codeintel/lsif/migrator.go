@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
 )
 
 // migrator is a code-intelligence-specific out-of-band migration runner. This migrator can
@@ -230,6 +231,14 @@ func (m *migrator) run(ctx context.Context, sourceVersion, targetVersion int, dr
 	if err != nil {
 		return err
 	}
+
+	// Report the migrated rows only once the transaction has been committed.
+	var numRows int
+	defer func() {
+		if err == nil {
+			oobmigration.ReportRowsProcessed(ctx, numRows)
+		}
+	}()
 	defer func() { err = tx.Done(err) }()
 
 	dumpID, ok, err := m.selectAndLockDump(ctx, tx, sourceVersion)
@@ -244,6 +253,8 @@ func (m *migrator) run(ctx context.Context, sourceVersion, targetVersion int, dr
 	if err != nil {
 		return err
 	}
+	// The channel is buffered to the batch size, so it holds all of the selected rows.
+	numRows = len(rowValues)
 
 	if err := m.updateBatch(ctx, tx, dumpID, targetVersion, rowValues); err != nil {
 		return err
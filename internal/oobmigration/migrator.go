@@ -1,6 +1,9 @@
 package oobmigration
 
-import "context"
+import (
+	"context"
+	"sync/atomic"
+)
 
 // Migrator handles migrating data from one format into another in a way that cannot easily
 // be done via the in-band migration mechanism. This may be due to a large amount of data, or
@@ -26,3 +29,14 @@ type Migrator interface {
 	// therefore do not need to be undone prior to a downgrade.
 	Down(ctx context.Context) error
 }
+
+type rowsProcessedKey struct{}
+
+// ReportRowsProcessed records that the current invocation of the Up or Down method of a
+// migrator migrated n records. Migrators that can cheaply count the records they touch
+// should call this so that the number of processed rows can be shown to site admins.
+func ReportRowsProcessed(ctx context.Context, n int) {
+	if counter, ok := ctx.Value(rowsProcessedKey{}).(*int64); ok {
+		atomic.AddInt64(counter, int64(n))
+	}
+}
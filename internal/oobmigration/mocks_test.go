@@ -402,6 +402,9 @@ type MockStoreIface struct {
 	// AddErrorFunc is an instance of a mock function object controlling the
 	// behavior of the method AddError.
 	AddErrorFunc *StoreIfaceAddErrorFunc
+	// AddRowsProcessedFunc is an instance of a mock function object
+	// controlling the behavior of the method AddRowsProcessed.
+	AddRowsProcessedFunc *StoreIfaceAddRowsProcessedFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *StoreIfaceDoneFunc
@@ -431,6 +434,11 @@ func NewMockStoreIface() *MockStoreIface {
 				return
 			},
 		},
+		AddRowsProcessedFunc: &StoreIfaceAddRowsProcessedFunc{
+			defaultHook: func(context.Context, int, int64) (r0 error) {
+				return
+			},
+		},
 		DoneFunc: &StoreIfaceDoneFunc{
 			defaultHook: func(error) (r0 error) {
 				return
@@ -473,6 +481,11 @@ func NewStrictMockStoreIface() *MockStoreIface {
 				panic("unexpected invocation of MockStoreIface.AddError")
 			},
 		},
+		AddRowsProcessedFunc: &StoreIfaceAddRowsProcessedFunc{
+			defaultHook: func(context.Context, int, int64) error {
+				panic("unexpected invocation of MockStoreIface.AddRowsProcessed")
+			},
+		},
 		DoneFunc: &StoreIfaceDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockStoreIface.Done")
@@ -511,6 +524,7 @@ func NewStrictMockStoreIface() *MockStoreIface {
 // redefined here as it is unexported in the source package.
 type surrogateMockStoreIface interface {
 	AddError(context.Context, int, string) error
+	AddRowsProcessed(context.Context, int, int64) error
 	Done(error) error
 	List(context.Context) ([]Migration, error)
 	SynchronizeMetadata(context.Context) error
@@ -526,6 +540,9 @@ func NewMockStoreIfaceFrom(i surrogateMockStoreIface) *MockStoreIface {
 		AddErrorFunc: &StoreIfaceAddErrorFunc{
 			defaultHook: i.AddError,
 		},
+		AddRowsProcessedFunc: &StoreIfaceAddRowsProcessedFunc{
+			defaultHook: i.AddRowsProcessed,
+		},
 		DoneFunc: &StoreIfaceDoneFunc{
 			defaultHook: i.Done,
 		},
@@ -655,6 +672,114 @@ func (c StoreIfaceAddErrorFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreIfaceAddRowsProcessedFunc describes the behavior when the
+// AddRowsProcessed method of the parent MockStoreIface instance is invoked.
+type StoreIfaceAddRowsProcessedFunc struct {
+	defaultHook func(context.Context, int, int64) error
+	hooks       []func(context.Context, int, int64) error
+	history     []StoreIfaceAddRowsProcessedFuncCall
+	mutex       sync.Mutex
+}
+
+// AddRowsProcessed delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStoreIface) AddRowsProcessed(v0 context.Context, v1 int, v2 int64) error {
+	r0 := m.AddRowsProcessedFunc.nextHook()(v0, v1, v2)
+	m.AddRowsProcessedFunc.appendCall(StoreIfaceAddRowsProcessedFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddRowsProcessed
+// method of the parent MockStoreIface instance is invoked and the hook
+// queue is empty.
+func (f *StoreIfaceAddRowsProcessedFunc) SetDefaultHook(hook func(context.Context, int, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddRowsProcessed method of the parent MockStoreIface instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreIfaceAddRowsProcessedFunc) PushHook(hook func(context.Context, int, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreIfaceAddRowsProcessedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreIfaceAddRowsProcessedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int64) error {
+		return r0
+	})
+}
+
+func (f *StoreIfaceAddRowsProcessedFunc) nextHook() func(context.Context, int, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreIfaceAddRowsProcessedFunc) appendCall(r0 StoreIfaceAddRowsProcessedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreIfaceAddRowsProcessedFuncCall objects
+// describing the invocations of this function.
+func (f *StoreIfaceAddRowsProcessedFunc) History() []StoreIfaceAddRowsProcessedFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreIfaceAddRowsProcessedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreIfaceAddRowsProcessedFuncCall is an object that describes an
+// invocation of method AddRowsProcessed on an instance of MockStoreIface.
+type StoreIfaceAddRowsProcessedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreIfaceAddRowsProcessedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreIfaceAddRowsProcessedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreIfaceDoneFunc describes the behavior when the Done method of the
 // parent MockStoreIface instance is invoked.
 type StoreIfaceDoneFunc struct {
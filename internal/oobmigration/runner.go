@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/derision-test/glock"
@@ -196,7 +197,7 @@ func (r *Runner) UpdateDirection(ctx context.Context, ids []int, applyReverse bo
 // Start runs registered migrators on a loop until they complete. This method will periodically
 // re-read from the database in order to refresh its current view of the migrations.
 func (r *Runner) Start(currentVersion Version) {
-	r.startInternal(func(migration Migration) bool {
+	r.startInternal(false, func(migration Migration) bool {
 		if CompareVersions(currentVersion, migration.Introduced) == VersionOrderBefore {
 			// current version before migration introduction
 			return false
@@ -210,20 +211,21 @@ func (r *Runner) Start(currentVersion Version) {
 // StartPartial runs registered migrators matching one of the given identifiers on a loop until
 // they complete. This method will periodically re-read from the database in order to refresh its
 // current view of the migrations. When the given set of identifiers is empty, all migrations in
-// the database with a registered migrator will be considered active.
+// the database with a registered migrator will be considered active. Migrations are run even if
+// they have been paused, as they were explicitly requested.
 func (r *Runner) StartPartial(ids []int) {
 	idMap := make(map[int]struct{}, len(ids))
 	for _, id := range ids {
 		idMap[id] = struct{}{}
 	}
 
-	r.startInternal(func(m Migration) bool {
+	r.startInternal(true, func(m Migration) bool {
 		_, ok := idMap[m.ID]
 		return ok
 	})
 }
 
-func (r *Runner) startInternal(shouldRunMigration func(m Migration) bool) {
+func (r *Runner) startInternal(ignorePause bool, shouldRunMigration func(m Migration) bool) {
 	defer close(r.finished)
 
 	ctx := r.ctx
@@ -241,6 +243,9 @@ func (r *Runner) startInternal(shouldRunMigration func(m Migration) bool) {
 			if !shouldRunMigration(migration) {
 				continue
 			}
+			if ignorePause {
+				migration.Paused = false
+			}
 
 			// Ensure we have a migration routine running for this migration
 			r.ensureProcessorIsRunning(&wg, migrationProcesses, migration.ID, func(ch <-chan Migration) {
@@ -260,7 +265,7 @@ func (r *Runner) startInternal(shouldRunMigration func(m Migration) bool) {
 		loop:
 			for {
 				select {
-				case migrationProcesses[migration.ID] <- migrations[i]:
+				case migrationProcesses[migration.ID] <- migration:
 					break loop
 				case <-migrationProcesses[migration.ID]:
 				}
@@ -343,7 +348,8 @@ type migratorOptions struct {
 
 // runMigrator runs the given migrator function periodically (on each read from ticker)
 // while the migration is not complete. We will periodically (on each read from migrations)
-// update our current view of the migration progress and (more importantly) its direction.
+// update our current view of the migration progress and (more importantly) its direction
+// and whether it has been paused.
 func runMigrator(ctx context.Context, store storeIface, migrator Migrator, migrations <-chan Migration, options migratorOptions, logger log.Logger, operations *operations) {
 	// Get initial migration. This channel will close when the context
 	// is canceled, so we don't need to do any more complex select here.
@@ -376,7 +382,7 @@ func runMigrator(ctx context.Context, store storeIface, migrator Migrator, migra
 			}
 
 		case <-options.ticker.Chan():
-			if !migration.Complete() {
+			if !migration.Complete() && !migration.Paused {
 				// Run the migration only if there's something left to do
 				if err := runMigrationFunction(ctx, store, &migration, migrator, logger, operations); err != nil {
 					if !errors.Is(err, ctx.Err()) {
@@ -401,7 +407,10 @@ func runMigrationFunction(ctx context.Context, store storeIface, migration *Migr
 		migrationFunc = runMigrationDown
 	}
 
-	if migrationErr := migrationFunc(ctx, migration, migrator, logger, operations); migrationErr != nil {
+	var rowsProcessed int64
+	migrationCtx := context.WithValue(ctx, rowsProcessedKey{}, &rowsProcessed)
+
+	if migrationErr := migrationFunc(migrationCtx, migration, migrator, logger, operations); migrationErr != nil {
 		if !errors.Is(migrationErr, ctx.Err()) {
 			logger.Error("Failed to perform migration", log.Error(migrationErr), log.Int("migrationID", migration.ID))
 		}
@@ -415,6 +424,13 @@ func runMigrationFunction(ctx context.Context, store storeIface, migration *Migr
 		}
 	}
 
+	if n := atomic.LoadInt64(&rowsProcessed); n > 0 {
+		if err := store.AddRowsProcessed(ctx, migration.ID, n); err != nil {
+			return err
+		}
+		migration.RowsProcessed += n
+	}
+
 	return updateProgress(ctx, store, migration, migrator)
 }
 
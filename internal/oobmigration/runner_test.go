@@ -34,7 +34,7 @@ func TestRunner(t *testing.T) {
 		t.Fatalf("unexpected error registering migrator: %s", err)
 	}
 
-	go runner.startInternal(allowAll)
+	go runner.startInternal(false, allowAll)
 	tickN(ticker, 3)
 	runner.Stop()
 
@@ -65,7 +65,7 @@ func TestRunnerError(t *testing.T) {
 		t.Fatalf("unexpected error registering migrator: %s", err)
 	}
 
-	go runner.startInternal(allowAll)
+	go runner.startInternal(false, allowAll)
 	tickN(ticker, 1)
 	runner.Stop()
 
@@ -120,7 +120,7 @@ func TestRunnerRemovesCompleted(t *testing.T) {
 		t.Fatalf("unexpected error registering migrator: %s", err)
 	}
 
-	go runner.startInternal(allowAll)
+	go runner.startInternal(false, allowAll)
 	tickN(ticker1, 5)
 	tickN(ticker2, 5)
 	tickN(ticker3, 5)
@@ -295,6 +295,79 @@ func TestRunMigratorMigrationDesyncedFromData(t *testing.T) {
 	}
 }
 
+func TestRunMigratorPaused(t *testing.T) {
+	store := NewMockStoreIface()
+	logger := logtest.Scoped(t)
+	ticker := glock.NewMockTicker(time.Second)
+
+	migrator := NewMockMigrator()
+	migrator.ProgressFunc.SetDefaultReturn(0.5, nil)
+
+	runMigratorWrapped(store, migrator, logger, ticker, func(migrations chan<- Migration) {
+		migrations <- Migration{ID: 1, Progress: 0.5, Paused: true}
+		tickN(ticker, 3)
+		migrations <- Migration{ID: 1, Progress: 0.5, Paused: false}
+		tickN(ticker, 2)
+	})
+
+	if callCount := len(migrator.UpFunc.History()); callCount != 2 {
+		t.Errorf("unexpected number of calls to Up. want=%d have=%d", 2, callCount)
+	}
+}
+
+func TestRunnerPartialIgnoresPause(t *testing.T) {
+	store := NewMockStoreIface()
+	ticker := glock.NewMockTicker(time.Second)
+	refreshTicker := glock.NewMockTicker(time.Second * 30)
+
+	store.ListFunc.SetDefaultReturn([]Migration{
+		{ID: 1, Progress: 0.5, Paused: true},
+	}, nil)
+
+	runner := newRunner(&observation.TestContext, store, refreshTicker)
+
+	migrator := NewMockMigrator()
+	migrator.ProgressFunc.SetDefaultReturn(0.5, nil)
+
+	if err := runner.Register(1, migrator, MigratorOptions{ticker: ticker}); err != nil {
+		t.Fatalf("unexpected error registering migrator: %s", err)
+	}
+
+	go runner.startInternal(true, allowAll)
+	tickN(ticker, 3)
+	runner.Stop()
+
+	if callCount := len(migrator.UpFunc.History()); callCount != 3 {
+		t.Errorf("unexpected number of calls to Up. want=%d have=%d", 3, callCount)
+	}
+}
+
+func TestRunMigratorRowsProcessed(t *testing.T) {
+	store := NewMockStoreIface()
+	logger := logtest.Scoped(t)
+	ticker := glock.NewMockTicker(time.Second)
+
+	migrator := NewMockMigrator()
+	migrator.ProgressFunc.SetDefaultReturn(0.5, nil)
+	migrator.UpFunc.PushHook(func(ctx context.Context) error {
+		ReportRowsProcessed(ctx, 100)
+		ReportRowsProcessed(ctx, 20)
+		return nil
+	})
+
+	runMigratorWrapped(store, migrator, logger, ticker, func(migrations chan<- Migration) {
+		migrations <- Migration{ID: 1, Progress: 0.5}
+		tickN(ticker, 2)
+	})
+
+	// The second batch did not report any rows
+	if calls := store.AddRowsProcessedFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of calls to AddRowsProcessed. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg2 != 120 {
+		t.Errorf("unexpected number of rows. want=%d have=%d", 120, calls[0].Arg2)
+	}
+}
+
 // runMigratorWrapped creates a migrations channel, then passes it to both the runMigrator
 // function and the given interact function, which execute concurrently. This channel can
 // control the behavior of the migration controller from within the interact function.
@@ -413,7 +486,7 @@ func TestRunnerBoundsFilter(t *testing.T) {
 		t.Fatalf("unexpected error registering migrator: %s", err)
 	}
 
-	go runner.startInternal(func(m Migration) bool {
+	go runner.startInternal(false, func(m Migration) bool {
 		return m.ID != 2
 	})
 	tickN(ticker, 64)
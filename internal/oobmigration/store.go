@@ -42,6 +42,13 @@ type Migration struct {
 	Errors         []MigrationError
 	// Metadata can be used to store custom JSON data
 	Metadata json.RawMessage
+	// Paused is true if a site admin has paused the migration on this instance.
+	Paused bool
+	// RowsProcessed is the number of records migrated so far, as reported by the migrator.
+	RowsProcessed int64
+	// ProgressRate is the smoothed rate of progress, as a fraction of the migration per second.
+	// It is nil until the progress of the migration has changed twice.
+	ProgressRate *float64
 }
 
 // Complete returns true if the migration has 0 un-migrated record in whichever
@@ -58,6 +65,25 @@ func (m Migration) Complete() bool {
 	return false
 }
 
+// EstimatedTimeRemaining returns the time the migration needs to complete in its current
+// direction at the current rate of progress. The second return value is false if there is
+// no estimate, either because the migration is paused or because it is not progressing.
+func (m Migration) EstimatedTimeRemaining() (time.Duration, bool) {
+	if m.Complete() {
+		return 0, true
+	}
+	if m.Paused || m.ProgressRate == nil || *m.ProgressRate <= 0 {
+		return 0, false
+	}
+
+	remaining := 1 - m.Progress
+	if m.ApplyReverse {
+		remaining = m.Progress
+	}
+
+	return time.Duration(remaining / *m.ProgressRate * float64(time.Second)), true
+}
+
 // MigrationError pairs an error message and the time the error occurred.
 type MigrationError struct {
 	Message string
@@ -94,6 +120,9 @@ func scanMigrations(rows *sql.Rows, queryErr error) (_ []Migration, err error) {
 			&value.IsEnterprise,
 			&value.ApplyReverse,
 			&value.Metadata,
+			&value.Paused,
+			&value.RowsProcessed,
+			&value.ProgressRate,
 			&dbutil.NullString{S: &message},
 			&created,
 		); err != nil {
@@ -343,12 +372,26 @@ ON CONFLICT (id) DO UPDATE SET
 	deprecated = %s
 `
 
+// progressColumns are the columns tracking whether migrations are paused and their rate of
+// progress. The migrator reads migrations at intermediate stops of multi-version upgrades,
+// before these columns are added, in which case progressFallbackColumns are selected instead.
+var (
+	progressColumns         = sqlf.Sprintf("m.paused, m.rows_processed, m.progress_rate")
+	progressFallbackColumns = sqlf.Sprintf("false AS paused, 0 AS rows_processed, NULL::double precision AS progress_rate")
+)
+
 // GetByID retrieves a migration by its identifier. If the migration does not exist, a false
 // valued flag is returned.
 func (s *Store) GetByID(ctx context.Context, id int) (_ Migration, _ bool, err error) {
-	migrations, err := scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(getByIDQuery, id)))
+	migrations, err := scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(getByIDQuery, progressColumns, id)))
 	if err != nil {
-		return Migration{}, false, err
+		if !shouldFallbackProgress(err) {
+			return Migration{}, false, err
+		}
+
+		if migrations, err = scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(getByIDQuery, progressFallbackColumns, id))); err != nil {
+			return Migration{}, false, err
+		}
 	}
 
 	if len(migrations) == 0 {
@@ -375,6 +418,7 @@ SELECT
 	m.is_enterprise,
 	m.apply_reverse,
 	m.metadata,
+	%s,
 	e.message,
 	e.created
 FROM out_of_band_migrations m
@@ -384,9 +428,15 @@ ORDER BY e.created desc
 `
 
 func (s *Store) GetByIDs(ctx context.Context, ids []int) (_ []Migration, err error) {
-	migrations, err := scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(getByIDsQuery, pq.Array(ids))))
+	migrations, err := scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(getByIDsQuery, progressColumns, pq.Array(ids))))
 	if err != nil {
-		return nil, err
+		if !shouldFallbackProgress(err) {
+			return nil, err
+		}
+
+		if migrations, err = scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(getByIDsQuery, progressFallbackColumns, pq.Array(ids)))); err != nil {
+			return nil, err
+		}
 	}
 
 	wanted := collections.NewSet(ids...)
@@ -420,6 +470,7 @@ SELECT
 	m.is_enterprise,
 	m.apply_reverse,
 	m.metadata,
+	%s,
 	e.message,
 	e.created
 FROM out_of_band_migrations m
@@ -442,13 +493,16 @@ func (s *Store) List(ctx context.Context) (_ []Migration, err error) {
 		sqlf.Sprintf("m.id = ANY(%s)", pq.Array(yamlMigrationIDs)),
 	}
 
-	migrations, err := scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(listQuery, sqlf.Join(conds, "AND"))))
+	migrations, err := scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(listQuery, progressColumns, sqlf.Join(conds, "AND"))))
 	if err != nil {
+		if shouldFallbackProgress(err) {
+			return scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(listQuery, progressFallbackColumns, sqlf.Join(conds, "AND"))))
+		}
 		if !shouldFallback(err) {
 			return nil, err
 		}
 
-		return scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(listFallbackQuery, sqlf.Join(conds, "AND"))))
+		return scanMigrations(s.Store.Query(ctx, sqlf.Sprintf(listFallbackQuery, progressFallbackColumns, sqlf.Join(conds, "AND"))))
 	}
 
 	return migrations, nil
@@ -471,6 +525,7 @@ SELECT
 	m.is_enterprise,
 	m.apply_reverse,
 	m.metadata,
+	%s,
 	e.message,
 	e.created
 FROM out_of_band_migrations m
@@ -506,6 +561,7 @@ SELECT
 	true AS is_enterprise,
 	m.apply_reverse,
 	m.metadata,
+	%s,
 	e.message,
 	e.created
 FROM split_migrations m
@@ -516,26 +572,95 @@ ORDER BY m.id desc, e.created DESC
 
 // UpdateDirection updates the direction for the given migration.
 func (s *Store) UpdateDirection(ctx context.Context, id int, applyReverse bool) error {
-	return s.Store.Exec(ctx, sqlf.Sprintf(updateDirectionQuery, applyReverse, id))
+	if err := s.Store.Exec(ctx, sqlf.Sprintf(updateDirectionQuery, applyReverse, applyReverse, id)); err != nil {
+		if !shouldFallbackProgress(err) {
+			return err
+		}
+
+		return s.Store.Exec(ctx, sqlf.Sprintf(updateDirectionFallbackQuery, applyReverse, id))
+	}
+
+	return nil
 }
 
 const updateDirectionQuery = `
+UPDATE out_of_band_migrations SET
+	-- The rate of progress in the previous direction says nothing about the new direction
+	progress_rate = CASE WHEN apply_reverse = %s THEN progress_rate END,
+	apply_reverse = %s
+WHERE id = %s
+`
+
+// UpdatePaused pauses or resumes the given migration. Paused migrations are not run by the
+// instance, but can still be run explicitly by the migrator.
+func (s *Store) UpdatePaused(ctx context.Context, id int, paused bool) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(updatePausedQuery, paused, id))
+}
+
+const updateDirectionFallbackQuery = `
 UPDATE out_of_band_migrations SET apply_reverse = %s WHERE id = %s
 `
 
+const updatePausedQuery = `
+UPDATE out_of_band_migrations SET paused = %s WHERE id = %s
+`
+
 // UpdateProgress updates the progress for the given migration.
 func (s *Store) UpdateProgress(ctx context.Context, id int, progress float64) error {
 	return s.updateProgress(ctx, id, progress, time.Now())
 }
 
 func (s *Store) updateProgress(ctx context.Context, id int, progress float64, now time.Time) error {
-	return s.Store.Exec(ctx, sqlf.Sprintf(updateProgressQuery, progress, now, id, progress))
+	rate := sqlf.Sprintf(updateProgressRateExpression, progress, now)
+	if err := s.Store.Exec(ctx, sqlf.Sprintf(updateProgressQuery, now, rate, rate, progress, now, now, id, progress)); err != nil {
+		if !shouldFallbackProgress(err) {
+			return err
+		}
+
+		return s.Store.Exec(ctx, sqlf.Sprintf(updateProgressFallbackQuery, progress, now, id, progress))
+	}
+
+	return nil
 }
 
+// updateProgressRateExpression is the rate of progress since the last change in progress.
+const updateProgressRateExpression = `abs(%s - progress) / extract(epoch FROM %s - progress_updated_at)`
+
 const updateProgressQuery = `
+UPDATE out_of_band_migrations SET
+	progress_rate = CASE
+		WHEN progress_updated_at IS NULL OR progress_updated_at >= %s THEN progress_rate
+		WHEN progress_rate IS NULL THEN %s
+		-- Smooth the rate so that a single slow or fast batch does not swing the estimate
+		ELSE 0.5 * (%s) + 0.5 * progress_rate
+	END,
+	progress = %s,
+	last_updated = %s,
+	progress_updated_at = %s
+WHERE id = %s AND progress != %s
+`
+
+const updateProgressFallbackQuery = `
 UPDATE out_of_band_migrations SET progress = %s, last_updated = %s WHERE id = %s AND progress != %s
 `
 
+// AddRowsProcessed increments the number of records migrated by the given migration.
+func (s *Store) AddRowsProcessed(ctx context.Context, id int, n int64) error {
+	if err := s.Store.Exec(ctx, sqlf.Sprintf(addRowsProcessedQuery, n, id)); err != nil {
+		// The count is only reported to site admins, there is nowhere to record it before
+		// the column is added.
+		if !shouldFallbackProgress(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const addRowsProcessedQuery = `
+UPDATE out_of_band_migrations SET rows_processed = rows_processed + %s WHERE id = %s
+`
+
 // UpdateMetadata updates the metadata for the given migration.
 func (s *Store) UpdateMetadata(ctx context.Context, id int, meta json.RawMessage) error {
 	return s.updateMetadata(ctx, id, meta, time.Now())
@@ -605,9 +730,26 @@ var columnsSupporingFallback = []string{
 }
 
 func shouldFallback(err error) bool {
+	return isUndefinedColumn(err, columnsSupporingFallback)
+}
+
+var progressColumnsSupportingFallback = []string{
+	"paused",
+	"rows_processed",
+	"progress_rate",
+	"progress_updated_at",
+}
+
+// shouldFallbackProgress returns true if the error is caused by the columns tracking the rate
+// of progress of migrations not existing yet.
+func shouldFallbackProgress(err error) bool {
+	return isUndefinedColumn(err, progressColumnsSupportingFallback)
+}
+
+func isUndefinedColumn(err error, columns []string) bool {
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) && pgErr.Code == "42703" {
-		for _, column := range columnsSupporingFallback {
+		for _, column := range columns {
 			if strings.Contains(pgErr.Message, column) {
 				return true
 			}
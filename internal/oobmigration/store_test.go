@@ -156,6 +156,69 @@ func TestSynchronizeMetadataFallback(t *testing.T) {
 	compareMigrations()
 }
 
+func TestProgressFallback(t *testing.T) {
+	// Note: package globals block test parallelism
+	withMigrationIDs(t, []int{1, 2, 3, 4, 5})
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := testStore(t, db)
+
+	// Intermediate stops of multi-version upgrades run before the columns are added
+	if err := store.Exec(ctx, sqlf.Sprintf(`
+		ALTER TABLE out_of_band_migrations
+			DROP COLUMN paused,
+			DROP COLUMN rows_processed,
+			DROP COLUMN progress_rate,
+			DROP COLUMN progress_updated_at
+	`)); err != nil {
+		t.Fatalf("failed to alter table: %s", err)
+	}
+
+	if err := store.updateProgress(ctx, 3, 0.7, testTime.Add(time.Hour*7)); err != nil {
+		t.Fatalf("unexpected error updating progress: %s", err)
+	}
+	if err := store.UpdateDirection(ctx, 3, true); err != nil {
+		t.Fatalf("unexpected error updating direction: %s", err)
+	}
+	if err := store.AddRowsProcessed(ctx, 3, 10); err != nil {
+		t.Fatalf("unexpected error adding rows processed: %s", err)
+	}
+
+	expectedMigration := testMigrations[2] // ID = 3
+	expectedMigration.Progress = 0.7
+	expectedMigration.LastUpdated = pointers.Ptr(testTime.Add(time.Hour * 7))
+	expectedMigration.ApplyReverse = true
+
+	migration, exists, err := store.GetByID(ctx, 3)
+	if err != nil {
+		t.Fatalf("unexpected error getting migration: %s", err)
+	}
+	if !exists {
+		t.Fatalf("expected record to exist")
+	}
+	if diff := cmp.Diff(expectedMigration, migration); diff != "" {
+		t.Errorf("unexpected migration (-want +got):\n%s", diff)
+	}
+
+	migrations, err := store.GetByIDs(ctx, []int{1, 2, 3, 4, 5})
+	if err != nil {
+		t.Fatalf("unexpected error getting multiple migrations: %s", err)
+	}
+	if diff := cmp.Diff(expectedMigration, migrations[2]); diff != "" {
+		t.Errorf("unexpected migration (-want +got):\n%s", diff)
+	}
+
+	migrations, err = store.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing migrations: %s", err)
+	}
+	if len(migrations) != len(testMigrations) {
+		t.Fatalf("unexpected number of migrations. want=%d have=%d", len(testMigrations), len(migrations))
+	}
+}
+
 func TestList(t *testing.T) {
 	// Note: package globals block test parallelism
 	withMigrationIDs(t, []int{1, 2, 3, 4, 5})
@@ -261,6 +324,74 @@ func TestUpdateProgress(t *testing.T) {
 	}
 }
 
+func TestUpdateProgressRate(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := testStore(t, db)
+
+	for i, progress := range []float64{0.5, 0.6, 0.8} {
+		if err := store.updateProgress(context.Background(), 1, progress, testTime.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("unexpected error updating migration: %s", err)
+		}
+	}
+
+	migration, _, err := store.GetByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting migrations: %s", err)
+	}
+
+	// 0.1 and 0.2 per minute, smoothed
+	if migration.ProgressRate == nil || fmt.Sprintf("%.5f", *migration.ProgressRate) != "0.00250" {
+		t.Fatalf("unexpected progress rate. want=%.5f have=%v", 0.0025, migration.ProgressRate)
+	}
+
+	// Changing the direction discards the rate
+	if err := store.UpdateDirection(context.Background(), 1, true); err != nil {
+		t.Fatalf("unexpected error updating direction: %s", err)
+	}
+	migration, _, err = store.GetByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting migrations: %s", err)
+	}
+	if migration.ProgressRate != nil {
+		t.Fatalf("unexpected progress rate. want=nil have=%.5f", *migration.ProgressRate)
+	}
+}
+
+func TestUpdatePaused(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := testStore(t, db)
+
+	if err := store.UpdatePaused(context.Background(), 3, true); err != nil {
+		t.Fatalf("unexpected error pausing migration: %s", err)
+	}
+	if err := store.AddRowsProcessed(context.Background(), 3, 50); err != nil {
+		t.Fatalf("unexpected error adding processed rows: %s", err)
+	}
+	if err := store.AddRowsProcessed(context.Background(), 3, 25); err != nil {
+		t.Fatalf("unexpected error adding processed rows: %s", err)
+	}
+
+	migration, exists, err := store.GetByID(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error getting migrations: %s", err)
+	}
+	if !exists {
+		t.Fatalf("expected record to exist")
+	}
+
+	expectedMigration := testMigrations[2] // ID = 3
+	expectedMigration.Paused = true
+	expectedMigration.RowsProcessed = 75
+
+	if diff := cmp.Diff(expectedMigration, migration); diff != "" {
+		t.Errorf("unexpected migration (-want +got):\n%s", diff)
+	}
+}
+
 func TestUpdateMetadata(t *testing.T) {
 	t.Parallel()
 	now := testTime.Add(time.Hour * 7)
@@ -386,6 +517,30 @@ func TestAddErrorBounded(t *testing.T) {
 	}
 }
 
+func TestEstimatedTimeRemaining(t *testing.T) {
+	rate := 0.001
+
+	for _, tc := range []struct {
+		name      string
+		migration Migration
+		want      time.Duration
+		wantOK    bool
+	}{
+		{"up", Migration{Progress: 0.4, ProgressRate: &rate}, 600 * time.Second, true},
+		{"down", Migration{Progress: 0.4, ApplyReverse: true, ProgressRate: &rate}, 400 * time.Second, true},
+		{"complete", Migration{Progress: 1}, 0, true},
+		{"no rate", Migration{Progress: 0.4}, 0, false},
+		{"paused", Migration{Progress: 0.4, ProgressRate: &rate, Paused: true}, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.migration.EstimatedTimeRemaining()
+			if ok != tc.wantOK || got.Round(time.Second) != tc.want {
+				t.Errorf("unexpected estimate. want=%s,%v have=%s,%v", tc.want, tc.wantOK, got, ok)
+			}
+		})
+	}
+}
+
 //
 //
 
@@ -0,0 +1,468 @@
+package own
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/paths"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ImportFormat is the format of a bulk assigned ownership import.
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "CSV"
+	ImportFormatJSON ImportFormat = "JSON"
+)
+
+const (
+	// maxImportEntries is the maximum number of entries in a single import.
+	maxImportEntries = 10000
+	// maxGlobMatches is the maximum number of paths a single path glob can
+	// expand to. Assigning ownership of a directory is preferable to assigning
+	// ownership of many files individually.
+	maxGlobMatches = 1000
+)
+
+// AssignedOwnershipEntry is a single entry of a bulk assigned ownership import.
+// Exactly one of User and Team is expected to be set.
+type AssignedOwnershipEntry struct {
+	// Line is the line of the entry in a CSV import, or the 1-based position
+	// of the entry in a JSON import. It is used to report errors.
+	Line int `json:"-"`
+	// Repo is the name of the repository.
+	Repo string `json:"repo"`
+	// Path is the path of a file or directory in the repository, or a glob
+	// pattern matching files in the repository. An empty path assigns
+	// ownership of the whole repository.
+	Path string `json:"path"`
+	// User is the username or a verified email of the user to assign.
+	User string `json:"user"`
+	// Team is the name of the team to assign.
+	Team string `json:"team"`
+}
+
+// ParseAssignedOwnershipImport parses the entries of a bulk assigned ownership
+// import. CSV input has to start with a header naming the columns, out of
+// "repo", "path", "user" and "team". JSON input is an array of objects with the
+// same keys.
+func ParseAssignedOwnershipImport(format ImportFormat, r io.Reader) ([]AssignedOwnershipEntry, error) {
+	var entries []AssignedOwnershipEntry
+	var err error
+	switch format {
+	case ImportFormatCSV:
+		entries, err = parseCSVImport(r)
+	case ImportFormatJSON:
+		entries, err = parseJSONImport(r)
+	default:
+		return nil, errors.Newf("unsupported import format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > maxImportEntries {
+		return nil, errors.Newf("too many entries: %d, at most %d entries can be imported at once", len(entries), maxImportEntries)
+	}
+	return entries, nil
+}
+
+func parseCSVImport(r io.Reader) ([]AssignedOwnershipEntry, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading CSV header")
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "repo", "path", "user", "team":
+		default:
+			return nil, errors.Newf("unknown CSV column %q, expected repo, path, user or team", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, errors.Newf("duplicate CSV column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["repo"]; !ok {
+		return nil, errors.New(`CSV header is missing the "repo" column`)
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var entries []AssignedOwnershipEntry
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading CSV")
+		}
+		line, _ := cr.FieldPos(0)
+		entries = append(entries, AssignedOwnershipEntry{
+			Line: line,
+			Repo: field(record, "repo"),
+			Path: field(record, "path"),
+			User: field(record, "user"),
+			Team: field(record, "team"),
+		})
+	}
+	return entries, nil
+}
+
+func parseJSONImport(r io.Reader) ([]AssignedOwnershipEntry, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var entries []AssignedOwnershipEntry
+	if err := dec.Decode(&entries); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "decoding JSON")
+	}
+	for i := range entries {
+		entries[i].Line = i + 1
+		entries[i].Repo = strings.TrimSpace(entries[i].Repo)
+		entries[i].Path = strings.TrimSpace(entries[i].Path)
+		entries[i].User = strings.TrimSpace(entries[i].User)
+		entries[i].Team = strings.TrimSpace(entries[i].Team)
+	}
+	return entries, nil
+}
+
+// ImportedAssignment is an assigned owner or team resulting from an import.
+// Exactly one of User and Team is set.
+type ImportedAssignment struct {
+	// Line is the line of the entry the assignment results from.
+	Line int
+	Repo *types.Repo
+	Path string
+	User *types.User
+	Team *types.Team
+	// Exists is true if the assignment existed before the import, in which
+	// case it is left as is.
+	Exists bool
+}
+
+// ImportError describes why an entry of an import is invalid.
+type ImportError struct {
+	Line    int
+	Message string
+}
+
+// AssignedOwnershipImportResult is the outcome of a bulk assigned ownership
+// import.
+type AssignedOwnershipImportResult struct {
+	Assignments []ImportedAssignment
+	Errors      []ImportError
+	// Applied is true if the assignments were stored. This is only the case
+	// if this is not a dry run and all entries are valid.
+	Applied bool
+}
+
+// ImportAssignedOwnership validates the given entries, and if all of them are
+// valid and dryRun is false, assigns ownership for all of them on behalf of
+// the given user. Entries with a path glob are expanded to all the matching
+// files at the head of the default branch of the repository.
+//
+// Assignments that already exist are skipped, so importing the same entries
+// again is a no-op.
+func ImportAssignedOwnership(ctx context.Context, db database.DB, gitserverClient gitserver.Client, entries []AssignedOwnershipEntry, whoAssignedUserID int32, dryRun bool) (*AssignedOwnershipImportResult, error) {
+	im := &importer{
+		db:              db,
+		gitserverClient: gitserverClient,
+		repos:           map[string]*types.Repo{},
+		users:           map[string]*types.User{},
+		teams:           map[string]*types.Team{},
+		existingOwners:  map[api.RepoID]map[assignmentKey]struct{}{},
+		existingTeams:   map[api.RepoID]map[assignmentKey]struct{}{},
+	}
+
+	res := &AssignedOwnershipImportResult{}
+	seen := map[assignmentKey]struct{}{}
+	for _, entry := range entries {
+		assignments, err := im.resolve(ctx, entry)
+		if err != nil {
+			var invalid invalidEntryError
+			if !errors.As(err, &invalid) {
+				return nil, err
+			}
+			res.Errors = append(res.Errors, ImportError{Line: entry.Line, Message: invalid.msg})
+			continue
+		}
+		for _, a := range assignments {
+			key := keyOf(a)
+			// Entries can overlap, for example by using globs.
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			res.Assignments = append(res.Assignments, a)
+		}
+	}
+
+	if dryRun || len(res.Errors) > 0 {
+		return res, nil
+	}
+
+	err := db.WithTransact(ctx, func(tx database.DB) error {
+		for _, a := range res.Assignments {
+			if a.Exists {
+				continue
+			}
+			if a.User != nil {
+				if err := tx.AssignedOwners().Insert(ctx, a.User.ID, a.Repo.ID, a.Path, whoAssignedUserID); err != nil {
+					return errors.Wrapf(err, "assigning owner on line %d", a.Line)
+				}
+			} else {
+				if err := tx.AssignedTeams().Insert(ctx, a.Team.ID, a.Repo.ID, a.Path, whoAssignedUserID); err != nil {
+					return errors.Wrapf(err, "assigning team on line %d", a.Line)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Applied = true
+	return res, nil
+}
+
+// invalidEntryError is returned for entries that cannot be imported, as
+// opposed to errors that fail the whole import.
+type invalidEntryError struct {
+	msg string
+}
+
+func (e invalidEntryError) Error() string { return e.msg }
+
+func invalidEntry(format string, args ...any) error {
+	return invalidEntryError{msg: fmt.Sprintf(format, args...)}
+}
+
+type assignmentKey struct {
+	repoID api.RepoID
+	path   string
+	userID int32
+	teamID int32
+}
+
+func keyOf(a ImportedAssignment) assignmentKey {
+	key := assignmentKey{repoID: a.Repo.ID, path: a.Path}
+	if a.User != nil {
+		key.userID = a.User.ID
+	} else {
+		key.teamID = a.Team.ID
+	}
+	return key
+}
+
+// importer resolves import entries, caching the lookups shared between
+// entries.
+type importer struct {
+	db              database.DB
+	gitserverClient gitserver.Client
+
+	repos          map[string]*types.Repo
+	users          map[string]*types.User
+	teams          map[string]*types.Team
+	existingOwners map[api.RepoID]map[assignmentKey]struct{}
+	existingTeams  map[api.RepoID]map[assignmentKey]struct{}
+}
+
+func (im *importer) resolve(ctx context.Context, entry AssignedOwnershipEntry) ([]ImportedAssignment, error) {
+	if entry.Repo == "" {
+		return nil, invalidEntry("repository is required")
+	}
+	if (entry.User == "") == (entry.Team == "") {
+		return nil, invalidEntry("exactly one of user or team is required")
+	}
+
+	repo, err := im.repo(ctx, entry.Repo)
+	if err != nil {
+		return nil, err
+	}
+	var user *types.User
+	var team *types.Team
+	if entry.User != "" {
+		user, err = im.user(ctx, entry.User)
+	} else {
+		team, err = im.team(ctx, entry.Team)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	filePaths, err := im.paths(ctx, repo, entry.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make([]ImportedAssignment, 0, len(filePaths))
+	for _, p := range filePaths {
+		a := ImportedAssignment{
+			Line: entry.Line,
+			Repo: repo,
+			Path: p,
+			User: user,
+			Team: team,
+		}
+		a.Exists, err = im.exists(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, nil
+}
+
+func (im *importer) repo(ctx context.Context, name string) (*types.Repo, error) {
+	if repo, ok := im.repos[name]; ok {
+		return repo, nil
+	}
+	repo, err := im.db.Repos().GetByName(ctx, api.RepoName(name))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, invalidEntry("repository %q not found", name)
+		}
+		return nil, err
+	}
+	im.repos[name] = repo
+	return repo, nil
+}
+
+func (im *importer) user(ctx context.Context, usernameOrEmail string) (*types.User, error) {
+	if user, ok := im.users[usernameOrEmail]; ok {
+		return user, nil
+	}
+	var user *types.User
+	var err error
+	if strings.Contains(usernameOrEmail, "@") {
+		user, err = im.db.Users().GetByVerifiedEmail(ctx, usernameOrEmail)
+	} else {
+		user, err = im.db.Users().GetByUsername(ctx, usernameOrEmail)
+	}
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, invalidEntry("user %q not found", usernameOrEmail)
+		}
+		return nil, err
+	}
+	im.users[usernameOrEmail] = user
+	return user, nil
+}
+
+func (im *importer) team(ctx context.Context, name string) (*types.Team, error) {
+	if team, ok := im.teams[name]; ok {
+		return team, nil
+	}
+	team, err := im.db.Teams().GetTeamByName(ctx, name)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, invalidEntry("team %q not found", name)
+		}
+		return nil, err
+	}
+	im.teams[name] = team
+	return team, nil
+}
+
+// paths returns the paths to assign ownership of for the given path or glob.
+// Paths are relative to the repository root, and the root itself is denoted
+// by the empty path.
+func (im *importer) paths(ctx context.Context, repo *types.Repo, pathOrGlob string) ([]string, error) {
+	if !strings.ContainsAny(pathOrGlob, "*?[") {
+		return []string{strings.Trim(pathOrGlob, "/")}, nil
+	}
+
+	glob, err := paths.Compile(pathOrGlob)
+	if err != nil {
+		return nil, invalidEntry("invalid path glob %q: %s", pathOrGlob, err)
+	}
+	_, commitID, err := im.gitserverClient.GetDefaultBranch(ctx, repo.Name, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving default branch of %q", repo.Name)
+	}
+	if commitID == "" {
+		return nil, invalidEntry("path glob %q cannot be expanded, repository %q is empty", pathOrGlob, repo.Name)
+	}
+	files, err := im.gitserverClient.LsFiles(ctx, repo.Name, commitID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing files of %q", repo.Name)
+	}
+
+	var matches []string
+	for _, f := range files {
+		if !glob.Match("/" + f) {
+			continue
+		}
+		if len(matches) == maxGlobMatches {
+			return nil, invalidEntry("path glob %q matches more than %d files, assign ownership of a directory instead", pathOrGlob, maxGlobMatches)
+		}
+		matches = append(matches, f)
+	}
+	if len(matches) == 0 {
+		return nil, invalidEntry("path glob %q does not match any files", pathOrGlob)
+	}
+	return matches, nil
+}
+
+// exists returns true if the given assignment is already stored.
+func (im *importer) exists(ctx context.Context, a ImportedAssignment) (bool, error) {
+	if a.User != nil {
+		existing, ok := im.existingOwners[a.Repo.ID]
+		if !ok {
+			summaries, err := im.db.AssignedOwners().ListAssignedOwnersForRepo(ctx, a.Repo.ID)
+			if err != nil {
+				return false, err
+			}
+			existing = make(map[assignmentKey]struct{}, len(summaries))
+			for _, s := range summaries {
+				existing[assignmentKey{repoID: s.RepoID, path: s.FilePath, userID: s.OwnerUserID}] = struct{}{}
+			}
+			im.existingOwners[a.Repo.ID] = existing
+		}
+		_, ok = existing[keyOf(a)]
+		return ok, nil
+	}
+
+	existing, ok := im.existingTeams[a.Repo.ID]
+	if !ok {
+		summaries, err := im.db.AssignedTeams().ListAssignedTeamsForRepo(ctx, a.Repo.ID)
+		if err != nil {
+			return false, err
+		}
+		existing = make(map[assignmentKey]struct{}, len(summaries))
+		for _, s := range summaries {
+			existing[assignmentKey{repoID: s.RepoID, path: s.FilePath, teamID: s.OwnerTeamID}] = struct{}{}
+		}
+		im.existingTeams[a.Repo.ID] = existing
+	}
+	_, ok = existing[keyOf(a)]
+	return ok, nil
+}
@@ -0,0 +1,202 @@
+package own
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestParseAssignedOwnershipImport(t *testing.T) {
+	want := []AssignedOwnershipEntry{
+		{Line: 2, Repo: "github.com/sourcegraph/sourcegraph", Path: "internal/own", User: "alice"},
+		{Line: 3, Repo: "github.com/sourcegraph/sourcegraph", Path: "**/*.md", Team: "docs"},
+		{Line: 4, Repo: "github.com/sourcegraph/about", User: "bob@example.com"},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		got, err := ParseAssignedOwnershipImport(ImportFormatCSV, strings.NewReader(`repo,path,user,team
+github.com/sourcegraph/sourcegraph, internal/own, alice,
+github.com/sourcegraph/sourcegraph,**/*.md,,docs
+github.com/sourcegraph/about,,bob@example.com,
+`))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("CSV columns in any order", func(t *testing.T) {
+		got, err := ParseAssignedOwnershipImport(ImportFormatCSV, strings.NewReader("user,repo\nalice,github.com/sourcegraph/sourcegraph\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []AssignedOwnershipEntry{{Line: 2, Repo: "github.com/sourcegraph/sourcegraph", User: "alice"}}, got)
+	})
+
+	t.Run("CSV unknown column", func(t *testing.T) {
+		_, err := ParseAssignedOwnershipImport(ImportFormatCSV, strings.NewReader("repo,owner\n"))
+		require.ErrorContains(t, err, `unknown CSV column "owner"`)
+	})
+
+	t.Run("CSV missing repo column", func(t *testing.T) {
+		_, err := ParseAssignedOwnershipImport(ImportFormatCSV, strings.NewReader("path,user\n"))
+		require.ErrorContains(t, err, `missing the "repo" column`)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		got, err := ParseAssignedOwnershipImport(ImportFormatJSON, strings.NewReader(`[
+			{"repo": "github.com/sourcegraph/sourcegraph", "path": "internal/own", "user": "alice"},
+			{"repo": "github.com/sourcegraph/sourcegraph", "path": "**/*.md", "team": "docs"},
+			{"repo": "github.com/sourcegraph/about", "user": "bob@example.com"}
+		]`))
+		require.NoError(t, err)
+		// Lines are the positions within the array for JSON.
+		want := append([]AssignedOwnershipEntry(nil), want...)
+		for i := range want {
+			want[i].Line = i + 1
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("JSON unknown key", func(t *testing.T) {
+		_, err := ParseAssignedOwnershipImport(ImportFormatJSON, strings.NewReader(`[{"repo": "a", "owner": "alice"}]`))
+		require.Error(t, err)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := ParseAssignedOwnershipImport("YAML", strings.NewReader(""))
+		require.Error(t, err)
+	})
+}
+
+func TestImportAssignedOwnership(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	alice := &types.User{ID: 10, Username: "alice"}
+	bob := &types.User{ID: 11, Username: "bob"}
+	docs := &types.Team{ID: 20, Name: "docs"}
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		if name == repo.Name {
+			return repo, nil
+		}
+		return nil, &database.RepoNotFoundErr{Name: name}
+	})
+	users := dbmocks.NewMockUserStore()
+	users.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, username string) (*types.User, error) {
+		if username == alice.Username {
+			return alice, nil
+		}
+		return nil, database.NewUserNotFoundErr()
+	})
+	users.GetByVerifiedEmailFunc.SetDefaultHook(func(_ context.Context, email string) (*types.User, error) {
+		if email == "bob@example.com" {
+			return bob, nil
+		}
+		return nil, database.NewUserNotFoundErr()
+	})
+	teams := dbmocks.NewMockTeamStore()
+	teams.GetTeamByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Team, error) {
+		if name == docs.Name {
+			return docs, nil
+		}
+		return nil, database.TeamNotFoundError{}
+	})
+	// alice already owns internal/own.
+	assignedOwners := dbmocks.NewMockAssignedOwnersStore()
+	assignedOwners.ListAssignedOwnersForRepoFunc.SetDefaultReturn([]*database.AssignedOwnerSummary{
+		{OwnerUserID: alice.ID, RepoID: repo.ID, FilePath: "internal/own"},
+	}, nil)
+	assignedTeams := dbmocks.NewMockAssignedTeamsStore()
+
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.UsersFunc.SetDefaultReturn(users)
+	db.TeamsFunc.SetDefaultReturn(teams)
+	db.AssignedOwnersFunc.SetDefaultReturn(assignedOwners)
+	db.AssignedTeamsFunc.SetDefaultReturn(assignedTeams)
+	db.WithTransactFunc.SetDefaultHook(func(ctx context.Context, f func(database.DB) error) error {
+		return f(db)
+	})
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.GetDefaultBranchFunc.SetDefaultReturn("main", "deadbeef", nil)
+	gitserverClient.LsFilesFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, commit api.CommitID, _ ...gitdomain.Pathspec) ([]string, error) {
+		require.Equal(t, api.CommitID("deadbeef"), commit)
+		return []string{"README.md", "doc/index.md", "main.go"}, nil
+	})
+
+	entries := []AssignedOwnershipEntry{
+		{Line: 2, Repo: string(repo.Name), Path: "/internal/own/", User: "alice"},
+		{Line: 3, Repo: string(repo.Name), Path: "*.md", Team: "docs"},
+		{Line: 4, Repo: string(repo.Name), User: "bob@example.com"},
+		// Overlaps with the previous entry.
+		{Line: 5, Repo: string(repo.Name), Path: "doc/index.md", Team: "docs"},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		res, err := ImportAssignedOwnership(ctx, db, gitserverClient, entries, 1, true)
+		require.NoError(t, err)
+		assert.False(t, res.Applied)
+		assert.Empty(t, res.Errors)
+		assert.Equal(t, []ImportedAssignment{
+			{Line: 2, Repo: repo, Path: "internal/own", User: alice, Exists: true},
+			{Line: 3, Repo: repo, Path: "README.md", Team: docs},
+			{Line: 3, Repo: repo, Path: "doc/index.md", Team: docs},
+			{Line: 4, Repo: repo, Path: "", User: bob},
+		}, res.Assignments)
+		assert.Empty(t, assignedOwners.InsertFunc.History())
+		assert.Empty(t, assignedTeams.InsertFunc.History())
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		res, err := ImportAssignedOwnership(ctx, db, gitserverClient, entries, 1, false)
+		require.NoError(t, err)
+		assert.True(t, res.Applied)
+		assert.Len(t, res.Assignments, 4)
+
+		// The existing assignment is not inserted again.
+		ownerInserts := assignedOwners.InsertFunc.History()
+		require.Len(t, ownerInserts, 1)
+		assert.Equal(t, bob.ID, ownerInserts[0].Arg1)
+		assert.Equal(t, "", ownerInserts[0].Arg3)
+		assert.Equal(t, int32(1), ownerInserts[0].Arg4)
+
+		teamInserts := assignedTeams.InsertFunc.History()
+		require.Len(t, teamInserts, 2)
+		assert.Equal(t, "README.md", teamInserts[0].Arg3)
+		assert.Equal(t, "doc/index.md", teamInserts[1].Arg3)
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		inserts := len(assignedOwners.InsertFunc.History())
+		invalid := append(entries[:1:1],
+			AssignedOwnershipEntry{Line: 6, Repo: "github.com/sourcegraph/unknown", User: "alice"},
+			AssignedOwnershipEntry{Line: 7, Repo: string(repo.Name), User: "carol"},
+			AssignedOwnershipEntry{Line: 8, Repo: string(repo.Name), Team: "unknown"},
+			AssignedOwnershipEntry{Line: 9, Repo: string(repo.Name), User: "alice", Team: "docs"},
+			AssignedOwnershipEntry{Line: 10, Repo: string(repo.Name), Path: "*.java", User: "alice"},
+			AssignedOwnershipEntry{Line: 11, Path: "main.go", User: "alice"},
+		)
+		res, err := ImportAssignedOwnership(ctx, db, gitserverClient, invalid, 1, false)
+		require.NoError(t, err)
+		assert.False(t, res.Applied)
+		assert.Equal(t, []ImportError{
+			{Line: 6, Message: `repository "github.com/sourcegraph/unknown" not found`},
+			{Line: 7, Message: `user "carol" not found`},
+			{Line: 8, Message: `team "unknown" not found`},
+			{Line: 9, Message: "exactly one of user or team is required"},
+			{Line: 10, Message: `path glob "*.java" does not match any files`},
+			{Line: 11, Message: "repository is required"},
+		}, res.Errors)
+		assert.Len(t, assignedOwners.InsertFunc.History(), inserts)
+	})
+}
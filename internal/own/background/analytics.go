@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -123,9 +124,9 @@ func (r *analyticsIndexer) codeowners(ctx context.Context, repo *types.Repo, com
 		return noOwners
 	}
 	return func(path string) bool {
-		rule := ruleset.Match(path)
-		owners := rule.GetOwner()
-		return len(owners) > 0
+		// Only count paths with an owner that can be resolved, like the
+		// has.owner search filter does.
+		return len(codeowners.ResolvableOwners(ruleset.MatchSections(path))) > 0
 	}
 }
 
@@ -0,0 +1,137 @@
+package background
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	logger "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxBlameFilesPerRepo caps the number of files blamed in a single repository,
+// since blame is computed for every file on each index run.
+const maxBlameFilesPerRepo = 10_000
+
+func handleBlame(ctx context.Context, lgr logger.Logger, repoId api.RepoID, db database.DB, subRepoPermsCache *rcache.Cache) error {
+	// 🚨 SECURITY: we use the internal actor because the background indexer is not associated with any user, and needs
+	// to see all repos and files
+	internalCtx := actor.WithInternalActor(ctx)
+
+	indexer := newBlameIndexer(gitserver.NewClient("own.blame"), db, lgr, subRepoPermsCache)
+	return indexer.indexRepo(internalCtx, repoId, authz.DefaultSubRepoPermsChecker)
+}
+
+type blameIndexer struct {
+	client            gitserver.Client
+	db                database.DB
+	logger            logger.Logger
+	subRepoPermsCache rcache.Cache
+}
+
+func newBlameIndexer(client gitserver.Client, db database.DB, lgr logger.Logger, subRepoPermsCache *rcache.Cache) *blameIndexer {
+	return &blameIndexer{client: client, db: db, logger: lgr, subRepoPermsCache: *subRepoPermsCache}
+}
+
+var blameFilesCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "src",
+	Name:      "own_blame_files_indexed_total",
+})
+
+// indexRepo blames every file at the HEAD of the default branch and replaces
+// the blame signals of the repository with the number of lines attributed to
+// each author.
+func (r *blameIndexer) indexRepo(ctx context.Context, repoId api.RepoID, checker authz.SubRepoPermissionChecker) error {
+	// If the repo has sub-repo perms enabled, skip indexing.
+	isSubRepoPermsRepo, err := isSubRepoPermsRepo(ctx, repoId, r.subRepoPermsCache, checker)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	} else if isSubRepoPermsRepo {
+		r.logger.Debug("skipping own blame signal due to the repo having subrepo perms enabled", logger.Int32("repoID", int32(repoId)))
+		return nil
+	}
+
+	repo, err := r.db.Repos().Get(ctx, repoId)
+	if err != nil {
+		return errors.Wrap(err, "repoStore.Get")
+	}
+	_, commitID, err := r.client.GetDefaultBranch(ctx, repo.Name, true)
+	if err != nil {
+		return errors.Wrap(err, "GetDefaultBranch")
+	}
+	if commitID == "" {
+		// Empty repository, nothing to blame.
+		return r.db.BlameSignals().ReplaceBlameSignals(ctx, repoId, nil)
+	}
+	files, err := r.client.LsFiles(ctx, repo.Name, commitID)
+	if err != nil {
+		return errors.Wrap(err, "ls-files")
+	}
+	if len(files) > maxBlameFilesPerRepo {
+		r.logger.Warn("too many files to blame, indexing only a part of the repository",
+			logger.Int("repo_id", int(repoId)),
+			logger.Int("files", len(files)),
+			logger.Int("limit", maxBlameFilesPerRepo))
+		files = files[:maxBlameFilesPerRepo]
+	}
+
+	var signals []database.BlameSignal
+	for _, file := range files {
+		hunks, err := r.client.BlameFile(ctx, repo.Name, file, &gitserver.BlameOptions{NewestCommit: commitID})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// A single file failing to blame should not prevent indexing the
+			// rest of the repository.
+			r.logger.Warn("cannot blame file", logger.Int("repo_id", int(repoId)), logger.String("path", file), logger.Error(err))
+			continue
+		}
+		signals = append(signals, blameSignals(file, hunks)...)
+	}
+
+	if err := r.db.BlameSignals().ReplaceBlameSignals(ctx, repoId, signals); err != nil {
+		return errors.Wrap(err, "ReplaceBlameSignals")
+	}
+	r.logger.Info("files blamed", logger.Int("count", len(files)), logger.Int("repo_id", int(repoId)))
+	blameFilesCounter.Add(float64(len(files)))
+	return nil
+}
+
+// blameSignals returns the number of lines attributed to each author of the
+// file by the given blame hunks, in order of first appearance.
+func blameSignals(file string, hunks []*gitserver.Hunk) []database.BlameSignal {
+	type author struct {
+		name  string
+		email string
+	}
+	var signals []database.BlameSignal
+	indexes := map[author]int{}
+	for _, h := range hunks {
+		lines := h.EndLine - h.StartLine
+		if lines <= 0 {
+			continue
+		}
+		a := author{name: h.Author.Name, email: h.Author.Email}
+		i, ok := indexes[a]
+		if !ok {
+			i = len(signals)
+			indexes[a] = i
+			signals = append(signals, database.BlameSignal{
+				Path:        file,
+				AuthorName:  a.name,
+				AuthorEmail: a.email,
+			})
+		}
+		signals[i].LineCount += lines
+	}
+	return signals
+}
@@ -0,0 +1,124 @@
+package background
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func hunk(name string, startLine, endLine int) *gitserver.Hunk {
+	return &gitserver.Hunk{
+		StartLine: startLine,
+		EndLine:   endLine,
+		Author:    gitdomain.Signature{Name: name, Email: name + "@example.com"},
+	}
+}
+
+func TestBlameSignals(t *testing.T) {
+	got := blameSignals("file.go", []*gitserver.Hunk{
+		hunk("alice", 1, 4),
+		hunk("bob", 4, 5),
+		hunk("alice", 5, 10),
+		hunk("carol", 10, 10),
+	})
+	assert.Equal(t, []database.BlameSignal{
+		{Path: "file.go", AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 8},
+		{Path: "file.go", AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 1},
+	}, got)
+}
+
+func Test_BlameIndexFromGitserver(t *testing.T) {
+	rcache.SetupForTest(t)
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+
+	ctx := context.Background()
+
+	err := db.Repos().Create(ctx, &types.Repo{
+		ID:   1,
+		Name: "own/repo1",
+	})
+	require.NoError(t, err)
+
+	blames := map[string][]*gitserver.Hunk{
+		"file1.txt":           {hunk("alice", 1, 11), hunk("bob", 11, 13)},
+		"dir/file2.txt":       {hunk("bob", 1, 6)},
+		"dir/subdir/file.txt": {hunk("alice", 1, 2), hunk("bob", 2, 5)},
+	}
+	client := gitserver.NewMockClient()
+	client.GetDefaultBranchFunc.SetDefaultReturn("main", "deadbeef", nil)
+	client.LsFilesFunc.SetDefaultReturn([]string{"file1.txt", "dir/file2.txt", "dir/subdir/file.txt", "binary.bin"}, nil)
+	client.BlameFileFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, path string, opts *gitserver.BlameOptions) ([]*gitserver.Hunk, error) {
+		require.Equal(t, api.CommitID("deadbeef"), opts.NewestCommit)
+		hunks, ok := blames[path]
+		if !ok {
+			return nil, errors.New("cannot blame")
+		}
+		return hunks, nil
+	})
+	indexer := newBlameIndexer(client, db, logger, rcache.New("testing_own_signals"))
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoIDFunc.SetDefaultReturn(false, nil)
+	err = indexer.indexRepo(ctx, api.RepoID(1), checker)
+	require.NoError(t, err)
+
+	for p, w := range map[string][]database.BlameAuthorSummary{
+		"file1.txt": {
+			{AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 10},
+			{AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 2},
+		},
+		"dir": {
+			{AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 8},
+			{AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 1},
+		},
+		"": {
+			{AuthorName: "alice", AuthorEmail: "alice@example.com", LineCount: 11},
+			{AuthorName: "bob", AuthorEmail: "bob@example.com", LineCount: 10},
+		},
+	} {
+		path := p
+		want := w
+		t.Run(path, func(t *testing.T) {
+			got, err := db.BlameSignals().FindBlameAuthors(ctx, 1, path)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func Test_BlameIndexSkipsSubrepoPermsRepos(t *testing.T) {
+	rcache.SetupForTest(t)
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+
+	ctx := context.Background()
+
+	err := db.Repos().Create(ctx, &types.Repo{
+		ID:   1,
+		Name: "own/repo1",
+	})
+	require.NoError(t, err)
+
+	client := gitserver.NewMockClient()
+	indexer := newBlameIndexer(client, db, logger, rcache.New("testing_own_signals"))
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoIDFunc.SetDefaultReturn(true, nil)
+	err = indexer.indexRepo(ctx, api.RepoID(1), checker)
+	require.NoError(t, err)
+	assert.Empty(t, client.BlameFileFunc.History())
+}
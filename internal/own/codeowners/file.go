@@ -1,6 +1,7 @@
 package codeowners
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -83,6 +84,49 @@ func (x *Ruleset) Match(path string) *codeownerspb.Rule {
 	return nil
 }
 
+// MatchSections returns the rules matching the given path for every section
+// of this CODEOWNERS ruleset, in the order they appear in the input file.
+// As in GitLab, sections are evaluated independently: For every section, the
+// returned rule is the rule of that section which pattern matches the given
+// path that is the furthest down the input file. For a ruleset without sections
+// this returns the same rule as Match.
+func (x *Ruleset) MatchSections(path string) []*codeownerspb.Rule {
+	if path[0] != '/' {
+		path = "/" + path
+	}
+	var matched []*codeownerspb.Rule
+	seenSections := map[string]struct{}{}
+	for i := len(x.rules) - 1; i >= 0; i-- {
+		rule := x.rules[i]
+		section := rule.proto.GetSectionName()
+		if _, ok := seenSections[section]; ok {
+			continue
+		}
+		if rule.match(path) {
+			seenSections[section] = struct{}{}
+			matched = append(matched, rule.proto)
+		}
+	}
+	slices.Reverse(matched)
+	return matched
+}
+
+// ResolvableOwners returns the owners of the given rules that refer to a
+// specific user or team. Owners given by a role, which are only supported by
+// GitLab, are skipped, as they cannot be resolved.
+func ResolvableOwners(rules []*codeownerspb.Rule) []*codeownerspb.Owner {
+	var owners []*codeownerspb.Owner
+	for _, r := range rules {
+		for _, o := range r.GetOwner() {
+			if o.GetRole() != "" {
+				continue
+			}
+			owners = append(owners, o)
+		}
+	}
+	return owners
+}
+
 type CompiledRule struct {
 	proto       *codeownerspb.Rule
 	glob        *paths.GlobPattern
@@ -255,6 +255,62 @@ func TestFileOwnersOrder(t *testing.T) {
 	assert.Equal(t, wantOwner, got.GetOwner())
 }
 
+func TestFileOwnersMatchSections(t *testing.T) {
+	rs := codeowners.NewRuleset(
+		codeowners.IngestedRulesetSource{},
+		&codeownerspb.File{
+			Rule: []*codeownerspb.Rule{
+				{
+					Pattern: "*",
+					Owner:   []*codeownerspb.Owner{{Handle: "default-owner"}},
+				},
+				{
+					Pattern:     "/docs/",
+					SectionName: "docs",
+					Owner:       []*codeownerspb.Owner{{Handle: "docs-owner"}},
+				},
+				{
+					Pattern:     "*.go",
+					SectionName: "backend",
+					Owner:       []*codeownerspb.Owner{{Handle: "backend-owner"}},
+				},
+				// Only the last matching pattern of a section is picked.
+				{
+					Pattern:     "*.md",
+					SectionName: "docs",
+					Owner:       []*codeownerspb.Owner{{Handle: "markdown-owner"}},
+				},
+			},
+		})
+
+	owners := func(rules []*codeownerspb.Rule) []string {
+		var handles []string
+		for _, r := range rules {
+			for _, o := range r.GetOwner() {
+				handles = append(handles, o.GetHandle())
+			}
+		}
+		return handles
+	}
+
+	assert.Equal(t, []string{"default-owner", "markdown-owner"}, owners(rs.MatchSections("docs/index.md")))
+	assert.Equal(t, []string{"default-owner", "docs-owner", "backend-owner"}, owners(rs.MatchSections("docs/gen.go")))
+	assert.Equal(t, []string{"default-owner"}, owners(rs.MatchSections("/README")))
+	// Match only considers the last matching rule across all sections.
+	assert.Equal(t, "markdown-owner", rs.Match("docs/index.md").GetOwner()[0].GetHandle())
+}
+
+func TestResolvableOwners(t *testing.T) {
+	rules := []*codeownerspb.Rule{
+		{Pattern: "*", Owner: []*codeownerspb.Owner{{Role: "maintainer"}}},
+		{Pattern: "*.go", SectionName: "backend", Owner: []*codeownerspb.Owner{{Handle: "backend-owner"}, {Role: "developer"}, {Email: "go@example.com"}}},
+	}
+
+	assert.Equal(t, []*codeownerspb.Owner{{Handle: "backend-owner"}, {Email: "go@example.com"}}, codeowners.ResolvableOwners(rules))
+	// Rules with only role owners have no owner that can be resolved.
+	assert.Empty(t, codeowners.ResolvableOwners(rules[:1]))
+}
+
 func BenchmarkOwnersMatchLiteral(b *testing.B) {
 	pattern := "/main/src/foo/bar/README.md"
 	paths := []string{
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"io"
 	"net/mail"
+	"strconv"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
@@ -32,13 +33,20 @@ func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 		if !ok {
 			return nil, errors.Errorf("failed to match rule: %s", p.line)
 		}
+		// In GitLab, rules without owners within a section with default
+		// owners are owned by the default owners.
+		if len(owners) == 0 {
+			owners = p.sectionOwners
+		}
 		// Need to handle this error once, codeownerspb.File supports
 		// error metadata.
 		r := codeownerspb.Rule{
 			Pattern: unescape(pattern),
 			// Section names are case-insensitive, so we lowercase it.
-			SectionName: strings.TrimSpace(strings.ToLower(p.section)),
-			LineNumber:  lineNumber,
+			SectionName:      strings.TrimSpace(strings.ToLower(p.section)),
+			SectionOptional:  p.sectionOptional,
+			SectionApprovals: p.sectionApprovals,
+			LineNumber:       lineNumber,
 		}
 		for _, ownerText := range owners {
 			o := ParseOwner(ownerText)
@@ -54,7 +62,10 @@ func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 
 func ParseOwner(ownerText string) *codeownerspb.Owner {
 	var o codeownerspb.Owner
-	if strings.HasPrefix(ownerText, "@") {
+	if strings.HasPrefix(ownerText, "@@") {
+		// GitLab roles are case-insensitive, so we lowercase it.
+		o.Role = strings.ToLower(strings.TrimPrefix(ownerText, "@@"))
+	} else if strings.HasPrefix(ownerText, "@") {
 		o.Handle = strings.TrimPrefix(ownerText, "@")
 	} else if a, err := mail.ParseAddress(ownerText); err == nil {
 		o.Email = a.Address
@@ -73,6 +84,13 @@ type parsing struct {
 	line string
 	// The most recently defined section, or "" if none.
 	section string
+	// Whether the most recently defined section is optional for approval.
+	sectionOptional bool
+	// The number of approvals required by the most recently defined
+	// section, or 0 if not specified.
+	sectionApprovals int32
+	// The default owners of the most recently defined section, if any.
+	sectionOwners []string
 }
 
 // nextLine advances parsing to focus on the next line.
@@ -113,17 +131,32 @@ func (p *parsing) matchRule() (string, []string, bool) {
 	return filePattern, owners, true
 }
 
-var sectionPattern = lazyregexp.New(`^\s*\^?\s*\[([^\]]+)\]\s*(?:\[[0-9]+\])?\s*$`)
+// sectionPattern is expected to match a section line like:
+// `^[Section name][2] @default-owner`.
+//
+// The optional caret marks the section as optional for approval, the
+// optional number in brackets is the number of approvals required, and
+// the optional trailing owners are the default owners of the section.
+var sectionPattern = lazyregexp.New(`^\s*(\^)?\s*\[([^\]]+)\]\s*(?:\[([0-9]+)\])?((?:\s+\S+)*)\s*$`)
 
 // matchSection tries to extract a section which looks like `[section name]`.
 // A section can also be defined as `^[Section]`, meaning it is optional for approval.
 // It can also be `[Section][2]`, meaning two approvals are required.
+// In GitLab, a section can be followed by default owners, like
+// `[Section] @owner`, which own the rules of the section that don't
+// list any owners.
 func (p *parsing) matchSection() bool {
 	match := sectionPattern.FindStringSubmatch(p.lineWithoutComments())
-	if len(match) != 2 {
+	if len(match) != 5 {
 		return false
 	}
-	p.section = match[1]
+	p.sectionOptional = match[1] != ""
+	p.section = match[2]
+	p.sectionApprovals = 0
+	if approvals, err := strconv.ParseInt(match[3], 10, 32); err == nil {
+		p.sectionApprovals = int32(approvals)
+	}
+	p.sectionOwners = strings.Fields(match[4])
 	return true
 }
 
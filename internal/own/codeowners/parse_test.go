@@ -459,16 +459,18 @@ own/codeowners/* @own-pms
 			LineNumber: 2,
 		},
 		{
-			Pattern:     "own/codeowners/*",
-			SectionName: "eng",
+			Pattern:         "own/codeowners/*",
+			SectionName:     "eng",
+			SectionOptional: true,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "own-engs"},
 			},
 			LineNumber: 6,
 		},
 		{
-			Pattern:     "own/codeowners/*",
-			SectionName: "eng",
+			Pattern:          "own/codeowners/*",
+			SectionName:      "eng",
+			SectionApprovals: 2,
 			Owner: []*codeownerspb.Owner{
 				{Handle: "own-engs"},
 			},
@@ -485,6 +487,68 @@ own/codeowners/* @own-pms
 	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
 }
 
+func TestParseGitlabSectionDefaultOwners(t *testing.T) {
+	got, err := codeowners.Parse(strings.NewReader(
+		`[Documentation] @docs-team docs@example.com
+docs/
+README.md @readme-owner
+
+^[Backend][2] @@maintainer
+*.go
+/internal/ @backend-team @@Developer
+
+[Frontend]
+*.ts
+`))
+	require.NoError(t, err)
+	want := []*codeownerspb.Rule{
+		{
+			Pattern:     "docs/",
+			SectionName: "documentation",
+			Owner: []*codeownerspb.Owner{
+				{Handle: "docs-team"},
+				{Email: "docs@example.com"},
+			},
+			LineNumber: 2,
+		},
+		{
+			Pattern:     "README.md",
+			SectionName: "documentation",
+			Owner: []*codeownerspb.Owner{
+				{Handle: "readme-owner"},
+			},
+			LineNumber: 3,
+		},
+		{
+			Pattern:          "*.go",
+			SectionName:      "backend",
+			SectionOptional:  true,
+			SectionApprovals: 2,
+			Owner: []*codeownerspb.Owner{
+				{Role: "maintainer"},
+			},
+			LineNumber: 6,
+		},
+		{
+			Pattern:          "/internal/",
+			SectionName:      "backend",
+			SectionOptional:  true,
+			SectionApprovals: 2,
+			Owner: []*codeownerspb.Owner{
+				{Handle: "backend-team"},
+				{Role: "developer"},
+			},
+			LineNumber: 7,
+		},
+		{
+			Pattern:     "*.ts",
+			SectionName: "frontend",
+			LineNumber:  10,
+		},
+	}
+	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+}
+
 func TestParseManySections(t *testing.T) {
 	got, err := codeowners.Parse(strings.NewReader(
 		`own/codeowners/* @own-eng
@@ -15,7 +15,14 @@ func (f *Ruleset) Repr() string {
 	var lastSeenSection string
 	for _, r := range f.proto.GetRule() {
 		if s := r.SectionName; s != lastSeenSection {
-			fmt.Fprintf(w, "[%s]\n", s)
+			if r.SectionOptional {
+				fmt.Fprint(w, "^")
+			}
+			fmt.Fprintf(w, "[%s]", s)
+			if a := r.SectionApprovals; a > 0 {
+				fmt.Fprintf(w, "[%d]", a)
+			}
+			fmt.Fprintln(w)
 			lastSeenSection = s
 		}
 		fmt.Fprint(w, r.Pattern)
@@ -26,6 +33,9 @@ func (f *Ruleset) Repr() string {
 			if e := o.GetEmail(); e != "" {
 				fmt.Fprintf(w, " %s", e)
 			}
+			if role := o.GetRole(); role != "" {
+				fmt.Fprintf(w, " @@%s", role)
+			}
 		}
 		fmt.Fprintln(w)
 	}
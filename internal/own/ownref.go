@@ -144,6 +144,11 @@ func EmptyBag() *bag {
 	}
 }
 
+// TeamReferencePrefix marks a text reference that refers to a team, like
+// "team:payments". A team referred to this way resolves to the team, all its
+// descendant teams, and all the members of these teams.
+const TeamReferencePrefix = "team:"
+
 // ByTextReference returns a Bag of all the forms (users, persons, teams)
 // that can be referred to by given text (name or email alike).
 // This can be used in search to find relevant owners by different identifiers
@@ -157,6 +162,10 @@ func ByTextReference(ctx context.Context, db database.DB, text ...string) Bag {
 		if t == "" {
 			continue
 		}
+		if name, ok := strings.CutPrefix(t, TeamReferencePrefix); ok {
+			b.addTeamWithMembers(ctx, db, strings.TrimPrefix(name, "@"))
+			continue
+		}
 		if _, err := mail.ParseAddress(t); err == nil {
 			b.add(refKey{email: t})
 		} else {
@@ -211,6 +220,13 @@ func (b bag) Contains(ref Reference) bool {
 			return true
 		}
 	}
+	// Code hosts namespace team handles, like "@org/team" on GitHub, while
+	// the team name in the database may not include the namespace.
+	if i := strings.LastIndex(ref.Handle, "/"); i >= 0 {
+		if refCtx, ok := b.references[refKey{handle: ref.Handle[i+1:]}]; ok && refCtx.resolvedTeamID != 0 {
+			return true
+		}
+	}
 	return false
 }
 
@@ -291,6 +307,53 @@ func (b *bag) Add(ref Reference) {
 	}
 }
 
+// addTeamWithMembers adds the team of given name to the bag, along with all its
+// descendant teams and the members of all these teams. Members are added by
+// user ID, so that they get resolved to all their references on Resolve.
+//
+// Teams synced from an identity provider or a code host are stored like any
+// other team, so membership is always read from the database.
+func (b *bag) addTeamWithMembers(ctx context.Context, db database.DB, name string) {
+	k := refKey{handle: name}
+	team, err := findTeamByName(ctx, db, name)
+	if err != nil || team == nil {
+		// Keep the handle so that it still matches literally, for instance
+		// a team handle in a CODEOWNERS file.
+		b.add(k)
+		if err != nil {
+			b.references[k].appendErr(err)
+		}
+		b.references[k].resolutionDone = true
+		return
+	}
+	teams := []*types.Team{team}
+	seen := map[int32]bool{team.ID: true}
+	for i := 0; i < len(teams); i++ {
+		t := teams[i]
+		teamRefs := &teamReferences{team: t}
+		b.resolvedTeams[t.ID] = teamRefs
+		teamRefs.linkBack(b)
+		children, err := listChildTeams(ctx, db, t.ID)
+		if err != nil {
+			b.references[refKey{teamID: t.ID}].appendErr(err)
+		}
+		for _, c := range children {
+			// Guard against cycles in the parent relationship.
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				teams = append(teams, c)
+			}
+		}
+		members, err := listTeamMembers(ctx, db, t.ID)
+		if err != nil {
+			b.references[refKey{teamID: t.ID}].appendErr(err)
+		}
+		for _, m := range members {
+			b.add(refKey{userID: m.UserID})
+		}
+	}
+}
+
 // add inserts given reference key (one of: user ID, team ID, email, handle)
 // to the bag, so that it can be resolved later in batch.
 func (b *bag) add(k refKey) {
@@ -631,6 +694,22 @@ func findTeamByName(ctx context.Context, db database.DB, name string) (*types.Te
 	return team, nil
 }
 
+func listChildTeams(ctx context.Context, db database.DB, parentID int32) ([]*types.Team, error) {
+	teams, _, err := db.Teams().ListTeams(ctx, database.ListTeamsOpts{WithParentID: parentID})
+	if err != nil {
+		return nil, errors.Wrap(err, "Teams.ListTeams")
+	}
+	return teams, nil
+}
+
+func listTeamMembers(ctx context.Context, db database.DB, teamID int32) ([]*types.TeamMember, error) {
+	members, _, err := db.Teams().ListTeamMembers(ctx, database.ListTeamMembersOpts{TeamID: teamID})
+	if err != nil {
+		return nil, errors.Wrap(err, "Teams.ListTeamMembers")
+	}
+	return members, nil
+}
+
 // refContext contains information about resolving a reference to a user.
 type refContext struct {
 	// resolvedUserID is not 0 if this reference has been recognized as a user.
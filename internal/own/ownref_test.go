@@ -313,6 +313,49 @@ func TestBagRetrievesTeamsByName(t *testing.T) {
 	assert.True(t, bag.Contains(ref), "%s contains %s", bag, ref)
 }
 
+func TestBagTeamReferenceIncludesMembers(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+	member, err := db.Users().Create(ctx, database.NewUser{
+		Email:           "alice@example.com",
+		Username:        "alice",
+		EmailIsVerified: true,
+	})
+	require.NoError(t, err)
+	childMember, err := db.Users().Create(ctx, database.NewUser{Username: "bob"})
+	require.NoError(t, err)
+	_, err = db.Users().Create(ctx, database.NewUser{Username: "carol"})
+	require.NoError(t, err)
+	team, err := db.Teams().CreateTeam(ctx, &types.Team{Name: "payments"})
+	require.NoError(t, err)
+	child, err := db.Teams().CreateTeam(ctx, &types.Team{Name: "payments-api", ParentTeamID: team.ID})
+	require.NoError(t, err)
+	require.NoError(t, db.Teams().CreateTeamMember(ctx,
+		&types.TeamMember{TeamID: team.ID, UserID: member.ID},
+		&types.TeamMember{TeamID: child.ID, UserID: childMember.ID},
+	))
+
+	bag := ByTextReference(ctx, db, "team:payments")
+	for _, ref := range []Reference{
+		{TeamID: team.ID},
+		{Handle: "payments"},
+		{Handle: "@acme/payments"},
+		{TeamID: child.ID},
+		{Handle: "payments-api"},
+		{UserID: member.ID},
+		{Handle: "alice"},
+		{Email: "alice@example.com"},
+		{UserID: childMember.ID},
+	} {
+		assert.True(t, bag.Contains(ref), "%s contains %s", bag, ref)
+	}
+	assert.False(t, bag.Contains(Reference{Handle: "carol"}))
+}
+
 func TestBagManyUsers(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
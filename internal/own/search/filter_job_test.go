@@ -531,6 +531,97 @@ func TestApplyCodeOwnershipFiltering(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "match files owned by a team, its child teams and its members",
+			args: args{
+				includeOwners: []string{"team:payments"},
+				matches: []result.Match{
+					&result.FileMatch{
+						File: result.File{
+							Path: "billing/invoice.go",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							Path: "api/charge.go",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							Path: "api/v2/refund.go",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							Path: "search/index.go",
+						},
+					},
+				},
+				repoContent: map[string]string{
+					"CODEOWNERS": strings.Join([]string{
+						"/billing/ @alice",
+						"/api/ @acme/payments",
+						"/api/v2/ @payments-api",
+						"/search/ @bob",
+					}, "\n"),
+				},
+			},
+			setup: teamMembersSetup(
+				&types.Team{ID: 5, Name: "payments"},
+				&types.Team{ID: 6, Name: "payments-api", ParentTeamID: 5},
+				&types.User{ID: 1, Username: "alice"},
+			),
+			want: autogold.Expect([]result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "billing/invoice.go",
+					},
+				},
+				&result.FileMatch{
+					File: result.File{
+						Path: "api/charge.go",
+					},
+				},
+				&result.FileMatch{
+					File: result.File{
+						Path: "api/v2/refund.go",
+					},
+				},
+			}),
+		},
+		{
+			name: "unknown team matches its handle literally",
+			args: args{
+				includeOwners: []string{"team:growth"},
+				matches: []result.Match{
+					&result.FileMatch{
+						File: result.File{
+							Path: "signup/form.go",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							Path: "billing/invoice.go",
+						},
+					},
+				},
+				repoContent: map[string]string{
+					"CODEOWNERS": "/signup/ @growth\n/billing/ @alice\n",
+				},
+			},
+			setup: teamMembersSetup(
+				&types.Team{ID: 5, Name: "payments"},
+				&types.Team{ID: 6, Name: "payments-api", ParentTeamID: 5},
+				&types.User{ID: 1, Username: "alice"},
+			),
+			want: autogold.Expect([]result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "signup/form.go",
+					},
+				},
+			}),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -621,3 +712,47 @@ func assignedOwnerSetup(path string, user *types.User) func(*dbmocks.MockDB) {
 		db.AssignedOwnersFunc.SetDefaultReturn(assignedOwnersStore)
 	}
 }
+
+// teamMembersSetup sets up team with a child team and user as the only member
+// of team.
+func teamMembersSetup(team, child *types.Team, user *types.User) func(*dbmocks.MockDB) {
+	return func(db *dbmocks.MockDB) {
+		teamsStore := dbmocks.NewMockTeamStore()
+		teamsStore.GetTeamByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Team, error) {
+			for _, t := range []*types.Team{team, child} {
+				if t.Name == name {
+					return t, nil
+				}
+			}
+			return nil, database.TeamNotFoundError{}
+		})
+		teamsStore.ListTeamsFunc.SetDefaultHook(func(_ context.Context, opts database.ListTeamsOpts) ([]*types.Team, int32, error) {
+			if opts.WithParentID == team.ID {
+				return []*types.Team{child}, 0, nil
+			}
+			return nil, 0, nil
+		})
+		teamsStore.ListTeamMembersFunc.SetDefaultHook(func(_ context.Context, opts database.ListTeamMembersOpts) ([]*types.TeamMember, *database.TeamMemberListCursor, error) {
+			if opts.TeamID == team.ID {
+				return []*types.TeamMember{{TeamID: team.ID, UserID: user.ID}}, nil, nil
+			}
+			return nil, nil, nil
+		})
+		db.TeamsFunc.SetDefaultReturn(teamsStore)
+		usersStore := dbmocks.NewMockUserStore()
+		usersStore.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+			if id == user.ID {
+				return user, nil
+			}
+			return nil, database.NewUserNotFoundErr()
+		})
+		usersStore.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.User, error) {
+			if name == user.Username {
+				return user, nil
+			}
+			return nil, database.NewUserNotFoundErr()
+		})
+		usersStore.GetByVerifiedEmailFunc.SetDefaultReturn(nil, nil)
+		db.UsersFunc.SetDefaultReturn(usersStore)
+	}
+}
@@ -0,0 +1,171 @@
+package pathindex
+
+import (
+	"context"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/diff"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var indexBuilds = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "src",
+	Name:      "path_index_builds_total",
+	Help:      "Number of path indexes built from all paths of a commit (full) or from the diff to another commit (incremental).",
+}, []string{"kind"})
+
+// Cache holds the path indexes of recently searched repositories, up to a total
+// estimated size.
+//
+// Only the index of the last searched commit of each repository is kept. When
+// another commit of the repository is searched, usually because the repository
+// was fetched and its branch moved, the index is updated with the paths that
+// were added and removed between the two commits instead of being rebuilt.
+type Cache struct {
+	logger       log.Logger
+	gitserver    gitserver.Client
+	maxSizeBytes int64
+
+	mu        sync.Mutex
+	entries   *lru.Cache[api.RepoName, cacheEntry]
+	sizeBytes int64
+
+	group singleflight.Group
+}
+
+type cacheEntry struct {
+	commit api.CommitID
+	index  *Index
+}
+
+// NewCache returns a cache of path indexes that uses up to about maxSizeBytes
+// of memory.
+func NewCache(logger log.Logger, gitserverClient gitserver.Client, maxSizeBytes int64) *Cache {
+	c := &Cache{
+		logger:       logger,
+		gitserver:    gitserverClient,
+		maxSizeBytes: maxSizeBytes,
+	}
+	// The cache is bounded by size, not by the number of entries.
+	c.entries, _ = lru.NewWithEvict(1_000_000, func(_ api.RepoName, e cacheEntry) {
+		c.sizeBytes -= e.index.Size()
+	})
+	return c
+}
+
+// Get returns the index of the paths of the given repository at the given
+// commit.
+//
+// 🚨 SECURITY: The index contains all paths of the repository. Callers must
+// filter the paths that they return by the sub-repository permissions of the
+// actor.
+func (c *Cache) Get(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Index, error) {
+	if e, ok := c.get(repo); ok && e.commit == commit {
+		return e.index, nil
+	}
+
+	v, err, _ := c.group.Do(string(repo)+"@"+string(commit), func() (any, error) {
+		ctx := actor.WithInternalActor(ctx)
+
+		prev, ok := c.get(repo)
+		if ok && prev.commit == commit {
+			return prev.index, nil
+		}
+
+		var ix *Index
+		if ok {
+			var err error
+			ix, err = c.update(ctx, repo, prev, commit)
+			if err != nil {
+				c.logger.Warn("updating path index, rebuilding it",
+					log.String("repo", string(repo)),
+					log.String("commit", string(commit)),
+					log.Error(err))
+			}
+		}
+		if ix == nil {
+			paths, err := c.gitserver.LsFiles(ctx, repo, commit)
+			if err != nil {
+				return nil, errors.Wrap(err, "listing paths")
+			}
+			ix = New(paths)
+			indexBuilds.WithLabelValues("full").Inc()
+		}
+
+		c.add(repo, cacheEntry{commit: commit, index: ix})
+		return ix, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Index), nil
+}
+
+// update returns the index of prev updated to commit, or nil if the diff is so
+// large that the index should be rebuilt.
+func (c *Cache) update(ctx context.Context, repo api.RepoName, prev cacheEntry, commit api.CommitID) (*Index, error) {
+	out, err := c.gitserver.DiffSymbols(ctx, repo, prev.commit, commit)
+	if err != nil {
+		return nil, err
+	}
+	changedA, changedB, err := diff.ParseGitDiffNameStatus(out)
+	if err != nil {
+		return nil, err
+	}
+	// Modified paths are changed in both commits, but don't change the index.
+	removed, added := difference(changedA, changedB), difference(changedB, changedA)
+	if len(removed)+len(added) > prev.index.Len()/2 {
+		return nil, nil
+	}
+	indexBuilds.WithLabelValues("incremental").Inc()
+	return prev.index.Update(removed, added), nil
+}
+
+// difference returns the paths in the sorted list a that are not in the sorted
+// list b.
+func difference(a, b []string) []string {
+	var d []string
+	j := 0
+	for _, p := range a {
+		for j < len(b) && b[j] < p {
+			j++
+		}
+		if j < len(b) && b[j] == p {
+			continue
+		}
+		d = append(d, p)
+	}
+	return d
+}
+
+func (c *Cache) get(repo api.RepoName) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Get(repo)
+}
+
+func (c *Cache) add(repo api.RepoName, e cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries.Remove(repo)
+	if e.index.Size() > c.maxSizeBytes {
+		return
+	}
+	for c.sizeBytes+e.index.Size() > c.maxSizeBytes {
+		if _, _, ok := c.entries.RemoveOldest(); !ok {
+			break
+		}
+	}
+	c.sizeBytes += e.index.Size()
+	c.entries.Add(repo, e)
+}
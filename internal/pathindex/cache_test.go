@@ -0,0 +1,76 @@
+package pathindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	gs := gitserver.NewMockClient()
+	gs.LsFilesFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, commit api.CommitID, _ ...gitdomain.Pathspec) ([]string, error) {
+		switch commit {
+		case "a":
+			return []string{"foo.go", "bar.go", "baz.go", "qux.go", "README.md"}, nil
+		default:
+			return []string{"other.go"}, nil
+		}
+	})
+	gs.DiffSymbolsFunc.SetDefaultReturn([]byte("D\x00bar.go\x00M\x00foo.go\x00A\x00new.go\x00"), nil)
+
+	c := NewCache(logtest.Scoped(t), gs, 1<<20)
+
+	ix, err := c.Get(ctx, "repo", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "bar.go", "baz.go", "foo.go", "qux.go"}, ix.Paths())
+
+	// The index of the same commit is cached.
+	_, err = c.Get(ctx, "repo", "a")
+	require.NoError(t, err)
+	assert.Len(t, gs.LsFilesFunc.History(), 1)
+
+	// The index of another commit is updated from the diff.
+	ix, err = c.Get(ctx, "repo", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "baz.go", "foo.go", "new.go", "qux.go"}, ix.Paths())
+	assert.Len(t, gs.LsFilesFunc.History(), 1)
+	assert.Len(t, gs.DiffSymbolsFunc.History(), 1)
+
+	// Other repositories have their own index.
+	ix, err = c.Get(ctx, "other", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other.go"}, ix.Paths())
+	assert.Len(t, gs.LsFilesFunc.History(), 2)
+}
+
+func TestCacheEvictsBySize(t *testing.T) {
+	ctx := context.Background()
+
+	gs := gitserver.NewMockClient()
+	gs.LsFilesFunc.SetDefaultReturn([]string{"foo.go"}, nil)
+
+	size := New([]string{"foo.go"}).Size()
+	c := NewCache(logtest.Scoped(t), gs, size*2)
+
+	for _, repo := range []api.RepoName{"a", "b", "c", "a"} {
+		_, err := c.Get(ctx, repo, "commit")
+		require.NoError(t, err)
+	}
+	// Repository a was evicted by c, and indexed again.
+	assert.Len(t, gs.LsFilesFunc.History(), 4)
+	assert.Equal(t, size*2, c.sizeBytes)
+}
+
+func TestDifference(t *testing.T) {
+	assert.Equal(t, []string{"a", "d"}, difference([]string{"a", "b", "c", "d"}, []string{"b", "c", "e"}))
+	assert.Nil(t, difference(nil, []string{"a"}))
+}
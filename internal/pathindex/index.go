@@ -0,0 +1,205 @@
+// Package pathindex implements an in-memory index of the file paths of a
+// repository at a commit that answers fuzzy path queries without scanning all
+// paths of the repository.
+package pathindex
+
+import (
+	"sort"
+	"strings"
+)
+
+// trigram is a sequence of three lowercased bytes of a path.
+type trigram uint32
+
+func newTrigram(a, b, c byte) trigram {
+	return trigram(toLower(a))<<16 | trigram(toLower(b))<<8 | trigram(toLower(c))
+}
+
+// Index is a trigram index of file paths. It is immutable: Update returns a new
+// index and leaves the receiver unchanged, so that an index can be searched
+// while it is updated.
+type Index struct {
+	// paths holds the indexed paths by ID. The paths that have been removed by
+	// Update are empty until the index is compacted.
+	paths []string
+	// postings holds the sorted IDs of the paths that contain each trigram.
+	postings map[trigram][]uint32
+	// removed is the number of removed paths in paths.
+	removed int
+	// size is an estimate of the memory used by the index in bytes.
+	size int64
+}
+
+// New returns an index of the given paths.
+func New(paths []string) *Index {
+	ix := &Index{
+		paths:    make([]string, 0, len(paths)),
+		postings: map[trigram][]uint32{},
+	}
+	ix.add(paths)
+	return ix
+}
+
+// Len returns the number of paths in the index.
+func (ix *Index) Len() int {
+	return len(ix.paths) - ix.removed
+}
+
+// Size returns an estimate of the memory used by the index in bytes.
+func (ix *Index) Size() int64 {
+	return ix.size
+}
+
+// Update returns a copy of the index without the paths in removed and with the
+// paths in added. Paths that are both removed and added stay in the index.
+func (ix *Index) Update(removed, added []string) *Index {
+	toRemove := make(map[string]struct{}, len(removed))
+	for _, p := range removed {
+		toRemove[p] = struct{}{}
+	}
+	for _, p := range added {
+		delete(toRemove, p)
+	}
+
+	paths := make([]string, len(ix.paths), len(ix.paths)+len(added))
+	copy(paths, ix.paths)
+	next := &Index{
+		paths:    paths,
+		postings: make(map[trigram][]uint32, len(ix.postings)),
+		removed:  ix.removed,
+		size:     ix.size,
+	}
+	for t, ids := range ix.postings {
+		next.postings[t] = ids
+	}
+
+	present := make(map[string]struct{}, len(added))
+	if len(toRemove) > 0 || len(added) > 0 {
+		for id, p := range next.paths {
+			if p == "" {
+				continue
+			}
+			if _, ok := toRemove[p]; ok {
+				next.paths[id] = ""
+				next.removed++
+				next.size -= int64(len(p))
+				continue
+			}
+			present[p] = struct{}{}
+		}
+	}
+
+	toAdd := make([]string, 0, len(added))
+	for _, p := range added {
+		if _, ok := present[p]; !ok {
+			present[p] = struct{}{}
+			toAdd = append(toAdd, p)
+		}
+	}
+
+	// Removed paths stay in the posting lists, which would otherwise have to be
+	// copied, until they make up a quarter of the index.
+	if next.removed > len(next.paths)/4 {
+		live := make([]string, 0, next.Len()+len(toAdd))
+		for _, p := range next.paths {
+			if p != "" {
+				live = append(live, p)
+			}
+		}
+		return New(append(live, toAdd...))
+	}
+
+	// The posting lists that change are copied, since they are shared with the
+	// receiver.
+	copied := map[trigram]struct{}{}
+	next.addWith(toAdd, func(t trigram) {
+		if _, ok := copied[t]; !ok {
+			copied[t] = struct{}{}
+			next.postings[t] = append([]uint32(nil), next.postings[t]...)
+		}
+	})
+	return next
+}
+
+func (ix *Index) add(paths []string) {
+	ix.addWith(paths, func(trigram) {})
+}
+
+// addWith adds the given paths to the index, calling beforeAppend before the ID
+// of a path is appended to the posting list of a trigram.
+func (ix *Index) addWith(paths []string, beforeAppend func(trigram)) {
+	seen := map[trigram]struct{}{}
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		id := uint32(len(ix.paths))
+		ix.paths = append(ix.paths, p)
+		ix.size += int64(len(p)) + 16
+
+		clear(seen)
+		for i := 0; i+2 < len(p); i++ {
+			t := newTrigram(p[i], p[i+1], p[i+2])
+			if _, ok := seen[t]; ok {
+				continue
+			}
+			seen[t] = struct{}{}
+			beforeAppend(t)
+			if _, ok := ix.postings[t]; !ok {
+				ix.size += 32
+			}
+			ix.postings[t] = append(ix.postings[t], id)
+			ix.size += 4
+		}
+	}
+}
+
+// Paths returns the paths in the index in sorted order.
+func (ix *Index) Paths() []string {
+	paths := make([]string, 0, ix.Len())
+	for _, p := range ix.paths {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// queryTrigrams returns the distinct trigrams of the normalized query q.
+func queryTrigrams(q string) []trigram {
+	var ts []trigram
+	seen := map[trigram]struct{}{}
+	for i := 0; i+2 < len(q); i++ {
+		t := newTrigram(q[i], q[i+1], q[i+2])
+		if _, ok := seen[t]; !ok {
+			seen[t] = struct{}{}
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+// normalizeQuery lowercases the query and removes the whitespace from it.
+func normalizeQuery(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		b.WriteByte(toLower(c))
+	}
+	return b.String()
+}
+
+// toLower lowercases ASCII letters, which keeps the byte offsets in paths
+// stable.
+func toLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
@@ -0,0 +1,115 @@
+package pathindex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func searchPaths(ix *Index, query string, limit int) []string {
+	var paths []string
+	for _, m := range ix.Search(query, limit) {
+		paths = append(paths, m.Path)
+	}
+	return paths
+}
+
+func TestSearch(t *testing.T) {
+	ix := New([]string{
+		"README.md",
+		"client/web/src/components/fuzzyFinder/FuzzyFinder.tsx",
+		"client/web/src/components/fuzzyFinder/FuzzyFiles.tsx",
+		"client/web/src/index.ts",
+		"client/web/src/index.tsx",
+		"cmd/frontend/graphqlbackend/git_commit.go",
+		"internal/pathindex/index.go",
+		"internal/pathindex/search.go",
+	})
+
+	for _, tc := range []struct {
+		query string
+		limit int
+		want  []string
+	}{
+		{
+			query: "readme",
+			limit: 10,
+			want:  []string{"README.md"},
+		},
+		{
+			// Matches in the base name rank before matches in directories, and
+			// shorter paths before longer ones.
+			query: "index.ts",
+			limit: 2,
+			want:  []string{"client/web/src/index.ts", "client/web/src/index.tsx"},
+		},
+		{
+			query: "fuzzy finder",
+			limit: 1,
+			want:  []string{"client/web/src/components/fuzzyFinder/FuzzyFinder.tsx"},
+		},
+		{
+			// Abbreviations don't share trigrams with the paths they match.
+			query: "gcg",
+			limit: 10,
+			want:  []string{"cmd/frontend/graphqlbackend/git_commit.go"},
+		},
+		{
+			query: "pathindex/search",
+			limit: 10,
+			want:  []string{"internal/pathindex/search.go"},
+		},
+		{
+			query: "qqq",
+			limit: 10,
+			want:  nil,
+		},
+		{
+			query: "  ",
+			limit: 10,
+			want:  nil,
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			assert.Equal(t, tc.want, searchPaths(ix, tc.query, tc.limit))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ix := New([]string{"a/foo.go", "a/bar.go", "b/baz.go"})
+	next := ix.Update([]string{"a/bar.go", "a/missing.go"}, []string{"c/qux.go", "b/baz.go"})
+
+	// The original index is unchanged.
+	assert.Equal(t, []string{"a/bar.go", "a/foo.go", "b/baz.go"}, ix.Paths())
+	assert.Equal(t, []string{"a/bar.go"}, searchPaths(ix, "bar", 10))
+	assert.Empty(t, searchPaths(ix, "qux", 10))
+
+	assert.Equal(t, []string{"a/foo.go", "b/baz.go", "c/qux.go"}, next.Paths())
+	assert.Equal(t, 3, next.Len())
+	assert.Empty(t, searchPaths(next, "bar", 10))
+	assert.Equal(t, []string{"c/qux.go"}, searchPaths(next, "qux", 10))
+
+	// Removing many paths compacts the index.
+	next = next.Update([]string{"a/foo.go", "b/baz.go"}, nil)
+	assert.Equal(t, []string{"c/qux.go"}, next.Paths())
+	assert.Equal(t, 0, next.removed)
+	assert.Equal(t, []string{"c/qux.go"}, searchPaths(next, "qux", 10))
+}
+
+func BenchmarkSearch(b *testing.B) {
+	paths := make([]string, 0, 1_000_000)
+	for i := 0; i < cap(paths); i++ {
+		paths = append(paths, fmt.Sprintf("dir%d/sub%d/package%d/file_%d.go", i%97, i%1013, i%7919, i))
+	}
+	ix := New(paths)
+
+	for _, query := range []string{"package4426/file_12345", "file_12345.go", "sub42 file"} {
+		b.Run(query, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ix.Search(query, 20)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package pathindex
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+)
+
+// Match is a path that matches a query.
+type Match struct {
+	Path string
+	// Score is higher for better matches.
+	Score int
+}
+
+// maxQueryLen is the maximum length of a query in bytes. The rest of longer
+// queries is ignored.
+const maxQueryLen = 256
+
+// Search returns up to limit paths that contain the characters of the query in
+// order, best matches first. Matches are ranked higher when the characters are
+// consecutive, start at word boundaries, or are in the base name of the path.
+// Case and whitespace in the query are ignored.
+//
+// Only the paths that contain all trigrams of the query are considered. If none
+// of them match, which is usually the case for misspelled or abbreviated
+// queries, the paths that contain at least half of the trigrams are considered
+// instead, and if none of those match either or the query is shorter than a
+// trigram, all paths are scanned.
+func (ix *Index) Search(query string, limit int) []Match {
+	q := normalizeQuery(query)
+	if len(q) > maxQueryLen {
+		q = q[:maxQueryLen]
+	}
+	if q == "" || limit <= 0 {
+		return nil
+	}
+
+	top := &topMatches{limit: limit}
+	if ts := queryTrigrams(q); len(ts) > 0 {
+		// Paths that contain all trigrams of the query are usually the best
+		// matches, and can be found by intersecting the posting lists.
+		for _, id := range ix.intersect(ts) {
+			top.consider(ix.paths[id], q)
+		}
+		if top.Len() > 0 {
+			return top.sorted()
+		}
+
+		for _, id := range ix.candidates(ts) {
+			top.consider(ix.paths[id], q)
+		}
+		if top.Len() > 0 {
+			return top.sorted()
+		}
+	}
+
+	for _, p := range ix.paths {
+		if p != "" {
+			top.consider(p, q)
+		}
+	}
+	return top.sorted()
+}
+
+// intersect returns the IDs of the paths that contain all trigrams ts.
+func (ix *Index) intersect(ts []trigram) []uint32 {
+	lists := make([][]uint32, len(ts))
+	for i, t := range ts {
+		lists[i] = ix.postings[t]
+	}
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+
+	var ids []uint32
+	for _, id := range lists[0] {
+		if ix.paths[id] == "" {
+			continue
+		}
+		all := true
+		for _, l := range lists[1:] {
+			if i := sort.Search(len(l), func(i int) bool { return l[i] >= id }); i == len(l) || l[i] != id {
+				all = false
+				break
+			}
+		}
+		if all {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// candidates returns the IDs of the paths that contain at least half of the
+// trigrams ts.
+func (ix *Index) candidates(ts []trigram) []uint32 {
+	counts := make([]uint16, len(ix.paths))
+	for _, t := range ts {
+		for _, id := range ix.postings[t] {
+			counts[id]++
+		}
+	}
+	need := uint16((len(ts) + 1) / 2)
+	var ids []uint32
+	for id, n := range counts {
+		if n >= need && ix.paths[id] != "" {
+			ids = append(ids, uint32(id))
+		}
+	}
+	return ids
+}
+
+const (
+	scoreMatch       = 16
+	bonusConsecutive = 8
+	bonusBoundary    = 8
+	bonusEnd         = 8
+	bonusBaseName    = 32
+	penaltyGap       = 1
+)
+
+// score returns the score of the match of the normalized query q in path, and
+// false if path does not contain the characters of q in order. Matches in the
+// base name of the path are preferred.
+func score(path, q string) (int, bool) {
+	base := strings.LastIndexByte(path, '/') + 1
+	if s, ok := scoreFrom(path, base, q); ok {
+		return s + bonusBaseName, true
+	}
+	if base == 0 {
+		return 0, false
+	}
+	return scoreFrom(path, 0, q)
+}
+
+// scoreFrom scores the shortest match of q in path[from:] that ends where the
+// leftmost match ends.
+func scoreFrom(path string, from int, q string) (int, bool) {
+	qi, end := 0, -1
+	for i := from; i < len(path); i++ {
+		if toLower(path[i]) == q[qi] {
+			qi++
+			if qi == len(q) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, false
+	}
+
+	qi, start := len(q)-1, from
+	for i := end; i >= from; i-- {
+		if toLower(path[i]) == q[qi] {
+			qi--
+			if qi < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	s, prevMatched := 0, false
+	qi = 0
+	for i := start; i <= end; i++ {
+		if qi < len(q) && toLower(path[i]) == q[qi] {
+			s += scoreMatch
+			if prevMatched {
+				s += bonusConsecutive
+			}
+			if isBoundary(path, i) {
+				s += bonusBoundary
+			}
+			prevMatched = true
+			qi++
+		} else {
+			s -= penaltyGap
+			prevMatched = false
+		}
+	}
+	if end == len(path)-1 {
+		s += bonusEnd
+	}
+	return s, true
+}
+
+// isBoundary reports whether a word starts at path[i].
+func isBoundary(path string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, c := path[i-1], path[i]
+	switch prev {
+	case '/', '_', '-', '.', ' ':
+		return true
+	}
+	return ('a' <= prev && prev <= 'z' && 'A' <= c && c <= 'Z') ||
+		(!isDigit(prev) && isDigit(c))
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// better reports whether a ranks before b: by score, then shorter paths first.
+func better(a, b Match) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if len(a.Path) != len(b.Path) {
+		return len(a.Path) < len(b.Path)
+	}
+	return a.Path < b.Path
+}
+
+// topMatches keeps the best limit matches in a heap whose root is the worst of
+// them.
+type topMatches struct {
+	limit   int
+	matches []Match
+}
+
+func (t *topMatches) consider(path, q string) {
+	s, ok := score(path, q)
+	if !ok {
+		return
+	}
+	m := Match{Path: path, Score: s}
+	if len(t.matches) < t.limit {
+		heap.Push(t, m)
+	} else if better(m, t.matches[0]) {
+		t.matches[0] = m
+		heap.Fix(t, 0)
+	}
+}
+
+func (t *topMatches) sorted() []Match {
+	sort.Slice(t.matches, func(i, j int) bool { return better(t.matches[i], t.matches[j]) })
+	return t.matches
+}
+
+func (t *topMatches) Len() int           { return len(t.matches) }
+func (t *topMatches) Less(i, j int) bool { return better(t.matches[j], t.matches[i]) }
+func (t *topMatches) Swap(i, j int)      { t.matches[i], t.matches[j] = t.matches[j], t.matches[i] }
+func (t *topMatches) Push(x any)         { t.matches = append(t.matches, x.(Match)) }
+func (t *topMatches) Pop() any {
+	m := t.matches[len(t.matches)-1]
+	t.matches = t.matches[:len(t.matches)-1]
+	return m
+}
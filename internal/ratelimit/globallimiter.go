@@ -294,15 +294,17 @@ func (r *globalRateLimiter) SetTokenBucketConfig(ctx context.Context, bucketQuot
 
 func getRateLimiterKeys(prefix, bucketName string) rateLimitBucketConfigKeys {
 	var keys rateLimitBucketConfigKeys
-	// e.g. v2:rate_limiters:github.com
-	keys.BucketKey = fmt.Sprintf("%s:%s", prefix, bucketName)
-	// e.g. v2:rate_limiters:github.com:config:bucket_rate
+	// The bucket name is a hash tag, so that all the keys of a bucket are stored in the
+	// same slot of a Redis Cluster and can be used by the same script.
+	// e.g. v2:rate_limiters:{github.com}
+	keys.BucketKey = fmt.Sprintf("%s:{%s}", prefix, bucketName)
+	// e.g. v2:rate_limiters:{github.com}:config:bucket_rate
 	keys.RateKey = fmt.Sprintf("%s:%s", keys.BucketKey, bucketRateConfigKeySuffix)
-	// e.g.. v2:rate_limiters:github.com:config:bucket_replenishment_interval_seconds
+	// e.g.. v2:rate_limiters:{github.com}:config:bucket_replenishment_interval_seconds
 	keys.ReplenishmentIntervalSecondsKey = fmt.Sprintf("%s:%s", keys.BucketKey, bucketReplenishmentConfigKeySuffix)
-	// e.g.. v2:rate_limiters:github.com:last_replenishment_timestamp
+	// e.g.. v2:rate_limiters:{github.com}:last_replenishment_timestamp
 	keys.LastReplenishmentTimestampKey = fmt.Sprintf("%s:%s", keys.BucketKey, bucketLastReplenishmentTimestampKeySuffix)
-	// e.g.. v2:rate_limiters:github.com:allowed_burst
+	// e.g.. v2:rate_limiters:{github.com}:allowed_burst
 	keys.BurstKey = fmt.Sprintf("%s:%s", keys.BucketKey, bucketAllowedBurstKeySuffix)
 
 	return keys
@@ -419,7 +421,7 @@ func GetGlobalLimiterStateFromPool(ctx context.Context, pool *redis.Pool, prefix
 	defer conn.Close()
 
 	// First, find all known limiters in redis.
-	resp, err := conn.Do("KEYS", fmt.Sprintf("%s:*:%s", prefix, bucketAllowedBurstKeySuffix))
+	resp, err := conn.Do("KEYS", fmt.Sprintf("%s:{*}:%s", prefix, bucketAllowedBurstKeySuffix))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list keys")
 	}
@@ -435,7 +437,7 @@ func GetGlobalLimiterStateFromPool(ctx context.Context, pool *redis.Pool, prefix
 			return nil, errors.Newf("invalid response from redis keys command, expected string, got %T", k)
 		}
 		key := string(kchars)
-		limiterName := strings.TrimSuffix(strings.TrimPrefix(key, prefix+":"), ":"+bucketAllowedBurstKeySuffix)
+		limiterName := strings.TrimSuffix(strings.TrimPrefix(key, prefix+":{"), "}:"+bucketAllowedBurstKeySuffix)
 		rlKeys := getRateLimiterKeys(prefix, limiterName)
 
 		rstore := redispool.RedisKeyValue(pool)
@@ -555,6 +557,11 @@ func SetupForTest(t TB) {
 			return err
 		},
 	}
+	// REDIS_CLUSTER_ENDPOINT runs the tests against a Redis Cluster, e.g.
+	// redis+cluster://127.0.0.1:7000.
+	if endpoint := os.Getenv("REDIS_CLUSTER_ENDPOINT"); endpoint != "" {
+		pool, _ = redispool.NewKeyValue(endpoint, &redis.Pool{MaxIdle: 3, IdleTimeout: 240 * time.Second}).Pool()
+	}
 	kvMock = redispool.RedisKeyValue(pool)
 
 	tokenBucketGlobalPrefix = "__test__" + t.Name()
@@ -3,6 +3,7 @@ package ratelimit
 import (
 	"context"
 	"errors"
+	"os"
 	"testing"
 	"time"
 
@@ -317,6 +318,11 @@ func redisPoolForTest(t *testing.T, prefix string) *redis.Pool {
 			return err
 		},
 	}
+	// REDIS_CLUSTER_ENDPOINT runs the tests against a Redis Cluster, e.g.
+	// redis+cluster://127.0.0.1:7000.
+	if endpoint := os.Getenv("REDIS_CLUSTER_ENDPOINT"); endpoint != "" {
+		pool, _ = redispool.NewKeyValue(endpoint, &redis.Pool{MaxIdle: 3, IdleTimeout: 240 * time.Second}).Pool()
+	}
 
 	c := pool.Get()
 	t.Cleanup(func() {
@@ -330,6 +336,16 @@ func redisPoolForTest(t *testing.T, prefix string) *redis.Pool {
 	return pool
 }
 
+func TestGetRateLimiterKeys(t *testing.T) {
+	// The scripts of the rate limiter use all the keys of a bucket, which must be in the
+	// same slot of a Redis Cluster.
+	keys := getRateLimiterKeys(tokenBucketGlobalPrefix, testBucketName)
+	slot := redispool.HashSlot(keys.BucketKey)
+	for _, key := range []string{keys.RateKey, keys.ReplenishmentIntervalSecondsKey, keys.LastReplenishmentTimestampKey, keys.BurstKey} {
+		assert.Equal(t, slot, redispool.HashSlot(key), key)
+	}
+}
+
 func TestLimitInfo(t *testing.T) {
 	ctx := context.Background()
 	prefix := "__test__" + t.Name()
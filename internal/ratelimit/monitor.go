@@ -17,7 +17,8 @@ var DefaultMonitorRegistry = NewMonitorRegistry()
 // NewMonitorRegistry creates a new empty registry.
 func NewMonitorRegistry() *MonitorRegistry {
 	return &MonitorRegistry{
-		monitors: make(map[string]*Monitor),
+		monitors:  make(map[string]*Monitor),
+		publisher: newMonitorStatePublisher(),
 	}
 }
 
@@ -27,6 +28,8 @@ type MonitorRegistry struct {
 	// Monitor per code host / token tuple, keys are the normalized base URL for a
 	// code host, plus the token hash.
 	monitors map[string]*Monitor
+	// publisher publishes the state of the registered monitors.
+	publisher *monitorStatePublisher
 }
 
 // GetOrSet fetches the rate limit monitor associated with the given code host /
@@ -41,6 +44,7 @@ func (r *MonitorRegistry) GetOrSet(baseURL, authHash, resource string, monitor *
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if _, ok := r.monitors[key]; !ok {
+		monitor.setIdentity(baseURL, authHash, resource, r.publisher)
 		r.monitors[key] = monitor
 	}
 	return r.monitors[key]
@@ -75,9 +79,28 @@ type Monitor struct {
 	retry     time.Time         // deadline based on Retry-After HTTP response header value
 	collector *MetricsCollector // metrics collector
 
+	// Adaptive backoff state, used when the code host throttles us without
+	// telling us for how long (e.g. a 429 without a Retry-After header).
+	backoff       time.Duration // the last backoff duration applied
+	backoffUntil  time.Time     // deadline of the current backoff
+	lastThrottled time.Time     // when we were last throttled
+
+	waiting int // number of callers currently sleeping in WaitForRateLimit
+
+	// identity and publisher are set when the monitor is registered in a
+	// MonitorRegistry. Only registered monitors publish their state, see
+	// monitor_state.go.
+	identity  *monitorIdentity
+	publisher *monitorStatePublisher
+
 	clock func() time.Time
 }
 
+const (
+	minThrottleBackoff = time.Second
+	maxThrottleBackoff = 5 * time.Minute
+)
+
 // Get reports the client's rate limit status (as of the last API response it received).
 func (c *Monitor) Get() (remaining int, reset, retry time.Duration, known bool) {
 	c.mu.Lock()
@@ -173,6 +196,12 @@ func (c *Monitor) calcRateLimitWaitTime(cost int) time.Duration {
 		}
 	}
 
+	if !c.backoffUntil.IsZero() {
+		if timeRemaining := c.backoffUntil.Sub(c.now()); timeRemaining > 0 {
+			return timeRemaining
+		}
+	}
+
 	// If the external rate limit is unknown,
 	// or if there are still enough remaining tokens,
 	// or if the cost is greater than the actual rate limit (in which case there will never be enough tokens),
@@ -207,10 +236,59 @@ func (c *Monitor) WaitForRateLimit(ctx context.Context, cost int) bool {
 		return false
 	}
 
+	c.addWaiting(1)
+	defer c.addWaiting(-1)
+
 	timeutil.SleepWithContext(ctx, sleepDuration)
 	return true
 }
 
+func (c *Monitor) addWaiting(delta int) {
+	c.mu.Lock()
+	c.waiting += delta
+	publisher := c.publisher
+	c.mu.Unlock()
+
+	publisher.monitorChanged(c)
+}
+
+// RecordThrottle records that the code host rejected a request because we
+// exceeded its rate limit, for example with a 429 Too Many Requests response.
+//
+// If the code host told us how long to wait, through the Retry-After or
+// RateLimit-Reset headers, subsequent calls to WaitForRateLimit will wait for
+// that long. Otherwise, we back off exponentially: every throttled response
+// that arrives shortly after the previous backoff expired doubles the backoff,
+// up to maxThrottleBackoff.
+func (c *Monitor) RecordThrottle() {
+	c.mu.Lock()
+	now := c.now()
+
+	switch {
+	case c.retry.After(now):
+		c.backoff = c.retry.Sub(now)
+	case c.known && c.remaining <= 0 && c.reset.After(now):
+		c.backoff = c.reset.Sub(now)
+	case !c.lastThrottled.IsZero() && now.Sub(c.lastThrottled) < 2*c.backoff+minThrottleBackoff:
+		// We were throttled again shortly after the last backoff, so it wasn't
+		// long enough.
+		c.backoff *= 2
+	default:
+		c.backoff = minThrottleBackoff
+	}
+	if c.backoff > maxThrottleBackoff {
+		c.backoff = maxThrottleBackoff
+	}
+	c.backoffUntil = now.Add(c.backoff)
+	c.lastThrottled = now
+
+	event := c.throttleEventLocked(now, ThrottleReasonTooManyRequests)
+	publisher := c.publisher
+	c.mu.Unlock()
+
+	publisher.monitorChanged(c, event)
+}
+
 // Update updates the monitor's rate limit information based on the HTTP response headers.
 func (c *Monitor) Update(h http.Header) {
 	if cached := h.Get("X-From-Cache"); cached != "" {
@@ -219,28 +297,38 @@ func (c *Monitor) Update(h http.Header) {
 	}
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	events := c.updateLocked(h)
+	publisher := c.publisher
+	c.mu.Unlock()
+
+	publisher.monitorChanged(c, events...)
+}
+
+func (c *Monitor) updateLocked(h http.Header) (events []*ThrottleEvent) {
+	now := c.now()
+	wasExhausted := c.known && c.remaining <= 0 && c.reset.After(now)
 
 	retry, _ := strconv.ParseInt(h.Get("Retry-After"), 10, 64)
 	if retry > 0 {
-		c.retry = c.now().Add(time.Duration(retry) * time.Second)
+		c.retry = now.Add(time.Duration(retry) * time.Second)
+		events = append(events, c.throttleEventLocked(now, ThrottleReasonRetryAfter))
 	}
 
 	// See https://developer.github.com/v3/#rate-limiting.
 	limit, err := strconv.Atoi(h.Get(c.HeaderPrefix + "RateLimit-Limit"))
 	if err != nil {
 		c.known = false
-		return
+		return events
 	}
 	remaining, err := strconv.Atoi(h.Get(c.HeaderPrefix + "RateLimit-Remaining"))
 	if err != nil {
 		c.known = false
-		return
+		return events
 	}
 	resetAtSeconds, err := strconv.ParseInt(h.Get(c.HeaderPrefix+"RateLimit-Reset"), 10, 64)
 	if err != nil {
 		c.known = false
-		return
+		return events
 	}
 	c.known = true
 	c.limit = limit
@@ -250,6 +338,14 @@ func (c *Monitor) Update(h http.Header) {
 	if c.known && c.collector != nil && c.collector.Remaining != nil {
 		c.collector.Remaining(float64(c.remaining))
 	}
+
+	// Record running out of rate limit tokens once per reset window.
+	exhausted := c.remaining <= 0 && c.reset.After(now)
+	if exhausted && !wasExhausted {
+		events = append(events, c.throttleEventLocked(now, ThrottleReasonExhausted))
+	}
+
+	return events
 }
 
 // SetCollector sets the metric collector.
@@ -0,0 +1,304 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Monitors live in the memory of each service talking to a code host. To make
+// their state observable from the frontend, registered monitors publish a
+// snapshot of their state and the throttle events they observe to Redis.
+//
+// Monitors are updated on every request to the code host, so they never talk
+// to Redis themselves. They only hand their changes to a monitorStatePublisher,
+// which writes them to Redis from a background goroutine.
+
+// monitorStatePublishInterval is the minimum interval between two writes of a
+// monitorStatePublisher.
+const monitorStatePublishInterval = 10 * time.Second
+
+// monitorStatesTTL is how long the published states of the monitors for a code
+// host are kept after the last publish of any of them, so that the states of
+// code hosts which are no longer used eventually disappear.
+const monitorStatesTTL = 24 * time.Hour
+
+// maxPendingThrottleEvents bounds the number of throttle events waiting to be
+// published, further events are dropped.
+const maxPendingThrottleEvents = 100
+
+var (
+	// monitorStates is a hash per code host base URL, keyed by monitor.
+	monitorStates = rcache.New("ratelimit_monitor_states")
+	// throttleEvents holds the most recent throttle events across all code hosts.
+	throttleEvents = rcache.NewFIFOList("ratelimit_throttle_events", 500)
+)
+
+// Overridden in tests.
+var (
+	publishMonitorState = func(state *MonitorState) {
+		if state == nil {
+			return
+		}
+		b, err := json.Marshal(state)
+		if err != nil {
+			return
+		}
+		if err := monitorStates.SetHashItemWithTTL(state.BaseURL, state.key(), string(b), int(monitorStatesTTL.Seconds())); err != nil {
+			log.Scoped("ratelimit.Monitor").Debug("failed to publish monitor state", log.Error(err))
+		}
+	}
+	recordThrottleEvent = func(event *ThrottleEvent) {
+		if event == nil {
+			return
+		}
+		b, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		if err := throttleEvents.Insert(b); err != nil {
+			log.Scoped("ratelimit.Monitor").Debug("failed to record throttle event", log.Error(err))
+		}
+	}
+)
+
+type monitorIdentity struct {
+	baseURL  string
+	authHash string
+	resource string
+}
+
+func (c *Monitor) setIdentity(baseURL, authHash, resource string, publisher *monitorStatePublisher) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Only a short prefix of the hash is kept, it is enough to tell the
+	// monitors for different credentials apart.
+	if len(authHash) > 8 {
+		authHash = authHash[:8]
+	}
+	c.identity = &monitorIdentity{baseURL: baseURL, authHash: authHash, resource: resource}
+	c.publisher = publisher
+}
+
+// monitorStatePublisher collects the monitors whose state changed and the
+// throttle events they recorded, and publishes them at most once every
+// monitorStatePublishInterval from a background goroutine.
+type monitorStatePublisher struct {
+	interval time.Duration
+
+	mu        sync.Mutex
+	scheduled bool
+	monitors  map[*Monitor]struct{}
+	events    []*ThrottleEvent
+}
+
+func newMonitorStatePublisher() *monitorStatePublisher {
+	return &monitorStatePublisher{interval: monitorStatePublishInterval}
+}
+
+// monitorChanged records that the state of the given monitor changed, and that
+// it recorded the given throttle events. It is a no-op on a nil publisher, which
+// is the publisher of unregistered monitors.
+func (p *monitorStatePublisher) monitorChanged(m *Monitor, events ...*ThrottleEvent) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.monitors == nil {
+		p.monitors = make(map[*Monitor]struct{})
+	}
+	p.monitors[m] = struct{}{}
+	for _, event := range events {
+		if event != nil && len(p.events) < maxPendingThrottleEvents {
+			p.events = append(p.events, event)
+		}
+	}
+
+	if !p.scheduled {
+		p.scheduled = true
+		time.AfterFunc(p.interval, p.flush)
+	}
+}
+
+// flush publishes the pending monitor states and throttle events.
+func (p *monitorStatePublisher) flush() {
+	p.mu.Lock()
+	monitors, events := p.monitors, p.events
+	p.monitors, p.events = nil, nil
+	p.scheduled = false
+	p.mu.Unlock()
+
+	for _, event := range events {
+		recordThrottleEvent(event)
+	}
+	for m := range monitors {
+		state := m.State()
+		publishMonitorState(&state)
+	}
+}
+
+// MonitorState is a snapshot of the state of a Monitor.
+type MonitorState struct {
+	// BaseURL is the normalized base URL of the code host API.
+	BaseURL string
+	// AuthHash is a prefix of the hash of the credentials used by the monitored client.
+	AuthHash string
+	// Resource is the API resource the rate limit applies to, e.g. "rest" or "graphql".
+	Resource string
+	// Service is the name of the service the monitor is running in.
+	Service string
+
+	// Known is true if the code host has reported its rate limit to us.
+	Known     bool
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+	// RetryAt is the deadline set by the last Retry-After header, if any.
+	RetryAt time.Time
+	// BackoffUntil is the deadline of the current adaptive backoff, if any.
+	BackoffUntil time.Time
+	// LastThrottledAt is when the code host last throttled a request.
+	LastThrottledAt time.Time
+	// Waiting is the number of requests currently waiting for the rate limit.
+	Waiting int
+
+	UpdatedAt time.Time
+}
+
+func (s *MonitorState) key() string {
+	return fmt.Sprintf("%s:%s:%s", s.Service, s.AuthHash, s.Resource)
+}
+
+// State returns a snapshot of the current state of the monitor.
+func (c *Monitor) State() MonitorState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stateLocked()
+}
+
+func (c *Monitor) stateLocked() MonitorState {
+	state := MonitorState{
+		Service:         env.MyName,
+		Known:           c.known,
+		Limit:           c.limit,
+		Remaining:       c.remaining,
+		ResetAt:         c.reset,
+		RetryAt:         c.retry,
+		BackoffUntil:    c.backoffUntil,
+		LastThrottledAt: c.lastThrottled,
+		Waiting:         c.waiting,
+		UpdatedAt:       c.now(),
+	}
+	if c.identity != nil {
+		state.BaseURL = c.identity.baseURL
+		state.AuthHash = c.identity.authHash
+		state.Resource = c.identity.resource
+	}
+	return state
+}
+
+// ThrottleReason describes why a ThrottleEvent was recorded.
+type ThrottleReason string
+
+const (
+	// ThrottleReasonTooManyRequests is used when the code host rejected a
+	// request because of rate limiting.
+	ThrottleReasonTooManyRequests ThrottleReason = "TOO_MANY_REQUESTS"
+	// ThrottleReasonRetryAfter is used when the code host asked us to retry
+	// later with a Retry-After header.
+	ThrottleReasonRetryAfter ThrottleReason = "RETRY_AFTER"
+	// ThrottleReasonExhausted is used when the code host reported that we
+	// have no rate limit tokens left until the limit resets.
+	ThrottleReasonExhausted ThrottleReason = "EXHAUSTED"
+)
+
+// ThrottleEvent is recorded whenever a code host throttles one of our clients.
+type ThrottleEvent struct {
+	BaseURL  string
+	Resource string
+	Service  string
+	Reason   ThrottleReason
+	// Backoff is how long requests will be held back because of this event.
+	Backoff   time.Duration
+	Timestamp time.Time
+}
+
+func (c *Monitor) throttleEventLocked(now time.Time, reason ThrottleReason) *ThrottleEvent {
+	if c.identity == nil {
+		return nil
+	}
+	var backoff time.Duration
+	switch reason {
+	case ThrottleReasonTooManyRequests:
+		backoff = c.backoff
+	case ThrottleReasonRetryAfter:
+		backoff = c.retry.Sub(now)
+	case ThrottleReasonExhausted:
+		backoff = c.reset.Sub(now)
+	}
+	return &ThrottleEvent{
+		BaseURL:   c.identity.baseURL,
+		Resource:  c.identity.resource,
+		Service:   env.MyName,
+		Reason:    reason,
+		Backoff:   backoff,
+		Timestamp: now,
+	}
+}
+
+// GetMonitorStates returns the last published state of all monitors for the
+// code host API with the given base URL.
+func GetMonitorStates(baseURL string) ([]MonitorState, error) {
+	raw, err := monitorStates.GetHashAll(normaliseURL(baseURL))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading monitor states")
+	}
+	states := make([]MonitorState, 0, len(raw))
+	for _, v := range raw {
+		var state MonitorState
+		if err := json.Unmarshal([]byte(v), &state); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling monitor state")
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// GetThrottleEvents returns up to limit of the most recent throttle events
+// recorded for the code host APIs with the given base URLs, most recent first.
+func GetThrottleEvents(ctx context.Context, limit int, baseURLs ...string) ([]ThrottleEvent, error) {
+	wanted := make(map[string]struct{}, len(baseURLs))
+	for _, u := range baseURLs {
+		wanted[normaliseURL(u)] = struct{}{}
+	}
+
+	raw, err := throttleEvents.All(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading throttle events")
+	}
+	events := make([]ThrottleEvent, 0, limit)
+	for _, b := range raw {
+		if len(events) >= limit {
+			break
+		}
+		var event ThrottleEvent
+		if err := json.Unmarshal(b, &event); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling throttle event")
+		}
+		if _, ok := wanted[event.BaseURL]; ok {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
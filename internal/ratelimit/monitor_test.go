@@ -3,6 +3,7 @@ package ratelimit
 import (
 	"net/http"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -228,3 +229,130 @@ func TestMonitor_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitor_RecordThrottle(t *testing.T) {
+	now := time.Now()
+	m := &Monitor{clock: func() time.Time { return now }}
+
+	t.Run("backs off exponentially without rate limit headers", func(t *testing.T) {
+		for _, want := range []time.Duration{
+			time.Second,
+			2 * time.Second,
+			4 * time.Second,
+			8 * time.Second,
+		} {
+			m.RecordThrottle()
+			assert.Equal(t, want, m.calcRateLimitWaitTime(1))
+
+			// Throttled again right after the backoff expired.
+			now = now.Add(want)
+		}
+	})
+
+	t.Run("backoff is capped", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			m.RecordThrottle()
+			now = now.Add(m.calcRateLimitWaitTime(1))
+		}
+		m.RecordThrottle()
+		assert.Equal(t, maxThrottleBackoff, m.calcRateLimitWaitTime(1))
+	})
+
+	t.Run("backoff resets after a quiet period", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		m.RecordThrottle()
+		assert.Equal(t, minThrottleBackoff, m.calcRateLimitWaitTime(1))
+	})
+
+	t.Run("Retry-After header takes precedence", func(t *testing.T) {
+		m.Update(http.Header{"Retry-After": []string{"42"}})
+		m.RecordThrottle()
+		assert.Equal(t, 42*time.Second, m.calcRateLimitWaitTime(1))
+	})
+}
+
+func TestMonitor_PublishesState(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		states []*MonitorState
+		events []*ThrottleEvent
+	)
+	oldPublish, oldRecord := publishMonitorState, recordThrottleEvent
+	publishMonitorState = func(state *MonitorState) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, state)
+	}
+	recordThrottleEvent = func(event *ThrottleEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+	t.Cleanup(func() { publishMonitorState, recordThrottleEvent = oldPublish, oldRecord })
+
+	now := time.Now()
+	registry := NewMonitorRegistry()
+	m := registry.GetOrSet("https://GitLab.example.com", "0123456789abcdef", "rest", &Monitor{clock: func() time.Time { return now }})
+
+	m.Update(http.Header{
+		"Ratelimit-Limit":     []string{"500"},
+		"Ratelimit-Remaining": []string{"0"},
+		"Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(time.Minute).Unix(), 10)},
+	})
+	m.RecordThrottle()
+	m.addWaiting(1)
+	m.addWaiting(-1)
+
+	// Nothing is published synchronously.
+	mu.Lock()
+	assert.Empty(t, states)
+	assert.Empty(t, events)
+	mu.Unlock()
+
+	registry.publisher.flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, ThrottleReasonExhausted, events[0].Reason)
+		assert.Equal(t, ThrottleReasonTooManyRequests, events[1].Reason)
+		assert.Equal(t, "https://gitlab.example.com/", events[1].BaseURL)
+	}
+	// All the changes are published as a single state.
+	if assert.Len(t, states, 1) {
+		assert.Equal(t, "01234567", states[0].AuthHash)
+		assert.Equal(t, "rest", states[0].Resource)
+		assert.Equal(t, 0, states[0].Remaining)
+		assert.Equal(t, now, states[0].LastThrottledAt)
+	}
+}
+
+func TestMonitor_PublishesStateInBackground(t *testing.T) {
+	published := make(chan *MonitorState, 10)
+	oldPublish := publishMonitorState
+	publishMonitorState = func(state *MonitorState) { published <- state }
+	t.Cleanup(func() { publishMonitorState = oldPublish })
+
+	registry := NewMonitorRegistry()
+	registry.publisher.interval = 10 * time.Millisecond
+	m := registry.GetOrSet("https://github.example.com", "0123456789abcdef", "rest", &Monitor{HeaderPrefix: "X-"})
+
+	for i := 100; i > 90; i-- {
+		m.Update(http.Header{
+			"X-Ratelimit-Limit":     []string{"100"},
+			"X-Ratelimit-Remaining": []string{strconv.Itoa(i)},
+			"X-Ratelimit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)},
+		})
+	}
+
+	select {
+	case state := <-published:
+		assert.Equal(t, 91, state.Remaining)
+	case <-time.After(5 * time.Second):
+		t.Fatal("monitor state was not published")
+	}
+
+	// The updates were published at once.
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, published)
+}
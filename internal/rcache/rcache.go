@@ -22,12 +22,16 @@ const dataVersion = "v2"
 const dataVersionToDelete = "v1"
 
 // DeleteOldCacheData deletes the rcache data in the given Redis instance
-// that's prefixed with dataVersionToDelete
+// that's prefixed with dataVersionToDelete. With Redis Cluster, c must be a
+// connection from the pool of the KeyValue so that every node is cleaned up.
 func DeleteOldCacheData(c redis.Conn) error {
 	return redispool.DeleteAllKeysWithPrefix(c, dataVersionToDelete)
 }
 
 // Cache implements httpcache.Cache
+//
+// All operations act on a single key, so that they work with Redis Cluster
+// regardless of the slots the keys hash to.
 type Cache struct {
 	keyPrefix  string
 	ttlSeconds int
@@ -128,6 +132,15 @@ func (r *Cache) SetHashItem(key string, hashKey string, hashValue string) error
 	return kv().HSet(r.rkeyPrefix()+key, hashKey, hashValue)
 }
 
+// SetHashItemWithTTL sets a key in a HASH like SetHashItem, and sets the TTL of
+// the whole HASH to ttlSeconds.
+func (r *Cache) SetHashItemWithTTL(key string, hashKey string, hashValue string, ttlSeconds int) error {
+	if err := kv().HSet(r.rkeyPrefix()+key, hashKey, hashValue); err != nil {
+		return err
+	}
+	return kv().Expire(r.rkeyPrefix()+key, ttlSeconds)
+}
+
 // GetHashItem gets a key in a HASH.
 func (r *Cache) GetHashItem(key string, hashKey string) (string, error) {
 	return kv().HGet(r.rkeyPrefix()+key, hashKey).String()
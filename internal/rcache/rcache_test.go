@@ -277,6 +277,23 @@ func TestCache_Hashes(t *testing.T) {
 	assert.Equal(t, 0, del4)
 }
 
+func TestCache_SetHashItemWithTTL(t *testing.T) {
+	SetupForTest(t)
+
+	c := New("hash_with_ttl")
+	assert.NoError(t, c.SetHashItemWithTTL("key", "hashKey1", "value1", 60))
+	assert.NoError(t, c.SetHashItemWithTTL("key", "hashKey2", "value2", 30))
+
+	all, err := c.GetHashAll("key")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"hashKey1": "value1", "hashKey2": "value2"}, all)
+
+	// The TTL of the hash is reset by every write.
+	ttl, ok := c.KeyTTL("key")
+	assert.True(t, ok)
+	assert.LessOrEqual(t, ttl, 30)
+}
+
 func bytes(s ...string) [][]byte {
 	t := make([][]byte, len(s))
 	for i, v := range s {
@@ -0,0 +1,925 @@
+package redispool
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// numSlots is the number of hash slots of a Redis Cluster.
+const numSlots = 16384
+
+// scanNodeBits is the number of low bits of a SCAN cursor which hold the index
+// of the master being scanned.
+const scanNodeBits = 10
+
+// maxRedirects bounds the number of MOVED and ASK redirections followed for a
+// single command.
+const maxRedirects = 5
+
+// cluster routes commands to the master serving the hash slot of their key in
+// a Redis Cluster. Keyless commands are sent to an arbitrary master, except
+// for the commands acting on the whole keyspace (e.g., KEYS) which are run on
+// every master. SCAN iterates over the keys of every master in turn.
+//
+// DEL, UNLINK, EXISTS, TOUCH and MGET are split by slot when their keys are in
+// several slots. Other commands which act on several keys (e.g., EVAL with
+// several keys, or the commands of a transaction) only work if all keys hash
+// to the same slot, which callers guarantee with hash tags.
+type cluster struct {
+	seeds []string
+	// dial connects to the node at addr.
+	dial func(addr string) (redis.Conn, error)
+	// poolOpts is the template of the pool of each node.
+	poolOpts *redis.Pool
+
+	mu    sync.RWMutex
+	slots [numSlots]string // address of the master serving each slot, or empty if unknown
+	pools map[string]*redis.Pool
+
+	// refreshing is set while the slot map is refreshed in the background.
+	refreshing atomic.Bool
+}
+
+func newCluster(u *multiHostURI, poolOpts *redis.Pool) *cluster {
+	dialOpts := u.dialOptions()
+	return &cluster{
+		seeds: u.Hosts,
+		dial: func(addr string) (redis.Conn, error) {
+			return redis.Dial("tcp", addr, dialOpts...)
+		},
+		poolOpts: poolOpts,
+		pools:    map[string]*redis.Pool{},
+	}
+}
+
+// Pool returns a pool of connections which route each command to the right
+// node of the cluster.
+func (c *cluster) Pool() *redis.Pool {
+	return &redis.Pool{
+		MaxIdle:     c.poolOpts.MaxIdle,
+		IdleTimeout: c.poolOpts.IdleTimeout,
+		Dial: func() (redis.Conn, error) {
+			return &clusterConn{cluster: c}, nil
+		},
+	}
+}
+
+// nodePool returns the pool of connections to the node at addr.
+func (c *cluster) nodePool(addr string) *redis.Pool {
+	c.mu.RLock()
+	p, ok := c.pools[addr]
+	c.mu.RUnlock()
+	if ok {
+		return p
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.pools[addr]; ok {
+		return p
+	}
+	p = &redis.Pool{
+		MaxIdle:     c.poolOpts.MaxIdle,
+		IdleTimeout: c.poolOpts.IdleTimeout,
+		Dial: func() (redis.Conn, error) {
+			return c.dial(addr)
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+	c.pools[addr] = p
+	return p
+}
+
+// do runs the given command on the master serving key, following MOVED and
+// ASK redirections. If hasKey is false, the command is run on any master.
+func (c *cluster) do(key string, hasKey bool, commandName string, args ...any) (any, error) {
+	addr, err := c.addrFor(key, hasKey)
+	if err != nil {
+		return nil, err
+	}
+
+	asking := false
+	for i := 0; i <= maxRedirects; i++ {
+		reply, err := c.doOnNode(addr, asking, commandName, args...)
+
+		redirect, ok := parseRedirect(err)
+		if !ok {
+			return reply, err
+		}
+		addr, asking = redirect.addr, redirect.ask
+		if !redirect.ask {
+			// The slot has moved for good, and others probably have too.
+			c.setSlot(redirect.slot, redirect.addr)
+			c.refreshInBackground()
+		}
+	}
+
+	return nil, errors.Newf("too many Redis Cluster redirections for %s", commandName)
+}
+
+func (c *cluster) doOnNode(addr string, asking bool, commandName string, args ...any) (any, error) {
+	conn := c.nodePool(addr).Get()
+	defer conn.Close()
+
+	if asking {
+		if _, err := conn.Do("ASKING"); err != nil {
+			return nil, err
+		}
+	}
+	return conn.Do(commandName, args...)
+}
+
+// addrForSlot returns the address of the master serving slot, fetching the
+// slot map if it is unknown.
+func (c *cluster) addrForSlot(slot int) (string, error) {
+	c.mu.RLock()
+	addr := c.slots[slot]
+	c.mu.RUnlock()
+	if addr != "" {
+		return addr, nil
+	}
+
+	if err := c.refresh(); err != nil {
+		return "", err
+	}
+
+	c.mu.RLock()
+	addr = c.slots[slot]
+	c.mu.RUnlock()
+	if addr == "" {
+		return "", errors.Newf("Redis Cluster slot %d is not served by any node", slot)
+	}
+	return addr, nil
+}
+
+// anyMaster returns the address of a master of the cluster.
+func (c *cluster) anyMaster() (string, error) {
+	masters, err := c.masters()
+	if err != nil {
+		return "", err
+	}
+	return masters[0], nil
+}
+
+// masters returns the addresses of the masters of the cluster.
+func (c *cluster) masters() ([]string, error) {
+	c.mu.RLock()
+	masters := distinctAddrs(c.slots[:])
+	c.mu.RUnlock()
+	if len(masters) > 0 {
+		return masters, nil
+	}
+
+	if err := c.refresh(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if masters = distinctAddrs(c.slots[:]); len(masters) == 0 {
+		return nil, errors.New("Redis Cluster has no masters")
+	}
+	return masters, nil
+}
+
+func distinctAddrs(slots []string) []string {
+	var addrs []string
+	seen := map[string]struct{}{}
+	for _, addr := range slots {
+		if _, ok := seen[addr]; ok || addr == "" {
+			continue
+		}
+		seen[addr] = struct{}{}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+func (c *cluster) setSlot(slot int, addr string) {
+	c.mu.Lock()
+	c.slots[slot] = addr
+	c.mu.Unlock()
+}
+
+func (c *cluster) refreshInBackground() {
+	if !c.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer c.refreshing.Store(false)
+		_ = c.refresh()
+	}()
+}
+
+// refresh fetches the slot map from the first known node which answers.
+func (c *cluster) refresh() error {
+	c.mu.RLock()
+	addrs := append(distinctAddrs(c.slots[:]), c.seeds...)
+	c.mu.RUnlock()
+
+	var errs error
+	for _, addr := range addrs {
+		slots, err := c.fetchSlots(addr)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "node %s", addr))
+			continue
+		}
+
+		c.mu.Lock()
+		c.slots = slots
+		c.mu.Unlock()
+		return nil
+	}
+	return errors.Wrap(errs, "failed to get the slots of the Redis Cluster")
+}
+
+func (c *cluster) fetchSlots(addr string) (slots [numSlots]string, err error) {
+	conn := c.nodePool(addr).Get()
+	defer conn.Close()
+
+	ranges, err := redis.Values(conn.Do("CLUSTER", "SLOTS"))
+	if err != nil {
+		return slots, err
+	}
+
+	host, _, _ := net.SplitHostPort(addr)
+	return parseClusterSlots(ranges, host)
+}
+
+// parseClusterSlots parses the reply of CLUSTER SLOTS into a slot map. Nodes
+// without a hostname are assumed to have the same hostname as the node which
+// was queried.
+func parseClusterSlots(ranges []any, defaultHost string) (slots [numSlots]string, err error) {
+	for _, r := range ranges {
+		fields, err := redis.Values(r, nil)
+		if err != nil {
+			return slots, err
+		}
+		if len(fields) < 3 {
+			return slots, errors.New("malformed CLUSTER SLOTS reply")
+		}
+
+		start, err := redis.Int(fields[0], nil)
+		if err != nil {
+			return slots, err
+		}
+		end, err := redis.Int(fields[1], nil)
+		if err != nil {
+			return slots, err
+		}
+		master, err := redis.Values(fields[2], nil)
+		if err != nil {
+			return slots, err
+		}
+		if len(master) < 2 || start < 0 || end >= numSlots {
+			return slots, errors.New("malformed CLUSTER SLOTS reply")
+		}
+		host, err := redis.String(master[0], nil)
+		if err != nil {
+			return slots, err
+		}
+		port, err := redis.Int(master[1], nil)
+		if err != nil {
+			return slots, err
+		}
+		if host == "" || host == "?" {
+			host = defaultHost
+		}
+
+		addr := net.JoinHostPort(host, strconv.Itoa(port))
+		for slot := start; slot <= end; slot++ {
+			slots[slot] = addr
+		}
+	}
+	return slots, nil
+}
+
+type redirect struct {
+	ask  bool
+	slot int
+	addr string
+}
+
+// parseRedirect parses MOVED and ASK errors, e.g. "MOVED 3999 127.0.0.1:6381".
+func parseRedirect(err error) (redirect, bool) {
+	var redisErr redis.Error
+	if !errors.As(err, &redisErr) {
+		return redirect{}, false
+	}
+
+	fields := strings.Fields(string(redisErr))
+	if len(fields) != 3 || (fields[0] != "MOVED" && fields[0] != "ASK") {
+		return redirect{}, false
+	}
+	slot, err := strconv.Atoi(fields[1])
+	if err != nil || slot < 0 || slot >= numSlots {
+		return redirect{}, false
+	}
+	return redirect{ask: fields[0] == "ASK", slot: slot, addr: fields[2]}, true
+}
+
+// HashSlot returns the Redis Cluster hash slot of key. If key contains a hash
+// tag, i.e. a non-empty substring between the first "{" and the next "}", only
+// the hash tag is hashed. Keys with the same hash tag are always stored on the
+// same node, so they can be used together in multi-key commands.
+func HashSlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key)) % numSlots
+}
+
+// crc16 implements the CRC16-CCITT (XMODEM) checksum used by Redis Cluster.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// commandKey returns the key used to route the given command, or false if the
+// command does not act on a key.
+func commandKey(commandName string, args []any) (string, bool) {
+	switch strings.ToUpper(commandName) {
+	case "PING", "INFO", "CONFIG", "CLUSTER", "SCRIPT", "BGREWRITEAOF", "DBSIZE", "TIME", "ROLE", "SCAN", "KEYS", "FLUSHDB", "FLUSHALL", "MULTI", "EXEC", "DISCARD", "UNWATCH":
+		return "", false
+	case "EVAL", "EVALSHA":
+		// EVAL script numkeys [key ...] [arg ...]
+		if len(args) < 3 {
+			return "", false
+		}
+		if numKeys, err := strconv.Atoi(fmt.Sprint(args[1])); err != nil || numKeys == 0 {
+			return "", false
+		}
+		args = args[2:]
+	}
+
+	if len(args) == 0 {
+		return "", false
+	}
+	return keyString(args[0])
+}
+
+func keyString(arg any) (string, bool) {
+	switch key := arg.(type) {
+	case string:
+		return key, true
+	case []byte:
+		return string(key), true
+	}
+	return "", false
+}
+
+// addrFor returns the address of the master serving key, or of any master if
+// hasKey is false.
+func (c *cluster) addrFor(key string, hasKey bool) (string, error) {
+	if hasKey {
+		return c.addrForSlot(HashSlot(key))
+	}
+	return c.anyMaster()
+}
+
+// fanOutCommands are the commands acting on the whole keyspace, which are run
+// on every master.
+var fanOutCommands = map[string]bool{"KEYS": true, "DBSIZE": true, "FLUSHDB": true, "FLUSHALL": true}
+
+// splitCommands are the commands whose arguments are all keys, and which are
+// split into one command per slot if their keys are in several slots.
+var splitCommands = map[string]bool{"DEL": true, "UNLINK": true, "EXISTS": true, "TOUCH": true, "MGET": true}
+
+// onSingleNode returns true if the command runs on a single node, and can be
+// pipelined with the other commands sent to that node.
+func onSingleNode(commandName string, args []any) bool {
+	name := strings.ToUpper(commandName)
+	return !fanOutCommands[name] && name != "SCAN" && !(splitCommands[name] && !sameSlot(args))
+}
+
+// sameSlot returns true if all keys hash to the same slot.
+func sameSlot(keys []any) bool {
+	slot := -1
+	for _, arg := range keys {
+		key, ok := keyString(arg)
+		if !ok {
+			// Let the node reject the command.
+			return true
+		}
+		if s := HashSlot(key); slot == -1 {
+			slot = s
+		} else if s != slot {
+			return false
+		}
+	}
+	return true
+}
+
+// doCommand runs a command which is not part of a transaction.
+func (c *cluster) doCommand(commandName string, args []any) (any, error) {
+	name := strings.ToUpper(commandName)
+	if fanOutCommands[name] {
+		return c.doOnMasters(name, args)
+	}
+	if name == "SCAN" {
+		return c.scan(args)
+	}
+	if splitCommands[name] && !sameSlot(args) {
+		return c.doPerSlot(name, args)
+	}
+
+	key, hasKey := commandKey(commandName, args)
+	return c.do(key, hasKey, commandName, args...)
+}
+
+// doOnMasters runs a command acting on the whole keyspace on every master, and
+// merges their replies.
+func (c *cluster) doOnMasters(name string, args []any) (any, error) {
+	masters, err := c.masters()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []any{}
+	var size int64
+	for _, addr := range masters {
+		reply, err := c.doOnNode(addr, false, name, args...)
+		switch name {
+		case "KEYS":
+			values, err := redis.Values(reply, err)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, values...)
+		case "DBSIZE":
+			n, err := redis.Int64(reply, err)
+			if err != nil {
+				return nil, err
+			}
+			size += n
+		default:
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch name {
+	case "KEYS":
+		return keys, nil
+	case "DBSIZE":
+		return size, nil
+	}
+	return "OK", nil
+}
+
+// scan runs SCAN on the masters one after the other. The cursor returned to the
+// caller holds the index of the master in its lowest scanNodeBits bits, and the
+// cursor of the master in the others.
+func (c *cluster) scan(args []any) (any, error) {
+	if len(args) == 0 {
+		return nil, errors.New("wrong number of arguments for SCAN")
+	}
+	arg, ok := keyString(args[0])
+	if !ok {
+		arg = fmt.Sprint(args[0])
+	}
+	cursor, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return nil, errors.Newf("invalid SCAN cursor %q", arg)
+	}
+
+	masters, err := c.masters()
+	if err != nil {
+		return nil, err
+	}
+	if len(masters) > 1<<scanNodeBits {
+		return nil, errors.Newf("SCAN does not support more than %d Redis Cluster masters", 1<<scanNodeBits)
+	}
+	i := int(cursor & (1<<scanNodeBits - 1))
+	if i >= len(masters) {
+		return nil, errors.Newf("invalid SCAN cursor %q", arg)
+	}
+
+	nodeArgs := append([]any{strconv.FormatUint(cursor>>scanNodeBits, 10)}, args[1:]...)
+	reply, err := redis.Values(c.doOnNode(masters[i], false, "SCAN", nodeArgs...))
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 2 {
+		return nil, errors.Newf("unexpected SCAN reply of length %d", len(reply))
+	}
+	next, err := redis.Uint64(reply[0], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if next == 0 {
+		// The master is done, continue with the next one.
+		if i++; i == len(masters) {
+			return []any{[]byte("0"), reply[1]}, nil
+		}
+	}
+	return []any{[]byte(strconv.FormatUint(next<<scanNodeBits|uint64(i), 10)), reply[1]}, nil
+}
+
+// doPerSlot runs a command whose keys are in several slots as one command per
+// slot, and merges their replies: MGET returns the values in the order of the
+// keys, the other commands return the sum of the replies.
+func (c *cluster) doPerSlot(name string, keys []any) (any, error) {
+	var slots []int
+	indexes := map[int][]int{}
+	for i, arg := range keys {
+		key, _ := keyString(arg)
+		slot := HashSlot(key)
+		if _, ok := indexes[slot]; !ok {
+			slots = append(slots, slot)
+		}
+		indexes[slot] = append(indexes[slot], i)
+	}
+
+	values := make([]any, len(keys))
+	var sum int64
+	for _, slot := range slots {
+		args := make([]any, 0, len(indexes[slot]))
+		for _, i := range indexes[slot] {
+			args = append(args, keys[i])
+		}
+		key, _ := keyString(args[0])
+		reply, err := c.do(key, true, name, args...)
+
+		if name != "MGET" {
+			n, err := redis.Int64(reply, err)
+			if err != nil {
+				return nil, err
+			}
+			sum += n
+			continue
+		}
+
+		slotValues, err := redis.Values(reply, err)
+		if err != nil {
+			return nil, err
+		}
+		if len(slotValues) != len(args) {
+			return nil, errors.Newf("unexpected number of values for MGET: got %d, want %d", len(slotValues), len(args))
+		}
+		for j, i := range indexes[slot] {
+			values[i] = slotValues[j]
+		}
+	}
+
+	if name == "MGET" {
+		return values, nil
+	}
+	return sum, nil
+}
+
+// pipeline runs commands which each run on a single node. The commands sent to
+// the same node are pipelined on one connection, and the nodes are sent their
+// commands concurrently. Commands whose slot moved are then redirected one at a
+// time.
+func (c *cluster) pipeline(cmds []pendingCommand) []pendingReply {
+	replies := make([]pendingReply, len(cmds))
+
+	batches := map[string][]int{}
+	for i, cmd := range cmds {
+		addr, err := c.addrFor(commandKey(cmd.commandName, cmd.args))
+		if err != nil {
+			replies[i].err = err
+			continue
+		}
+		batches[addr] = append(batches[addr], i)
+	}
+
+	var wg sync.WaitGroup
+	for addr, batch := range batches {
+		wg.Add(1)
+		go func(addr string, batch []int) {
+			defer wg.Done()
+			c.pipelineOnNode(addr, cmds, batch, replies)
+		}(addr, batch)
+	}
+	wg.Wait()
+
+	for i, cmd := range cmds {
+		if _, ok := parseRedirect(replies[i].err); ok {
+			key, hasKey := commandKey(cmd.commandName, cmd.args)
+			replies[i].reply, replies[i].err = c.do(key, hasKey, cmd.commandName, cmd.args...)
+		}
+	}
+	return replies
+}
+
+// pipelineOnNode runs the commands of cmds at the given indexes on the node at
+// addr, and sets their replies.
+func (c *cluster) pipelineOnNode(addr string, cmds []pendingCommand, batch []int, replies []pendingReply) {
+	conn := c.nodePool(addr).Get()
+	defer conn.Close()
+
+	err := func() error {
+		for _, i := range batch {
+			if err := conn.Send(cmds[i].commandName, cmds[i].args...); err != nil {
+				return err
+			}
+		}
+		return conn.Flush()
+	}()
+
+	for _, i := range batch {
+		if err != nil {
+			replies[i].err = err
+			continue
+		}
+
+		replies[i].reply, replies[i].err = conn.Receive()
+		if _, ok := replies[i].err.(redis.Error); !ok && replies[i].err != nil {
+			// The connection is broken, so are the replies of the next commands.
+			err = replies[i].err
+		}
+	}
+}
+
+// clusterConn is a redis.Conn which routes each command to the right node of a
+// Redis Cluster.
+//
+// Pipelined commands are run on Flush, or on Receive if they were not flushed.
+// The commands of a transaction, from WATCH or MULTI until EXEC, DISCARD or
+// UNWATCH, are sent on a single connection to the node serving the slot of the
+// first key of the transaction, so all its keys must be in the same slot.
+type clusterConn struct {
+	cluster *cluster
+
+	// pending holds the commands which were sent but not run yet, and replies
+	// the replies which were not received yet of the commands which were run.
+	pending []pendingCommand
+	replies []pendingReply
+
+	// multi is set when MULTI was sent but not forwarded to a node yet, since
+	// the node of a transaction is only known once its first command is sent.
+	multi bool
+	// tx is the transaction in progress, if any.
+	tx *clusterTx
+}
+
+type pendingCommand struct {
+	commandName string
+	args        []any
+}
+
+type pendingReply struct {
+	reply any
+	err   error
+}
+
+// clusterTx is a transaction in progress on a connection to a node.
+type clusterTx struct {
+	conn redis.Conn
+	// pending is the number of replies to receive from conn. The first discard
+	// of them are not returned to the caller.
+	pending int
+	discard int
+	// inMulti is set once MULTI was sent on conn, and done once the command
+	// ending the transaction was.
+	inMulti bool
+	done    bool
+}
+
+func (c *clusterConn) Close() error {
+	if c.tx != nil {
+		// The pool of the connection ends the transaction.
+		_ = c.tx.conn.Close()
+	}
+	c.pending, c.replies, c.multi, c.tx = nil, nil, false, nil
+	return nil
+}
+
+// Err always returns nil: connection errors only affect the connections to the
+// nodes, which are managed by their own pools.
+func (c *clusterConn) Err() error { return nil }
+
+func (c *clusterConn) Do(commandName string, args ...any) (reply any, err error) {
+	if commandName != "" {
+		if err := c.Send(commandName, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	// As with other connections, receive the replies of all pending commands
+	// and return the last one, or the first error.
+	for c.outstanding() > 0 {
+		r, e := c.Receive()
+		if e != nil && err == nil {
+			err = e
+		}
+		reply = r
+	}
+	return reply, err
+}
+
+func (c *clusterConn) Send(commandName string, args ...any) error {
+	if c.tx != nil && c.tx.done {
+		// The replies of the transaction are received before the replies of
+		// the next commands.
+		c.endTx()
+	}
+
+	if c.tx == nil {
+		switch name := strings.ToUpper(commandName); {
+		case name == "MULTI" && !c.multi:
+			c.run()
+			c.replies = append(c.replies, pendingReply{reply: "OK"})
+			c.multi = true
+			return nil
+		case name == "DISCARD" && c.multi:
+			c.replies = append(c.replies, pendingReply{reply: "OK"})
+			c.multi = false
+			return nil
+		case name == "WATCH" || c.multi:
+			if err := c.beginTx(commandName, args); err != nil {
+				return err
+			}
+		default:
+			c.pending = append(c.pending, pendingCommand{commandName: commandName, args: args})
+			return nil
+		}
+	}
+
+	return c.sendTx(commandName, args)
+}
+
+func (c *clusterConn) Flush() error {
+	c.run()
+	if c.tx != nil {
+		if err := c.tx.conn.Flush(); err != nil {
+			return c.failTx(err)
+		}
+	}
+	return nil
+}
+
+func (c *clusterConn) Receive() (any, error) {
+	if len(c.replies) == 0 {
+		c.run()
+	}
+	if len(c.replies) > 0 {
+		r := c.replies[0]
+		c.replies = c.replies[1:]
+		return r.reply, r.err
+	}
+	if c.tx != nil && c.tx.pending > c.tx.discard {
+		return c.receiveTx()
+	}
+	return nil, errors.New("no pending Redis command to receive the reply of")
+}
+
+// outstanding returns the number of replies which were not received yet.
+func (c *clusterConn) outstanding() int {
+	n := len(c.pending) + len(c.replies)
+	if c.tx != nil {
+		n += c.tx.pending - c.tx.discard
+	}
+	return n
+}
+
+// run runs the pending commands and queues their replies. Commands which run
+// on several nodes are run one at a time, and the commands between them are
+// pipelined.
+func (c *clusterConn) run() {
+	cmds := c.pending
+	c.pending = nil
+
+	for len(cmds) > 0 {
+		if !onSingleNode(cmds[0].commandName, cmds[0].args) {
+			reply, err := c.cluster.doCommand(cmds[0].commandName, cmds[0].args)
+			c.replies = append(c.replies, pendingReply{reply: reply, err: err})
+			cmds = cmds[1:]
+			continue
+		}
+
+		n := 1
+		for n < len(cmds) && onSingleNode(cmds[n].commandName, cmds[n].args) {
+			n++
+		}
+		c.replies = append(c.replies, c.cluster.pipeline(cmds[:n])...)
+		cmds = cmds[n:]
+	}
+}
+
+// beginTx starts a transaction on a connection to the node serving the key of
+// the given command, which is its first command. The commands sent before run
+// before the transaction.
+func (c *clusterConn) beginTx(commandName string, args []any) error {
+	c.run()
+
+	addr, err := c.cluster.addrFor(commandKey(commandName, args))
+	if err != nil {
+		return err
+	}
+	c.tx = &clusterTx{conn: c.cluster.nodePool(addr).Get()}
+
+	if c.multi {
+		// The caller already received the reply to MULTI.
+		c.multi = false
+		if err := c.sendTx("MULTI", nil); err != nil {
+			return err
+		}
+		c.tx.discard++
+	}
+	return nil
+}
+
+func (c *clusterConn) sendTx(commandName string, args []any) error {
+	if err := c.tx.conn.Send(commandName, args...); err != nil {
+		return c.failTx(err)
+	}
+	c.tx.pending++
+
+	switch strings.ToUpper(commandName) {
+	case "MULTI":
+		c.tx.inMulti = true
+	case "EXEC", "DISCARD":
+		c.tx.done = true
+	case "UNWATCH":
+		// Within MULTI, UNWATCH is queued like any other command.
+		c.tx.done = !c.tx.inMulti
+	}
+	return nil
+}
+
+// receiveTx receives the next reply of the transaction. The connection of the
+// transaction is released once the replies of all its commands are received.
+func (c *clusterConn) receiveTx() (any, error) {
+	if err := c.tx.conn.Flush(); err != nil {
+		return nil, c.failTx(err)
+	}
+
+	for {
+		reply, err := c.tx.conn.Receive()
+		if err != nil {
+			if _, ok := err.(redis.Error); !ok {
+				return nil, c.failTx(err)
+			}
+			if _, ok := parseRedirect(err); ok {
+				// The transaction is aborted, and has to be retried by the
+				// caller once the slot map is up to date.
+				c.cluster.refreshInBackground()
+			}
+		}
+
+		c.tx.pending--
+		if c.tx.discard > 0 {
+			c.tx.discard--
+			continue
+		}
+		if c.tx.done && c.tx.pending == 0 {
+			_ = c.tx.conn.Close()
+			c.tx = nil
+		}
+		return reply, err
+	}
+}
+
+// endTx receives the replies of a transaction which ended, and releases its
+// connection.
+func (c *clusterConn) endTx() {
+	for c.tx != nil && c.tx.pending > c.tx.discard {
+		reply, err := c.receiveTx()
+		c.replies = append(c.replies, pendingReply{reply: reply, err: err})
+	}
+	if c.tx != nil {
+		_ = c.tx.conn.Close()
+		c.tx = nil
+	}
+}
+
+// failTx aborts the transaction after its connection failed with err. The
+// replies of its commands which were not received yet are err.
+func (c *clusterConn) failTx(err error) error {
+	// The failing reply is returned by the caller.
+	for i := c.tx.pending - c.tx.discard; i > 1; i-- {
+		c.replies = append(c.replies, pendingReply{err: err})
+	}
+	_ = c.tx.conn.Close()
+	c.tx = nil
+	return err
+}
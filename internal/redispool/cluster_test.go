@@ -0,0 +1,623 @@
+package redispool
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestHashSlot(t *testing.T) {
+	// Expected values from CLUSTER KEYSLOT.
+	assert.Equal(t, 12182, HashSlot("foo"))
+	assert.Equal(t, 12739, HashSlot("123456789"))
+	assert.Equal(t, HashSlot("user1000"), HashSlot("{user1000}.following"))
+	assert.Equal(t, HashSlot("{user1000}.following"), HashSlot("{user1000}.followers"))
+	// Empty hash tags are ignored.
+	assert.Equal(t, int(crc16("foo{}{bar}"))%numSlots, HashSlot("foo{}{bar}"))
+	assert.Equal(t, HashSlot("bar"), HashSlot("foo{bar}{zap}"))
+}
+
+func TestParseRedirect(t *testing.T) {
+	r, ok := parseRedirect(redis.Error("MOVED 3999 127.0.0.1:6381"))
+	require.True(t, ok)
+	assert.Equal(t, redirect{slot: 3999, addr: "127.0.0.1:6381"}, r)
+
+	r, ok = parseRedirect(errors.Wrap(redis.Error("ASK 3999 127.0.0.1:6381"), "wrapped"))
+	require.True(t, ok)
+	assert.Equal(t, redirect{ask: true, slot: 3999, addr: "127.0.0.1:6381"}, r)
+
+	for _, err := range []error{nil, errors.New("MOVED 3999 127.0.0.1:6381"), redis.Error("WRONGTYPE Operation against a key")} {
+		_, ok = parseRedirect(err)
+		assert.False(t, ok, "%v", err)
+	}
+}
+
+func TestParseClusterSlots(t *testing.T) {
+	reply := []any{
+		[]any{int64(0), int64(5460), []any{[]byte("10.0.0.1"), int64(6379), []byte("id1")}, []any{[]byte("10.0.0.4"), int64(6379), []byte("id4")}},
+		[]any{int64(5461), int64(16383), []any{[]byte(""), int64(6380), []byte("id2")}},
+	}
+
+	slots, err := parseClusterSlots(reply, "10.0.0.2")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:6379", slots[0])
+	assert.Equal(t, "10.0.0.1:6379", slots[5460])
+	assert.Equal(t, "10.0.0.2:6380", slots[5461])
+	assert.Equal(t, "10.0.0.2:6380", slots[numSlots-1])
+	assert.Equal(t, []string{"10.0.0.1:6379", "10.0.0.2:6380"}, distinctAddrs(slots[:]))
+}
+
+func TestCommandKey(t *testing.T) {
+	for _, tc := range []struct {
+		commandName string
+		args        []any
+		key         string
+		hasKey      bool
+	}{
+		{"GET", []any{"foo"}, "foo", true},
+		{"set", []any{[]byte("foo"), "bar"}, "foo", true},
+		{"PING", nil, "", false},
+		{"INFO", []any{"server"}, "", false},
+		{"EVAL", []any{"return 1", 0, "arg"}, "", false},
+		{"EVALSHA", []any{"sha", 2, "foo", "bar", "arg"}, "foo", true},
+	} {
+		key, hasKey := commandKey(tc.commandName, tc.args)
+		assert.Equal(t, tc.key, key, tc.commandName)
+		assert.Equal(t, tc.hasKey, hasKey, tc.commandName)
+	}
+}
+
+func TestClusterConn(t *testing.T) {
+	f := newFakeCluster("10.0.0.1:6379", "10.0.0.2:6379")
+	a := f.keyOnNode("a", "10.0.0.1:6379")
+	b := f.keyOnNode("b", "10.0.0.2:6379")
+
+	conn := &clusterConn{cluster: f.cluster()}
+	reply, err := conn.Do("SET", a, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "OK", reply)
+	reply, err = conn.Do("SET", b, "2")
+	require.NoError(t, err)
+	assert.Equal(t, "OK", reply)
+
+	// Redirections are followed after the slot moved to another node.
+	f.setSlot(HashSlot(a), "10.0.0.2:6379")
+	value, err := redis.String(conn.Do("GET", a))
+	require.NoError(t, err)
+	assert.Equal(t, "1", value)
+}
+
+func TestClusterConn_Pipeline(t *testing.T) {
+	f := newFakeCluster("10.0.0.1:6379", "10.0.0.2:6379")
+	keys := []string{
+		f.keyOnNode("a", "10.0.0.1:6379"),
+		f.keyOnNode("b", "10.0.0.2:6379"),
+		f.keyOnNode("c", "10.0.0.1:6379"),
+		f.keyOnNode("d", "10.0.0.2:6379"),
+	}
+
+	conn := &clusterConn{cluster: f.cluster()}
+	for i, key := range keys {
+		require.NoError(t, conn.Send("SET", key, i))
+	}
+	require.NoError(t, conn.Flush())
+	for range keys {
+		reply, err := conn.Receive()
+		require.NoError(t, err)
+		assert.Equal(t, "OK", reply)
+	}
+
+	for _, key := range keys {
+		require.NoError(t, conn.Send("GET", key))
+	}
+	for i := range keys {
+		value, err := redis.Int(conn.Receive())
+		require.NoError(t, err)
+		assert.Equal(t, i, value)
+	}
+
+	// Each node is sent its commands in a single round trip per pipeline.
+	assert.Equal(t, map[string]int{"10.0.0.1:6379": 2, "10.0.0.2:6379": 2}, f.roundTrips())
+}
+
+func TestClusterConn_MultiSlotCommands(t *testing.T) {
+	f := newFakeCluster("10.0.0.1:6379", "10.0.0.2:6379")
+	a := f.keyOnNode("a", "10.0.0.1:6379")
+	b := f.keyOnNode("b", "10.0.0.2:6379")
+	c := f.keyOnNode("c", "10.0.0.2:6379")
+
+	conn := &clusterConn{cluster: f.cluster()}
+	for _, key := range []string{a, b, c} {
+		_, err := conn.Do("SET", key, key)
+		require.NoError(t, err)
+	}
+
+	values, err := redis.Strings(conn.Do("MGET", c, "missing", a, b))
+	require.NoError(t, err)
+	assert.Equal(t, []string{c, "", a, b}, values)
+
+	keys, err := redis.Strings(conn.Do("KEYS", "*"))
+	require.NoError(t, err)
+	sort.Strings(keys)
+	want := []string{a, b, c}
+	sort.Strings(want)
+	assert.Equal(t, want, keys)
+
+	n, err := redis.Int(conn.Do("EXISTS", a, b, "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = redis.Int(conn.Do("DEL", a, c))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = redis.Int(conn.Do("DBSIZE"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestClusterConn_Transactions(t *testing.T) {
+	f := newFakeCluster("10.0.0.1:6379", "10.0.0.2:6379")
+	conn := &clusterConn{cluster: f.cluster()}
+
+	t.Run("MULTI", func(t *testing.T) {
+		reply, err := conn.Do("MULTI")
+		require.NoError(t, err)
+		assert.Equal(t, "OK", reply)
+		reply, err = conn.Do("SET", "{user1}:name", "alice")
+		require.NoError(t, err)
+		assert.Equal(t, "QUEUED", reply)
+		reply, err = conn.Do("INCR", "{user1}:visits")
+		require.NoError(t, err)
+		assert.Equal(t, "QUEUED", reply)
+		reply, err = conn.Do("EXEC")
+		require.NoError(t, err)
+		assert.Equal(t, []any{"OK", int64(1)}, reply)
+		assert.Nil(t, conn.tx)
+
+		value, err := redis.String(conn.Do("GET", "{user1}:name"))
+		require.NoError(t, err)
+		assert.Equal(t, "alice", value)
+	})
+
+	t.Run("pipelined MULTI", func(t *testing.T) {
+		require.NoError(t, conn.Send("MULTI"))
+		require.NoError(t, conn.Send("INCR", "{user2}:visits"))
+		require.NoError(t, conn.Send("INCR", "{user2}:visits"))
+		require.NoError(t, conn.Send("EXEC"))
+		require.NoError(t, conn.Send("GET", "{user2}:visits"))
+		require.NoError(t, conn.Flush())
+
+		for _, want := range []any{"OK", "QUEUED", "QUEUED", []any{int64(1), int64(2)}, []byte("2")} {
+			reply, err := conn.Receive()
+			require.NoError(t, err)
+			assert.Equal(t, want, reply)
+		}
+		assert.Nil(t, conn.tx)
+	})
+
+	t.Run("WATCH", func(t *testing.T) {
+		_, err := conn.Do("WATCH", "{user3}:visits")
+		require.NoError(t, err)
+		visits, err := redis.Int(conn.Do("GET", "{user3}:visits"))
+		require.ErrorIs(t, err, redis.ErrNil)
+		_, err = conn.Do("MULTI")
+		require.NoError(t, err)
+		_, err = conn.Do("SET", "{user3}:visits", visits+1)
+		require.NoError(t, err)
+		reply, err := conn.Do("EXEC")
+		require.NoError(t, err)
+		assert.Equal(t, []any{"OK"}, reply)
+		assert.Nil(t, conn.tx)
+	})
+
+	t.Run("keys in several slots", func(t *testing.T) {
+		_, err := conn.Do("MULTI")
+		require.NoError(t, err)
+		_, err = conn.Do("SET", "{user4}:name", "bob")
+		require.NoError(t, err)
+		_, err = conn.Do("SET", "{user5}:name", "bob")
+		require.ErrorContains(t, err, "CROSSSLOT")
+		_, err = conn.Do("EXEC")
+		require.ErrorContains(t, err, "EXECABORT")
+		assert.Nil(t, conn.tx)
+	})
+
+	t.Run("DISCARD", func(t *testing.T) {
+		_, err := conn.Do("MULTI")
+		require.NoError(t, err)
+		reply, err := conn.Do("DISCARD")
+		require.NoError(t, err)
+		assert.Equal(t, "OK", reply)
+		assert.False(t, conn.multi)
+	})
+}
+
+func TestClusterPool_ClosingDiscardsTransaction(t *testing.T) {
+	f := newFakeCluster("10.0.0.1:6379", "10.0.0.2:6379")
+	pool := f.cluster().Pool()
+
+	conn := pool.Get()
+	_, err := conn.Do("MULTI")
+	require.NoError(t, err)
+	_, err = conn.Do("SET", "{user1}:name", "alice")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	conn = pool.Get()
+	defer conn.Close()
+	_, err = redis.String(conn.Do("GET", "{user1}:name"))
+	assert.ErrorIs(t, err, redis.ErrNil)
+}
+
+func TestDeleteAllKeysWithPrefix_Cluster(t *testing.T) {
+	// Scan the keys of each node over several pages.
+	defer func(size int) { deleteBatchSize = size }(deleteBatchSize)
+	deleteBatchSize = 1
+
+	f := newFakeCluster("10.0.0.1:6379", "10.0.0.2:6379")
+	conn := f.cluster().Pool().Get()
+	defer conn.Close()
+
+	keys := []string{
+		f.keyOnNode("other:a", "10.0.0.1:6379"),
+		f.keyOnNode("prefix:a", "10.0.0.1:6379"),
+		f.keyOnNode("prefix:b", "10.0.0.1:6379"),
+		f.keyOnNode("prefix:c", "10.0.0.2:6379"),
+		f.keyOnNode("prefix:d", "10.0.0.2:6379"),
+	}
+	for _, key := range keys {
+		_, err := conn.Do("SET", key, "1")
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, DeleteAllKeysWithPrefix(conn, "prefix"))
+
+	remaining, err := redis.Strings(conn.Do("KEYS", "*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{keys[0]}, remaining)
+}
+
+func TestClusterScan(t *testing.T) {
+	f := newFakeCluster("10.0.0.1:6379", "10.0.0.2:6379")
+	conn := f.cluster().Pool().Get()
+	defer conn.Close()
+
+	var want []string
+	for _, addr := range []string{"10.0.0.1:6379", "10.0.0.2:6379"} {
+		for _, prefix := range []string{"a", "b", "c"} {
+			key := f.keyOnNode(prefix+":", addr)
+			_, err := conn.Do("SET", key, "1")
+			require.NoError(t, err)
+			want = append(want, key)
+		}
+	}
+
+	var got []string
+	cursor, calls := "0", 0
+	for {
+		reply, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", "*", "COUNT", 2))
+		require.NoError(t, err)
+		var keys []string
+		_, err = redis.Scan(reply, &cursor, &keys)
+		require.NoError(t, err)
+		got = append(got, keys...)
+		calls++
+		if cursor == "0" {
+			break
+		}
+	}
+
+	sort.Strings(got)
+	sort.Strings(want)
+	assert.Equal(t, want, got)
+	// Two pages on each node.
+	assert.Equal(t, 4, calls)
+
+	_, err := conn.Do("SCAN", "7")
+	assert.Error(t, err)
+}
+
+// fakeCluster is an in-memory Redis Cluster. It supports enough commands to
+// test the routing of commands and transactions.
+type fakeCluster struct {
+	mu    sync.Mutex
+	slots [numSlots]string
+	data  map[string]string
+	// flushes is the number of round trips to each node.
+	flushes map[string]int
+}
+
+// newFakeCluster returns a cluster whose slots are evenly split between the
+// nodes at addrs.
+func newFakeCluster(addrs ...string) *fakeCluster {
+	f := &fakeCluster{data: map[string]string{}, flushes: map[string]int{}}
+	for slot := range f.slots {
+		f.slots[slot] = addrs[slot*len(addrs)/numSlots]
+	}
+	return f
+}
+
+func (f *fakeCluster) cluster() *cluster {
+	return &cluster{
+		seeds: []string{f.slots[0]},
+		dial: func(addr string) (redis.Conn, error) {
+			return &fakeNodeConn{cluster: f, addr: addr}, nil
+		},
+		poolOpts: &redis.Pool{MaxIdle: 10},
+		pools:    map[string]*redis.Pool{},
+	}
+}
+
+// keyOnNode returns a key starting with prefix which is stored on the node at addr.
+func (f *fakeCluster) keyOnNode(prefix, addr string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := 0; ; i++ {
+		if key := prefix + strconv.Itoa(i); f.slots[HashSlot(key)] == addr {
+			return key
+		}
+	}
+}
+
+func (f *fakeCluster) setSlot(slot int, addr string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.slots[slot] = addr
+}
+
+// roundTrips returns the number of round trips to each node, ignoring the
+// requests for the slot map and the health checks of the connections.
+func (f *fakeCluster) roundTrips() map[string]int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	flushes := f.flushes
+	f.flushes = map[string]int{}
+	return flushes
+}
+
+func (f *fakeCluster) clusterSlots() []any {
+	var ranges []any
+	for start := 0; start < numSlots; {
+		end := start
+		for end+1 < numSlots && f.slots[end+1] == f.slots[start] {
+			end++
+		}
+		host, port, _ := strings.Cut(f.slots[start], ":")
+		p, _ := strconv.Atoi(port)
+		ranges = append(ranges, []any{int64(start), int64(end), []any{[]byte(host), int64(p), []byte("id")}})
+		start = end + 1
+	}
+	return ranges
+}
+
+// fakeNodeConn is a connection to a node of a fakeCluster.
+type fakeNodeConn struct {
+	cluster *fakeCluster
+	addr    string
+
+	sent    []pendingCommand
+	replies []any
+
+	// multi is set within MULTI, queued holds the commands of the transaction
+	// and slot the slot of their keys, and aborted is set if one of them was
+	// rejected.
+	multi   bool
+	queued  [][]string
+	slot    int
+	aborted bool
+}
+
+func (c *fakeNodeConn) Close() error { return nil }
+func (c *fakeNodeConn) Err() error   { return nil }
+
+func (c *fakeNodeConn) Send(commandName string, args ...any) error {
+	c.sent = append(c.sent, pendingCommand{commandName: commandName, args: args})
+	return nil
+}
+
+func (c *fakeNodeConn) Flush() error {
+	if len(c.sent) == 0 {
+		return nil
+	}
+
+	f := c.cluster
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.ToUpper(c.sent[0].commandName) {
+	case "CLUSTER", "PING":
+	default:
+		f.flushes[c.addr]++
+	}
+	for _, cmd := range c.sent {
+		args := []string{strings.ToUpper(cmd.commandName)}
+		for _, arg := range cmd.args {
+			if b, ok := arg.([]byte); ok {
+				arg = string(b)
+			}
+			args = append(args, fmt.Sprint(arg))
+		}
+		c.replies = append(c.replies, c.run(args))
+	}
+	c.sent = nil
+	return nil
+}
+
+func (c *fakeNodeConn) Receive() (any, error) {
+	if len(c.replies) == 0 {
+		return nil, errors.New("no reply")
+	}
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+	if err, ok := reply.(redis.Error); ok {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *fakeNodeConn) Do(commandName string, args ...any) (reply any, err error) {
+	if commandName != "" {
+		_ = c.Send(commandName, args...)
+	}
+	_ = c.Flush()
+	for len(c.replies) > 0 {
+		r, e := c.Receive()
+		if e != nil && err == nil {
+			err = e
+		}
+		reply = r
+	}
+	return reply, err
+}
+
+// run runs a command with the lock of the cluster held.
+func (c *fakeNodeConn) run(args []string) any {
+	f := c.cluster
+	switch args[0] {
+	case "PING":
+		return "PONG"
+	case "CLUSTER":
+		return f.clusterSlots()
+	case "UNWATCH":
+		return "OK"
+	case "MULTI":
+		if c.multi {
+			return redis.Error("ERR MULTI calls can not be nested")
+		}
+		c.multi, c.queued, c.slot, c.aborted = true, nil, -1, false
+		return "OK"
+	case "DISCARD":
+		c.multi, c.queued = false, nil
+		return "OK"
+	case "EXEC":
+		if !c.multi {
+			return redis.Error("ERR EXEC without MULTI")
+		}
+		c.multi = false
+		if c.aborted {
+			return redis.Error("EXECABORT Transaction discarded because of previous errors.")
+		}
+		replies := []any{}
+		for _, cmd := range c.queued {
+			replies = append(replies, c.exec(cmd))
+		}
+		return replies
+	case "KEYS", "DBSIZE":
+		var keys []any
+		for key := range f.data {
+			if ok, _ := path.Match(args[len(args)-1], key); (ok || args[0] == "DBSIZE") && f.slots[HashSlot(key)] == c.addr {
+				keys = append(keys, []byte(key))
+			}
+		}
+		if args[0] == "DBSIZE" {
+			return int64(len(keys))
+		}
+		return keys
+	case "SCAN":
+		// SCAN cursor MATCH pattern COUNT count, where cursor is the first slot
+		// to scan. Like the buckets of Redis, the keys of a slot are returned
+		// together, so that deleting keys does not make the scan skip others.
+		cursor, _ := strconv.Atoi(args[1])
+		count, _ := strconv.Atoi(args[5])
+		page := []any{}
+		for ; cursor < numSlots && len(page) < count; cursor++ {
+			if f.slots[cursor] != c.addr {
+				continue
+			}
+			var keys []string
+			for key := range f.data {
+				if ok, _ := path.Match(args[3], key); ok && HashSlot(key) == cursor {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				page = append(page, []byte(key))
+			}
+		}
+		if cursor == numSlots {
+			cursor = 0
+		}
+		return []any{[]byte(strconv.Itoa(cursor)), page}
+	}
+
+	keys := args[1:2]
+	switch args[0] {
+	case "DEL", "EXISTS", "MGET":
+		keys = args[1:]
+	}
+	slot := HashSlot(keys[0])
+	if c.multi && c.slot == -1 {
+		c.slot = slot
+	}
+	for _, key := range keys {
+		if HashSlot(key) != slot || (c.multi && c.slot != slot) {
+			c.aborted = c.multi
+			return redis.Error("CROSSSLOT Keys in request don't hash to the same slot")
+		}
+	}
+	if addr := f.slots[slot]; addr != c.addr {
+		c.aborted = c.multi
+		return redis.Error(fmt.Sprintf("MOVED %d %s", slot, addr))
+	}
+
+	if c.multi {
+		c.queued = append(c.queued, args)
+		return "QUEUED"
+	}
+	return c.exec(args)
+}
+
+func (c *fakeNodeConn) exec(args []string) any {
+	data := c.cluster.data
+	switch args[0] {
+	case "WATCH":
+		return "OK"
+	case "GET":
+		if value, ok := data[args[1]]; ok {
+			return []byte(value)
+		}
+		return nil
+	case "SET":
+		data[args[1]] = args[2]
+		return "OK"
+	case "INCR":
+		n, _ := strconv.Atoi(data[args[1]])
+		data[args[1]] = strconv.Itoa(n + 1)
+		return int64(n + 1)
+	case "MGET":
+		var values []any
+		for _, key := range args[1:] {
+			if value, ok := data[key]; ok {
+				values = append(values, []byte(value))
+			} else {
+				values = append(values, nil)
+			}
+		}
+		return values
+	case "DEL", "EXISTS":
+		var n int64
+		for _, key := range args[1:] {
+			if _, ok := data[key]; ok {
+				n++
+				if args[0] == "DEL" {
+					delete(data, key)
+				}
+			}
+		}
+		return n
+	}
+	return redis.Error("ERR unknown command " + args[0])
+}
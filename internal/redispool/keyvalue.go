@@ -136,7 +136,10 @@ func DBKeyValueURI(namespace string) string {
 //     in memory of the current process.
 //  2. if addr was created by DBKeyValueURI we use a KeyValue that is backed
 //     by postgres.
-//  3. otherwise treat as a redis address.
+//  3. if addr has a redis+sentinel:// or redis+cluster:// scheme we connect
+//     to the master monitored by Redis Sentinel, or to the nodes of a Redis
+//     Cluster. See multiHostURI for the format.
+//  4. otherwise treat as a redis address.
 //
 // poolOpts is a required argument which sets defaults in the case we connect
 // to redis. If used we only override TestOnBorrow and Dial.
@@ -149,12 +152,33 @@ func NewKeyValue(addr string, poolOpts *redis.Pool) KeyValue {
 		return DBKeyValue(namespace)
 	}
 
-	poolOpts.TestOnBorrow = func(c redis.Conn, t time.Time) error {
-		_, err := c.Do("PING")
-		return err
+	u, err := parseMultiHostURI(addr)
+	if err != nil {
+		// Like other invalid addresses, fail when connecting.
+		poolOpts.Dial = func() (redis.Conn, error) {
+			return nil, err
+		}
+		return RedisKeyValue(poolOpts)
 	}
-	poolOpts.Dial = func() (redis.Conn, error) {
-		return dialRedis(addr)
+
+	switch {
+	case u != nil && u.Sentinel:
+		s := newSentinel(u)
+		poolOpts.Dial = s.dial
+		// Discard connections to a master which has since been demoted.
+		poolOpts.TestOnBorrow = func(c redis.Conn, t time.Time) error {
+			return checkIsMaster(c)
+		}
+	case u != nil:
+		return RedisKeyValue(newCluster(u, poolOpts).Pool())
+	default:
+		poolOpts.Dial = func() (redis.Conn, error) {
+			return dialRedis(addr)
+		}
+		poolOpts.TestOnBorrow = func(c redis.Conn, t time.Time) error {
+			_, err := c.Do("PING")
+			return err
+		}
 	}
 	return RedisKeyValue(poolOpts)
 }
@@ -1,6 +1,7 @@
 package redispool_test
 
 import (
+	"fmt"
 	"os"
 	"reflect"
 	"strings"
@@ -18,6 +19,76 @@ func TestRedisKeyValue(t *testing.T) {
 	testKeyValue(t, redisKeyValueForTest(t))
 }
 
+func TestRedisCluster(t *testing.T) {
+	// e.g. redis+cluster://127.0.0.1:7000
+	endpoint := os.Getenv("REDIS_CLUSTER_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("REDIS_CLUSTER_ENDPOINT is not set")
+	}
+	pool, ok := redispool.NewKeyValue(endpoint, &redis.Pool{MaxIdle: 3, IdleTimeout: 240 * time.Second}).Pool()
+	if !ok {
+		t.Fatal("expected a pool")
+	}
+
+	prefix := "__test__" + t.Name()
+	c := pool.Get()
+	defer c.Close()
+	if err := redispool.DeleteAllKeysWithPrefix(c, prefix); err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys sharing a hash tag can be used in the same transaction.
+	a, b := prefix+":{tx}:a", prefix+":{tx}:b"
+	if _, err := c.Do("WATCH", a); err != nil {
+		t.Fatal(err)
+	}
+	c.Send("MULTI")
+	c.Send("SET", a, "1")
+	c.Send("INCR", b)
+	got, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{"OK", int64(1)}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected EXEC reply: got %q, want %q", got, want)
+	}
+
+	// Pipelines and multi-key commands can span nodes.
+	var keys []any
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("%s:pipeline:%d", prefix, i)
+		keys = append(keys, key)
+		c.Send("SET", key, i)
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	for range keys {
+		if _, err := c.Receive(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	values, err := redis.Ints(c.Do("MGET", keys...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(values, want) {
+		t.Fatalf("unexpected MGET reply: got %v, want %v", values, want)
+	}
+
+	if err := redispool.DeleteAllKeysWithPrefix(c, prefix); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := redis.Int(c.Do("EXISTS", append(keys, a, b)...)); err != nil || n != 0 {
+		t.Fatalf("expected all keys to be deleted: %d, %v", n, err)
+	}
+
+	// The key value store works the same on a cluster.
+	testKeyValue(t, redispool.RedisKeyValue(pool).(interface {
+		WithPrefix(string) redispool.KeyValue
+	}).WithPrefix(prefix))
+}
+
 func testKeyValue(t *testing.T, kv redispool.KeyValue) {
 	t.Parallel()
 
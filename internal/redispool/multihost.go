@@ -0,0 +1,136 @@
+package redispool
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	sentinelScheme    = "redis+sentinel"
+	sentinelTLSScheme = "rediss+sentinel"
+	clusterScheme     = "redis+cluster"
+	clusterTLSScheme  = "rediss+cluster"
+)
+
+// multiHostURI is a parsed endpoint of a Redis deployment made of several
+// hosts: a set of Redis Sentinels, or the seed nodes of a Redis Cluster.
+// Endpoints have the following formats:
+//
+//	redis+sentinel://[[username]:password@]host:port[,host:port...]/master-name[?db=N&sentinel_password=...]
+//	redis+cluster://[[username]:password@]host:port[,host:port...]
+//
+// The rediss+sentinel and rediss+cluster schemes connect over TLS. The
+// credentials are used to authenticate with the Redis servers, not with the
+// Sentinels.
+type multiHostURI struct {
+	// Sentinel is true if Hosts are Redis Sentinels, false if they are Redis
+	// Cluster nodes.
+	Sentinel bool
+	Hosts    []string
+	Username string
+	Password string
+	TLS      bool
+
+	// MasterName is the name of the master monitored by the Sentinels.
+	MasterName string
+	// DB is the database to select. Redis Cluster only supports database 0.
+	DB int
+	// SentinelPassword authenticates with the Sentinels.
+	SentinelPassword string
+}
+
+// parseMultiHostURI parses raw if it uses one of the multi-host schemes. It
+// returns nil if raw is the endpoint of a single Redis server.
+func parseMultiHostURI(raw string) (*multiHostURI, error) {
+	scheme, rest, found := strings.Cut(raw, "://")
+	if !found {
+		return nil, nil
+	}
+
+	u := &multiHostURI{TLS: strings.HasPrefix(scheme, "rediss")}
+	switch scheme {
+	case sentinelScheme, sentinelTLSScheme:
+		u.Sentinel = true
+	case clusterScheme, clusterTLSScheme:
+	default:
+		return nil, nil
+	}
+
+	var err error
+	rest, rawQuery, _ := strings.Cut(rest, "?")
+	hosts, path, _ := strings.Cut(rest, "/")
+	if i := strings.LastIndex(hosts, "@"); i >= 0 {
+		userinfo := hosts[:i]
+		hosts = hosts[i+1:]
+		if u.Username, u.Password, err = parseUserinfo(userinfo); err != nil {
+			return nil, err
+		}
+	}
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			u.Hosts = append(u.Hosts, host)
+		}
+	}
+	if len(u.Hosts) == 0 {
+		return nil, errors.Newf("Redis endpoint %q has no hosts", scheme+"://")
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Redis endpoint query")
+	}
+	if db := query.Get("db"); db != "" {
+		if u.DB, err = strconv.Atoi(db); err != nil {
+			return nil, errors.Wrap(err, "invalid Redis database")
+		}
+	}
+	u.SentinelPassword = query.Get("sentinel_password")
+
+	if u.Sentinel {
+		if u.MasterName, err = url.PathUnescape(strings.Trim(path, "/")); err != nil {
+			return nil, errors.Wrap(err, "invalid Redis Sentinel master name")
+		}
+		if u.MasterName == "" {
+			return nil, errors.New("Redis Sentinel endpoint must specify the master name as its path")
+		}
+	} else if u.DB != 0 {
+		return nil, errors.New("Redis Cluster only supports database 0")
+	}
+
+	return u, nil
+}
+
+func parseUserinfo(userinfo string) (username, password string, err error) {
+	username, password, hasPassword := strings.Cut(userinfo, ":")
+	if username, err = url.PathUnescape(username); err != nil {
+		return "", "", err
+	}
+	if !hasPassword {
+		// As with redis:// URLs, a lone userinfo is the password.
+		return "", username, nil
+	}
+	password, err = url.PathUnescape(password)
+	return username, password, err
+}
+
+// dialOptions returns the options to connect to the Redis servers.
+func (u *multiHostURI) dialOptions() []redis.DialOption {
+	opts := []redis.DialOption{
+		redis.DialConnectTimeout(5 * time.Second),
+		redis.DialUseTLS(u.TLS),
+		redis.DialDatabase(u.DB),
+	}
+	if u.Username != "" {
+		opts = append(opts, redis.DialUsername(u.Username))
+	}
+	if u.Password != "" {
+		opts = append(opts, redis.DialPassword(u.Password))
+	}
+	return opts
+}
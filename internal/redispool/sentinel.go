@@ -0,0 +1,103 @@
+package redispool
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// sentinel dials the current master of a Redis deployment monitored by Redis
+// Sentinel.
+type sentinel struct {
+	addrs      []string
+	masterName string
+	// dialOpts are used to connect to the master.
+	dialOpts []redis.DialOption
+	// sentinelDialOpts are used to connect to the Sentinels.
+	sentinelDialOpts []redis.DialOption
+}
+
+func newSentinel(u *multiHostURI) *sentinel {
+	sentinelDialOpts := []redis.DialOption{
+		redis.DialConnectTimeout(5 * time.Second),
+		redis.DialReadTimeout(5 * time.Second),
+		redis.DialUseTLS(u.TLS),
+	}
+	if u.SentinelPassword != "" {
+		sentinelDialOpts = append(sentinelDialOpts, redis.DialPassword(u.SentinelPassword))
+	}
+
+	return &sentinel{
+		addrs:            u.Hosts,
+		masterName:       u.MasterName,
+		dialOpts:         u.dialOptions(),
+		sentinelDialOpts: sentinelDialOpts,
+	}
+}
+
+// dial connects to the current master. Connections to a server which is no
+// longer the master are rejected, so that the pool eventually reconnects to
+// the new master after a failover.
+func (s *sentinel) dial() (redis.Conn, error) {
+	addr, err := s.masterAddr()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := redis.Dial("tcp", addr, s.dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIsMaster(c); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// masterAddr asks the Sentinels, in order, for the address of the master.
+func (s *sentinel) masterAddr() (string, error) {
+	var errs error
+	for _, addr := range s.addrs {
+		masterAddr, err := s.queryMasterAddr(addr)
+		if err == nil {
+			return masterAddr, nil
+		}
+		errs = errors.Append(errs, errors.Wrapf(err, "sentinel %s", addr))
+	}
+	return "", errors.Wrapf(errs, "failed to get the address of Redis master %q", s.masterName)
+}
+
+func (s *sentinel) queryMasterAddr(addr string) (string, error) {
+	c, err := redis.Dial("tcp", addr, s.sentinelDialOpts...)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	hostPort, err := redis.Strings(c.Do("SENTINEL", "get-master-addr-by-name", s.masterName))
+	if err != nil {
+		return "", err
+	}
+	if len(hostPort) != 2 {
+		return "", errors.Newf("unexpected reply %q", hostPort)
+	}
+	return hostPort[0] + ":" + hostPort[1], nil
+}
+
+// checkIsMaster returns an error if c is not connected to a master.
+func checkIsMaster(c redis.Conn) error {
+	role, err := redis.Values(c.Do("ROLE"))
+	if err != nil {
+		return err
+	}
+	if len(role) == 0 {
+		return errors.New("empty reply to ROLE")
+	}
+	if r, _ := redis.String(role[0], nil); r != "master" {
+		return errors.Newf("Redis server is a %s, not a master", r)
+	}
+	return nil
+}
@@ -1,33 +1,41 @@
 package redispool
 
-import "github.com/gomodule/redigo/redis"
+import (
+	"github.com/gomodule/redigo/redis"
 
-// The number of keys to delete per batch.
-// The maximum number of keys that can be unpacked
-// is determined by the Lua config LUAI_MAXCSTACK
-// which is 8000 by default.
-// See https://www.lua.org/source/5.1/luaconf.h.html
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// The number of keys to scan and delete per batch.
 var deleteBatchSize = 5000
 
+// DeleteAllKeysWithPrefix deletes all keys starting with prefix + ":". With
+// Redis Cluster, SCAN iterates over the keys of every master in turn.
 func DeleteAllKeysWithPrefix(c redis.Conn, prefix string) error {
-	const script = `
-redis.replicate_commands()
-local cursor = '0'
-local prefix = ARGV[1]
-local batchSize = ARGV[2]
-local result = ''
-repeat
-	local keys = redis.call('SCAN', cursor, 'MATCH', prefix, 'COUNT', batchSize)
-	if #keys[2] > 0
-	then
-		result = redis.call('DEL', unpack(keys[2]))
-	end
-
-	cursor = keys[1]
-until cursor == '0'
-return result
-`
+	cursor := "0"
+	for {
+		reply, err := redis.Values(c.Do("SCAN", cursor, "MATCH", prefix+":*", "COUNT", deleteBatchSize))
+		if err != nil {
+			return err
+		}
+		if len(reply) != 2 {
+			return errors.Newf("unexpected SCAN reply of length %d", len(reply))
+		}
+		if cursor, err = redis.String(reply[0], nil); err != nil {
+			return err
+		}
+		keys, err := redis.Values(reply[1], nil)
+		if err != nil {
+			return err
+		}
 
-	_, err := c.Do("EVAL", script, 0, prefix+":*", deleteBatchSize)
-	return err
+		if len(keys) > 0 {
+			if _, err := c.Do("DEL", keys...); err != nil {
+				return err
+			}
+		}
+		if cursor == "0" {
+			return nil
+		}
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/goware/urlx"
 	"github.com/sourcegraph/log"
@@ -50,7 +51,7 @@ func NewAzureDevOpsSource(ctx context.Context, logger log.Logger, svc *types.Ext
 		return nil, err
 	}
 
-	cli, err := azuredevops.NewClient(svc.URN(), c.Url, &auth.BasicAuth{Username: c.Username, Password: c.Token}, httpCli)
+	cli, err := azuredevops.NewClient(svc.URN(), c.Url, azuredevops.AuthenticatorFromConfig(&c), httpCli)
 	if err != nil {
 		return nil, err
 	}
@@ -83,24 +84,56 @@ func (s *AzureDevOpsSource) CheckConnection(ctx context.Context) error {
 		_, err := s.cli.GetAuthorizedProfile(ctx)
 		return err
 	}
-	// If this isn't Azure DevOps Services, i.e. not https://dev.azure.com, return
-	// ok but log a warning because it is not supported.
-	s.logger.Warn("connection check for Azure DevOps Server is not supported, skipping.")
+
+	// Azure DevOps Server has no profile API, so we check that we can access
+	// the first configured collection or project instead.
+	switch {
+	case len(s.config.Orgs) > 0:
+		_, err := s.cli.ListProjects(ctx, s.config.Orgs[0])
+		return err
+	case len(s.config.Projects) > 0:
+		org, project, ok := strings.Cut(s.config.Projects[0], "/")
+		if !ok {
+			return errors.Newf("invalid project name %q, expected collection/project", s.config.Projects[0])
+		}
+		_, err := s.cli.GetProject(ctx, org, project)
+		return err
+	}
 	return nil
 }
 
 // ListRepos returns all Azure DevOps repositories configured with this AzureDevOpsSource's config.
 func (s *AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
 	for _, project := range s.config.Projects {
-		s.processReposFromProjectOrOrg(ctx, project, results)
+		org, _, _ := strings.Cut(project, "/")
+		s.processReposFromProjectOrOrg(ctx, org, project, results)
 	}
 
 	for _, org := range s.config.Orgs {
-		s.processReposFromProjectOrOrg(ctx, org, results)
+		if s.cli.IsAzureDevOpsServices() {
+			s.processReposFromProjectOrOrg(ctx, org, org, results)
+			continue
+		}
+		s.processReposFromCollection(ctx, org, results)
+	}
+}
+
+// processReposFromCollection lists the repositories of an Azure DevOps Server
+// collection project by project, so that a single project we can't access
+// doesn't prevent discovering the repositories of the other projects.
+func (s *AzureDevOpsSource) processReposFromCollection(ctx context.Context, collection string, results chan SourceResult) {
+	projects, err := s.cli.ListProjects(ctx, collection)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: errors.Wrapf(err, "listing projects of collection %q", collection)}
+		return
+	}
+
+	for _, project := range projects {
+		s.processReposFromProjectOrOrg(ctx, collection, collection+"/"+project.Name, results)
 	}
 }
 
-func (s *AzureDevOpsSource) processReposFromProjectOrOrg(ctx context.Context, name string, results chan SourceResult) {
+func (s *AzureDevOpsSource) processReposFromProjectOrOrg(ctx context.Context, org, name string, results chan SourceResult) {
 	repos, err := s.cli.ListRepositoriesByProjectOrOrg(ctx, azuredevops.ListRepositoriesByProjectOrOrgArgs{
 		ProjectOrOrgName: name,
 	})
@@ -110,7 +143,7 @@ func (s *AzureDevOpsSource) processReposFromProjectOrOrg(ctx context.Context, na
 	}
 
 	for _, repo := range repos {
-		org, err := repo.GetOrganization()
+		org, err := s.organization(repo, org)
 		if err != nil {
 			results <- SourceResult{Source: s, Err: err}
 			continue
@@ -119,7 +152,7 @@ func (s *AzureDevOpsSource) processReposFromProjectOrOrg(ctx context.Context, na
 		if s.excluder.ShouldExclude(fullName) {
 			continue
 		}
-		repo, err := s.makeRepo(repo)
+		repo, err := s.makeRepo(repo, org)
 		if err != nil {
 			results <- SourceResult{Source: s, Err: err}
 			return
@@ -128,6 +161,16 @@ func (s *AzureDevOpsSource) processReposFromProjectOrOrg(ctx context.Context, na
 	}
 }
 
+// organization returns the organization of the repository. On Azure DevOps
+// Server the server may be hosted under a path, so the collection can't be
+// parsed from the repository URL and the configured collection is used.
+func (s *AzureDevOpsSource) organization(repo azuredevops.Repository, configured string) (string, error) {
+	if !s.cli.IsAzureDevOpsServices() {
+		return configured, nil
+	}
+	return repo.GetOrganization()
+}
+
 // ExternalServices returns a singleton slice containing the external service.
 func (s *AzureDevOpsSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
@@ -147,12 +190,8 @@ func (s *AzureDevOpsSource) WithAuthenticator(a auth.Authenticator) (Source, err
 	return &sc, nil
 }
 
-func (s *AzureDevOpsSource) makeRepo(p azuredevops.Repository) (*types.Repo, error) {
+func (s *AzureDevOpsSource) makeRepo(p azuredevops.Repository, org string) (*types.Repo, error) {
 	urn := s.svc.URN()
-	org, err := p.GetOrganization()
-	if err != nil {
-		return nil, err
-	}
 	fullURL, err := urlx.Parse(fmt.Sprintf("%s%s/%s/%s", s.cli.GetURL().String(), org, p.Project.Name, p.Name))
 	if err != nil {
 		return nil, err
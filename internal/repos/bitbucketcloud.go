@@ -49,6 +49,11 @@ func newBitbucketCloudSource(logger log.Logger, svc *types.ExternalService, c *s
 	if cf == nil {
 		cf = httpcli.ExternalClientFactory
 	}
+	cf = cf.WithMiddleware(externalServiceMiddleware(
+		svc,
+		(*circuitBreakerConfig)(c.CircuitBreaker),
+		(*adaptiveConcurrencyConfig)(c.AdaptiveConcurrency),
+	)...)
 
 	cli, err := cf.Doer()
 	if err != nil {
@@ -53,6 +53,11 @@ func newBitbucketServerSource(logger log.Logger, svc *types.ExternalService, c *
 	if cf == nil {
 		cf = httpcli.ExternalClientFactory
 	}
+	cf = cf.WithMiddleware(externalServiceMiddleware(
+		svc,
+		(*circuitBreakerConfig)(c.CircuitBreaker),
+		(*adaptiveConcurrencyConfig)(c.AdaptiveConcurrency),
+	)...)
 
 	var opts []httpcli.Opt
 	if c.Certificate != "" {
@@ -31,3 +31,13 @@ func ConfRepoConcurrentExternalServiceSyncers() int {
 	}
 	return v
 }
+
+// ConfRepoExternalServiceSyncWorkers returns the number of workers that a
+// single external service sync uses.
+func ConfRepoExternalServiceSyncWorkers() int {
+	v := conf.Get().RepoExternalServiceSyncWorkers
+	if v <= 0 {
+		return 1
+	}
+	return v
+}
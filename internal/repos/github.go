@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
+	"github.com/sourcegraph/conc/pool"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
@@ -105,6 +106,11 @@ func newGitHubSource(
 	if cf == nil {
 		cf = httpcli.ExternalClientFactory
 	}
+	cf = cf.WithMiddleware(externalServiceMiddleware(
+		svc,
+		(*circuitBreakerConfig)(c.CircuitBreaker),
+		(*adaptiveConcurrencyConfig)(c.AdaptiveConcurrency),
+	)...)
 
 	opts := []httpcli.Opt{
 		// Use a 30s timeout to avoid running into EOF errors, because GitHub
@@ -1186,22 +1192,35 @@ func (s *GitHubSource) listRepositoryQuery(ctx context.Context, query string, re
 
 // listAllRepositories returns the repositories from the given `orgs`, `repos`,
 // `repositoryQuery`, and GitHubAppDetails config options, excluding the ones specified by `exclude`.
+//
+// Up to ConfRepoExternalServiceSyncWorkers of the configured orgs and queries
+// are listed concurrently.
 func (s *GitHubSource) listAllRepositories(ctx context.Context, results chan *githubResult) {
-	s.listRepos(ctx, s.config.Repos, results)
+	listers := []func(){
+		func() { s.listRepos(ctx, s.config.Repos, results) },
+	}
 
 	// Admins normally add to end of lists, so end of list most likely has new
 	// repos => stream them first.
 	for i := len(s.config.RepositoryQuery) - 1; i >= 0; i-- {
-		s.listRepositoryQuery(ctx, s.config.RepositoryQuery[i], results)
+		query := s.config.RepositoryQuery[i]
+		listers = append(listers, func() { s.listRepositoryQuery(ctx, query, results) })
 	}
 
 	for i := len(s.config.Orgs) - 1; i >= 0; i-- {
-		s.listOrg(ctx, s.config.Orgs[i], results)
+		org := s.config.Orgs[i]
+		listers = append(listers, func() { s.listOrg(ctx, org, results) })
 	}
 
 	if s.config.GitHubAppDetails != nil && s.config.GitHubAppDetails.CloneAllRepositories {
-		s.listAppInstallation(ctx, results)
+		listers = append(listers, func() { s.listAppInstallation(ctx, results) })
+	}
+
+	p := pool.New().WithMaxGoroutines(ConfRepoExternalServiceSyncWorkers())
+	for _, list := range listers {
+		p.Go(list)
 	}
+	p.Wait()
 }
 
 func (s *GitHubSource) getRepository(ctx context.Context, nameWithOwner string) (*github.Repository, error) {
@@ -79,6 +79,11 @@ func newGitLabSource(logger log.Logger, svc *types.ExternalService, c *schema.Gi
 	if cf == nil {
 		cf = httpcli.ExternalClientFactory
 	}
+	cf = cf.WithMiddleware(externalServiceMiddleware(
+		svc,
+		(*circuitBreakerConfig)(c.CircuitBreaker),
+		(*adaptiveConcurrencyConfig)(c.AdaptiveConcurrency),
+	)...)
 
 	var opts []httpcli.Opt
 	if c.Certificate != "" {
@@ -0,0 +1,53 @@
+package repos
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// circuitBreakerConfig has the shape of the circuitBreaker setting of code host
+// connections, e.g. schema.GitHubCircuitBreaker, which convert to it.
+type circuitBreakerConfig struct {
+	Enabled             bool
+	FailureThreshold    int
+	OpenDurationSeconds int
+}
+
+// adaptiveConcurrencyConfig has the shape of the adaptiveConcurrency setting of
+// code host connections, e.g. schema.GitHubAdaptiveConcurrency, which convert to it.
+type adaptiveConcurrencyConfig struct {
+	Enabled            bool
+	LatencyThresholdMs int
+	MaxLimit           int
+	MinLimit           int
+}
+
+// externalServiceMiddleware returns the middleware configured for the API client
+// of the given external service. The state of the middleware is shared by all
+// clients of the external service.
+func externalServiceMiddleware(svc *types.ExternalService, cb *circuitBreakerConfig, ac *adaptiveConcurrencyConfig) []httpcli.Middleware {
+	if svc == nil {
+		return nil
+	}
+
+	var mws []httpcli.Middleware
+	if ac != nil && ac.Enabled {
+		mws = append(mws, httpcli.NewAdaptiveConcurrencyMiddleware(svc.URN(), httpcli.AdaptiveConcurrencyOptions{
+			MinLimit:         ac.MinLimit,
+			MaxLimit:         ac.MaxLimit,
+			LatencyThreshold: time.Duration(ac.LatencyThresholdMs) * time.Millisecond,
+		}))
+	}
+	// The circuit breaker wraps the concurrency limit, so that requests fail fast
+	// rather than wait for a slot while the circuit is open.
+	if cb != nil && cb.Enabled {
+		mws = append(mws, httpcli.NewCircuitBreakerMiddleware(svc.URN(), httpcli.CircuitBreakerOptions{
+			FailureThreshold: cb.FailureThreshold,
+			OpenDuration:     time.Duration(cb.OpenDurationSeconds) * time.Second,
+		}))
+	}
+
+	return mws
+}
@@ -20,6 +20,9 @@ import (
 // package github.com/sourcegraph/sourcegraph/internal/repos) used for unit
 // testing.
 type MockStore struct {
+	// AddSyncCheckpointsFunc is an instance of a mock function object
+	// controlling the behavior of the method AddSyncCheckpoints.
+	AddSyncCheckpointsFunc *StoreAddSyncCheckpointsFunc
 	// CreateExternalServiceRepoFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// CreateExternalServiceRepo.
@@ -32,6 +35,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// DeleteExternalServiceReposNotIn.
 	DeleteExternalServiceReposNotInFunc *StoreDeleteExternalServiceReposNotInFunc
+	// DeleteSyncCheckpointsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteSyncCheckpoints.
+	DeleteSyncCheckpointsFunc *StoreDeleteSyncCheckpointsFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *StoreDoneFunc
@@ -53,12 +59,18 @@ type MockStore struct {
 	// ListSyncJobsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListSyncJobs.
 	ListSyncJobsFunc *StoreListSyncJobsFunc
+	// RepoKVPStoreFunc is an instance of a mock function object controlling
+	// the behavior of the method RepoKVPStore.
+	RepoKVPStoreFunc *StoreRepoKVPStoreFunc
 	// RepoStoreFunc is an instance of a mock function object controlling
 	// the behavior of the method RepoStore.
 	RepoStoreFunc *StoreRepoStoreFunc
 	// SetMetricsFunc is an instance of a mock function object controlling
 	// the behavior of the method SetMetrics.
 	SetMetricsFunc *StoreSetMetricsFunc
+	// SyncCheckpointsFunc is an instance of a mock function object
+	// controlling the behavior of the method SyncCheckpoints.
+	SyncCheckpointsFunc *StoreSyncCheckpointsFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *StoreTransactFunc
@@ -78,6 +90,11 @@ type MockStore struct {
 // return zero values for all results, unless overwritten.
 func NewMockStore() *MockStore {
 	return &MockStore{
+		AddSyncCheckpointsFunc: &StoreAddSyncCheckpointsFunc{
+			defaultHook: func(context.Context, int64, int, []*types.Repo) (r0 error) {
+				return
+			},
+		},
 		CreateExternalServiceRepoFunc: &StoreCreateExternalServiceRepoFunc{
 			defaultHook: func(context.Context, *types.ExternalService, *types.Repo) (r0 error) {
 				return
@@ -93,6 +110,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		DeleteSyncCheckpointsFunc: &StoreDeleteSyncCheckpointsFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
 		DoneFunc: &StoreDoneFunc{
 			defaultHook: func(error) (r0 error) {
 				return
@@ -128,6 +150,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		RepoKVPStoreFunc: &StoreRepoKVPStoreFunc{
+			defaultHook: func() (r0 database.RepoKVPStore) {
+				return
+			},
+		},
 		RepoStoreFunc: &StoreRepoStoreFunc{
 			defaultHook: func() (r0 database.RepoStore) {
 				return
@@ -138,6 +165,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		SyncCheckpointsFunc: &StoreSyncCheckpointsFunc{
+			defaultHook: func(context.Context, int64, int) (r0 map[string]api.RepoID, r1 error) {
+				return
+			},
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: func(context.Context) (r0 Store, r1 error) {
 				return
@@ -165,6 +197,11 @@ func NewMockStore() *MockStore {
 // panic on invocation, unless overwritten.
 func NewStrictMockStore() *MockStore {
 	return &MockStore{
+		AddSyncCheckpointsFunc: &StoreAddSyncCheckpointsFunc{
+			defaultHook: func(context.Context, int64, int, []*types.Repo) error {
+				panic("unexpected invocation of MockStore.AddSyncCheckpoints")
+			},
+		},
 		CreateExternalServiceRepoFunc: &StoreCreateExternalServiceRepoFunc{
 			defaultHook: func(context.Context, *types.ExternalService, *types.Repo) error {
 				panic("unexpected invocation of MockStore.CreateExternalServiceRepo")
@@ -180,6 +217,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.DeleteExternalServiceReposNotIn")
 			},
 		},
+		DeleteSyncCheckpointsFunc: &StoreDeleteSyncCheckpointsFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockStore.DeleteSyncCheckpoints")
+			},
+		},
 		DoneFunc: &StoreDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockStore.Done")
@@ -215,6 +257,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.ListSyncJobs")
 			},
 		},
+		RepoKVPStoreFunc: &StoreRepoKVPStoreFunc{
+			defaultHook: func() database.RepoKVPStore {
+				panic("unexpected invocation of MockStore.RepoKVPStore")
+			},
+		},
 		RepoStoreFunc: &StoreRepoStoreFunc{
 			defaultHook: func() database.RepoStore {
 				panic("unexpected invocation of MockStore.RepoStore")
@@ -225,6 +272,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.SetMetrics")
 			},
 		},
+		SyncCheckpointsFunc: &StoreSyncCheckpointsFunc{
+			defaultHook: func(context.Context, int64, int) (map[string]api.RepoID, error) {
+				panic("unexpected invocation of MockStore.SyncCheckpoints")
+			},
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: func(context.Context) (Store, error) {
 				panic("unexpected invocation of MockStore.Transact")
@@ -252,6 +304,9 @@ func NewStrictMockStore() *MockStore {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockStoreFrom(i Store) *MockStore {
 	return &MockStore{
+		AddSyncCheckpointsFunc: &StoreAddSyncCheckpointsFunc{
+			defaultHook: i.AddSyncCheckpoints,
+		},
 		CreateExternalServiceRepoFunc: &StoreCreateExternalServiceRepoFunc{
 			defaultHook: i.CreateExternalServiceRepo,
 		},
@@ -261,6 +316,9 @@ func NewMockStoreFrom(i Store) *MockStore {
 		DeleteExternalServiceReposNotInFunc: &StoreDeleteExternalServiceReposNotInFunc{
 			defaultHook: i.DeleteExternalServiceReposNotIn,
 		},
+		DeleteSyncCheckpointsFunc: &StoreDeleteSyncCheckpointsFunc{
+			defaultHook: i.DeleteSyncCheckpoints,
+		},
 		DoneFunc: &StoreDoneFunc{
 			defaultHook: i.Done,
 		},
@@ -282,12 +340,18 @@ func NewMockStoreFrom(i Store) *MockStore {
 		ListSyncJobsFunc: &StoreListSyncJobsFunc{
 			defaultHook: i.ListSyncJobs,
 		},
+		RepoKVPStoreFunc: &StoreRepoKVPStoreFunc{
+			defaultHook: i.RepoKVPStore,
+		},
 		RepoStoreFunc: &StoreRepoStoreFunc{
 			defaultHook: i.RepoStore,
 		},
 		SetMetricsFunc: &StoreSetMetricsFunc{
 			defaultHook: i.SetMetrics,
 		},
+		SyncCheckpointsFunc: &StoreSyncCheckpointsFunc{
+			defaultHook: i.SyncCheckpoints,
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: i.Transact,
 		},
@@ -303,6 +367,117 @@ func NewMockStoreFrom(i Store) *MockStore {
 	}
 }
 
+// StoreAddSyncCheckpointsFunc describes the behavior when the
+// AddSyncCheckpoints method of the parent MockStore instance is invoked.
+type StoreAddSyncCheckpointsFunc struct {
+	defaultHook func(context.Context, int64, int, []*types.Repo) error
+	hooks       []func(context.Context, int64, int, []*types.Repo) error
+	history     []StoreAddSyncCheckpointsFuncCall
+	mutex       sync.Mutex
+}
+
+// AddSyncCheckpoints delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) AddSyncCheckpoints(v0 context.Context, v1 int64, v2 int, v3 []*types.Repo) error {
+	r0 := m.AddSyncCheckpointsFunc.nextHook()(v0, v1, v2, v3)
+	m.AddSyncCheckpointsFunc.appendCall(StoreAddSyncCheckpointsFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddSyncCheckpoints
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreAddSyncCheckpointsFunc) SetDefaultHook(hook func(context.Context, int64, int, []*types.Repo) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddSyncCheckpoints method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreAddSyncCheckpointsFunc) PushHook(hook func(context.Context, int64, int, []*types.Repo) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreAddSyncCheckpointsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int, []*types.Repo) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreAddSyncCheckpointsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int, []*types.Repo) error {
+		return r0
+	})
+}
+
+func (f *StoreAddSyncCheckpointsFunc) nextHook() func(context.Context, int64, int, []*types.Repo) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreAddSyncCheckpointsFunc) appendCall(r0 StoreAddSyncCheckpointsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreAddSyncCheckpointsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreAddSyncCheckpointsFunc) History() []StoreAddSyncCheckpointsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreAddSyncCheckpointsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreAddSyncCheckpointsFuncCall is an object that describes an invocation
+// of method AddSyncCheckpoints on an instance of MockStore.
+type StoreAddSyncCheckpointsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []*types.Repo
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreAddSyncCheckpointsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreAddSyncCheckpointsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreCreateExternalServiceRepoFunc describes the behavior when the
 // CreateExternalServiceRepo method of the parent MockStore instance is
 // invoked.
@@ -638,6 +813,111 @@ func (c StoreDeleteExternalServiceReposNotInFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreDeleteSyncCheckpointsFunc describes the behavior when the
+// DeleteSyncCheckpoints method of the parent MockStore instance is invoked.
+type StoreDeleteSyncCheckpointsFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []StoreDeleteSyncCheckpointsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteSyncCheckpoints delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) DeleteSyncCheckpoints(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteSyncCheckpointsFunc.nextHook()(v0, v1)
+	m.DeleteSyncCheckpointsFunc.appendCall(StoreDeleteSyncCheckpointsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteSyncCheckpoints method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreDeleteSyncCheckpointsFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteSyncCheckpoints method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreDeleteSyncCheckpointsFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDeleteSyncCheckpointsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDeleteSyncCheckpointsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *StoreDeleteSyncCheckpointsFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDeleteSyncCheckpointsFunc) appendCall(r0 StoreDeleteSyncCheckpointsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDeleteSyncCheckpointsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreDeleteSyncCheckpointsFunc) History() []StoreDeleteSyncCheckpointsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDeleteSyncCheckpointsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDeleteSyncCheckpointsFuncCall is an object that describes an
+// invocation of method DeleteSyncCheckpoints on an instance of MockStore.
+type StoreDeleteSyncCheckpointsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDeleteSyncCheckpointsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDeleteSyncCheckpointsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreDoneFunc describes the behavior when the Done method of the parent
 // MockStore instance is invoked.
 type StoreDoneFunc struct {
@@ -1349,6 +1629,104 @@ func (c StoreListSyncJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreRepoKVPStoreFunc describes the behavior when the RepoKVPStore method
+// of the parent MockStore instance is invoked.
+type StoreRepoKVPStoreFunc struct {
+	defaultHook func() database.RepoKVPStore
+	hooks       []func() database.RepoKVPStore
+	history     []StoreRepoKVPStoreFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoKVPStore delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) RepoKVPStore() database.RepoKVPStore {
+	r0 := m.RepoKVPStoreFunc.nextHook()()
+	m.RepoKVPStoreFunc.appendCall(StoreRepoKVPStoreFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RepoKVPStore method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreRepoKVPStoreFunc) SetDefaultHook(hook func() database.RepoKVPStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoKVPStore method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreRepoKVPStoreFunc) PushHook(hook func() database.RepoKVPStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreRepoKVPStoreFunc) SetDefaultReturn(r0 database.RepoKVPStore) {
+	f.SetDefaultHook(func() database.RepoKVPStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreRepoKVPStoreFunc) PushReturn(r0 database.RepoKVPStore) {
+	f.PushHook(func() database.RepoKVPStore {
+		return r0
+	})
+}
+
+func (f *StoreRepoKVPStoreFunc) nextHook() func() database.RepoKVPStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreRepoKVPStoreFunc) appendCall(r0 StoreRepoKVPStoreFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreRepoKVPStoreFuncCall objects
+// describing the invocations of this function.
+func (f *StoreRepoKVPStoreFunc) History() []StoreRepoKVPStoreFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreRepoKVPStoreFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreRepoKVPStoreFuncCall is an object that describes an invocation of
+// method RepoKVPStore on an instance of MockStore.
+type StoreRepoKVPStoreFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RepoKVPStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreRepoKVPStoreFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreRepoKVPStoreFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreRepoStoreFunc describes the behavior when the RepoStore method of
 // the parent MockStore instance is invoked.
 type StoreRepoStoreFunc struct {
@@ -1545,6 +1923,117 @@ func (c StoreSetMetricsFuncCall) Results() []interface{} {
 	return []interface{}{}
 }
 
+// StoreSyncCheckpointsFunc describes the behavior when the SyncCheckpoints
+// method of the parent MockStore instance is invoked.
+type StoreSyncCheckpointsFunc struct {
+	defaultHook func(context.Context, int64, int) (map[string]api.RepoID, error)
+	hooks       []func(context.Context, int64, int) (map[string]api.RepoID, error)
+	history     []StoreSyncCheckpointsFuncCall
+	mutex       sync.Mutex
+}
+
+// SyncCheckpoints delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) SyncCheckpoints(v0 context.Context, v1 int64, v2 int) (map[string]api.RepoID, error) {
+	r0, r1 := m.SyncCheckpointsFunc.nextHook()(v0, v1, v2)
+	m.SyncCheckpointsFunc.appendCall(StoreSyncCheckpointsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SyncCheckpoints
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreSyncCheckpointsFunc) SetDefaultHook(hook func(context.Context, int64, int) (map[string]api.RepoID, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SyncCheckpoints method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreSyncCheckpointsFunc) PushHook(hook func(context.Context, int64, int) (map[string]api.RepoID, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreSyncCheckpointsFunc) SetDefaultReturn(r0 map[string]api.RepoID, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int) (map[string]api.RepoID, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreSyncCheckpointsFunc) PushReturn(r0 map[string]api.RepoID, r1 error) {
+	f.PushHook(func(context.Context, int64, int) (map[string]api.RepoID, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreSyncCheckpointsFunc) nextHook() func(context.Context, int64, int) (map[string]api.RepoID, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreSyncCheckpointsFunc) appendCall(r0 StoreSyncCheckpointsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreSyncCheckpointsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreSyncCheckpointsFunc) History() []StoreSyncCheckpointsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreSyncCheckpointsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreSyncCheckpointsFuncCall is an object that describes an invocation of
+// method SyncCheckpoints on an instance of MockStore.
+type StoreSyncCheckpointsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]api.RepoID
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreSyncCheckpointsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreSyncCheckpointsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreTransactFunc describes the behavior when the Transact method of the
 // parent MockStore instance is invoked.
 type StoreTransactFunc struct {
@@ -30,14 +30,7 @@ func NewRepositoryPurgeWorker(ctx context.Context, logger log.Logger, db databas
 	return goroutine.NewPeriodicGoroutine(
 		actor.WithInternalActor(ctx),
 		goroutine.HandlerFunc(func(ctx context.Context) error {
-			purgeConfig := conf.SiteConfig().RepoPurgeWorker
-			if purgeConfig == nil {
-				purgeConfig = &schema.RepoPurgeWorker{
-					// Defaults - align with documentation
-					IntervalMinutes:   15,
-					DeletedTTLMinutes: 60,
-				}
-			}
+			purgeConfig := purgeWorkerConfig(conf)
 			if purgeConfig.IntervalMinutes <= 0 {
 				logger.Debug("purge worker disabled via site config", log.Int("repoPurgeWorker.interval", purgeConfig.IntervalMinutes))
 				return nil
@@ -66,6 +59,33 @@ func NewRepositoryPurgeWorker(ctx context.Context, logger log.Logger, db databas
 	)
 }
 
+// purgeWorkerConfig returns the configuration of the repository purge worker.
+func purgeWorkerConfig(conf conftypes.SiteConfigQuerier) *schema.RepoPurgeWorker {
+	if c := conf.SiteConfig().RepoPurgeWorker; c != nil {
+		return c
+	}
+	return &schema.RepoPurgeWorker{
+		// Defaults - align with documentation
+		IntervalMinutes:   15,
+		DeletedTTLMinutes: 60,
+	}
+}
+
+// RestorableDeletedAfter returns the time after which a repository must have
+// been deleted for it to be restorable. Repositories deleted before are
+// purged from gitserver by the repository purge worker once their TTL
+// expired, so the TTL is the retention window of deleted repositories.
+//
+// The zero time is returned if the purge worker is disabled, in which case
+// deleted repositories are retained forever.
+func RestorableDeletedAfter(conf conftypes.SiteConfigQuerier, now time.Time) time.Time {
+	purgeConfig := purgeWorkerConfig(conf)
+	if purgeConfig.IntervalMinutes <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-time.Duration(purgeConfig.DeletedTTLMinutes) * time.Minute)
+}
+
 // PurgeOldestRepos will start a go routine to purge the oldest repos limited by
 // limit. The repos are ordered by when they were deleted. limit must be greater
 // than zero.
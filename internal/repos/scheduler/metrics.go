@@ -22,6 +22,10 @@ var (
 		Name: "src_repoupdater_sched_manual_fetch",
 		Help: "Incremented each time the scheduler updates a repository due to user traffic.",
 	})
+	schedForcedFetch = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_sched_forced_fetch",
+		Help: "Incremented each time a site admin forces the update of a repository.",
+	})
 	schedKnownRepos = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "src_repoupdater_sched_known_repos",
 		Help: "The number of repositories that are managed by the scheduler.",
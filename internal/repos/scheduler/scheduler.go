@@ -361,6 +361,19 @@ func (s *UpdateScheduler) UpdateOnce(id api.RepoID, name api.RepoName) {
 	s.updateQueue.enqueue(repo, priorityHigh)
 }
 
+// ForceUpdate causes a single update of the given repository ahead of all
+// other updates that weren't forced, and returns the ID of the job tracking
+// the update. The job ID is reported by ScheduleInfo until the update is done.
+// It neither adds nor removes the repo from the schedule.
+func (s *UpdateScheduler) ForceUpdate(id api.RepoID, name api.RepoName) (jobID uint64) {
+	repo := configuredRepo{
+		ID:   id,
+		Name: name,
+	}
+	schedForcedFetch.Inc()
+	return s.updateQueue.enqueueForced(repo)
+}
+
 // DebugDump returns the state of the update scheduler for debugging.
 func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 	data := struct {
@@ -441,6 +454,7 @@ func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 			Total:    len(s.updateQueue.index),
 			Updating: update.Updating,
 			Priority: int(update.Priority),
+			JobID:    update.JobID,
 		}
 	}
 	s.updateQueue.mu.Unlock()
@@ -453,6 +467,7 @@ type priority int
 const (
 	priorityLow priority = iota
 	priorityHigh
+	priorityForced
 )
 
 // repoUpdate is a repository that has been queued for an update.
@@ -460,6 +475,7 @@ type repoUpdate struct {
 	Repo     configuredRepo
 	Priority priority
 	Seq      uint64 // the sequence number of the update
+	JobID    uint64 // the ID of the job tracking a forced update, if any
 	Updating bool   // whether the repo has been acquired for update
 	Index    int    `json:"-"` // the index in the heap
 }
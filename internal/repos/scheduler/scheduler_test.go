@@ -305,6 +305,42 @@ func TestUpdateQueue_enqueue(t *testing.T) {
 	}
 }
 
+func TestUpdateQueue_enqueueForced(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+	c := configuredRepo{ID: 3, Name: "c"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler(logtest.Scoped(t), dbmocks.NewMockDB(), gitserver.NewMockClient())
+
+	s.updateQueue.enqueue(a, priorityHigh)
+	s.updateQueue.enqueue(b, priorityLow)
+
+	// Forcing an update of a queued repo bumps it ahead of all other updates.
+	if jobID := s.updateQueue.enqueueForced(b); jobID != 1 {
+		t.Fatalf("unexpected job ID: have %d, want 1", jobID)
+	}
+	// Forcing it again returns the same job.
+	if jobID := s.updateQueue.enqueueForced(b); jobID != 1 {
+		t.Fatalf("unexpected job ID: have %d, want 1", jobID)
+	}
+	if jobID := s.updateQueue.enqueueForced(c); jobID != 2 {
+		t.Fatalf("unexpected job ID: have %d, want 2", jobID)
+	}
+
+	if info := s.ScheduleInfo(c.ID); info.Queue == nil || info.Queue.JobID != 2 {
+		t.Fatalf("unexpected queue state: %s", spew.Sdump(info.Queue))
+	}
+
+	verifyQueue(t, s, []*repoUpdate{
+		{Repo: b, Priority: priorityForced, Seq: 3, JobID: 1},
+		{Repo: c, Priority: priorityForced, Seq: 4, JobID: 2},
+		{Repo: a, Priority: priorityHigh, Seq: 1},
+	})
+}
+
 func TestUpdateQueue_remove(t *testing.T) {
 	a := configuredRepo{ID: 1, Name: "a"}
 	b := configuredRepo{ID: 2, Name: "b"}
@@ -18,6 +18,9 @@ type updateQueue struct {
 
 	seq uint64
 
+	// jobSeq is the ID of the last job tracking a forced update.
+	jobSeq uint64
+
 	// The queue performs a non-blocking send on this channel
 	// when a new value is enqueued so that the update loop
 	// can wake up if it is idle.
@@ -31,6 +34,7 @@ func (q *updateQueue) reset() {
 	q.heap = q.heap[:0]
 	q.index = map[api.RepoID]*repoUpdate{}
 	q.seq = 0
+	q.jobSeq = 0
 	q.notifyEnqueue = make(chan struct{}, notifyChanBuffer)
 
 	schedUpdateQueueLength.Set(0)
@@ -51,6 +55,34 @@ func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
+	return q.enqueueLocked(repo, p)
+}
+
+// enqueueForced adds the repo to the queue ahead of all updates that weren't
+// forced and returns the ID of the job tracking the update.
+//
+// If the repo is already in the queue with a job ID, or if it is already
+// updating, the ID of the existing update is returned.
+func (q *updateQueue) enqueueForced(repo configuredRepo) (jobID uint64) {
+	if repo.ID == 0 {
+		panic("repo.id is zero")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.enqueueLocked(repo, priorityForced)
+
+	update := q.index[repo.ID]
+	if update.JobID == 0 {
+		q.jobSeq++
+		update.JobID = q.jobSeq
+	}
+	return update.JobID
+}
+
+// enqueueLocked implements enqueue. The caller must hold the lock on q.mu.
+func (q *updateQueue) enqueueLocked(repo configuredRepo, p priority) (updated bool) {
 	update := q.index[repo.ID]
 	if update == nil {
 		heap.Push(q, &repoUpdate{
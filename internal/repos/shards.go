@@ -0,0 +1,113 @@
+package repos
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// shardQueue distributes the repositories sourced during an external service
+// sync over a fixed number of shards, one per sync worker.
+//
+// Repositories are assigned to shards by their external ID, so that a
+// repository that is sourced more than once (for example because it matches
+// several repository queries) is usually synced by the same worker. A worker
+// that runs out of work steals from the shard with the most pending
+// repositories, so that a few slow shards don't hold up the whole sync.
+type shardQueue[T any] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	shards  [][]T
+	pending int
+	// maxPending bounds the number of queued items, so that listing a large
+	// code host doesn't buffer all of its repositories in memory when the
+	// workers can't keep up.
+	maxPending int
+	closed     bool
+}
+
+func newShardQueue[T any](shards, maxPending int) *shardQueue[T] {
+	q := &shardQueue[T]{
+		shards:     make([][]T, shards),
+		maxPending: maxPending,
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// shardFor returns the shard for the given key.
+func (q *shardQueue[T]) shardFor(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(q.shards)))
+}
+
+// push adds item to the shard of the given key. It blocks while the queue is
+// full. Items pushed after close are dropped.
+func (q *shardQueue[T]) push(key string, item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.pending >= q.maxPending && !q.closed {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return
+	}
+
+	shard := q.shardFor(key)
+	q.shards[shard] = append(q.shards[shard], item)
+	q.pending++
+	q.cond.Broadcast()
+}
+
+// close marks the queue as complete. Workers drain the items that are still
+// queued before pop reports that the queue is done.
+func (q *shardQueue[T]) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// pop returns the next item for the worker of the given shard. Workers take
+// the oldest item of their own shard first and otherwise steal the newest item
+// of the fullest shard. pop blocks until an item is available, and returns
+// false once the queue is closed and drained.
+func (q *shardQueue[T]) pop(shard int) (item T, stolen, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for {
+		if own := q.shards[shard]; len(own) > 0 {
+			item, q.shards[shard] = own[0], own[1:]
+			q.taken()
+			return item, false, true
+		}
+
+		victim := -1
+		for i, s := range q.shards {
+			if len(s) > 0 && (victim == -1 || len(s) > len(q.shards[victim])) {
+				victim = i
+			}
+		}
+		if victim != -1 {
+			s := q.shards[victim]
+			item, q.shards[victim] = s[len(s)-1], s[:len(s)-1]
+			q.taken()
+			return item, true, true
+		}
+
+		if q.closed {
+			return item, false, false
+		}
+		q.cond.Wait()
+	}
+}
+
+// taken must be called with q.mu held after an item was removed.
+func (q *shardQueue[T]) taken() {
+	q.pending--
+	q.cond.Broadcast()
+}
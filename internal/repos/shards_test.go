@@ -0,0 +1,86 @@
+package repos
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardQueue(t *testing.T) {
+	t.Run("own shard first, then steal", func(t *testing.T) {
+		q := newShardQueue[string](2, 100)
+
+		// Put three items into shard 0 and one into shard 1.
+		want := [2]int{3, 1}
+		var keys [2][]string
+		for i := 0; len(keys[0]) < want[0] || len(keys[1]) < want[1]; i++ {
+			key := fmt.Sprintf("repo-%d", i)
+			shard := q.shardFor(key)
+			if len(keys[shard]) < want[shard] {
+				keys[shard] = append(keys[shard], key)
+				q.push(key, key)
+			}
+		}
+		q.close()
+
+		// Worker 1 drains its own shard in order.
+		item, stolen, ok := q.pop(1)
+		require.True(t, ok)
+		assert.False(t, stolen)
+		assert.Equal(t, keys[1][0], item)
+
+		// Afterwards, it steals the newest item of shard 0.
+		item, stolen, ok = q.pop(1)
+		require.True(t, ok)
+		assert.True(t, stolen)
+		assert.Equal(t, keys[0][len(keys[0])-1], item)
+
+		// Worker 0 takes its remaining items oldest first.
+		item, stolen, ok = q.pop(0)
+		require.True(t, ok)
+		assert.False(t, stolen)
+		assert.Equal(t, keys[0][0], item)
+	})
+
+	t.Run("concurrent workers see every item once", func(t *testing.T) {
+		const workers, items = 4, 1000
+		q := newShardQueue[int](workers, 10)
+
+		var (
+			mu   sync.Mutex
+			seen []int
+			wg   sync.WaitGroup
+		)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for {
+					item, _, ok := q.pop(w)
+					if !ok {
+						return
+					}
+					mu.Lock()
+					seen = append(seen, item)
+					mu.Unlock()
+				}
+			}(w)
+		}
+
+		for i := 0; i < items; i++ {
+			q.push(fmt.Sprint(i), i)
+		}
+		q.close()
+		wg.Wait()
+
+		sort.Ints(seen)
+		require.Len(t, seen, items)
+		for i := range seen {
+			assert.Equal(t, i, seen[i])
+		}
+	})
+}
@@ -31,6 +31,9 @@ type Store interface {
 	// ExternalServiceStore returns a database.ExternalServiceStore using the same
 	// database handle.
 	ExternalServiceStore() database.ExternalServiceStore
+	// RepoKVPStore returns a database.RepoKVPStore using the same database
+	// handle.
+	RepoKVPStore() database.RepoKVPStore
 
 	// SetMetrics updates metrics for the store in place.
 	SetMetrics(m StoreMetrics)
@@ -86,6 +89,18 @@ type Store interface {
 	EnqueueSyncJobs(ctx context.Context, isCloud bool) (err error)
 	// ListSyncJobs returns all sync jobs.
 	ListSyncJobs(ctx context.Context) ([]SyncJob, error)
+
+	// SyncCheckpoints returns the repos that the given sync job of the given
+	// external service has already synced, keyed by their external ID. The
+	// checkpoints of any other sync job of the external service are deleted,
+	// since only one sync job of an external service runs at a time.
+	SyncCheckpoints(ctx context.Context, extSvcID int64, jobID int) (map[string]api.RepoID, error)
+	// AddSyncCheckpoints records that the given sync job of the given external
+	// service has synced the given repos.
+	AddSyncCheckpoints(ctx context.Context, extSvcID int64, jobID int, repos []*types.Repo) error
+	// DeleteSyncCheckpoints deletes all checkpoints of the given external
+	// service.
+	DeleteSyncCheckpoints(ctx context.Context, extSvcID int64) error
 }
 
 // A Store exposes methods to read and write repos and external services.
@@ -122,6 +137,10 @@ func (s *store) ExternalServiceStore() database.ExternalServiceStore {
 	return database.ExternalServicesWith(s.Logger, s)
 }
 
+func (s *store) RepoKVPStore() database.RepoKVPStore {
+	return database.RepoKVPsWith(s)
+}
+
 func (s *store) SetMetrics(m StoreMetrics) { s.Metrics = m }
 
 func (s *store) With(other basestore.ShareableStore) Store {
@@ -666,6 +685,74 @@ func (s *store) ListSyncJobs(ctx context.Context) ([]SyncJob, error) {
 	return scanJobs(rows)
 }
 
+func (s *store) SyncCheckpoints(ctx context.Context, extSvcID int64, jobID int) (map[string]api.RepoID, error) {
+	if err := s.Exec(ctx, sqlf.Sprintf(
+		"DELETE FROM external_service_sync_checkpoints WHERE external_service_id = %s AND sync_job_id != %s",
+		extSvcID,
+		jobID,
+	)); err != nil {
+		return nil, errors.Wrap(err, "deleting stale checkpoints")
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(
+		"SELECT external_id, repo_id FROM external_service_sync_checkpoints WHERE external_service_id = %s",
+		extSvcID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checkpoints := make(map[string]api.RepoID)
+	for rows.Next() {
+		var (
+			externalID string
+			repoID     api.RepoID
+		)
+		if err := rows.Scan(&externalID, &repoID); err != nil {
+			return nil, err
+		}
+		checkpoints[externalID] = repoID
+	}
+	return checkpoints, rows.Err()
+}
+
+func (s *store) AddSyncCheckpoints(ctx context.Context, extSvcID int64, jobID int, repos []*types.Repo) error {
+	if len(repos) == 0 {
+		return nil
+	}
+
+	repoIDs := make(pq.Int64Array, 0, len(repos))
+	externalIDs := make(pq.StringArray, 0, len(repos))
+	seen := make(map[api.RepoID]struct{}, len(repos))
+	for _, r := range repos {
+		// A single statement can't insert the same row twice.
+		if _, ok := seen[r.ID]; ok {
+			continue
+		}
+		seen[r.ID] = struct{}{}
+		repoIDs = append(repoIDs, int64(r.ID))
+		externalIDs = append(externalIDs, r.ExternalRepo.ID)
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(addSyncCheckpointsQueryFmtstr, extSvcID, jobID, repoIDs, externalIDs))
+}
+
+const addSyncCheckpointsQueryFmtstr = `
+INSERT INTO external_service_sync_checkpoints (external_service_id, sync_job_id, repo_id, external_id)
+SELECT %s, %s, c.repo_id, c.external_id
+FROM unnest(%s::integer[], %s::text[]) AS c(repo_id, external_id)
+-- Repos that were deleted in the meantime can't be checkpointed.
+WHERE EXISTS (SELECT 1 FROM repo WHERE repo.id = c.repo_id)
+ON CONFLICT (external_service_id, repo_id) DO UPDATE SET
+	sync_job_id = EXCLUDED.sync_job_id,
+	external_id = EXCLUDED.external_id
+`
+
+func (s *store) DeleteSyncCheckpoints(ctx context.Context, extSvcID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf("DELETE FROM external_service_sync_checkpoints WHERE external_service_id = %s", extSvcID))
+}
+
 func scanJobs(rows *sql.Rows) ([]SyncJob, error) {
 	var jobs []SyncJob
 
@@ -359,6 +359,63 @@ func TestStoreEnqueuingSyncJobsWhileExtSvcBeingDeleted(t *testing.T) {
 	}
 }
 
+func TestStoreSyncCheckpoints(t *testing.T) {
+	t.Parallel()
+	store := getTestRepoStore(t)
+	ctx := context.Background()
+
+	svc := createExternalServices(t, store)[extsvc.KindGitHub]
+
+	var rs types.Repos
+	for i := 0; i < 2; i++ {
+		r := &types.Repo{
+			Name:         api.RepoName(fmt.Sprintf("github.com/foo/bar%d", i)),
+			ExternalRepo: api.ExternalRepoSpec{ID: fmt.Sprintf("external-%d", i), ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"},
+		}
+		if err := store.RepoStore().Create(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+		rs = append(rs, r)
+	}
+
+	// Checkpointing the same repo twice is fine.
+	if err := store.AddSyncCheckpoints(ctx, svc.ID, 1, append(rs, rs[0])); err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := store.SyncCheckpoints(ctx, svc.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]api.RepoID{"external-0": rs[0].ID, "external-1": rs[1].ID}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected checkpoints (-want +got):\n%s", diff)
+	}
+
+	// Checkpoints of another sync job are discarded.
+	have, err = store.SyncCheckpoints(ctx, svc.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != 0 {
+		t.Fatalf("expected no checkpoints, got %v", have)
+	}
+
+	if err := store.AddSyncCheckpoints(ctx, svc.ID, 2, rs[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteSyncCheckpoints(ctx, svc.ID); err != nil {
+		t.Fatal(err)
+	}
+	have, err = store.SyncCheckpoints(ctx, svc.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != 0 {
+		t.Fatalf("expected no checkpoints, got %v", have)
+	}
+}
+
 func mkRepos(n int, base ...*types.Repo) types.Repos {
 	if len(base) == 0 {
 		return nil
@@ -0,0 +1,110 @@
+package repos
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// syncCheckpointBatchSize is the number of synced repos that are buffered
+// before they are checkpointed. At most this many repos are synced again when
+// a sync job is resumed.
+const syncCheckpointBatchSize = 500
+
+// syncCheckpointer records which repos a sync job has synced, so that the job
+// can skip them when it is resumed after a restart instead of starting over.
+//
+// A nil *syncCheckpointer is valid and records nothing.
+type syncCheckpointer struct {
+	store    Store
+	extSvcID int64
+	jobID    int
+
+	mu      sync.Mutex
+	synced  map[string]api.RepoID
+	pending []*types.Repo
+}
+
+func newSyncCheckpointer(store Store, extSvcID int64, jobID int) *syncCheckpointer {
+	return &syncCheckpointer{store: store, extSvcID: extSvcID, jobID: jobID}
+}
+
+// load loads the checkpoints that the sync job recorded before it was
+// interrupted, and returns how many there are.
+func (c *syncCheckpointer) load(ctx context.Context) (int, error) {
+	if c == nil {
+		return 0, nil
+	}
+
+	synced, err := c.store.SyncCheckpoints(ctx, c.extSvcID, c.jobID)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.synced = synced
+	return len(synced), nil
+}
+
+// lookup returns the ID of the repo with the given external ID if it was
+// synced before the sync job was interrupted.
+func (c *syncCheckpointer) lookup(externalID string) (api.RepoID, bool) {
+	if c == nil {
+		return 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.synced[externalID]
+	return id, ok
+}
+
+// add records that the given repos were synced. Repos are checkpointed in
+// batches.
+func (c *syncCheckpointer) add(ctx context.Context, repos ...*types.Repo) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	c.pending = append(c.pending, repos...)
+	if len(c.pending) < syncCheckpointBatchSize {
+		c.mu.Unlock()
+		return nil
+	}
+	batch := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	return c.store.AddSyncCheckpoints(ctx, c.extSvcID, c.jobID, batch)
+}
+
+// flush checkpoints all buffered repos.
+func (c *syncCheckpointer) flush(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	batch := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	return c.store.AddSyncCheckpoints(ctx, c.extSvcID, c.jobID, batch)
+}
+
+// clear deletes all checkpoints once the sync job is done.
+func (c *syncCheckpointer) clear(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	c.pending = nil
+	c.mu.Unlock()
+
+	return c.store.DeleteSyncCheckpoints(ctx, c.extSvcID)
+}
@@ -0,0 +1,65 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSyncCheckpointer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil checkpointer", func(t *testing.T) {
+		var c *syncCheckpointer
+		n, err := c.load(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		_, ok := c.lookup("a")
+		assert.False(t, ok)
+		assert.NoError(t, c.add(ctx, &types.Repo{ID: 1}))
+		assert.NoError(t, c.flush(ctx))
+		assert.NoError(t, c.clear(ctx))
+	})
+
+	t.Run("load, batch and clear", func(t *testing.T) {
+		store := NewMockStore()
+		store.SyncCheckpointsFunc.SetDefaultReturn(map[string]api.RepoID{"a": 1}, nil)
+
+		c := newSyncCheckpointer(store, 42, 7)
+		n, err := c.load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		id, ok := c.lookup("a")
+		assert.True(t, ok)
+		assert.Equal(t, api.RepoID(1), id)
+		_, ok = c.lookup("b")
+		assert.False(t, ok)
+
+		// Repos are only checkpointed once a batch is full.
+		for i := 0; i < syncCheckpointBatchSize+1; i++ {
+			require.NoError(t, c.add(ctx, &types.Repo{ID: api.RepoID(i + 2), Name: api.RepoName(fmt.Sprintf("repo-%d", i))}))
+		}
+		history := store.AddSyncCheckpointsFunc.History()
+		require.Len(t, history, 1)
+		assert.Equal(t, int64(42), history[0].Arg1)
+		assert.Equal(t, 7, history[0].Arg2)
+		assert.Len(t, history[0].Arg3, syncCheckpointBatchSize)
+
+		// flush checkpoints the rest.
+		require.NoError(t, c.flush(ctx))
+		history = store.AddSyncCheckpointsFunc.History()
+		require.Len(t, history, 2)
+		assert.Len(t, history[1].Arg3, 1)
+
+		require.NoError(t, c.clear(ctx))
+		require.Len(t, store.DeleteSyncCheckpointsFunc.History(), 1)
+		assert.Equal(t, int64(42), store.DeleteSyncCheckpointsFunc.History()[0].Arg1)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sourcegraph/log"
@@ -46,6 +47,9 @@ type Syncer struct {
 
 	// Ensure that we only run one sync per repo at a time
 	syncGroup singleflight.Group
+
+	// nameConflictMu serializes the syncs that resolve naming conflicts.
+	nameConflictMu sync.Mutex
 }
 
 func NewSyncer(observationCtx *observation.Context, store Store, sourcer Sourcer) *Syncer {
@@ -141,7 +145,8 @@ func (s *syncHandler) Handle(ctx context.Context, _ log.Logger, sj *SyncJob) (er
 		return nil
 	}
 
-	return s.syncer.SyncExternalService(ctx, sj.ExternalServiceID, s.minSyncInterval(), progressRecorder)
+	checkpoints := newSyncCheckpointer(s.store, sj.ExternalServiceID, sj.ID)
+	return s.syncer.syncExternalService(ctx, sj.ExternalServiceID, checkpoints, s.minSyncInterval(), progressRecorder)
 }
 
 // TriggerExternalServiceSync will enqueue a sync job for the supplied external
@@ -432,11 +437,26 @@ type progressRecorderFunc func(ctx context.Context, progress SyncProgress, final
 // SyncExternalService syncs repos using the supplied external service in a streaming fashion, rather than batch.
 // This allows very large sync jobs (i.e. that source potentially millions of repos) to incrementally persist changes.
 // Deletes of repositories that were not sourced are done at the end.
+//
+// Sourced repos are synced by ConfRepoExternalServiceSyncWorkers workers in parallel.
 func (s *Syncer) SyncExternalService(
 	ctx context.Context,
 	externalServiceID int64,
 	minSyncInterval time.Duration,
 	progressRecorder progressRecorderFunc,
+) (err error) {
+	return s.syncExternalService(ctx, externalServiceID, nil, minSyncInterval, progressRecorder)
+}
+
+// syncExternalService implements SyncExternalService. If checkpoints is not
+// nil, the synced repos are checkpointed so that a sync job which is resumed
+// after a restart skips the repos it already synced.
+func (s *Syncer) syncExternalService(
+	ctx context.Context,
+	externalServiceID int64,
+	checkpoints *syncCheckpointer,
+	minSyncInterval time.Duration,
+	progressRecorder progressRecorderFunc,
 ) (err error) {
 	logger := s.ObsvCtx.Logger.With(log.Int64("externalServiceID", externalServiceID))
 	logger.Info("syncing external service")
@@ -500,14 +520,25 @@ func (s *Syncer) SyncExternalService(
 		logger.Warn("connection check failed. syncing repositories might still succeed.", log.Error(err))
 	}
 
+	if n, err := checkpoints.load(ctx); err != nil {
+		logger.Warn("loading sync checkpoints, syncing all repositories", log.Error(err))
+	} else if n > 0 {
+		logger.Info("resuming interrupted sync", log.Int("checkpointed", n))
+	}
+
 	results := make(chan SourceResult)
 	go func() {
 		src.ListRepos(ctx, results)
 		close(results)
 	}()
 
-	seen := make(map[api.RepoID]struct{})
-	var errs error
+	var (
+		// mu guards the sync state below, which is updated by all workers.
+		mu           sync.Mutex
+		seen         = make(map[api.RepoID]struct{})
+		errs         error
+		syncProgress SyncProgress
+	)
 	fatal := func(err error) bool {
 		// If the error is just a warning, then it is not fatal.
 		if errors.IsWarning(err) && !errcode.IsAccountSuspended(err) {
@@ -521,7 +552,6 @@ func (s *Syncer) SyncExternalService(
 
 	logger = s.ObsvCtx.Logger.With(log.Object("svc", log.String("name", svc.DisplayName), log.Int64("id", svc.ID)))
 
-	var syncProgress SyncProgress
 	// Record the final progress state
 	defer func() {
 		// Use a different context here so that we make sure to record progress
@@ -531,24 +561,87 @@ func (s *Syncer) SyncExternalService(
 		}
 	}()
 
-	// Insert or update repos as they are sourced. Keep track of what was seen so we
-	// can remove anything else at the end.
+	// syncSourced inserts or updates a sourced repo and keeps track of what was
+	// seen so we can remove anything else at the end.
+	syncSourced := func(sourced *types.Repo) {
+		if id, ok := checkpoints.lookup(sourced.ExternalRepo.ID); ok {
+			mu.Lock()
+			seen[id] = struct{}{}
+			syncProgress.Synced = int32(len(seen))
+			mu.Unlock()
+			return
+		}
+
+		diff, err := s.sync(ctx, svc, sourced)
+		if err != nil {
+			logger.Error("failed to sync, skipping", log.String("repo", string(sourced.Name)), log.Error(err))
+
+			mu.Lock()
+			syncProgress.Errors++
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+			return
+		}
+
+		mu.Lock()
+		syncProgress.Added += int32(diff.Added.Len())
+		syncProgress.Removed += int32(diff.Deleted.Len())
+		syncProgress.Modified += int32(diff.Modified.Repos().Len())
+		syncProgress.Unmodified += int32(diff.Unmodified.Len())
+
+		for _, r := range diff.Repos() {
+			seen[r.ID] = struct{}{}
+		}
+		syncProgress.Synced = int32(len(seen))
+
+		modified = modified || len(diff.Modified)+len(diff.Added) > 0
+		mu.Unlock()
+
+		if err := checkpoints.add(ctx, diff.Repos()...); err != nil {
+			logger.Warn("checkpointing sync progress", log.Error(err))
+		}
+	}
+
+	// Sourced repos are sharded over the workers, which steal work from each
+	// other once they run out of their own.
+	workers := ConfRepoExternalServiceSyncWorkers()
+	queue := newShardQueue[*types.Repo](workers, workers*100)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(shard int) {
+			defer wg.Done()
+			for {
+				sourced, _, ok := queue.pop(shard)
+				if !ok {
+					return
+				}
+				syncSourced(sourced)
+			}
+		}(i)
+	}
+
+	var fatalErr bool
 	for res := range results {
 		logger.Debug("received result", log.String("repo", fmt.Sprintf("%v", res)))
 
-		if err := progressRecorder(ctx, syncProgress, false); err != nil {
+		mu.Lock()
+		progress := syncProgress
+		mu.Unlock()
+		if err := progressRecorder(ctx, progress, false); err != nil {
 			logger.Warn("recording sync progress", log.Error(err))
 		}
 
 		if err := res.Err; err != nil {
+			mu.Lock()
 			syncProgress.Errors++
 			logger.Error("error from codehost", log.Int("seen", len(seen)), log.Error(err))
 
 			errs = errors.Append(errs, errors.Wrapf(err, "fetching from code host %s", svc.DisplayName))
+			mu.Unlock()
 			if fatal(err) {
-				// Delete all external service repos of this external service
 				logger.Error("stopping external service sync due to fatal error from codehost", log.Error(err))
-				seen = map[api.RepoID]struct{}{}
+				fatalErr = true
 				break
 			}
 
@@ -559,31 +652,24 @@ func (s *Syncer) SyncExternalService(
 
 		if envvar.SourcegraphDotComMode() && sourced.Private {
 			err := errors.Newf("%s is private, but dotcom does not support private repositories.", sourced.Name)
-			syncProgress.Errors++
 			logger.Error("failed to sync private repo", log.String("repo", string(sourced.Name)), log.Error(err))
-			errs = errors.Append(errs, err)
-			continue
-		}
-
-		var diff types.RepoSyncDiff
-		if diff, err = s.sync(ctx, svc, sourced); err != nil {
+			mu.Lock()
 			syncProgress.Errors++
-			logger.Error("failed to sync, skipping", log.String("repo", string(sourced.Name)), log.Error(err))
 			errs = errors.Append(errs, err)
+			mu.Unlock()
 			continue
 		}
 
-		syncProgress.Added += int32(diff.Added.Len())
-		syncProgress.Removed += int32(diff.Deleted.Len())
-		syncProgress.Modified += int32(diff.Modified.Repos().Len())
-		syncProgress.Unmodified += int32(diff.Unmodified.Len())
+		queue.push(sourced.ExternalRepo.ID, sourced)
+	}
 
-		for _, r := range diff.Repos() {
-			seen[r.ID] = struct{}{}
-		}
-		syncProgress.Synced = int32(len(seen))
+	// Wait for the workers to sync the remaining repos.
+	queue.close()
+	wg.Wait()
 
-		modified = modified || len(diff.Modified)+len(diff.Added) > 0
+	if fatalErr {
+		// Delete all external service repos of this external service
+		seen = map[api.RepoID]struct{}{}
 	}
 
 	// We don't delete any repos of site-level external services if there were any
@@ -654,11 +740,41 @@ func (s *Syncer) SyncExternalService(
 
 	modified = modified || deleted > 0
 
+	if ctx.Err() != nil {
+		// The sync was interrupted, so keep what we synced for when the job is
+		// resumed. The context is canceled, so we use a different one.
+		if err := checkpoints.flush(context.Background()); err != nil {
+			logger.Warn("checkpointing sync progress", log.Error(err))
+		}
+	} else if err := checkpoints.clear(ctx); err != nil {
+		logger.Warn("clearing sync checkpoints", log.Error(err))
+	}
+
 	return errs
 }
 
 // syncs a sourced repo of a given external service, returning a diff with a single repo.
-func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *types.Repo) (d types.RepoSyncDiff, err error) {
+// errNameConflict is returned by upsert when the sourced repo takes the name
+// of another stored repo and conflicts were not to be resolved.
+var errNameConflict = errors.New("naming conflict")
+
+func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *types.Repo) (types.RepoSyncDiff, error) {
+	d, err := s.upsert(ctx, svc, sourced, false)
+	if !errors.Is(err, errNameConflict) {
+		return d, err
+	}
+
+	// Resolving a naming conflict deletes and renames repos that concurrent
+	// workers may be syncing too, e.g. when two repos swap their names. We retry
+	// those syncs one at a time to avoid unique violations and deadlocks.
+	s.nameConflictMu.Lock()
+	defer s.nameConflictMu.Unlock()
+	return s.upsert(ctx, svc, sourced, true)
+}
+
+// upsert stores the sourced repo in a transaction. If resolveConflicts is false,
+// it returns errNameConflict instead of deleting another repo with the same name.
+func (s *Syncer) upsert(ctx context.Context, svc *types.ExternalService, sourced *types.Repo, resolveConflicts bool) (d types.RepoSyncDiff, err error) {
 	tx, err := s.Store.Transact(ctx)
 	if err != nil {
 		return types.RepoSyncDiff{}, errors.Wrap(err, "syncer: opening transaction")
@@ -672,7 +788,9 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		s.ObsvCtx.Logger.Debug("committing transaction")
 		err = tx.Done(err)
 		if err != nil {
-			s.ObsvCtx.Logger.Warn("failed to commit transaction", log.Error(err))
+			if !errors.Is(err, errNameConflict) {
+				s.ObsvCtx.Logger.Warn("failed to commit transaction", log.Error(err))
+			}
 			return
 		}
 
@@ -697,6 +815,9 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		return types.RepoSyncDiff{}, errors.Wrap(err, "syncer: getting repo from the database")
 	}
 
+	// The ID of the synced repo, set once it is stored.
+	var repoID api.RepoID
+
 	switch len(stored) {
 	case 2: // Existing repo with a naming conflict
 		// Scenario where this can happen:
@@ -708,6 +829,9 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		// Then the above query will return two results: one matching the name owner/repo1, and one matching the external_service_id 2
 		// The original owner/repo1 should be deleted, and then owner/repo2 with the matching external_service_id should be updated
 		s.ObsvCtx.Logger.Debug("naming conflict")
+		if !resolveConflicts {
+			return types.RepoSyncDiff{}, errNameConflict
+		}
 
 		// Pick this sourced repo to own the name by deleting the other repo. If it still exists, it'll have a different
 		// name when we source it from the same code host, and it will be re-created.
@@ -732,6 +856,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		fallthrough
 	case 1: // Existing repo, update.
 		wasDeleted := !stored[0].DeletedAt.IsZero()
+		repoID = stored[0].ID
 		s.ObsvCtx.Logger.Debug("existing repo")
 		if err := UpdateRepoLicenseHook(ctx, tx, stored[0], sourced); err != nil {
 			return types.RepoSyncDiff{}, LicenseError{errors.Wrapf(err, "syncer: failed to update repo %s", sourced.Name)}
@@ -765,12 +890,19 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return types.RepoSyncDiff{}, errors.Wrapf(err, "syncer: failed to create external service repo: %s", sourced.Name)
 		}
 
+		repoID = sourced.ID
 		d.Added = append(d.Added, sourced)
 		s.ObsvCtx.Logger.Debug("appended to added repos")
 	default: // Impossible since we have two separate unique constraints on name and external repo spec
 		panic("unreachable")
 	}
 
+	if topics, ok := codeHostTopics(sourced); ok {
+		if err = tx.RepoKVPStore().SyncCodeHostTags(ctx, repoID, topics); err != nil {
+			return types.RepoSyncDiff{}, errors.Wrapf(err, "syncer: failed to sync topics of repo %s", sourced.Name)
+		}
+	}
+
 	s.ObsvCtx.Logger.Debug("completed")
 	return d, nil
 }
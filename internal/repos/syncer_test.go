@@ -1296,6 +1296,88 @@ func TestOrphanedRepo(t *testing.T) {
 	assertDeletedRepoCount(ctx, t, store, 1)
 }
 
+func TestSyncRestoredRepo(t *testing.T) {
+	t.Parallel()
+	store := getTestRepoStore(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Now()
+
+	svc := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "Github - Test",
+		Config:      extsvc.NewUnencryptedConfig(basicGitHubConfig),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := store.ExternalServiceStore().Upsert(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	githubRepo := &types.Repo{
+		Name:     "github.com/org/foo",
+		Metadata: &github.Repository{},
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "foo-external-12345",
+			ServiceID:   "https://github.com/",
+			ServiceType: extsvc.TypeGitHub,
+		},
+	}
+
+	syncer := &repos.Syncer{
+		ObsvCtx: observation.TestContextTB(t),
+		Sourcer: func(ctx context.Context, service *types.ExternalService) (repos.Source, error) {
+			return repos.NewFakeSource(svc, nil, githubRepo.Clone()), nil
+		},
+		Store: store,
+		Now:   time.Now,
+	}
+	if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second, noopProgressRecorder); err != nil {
+		t.Fatal(err)
+	}
+
+	synced, err := store.RepoStore().GetByName(ctx, githubRepo.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleting the repo removes its sources, which restoring it doesn't bring back.
+	if err := store.RepoStore().Delete(ctx, synced.ID); err != nil {
+		t.Fatal(err)
+	}
+	assertSourceCount(ctx, t, store, 0)
+
+	restored, err := store.RepoStore().Restore(ctx, githubRepo.Name, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.ID != synced.ID {
+		t.Fatalf("restored repo %d, want %d", restored.ID, synced.ID)
+	}
+	if len(restored.Sources) != 0 {
+		t.Fatalf("restored repo has sources before syncing: %v", restored.Sources)
+	}
+
+	// Syncing the external service links the restored repo again.
+	if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second, noopProgressRecorder); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err = store.RepoStore().GetByName(ctx, githubRepo.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.ID != synced.ID {
+		t.Fatalf("synced repo %d, want restored repo %d", restored.ID, synced.ID)
+	}
+	if _, ok := restored.Sources[svc.URN()]; !ok {
+		t.Fatalf("restored repo has no source for %s: %v", svc.URN(), restored.Sources)
+	}
+	assertSourceCount(ctx, t, store, 1)
+}
+
 func TestCloudDefaultExternalServicesDontSync(t *testing.T) {
 	t.Parallel()
 	store := getTestRepoStore(t)
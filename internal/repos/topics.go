@@ -0,0 +1,43 @@
+package repos
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// codeHostTopics returns the topics of the repository on the code host, which
+// are synced as tags into the repository metadata. It returns false if the
+// code host of the repository doesn't support topics.
+func codeHostTopics(r *types.Repo) ([]string, bool) {
+	var topics []string
+	switch m := r.Metadata.(type) {
+	case *github.Repository:
+		for _, node := range m.RepositoryTopics.Nodes {
+			topics = append(topics, node.Topic.Name)
+		}
+	case *gitlab.Project:
+		topics = append(topics, m.Topics...)
+	default:
+		return nil, false
+	}
+
+	// Topics are case-insensitive on GitLab, so we may get duplicates that
+	// only differ in case. Keep the first occurrence.
+	seen := make(map[string]struct{}, len(topics))
+	deduped := topics[:0]
+	for _, topic := range topics {
+		if topic == "" {
+			continue
+		}
+		key := strings.ToLower(topic)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		deduped = append(deduped, topic)
+	}
+	return deduped, true
+}
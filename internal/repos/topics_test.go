@@ -0,0 +1,58 @@
+package repos
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeHostTopics(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		repo   *types.Repo
+		want   []string
+		wantOK bool
+	}{
+		{
+			name: "github",
+			repo: &types.Repo{Metadata: &github.Repository{
+				RepositoryTopics: github.RepositoryTopics{Nodes: []github.RepositoryTopic{
+					{Topic: github.Topic{Name: "go"}},
+					{Topic: github.Topic{Name: "search"}},
+				}},
+			}},
+			want:   []string{"go", "search"},
+			wantOK: true,
+		},
+		{
+			name:   "github without topics",
+			repo:   &types.Repo{Metadata: &github.Repository{}},
+			wantOK: true,
+		},
+		{
+			name:   "gitlab with duplicates",
+			repo:   &types.Repo{Metadata: &gitlab.Project{Topics: []string{"ci", "", "CI", "infra"}}},
+			want:   []string{"ci", "infra"},
+			wantOK: true,
+		},
+		{
+			name:   "unsupported code host",
+			repo:   &types.Repo{Metadata: &struct{}{}},
+			wantOK: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, ok := codeHostTopics(tc.repo)
+			if ok != tc.wantOK {
+				t.Fatalf("unexpected ok: have %t, want %t", ok, tc.wantOK)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("unexpected topics (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
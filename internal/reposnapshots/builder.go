@@ -0,0 +1,127 @@
+package reposnapshots
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Builder writes the archives and the signed manifest of snapshots to the blob
+// store.
+type Builder struct {
+	repos           database.RepoStore
+	gitserverClient gitserver.Client
+	uploadStore     uploadstore.Store
+	now             func() time.Time
+}
+
+func NewBuilder(repos database.RepoStore, gitserverClient gitserver.Client, uploadStore uploadstore.Store) *Builder {
+	return &Builder{
+		repos:           repos,
+		gitserverClient: gitserverClient,
+		uploadStore:     uploadStore,
+		now:             time.Now,
+	}
+}
+
+// Build archives every target of the given snapshot and uploads the archives
+// together with the manifest and its signature. It returns the manifest and the
+// base64-encoded signature of the uploaded manifest.
+func (b *Builder) Build(ctx context.Context, snapshot *Snapshot, signer *Signer) (*Manifest, string, error) {
+	manifest := &Manifest{
+		SnapshotID: snapshot.ID,
+		CreatedAt:  b.now().UTC(),
+		Archives:   make([]ManifestArchive, 0, len(snapshot.Targets)),
+	}
+
+	for _, target := range snapshot.Targets {
+		archive, err := b.buildArchive(ctx, snapshot.ID, target)
+		if err != nil {
+			return nil, "", err
+		}
+		manifest.Archives = append(manifest.Archives, archive)
+	}
+
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+	signature := base64.StdEncoding.EncodeToString(signer.Sign(contents))
+
+	if _, err := b.uploadStore.Upload(ctx, Key(snapshot.ID, ManifestName), bytes.NewReader(contents)); err != nil {
+		return nil, "", errors.Wrap(err, "uploading manifest")
+	}
+	if _, err := b.uploadStore.Upload(ctx, Key(snapshot.ID, SignatureName), bytes.NewReader([]byte(signature))); err != nil {
+		return nil, "", errors.Wrap(err, "uploading manifest signature")
+	}
+
+	return manifest, signature, nil
+}
+
+func (b *Builder) buildArchive(ctx context.Context, snapshotID int, target Target) (ManifestArchive, error) {
+	repo, err := b.repos.Get(ctx, api.RepoID(target.RepositoryID))
+	if err != nil {
+		return ManifestArchive{}, errors.Wrapf(err, "getting repository %d", target.RepositoryID)
+	}
+
+	revision := target.Revision
+	if revision == "" {
+		revision = "HEAD"
+	}
+	commit, err := b.gitserverClient.ResolveRevision(ctx, repo.Name, revision, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return ManifestArchive{}, errors.Wrapf(err, "resolving revision %q of %s", revision, repo.Name)
+	}
+
+	rc, err := b.gitserverClient.ArchiveReader(ctx, repo.Name, gitserver.ArchiveOptions{
+		Treeish: string(commit),
+		Format:  gitserver.ArchiveFormatTar,
+	})
+	if err != nil {
+		return ManifestArchive{}, errors.Wrapf(err, "archiving %s@%s", repo.Name, commit)
+	}
+	defer rc.Close()
+
+	// Compress the archive while it is being uploaded, and compute the checksum
+	// of the compressed bytes that end up in the blob store.
+	pr, pw := io.Pipe()
+	go func() {
+		gzw := gzip.NewWriter(pw)
+		_, err := io.Copy(gzw, rc)
+		if closeErr := gzw.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+
+	name := ArchiveName(string(repo.Name), string(commit))
+	hash := sha256.New()
+	size, err := b.uploadStore.Upload(ctx, Key(snapshotID, name), io.TeeReader(pr, hash))
+	// Unblock the compressing goroutine if the upload stopped reading early.
+	pr.CloseWithError(err)
+	if err != nil {
+		return ManifestArchive{}, errors.Wrapf(err, "uploading archive of %s@%s", repo.Name, commit)
+	}
+
+	return ManifestArchive{
+		RepositoryID: target.RepositoryID,
+		Repository:   string(repo.Name),
+		Revision:     revision,
+		Commit:       string(commit),
+		Name:         name,
+		SHA256:       hex.EncodeToString(hash.Sum(nil)),
+		Size:         size,
+	}, nil
+}
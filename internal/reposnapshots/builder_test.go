@@ -0,0 +1,106 @@
+package reposnapshots
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+)
+
+func TestBuilder(t *testing.T) {
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: map[api.RepoID]api.RepoName{1: "github.com/foo/bar", 2: "github.com/foo/baz"}[id]}, nil
+	})
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID(strings.Repeat(map[string]string{"HEAD": "a", "v1.0.0": "b"}[spec], 40)), nil
+	})
+	gitserverClient.ArchiveReaderFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, opts gitserver.ArchiveOptions) (io.ReadCloser, error) {
+		assert.Equal(t, gitserver.ArchiveFormatTar, opts.Format)
+		return io.NopCloser(strings.NewReader("tar of " + string(repo) + "@" + opts.Treeish)), nil
+	})
+
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	uploadStore := mocks.NewMockStore()
+	uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, key string, r io.Reader) (int64, error) {
+		contents, err := io.ReadAll(r)
+		if err != nil {
+			return 0, err
+		}
+		mu.Lock()
+		objects[key] = contents
+		mu.Unlock()
+		return int64(len(contents)), nil
+	})
+
+	signer, err := NewSigner(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", ed25519.SeedSize))))
+	require.NoError(t, err)
+
+	builder := NewBuilder(repos, gitserverClient, uploadStore)
+	builder.now = func() time.Time { return time.Date(2023, 12, 27, 12, 0, 0, 0, time.UTC) }
+
+	manifest, signature, err := builder.Build(context.Background(), &Snapshot{
+		ID: 42,
+		Targets: []Target{
+			{RepositoryID: 1},
+			{RepositoryID: 2, Revision: "v1.0.0"},
+		},
+	}, signer)
+	require.NoError(t, err)
+
+	require.Len(t, manifest.Archives, 2)
+	assert.Equal(t, 42, manifest.SnapshotID)
+	assert.Equal(t, "HEAD", manifest.Archives[0].Revision)
+	assert.Equal(t, strings.Repeat("a", 40), manifest.Archives[0].Commit)
+	assert.Equal(t, "v1.0.0", manifest.Archives[1].Revision)
+	assert.Equal(t, strings.Repeat("b", 40), manifest.Archives[1].Commit)
+
+	for _, archive := range manifest.Archives {
+		contents, ok := objects[Key(42, archive.Name)]
+		require.True(t, ok, "archive %q was not uploaded", archive.Name)
+
+		checksum := sha256.Sum256(contents)
+		assert.Equal(t, hex.EncodeToString(checksum[:]), archive.SHA256)
+		assert.Equal(t, int64(len(contents)), archive.Size)
+
+		gzr, err := gzip.NewReader(bytes.NewReader(contents))
+		require.NoError(t, err)
+		tar, err := io.ReadAll(gzr)
+		require.NoError(t, err)
+		assert.Equal(t, "tar of "+archive.Repository+"@"+archive.Commit, string(tar))
+	}
+	assert.Equal(t, "42/archives/github.com/foo/bar@"+strings.Repeat("a", 40)+".tar.gz", Key(42, manifest.Archives[0].Name))
+
+	// The uploaded manifest matches the returned one and is signed.
+	var uploaded Manifest
+	require.NoError(t, json.Unmarshal(objects["42/manifest.json"], &uploaded))
+	assert.Equal(t, *manifest, uploaded)
+	assert.Equal(t, signature, string(objects["42/manifest.json.sig"]))
+
+	publicKey, err := base64.StdEncoding.DecodeString(signer.PublicKey())
+	require.NoError(t, err)
+	rawSignature, err := base64.StdEncoding.DecodeString(signature)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, objects["42/manifest.json"], rawSignature))
+}
@@ -0,0 +1,49 @@
+package reposnapshots
+
+import (
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ValidateCronExpression returns an error if the given cron expression cannot be
+// used for a schedule.
+func ValidateCronExpression(expression string) error {
+	if _, err := cronexpr.Parse(expression); err != nil {
+		return errors.Wrapf(err, "invalid cron expression %q", expression)
+	}
+	return nil
+}
+
+// NextRun returns the time at which the next snapshot of the given schedule is
+// due. This is the first time matching the cron expression of the schedule after
+// the last snapshot was enqueued, or after the schedule was created if it never
+// enqueued a snapshot. The zero time is returned if the expression never matches.
+func NextRun(schedule *Schedule) (time.Time, error) {
+	expression, err := cronexpr.Parse(schedule.CronExpression)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid cron expression %q", schedule.CronExpression)
+	}
+
+	from := schedule.CreatedAt
+	if schedule.LastEnqueuedAt != nil {
+		from = *schedule.LastEnqueuedAt
+	}
+	return expression.Next(from), nil
+}
+
+// isDue returns true if a snapshot of the given schedule should be enqueued at
+// the given time.
+func isDue(schedule *Schedule, now time.Time) (bool, error) {
+	if !schedule.Enabled {
+		return false, nil
+	}
+
+	next, err := NextRun(schedule)
+	if err != nil {
+		return false, err
+	}
+	return !next.IsZero() && !next.After(now), nil
+}
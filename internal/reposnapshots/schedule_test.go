@@ -0,0 +1,70 @@
+package reposnapshots
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDue(t *testing.T) {
+	createdAt := time.Date(2023, 12, 27, 10, 30, 0, 0, time.UTC)
+	lastEnqueuedAt := time.Date(2023, 12, 28, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		schedule Schedule
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "never enqueued, before first run",
+			schedule: Schedule{CronExpression: "0 0 * * *", Enabled: true, CreatedAt: createdAt},
+			now:      createdAt.Add(time.Hour),
+			expected: false,
+		},
+		{
+			name:     "never enqueued, after first run",
+			schedule: Schedule{CronExpression: "0 0 * * *", Enabled: true, CreatedAt: createdAt},
+			now:      lastEnqueuedAt.Add(time.Minute),
+			expected: true,
+		},
+		{
+			name:     "enqueued, before next run",
+			schedule: Schedule{CronExpression: "0 0 * * *", Enabled: true, CreatedAt: createdAt, LastEnqueuedAt: &lastEnqueuedAt},
+			now:      lastEnqueuedAt.Add(23 * time.Hour),
+			expected: false,
+		},
+		{
+			name:     "enqueued, at next run",
+			schedule: Schedule{CronExpression: "0 0 * * *", Enabled: true, CreatedAt: createdAt, LastEnqueuedAt: &lastEnqueuedAt},
+			now:      lastEnqueuedAt.Add(24 * time.Hour),
+			expected: true,
+		},
+		{
+			name:     "disabled",
+			schedule: Schedule{CronExpression: "0 0 * * *", Enabled: false, CreatedAt: createdAt},
+			now:      lastEnqueuedAt.Add(time.Minute),
+			expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			due, err := isDue(&testCase.schedule, testCase.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if due != testCase.expected {
+				t.Errorf("unexpected due. want=%v have=%v", testCase.expected, due)
+			}
+		})
+	}
+}
+
+func TestValidateCronExpression(t *testing.T) {
+	if err := ValidateCronExpression("0 0 * * 1"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := ValidateCronExpression("every monday"); err == nil {
+		t.Error("expected error for invalid expression")
+	}
+}
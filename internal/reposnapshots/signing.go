@@ -0,0 +1,52 @@
+package reposnapshots
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrNoSigningKey is returned when repositorySnapshots.signingKey is not set in
+// site configuration.
+var ErrNoSigningKey = errors.New("repositorySnapshots.signingKey is not set in site configuration")
+
+// Signer signs the manifests of snapshots with an Ed25519 key.
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewSigner returns a signer for the given base64-encoded Ed25519 seed.
+func NewSigner(encodedSeed string) (*Signer, error) {
+	seed, err := base64.StdEncoding.DecodeString(encodedSeed)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding repository snapshot signing key")
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.Newf("repository snapshot signing key must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+
+	return &Signer{key: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// SignerFromSiteConfig returns a signer for the key configured in the
+// repositorySnapshots.signingKey site configuration setting.
+func SignerFromSiteConfig() (*Signer, error) {
+	c := conf.Get().RepositorySnapshots
+	if c == nil || c.SigningKey == "" {
+		return nil, ErrNoSigningKey
+	}
+	return NewSigner(c.SigningKey)
+}
+
+// Sign returns the signature of the given data.
+func (s *Signer) Sign(data []byte) []byte {
+	return ed25519.Sign(s.key, data)
+}
+
+// PublicKey returns the base64-encoded public key that verifies the signatures
+// of the signer.
+func (s *Signer) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey))
+}
@@ -0,0 +1,36 @@
+package reposnapshots
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	seed := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", ed25519.SeedSize)))
+	signer, err := NewSigner(seed)
+	require.NoError(t, err)
+
+	publicKey, err := base64.StdEncoding.DecodeString(signer.PublicKey())
+	require.NoError(t, err)
+
+	data := []byte(`{"snapshot_id":1}`)
+	signature := signer.Sign(data)
+	assert.True(t, ed25519.Verify(publicKey, data, signature))
+	assert.False(t, ed25519.Verify(publicKey, []byte(`{"snapshot_id":2}`), signature))
+}
+
+func TestNewSignerInvalidKey(t *testing.T) {
+	for _, key := range []string{
+		"not base64!",
+		base64.StdEncoding.EncodeToString([]byte("too short")),
+	} {
+		if _, err := NewSigner(key); err == nil {
+			t.Errorf("expected error for key %q", key)
+		}
+	}
+}
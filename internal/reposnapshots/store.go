@@ -0,0 +1,615 @@
+package reposnapshots
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrNotFound is returned by Store methods for schedules and snapshots that do
+// not exist.
+var ErrNotFound = errors.New("not found")
+
+// Store reads and writes repository snapshots and their schedules.
+type Store struct {
+	*basestore.Store
+	operations *operations
+}
+
+// NewStore returns a new Store backed by the given database.
+func NewStore(db database.DB, observationCtx *observation.Context) *Store {
+	return &Store{
+		Store:      basestore.NewWithHandle(db.Handle()),
+		operations: newOperations(observationCtx),
+	}
+}
+
+// Transact creates a new transaction.
+func (s *Store) Transact(ctx context.Context) (*Store, error) {
+	txBase, err := s.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{
+		Store:      txBase,
+		operations: s.operations,
+	}, nil
+}
+
+const maxNumResets = 5
+const maxNumRetries = 3
+
+var snapshotWorkerOpts = dbworkerstore.Options[*Snapshot]{
+	Name:              "repository_snapshots_worker_store",
+	TableName:         "repository_snapshots",
+	ColumnExpressions: snapshotColumns,
+
+	Scan: dbworkerstore.BuildWorkerScan(scanSnapshot),
+
+	OrderByExpression: sqlf.Sprintf("repository_snapshots.queued_at, repository_snapshots.id"),
+
+	StalledMaxAge: 60 * time.Second,
+	MaxNumResets:  maxNumResets,
+
+	RetryAfter:    time.Minute,
+	MaxNumRetries: maxNumRetries,
+}
+
+// NewWorkerStore returns a dbworkerstore.Store that wraps the "repository_snapshots" table.
+func NewWorkerStore(observationCtx *observation.Context, handle basestore.TransactableHandle) dbworkerstore.Store[*Snapshot] {
+	return dbworkerstore.New(observationCtx, handle, snapshotWorkerOpts)
+}
+
+//
+// Schedules
+
+var scheduleColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("name"),
+	sqlf.Sprintf("targets"),
+	sqlf.Sprintf("cron_expression"),
+	sqlf.Sprintf("enabled"),
+	sqlf.Sprintf("last_enqueued_at"),
+	sqlf.Sprintf("creator_id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// CreateSchedule inserts the given schedule and returns it with its ID and
+// timestamps set.
+func (s *Store) CreateSchedule(ctx context.Context, schedule Schedule) (_ *Schedule, err error) {
+	ctx, _, endObservation := s.operations.createSchedule.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("name", schedule.Name),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := ValidateCronExpression(schedule.CronExpression); err != nil {
+		return nil, err
+	}
+	targets, err := json.Marshal(nonNilTargets(schedule.Targets))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanFirstSchedule(s.Query(ctx, sqlf.Sprintf(
+		createScheduleQuery,
+		schedule.Name,
+		targets,
+		schedule.CronExpression,
+		schedule.Enabled,
+		schedule.CreatorID,
+		sqlf.Join(scheduleColumns, ", "),
+	)))
+}
+
+const createScheduleQuery = `
+INSERT INTO repository_snapshot_schedules (name, targets, cron_expression, enabled, creator_id)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateSchedule updates the name, targets, cron expression, and enabled flag
+// of the given schedule.
+func (s *Store) UpdateSchedule(ctx context.Context, schedule Schedule) (_ *Schedule, err error) {
+	ctx, _, endObservation := s.operations.updateSchedule.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", schedule.ID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := ValidateCronExpression(schedule.CronExpression); err != nil {
+		return nil, err
+	}
+	targets, err := json.Marshal(nonNilTargets(schedule.Targets))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanFirstSchedule(s.Query(ctx, sqlf.Sprintf(
+		updateScheduleQuery,
+		schedule.Name,
+		targets,
+		schedule.CronExpression,
+		schedule.Enabled,
+		schedule.ID,
+		sqlf.Join(scheduleColumns, ", "),
+	)))
+}
+
+const updateScheduleQuery = `
+UPDATE repository_snapshot_schedules
+SET
+	name = %s,
+	targets = %s,
+	cron_expression = %s,
+	enabled = %s,
+	updated_at = NOW()
+WHERE id = %s
+RETURNING %s
+`
+
+// GetSchedule returns the schedule with the given ID.
+func (s *Store) GetSchedule(ctx context.Context, id int) (_ *Schedule, err error) {
+	ctx, _, endObservation := s.operations.getSchedule.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstSchedule(s.Query(ctx, sqlf.Sprintf(
+		getScheduleQuery,
+		sqlf.Join(scheduleColumns, ", "),
+		id,
+	)))
+}
+
+const getScheduleQuery = `
+SELECT %s
+FROM repository_snapshot_schedules
+WHERE id = %s
+`
+
+// ListSchedules returns all schedules, ordered by name.
+func (s *Store) ListSchedules(ctx context.Context) (_ []*Schedule, err error) {
+	ctx, _, endObservation := s.operations.listSchedules.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return scanSchedules(s.Query(ctx, sqlf.Sprintf(
+		listSchedulesQuery,
+		sqlf.Join(scheduleColumns, ", "),
+	)))
+}
+
+const listSchedulesQuery = `
+SELECT %s
+FROM repository_snapshot_schedules
+ORDER BY name, id
+`
+
+// DeleteSchedule deletes the schedule with the given ID. The snapshots enqueued
+// by the schedule are kept.
+func (s *Store) DeleteSchedule(ctx context.Context, id int) (err error) {
+	ctx, _, endObservation := s.operations.deleteSchedule.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(deleteScheduleQuery, id)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const deleteScheduleQuery = `
+DELETE FROM repository_snapshot_schedules
+WHERE id = %s
+RETURNING id
+`
+
+// EnqueueDueSnapshots enqueues a snapshot for every enabled schedule whose next
+// run is at or before the given time, and returns the number of enqueued snapshots.
+// Schedules that missed several runs enqueue a single snapshot.
+func (s *Store) EnqueueDueSnapshots(ctx context.Context, now time.Time) (_ int, err error) {
+	ctx, _, endObservation := s.operations.enqueueDueSnapshots.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	schedules, err := scanSchedules(tx.Query(ctx, sqlf.Sprintf(
+		lockEnabledSchedulesQuery,
+		sqlf.Join(scheduleColumns, ", "),
+	)))
+	if err != nil {
+		return 0, err
+	}
+
+	numEnqueued := 0
+	for _, schedule := range schedules {
+		due, err := isDue(schedule, now)
+		if err != nil {
+			// A schedule with an invalid expression should not block the others
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		if _, err := tx.CreateSnapshot(ctx, schedule.Targets, &schedule.ID, nil); err != nil {
+			return 0, err
+		}
+		if err := tx.Exec(ctx, sqlf.Sprintf(markScheduleEnqueuedQuery, now, schedule.ID)); err != nil {
+			return 0, err
+		}
+		numEnqueued++
+	}
+
+	return numEnqueued, nil
+}
+
+const lockEnabledSchedulesQuery = `
+SELECT %s
+FROM repository_snapshot_schedules
+WHERE enabled
+ORDER BY id
+FOR UPDATE SKIP LOCKED
+`
+
+const markScheduleEnqueuedQuery = `
+UPDATE repository_snapshot_schedules
+SET last_enqueued_at = %s
+WHERE id = %s
+`
+
+//
+// Snapshots
+
+var snapshotColumns = []*sqlf.Query{
+	sqlf.Sprintf("repository_snapshots.id"),
+	sqlf.Sprintf("repository_snapshots.schedule_id"),
+	sqlf.Sprintf("repository_snapshots.initiator_id"),
+	sqlf.Sprintf("repository_snapshots.targets"),
+	sqlf.Sprintf("repository_snapshots.manifest"),
+	sqlf.Sprintf("repository_snapshots.signature"),
+	sqlf.Sprintf("repository_snapshots.state"),
+	sqlf.Sprintf("repository_snapshots.failure_message"),
+	sqlf.Sprintf("repository_snapshots.queued_at"),
+	sqlf.Sprintf("repository_snapshots.started_at"),
+	sqlf.Sprintf("repository_snapshots.finished_at"),
+	sqlf.Sprintf("repository_snapshots.process_after"),
+	sqlf.Sprintf("repository_snapshots.num_resets"),
+	sqlf.Sprintf("repository_snapshots.num_failures"),
+	sqlf.Sprintf("repository_snapshots.last_heartbeat_at"),
+	sqlf.Sprintf("repository_snapshots.execution_logs"),
+	sqlf.Sprintf("repository_snapshots.worker_hostname"),
+	sqlf.Sprintf("repository_snapshots.cancel"),
+	sqlf.Sprintf("repository_snapshots.created_at"),
+}
+
+// CreateSnapshot enqueues a snapshot of the given targets. The schedule ID is
+// set for snapshots enqueued by a schedule, and the initiator ID for snapshots
+// created on demand.
+func (s *Store) CreateSnapshot(ctx context.Context, targets []Target, scheduleID *int, initiatorID *int32) (_ *Snapshot, err error) {
+	ctx, _, endObservation := s.operations.createSnapshot.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("numTargets", len(targets)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(targets) == 0 {
+		return nil, errors.New("a snapshot must have at least one target")
+	}
+	serializedTargets, err := json.Marshal(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanFirstSnapshot(s.Query(ctx, sqlf.Sprintf(
+		createSnapshotQuery,
+		scheduleID,
+		initiatorID,
+		serializedTargets,
+		sqlf.Join(snapshotColumns, ", "),
+	)))
+}
+
+const createSnapshotQuery = `
+INSERT INTO repository_snapshots (schedule_id, initiator_id, targets)
+VALUES (%s, %s, %s)
+RETURNING %s
+`
+
+// GetSnapshot returns the snapshot with the given ID.
+func (s *Store) GetSnapshot(ctx context.Context, id int) (_ *Snapshot, err error) {
+	ctx, _, endObservation := s.operations.getSnapshot.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstSnapshot(s.Query(ctx, sqlf.Sprintf(
+		getSnapshotQuery,
+		sqlf.Join(snapshotColumns, ", "),
+		id,
+	)))
+}
+
+const getSnapshotQuery = `
+SELECT %s
+FROM repository_snapshots
+WHERE id = %s
+`
+
+// ListSnapshotsOpts are the options for ListSnapshots.
+type ListSnapshotsOpts struct {
+	// ScheduleID, if set, limits the results to the snapshots of the given schedule.
+	ScheduleID *int
+	Limit      int
+	Offset     int
+}
+
+// ListSnapshots returns the snapshots matching the given options, newest first,
+// and the total number of matching snapshots.
+func (s *Store) ListSnapshots(ctx context.Context, opts ListSnapshotsOpts) (_ []*Snapshot, _ int, err error) {
+	ctx, _, endObservation := s.operations.listSnapshots.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	cond := sqlf.Sprintf("TRUE")
+	if opts.ScheduleID != nil {
+		cond = sqlf.Sprintf("schedule_id = %s", *opts.ScheduleID)
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	snapshots, err := scanSnapshots(tx.Query(ctx, sqlf.Sprintf(
+		listSnapshotsQuery,
+		sqlf.Join(snapshotColumns, ", "),
+		cond,
+		opts.Limit,
+		opts.Offset,
+	)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount, _, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(countSnapshotsQuery, cond)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return snapshots, totalCount, nil
+}
+
+const listSnapshotsQuery = `
+SELECT %s
+FROM repository_snapshots
+WHERE %s
+ORDER BY id DESC
+LIMIT %s OFFSET %s
+`
+
+const countSnapshotsQuery = `
+SELECT COUNT(*)
+FROM repository_snapshots
+WHERE %s
+`
+
+// DeleteSnapshot deletes the snapshot with the given ID. The caller is
+// responsible for deleting the objects of the snapshot from the blob store.
+func (s *Store) DeleteSnapshot(ctx context.Context, id int) (err error) {
+	ctx, _, endObservation := s.operations.deleteSnapshot.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(deleteSnapshotQuery, id)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const deleteSnapshotQuery = `
+DELETE FROM repository_snapshots
+WHERE id = %s
+RETURNING id
+`
+
+// SetSnapshotManifest stores the manifest and the signature of the given
+// snapshot once its archives have been uploaded.
+func (s *Store) SetSnapshotManifest(ctx context.Context, id int, manifest *Manifest, signature string) (err error) {
+	ctx, _, endObservation := s.operations.setSnapshotManifest.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	serializedManifest, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(setSnapshotManifestQuery, serializedManifest, signature, id))
+}
+
+const setSnapshotManifestQuery = `
+UPDATE repository_snapshots
+SET manifest = %s, signature = %s
+WHERE id = %s
+`
+
+//
+// Scanners
+
+func scanSchedule(sc dbutil.Scanner) (*Schedule, error) {
+	var schedule Schedule
+	var targets []byte
+	if err := sc.Scan(
+		&schedule.ID,
+		&schedule.Name,
+		&targets,
+		&schedule.CronExpression,
+		&schedule.Enabled,
+		&schedule.LastEnqueuedAt,
+		&schedule.CreatorID,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(targets, &schedule.Targets); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling schedule targets")
+	}
+	return &schedule, nil
+}
+
+var scanSchedules = basestore.NewSliceScanner(scanSchedule)
+
+func scanFirstSchedule(rows basestore.Rows, queryErr error) (*Schedule, error) {
+	schedule, ok, err := basestore.NewFirstScanner(scanSchedule)(rows, queryErr)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return schedule, nil
+}
+
+func scanSnapshot(sc dbutil.Scanner) (*Snapshot, error) {
+	var snapshot Snapshot
+	var targets, manifest []byte
+	// required field for the worker, but the value is thrown out here
+	var executionLogs *[]any
+
+	if err := sc.Scan(
+		&snapshot.ID,
+		&snapshot.ScheduleID,
+		&snapshot.InitiatorID,
+		&targets,
+		&manifest,
+		&dbutil.NullString{S: &snapshot.Signature},
+		&snapshot.State,
+		&snapshot.FailureMessage,
+		&snapshot.QueuedAt,
+		&snapshot.StartedAt,
+		&snapshot.FinishedAt,
+		&snapshot.ProcessAfter,
+		&snapshot.NumResets,
+		&snapshot.NumFailures,
+		&snapshot.LastHeartbeatAt,
+		&executionLogs,
+		&snapshot.WorkerHostname,
+		&snapshot.Cancel,
+		&snapshot.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(targets, &snapshot.Targets); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling snapshot targets")
+	}
+	if manifest != nil {
+		snapshot.Manifest = &Manifest{}
+		if err := json.Unmarshal(manifest, snapshot.Manifest); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling snapshot manifest")
+		}
+	}
+	return &snapshot, nil
+}
+
+var scanSnapshots = basestore.NewSliceScanner(scanSnapshot)
+
+func scanFirstSnapshot(rows basestore.Rows, queryErr error) (*Snapshot, error) {
+	snapshot, ok, err := basestore.NewFirstScanner(scanSnapshot)(rows, queryErr)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return snapshot, nil
+}
+
+func nonNilTargets(targets []Target) []Target {
+	if targets == nil {
+		return []Target{}
+	}
+	return targets
+}
+
+//
+// Observability
+
+type operations struct {
+	createSchedule      *observation.Operation
+	updateSchedule      *observation.Operation
+	getSchedule         *observation.Operation
+	listSchedules       *observation.Operation
+	deleteSchedule      *observation.Operation
+	enqueueDueSnapshots *observation.Operation
+	createSnapshot      *observation.Operation
+	getSnapshot         *observation.Operation
+	listSnapshots       *observation.Operation
+	deleteSnapshot      *observation.Operation
+	setSnapshotManifest *observation.Operation
+}
+
+var m = new(metrics.SingletonREDMetrics)
+
+func newOperations(observationCtx *observation.Context) *operations {
+	redMetrics := m.Get(func() *metrics.REDMetrics {
+		return metrics.NewREDMetrics(
+			observationCtx.Registerer,
+			"repository_snapshots_store",
+			metrics.WithLabels("op"),
+			metrics.WithCountHelp("Total number of method invocations."),
+		)
+	})
+
+	op := func(name string) *observation.Operation {
+		return observationCtx.Operation(observation.Op{
+			Name:              fmt.Sprintf("reposnapshots.store.%s", name),
+			MetricLabelValues: []string{name},
+			Metrics:           redMetrics,
+		})
+	}
+
+	return &operations{
+		createSchedule:      op("CreateSchedule"),
+		updateSchedule:      op("UpdateSchedule"),
+		getSchedule:         op("GetSchedule"),
+		listSchedules:       op("ListSchedules"),
+		deleteSchedule:      op("DeleteSchedule"),
+		enqueueDueSnapshots: op("EnqueueDueSnapshots"),
+		createSnapshot:      op("CreateSnapshot"),
+		getSnapshot:         op("GetSnapshot"),
+		listSnapshots:       op("ListSnapshots"),
+		deleteSnapshot:      op("DeleteSnapshot"),
+		setSnapshotManifest: op("SetSnapshotManifest"),
+	}
+}
@@ -0,0 +1,125 @@
+package reposnapshots
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestStoreSchedules(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := NewStore(db, &observation.TestContext)
+
+	_, err := store.CreateSchedule(ctx, Schedule{Name: "invalid", CronExpression: "whenever"})
+	require.Error(t, err)
+
+	schedule, err := store.CreateSchedule(ctx, Schedule{
+		Name:           "weekly",
+		Targets:        []Target{{RepositoryID: 1, Revision: "main"}},
+		CronExpression: "0 0 * * 1",
+		Enabled:        true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "weekly", schedule.Name)
+	assert.Equal(t, []Target{{RepositoryID: 1, Revision: "main"}}, schedule.Targets)
+	assert.Nil(t, schedule.LastEnqueuedAt)
+
+	schedule.Name = "daily"
+	schedule.CronExpression = "0 0 * * *"
+	schedule.Enabled = false
+	updated, err := store.UpdateSchedule(ctx, *schedule)
+	require.NoError(t, err)
+	assert.Equal(t, "daily", updated.Name)
+	assert.False(t, updated.Enabled)
+
+	schedules, err := store.ListSchedules(ctx)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, updated.ID, schedules[0].ID)
+
+	require.NoError(t, store.DeleteSchedule(ctx, schedule.ID))
+	_, err = store.GetSchedule(ctx, schedule.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(store.DeleteSchedule(ctx, schedule.ID), ErrNotFound))
+}
+
+func TestStoreSnapshots(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := NewStore(db, &observation.TestContext)
+
+	_, err := store.CreateSnapshot(ctx, nil, nil, nil)
+	require.Error(t, err)
+
+	first, err := store.CreateSnapshot(ctx, []Target{{RepositoryID: 1, Revision: "v1"}}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotStateQueued, first.State)
+	assert.Nil(t, first.Manifest)
+
+	second, err := store.CreateSnapshot(ctx, []Target{{RepositoryID: 2}}, nil, nil)
+	require.NoError(t, err)
+
+	manifest := &Manifest{
+		SnapshotID: first.ID,
+		CreatedAt:  time.Date(2023, 12, 27, 12, 0, 0, 0, time.UTC),
+		Archives:   []ManifestArchive{{RepositoryID: 1, Repository: "github.com/foo/bar", Revision: "v1", Commit: "deadbeef", Name: "archives/github.com/foo/bar@deadbeef.tar.gz", SHA256: "abc", Size: 3}},
+	}
+	require.NoError(t, store.SetSnapshotManifest(ctx, first.ID, manifest, "signature"))
+
+	snapshot, err := store.GetSnapshot(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, snapshot.Manifest)
+	assert.Equal(t, "signature", snapshot.Signature)
+
+	snapshots, totalCount, err := store.ListSnapshots(ctx, ListSnapshotsOpts{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, totalCount)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, second.ID, snapshots[0].ID)
+
+	require.NoError(t, store.DeleteSnapshot(ctx, first.ID))
+	_, err = store.GetSnapshot(ctx, first.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestEnqueueDueSnapshots(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := NewStore(db, &observation.TestContext)
+
+	targets := []Target{{RepositoryID: 1, Revision: "main"}}
+	due, err := store.CreateSchedule(ctx, Schedule{Name: "due", Targets: targets, CronExpression: "* * * * *", Enabled: true})
+	require.NoError(t, err)
+	_, err = store.CreateSchedule(ctx, Schedule{Name: "disabled", Targets: targets, CronExpression: "* * * * *", Enabled: false})
+	require.NoError(t, err)
+
+	now := time.Now().Add(time.Hour)
+	numEnqueued, err := store.EnqueueDueSnapshots(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, numEnqueued)
+
+	// The schedule is not due again until its next run.
+	numEnqueued, err = store.EnqueueDueSnapshots(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, numEnqueued)
+
+	snapshots, totalCount, err := store.ListSnapshots(ctx, ListSnapshotsOpts{ScheduleID: &due.ID, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, totalCount)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, targets, snapshots[0].Targets)
+	assert.Nil(t, snapshots[0].InitiatorID)
+}
@@ -0,0 +1,106 @@
+// Package reposnapshots produces point-in-time exports of repositories for
+// compliance purposes. A snapshot archives a set of repositories at the given
+// revisions into the blob store, together with a manifest that lists the
+// resolved commits and the checksums of the archives. The manifest is signed
+// with the Ed25519 key configured in the repositorySnapshots.signingKey site
+// configuration setting.
+//
+// Snapshots are created on demand by site admins, or periodically by the
+// schedules stored in the repository_snapshot_schedules table.
+package reposnapshots
+
+import (
+	"strconv"
+	"time"
+)
+
+// Target is a repository and revision to include in a snapshot.
+type Target struct {
+	RepositoryID int32  `json:"repository_id"`
+	Revision     string `json:"revision"`
+}
+
+// Schedule periodically enqueues a snapshot of its targets. Maps to the
+// repository_snapshot_schedules database table.
+type Schedule struct {
+	ID             int
+	Name           string
+	Targets        []Target
+	CronExpression string
+	Enabled        bool
+	LastEnqueuedAt *time.Time
+	CreatorID      *int32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// SnapshotState is the state of a snapshot in the worker queue.
+type SnapshotState string
+
+// SnapshotState constants.
+const (
+	SnapshotStateQueued     SnapshotState = "queued"
+	SnapshotStateProcessing SnapshotState = "processing"
+	SnapshotStateErrored    SnapshotState = "errored"
+	SnapshotStateFailed     SnapshotState = "failed"
+	SnapshotStateCompleted  SnapshotState = "completed"
+	SnapshotStateCanceled   SnapshotState = "canceled"
+)
+
+// Snapshot is a point-in-time export of a set of repositories. Maps to the
+// repository_snapshots database table.
+type Snapshot struct {
+	ID          int
+	ScheduleID  *int
+	InitiatorID *int32
+	Targets     []Target
+
+	// Manifest and Signature are set once the snapshot has been completed.
+	Manifest  *Manifest
+	Signature string
+
+	State           SnapshotState
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt *time.Time
+	WorkerHostname  string
+	Cancel          bool
+	CreatedAt       time.Time
+}
+
+func (s *Snapshot) RecordID() int {
+	return s.ID
+}
+
+func (s *Snapshot) RecordUID() string {
+	return strconv.Itoa(s.ID)
+}
+
+// Manifest describes the contents of a completed snapshot. It is stored as JSON
+// next to the archives of the snapshot, and signed.
+type Manifest struct {
+	SnapshotID int               `json:"snapshot_id"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Archives   []ManifestArchive `json:"archives"`
+}
+
+// ManifestArchive describes the archive of a single repository of a snapshot.
+type ManifestArchive struct {
+	RepositoryID int32  `json:"repository_id"`
+	Repository   string `json:"repository"`
+	Revision     string `json:"revision"`
+	Commit       string `json:"commit"`
+
+	// Name is the name of the archive relative to the snapshot, e.g.
+	// archives/github.com/sourcegraph/sourcegraph@<commit>.tar.gz.
+	Name string `json:"name"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the gzipped archive.
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
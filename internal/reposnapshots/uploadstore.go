@@ -0,0 +1,83 @@
+package reposnapshots
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Snapshots are stored in the blob store under the keys
+//
+//	<snapshot ID>/manifest.json
+//	<snapshot ID>/manifest.json.sig
+//	<snapshot ID>/archives/<repository name>@<commit>.tar.gz
+//
+// so that all objects of a snapshot can be listed and deleted with a single prefix.
+const (
+	ManifestName  = "manifest.json"
+	SignatureName = "manifest.json.sig"
+)
+
+func NewUploadStore(ctx context.Context, observationCtx *observation.Context, conf *Config) (uploadstore.Store, error) {
+	c := uploadstore.Config{
+		Backend:      conf.Backend,
+		ManageBucket: conf.ManageBucket,
+		Bucket:       conf.Bucket,
+		S3: uploadstore.S3Config{
+			Region:          conf.S3Region,
+			Endpoint:        conf.S3Endpoint,
+			UsePathStyle:    conf.S3UsePathStyle,
+			AccessKeyID:     conf.S3AccessKeyID,
+			SecretAccessKey: conf.S3SecretAccessKey,
+			SessionToken:    conf.S3SessionToken,
+		},
+		GCS: uploadstore.GCSConfig{
+			ProjectID:               conf.GCSProjectID,
+			CredentialsFile:         conf.GCSCredentialsFile,
+			CredentialsFileContents: conf.GCSCredentialsFileContents,
+		},
+	}
+	return uploadstore.CreateLazy(ctx, c, uploadstore.NewOperations(observationCtx, "repository_snapshots", "uploadstore"))
+}
+
+// SnapshotPrefix returns the prefix of the keys of all objects of the given snapshot.
+func SnapshotPrefix(snapshotID int) string {
+	return strconv.Itoa(snapshotID) + "/"
+}
+
+// Key returns the key of the object of the given snapshot with the given name.
+func Key(snapshotID int, name string) string {
+	return SnapshotPrefix(snapshotID) + name
+}
+
+// ArchiveName returns the name of the archive of the given repository at the
+// given commit, relative to its snapshot.
+func ArchiveName(repoName, commit string) string {
+	return "archives/" + repoName + "@" + commit + ".tar.gz"
+}
+
+// DeleteObjects deletes all objects of the given snapshot from the blob store.
+func DeleteObjects(ctx context.Context, store uploadstore.Store, snapshotID int) error {
+	it, err := store.List(ctx, SnapshotPrefix(snapshotID))
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	for it.Next() {
+		keys = append(keys, it.Current())
+	}
+	if err := it.Err(); err != nil {
+		return errors.Wrapf(err, "listing objects of snapshot %d", snapshotID)
+	}
+
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			return errors.Wrapf(err, "deleting %q", key)
+		}
+	}
+	return nil
+}
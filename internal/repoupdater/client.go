@@ -217,13 +217,33 @@ func (c *Client) EnqueueRepoUpdate(ctx context.Context, repo api.RepoName) (*pro
 		return MockEnqueueRepoUpdate(ctx, repo)
 	}
 
+	return c.enqueueRepoUpdate(ctx, &protocol.RepoUpdateRequest{Repo: repo})
+}
+
+// MockForceRepoUpdate mocks (*Client).ForceRepoUpdate for tests.
+var MockForceRepoUpdate func(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error)
+
+// ForceRepoUpdate requests that the named repository be updated ahead of all
+// other scheduled updates. It does not wait for the update, the returned
+// response contains the ID of the job tracking it instead.
+func (c *Client) ForceRepoUpdate(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
+	if MockForceRepoUpdate != nil {
+		return MockForceRepoUpdate(ctx, repo)
+	}
+
+	return c.enqueueRepoUpdate(ctx, &protocol.RepoUpdateRequest{Repo: repo, Force: true})
+}
+
+func (c *Client) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	repo := req.Repo
+
 	if conf.IsGRPCEnabled(ctx) {
 		client, err := c.grpcClient()
 		if err != nil {
 			return nil, err
 		}
 
-		req := proto.EnqueueRepoUpdateRequest{Repo: string(repo)}
+		req := proto.EnqueueRepoUpdateRequest{Repo: string(repo), Force: req.Force}
 		resp, err := client.EnqueueRepoUpdate(ctx, &req)
 		if err != nil {
 			if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
@@ -236,10 +256,6 @@ func (c *Client) EnqueueRepoUpdate(ctx context.Context, repo api.RepoName) (*pro
 		return protocol.RepoUpdateResponseFromProto(resp), nil
 	}
 
-	req := &protocol.RepoUpdateRequest{
-		Repo: repo,
-	}
-
 	resp, err := c.httpPost(ctx, "enqueue-repo-update", req)
 	if err != nil {
 		return nil, err
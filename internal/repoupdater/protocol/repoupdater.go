@@ -45,6 +45,7 @@ func (r *RepoUpdateSchedulerInfoResult) ToProto() *proto.RepoUpdateSchedulerInfo
 			Total:    int64(r.Queue.Total),
 			Updating: r.Queue.Updating,
 			Priority: int64(r.Queue.Priority),
+			JobId:    r.Queue.JobID,
 		}
 	}
 	return res
@@ -68,6 +69,7 @@ func RepoUpdateSchedulerInfoResultFromProto(p *proto.RepoUpdateSchedulerInfoResp
 			Total:    int(p.Queue.GetTotal()),
 			Updating: p.Queue.GetUpdating(),
 			Priority: int(p.Queue.GetPriority()),
+			JobID:    p.Queue.GetJobId(),
 		}
 	}
 
@@ -86,6 +88,9 @@ type RepoQueueState struct {
 	Total    int
 	Updating bool
 	Priority int
+	// JobID is the ID of the job tracking a forced update of the repo, or
+	// zero if the update wasn't forced.
+	JobID uint64 `json:",omitempty"`
 }
 
 // RepoLookupArgs is a request for information about a repository on repoupdater.
@@ -367,9 +372,15 @@ func RepoLinksFromProto(p *proto.RepoLinks) *RepoLinks {
 // RepoUpdateRequest is a request to update the contents of a given repo, or clone it if it doesn't exist.
 type RepoUpdateRequest struct {
 	Repo api.RepoName `json:"repo"`
+	// Force puts the update ahead of all other updates in the queue and
+	// tracks it with a job ID.
+	Force bool `json:"force,omitempty"`
 }
 
 func (a *RepoUpdateRequest) String() string {
+	if a.Force {
+		return fmt.Sprintf("RepoUpdateRequest{%s force}", a.Repo)
+	}
 	return fmt.Sprintf("RepoUpdateRequest{%s}", a.Repo)
 }
 
@@ -379,17 +390,21 @@ type RepoUpdateResponse struct {
 	ID api.RepoID `json:"id"`
 	// Name of the repo that got an update request.
 	Name string `json:"name"`
+	// JobID is the ID of the job tracking the update, only set for forced
+	// updates.
+	JobID uint64 `json:"job_id,omitempty"`
 }
 
 func RepoUpdateResponseFromProto(p *proto.EnqueueRepoUpdateResponse) *RepoUpdateResponse {
 	return &RepoUpdateResponse{
-		ID:   api.RepoID(p.GetId()),
-		Name: p.GetName(),
+		ID:    api.RepoID(p.GetId()),
+		Name:  p.GetName(),
+		JobID: p.GetJobId(),
 	}
 }
 
 func (a *RepoUpdateResponse) String() string {
-	return fmt.Sprintf("RepoUpdateResponse{ID: %d Name: %s}", a.ID, a.Name)
+	return fmt.Sprintf("RepoUpdateResponse{ID: %d Name: %s JobID: %d}", a.ID, a.Name, a.JobID)
 }
 
 // ChangesetSyncRequest is a request to sync a number of changesets
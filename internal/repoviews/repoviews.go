@@ -0,0 +1,248 @@
+// Package repoviews implements the path matching of repository views, which are
+// virtual repositories defined by path globs over a parent repository. A view
+// is resolved to its parent repository and a path filter wherever it is used,
+// so no git data is duplicated.
+package repoviews
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/gobwas/glob/syntax"
+	"github.com/gobwas/glob/syntax/ast"
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	maxNameLength     = 255
+	maxPathGlobs      = 100
+	pathGlobSeparator = '/'
+	pathGlobMetaChars = `*?[{\`
+)
+
+var validNameRegexp = lazyregexp.New(`^[a-zA-Z0-9_\-\/\.]+$`)
+
+// ValidateName returns an error if the given name is not a valid view name.
+// Names are used in search queries (view:name) and batch specs, so they are
+// restricted to the same characters as search context names.
+func ValidateName(name string) error {
+	if len(name) > maxNameLength {
+		return errors.Errorf("repository view name %q exceeds maximum allowed length (%d)", name, maxNameLength)
+	}
+	if !validNameRegexp.MatchString(name) {
+		return errors.Errorf("%q is not a valid repository view name", name)
+	}
+	return nil
+}
+
+// ValidatePathGlobs returns an error if the given path globs cannot define a
+// view.
+func ValidatePathGlobs(pathGlobs []string) error {
+	if len(pathGlobs) == 0 {
+		return errors.New("repository view must have at least one path glob")
+	}
+	if len(pathGlobs) > maxPathGlobs {
+		return errors.Errorf("repository view has more than %d path globs", maxPathGlobs)
+	}
+
+	var errs error
+	for _, pathGlob := range pathGlobs {
+		if pathGlob == "" {
+			errs = errors.Append(errs, errors.New("path glob must not be empty"))
+			continue
+		}
+		if strings.HasPrefix(pathGlob, "/") {
+			errs = errors.Append(errs, errors.Errorf("path glob %q must be relative to the repository root", pathGlob))
+			continue
+		}
+		if _, err := glob.Compile(pathGlob, pathGlobSeparator); err != nil {
+			errs = errors.Append(errs, errors.Errorf("invalid path glob %q: %v", pathGlob, err))
+		}
+	}
+	return errs
+}
+
+// Matcher matches paths of the parent repository against the path globs of a
+// view.
+type Matcher struct {
+	globs []glob.Glob
+	roots []string
+}
+
+// NewMatcher compiles the given path globs. `*` and `?` do not match path
+// separators, `**` matches any number of path segments.
+func NewMatcher(pathGlobs []string) (*Matcher, error) {
+	if err := ValidatePathGlobs(pathGlobs); err != nil {
+		return nil, err
+	}
+
+	globs := make([]glob.Glob, 0, len(pathGlobs))
+	for _, pathGlob := range pathGlobs {
+		g, err := glob.Compile(pathGlob, pathGlobSeparator)
+		if err != nil {
+			return nil, err
+		}
+		globs = append(globs, g)
+	}
+
+	return &Matcher{globs: globs, roots: Roots(pathGlobs)}, nil
+}
+
+// Match reports whether the file at the given path is part of the view.
+func (m *Matcher) Match(path string) bool {
+	for _, g := range m.globs {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsDir reports whether the given directory lies within one of the roots
+// of the view.
+func (m *Matcher) ContainsDir(dir string) bool {
+	dir = strings.Trim(dir, "/")
+	for _, root := range m.roots {
+		if root == "" || dir == root || strings.HasPrefix(dir, root+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Roots returns the deepest directories that contain all the paths matched by
+// the given path globs, e.g. client/web for client/web/**. Nested roots are
+// collapsed into their parents. The repository root is returned as "".
+func Roots(pathGlobs []string) []string {
+	candidates := make([]string, 0, len(pathGlobs))
+	for _, pathGlob := range pathGlobs {
+		literal := pathGlob
+		if i := strings.IndexAny(pathGlob, pathGlobMetaChars); i >= 0 {
+			literal = pathGlob[:i]
+		}
+		root := ""
+		if i := strings.LastIndexByte(literal, '/'); i >= 0 {
+			root = literal[:i]
+		}
+		if root == "" {
+			return []string{""}
+		}
+		candidates = append(candidates, root)
+	}
+
+	sort.Strings(candidates)
+	roots := candidates[:0]
+	for _, candidate := range candidates {
+		if n := len(roots); n > 0 && (candidate == roots[n-1] || strings.HasPrefix(candidate, roots[n-1]+"/")) {
+			continue
+		}
+		roots = append(roots, candidate)
+	}
+	return roots
+}
+
+// RepoRegexp returns the repo: filter value matching exactly the parent
+// repository with the given name.
+func RepoRegexp(name api.RepoName) string {
+	return "^" + regexp.QuoteMeta(string(name)) + "$"
+}
+
+// FileRegexp returns the file: filter value matching the paths that are part of
+// a view with the given path globs.
+func FileRegexp(pathGlobs []string) (string, error) {
+	if err := ValidatePathGlobs(pathGlobs); err != nil {
+		return "", err
+	}
+
+	exprs := make([]string, 0, len(pathGlobs))
+	for _, pathGlob := range pathGlobs {
+		tree, err := syntax.Parse(pathGlob)
+		if err != nil {
+			return "", err
+		}
+		exprs = append(exprs, globNodeToRegexp(tree))
+	}
+	return "^(?:" + strings.Join(exprs, "|") + ")$", nil
+}
+
+// globNodeToRegexp converts a parsed glob into an unanchored regular expression
+// with the same semantics as glob.Compile with a '/' separator.
+func globNodeToRegexp(node *ast.Node) string {
+	switch node.Kind {
+	case ast.KindPattern, ast.KindNothing:
+		var b strings.Builder
+		for _, child := range node.Children {
+			b.WriteString(globNodeToRegexp(child))
+		}
+		return b.String()
+	case ast.KindAny:
+		return "[^/]*"
+	case ast.KindSuper:
+		return ".*"
+	case ast.KindSingle:
+		return "[^/]"
+	case ast.KindText:
+		return regexp.QuoteMeta(node.Value.(ast.Text).Text)
+	case ast.KindAnyOf:
+		exprs := make([]string, 0, len(node.Children))
+		for _, child := range node.Children {
+			exprs = append(exprs, globNodeToRegexp(child))
+		}
+		return "(?:" + strings.Join(exprs, "|") + ")"
+	case ast.KindList:
+		list := node.Value.(ast.List)
+		if list.Not {
+			return "[^/" + regexp.QuoteMeta(list.Chars) + "]"
+		}
+		return "[" + regexp.QuoteMeta(list.Chars) + "]"
+	case ast.KindRange:
+		r := node.Value.(ast.Range)
+		if r.Not {
+			return "[^/" + regexp.QuoteMeta(string(r.Lo)) + "-" + regexp.QuoteMeta(string(r.Hi)) + "]"
+		}
+		return "[" + regexp.QuoteMeta(string(r.Lo)) + "-" + regexp.QuoteMeta(string(r.Hi)) + "]"
+	}
+	return ""
+}
+
+// Resolve returns the view with the given name and its parent repository.
+//
+// 🚨 SECURITY: The parent repository is resolved through the repo store, which
+// returns a not found error if the current user does not have access to it.
+func Resolve(ctx context.Context, db database.DB, name string) (*types.RepoView, *types.Repo, error) {
+	view, err := db.RepoViews().GetByName(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := db.Repos().Get(ctx, view.RepoID)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "repository view %q", name)
+	}
+
+	return view, repo, nil
+}
+
+// SearchFilters returns the repo: and file: filter values that restrict a search
+// to the view with the given name.
+func SearchFilters(ctx context.Context, db database.DB, name string) (repo string, file string, err error) {
+	view, parent, err := Resolve(ctx, db, name)
+	if err != nil {
+		return "", "", err
+	}
+
+	file, err = FileRegexp(view.PathGlobs)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "repository view %q", name)
+	}
+
+	return RepoRegexp(parent.Name), file, nil
+}
@@ -0,0 +1,95 @@
+package repoviews
+
+import (
+	"testing"
+
+	"github.com/grafana/regexp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"web", "mono/web", "web-app_v2.1"} {
+		assert.NoError(t, ValidateName(name), name)
+	}
+	for _, name := range []string{"", "web app", "web:app"} {
+		assert.Error(t, ValidateName(name), name)
+	}
+}
+
+func TestValidatePathGlobs(t *testing.T) {
+	assert.NoError(t, ValidatePathGlobs([]string{"client/web/**", "*.md"}))
+	assert.Error(t, ValidatePathGlobs(nil))
+	assert.Error(t, ValidatePathGlobs([]string{""}))
+	assert.Error(t, ValidatePathGlobs([]string{"/client/**"}))
+	assert.Error(t, ValidatePathGlobs([]string{"client/[web"}))
+}
+
+func TestMatcher(t *testing.T) {
+	m, err := NewMatcher([]string{"client/web/**", "lib/*.go", "doc/{admin,dev}/**"})
+	require.NoError(t, err)
+
+	for path, want := range map[string]bool{
+		"client/web/src/index.ts": true,
+		"client/shared/index.ts":  false,
+		"lib/errors.go":           true,
+		"lib/errors/errors.go":    false,
+		"doc/admin/index.md":      true,
+		"doc/user/index.md":       false,
+	} {
+		assert.Equal(t, want, m.Match(path), path)
+	}
+
+	for dir, want := range map[string]bool{
+		"client/web":     true,
+		"client/web/src": true,
+		"client":         false,
+		"client/webapp":  false,
+		"lib":            true,
+		"doc/admin":      true,
+		"doc":            true,
+	} {
+		assert.Equal(t, want, m.ContainsDir(dir), dir)
+	}
+}
+
+func TestRoots(t *testing.T) {
+	for _, test := range []struct {
+		globs []string
+		want  []string
+	}{
+		{globs: []string{"client/web/**"}, want: []string{"client/web"}},
+		{globs: []string{"client/web/**", "client/**", "lib/*.go"}, want: []string{"client", "lib"}},
+		{globs: []string{"client/web/**", "client/webapp/**"}, want: []string{"client/web", "client/webapp"}},
+		{globs: []string{"client/web/**", "**/*.md"}, want: []string{""}},
+		{globs: []string{"go.mod"}, want: []string{""}},
+	} {
+		assert.Equal(t, test.want, Roots(test.globs), test.globs)
+	}
+}
+
+func TestFileRegexp(t *testing.T) {
+	globs := []string{"client/web/**", "lib/*.go", "doc/{admin,dev}/**", "src/[!_]?.c"}
+	m, err := NewMatcher(globs)
+	require.NoError(t, err)
+	expr, err := FileRegexp(globs)
+	require.NoError(t, err)
+	re := regexp.MustCompile(expr)
+
+	// The regular expression used in search must agree with the matcher.
+	for _, path := range []string{
+		"client/web/src/index.ts",
+		"client/shared/index.ts",
+		"lib/errors.go",
+		"lib/errors/errors.go",
+		"doc/admin/index.md",
+		"doc/dev/index.md",
+		"doc/user/index.md",
+		"src/ab.c",
+		"src/_b.c",
+		"src/a/.c",
+		"xclient/web/index.ts",
+	} {
+		assert.Equal(t, m.Match(path), re.MatchString(path), path)
+	}
+}
@@ -22,9 +22,21 @@ type ConfigFingerprint struct {
 	hash uint64
 }
 
-// NewConfigFingerprint returns a ConfigFingerprint for the current time and sc.
-func NewConfigFingerprint(sc *schema.SiteConfiguration) (*ConfigFingerprint, error) {
-	hash, err := hashstructure.Hash(sc, nil)
+// NewConfigFingerprint returns a ConfigFingerprint for the current time, sc
+// and the branch indexing rules.
+func NewConfigFingerprint(sc *schema.SiteConfiguration, branchRules ...IndexBranchRule) (*ConfigFingerprint, error) {
+	var v any = sc
+	if len(branchRules) > 0 {
+		// Branch indexing rules affect many repositories at once, so treat
+		// them like site configuration. Without rules we hash sc alone so
+		// that fingerprints stay stable across upgrades.
+		v = struct {
+			SiteConfig  *schema.SiteConfiguration
+			BranchRules []IndexBranchRule
+		}{sc, branchRules}
+	}
+
+	hash, err := hashstructure.Hash(v, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +154,15 @@ func TestConfigFingerprint(t *testing.T) {
 	if cfA.sameConfig(cfC) {
 		t.Fatal("expected different config for A and C")
 	}
+
+	// Branch indexing rules are part of the configuration
+	cfD, err := NewConfigFingerprint(sc1, IndexBranchRule{RepoPattern: "foo", Branches: []string{"dev"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfA.sameConfig(cfD) {
+		t.Fatal("expected different config for A and D")
+	}
 }
 
 func TestSiteConfigFingerprint_RoundTrip(t *testing.T) {
@@ -154,6 +154,10 @@ type RepoIndexOptions struct {
 	// error is encoded in the body. If the revision is missing, an empty
 	// string should be returned rather than an error.
 	GetVersion func(branch string) (string, error)
+
+	// Branches are additional branches configured for this repository, for
+	// example via repo metadata or branch indexing rules.
+	Branches []string
 }
 
 type getRepoIndexOptsFn func(repoID api.RepoID) (*RepoIndexOptions, error)
@@ -215,7 +219,7 @@ func getIndexOptions(
 		Symbols:    getBoolPtr(c.SearchIndexSymbolsEnabled, true),
 
 		DocumentRanksVersion: opts.DocumentRanksVersion,
-		LanguageMap:          ctags_config.CreateEngineMap(*c),
+		LanguageMap:          zoektEngineMap(*c),
 		ShardConcurrency:     int32(c.SearchIndexShardConcurrency),
 	}
 
@@ -229,6 +233,11 @@ func getIndexOptions(
 		}
 	}
 
+	// Add all branches that are configured for the repository.
+	for _, rev := range opts.Branches {
+		branches[rev] = struct{}{}
+	}
+
 	// Add all branches that are referenced by search contexts
 	revs, err := getSearchContextRevisions(opts.RepoID)
 	if err != nil {
@@ -289,6 +298,41 @@ func getIndexOptions(
 
 type revsRuleFunc func(*RepoIndexOptions) (revs []string)
 
+// IndexBranchRule configures additional branches to index for all
+// repositories whose name matches the regular expression RepoPattern.
+type IndexBranchRule struct {
+	RepoPattern string
+	Branches    []string
+}
+
+// IndexBranchRulesFunc returns a function which returns the branches of all
+// rules matching a repository name. Rules with invalid patterns are skipped.
+func IndexBranchRulesFunc(rules []IndexBranchRule) func(repoName string) []string {
+	type compiledRule struct {
+		pattern  *regexp.Regexp
+		branches []string
+	}
+
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.RepoPattern)
+		if err != nil {
+			log15.Error("error compiling regex from search index branch rule", "regex", rule.RepoPattern, "err", err)
+			continue
+		}
+		compiled = append(compiled, compiledRule{pattern: pattern, branches: rule.Branches})
+	}
+
+	return func(repoName string) (matched []string) {
+		for _, rule := range compiled {
+			if rule.pattern.MatchString(repoName) {
+				matched = append(matched, rule.branches...)
+			}
+		}
+		return matched
+	}
+}
+
 func siteConfigRevisionsRuleFunc(c *schema.SiteConfiguration) revsRuleFunc {
 	if c == nil || c.ExperimentalFeatures == nil {
 		return nil
@@ -335,3 +379,13 @@ func getBoolPtr(b *bool, default_ bool) bool {
 	}
 	return *b
 }
+
+// zoektEngineMap returns the symbols engine Zoekt should use for each language,
+// replacing engines that only the symbols service supports.
+func zoektEngineMap(c schema.SiteConfiguration) map[string]ctags_config.ParserType {
+	engines := ctags_config.CreateEngineMap(c)
+	for lang, engine := range engines {
+		engines[lang] = ctags_config.ZoektEngine(lang, engine)
+	}
+	return engines
+}
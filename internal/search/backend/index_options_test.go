@@ -461,3 +461,22 @@ func TestGetIndexOptions_concurrency(t *testing.T) {
 		}
 	}
 }
+
+func TestIndexBranchRulesFunc(t *testing.T) {
+	getBranches := IndexBranchRulesFunc([]IndexBranchRule{
+		{RepoPattern: "^github\\.com/sourcegraph/", Branches: []string{"release/5.2"}},
+		{RepoPattern: "(", Branches: []string{"invalid"}},
+		{RepoPattern: "zoekt$", Branches: []string{"dev", "qa"}},
+	})
+
+	cases := map[string][]string{
+		"github.com/sourcegraph/sourcegraph": {"release/5.2"},
+		"github.com/sourcegraph/zoekt":       {"release/5.2", "dev", "qa"},
+		"github.com/golang/go":               nil,
+	}
+	for repoName, want := range cases {
+		if diff := cmp.Diff(want, getBranches(repoName)); diff != "" {
+			t.Errorf("unexpected branches for %s (-want, +got):\n%s", repoName, diff)
+		}
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/repoviews"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
@@ -118,10 +119,22 @@ func (s *searchClient) Plan(
 		return sc.Query, nil
 	})
 
+	// Replace each view in the query, including views referenced by search context
+	// queries, with the repository and file filters that define it.
+	substituteRepoViewsStep := query.SubstituteRepoViews(func(name string) (string, string, error) {
+		repo, file, err := repoviews.SearchFilters(ctx, s.runtimeClients.DB, name)
+		if err != nil {
+			return "", "", err
+		}
+		tr.AddEvent("substituted view filter with repo and file filters", attribute.String("view", name), attribute.String("repo", repo), attribute.String("file", file))
+		return repo, file, nil
+	})
+
 	var plan query.Plan
 	plan, err = query.Pipeline(
 		query.Init(searchQuery, searchType),
 		query.With(searchContextsQueryEnabled, substituteContextsStep),
+		substituteRepoViewsStep,
 	)
 	if err != nil {
 		return nil, &QueryError{Query: searchQuery, Err: err}
@@ -19,6 +19,7 @@ const (
 	FieldVisibility         = "visibility"
 	FieldRev                = "rev"
 	FieldContext            = "context"
+	FieldView               = "view"
 
 	// For diff and commit search only:
 	FieldBefore    = "before"
@@ -40,6 +41,7 @@ var allFields = map[string]struct{}{
 	FieldRepo:               empty,
 	"r":                     empty,
 	FieldContext:            empty,
+	FieldView:               empty,
 	"g":                     empty,
 	FieldFile:               empty,
 	"f":                     empty,
@@ -88,6 +88,39 @@ func SubstituteSearchContexts(lookupQueryString func(contextValue string) (strin
 	}
 }
 
+// SubstituteRepoViews substitutes terms of the form `view:name` for the
+// repository and file filters that define the repository view, like
+// (repo:^github\.com/org/monorepo$ file:^(?:client/web/.*)$). It relies on a
+// lookup function, which should return the repo: and file: values for some view
+// name.
+func SubstituteRepoViews(lookupFilters func(name string) (repo string, file string, err error)) step {
+	return func(nodes []Node) ([]Node, error) {
+		var errs error
+		substitutedViews := MapField(nodes, FieldView, func(value string, negated bool, ann Annotation) Node {
+			if negated {
+				errs = errors.Append(errs, errors.Errorf("field %q does not support negation", FieldView))
+				return nil
+			}
+
+			repo, file, err := lookupFilters(value)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				return nil
+			}
+
+			return Operator{
+				Kind: And,
+				Operands: []Node{
+					Parameter{Field: FieldRepo, Value: repo, Annotation: ann},
+					Parameter{Field: FieldFile, Value: file, Annotation: ann},
+				},
+			}
+		})
+
+		return substitutedViews, errs
+	}
+}
+
 // For runs processing steps for a given search type. This includes
 // normalization, substitution for whitespace, and pattern labeling.
 func For(searchType SearchType) step {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestPipelineStructural(t *testing.T) {
@@ -44,3 +46,25 @@ func TestSubstituteSearchContexts(t *testing.T) {
 		autogold.ExpectFile(t, autogold.Raw(test("context:gordo repo:contains.path(gordo)", true)))
 	})
 }
+
+func TestSubstituteRepoViews(t *testing.T) {
+	test := func(input string) string {
+		lookup := func(name string) (string, string, error) {
+			if name != "web" {
+				return "", "", errors.Errorf("repository view %q not found", name)
+			}
+			return `^github\.com/org/monorepo$`, `^(?:client/web/.*)$`, nil
+		}
+		plan, err := Pipeline(InitLiteral(input), SubstituteRepoViews(lookup))
+		if err != nil {
+			return err.Error()
+		}
+		return plan.ToQ().String()
+	}
+
+	autogold.Expect(`(and "repo:^github\\.com/org/monorepo$" "file:^(?:client/web/.*)$" "foo")`).Equal(t, test("view:web foo"))
+	autogold.Expect(`(and "repo:^github\\.com/org/monorepo$" "file:^(?:client/web/.*)$" "file:index" "foo")`).Equal(t, test("view:web file:index foo"))
+	autogold.Expect(`repository view "missing" not found`).Equal(t, test("view:missing foo"))
+	autogold.Expect(`field "view" does not support negation`).Equal(t, test("-view:web foo"))
+	autogold.Expect(`(and "repo:^github\\.com/org/monorepo$" "file:^(?:client/web/.*)$" "repo:^github\\.com/org/monorepo$" "file:^(?:client/web/.*)$" "foo")`).Equal(t, test("view:web view:web foo"))
+}
@@ -249,6 +249,9 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldContext:
 		return satisfies(isSingular, isNotNegated)
+	case
+		FieldView:
+		return satisfies(isSingular, isNotNegated)
 	case
 		FieldFile:
 		return satisfies(isValidRegexp)
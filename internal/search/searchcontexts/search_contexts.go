@@ -232,6 +232,7 @@ func validateSearchContextQuery(contextQuery string) error {
 		case query.FieldCase:
 		case query.FieldFile:
 		case query.FieldLang:
+		case query.FieldView:
 
 		default:
 			errs = errors.Append(errs,
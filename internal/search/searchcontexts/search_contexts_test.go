@@ -763,6 +763,15 @@ func Test_validateSearchContextQuery(t *testing.T) {
 	}, {
 		query:   "visibility:public",
 		wantErr: false,
+	}, {
+		query:   "view:web",
+		wantErr: false,
+	}, {
+		query:   "view:web or view:docs",
+		wantErr: false,
+	}, {
+		query:   "-view:web",
+		wantErr: true,
 	}, {
 		query:   "type:commit author:camden",
 		wantErr: true,
@@ -0,0 +1,239 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// activeSessionsStore indexes the sessions of each user, so that they can be
+// listed and revoked individually. Every session cookie refers to an entry in
+// this index, and sessions without an entry are rejected.
+var activeSessionsStore = redispool.Store
+
+// ActiveSession describes a session of a user that has not expired or been
+// revoked.
+type ActiveSession struct {
+	ID         string    `json:"-"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastActive time.Time `json:"lastActive"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	// IP and UserAgent are those of the last request that renewed the session.
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+// SessionNotFoundErr is returned when revoking a session that does not exist.
+type SessionNotFoundErr struct {
+	userID    int32
+	sessionID string
+}
+
+func (err SessionNotFoundErr) Error() string {
+	return fmt.Sprintf("session not found: user_id=%d, id=%q", err.userID, err.sessionID)
+}
+
+func (SessionNotFoundErr) NotFound() bool {
+	return true
+}
+
+func activeSessionsKey(userID int32) string {
+	return "active_sessions:" + strconv.Itoa(int(userID))
+}
+
+// ListActiveSessions returns the active sessions of the given user, most
+// recently active first.
+func ListActiveSessions(userID int32) ([]*ActiveSession, error) {
+	all, err := getActiveSessions(activeSessionsKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sessions := make([]*ActiveSession, 0, len(all))
+	for id, data := range all {
+		var s ActiveSession
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			return nil, errors.Wrapf(err, "decoding session %q", id)
+		}
+		if s.ExpiresAt.Before(now) {
+			continue
+		}
+		s.ID = id
+		sessions = append(sessions, &s)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActive.After(sessions[j].LastActive)
+	})
+	return sessions, nil
+}
+
+// getActiveSessions returns the encoded sessions in the index at key by ID.
+func getActiveSessions(key string) (map[string]string, error) {
+	all, err := activeSessionsStore.HGetAll(key).StringMap()
+	if err == redis.ErrNil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "listing sessions")
+	}
+	return all, nil
+}
+
+// RevokeSession revokes the session with the given ID of the given user. The
+// next request that uses the session is unauthenticated.
+func RevokeSession(userID int32, sessionID string) error {
+	removed, err := activeSessionsStore.HDel(activeSessionsKey(userID), sessionID).Int()
+	if err != nil {
+		return errors.Wrap(err, "revoking session")
+	}
+	if removed == 0 {
+		return SessionNotFoundErr{userID: userID, sessionID: sessionID}
+	}
+	return nil
+}
+
+// revokeAllSessions removes the sessions of the given users from the index. It
+// is called after invalidating the sessions in the database, which also rejects
+// sessions that are not indexed yet.
+func revokeAllSessions(userIDs ...int32) error {
+	for _, id := range userIDs {
+		if err := activeSessionsStore.Del(activeSessionsKey(id)); err != nil {
+			return errors.Wrap(err, "revoking sessions")
+		}
+	}
+	return nil
+}
+
+// isActiveSession reports whether the session with the given ID of the given
+// user is still in the index.
+func isActiveSession(userID int32, sessionID string) (bool, error) {
+	_, err := activeSessionsStore.HGet(activeSessionsKey(userID), sessionID).Bytes()
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "checking session")
+	}
+	return true, nil
+}
+
+// newSessionID returns a new random session ID.
+func newSessionID() string {
+	return uuid.NewString()
+}
+
+// recordActiveSession adds or updates the session of info in the index, using
+// the client of the request r.
+func recordActiveSession(r *http.Request, info *sessionInfo) error {
+	data, err := encodeActiveSession(r, info)
+	if err != nil {
+		return err
+	}
+
+	key := activeSessionsKey(info.Actor.UID)
+	if err := activeSessionsStore.HSet(key, info.ID, data); err != nil {
+		return errors.Wrap(err, "recording session")
+	}
+	return pruneActiveSessions(key)
+}
+
+// renewActiveSessionScript updates a session in the index only if it is still
+// there, so that renewing a session can't restore it after it was revoked.
+var renewActiveSessionScript = redis.NewScript(1, `
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 1 then
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// renewActiveSession updates the session of info in the index, using the
+// client of the request r. It reports false, and doesn't update the index, if
+// the session was revoked.
+func renewActiveSession(r *http.Request, info *sessionInfo) (bool, error) {
+	data, err := encodeActiveSession(r, info)
+	if err != nil {
+		return false, err
+	}
+
+	key := activeSessionsKey(info.Actor.UID)
+	var renewed bool
+	if pool, ok := activeSessionsStore.Pool(); ok {
+		c := pool.Get()
+		defer c.Close()
+		renewed, err = redis.Bool(renewActiveSessionScript.Do(c, key, info.ID, data))
+		if err != nil {
+			return false, errors.Wrap(err, "renewing session")
+		}
+	} else {
+		// Without Redis, the check and the update can't be atomic.
+		if renewed, err = isActiveSession(info.Actor.UID, info.ID); err != nil || !renewed {
+			return false, err
+		}
+		if err := activeSessionsStore.HSet(key, info.ID, data); err != nil {
+			return false, errors.Wrap(err, "renewing session")
+		}
+	}
+	if !renewed {
+		return false, nil
+	}
+	return true, pruneActiveSessions(key)
+}
+
+// encodeActiveSession returns the index entry of the session of info, using
+// the client of the request r.
+func encodeActiveSession(r *http.Request, info *sessionInfo) ([]byte, error) {
+	s := ActiveSession{
+		CreatedAt:  info.CreatedAt,
+		LastActive: info.LastActive,
+		ExpiresAt:  info.LastActive.Add(info.ExpiryPeriod),
+	}
+	if client := requestclient.FromContext(r.Context()); client != nil {
+		s.IP = client.IP
+		s.UserAgent = client.UserAgent
+	} else {
+		s.IP, _, _ = net.SplitHostPort(r.RemoteAddr)
+		s.UserAgent = r.UserAgent()
+	}
+	return json.Marshal(s)
+}
+
+// pruneActiveSessions removes expired sessions from the index at key and makes
+// the index expire together with its last session.
+func pruneActiveSessions(key string) error {
+	all, err := getActiveSessions(key)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var last time.Time
+	for id, data := range all {
+		var s ActiveSession
+		if err := json.Unmarshal([]byte(data), &s); err != nil || s.ExpiresAt.Before(now) {
+			if _, err := activeSessionsStore.HDel(key, id).Int(); err != nil {
+				return errors.Wrap(err, "pruning sessions")
+			}
+			continue
+		}
+		if s.ExpiresAt.After(last) {
+			last = s.ExpiresAt
+		}
+	}
+	if last.IsZero() {
+		return nil
+	}
+	return activeSessionsStore.Expire(key, int(last.Sub(now).Seconds())+1)
+}
@@ -66,6 +66,9 @@ func init() {
 // sessionInfo is the information we store in the session. The gorilla/sessions library doesn't appear to
 // enforce the maxAge field in its session store implementations, so we include the expiry here.
 type sessionInfo struct {
+	// ID identifies the session in the index of active sessions.
+	ID            string        `json:"id,omitempty"`
+	CreatedAt     time.Time     `json:"createdAt,omitempty"`
 	Actor         *actor.Actor  `json:"actor"`
 	LastActive    time.Time     `json:"lastActive"`
 	ExpiryPeriod  time.Duration `json:"expiryPeriod"`
@@ -267,6 +270,10 @@ func SetActorFromUser(ctx context.Context, w http.ResponseWriter, r *http.Reques
 //
 // If expiryPeriod is 0, the default expiry period is used.
 func SetActor(w http.ResponseWriter, r *http.Request, actor *actor.Actor, expiryPeriod time.Duration, userCreatedAt time.Time) error {
+	// SetData reuses the session cookie of the request, so the session it
+	// previously referred to ends here.
+	forgetCurrentSession(r)
+
 	var value *sessionInfo
 	if actor != nil {
 		if expiryPeriod == 0 {
@@ -278,11 +285,28 @@ func SetActor(w http.ResponseWriter, r *http.Request, actor *actor.Actor, expiry
 		}
 		RemoveSignOutCookieIfSet(r, w)
 
-		value = &sessionInfo{Actor: actor, ExpiryPeriod: expiryPeriod, LastActive: time.Now(), UserCreatedAt: userCreatedAt}
+		now := time.Now()
+		value = &sessionInfo{ID: newSessionID(), CreatedAt: now, Actor: actor, ExpiryPeriod: expiryPeriod, LastActive: now, UserCreatedAt: userCreatedAt}
+		if err := recordActiveSession(r, value); err != nil {
+			return err
+		}
 	}
 	return SetData(w, r, "actor", value)
 }
 
+// forgetCurrentSession removes the session of the request, if any, from the
+// index of active sessions.
+func forgetCurrentSession(r *http.Request) {
+	if !hasSessionCookie(r) {
+		return
+	}
+	var info *sessionInfo
+	if err := GetData(r, "actor", &info); err != nil || info == nil || info.Actor == nil || info.ID == "" {
+		return
+	}
+	_ = RevokeSession(info.Actor.UID, info.ID)
+}
+
 // RemoveSignOutCookieIfSet removes the sign-out cookie if it is set.
 func RemoveSignOutCookieIfSet(r *http.Request, w http.ResponseWriter) {
 	if HasSignOutCookie(r) {
@@ -334,6 +358,9 @@ func InvalidateSessionCurrentUser(w http.ResponseWriter, r *http.Request, db dat
 	if err != nil {
 		return err
 	}
+	if err := revokeAllSessions(a.UID); err != nil {
+		return err
+	}
 
 	// We make sure the session is actually removed from the client and from Redis
 	// because SetData actually reuses the client session cookie if it exists.
@@ -343,7 +370,10 @@ func InvalidateSessionCurrentUser(w http.ResponseWriter, r *http.Request, db dat
 
 // InvalidateSessionsByIDs is a bulk action.
 func InvalidateSessionsByIDs(ctx context.Context, db database.DB, ids []int32) error {
-	return db.Users().InvalidateSessionsByIDs(ctx, ids)
+	if err := db.Users().InvalidateSessionsByIDs(ctx, ids); err != nil {
+		return err
+	}
+	return revokeAllSessions(ids...)
 }
 
 // CookieMiddleware is an http.Handler middleware that authenticates
@@ -496,9 +526,42 @@ func authenticateByCookie(logger log.Logger, db database.DB, r *http.Request, w
 			return ctx
 		}
 
+		// Check that the session was not revoked. Sessions created before
+		// sessions were indexed are added to the index.
+		if info.ID == "" {
+			info.ID = newSessionID()
+			info.CreatedAt = info.LastActive
+			if err := recordActiveSession(r, info); err != nil {
+				logger.Error("error indexing session", log.Error(err))
+				return ctx
+			}
+			if err := SetData(w, r, "actor", info); err != nil {
+				logger.Error("error setting session ID", log.Error(err))
+				return ctx
+			}
+		} else if active, err := isActiveSession(info.Actor.UID, info.ID); err != nil {
+			// Don't delete session, since the error might be ephemeral.
+			logger.Error("error checking whether session is active", log.Error(err))
+			span.SetError(err)
+			return ctx
+		} else if !active {
+			span.SetAttributes(attribute.Bool("revoked", true))
+			_ = deleteSession(w, r) // Delete the revoked session
+			return ctx
+		}
+
 		// Renew session
 		if time.Since(info.LastActive) > 5*time.Minute {
 			info.LastActive = time.Now()
+			if renewed, err := renewActiveSession(r, info); err != nil {
+				logger.Error("error renewing session", log.Error(err))
+				return ctx
+			} else if !renewed {
+				// The session was revoked since we checked it above.
+				span.SetAttributes(attribute.Bool("revoked", true))
+				_ = deleteSession(w, r)
+				return ctx
+			}
 			if err := SetData(w, r, "actor", info); err != nil {
 				logger.Error("error renewing session", log.Error(err))
 				return ctx
@@ -565,3 +565,133 @@ func TestExpiredLicenseOnlyAllowsAdmins(t *testing.T) {
 		})
 	}
 }
+
+func TestRevokeSession(t *testing.T) {
+	logger := logtest.Scoped(t)
+
+	oldLicenseMock := licensing.MockGetConfiguredProductLicenseInfo
+	licensing.MockGetConfiguredProductLicenseInfo = nil
+	t.Cleanup(func() { licensing.MockGetConfiguredProductLicenseInfo = oldLicenseMock })
+
+	cleanup := ResetMockSessionStore(t)
+	defer cleanup()
+
+	userCreatedAt := time.Now()
+
+	users := dbmocks.NewStrictMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, CreatedAt: userCreatedAt}, nil
+	})
+
+	db := dbmocks.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	signIn := func(userAgent string) *http.Request {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("User-Agent", userAgent)
+		if err := SetActor(w, req, &actor.Actor{UID: 123}, time.Hour, userCreatedAt); err != nil {
+			t.Fatal(err)
+		}
+		authedReq := httptest.NewRequest("GET", "/", nil)
+		for _, cookie := range w.Result().Cookies() {
+			authedReq.AddCookie(cookie)
+		}
+		return authedReq
+	}
+	authenticated := func(req *http.Request) bool {
+		return actor.FromContext(authenticateByCookie(logger, db, req, httptest.NewRecorder())).IsAuthenticated()
+	}
+
+	laptop := signIn("laptop")
+	phone := signIn("phone")
+	if !authenticated(laptop) || !authenticated(phone) {
+		t.Fatal("expected both sessions to be authenticated")
+	}
+
+	sessions, err := ListActiveSessions(123)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 active sessions, got %d", len(sessions))
+	}
+
+	var laptopID string
+	for _, s := range sessions {
+		if s.UserAgent == "laptop" {
+			laptopID = s.ID
+		}
+		if s.IP != "192.0.2.1" {
+			t.Errorf("unexpected IP %q", s.IP)
+		}
+	}
+	if laptopID == "" {
+		t.Fatal("laptop session not found")
+	}
+
+	if err := RevokeSession(123, laptopID); err != nil {
+		t.Fatal(err)
+	}
+	if authenticated(laptop) {
+		t.Error("revoked session is still authenticated")
+	}
+	if !authenticated(phone) {
+		t.Error("other session was revoked")
+	}
+	if err := RevokeSession(123, laptopID); !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	// Signing out removes the session from the index.
+	if err := SetActor(httptest.NewRecorder(), phone, nil, 0, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err = ListActiveSessions(123)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("expected no active sessions, got %d", len(sessions))
+	}
+}
+
+func TestRenewRevokedSession(t *testing.T) {
+	cleanup := ResetMockSessionStore(t)
+	defer cleanup()
+
+	req := httptest.NewRequest("GET", "/", nil)
+	info := &sessionInfo{
+		Actor:        &actor.Actor{UID: 123},
+		ID:           newSessionID(),
+		CreatedAt:    time.Now(),
+		LastActive:   time.Now(),
+		ExpiryPeriod: time.Hour,
+	}
+	if err := recordActiveSession(req, info); err != nil {
+		t.Fatal(err)
+	}
+
+	info.LastActive = time.Now()
+	if renewed, err := renewActiveSession(req, info); err != nil {
+		t.Fatal(err)
+	} else if !renewed {
+		t.Fatal("expected active session to be renewed")
+	}
+
+	if err := RevokeSession(123, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renewing a session that was revoked concurrently doesn't restore it.
+	if renewed, err := renewActiveSession(req, info); err != nil {
+		t.Fatal(err)
+	} else if renewed {
+		t.Fatal("expected revoked session not to be renewed")
+	}
+	if active, err := isActiveSession(123, info.ID); err != nil {
+		t.Fatal(err)
+	} else if active {
+		t.Fatal("revoked session was restored")
+	}
+}
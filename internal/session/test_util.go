@@ -6,6 +6,8 @@ import (
 
 	"github.com/gorilla/securecookie"
 	"github.com/gorilla/sessions"
+
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 )
 
 func ResetMockSessionStore(t *testing.T) (cleanup func()) {
@@ -22,7 +24,10 @@ func ResetMockSessionStore(t *testing.T) (cleanup func()) {
 	}()
 
 	SetSessionStore(sessions.NewFilesystemStore(tempdir, securecookie.GenerateRandomKey(2048)))
+	prevActiveSessionsStore := activeSessionsStore
+	activeSessionsStore = redispool.MemoryKeyValue()
 	return func() {
 		os.RemoveAll(tempdir)
+		activeSessionsStore = prevActiveSessionsStore
 	}
 }
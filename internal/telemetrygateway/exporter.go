@@ -9,6 +9,8 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -21,7 +23,18 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
+var droppedEventsCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "src",
+	Subsystem: "telemetrygatewayexporter",
+	Name:      "dropped_events",
+	Help:      "Number of events dropped by the export rules instead of being exported.",
+})
+
 type Exporter interface {
+	// ExportEvents exports the given events, applying the site-configured
+	// export rules first, and returns the IDs of the events that no longer
+	// need to be exported: events that were exported successfully, and events
+	// that were dropped by the export rules.
 	ExportEvents(context.Context, []*telemetrygatewayv1.Event) ([]string, error)
 	Close() error
 }
@@ -72,10 +85,21 @@ func (e *exporter) ExportEvents(ctx context.Context, events []*telemetrygatewayv
 	tr, ctx := trace.New(ctx, "ExportEvents", attribute.Int("events", len(events)))
 	defer tr.End()
 
+	// Export rules are read from the site configuration on each export, so
+	// that changes apply without a restart.
+	events, dropped := exportRules(e.conf.SiteConfig().TelemetryExportRules).apply(events)
+	if len(dropped) > 0 {
+		tr.AddEvent("applied export rules", attribute.Int("dropped", len(dropped)))
+		droppedEventsCounter.Add(float64(len(dropped)))
+	}
+	if len(events) == 0 {
+		return dropped, nil
+	}
+
 	identifier, err := newIdentifier(ctx, e.conf, e.globalState)
 	if err != nil {
 		tr.SetError(err)
-		return nil, err
+		return dropped, err
 	}
 
 	var requestID string
@@ -86,6 +110,7 @@ func (e *exporter) ExportEvents(ctx context.Context, events []*telemetrygatewayv
 	}
 
 	succeeded, err := e.doExportEvents(ctx, requestID, identifier, events)
+	succeeded = append(dropped, succeeded...)
 	if err != nil {
 		tr.SetError(err)
 		// Surface request ID to help us correlate log entries more easily on
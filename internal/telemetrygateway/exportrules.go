@@ -0,0 +1,90 @@
+package telemetrygateway
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"strings"
+
+	telemetrygatewayv1 "github.com/sourcegraph/sourcegraph/internal/telemetrygateway/v1"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// exportRules are the site-configured rules applied to events before export,
+// see "telemetry.exportRules" in the site configuration.
+type exportRules []*schema.TelemetryExportRule
+
+// apply applies the first matching rule to each event, and returns the events
+// to export along with the IDs of the events that were dropped. Dropped events
+// must still be marked as exported so that they leave the export queue.
+//
+// 🚨 SECURITY: Redaction mutates the given events in place.
+func (rules exportRules) apply(events []*telemetrygatewayv1.Event) (export []*telemetrygatewayv1.Event, dropped []string) {
+	if len(rules) == 0 {
+		return events, nil
+	}
+	export = make([]*telemetrygatewayv1.Event, 0, len(events))
+	for _, event := range events {
+		rule := rules.match(event)
+		if rule == nil {
+			export = append(export, event)
+			continue
+		}
+		if rule.Drop || !sampled(event.GetId(), rule.SampleRate) {
+			dropped = append(dropped, event.GetId())
+			continue
+		}
+		redactMetadata(event, rule.RedactMetadata)
+		export = append(export, event)
+	}
+	return export, dropped
+}
+
+// match returns the first rule matching the event, or nil.
+func (rules exportRules) match(event *telemetrygatewayv1.Event) *schema.TelemetryExportRule {
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		if matchesPattern(rule.Feature, event.GetFeature()) && matchesPattern(rule.Action, event.GetAction()) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// matchesPattern reports whether value matches pattern. An empty pattern or
+// "*" matches everything, and a trailing "*" matches by prefix.
+func matchesPattern(pattern, value string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(value, prefix)
+	}
+	return pattern == "" || pattern == value
+}
+
+// sampled reports whether the event with the given ID is kept when sampling at
+// the given rate. The decision is derived from the event ID so that an event
+// whose export is retried gets the same decision. A rate of 0, meaning unset,
+// keeps all events.
+func sampled(eventID string, rate float64) bool {
+	if rate <= 0 || rate >= 1 {
+		return true
+	}
+	sum := sha256.Sum256([]byte(eventID))
+	return float64(binary.BigEndian.Uint64(sum[:8]))/math.MaxUint64 < rate
+}
+
+// redactMetadata removes the given keys from all the metadata of the event.
+func redactMetadata(event *telemetrygatewayv1.Event, keys []string) {
+	params := event.GetParameters()
+	if params == nil || len(keys) == 0 {
+		return
+	}
+	for _, key := range keys {
+		delete(params.Metadata, key)
+		delete(params.LegacyMetadata, key)
+		if params.PrivateMetadata != nil {
+			delete(params.PrivateMetadata.Fields, key)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package telemetrygateway
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	telemetrygatewayv1 "github.com/sourcegraph/sourcegraph/internal/telemetrygateway/v1"
+)
+
+func TestExportRules(t *testing.T) {
+	newEvent := func(id, feature, action string) *telemetrygatewayv1.Event {
+		private, err := structpb.NewStruct(map[string]any{"query": "secret", "kept": "value"})
+		require.NoError(t, err)
+		return &telemetrygatewayv1.Event{
+			Id:      id,
+			Feature: feature,
+			Action:  action,
+			Parameters: &telemetrygatewayv1.EventParameters{
+				Metadata:        map[string]float64{"resultCount": 3, "durationMs": 10},
+				LegacyMetadata:  map[string]int64{"resultCount": 3},
+				PrivateMetadata: private,
+			},
+		}
+	}
+	ids := func(events []*telemetrygatewayv1.Event) []string {
+		var ids []string
+		for _, e := range events {
+			ids = append(ids, e.GetId())
+		}
+		return ids
+	}
+
+	t.Run("no rules", func(t *testing.T) {
+		events := []*telemetrygatewayv1.Event{newEvent("1", "search", "submitted")}
+		export, dropped := exportRules(nil).apply(events)
+		assert.Equal(t, events, export)
+		assert.Empty(t, dropped)
+	})
+
+	t.Run("first matching rule applies", func(t *testing.T) {
+		rules := exportRules{
+			{Feature: "blob", Action: "viewed", Drop: true},
+			{Feature: "search.*", RedactMetadata: []string{"resultCount", "query"}},
+			{Feature: "*", Action: "submitted", Drop: true},
+		}
+		export, dropped := rules.apply([]*telemetrygatewayv1.Event{
+			newEvent("1", "blob", "viewed"),
+			newEvent("2", "blob", "clicked"),
+			newEvent("3", "search.results", "submitted"),
+			newEvent("4", "cody.chat", "submitted"),
+		})
+		assert.Equal(t, []string{"2", "3"}, ids(export))
+		assert.Equal(t, []string{"1", "4"}, dropped)
+
+		// Unmatched events are untouched.
+		assert.Equal(t, map[string]float64{"resultCount": 3, "durationMs": 10}, export[0].Parameters.Metadata)
+
+		// Matched events are redacted.
+		params := export[1].Parameters
+		assert.Equal(t, map[string]float64{"durationMs": 10}, params.Metadata)
+		assert.Empty(t, params.LegacyMetadata)
+		assert.Equal(t, map[string]any{"kept": "value"}, params.PrivateMetadata.AsMap())
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		rules := exportRules{{Feature: "cody.completions", SampleRate: 0.25}}
+		var events []*telemetrygatewayv1.Event
+		for i := 0; i < 1000; i++ {
+			events = append(events, newEvent(fmt.Sprintf("event-%d", i), "cody.completions", "suggested"))
+		}
+		export, dropped := rules.apply(events)
+		assert.Len(t, dropped, len(events)-len(export))
+		assert.InDelta(t, 250, len(export), 50)
+
+		// The decision is stable across retries.
+		again, _ := rules.apply(events)
+		assert.Equal(t, ids(export), ids(again))
+	})
+}
+
+func TestMatchesPattern(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{pattern: "", value: "anything", want: true},
+		{pattern: "*", value: "anything", want: true},
+		{pattern: "cody.chat", value: "cody.chat", want: true},
+		{pattern: "cody.chat", value: "cody.chatter", want: false},
+		{pattern: "cody.*", value: "cody.chat", want: true},
+		{pattern: "cody.*", value: "search", want: false},
+	} {
+		assert.Equal(t, tc.want, matchesPattern(tc.pattern, tc.value), "%q matches %q", tc.pattern, tc.value)
+	}
+}
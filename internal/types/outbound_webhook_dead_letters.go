@@ -0,0 +1,24 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+)
+
+// OutboundWebhookDeadLetter is a payload that could not be delivered to an
+// outbound webhook within the maximum number of attempts.
+type OutboundWebhookDeadLetter struct {
+	ID                int64
+	OutboundWebhookID int64
+	JobID             int64
+	EventType         string
+	Scope             *string
+	Payload           *encryption.Encryptable
+	Attempts          int
+	LastStatusCode    int
+	LastError         *encryption.Encryptable
+	CreatedAt         time.Time
+	ReplayedAt        *time.Time
+	ReplayJobID       *int64
+}
@@ -15,6 +15,10 @@ type OutboundWebhookJob struct {
 	Scope     *string
 	Payload   *encryption.Encryptable
 
+	// OutboundWebhookID is set if the payload is only sent to one outbound
+	// webhook, e.g. when a dead letter is replayed.
+	OutboundWebhookID *int64
+
 	State           string
 	FailureMessage  *string
 	QueuedAt        time.Time
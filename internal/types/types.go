@@ -1987,6 +1987,23 @@ type SearchContextRepositoryRevisions struct {
 	Revisions []string
 }
 
+// RepoView is a virtual repository defined by path globs over a parent
+// repository, e.g. the client/web/** subtree of a monorepo. Views do not
+// duplicate any git data: they are resolved to their parent repository and a
+// path filter wherever they are used.
+type RepoView struct {
+	ID          int32
+	Name        string
+	Description string
+	RepoID      api.RepoID
+	// PathGlobs are the globs matching the paths of the parent repository that
+	// are part of the view. `*` does not match path separators, `**` does.
+	PathGlobs []string
+	CreatorID *int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type EncryptableSecret = encryption.Encryptable
 
 // NewUnencryptedSecret creates an EncryptableSecret that *may* be encrypted in
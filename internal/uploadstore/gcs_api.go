@@ -22,17 +22,29 @@ type gcsBucketHandle interface {
 type gcsObjectHandle interface {
 	Delete(ctx context.Context) error
 	NewRangeReader(ctx context.Context, offset, length int64) (io.ReadCloser, error)
-	NewWriter(ctx context.Context) io.WriteCloser
+	NewWriter(ctx context.Context, opts gcsWriterOptions) io.WriteCloser
 	ComposerFrom(sources ...gcsObjectHandle) gcsComposer
 }
 
+// gcsWriterOptions configures the writer of a new object.
+type gcsWriterOptions struct {
+	// KMSKeyName is the Cloud KMS key used to encrypt the object. If empty, the
+	// bucket's default encryption applies.
+	KMSKeyName string
+	// ChunkSize is the size of each chunk of a resumable upload. If zero, the
+	// client default is used.
+	ChunkSize int
+}
+
 type gcsObjectIterator interface {
 	Next() (*storage.ObjectAttrs, error)
 	PageInfo() *iterator.PageInfo
 }
 
 type gcsComposer interface {
-	Run(ctx context.Context) (*storage.ObjectAttrs, error)
+	// Run composes the sources into the destination object. Only the KMSKeyName
+	// of the given options applies.
+	Run(ctx context.Context, opts gcsWriterOptions) (*storage.ObjectAttrs, error)
 }
 
 type gcsAPIShim struct{ client *storage.Client }
@@ -79,8 +91,13 @@ func (s *objectHandleShim) NewRangeReader(ctx context.Context, offset, length in
 	return s.handle.NewRangeReader(ctx, offset, length)
 }
 
-func (s *objectHandleShim) NewWriter(ctx context.Context) io.WriteCloser {
-	return s.handle.NewWriter(ctx)
+func (s *objectHandleShim) NewWriter(ctx context.Context, opts gcsWriterOptions) io.WriteCloser {
+	w := s.handle.NewWriter(ctx)
+	w.KMSKeyName = opts.KMSKeyName
+	if opts.ChunkSize != 0 {
+		w.ChunkSize = opts.ChunkSize
+	}
+	return w
 }
 
 func (s *objectHandleShim) ComposerFrom(sources ...gcsObjectHandle) gcsComposer {
@@ -102,14 +119,20 @@ func (s *objectIteratorShim) PageInfo() *iterator.PageInfo {
 	return s.handle.PageInfo()
 }
 
-func (s *composerShim) Run(ctx context.Context) (*storage.ObjectAttrs, error) {
+func (s *composerShim) Run(ctx context.Context, opts gcsWriterOptions) (*storage.ObjectAttrs, error) {
+	composerFrom := func(sources ...*storage.ObjectHandle) *storage.Composer {
+		c := s.handle.ComposerFrom(sources...)
+		c.KMSKeyName = opts.KMSKeyName
+		return c
+	}
+
 	for len(s.sources) > 32 {
-		if _, err := s.handle.ComposerFrom(s.sources[:32]...).Run(ctx); err != nil {
+		if _, err := composerFrom(s.sources[:32]...).Run(ctx); err != nil {
 			return nil, err
 		}
 
 		s.sources = append([]*storage.ObjectHandle{s.handle}, s.sources[32:]...)
 	}
 
-	return s.handle.ComposerFrom(s.sources...).Run(ctx)
+	return composerFrom(s.sources...).Run(ctx)
 }
@@ -32,6 +32,14 @@ type GCSConfig struct {
 	ProjectID               string
 	CredentialsFile         string
 	CredentialsFileContents string
+
+	// KMSKeyName is the resource name of the Cloud KMS key used to encrypt uploaded
+	// objects (CMEK). It is also set as the default key of managed buckets. If empty,
+	// Google-managed encryption keys are used.
+	KMSKeyName string
+	// ChunkSize is the size in bytes of each chunk of a resumable upload. If zero, the
+	// client default of 16MiB is used.
+	ChunkSize int
 }
 
 // newGCSFromConfig creates a new store backed by GCP storage.
@@ -136,7 +144,10 @@ func (s *gcsStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	writer := s.client.Bucket(s.bucket).Object(key).NewWriter(ctx)
+	writer := s.client.Bucket(s.bucket).Object(key).NewWriter(ctx, gcsWriterOptions{
+		KMSKeyName: s.config.KMSKeyName,
+		ChunkSize:  s.config.ChunkSize,
+	})
 	defer func() {
 		if closeErr := writer.Close(); closeErr != nil {
 			err = errors.Append(err, errors.Wrap(closeErr, "failed to close writer"))
@@ -176,7 +187,7 @@ func (s *gcsStore) Compose(ctx context.Context, destination string, sources ...s
 		handles = append(handles, bucket.Object(source))
 	}
 
-	attrs, err := bucket.Object(destination).ComposerFrom(handles...).Run(ctx)
+	attrs, err := bucket.Object(destination).ComposerFrom(handles...).Run(ctx, gcsWriterOptions{KMSKeyName: s.config.KMSKeyName})
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to compose objects")
 	}
@@ -226,7 +237,14 @@ func (s *gcsStore) ExpireObjects(ctx context.Context, prefix string, maxAge time
 }
 
 func (s *gcsStore) create(ctx context.Context, bucket gcsBucketHandle) error {
-	return bucket.Create(ctx, s.config.ProjectID, nil)
+	var attrs *storage.BucketAttrs
+	if s.config.KMSKeyName != "" {
+		attrs = &storage.BucketAttrs{
+			Encryption: &storage.BucketEncryption{DefaultKMSKeyName: s.config.KMSKeyName},
+		}
+	}
+
+	return bucket.Create(ctx, s.config.ProjectID, attrs)
 }
 
 func (s *gcsStore) deleteSources(ctx context.Context, bucket gcsBucketHandle, sources []string) error {
@@ -151,6 +151,41 @@ func TestGCSUpload(t *testing.T) {
 	}
 }
 
+func TestGCSUploadCMEK(t *testing.T) {
+	gcsClient := NewMockGcsAPI()
+	bucketHandle := NewMockGcsBucketHandle()
+	objectHandle := NewMockGcsObjectHandle()
+
+	gcsClient.BucketFunc.SetDefaultReturn(bucketHandle)
+	bucketHandle.ObjectFunc.SetDefaultReturn(objectHandle)
+	objectHandle.NewWriterFunc.SetDefaultReturn(nopCloser{&bytes.Buffer{}})
+
+	config := GCSConfig{ProjectID: "pid", KMSKeyName: "projects/p/locations/l/keyRings/r/cryptoKeys/k", ChunkSize: 32 << 20}
+	client := newGCSWithClient(gcsClient, "test-bucket", time.Hour, true, config, NewOperations(&observation.TestContext, "test", "brittlestore"))
+
+	if _, err := client.Upload(context.Background(), "test-key", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	}
+
+	if calls := objectHandle.NewWriterFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of NewWriter calls. want=%d have=%d", 1, len(calls))
+	} else if diff := cmp.Diff(gcsWriterOptions{KMSKeyName: config.KMSKeyName, ChunkSize: config.ChunkSize}, calls[0].Arg1); diff != "" {
+		t.Errorf("unexpected writer options (-want +got):\n%s", diff)
+	}
+
+	// Managed buckets are created with the key as their default.
+	bucketHandle.AttrsFunc.SetDefaultReturn(nil, storage.ErrBucketNotExist)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if calls := bucketHandle.CreateFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of Create calls. want=%d have=%d", 1, len(calls))
+	} else if attrs := calls[0].Arg2; attrs == nil || attrs.Encryption == nil || attrs.Encryption.DefaultKMSKeyName != config.KMSKeyName {
+		t.Errorf("unexpected bucket attributes. want default KMS key %s have=%+v", config.KMSKeyName, attrs)
+	}
+}
+
 type mockGCSObjectsIterator struct {
 	objects []storage.ObjectAttrs
 }
@@ -706,7 +706,7 @@ type MockGcsComposer struct {
 func NewMockGcsComposer() *MockGcsComposer {
 	return &MockGcsComposer{
 		RunFunc: &GcsComposerRunFunc{
-			defaultHook: func(context.Context) (r0 *storage.ObjectAttrs, r1 error) {
+			defaultHook: func(context.Context, gcsWriterOptions) (r0 *storage.ObjectAttrs, r1 error) {
 				return
 			},
 		},
@@ -718,7 +718,7 @@ func NewMockGcsComposer() *MockGcsComposer {
 func NewStrictMockGcsComposer() *MockGcsComposer {
 	return &MockGcsComposer{
 		RunFunc: &GcsComposerRunFunc{
-			defaultHook: func(context.Context) (*storage.ObjectAttrs, error) {
+			defaultHook: func(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error) {
 				panic("unexpected invocation of MockGcsComposer.Run")
 			},
 		},
@@ -729,7 +729,7 @@ func NewStrictMockGcsComposer() *MockGcsComposer {
 // package github.com/sourcegraph/sourcegraph/internal/uploadstore). It is
 // redefined here as it is unexported in the source package.
 type surrogateMockGcsComposer interface {
-	Run(context.Context) (*storage.ObjectAttrs, error)
+	Run(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error)
 }
 
 // NewMockGcsComposerFrom creates a new mock of the MockGcsComposer
@@ -746,23 +746,23 @@ func NewMockGcsComposerFrom(i surrogateMockGcsComposer) *MockGcsComposer {
 // GcsComposerRunFunc describes the behavior when the Run method of the
 // parent MockGcsComposer instance is invoked.
 type GcsComposerRunFunc struct {
-	defaultHook func(context.Context) (*storage.ObjectAttrs, error)
-	hooks       []func(context.Context) (*storage.ObjectAttrs, error)
+	defaultHook func(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error)
+	hooks       []func(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error)
 	history     []GcsComposerRunFuncCall
 	mutex       sync.Mutex
 }
 
 // Run delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockGcsComposer) Run(v0 context.Context) (*storage.ObjectAttrs, error) {
-	r0, r1 := m.RunFunc.nextHook()(v0)
-	m.RunFunc.appendCall(GcsComposerRunFuncCall{v0, r0, r1})
+func (m *MockGcsComposer) Run(v0 context.Context, v1 gcsWriterOptions) (*storage.ObjectAttrs, error) {
+	r0, r1 := m.RunFunc.nextHook()(v0, v1)
+	m.RunFunc.appendCall(GcsComposerRunFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the Run method of the
 // parent MockGcsComposer instance is invoked and the hook queue is empty.
-func (f *GcsComposerRunFunc) SetDefaultHook(hook func(context.Context) (*storage.ObjectAttrs, error)) {
+func (f *GcsComposerRunFunc) SetDefaultHook(hook func(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error)) {
 	f.defaultHook = hook
 }
 
@@ -770,7 +770,7 @@ func (f *GcsComposerRunFunc) SetDefaultHook(hook func(context.Context) (*storage
 // Run method of the parent MockGcsComposer instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *GcsComposerRunFunc) PushHook(hook func(context.Context) (*storage.ObjectAttrs, error)) {
+func (f *GcsComposerRunFunc) PushHook(hook func(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -779,19 +779,19 @@ func (f *GcsComposerRunFunc) PushHook(hook func(context.Context) (*storage.Objec
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *GcsComposerRunFunc) SetDefaultReturn(r0 *storage.ObjectAttrs, r1 error) {
-	f.SetDefaultHook(func(context.Context) (*storage.ObjectAttrs, error) {
+	f.SetDefaultHook(func(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *GcsComposerRunFunc) PushReturn(r0 *storage.ObjectAttrs, r1 error) {
-	f.PushHook(func(context.Context) (*storage.ObjectAttrs, error) {
+	f.PushHook(func(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error) {
 		return r0, r1
 	})
 }
 
-func (f *GcsComposerRunFunc) nextHook() func(context.Context) (*storage.ObjectAttrs, error) {
+func (f *GcsComposerRunFunc) nextHook() func(context.Context, gcsWriterOptions) (*storage.ObjectAttrs, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -827,6 +827,9 @@ type GcsComposerRunFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 gcsWriterOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *storage.ObjectAttrs
@@ -838,7 +841,7 @@ type GcsComposerRunFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c GcsComposerRunFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
@@ -887,7 +890,7 @@ func NewMockGcsObjectHandle() *MockGcsObjectHandle {
 			},
 		},
 		NewWriterFunc: &GcsObjectHandleNewWriterFunc{
-			defaultHook: func(context.Context) (r0 io.WriteCloser) {
+			defaultHook: func(context.Context, gcsWriterOptions) (r0 io.WriteCloser) {
 				return
 			},
 		},
@@ -914,7 +917,7 @@ func NewStrictMockGcsObjectHandle() *MockGcsObjectHandle {
 			},
 		},
 		NewWriterFunc: &GcsObjectHandleNewWriterFunc{
-			defaultHook: func(context.Context) io.WriteCloser {
+			defaultHook: func(context.Context, gcsWriterOptions) io.WriteCloser {
 				panic("unexpected invocation of MockGcsObjectHandle.NewWriter")
 			},
 		},
@@ -929,7 +932,7 @@ type surrogateMockGcsObjectHandle interface {
 	ComposerFrom(...gcsObjectHandle) gcsComposer
 	Delete(context.Context) error
 	NewRangeReader(context.Context, int64, int64) (io.ReadCloser, error)
-	NewWriter(context.Context) io.WriteCloser
+	NewWriter(context.Context, gcsWriterOptions) io.WriteCloser
 }
 
 // NewMockGcsObjectHandleFrom creates a new mock of the MockGcsObjectHandle
@@ -1280,24 +1283,24 @@ func (c GcsObjectHandleNewRangeReaderFuncCall) Results() []interface{} {
 // GcsObjectHandleNewWriterFunc describes the behavior when the NewWriter
 // method of the parent MockGcsObjectHandle instance is invoked.
 type GcsObjectHandleNewWriterFunc struct {
-	defaultHook func(context.Context) io.WriteCloser
-	hooks       []func(context.Context) io.WriteCloser
+	defaultHook func(context.Context, gcsWriterOptions) io.WriteCloser
+	hooks       []func(context.Context, gcsWriterOptions) io.WriteCloser
 	history     []GcsObjectHandleNewWriterFuncCall
 	mutex       sync.Mutex
 }
 
 // NewWriter delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockGcsObjectHandle) NewWriter(v0 context.Context) io.WriteCloser {
-	r0 := m.NewWriterFunc.nextHook()(v0)
-	m.NewWriterFunc.appendCall(GcsObjectHandleNewWriterFuncCall{v0, r0})
+func (m *MockGcsObjectHandle) NewWriter(v0 context.Context, v1 gcsWriterOptions) io.WriteCloser {
+	r0 := m.NewWriterFunc.nextHook()(v0, v1)
+	m.NewWriterFunc.appendCall(GcsObjectHandleNewWriterFuncCall{v0, v1, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the NewWriter method of
 // the parent MockGcsObjectHandle instance is invoked and the hook queue is
 // empty.
-func (f *GcsObjectHandleNewWriterFunc) SetDefaultHook(hook func(context.Context) io.WriteCloser) {
+func (f *GcsObjectHandleNewWriterFunc) SetDefaultHook(hook func(context.Context, gcsWriterOptions) io.WriteCloser) {
 	f.defaultHook = hook
 }
 
@@ -1305,7 +1308,7 @@ func (f *GcsObjectHandleNewWriterFunc) SetDefaultHook(hook func(context.Context)
 // NewWriter method of the parent MockGcsObjectHandle instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *GcsObjectHandleNewWriterFunc) PushHook(hook func(context.Context) io.WriteCloser) {
+func (f *GcsObjectHandleNewWriterFunc) PushHook(hook func(context.Context, gcsWriterOptions) io.WriteCloser) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1314,19 +1317,19 @@ func (f *GcsObjectHandleNewWriterFunc) PushHook(hook func(context.Context) io.Wr
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *GcsObjectHandleNewWriterFunc) SetDefaultReturn(r0 io.WriteCloser) {
-	f.SetDefaultHook(func(context.Context) io.WriteCloser {
+	f.SetDefaultHook(func(context.Context, gcsWriterOptions) io.WriteCloser {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *GcsObjectHandleNewWriterFunc) PushReturn(r0 io.WriteCloser) {
-	f.PushHook(func(context.Context) io.WriteCloser {
+	f.PushHook(func(context.Context, gcsWriterOptions) io.WriteCloser {
 		return r0
 	})
 }
 
-func (f *GcsObjectHandleNewWriterFunc) nextHook() func(context.Context) io.WriteCloser {
+func (f *GcsObjectHandleNewWriterFunc) nextHook() func(context.Context, gcsWriterOptions) io.WriteCloser {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1362,6 +1365,9 @@ type GcsObjectHandleNewWriterFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 gcsWriterOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 io.WriteCloser
@@ -1370,7 +1376,7 @@ type GcsObjectHandleNewWriterFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c GcsObjectHandleNewWriterFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
@@ -26,6 +26,7 @@ type s3Store struct {
 	logger       log.Logger
 	bucket       string
 	manageBucket bool
+	config       S3Config
 	client       s3API
 	uploader     s3Uploader
 	operations   *Operations
@@ -41,6 +42,16 @@ type S3Config struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	SessionToken    string
+
+	// KMSKeyID is the ID or ARN of the AWS KMS key used to encrypt uploaded objects
+	// (SSE-KMS). If empty, objects are encrypted according to the bucket defaults.
+	KMSKeyID string
+	// PartSize is the size in bytes of each part of a multipart upload. If zero, the
+	// SDK default of 5MiB is used.
+	PartSize int64
+	// Concurrency is the number of parts of a single upload sent in parallel. If zero,
+	// the SDK default is used.
+	Concurrency int
 }
 
 // newS3FromConfig creates a new store backed by AWS Simple Storage Service.
@@ -52,14 +63,15 @@ func newS3FromConfig(ctx context.Context, config Config, operations *Operations)
 
 	s3Client := s3.NewFromConfig(cfg, s3ClientOptions(config.S3))
 	api := &s3APIShim{s3Client}
-	uploader := &s3UploaderShim{manager.NewUploader(s3Client)}
-	return newS3WithClients(api, uploader, config.Bucket, config.ManageBucket, operations), nil
+	uploader := &s3UploaderShim{manager.NewUploader(s3Client, s3UploaderOptions(config.S3))}
+	return newS3WithClients(api, uploader, config.Bucket, config.ManageBucket, config.S3, operations), nil
 }
 
-func newS3WithClients(client s3API, uploader s3Uploader, bucket string, manageBucket bool, operations *Operations) *s3Store {
+func newS3WithClients(client s3API, uploader s3Uploader, bucket string, manageBucket bool, config S3Config, operations *Operations) *s3Store {
 	return &s3Store{
 		bucket:       bucket,
 		manageBucket: manageBucket,
+		config:       config,
 		client:       client,
 		uploader:     uploader,
 		operations:   operations,
@@ -197,11 +209,17 @@ func (s *s3Store) Upload(ctx context.Context, key string, r io.Reader) (_ int64,
 
 	cr := &countingReader{r: r}
 
-	if err := s.uploader.Upload(ctx, &s3.PutObjectInput{
+	input := &s3.PutObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(key),
 		Body:   cr,
-	}); err != nil {
+	}
+	if s.config.KMSKeyID != "" {
+		input.ServerSideEncryption = s3types.ServerSideEncryptionAwsKms
+		input.SSEKMSKeyId = aws.String(s.config.KMSKeyID)
+	}
+
+	if err := s.uploader.Upload(ctx, input); err != nil {
 		return 0, errors.Wrap(err, "failed to upload object")
 	}
 
@@ -215,10 +233,16 @@ func (s *s3Store) Compose(ctx context.Context, destination string, sources ...st
 	}})
 	defer endObservation(1, observation.Args{})
 
-	multipartUpload, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+	input := &s3.CreateMultipartUploadInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(destination),
-	})
+	}
+	if s.config.KMSKeyID != "" {
+		input.ServerSideEncryption = s3types.ServerSideEncryptionAwsKms
+		input.SSEKMSKeyId = aws.String(s.config.KMSKeyID)
+	}
+
+	multipartUpload, err := s.client.CreateMultipartUpload(ctx, input)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to create multipart upload")
 	}
@@ -440,6 +464,17 @@ func s3ClientOptions(config S3Config) func(o *s3.Options) {
 	}
 }
 
+func s3UploaderOptions(config S3Config) func(u *manager.Uploader) {
+	return func(u *manager.Uploader) {
+		if config.PartSize != 0 {
+			u.PartSize = config.PartSize
+		}
+		if config.Concurrency != 0 {
+			u.Concurrency = config.Concurrency
+		}
+	}
+}
+
 // writeToPipe invokes the given function with a pipe writer in a goroutine
 // and returns the associated pipe reader.
 func writeToPipe(fn func(w io.Writer) error) io.Reader {
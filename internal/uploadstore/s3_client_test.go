@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/go-cmp/cmp"
@@ -53,7 +54,7 @@ func TestS3InitBucketExists(t *testing.T) {
 
 func TestS3UnmanagedInit(t *testing.T) {
 	s3Client := NewMockS3API()
-	client := newS3WithClients(s3Client, nil, "test-bucket", false, NewOperations(&observation.TestContext, "test", "brittleStore"))
+	client := newS3WithClients(s3Client, nil, "test-bucket", false, S3Config{}, NewOperations(&observation.TestContext, "test", "brittleStore"))
 	if err := client.Init(context.Background()); err != nil {
 		t.Fatalf("unexpected error initializing client: %s", err)
 	}
@@ -69,7 +70,7 @@ func TestS3Get(t *testing.T) {
 		Body: io.NopCloser(bytes.NewReader([]byte("TEST PAYLOAD"))),
 	}, nil)
 
-	client := newS3WithClients(s3Client, nil, "test-bucket", false, NewOperations(&observation.TestContext, "test", "brittleStore"))
+	client := newS3WithClients(s3Client, nil, "test-bucket", false, S3Config{}, NewOperations(&observation.TestContext, "test", "brittleStore"))
 	rc, err := client.Get(context.Background(), "test-key")
 	if err != nil {
 		t.Fatalf("unexpected error getting key: %s", err)
@@ -121,7 +122,7 @@ func TestS3GetTransientErrors(t *testing.T) {
 	}
 
 	s3Client := fullContentsS3API()
-	client := newS3WithClients(s3Client, nil, "test-bucket", false, NewOperations(&observation.TestContext, "test", "brittleStore"))
+	client := newS3WithClients(s3Client, nil, "test-bucket", false, S3Config{}, NewOperations(&observation.TestContext, "test", "brittleStore"))
 	rc, err := client.Get(context.Background(), "test-key")
 	if err != nil {
 		t.Fatalf("unexpected error getting key: %s", err)
@@ -150,7 +151,7 @@ func TestS3GetReadNothingLoop(t *testing.T) {
 	}
 
 	s3Client := fullContentsS3API()
-	client := newS3WithClients(s3Client, nil, "test-bucket", false, NewOperations(&observation.TestContext, "test", "brittleStore"))
+	client := newS3WithClients(s3Client, nil, "test-bucket", false, S3Config{}, NewOperations(&observation.TestContext, "test", "brittleStore"))
 	rc, err := client.Get(context.Background(), "test-key")
 	if err != nil {
 		t.Fatalf("unexpected error getting key: %s", err)
@@ -229,6 +230,51 @@ func TestS3Upload(t *testing.T) {
 	}
 }
 
+func TestS3UploadSSEKMS(t *testing.T) {
+	s3Client := NewMockS3API()
+	s3Client.CreateMultipartUploadFunc.SetDefaultReturn(nil, errors.New("access denied"))
+	uploaderClient := NewMockS3Uploader()
+
+	client := newS3WithClients(s3Client, uploaderClient, "test-bucket", false, S3Config{KMSKeyID: "test-key-id"}, NewOperations(&observation.TestContext, "test", "brittleStore"))
+	if _, err := client.Upload(context.Background(), "test-key", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	}
+
+	if calls := uploaderClient.UploadFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of Upload calls. want=%d have=%d", 1, len(calls))
+	} else if value := calls[0].Arg1.ServerSideEncryption; value != s3types.ServerSideEncryptionAwsKms {
+		t.Errorf("unexpected server-side encryption. want=%s have=%s", s3types.ServerSideEncryptionAwsKms, value)
+	} else if value := aws.ToString(calls[0].Arg1.SSEKMSKeyId); value != "test-key-id" {
+		t.Errorf("unexpected KMS key ID. want=%s have=%s", "test-key-id", value)
+	}
+
+	if _, err := client.Compose(context.Background(), "test-key", "test-src1"); err == nil {
+		t.Fatalf("expected error composing objects")
+	}
+
+	if calls := s3Client.CreateMultipartUploadFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of CreateMultipartUpload calls. want=%d have=%d", 1, len(calls))
+	} else if value := aws.ToString(calls[0].Arg1.SSEKMSKeyId); value != "test-key-id" {
+		t.Errorf("unexpected KMS key ID. want=%s have=%s", "test-key-id", value)
+	}
+}
+
+func TestS3UploaderOptions(t *testing.T) {
+	uploader := &manager.Uploader{PartSize: manager.DefaultUploadPartSize, Concurrency: manager.DefaultUploadConcurrency}
+	s3UploaderOptions(S3Config{})(uploader)
+	if uploader.PartSize != manager.DefaultUploadPartSize || uploader.Concurrency != manager.DefaultUploadConcurrency {
+		t.Errorf("unexpected uploader options. want defaults have=%d/%d", uploader.PartSize, uploader.Concurrency)
+	}
+
+	s3UploaderOptions(S3Config{PartSize: 64 << 20, Concurrency: 10})(uploader)
+	if uploader.PartSize != 64<<20 {
+		t.Errorf("unexpected part size. want=%d have=%d", 64<<20, uploader.PartSize)
+	}
+	if uploader.Concurrency != 10 {
+		t.Errorf("unexpected concurrency. want=%d have=%d", 10, uploader.Concurrency)
+	}
+}
+
 func TestS3Combine(t *testing.T) {
 	s3Client := NewMockS3API()
 	s3Client.CreateMultipartUploadFunc.SetDefaultReturn(&s3.CreateMultipartUploadOutput{
@@ -368,5 +414,5 @@ func testS3Client(client s3API, uploader s3Uploader) Store {
 }
 
 func rawS3Client(client s3API, uploader s3Uploader) *s3Store {
-	return newS3WithClients(client, uploader, "test-bucket", true, NewOperations(&observation.TestContext, "test", "brittleStore"))
+	return newS3WithClients(client, uploader, "test-bucket", true, S3Config{}, NewOperations(&observation.TestContext, "test", "brittleStore"))
 }
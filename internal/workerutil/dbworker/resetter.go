@@ -21,6 +21,9 @@ import (
 // An unlocked record signifies that it is not actively being processed and records in this
 // state for more than a few seconds are very likely to be stuck after the worker processing
 // them has crashed.
+//
+// If the store is configured with deadlines, the resetter also moves queued and errored
+// records whose deadline has passed into the failed state.
 type Resetter[T workerutil.Record] struct {
 	store    store.Store[T]
 	options  ResetterOptions
@@ -41,6 +44,9 @@ type ResetterMetrics struct {
 	RecordResets        prometheus.Counter
 	RecordResetFailures prometheus.Counter
 	Errors              prometheus.Counter
+
+	// RecordDeadlinesExceeded is optional.
+	RecordDeadlinesExceeded prometheus.Counter
 }
 
 // NewResetterMetrics returns a metrics object for a resetter that follows
@@ -67,10 +73,17 @@ func NewResetterMetrics(observationCtx *observation.Context, metricNameRoot stri
 	})
 	observationCtx.Registerer.MustRegister(resetErrors)
 
+	deadlinesExceeded := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_" + metricNameRoot + "_record_deadlines_exceeded_total",
+		Help: "The number of records marked as failed because their deadline has passed.",
+	})
+	observationCtx.Registerer.MustRegister(deadlinesExceeded)
+
 	return ResetterMetrics{
-		RecordResets:        resets,
-		RecordResetFailures: resetFailures,
-		Errors:              resetErrors,
+		RecordResets:            resets,
+		RecordResetFailures:     resetFailures,
+		Errors:                  resetErrors,
+		RecordDeadlinesExceeded: deadlinesExceeded,
 	}
 }
 
@@ -123,6 +136,22 @@ loop:
 		r.options.Metrics.RecordResets.Add(float64(len(resetLastHeartbeatsByIDs)))
 		r.options.Metrics.RecordResetFailures.Add(float64(len(failedLastHeartbeatsByIDs)))
 
+		expiredIDs, err := r.store.MarkDeadlineExceeded(r.ctx)
+		if err != nil {
+			if r.ctx.Err() != nil && errors.Is(err, r.ctx.Err()) {
+				break loop
+			}
+
+			r.options.Metrics.Errors.Inc()
+			r.logger.Error("Failed to mark records past their deadline", log.String("name", r.options.Name), log.Error(err))
+		}
+		for _, id := range expiredIDs {
+			r.logger.Warn("Marked record past its deadline as 'failed'", log.String("name", r.options.Name), log.Int("id", id))
+		}
+		if r.options.Metrics.RecordDeadlinesExceeded != nil {
+			r.options.Metrics.RecordDeadlinesExceeded.Add(float64(len(expiredIDs)))
+		}
+
 		select {
 		case <-r.clock.After(r.options.Interval):
 		case <-r.ctx.Done():
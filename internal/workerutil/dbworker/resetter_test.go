@@ -48,4 +48,7 @@ func TestResetter(t *testing.T) {
 	if callCount := len(s.ResetStalledFunc.History()); callCount < 1 {
 		t.Errorf("unexpected reset stalled call count. want>=%d have=%d", 1, callCount)
 	}
+	if callCount := len(s.MarkDeadlineExceededFunc.History()); callCount < 1 {
+		t.Errorf("unexpected mark deadline exceeded call count. want>=%d have=%d", 1, callCount)
+	}
 }
@@ -16,6 +16,7 @@ import (
 	executor "github.com/sourcegraph/sourcegraph/internal/executor"
 	workerutil "github.com/sourcegraph/sourcegraph/internal/workerutil"
 	store "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	trace "go.opentelemetry.io/otel/trace"
 )
 
 // MockStore is a mock implementation of the Store interface (from the
@@ -26,9 +27,19 @@ type MockStore[T workerutil.Record] struct {
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *StoreAddExecutionLogEntryFunc[T]
+	// AppendExecutionLogEntryOutputFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// AppendExecutionLogEntryOutput.
+	AppendExecutionLogEntryOutputFunc *StoreAppendExecutionLogEntryOutputFunc[T]
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *StoreDequeueFunc[T]
+	// ExecutionLogsFunc is an instance of a mock function object
+	// controlling the behavior of the method ExecutionLogs.
+	ExecutionLogsFunc *StoreExecutionLogsFunc[T]
+	// FinishedCountFunc is an instance of a mock function object
+	// controlling the behavior of the method FinishedCount.
+	FinishedCountFunc *StoreFinishedCountFunc[T]
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *StoreHandleFunc[T]
@@ -38,6 +49,9 @@ type MockStore[T workerutil.Record] struct {
 	// MarkCompleteFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkComplete.
 	MarkCompleteFunc *StoreMarkCompleteFunc[T]
+	// MarkDeadlineExceededFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkDeadlineExceeded.
+	MarkDeadlineExceededFunc *StoreMarkDeadlineExceededFunc[T]
 	// MarkErroredFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkErrored.
 	MarkErroredFunc *StoreMarkErroredFunc[T]
@@ -47,6 +61,9 @@ type MockStore[T workerutil.Record] struct {
 	// MaxDurationInQueueFunc is an instance of a mock function object
 	// controlling the behavior of the method MaxDurationInQueue.
 	MaxDurationInQueueFunc *StoreMaxDurationInQueueFunc[T]
+	// ProcessingCountFunc is an instance of a mock function object
+	// controlling the behavior of the method ProcessingCount.
+	ProcessingCountFunc *StoreProcessingCountFunc[T]
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *StoreQueuedCountFunc[T]
@@ -56,6 +73,9 @@ type MockStore[T workerutil.Record] struct {
 	// ResetStalledFunc is an instance of a mock function object controlling
 	// the behavior of the method ResetStalled.
 	ResetStalledFunc *StoreResetStalledFunc[T]
+	// TraceContextFunc is an instance of a mock function object controlling
+	// the behavior of the method TraceContext.
+	TraceContextFunc *StoreTraceContextFunc[T]
 	// UpdateExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateExecutionLogEntry.
 	UpdateExecutionLogEntryFunc *StoreUpdateExecutionLogEntryFunc[T]
@@ -73,11 +93,26 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &StoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: func(context.Context, int, int, string, store.ExecutionLogEntryOptions) (r0 error) {
+				return
+			},
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (r0 T, r1 bool, r2 error) {
 				return
 			},
 		},
+		ExecutionLogsFunc: &StoreExecutionLogsFunc[T]{
+			defaultHook: func(context.Context, int) (r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+				return
+			},
+		},
+		FinishedCountFunc: &StoreFinishedCountFunc[T]{
+			defaultHook: func(context.Context, time.Time) (r0 int, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &StoreHandleFunc[T]{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
@@ -93,6 +128,11 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		MarkDeadlineExceededFunc: &StoreMarkDeadlineExceededFunc[T]{
+			defaultHook: func(context.Context) (r0 []int, r1 error) {
+				return
+			},
+		},
 		MarkErroredFunc: &StoreMarkErroredFunc[T]{
 			defaultHook: func(context.Context, int, string, store.MarkFinalOptions) (r0 bool, r1 error) {
 				return
@@ -108,6 +148,11 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		ProcessingCountFunc: &StoreProcessingCountFunc[T]{
+			defaultHook: func(context.Context) (r0 int, r1 error) {
+				return
+			},
+		},
 		QueuedCountFunc: &StoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (r0 int, r1 error) {
 				return
@@ -123,6 +168,11 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		TraceContextFunc: &StoreTraceContextFunc[T]{
+			defaultHook: func(context.Context, int) (r0 trace.SpanContext, r1 error) {
+				return
+			},
+		},
 		UpdateExecutionLogEntryFunc: &StoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store.ExecutionLogEntryOptions) (r0 error) {
 				return
@@ -145,11 +195,26 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.AddExecutionLogEntry")
 			},
 		},
+		AppendExecutionLogEntryOutputFunc: &StoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+				panic("unexpected invocation of MockStore.AppendExecutionLogEntryOutput")
+			},
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (T, bool, error) {
 				panic("unexpected invocation of MockStore.Dequeue")
 			},
 		},
+		ExecutionLogsFunc: &StoreExecutionLogsFunc[T]{
+			defaultHook: func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+				panic("unexpected invocation of MockStore.ExecutionLogs")
+			},
+		},
+		FinishedCountFunc: &StoreFinishedCountFunc[T]{
+			defaultHook: func(context.Context, time.Time) (int, error) {
+				panic("unexpected invocation of MockStore.FinishedCount")
+			},
+		},
 		HandleFunc: &StoreHandleFunc[T]{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockStore.Handle")
@@ -165,6 +230,11 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.MarkComplete")
 			},
 		},
+		MarkDeadlineExceededFunc: &StoreMarkDeadlineExceededFunc[T]{
+			defaultHook: func(context.Context) ([]int, error) {
+				panic("unexpected invocation of MockStore.MarkDeadlineExceeded")
+			},
+		},
 		MarkErroredFunc: &StoreMarkErroredFunc[T]{
 			defaultHook: func(context.Context, int, string, store.MarkFinalOptions) (bool, error) {
 				panic("unexpected invocation of MockStore.MarkErrored")
@@ -180,6 +250,11 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.MaxDurationInQueue")
 			},
 		},
+		ProcessingCountFunc: &StoreProcessingCountFunc[T]{
+			defaultHook: func(context.Context) (int, error) {
+				panic("unexpected invocation of MockStore.ProcessingCount")
+			},
+		},
 		QueuedCountFunc: &StoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (int, error) {
 				panic("unexpected invocation of MockStore.QueuedCount")
@@ -195,6 +270,11 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.ResetStalled")
 			},
 		},
+		TraceContextFunc: &StoreTraceContextFunc[T]{
+			defaultHook: func(context.Context, int) (trace.SpanContext, error) {
+				panic("unexpected invocation of MockStore.TraceContext")
+			},
+		},
 		UpdateExecutionLogEntryFunc: &StoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store.ExecutionLogEntryOptions) error {
 				panic("unexpected invocation of MockStore.UpdateExecutionLogEntry")
@@ -215,9 +295,18 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 		AddExecutionLogEntryFunc: &StoreAddExecutionLogEntryFunc[T]{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		AppendExecutionLogEntryOutputFunc: &StoreAppendExecutionLogEntryOutputFunc[T]{
+			defaultHook: i.AppendExecutionLogEntryOutput,
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: i.Dequeue,
 		},
+		ExecutionLogsFunc: &StoreExecutionLogsFunc[T]{
+			defaultHook: i.ExecutionLogs,
+		},
+		FinishedCountFunc: &StoreFinishedCountFunc[T]{
+			defaultHook: i.FinishedCount,
+		},
 		HandleFunc: &StoreHandleFunc[T]{
 			defaultHook: i.Handle,
 		},
@@ -227,6 +316,9 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 		MarkCompleteFunc: &StoreMarkCompleteFunc[T]{
 			defaultHook: i.MarkComplete,
 		},
+		MarkDeadlineExceededFunc: &StoreMarkDeadlineExceededFunc[T]{
+			defaultHook: i.MarkDeadlineExceeded,
+		},
 		MarkErroredFunc: &StoreMarkErroredFunc[T]{
 			defaultHook: i.MarkErrored,
 		},
@@ -236,6 +328,9 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 		MaxDurationInQueueFunc: &StoreMaxDurationInQueueFunc[T]{
 			defaultHook: i.MaxDurationInQueue,
 		},
+		ProcessingCountFunc: &StoreProcessingCountFunc[T]{
+			defaultHook: i.ProcessingCount,
+		},
 		QueuedCountFunc: &StoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
@@ -245,6 +340,9 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 		ResetStalledFunc: &StoreResetStalledFunc[T]{
 			defaultHook: i.ResetStalled,
 		},
+		TraceContextFunc: &StoreTraceContextFunc[T]{
+			defaultHook: i.TraceContext,
+		},
 		UpdateExecutionLogEntryFunc: &StoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: i.UpdateExecutionLogEntry,
 		},
@@ -368,6 +466,123 @@ func (c StoreAddExecutionLogEntryFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreAppendExecutionLogEntryOutputFunc describes the behavior when the
+// AppendExecutionLogEntryOutput method of the parent MockStore instance is
+// invoked.
+type StoreAppendExecutionLogEntryOutputFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error
+	hooks       []func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error
+	history     []StoreAppendExecutionLogEntryOutputFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// AppendExecutionLogEntryOutput delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore[T]) AppendExecutionLogEntryOutput(v0 context.Context, v1 int, v2 int, v3 string, v4 store.ExecutionLogEntryOptions) error {
+	r0 := m.AppendExecutionLogEntryOutputFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AppendExecutionLogEntryOutputFunc.appendCall(StoreAppendExecutionLogEntryOutputFuncCall[T]{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// AppendExecutionLogEntryOutput method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreAppendExecutionLogEntryOutputFunc[T]) SetDefaultHook(hook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AppendExecutionLogEntryOutput method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreAppendExecutionLogEntryOutputFunc[T]) PushHook(hook func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreAppendExecutionLogEntryOutputFunc[T]) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreAppendExecutionLogEntryOutputFunc[T]) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+		return r0
+	})
+}
+
+func (f *StoreAppendExecutionLogEntryOutputFunc[T]) nextHook() func(context.Context, int, int, string, store.ExecutionLogEntryOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreAppendExecutionLogEntryOutputFunc[T]) appendCall(r0 StoreAppendExecutionLogEntryOutputFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreAppendExecutionLogEntryOutputFuncCall
+// objects describing the invocations of this function.
+func (f *StoreAppendExecutionLogEntryOutputFunc[T]) History() []StoreAppendExecutionLogEntryOutputFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreAppendExecutionLogEntryOutputFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreAppendExecutionLogEntryOutputFuncCall is an object that describes an
+// invocation of method AppendExecutionLogEntryOutput on an instance of
+// MockStore.
+type StoreAppendExecutionLogEntryOutputFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 store.ExecutionLogEntryOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreAppendExecutionLogEntryOutputFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreAppendExecutionLogEntryOutputFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreDequeueFunc describes the behavior when the Dequeue method of the
 // parent MockStore instance is invoked.
 type StoreDequeueFunc[T workerutil.Record] struct {
@@ -481,6 +696,226 @@ func (c StoreDequeueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreExecutionLogsFunc describes the behavior when the ExecutionLogs
+// method of the parent MockStore instance is invoked.
+type StoreExecutionLogsFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)
+	hooks       []func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)
+	history     []StoreExecutionLogsFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// ExecutionLogs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore[T]) ExecutionLogs(v0 context.Context, v1 int) ([]executor.ExecutionLogEntry, string, bool, error) {
+	r0, r1, r2, r3 := m.ExecutionLogsFunc.nextHook()(v0, v1)
+	m.ExecutionLogsFunc.appendCall(StoreExecutionLogsFuncCall[T]{v0, v1, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the ExecutionLogs method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreExecutionLogsFunc[T]) SetDefaultHook(hook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExecutionLogs method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreExecutionLogsFunc[T]) PushHook(hook func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreExecutionLogsFunc[T]) SetDefaultReturn(r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreExecutionLogsFunc[T]) PushReturn(r0 []executor.ExecutionLogEntry, r1 string, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *StoreExecutionLogsFunc[T]) nextHook() func(context.Context, int) ([]executor.ExecutionLogEntry, string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreExecutionLogsFunc[T]) appendCall(r0 StoreExecutionLogsFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreExecutionLogsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreExecutionLogsFunc[T]) History() []StoreExecutionLogsFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreExecutionLogsFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreExecutionLogsFuncCall is an object that describes an invocation of
+// method ExecutionLogs on an instance of MockStore.
+type StoreExecutionLogsFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []executor.ExecutionLogEntry
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreExecutionLogsFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreExecutionLogsFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// StoreFinishedCountFunc describes the behavior when the FinishedCount
+// method of the parent MockStore instance is invoked.
+type StoreFinishedCountFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, time.Time) (int, error)
+	hooks       []func(context.Context, time.Time) (int, error)
+	history     []StoreFinishedCountFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// FinishedCount delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore[T]) FinishedCount(v0 context.Context, v1 time.Time) (int, error) {
+	r0, r1 := m.FinishedCountFunc.nextHook()(v0, v1)
+	m.FinishedCountFunc.appendCall(StoreFinishedCountFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FinishedCount method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreFinishedCountFunc[T]) SetDefaultHook(hook func(context.Context, time.Time) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FinishedCount method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreFinishedCountFunc[T]) PushHook(hook func(context.Context, time.Time) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreFinishedCountFunc[T]) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreFinishedCountFunc[T]) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreFinishedCountFunc[T]) nextHook() func(context.Context, time.Time) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreFinishedCountFunc[T]) appendCall(r0 StoreFinishedCountFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreFinishedCountFuncCall objects
+// describing the invocations of this function.
+func (f *StoreFinishedCountFunc[T]) History() []StoreFinishedCountFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreFinishedCountFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreFinishedCountFuncCall is an object that describes an invocation of
+// method FinishedCount on an instance of MockStore.
+type StoreFinishedCountFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreFinishedCountFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreFinishedCountFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreHandleFunc describes the behavior when the Handle method of the
 // parent MockStore instance is invoked.
 type StoreHandleFunc[T workerutil.Record] struct {
@@ -802,6 +1237,111 @@ func (c StoreMarkCompleteFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreMarkDeadlineExceededFunc describes the behavior when the
+// MarkDeadlineExceeded method of the parent MockStore instance is invoked.
+type StoreMarkDeadlineExceededFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []StoreMarkDeadlineExceededFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// MarkDeadlineExceeded delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore[T]) MarkDeadlineExceeded(v0 context.Context) ([]int, error) {
+	r0, r1 := m.MarkDeadlineExceededFunc.nextHook()(v0)
+	m.MarkDeadlineExceededFunc.appendCall(StoreMarkDeadlineExceededFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the MarkDeadlineExceeded
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreMarkDeadlineExceededFunc[T]) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkDeadlineExceeded method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreMarkDeadlineExceededFunc[T]) PushHook(hook func(context.Context) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreMarkDeadlineExceededFunc[T]) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreMarkDeadlineExceededFunc[T]) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreMarkDeadlineExceededFunc[T]) nextHook() func(context.Context) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreMarkDeadlineExceededFunc[T]) appendCall(r0 StoreMarkDeadlineExceededFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreMarkDeadlineExceededFuncCall objects
+// describing the invocations of this function.
+func (f *StoreMarkDeadlineExceededFunc[T]) History() []StoreMarkDeadlineExceededFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreMarkDeadlineExceededFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreMarkDeadlineExceededFuncCall is an object that describes an
+// invocation of method MarkDeadlineExceeded on an instance of MockStore.
+type StoreMarkDeadlineExceededFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreMarkDeadlineExceededFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreMarkDeadlineExceededFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreMarkErroredFunc describes the behavior when the MarkErrored method
 // of the parent MockStore instance is invoked.
 type StoreMarkErroredFunc[T workerutil.Record] struct {
@@ -1133,6 +1673,111 @@ func (c StoreMaxDurationInQueueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreProcessingCountFunc describes the behavior when the ProcessingCount
+// method of the parent MockStore instance is invoked.
+type StoreProcessingCountFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) (int, error)
+	hooks       []func(context.Context) (int, error)
+	history     []StoreProcessingCountFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// ProcessingCount delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore[T]) ProcessingCount(v0 context.Context) (int, error) {
+	r0, r1 := m.ProcessingCountFunc.nextHook()(v0)
+	m.ProcessingCountFunc.appendCall(StoreProcessingCountFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ProcessingCount
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreProcessingCountFunc[T]) SetDefaultHook(hook func(context.Context) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ProcessingCount method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreProcessingCountFunc[T]) PushHook(hook func(context.Context) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreProcessingCountFunc[T]) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreProcessingCountFunc[T]) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreProcessingCountFunc[T]) nextHook() func(context.Context) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreProcessingCountFunc[T]) appendCall(r0 StoreProcessingCountFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreProcessingCountFuncCall objects
+// describing the invocations of this function.
+func (f *StoreProcessingCountFunc[T]) History() []StoreProcessingCountFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreProcessingCountFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreProcessingCountFuncCall is an object that describes an invocation of
+// method ProcessingCount on an instance of MockStore.
+type StoreProcessingCountFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreProcessingCountFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreProcessingCountFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreQueuedCountFunc describes the behavior when the QueuedCount method
 // of the parent MockStore instance is invoked.
 type StoreQueuedCountFunc[T workerutil.Record] struct {
@@ -1454,6 +2099,113 @@ func (c StoreResetStalledFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreTraceContextFunc describes the behavior when the TraceContext method
+// of the parent MockStore instance is invoked.
+type StoreTraceContextFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) (trace.SpanContext, error)
+	hooks       []func(context.Context, int) (trace.SpanContext, error)
+	history     []StoreTraceContextFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// TraceContext delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore[T]) TraceContext(v0 context.Context, v1 int) (trace.SpanContext, error) {
+	r0, r1 := m.TraceContextFunc.nextHook()(v0, v1)
+	m.TraceContextFunc.appendCall(StoreTraceContextFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the TraceContext method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreTraceContextFunc[T]) SetDefaultHook(hook func(context.Context, int) (trace.SpanContext, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TraceContext method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreTraceContextFunc[T]) PushHook(hook func(context.Context, int) (trace.SpanContext, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreTraceContextFunc[T]) SetDefaultReturn(r0 trace.SpanContext, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (trace.SpanContext, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreTraceContextFunc[T]) PushReturn(r0 trace.SpanContext, r1 error) {
+	f.PushHook(func(context.Context, int) (trace.SpanContext, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreTraceContextFunc[T]) nextHook() func(context.Context, int) (trace.SpanContext, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreTraceContextFunc[T]) appendCall(r0 StoreTraceContextFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreTraceContextFuncCall objects
+// describing the invocations of this function.
+func (f *StoreTraceContextFunc[T]) History() []StoreTraceContextFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreTraceContextFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreTraceContextFuncCall is an object that describes an invocation of
+// method TraceContext on an instance of MockStore.
+type StoreTraceContextFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 trace.SpanContext
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreTraceContextFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreTraceContextFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreUpdateExecutionLogEntryFunc describes the behavior when the
 // UpdateExecutionLogEntry method of the parent MockStore instance is
 // invoked.
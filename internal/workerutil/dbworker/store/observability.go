@@ -10,15 +10,21 @@ import (
 
 type operations struct {
 	addExecutionLogEntry    *observation.Operation
+	appendExecutionLogOut   *observation.Operation
 	dequeue                 *observation.Operation
+	executionLogs           *observation.Operation
+	finishedCount           *observation.Operation
 	heartbeat               *observation.Operation
 	markComplete            *observation.Operation
 	markErrored             *observation.Operation
 	markFailed              *observation.Operation
+	markDeadlineExceeded    *observation.Operation
 	maxDurationInQueue      *observation.Operation
+	processingCount         *observation.Operation
 	queuedCount             *observation.Operation
 	requeue                 *observation.Operation
 	resetStalled            *observation.Operation
+	traceContext            *observation.Operation
 	updateExecutionLogEntry *observation.Operation
 	canceledJobs            *observation.Operation
 }
@@ -59,15 +65,21 @@ func newOperations(observationCtx *observation.Context, storeName string) *opera
 
 	return &operations{
 		addExecutionLogEntry:    op("AddExecutionLogEntry"),
+		appendExecutionLogOut:   op("AppendExecutionLogEntryOutput"),
 		dequeue:                 op("Dequeue"),
+		executionLogs:           op("ExecutionLogs"),
+		finishedCount:           op("FinishedCount"),
 		heartbeat:               op("Heartbeat"),
 		markComplete:            op("MarkComplete"),
 		markErrored:             op("MarkErrored"),
 		markFailed:              op("MarkFailed"),
+		markDeadlineExceeded:    op("MarkDeadlineExceeded"),
 		maxDurationInQueue:      op("MaxDurationInQueue"),
+		processingCount:         op("ProcessingCount"),
 		queuedCount:             op("QueuedCount"),
 		requeue:                 op("Requeue"),
 		resetStalled:            op("ResetStalled"),
+		traceContext:            op("TraceContext"),
 		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
 		canceledJobs:            op("CanceledJobs"),
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/sourcegraph/log"
 
@@ -88,6 +89,13 @@ type Store[T workerutil.Record] interface {
 	// MaxDurationInQueue returns the maximum age of queued records in this store. Returns 0 if there are no queued records.
 	MaxDurationInQueue(ctx context.Context) (time.Duration, error)
 
+	// ProcessingCount returns the number of records that are currently being processed.
+	ProcessingCount(ctx context.Context) (int, error)
+
+	// FinishedCount returns the number of records that finished processing (successfully or not) since the
+	// given time.
+	FinishedCount(ctx context.Context, since time.Time) (int, error)
+
 	// Dequeue selects the first queued record matching the given conditions and updates the state to processing. If there
 	// is such a record, it is returned. If there is no such unclaimed record, a nil record and a nil cancel function
 	// will be returned along with a false-valued flag. This method must not be called from within a transaction.
@@ -112,6 +120,20 @@ type Store[T workerutil.Record] interface {
 	// found (due to options not matching or the record being deleted), ErrExecutionLogEntryNotUpdated is returned.
 	UpdateExecutionLogEntry(ctx context.Context, recordID, entryID int, entry executor.ExecutionLogEntry, options ExecutionLogEntryOptions) error
 
+	// AppendExecutionLogEntryOutput appends the given output to the executor log entry with the given ID on the given
+	// record. This allows executors to upload the output of long-running commands incrementally. When the record is not
+	// found (due to options not matching or the record being deleted), ErrExecutionLogEntryNotUpdated is returned.
+	AppendExecutionLogEntryOutput(ctx context.Context, recordID, entryID int, out string, options ExecutionLogEntryOptions) error
+
+	// ExecutionLogs returns the executor log entries and the state of the record with the given identifier. A false-valued
+	// flag is returned if the record does not exist.
+	ExecutionLogs(ctx context.Context, id int) ([]executor.ExecutionLogEntry, string, bool, error)
+
+	// TraceContext returns the span context of the operation that enqueued the record with the given identifier.
+	// The returned span context is invalid if the store is not configured to persist trace context, or if none was
+	// persisted with the record.
+	TraceContext(ctx context.Context, id int) (oteltrace.SpanContext, error)
+
 	// MarkComplete attempts to update the state of the record to complete. If this record has already been moved from
 	// the processing state to a terminal state, this method will have no effect. This method returns a boolean flag
 	// indicating if the record was updated.
@@ -133,6 +155,13 @@ type Store[T workerutil.Record] interface {
 	// identifiers the age of the record's last heartbeat timestamp for each record reset to queued and failed states,
 	// respectively.
 	ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error)
+
+	// MarkDeadlineExceeded moves all queued and errored records whose deadline has passed into the failed
+	// state, and returns their identifiers. Such records are never dequeued again. Records that are being
+	// processed are left to their worker; if they are not completed, they become errored or are reset to
+	// queued, and are then failed by a later call. This method has no effect if the store is not configured
+	// with deadlines.
+	MarkDeadlineExceeded(ctx context.Context) ([]int, error)
 }
 
 type store[T workerutil.Record] struct {
@@ -238,6 +267,22 @@ type Options[T workerutil.Record] struct {
 	// Setting this value to zero will disable retries entirely.
 	MaxNumRetries int
 
+	// TraceContext indicates that the target table has a nullable `trace_context: jsonb` column holding the
+	// W3C trace context of the operation that enqueued each record (see NewTraceContext). Workers processing
+	// records of such a store continue the trace of the enqueueing operation.
+	TraceContext bool
+
+	// Priority indicates that the target table has a `priority: integer not null` column. Records with a higher
+	// priority are dequeued before records with a lower priority; `OrderByExpression` only orders records of
+	// equal priority.
+	Priority bool
+
+	// Deadline indicates that the target table has a nullable `deadline: timestamp with time zone` column. Among
+	// records of equal priority, records with an earlier deadline are dequeued first. Records whose deadline has
+	// passed are never dequeued, and are moved into the failed state by MarkDeadlineExceeded, which is called
+	// periodically by the resetter.
+	Deadline bool
+
 	// clock is used to mock out the wall clock used for heartbeat updates.
 	clock glock.Clock
 }
@@ -341,6 +386,9 @@ var columnNames = []string{
 	"execution_logs",
 	"worker_hostname",
 	"cancel",
+	"trace_context",
+	"priority",
+	"deadline",
 }
 
 // QueuedCount returns the number of queued records matching the given conditions.
@@ -371,6 +419,51 @@ WHERE
 	{state} IN (%s)
 `
 
+// ProcessingCount returns the number of records that are currently in the processing state.
+func (s *store[T]) ProcessingCount(ctx context.Context) (_ int, err error) {
+	ctx, _, endObservation := s.operations.processingCount.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(
+		processingCountQuery,
+		quote(s.options.ViewName),
+	)))
+
+	return count, err
+}
+
+const processingCountQuery = `
+SELECT
+	COUNT(*)
+FROM %s
+WHERE
+	{state} = 'processing'
+`
+
+// FinishedCount returns the number of records that were marked as completed, errored, or failed since the
+// given time.
+func (s *store[T]) FinishedCount(ctx context.Context, since time.Time) (_ int, err error) {
+	ctx, _, endObservation := s.operations.finishedCount.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(
+		finishedCountQuery,
+		quote(s.options.ViewName),
+		since,
+	)))
+
+	return count, err
+}
+
+const finishedCountQuery = `
+SELECT
+	COUNT(*)
+FROM %s
+WHERE
+	{state} IN ('completed', 'errored', 'failed') AND
+	{finished_at} >= %s
+`
+
 // MaxDurationInQueue returns the longest duration for which a job associated with this store instance has
 // been in the queued state (including errored records that can be retried in the future). This method returns
 // a duration of zero if there are no jobs ready for processing.
@@ -492,16 +585,22 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 		s.columnReplacer.Replace("{worker_hostname}"):   workerHostnameExpr,
 	}
 
+	if s.options.Deadline {
+		// Copy to avoid appending to the caller's slice.
+		conditions = append(conditions[:len(conditions):len(conditions)], s.formatQuery("{deadline} IS NULL OR {deadline} > %s", now))
+	}
+	orderByExpression := s.dequeueOrderByExpression()
+
 	records, err := s.options.Scan(s.Query(ctx, s.formatQuery(
 		dequeueQuery,
-		s.options.OrderByExpression,
+		orderByExpression,
 		quote(s.options.ViewName),
 		now,
 		retryAfter,
 		now,
 		retryAfter,
 		makeConditionSuffix(conditions),
-		s.options.OrderByExpression,
+		orderByExpression,
 		quote(s.options.TableName),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
@@ -571,6 +670,25 @@ WHERE
 	{id} IN (SELECT {id} FROM candidate)
 `
 
+// dequeueOrderByExpression returns the expression used to order candidate records on dequeue: by priority
+// and deadline if the store is configured with them, then by the configured `OrderByExpression`.
+func (s *store[T]) dequeueOrderByExpression() *sqlf.Query {
+	var orderBy []*sqlf.Query
+	if s.options.Priority {
+		orderBy = append(orderBy, s.formatQuery("{priority} DESC"))
+	}
+	if s.options.Deadline {
+		orderBy = append(orderBy, s.formatQuery("{deadline} ASC NULLS LAST"))
+	}
+	if len(orderBy) == 0 {
+		return s.options.OrderByExpression
+	}
+	if s.options.OrderByExpression != nil {
+		orderBy = append(orderBy, s.options.OrderByExpression)
+	}
+	return sqlf.Join(orderBy, ", ")
+}
+
 // makeDequeueSelectExpressions constructs the ordered set of SQL expressions that are returned
 // from the dequeue query. This method returns a copy of the configured column expressions slice
 // where expressions referencing one of the column updated by dequeue are replaced by the updated
@@ -828,6 +946,126 @@ RETURNING
 	array_length({execution_logs}, 1)
 `
 
+// AppendExecutionLogEntryOutput appends the given output to the executor log entry with the given ID on the given
+// record. This allows executors to upload the output of long-running commands incrementally. When the record is not
+// found (due to options not matching or the record being deleted), ErrExecutionLogEntryNotUpdated is returned.
+func (s *store[T]) AppendExecutionLogEntryOutput(ctx context.Context, recordID, entryID int, out string, options ExecutionLogEntryOptions) (err error) {
+	ctx, _, endObservation := s.operations.appendExecutionLogOut.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("recordID", recordID),
+		attribute.Int("entryID", entryID),
+		attribute.Int("outLen", len(out)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	conds := []*sqlf.Query{
+		s.formatQuery("{id} = %s", recordID),
+		s.formatQuery("array_length({execution_logs}, 1) >= %s", entryID),
+	}
+	conds = append(conds, options.ToSQLConds(s.formatQuery)...)
+
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(
+		appendExecutionLogEntryOutputQuery,
+		quote(s.options.TableName),
+		entryID,
+		entryID,
+		entryID,
+		out,
+		sqlf.Join(conds, "AND"),
+	)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		s.logger.Error("appendExecutionLogEntryOutput failed and didn't match rows",
+			log.Int("recordID", recordID),
+			log.Int("entryID", entryID),
+			log.String("options.workerHostname", options.WorkerHostname),
+			log.String("options.state", options.State),
+		)
+
+		return ErrExecutionLogEntryNotUpdated
+	}
+
+	return nil
+}
+
+const appendExecutionLogEntryOutputQuery = `
+UPDATE
+	%s
+SET {execution_logs}[%s] = jsonb_set(
+	{execution_logs}[%s]::jsonb,
+	'{out}',
+	to_jsonb(COALESCE({execution_logs}[%s]->>'out', '') || %s)
+)::json
+WHERE
+	%s
+RETURNING
+	array_length({execution_logs}, 1)
+`
+
+// ExecutionLogs returns the executor log entries and the state of the record with the given identifier. A false-valued
+// flag is returned if the record does not exist.
+func (s *store[T]) ExecutionLogs(ctx context.Context, id int) (_ []executor.ExecutionLogEntry, _ string, _ bool, err error) {
+	ctx, _, endObservation := s.operations.executionLogs.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rows, err := s.Query(ctx, s.formatQuery(executionLogsQuery, quote(s.options.TableName), id))
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if !rows.Next() {
+		return nil, "", false, nil
+	}
+
+	var (
+		entries []executor.ExecutionLogEntry
+		state   string
+	)
+	if err := rows.Scan(pq.Array(&entries), &state); err != nil {
+		return nil, "", false, err
+	}
+	return entries, state, true, nil
+}
+
+const executionLogsQuery = `
+SELECT
+	{execution_logs},
+	{state}
+FROM %s
+WHERE {id} = %s
+`
+
+// TraceContext returns the span context of the operation that enqueued the record with the given identifier.
+func (s *store[T]) TraceContext(ctx context.Context, id int) (_ oteltrace.SpanContext, err error) {
+	if !s.options.TraceContext {
+		return oteltrace.SpanContext{}, nil
+	}
+
+	ctx, _, endObservation := s.operations.traceContext.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var tc TraceContext
+	if err := s.QueryRow(ctx, s.formatQuery(traceContextQuery, quote(s.options.TableName), id)).Scan(&tc); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return oteltrace.SpanContext{}, nil
+		}
+		return oteltrace.SpanContext{}, err
+	}
+	return tc.SpanContext(), nil
+}
+
+const traceContextQuery = `
+SELECT {trace_context}
+FROM %s
+WHERE {id} = %s
+`
+
 // MarkComplete attempts to update the state of the record to complete. If this record has already been moved from
 // the processing state to a terminal state, this method will have no effect. This method returns a boolean flag
 // indicating if the record was updated.
@@ -1028,6 +1266,51 @@ WHERE {id} IN (SELECT {id} FROM stalled)
 RETURNING {id}, {last_heartbeat_at}
 `
 
+const defaultDeadlineExceededMessage = "job did not complete before its deadline"
+
+// MarkDeadlineExceeded moves all queued and errored records whose deadline has passed into the failed
+// state, and returns their identifiers. This method has no effect if the store is not configured with
+// deadlines.
+func (s *store[T]) MarkDeadlineExceeded(ctx context.Context) (_ []int, err error) {
+	if !s.options.Deadline {
+		return nil, nil
+	}
+
+	ctx, trace, endObservation := s.operations.markDeadlineExceeded.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	ids, err := basestore.ScanInts(s.Query(ctx, s.formatQuery(
+		markDeadlineExceededQuery,
+		quote(s.options.TableName),
+		s.now(),
+		quote(s.options.TableName),
+		defaultDeadlineExceededMessage,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numExpiredIDs", len(ids)))
+
+	return ids, nil
+}
+
+const markDeadlineExceededQuery = `
+WITH expired AS (
+	SELECT {id} FROM %s
+	WHERE
+		{state} IN ('queued', 'errored') AND
+		{deadline} <= %s
+	FOR UPDATE SKIP LOCKED
+)
+UPDATE %s
+SET
+	{state} = 'failed',
+	{finished_at} = clock_timestamp(),
+	{failure_message} = %s
+WHERE {id} IN (SELECT {id} FROM expired)
+RETURNING {id}
+`
+
 func (s *store[T]) formatQuery(query string, args ...any) *sqlf.Query {
 	return sqlf.Sprintf(s.columnReplacer.Replace(query), args...)
 }
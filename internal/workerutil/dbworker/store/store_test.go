@@ -90,6 +90,54 @@ func TestStoreQueuedCountFailed(t *testing.T) {
 	}
 }
 
+func TestStoreProcessingCount(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(1, 'queued'),
+			(2, 'processing'),
+			(3, 'processing'),
+			(4, 'completed')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	count, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).ProcessingCount(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting processing count: %s", err)
+	}
+	if count != 2 {
+		t.Errorf("unexpected count. want=%d have=%d", 2, count)
+	}
+}
+
+func TestStoreFinishedCount(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at)
+		VALUES
+			(1, 'completed', NOW() - '1 minute'::interval),
+			(2, 'failed', NOW() - '2 minutes'::interval),
+			(3, 'errored', NOW() - '3 minutes'::interval),
+			(4, 'completed', NOW() - '20 minutes'::interval), -- too old
+			(5, 'processing', NULL),
+			(6, 'queued', NULL)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	count, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).FinishedCount(context.Background(), time.Now().Add(-10*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error getting finished count: %s", err)
+	}
+	if count != 3 {
+		t.Errorf("unexpected count. want=%d have=%d", 3, count)
+	}
+}
+
 func TestStoreMaxDurationInQueue(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -216,6 +264,64 @@ func TestStoreDequeueOrder(t *testing.T) {
 	assertDequeueRecordResult(t, 2, record, ok, err)
 }
 
+func TestStoreDequeuePriority(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at, priority)
+		VALUES
+			(1, 'queued', NOW() - '2 minute'::interval, 1),
+			(2, 'queued', NOW() - '5 minute'::interval, 0),
+			(3, 'queued', NOW() - '3 minute'::interval, 1),
+			(4, 'queued', NOW() - '1 minute'::interval, 2),
+			(5, 'state2', NOW() - '4 minute'::interval, 3)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.Priority = true
+	store := testStore(db, options)
+
+	for _, expectedID := range []int{4, 3, 1, 2} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+}
+
+func TestStoreDequeueDeadline(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at, priority, deadline)
+		VALUES
+			(1, 'queued', NOW() - '5 minute'::interval, 0, NULL),
+			(2, 'queued', NOW() - '4 minute'::interval, 0, NOW() + '2 hour'::interval),
+			(3, 'queued', NOW() - '3 minute'::interval, 0, NOW() + '1 hour'::interval),
+			(4, 'queued', NOW() - '2 minute'::interval, 0, NOW() - '1 minute'::interval),
+			(5, 'queued', NOW() - '1 minute'::interval, 1, NULL)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.Priority = true
+	options.Deadline = true
+	store := testStore(db, options)
+
+	// Priority comes first, then the earliest deadline. Record 4 is past its deadline.
+	for _, expectedID := range []int{5, 3, 2, 1} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+
+	if _, ok, err := store.Dequeue(context.Background(), "test", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if ok {
+		t.Fatalf("did not expect a record past its deadline to be dequeued")
+	}
+}
+
 func TestStoreDequeueConditions(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -617,6 +723,66 @@ func TestStoreUpdateExecutionLogEntryUnknownEntry(t *testing.T) {
 	}
 }
 
+func TestStoreAppendExecutionLogEntryOutput(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(1, 'processing')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	store := testStore(db, defaultTestStoreOptions(nil, testScanRecord))
+	entry := executor.ExecutionLogEntry{Command: []string{"ls", "-a"}}
+	entryID, err := store.AddExecutionLogEntry(context.Background(), 1, entry, ExecutionLogEntryOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error adding executor log entry: %s", err)
+	}
+
+	for _, out := range []string{"stdout: a\n", "stdout: b\n", "stderr: c\n"} {
+		if err := store.AppendExecutionLogEntryOutput(context.Background(), 1, entryID, out, ExecutionLogEntryOptions{}); err != nil {
+			t.Fatalf("unexpected error appending executor log entry output: %s", err)
+		}
+	}
+
+	entries, state, ok, err := store.ExecutionLogs(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error fetching execution logs: %s", err)
+	}
+	if !ok {
+		t.Fatal("expected record to exist")
+	}
+	if state != "processing" {
+		t.Errorf("unexpected state. want=%q have=%q", "processing", state)
+	}
+
+	expected := []executor.ExecutionLogEntry{{
+		Command: []string{"ls", "-a"},
+		Out:     "stdout: a\nstdout: b\nstderr: c\n",
+	}}
+	if diff := cmp.Diff(expected, entries); diff != "" {
+		t.Errorf("unexpected entries (-want +got):\n%s", diff)
+	}
+
+	if err := store.AppendExecutionLogEntryOutput(context.Background(), 1, entryID+1, "out", ExecutionLogEntryOptions{}); err != ErrExecutionLogEntryNotUpdated {
+		t.Fatalf("unexpected error appending to unknown entry. want=%q have=%q", ErrExecutionLogEntryNotUpdated, err)
+	}
+}
+
+func TestStoreExecutionLogsNoRecord(t *testing.T) {
+	db := setupStoreTest(t)
+
+	_, _, ok, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).ExecutionLogs(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error fetching execution logs: %s", err)
+	}
+	if ok {
+		t.Fatal("unexpected record")
+	}
+}
+
 func TestStoreMarkComplete(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -1013,6 +1179,64 @@ func TestStoreResetStalled(t *testing.T) {
 	}
 }
 
+func TestStoreMarkDeadlineExceeded(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, deadline)
+		VALUES
+			(1, 'queued', NOW() - '1 minute'::interval),
+			(2, 'processing', NOW() - '1 minute'::interval),
+			(3, 'queued', NOW() + '1 minute'::interval),
+			(4, 'queued', NULL),
+			(5, 'completed', NOW() - '1 minute'::interval),
+			(6, 'errored', NOW() - '1 minute'::interval),
+			(7, 'errored', NOW() + '1 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	if ids, err := testStore(db, options).MarkDeadlineExceeded(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if len(ids) != 0 {
+		t.Fatalf("expected no records to be marked without deadlines configured, got %v", ids)
+	}
+
+	options.Deadline = true
+	ids, err := testStore(db, options).MarkDeadlineExceeded(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error marking records: %s", err)
+	}
+	sort.Ints(ids)
+
+	if diff := cmp.Diff([]int{1, 6}, ids); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+
+	for _, id := range []int{1, 6} {
+		var state, failureMessage string
+		if err := db.QueryRowContext(context.Background(), `SELECT state, failure_message FROM workerutil_test WHERE id = $1`, id).Scan(&state, &failureMessage); err != nil {
+			t.Fatalf("unexpected error querying record: %s", err)
+		}
+		if state != "failed" {
+			t.Errorf("unexpected state for record %d. want=%q have=%q", id, "failed", state)
+		}
+		if failureMessage != defaultDeadlineExceededMessage {
+			t.Errorf("unexpected failure message for record %d. want=%q have=%q", id, defaultDeadlineExceededMessage, failureMessage)
+		}
+	}
+
+	// The record that is being processed is left to its worker.
+	var state string
+	if err := db.QueryRowContext(context.Background(), `SELECT state FROM workerutil_test WHERE id = 2`).Scan(&state); err != nil {
+		t.Fatalf("unexpected error querying record: %s", err)
+	}
+	if state != "processing" {
+		t.Errorf("unexpected state. want=%q have=%q", "processing", state)
+	}
+}
+
 func TestStoreHeartbeat(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// TraceContext is the W3C trace context (https://www.w3.org/TR/trace-context/)
+// of the operation that enqueued a record, as persisted in the trace_context
+// column of stores configured with Options.TraceContext.
+type TraceContext map[string]string
+
+// NewTraceContext returns the trace context of the span in the given context,
+// to be written to the trace_context column when a record is enqueued. It is
+// empty, and written as NULL, if the context does not carry a span.
+func NewTraceContext(ctx context.Context) TraceContext {
+	tc := TraceContext{}
+	propagation.TraceContext{}.Inject(ctx, propagation.MapCarrier(tc))
+	return tc
+}
+
+// SpanContext returns the span context of the operation that enqueued the
+// record. It is invalid if no trace context was persisted.
+func (tc TraceContext) SpanContext() oteltrace.SpanContext {
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier(tc))
+	return oteltrace.SpanContextFromContext(ctx)
+}
+
+// Value implements driver.Valuer.
+func (tc TraceContext) Value() (driver.Value, error) {
+	if len(tc) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(tc)
+}
+
+// Scan implements sql.Scanner.
+func (tc *TraceContext) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*tc = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, tc)
+	case string:
+		return json.Unmarshal([]byte(v), tc)
+	default:
+		return errors.Errorf("value is not a valid trace context: %T", value)
+	}
+}
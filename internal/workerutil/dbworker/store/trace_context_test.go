@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContext(t *testing.T) {
+	t.Run("no span", func(t *testing.T) {
+		tc := NewTraceContext(context.Background())
+		value, err := tc.Value()
+		require.NoError(t, err)
+		require.Nil(t, value)
+
+		var scanned TraceContext
+		require.NoError(t, scanned.Scan(nil))
+		require.False(t, scanned.SpanContext().IsValid())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		spanContext := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+			TraceID:    oteltrace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:     oteltrace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			TraceFlags: oteltrace.FlagsSampled,
+		})
+		tc := NewTraceContext(oteltrace.ContextWithSpanContext(context.Background(), spanContext))
+		value, err := tc.Value()
+		require.NoError(t, err)
+
+		var scanned TraceContext
+		require.NoError(t, scanned.Scan(value))
+		got := scanned.SpanContext()
+		require.Equal(t, spanContext.TraceID(), got.TraceID())
+		require.Equal(t, spanContext.SpanID(), got.SpanID())
+		require.True(t, got.IsSampled())
+		require.True(t, got.IsRemote())
+	})
+}
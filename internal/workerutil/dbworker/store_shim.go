@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/keegancsmith/sqlf"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -15,7 +16,10 @@ type storeShim[T workerutil.Record] struct {
 	store.Store[T]
 }
 
-var _ workerutil.Store[workerutil.Record] = &storeShim[workerutil.Record]{}
+var (
+	_ workerutil.Store[workerutil.Record]            = &storeShim[workerutil.Record]{}
+	_ workerutil.WithTraceContext[workerutil.Record] = &storeShim[workerutil.Record]{}
+)
 
 // newStoreShim wraps the given store in a shim.
 func newStoreShim[T workerutil.Record](store store.Store[T]) workerutil.Store[T] {
@@ -57,6 +61,11 @@ func (s *storeShim[T]) MarkErrored(ctx context.Context, rec T, errorMessage stri
 	return s.Store.MarkErrored(ctx, rec.RecordID(), errorMessage, store.MarkFinalOptions{})
 }
 
+// TraceContext calls into the inner store.
+func (s *storeShim[T]) TraceContext(ctx context.Context, rec T) (oteltrace.SpanContext, error) {
+	return s.Store.TraceContext(ctx, rec.RecordID())
+}
+
 // ErrNotConditions occurs when a PreDequeue handler returns non-sql query extra arguments.
 var ErrNotConditions = errors.New("expected slice of *sqlf.Query values")
 
@@ -2,6 +2,8 @@ package workerutil
 
 import (
 	"context"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // Record is a generic interface for record conforming to the requirements of the store.
@@ -38,3 +40,11 @@ type Store[T Record] interface {
 	// if the record was updated.
 	MarkFailed(ctx context.Context, rec T, failureMessage string) (bool, error)
 }
+
+// WithTraceContext is an extension of the Store interface for stores that persist the trace context of the
+// operation that enqueued a record. Records of such stores are processed in a span continuing that trace.
+type WithTraceContext[T Record] interface {
+	// TraceContext returns the span context of the operation that enqueued the record. The returned span
+	// context is invalid if none was persisted with the record.
+	TraceContext(ctx context.Context, rec T) (oteltrace.SpanContext, error)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/derision-test/glock"
 	"github.com/sourcegraph/log"
 	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine/recorder"
@@ -309,12 +310,21 @@ func (w *Worker[T]) dequeueAndHandle() (dequeued bool, err error) {
 		return false, nil
 	}
 
-	// Create context and span based on the root context
-	workerSpan, workerCtxWithSpan := trace.New(
-		// TODO tail-based sampling once its a thing, until then, we can configure on a per-job basis
-		policy.WithShouldTrace(w.rootCtx, w.options.Metrics.traceSampler(record)),
-		w.options.Name,
-	)
+	// Create context and span based on the root context, continuing the trace of the
+	// operation that enqueued the record if the store persists it.
+	rootCtx := w.rootCtx
+	// TODO tail-based sampling once its a thing, until then, we can configure on a per-job basis
+	shouldTrace := w.options.Metrics.traceSampler(record)
+	if s, ok := w.store.(WithTraceContext[T]); ok {
+		spanContext, err := s.TraceContext(w.dequeueCtx, record)
+		if err != nil {
+			w.options.Metrics.logger.Warn("Failed to get trace context of record", log.String("id", record.RecordUID()), log.Error(err))
+		} else if spanContext.IsValid() {
+			rootCtx = oteltrace.ContextWithRemoteSpanContext(rootCtx, spanContext)
+			shouldTrace = shouldTrace || spanContext.IsSampled()
+		}
+	}
+	workerSpan, workerCtxWithSpan := trace.New(policy.WithShouldTrace(rootCtx, shouldTrace), w.options.Name)
 	handleCtx, cancel := context.WithCancel(workerCtxWithSpan)
 	processLog := trace.Logger(workerCtxWithSpan, w.options.Metrics.logger)
 
@@ -10,10 +10,14 @@ import (
 	"time"
 
 	"github.com/derision-test/glock"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace/policy"
+	"github.com/sourcegraph/sourcegraph/internal/trace/tracetest"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -151,6 +155,58 @@ func TestWorkerHandlerNonRetryableFailure(t *testing.T) {
 	}
 }
 
+type mockStoreWithTraceContext struct {
+	*MockStore[*TestRecord]
+	spanContext oteltrace.SpanContext
+}
+
+func (s mockStoreWithTraceContext) TraceContext(context.Context, *TestRecord) (oteltrace.SpanContext, error) {
+	return s.spanContext, nil
+}
+
+func TestWorkerHandlerContinuesTrace(t *testing.T) {
+	spanContext := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     oteltrace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: oteltrace.FlagsSampled,
+		Remote:     true,
+	})
+	store := mockStoreWithTraceContext{MockStore: NewMockStore[*TestRecord](), spanContext: spanContext}
+	handler := NewMockHandler[*TestRecord]()
+	dequeueClock := glock.NewMockClock()
+	heartbeatClock := glock.NewMockClock()
+	shutdownClock := glock.NewMockClock()
+	tracetest.ConfigureStaticTracerProvider(t)
+	observationCtx := observation.NewContext(log.NoOp(), observation.Metrics(metrics.NoOpRegisterer))
+	options := WorkerOptions{
+		Name:           "test",
+		WorkerHostname: "test",
+		NumHandlers:    1,
+		Interval:       time.Second,
+		Metrics:        NewMetrics(observationCtx, ""),
+	}
+
+	store.DequeueFunc.PushReturn(&TestRecord{ID: 42}, true, nil)
+	store.DequeueFunc.SetDefaultReturn(nil, false, nil)
+	store.MarkCompleteFunc.SetDefaultReturn(true, nil)
+
+	worker := newWorker(context.Background(), Store[*TestRecord](store), Handler[*TestRecord](handler), options, dequeueClock, heartbeatClock, shutdownClock)
+	go func() { worker.Start() }()
+	dequeueClock.BlockingAdvance(time.Second)
+	worker.Stop()
+
+	if callCount := len(handler.HandleFunc.History()); callCount != 1 {
+		t.Fatalf("unexpected handle call count. want=%d have=%d", 1, callCount)
+	}
+	ctx := handler.HandleFunc.History()[0].Arg0
+	if traceID := oteltrace.SpanContextFromContext(ctx).TraceID(); traceID != spanContext.TraceID() {
+		t.Errorf("unexpected trace id. want=%s have=%s", spanContext.TraceID(), traceID)
+	}
+	if !policy.ShouldTrace(ctx) {
+		t.Errorf("expected record enqueued in a sampled trace to be traced")
+	}
+}
+
 func TestWorkerConcurrent(t *testing.T) {
 	NumTestRecords := 50
 
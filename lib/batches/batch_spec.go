@@ -72,6 +72,7 @@ type WorkspaceConfiguration struct {
 type OnQueryOrRepository struct {
 	RepositoriesMatchingQuery string   `json:"repositoriesMatchingQuery,omitempty" yaml:"repositoriesMatchingQuery"`
 	Repository                string   `json:"repository,omitempty" yaml:"repository"`
+	View                      string   `json:"view,omitempty" yaml:"view"`
 	Branch                    string   `json:"branch,omitempty" yaml:"branch"`
 	Branches                  []string `json:"branches,omitempty" yaml:"branches"`
 }
@@ -187,6 +188,8 @@ func (on *OnQueryOrRepository) String() string {
 		return on.RepositoriesMatchingQuery
 	} else if on.Repository != "" {
 		return "repository:" + on.Repository
+	} else if on.View != "" {
+		return "view:" + on.View
 	}
 
 	return fmt.Sprintf("%v", *on)
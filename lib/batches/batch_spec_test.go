@@ -36,6 +36,35 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("valid with view", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - view: web
+    branch: main
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: false
+  fork: false
+`
+
+		parsed, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		if have, want := parsed.On[0].View, "web"; have != want {
+			t.Fatalf("wrong view. want=%q, have=%q", want, have)
+		}
+	})
+
 	t.Run("missing changesetTemplate", func(t *testing.T) {
 		const spec = `
 name: hello-world
@@ -12,15 +12,9 @@ import (
 //
 // It returns either nil, in case the input is valid, or an error.
 func Validate(schema string, input []byte) error {
-	sl := gojsonschema.NewSchemaLoader()
-	sc, err := sl.Compile(gojsonschema.NewStringLoader(schema))
+	res, err := validate(schema, input)
 	if err != nil {
-		return errors.Wrap(err, "failed to compile JSON schema")
-	}
-
-	res, err := sc.Validate(gojsonschema.NewBytesLoader(input))
-	if err != nil {
-		return errors.Wrap(err, "failed to validate input against schema")
+		return err
 	}
 
 	var errs error
@@ -34,3 +28,47 @@ func Validate(schema string, input []byte) error {
 
 	return errs
 }
+
+// FieldError is a single violation of a JSON schema.
+type FieldError struct {
+	// Field is the dot-separated path to the offending value, e.g. `steps.0.run`.
+	// It is empty for violations of the root object.
+	Field string
+	// Description is a human-readable explanation of the violation.
+	Description string
+}
+
+// ValidateFields validates the given input against the JSON schema and returns
+// every violation along with the path of the offending value.
+func ValidateFields(schema string, input []byte) ([]FieldError, error) {
+	res, err := validate(schema, input)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldErrors := make([]FieldError, 0, len(res.Errors()))
+	for _, err := range res.Errors() {
+		field := err.Field()
+		if field == gojsonschema.STRING_CONTEXT_ROOT {
+			field = ""
+		}
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Description: err.Description()})
+	}
+
+	return fieldErrors, nil
+}
+
+func validate(schema string, input []byte) (*gojsonschema.Result, error) {
+	sl := gojsonschema.NewSchemaLoader()
+	sc, err := sl.Compile(gojsonschema.NewStringLoader(schema))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compile JSON schema")
+	}
+
+	res, err := sc.Validate(gojsonschema.NewBytesLoader(input))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to validate input against schema")
+	}
+
+	return res, nil
+}
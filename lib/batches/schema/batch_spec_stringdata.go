@@ -83,6 +83,24 @@ const BatchSpecJSON = `{
                 }
               }
             ]
+          },
+          {
+            "title": "OnView",
+            "type": "object",
+            "description": "A repository view whose parent repository (and branch) is added to the list of repositories that the batch change will be run on. Workspaces are limited to the paths of the view.",
+            "additionalProperties": false,
+            "required": ["view"],
+            "properties": {
+              "view": {
+                "type": "string",
+                "description": "The name of the repository view (as it is known to Sourcegraph).",
+                "examples": ["web"]
+              },
+              "branch": {
+                "description": "The branch of the parent repository to propose changes to. If unset, the repository's default branch is used.",
+                "type": "string"
+              }
+            }
           }
         ]
       }
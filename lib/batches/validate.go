@@ -0,0 +1,223 @@
+package batches
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/jsonschema"
+	"github.com/sourcegraph/sourcegraph/lib/batches/schema"
+)
+
+// SpecErrorKind describes which check of the batch spec validation failed.
+type SpecErrorKind string
+
+const (
+	// SpecErrorKindSyntax is used for input that is not valid YAML or JSON.
+	SpecErrorKindSyntax SpecErrorKind = "SYNTAX"
+	// SpecErrorKindSchema is used for input that does not match the batch spec schema.
+	SpecErrorKindSchema SpecErrorKind = "SCHEMA"
+	// SpecErrorKindOnQuery is used for `on.repositoriesMatchingQuery` values that
+	// are not valid search queries.
+	SpecErrorKindOnQuery SpecErrorKind = "ON_QUERY"
+	// SpecErrorKindStepImage is used for step containers that are not valid image
+	// references.
+	SpecErrorKindStepImage SpecErrorKind = "STEP_IMAGE"
+	// SpecErrorKindMount is used for invalid step mount declarations.
+	SpecErrorKindMount SpecErrorKind = "MOUNT"
+)
+
+// SpecError is a single problem found by ValidateBatchSpec.
+type SpecError struct {
+	Kind    SpecErrorKind
+	Message string
+	// Path is the location of the offending value within the spec, such as
+	// `steps[0].container`. It is empty for problems with the spec as a whole.
+	Path string
+	// Line and Column are the 1-based position of the offending value in the
+	// raw spec, or zero if the position is unknown.
+	Line   int
+	Column int
+}
+
+// ValidateBatchSpec validates the given raw batch spec without executing it. Unlike
+// ParseBatchSpec, it does not stop at the first class of problems but returns all
+// problems that could be found, each positioned within the raw input.
+//
+// Checks that depend on the Sourcegraph instance, such as the syntax of search
+// queries, are not performed. The parsed spec is returned along with the problems
+// so that callers can perform them, or nil if the spec could not be parsed at all.
+func ValidateBatchSpec(data []byte) (*BatchSpec, []SpecError) {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &root); err != nil {
+		line, _ := yamlErrorLine(err)
+		return nil, []SpecError{{Kind: SpecErrorKindSyntax, Message: err.Error(), Line: line}}
+	}
+
+	normalized, err := yaml.YAMLToJSONCustom(data, yamlv3.Unmarshal)
+	if err != nil {
+		return nil, []SpecError{{Kind: SpecErrorKindSyntax, Message: err.Error()}}
+	}
+
+	locator := newSpecLocator(&root)
+
+	fieldErrors, err := jsonschema.ValidateFields(schema.BatchSpecJSON, normalized)
+	if err != nil {
+		return nil, []SpecError{{Kind: SpecErrorKindSchema, Message: err.Error()}}
+	}
+
+	var specErrors []SpecError
+	for _, fieldError := range fieldErrors {
+		specErrors = append(specErrors, locator.ErrorAt(SpecErrorKindSchema, fieldError.Description, strings.Split(fieldError.Field, ".")...))
+	}
+
+	var spec BatchSpec
+	if err := json.Unmarshal(normalized, &spec); err != nil {
+		// Schema violations usually explain why the spec does not unmarshal.
+		if len(specErrors) == 0 {
+			specErrors = append(specErrors, SpecError{Kind: SpecErrorKindSchema, Message: err.Error()})
+		}
+		return nil, specErrors
+	}
+
+	if len(spec.Steps) != 0 && spec.ChangesetTemplate == nil {
+		specErrors = append(specErrors, locator.ErrorAt(SpecErrorKindSchema, "batch spec includes steps but no changesetTemplate", "steps"))
+	}
+
+	for i, step := range spec.Steps {
+		stepIndex := strconv.Itoa(i)
+
+		if step.Container != "" && !strings.Contains(step.Container, "${{") && !imageReferencePattern.MatchString(step.Container) {
+			specErrors = append(specErrors, locator.ErrorAt(SpecErrorKindStepImage, fmt.Sprintf("%q is not a valid image reference", step.Container), "steps", stepIndex, "container"))
+		}
+
+		for j, mount := range step.Mount {
+			mountIndex := strconv.Itoa(j)
+
+			if strings.Contains(mount.Path, invalidMountCharacters) {
+				specErrors = append(specErrors, locator.ErrorAt(SpecErrorKindMount, fmt.Sprintf("step %d mount path contains invalid characters", i+1), "steps", stepIndex, "mount", mountIndex, "path"))
+			} else if cleaned := path.Clean(mount.Path); cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+				specErrors = append(specErrors, locator.ErrorAt(SpecErrorKindMount, fmt.Sprintf("step %d mount path must not be outside of the directory of the batch spec", i+1), "steps", stepIndex, "mount", mountIndex, "path"))
+			}
+			if strings.Contains(mount.Mountpoint, invalidMountCharacters) {
+				specErrors = append(specErrors, locator.ErrorAt(SpecErrorKindMount, fmt.Sprintf("step %d mount mountpoint contains invalid characters", i+1), "steps", stepIndex, "mount", mountIndex, "mountpoint"))
+			}
+		}
+	}
+
+	return &spec, specErrors
+}
+
+// imageReferencePattern matches Docker image references of the form
+// [registry[:port]/]name[:tag][@digest].
+var imageReferencePattern = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9.-]*[a-zA-Z0-9])?(?::[0-9]+)?/)?[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*(?::[\w][\w.-]{0,127})?(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`)
+
+var yamlErrorLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// yamlErrorLine extracts the line number from an error returned by the YAML parser.
+func yamlErrorLine(err error) (int, bool) {
+	if match := yamlErrorLinePattern.FindStringSubmatch(err.Error()); match != nil {
+		line, err := strconv.Atoi(match[1])
+		return line, err == nil
+	}
+
+	return 0, false
+}
+
+// SpecLocator maps paths within a batch spec to positions in its raw input.
+type SpecLocator struct {
+	root *yamlv3.Node
+}
+
+// NewSpecLocator returns a locator for the given raw batch spec. Positions are
+// unknown if the input cannot be parsed.
+func NewSpecLocator(data []byte) *SpecLocator {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &root); err != nil {
+		return newSpecLocator(nil)
+	}
+
+	return newSpecLocator(&root)
+}
+
+func newSpecLocator(root *yamlv3.Node) *SpecLocator {
+	return &SpecLocator{root: root}
+}
+
+// ErrorAt returns a SpecError of the given kind positioned at the value with the
+// given path segments, e.g. "steps", "0", "container".
+func (l *SpecLocator) ErrorAt(kind SpecErrorKind, message string, segments ...string) SpecError {
+	var filtered []string
+	for _, segment := range segments {
+		if segment != "" {
+			filtered = append(filtered, segment)
+		}
+	}
+
+	specError := SpecError{Kind: kind, Message: message, Path: formatSpecPath(filtered)}
+	if node := l.find(filtered); node != nil {
+		specError.Line = node.Line
+		specError.Column = node.Column
+	}
+
+	return specError
+}
+
+// find returns the deepest node along the given path. Missing values are
+// attributed to their closest existing parent.
+func (l *SpecLocator) find(segments []string) *yamlv3.Node {
+	if l.root == nil {
+		return nil
+	}
+
+	node := l.root
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+outer:
+	for _, segment := range segments {
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					node = node.Content[i+1]
+					continue outer
+				}
+			}
+		case yamlv3.SequenceNode:
+			if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(node.Content) {
+				node = node.Content[index]
+				continue outer
+			}
+		}
+
+		break
+	}
+
+	return node
+}
+
+// formatSpecPath formats path segments as `steps[0].container`.
+func formatSpecPath(segments []string) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil {
+			fmt.Fprintf(&b, "[%s]", segment)
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package batches
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateBatchSpec(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		const spec = `name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: ghcr.io/sourcegraph/alpine:3.18@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+    mount:
+      - path: ./scripts/hello.sh
+        mountpoint: /tmp/hello.sh
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+`
+
+		parsed, specErrors := ValidateBatchSpec([]byte(spec))
+		if len(specErrors) != 0 {
+			t.Fatalf("unexpected errors: %v", specErrors)
+		}
+		if parsed == nil || parsed.Name != "hello-world" {
+			t.Fatalf("unexpected spec: %v", parsed)
+		}
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		const spec = `name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+  bad indentation: true
+`
+
+		parsed, specErrors := ValidateBatchSpec([]byte(spec))
+		if parsed != nil {
+			t.Fatalf("unexpected spec: %v", parsed)
+		}
+		if len(specErrors) != 1 || specErrors[0].Kind != SpecErrorKindSyntax || specErrors[0].Line != 2 {
+			t.Fatalf("unexpected errors: %v", specErrors)
+		}
+	})
+
+	t.Run("positioned errors", func(t *testing.T) {
+		const spec = `name: hello world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World
+    container: Not A Valid Image
+    mount:
+      - path: ../secrets
+        mountpoint: /tmp/secrets,
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+`
+
+		_, specErrors := ValidateBatchSpec([]byte(spec))
+		for i := range specErrors {
+			// Messages of the schema library are not under test here
+			if specErrors[i].Kind == SpecErrorKindSchema {
+				specErrors[i].Message = ""
+			}
+		}
+
+		expected := []SpecError{
+			{Kind: SpecErrorKindSchema, Path: "name", Line: 1, Column: 7},
+			{Kind: SpecErrorKindStepImage, Message: `"Not A Valid Image" is not a valid image reference`, Path: "steps[0].container", Line: 6, Column: 16},
+			{Kind: SpecErrorKindMount, Message: "step 1 mount path must not be outside of the directory of the batch spec", Path: "steps[0].mount[0].path", Line: 8, Column: 15},
+			{Kind: SpecErrorKindMount, Message: "step 1 mount mountpoint contains invalid characters", Path: "steps[0].mount[0].mountpoint", Line: 9, Column: 21},
+		}
+		if diff := cmp.Diff(expected, specErrors); diff != "" {
+			t.Errorf("unexpected errors (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestSpecLocator(t *testing.T) {
+	const spec = `name: hello-world
+on:
+  - repository: github.com/sourcegraph/sourcegraph
+  - repositoriesMatchingQuery: repo:foo
+`
+
+	locator := NewSpecLocator([]byte(spec))
+
+	specError := locator.ErrorAt(SpecErrorKindOnQuery, "invalid query", "on", "1", "repositoriesMatchingQuery")
+	if diff := cmp.Diff(SpecError{Kind: SpecErrorKindOnQuery, Message: "invalid query", Path: "on[1].repositoriesMatchingQuery", Line: 4, Column: 32}, specError); diff != "" {
+		t.Errorf("unexpected error (-want +got):\n%s", diff)
+	}
+
+	// Missing values are attributed to their closest parent
+	specError = locator.ErrorAt(SpecErrorKindSchema, "missing", "on", "5")
+	if specError.Line != 3 || specError.Column != 3 {
+		t.Errorf("unexpected position. want=3:3 have=%d:%d", specError.Line, specError.Column)
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS codeintel_scip_processing_checkpoints;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS codeintel_scip_processing_checkpoints (
+    upload_id integer PRIMARY KEY,
+    num_documents integer NOT NULL,
+    next_symbol_name_id integer NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE codeintel_scip_processing_checkpoints IS 'Progress of uploads whose documents are being written to the codeintel-db, used to resume processing after a failure.';
+COMMENT ON COLUMN codeintel_scip_processing_checkpoints.num_documents IS 'The number of documents of the upload that have been committed, in index order.';
+COMMENT ON COLUMN codeintel_scip_processing_checkpoints.next_symbol_name_id IS 'The next identifier to assign to a row of codeintel_scip_symbol_names for this upload.';
@@ -211,6 +211,19 @@ CREATE SEQUENCE codeintel_scip_metadata_id_seq
 
 ALTER SEQUENCE codeintel_scip_metadata_id_seq OWNED BY codeintel_scip_metadata.id;
 
+CREATE TABLE codeintel_scip_processing_checkpoints (
+    upload_id integer NOT NULL,
+    num_documents integer NOT NULL,
+    next_symbol_name_id integer NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE codeintel_scip_processing_checkpoints IS 'Progress of uploads whose documents are being written to the codeintel-db, used to resume processing after a failure.';
+
+COMMENT ON COLUMN codeintel_scip_processing_checkpoints.num_documents IS 'The number of documents of the upload that have been committed, in index order.';
+
+COMMENT ON COLUMN codeintel_scip_processing_checkpoints.next_symbol_name_id IS 'The next identifier to assign to a row of codeintel_scip_symbol_names for this upload.';
+
 CREATE TABLE codeintel_scip_symbol_names (
     id integer NOT NULL,
     upload_id integer NOT NULL,
@@ -362,6 +375,9 @@ ALTER TABLE ONLY codeintel_scip_documents
 ALTER TABLE ONLY codeintel_scip_metadata
     ADD CONSTRAINT codeintel_scip_metadata_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY codeintel_scip_processing_checkpoints
+    ADD CONSTRAINT codeintel_scip_processing_checkpoints_pkey PRIMARY KEY (upload_id);
+
 ALTER TABLE ONLY codeintel_scip_symbol_names
     ADD CONSTRAINT codeintel_scip_symbol_names_pkey PRIMARY KEY (upload_id, id);
 
@@ -0,0 +1 @@
+ALTER TABLE repo_kvps DROP COLUMN IF EXISTS source;
@@ -0,0 +1,3 @@
+ALTER TABLE repo_kvps ADD COLUMN IF NOT EXISTS source text NOT NULL DEFAULT 'manual';
+
+COMMENT ON COLUMN repo_kvps.source IS 'Where the key-value pair comes from: manual if it was set by a user, code_host if it was synced from the code host, for example from GitHub topics.';
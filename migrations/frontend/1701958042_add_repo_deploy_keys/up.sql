@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS repo_deploy_keys (
+    repo_id integer NOT NULL PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    public_key text NOT NULL,
+    private_key text NOT NULL,
+    passphrase text NOT NULL,
+    encryption_key_id text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repo_deploy_keys IS 'SSH deploy keys that gitserver uses to clone and fetch individual repositories from generic Git code hosts.';
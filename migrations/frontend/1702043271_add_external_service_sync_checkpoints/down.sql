@@ -0,0 +1 @@
+DROP TABLE IF EXISTS external_service_sync_checkpoints;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS external_service_sync_checkpoints (
+    external_service_id bigint NOT NULL REFERENCES external_services(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    sync_job_id integer NOT NULL,
+    external_id text NOT NULL,
+    PRIMARY KEY (external_service_id, repo_id)
+);
+
+COMMENT ON TABLE external_service_sync_checkpoints IS 'Repositories that the current sync job of an external service has already synced. A sync job that is resumed after a restart skips these repositories.';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS outbound_webhook_dead_letters;
+
+ALTER TABLE outbound_webhook_jobs DROP COLUMN IF EXISTS outbound_webhook_id;
@@ -0,0 +1,24 @@
+ALTER TABLE outbound_webhook_jobs ADD COLUMN IF NOT EXISTS outbound_webhook_id bigint REFERENCES outbound_webhooks(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN outbound_webhook_jobs.outbound_webhook_id IS 'If set, the payload is only sent to this outbound webhook instead of all webhooks registered for the event type. Used to replay dead letters.';
+
+CREATE TABLE IF NOT EXISTS outbound_webhook_dead_letters (
+    id bigserial PRIMARY KEY,
+    outbound_webhook_id bigint NOT NULL REFERENCES outbound_webhooks(id) ON DELETE CASCADE,
+    job_id bigint NOT NULL,
+    event_type text NOT NULL,
+    scope text,
+    encryption_key_id text,
+    payload bytea NOT NULL,
+    attempts integer NOT NULL,
+    last_status_code integer NOT NULL,
+    last_error bytea NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    replayed_at timestamp with time zone,
+    replay_job_id bigint
+);
+
+CREATE INDEX IF NOT EXISTS outbound_webhook_dead_letters_outbound_webhook_id_idx ON outbound_webhook_dead_letters (outbound_webhook_id);
+
+COMMENT ON TABLE outbound_webhook_dead_letters IS 'Outbound webhook payloads that could not be delivered to a webhook after the maximum number of attempts.';
+COMMENT ON COLUMN outbound_webhook_dead_letters.job_id IS 'The outbound webhook job that failed. Jobs are deleted after the webhook log retention period, so this may not reference an existing job.';
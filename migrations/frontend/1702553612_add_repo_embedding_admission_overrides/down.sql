@@ -0,0 +1 @@
+DROP TABLE IF EXISTS repo_embedding_admission_overrides;
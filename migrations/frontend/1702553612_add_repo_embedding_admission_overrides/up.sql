@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS repo_embedding_admission_overrides (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    created_by integer REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repo_embedding_admission_overrides IS 'Repositories that site admins have exempted from the embeddings admission policy.';
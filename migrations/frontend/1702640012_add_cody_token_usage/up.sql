@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS cody_token_usage (
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    feature text NOT NULL,
+    model text NOT NULL,
+    day date NOT NULL,
+    prompt_tokens bigint DEFAULT 0 NOT NULL,
+    completion_tokens bigint DEFAULT 0 NOT NULL,
+    request_count integer DEFAULT 0 NOT NULL,
+    PRIMARY KEY (user_id, feature, model, day)
+);
+
+CREATE INDEX IF NOT EXISTS cody_token_usage_day ON cody_token_usage(day);
+
+COMMENT ON TABLE cody_token_usage IS 'Daily number of LLM tokens used by each user, per completions feature and model.';
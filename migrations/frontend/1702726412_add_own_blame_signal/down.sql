@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS own_aggregate_blame_lines;
+
+DELETE FROM own_signal_configurations
+WHERE name = 'blame';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS own_aggregate_blame_lines (
+    commit_author_id integer NOT NULL REFERENCES commit_authors(id) ON DELETE CASCADE,
+    file_path_id integer NOT NULL REFERENCES repo_paths(id) ON DELETE CASCADE,
+    line_count integer NOT NULL,
+    PRIMARY KEY (file_path_id, commit_author_id)
+);
+
+COMMENT ON TABLE own_aggregate_blame_lines IS 'The number of lines attributed to each author by git blame at the default branch HEAD, aggregated per file and per directory.';
+
+INSERT INTO own_signal_configurations (name, enabled, description)
+VALUES (
+        'blame',
+        FALSE,
+        'Indexes the authors of the lines in each file using git blame at the default branch HEAD.'
+    ) ON CONFLICT DO NOTHING;
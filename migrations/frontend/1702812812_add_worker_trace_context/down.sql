@@ -0,0 +1,3 @@
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS trace_context;
+ALTER TABLE permission_sync_jobs DROP COLUMN IF EXISTS trace_context;
+ALTER TABLE batch_spec_workspace_execution_jobs DROP COLUMN IF EXISTS trace_context;
@@ -0,0 +1,7 @@
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS trace_context jsonb;
+ALTER TABLE permission_sync_jobs ADD COLUMN IF NOT EXISTS trace_context jsonb;
+ALTER TABLE batch_spec_workspace_execution_jobs ADD COLUMN IF NOT EXISTS trace_context jsonb;
+
+COMMENT ON COLUMN lsif_uploads.trace_context IS 'W3C trace context of the request that enqueued the upload for processing.';
+COMMENT ON COLUMN permission_sync_jobs.trace_context IS 'W3C trace context of the operation that enqueued the job.';
+COMMENT ON COLUMN batch_spec_workspace_execution_jobs.trace_context IS 'W3C trace context of the operation that enqueued the job.';
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id bigserial PRIMARY KEY,
+    action text NOT NULL,
+    entity_type text NOT NULL,
+    entity_id text,
+    actor_user_id integer,
+    actor_ip text,
+    actor_user_agent text,
+    metadata jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    exported_at timestamp with time zone
+);
+
+COMMENT ON TABLE audit_events IS 'Contains admin-sensitive actions, such as access token creation or repository permission changes, with a stable schema.';
+COMMENT ON COLUMN audit_events.action IS 'The action that was performed, such as access_token.created.';
+COMMENT ON COLUMN audit_events.entity_type IS 'The type of the entity the action was performed on, such as access_token.';
+COMMENT ON COLUMN audit_events.actor_user_id IS 'The ID of the user who performed the action. Not a foreign key so that events outlive the user.';
+COMMENT ON COLUMN audit_events.exported_at IS 'When the event was exported to all the configured audit log destinations.';
+
+CREATE INDEX IF NOT EXISTS audit_events_created_at ON audit_events USING btree (created_at);
+CREATE INDEX IF NOT EXISTS audit_events_action ON audit_events USING btree (action);
+CREATE INDEX IF NOT EXISTS audit_events_actor_user_id ON audit_events USING btree (actor_user_id);
+CREATE INDEX IF NOT EXISTS audit_events_entity ON audit_events USING btree (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS audit_events_not_exported ON audit_events USING btree (id) WHERE (exported_at IS NULL);
@@ -0,0 +1,4 @@
+ALTER TABLE out_of_band_migrations DROP COLUMN IF EXISTS paused;
+ALTER TABLE out_of_band_migrations DROP COLUMN IF EXISTS rows_processed;
+ALTER TABLE out_of_band_migrations DROP COLUMN IF EXISTS progress_rate;
+ALTER TABLE out_of_band_migrations DROP COLUMN IF EXISTS progress_updated_at;
@@ -0,0 +1,9 @@
+ALTER TABLE out_of_band_migrations ADD COLUMN IF NOT EXISTS paused boolean DEFAULT false NOT NULL;
+ALTER TABLE out_of_band_migrations ADD COLUMN IF NOT EXISTS rows_processed bigint DEFAULT 0 NOT NULL;
+ALTER TABLE out_of_band_migrations ADD COLUMN IF NOT EXISTS progress_rate double precision;
+ALTER TABLE out_of_band_migrations ADD COLUMN IF NOT EXISTS progress_updated_at timestamp with time zone;
+
+COMMENT ON COLUMN out_of_band_migrations.paused IS 'Whether the migration has been paused by a site admin. Paused migrations are not run by the instance.';
+COMMENT ON COLUMN out_of_band_migrations.rows_processed IS 'The number of records migrated (in either direction), as reported by the migrator.';
+COMMENT ON COLUMN out_of_band_migrations.progress_rate IS 'The smoothed rate of progress, as a fraction of the migration per second.';
+COMMENT ON COLUMN out_of_band_migrations.progress_updated_at IS 'The date and time the progress of the migration last changed.';
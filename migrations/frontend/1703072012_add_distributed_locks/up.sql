@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS distributed_locks (
+    name text PRIMARY KEY,
+    holder text NOT NULL,
+    fencing_token bigint NOT NULL,
+    acquired_at timestamp with time zone DEFAULT now() NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE distributed_locks IS 'Leases on named locks shared by background workers. Rows are kept after release so that fencing tokens keep increasing.';
+COMMENT ON COLUMN distributed_locks.holder IS 'The instance holding or last holding the lock.';
+COMMENT ON COLUMN distributed_locks.fencing_token IS 'Incremented every time the lock is acquired. Writes guarded by the lock check that the token is still current.';
+COMMENT ON COLUMN distributed_locks.expires_at IS 'When the lease expires unless renewed. The lock is free once this time has passed.';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS lsif_dependency_indexing_jobs_package;
+
+ALTER TABLE lsif_dependency_indexing_jobs DROP COLUMN IF EXISTS package_scheme;
+ALTER TABLE lsif_dependency_indexing_jobs DROP COLUMN IF EXISTS package_name;
+ALTER TABLE lsif_dependency_indexing_jobs DROP COLUMN IF EXISTS package_version;
@@ -0,0 +1,9 @@
+ALTER TABLE lsif_dependency_indexing_jobs ADD COLUMN IF NOT EXISTS package_scheme text NOT NULL DEFAULT '';
+ALTER TABLE lsif_dependency_indexing_jobs ADD COLUMN IF NOT EXISTS package_name text NOT NULL DEFAULT '';
+ALTER TABLE lsif_dependency_indexing_jobs ADD COLUMN IF NOT EXISTS package_version text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN lsif_dependency_indexing_jobs.package_scheme IS 'The scheme of the single package to resolve and index. If empty, all packages referenced by the upload are considered.';
+COMMENT ON COLUMN lsif_dependency_indexing_jobs.package_name IS 'The name of the single package to resolve and index.';
+COMMENT ON COLUMN lsif_dependency_indexing_jobs.package_version IS 'The version of the single package to resolve and index.';
+
+CREATE INDEX IF NOT EXISTS lsif_dependency_indexing_jobs_package ON lsif_dependency_indexing_jobs (package_scheme, package_name, package_version) WHERE package_scheme != '';
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS lsif_uploads_visibility_audit_logs (
+    id SERIAL PRIMARY KEY,
+    log_timestamp timestamp with time zone NOT NULL DEFAULT NOW(),
+    repository_id integer NOT NULL,
+    upload_id integer,
+    operation text NOT NULL,
+    branch_or_tag_name text,
+    is_default_branch boolean,
+    reason text NOT NULL DEFAULT ''
+);
+
+COMMENT ON TABLE lsif_uploads_visibility_audit_logs IS 'Records changes to the set of uploads visible from the tips of branches and tags, as well as the outcome of each commit graph recalculation.';
+COMMENT ON COLUMN lsif_uploads_visibility_audit_logs.upload_id IS 'The upload whose visibility changed. Null for commit graph recalculation entries.';
+COMMENT ON COLUMN lsif_uploads_visibility_audit_logs.operation IS 'One of visible, hidden, commit_graph_updated, or commit_graph_update_failed.';
+COMMENT ON COLUMN lsif_uploads_visibility_audit_logs.branch_or_tag_name IS 'The branch or tag whose tip the upload became visible or hidden from.';
+COMMENT ON COLUMN lsif_uploads_visibility_audit_logs.reason IS 'A human-readable explanation of the change.';
+
+CREATE INDEX IF NOT EXISTS lsif_uploads_visibility_audit_logs_repository_id_log_timestamp ON lsif_uploads_visibility_audit_logs (repository_id, log_timestamp DESC);
+CREATE INDEX IF NOT EXISTS lsif_uploads_visibility_audit_logs_log_timestamp ON lsif_uploads_visibility_audit_logs (log_timestamp);
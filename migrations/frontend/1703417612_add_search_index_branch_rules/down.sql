@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trig_repo_kvps_search_index_branches_touch_repo ON repo_kvps;
+DROP FUNCTION IF EXISTS func_repo_kvps_search_index_branches_touch_repo();
+DROP TABLE IF EXISTS search_index_branch_rules;
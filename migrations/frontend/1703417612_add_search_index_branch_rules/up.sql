@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS search_index_branch_rules (
+    id SERIAL PRIMARY KEY,
+    repo_pattern text NOT NULL,
+    branches text[] NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE search_index_branch_rules IS 'Additional branches indexed by Zoekt for all repositories whose name matches a pattern.';
+COMMENT ON COLUMN search_index_branch_rules.repo_pattern IS 'A regular expression matched against repository names.';
+
+-- The search indexer only reloads the configuration of repositories whose
+-- updated_at changed since it last polled, so changes to the per-repository
+-- branch configuration stored in repo metadata need to touch the repository.
+CREATE OR REPLACE FUNCTION func_repo_kvps_search_index_branches_touch_repo() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        IF OLD.key = 'search.index.branches' THEN
+            UPDATE repo SET updated_at = NOW() WHERE id = OLD.repo_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+
+    IF NEW.key = 'search.index.branches' OR (TG_OP = 'UPDATE' AND OLD.key = 'search.index.branches') THEN
+        UPDATE repo SET updated_at = NOW() WHERE id = NEW.repo_id;
+    END IF;
+    RETURN NEW;
+END $$;
+
+DROP TRIGGER IF EXISTS trig_repo_kvps_search_index_branches_touch_repo ON repo_kvps;
+CREATE TRIGGER trig_repo_kvps_search_index_branches_touch_repo AFTER INSERT OR UPDATE OR DELETE ON repo_kvps FOR EACH ROW EXECUTE FUNCTION func_repo_kvps_search_index_branches_touch_repo();
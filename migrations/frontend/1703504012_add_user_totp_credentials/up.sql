@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS user_totp_credentials (
+    user_id integer NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret text NOT NULL,
+    encryption_key_id text DEFAULT ''::text NOT NULL,
+    recovery_codes text[] DEFAULT '{}'::text[] NOT NULL,
+    last_used_step bigint DEFAULT 0 NOT NULL,
+    enabled_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE user_totp_credentials IS 'TOTP two-factor authentication credentials of users of the builtin auth provider.';
+
+COMMENT ON COLUMN user_totp_credentials.recovery_codes IS 'SHA-256 hashes of the unused recovery codes. Recovery codes are 80-bit random values, so their hashes are not salted.';
+
+COMMENT ON COLUMN user_totp_credentials.last_used_step IS 'The time step of the last accepted code. Codes of this or earlier steps are rejected.';
+
+COMMENT ON COLUMN user_totp_credentials.enabled_at IS 'The time enrollment was confirmed with a valid code. NULL while enrollment is pending.';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS codeintel_ranking_definitions_graph_key_symbol_name;
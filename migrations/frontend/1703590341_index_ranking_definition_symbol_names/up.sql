@@ -0,0 +1,3 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS codeintel_ranking_definitions_graph_key_symbol_name
+ON codeintel_ranking_definitions(graph_key, symbol_name text_pattern_ops)
+WHERE symbol_name <> '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repository_snapshots;
+DROP TABLE IF EXISTS repository_snapshot_schedules;
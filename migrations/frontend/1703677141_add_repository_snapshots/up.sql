@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS repository_snapshot_schedules (
+    id SERIAL PRIMARY KEY,
+    name text NOT NULL,
+    targets jsonb DEFAULT '[]'::jsonb NOT NULL,
+    cron_expression text NOT NULL,
+    enabled boolean DEFAULT true NOT NULL,
+    last_enqueued_at timestamp with time zone,
+    creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repository_snapshot_schedules IS 'Schedules on which snapshots of a set of repositories are exported to the blob store.';
+
+COMMENT ON COLUMN repository_snapshot_schedules.targets IS 'The repositories and revisions to export, as a JSON array of {"repository_id", "revision"} objects.';
+
+COMMENT ON COLUMN repository_snapshot_schedules.last_enqueued_at IS 'The time the last snapshot of this schedule was enqueued. The next snapshot is due at the next time matching the cron expression after this time (or after created_at).';
+
+CREATE TABLE IF NOT EXISTS repository_snapshots (
+    id SERIAL PRIMARY KEY,
+    schedule_id integer REFERENCES repository_snapshot_schedules(id) ON DELETE SET NULL,
+    initiator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    targets jsonb DEFAULT '[]'::jsonb NOT NULL,
+    manifest jsonb,
+    signature text,
+    state text DEFAULT 'queued'::text NOT NULL,
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer DEFAULT 0 NOT NULL,
+    num_failures integer DEFAULT 0 NOT NULL,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text DEFAULT ''::text NOT NULL,
+    cancel boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repository_snapshots IS 'Point-in-time exports of a set of repositories to the blob store.';
+
+COMMENT ON COLUMN repository_snapshots.targets IS 'The repositories and revisions to export, as a JSON array of {"repository_id", "revision"} objects.';
+
+COMMENT ON COLUMN repository_snapshots.manifest IS 'The manifest of the completed snapshot, listing the resolved commit and the SHA-256 checksum of the archive of each repository.';
+
+COMMENT ON COLUMN repository_snapshots.signature IS 'The base64-encoded Ed25519 signature of the manifest as stored in the blob store.';
+
+CREATE INDEX IF NOT EXISTS repository_snapshots_state ON repository_snapshots(state);
+
+CREATE INDEX IF NOT EXISTS repository_snapshots_schedule_id ON repository_snapshots(schedule_id);
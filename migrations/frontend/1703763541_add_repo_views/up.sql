@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS repo_views (
+    id SERIAL PRIMARY KEY,
+    name citext NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    path_globs text[] NOT NULL,
+    creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT repo_views_path_globs_not_empty CHECK ((array_length(path_globs, 1) > 0))
+);
+
+COMMENT ON TABLE repo_views IS 'Virtual repositories defined by path globs over a parent repository.';
+
+COMMENT ON COLUMN repo_views.path_globs IS 'The globs matching the paths of the parent repository that are part of the view, e.g. client/web/**.';
+
+CREATE UNIQUE INDEX IF NOT EXISTS repo_views_name_unique ON repo_views (name);
+
+CREATE INDEX IF NOT EXISTS repo_views_repo_id ON repo_views (repo_id);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS changeset_external_checks (
+    id BIGSERIAL PRIMARY KEY,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    context text NOT NULL,
+    state text NOT NULL,
+    commit_oid text DEFAULT ''::text NOT NULL,
+    target_url text DEFAULT ''::text NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT changeset_external_checks_context_check CHECK ((context <> ''::text)),
+    CONSTRAINT changeset_external_checks_state_check CHECK ((state = ANY (ARRAY['PENDING'::text, 'PASSED'::text, 'FAILED'::text])))
+);
+
+COMMENT ON TABLE changeset_external_checks IS 'Check statuses of changesets reported by external CI systems that the code host does not know about.';
+
+COMMENT ON COLUMN changeset_external_checks.context IS 'The name of the check, e.g. jenkins/build. A changeset has at most one status per context.';
+
+COMMENT ON COLUMN changeset_external_checks.commit_oid IS 'The commit the status was reported for. Statuses for commits other than the head of the changeset are ignored. Empty if the status applies to any commit.';
+
+CREATE UNIQUE INDEX IF NOT EXISTS changeset_external_checks_changeset_id_context ON changeset_external_checks (changeset_id, context);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS distributed_locks_expires_at;
+DROP SEQUENCE IF EXISTS distributed_locks_fencing_token_seq;
+
+COMMENT ON TABLE distributed_locks IS 'Leases on named locks shared by background workers. Rows are kept after release so that fencing tokens keep increasing.';
+COMMENT ON COLUMN distributed_locks.fencing_token IS 'Incremented every time the lock is acquired. Writes guarded by the lock check that the token is still current.';
@@ -0,0 +1,9 @@
+CREATE SEQUENCE IF NOT EXISTS distributed_locks_fencing_token_seq;
+
+-- Tokens drawn from the sequence must be greater than the tokens issued so far.
+SELECT setval('distributed_locks_fencing_token_seq', COALESCE((SELECT MAX(fencing_token) FROM distributed_locks), 0) + 1, false);
+
+CREATE INDEX IF NOT EXISTS distributed_locks_expires_at ON distributed_locks (expires_at);
+
+COMMENT ON TABLE distributed_locks IS 'Leases on named locks shared by background workers. Rows are deleted when the lock is released or some time after the lease expired.';
+COMMENT ON COLUMN distributed_locks.fencing_token IS 'Drawn from distributed_locks_fencing_token_seq every time the lock is acquired, so that tokens keep increasing after rows are deleted. Writes guarded by the lock check that the token is still current.';
@@ -437,6 +437,23 @@ BEGIN
     RETURN NEW;
 END $$;
 
+CREATE FUNCTION func_repo_kvps_search_index_branches_touch_repo() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        IF OLD.key = 'search.index.branches' THEN
+            UPDATE repo SET updated_at = NOW() WHERE id = OLD.repo_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+
+    IF NEW.key = 'search.index.branches' OR (TG_OP = 'UPDATE' AND OLD.key = 'search.index.branches') THEN
+        UPDATE repo SET updated_at = NOW() WHERE id = NEW.repo_id;
+    END IF;
+    RETURN NEW;
+END $$;
+
 CREATE FUNCTION func_row_to_configuration_policies_transition_columns(rec record) RETURNS configuration_policies_transition_columns
     LANGUAGE plpgsql
     AS $$
@@ -960,6 +977,38 @@ CREATE SEQUENCE assigned_teams_id_seq
 
 ALTER SEQUENCE assigned_teams_id_seq OWNED BY assigned_teams.id;
 
+CREATE TABLE audit_events (
+    id bigint NOT NULL,
+    action text NOT NULL,
+    entity_type text NOT NULL,
+    entity_id text,
+    actor_user_id integer,
+    actor_ip text,
+    actor_user_agent text,
+    metadata jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    exported_at timestamp with time zone
+);
+
+COMMENT ON TABLE audit_events IS 'Contains admin-sensitive actions, such as access token creation or repository permission changes, with a stable schema.';
+
+COMMENT ON COLUMN audit_events.action IS 'The action that was performed, such as access_token.created.';
+
+COMMENT ON COLUMN audit_events.entity_type IS 'The type of the entity the action was performed on, such as access_token.';
+
+COMMENT ON COLUMN audit_events.actor_user_id IS 'The ID of the user who performed the action. Not a foreign key so that events outlive the user.';
+
+COMMENT ON COLUMN audit_events.exported_at IS 'When the event was exported to all the configured audit log destinations.';
+
+CREATE SEQUENCE audit_events_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE audit_events_id_seq OWNED BY audit_events.id;
+
 CREATE TABLE batch_changes (
     id bigint NOT NULL,
     name text NOT NULL,
@@ -1072,9 +1121,12 @@ CREATE TABLE batch_spec_workspace_execution_jobs (
     cancel boolean DEFAULT false NOT NULL,
     queued_at timestamp with time zone DEFAULT now(),
     user_id integer NOT NULL,
-    version integer DEFAULT 1 NOT NULL
+    version integer DEFAULT 1 NOT NULL,
+    trace_context jsonb
 );
 
+COMMENT ON COLUMN batch_spec_workspace_execution_jobs.trace_context IS 'W3C trace context of the operation that enqueued the job.';
+
 CREATE SEQUENCE batch_spec_workspace_execution_jobs_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -1366,6 +1418,35 @@ CREATE SEQUENCE changeset_events_id_seq
 
 ALTER SEQUENCE changeset_events_id_seq OWNED BY changeset_events.id;
 
+CREATE TABLE changeset_external_checks (
+    id bigint NOT NULL,
+    changeset_id bigint NOT NULL,
+    context text NOT NULL,
+    state text NOT NULL,
+    commit_oid text DEFAULT ''::text NOT NULL,
+    target_url text DEFAULT ''::text NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT changeset_external_checks_context_check CHECK ((context <> ''::text)),
+    CONSTRAINT changeset_external_checks_state_check CHECK ((state = ANY (ARRAY['PENDING'::text, 'PASSED'::text, 'FAILED'::text])))
+);
+
+COMMENT ON TABLE changeset_external_checks IS 'Check statuses of changesets reported by external CI systems that the code host does not know about.';
+
+COMMENT ON COLUMN changeset_external_checks.context IS 'The name of the check, e.g. jenkins/build. A changeset has at most one status per context.';
+
+COMMENT ON COLUMN changeset_external_checks.commit_oid IS 'The commit the status was reported for. Statuses for commits other than the head of the changeset are ignored. Empty if the status applies to any commit.';
+
+CREATE SEQUENCE changeset_external_checks_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE changeset_external_checks_id_seq OWNED BY changeset_external_checks.id;
+
 CREATE TABLE changeset_jobs (
     id bigint NOT NULL,
     bulk_group text NOT NULL,
@@ -2059,6 +2140,18 @@ CREATE SEQUENCE codeowners_owners_id_seq
 
 ALTER SEQUENCE codeowners_owners_id_seq OWNED BY codeowners_owners.id;
 
+CREATE TABLE cody_token_usage (
+    user_id integer NOT NULL,
+    feature text NOT NULL,
+    model text NOT NULL,
+    day date NOT NULL,
+    prompt_tokens bigint DEFAULT 0 NOT NULL,
+    completion_tokens bigint DEFAULT 0 NOT NULL,
+    request_count integer DEFAULT 0 NOT NULL
+);
+
+COMMENT ON TABLE cody_token_usage IS 'Daily number of LLM tokens used by each user, per completions feature and model.';
+
 CREATE TABLE commit_authors (
     id integer NOT NULL,
     email text NOT NULL,
@@ -2220,6 +2313,29 @@ CREATE SEQUENCE discussion_threads_target_repo_id_seq
 
 ALTER SEQUENCE discussion_threads_target_repo_id_seq OWNED BY discussion_threads_target_repo.id;
 
+CREATE TABLE distributed_locks (
+    name text NOT NULL,
+    holder text NOT NULL,
+    fencing_token bigint NOT NULL,
+    acquired_at timestamp with time zone DEFAULT now() NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE distributed_locks IS 'Leases on named locks shared by background workers. Rows are deleted when the lock is released or some time after the lease expired.';
+
+COMMENT ON COLUMN distributed_locks.holder IS 'The instance holding or last holding the lock.';
+
+COMMENT ON COLUMN distributed_locks.fencing_token IS 'Drawn from distributed_locks_fencing_token_seq every time the lock is acquired, so that tokens keep increasing after rows are deleted. Writes guarded by the lock check that the token is still current.';
+
+COMMENT ON COLUMN distributed_locks.expires_at IS 'When the lease expires unless renewed. The lock is free once this time has passed.';
+
+CREATE SEQUENCE distributed_locks_fencing_token_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
 CREATE TABLE event_logs (
     id bigint NOT NULL,
     name text NOT NULL,
@@ -2562,6 +2678,15 @@ CREATE TABLE external_service_repos (
     created_at timestamp with time zone DEFAULT transaction_timestamp() NOT NULL
 );
 
+CREATE TABLE external_service_sync_checkpoints (
+    external_service_id bigint NOT NULL,
+    repo_id integer NOT NULL,
+    sync_job_id integer NOT NULL,
+    external_id text NOT NULL
+);
+
+COMMENT ON TABLE external_service_sync_checkpoints IS 'Repositories that the current sync job of an external service has already synced. A sync job that is resumed after a restart skips these repositories.';
+
 CREATE SEQUENCE external_service_sync_jobs_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -2966,13 +3091,22 @@ CREATE TABLE lsif_dependency_indexing_jobs (
     upload_id integer,
     external_service_kind text DEFAULT ''::text NOT NULL,
     external_service_sync timestamp with time zone,
-    cancel boolean DEFAULT false NOT NULL
+    cancel boolean DEFAULT false NOT NULL,
+    package_scheme text DEFAULT ''::text NOT NULL,
+    package_name text DEFAULT ''::text NOT NULL,
+    package_version text DEFAULT ''::text NOT NULL
 );
 
 COMMENT ON COLUMN lsif_dependency_indexing_jobs.external_service_kind IS 'Filter the external services for this kind to wait to have synced. If empty, external_service_sync is ignored and no external services are polled for their last sync time.';
 
 COMMENT ON COLUMN lsif_dependency_indexing_jobs.external_service_sync IS 'The sync time after which external services of the given kind will have synced/created any repositories referenced by the LSIF upload that are resolvable.';
 
+COMMENT ON COLUMN lsif_dependency_indexing_jobs.package_scheme IS 'The scheme of the single package to resolve and index. If empty, all packages referenced by the upload are considered.';
+
+COMMENT ON COLUMN lsif_dependency_indexing_jobs.package_name IS 'The name of the single package to resolve and index.';
+
+COMMENT ON COLUMN lsif_dependency_indexing_jobs.package_version IS 'The version of the single package to resolve and index.';
+
 CREATE TABLE lsif_dependency_syncing_jobs (
     id integer NOT NULL,
     state text DEFAULT 'queued'::text NOT NULL,
@@ -3083,6 +3217,7 @@ CREATE TABLE lsif_uploads (
     last_reconcile_at timestamp with time zone,
     content_type text DEFAULT 'application/x-ndjson+lsif'::text NOT NULL,
     should_reindex boolean DEFAULT false NOT NULL,
+    trace_context jsonb,
     CONSTRAINT lsif_uploads_commit_valid_chars CHECK ((commit ~ '^[a-z0-9]{40}$'::text))
 );
 
@@ -3118,6 +3253,8 @@ COMMENT ON COLUMN lsif_uploads.last_traversal_scan_at IS 'The last time this upl
 
 COMMENT ON COLUMN lsif_uploads.content_type IS 'The content type of the upload record. For now, the default value is `application/x-ndjson+lsif` to backfill existing records. This will change as we remove LSIF support.';
 
+COMMENT ON COLUMN lsif_uploads.trace_context IS 'W3C trace context of the request that enqueued the upload for processing.';
+
 CREATE VIEW lsif_dumps AS
  SELECT u.id,
     u.commit,
@@ -3480,6 +3617,37 @@ COMMENT ON COLUMN lsif_uploads_visible_at_tip.branch_or_tag_name IS 'The name of
 
 COMMENT ON COLUMN lsif_uploads_visible_at_tip.is_default_branch IS 'Whether the specified branch is the default of the repository. Always false for tags.';
 
+CREATE TABLE lsif_uploads_visibility_audit_logs (
+    id integer NOT NULL,
+    log_timestamp timestamp with time zone DEFAULT now() NOT NULL,
+    repository_id integer NOT NULL,
+    upload_id integer,
+    operation text NOT NULL,
+    branch_or_tag_name text,
+    is_default_branch boolean,
+    reason text DEFAULT ''::text NOT NULL
+);
+
+COMMENT ON TABLE lsif_uploads_visibility_audit_logs IS 'Records changes to the set of uploads visible from the tips of branches and tags, as well as the outcome of each commit graph recalculation.';
+
+COMMENT ON COLUMN lsif_uploads_visibility_audit_logs.upload_id IS 'The upload whose visibility changed. Null for commit graph recalculation entries.';
+
+COMMENT ON COLUMN lsif_uploads_visibility_audit_logs.operation IS 'One of visible, hidden, commit_graph_updated, or commit_graph_update_failed.';
+
+COMMENT ON COLUMN lsif_uploads_visibility_audit_logs.branch_or_tag_name IS 'The branch or tag whose tip the upload became visible or hidden from.';
+
+COMMENT ON COLUMN lsif_uploads_visibility_audit_logs.reason IS 'A human-readable explanation of the change.';
+
+CREATE SEQUENCE lsif_uploads_visibility_audit_logs_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE lsif_uploads_visibility_audit_logs_id_seq OWNED BY lsif_uploads_visibility_audit_logs.id;
+
 CREATE TABLE lsif_uploads_vulnerability_scan (
     id bigint NOT NULL,
     upload_id integer NOT NULL,
@@ -3685,6 +3853,10 @@ CREATE TABLE out_of_band_migrations (
     deprecated_version_major integer,
     deprecated_version_minor integer,
     metadata jsonb DEFAULT '{}'::jsonb NOT NULL,
+    paused boolean DEFAULT false NOT NULL,
+    rows_processed bigint DEFAULT 0 NOT NULL,
+    progress_rate double precision,
+    progress_updated_at timestamp with time zone,
     CONSTRAINT out_of_band_migrations_component_nonempty CHECK ((component <> ''::text)),
     CONSTRAINT out_of_band_migrations_description_nonempty CHECK ((description <> ''::text)),
     CONSTRAINT out_of_band_migrations_progress_range CHECK (((progress >= (0)::double precision) AND (progress <= (1)::double precision))),
@@ -3721,6 +3893,14 @@ COMMENT ON COLUMN out_of_band_migrations.deprecated_version_major IS 'The lowest
 
 COMMENT ON COLUMN out_of_band_migrations.deprecated_version_minor IS 'The lowest Sourcegraph version (minor component) that assumes the migration has completed.';
 
+COMMENT ON COLUMN out_of_band_migrations.paused IS 'Whether the migration has been paused by a site admin. Paused migrations are not run by the instance.';
+
+COMMENT ON COLUMN out_of_band_migrations.rows_processed IS 'The number of records migrated (in either direction), as reported by the migrator.';
+
+COMMENT ON COLUMN out_of_band_migrations.progress_rate IS 'The smoothed rate of progress, as a fraction of the migration per second.';
+
+COMMENT ON COLUMN out_of_band_migrations.progress_updated_at IS 'The date and time the progress of the migration last changed.';
+
 CREATE TABLE out_of_band_migrations_errors (
     id integer NOT NULL,
     migration_id integer NOT NULL,
@@ -3759,6 +3939,35 @@ CREATE SEQUENCE out_of_band_migrations_id_seq
 
 ALTER SEQUENCE out_of_band_migrations_id_seq OWNED BY out_of_band_migrations.id;
 
+CREATE TABLE outbound_webhook_dead_letters (
+    id bigint NOT NULL,
+    outbound_webhook_id bigint NOT NULL,
+    job_id bigint NOT NULL,
+    event_type text NOT NULL,
+    scope text,
+    encryption_key_id text,
+    payload bytea NOT NULL,
+    attempts integer NOT NULL,
+    last_status_code integer NOT NULL,
+    last_error bytea NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    replayed_at timestamp with time zone,
+    replay_job_id bigint
+);
+
+COMMENT ON TABLE outbound_webhook_dead_letters IS 'Outbound webhook payloads that could not be delivered to a webhook after the maximum number of attempts.';
+
+COMMENT ON COLUMN outbound_webhook_dead_letters.job_id IS 'The outbound webhook job that failed. Jobs are deleted after the webhook log retention period, so this may not reference an existing job.';
+
+CREATE SEQUENCE outbound_webhook_dead_letters_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE outbound_webhook_dead_letters_id_seq OWNED BY outbound_webhook_dead_letters.id;
+
 CREATE TABLE outbound_webhook_event_types (
     id bigint NOT NULL,
     outbound_webhook_id bigint NOT NULL,
@@ -3792,9 +4001,12 @@ CREATE TABLE outbound_webhook_jobs (
     last_heartbeat_at timestamp with time zone,
     execution_logs json[],
     worker_hostname text DEFAULT ''::text NOT NULL,
-    cancel boolean DEFAULT false NOT NULL
+    cancel boolean DEFAULT false NOT NULL,
+    outbound_webhook_id bigint
 );
 
+COMMENT ON COLUMN outbound_webhook_jobs.outbound_webhook_id IS 'If set, the payload is only sent to this outbound webhook instead of all webhooks registered for the event type. Used to replay dead letters.';
+
 CREATE SEQUENCE outbound_webhook_jobs_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -3859,6 +4071,14 @@ CREATE VIEW outbound_webhooks_with_event_types AS
           WHERE (outbound_webhook_event_types.outbound_webhook_id = outbound_webhooks.id))) AS event_types
    FROM outbound_webhooks;
 
+CREATE TABLE own_aggregate_blame_lines (
+    commit_author_id integer NOT NULL,
+    file_path_id integer NOT NULL,
+    line_count integer NOT NULL
+);
+
+COMMENT ON TABLE own_aggregate_blame_lines IS 'The number of lines attributed to each author by git blame at the default branch HEAD, aggregated per file and per directory.';
+
 CREATE TABLE own_aggregate_recent_contribution (
     id integer NOT NULL,
     commit_author_id integer NOT NULL,
@@ -4065,6 +4285,7 @@ CREATE TABLE permission_sync_jobs (
     permissions_found integer DEFAULT 0 NOT NULL,
     code_host_states json[],
     is_partial_success boolean DEFAULT false,
+    trace_context jsonb,
     CONSTRAINT permission_sync_jobs_for_repo_or_user CHECK (((user_id IS NULL) <> (repository_id IS NULL)))
 );
 
@@ -4076,6 +4297,8 @@ COMMENT ON COLUMN permission_sync_jobs.priority IS 'Specifies numeric priority f
 
 COMMENT ON COLUMN permission_sync_jobs.cancellation_reason IS 'Specifies why permissions sync job was cancelled.';
 
+COMMENT ON COLUMN permission_sync_jobs.trace_context IS 'W3C trace context of the operation that enqueued the job.';
+
 CREATE SEQUENCE permission_sync_jobs_id_seq
     AS integer
     START WITH 1
@@ -4327,6 +4550,26 @@ CREATE SEQUENCE repo_commits_changelists_id_seq
 
 ALTER SEQUENCE repo_commits_changelists_id_seq OWNED BY repo_commits_changelists.id;
 
+CREATE TABLE repo_deploy_keys (
+    repo_id integer NOT NULL,
+    public_key text NOT NULL,
+    private_key text NOT NULL,
+    passphrase text NOT NULL,
+    encryption_key_id text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repo_deploy_keys IS 'SSH deploy keys that gitserver uses to clone and fetch individual repositories from generic Git code hosts.';
+
+CREATE TABLE repo_embedding_admission_overrides (
+    repo_id integer NOT NULL,
+    created_by integer,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repo_embedding_admission_overrides IS 'Repositories that site admins have exempted from the embeddings admission policy.';
+
 CREATE TABLE repo_embedding_job_stats (
     job_id integer NOT NULL,
     is_incremental boolean DEFAULT false NOT NULL,
@@ -4384,9 +4627,12 @@ ALTER SEQUENCE repo_id_seq OWNED BY repo.id;
 CREATE TABLE repo_kvps (
     repo_id integer NOT NULL,
     key text NOT NULL,
-    value text
+    value text,
+    source text DEFAULT 'manual'::text NOT NULL
 );
 
+COMMENT ON COLUMN repo_kvps.source IS 'Where the key-value pair comes from: manual if it was set by a user, code_host if it was synced from the code host, for example from GitHub topics.';
+
 CREATE TABLE repo_paths (
     id integer NOT NULL,
     repo_id integer NOT NULL,
@@ -4452,6 +4698,100 @@ COMMENT ON COLUMN repo_statistics.failed_fetch IS 'Number of repositories that a
 
 COMMENT ON COLUMN repo_statistics.corrupted IS 'Number of repositories that are NOT soft-deleted and not blocked and have corrupted_at set in gitserver_repos table';
 
+CREATE TABLE repo_views (
+    id integer NOT NULL,
+    name citext NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    repo_id integer NOT NULL,
+    path_globs text[] NOT NULL,
+    creator_id integer,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT repo_views_path_globs_not_empty CHECK ((array_length(path_globs, 1) > 0))
+);
+
+COMMENT ON TABLE repo_views IS 'Virtual repositories defined by path globs over a parent repository.';
+
+COMMENT ON COLUMN repo_views.path_globs IS 'The globs matching the paths of the parent repository that are part of the view, e.g. client/web/**.';
+
+CREATE SEQUENCE repo_views_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE repo_views_id_seq OWNED BY repo_views.id;
+
+CREATE TABLE repository_snapshot_schedules (
+    id integer NOT NULL,
+    name text NOT NULL,
+    targets jsonb DEFAULT '[]'::jsonb NOT NULL,
+    cron_expression text NOT NULL,
+    enabled boolean DEFAULT true NOT NULL,
+    last_enqueued_at timestamp with time zone,
+    creator_id integer,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repository_snapshot_schedules IS 'Schedules on which snapshots of a set of repositories are exported to the blob store.';
+
+COMMENT ON COLUMN repository_snapshot_schedules.targets IS 'The repositories and revisions to export, as a JSON array of {"repository_id", "revision"} objects.';
+
+COMMENT ON COLUMN repository_snapshot_schedules.last_enqueued_at IS 'The time the last snapshot of this schedule was enqueued. The next snapshot is due at the next time matching the cron expression after this time (or after created_at).';
+
+CREATE SEQUENCE repository_snapshot_schedules_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE repository_snapshot_schedules_id_seq OWNED BY repository_snapshot_schedules.id;
+
+CREATE TABLE repository_snapshots (
+    id integer NOT NULL,
+    schedule_id integer,
+    initiator_id integer,
+    targets jsonb DEFAULT '[]'::jsonb NOT NULL,
+    manifest jsonb,
+    signature text,
+    state text DEFAULT 'queued'::text NOT NULL,
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer DEFAULT 0 NOT NULL,
+    num_failures integer DEFAULT 0 NOT NULL,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text DEFAULT ''::text NOT NULL,
+    cancel boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE repository_snapshots IS 'Point-in-time exports of a set of repositories to the blob store.';
+
+COMMENT ON COLUMN repository_snapshots.targets IS 'The repositories and revisions to export, as a JSON array of {"repository_id", "revision"} objects.';
+
+COMMENT ON COLUMN repository_snapshots.manifest IS 'The manifest of the completed snapshot, listing the resolved commit and the SHA-256 checksum of the archive of each repository.';
+
+COMMENT ON COLUMN repository_snapshots.signature IS 'The base64-encoded Ed25519 signature of the manifest as stored in the blob store.';
+
+CREATE SEQUENCE repository_snapshots_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE repository_snapshots_id_seq OWNED BY repository_snapshots.id;
+
 CREATE TABLE role_permissions (
     role_id integer NOT NULL,
     permission_id integer NOT NULL,
@@ -4547,6 +4887,28 @@ CREATE SEQUENCE search_contexts_id_seq
 
 ALTER SEQUENCE search_contexts_id_seq OWNED BY search_contexts.id;
 
+CREATE TABLE search_index_branch_rules (
+    id integer NOT NULL,
+    repo_pattern text NOT NULL,
+    branches text[] NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE search_index_branch_rules IS 'Additional branches indexed by Zoekt for all repositories whose name matches a pattern.';
+
+COMMENT ON COLUMN search_index_branch_rules.repo_pattern IS 'A regular expression matched against repository names.';
+
+CREATE SEQUENCE search_index_branch_rules_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE search_index_branch_rules_id_seq OWNED BY search_index_branch_rules.id;
+
 CREATE TABLE security_event_logs (
     id bigint NOT NULL,
     name text NOT NULL,
@@ -4859,6 +5221,24 @@ CREATE TABLE user_roles (
     created_at timestamp with time zone DEFAULT now() NOT NULL
 );
 
+CREATE TABLE user_totp_credentials (
+    user_id integer NOT NULL,
+    secret text NOT NULL,
+    encryption_key_id text DEFAULT ''::text NOT NULL,
+    recovery_codes text[] DEFAULT '{}'::text[] NOT NULL,
+    last_used_step bigint DEFAULT 0 NOT NULL,
+    enabled_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE user_totp_credentials IS 'TOTP two-factor authentication credentials of users of the builtin auth provider.';
+
+COMMENT ON COLUMN user_totp_credentials.recovery_codes IS 'SHA-256 hashes of the unused recovery codes. Recovery codes are 80-bit random values, so their hashes are not salted.';
+
+COMMENT ON COLUMN user_totp_credentials.last_used_step IS 'The time step of the last accepted code. Codes of this or earlier steps are rejected.';
+
+COMMENT ON COLUMN user_totp_credentials.enabled_at IS 'The time enrollment was confirmed with a valid code. NULL while enrollment is pending.';
+
 CREATE SEQUENCE users_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -5034,6 +5414,8 @@ ALTER TABLE ONLY assigned_owners ALTER COLUMN id SET DEFAULT nextval('assigned_o
 
 ALTER TABLE ONLY assigned_teams ALTER COLUMN id SET DEFAULT nextval('assigned_teams_id_seq'::regclass);
 
+ALTER TABLE ONLY audit_events ALTER COLUMN id SET DEFAULT nextval('audit_events_id_seq'::regclass);
+
 ALTER TABLE ONLY batch_changes ALTER COLUMN id SET DEFAULT nextval('batch_changes_id_seq'::regclass);
 
 ALTER TABLE ONLY batch_changes_site_credentials ALTER COLUMN id SET DEFAULT nextval('batch_changes_site_credentials_id_seq'::regclass);
@@ -5054,6 +5436,8 @@ ALTER TABLE ONLY cached_available_indexers ALTER COLUMN id SET DEFAULT nextval('
 
 ALTER TABLE ONLY changeset_events ALTER COLUMN id SET DEFAULT nextval('changeset_events_id_seq'::regclass);
 
+ALTER TABLE ONLY changeset_external_checks ALTER COLUMN id SET DEFAULT nextval('changeset_external_checks_id_seq'::regclass);
+
 ALTER TABLE ONLY changeset_jobs ALTER COLUMN id SET DEFAULT nextval('changeset_jobs_id_seq'::regclass);
 
 ALTER TABLE ONLY changeset_specs ALTER COLUMN id SET DEFAULT nextval('changeset_specs_id_seq'::regclass);
@@ -5182,6 +5566,8 @@ ALTER TABLE ONLY lsif_uploads ALTER COLUMN id SET DEFAULT nextval('lsif_dumps_id
 
 ALTER TABLE ONLY lsif_uploads_audit_logs ALTER COLUMN sequence SET DEFAULT nextval('lsif_uploads_audit_logs_seq'::regclass);
 
+ALTER TABLE ONLY lsif_uploads_visibility_audit_logs ALTER COLUMN id SET DEFAULT nextval('lsif_uploads_visibility_audit_logs_id_seq'::regclass);
+
 ALTER TABLE ONLY lsif_uploads_vulnerability_scan ALTER COLUMN id SET DEFAULT nextval('lsif_uploads_vulnerability_scan_id_seq'::regclass);
 
 ALTER TABLE ONLY namespace_permissions ALTER COLUMN id SET DEFAULT nextval('namespace_permissions_id_seq'::regclass);
@@ -5198,6 +5584,8 @@ ALTER TABLE ONLY out_of_band_migrations ALTER COLUMN id SET DEFAULT nextval('out
 
 ALTER TABLE ONLY out_of_band_migrations_errors ALTER COLUMN id SET DEFAULT nextval('out_of_band_migrations_errors_id_seq'::regclass);
 
+ALTER TABLE ONLY outbound_webhook_dead_letters ALTER COLUMN id SET DEFAULT nextval('outbound_webhook_dead_letters_id_seq'::regclass);
+
 ALTER TABLE ONLY outbound_webhook_event_types ALTER COLUMN id SET DEFAULT nextval('outbound_webhook_event_types_id_seq'::regclass);
 
 ALTER TABLE ONLY outbound_webhook_jobs ALTER COLUMN id SET DEFAULT nextval('outbound_webhook_jobs_id_seq'::regclass);
@@ -5238,12 +5626,20 @@ ALTER TABLE ONLY repo_embedding_jobs ALTER COLUMN id SET DEFAULT nextval('repo_e
 
 ALTER TABLE ONLY repo_paths ALTER COLUMN id SET DEFAULT nextval('repo_paths_id_seq'::regclass);
 
+ALTER TABLE ONLY repo_views ALTER COLUMN id SET DEFAULT nextval('repo_views_id_seq'::regclass);
+
+ALTER TABLE ONLY repository_snapshot_schedules ALTER COLUMN id SET DEFAULT nextval('repository_snapshot_schedules_id_seq'::regclass);
+
+ALTER TABLE ONLY repository_snapshots ALTER COLUMN id SET DEFAULT nextval('repository_snapshots_id_seq'::regclass);
+
 ALTER TABLE ONLY roles ALTER COLUMN id SET DEFAULT nextval('roles_id_seq'::regclass);
 
 ALTER TABLE ONLY saved_searches ALTER COLUMN id SET DEFAULT nextval('saved_searches_id_seq'::regclass);
 
 ALTER TABLE ONLY search_contexts ALTER COLUMN id SET DEFAULT nextval('search_contexts_id_seq'::regclass);
 
+ALTER TABLE ONLY search_index_branch_rules ALTER COLUMN id SET DEFAULT nextval('search_index_branch_rules_id_seq'::regclass);
+
 ALTER TABLE ONLY security_event_logs ALTER COLUMN id SET DEFAULT nextval('security_event_logs_id_seq'::regclass);
 
 ALTER TABLE ONLY settings ALTER COLUMN id SET DEFAULT nextval('settings_id_seq'::regclass);
@@ -5299,6 +5695,9 @@ ALTER TABLE ONLY assigned_owners
 ALTER TABLE ONLY assigned_teams
     ADD CONSTRAINT assigned_teams_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY audit_events
+    ADD CONSTRAINT audit_events_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY batch_changes
     ADD CONSTRAINT batch_changes_pkey PRIMARY KEY (id);
 
@@ -5341,6 +5740,9 @@ ALTER TABLE ONLY changeset_events
 ALTER TABLE ONLY changeset_events
     ADD CONSTRAINT changeset_events_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY changeset_external_checks
+    ADD CONSTRAINT changeset_external_checks_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY changeset_jobs
     ADD CONSTRAINT changeset_jobs_pkey PRIMARY KEY (id);
 
@@ -5443,6 +5845,9 @@ ALTER TABLE ONLY codeowners
 ALTER TABLE ONLY codeowners
     ADD CONSTRAINT codeowners_repo_id_key UNIQUE (repo_id);
 
+ALTER TABLE ONLY cody_token_usage
+    ADD CONSTRAINT cody_token_usage_pkey PRIMARY KEY (user_id, feature, model, day);
+
 ALTER TABLE ONLY commit_authors
     ADD CONSTRAINT commit_authors_pkey PRIMARY KEY (id);
 
@@ -5464,6 +5869,9 @@ ALTER TABLE ONLY discussion_threads
 ALTER TABLE ONLY discussion_threads_target_repo
     ADD CONSTRAINT discussion_threads_target_repo_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY distributed_locks
+    ADD CONSTRAINT distributed_locks_pkey PRIMARY KEY (name);
+
 ALTER TABLE ONLY event_logs_export_allowlist
     ADD CONSTRAINT event_logs_export_allowlist_pkey PRIMARY KEY (id);
 
@@ -5512,6 +5920,9 @@ ALTER TABLE ONLY explicit_permissions_bitbucket_projects_jobs
 ALTER TABLE ONLY external_service_repos
     ADD CONSTRAINT external_service_repos_repo_id_external_service_id_unique UNIQUE (repo_id, external_service_id);
 
+ALTER TABLE ONLY external_service_sync_checkpoints
+    ADD CONSTRAINT external_service_sync_checkpoints_pkey PRIMARY KEY (external_service_id, repo_id);
+
 ALTER TABLE ONLY external_services
     ADD CONSTRAINT external_services_pkey PRIMARY KEY (id);
 
@@ -5599,6 +6010,9 @@ ALTER TABLE ONLY lsif_uploads
 ALTER TABLE ONLY lsif_uploads_reference_counts
     ADD CONSTRAINT lsif_uploads_reference_counts_upload_id_key UNIQUE (upload_id);
 
+ALTER TABLE ONLY lsif_uploads_visibility_audit_logs
+    ADD CONSTRAINT lsif_uploads_visibility_audit_logs_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY lsif_uploads_vulnerability_scan
     ADD CONSTRAINT lsif_uploads_vulnerability_scan_pkey PRIMARY KEY (id);
 
@@ -5638,6 +6052,9 @@ ALTER TABLE ONLY out_of_band_migrations_errors
 ALTER TABLE ONLY out_of_band_migrations
     ADD CONSTRAINT out_of_band_migrations_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY outbound_webhook_dead_letters
+    ADD CONSTRAINT outbound_webhook_dead_letters_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY outbound_webhook_event_types
     ADD CONSTRAINT outbound_webhook_event_types_pkey PRIMARY KEY (id);
 
@@ -5650,6 +6067,9 @@ ALTER TABLE ONLY outbound_webhook_logs
 ALTER TABLE ONLY outbound_webhooks
     ADD CONSTRAINT outbound_webhooks_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY own_aggregate_blame_lines
+    ADD CONSTRAINT own_aggregate_blame_lines_pkey PRIMARY KEY (file_path_id, commit_author_id);
+
 ALTER TABLE ONLY own_aggregate_recent_contribution
     ADD CONSTRAINT own_aggregate_recent_contribution_pkey PRIMARY KEY (id);
 
@@ -5704,6 +6124,12 @@ ALTER TABLE ONLY registry_extensions
 ALTER TABLE ONLY repo_commits_changelists
     ADD CONSTRAINT repo_commits_changelists_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY repo_deploy_keys
+    ADD CONSTRAINT repo_deploy_keys_pkey PRIMARY KEY (repo_id);
+
+ALTER TABLE ONLY repo_embedding_admission_overrides
+    ADD CONSTRAINT repo_embedding_admission_overrides_pkey PRIMARY KEY (repo_id);
+
 ALTER TABLE ONLY repo_embedding_job_stats
     ADD CONSTRAINT repo_embedding_job_stats_pkey PRIMARY KEY (job_id);
 
@@ -5728,6 +6154,15 @@ ALTER TABLE ONLY repo_permissions
 ALTER TABLE ONLY repo
     ADD CONSTRAINT repo_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY repo_views
+    ADD CONSTRAINT repo_views_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY repository_snapshot_schedules
+    ADD CONSTRAINT repository_snapshot_schedules_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY repository_snapshots
+    ADD CONSTRAINT repository_snapshots_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY role_permissions
     ADD CONSTRAINT role_permissions_pkey PRIMARY KEY (permission_id, role_id);
 
@@ -5749,6 +6184,9 @@ ALTER TABLE ONLY search_context_stars
 ALTER TABLE ONLY search_contexts
     ADD CONSTRAINT search_contexts_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY search_index_branch_rules
+    ADD CONSTRAINT search_index_branch_rules_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY security_event_logs
     ADD CONSTRAINT security_event_logs_pkey PRIMARY KEY (id);
 
@@ -5809,6 +6247,9 @@ ALTER TABLE ONLY user_repo_permissions
 ALTER TABLE ONLY user_roles
     ADD CONSTRAINT user_roles_pkey PRIMARY KEY (user_id, role_id);
 
+ALTER TABLE ONLY user_totp_credentials
+    ADD CONSTRAINT user_totp_credentials_pkey PRIMARY KEY (user_id);
+
 ALTER TABLE ONLY users
     ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
@@ -5851,6 +6292,16 @@ CREATE UNIQUE INDEX assigned_owners_file_path_owner ON assigned_owners USING btr
 
 CREATE UNIQUE INDEX assigned_teams_file_path_owner ON assigned_teams USING btree (file_path_id, owner_team_id);
 
+CREATE INDEX audit_events_action ON audit_events USING btree (action);
+
+CREATE INDEX audit_events_actor_user_id ON audit_events USING btree (actor_user_id);
+
+CREATE INDEX audit_events_created_at ON audit_events USING btree (created_at);
+
+CREATE INDEX audit_events_entity ON audit_events USING btree (entity_type, entity_id);
+
+CREATE INDEX audit_events_not_exported ON audit_events USING btree (id) WHERE (exported_at IS NULL);
+
 CREATE INDEX batch_changes_namespace_org_id ON batch_changes USING btree (namespace_org_id);
 
 CREATE INDEX batch_changes_namespace_user_id ON batch_changes USING btree (namespace_user_id);
@@ -5887,6 +6338,8 @@ CREATE INDEX cached_available_indexers_num_events ON cached_available_indexers U
 
 CREATE UNIQUE INDEX cached_available_indexers_repository_id ON cached_available_indexers USING btree (repository_id);
 
+CREATE UNIQUE INDEX changeset_external_checks_changeset_id_context ON changeset_external_checks USING btree (changeset_id, context);
+
 CREATE INDEX changeset_jobs_bulk_group_idx ON changeset_jobs USING btree (bulk_group);
 
 CREATE INDEX changeset_jobs_state_idx ON changeset_jobs USING btree (state);
@@ -5955,6 +6408,8 @@ CREATE INDEX codeintel_ranking_definitions_exported_upload_id ON codeintel_ranki
 
 CREATE INDEX codeintel_ranking_definitions_graph_key_symbol_checksum_search ON codeintel_ranking_definitions USING btree (graph_key, symbol_checksum, exported_upload_id, document_path);
 
+CREATE INDEX codeintel_ranking_definitions_graph_key_symbol_name ON codeintel_ranking_definitions USING btree (graph_key, symbol_name text_pattern_ops) WHERE (symbol_name <> ''::text);
+
 CREATE INDEX codeintel_ranking_exports_graph_key_deleted_at_id ON codeintel_ranking_exports USING btree (graph_key, deleted_at DESC, id);
 
 CREATE INDEX codeintel_ranking_exports_graph_key_last_scanned_at ON codeintel_ranking_exports USING btree (graph_key, last_scanned_at NULLS FIRST, id);
@@ -5975,6 +6430,8 @@ CREATE INDEX codeintel_ranking_references_processed_reference_id ON codeintel_ra
 
 CREATE INDEX codeowners_owners_reference ON codeowners_owners USING btree (reference);
 
+CREATE INDEX cody_token_usage_day ON cody_token_usage USING btree (day);
+
 CREATE UNIQUE INDEX commit_authors_email_name ON commit_authors USING btree (email, name);
 
 CREATE INDEX configuration_policies_audit_logs_policy_id ON configuration_policies_audit_logs USING btree (policy_id);
@@ -5995,6 +6452,8 @@ CREATE INDEX discussion_threads_author_user_id_idx ON discussion_threads USING b
 
 CREATE INDEX discussion_threads_target_repo_repo_id_path_idx ON discussion_threads_target_repo USING btree (repo_id, path);
 
+CREATE INDEX distributed_locks_expires_at ON distributed_locks USING btree (expires_at);
+
 CREATE INDEX event_logs_anonymous_user_id ON event_logs USING btree (anonymous_user_id);
 
 CREATE UNIQUE INDEX event_logs_export_allowlist_event_name_idx ON event_logs_export_allowlist USING btree (event_name);
@@ -6093,6 +6552,8 @@ CREATE UNIQUE INDEX kind_cloud_default ON external_services USING btree (kind, c
 
 CREATE INDEX lsif_configuration_policies_repository_id ON lsif_configuration_policies USING btree (repository_id);
 
+CREATE INDEX lsif_dependency_indexing_jobs_package ON lsif_dependency_indexing_jobs USING btree (package_scheme, package_name, package_version) WHERE (package_scheme <> ''::text);
+
 CREATE INDEX lsif_dependency_indexing_jobs_state ON lsif_dependency_indexing_jobs USING btree (state);
 
 CREATE INDEX lsif_dependency_indexing_jobs_upload_id ON lsif_dependency_syncing_jobs USING btree (upload_id);
@@ -6161,6 +6622,10 @@ CREATE INDEX lsif_uploads_state ON lsif_uploads USING btree (state);
 
 CREATE INDEX lsif_uploads_uploaded_at_id ON lsif_uploads USING btree (uploaded_at DESC, id) WHERE (state <> 'deleted'::text);
 
+CREATE INDEX lsif_uploads_visibility_audit_logs_log_timestamp ON lsif_uploads_visibility_audit_logs USING btree (log_timestamp);
+
+CREATE INDEX lsif_uploads_visibility_audit_logs_repository_id_log_timestamp ON lsif_uploads_visibility_audit_logs USING btree (repository_id, log_timestamp DESC);
+
 CREATE INDEX lsif_uploads_visible_at_tip_is_default_branch ON lsif_uploads_visible_at_tip USING btree (upload_id) WHERE is_default_branch;
 
 CREATE INDEX lsif_uploads_visible_at_tip_repository_id_upload_id ON lsif_uploads_visible_at_tip USING btree (repository_id, upload_id);
@@ -6183,6 +6648,8 @@ CREATE INDEX org_invitations_recipient_user_id ON org_invitations USING btree (r
 
 CREATE UNIQUE INDEX orgs_name ON orgs USING btree (name) WHERE (deleted_at IS NULL);
 
+CREATE INDEX outbound_webhook_dead_letters_outbound_webhook_id_idx ON outbound_webhook_dead_letters USING btree (outbound_webhook_id);
+
 CREATE INDEX outbound_webhook_event_types_event_type_idx ON outbound_webhook_event_types USING btree (event_type, scope);
 
 CREATE INDEX outbound_webhook_jobs_state_idx ON outbound_webhook_jobs USING btree (state);
@@ -6281,6 +6748,14 @@ CREATE INDEX repo_stars_idx ON repo USING btree (stars DESC NULLS LAST);
 
 CREATE INDEX repo_uri_idx ON repo USING btree (uri);
 
+CREATE UNIQUE INDEX repo_views_name_unique ON repo_views USING btree (name);
+
+CREATE INDEX repo_views_repo_id ON repo_views USING btree (repo_id);
+
+CREATE INDEX repository_snapshots_schedule_id ON repository_snapshots USING btree (schedule_id);
+
+CREATE INDEX repository_snapshots_state ON repository_snapshots USING btree (state);
+
 CREATE UNIQUE INDEX search_contexts_name_namespace_org_id_unique ON search_contexts USING btree (name, namespace_org_id) WHERE (namespace_org_id IS NOT NULL);
 
 CREATE UNIQUE INDEX search_contexts_name_namespace_user_id_unique ON search_contexts USING btree (name, namespace_user_id) WHERE (namespace_user_id IS NOT NULL);
@@ -6385,6 +6860,8 @@ CREATE TRIGGER trig_recalc_repo_statistics_on_repo_insert AFTER INSERT ON repo R
 
 CREATE TRIGGER trig_recalc_repo_statistics_on_repo_update AFTER UPDATE ON repo REFERENCING OLD TABLE AS oldtab NEW TABLE AS newtab FOR EACH STATEMENT EXECUTE FUNCTION recalc_repo_statistics_on_repo_update();
 
+CREATE TRIGGER trig_repo_kvps_search_index_branches_touch_repo AFTER INSERT OR DELETE OR UPDATE ON repo_kvps FOR EACH ROW EXECUTE FUNCTION func_repo_kvps_search_index_branches_touch_repo();
+
 CREATE TRIGGER trig_soft_delete_user_reference_on_external_service AFTER UPDATE OF deleted_at ON users FOR EACH ROW EXECUTE FUNCTION soft_delete_user_reference_on_external_service();
 
 CREATE TRIGGER trigger_configuration_policies_delete AFTER DELETE ON lsif_configuration_policies REFERENCING OLD TABLE AS old FOR EACH STATEMENT EXECUTE FUNCTION func_configuration_policies_delete();
@@ -6489,6 +6966,9 @@ ALTER TABLE ONLY batch_specs
 ALTER TABLE ONLY changeset_events
     ADD CONSTRAINT changeset_events_changeset_id_fkey FOREIGN KEY (changeset_id) REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY changeset_external_checks
+    ADD CONSTRAINT changeset_external_checks_changeset_id_fkey FOREIGN KEY (changeset_id) REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE;
+
 ALTER TABLE ONLY changeset_jobs
     ADD CONSTRAINT changeset_jobs_batch_change_id_fkey FOREIGN KEY (batch_change_id) REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE;
 
@@ -6621,6 +7101,9 @@ ALTER TABLE ONLY codeowners_individual_stats
 ALTER TABLE ONLY codeowners
     ADD CONSTRAINT codeowners_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY cody_token_usage
+    ADD CONSTRAINT cody_token_usage_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY discussion_comments
     ADD CONSTRAINT discussion_comments_author_user_id_fkey FOREIGN KEY (author_user_id) REFERENCES users(id) ON DELETE RESTRICT;
 
@@ -6684,6 +7167,12 @@ ALTER TABLE ONLY external_service_repos
 ALTER TABLE ONLY external_service_repos
     ADD CONSTRAINT external_service_repos_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY external_service_sync_checkpoints
+    ADD CONSTRAINT external_service_sync_checkpoints_external_service_id_fkey FOREIGN KEY (external_service_id) REFERENCES external_services(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY external_service_sync_checkpoints
+    ADD CONSTRAINT external_service_sync_checkpoints_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY external_services
     ADD CONSTRAINT external_services_code_host_id_fkey FOREIGN KEY (code_host_id) REFERENCES code_hosts(id) ON UPDATE CASCADE ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED;
 
@@ -6819,9 +7308,15 @@ ALTER TABLE ONLY org_stats
 ALTER TABLE ONLY out_of_band_migrations_errors
     ADD CONSTRAINT out_of_band_migrations_errors_migration_id_fkey FOREIGN KEY (migration_id) REFERENCES out_of_band_migrations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY outbound_webhook_dead_letters
+    ADD CONSTRAINT outbound_webhook_dead_letters_outbound_webhook_id_fkey FOREIGN KEY (outbound_webhook_id) REFERENCES outbound_webhooks(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY outbound_webhook_event_types
     ADD CONSTRAINT outbound_webhook_event_types_outbound_webhook_id_fkey FOREIGN KEY (outbound_webhook_id) REFERENCES outbound_webhooks(id) ON UPDATE CASCADE ON DELETE CASCADE;
 
+ALTER TABLE ONLY outbound_webhook_jobs
+    ADD CONSTRAINT outbound_webhook_jobs_outbound_webhook_id_fkey FOREIGN KEY (outbound_webhook_id) REFERENCES outbound_webhooks(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY outbound_webhook_logs
     ADD CONSTRAINT outbound_webhook_logs_job_id_fkey FOREIGN KEY (job_id) REFERENCES outbound_webhook_jobs(id) ON UPDATE CASCADE ON DELETE CASCADE;
 
@@ -6834,6 +7329,12 @@ ALTER TABLE ONLY outbound_webhooks
 ALTER TABLE ONLY outbound_webhooks
     ADD CONSTRAINT outbound_webhooks_updated_by_fkey FOREIGN KEY (updated_by) REFERENCES users(id) ON DELETE SET NULL;
 
+ALTER TABLE ONLY own_aggregate_blame_lines
+    ADD CONSTRAINT own_aggregate_blame_lines_commit_author_id_fkey FOREIGN KEY (commit_author_id) REFERENCES commit_authors(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY own_aggregate_blame_lines
+    ADD CONSTRAINT own_aggregate_blame_lines_file_path_id_fkey FOREIGN KEY (file_path_id) REFERENCES repo_paths(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY own_aggregate_recent_contribution
     ADD CONSTRAINT own_aggregate_recent_contribution_changed_file_path_id_fkey FOREIGN KEY (changed_file_path_id) REFERENCES repo_paths(id);
 
@@ -6888,6 +7389,15 @@ ALTER TABLE ONLY registry_extensions
 ALTER TABLE ONLY repo_commits_changelists
     ADD CONSTRAINT repo_commits_changelists_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY repo_deploy_keys
+    ADD CONSTRAINT repo_deploy_keys_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY repo_embedding_admission_overrides
+    ADD CONSTRAINT repo_embedding_admission_overrides_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY repo_embedding_admission_overrides
+    ADD CONSTRAINT repo_embedding_admission_overrides_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY repo_embedding_job_stats
     ADD CONSTRAINT repo_embedding_job_stats_job_id_fkey FOREIGN KEY (job_id) REFERENCES repo_embedding_jobs(id) ON DELETE CASCADE DEFERRABLE;
 
@@ -6900,6 +7410,21 @@ ALTER TABLE ONLY repo_paths
 ALTER TABLE ONLY repo_paths
     ADD CONSTRAINT repo_paths_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY repo_views
+    ADD CONSTRAINT repo_views_creator_id_fkey FOREIGN KEY (creator_id) REFERENCES users(id) ON DELETE SET NULL DEFERRABLE;
+
+ALTER TABLE ONLY repo_views
+    ADD CONSTRAINT repo_views_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE;
+
+ALTER TABLE ONLY repository_snapshot_schedules
+    ADD CONSTRAINT repository_snapshot_schedules_creator_id_fkey FOREIGN KEY (creator_id) REFERENCES users(id) ON DELETE SET NULL DEFERRABLE;
+
+ALTER TABLE ONLY repository_snapshots
+    ADD CONSTRAINT repository_snapshots_initiator_id_fkey FOREIGN KEY (initiator_id) REFERENCES users(id) ON DELETE SET NULL DEFERRABLE;
+
+ALTER TABLE ONLY repository_snapshots
+    ADD CONSTRAINT repository_snapshots_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES repository_snapshot_schedules(id) ON DELETE SET NULL;
+
 ALTER TABLE ONLY role_permissions
     ADD CONSTRAINT role_permissions_permission_id_fkey FOREIGN KEY (permission_id) REFERENCES permissions(id) ON DELETE CASCADE DEFERRABLE;
 
@@ -7002,6 +7527,9 @@ ALTER TABLE ONLY user_roles
 ALTER TABLE ONLY user_roles
     ADD CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY user_totp_credentials
+    ADD CONSTRAINT user_totp_credentials_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY webhook_logs
     ADD CONSTRAINT webhook_logs_external_service_id_fkey FOREIGN KEY (external_service_id) REFERENCES external_services(id) ON UPDATE CASCADE ON DELETE CASCADE;
 
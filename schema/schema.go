@@ -84,6 +84,8 @@ type AppNotifications struct {
 
 // AuditLog description: EXPERIMENTAL: Configuration for audit logging (specially formatted log entries for tracking sensitive events)
 type AuditLog struct {
+	// Export description: Streams audit events (such as access token creation, code host connection changes, explicit permission changes and repository deletions) to a SIEM. Events are exported in order, and an event is only considered exported once it was delivered to all configured destinations.
+	Export *AuditLogExport `json:"export,omitempty"`
 	// GitserverAccess description: Capture gitserver access logs as part of the audit log.
 	GitserverAccess bool `json:"gitserverAccess"`
 	// GraphQL description: Capture GraphQL requests and responses as part of the audit log.
@@ -94,6 +96,32 @@ type AuditLog struct {
 	SeverityLevel string `json:"severityLevel,omitempty"`
 }
 
+// AuditLogExport description: Streams audit events (such as access token creation, code host connection changes, explicit permission changes and repository deletions) to a SIEM. Events are exported in order, and an event is only considered exported once it was delivered to all configured destinations.
+type AuditLogExport struct {
+	// Syslog description: Deliver each audit event as a JSON syslog message.
+	Syslog *AuditLogSyslogExport `json:"syslog,omitempty"`
+	// Webhook description: Deliver batches of audit events as a JSON array in the body of a POST request.
+	Webhook *AuditLogWebhookExport `json:"webhook,omitempty"`
+}
+
+// AuditLogSyslogExport description: Deliver each audit event as a JSON syslog message.
+type AuditLogSyslogExport struct {
+	// Address description: The address of the syslog server, as host:port.
+	Address string `json:"address"`
+	// Network description: The network of the syslog server.
+	Network string `json:"network,omitempty"`
+	// Tag description: The syslog tag of the messages.
+	Tag string `json:"tag,omitempty"`
+}
+
+// AuditLogWebhookExport description: Deliver batches of audit events as a JSON array in the body of a POST request.
+type AuditLogWebhookExport struct {
+	// Secret description: If set, each request has an X-Sourcegraph-Signature header containing the hex-encoded HMAC-SHA256 of the request body keyed with this secret.
+	Secret string `json:"secret,omitempty"`
+	// Url description: The URL to POST audit events to.
+	Url string `json:"url"`
+}
+
 // AuthAccessRequest description: The config options for access requests
 type AuthAccessRequest struct {
 	// Enabled description: Enable/disable the access request feature, which allows users to request access if built-in signup is disabled.
@@ -248,6 +276,12 @@ type AzureDevOpsAuthProvider struct {
 
 // AzureDevOpsConnection description: Configuration for a connection to Azure DevOps.
 type AzureDevOpsConnection struct {
+	// AuthType description: The authentication mechanism to use.
+	//
+	// If "pat", Sourcegraph authenticates with the username and a personal access token set in token.
+	//
+	// If "ntlm", Sourcegraph authenticates with Windows (NTLM) authentication, using the username (in the form DOMAIN\username) and the password set in token. Only supported by Azure DevOps Server.
+	AuthType string `json:"authType,omitempty"`
 	// EnforcePermissions description: A flag to enforce Azure DevOps repository access permissions
 	EnforcePermissions bool `json:"enforcePermissions,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Azure DevOps Services.
@@ -258,13 +292,13 @@ type AzureDevOpsConnection struct {
 	//
 	// If "ssh", Sourcegraph will access repositories using Git URLs of the form git@ssh.dev.azure.com:v3/myrepo. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
-	// Orgs description: An array of organization names identifying Azure DevOps organizations whose repositories should be mirrored on Sourcegraph.
+	// Orgs description: An array of organization names identifying Azure DevOps organizations whose repositories should be mirrored on Sourcegraph. For Azure DevOps Server, the names of collections, whose repositories are discovered project by project.
 	Orgs []string `json:"orgs,omitempty"`
 	// Projects description: An array of projects "org/project" strings specifying which Azure DevOps projects' repositories should be mirrored on Sourcegraph.
 	Projects []string `json:"projects,omitempty"`
-	// Token description: The Personal Access Token associated with the Azure DevOps username used for authentication.
+	// Token description: The Personal Access Token associated with the Azure DevOps username used for authentication. When authType is "ntlm", the password of the user.
 	Token string `json:"token"`
-	// Url description: URL for Azure DevOps Services, set to https://dev.azure.com.
+	// Url description: URL for Azure DevOps Services, set to https://dev.azure.com. For Azure DevOps Server, set to the URL of the server without collection, for example https://ado.example.com/tfs.
 	Url string `json:"url"`
 	// Username description: A username for authentication with the Azure DevOps code host.
 	Username string `json:"username"`
@@ -308,6 +342,18 @@ type Batches struct {
 	Weight int `json:"weight"`
 }
 
+// BitbucketCloudAdaptiveConcurrency description: Adapts the number of concurrent API requests to Bitbucket Cloud to its latency and error rate, so that a slow code host receives fewer requests at once.
+type BitbucketCloudAdaptiveConcurrency struct {
+	// Enabled description: true if adaptive concurrency is enabled.
+	Enabled bool `json:"enabled"`
+	// LatencyThresholdMs description: Requests taking longer than this number of milliseconds lower the number of concurrent requests allowed.
+	LatencyThresholdMs int `json:"latencyThresholdMs,omitempty"`
+	// MaxLimit description: The highest number of concurrent requests allowed.
+	MaxLimit int `json:"maxLimit,omitempty"`
+	// MinLimit description: The lowest number of concurrent requests allowed.
+	MinLimit int `json:"minLimit,omitempty"`
+}
+
 // BitbucketCloudAuthProvider description: Configures the Bitbucket Cloud OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your Bitbucket Cloud workspace: https://support.atlassian.com/bitbucket-cloud/docs/use-oauth-on-bitbucket-cloud/. The application should have account, email, and repository scopes and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/bitbucketcloud/callback".
 type BitbucketCloudAuthProvider struct {
 	// AllowSignup description: Allows new visitors to sign up for accounts via Bitbucket Cloud authentication. If false, users signing in via Bitbucket Cloud must have an existing Sourcegraph account, which will be linked to their Bitbucket Cloud identity after sign-in.
@@ -333,16 +379,30 @@ type BitbucketCloudAuthorization struct {
 	IdentityProvider string `json:"identityProvider,omitempty"`
 }
 
+// BitbucketCloudCircuitBreaker description: Stops sending API requests to Bitbucket Cloud for a while after consecutive failed requests, so that an unavailable code host fails fast instead of tying up requests.
+type BitbucketCloudCircuitBreaker struct {
+	// Enabled description: true if the circuit breaker is enabled.
+	Enabled bool `json:"enabled"`
+	// FailureThreshold description: The number of consecutive failed requests after which requests are stopped.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// OpenDurationSeconds description: The number of seconds requests are stopped for before a single trial request is sent.
+	OpenDurationSeconds int `json:"openDurationSeconds,omitempty"`
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// AccessToken description: The workspace access token to use when authenticating with Bitbucket Cloud.
 	AccessToken string `json:"accessToken,omitempty"`
+	// AdaptiveConcurrency description: Adapts the number of concurrent API requests to Bitbucket Cloud to its latency and error rate, so that a slow code host receives fewer requests at once.
+	AdaptiveConcurrency *BitbucketCloudAdaptiveConcurrency `json:"adaptiveConcurrency,omitempty"`
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword,omitempty"`
 	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. This requires that there is an item in the [site configuration json](https://docs.sourcegraph.com/admin/config/site_config#auth-providers) `auth.providers` field, of type "bitbucketcloud" with the same `url` field as specified in this `BitbucketCloudConnection`.
 	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
+	// CircuitBreaker description: Stops sending API requests to Bitbucket Cloud for a while after consecutive failed requests, so that an unavailable code host fails fast instead of tying up requests.
+	CircuitBreaker *BitbucketCloudCircuitBreaker `json:"circuitBreaker,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -381,6 +441,18 @@ type BitbucketCloudRateLimit struct {
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
 
+// BitbucketServerAdaptiveConcurrency description: Adapts the number of concurrent API requests to Bitbucket Server / Bitbucket Data Center to its latency and error rate, so that a slow code host receives fewer requests at once.
+type BitbucketServerAdaptiveConcurrency struct {
+	// Enabled description: true if adaptive concurrency is enabled.
+	Enabled bool `json:"enabled"`
+	// LatencyThresholdMs description: Requests taking longer than this number of milliseconds lower the number of concurrent requests allowed.
+	LatencyThresholdMs int `json:"latencyThresholdMs,omitempty"`
+	// MaxLimit description: The highest number of concurrent requests allowed.
+	MaxLimit int `json:"maxLimit,omitempty"`
+	// MinLimit description: The lowest number of concurrent requests allowed.
+	MinLimit int `json:"minLimit,omitempty"`
+}
+
 // BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server / Bitbucket Data Center repository permissions.
 type BitbucketServerAuthorization struct {
 	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Server / Bitbucket Data Center identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Server / Bitbucket Data Center accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
@@ -389,12 +461,26 @@ type BitbucketServerAuthorization struct {
 	Oauth BitbucketServerOAuth `json:"oauth"`
 }
 
+// BitbucketServerCircuitBreaker description: Stops sending API requests to Bitbucket Server / Bitbucket Data Center for a while after consecutive failed requests, so that an unavailable code host fails fast instead of tying up requests.
+type BitbucketServerCircuitBreaker struct {
+	// Enabled description: true if the circuit breaker is enabled.
+	Enabled bool `json:"enabled"`
+	// FailureThreshold description: The number of consecutive failed requests after which requests are stopped.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// OpenDurationSeconds description: The number of seconds requests are stopped for before a single trial request is sent.
+	OpenDurationSeconds int `json:"openDurationSeconds,omitempty"`
+}
+
 // BitbucketServerConnection description: Configuration for a connection to Bitbucket Server / Bitbucket Data Center.
 type BitbucketServerConnection struct {
+	// AdaptiveConcurrency description: Adapts the number of concurrent API requests to Bitbucket Server / Bitbucket Data Center to its latency and error rate, so that a slow code host receives fewer requests at once.
+	AdaptiveConcurrency *BitbucketServerAdaptiveConcurrency `json:"adaptiveConcurrency,omitempty"`
 	// Authorization description: If non-null, enforces Bitbucket Server / Bitbucket Data Center repository permissions.
 	Authorization *BitbucketServerAuthorization `json:"authorization,omitempty"`
 	// Certificate description: TLS certificate of the Bitbucket Server / Bitbucket Data Center instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
+	// CircuitBreaker description: Stops sending API requests to Bitbucket Server / Bitbucket Data Center for a while after consecutive failed requests, so that an unavailable code host fails fast instead of tying up requests.
+	CircuitBreaker *BitbucketServerCircuitBreaker `json:"circuitBreaker,omitempty"`
 	// Exclude description: A list of repositories to never mirror from this Bitbucket Server / Bitbucket Data Center instance. Takes precedence over "repos" and "repositoryQuery".
 	//
 	// Supports excluding by name ({"name": "projectKey/repositorySlug"}) or by ID ({"id": 42}).
@@ -551,8 +637,10 @@ type BuiltinAuthProvider struct {
 	// AllowSignup description: Allows new visitors to sign up for accounts. The sign-up page will be enabled and accessible to all visitors.
 	//
 	// SECURITY: If the site has no users (i.e., during initial setup), it will always allow the first user to sign up and become site admin **without any approval** (first user to sign up becomes the admin).
-	AllowSignup bool   `json:"allowSignup,omitempty"`
-	Type        string `json:"type"`
+	AllowSignup bool `json:"allowSignup,omitempty"`
+	// RequireTwoFactor description: Which users must use TOTP two-factor authentication to sign in with a username and password. Users that are required to use two-factor authentication but have not enrolled yet are asked to enroll an authenticator app during sign-in. Users can always enroll voluntarily in their account security settings.
+	RequireTwoFactor string `json:"requireTwoFactor,omitempty"`
+	Type             string `json:"type"`
 }
 type CapabilitiesParams struct {
 }
@@ -654,6 +742,8 @@ type Completions struct {
 	PerUserCodeCompletionsDailyLimit int `json:"perUserCodeCompletionsDailyLimit,omitempty"`
 	// PerUserDailyLimit description: If > 0, limits the number of completions requests allowed for a user in a day. On instances that allow anonymous requests, we enforce the rate limit by IP.
 	PerUserDailyLimit int `json:"perUserDailyLimit,omitempty"`
+	// PerUserDailyTokenBudget description: If > 0, limits the number of LLM tokens (prompt and completion tokens combined) a user can use in a day across all completions features. Token counts are estimated for providers that don't report them.
+	PerUserDailyTokenBudget int `json:"perUserDailyTokenBudget,omitempty"`
 	// Provider description: The external completions provider. Defaults to 'sourcegraph'.
 	Provider string `json:"provider,omitempty"`
 }
@@ -672,7 +762,7 @@ type DebugLog struct {
 	ExtsvcGitlab bool `json:"extsvc.gitlab,omitempty"`
 }
 
-// DequeueCacheConfig description: The configuration for the dequeue cache of multiqueue executors. Each queue defines a limit of dequeues in the expiration window as well as a weight, indicating how frequently a queue is picked at random. For example, a weight of 4 for batches and 1 for codeintel means out of 5 dequeues, statistically batches will be picked 4 times and codeintel 1 time (unless one of those queues is at its limit).
+// DequeueCacheConfig description: The configuration for the dequeue cache of multiqueue executors. Each queue defines a limit of dequeues in the expiration window as well as a weight, indicating how frequently a queue is picked at random. For example, a weight of 4 for batches and 1 for codeintel means out of 5 dequeues, statistically batches will be picked 4 times and codeintel 1 time (unless one of those queues is at its limit). Weights also define each queue's fair share of the jobs that executors are processing: a queue that is above its share is not picked while another queue is below its share.
 type DequeueCacheConfig struct {
 	// Batches description: The configuration for the batches queue.
 	Batches *Batches `json:"batches,omitempty"`
@@ -716,8 +806,12 @@ type EmailTemplates struct {
 
 // Embeddings description: Configuration for embeddings service.
 type Embeddings struct {
-	// AccessToken description: The access token used to authenticate with the external embedding API service. For provider sourcegraph, this is optional.
+	// AccessToken description: The access token used to authenticate with the external embedding API service. For providers sourcegraph and openai-compatible, this is optional.
 	AccessToken string `json:"accessToken,omitempty"`
+	// Admission description: Limits on which repositories can be embedded. They are checked when embedding jobs are scheduled, and repositories that exceed them are not embedded. Site admins can exempt individual repositories with the setRepoEmbeddingAdmissionOverride GraphQL mutation.
+	Admission *EmbeddingsAdmission `json:"admission,omitempty"`
+	// BatchSize description: The number of chunks to send to the embedding API service in a single request. If not set, the value of the SRC_EMBEDDINGS_BATCH_SIZE environment variable on the worker is used, which defaults to 512. Self-hosted model servers often accept smaller batches.
+	BatchSize int `json:"batchSize,omitempty"`
 	// Dimensions description: The dimensionality of the embedding vectors. Required field if not using the sourcegraph provider.
 	Dimensions int `json:"dimensions,omitempty"`
 	// Enabled description: Toggles whether embedding service is enabled.
@@ -746,7 +840,7 @@ type Embeddings struct {
 	PerProUserEmbeddingsMonthlyLimit int `json:"perProUserEmbeddingsMonthlyLimit,omitempty"`
 	// PolicyRepositoryMatchLimit description: The maximum number of repositories that can be matched by a global embeddings policy
 	PolicyRepositoryMatchLimit *int `json:"policyRepositoryMatchLimit,omitempty"`
-	// Provider description: The provider to use for generating embeddings. Defaults to sourcegraph.
+	// Provider description: The provider to use for generating embeddings. Defaults to sourcegraph. Use openai-compatible for self-hosted model servers that implement the OpenAI embeddings API, in which case endpoint, model and dimensions are required.
 	Provider string `json:"provider,omitempty"`
 	// Qdrant description: Overrides for the default qdrant config. These should generally not be modified without direction from the Sourcegraph support team.
 	Qdrant *Qdrant `json:"qdrant,omitempty"`
@@ -754,6 +848,24 @@ type Embeddings struct {
 	Url string `json:"url,omitempty"`
 }
 
+// EmbeddingsAdmission description: Limits on which repositories can be embedded. They are checked when embedding jobs are scheduled, and repositories that exceed them are not embedded. Site admins can exempt individual repositories with the setRepoEmbeddingAdmissionOverride GraphQL mutation.
+type EmbeddingsAdmission struct {
+	// MaxFileCount description: The maximum number of files that would be embedded for a repository, after applying the file filters. Repositories with more files are not embedded. Set to 0 to disable the limit.
+	MaxFileCount int `json:"maxFileCount,omitempty"`
+	// MaxRepoSizeBytes description: The maximum size of a repository on disk, in bytes. Larger repositories are not embedded. Set to 0 to disable the limit.
+	MaxRepoSizeBytes int `json:"maxRepoSizeBytes,omitempty"`
+	// RepoFileFilters description: File filters that only apply to the repositories matching a pattern. They are used both to count the files of a repository on admission and when embedding it.
+	RepoFileFilters []*EmbeddingsRepoFileFilters `json:"repoFileFilters,omitempty"`
+}
+type EmbeddingsRepoFileFilters struct {
+	// ExcludedFilePathPatterns description: A list of glob patterns that match file paths you want to exclude from embeddings, in addition to the globally excluded file path patterns.
+	ExcludedFilePathPatterns []string `json:"excludedFilePathPatterns,omitempty"`
+	// IncludedFilePathPatterns description: A list of glob patterns that match file paths you want to include in embeddings. If specified, they replace the globally included file path patterns for the matching repositories.
+	IncludedFilePathPatterns []string `json:"includedFilePathPatterns,omitempty"`
+	// RepoNamePattern description: A regular expression that matches the names of the repositories the filters apply to.
+	RepoNamePattern string `json:"repoNamePattern"`
+}
+
 // EncryptionKey description: Config for a key
 type EncryptionKey struct {
 	Cloudkms *CloudKMSEncryptionKey
@@ -881,8 +993,10 @@ type ExcludedOtherRepo struct {
 
 // ExecutorsMultiqueue description: The configuration for multiqueue executors.
 type ExecutorsMultiqueue struct {
-	// DequeueCacheConfig description: The configuration for the dequeue cache of multiqueue executors. Each queue defines a limit of dequeues in the expiration window as well as a weight, indicating how frequently a queue is picked at random. For example, a weight of 4 for batches and 1 for codeintel means out of 5 dequeues, statistically batches will be picked 4 times and codeintel 1 time (unless one of those queues is at its limit).
+	// DequeueCacheConfig description: The configuration for the dequeue cache of multiqueue executors. Each queue defines a limit of dequeues in the expiration window as well as a weight, indicating how frequently a queue is picked at random. For example, a weight of 4 for batches and 1 for codeintel means out of 5 dequeues, statistically batches will be picked 4 times and codeintel 1 time (unless one of those queues is at its limit). Weights also define each queue's fair share of the jobs that executors are processing: a queue that is above its share is not picked while another queue is below its share.
 	DequeueCacheConfig *DequeueCacheConfig `json:"dequeueCacheConfig,omitempty"`
+	// MaxConcurrentJobsPerUser description: The maximum number of batch change execution jobs of a single user that executors process at once. Further jobs of that user stay queued until one of their jobs finishes, so a single large batch change can't take up all executors. Auto-indexing jobs are not owned by a user and are not limited. 0 means unlimited.
+	MaxConcurrentJobsPerUser int `json:"maxConcurrentJobsPerUser,omitempty"`
 }
 type ExistingChangesetSpec struct {
 	// BaseRepository description: The GraphQL ID of the repository that contains the existing changeset on the code host.
@@ -1147,6 +1261,18 @@ type GitCommitDescription struct {
 	Version int `json:"version,omitempty"`
 }
 
+// GitHubAdaptiveConcurrency description: Adapts the number of concurrent API requests to GitHub to its latency and error rate, so that a slow code host receives fewer requests at once.
+type GitHubAdaptiveConcurrency struct {
+	// Enabled description: true if adaptive concurrency is enabled.
+	Enabled bool `json:"enabled"`
+	// LatencyThresholdMs description: Requests taking longer than this number of milliseconds lower the number of concurrent requests allowed.
+	LatencyThresholdMs int `json:"latencyThresholdMs,omitempty"`
+	// MaxLimit description: The highest number of concurrent requests allowed.
+	MaxLimit int `json:"maxLimit,omitempty"`
+	// MinLimit description: The lowest number of concurrent requests allowed.
+	MinLimit int `json:"minLimit,omitempty"`
+}
+
 // GitHubApp description: DEPRECATED: The config options for Sourcegraph GitHub App.
 type GitHubApp struct {
 	// AppID description: The app ID of the GitHub App for Sourcegraph.
@@ -1206,12 +1332,26 @@ type GitHubAuthorization struct {
 	SyncInternalRepoPermissions bool `json:"syncInternalRepoPermissions,omitempty"`
 }
 
+// GitHubCircuitBreaker description: Stops sending API requests to GitHub for a while after consecutive failed requests, so that an unavailable code host fails fast instead of tying up requests.
+type GitHubCircuitBreaker struct {
+	// Enabled description: true if the circuit breaker is enabled.
+	Enabled bool `json:"enabled"`
+	// FailureThreshold description: The number of consecutive failed requests after which requests are stopped.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// OpenDurationSeconds description: The number of seconds requests are stopped for before a single trial request is sent.
+	OpenDurationSeconds int `json:"openDurationSeconds,omitempty"`
+}
+
 // GitHubConnection description: Configuration for a connection to GitHub or GitHub Enterprise.
 type GitHubConnection struct {
+	// AdaptiveConcurrency description: Adapts the number of concurrent API requests to GitHub to its latency and error rate, so that a slow code host receives fewer requests at once.
+	AdaptiveConcurrency *GitHubAdaptiveConcurrency `json:"adaptiveConcurrency,omitempty"`
 	// Authorization description: If non-null, enforces GitHub repository permissions. This requires that there is an item in the [site configuration json](https://docs.sourcegraph.com/admin/config/site_config#auth-providers) `auth.providers` field, of type "github" with the same `url` field as specified in this `GitHubConnection`.
 	Authorization *GitHubAuthorization `json:"authorization,omitempty"`
 	// Certificate description: TLS certificate of the GitHub Enterprise instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
+	// CircuitBreaker description: Stops sending API requests to GitHub for a while after consecutive failed requests, so that an unavailable code host fails fast instead of tying up requests.
+	CircuitBreaker *GitHubCircuitBreaker `json:"circuitBreaker,omitempty"`
 	// CloudDefault description: Only used to override the cloud_default column from a config file specified by EXTSVC_CONFIG_FILE
 	CloudDefault bool `json:"cloudDefault,omitempty"`
 	// CloudGlobal description: When set to true, this external service will be chosen as our 'Global' GitHub service. Only valid on Sourcegraph.com. Only one service can have this flag set.
@@ -1287,6 +1427,18 @@ type GitHubWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitLabAdaptiveConcurrency description: Adapts the number of concurrent API requests to GitLab to its latency and error rate, so that a slow code host receives fewer requests at once.
+type GitLabAdaptiveConcurrency struct {
+	// Enabled description: true if adaptive concurrency is enabled.
+	Enabled bool `json:"enabled"`
+	// LatencyThresholdMs description: Requests taking longer than this number of milliseconds lower the number of concurrent requests allowed.
+	LatencyThresholdMs int `json:"latencyThresholdMs,omitempty"`
+	// MaxLimit description: The highest number of concurrent requests allowed.
+	MaxLimit int `json:"maxLimit,omitempty"`
+	// MinLimit description: The lowest number of concurrent requests allowed.
+	MinLimit int `json:"minLimit,omitempty"`
+}
+
 // GitLabAuthProvider description: Configures the GitLab OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitLab instance: https://docs.gitlab.com/ee/integration/oauth_provider.html. The application should have `api` and `read_user` scopes and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/gitlab/callback".
 type GitLabAuthProvider struct {
 	// AllowGroups description: Restricts new logins and signups (if allowSignup is true) to members of these GitLab groups. Existing sessions won't be invalidated. Make sure to inform the full path for groups or subgroups instead of their names. Leave empty or unset for no group restrictions.
@@ -1320,12 +1472,26 @@ type GitLabAuthorization struct {
 	IdentityProvider IdentityProvider `json:"identityProvider"`
 }
 
+// GitLabCircuitBreaker description: Stops sending API requests to GitLab for a while after consecutive failed requests, so that an unavailable code host fails fast instead of tying up requests.
+type GitLabCircuitBreaker struct {
+	// Enabled description: true if the circuit breaker is enabled.
+	Enabled bool `json:"enabled"`
+	// FailureThreshold description: The number of consecutive failed requests after which requests are stopped.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// OpenDurationSeconds description: The number of seconds requests are stopped for before a single trial request is sent.
+	OpenDurationSeconds int `json:"openDurationSeconds,omitempty"`
+}
+
 // GitLabConnection description: Configuration for a connection to GitLab (GitLab.com or GitLab self-managed).
 type GitLabConnection struct {
+	// AdaptiveConcurrency description: Adapts the number of concurrent API requests to GitLab to its latency and error rate, so that a slow code host receives fewer requests at once.
+	AdaptiveConcurrency *GitLabAdaptiveConcurrency `json:"adaptiveConcurrency,omitempty"`
 	// Authorization description: If non-null, enforces GitLab repository permissions. This requires that there be an item in the `auth.providers` field of type "gitlab" with the same `url` field as specified in this `GitLabConnection`.
 	Authorization *GitLabAuthorization `json:"authorization,omitempty"`
 	// Certificate description: TLS certificate of the GitLab instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
+	// CircuitBreaker description: Stops sending API requests to GitLab for a while after consecutive failed requests, so that an unavailable code host fails fast instead of tying up requests.
+	CircuitBreaker *GitLabCircuitBreaker `json:"circuitBreaker,omitempty"`
 	// CloudDefault description: Only used to override the cloud_default column from a config file specified by EXTSVC_CONFIG_FILE
 	CloudDefault bool `json:"cloudDefault,omitempty"`
 	// CloudGlobal description: When set to true, this external service will be chosen as our 'Global' GitLab service. Only valid on Sourcegraph.com. Only one service can have this flag set.
@@ -1577,6 +1743,16 @@ type MavenRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// MetricsTenantLabel description: Adds a "tenant" label to request-level metrics of the frontend and code navigation, so that load can be attributed to the organization of the requesting user. The number of distinct tenants is capped to bound the cardinality of metrics.
+type MetricsTenantLabel struct {
+	// Enabled description: Whether to label request-level metrics with the tenant of the requesting user.
+	Enabled bool `json:"enabled,omitempty"`
+	// Groups description: Maps organization names to the tenant reported for their members, e.g. to report several organizations under one label. Members of organizations that are not listed are reported under the name of their first organization in alphabetical order. Users without an organization are reported as "none".
+	Groups map[string]string `json:"groups,omitempty"`
+	// MaxTenants description: The maximum number of distinct tenants reported. Requests of further tenants are reported as "other".
+	MaxTenants int `json:"maxTenants,omitempty"`
+}
 type Mount struct {
 	// Mountpoint description: The path in the container to mount the path on the local machine to.
 	Mountpoint string `json:"mountpoint"`
@@ -1778,6 +1954,14 @@ type OnRepository struct {
 	// Repository description: The name of the repository (as it is known to Sourcegraph).
 	Repository string `json:"repository"`
 }
+
+// OnView description: A repository view whose parent repository (and branch) is added to the list of repositories that the batch change will be run on. Workspaces are limited to the paths of the view.
+type OnView struct {
+	// Branch description: The branch of the parent repository to propose changes to. If unset, the repository's default branch is used.
+	Branch string `json:"branch,omitempty"`
+	// View description: The name of the repository view (as it is known to Sourcegraph).
+	View string `json:"view"`
+}
 type OnboardingStep struct {
 	Action              any      `json:"action"`
 	CompleteAfterEvents []string `json:"completeAfterEvents,omitempty"`
@@ -2091,17 +2275,27 @@ type Ranking struct {
 	RepoScores map[string]float64 `json:"repoScores,omitempty"`
 }
 type RateLimits struct {
+	// AccessTokenRequestsPerMinute description: The number of requests to the REST and streaming API endpoints that each access token can make per minute. Requests made with an access token count towards both the limit of the token and the limit of its user. Setting this to 0 disables the limit.
+	AccessTokenRequestsPerMinute int `json:"accessTokenRequestsPerMinute,omitempty"`
+	// GraphQLAccessTokenCostBudget description: The estimated cost of GraphQL queries that each access token can spend per hour. Requests made with an access token are charged to both the budget of the token and the budget of its user. Setting this to 0 disables the budget.
+	GraphQLAccessTokenCostBudget int `json:"graphQLAccessTokenCostBudget,omitempty"`
 	// GraphQLMaxAliases description: Maximum number of aliases allowed in a GraphQL query
 	GraphQLMaxAliases int `json:"graphQLMaxAliases,omitempty"`
 	// GraphQLMaxDepth description: Maximum depth of nested objects allowed for GraphQL queries. Changes to this setting require a restart.
 	GraphQLMaxDepth int `json:"graphQLMaxDepth,omitempty"`
 	// GraphQLMaxFieldCount description: Maximum number of estimated fields allowed in a GraphQL response
 	GraphQLMaxFieldCount int `json:"graphQLMaxFieldCount,omitempty"`
+	// GraphQLUserCostBudget description: The estimated cost of GraphQL queries that each authenticated user can spend per hour, summed over all their sessions and access tokens. The cost of a query is its estimated number of fields, which is charged before it runs. Setting this to 0 disables the budget.
+	GraphQLUserCostBudget int `json:"graphQLUserCostBudget,omitempty"`
+	// RequestRateLimitExemptRoles description: Names of roles whose users are not subject to the per-user and per-access-token request rate limits, for example SITE_ADMINISTRATOR.
+	RequestRateLimitExemptRoles []string `json:"requestRateLimitExemptRoles,omitempty"`
+	// UserRequestsPerMinute description: The number of requests to the REST and streaming API endpoints, such as search streaming, that each authenticated user can make per minute, summed over all their sessions and access tokens. Bursts of up to this many requests are allowed. GraphQL requests are limited by the cost budgets instead. Setting this to 0 disables the limit.
+	UserRequestsPerMinute int `json:"userRequestsPerMinute,omitempty"`
 }
 
 // RepoPurgeWorker description: Configuration for repository purge worker.
 type RepoPurgeWorker struct {
-	// DeletedTTLMinutes description: Repository TTL in minutes after deletion before it becomes eligible to be purged. A migration or admin could accidentally remove all or a significant number of repositories - recloning all of them is slow, so a TTL acts as a grace period so that admins can recover from accidental deletions
+	// DeletedTTLMinutes description: Repository TTL in minutes after deletion before it becomes eligible to be purged. A migration or admin could accidentally remove all or a significant number of repositories - recloning all of them is slow, so a TTL acts as a grace period so that admins can recover from accidental deletions. Deleted repositories can be restored with the restoreRepository GraphQL mutation until their TTL expires.
 	DeletedTTLMinutes int `json:"deletedTTLMinutes,omitempty"`
 	// IntervalMinutes description: Interval in minutes at which to run purge jobs. Set to 0 to disable.
 	IntervalMinutes int `json:"intervalMinutes,omitempty"`
@@ -2120,6 +2314,12 @@ type Repository struct {
 	// Owner description: The repository namespace.
 	Owner string `json:"owner,omitempty"`
 }
+
+// RepositorySnapshots description: Configuration for repository snapshots, point-in-time exports of repositories to the blob store that can be downloaded by site admins.
+type RepositorySnapshots struct {
+	// SigningKey description: Base64 encoded 32 byte Ed25519 seed used to sign the manifests of repository snapshots. The public key to verify the signatures with is returned by the repositorySnapshotSigningPublicKey GraphQL query. Snapshots cannot be created if this is not set.
+	SigningKey string `json:"signingKey,omitempty"`
+}
 type RequestMessage struct {
 	Method   string         `json:"method"`
 	Params   any            `json:"params,omitempty"`
@@ -2702,6 +2902,10 @@ type SiteConfiguration struct {
 	CodeIntelAutoIndexingIndexerMap map[string]string `json:"codeIntelAutoIndexing.indexerMap,omitempty"`
 	// CodeIntelAutoIndexingPolicyRepositoryMatchLimit description: The maximum number of repositories to which a single auto-indexing policy can apply. Default is -1, which is unlimited.
 	CodeIntelAutoIndexingPolicyRepositoryMatchLimit *int `json:"codeIntelAutoIndexing.policyRepositoryMatchLimit,omitempty"`
+	// CodeIntelAutoIndexingResolveDependenciesOnNavigation description: Whether a go-to-definition request that cannot be resolved because a dependency is not indexed on the Sourcegraph instance should add and clone the dependency's repository and schedule auto-indexing for it. Requires codeIntelAutoIndexing.enabled. Default is false.
+	CodeIntelAutoIndexingResolveDependenciesOnNavigation *bool `json:"codeIntelAutoIndexing.resolveDependenciesOnNavigation,omitempty"`
+	// CodeIntelNavigationMaximumIndexesPerMonikerSearch description: The maximum number of indexes to search at once when doing cross-index code navigation. Changes take effect without a restart. If unset, the value of the PRECISE_CODE_INTEL_MAXIMUM_INDEXES_PER_MONIKER_SEARCH environment variable is used.
+	CodeIntelNavigationMaximumIndexesPerMonikerSearch int `json:"codeIntelNavigation.maximumIndexesPerMonikerSearch,omitempty"`
 	// CodeIntelRankingDocumentReferenceCountsCronExpression description: A cron expression indicating when to run the document reference counts graph reduction job.
 	CodeIntelRankingDocumentReferenceCountsCronExpression *string `json:"codeIntelRanking.documentReferenceCountsCronExpression,omitempty"`
 	// CodeIntelRankingDocumentReferenceCountsDerivativeGraphKeyPrefix description: An arbitrary identifier used to group calculated rankings from SCIP data (excluding the SCIP export).
@@ -2712,6 +2916,10 @@ type SiteConfiguration struct {
 	CodeIntelRankingDocumentReferenceCountsGraphKey string `json:"codeIntelRanking.documentReferenceCountsGraphKey,omitempty"`
 	// CodeIntelRankingStaleResultsAge description: The interval at which to run the reduce job that computes document reference counts. Default is 24hrs.
 	CodeIntelRankingStaleResultsAge int `json:"codeIntelRanking.staleResultsAge,omitempty"`
+	// CodeIntelSlowQueriesLimit description: The maximum number of slow codeintel-db queries to retain. If the limit is exceeded, older items will be deleted.
+	CodeIntelSlowQueriesLimit int `json:"codeIntelSlowQueries.limit,omitempty"`
+	// CodeIntelSlowQueriesThresholdMs description: Queries against the codeintel-db taking at least this many milliseconds are recorded and can be inspected by site admins. If 0, slow queries are not recorded.
+	CodeIntelSlowQueriesThresholdMs int `json:"codeIntelSlowQueries.thresholdMs,omitempty"`
 	// CodyEnabled description: Enable or disable Cody instance-wide. When Cody is disabled, all Cody endpoints and GraphQL queries will return errors, Cody will not show up in the site-admin sidebar, and Cody in the global navbar will only show a call-to-action for site-admins to enable Cody.
 	CodyEnabled *bool `json:"cody.enabled,omitempty"`
 	// CodyRestrictUsersFeatureFlag description: Restrict Cody to only be enabled for users that have a feature flag labeled "cody" set to true. You must create a feature flag with this ID after enabling this setting: https://docs.sourcegraph.com/dev/how-to/use_feature_flags#create-a-feature-flag. This setting only has an effect if cody.enabled is true.
@@ -2837,6 +3045,8 @@ type SiteConfiguration struct {
 	ObservabilityLogSlowGraphQLRequests int `json:"observability.logSlowGraphQLRequests,omitempty"`
 	// ObservabilityLogSlowSearches description: (debug) logs all search queries (issued by users, code intelligence, or API requests) slower than the specified number of milliseconds.
 	ObservabilityLogSlowSearches int `json:"observability.logSlowSearches,omitempty"`
+	// ObservabilityMetricsTenantLabel description: Adds a "tenant" label to request-level metrics of the frontend and code navigation, so that load can be attributed to the organization of the requesting user. The number of distinct tenants is capped to bound the cardinality of metrics.
+	ObservabilityMetricsTenantLabel *MetricsTenantLabel `json:"observability.metricsTenantLabel,omitempty"`
 	// ObservabilitySilenceAlerts description: Silence individual Sourcegraph alerts by identifier.
 	ObservabilitySilenceAlerts []string `json:"observability.silenceAlerts,omitempty"`
 	// ObservabilityTracing description: Configures distributed tracing within Sourcegraph. To learn more, refer to https://docs.sourcegraph.com/admin/observability/tracing
@@ -2880,10 +3090,14 @@ type SiteConfiguration struct {
 	RedactOutboundRequestHeaders *bool `json:"redactOutboundRequestHeaders,omitempty"`
 	// RepoConcurrentExternalServiceSyncers description: The number of concurrent external service syncers that can run.
 	RepoConcurrentExternalServiceSyncers int `json:"repoConcurrentExternalServiceSyncers,omitempty"`
+	// RepoExternalServiceSyncWorkers description: The number of workers that a single external service sync uses to list and sync repositories in parallel. Raising it speeds up syncs of code host connections with many repositories, at the cost of more concurrent requests to the code host and the database. Sync progress is checkpointed, so a sync that is interrupted by a restart resumes where it left off.
+	RepoExternalServiceSyncWorkers int `json:"repoExternalServiceSyncWorkers,omitempty"`
 	// RepoListUpdateInterval description: Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
 	// RepoPurgeWorker description: Configuration for repository purge worker.
 	RepoPurgeWorker *RepoPurgeWorker `json:"repoPurgeWorker,omitempty"`
+	// RepositorySnapshots description: Configuration for repository snapshots, point-in-time exports of repositories to the blob store that can be downloaded by site admins.
+	RepositorySnapshots *RepositorySnapshots `json:"repositorySnapshots,omitempty"`
 	// ScimAuthToken description: The SCIM auth token is used to authenticate SCIM requests. If not set, SCIM is disabled.
 	ScimAuthToken string `json:"scim.authToken,omitempty"`
 	// ScimIdentityProvider description: Identity provider used for SCIM support.  "STANDARD" should be used unless a more specific value is available
@@ -2898,6 +3112,8 @@ type SiteConfiguration struct {
 	SearchLimits *SearchLimits `json:"search.limits,omitempty"`
 	// SyntaxHighlighting description: Syntax highlighting configuration
 	SyntaxHighlighting *SyntaxHighlighting `json:"syntaxHighlighting,omitempty"`
+	// TelemetryExportRules description: Rules applied to telemetry events before they are exported to Sourcegraph. For each event, the first rule matching its feature and action applies. Events that don't match any rule are exported unchanged. Changes take effect on the next export without a restart.
+	TelemetryExportRules []*TelemetryExportRule `json:"telemetry.exportRules,omitempty"`
 	// UpdateChannel description: The channel on which to automatically check for Sourcegraph updates.
 	UpdateChannel string `json:"update.channel,omitempty"`
 	// WebhookLogging description: Configuration for logging incoming webhooks.
@@ -2968,11 +3184,15 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "codeIntelAutoIndexing.enabled")
 	delete(m, "codeIntelAutoIndexing.indexerMap")
 	delete(m, "codeIntelAutoIndexing.policyRepositoryMatchLimit")
+	delete(m, "codeIntelAutoIndexing.resolveDependenciesOnNavigation")
+	delete(m, "codeIntelNavigation.maximumIndexesPerMonikerSearch")
 	delete(m, "codeIntelRanking.documentReferenceCountsCronExpression")
 	delete(m, "codeIntelRanking.documentReferenceCountsDerivativeGraphKeyPrefix")
 	delete(m, "codeIntelRanking.documentReferenceCountsEnabled")
 	delete(m, "codeIntelRanking.documentReferenceCountsGraphKey")
 	delete(m, "codeIntelRanking.staleResultsAge")
+	delete(m, "codeIntelSlowQueries.limit")
+	delete(m, "codeIntelSlowQueries.thresholdMs")
 	delete(m, "cody.enabled")
 	delete(m, "cody.restrictUsersFeatureFlag")
 	delete(m, "completions")
@@ -3035,6 +3255,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "observability.client")
 	delete(m, "observability.logSlowGraphQLRequests")
 	delete(m, "observability.logSlowSearches")
+	delete(m, "observability.metricsTenantLabel")
 	delete(m, "observability.silenceAlerts")
 	delete(m, "observability.tracing")
 	delete(m, "organizationInvitations")
@@ -3057,8 +3278,10 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "rateLimits")
 	delete(m, "redactOutboundRequestHeaders")
 	delete(m, "repoConcurrentExternalServiceSyncers")
+	delete(m, "repoExternalServiceSyncWorkers")
 	delete(m, "repoListUpdateInterval")
 	delete(m, "repoPurgeWorker")
+	delete(m, "repositorySnapshots")
 	delete(m, "scim.authToken")
 	delete(m, "scim.identityProvider")
 	delete(m, "search.index.shardConcurrency")
@@ -3066,6 +3289,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "search.largeFiles")
 	delete(m, "search.limits")
 	delete(m, "syntaxHighlighting")
+	delete(m, "telemetry.exportRules")
 	delete(m, "update.channel")
 	delete(m, "webhook.logging")
 	if len(m) > 0 {
@@ -3144,6 +3368,18 @@ type SyntaxHighlightingLanguagePatterns struct {
 	// Pattern description: Regular expression which matches the filepath
 	Pattern string `json:"pattern"`
 }
+type TelemetryExportRule struct {
+	// Action description: The event action to match, like "submitted". A trailing "*" matches any action with the given prefix. Empty or "*" matches all actions.
+	Action string `json:"action,omitempty"`
+	// Drop description: Drop matching events instead of exporting them.
+	Drop bool `json:"drop,omitempty"`
+	// Feature description: The event feature to match, like "cody.chat". A trailing "*" matches any feature with the given prefix. Empty or "*" matches all features.
+	Feature string `json:"feature,omitempty"`
+	// RedactMetadata description: Keys to remove from the metadata and private metadata of matching events.
+	RedactMetadata []string `json:"redactMetadata,omitempty"`
+	// SampleRate description: The fraction of matching events to export, greater than 0 and at most 1. Use "drop" to export none. Sampling is based on the event ID, so that retried exports make the same decision. Defaults to 1, exporting all matching events.
+	SampleRate float64 `json:"sampleRate,omitempty"`
+}
 
 // TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 type TlsExternal struct {